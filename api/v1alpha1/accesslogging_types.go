@@ -39,8 +39,28 @@ type ProxyAccessLogSetting struct {
 	// +optional
 	// +notImplementedHide
 	Type *ProxyAccessLogType `json:"type,omitempty"`
+	// Protocol restricts the setting to the accesslogs of the traffic of the protocol,
+	// such as the TCP connections proxied for the TCP and TLS routes.
+	// If unspecified, the setting applies to the accesslogs of all the traffic.
+	// When the format is unspecified, the TCP connections are logged with a default
+	// format holding their properties, such as the bytes sent and received,
+	// the duration, the SNI and the source address.
+	// +kubebuilder:validation:Enum=HTTP;TCP;UDP
+	// +optional
+	Protocol *ProxyAccessLogProtocol `json:"protocol,omitempty"`
 }
 
+type ProxyAccessLogProtocol string
+
+const (
+	// ProxyAccessLogProtocolHTTP defines the accesslogs of the HTTP and GRPC routes.
+	ProxyAccessLogProtocolHTTP ProxyAccessLogProtocol = "HTTP"
+	// ProxyAccessLogProtocolTCP defines the accesslogs of the TCP and TLS routes.
+	ProxyAccessLogProtocolTCP ProxyAccessLogProtocol = "TCP"
+	// ProxyAccessLogProtocolUDP defines the accesslogs of the UDP routes.
+	ProxyAccessLogProtocolUDP ProxyAccessLogProtocol = "UDP"
+)
+
 type ProxyAccessLogType string
 
 const (
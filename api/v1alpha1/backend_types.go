@@ -110,7 +110,23 @@ type UnixSocket struct {
 	Path string `json:"path"`
 }
 
+// OriginalDestination describes a backend forwarding the traffic to its original destination,
+// corresponding to Envoy's Original destination cluster:
+// https://www.envoyproxy.io/docs/envoy/latest/intro/arch_overview/upstream/load_balancing/original_dst
+type OriginalDestination struct {
+	// HeaderName defines the name of the request header holding the original destination
+	// address of the requests, as an IP address and port, e.g. 10.0.0.1:8080.
+	// If unspecified, the requests are forwarded to the destination address of the downstream
+	// connection, which requires the connections to be redirected to the proxy transparently.
+	//
+	// +kubebuilder:validation:MinLength=1
+	// +optional
+	HeaderName *string `json:"headerName,omitempty"`
+}
+
 // BackendSpec describes the desired state of BackendSpec.
+//
+// +kubebuilder:validation:XValidation:rule="has(self.endpoints) != has(self.originalDestination)",message="exactly one of endpoints or originalDestination must be specified"
 type BackendSpec struct {
 	// Endpoints defines the endpoints to be used when connecting to the backend.
 	//
@@ -119,6 +135,14 @@ type BackendSpec struct {
 	// +kubebuilder:validation:XValidation:rule="self.all(f, has(f.fqdn)) || !self.exists(f, has(f.fqdn))",message="fqdn addresses cannot be mixed with other address types"
 	Endpoints []BackendEndpoint `json:"endpoints,omitempty"`
 
+	// OriginalDestination forwards the traffic to its original destination instead of to
+	// the endpoints, for transparent proxy deployments where the target of the traffic must
+	// not be rewritten.
+	// Only one of Endpoints or OriginalDestination can be specified.
+	//
+	// +optional
+	OriginalDestination *OriginalDestination `json:"originalDestination,omitempty"`
+
 	// AppProtocols defines the application protocols to be supported when connecting to the backend.
 	//
 	// +optional
@@ -41,6 +41,7 @@ type BackendTrafficPolicy struct {
 // +kubebuilder:validation:XValidation:rule="has(self.targetRefs) ? self.targetRefs.all(ref, ref.group == 'gateway.networking.k8s.io') : true ", message="this policy can only have a targetRefs[*].group of gateway.networking.k8s.io"
 // +kubebuilder:validation:XValidation:rule="has(self.targetRefs) ? self.targetRefs.all(ref, ref.kind in ['Gateway', 'HTTPRoute', 'GRPCRoute', 'UDPRoute', 'TCPRoute', 'TLSRoute']) : true ", message="this policy can only have a targetRefs[*].kind of Gateway/HTTPRoute/GRPCRoute/TCPRoute/UDPRoute/TLSRoute"
 // +kubebuilder:validation:XValidation:rule="has(self.targetRefs) ? self.targetRefs.all(ref, !has(ref.sectionName)) : true",message="this policy does not yet support the sectionName field"
+// +kubebuilder:validation:XValidation:rule="has(self.canaryRollout) ? !has(self.targetSelectors) && (has(self.targetRef) ? self.targetRef.kind == 'HTTPRoute' : true) && (has(self.targetRefs) ? self.targetRefs.all(ref, ref.kind == 'HTTPRoute') : true) : true",message="canaryRollout can only target HTTPRoutes with targetRef or targetRefs"
 //
 // BackendTrafficPolicySpec defines the desired state of BackendTrafficPolicy.
 type BackendTrafficPolicySpec struct {
@@ -64,6 +65,18 @@ type BackendTrafficPolicySpec struct {
 	// +optional
 	UseClientProtocol *bool `json:"useClientProtocol,omitempty"`
 
+	// Experiment assigns the requests of the clients to the buckets of an A/B experiment,
+	// routing each bucket to its own backends.
+	//
+	// +optional
+	Experiment *Experiment `json:"experiment,omitempty"`
+
+	// HeaderToMetadata sets request headers as dynamic metadata of the requests, and
+	// dynamic metadata as headers of the responses.
+	//
+	// +optional
+	HeaderToMetadata *HeaderToMetadata `json:"headerToMetadata,omitempty"`
+
 	// The compression config for the http streams.
 	//
 	// +optional
@@ -74,8 +87,39 @@ type BackendTrafficPolicySpec struct {
 	// If multiple configurations are specified, the first one to match wins.
 	//
 	// +optional
-	// +notImplementedHide
 	ResponseOverride []*ResponseOverride `json:"responseOverride,omitempty"`
+
+	// GRPCStatusMapping maps the statuses of the responses of the backends between gRPC
+	// and HTTP, so that the clients keep receiving the same statuses when the backends of
+	// the route change protocols. The mappings are applied before the ResponseOverride.
+	//
+	// +optional
+	GRPCStatusMapping *GRPCStatusMapping `json:"grpcStatusMapping,omitempty"`
+
+	// ResponseBuffer buffers the bodies of the responses of the route up to a limit, with
+	// an explicit behavior when the limit is exceeded.
+	//
+	// +optional
+	ResponseBuffer *ResponseBuffer `json:"responseBuffer,omitempty"`
+
+	// RequestSigning signs the requests forwarded to the backends, with the AWS
+	// Signature Version 4 or an HMAC signature.
+	//
+	// +optional
+	RequestSigning *RequestSigning `json:"requestSigning,omitempty"`
+
+	// CredentialInjection injects the credentials of the gateway into the requests
+	// forwarded to the backends, a static bearer token or an OAuth2 access token.
+	//
+	// +optional
+	CredentialInjection *CredentialInjection `json:"credentialInjection,omitempty"`
+
+	// CanaryRollout shifts the requests of the targeted HTTPRoutes to a canary backend
+	// step by step, and rolls it back once a guardrail is breached. Only HTTPRoutes can be
+	// targeted, with targetRef or targetRefs.
+	//
+	// +optional
+	CanaryRollout *CanaryRollout `json:"canaryRollout,omitempty"`
 }
 
 // +kubebuilder:object:root=true
@@ -0,0 +1,99 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package v1alpha1
+
+import (
+	gwapiv1 "sigs.k8s.io/gateway-api/apis/v1"
+	gwapiv1a2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
+)
+
+// CanaryRollout defines the rollout of a canary backend, shifting the requests of the
+// targeted HTTPRoutes to the canary backend step by step, and rolling it back once a
+// guardrail is breached.
+//
+// The rollout is run by the canary rollout controller of Envoy Gateway, enabled by the
+// canaryRollout of the EnvoyGateway configuration, which manages the weights of the
+// backendRefs of the rules of the targeted HTTPRoutes referencing the canary backend: the
+// canary backend receives the percentage of the requests of the current step, and the
+// other backends of the rule share the rest equally. The rollout starts over from the
+// first step whenever the policy changes.
+//
+// +kubebuilder:validation:XValidation:rule="self.steps.isSorted() && self.steps.all(s, self.steps.exists_one(t, t == s))",message="the steps must be in increasing order"
+// +kubebuilder:validation:XValidation:rule="!has(self.guardrails) || self.guardrails.all(g, self.guardrails.exists_one(h, h.name == g.name))",message="the names of the guardrails must be unique"
+type CanaryRollout struct {
+	// BackendRef references the canary backend, among the backendRefs of the rules of the
+	// targeted HTTPRoutes.
+	BackendRef gwapiv1.BackendObjectReference `json:"backendRef"`
+
+	// Steps are the percentages of the requests routed to the canary backend at each step
+	// of the rollout. The rollout succeeds once the last step lasted the interval.
+	//
+	// +kubebuilder:validation:MinItems=1
+	// +kubebuilder:validation:MaxItems=20
+	// +kubebuilder:validation:items:Minimum=1
+	// +kubebuilder:validation:items:Maximum=100
+	Steps []uint32 `json:"steps"`
+
+	// Interval is how long each step lasts before the rollout moves to the next step.
+	// Defaults to 5m.
+	//
+	// +optional
+	Interval *gwapiv1.Duration `json:"interval,omitempty"`
+
+	// Guardrails are checked throughout the rollout. Once a guardrail is breached, the
+	// rollout is rolled back: all the requests are routed to the other backends again.
+	//
+	// +optional
+	// +kubebuilder:validation:MaxItems=8
+	Guardrails []CanaryGuardrail `json:"guardrails,omitempty"`
+}
+
+// CanaryGuardrail defines a guardrail of a canary rollout, as a Prometheus alerting
+// expression.
+type CanaryGuardrail struct {
+	// Name is the name of the guardrail, reported in the status of the policy once the
+	// guardrail is breached.
+	//
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=64
+	Name string `json:"name"`
+
+	// Query is the PromQL expression of the guardrail, queried from the Prometheus server
+	// of the canaryRollout of the EnvoyGateway configuration. The guardrail is breached
+	// when the expression returns any sample, e.g. when the error rate of the canary
+	// backend exceeds a threshold.
+	//
+	// +kubebuilder:validation:MinLength=1
+	Query string `json:"query"`
+}
+
+const (
+	// PolicyConditionCanaryRollout indicates the progress of the canary rollout of the
+	// policy.
+	//
+	// Possible reasons for this condition to be True are:
+	//
+	// * "Progressing"
+	// * "Succeeded"
+	//
+	// Possible reasons for this condition to be False are:
+	//
+	// * "RolledBack"
+	//
+	PolicyConditionCanaryRollout gwapiv1a2.PolicyConditionType = "CanaryRollout"
+
+	// PolicyReasonCanaryProgressing is used with the "CanaryRollout" condition while the
+	// requests are shifted to the canary backend.
+	PolicyReasonCanaryProgressing gwapiv1a2.PolicyConditionReason = "Progressing"
+
+	// PolicyReasonCanarySucceeded is used with the "CanaryRollout" condition once the last
+	// step of the rollout lasted its interval.
+	PolicyReasonCanarySucceeded gwapiv1a2.PolicyConditionReason = "Succeeded"
+
+	// PolicyReasonCanaryRolledBack is used with the "CanaryRollout" condition once a
+	// guardrail was breached and the requests were routed to the other backends again.
+	PolicyReasonCanaryRolledBack gwapiv1a2.PolicyConditionReason = "RolledBack"
+)
@@ -46,4 +46,21 @@ type CircuitBreaker struct {
 	// +kubebuilder:validation:Maximum=4294967295
 	// +optional
 	MaxRequestsPerConnection *int64 `json:"maxRequestsPerConnection,omitempty"`
+
+	// PerEndpoint defines the Circuit Breakers applied to each endpoint of the referenced backend.
+	//
+	// +optional
+	PerEndpoint *PerEndpointCircuitBreakers `json:"perEndpoint,omitempty"`
+}
+
+// PerEndpointCircuitBreakers defines the Circuit Breakers applied to each endpoint of a backend.
+type PerEndpointCircuitBreakers struct {
+	// MaxConnections is the maximum number of connections that Envoy will establish to each endpoint
+	// of the referenced backend.
+	// Default: unlimited.
+	//
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=4294967295
+	// +optional
+	MaxConnections *int64 `json:"maxConnections,omitempty"`
 }
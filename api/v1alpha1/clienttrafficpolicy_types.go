@@ -296,11 +296,55 @@ type HTTP10Settings struct {
 }
 
 // HealthCheckSettings provides HealthCheck configuration on the HTTP/HTTPS listener.
+// The health check requests are answered by Envoy itself, without reaching the backends,
+// so that external load balancers can probe the proxies.
 type HealthCheckSettings struct {
 	// Path specifies the HTTP path to match on for health check requests.
+	// Defaults to /healthz.
+	//
+	// +kubebuilder:default="/healthz"
 	// +kubebuilder:validation:MinLength=1
 	// +kubebuilder:validation:MaxLength=1024
-	Path string `json:"path"`
+	// +optional
+	Path string `json:"path,omitempty"`
+
+	// Routes are the routes whose backends' health is aggregated into the health of the
+	// listener. The health check requests are answered with 503 when the percentage of
+	// healthy endpoints of the backends of any of the routes falls below its minimum, so
+	// that the external load balancers stop sending traffic to the proxies which can't
+	// serve it. The endpoints are reported unhealthy by the active and passive health
+	// checks of the backends, configured with BackendTrafficPolicy.
+	// The routes not attached to the listener are ignored.
+	//
+	// +optional
+	// +kubebuilder:validation:MaxItems=16
+	Routes []HealthCheckRoute `json:"routes,omitempty"`
+}
+
+// HealthCheckRoute defines the minimum percentage of healthy endpoints of the backends of
+// a route for the listener to be healthy.
+type HealthCheckRoute struct {
+	// Kind is the kind of the route.
+	//
+	// +kubebuilder:default=HTTPRoute
+	// +kubebuilder:validation:Enum=HTTPRoute;GRPCRoute
+	// +optional
+	Kind *gwapiv1.Kind `json:"kind,omitempty"`
+
+	// Name is the name of the route.
+	Name gwapiv1.ObjectName `json:"name"`
+
+	// Namespace is the namespace of the route. Defaults to the namespace of the policy.
+	//
+	// +optional
+	Namespace *gwapiv1.Namespace `json:"namespace,omitempty"`
+
+	// MinHealthyPercentage is the minimum percentage of healthy endpoints of each backend
+	// of the route.
+	//
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=100
+	MinHealthyPercentage uint32 `json:"minHealthyPercentage"`
 }
 
 const (
@@ -316,6 +360,21 @@ const (
 	// PolicyReasonOverridden is used with the "Overridden" condition when the policy
 	// has been overridden by another policy targeting a section within the same target.
 	PolicyReasonOverridden gwapiv1a2.PolicyConditionReason = "Overridden"
+
+	// PolicyConditionNoDefaultFilterChain indicates whether the TLS connections whose
+	// SNI matches none of the hostnames of the targeted listeners are closed, since
+	// no listener handles them.
+	//
+	// Possible reasons for this condition to be True are:
+	//
+	// * "NoDefaultFilterChain"
+	//
+	PolicyConditionNoDefaultFilterChain gwapiv1a2.PolicyConditionType = "NoDefaultFilterChain"
+
+	// PolicyReasonNoDefaultFilterChain is used with the "NoDefaultFilterChain" condition
+	// when the policy configures TLS on listeners with hostnames, without configuring
+	// how the connections matching none of these hostnames are handled.
+	PolicyReasonNoDefaultFilterChain gwapiv1a2.PolicyConditionReason = "NoDefaultFilterChain"
 )
 
 //+kubebuilder:object:root=true
@@ -16,6 +16,12 @@ type ClientConnection struct {
 	//
 	// +optional
 	ConnectionLimit *ConnectionLimit `json:"connectionLimit,omitempty"`
+	// ConnectionRateLimit defines the rate limit of the new connections accepted by
+	// the listener, which mitigates connection floods at the proxy level. The
+	// connections exceeding the limit are closed as soon as they are accepted.
+	//
+	// +optional
+	ConnectionRateLimit *ConnectionRateLimit `json:"connectionRateLimit,omitempty"`
 	// BufferLimit provides configuration for the maximum buffer size in bytes for each incoming connection.
 	// BufferLimit applies to connection streaming (maybe non-streaming) channel between processes, it's in user space.
 	// For example, 20Mi, 1Gi, 256Ki etc.
@@ -36,6 +42,33 @@ type ClientConnection struct {
 	// +optional
 	// +notImplementedHide
 	SocketBufferLimit *resource.Quantity `json:"socketBufferLimit,omitempty"`
+	// SocketOptions provides configuration for the options of the listener socket.
+	//
+	// +optional
+	SocketOptions *ClientSocketOptions `json:"socketOptions,omitempty"`
+}
+
+// ClientSocketOptions provides configuration for the options of the listener socket,
+// which are inherited by the accepted client connections.
+type ClientSocketOptions struct {
+	// NoDelay sets TCP_NODELAY on the listener socket, which disables Nagle's algorithm
+	// for the client connections.
+	//
+	// +optional
+	NoDelay *bool `json:"noDelay,omitempty"`
+	// ReusePort defines whether SO_REUSEPORT is set on the listener socket, which lets
+	// the kernel balance the client connections across the Envoy worker threads.
+	// Envoy enables it by default on Linux.
+	//
+	// +optional
+	ReusePort *bool `json:"reusePort,omitempty"`
+	// DSCP is the Differentiated Services Code Point used to mark the IP packets sent
+	// to the clients. It's set with IP_TOS on IPv4 listeners and IPV6_TCLASS on IPv6 listeners.
+	//
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=63
+	// +optional
+	DSCP *uint32 `json:"dscp,omitempty"`
 }
 
 // BackendConnection allows users to configure connection-level settings of backend
@@ -61,6 +94,73 @@ type BackendConnection struct {
 	// +optional
 	// +notImplementedHide
 	SocketBufferLimit *resource.Quantity `json:"socketBufferLimit,omitempty"`
+	// Preconnect configures Envoy to establish connections to the backend ahead of the requests,
+	// which avoids the latency of the connection setup for high-QPS backends.
+	// Disabled by default.
+	//
+	// +optional
+	Preconnect *PreconnectPolicy `json:"preconnect,omitempty"`
+	// HappyEyeballs defines the order in which Envoy connects to the addresses of the
+	// backend hostnames resolved with the IPv4AndIPv6 DNS lookup family.
+	//
+	// +optional
+	HappyEyeballs *HappyEyeballs `json:"happyEyeballs,omitempty"`
+}
+
+// HappyEyeballs defines how Envoy races the connections to the addresses of a dual-stack backend,
+// as described in RFC 8305.
+//
+// +kubebuilder:validation:XValidation:rule="!has(self.firstAddressFamily) || self.firstAddressFamily != 'DualStack'",message="firstAddressFamily must be either IPv4 or IPv6"
+type HappyEyeballs struct {
+	// FirstAddressFamily is the address family that Envoy tries first.
+	// Defaults to the address family of the first resolved address.
+	//
+	// +optional
+	FirstAddressFamily *IPFamily `json:"firstAddressFamily,omitempty"`
+	// FirstAddressFamilyCount is the number of addresses of the first address family that Envoy
+	// tries before the addresses of the other family.
+	// Defaults to 1.
+	//
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	FirstAddressFamilyCount *uint32 `json:"firstAddressFamilyCount,omitempty"`
+}
+
+// PreconnectPolicy defines how Envoy preconnects to the backend endpoints.
+type PreconnectPolicy struct {
+	// PerEndpointPercent is the percentage of the connections needed by the in-flight requests
+	// that Envoy establishes to each endpoint of the backend. For example, 150 establishes
+	// one extra connection for every two connections in use.
+	// Only applies to HTTP/1.1 backends, as HTTP/2 connections are multiplexed.
+	//
+	// +kubebuilder:validation:Minimum=100
+	// +kubebuilder:validation:Maximum=300
+	// +optional
+	PerEndpointPercent *uint32 `json:"perEndpointPercent,omitempty"`
+	// PredictivePercent is the percentage of the connections needed by the in-flight requests
+	// that Envoy establishes across all the endpoints of the backend, anticipating which endpoint
+	// the load balancer picks next. Useful for backends with low QPS per endpoint.
+	//
+	// +kubebuilder:validation:Minimum=100
+	// +optional
+	PredictivePercent *uint32 `json:"predictivePercent,omitempty"`
+}
+
+// ConnectionRateLimit defines the rate limit of the new connections accepted by a listener.
+//
+// +kubebuilder:validation:XValidation:rule="!has(self.burst) || self.burst >= self.connectionsPerSecond",message="burst must be greater than or equal to connectionsPerSecond"
+type ConnectionRateLimit struct {
+	// ConnectionsPerSecond is the number of new connections accepted per second.
+	//
+	// +kubebuilder:validation:Minimum=1
+	ConnectionsPerSecond uint32 `json:"connectionsPerSecond"`
+
+	// Burst is the number of new connections that can be accepted at once, before
+	// the rate applies.
+	// Defaults to ConnectionsPerSecond.
+	//
+	// +optional
+	Burst *uint32 `json:"burst,omitempty"`
 }
 
 type ConnectionLimit struct {
@@ -0,0 +1,104 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package v1alpha1
+
+import gwapiv1b1 "sigs.k8s.io/gateway-api/apis/v1beta1"
+
+// CredentialInjectionTokenSecretKey is the key of the Secret holding the bearer token
+// injected into the requests.
+const CredentialInjectionTokenSecretKey = "token"
+
+// CredentialInjection defines the credentials injected into the requests forwarded to the
+// backends, so that the backends are called with the credentials of the gateway rather
+// than with the credentials of the clients.
+//
+// The credentials are injected once the requests are processed by the other filters of
+// the route, such as the authentication filters checking the credentials of the clients,
+// and before the requests are signed.
+//
+// +kubebuilder:validation:XValidation:rule="self.type == 'BearerToken' ? has(self.bearerToken) && !has(self.oauth2ClientCredentials) : true",message="bearerToken must be set, and oauth2ClientCredentials unset, for the BearerToken type"
+// +kubebuilder:validation:XValidation:rule="self.type == 'OAuth2ClientCredentials' ? has(self.oauth2ClientCredentials) && !has(self.bearerToken) : true",message="oauth2ClientCredentials must be set, and bearerToken unset, for the OAuth2ClientCredentials type"
+type CredentialInjection struct {
+	// Type is the type of the credentials injected into the requests.
+	//
+	// +unionDiscriminator
+	Type CredentialInjectionType `json:"type"`
+
+	// BearerToken injects a static bearer token read from a Secret.
+	//
+	// +optional
+	BearerToken *BearerTokenCredential `json:"bearerToken,omitempty"`
+
+	// OAuth2ClientCredentials injects an access token fetched from an OAuth2
+	// authorization server with the client credentials grant.
+	//
+	// +optional
+	OAuth2ClientCredentials *OAuth2ClientCredentials `json:"oauth2ClientCredentials,omitempty"`
+
+	// Overwrite replaces the credentials the requests already carry, such as the
+	// credentials of the clients. Otherwise, the requests carrying credentials are
+	// forwarded as is. Defaults to true.
+	//
+	// +optional
+	Overwrite *bool `json:"overwrite,omitempty"`
+}
+
+// CredentialInjectionType is the type of the credentials injected into the requests.
+//
+// +kubebuilder:validation:Enum=BearerToken;OAuth2ClientCredentials
+type CredentialInjectionType string
+
+const (
+	// CredentialInjectionTypeBearerToken injects a static bearer token.
+	CredentialInjectionTypeBearerToken CredentialInjectionType = "BearerToken"
+
+	// CredentialInjectionTypeOAuth2ClientCredentials injects an access token fetched with
+	// the OAuth2 client credentials grant.
+	CredentialInjectionTypeOAuth2ClientCredentials CredentialInjectionType = "OAuth2ClientCredentials"
+)
+
+// BearerTokenCredential defines a static bearer token injected into the requests.
+type BearerTokenCredential struct {
+	// Token references the Secret holding the bearer token, under the "token" key.
+	//
+	// Note: The secret must be in the same namespace as the BackendTrafficPolicy.
+	Token gwapiv1b1.SecretObjectReference `json:"token"`
+
+	// Header is the header the token is set in, prefixed with "Bearer ".
+	// Defaults to Authorization.
+	//
+	// +optional
+	Header *string `json:"header,omitempty"`
+}
+
+// OAuth2ClientCredentials defines the OAuth2 client credentials grant the access token
+// injected into the requests is fetched with.
+//
+// The access token is fetched by the envoy proxies, cached until it expires, and set
+// as a bearer token in the Authorization header of the requests.
+type OAuth2ClientCredentials struct {
+	// TokenEndpoint is the token endpoint of the authorization server the access token
+	// is fetched from.
+	//
+	// +kubebuilder:validation:MinLength=1
+	TokenEndpoint string `json:"tokenEndpoint"`
+
+	// ClientID is the client ID the access token is fetched with.
+	//
+	// +kubebuilder:validation:MinLength=1
+	ClientID string `json:"clientID"`
+
+	// ClientSecret references the Secret holding the client secret the access token is
+	// fetched with, under the "client-secret" key.
+	//
+	// Note: The secret must be in the same namespace as the BackendTrafficPolicy.
+	ClientSecret gwapiv1b1.SecretObjectReference `json:"clientSecret"`
+
+	// Scopes are the scopes requested for the access token.
+	//
+	// +optional
+	Scopes []string `json:"scopes,omitempty"`
+}
@@ -15,4 +15,30 @@ type DNS struct {
 	// If the value is set to true, the DNS refresh rate will be set to the resource record’s TTL.
 	// Defaults to true.
 	RespectDNSTTL *bool `json:"respectDnsTtl,omitempty"`
+	// LookupFamily defines the address families resolved for the hostnames of the backend.
+	// Defaults to IPv4.
+	//
+	// +optional
+	LookupFamily *DNSLookupFamily `json:"lookupFamily,omitempty"`
 }
+
+// DNSLookupFamily defines the address families resolved for a hostname.
+//
+// +kubebuilder:validation:Enum=IPv4;IPv6;IPv4Preferred;IPv6Preferred;IPv4AndIPv6
+type DNSLookupFamily string
+
+const (
+	// IPv4DNSLookupFamily only resolves IPv4 addresses.
+	IPv4DNSLookupFamily DNSLookupFamily = "IPv4"
+	// IPv6DNSLookupFamily only resolves IPv6 addresses.
+	IPv6DNSLookupFamily DNSLookupFamily = "IPv6"
+	// IPv4PreferredDNSLookupFamily resolves IPv4 addresses, and falls back to IPv6 addresses
+	// if there are none.
+	IPv4PreferredDNSLookupFamily DNSLookupFamily = "IPv4Preferred"
+	// IPv6PreferredDNSLookupFamily resolves IPv6 addresses, and falls back to IPv4 addresses
+	// if there are none.
+	IPv6PreferredDNSLookupFamily DNSLookupFamily = "IPv6Preferred"
+	// IPv4AndIPv6DNSLookupFamily resolves both IPv4 and IPv6 addresses, which are tried in
+	// the order of the HappyEyeballs settings of the backend connection.
+	IPv4AndIPv6DNSLookupFamily DNSLookupFamily = "IPv4AndIPv6"
+)
@@ -59,6 +59,12 @@ type EnvoyExtensionPolicySpec struct {
 	// +kubebuilder:validation:MaxItems=16
 	// +optional
 	ExtProc []ExtProc `json:"extProc,omitempty"`
+
+	// OpenAPIValidation validates the requests against an OpenAPI document,
+	// rejecting the invalid requests at the edge.
+	//
+	// +optional
+	OpenAPIValidation *OpenAPIValidation `json:"openAPIValidation,omitempty"`
 }
 
 //+kubebuilder:object:root=true
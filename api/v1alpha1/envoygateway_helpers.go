@@ -7,6 +7,7 @@ package v1alpha1
 
 import (
 	"fmt"
+	"sort"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/utils/ptr"
@@ -112,6 +113,87 @@ func DefaultGateway() *Gateway {
 	}
 }
 
+// ControllerNames returns the names of the Gateway API controllers run by Envoy Gateway,
+// starting with the one named by ControllerName.
+func (g *Gateway) ControllerNames() []string {
+	if g == nil {
+		return []string{GatewayControllerName}
+	}
+	names := make([]string, 0, len(g.AdditionalControllers)+1)
+	names = append(names, g.ControllerName)
+	for _, c := range g.AdditionalControllers {
+		names = append(names, c.ControllerName)
+	}
+	return names
+}
+
+// XdsServerPort returns the port the xDS server serves the proxies of the GatewayClasses
+// of the controller on, or zero if they are served on the default port.
+func (g *Gateway) XdsServerPort(controllerName string) int32 {
+	if g == nil {
+		return 0
+	}
+	for _, c := range g.AdditionalControllers {
+		if c.ControllerName == controllerName && c.XdsServerPort != nil {
+			return *c.XdsServerPort
+		}
+	}
+	return 0
+}
+
+// IsolatedGatewayPort returns the port the xDS server serves the proxies of the Gateway
+// on, or zero if the Gateway is not isolated.
+func (x *EnvoyGatewayXdsServer) IsolatedGatewayPort(namespace, name string) int32 {
+	if x == nil {
+		return 0
+	}
+	for _, g := range x.IsolatedGateways {
+		if string(g.Namespace) == namespace && string(g.Name) == name {
+			return g.Port
+		}
+	}
+	return 0
+}
+
+// defaultFeatureGates are the known feature gates, and whether they are enabled by default.
+var defaultFeatureGates = map[FeatureGate]bool{
+	FeatureGateHTTP3:         true,
+	FeatureGateMergeGateways: true,
+}
+
+// KnownFeatureGates returns the names of the known feature gates, sorted.
+func KnownFeatureGates() []FeatureGate {
+	gates := make([]FeatureGate, 0, len(defaultFeatureGates))
+	for gate := range defaultFeatureGates {
+		gates = append(gates, gate)
+	}
+	sort.Slice(gates, func(i, j int) bool { return gates[i] < gates[j] })
+	return gates
+}
+
+// FeatureGateEnabledByDefault returns whether the feature gate is enabled by default.
+func FeatureGateEnabledByDefault(gate FeatureGate) bool {
+	return defaultFeatureGates[gate]
+}
+
+// ResolvedFeatureGates returns whether each known feature gate is enabled, applying
+// the defaults of the gates not set.
+func (e *EnvoyGateway) ResolvedFeatureGates() map[FeatureGate]bool {
+	gates := make(map[FeatureGate]bool, len(defaultFeatureGates))
+	for gate, enabled := range defaultFeatureGates {
+		gates[gate] = enabled
+	}
+	if e == nil {
+		return gates
+	}
+	for gate, enabled := range e.FeatureGates {
+		if _, ok := gates[gate]; ok {
+			gates[gate] = enabled
+		}
+	}
+	return gates
+}
+
 // DefaultEnvoyGatewayLogging returns a new EnvoyGatewayLogging with default configuration parameters.
 func DefaultEnvoyGatewayLogging() *EnvoyGatewayLogging {
 	return &EnvoyGatewayLogging{
@@ -197,6 +279,8 @@ func DefaultEnvoyGatewayAdmin() *EnvoyGatewayAdmin {
 		Address:          DefaultEnvoyGatewayAdminAddress(),
 		EnableDumpConfig: false,
 		EnablePprof:      false,
+		EnableAPI:        false,
+		EnableDashboard:  false,
 	}
 }
 
@@ -6,6 +6,7 @@
 package v1alpha1
 
 import (
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	gwapiv1 "sigs.k8s.io/gateway-api/apis/v1"
 )
@@ -88,6 +89,892 @@ type EnvoyGatewaySpec struct {
 	//
 	// +optional
 	ExtensionAPIs *ExtensionAPISettings `json:"extensionApis,omitempty"`
+
+	// ProxySizing defines the settings of the resource sizing recommendations
+	// that Envoy Gateway computes for the Envoy Proxy fleets it manages.
+	// Only supported by the Kubernetes provider, and requires the Kubernetes
+	// metrics API (metrics.k8s.io) to be available in the cluster.
+	//
+	// +optional
+	ProxySizing *EnvoyGatewayProxySizing `json:"proxySizing,omitempty"`
+
+	// SecretRotation defines the settings of the coordinated rotation of the
+	// TLS secrets served to the Envoy Proxy fleets. If unset, updated secrets
+	// are pushed to the fleets without tracking the rotation.
+	//
+	// +optional
+	SecretRotation *EnvoyGatewaySecretRotation `json:"secretRotation,omitempty"`
+
+	// SnapshotCache defines the settings of the cache of the xDS snapshots served
+	// to the Envoy Proxy fleets. If unset, the snapshots of all the Gateways are
+	// retained.
+	//
+	// +optional
+	SnapshotCache *EnvoyGatewaySnapshotCache `json:"snapshotCache,omitempty"`
+
+	// XdsServer defines the gRPC settings of the xDS server the Envoy Proxy fleets
+	// connect to. If unset, the gRPC defaults are used.
+	//
+	// +optional
+	XdsServer *EnvoyGatewayXdsServer `json:"xdsServer,omitempty"`
+
+	// HostnameDelegation defines the hostnames delegated to the namespaces, which
+	// restricts the hostnames their routes may use on the shared Gateways.
+	// If unset, routes may use any hostname.
+	//
+	// +optional
+	HostnameDelegation *EnvoyGatewayHostnameDelegation `json:"hostnameDelegation,omitempty"`
+
+	// BulkImport defines how the HTTPRoutes created at once, e.g. while migrating to
+	// Envoy Gateway, are translated in batches, so that the configuration of the routes
+	// translated so far is published after each batch instead of once all of them are
+	// translated. If unset, the routes are always translated at once.
+	//
+	// +optional
+	BulkImport *EnvoyGatewayBulkImport `json:"bulkImport,omitempty"`
+
+	// ShadowTranslation defines a shadow translator translating the xds IRs alongside
+	// the active translator, whose output is only compared with the output of the
+	// active translator and reported in the metrics, to validate a change of the
+	// translation against the live configuration before it becomes active.
+	// If unset, the xds IRs are only translated by the active translator.
+	//
+	// +optional
+	ShadowTranslation *EnvoyGatewayShadowTranslation `json:"shadowTranslation,omitempty"`
+
+	// Watchable defines the settings of the watchable queues the messages are passed
+	// through from a runner to the next, so that a slow subscriber, e.g. the xDS
+	// translator, does not grow the memory without bound. If unset, the queues are
+	// unbounded.
+	//
+	// +optional
+	Watchable *EnvoyGatewayWatchable `json:"watchable,omitempty"`
+
+	// CanaryRollout enables the canary rollout controller, which runs the canary
+	// rollouts of the BackendTrafficPolicies by adjusting the weights of the backendRefs
+	// of the targeted HTTPRoutes. Only supported by the Kubernetes provider.
+	// If unset, the canary rollouts of the policies are not run.
+	//
+	// +optional
+	CanaryRollout *EnvoyGatewayCanaryRollout `json:"canaryRollout,omitempty"`
+
+	// FeatureGates enables or disables the features of the translation, keyed by
+	// the name of their gate. The gates not set keep their default, so that the
+	// experimental features can ship disabled and be enabled per environment.
+	//
+	// +optional
+	FeatureGates map[FeatureGate]bool `json:"featureGates,omitempty"`
+}
+
+// FeatureGate is the name of a gate enabling or disabling a feature of the translation.
+type FeatureGate string
+
+const (
+	// FeatureGateHTTP3 gates the HTTP/3 listeners enabled by ClientTrafficPolicies.
+	// Enabled by default.
+	FeatureGateHTTP3 FeatureGate = "HTTP3"
+
+	// FeatureGateMergeGateways gates the merging of the Gateways of a GatewayClass
+	// into a single Envoy Proxy fleet enabled by EnvoyProxy. Enabled by default.
+	FeatureGateMergeGateways FeatureGate = "MergeGateways"
+)
+
+// EnvoyGatewayShadowTranslation defines the shadow translator of the xds IRs.
+//
+// Each xds IR is translated again by the shadow translator once the output of the active
+// translator is published. The output of the shadow translator is never published: the
+// resources it translates differently, by type, are counted in the metrics and logged.
+type EnvoyGatewayShadowTranslation struct {
+	// Translator is the name of the shadow translator. The Full translator translates the
+	// whole xds IRs, which validates the translation of the changed routes only.
+	Translator ShadowTranslator `json:"translator"`
+}
+
+// ShadowTranslator is the name of a shadow translator of the xds IRs.
+type ShadowTranslator string
+
+const (
+	// ShadowTranslatorFull translates the whole xds IRs, even when the active translator
+	// only translates their changed routes.
+	ShadowTranslatorFull ShadowTranslator = "Full"
+)
+
+// EnvoyGatewayWatchable defines the settings of the watchable queues of the messages.
+//
+// Each subscription of a message has a queue of the updates of the message not handled
+// yet by its subscriber.
+type EnvoyGatewayWatchable struct {
+	// Queues bounds, or coalesces, the queues of the subscriptions of the messages, by
+	// message. The queues of the messages not listed are unbounded.
+	//
+	// +optional
+	Queues []WatchableQueue `json:"queues,omitempty"`
+
+	// Retries retries the updates of the messages whose subscriber fails to handle them,
+	// by message. The updates of the messages not listed are handled once, whether or
+	// not their subscriber fails.
+	//
+	// +optional
+	Retries []WatchableRetry `json:"retries,omitempty"`
+
+	// Tracing traces the updates of the messages through the runners, from the event of
+	// the provider they originate from up to their acknowledgement by the proxies.
+	// The updates are not traced if unset.
+	//
+	// +optional
+	Tracing *WatchableTracing `json:"tracing,omitempty"`
+}
+
+// EnvoyGatewayCanaryRollout defines the settings of the canary rollout controller.
+type EnvoyGatewayCanaryRollout struct {
+	// PrometheusURL is the URL of the Prometheus server the guardrails of the canary
+	// rollouts are queried from, e.g. http://prometheus.monitoring:9090.
+	PrometheusURL string `json:"prometheusURL"`
+}
+
+// WatchableTracing defines the collector the spans of the updates of the messages are
+// exported to with OTLP over gRPC.
+//
+// Each event of the provider starts a trace, which the updates it causes carry from a
+// runner to the next: a span is recorded for each update handled by a subscriber, labeled
+// with its runner and message, and for the first acknowledgement by each proxy of the
+// snapshot generated from it. The deletions are not traced.
+type WatchableTracing struct {
+	// Host is the hostname of the collector.
+	Host string `json:"host"`
+
+	// Port is the gRPC port of the collector. Defaults to 4317.
+	//
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=65535
+	Port *int32 `json:"port,omitempty"`
+
+	// SamplingRate is the percentage of the events of the provider that are traced.
+	// Defaults to 100, valid values [0-100]. 100 indicates 100% sampling.
+	//
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=100
+	SamplingRate *uint32 `json:"samplingRate,omitempty"`
+}
+
+// WatchableQueue bounds, or coalesces, the queues of the subscriptions of a message.
+type WatchableQueue struct {
+	// Message is the name of the message whose queues are bounded, e.g. xds-ir.
+	Message string `json:"message"`
+
+	// Capacity is the maximum number of updates pending in the queue of a subscription.
+	// The queue is unbounded if unset, which requires Coalesce.
+	//
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	Capacity uint32 `json:"capacity,omitempty"`
+
+	// Coalesce replaces the update of the same key pending in the queue with the new one
+	// as soon as it is pushed, whether or not the queue is full, so that a burst of updates
+	// of a key is handled once, with its newest value. Defaults to false.
+	//
+	// +optional
+	Coalesce *bool `json:"coalesce,omitempty"`
+
+	// OverflowPolicy is what happens to the updates of the message once the queue is full.
+	// Defaults to Block.
+	//
+	// +optional
+	OverflowPolicy *WatchableOverflowPolicy `json:"overflowPolicy,omitempty"`
+}
+
+// WatchableRetry retries the updates of a message whose subscriber fails to handle them,
+// i.e. reports an error or panics, with an exponential backoff. The updates still failing
+// after the last attempt are dead-lettered: they are held in the dead-letter buffer of the
+// subscription, listed by the admin API, rather than retried any longer.
+//
+// The failing updates are retried in between the updates following them, which don't wait
+// for the backoff. A newer update of a key supersedes the pending retry of its update, so
+// that the updates of a key are still handled in order.
+type WatchableRetry struct {
+	// Message is the name of the message whose updates are retried, e.g. xds-ir.
+	Message string `json:"message"`
+
+	// MaxAttempts is the number of times an update is handled before it is dead-lettered,
+	// the first attempt included. Defaults to 3.
+	//
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	MaxAttempts *uint32 `json:"maxAttempts,omitempty"`
+
+	// Backoff is the delay before the first retry of an update, which is doubled with
+	// each retry. Defaults to 100ms.
+	//
+	// +optional
+	Backoff *gwapiv1.Duration `json:"backoff,omitempty"`
+
+	// MaxBackoff is the maximum delay between the retries of an update. Defaults to 10s.
+	//
+	// +optional
+	MaxBackoff *gwapiv1.Duration `json:"maxBackoff,omitempty"`
+
+	// DeadLetterCapacity is the maximum number of updates held in the dead-letter buffer
+	// of a subscription, the oldest ones being evicted first. Defaults to 100.
+	//
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	DeadLetterCapacity *uint32 `json:"deadLetterCapacity,omitempty"`
+}
+
+// WatchableOverflowPolicy is what happens to the updates of a message once the queue of
+// a subscription is full.
+type WatchableOverflowPolicy string
+
+const (
+	// WatchableOverflowPolicyBlock stops reading the updates of the message until the
+	// subscriber makes room in the queue. No update is lost, but the watchable map holds
+	// every update stored meanwhile, without bound, until the subscriber catches up.
+	WatchableOverflowPolicyBlock WatchableOverflowPolicy = "Block"
+
+	// WatchableOverflowPolicyDropOldest drops the oldest update pending in the queue which
+	// a newer update of its key supersedes to make room for the new one. The latest update
+	// of a key and the deletions are never dropped: the queue blocks as with Block when no
+	// pending update is superseded.
+	WatchableOverflowPolicyDropOldest WatchableOverflowPolicy = "DropOldest"
+
+	// WatchableOverflowPolicyCoalesceByKey replaces the update of the same key pending in
+	// the queue with the new one, so that only the latest update of the key is handled.
+	// The queue blocks as with Block when no update of the key is pending.
+	WatchableOverflowPolicyCoalesceByKey WatchableOverflowPolicy = "CoalesceByKey"
+)
+
+// EnvoyGatewayBulkImport defines how the HTTPRoutes created at once are translated.
+//
+// The HTTPRoutes not translated yet are added to the routes already translated batch
+// after batch: each batch translates its new routes only, whose configuration is added
+// to the configuration published by the previous batches, so that its first routes are
+// served sooner. The statuses of the routes are only updated once all of them are
+// translated.
+type EnvoyGatewayBulkImport struct {
+	// Threshold is the number of the HTTPRoutes not translated yet above which they
+	// are translated in batches. Defaults to 1000.
+	//
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	Threshold *uint32 `json:"threshold,omitempty"`
+
+	// BatchSize is the number of the HTTPRoutes not translated yet added to the
+	// translation with each batch. Defaults to 500.
+	//
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	BatchSize *uint32 `json:"batchSize,omitempty"`
+}
+
+// EnvoyGatewayHostnameDelegation defines the hostnames delegated to the namespaces.
+//
+// The routes of a namespace a hostname is delegated to may only use the hostnames
+// delegated to it. The hostnames delegated to a namespace are owned by it: the routes
+// of the other namespaces may not use them. The routes of the namespaces without
+// delegation may use any hostname not owned by another namespace.
+//
+// The hostnames of a route are checked as attached to each listener, so that a route
+// without hostnames is checked against the hostnames of its listeners. A route using a
+// hostname it is not allowed to is not accepted by the parent Gateway.
+type EnvoyGatewayHostnameDelegation struct {
+	// Delegations defines the hostnames delegated to each namespace.
+	Delegations []HostnameDelegation `json:"delegations"`
+}
+
+// HostnameDelegation delegates hostnames to namespaces.
+type HostnameDelegation struct {
+	// Namespaces the hostnames are delegated to.
+	Namespaces []string `json:"namespaces"`
+
+	// Hostnames delegated to the namespaces. A wildcard hostname, such as
+	// "*.team-a.example.com", delegates all its subdomains, including the
+	// wildcard hostnames they match.
+	Hostnames []gwapiv1.Hostname `json:"hostnames"`
+}
+
+// EnvoyGatewaySecretRotation defines the settings of the coordinated rotation of
+// the TLS secrets served to the Envoy Proxy fleets.
+//
+// A rotation starts when a secret served to a fleet is updated, and completes once
+// all the proxies of the fleet acknowledged the updated secret. While in progress,
+// the Gateways of the fleet surface a SecretRotationInProgress condition.
+type EnvoyGatewaySecretRotation struct {
+	// OverlapWindow defines how long the previous CA certificates remain trusted
+	// alongside the new ones when a CA certificate bundle is rotated, so that the
+	// peers presenting certificates issued by either CA are accepted during the
+	// rollover. Certificates and keys are pushed as soon as they are updated.
+	// Defaults to 5m.
+	//
+	// +optional
+	OverlapWindow *gwapiv1.Duration `json:"overlapWindow,omitempty"`
+}
+
+// EnvoyGatewaySnapshotCache defines the settings of the cache of the xDS snapshots.
+//
+// Once the snapshots exceed the memory limit, the least recently used snapshots of the
+// Gateways without connected proxies are evicted, and generated again from the latest
+// translation of their Gateway when a proxy requests them.
+type EnvoyGatewaySnapshotCache struct {
+	// MemoryLimit is the approximate size of the serialized resources of the cached
+	// snapshots above which the snapshots are evicted. The snapshots served to the
+	// connected proxies are never evicted, so that the limit may be exceeded.
+	// The snapshots are never evicted if unset.
+	//
+	// +optional
+	MemoryLimit *resource.Quantity `json:"memoryLimit,omitempty"`
+
+	// History is the number of the last snapshots of each Gateway retained with their
+	// version and generation time, to diff them and debug a bad configuration push.
+	// The snapshots of the history are not accounted in the memory limit, and are
+	// dropped along with the last snapshot of their Gateway when it is evicted.
+	// Defaults to 10.
+	//
+	// +optional
+	History *uint32 `json:"history,omitempty"`
+
+	// Persistence defines where the last snapshot of each Gateway is persisted, to be
+	// restored when Envoy Gateway starts, so that the proxies reconnecting after a
+	// restart are served their last configuration before the first translation
+	// completes. The snapshots are not persisted if unset.
+	//
+	// +optional
+	Persistence *EnvoyGatewaySnapshotPersistence `json:"persistence,omitempty"`
+
+	// Debounce defines how the successive updates of a Gateway are coalesced into a
+	// single snapshot, instead of pushing a snapshot to the proxies for each of them,
+	// e.g. while a rollout churns the endpoints of its backends. A snapshot is
+	// generated as soon as a Gateway is updated if unset.
+	//
+	// +optional
+	Debounce *EnvoyGatewaySnapshotDebounce `json:"debounce,omitempty"`
+
+	// ResourceTTL sets a TTL on the resources served to the proxies, which drop the
+	// resources once expired unless they are sent them again, so that they stop using a
+	// stale configuration if Envoy Gateway stops responding. The resources are sent again
+	// as heartbeats before they expire. The resources have no TTL if unset.
+	//
+	// +optional
+	ResourceTTL *EnvoyGatewayResourceTTL `json:"resourceTTL,omitempty"`
+
+	// ValidateConsistency enables the validation of each snapshot before it is set:
+	// the routes must only reference the clusters of the snapshot, and the clusters
+	// the secrets of the snapshot. An inconsistent snapshot is not pushed to the
+	// proxies, which keep being served the previous snapshot of their Gateway, and
+	// the Gateway is set an XdsInconsistent condition instead.
+	// Defaults to false.
+	//
+	// +optional
+	ValidateConsistency *bool `json:"validateConsistency,omitempty"`
+
+	// NodeHash defines the key of the snapshot served to each proxy in the cache, and so
+	// whether the proxies share a single cache entry or each get their own. Sharing an
+	// entry saves the memory and the CPU of the snapshots of the Gateways with many
+	// replicas, but the proxies sharing it are rolled back together when one of them
+	// rejects a snapshot. The snapshots are keyed by the ID of the proxies if unset.
+	//
+	// +optional
+	NodeHash *EnvoyGatewayNodeHash `json:"nodeHash,omitempty"`
+
+	// StaleNodeTimeout is the time after which the snapshot served to a proxy without
+	// an open xDS stream is cleared from the cache, such as the snapshot of a proxy
+	// whose pod was deleted. A proxy reconnecting later is served the last snapshot of
+	// its Gateway again. The snapshots of the disconnected proxies are retained until
+	// they are evicted to stay within the memory limit if unset.
+	//
+	// +optional
+	StaleNodeTimeout *gwapiv1.Duration `json:"staleNodeTimeout,omitempty"`
+
+	// StagedRollout defines how a new snapshot of a Gateway is rolled out to its proxies
+	// in stages, so that a bad configuration only reaches a few of them. A new snapshot
+	// is pushed to all the proxies at once if unset.
+	//
+	// +optional
+	StagedRollout *EnvoyGatewayStagedRollout `json:"stagedRollout,omitempty"`
+
+	// PushPacing defines the pace the proxies are pushed the new snapshots of their
+	// Gateway at, so that a change pushed to thousands of proxies does not make them
+	// all request and apply the configuration at once. All the proxies are pushed a
+	// new snapshot at once if unset.
+	//
+	// +optional
+	PushPacing *EnvoyGatewayPushPacing `json:"pushPacing,omitempty"`
+
+	// DriftDetection defines how the proxies whose acknowledged configuration stays behind
+	// the configuration they are served are detected, such as the proxies silently stuck
+	// on an older configuration. The drift of the proxies is not detected if unset.
+	//
+	// +optional
+	DriftDetection *EnvoyGatewayDriftDetection `json:"driftDetection,omitempty"`
+}
+
+// EnvoyGatewayDriftDetection defines the detection of the drift of the proxies.
+//
+// The hashes of the resources each proxy acknowledged last are recorded, and listed with
+// the connected proxies by the admin API. A proxy whose acknowledged resources differ from
+// the resources it is served for longer than the window is reported as drifted by the
+// xds_drifted_nodes metric and the XdsDrifted condition of its Gateways. The proxies
+// waiting for a staged rollout or a paced push are served their previous snapshot, so they
+// are not behind.
+type EnvoyGatewayDriftDetection struct {
+	// Window is how long a proxy may stay behind the configuration it is served before it
+	// is reported as drifted.
+	// Defaults to 5m.
+	//
+	// +optional
+	Window *gwapiv1.Duration `json:"window,omitempty"`
+}
+
+// EnvoyGatewayPushPacing defines the pace of the pushes of the snapshots to the proxies.
+//
+// The proxies to push a new snapshot to are queued, and pushed the last snapshot of their
+// Gateway one after the other at the paced rate, so that a proxy queued again before it is
+// pushed is only pushed once. The proxies sharing a snapshot key, as set by the NodeHash,
+// are pushed together. The proxies connecting, and the proxies rolled back after rejecting
+// a snapshot, are served their snapshot right away.
+type EnvoyGatewayPushPacing struct {
+	// MaxNodeUpdatesPerSecond is the maximum number of the snapshot keys of the proxies
+	// pushed a snapshot per second.
+	MaxNodeUpdatesPerSecond uint32 `json:"maxNodeUpdatesPerSecond"`
+
+	// Jitter is the maximum random delay added between two pushes, which spreads the
+	// requests of the proxies further.
+	//
+	// +optional
+	Jitter *gwapiv1.Duration `json:"jitter,omitempty"`
+}
+
+// EnvoyGatewayStagedRollout defines the staged rollout of the snapshots of the Gateways.
+//
+// A new snapshot of a Gateway is first pushed to a percentage of its proxies, the canaries,
+// while the other proxies keep being served their previous snapshot. Once all the connected
+// canaries acknowledged the snapshot and kept acknowledging it for the soak period, it is
+// pushed to the other proxies. If a canary rejects the snapshot, the rollout halts and the
+// canaries are rolled back to their previous snapshot until a new snapshot is generated.
+//
+// The proxies sharing a snapshot key, as set by the NodeHash, are rolled out together, and
+// the proxies connecting during a rollout are served the new snapshot. The endpoints and
+// the other resources updated in place during a rollout are only pushed to the canaries.
+type EnvoyGatewayStagedRollout struct {
+	// CanaryPercentage is the percentage of the proxies of a Gateway the new snapshots are
+	// pushed to first, rounded up to at least one proxy. A snapshot is pushed to all the
+	// proxies at once if there would be no other proxy to roll it out to.
+	CanaryPercentage uint32 `json:"canaryPercentage"`
+
+	// SoakPeriod is how long the canaries must keep acknowledging a new snapshot before it
+	// is pushed to the other proxies.
+	// Defaults to 1m.
+	//
+	// +optional
+	SoakPeriod *gwapiv1.Duration `json:"soakPeriod,omitempty"`
+}
+
+// NodeHashType defines the field of the proxies their snapshot is keyed by.
+type NodeHashType string
+
+const (
+	// NodeHashTypeNodeID keys the snapshot of each proxy by its node ID, so that each
+	// proxy gets a cache entry of its own.
+	NodeHashTypeNodeID NodeHashType = "NodeID"
+
+	// NodeHashTypeCluster keys the snapshots of the proxies by their node cluster, which
+	// is their Gateway, so that all the proxies of a Gateway share a cache entry. It
+	// cannot be used with node groups, which serve different snapshots to the proxies
+	// of a Gateway.
+	NodeHashTypeCluster NodeHashType = "Cluster"
+
+	// NodeHashTypeMetadata keys the snapshots of the proxies of a Gateway by the value of
+	// a key of their node metadata, so that the proxies of a Gateway with the same value
+	// share a cache entry. The proxies without the key get a cache entry of their own.
+	// It cannot be used with node groups either.
+	NodeHashTypeMetadata NodeHashType = "Metadata"
+)
+
+// EnvoyGatewayNodeHash defines how the snapshots served to the proxies are keyed in the
+// snapshot cache.
+type EnvoyGatewayNodeHash struct {
+	// Type is the field of the proxies their snapshot is keyed by.
+	//
+	// +kubebuilder:validation:Enum=NodeID;Cluster;Metadata
+	Type NodeHashType `json:"type"`
+
+	// MetadataKey is the key of the node metadata the snapshots are keyed by. It must be
+	// set if the type is Metadata, and only then.
+	//
+	// +optional
+	MetadataKey *string `json:"metadataKey,omitempty"`
+}
+
+// EnvoyGatewayResourceTTL defines the TTL of the resources of each type served to the
+// proxies.
+//
+// The heartbeats are only sent on the State of the World xDS streams, so that the
+// proxies using the incremental xDS protocol drop the resources once expired until they
+// are updated.
+type EnvoyGatewayResourceTTL struct {
+	// Default is the TTL of the resources of the types without a TTL of their own. The
+	// resources of these types have no TTL if unset.
+	//
+	// +optional
+	Default *gwapiv1.Duration `json:"default,omitempty"`
+
+	// Types are the TTLs of the resources of specific types, e.g. to only set a TTL on
+	// the endpoints.
+	//
+	// +optional
+	Types []EnvoyGatewayResourceTypeTTL `json:"types,omitempty"`
+
+	// HeartbeatInterval is the interval the resources with a TTL are sent again at. It
+	// must be shorter than the TTLs. Defaults to a third of the shortest TTL.
+	//
+	// +optional
+	HeartbeatInterval *gwapiv1.Duration `json:"heartbeatInterval,omitempty"`
+}
+
+// EnvoyGatewayResourceTypeTTL defines the TTL of the resources of a type.
+type EnvoyGatewayResourceTypeTTL struct {
+	// Type is the type URL of the resources.
+	Type EnvoyResourceType `json:"type"`
+
+	// TTL is the TTL of the resources.
+	TTL gwapiv1.Duration `json:"ttl"`
+}
+
+// EnvoyGatewaySnapshotDebounce defines how the updates of a Gateway are coalesced.
+//
+// The snapshot of a Gateway is generated once no update of the Gateway was received
+// for the interval, or once the max delay elapsed since its first update pending, so
+// that a Gateway updated continuously is still pushed to the proxies.
+type EnvoyGatewaySnapshotDebounce struct {
+	// Interval is the time without update of a Gateway after which its snapshot is
+	// generated. Defaults to 100ms.
+	//
+	// +optional
+	Interval *gwapiv1.Duration `json:"interval,omitempty"`
+
+	// MaxDelay is the maximum time an update of a Gateway is delayed for. It must not
+	// be shorter than the interval. Defaults to 1s.
+	//
+	// +optional
+	MaxDelay *gwapiv1.Duration `json:"maxDelay,omitempty"`
+}
+
+// EnvoyGatewaySnapshotPersistence defines where the xDS snapshots are persisted.
+//
+// A snapshot is restored until a new snapshot is generated for its Gateway, so that the
+// snapshot of a Gateway deleted while Envoy Gateway was not running is kept. The snapshots
+// are written in the background, without their secrets, which the restored snapshots are
+// served without until their Gateway is translated.
+type EnvoyGatewaySnapshotPersistence struct {
+	// Path is the absolute path of the directory the snapshots are written to, such as
+	// the mount path of a PersistentVolumeClaim, to keep them across the restarts of
+	// the pod. The directory is created if it does not exist.
+	Path string `json:"path"`
+}
+
+// EnvoyGatewayXdsServer defines the gRPC settings of the xDS server.
+//
+// The defaults of gRPC may not suit very large snapshots, which exceed the maximum
+// message size, or unreliable networks, where broken connections are only detected
+// after hours without keepalives.
+type EnvoyGatewayXdsServer struct {
+	// MaxRecvMessageSize is the maximum size of the messages received from the proxies.
+	// Defaults to 4Mi.
+	//
+	// +optional
+	MaxRecvMessageSize *resource.Quantity `json:"maxRecvMessageSize,omitempty"`
+
+	// MaxSendMessageSize is the maximum size of the messages sent to the proxies, such
+	// as the xDS responses. Defaults to 2Gi.
+	//
+	// +optional
+	MaxSendMessageSize *resource.Quantity `json:"maxSendMessageSize,omitempty"`
+
+	// MaxConcurrentStreams is the maximum number of concurrent streams per connection
+	// of a proxy. Unlimited by default.
+	//
+	// +optional
+	MaxConcurrentStreams *uint32 `json:"maxConcurrentStreams,omitempty"`
+
+	// Keepalive defines the keepalive pings the server sends to the proxies.
+	//
+	// +optional
+	Keepalive *XdsServerKeepalive `json:"keepalive,omitempty"`
+
+	// SendTimeout is how long an xDS response may wait to be sent to a proxy before its
+	// stream is reset, so that the proxy reconnects and is served its configuration again.
+	// The responses are sent to each proxy at its own pace, so that a slow proxy doesn't
+	// delay the others. If unset, the streams of slow proxies are never reset.
+	//
+	// +optional
+	SendTimeout *gwapiv1.Duration `json:"sendTimeout,omitempty"`
+
+	// DrainTimeout is how long the xDS streams are drained for on shutdown. While draining,
+	// new connections and streams are refused, the updates held by the debouncer are
+	// published, and the responses in flight are waited for until the proxies acknowledge
+	// or reject them, before the connections are closed. If unset, the connections are
+	// closed immediately.
+	//
+	// +optional
+	DrainTimeout *gwapiv1.Duration `json:"drainTimeout,omitempty"`
+
+	// IsolatedGateways defines the Gateways whose proxies are served on an xDS server
+	// port of their own, from a partition of the snapshot cache of their own, with its
+	// own lock and metrics, so that the updates of the other Gateways don't delay the
+	// propagation of their configuration. The Gateways merged by the mergeGateways field
+	// of their EnvoyProxy are not isolated.
+	//
+	// The ports must be exposed by the Service of Envoy Gateway.
+	//
+	// +optional
+	IsolatedGateways []XdsServerIsolatedGateway `json:"isolatedGateways,omitempty"`
+
+	// AuditLog defines the structured log of the xDS requests received from the proxies
+	// and of the responses sent to them. The exchanges are not logged if unset.
+	//
+	// +optional
+	AuditLog *XdsAuditLog `json:"auditLog,omitempty"`
+
+	// NodeAuthorization defines how the proxies are authenticated, so that each proxy is
+	// only served the configuration of the Gateways of its tenant. The streams of a proxy
+	// whose node claims the cluster of a Gateway it's not authorized for are refused.
+	// The proxies are not authorized if unset.
+	//
+	// +optional
+	NodeAuthorization *XdsNodeAuthorization `json:"nodeAuthorization,omitempty"`
+
+	// AnomalyDetection defines the external detector the success rate of the routes is
+	// streamed to, and which degrades the routes it detects an anomaly on. The routes are
+	// not degraded if unset.
+	//
+	// +optional
+	AnomalyDetection *XdsAnomalyDetection `json:"anomalyDetection,omitempty"`
+}
+
+// XdsNodeAuthenticationType is the type of the identity the proxies are authenticated by.
+type XdsNodeAuthenticationType string
+
+const (
+	// XdsNodeAuthenticationTypeClientCertificate authenticates the proxies by the SPIFFE ID
+	// of their client certificate, spiffe://<trust domain>/ns/<namespace>/sa/<service account>.
+	XdsNodeAuthenticationTypeClientCertificate XdsNodeAuthenticationType = "ClientCertificate"
+	// XdsNodeAuthenticationTypeServiceAccountToken authenticates the proxies by the token of
+	// a Kubernetes service account set in their node metadata, reviewed by the API server.
+	XdsNodeAuthenticationTypeServiceAccountToken XdsNodeAuthenticationType = "ServiceAccountToken"
+)
+
+// XdsNodeAuthorization defines how the proxies are authenticated and authorized.
+//
+// A proxy is authorized for the Gateways of the namespace of its service account, and
+// the proxies managed by Envoy Gateway for the Gateways they are deployed for. The merged
+// Gateways are only served to the proxies managed by Envoy Gateway.
+type XdsNodeAuthorization struct {
+	// Type is the type of the identity the proxies are authenticated by.
+	Type XdsNodeAuthenticationType `json:"type"`
+
+	// ServiceAccountToken defines how the service account tokens are read and reviewed.
+	//
+	// +optional
+	ServiceAccountToken *XdsNodeServiceAccountToken `json:"serviceAccountToken,omitempty"`
+}
+
+// XdsNodeServiceAccountToken defines how the service account tokens of the proxies are
+// read from their node metadata and reviewed.
+type XdsNodeServiceAccountToken struct {
+	// MetadataKey is the key of the node metadata field holding the token.
+	// Defaults to serviceAccountToken.
+	//
+	// +optional
+	MetadataKey *string `json:"metadataKey,omitempty"`
+
+	// Audiences are the audiences the tokens must be issued for. The audiences of the
+	// API server are used if empty.
+	//
+	// +optional
+	Audiences []string `json:"audiences,omitempty"`
+}
+
+// XdsAuditLog defines the structured log of the xDS exchanges.
+//
+// Each request and response is logged as a JSON record with the node ID, the type URL,
+// the version, the nonce and the resource names of the exchange, whether a request
+// acknowledges or rejects a response, and how long the proxy took to answer it.
+type XdsAuditLog struct {
+	// Sink defines where the records are written.
+	Sink XdsAuditLogSink `json:"sink"`
+
+	// SamplingPercent is the percentage of the exchanges logged. Defaults to 100.
+	//
+	// +optional
+	// +kubebuilder:validation:Maximum=100
+	SamplingPercent *uint32 `json:"samplingPercent,omitempty"`
+
+	// Types are the type URLs of the resources whose exchanges are logged. The
+	// exchanges of the resources of all the types are logged if empty.
+	//
+	// +optional
+	Types []EnvoyResourceType `json:"types,omitempty"`
+}
+
+// XdsAuditLogSinkType is the type of the sink of the xDS audit log.
+// +kubebuilder:validation:Enum=Stdout;File;OpenTelemetry
+type XdsAuditLogSinkType string
+
+const (
+	// XdsAuditLogSinkTypeStdout writes the records to the standard output.
+	XdsAuditLogSinkTypeStdout XdsAuditLogSinkType = "Stdout"
+	// XdsAuditLogSinkTypeFile writes the records to a file.
+	XdsAuditLogSinkTypeFile XdsAuditLogSinkType = "File"
+	// XdsAuditLogSinkTypeOpenTelemetry exports the records to an OpenTelemetry collector.
+	XdsAuditLogSinkTypeOpenTelemetry XdsAuditLogSinkType = "OpenTelemetry"
+)
+
+// XdsAuditLogSink defines the sink of the xDS audit log.
+// +union
+type XdsAuditLogSink struct {
+	// Type defines the type of the sink.
+	// +unionDiscriminator
+	Type XdsAuditLogSinkType `json:"type"`
+
+	// File defines the file the records are appended to.
+	//
+	// +optional
+	File *XdsAuditLogFileSink `json:"file,omitempty"`
+
+	// OpenTelemetry defines the OpenTelemetry collector the records are exported to
+	// with the OTLP/gRPC protocol.
+	//
+	// +optional
+	OpenTelemetry *XdsAuditLogOpenTelemetrySink `json:"openTelemetry,omitempty"`
+}
+
+// XdsAuditLogFileSink defines the file the xDS audit log is appended to.
+type XdsAuditLogFileSink struct {
+	// Path is the absolute path of the file.
+	Path string `json:"path"`
+}
+
+// XdsAuditLogOpenTelemetrySink defines the OpenTelemetry collector the xDS audit log
+// is exported to.
+type XdsAuditLogOpenTelemetrySink struct {
+	// Host is the hostname of the collector.
+	Host string `json:"host"`
+
+	// Port is the OTLP/gRPC port of the collector. Defaults to 4317.
+	//
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	Port *int32 `json:"port,omitempty"`
+}
+
+// XdsServerIsolatedGateway defines a Gateway whose proxies are served on an xDS server
+// port of their own.
+type XdsServerIsolatedGateway struct {
+	// Namespace is the namespace of the Gateway.
+	Namespace gwapiv1.Namespace `json:"namespace"`
+
+	// Name is the name of the Gateway.
+	Name gwapiv1.ObjectName `json:"name"`
+
+	// Port is the port the xDS server serves the proxies of the Gateway on. The proxies
+	// are only served on this port.
+	Port int32 `json:"port"`
+}
+
+// XdsServerKeepalive defines the keepalive pings the xDS server sends to the proxies.
+type XdsServerKeepalive struct {
+	// Time is the duration without activity on a connection after which the server
+	// pings the proxy. Defaults to 2h.
+	//
+	// +optional
+	Time *gwapiv1.Duration `json:"time,omitempty"`
+
+	// Timeout is the duration the server waits for the response to a ping before
+	// closing the connection. Defaults to 20s.
+	//
+	// +optional
+	Timeout *gwapiv1.Duration `json:"timeout,omitempty"`
+}
+
+// XdsAnomalyDetection defines the external detector of the anomalies of the routes.
+//
+// The detector implements the Load Reporting Service (LRS) of Envoy, which the xDS server
+// streams the requests of the route rules reported by the proxies to, aggregated across
+// the proxies of each Gateway. Each route rule is reported as a cluster, with its
+// successful, failed and issued requests since the previous report. The clusters of each
+// response of the detector are the route rules it degrades: their traffic is shifted to
+// their fallback backends until the degradation expires, unless the detector degrades
+// them again in the meantime.
+//
+// The requests of the route rules are only reported by the proxies whose EnvoyProxy
+// enables the load reporting.
+type XdsAnomalyDetection struct {
+	// Host is the hostname of the detector.
+	Host string `json:"host"`
+
+	// Port is the gRPC port of the detector.
+	//
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=65535
+	Port int32 `json:"port"`
+
+	// Interval is the interval the requests of the route rules are streamed at.
+	// Defaults to 10s.
+	//
+	// +optional
+	Interval *gwapiv1.Duration `json:"interval,omitempty"`
+
+	// DegradationTTL is how long a route rule stays degraded once the detector no longer
+	// degrades it. Defaults to 1m.
+	//
+	// +optional
+	DegradationTTL *gwapiv1.Duration `json:"degradationTTL,omitempty"`
+}
+
+// ProxySizingMode defines how the resource sizing recommendations are used.
+// +kubebuilder:validation:Enum=Recommend;Auto
+type ProxySizingMode string
+
+const (
+	// ProxySizingModeRecommend only publishes the recommendations as metrics.
+	ProxySizingModeRecommend ProxySizingMode = "Recommend"
+	// ProxySizingModeAuto publishes the recommendations as metrics, and
+	// applies them to the Envoy Proxy fleets.
+	ProxySizingModeAuto ProxySizingMode = "Auto"
+)
+
+// EnvoyGatewayProxySizing defines the settings of the resource sizing recommendations
+// for the Envoy Proxy fleets.
+type EnvoyGatewayProxySizing struct {
+	// Mode defines how the recommendations are used.
+	// In the Recommend mode, the recommended resource requests, memory limit and
+	// concurrency are published as metrics. In the Auto mode, they are also applied
+	// to the Envoy Proxy fleets, overriding the resource requests configured in the
+	// EnvoyProxy. The concurrency is only applied if it's not set in the EnvoyProxy.
+	// Defaults to Recommend.
+	//
+	// +optional
+	Mode *ProxySizingMode `json:"mode,omitempty"`
+
+	// Interval defines the interval at which the resource usage of the Envoy Proxy
+	// fleets is sampled and the recommendations are updated. Defaults to 1m.
+	//
+	// +optional
+	Interval *gwapiv1.Duration `json:"interval,omitempty"`
+
+	// Window defines the number of samples the recommendations are based on.
+	// Defaults to 60.
+	//
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	Window *uint32 `json:"window,omitempty"`
+
+	// HeadroomPercent defines the percentage added on top of the peak resource
+	// usage observed in the window. Defaults to 20.
+	//
+	// +optional
+	HeadroomPercent *uint32 `json:"headroomPercent,omitempty"`
 }
 
 // LeaderElection defines the desired leader election settings.
@@ -159,6 +1046,31 @@ type Gateway struct {
 	//
 	// +optional
 	ControllerName string `json:"controllerName,omitempty"`
+
+	// AdditionalControllers defines the Gateway API controllers Envoy Gateway runs in
+	// addition to the one named by ControllerName. The GatewayClasses of each controller
+	// are reconciled and translated apart from the ones of the other controllers, and
+	// may reference different EnvoyProxy parameters.
+	//
+	// +optional
+	AdditionalControllers []GatewayController `json:"additionalControllers,omitempty"`
+}
+
+// GatewayController defines a Gateway API controller run by Envoy Gateway.
+type GatewayController struct {
+	// ControllerName defines the name of the Gateway API controller.
+	ControllerName string `json:"controllerName"`
+
+	// XdsServerPort defines the port the xDS server serves the proxies of the
+	// GatewayClasses of the controller on. The proxies are only served on the port
+	// of their controller, so that the proxies of different controllers are isolated
+	// from each other. If unspecified, the proxies are served on the default port of
+	// the xDS server, along with the proxies of the controller named by ControllerName.
+	//
+	// The port must be exposed by the Service of Envoy Gateway.
+	//
+	// +optional
+	XdsServerPort *int32 `json:"xdsServerPort,omitempty"`
 }
 
 // ExtensionAPISettings defines the settings specific to Gateway API Extensions.
@@ -543,6 +1455,17 @@ type EnvoyGatewayAdmin struct {
 	//
 	// +optional
 	EnablePprof bool `json:"enablePprof,omitempty"`
+	// EnableAPI defines if enable the versioned admin API in Envoy Gateway Admin Server.
+	// The admin API exposes the state of the translation pipeline and allows
+	// operators to re-translate, pause and resume the publishing of xDS snapshots.
+	//
+	// +optional
+	EnableAPI bool `json:"enableAPI,omitempty"`
+	// EnableDashboard defines if enable the read-only dashboard in Envoy Gateway Admin Server.
+	// The dashboard is served at /dashboard/ and requires EnableAPI.
+	//
+	// +optional
+	EnableDashboard bool `json:"enableDashboard,omitempty"`
 }
 
 // EnvoyGatewayAdminAddress defines the Envoy Gateway Admin Address configuration.
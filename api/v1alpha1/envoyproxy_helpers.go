@@ -162,3 +162,33 @@ func DefaultShutdownManagerContainerResourceRequirements() *corev1.ResourceRequi
 func (f EnvoyFilter) String() string {
 	return string(f)
 }
+
+// DefaultProxyAdminAllowedPaths returns the read-only Envoy admin endpoints proxied by default.
+func DefaultProxyAdminAllowedPaths() []string {
+	return []string{
+		"/config_dump",
+		"/clusters",
+		"/listeners",
+		"/server_info",
+		"/ready",
+		"/stats",
+		"/stats/prometheus",
+	}
+}
+
+// GetExposure returns the exposure of the Envoy admin interface, or Localhost if unspecified.
+func (a *ProxyAdmin) GetExposure() ProxyAdminExposure {
+	if a == nil || a.Exposure == nil {
+		return ProxyAdminExposureLocalhost
+	}
+	return *a.Exposure
+}
+
+// GetAllowedPaths returns the Envoy admin endpoints proxied by Envoy Gateway,
+// or the default read-only endpoints if unspecified.
+func (a *ProxyAdmin) GetAllowedPaths() []string {
+	if a == nil || len(a.AllowedPaths) == 0 {
+		return DefaultProxyAdminAllowedPaths()
+	}
+	return a.AllowedPaths
+}
@@ -68,6 +68,165 @@ type EnvoyProxySpec struct {
 	// +optional
 	Concurrency *int32 `json:"concurrency,omitempty"`
 
+	// RuntimeFlags defines the Envoy runtime flags of the managed proxies, which are set in the
+	// static layer of the layered runtime of the default Bootstrap configuration.
+	// If the Bootstrap is replaced using the Bootstrap field, the runtime flags must be set there instead.
+	//
+	// +optional
+	RuntimeFlags *ProxyRuntimeFlags `json:"runtimeFlags,omitempty"`
+
+	// RuntimeDiscovery defines the runtime keys delivered to the managed proxies with the
+	// Runtime Discovery Service (RTDS), in a runtime layer above the static layer of the
+	// runtime flags. The keys are updated on the proxies without restarting them.
+	// Enabling it adds the RTDS layer to the default Bootstrap configuration, which rolls
+	// the proxies out once.
+	//
+	// +optional
+	RuntimeDiscovery *ProxyRuntimeDiscovery `json:"runtimeDiscovery,omitempty"`
+
+	// IPFamily specifies the IP family of the listeners of the managed proxies, of their
+	// Kubernetes Service, and of the endpoints of the backends they route to.
+	// Defaults to the primary IP family of the cluster, or IPv4 if it is not detected.
+	//
+	// +optional
+	IPFamily *IPFamily `json:"ipFamily,omitempty"`
+
+	// ListenerUnixSocket binds the HTTP, HTTPS, TLS and TCP listeners of the managed proxies to
+	// unix domain sockets instead of IP addresses, for sidecar-style deployments where the
+	// proxies are reached by other containers of the same pod.
+	// UDP listeners and HTTP/3 are not supported on unix domain sockets and keep binding to
+	// IP addresses.
+	//
+	// +optional
+	ListenerUnixSocket *ListenerUnixSocket `json:"listenerUnixSocket,omitempty"`
+
+	// ListenerTuning tunes how the HTTP, HTTPS, TLS and TCP listeners of the managed proxies
+	// accept the client connections and balance them over the worker threads, for the
+	// latency-sensitive workloads mixing long and short lived connections. The first tuning
+	// whose ports include the port of a Gateway listener applies to it.
+	// If unspecified, the listeners use the defaults of Envoy.
+	//
+	// +kubebuilder:validation:MaxItems=16
+	// +optional
+	ListenerTuning []ProxyListenerTuning `json:"listenerTuning,omitempty"`
+
+	// Warming defines how the managed proxies warm the new listeners and clusters up before
+	// serving them, and whether the Programmed condition of the Gateways waits for it.
+	//
+	// +optional
+	Warming *ProxyWarming `json:"warming,omitempty"`
+
+	// DeferDrains defers the changes of the listeners that drain the connections of the
+	// managed proxies, such as changing the address of a listener, to a maintenance window.
+	// The changes applied in place, such as the changes of the routes, are not deferred.
+	// If unspecified, all the changes are applied immediately.
+	//
+	// +optional
+	DeferDrains *ProxyDeferDrains `json:"deferDrains,omitempty"`
+
+	// LoadReporting enables the managed proxies to report the load of the upstream
+	// endpoints to Envoy Gateway with the Load Reporting Service (LRS), and defines
+	// how the reported load is used.
+	//
+	// +optional
+	LoadReporting *ProxyLoadReporting `json:"loadReporting,omitempty"`
+
+	// XdsCompression enables the compression of the xDS responses served to the managed
+	// proxies, which reduces the bandwidth and the transfer time of large configurations
+	// at the cost of CPU. The proxies then fetch their configuration with the Google gRPC
+	// client of Envoy, which supports compression.
+	// If unspecified, the xDS responses are not compressed.
+	//
+	// +optional
+	XdsCompression *ProxyXdsCompression `json:"xdsCompression,omitempty"`
+
+	// TrafficRecording enables the managed proxies to send a sample of the requests they
+	// receive to Envoy Gateway with the Access Log Service (ALS). The recorded requests can
+	// be replayed against a shadow backend with the admin API, to load test a new version
+	// with production-shaped traffic.
+	//
+	// +optional
+	TrafficRecording *ProxyTrafficRecording `json:"trafficRecording,omitempty"`
+
+	// Preview exposes the HTTPRoutes annotated with gateway.envoyproxy.io/preview on a
+	// preview listener of their Gateways, with a temporary hostname, for review apps.
+	//
+	// +optional
+	Preview *ProxyPreview `json:"preview,omitempty"`
+
+	// RouteMatcherTree matches the requests of the virtual hosts with many routes with a
+	// matcher tree indexed by the first segment of their path and their method, instead of
+	// matching the routes one after the other, to reduce the cost of matching a request
+	// with tens of thousands of routes. The routes are matched linearly if unset.
+	//
+	// +optional
+	RouteMatcherTree *ProxyRouteMatcherTree `json:"routeMatcherTree,omitempty"`
+
+	// RouteConflictResolution defines how the conflicts between the routes of different
+	// HTTPRoutes and GRPCRoutes claiming the same hostname and path on a listener are resolved.
+	// Set on the EnvoyProxy of a GatewayClass, it applies to all the Gateways of the class.
+	// If unset, the rules of the conflicting routes are all kept, in the order of the
+	// precedence of their matches.
+	//
+	// +optional
+	RouteConflictResolution *RouteConflictResolution `json:"routeConflictResolution,omitempty"`
+
+	// LocalityEndpointDiscovery delivers the endpoints of the clusters with many endpoints
+	// with the Locality Endpoint Discovery Service (LEDS), one resource per endpoint, so
+	// that a change of some endpoints only sends these endpoints to the proxies instead of
+	// all the endpoints of their cluster. The endpoints are delivered with the cluster load
+	// assignments if unset.
+	//
+	// +optional
+	LocalityEndpointDiscovery *ProxyLocalityEndpointDiscovery `json:"localityEndpointDiscovery,omitempty"`
+
+	// VirtualHostDiscovery delivers the virtual hosts of the route configurations with many
+	// virtual hosts on demand with the Virtual Host Discovery Service (VHDS): the proxies
+	// request the virtual host of the hostname of a request the first time they serve it,
+	// instead of receiving all the virtual hosts of their route configurations. The virtual
+	// hosts are delivered with the route configurations if unset.
+	//
+	// +optional
+	VirtualHostDiscovery *ProxyVirtualHostDiscovery `json:"virtualHostDiscovery,omitempty"`
+
+	// ExtensionConfigDiscovery delivers the configurations of the selected HTTP filters of
+	// the managed proxies with the Extension Config Discovery Service (ECDS), so that a change
+	// of the configuration of a filter updates the filter without draining the listeners
+	// serving it. The filter configurations are delivered with the listeners if unset.
+	//
+	// +optional
+	ExtensionConfigDiscovery *ProxyExtensionConfigDiscovery `json:"extensionConfigDiscovery,omitempty"`
+
+	// ConfigCheckpoint checkpoints the last configuration acknowledged by the managed proxies
+	// to a ConfigMap mounted into the shutdown manager sidecar of their pods, which serves it
+	// as a fallback xDS server, so that the proxies started while Envoy Gateway is unreachable
+	// serve traffic with the checkpointed configuration until they reach it. The TLS secrets
+	// are not checkpointed.
+	// The proxies start with no configuration until they reach Envoy Gateway if unset.
+	//
+	// +optional
+	ConfigCheckpoint *ProxyConfigCheckpoint `json:"configCheckpoint,omitempty"`
+
+	// NodeGroup is the group of the managed proxies, which only serve the routes annotated
+	// with gateway.envoyproxy.io/node-groups if they list the group, so that several pools of
+	// proxies of a Gateway serve different subsets of its routes. The group is set as the
+	// gateway.envoyproxy.io/node-group label of the proxy pods, and propagated from the label
+	// to the node metadata of the proxies, so that the pods of an additional pool only need
+	// a different label.
+	// If unspecified, the proxies only serve the routes not annotated with node groups.
+	//
+	// +kubebuilder:validation:MaxLength=63
+	// +kubebuilder:validation:Pattern=`^[A-Za-z0-9]([-A-Za-z0-9_.]*[A-Za-z0-9])?$`
+	// +optional
+	NodeGroup *string `json:"nodeGroup,omitempty"`
+
+	// Admin defines how the Envoy admin interface of the managed proxies is exposed, and
+	// which of its endpoints Envoy Gateway proxies for egctl.
+	// If unspecified, the admin interface listens on localhost.
+	//
+	// +optional
+	Admin *ProxyAdmin `json:"admin,omitempty"`
+
 	// RoutingType can be set to "Service" to use the Service Cluster IP for routing to the backend,
 	// or it can be set to "Endpoint" to use Endpoint routing. The default is "Endpoint".
 	// +optional
@@ -136,6 +295,439 @@ type EnvoyProxySpec struct {
 	BackendTLS *BackendTLSConfig `json:"backendTLS,omitempty"`
 }
 
+// IPFamily defines the IP family of the listeners of the managed proxies.
+//
+// +kubebuilder:validation:Enum=IPv4;IPv6;DualStack
+type IPFamily string
+
+const (
+	// IPv4 binds the listeners to the IPv4 wildcard address.
+	IPv4 IPFamily = "IPv4"
+	// IPv6 binds the listeners to the IPv6 wildcard address.
+	IPv6 IPFamily = "IPv6"
+	// DualStack binds the listeners to the IPv6 wildcard address, and also accepts
+	// the IPv4 connections as IPv4-mapped IPv6 addresses.
+	DualStack IPFamily = "DualStack"
+)
+
+// ProxyAdminExposure defines how the Envoy admin interface is exposed.
+//
+// +kubebuilder:validation:Enum=Localhost;UnixSocket
+type ProxyAdminExposure string
+
+const (
+	// ProxyAdminExposureLocalhost exposes the admin interface on the localhost port 19000.
+	// It's only reachable from within the pod, or through port forwarding, which is
+	// authenticated and authorized by the Kubernetes API server.
+	ProxyAdminExposureLocalhost ProxyAdminExposure = "Localhost"
+	// ProxyAdminExposureUnixSocket exposes the admin interface on a unix domain socket
+	// shared with the shutdown manager container. It's only reachable from within the pod,
+	// so neither port forwarding nor Envoy Gateway can reach it.
+	ProxyAdminExposureUnixSocket ProxyAdminExposure = "UnixSocket"
+)
+
+// ListenerUnixSocket defines the unix domain sockets the listeners of the managed proxies bind to.
+type ListenerUnixSocket struct {
+	// Directory is the directory holding the unix domain sockets of the listeners, which is
+	// mounted as an emptyDir volume in the Envoy container.
+	// Each listener binds to the socket <directory>/<port>.sock, where port is the port
+	// of the Gateway listener.
+	//
+	// +kubebuilder:validation:MaxLength=96
+	// +kubebuilder:validation:Pattern=`^/[A-Za-z0-9._/-]*[A-Za-z0-9._-]$`
+	Directory string `json:"directory"`
+}
+
+// LoadReportingMode defines how the load reported by the proxies is used.
+// +kubebuilder:validation:Enum=Report;Balance
+type LoadReportingMode string
+
+const (
+	// LoadReportingModeReport only publishes the reported load as metrics.
+	LoadReportingModeReport LoadReportingMode = "Report"
+	// LoadReportingModeBalance publishes the reported load as metrics, and adjusts
+	// the load balancing weights of the endpoints based on it.
+	LoadReportingModeBalance LoadReportingMode = "Balance"
+)
+
+// XdsCompressionType defines the compression algorithm of the xDS responses.
+// +kubebuilder:validation:Enum=Gzip
+type XdsCompressionType string
+
+const (
+	// XdsCompressionTypeGzip compresses the xDS responses with gzip.
+	XdsCompressionTypeGzip XdsCompressionType = "Gzip"
+)
+
+// ProxyXdsCompression defines the compression of the xDS responses served to the managed proxies.
+type ProxyXdsCompression struct {
+	// Type is the compression algorithm of the xDS responses. Only Gzip is supported,
+	// since it's the only algorithm supported by both Envoy and the xDS server.
+	//
+	// +kubebuilder:default=Gzip
+	// +optional
+	Type XdsCompressionType `json:"type,omitempty"`
+}
+
+// ProxyLoadReporting defines the settings of the load reports of the managed proxies.
+type ProxyLoadReporting struct {
+	// Mode defines how the reported load is used.
+	// In the Report mode, the load of the endpoints is published as metrics.
+	// In the Balance mode, the load balancing weights of the endpoints of a backend
+	// are also adjusted inversely to their load relative to the other endpoints, so
+	// that the less loaded endpoints receive more requests. This improves the balance
+	// across heterogeneous endpoints, and requires a load balancer honoring the
+	// endpoint weights, such as RoundRobin or LeastRequest.
+	// Defaults to Report.
+	//
+	// +optional
+	Mode *LoadReportingMode `json:"mode,omitempty"`
+
+	// Interval defines the interval at which the proxies report the load.
+	// Defaults to 10s.
+	//
+	// +optional
+	Interval *gwapiv1.Duration `json:"interval,omitempty"`
+
+	// Metric defines the name of the custom metric reported by the endpoints with
+	// ORCA (Open Request Cost Aggregation) load reports that is used as their load,
+	// e.g. cpu_utilization or named_metrics.queue_size.
+	// If unset, the number of requests in progress is used as the load.
+	//
+	// +optional
+	// +kubebuilder:validation:MinLength=1
+	Metric *string `json:"metric,omitempty"`
+}
+
+// ProxyTrafficRecording defines the recording of the requests of the managed proxies.
+type ProxyTrafficRecording struct {
+	// SamplingPercentage is the percentage of the requests that are recorded.
+	// Defaults to 1.
+	//
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=100
+	SamplingPercentage *uint32 `json:"samplingPercentage,omitempty"`
+
+	// MaxRequests is the number of the most recent requests Envoy Gateway keeps for each
+	// Gateway, or for all the Gateways if they are merged. The older requests are dropped.
+	// Defaults to 10000.
+	//
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=1000000
+	MaxRequests *uint32 `json:"maxRequests,omitempty"`
+
+	// Headers are the names of the request headers that are recorded, and replayed along with
+	// the requests. The other headers are neither recorded nor replayed, so that credentials
+	// aren't recorded unless explicitly listed.
+	//
+	// +optional
+	// +kubebuilder:validation:MaxItems=32
+	Headers []string `json:"headers,omitempty"`
+}
+
+// ProxyPreview defines the preview listener the annotated HTTPRoutes are exposed on.
+type ProxyPreview struct {
+	// Listener is the name of the listener of the Gateways the annotated HTTPRoutes are
+	// exposed on. The listener must accept the preview hostnames, e.g. with a wildcard
+	// hostname of the HostnameSuffix.
+	Listener gwapiv1.SectionName `json:"listener"`
+
+	// HostnameSuffix is the suffix of the preview hostnames. An HTTPRoute annotated with
+	// gateway.envoyproxy.io/preview: pr-123 is exposed on the pr-123.<HostnameSuffix>
+	// hostname.
+	HostnameSuffix gwapiv1.PreciseHostname `json:"hostnameSuffix"`
+
+	// TTL is how long the HTTPRoutes are exposed on the preview listener after their
+	// creation. Defaults to 72h.
+	//
+	// +optional
+	TTL *gwapiv1.Duration `json:"ttl,omitempty"`
+}
+
+// ProxyRouteMatcherTree defines which virtual hosts match their routes with a matcher tree.
+type ProxyRouteMatcherTree struct {
+	// MinRoutes is the number of routes of a virtual host from which its routes are
+	// matched with a matcher tree. Defaults to 1000.
+	//
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	MinRoutes *uint32 `json:"minRoutes,omitempty"`
+}
+
+// ProxyLocalityEndpointDiscovery defines which clusters have their endpoints delivered with LEDS.
+type ProxyLocalityEndpointDiscovery struct {
+	// MinEndpoints is the number of endpoints of a cluster from which its endpoints are
+	// delivered with LEDS. Defaults to 1000.
+	//
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	MinEndpoints *uint32 `json:"minEndpoints,omitempty"`
+}
+
+// ProxyVirtualHostDiscovery defines which route configurations have their virtual hosts
+// delivered on demand with VHDS.
+type ProxyVirtualHostDiscovery struct {
+	// MinVirtualHosts is the number of virtual hosts of a route configuration from which
+	// its virtual hosts are delivered on demand with VHDS. Defaults to 1000.
+	//
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	MinVirtualHosts *uint32 `json:"minVirtualHosts,omitempty"`
+}
+
+// ProxyExtensionConfigDiscovery defines which HTTP filters have their configurations
+// delivered with ECDS.
+type ProxyExtensionConfigDiscovery struct {
+	// Filters are the HTTP filters whose configurations are delivered with ECDS.
+	// Defaults to the envoy.filters.http.wasm and envoy.filters.http.ext_authz filters.
+	//
+	// +kubebuilder:validation:MaxItems=16
+	// +optional
+	Filters []EnvoyFilter `json:"filters,omitempty"`
+}
+
+// ProxyConfigCheckpoint defines how often the configuration of the proxies is checkpointed.
+type ProxyConfigCheckpoint struct {
+	// Interval is how often the last configuration acknowledged by the proxies is
+	// checkpointed, if it changed. Defaults to 5m.
+	//
+	// +optional
+	Interval *gwapiv1.Duration `json:"interval,omitempty"`
+}
+
+// RouteConflictStrategy is the strategy resolving the conflicts between routes.
+// +kubebuilder:validation:Enum=OldestWins;MostSpecificWins;Reject
+type RouteConflictStrategy string
+
+const (
+	// RouteConflictStrategyOldestWins keeps the rules of the oldest route claiming a
+	// hostname and path, and drops the conflicting rules of the newer routes.
+	RouteConflictStrategyOldestWins RouteConflictStrategy = "OldestWins"
+	// RouteConflictStrategyMostSpecificWins keeps the rules of all the routes claiming a
+	// hostname and path, the rules with the most specific matches taking precedence, and
+	// the rules of the oldest route among equally specific ones.
+	RouteConflictStrategyMostSpecificWins RouteConflictStrategy = "MostSpecificWins"
+	// RouteConflictStrategyReject rejects the newer routes claiming a hostname and path
+	// already claimed by an older route on the listeners they conflict on.
+	RouteConflictStrategyReject RouteConflictStrategy = "Reject"
+)
+
+// RouteConflictResolution defines how the conflicts between routes are resolved.
+type RouteConflictResolution struct {
+	// Strategy is the strategy resolving the conflicts. The age of the routes is their
+	// creation time, then the alphabetical order of their namespace and name.
+	Strategy RouteConflictStrategy `json:"strategy"`
+}
+
+// ProxyWarming defines how the managed proxies warm the new listeners and clusters up.
+type ProxyWarming struct {
+	// InitialFetchTimeout is the maximum time the new listeners wait for their routes, and the new
+	// clusters wait for their endpoints, before they are considered warm and start serving.
+	// Setting it to 0s makes them wait indefinitely for their configuration.
+	// Defaults to 15s.
+	//
+	// +optional
+	InitialFetchTimeout *gwapiv1.Duration `json:"initialFetchTimeout,omitempty"`
+
+	// WaitForListenerAck makes the Programmed condition of the Gateways only become true once
+	// all the managed proxies have acknowledged the listeners of the Gateways, instead of as
+	// soon as the proxies are available. This prevents pointing DNS records to the Gateways
+	// before their new listeners are served.
+	// Defaults to false.
+	//
+	// +optional
+	WaitForListenerAck *bool `json:"waitForListenerAck,omitempty"`
+}
+
+// ConnectionBalanceType defines how a listener balances the accepted connections over the
+// worker threads.
+// +kubebuilder:validation:Enum=Exact
+type ConnectionBalanceType string
+
+const (
+	// ConnectionBalanceTypeExact hands each accepted connection to the worker thread with
+	// the fewest active connections, holding a lock while accepting the connections.
+	ConnectionBalanceTypeExact ConnectionBalanceType = "Exact"
+)
+
+// ProxyListenerTuning defines how the listeners on some ports of the managed proxies accept
+// the client connections and balance them over the worker threads.
+type ProxyListenerTuning struct {
+	// Ports are the ports of the Gateway listeners tuned.
+	// If unspecified, all the listeners are tuned.
+	//
+	// +kubebuilder:validation:MaxItems=64
+	// +optional
+	Ports []gwapiv1.PortNumber `json:"ports,omitempty"`
+
+	// ConnectionBalance defines how the listeners balance the accepted connections over the
+	// worker threads. Exact balancing keeps the long lived connections from piling up on some
+	// worker threads, at the cost of the throughput of accepting the connections.
+	// If unspecified, each connection is served by the worker thread accepting it.
+	//
+	// +optional
+	ConnectionBalance *ConnectionBalanceType `json:"connectionBalance,omitempty"`
+
+	// MaxConnectionsToAcceptPerSocketEvent is the maximum number of connections a worker thread
+	// accepts at once, before serving the connections it already accepted. Lower values spread
+	// the bursts of new connections over the worker threads.
+	// If unspecified, the default of Envoy applies.
+	//
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	MaxConnectionsToAcceptPerSocketEvent *uint32 `json:"maxConnectionsToAcceptPerSocketEvent,omitempty"`
+
+	// ListenerFiltersTimeout is the maximum time the listener filters, such as the TLS
+	// inspector and the PROXY protocol filter, wait for the first bytes of a connection before
+	// its filter chain is selected. Setting it to 0s makes them wait indefinitely.
+	// Defaults to 15s.
+	//
+	// +optional
+	ListenerFiltersTimeout *gwapiv1.Duration `json:"listenerFiltersTimeout,omitempty"`
+
+	// ContinueOnListenerFiltersTimeout selects the filter chain of the connections whose
+	// listener filters time out with what the filters inspected, instead of closing them.
+	// Defaults to false.
+	//
+	// +optional
+	ContinueOnListenerFiltersTimeout *bool `json:"continueOnListenerFiltersTimeout,omitempty"`
+}
+
+// DrainScope defines the changes of the listeners whose draining is deferred.
+// +kubebuilder:validation:Enum=Listener;FilterChain
+type DrainScope string
+
+const (
+	// DrainScopeListener defers the changes draining all the connections of a listener,
+	// such as changing its address, socket options or listener filters, or removing it.
+	DrainScopeListener DrainScope = "Listener"
+	// DrainScopeFilterChain also defers the changes draining the connections of a filter
+	// chain of a listener, such as changing its HTTP filters or TLS settings.
+	DrainScopeFilterChain DrainScope = "FilterChain"
+)
+
+// ProxyDeferDrains defines the deferral of the changes of the listeners that drain the
+// connections of the managed proxies.
+type ProxyDeferDrains struct {
+	// Scope defines the changes that are deferred. Defaults to Listener.
+	//
+	// +optional
+	Scope *DrainScope `json:"scope,omitempty"`
+
+	// MaintenanceWindow is the window the deferred changes are applied during.
+	MaintenanceWindow MaintenanceWindow `json:"maintenanceWindow"`
+}
+
+// Weekday is a day of the week.
+// +kubebuilder:validation:Enum=Monday;Tuesday;Wednesday;Thursday;Friday;Saturday;Sunday
+type Weekday string
+
+// MaintenanceWindow defines a recurring window of time.
+type MaintenanceWindow struct {
+	// Start is the time of the day the window opens at, in the HH:MM format, in UTC.
+	//
+	// +kubebuilder:validation:Pattern=`^([01][0-9]|2[0-3]):[0-5][0-9]$`
+	Start string `json:"start"`
+
+	// Duration is how long the window stays open.
+	Duration gwapiv1.Duration `json:"duration"`
+
+	// Days are the days of the week the window opens on. Defaults to every day.
+	//
+	// +optional
+	// +kubebuilder:validation:MaxItems=7
+	Days []Weekday `json:"days,omitempty"`
+}
+
+// ProxyAdmin defines the exposure of the Envoy admin interface.
+type ProxyAdmin struct {
+	// Exposure defines how the admin interface is exposed. Defaults to Localhost.
+	//
+	// +optional
+	Exposure *ProxyAdminExposure `json:"exposure,omitempty"`
+
+	// AllowedPaths defines the admin endpoints that Envoy Gateway proxies through its admin API,
+	// which lets egctl retrieve them without port forwarding to the proxies. Only GET requests are
+	// proxied, and only when the admin interface is exposed on localhost.
+	// Defaults to the read-only endpoints /config_dump, /clusters, /listeners, /server_info, /ready,
+	// /stats and /stats/prometheus.
+	//
+	// +optional
+	// +kubebuilder:validation:MaxItems=32
+	// +kubebuilder:validation:items:Pattern=`^/[a-z_/]*$`
+	AllowedPaths []string `json:"allowedPaths,omitempty"`
+}
+
+// RuntimeFlag defines an Envoy runtime flag that can be set on the managed proxies.
+// Visit https://www.envoyproxy.io/docs/envoy/latest/configuration/operations/runtime
+// to learn more about the runtime flags.
+//
+// +kubebuilder:validation:Enum=envoy.reloadable_features.http1_use_balsa_parser;envoy.reloadable_features.http2_use_oghttp2;envoy.reloadable_features.http_reject_path_with_fragment;envoy.reloadable_features.no_extension_lookup_by_name
+type RuntimeFlag string
+
+const (
+	// RuntimeFlagHTTP1UseBalsaParser uses the Balsa parser for HTTP/1.
+	RuntimeFlagHTTP1UseBalsaParser RuntimeFlag = "envoy.reloadable_features.http1_use_balsa_parser"
+	// RuntimeFlagHTTP2UseOghttp2 uses the oghttp2 codec for HTTP/2.
+	RuntimeFlagHTTP2UseOghttp2 RuntimeFlag = "envoy.reloadable_features.http2_use_oghttp2"
+	// RuntimeFlagHTTPRejectPathWithFragment rejects requests with a fragment in the path.
+	RuntimeFlagHTTPRejectPathWithFragment RuntimeFlag = "envoy.reloadable_features.http_reject_path_with_fragment"
+	// RuntimeFlagNoExtensionLookupByName disables the lookup of extensions by name
+	// instead of by the type of their typed config.
+	RuntimeFlagNoExtensionLookupByName RuntimeFlag = "envoy.reloadable_features.no_extension_lookup_by_name"
+)
+
+// ProxyRuntimeFlags defines the Envoy runtime flags to enable or disable on the managed proxies.
+// Runtime flags that aren't listed keep the default value of the Envoy version in use.
+//
+// +kubebuilder:validation:XValidation:rule="!has(self.enabled) || !has(self.disabled) || self.enabled.all(f, !(f in self.disabled))",message="a runtime flag can't be both enabled and disabled"
+type ProxyRuntimeFlags struct {
+	// Enabled defines the runtime flags to enable.
+	//
+	// +optional
+	// +kubebuilder:validation:MaxItems=16
+	Enabled []RuntimeFlag `json:"enabled,omitempty"`
+
+	// Disabled defines the runtime flags to disable.
+	//
+	// +optional
+	// +kubebuilder:validation:MaxItems=16
+	Disabled []RuntimeFlag `json:"disabled,omitempty"`
+}
+
+// ProxyRuntimeDiscovery defines the runtime keys delivered to the managed proxies with RTDS.
+type ProxyRuntimeDiscovery struct {
+	// Values defines the runtime keys and their values. The keys that aren't listed keep
+	// the value of the lower runtime layers.
+	//
+	// +listType=map
+	// +listMapKey=key
+	// +optional
+	// +kubebuilder:validation:MaxItems=64
+	Values []ProxyRuntimeValue `json:"values,omitempty"`
+}
+
+// ProxyRuntimeValue defines the value of an Envoy runtime key.
+// Visit https://www.envoyproxy.io/docs/envoy/latest/configuration/operations/runtime
+// to learn more about the runtime keys.
+type ProxyRuntimeValue struct {
+	// Key is the runtime key, such as envoy.reloadable_features.http1_use_balsa_parser
+	// or overload.global_downstream_max_connections.
+	//
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=253
+	// +kubebuilder:validation:Pattern=`^[a-zA-Z0-9_.-]+$`
+	Key string `json:"key"`
+
+	// Value is the value of the runtime key. The values true and false are delivered as
+	// booleans, the numbers as numbers, and the other values as strings.
+	//
+	// +kubebuilder:validation:MinLength=1
+	Value string `json:"value"`
+}
+
 // RoutingType defines the type of routing of this Envoy proxy.
 type RoutingType string
 
@@ -174,13 +766,19 @@ type FilterPosition struct {
 }
 
 // EnvoyFilter defines the type of Envoy HTTP filter.
-// +kubebuilder:validation:Enum=envoy.filters.http.health_check;envoy.filters.http.fault;envoy.filters.http.cors;envoy.filters.http.ext_authz;envoy.filters.http.basic_auth;envoy.filters.http.oauth2;envoy.filters.http.jwt_authn;envoy.filters.http.stateful_session;envoy.filters.http.ext_proc;envoy.filters.http.wasm;envoy.filters.http.rbac;envoy.filters.http.local_ratelimit;envoy.filters.http.ratelimit
+// +kubebuilder:validation:Enum=envoy.filters.http.health_check;envoy.filters.http.header_to_metadata;envoy.filters.http.lua;envoy.filters.http.fault;envoy.filters.http.cors;envoy.filters.http.ext_authz;envoy.filters.http.basic_auth;envoy.filters.http.oauth2;envoy.filters.http.jwt_authn;envoy.filters.http.stateful_session;envoy.filters.http.ext_proc;envoy.filters.http.wasm;envoy.filters.http.rbac;envoy.filters.http.local_ratelimit;envoy.filters.http.ratelimit;envoy.filters.http.credential_injector;envoy.filters.http.aws_request_signing;envoy.filters.http.custom_response;envoy.filters.http.file_system_buffer
 type EnvoyFilter string
 
 const (
 	// EnvoyFilterHealthCheck defines the Envoy HTTP health check filter.
 	EnvoyFilterHealthCheck EnvoyFilter = "envoy.filters.http.health_check"
 
+	// EnvoyFilterHeaderToMetadata defines the Envoy HTTP header to metadata filter.
+	EnvoyFilterHeaderToMetadata EnvoyFilter = "envoy.filters.http.header_to_metadata"
+
+	// EnvoyFilterLua defines the Envoy HTTP Lua filter, which normalizes the requests.
+	EnvoyFilterLua EnvoyFilter = "envoy.filters.http.lua"
+
 	// EnvoyFilterFault defines the Envoy HTTP fault filter.
 	EnvoyFilterFault EnvoyFilter = "envoy.filters.http.fault"
 
@@ -217,6 +815,18 @@ const (
 	// EnvoyFilterRateLimit defines the Envoy HTTP rate limit filter.
 	EnvoyFilterRateLimit EnvoyFilter = "envoy.filters.http.ratelimit"
 
+	// EnvoyFilterCredentialInjector defines the Envoy HTTP credential injector filter.
+	EnvoyFilterCredentialInjector EnvoyFilter = "envoy.filters.http.credential_injector"
+
+	// EnvoyFilterAWSRequestSigning defines the Envoy HTTP AWS request signing filter.
+	EnvoyFilterAWSRequestSigning EnvoyFilter = "envoy.filters.http.aws_request_signing"
+
+	// EnvoyFilterCustomResponse defines the Envoy HTTP custom response filter.
+	EnvoyFilterCustomResponse EnvoyFilter = "envoy.filters.http.custom_response"
+
+	// EnvoyFilterFileSystemBuffer defines the Envoy HTTP file system buffer filter.
+	EnvoyFilterFileSystemBuffer EnvoyFilter = "envoy.filters.http.file_system_buffer"
+
 	// EnvoyFilterRouter defines the Envoy HTTP router filter.
 	EnvoyFilterRouter EnvoyFilter = "envoy.filters.http.router"
 )
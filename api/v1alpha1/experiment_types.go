@@ -0,0 +1,76 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package v1alpha1
+
+import (
+	gwapiv1 "sigs.k8s.io/gateway-api/apis/v1"
+)
+
+// Experiment defines an A/B experiment assigning the requests of the clients to buckets,
+// each routed to its own backends.
+//
+// A client without the experiment cookie is assigned a bucket according to the percentages
+// of the buckets, and the cookie holding the name of its bucket is set on the response, so
+// that its subsequent requests are routed to the same bucket.
+//
+// +kubebuilder:validation:XValidation:rule="self.buckets.all(b, self.buckets.exists_one(c, c.name == b.name))",message="the names of the buckets must be unique"
+// +kubebuilder:validation:XValidation:rule="!has(self.salt) || has(self.header)",message="salt can only be set along with header"
+type Experiment struct {
+	// Cookie is the name of the cookie holding the bucket the client is assigned to.
+	//
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:Pattern=`^[!#$%&'*+\-.^_|~0-9A-Za-z]+$`
+	Cookie string `json:"cookie"`
+
+	// CookieTTL is how long the client keeps its bucket. The cookie lasts for the browser
+	// session if unset.
+	//
+	// +optional
+	CookieTTL *gwapiv1.Duration `json:"cookieTTL,omitempty"`
+
+	// Header is the name of the request header holding the numeric identifier the clients
+	// are assigned a bucket by, such as a user ID, so that a client keeps its bucket across
+	// devices. The clients without a numeric value in the header are randomly assigned a bucket.
+	// All the clients are randomly assigned a bucket if unset.
+	//
+	// +optional
+	// +kubebuilder:validation:MinLength=1
+	Header *string `json:"header,omitempty"`
+
+	// Salt shifts the boundaries of the buckets the identifiers of the header are hashed into,
+	// so that each experiment assigns the same identifiers to different buckets.
+	//
+	// +optional
+	Salt *string `json:"salt,omitempty"`
+
+	// Buckets are the buckets of the experiment. Their percentages must add up to 100.
+	//
+	// +kubebuilder:validation:MinItems=2
+	// +kubebuilder:validation:MaxItems=16
+	Buckets []ExperimentBucket `json:"buckets"`
+}
+
+// ExperimentBucket defines a bucket of an experiment.
+type ExperimentBucket struct {
+	// Name is the name of the bucket, which is the value of the experiment cookie.
+	//
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=64
+	// +kubebuilder:validation:Pattern=`^[A-Za-z0-9_-]+$`
+	Name string `json:"name"`
+
+	// Percentage is the percentage of the clients assigned to the bucket.
+	//
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=100
+	Percentage uint32 `json:"percentage"`
+
+	// BackendRefs references the backends the requests of the bucket are routed to.
+	//
+	// +kubebuilder:validation:MinItems=1
+	// +kubebuilder:validation:MaxItems=16
+	BackendRefs []BackendRef `json:"backendRefs"`
+}
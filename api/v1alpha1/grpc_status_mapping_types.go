@@ -0,0 +1,62 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package v1alpha1
+
+// GRPCStatusMapping defines the mapping of the statuses of the responses of the backends
+// between gRPC and HTTP.
+//
+// +kubebuilder:validation:XValidation:rule="has(self.toHTTP) || has(self.toGRPC)",message="at least one of toHTTP or toGRPC must be specified"
+type GRPCStatusMapping struct {
+	// ToHTTP maps the gRPC statuses of the error responses of gRPC backends to HTTP
+	// statuses, for the HTTP clients of a backend migrated to gRPC. Only the gRPC status
+	// of trailers-only responses, which gRPC servers send on errors, is matched.
+	//
+	// +optional
+	// +kubebuilder:validation:MinItems=1
+	// +kubebuilder:validation:MaxItems=17
+	ToHTTP []GRPCToHTTPStatus `json:"toHTTP,omitempty"`
+
+	// ToGRPC maps the HTTP statuses of the responses of HTTP backends to gRPC statuses,
+	// for the gRPC clients of a backend migrated from gRPC. The responses matched are
+	// replaced with trailers-only gRPC responses carrying the gRPC status.
+	//
+	// +optional
+	// +kubebuilder:validation:MinItems=1
+	// +kubebuilder:validation:MaxItems=16
+	ToGRPC []HTTPToGRPCStatus `json:"toGRPC,omitempty"`
+}
+
+// GRPCToHTTPStatus defines the HTTP status a gRPC status is mapped to.
+type GRPCToHTTPStatus struct {
+	// GRPCStatus is the gRPC status code matched, from 0 (OK) to 16 (UNAUTHENTICATED).
+	//
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=16
+	GRPCStatus uint32 `json:"grpcStatus"`
+
+	// HTTPStatus is the status code of the response.
+	//
+	// +kubebuilder:validation:Minimum=200
+	// +kubebuilder:validation:Maximum=599
+	HTTPStatus int `json:"httpStatus"`
+}
+
+// HTTPToGRPCStatus defines the gRPC status HTTP statuses are mapped to.
+type HTTPToGRPCStatus struct {
+	// HTTPStatus is the status code matched.
+	HTTPStatus StatusCodeMatch `json:"httpStatus"`
+
+	// GRPCStatus is the gRPC status code of the response, from 0 (OK) to 16 (UNAUTHENTICATED).
+	//
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=16
+	GRPCStatus uint32 `json:"grpcStatus"`
+
+	// Message is the gRPC status message of the response.
+	//
+	// +optional
+	Message *string `json:"message,omitempty"`
+}
@@ -0,0 +1,119 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package v1alpha1
+
+import (
+	gwapiv1 "sigs.k8s.io/gateway-api/apis/v1"
+)
+
+// HeaderToMetadataNamespace is the dynamic metadata namespace of the values set from the
+// request headers when no namespace is specified.
+const HeaderToMetadataNamespace = "envoy.filters.http.header_to_metadata"
+
+// HeaderToMetadata defines the request headers set as dynamic metadata of the requests,
+// which the filters processing the requests afterwards, such as the rate limit, RBAC and
+// access log, can refer to, and the dynamic metadata set as headers of the responses.
+//
+// +kubebuilder:validation:XValidation:rule="has(self.requestHeaders) || has(self.responseHeaders)",message="at least one of requestHeaders or responseHeaders must be specified"
+type HeaderToMetadata struct {
+	// RequestHeaders defines the request headers set as dynamic metadata.
+	//
+	// +optional
+	// +kubebuilder:validation:MinItems=1
+	// +kubebuilder:validation:MaxItems=16
+	RequestHeaders []RequestHeaderToMetadata `json:"requestHeaders,omitempty"`
+
+	// ResponseHeaders defines the dynamic metadata set as headers of the responses, for
+	// example to debug the values set from the request headers. A header is not set if the
+	// request has no value for its metadata.
+	//
+	// +optional
+	// +kubebuilder:validation:MinItems=1
+	// +kubebuilder:validation:MaxItems=16
+	ResponseHeaders []MetadataToResponseHeader `json:"responseHeaders,omitempty"`
+}
+
+// RequestHeaderToMetadata defines the dynamic metadata set from a request header.
+//
+// +kubebuilder:validation:XValidation:rule="has(self.onPresent) || has(self.onMissing)",message="at least one of onPresent or onMissing must be specified"
+// +kubebuilder:validation:XValidation:rule="!has(self.onMissing) || has(self.onMissing.value)",message="onMissing.value must be specified"
+type RequestHeaderToMetadata struct {
+	// Header is the name of the request header.
+	Header gwapiv1.HeaderName `json:"header"`
+
+	// OnPresent defines the metadata set when the header is present.
+	//
+	// +optional
+	OnPresent *MetadataValue `json:"onPresent,omitempty"`
+
+	// OnMissing defines the metadata set when the header is missing.
+	//
+	// +optional
+	OnMissing *MetadataValue `json:"onMissing,omitempty"`
+
+	// Remove removes the header from the request once the metadata is set.
+	// Defaults to false.
+	//
+	// +optional
+	Remove *bool `json:"remove,omitempty"`
+}
+
+// MetadataValueType is the type of a dynamic metadata value.
+//
+// +kubebuilder:validation:Enum=String;Number
+type MetadataValueType string
+
+const (
+	// MetadataValueTypeString sets the value as a string.
+	MetadataValueTypeString MetadataValueType = "String"
+	// MetadataValueTypeNumber sets the value as a number, the requests whose value is not
+	// a number not being set the metadata.
+	MetadataValueTypeNumber MetadataValueType = "Number"
+)
+
+// MetadataValue defines a dynamic metadata value of the requests.
+type MetadataValue struct {
+	// Namespace is the namespace of the metadata.
+	// Defaults to envoy.filters.http.header_to_metadata.
+	//
+	// +optional
+	// +kubebuilder:validation:MinLength=1
+	Namespace *string `json:"namespace,omitempty"`
+
+	// Key is the key of the metadata.
+	//
+	// +kubebuilder:validation:MinLength=1
+	Key string `json:"key"`
+
+	// Value is the value of the metadata. The value of the header is set if unset.
+	//
+	// +optional
+	// +kubebuilder:validation:MinLength=1
+	Value *string `json:"value,omitempty"`
+
+	// Type is the type of the value. Defaults to String.
+	//
+	// +optional
+	Type *MetadataValueType `json:"type,omitempty"`
+}
+
+// MetadataToResponseHeader defines a response header set from the dynamic metadata.
+type MetadataToResponseHeader struct {
+	// Name is the name of the response header.
+	Name gwapiv1.HeaderName `json:"name"`
+
+	// Namespace is the namespace of the metadata.
+	// Defaults to envoy.filters.http.header_to_metadata.
+	//
+	// +optional
+	// +kubebuilder:validation:MinLength=1
+	Namespace *string `json:"namespace,omitempty"`
+
+	// Key is the key of the metadata.
+	//
+	// +kubebuilder:validation:MinLength=1
+	Key string `json:"key"`
+}
@@ -33,6 +33,10 @@ type HTTPRouteFilter struct {
 type HTTPRouteFilterSpec struct {
 	// +optional
 	URLRewrite *HTTPURLRewriteFilter `json:"urlRewrite,omitempty"`
+	// +optional
+	Normalization *HTTPNormalizationFilter `json:"normalization,omitempty"`
+	// +optional
+	QueryParamMatch *HTTPQueryParamMatchFilter `json:"queryParamMatch,omitempty"`
 }
 
 // HTTPURLRewriteFilter define rewrites of HTTP URL components such as path and host
@@ -121,6 +125,98 @@ type HTTPHostnameModifier struct {
 	SetFromHeader *string `json:"setFromHeader,omitempty"`
 }
 
+// HTTPNormalizationFilter defines the normalization of the requests, for example to remove
+// the tracking query parameters and to sort the query parameters so that the equivalent
+// requests share the same cache key, or to handle the trailing slash of the path.
+//
+// +kubebuilder:validation:XValidation:rule="has(self.query) || has(self.trailingSlash)",message="at least one of query or trailingSlash must be specified"
+type HTTPNormalizationFilter struct {
+	// Query defines the normalization of the query parameters.
+	//
+	// +optional
+	Query *HTTPQueryNormalization `json:"query,omitempty"`
+
+	// TrailingSlash defines the handling of the trailing slash of the path.
+	//
+	// +optional
+	TrailingSlash *HTTPTrailingSlashNormalization `json:"trailingSlash,omitempty"`
+}
+
+// HTTPQueryNormalization defines the normalization of the query parameters.
+//
+// +kubebuilder:validation:XValidation:rule="has(self.removeParameters) || (has(self.sort) && self.sort)",message="at least one of removeParameters or sort must be specified"
+type HTTPQueryNormalization struct {
+	// RemoveParameters are the names of the query parameters removed from the requests,
+	// for example utm_source. The names are compared with the names of the parameters of
+	// the requests without decoding them.
+	//
+	// +optional
+	// +kubebuilder:validation:MinItems=1
+	// +kubebuilder:validation:MaxItems=32
+	// +kubebuilder:validation:items:Pattern=`^[^&=#?\s]+$`
+	RemoveParameters []string `json:"removeParameters,omitempty"`
+
+	// Sort sorts the query parameters by name, keeping the order of the values of each
+	// parameter. Defaults to false.
+	//
+	// +optional
+	Sort *bool `json:"sort,omitempty"`
+}
+
+// TrailingSlashAction defines how the trailing slash of the path is normalized.
+//
+// +kubebuilder:validation:Enum=Add;Remove
+type TrailingSlashAction string
+
+const (
+	// TrailingSlashActionAdd adds a trailing slash to the paths without one.
+	TrailingSlashActionAdd TrailingSlashAction = "Add"
+	// TrailingSlashActionRemove removes the trailing slashes of the paths other than /.
+	TrailingSlashActionRemove TrailingSlashAction = "Remove"
+)
+
+// HTTPTrailingSlashNormalization defines the handling of the trailing slash of the path.
+type HTTPTrailingSlashNormalization struct {
+	// Action defines whether the trailing slash is added to or removed from the path.
+	Action TrailingSlashAction `json:"action"`
+
+	// RedirectStatusCode redirects the clients to the normalized path with the status code
+	// when set. Otherwise, the path of the requests forwarded to the backends is rewritten,
+	// which cannot be combined with a path rewrite of the rule.
+	//
+	// +optional
+	// +kubebuilder:validation:Enum=301;302;307;308
+	RedirectStatusCode *int `json:"redirectStatusCode,omitempty"`
+}
+
+// HTTPQueryParamMatchFilter defines the presence and absence match conditions on the
+// query parameters, which are added to each match of the rules referencing the filter.
+// All the conditions of a match must be satisfied, along with its Exact and
+// RegularExpression query parameter matches, for a request to match.
+//
+// +kubebuilder:validation:XValidation:rule="has(self.present) || has(self.absent)",message="at least one of present or absent must be specified"
+type HTTPQueryParamMatchFilter struct {
+	// Present are the names of the query parameters the requests must have, with any
+	// value. The names are compared with the names of the parameters of the requests
+	// without decoding them.
+	//
+	// +optional
+	// +kubebuilder:validation:MinItems=1
+	// +kubebuilder:validation:MaxItems=16
+	// +kubebuilder:validation:items:Pattern=`^[^&=#?\s]+$`
+	Present []string `json:"present,omitempty"`
+
+	// Absent are the names of the query parameters the requests must not have. The
+	// names are compared with the names of the parameters of the requests without
+	// decoding them.
+	//
+	// +optional
+	// +kubebuilder:validation:MinItems=1
+	// +kubebuilder:validation:MaxItems=16
+	// +kubebuilder:validation:items:Pattern=`^[^&=#?\s]+$`
+	Absent []string `json:"absent,omitempty"`
+}
+
 //+kubebuilder:object:root=true
 
 // HTTPRouteFilterList contains a list of HTTPRouteFilter resources.
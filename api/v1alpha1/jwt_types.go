@@ -6,6 +6,8 @@
 package v1alpha1
 
 // JWT defines the configuration for JSON Web Token (JWT) authentication.
+//
+// +kubebuilder:validation:XValidation:rule="has(self.routeMatch) ? size(self.providers) == 1 : true",message="routeMatch can only be specified with a single provider"
 type JWT struct {
 	// Optional determines whether a missing JWT is acceptable, defaulting to false if not specified.
 	// Note: Even if optional is set to true, JWT authentication will still fail if an invalid JWT is presented.
@@ -19,6 +21,44 @@ type JWT struct {
 	// +kubebuilder:validation:MinItems=1
 	// +kubebuilder:validation:MaxItems=4
 	Providers []JWTProvider `json:"providers"`
+
+	// RouteMatch restricts the targeted routes to the requests whose validated JWT
+	// carries the specified claims and scopes, so that the requests can be routed by
+	// their claims, such as their tenant, without custom headers.
+	// The requests whose JWT doesn't match are matched against the next routes, and the
+	// requests without a JWT don't match.
+	//
+	// Note: The JWT is validated by the provider of the first route matching the request
+	// regardless of its claims, so the routes matching the same requests must use the same
+	// provider.
+	//
+	// +optional
+	RouteMatch *JWTRouteMatch `json:"routeMatch,omitempty"`
+}
+
+// JWTRouteMatch defines the claims and the scopes a validated JWT must carry for
+// the request to match the route.
+// Claims and scopes are And-ed together if both are specified.
+//
+// +kubebuilder:validation:XValidation:rule="(has(self.claims) || has(self.scopes))",message="at least one of claims or scopes must be specified"
+type JWTRouteMatch struct {
+	// Claims are the claims the JWT must carry.
+	//
+	// If multiple claims are specified, all claims must match for the route to match.
+	//
+	// +optional
+	// +kubebuilder:validation:MinItems=1
+	// +kubebuilder:validation:MaxItems=16
+	Claims []JWTClaim `json:"claims,omitempty"`
+
+	// Scopes are the scopes the JWT must carry.
+	//
+	// If multiple scopes are specified, all scopes must match for the route to match.
+	//
+	// +optional
+	// +kubebuilder:validation:MinItems=1
+	// +kubebuilder:validation:MaxItems=16
+	Scopes []JWTScope `json:"scopes,omitempty"`
 }
 
 // JWTProvider defines how a JSON Web Token (JWT) can be verified.
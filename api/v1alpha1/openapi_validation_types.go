@@ -0,0 +1,49 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package v1alpha1
+
+import (
+	gwapiv1 "sigs.k8s.io/gateway-api/apis/v1"
+)
+
+// OpenAPIValidation defines the validation of the requests against an OpenAPI document.
+// The requests whose path, method, parameters or body don't conform to the document
+// are rejected before reaching the backend.
+//
+// The validation is performed by an external processing service referenced by the
+// BackendRefs. The Envoy proxy sends the request headers and the buffered request body
+// to the service, along with the gRPC metadata identifying the document and its digest.
+//
+// +kubebuilder:validation:XValidation:message="BackendRefs must be used, backendRef is not supported.",rule="!has(self.backendRef)"
+// +kubebuilder:validation:XValidation:message="BackendRefs is required.",rule="has(self.backendRefs) && self.backendRefs.size() > 0"
+// +kubebuilder:validation:XValidation:message="BackendRefs only supports Service and Backend kind.",rule="has(self.backendRefs) ? self.backendRefs.all(f, f.kind == 'Service' || f.kind == 'Backend') : true"
+// +kubebuilder:validation:XValidation:message="BackendRefs only supports Core and gateway.envoyproxy.io group.",rule="has(self.backendRefs) ? (self.backendRefs.all(f, f.group == \"\" || f.group == 'gateway.envoyproxy.io')) : true"
+type OpenAPIValidation struct {
+	// BackendCluster references the external processing service validating the requests.
+	BackendCluster `json:",inline"`
+
+	// ConfigMapRef is a reference to the ConfigMap holding the OpenAPI document,
+	// in the JSON or YAML format. Both OpenAPI v3 and Swagger v2 documents are supported.
+	// Only a reference to a ConfigMap in the namespace of the policy is supported.
+	//
+	// +kubebuilder:validation:XValidation:message="only support ConfigMap kind.",rule="self.kind == 'ConfigMap' && self.group == ''"
+	ConfigMapRef gwapiv1.LocalObjectReference `json:"configMapRef"`
+
+	// Key is the key of the ConfigMap data holding the OpenAPI document.
+	// Defaults to "openapi.yaml".
+	//
+	// +kubebuilder:validation:MinLength=1
+	// +optional
+	Key *string `json:"key,omitempty"`
+
+	// FailOpen allows the requests through when the validation can't be performed,
+	// e.g. when the external processing service can't be reached. The requests which don't conform to
+	// the document are rejected regardless.
+	// Defaults to false.
+	//
+	// +optional
+	FailOpen *bool `json:"failOpen,omitempty"`
+}
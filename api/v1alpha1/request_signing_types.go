@@ -0,0 +1,128 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package v1alpha1
+
+import gwapiv1b1 "sigs.k8s.io/gateway-api/apis/v1beta1"
+
+// RequestSigningHMACSecretKey is the key of the Secret holding the key the requests are
+// signed with by HMAC.
+const RequestSigningHMACSecretKey = "hmac-key"
+
+// RequestSigning defines how the requests forwarded to the backends are signed, so that
+// the backends requiring signed requests, such as the cloud services and the signed
+// internal APIs, can be fronted by the gateway directly.
+//
+// The requests are signed once processed by the other filters of the route, but before
+// the URL rewrites and the header modifiers of the route are applied.
+//
+// +kubebuilder:validation:XValidation:rule="self.type == 'AWSSigV4' ? has(self.awsSigV4) && !has(self.hmac) : true",message="awsSigV4 must be set, and hmac unset, for the AWSSigV4 type"
+// +kubebuilder:validation:XValidation:rule="self.type == 'HMAC' ? has(self.hmac) && !has(self.awsSigV4) : true",message="hmac must be set, and awsSigV4 unset, for the HMAC type"
+type RequestSigning struct {
+	// Type is the type of the signature of the requests.
+	//
+	// +unionDiscriminator
+	Type RequestSigningType `json:"type"`
+
+	// AWSSigV4 signs the requests with the AWS Signature Version 4.
+	//
+	// +optional
+	AWSSigV4 *AWSSigV4RequestSigning `json:"awsSigV4,omitempty"`
+
+	// HMAC signs the requests with an HMAC-SHA256 signature.
+	//
+	// +optional
+	HMAC *HMACRequestSigning `json:"hmac,omitempty"`
+}
+
+// RequestSigningType is the type of the signature of the requests.
+//
+// +kubebuilder:validation:Enum=AWSSigV4;HMAC
+type RequestSigningType string
+
+const (
+	// RequestSigningTypeAWSSigV4 signs the requests with the AWS Signature Version 4.
+	RequestSigningTypeAWSSigV4 RequestSigningType = "AWSSigV4"
+
+	// RequestSigningTypeHMAC signs the requests with an HMAC-SHA256 signature.
+	RequestSigningTypeHMAC RequestSigningType = "HMAC"
+)
+
+// AWSSigV4RequestSigning defines the AWS Signature Version 4 of the requests.
+//
+// The requests are signed with the AWS credentials of the envoy proxies, looked up from
+// their environment variables, their web identity token, such as the one provided by IAM
+// Roles for Service Accounts (IRSA) to the ServiceAccount of the envoy proxies, or their
+// instance metadata.
+type AWSSigV4RequestSigning struct {
+	// Service is the name of the AWS service the requests are signed for, e.g. s3.
+	//
+	// +kubebuilder:validation:MinLength=1
+	Service string `json:"service"`
+
+	// Region is the AWS region the requests are signed for, e.g. us-east-1.
+	//
+	// +kubebuilder:validation:MinLength=1
+	Region string `json:"region"`
+
+	// UnsignedPayload signs the requests without their body, which is then neither
+	// buffered nor hashed. Defaults to false.
+	//
+	// +optional
+	UnsignedPayload *bool `json:"unsignedPayload,omitempty"`
+}
+
+// HMACRequestSigning defines the HMAC-SHA256 signature of the requests.
+//
+// The signature is the hex-encoded HMAC-SHA256, with the key of the Secret, of the lines
+// holding the method, the path, the timestamp of the request in seconds since the Unix
+// epoch, the lowercase name and the value of each signed header, and, if the body is
+// signed, the hex-encoded SHA-256 digest of the body. The signature and the timestamp
+// are set in the signature and timestamp headers of the request.
+//
+// The requests are signed by an external processing service referenced by the BackendRefs.
+// The Envoy proxy sends the request headers, and the buffered request body if it is signed,
+// to the service, along with the gRPC metadata identifying the Secret and the signed headers.
+//
+// +kubebuilder:validation:XValidation:message="BackendRefs must be used, backendRef is not supported.",rule="!has(self.backendRef)"
+// +kubebuilder:validation:XValidation:message="BackendRefs is required.",rule="has(self.backendRefs) && self.backendRefs.size() > 0"
+// +kubebuilder:validation:XValidation:message="BackendRefs only supports Service and Backend kind.",rule="has(self.backendRefs) ? self.backendRefs.all(f, f.kind == 'Service' || f.kind == 'Backend') : true"
+// +kubebuilder:validation:XValidation:message="BackendRefs only supports Core and gateway.envoyproxy.io group.",rule="has(self.backendRefs) ? (self.backendRefs.all(f, f.group == \"\" || f.group == 'gateway.envoyproxy.io')) : true"
+type HMACRequestSigning struct {
+	// BackendCluster references the external processing service signing the requests.
+	BackendCluster `json:",inline"`
+
+	// Key references the Secret holding the key the requests are signed with, under
+	// the "hmac-key" key. The Secret is read by the external processing service, the key
+	// is never sent to the Envoy proxies.
+	//
+	// Note: The secret must be in the same namespace as the BackendTrafficPolicy.
+	Key gwapiv1b1.SecretObjectReference `json:"key"`
+
+	// SignatureHeader is the header the signature is set in.
+	// Defaults to X-Signature.
+	//
+	// +optional
+	SignatureHeader *string `json:"signatureHeader,omitempty"`
+
+	// TimestampHeader is the header the timestamp of the signature is set in.
+	// Defaults to X-Signature-Timestamp.
+	//
+	// +optional
+	TimestampHeader *string `json:"timestampHeader,omitempty"`
+
+	// SignedHeaders are the headers whose values are signed, in order, in addition to
+	// the method, the path and the timestamp. A missing header is signed empty.
+	//
+	// +optional
+	// +kubebuilder:validation:MaxItems=16
+	SignedHeaders []string `json:"signedHeaders,omitempty"`
+
+	// SignBody signs the SHA-256 digest of the bodies of the requests, which are then
+	// buffered. Defaults to false.
+	//
+	// +optional
+	SignBody *bool `json:"signBody,omitempty"`
+}
@@ -0,0 +1,74 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package v1alpha1
+
+import (
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+// ResponseBuffer defines how the bodies of the responses of the backends are buffered
+// before being processed by the filters of the route, such as the ones inspecting or
+// modifying the bodies, which otherwise buffer them implicitly up to the buffer limit of
+// the connections and change the streaming behavior of the responses silently.
+//
+// +kubebuilder:validation:XValidation:rule="!(has(self.onLimitExceeded) && self.onLimitExceeded == 'StreamThrough' && has(self.chunkedEncoding) && self.chunkedEncoding == 'InjectContentLength')",message="chunkedEncoding InjectContentLength requires onLimitExceeded Reject"
+type ResponseBuffer struct {
+	// Limit is the maximum size of the body of a response buffered in memory.
+	// For example, 20Mi, 1Gi, 256Ki etc.
+	// Note that when the suffix is not provided, the value is interpreted as bytes.
+	//
+	// +kubebuilder:validation:XIntOrString
+	// +kubebuilder:validation:Pattern="^[1-9]+[0-9]*([EPTGMK]i|[EPTGMk])?$"
+	Limit resource.Quantity `json:"limit"`
+
+	// OnLimitExceeded defines the behavior when the body of a response exceeds the limit.
+	// Defaults to Reject.
+	//
+	// +optional
+	OnLimitExceeded *ResponseBufferLimitAction `json:"onLimitExceeded,omitempty"`
+
+	// ChunkedEncoding defines how the responses without a Content-Length header, which are
+	// sent with the chunked transfer encoding, are forwarded to the clients.
+	// Defaults to Preserve.
+	//
+	// +optional
+	ChunkedEncoding *ResponseChunkedEncoding `json:"chunkedEncoding,omitempty"`
+}
+
+// ResponseBufferLimitAction defines the behavior when the body of a response exceeds the
+// limit of the buffer.
+//
+// +kubebuilder:validation:Enum=Reject;StreamThrough
+type ResponseBufferLimitAction string
+
+const (
+	// ResponseBufferLimitActionReject fully buffers the bodies of the responses, and replies
+	// with a 500 status code instead of the responses whose bodies exceed the limit.
+	ResponseBufferLimitActionReject ResponseBufferLimitAction = "Reject"
+
+	// ResponseBufferLimitActionStreamThrough buffers the bodies of the responses only while
+	// the clients are slower than the backends, and streams the responses whose bodies
+	// exceed the limit to the clients, as if they were not buffered.
+	ResponseBufferLimitActionStreamThrough ResponseBufferLimitAction = "StreamThrough"
+)
+
+// ResponseChunkedEncoding defines how the responses sent with the chunked transfer
+// encoding are forwarded to the clients.
+//
+// +kubebuilder:validation:Enum=Preserve;InjectContentLength
+type ResponseChunkedEncoding string
+
+const (
+	// ResponseChunkedEncodingPreserve forwards the responses with the encoding of the
+	// backends.
+	ResponseChunkedEncodingPreserve ResponseChunkedEncoding = "Preserve"
+
+	// ResponseChunkedEncodingInjectContentLength sets the Content-Length header of the
+	// fully buffered responses, so that they are not sent with the chunked transfer
+	// encoding. It requires the Reject behavior, as the streamed responses have no
+	// known length.
+	ResponseChunkedEncodingInjectContentLength ResponseChunkedEncoding = "InjectContentLength"
+)
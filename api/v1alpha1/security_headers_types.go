@@ -0,0 +1,75 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package v1alpha1
+
+// SecurityHeaders defines the security headers added to the responses. The headers with a
+// recommended value are added unless disabled, the others only when their value is set.
+type SecurityHeaders struct {
+	// StrictTransportSecurity configures the Strict-Transport-Security header, which tells
+	// the browsers to only connect to the host over HTTPS.
+	// Defaults to "max-age=31536000; includeSubDomains".
+	//
+	// +optional
+	StrictTransportSecurity *SecurityHeader `json:"strictTransportSecurity,omitempty"`
+
+	// ContentSecurityPolicy configures the Content-Security-Policy header, which restricts
+	// the resources the pages can load. The header is only added when its value is set,
+	// since the policy depends on the pages.
+	//
+	// +optional
+	ContentSecurityPolicy *SecurityHeader `json:"contentSecurityPolicy,omitempty"`
+
+	// XFrameOptions configures the X-Frame-Options header, which tells the browsers whether
+	// the pages can be embedded in frames.
+	// Defaults to "DENY".
+	//
+	// +optional
+	XFrameOptions *SecurityHeader `json:"xFrameOptions,omitempty"`
+
+	// XContentTypeOptions configures the X-Content-Type-Options header, which tells the
+	// browsers not to guess the content type of the responses.
+	// Defaults to "nosniff".
+	//
+	// +optional
+	XContentTypeOptions *SecurityHeader `json:"xContentTypeOptions,omitempty"`
+
+	// ReferrerPolicy configures the Referrer-Policy header, which restricts the referrer
+	// information sent by the browsers.
+	// Defaults to "strict-origin-when-cross-origin".
+	//
+	// +optional
+	ReferrerPolicy *SecurityHeader `json:"referrerPolicy,omitempty"`
+
+	// PermissionsPolicy configures the Permissions-Policy header, which restricts the
+	// browser features the pages can use. The header is only added when its value is set.
+	//
+	// +optional
+	PermissionsPolicy *SecurityHeader `json:"permissionsPolicy,omitempty"`
+
+	// Overwrite replaces the security headers set by the backends.
+	// Defaults to false, the headers of the backends being kept.
+	//
+	// +optional
+	Overwrite *bool `json:"overwrite,omitempty"`
+}
+
+// SecurityHeader overrides the value of a security header, or disables it.
+//
+// +kubebuilder:validation:XValidation:rule="!(has(self.disabled) && self.disabled && has(self.value))",message="value cannot be set when the header is disabled"
+type SecurityHeader struct {
+	// Value overrides the default value of the header.
+	//
+	// +optional
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=4096
+	Value *string `json:"value,omitempty"`
+
+	// Disabled omits the header from the responses.
+	// Defaults to false.
+	//
+	// +optional
+	Disabled *bool `json:"disabled,omitempty"`
+}
@@ -76,6 +76,12 @@ type SecurityPolicySpec struct {
 	//
 	// +optional
 	Authorization *Authorization `json:"authorization,omitempty"`
+
+	// SecurityHeaders defines the security headers, such as Strict-Transport-Security,
+	// added to the responses.
+	//
+	// +optional
+	SecurityHeaders *SecurityHeaders `json:"securityHeaders,omitempty"`
 }
 
 //+kubebuilder:object:root=true
@@ -255,6 +255,7 @@ const (
 // +kubebuilder:validation:XValidation:message="allocateLoadBalancerNodePorts can only be set for LoadBalancer type",rule="!has(self.allocateLoadBalancerNodePorts) || self.type == 'LoadBalancer'"
 // +kubebuilder:validation:XValidation:message="loadBalancerSourceRanges can only be set for LoadBalancer type",rule="!has(self.loadBalancerSourceRanges) || self.type == 'LoadBalancer'"
 // +kubebuilder:validation:XValidation:message="loadBalancerIP can only be set for LoadBalancer type",rule="!has(self.loadBalancerIP) || self.type == 'LoadBalancer'"
+// +kubebuilder:validation:XValidation:message="healthCheckNodePort can only be set for LoadBalancer type with Local externalTrafficPolicy",rule="!has(self.healthCheckNodePort) || (self.type == 'LoadBalancer' && (!has(self.externalTrafficPolicy) || self.externalTrafficPolicy == 'Local'))"
 type KubernetesServiceSpec struct {
 	// Annotations that should be appended to the service.
 	// By default, no annotations are appended.
@@ -308,6 +309,17 @@ type KubernetesServiceSpec struct {
 	// +optional
 	ExternalTrafficPolicy *ServiceExternalTrafficPolicy `json:"externalTrafficPolicy,omitempty"`
 
+	// HealthCheckNodePort is the node port the load balancer health checks the nodes on when
+	// the externalTrafficPolicy is Local, which only the nodes running a ready Envoy pod pass,
+	// so that the load balancer only sends the traffic to the nodes preserving the client IP.
+	// It's allocated by Kubernetes when unset, and may only be set for the Services of type
+	// LoadBalancer.
+	//
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=65535
+	// +optional
+	HealthCheckNodePort *int32 `json:"healthCheckNodePort,omitempty"`
+
 	// Patch defines how to perform the patch operation to the service
 	//
 	// +optional
@@ -628,6 +640,14 @@ type CustomResponseMatch struct {
 // +kubebuilder:validation:Enum=Value;Range
 type StatusCodeValueType string
 
+const (
+	// StatusCodeValueTypeValue defines the "Value" status code match type.
+	StatusCodeValueTypeValue StatusCodeValueType = "Value"
+
+	// StatusCodeValueTypeRange defines the "Range" status code match type.
+	StatusCodeValueTypeRange StatusCodeValueType = "Range"
+)
+
 type StatusCodeMatch struct {
 	// Type is the type of value.
 	//
@@ -662,6 +682,14 @@ type CustomResponse struct {
 	// +optional
 	ContentType *string `json:"contentType,omitempty"`
 
+	// StatusCode is the status code of the custom response. Defaults to the status code
+	// of the response overridden.
+	//
+	// +kubebuilder:validation:Minimum=200
+	// +kubebuilder:validation:Maximum=599
+	// +optional
+	StatusCode *int `json:"statusCode,omitempty"`
+
 	// Body of the Custom Response
 	Body CustomResponseBody `json:"body"`
 }
@@ -670,6 +698,14 @@ type CustomResponse struct {
 // +kubebuilder:validation:Enum=Inline;ValueRef
 type ResponseValueType string
 
+const (
+	// ResponseValueTypeInline defines the "Inline" response body type.
+	ResponseValueTypeInline ResponseValueType = "Inline"
+
+	// ResponseValueTypeValueRef defines the "ValueRef" response body type.
+	ResponseValueTypeValueRef ResponseValueType = "ValueRef"
+)
+
 // CustomResponseBody
 type CustomResponseBody struct {
 	// Type is the type of method to use to read the body value.
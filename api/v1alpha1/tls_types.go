@@ -15,6 +15,117 @@ type ClientTLSSettings struct {
 	// +optional
 	ClientValidation *ClientValidationContext `json:"clientValidation,omitempty"`
 	TLSSettings      `json:",inline"`
+
+	// SessionResumption configures the resumption of the TLS sessions of the
+	// clients, which saves a full handshake on reconnection.
+	// By default, both the stateless and the stateful session resumption are
+	// enabled, with session ticket keys generated by each proxy on startup.
+	//
+	// +optional
+	SessionResumption *SessionResumption `json:"sessionResumption,omitempty"`
+
+	// NoSNIMatch configures how the TLS connections whose SNI matches none of
+	// the hostnames of the listeners sharing the port are handled, including
+	// the connections without SNI.
+	// By default, these connections are closed by the proxy, unless a listener
+	// without hostname shares the port.
+	//
+	// +optional
+	NoSNIMatch *NoSNIMatch `json:"noSNIMatch,omitempty"`
+}
+
+// NoSNIMatch defines the handling of the TLS connections whose SNI matches none
+// of the hostnames of the listeners sharing the port.
+//
+// +kubebuilder:validation:XValidation:rule="self.action == 'DefaultBackend' ? has(self.backendRef) : !has(self.backendRef)", message="backendRef must be set if, and only if, the action is DefaultBackend"
+type NoSNIMatch struct {
+	// Action is the action taken on the connections whose SNI matches no hostname.
+	Action NoSNIMatchAction `json:"action"`
+
+	// BackendRef is the backend the connections are proxied to when the action
+	// is DefaultBackend. The connections are proxied without terminating TLS.
+	//
+	// +optional
+	BackendRef *BackendRef `json:"backendRef,omitempty"`
+}
+
+// NoSNIMatchAction is the action taken on the TLS connections whose SNI matches
+// no hostname.
+// +kubebuilder:validation:Enum=Reject;FallbackCertificate;DefaultBackend
+type NoSNIMatchAction string
+
+const (
+	// NoSNIMatchActionReject closes the connections.
+	NoSNIMatchActionReject NoSNIMatchAction = "Reject"
+	// NoSNIMatchActionFallbackCertificate terminates TLS with the certificates of
+	// the listener, and handles the connections like the connections matching its
+	// hostname.
+	NoSNIMatchActionFallbackCertificate NoSNIMatchAction = "FallbackCertificate"
+	// NoSNIMatchActionDefaultBackend proxies the connections to the backend
+	// without terminating TLS.
+	NoSNIMatchActionDefaultBackend NoSNIMatchAction = "DefaultBackend"
+)
+
+// SessionResumption defines the TLS session resumption settings of the listener.
+type SessionResumption struct {
+	// Stateless configures the stateless session resumption, where the session
+	// state is encrypted into a session ticket held by the client.
+	//
+	// +optional
+	Stateless *StatelessSessionResumption `json:"stateless,omitempty"`
+
+	// Stateful configures the stateful session resumption, where the session
+	// state is cached by the proxy under a session ID.
+	//
+	// +optional
+	Stateful *StatefulSessionResumption `json:"stateful,omitempty"`
+}
+
+// StatelessSessionResumption defines the stateless TLS session resumption settings.
+//
+// +kubebuilder:validation:XValidation:rule="has(self.ticketKeys) ? !has(self.enabled) || self.enabled : true", message="ticketKeys can only be set if the stateless session resumption is enabled"
+type StatelessSessionResumption struct {
+	// Enabled enables the stateless session resumption. Defaults to true.
+	//
+	// +optional
+	Enabled *bool `json:"enabled,omitempty"`
+
+	// TicketKeys configures the session ticket keys managed by Envoy Gateway,
+	// which are distributed to the proxies over SDS and rotated periodically.
+	// The same keys are shared by all the proxies of the Gateway, so that a session
+	// is resumed by any of them.
+	// If unset, each proxy generates its own session ticket key on startup, which
+	// is never rotated.
+	//
+	// +optional
+	TicketKeys *SessionTicketKeys `json:"ticketKeys,omitempty"`
+}
+
+// SessionTicketKeys defines the rotation of the session ticket keys. A new key
+// encrypts the session tickets every rotation interval, and the previous keys are
+// retained to decrypt the tickets issued before. A shorter interval and fewer
+// retained keys improve forward secrecy, at the cost of fewer resumed sessions.
+type SessionTicketKeys struct {
+	// RotationInterval is the interval a new session ticket key is generated at.
+	// Defaults to 1h.
+	//
+	// +optional
+	RotationInterval *gwapiv1.Duration `json:"rotationInterval,omitempty"`
+
+	// RetainedKeys is the number of previous session ticket keys still accepted
+	// to resume a session. Defaults to 2.
+	//
+	// +kubebuilder:validation:Maximum=8
+	// +optional
+	RetainedKeys *uint32 `json:"retainedKeys,omitempty"`
+}
+
+// StatefulSessionResumption defines the stateful TLS session resumption settings.
+type StatefulSessionResumption struct {
+	// Enabled enables the stateful session resumption. Defaults to true.
+	//
+	// +optional
+	Enabled *bool `json:"enabled,omitempty"`
 }
 
 // +kubebuilder:validation:XValidation:rule="has(self.minVersion) && self.minVersion == '1.3' ? !has(self.ciphers) : true", message="setting ciphers has no effect if the minimum possible TLS version is 1.3"
@@ -7,7 +7,16 @@ package validation
 
 import (
 	"fmt"
+	"math"
 	"net/url"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/util/validation"
+	gwapiv1 "sigs.k8s.io/gateway-api/apis/v1"
 
 	egv1a1 "github.com/envoyproxy/gateway/api/v1alpha1"
 )
@@ -26,6 +35,10 @@ func ValidateEnvoyGateway(eg *egv1a1.EnvoyGateway) error {
 		return fmt.Errorf("gateway controllerName is unspecified")
 	}
 
+	if err := validateEnvoyGatewayAdditionalControllers(eg.Gateway); err != nil {
+		return err
+	}
+
 	if eg.Provider == nil {
 		return fmt.Errorf("provider is unspecified")
 	}
@@ -59,6 +72,54 @@ func ValidateEnvoyGateway(eg *egv1a1.EnvoyGateway) error {
 		return err
 	}
 
+	if err := validateEnvoyGatewayAdmin(eg.Admin); err != nil {
+		return err
+	}
+
+	if err := validateEnvoyGatewayProxySizing(eg.Provider, eg.ProxySizing); err != nil {
+		return err
+	}
+
+	if err := validateEnvoyGatewaySecretRotation(eg.SecretRotation); err != nil {
+		return err
+	}
+
+	if err := validateEnvoyGatewaySnapshotCache(eg.SnapshotCache); err != nil {
+		return err
+	}
+
+	if err := validateEnvoyGatewayXdsServer(eg.XdsServer); err != nil {
+		return err
+	}
+
+	if err := validateXdsServerIsolatedGateways(eg); err != nil {
+		return err
+	}
+
+	if err := validateEnvoyGatewayHostnameDelegation(eg.HostnameDelegation); err != nil {
+		return err
+	}
+
+	if err := validateEnvoyGatewayBulkImport(eg.BulkImport); err != nil {
+		return err
+	}
+
+	if err := validateEnvoyGatewayShadowTranslation(eg.ShadowTranslation); err != nil {
+		return err
+	}
+
+	if err := validateEnvoyGatewayWatchable(eg.Watchable); err != nil {
+		return err
+	}
+
+	if err := validateEnvoyGatewayCanaryRollout(eg.Provider, eg.CanaryRollout); err != nil {
+		return err
+	}
+
+	if err := validateEnvoyGatewayFeatureGates(eg.FeatureGates); err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -230,3 +291,628 @@ func validateEnvoyGatewayTelemetry(telemetry *egv1a1.EnvoyGatewayTelemetry) erro
 	}
 	return nil
 }
+
+func validateEnvoyGatewayAdmin(admin *egv1a1.EnvoyGatewayAdmin) error {
+	if admin == nil {
+		return nil
+	}
+
+	if admin.EnableDashboard && !admin.EnableAPI {
+		return fmt.Errorf("admin API must be enabled to serve the dashboard")
+	}
+	return nil
+}
+
+func validateEnvoyGatewayProxySizing(provider *egv1a1.EnvoyGatewayProvider, sizing *egv1a1.EnvoyGatewayProxySizing) error {
+	if sizing == nil {
+		return nil
+	}
+
+	if provider.Type != egv1a1.ProviderTypeKubernetes {
+		return fmt.Errorf("proxy sizing is only supported by the Kubernetes provider")
+	}
+	if sizing.Mode != nil {
+		switch *sizing.Mode {
+		case egv1a1.ProxySizingModeRecommend, egv1a1.ProxySizingModeAuto:
+		default:
+			return fmt.Errorf("unsupported proxy sizing mode %s", *sizing.Mode)
+		}
+	}
+	if sizing.Interval != nil {
+		d, err := time.ParseDuration(string(*sizing.Interval))
+		if err != nil {
+			return fmt.Errorf("invalid proxy sizing interval: %w", err)
+		}
+		if d < time.Second {
+			return fmt.Errorf("proxy sizing interval must be at least 1s")
+		}
+	}
+	if sizing.Window != nil && *sizing.Window == 0 {
+		return fmt.Errorf("proxy sizing window must be greater than 0")
+	}
+	return nil
+}
+
+func validateEnvoyGatewaySecretRotation(rotation *egv1a1.EnvoyGatewaySecretRotation) error {
+	if rotation == nil || rotation.OverlapWindow == nil {
+		return nil
+	}
+
+	d, err := time.ParseDuration(string(*rotation.OverlapWindow))
+	if err != nil {
+		return fmt.Errorf("invalid secret rotation overlap window: %w", err)
+	}
+	if d < 0 {
+		return fmt.Errorf("secret rotation overlap window must not be negative")
+	}
+	return nil
+}
+
+func validateEnvoyGatewayAdditionalControllers(gateway *egv1a1.Gateway) error {
+	names := map[string]bool{gateway.ControllerName: true}
+	for i, c := range gateway.AdditionalControllers {
+		if len(c.ControllerName) == 0 {
+			return fmt.Errorf("additional controller %d: controllerName is unspecified", i)
+		}
+		if names[c.ControllerName] {
+			return fmt.Errorf("additional controller %d: duplicate controllerName %s", i, c.ControllerName)
+		}
+		names[c.ControllerName] = true
+		if c.XdsServerPort != nil && (*c.XdsServerPort < 1 || *c.XdsServerPort > 65535) {
+			return fmt.Errorf("additional controller %d: xdsServerPort must be between 1 and 65535", i)
+		}
+	}
+	return nil
+}
+
+func validateEnvoyGatewaySnapshotCache(snapshotCache *egv1a1.EnvoyGatewaySnapshotCache) error {
+	if snapshotCache == nil {
+		return nil
+	}
+	if snapshotCache.MemoryLimit != nil && snapshotCache.MemoryLimit.Sign() <= 0 {
+		return fmt.Errorf("snapshot cache memoryLimit must be greater than zero")
+	}
+	if snapshotCache.History != nil && *snapshotCache.History == 0 {
+		return fmt.Errorf("snapshot cache history must be greater than zero")
+	}
+	if snapshotCache.Persistence != nil && !filepath.IsAbs(snapshotCache.Persistence.Path) {
+		return fmt.Errorf("snapshot cache persistence path must be an absolute path")
+	}
+	if err := validateSnapshotDebounce(snapshotCache.Debounce); err != nil {
+		return err
+	}
+	if err := validateNodeHash(snapshotCache.NodeHash, nil); err != nil {
+		return err
+	}
+	if snapshotCache.StaleNodeTimeout != nil {
+		d, err := time.ParseDuration(string(*snapshotCache.StaleNodeTimeout))
+		if err != nil {
+			return fmt.Errorf("invalid snapshot cache staleNodeTimeout: %w", err)
+		}
+		if d <= 0 {
+			return fmt.Errorf("snapshot cache staleNodeTimeout must be greater than 0")
+		}
+	}
+	if err := validateStagedRollout(snapshotCache.StagedRollout); err != nil {
+		return err
+	}
+	if err := validatePushPacing(snapshotCache.PushPacing); err != nil {
+		return err
+	}
+	if driftDetection := snapshotCache.DriftDetection; driftDetection != nil && driftDetection.Window != nil {
+		d, err := time.ParseDuration(string(*driftDetection.Window))
+		if err != nil {
+			return fmt.Errorf("invalid snapshot cache driftDetection window: %w", err)
+		}
+		if d <= 0 {
+			return fmt.Errorf("snapshot cache driftDetection window must be greater than 0")
+		}
+	}
+	return validateResourceTTL(snapshotCache.ResourceTTL)
+}
+
+func validateStagedRollout(rollout *egv1a1.EnvoyGatewayStagedRollout) error {
+	if rollout == nil {
+		return nil
+	}
+	if rollout.CanaryPercentage == 0 || rollout.CanaryPercentage >= 100 {
+		return fmt.Errorf("snapshot cache stagedRollout canaryPercentage must be between 1 and 99")
+	}
+	if rollout.SoakPeriod != nil {
+		d, err := time.ParseDuration(string(*rollout.SoakPeriod))
+		if err != nil {
+			return fmt.Errorf("invalid snapshot cache stagedRollout soakPeriod: %w", err)
+		}
+		if d <= 0 {
+			return fmt.Errorf("snapshot cache stagedRollout soakPeriod must be greater than 0")
+		}
+	}
+	return nil
+}
+
+func validatePushPacing(pacing *egv1a1.EnvoyGatewayPushPacing) error {
+	if pacing == nil {
+		return nil
+	}
+	if pacing.MaxNodeUpdatesPerSecond == 0 {
+		return fmt.Errorf("snapshot cache pushPacing maxNodeUpdatesPerSecond must be greater than 0")
+	}
+	if pacing.Jitter != nil {
+		d, err := time.ParseDuration(string(*pacing.Jitter))
+		if err != nil {
+			return fmt.Errorf("invalid snapshot cache pushPacing jitter: %w", err)
+		}
+		if d < 0 {
+			return fmt.Errorf("snapshot cache pushPacing jitter must not be negative")
+		}
+	}
+	return nil
+}
+
+// ValidateEnvoyProxyNodeHash validates the node group of the EnvoyProxy against the node
+// hash of the snapshot cache of the EnvoyGateway.
+func ValidateEnvoyProxyNodeHash(eg *egv1a1.EnvoyGateway, proxy *egv1a1.EnvoyProxy) error {
+	if eg == nil || eg.SnapshotCache == nil || proxy == nil {
+		return nil
+	}
+	return validateNodeHash(eg.SnapshotCache.NodeHash, proxy.Spec.NodeGroup)
+}
+
+// validateNodeHash validates the node hash, and rejects it if it keys the snapshots of the
+// proxies of a Gateway by their cluster or node metadata while the proxies are grouped: the
+// proxies of the node groups and scopes of a Gateway are served different subsets of its
+// resources, so the proxies sharing a snapshot key would be served the subset of one of
+// them, and rolled back together when one of them rejects it.
+func validateNodeHash(nodeHash *egv1a1.EnvoyGatewayNodeHash, nodeGroup *string) error {
+	if nodeHash == nil {
+		return nil
+	}
+	switch nodeHash.Type {
+	case egv1a1.NodeHashTypeNodeID, egv1a1.NodeHashTypeCluster:
+		if nodeHash.MetadataKey != nil {
+			return fmt.Errorf("snapshot cache nodeHash metadataKey must only be set with the %s type", egv1a1.NodeHashTypeMetadata)
+		}
+	case egv1a1.NodeHashTypeMetadata:
+		if nodeHash.MetadataKey == nil || *nodeHash.MetadataKey == "" {
+			return fmt.Errorf("snapshot cache nodeHash metadataKey must be set with the %s type", egv1a1.NodeHashTypeMetadata)
+		}
+	default:
+		return fmt.Errorf("unsupported snapshot cache nodeHash type %q", nodeHash.Type)
+	}
+	if nodeGroup != nil && nodeHash.Type != egv1a1.NodeHashTypeNodeID {
+		return fmt.Errorf("nodeGroup cannot be set with the %s snapshot cache nodeHash type, which would serve the proxies of a node group the snapshot of another one", nodeHash.Type)
+	}
+	return nil
+}
+
+func validateResourceTTL(resourceTTL *egv1a1.EnvoyGatewayResourceTTL) error {
+	if resourceTTL == nil {
+		return nil
+	}
+	if resourceTTL.Default == nil && len(resourceTTL.Types) == 0 {
+		return fmt.Errorf("snapshot cache resourceTTL must set a default TTL or the TTL of types")
+	}
+	var shortest time.Duration
+	parse := func(name string, duration gwapiv1.Duration) (time.Duration, error) {
+		d, err := time.ParseDuration(string(duration))
+		if err != nil {
+			return 0, fmt.Errorf("invalid snapshot cache resourceTTL %s: %w", name, err)
+		}
+		if d <= 0 {
+			return 0, fmt.Errorf("snapshot cache resourceTTL %s must be greater than 0", name)
+		}
+		return d, nil
+	}
+	if resourceTTL.Default != nil {
+		d, err := parse("default", *resourceTTL.Default)
+		if err != nil {
+			return err
+		}
+		shortest = d
+	}
+	types := make(map[egv1a1.EnvoyResourceType]bool, len(resourceTTL.Types))
+	for _, t := range resourceTTL.Types {
+		if types[t.Type] {
+			return fmt.Errorf("snapshot cache resourceTTL has a duplicate type %s", t.Type)
+		}
+		types[t.Type] = true
+		d, err := parse("of type "+string(t.Type), t.TTL)
+		if err != nil {
+			return err
+		}
+		if shortest == 0 || d < shortest {
+			shortest = d
+		}
+	}
+	if resourceTTL.HeartbeatInterval != nil {
+		d, err := parse("heartbeatInterval", *resourceTTL.HeartbeatInterval)
+		if err != nil {
+			return err
+		}
+		if d >= shortest {
+			return fmt.Errorf("snapshot cache resourceTTL heartbeatInterval must be shorter than the TTLs")
+		}
+	}
+	return nil
+}
+
+func validateSnapshotDebounce(debounce *egv1a1.EnvoyGatewaySnapshotDebounce) error {
+	if debounce == nil {
+		return nil
+	}
+	var interval, maxDelay time.Duration
+	if debounce.Interval != nil {
+		d, err := time.ParseDuration(string(*debounce.Interval))
+		if err != nil {
+			return fmt.Errorf("invalid snapshot cache debounce interval: %w", err)
+		}
+		if d <= 0 {
+			return fmt.Errorf("snapshot cache debounce interval must be greater than 0")
+		}
+		interval = d
+	}
+	if debounce.MaxDelay != nil {
+		d, err := time.ParseDuration(string(*debounce.MaxDelay))
+		if err != nil {
+			return fmt.Errorf("invalid snapshot cache debounce maxDelay: %w", err)
+		}
+		if d <= 0 {
+			return fmt.Errorf("snapshot cache debounce maxDelay must be greater than 0")
+		}
+		maxDelay = d
+	}
+	if interval > 0 && maxDelay > 0 && maxDelay < interval {
+		return fmt.Errorf("snapshot cache debounce maxDelay must not be shorter than the interval")
+	}
+	return nil
+}
+
+func validateEnvoyGatewayXdsServer(xdsServer *egv1a1.EnvoyGatewayXdsServer) error {
+	if xdsServer == nil {
+		return nil
+	}
+	if err := validateXdsServerMessageSize("maxRecvMessageSize", xdsServer.MaxRecvMessageSize); err != nil {
+		return err
+	}
+	if err := validateXdsServerMessageSize("maxSendMessageSize", xdsServer.MaxSendMessageSize); err != nil {
+		return err
+	}
+	if xdsServer.MaxConcurrentStreams != nil && *xdsServer.MaxConcurrentStreams == 0 {
+		return fmt.Errorf("xds server maxConcurrentStreams must be greater than 0")
+	}
+	if xdsServer.SendTimeout != nil {
+		d, err := time.ParseDuration(string(*xdsServer.SendTimeout))
+		if err != nil {
+			return fmt.Errorf("invalid xds server sendTimeout: %w", err)
+		}
+		if d <= 0 {
+			return fmt.Errorf("xds server sendTimeout must be greater than 0")
+		}
+	}
+	if xdsServer.DrainTimeout != nil {
+		d, err := time.ParseDuration(string(*xdsServer.DrainTimeout))
+		if err != nil {
+			return fmt.Errorf("invalid xds server drainTimeout: %w", err)
+		}
+		if d <= 0 {
+			return fmt.Errorf("xds server drainTimeout must be greater than 0")
+		}
+	}
+	if err := validateXdsAuditLog(xdsServer.AuditLog); err != nil {
+		return err
+	}
+	if err := validateXdsNodeAuthorization(xdsServer.NodeAuthorization); err != nil {
+		return err
+	}
+	if err := validateXdsAnomalyDetection(xdsServer.AnomalyDetection); err != nil {
+		return err
+	}
+	if xdsServer.Keepalive == nil {
+		return nil
+	}
+	if err := validateXdsServerKeepaliveDuration("time", xdsServer.Keepalive.Time); err != nil {
+		return err
+	}
+	return validateXdsServerKeepaliveDuration("timeout", xdsServer.Keepalive.Timeout)
+}
+
+func validateXdsAuditLog(auditLog *egv1a1.XdsAuditLog) error {
+	if auditLog == nil {
+		return nil
+	}
+	if auditLog.SamplingPercent != nil && *auditLog.SamplingPercent > 100 {
+		return fmt.Errorf("xds server auditLog samplingPercent must not be greater than 100")
+	}
+	sink := auditLog.Sink
+	switch sink.Type {
+	case egv1a1.XdsAuditLogSinkTypeStdout:
+		if sink.File != nil || sink.OpenTelemetry != nil {
+			return fmt.Errorf("xds server auditLog sink of type Stdout must not set file nor openTelemetry")
+		}
+	case egv1a1.XdsAuditLogSinkTypeFile:
+		if sink.File == nil || sink.OpenTelemetry != nil {
+			return fmt.Errorf("xds server auditLog sink of type File must only set file")
+		}
+		if !filepath.IsAbs(sink.File.Path) {
+			return fmt.Errorf("xds server auditLog file path %q must be absolute", sink.File.Path)
+		}
+	case egv1a1.XdsAuditLogSinkTypeOpenTelemetry:
+		if sink.OpenTelemetry == nil || sink.File != nil {
+			return fmt.Errorf("xds server auditLog sink of type OpenTelemetry must only set openTelemetry")
+		}
+		if sink.OpenTelemetry.Host == "" {
+			return fmt.Errorf("xds server auditLog openTelemetry host must be specified")
+		}
+	default:
+		return fmt.Errorf("unsupported xds server auditLog sink type %q", sink.Type)
+	}
+	return nil
+}
+
+func validateXdsNodeAuthorization(authorization *egv1a1.XdsNodeAuthorization) error {
+	if authorization == nil {
+		return nil
+	}
+	switch authorization.Type {
+	case egv1a1.XdsNodeAuthenticationTypeClientCertificate:
+		if authorization.ServiceAccountToken != nil {
+			return fmt.Errorf("xds server nodeAuthorization of type ClientCertificate must not set serviceAccountToken")
+		}
+	case egv1a1.XdsNodeAuthenticationTypeServiceAccountToken:
+		if token := authorization.ServiceAccountToken; token != nil && token.MetadataKey != nil && *token.MetadataKey == "" {
+			return fmt.Errorf("xds server nodeAuthorization serviceAccountToken metadataKey must not be empty")
+		}
+	default:
+		return fmt.Errorf("unsupported xds server nodeAuthorization type %q", authorization.Type)
+	}
+	return nil
+}
+
+// defaultXdsServerPort is the default port of the xDS server.
+const defaultXdsServerPort = 18000
+
+// validateXdsServerIsolatedGateways checks that each isolated Gateway is served on a port
+// of its own, which no additional controller is served on either.
+func validateXdsServerIsolatedGateways(eg *egv1a1.EnvoyGateway) error {
+	if eg.XdsServer == nil {
+		return nil
+	}
+	ports := map[int32]bool{defaultXdsServerPort: true}
+	if eg.Gateway != nil {
+		for _, c := range eg.Gateway.AdditionalControllers {
+			if c.XdsServerPort != nil {
+				ports[*c.XdsServerPort] = true
+			}
+		}
+	}
+	gateways := make(map[string]bool)
+	for i, g := range eg.XdsServer.IsolatedGateways {
+		if len(g.Namespace) == 0 || len(g.Name) == 0 {
+			return fmt.Errorf("xds server isolated gateway %d: namespace and name must be specified", i)
+		}
+		key := string(g.Namespace) + "/" + string(g.Name)
+		if gateways[key] {
+			return fmt.Errorf("xds server isolated gateway %d: duplicate gateway %s", i, key)
+		}
+		gateways[key] = true
+		if g.Port < 1 || g.Port > 65535 {
+			return fmt.Errorf("xds server isolated gateway %d: port must be between 1 and 65535", i)
+		}
+		if ports[g.Port] {
+			return fmt.Errorf("xds server isolated gateway %d: port %d is already used by the xds server", i, g.Port)
+		}
+		ports[g.Port] = true
+	}
+	return nil
+}
+
+func validateXdsServerMessageSize(name string, size *resource.Quantity) error {
+	if size != nil && (size.Sign() <= 0 || size.Value() > math.MaxInt32) {
+		return fmt.Errorf("xds server %s must be between 1 and %d bytes", name, math.MaxInt32)
+	}
+	return nil
+}
+
+func validateXdsAnomalyDetection(anomalyDetection *egv1a1.XdsAnomalyDetection) error {
+	if anomalyDetection == nil {
+		return nil
+	}
+	if anomalyDetection.Host == "" {
+		return fmt.Errorf("xds server anomalyDetection host must be specified")
+	}
+	if anomalyDetection.Port <= 0 || anomalyDetection.Port > 65535 {
+		return fmt.Errorf("xds server anomalyDetection port must be between 1 and 65535")
+	}
+	validate := func(name string, duration *gwapiv1.Duration) error {
+		if duration == nil {
+			return nil
+		}
+		d, err := time.ParseDuration(string(*duration))
+		if err != nil {
+			return fmt.Errorf("invalid xds server anomalyDetection %s: %w", name, err)
+		}
+		if d <= 0 {
+			return fmt.Errorf("xds server anomalyDetection %s must be greater than 0", name)
+		}
+		return nil
+	}
+	if err := validate("interval", anomalyDetection.Interval); err != nil {
+		return err
+	}
+	return validate("degradationTTL", anomalyDetection.DegradationTTL)
+}
+
+func validateXdsServerKeepaliveDuration(name string, duration *gwapiv1.Duration) error {
+	if duration == nil {
+		return nil
+	}
+	d, err := time.ParseDuration(string(*duration))
+	if err != nil {
+		return fmt.Errorf("invalid xds server keepalive %s: %w", name, err)
+	}
+	if d <= 0 {
+		return fmt.Errorf("xds server keepalive %s must be greater than 0", name)
+	}
+	return nil
+}
+
+func validateEnvoyGatewayFeatureGates(gates map[egv1a1.FeatureGate]bool) error {
+	known := egv1a1.KnownFeatureGates()
+	for gate := range gates {
+		if !slices.Contains(known, gate) {
+			return fmt.Errorf("unknown feature gate %s, must be one of %v", gate, known)
+		}
+	}
+	return nil
+}
+
+func validateEnvoyGatewayBulkImport(bulkImport *egv1a1.EnvoyGatewayBulkImport) error {
+	if bulkImport == nil {
+		return nil
+	}
+
+	if bulkImport.Threshold != nil && *bulkImport.Threshold == 0 {
+		return fmt.Errorf("bulk import threshold must be greater than 0")
+	}
+	if bulkImport.BatchSize != nil && *bulkImport.BatchSize == 0 {
+		return fmt.Errorf("bulk import batch size must be greater than 0")
+	}
+	return nil
+}
+
+func validateEnvoyGatewayShadowTranslation(shadow *egv1a1.EnvoyGatewayShadowTranslation) error {
+	if shadow == nil {
+		return nil
+	}
+
+	switch shadow.Translator {
+	case egv1a1.ShadowTranslatorFull:
+		return nil
+	}
+	return fmt.Errorf("unknown shadow translator %q", shadow.Translator)
+}
+
+func validateEnvoyGatewayCanaryRollout(provider *egv1a1.EnvoyGatewayProvider, canary *egv1a1.EnvoyGatewayCanaryRollout) error {
+	if canary == nil {
+		return nil
+	}
+
+	if provider.Type != egv1a1.ProviderTypeKubernetes {
+		return fmt.Errorf("canary rollout is only supported by the Kubernetes provider")
+	}
+	u, err := url.ParseRequestURI(canary.PrometheusURL)
+	if err != nil {
+		return fmt.Errorf("invalid canary rollout prometheus URL: %w", err)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return fmt.Errorf("canary rollout prometheus URL must be an http or https URL")
+	}
+	return nil
+}
+
+func validateEnvoyGatewayWatchable(watchable *egv1a1.EnvoyGatewayWatchable) error {
+	if watchable == nil {
+		return nil
+	}
+
+	messages := make(map[string]bool)
+	for i, queue := range watchable.Queues {
+		if queue.Message == "" {
+			return fmt.Errorf("watchable queue %d: message should be specified", i)
+		}
+		if messages[queue.Message] {
+			return fmt.Errorf("watchable queue %d: duplicate message %s", i, queue.Message)
+		}
+		messages[queue.Message] = true
+		if queue.Capacity == 0 && (queue.Coalesce == nil || !*queue.Coalesce) {
+			return fmt.Errorf("watchable queue %d: capacity should be greater than 0 unless coalesce is set", i)
+		}
+		if queue.OverflowPolicy == nil {
+			continue
+		}
+		switch *queue.OverflowPolicy {
+		case egv1a1.WatchableOverflowPolicyBlock, egv1a1.WatchableOverflowPolicyDropOldest, egv1a1.WatchableOverflowPolicyCoalesceByKey:
+		default:
+			return fmt.Errorf("watchable queue %d: unknown overflow policy %q", i, *queue.OverflowPolicy)
+		}
+	}
+
+	messages = make(map[string]bool)
+	for i, retry := range watchable.Retries {
+		if retry.Message == "" {
+			return fmt.Errorf("watchable retry %d: message should be specified", i)
+		}
+		if messages[retry.Message] {
+			return fmt.Errorf("watchable retry %d: duplicate message %s", i, retry.Message)
+		}
+		messages[retry.Message] = true
+		if retry.MaxAttempts != nil && *retry.MaxAttempts == 0 {
+			return fmt.Errorf("watchable retry %d: maxAttempts should be greater than 0", i)
+		}
+		if retry.DeadLetterCapacity != nil && *retry.DeadLetterCapacity == 0 {
+			return fmt.Errorf("watchable retry %d: deadLetterCapacity should be greater than 0", i)
+		}
+		parse := func(name string, duration *gwapiv1.Duration) (time.Duration, error) {
+			if duration == nil {
+				return 0, nil
+			}
+			d, err := time.ParseDuration(string(*duration))
+			if err != nil {
+				return 0, fmt.Errorf("watchable retry %d: invalid %s: %w", i, name, err)
+			}
+			if d <= 0 {
+				return 0, fmt.Errorf("watchable retry %d: %s should be greater than 0", i, name)
+			}
+			return d, nil
+		}
+		backoff, err := parse("backoff", retry.Backoff)
+		if err != nil {
+			return err
+		}
+		maxBackoff, err := parse("maxBackoff", retry.MaxBackoff)
+		if err != nil {
+			return err
+		}
+		if backoff > 0 && maxBackoff > 0 && backoff > maxBackoff {
+			return fmt.Errorf("watchable retry %d: backoff should not be greater than maxBackoff", i)
+		}
+	}
+
+	if tracing := watchable.Tracing; tracing != nil {
+		if tracing.Host == "" {
+			return fmt.Errorf("watchable tracing: host should be specified")
+		}
+		if tracing.Port != nil && (*tracing.Port < 1 || *tracing.Port > 65535) {
+			return fmt.Errorf("watchable tracing: port should be between 1 and 65535")
+		}
+		if tracing.SamplingRate != nil && *tracing.SamplingRate > 100 {
+			return fmt.Errorf("watchable tracing: samplingRate should not be greater than 100")
+		}
+	}
+	return nil
+}
+
+func validateEnvoyGatewayHostnameDelegation(delegation *egv1a1.EnvoyGatewayHostnameDelegation) error {
+	if delegation == nil {
+		return nil
+	}
+
+	for i, d := range delegation.Delegations {
+		if len(d.Namespaces) == 0 {
+			return fmt.Errorf("hostname delegation %d: namespaces should be specified", i)
+		}
+		if len(d.Hostnames) == 0 {
+			return fmt.Errorf("hostname delegation %d: hostnames should be specified", i)
+		}
+		for _, hostname := range d.Hostnames {
+			h := strings.TrimPrefix(string(hostname), "*.")
+			if hostname == "*" {
+				continue
+			}
+			if errs := validation.IsDNS1123Subdomain(h); len(errs) > 0 {
+				return fmt.Errorf("hostname delegation %d: invalid hostname %q: %s", i, hostname, strings.Join(errs, "; "))
+			}
+		}
+	}
+	return nil
+}
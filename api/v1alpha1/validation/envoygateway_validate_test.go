@@ -10,7 +10,9 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
 	gwapiv1 "sigs.k8s.io/gateway-api/apis/v1"
 
 	egv1a1 "github.com/envoyproxy/gateway/api/v1alpha1"
@@ -621,6 +623,1085 @@ func TestValidateEnvoyGateway(t *testing.T) {
 			},
 			expect: false,
 		},
+		{
+			name: "dashboard with admin API",
+			eg: &egv1a1.EnvoyGateway{
+				EnvoyGatewaySpec: egv1a1.EnvoyGatewaySpec{
+					Gateway:  egv1a1.DefaultGateway(),
+					Provider: egv1a1.DefaultEnvoyGatewayProvider(),
+					Admin: &egv1a1.EnvoyGatewayAdmin{
+						EnableAPI:       true,
+						EnableDashboard: true,
+					},
+				},
+			},
+			expect: true,
+		},
+		{
+			name: "dashboard without admin API",
+			eg: &egv1a1.EnvoyGateway{
+				EnvoyGatewaySpec: egv1a1.EnvoyGatewaySpec{
+					Gateway:  egv1a1.DefaultGateway(),
+					Provider: egv1a1.DefaultEnvoyGatewayProvider(),
+					Admin: &egv1a1.EnvoyGatewayAdmin{
+						EnableDashboard: true,
+					},
+				},
+			},
+			expect: false,
+		},
+		{
+			name: "valid proxy sizing",
+			eg: &egv1a1.EnvoyGateway{
+				EnvoyGatewaySpec: egv1a1.EnvoyGatewaySpec{
+					Gateway:  egv1a1.DefaultGateway(),
+					Provider: egv1a1.DefaultEnvoyGatewayProvider(),
+					ProxySizing: &egv1a1.EnvoyGatewayProxySizing{
+						Mode:     ptr.To(egv1a1.ProxySizingModeAuto),
+						Interval: ptr.To(gwapiv1.Duration("30s")),
+					},
+				},
+			},
+			expect: true,
+		},
+		{
+			name: "proxy sizing with invalid interval",
+			eg: &egv1a1.EnvoyGateway{
+				EnvoyGatewaySpec: egv1a1.EnvoyGatewaySpec{
+					Gateway:  egv1a1.DefaultGateway(),
+					Provider: egv1a1.DefaultEnvoyGatewayProvider(),
+					ProxySizing: &egv1a1.EnvoyGatewayProxySizing{
+						Interval: ptr.To(gwapiv1.Duration("10ms")),
+					},
+				},
+			},
+			expect: false,
+		},
+		{
+			name: "proxy sizing with custom provider",
+			eg: &egv1a1.EnvoyGateway{
+				EnvoyGatewaySpec: egv1a1.EnvoyGatewaySpec{
+					Gateway: egv1a1.DefaultGateway(),
+					Provider: &egv1a1.EnvoyGatewayProvider{
+						Type: egv1a1.ProviderTypeCustom,
+						Custom: &egv1a1.EnvoyGatewayCustomProvider{
+							Resource: egv1a1.EnvoyGatewayResourceProvider{
+								Type: egv1a1.ResourceProviderTypeFile,
+								File: &egv1a1.EnvoyGatewayFileResourceProvider{
+									Paths: []string{"foo"},
+								},
+							},
+						},
+					},
+					ProxySizing: &egv1a1.EnvoyGatewayProxySizing{},
+				},
+			},
+			expect: false,
+		},
+		{
+			name: "valid secret rotation",
+			eg: &egv1a1.EnvoyGateway{
+				EnvoyGatewaySpec: egv1a1.EnvoyGatewaySpec{
+					Gateway:  egv1a1.DefaultGateway(),
+					Provider: egv1a1.DefaultEnvoyGatewayProvider(),
+					SecretRotation: &egv1a1.EnvoyGatewaySecretRotation{
+						OverlapWindow: ptr.To(gwapiv1.Duration("10m")),
+					},
+				},
+			},
+			expect: true,
+		},
+		{
+			name: "secret rotation with invalid overlap window",
+			eg: &egv1a1.EnvoyGateway{
+				EnvoyGatewaySpec: egv1a1.EnvoyGatewaySpec{
+					Gateway:  egv1a1.DefaultGateway(),
+					Provider: egv1a1.DefaultEnvoyGatewayProvider(),
+					SecretRotation: &egv1a1.EnvoyGatewaySecretRotation{
+						OverlapWindow: ptr.To(gwapiv1.Duration("ten minutes")),
+					},
+				},
+			},
+			expect: false,
+		},
+		{
+			name: "valid additional controllers",
+			eg: &egv1a1.EnvoyGateway{
+				EnvoyGatewaySpec: egv1a1.EnvoyGatewaySpec{
+					Gateway: &egv1a1.Gateway{
+						ControllerName: egv1a1.GatewayControllerName,
+						AdditionalControllers: []egv1a1.GatewayController{
+							{ControllerName: "example.com/internal-gateway", XdsServerPort: ptr.To[int32](18010)},
+							{ControllerName: "example.com/partner-gateway"},
+						},
+					},
+					Provider: egv1a1.DefaultEnvoyGatewayProvider(),
+				},
+			},
+			expect: true,
+		},
+		{
+			name: "additional controller with duplicate controllerName",
+			eg: &egv1a1.EnvoyGateway{
+				EnvoyGatewaySpec: egv1a1.EnvoyGatewaySpec{
+					Gateway: &egv1a1.Gateway{
+						ControllerName: egv1a1.GatewayControllerName,
+						AdditionalControllers: []egv1a1.GatewayController{
+							{ControllerName: egv1a1.GatewayControllerName},
+						},
+					},
+					Provider: egv1a1.DefaultEnvoyGatewayProvider(),
+				},
+			},
+			expect: false,
+		},
+		{
+			name: "additional controller with invalid xdsServerPort",
+			eg: &egv1a1.EnvoyGateway{
+				EnvoyGatewaySpec: egv1a1.EnvoyGatewaySpec{
+					Gateway: &egv1a1.Gateway{
+						ControllerName: egv1a1.GatewayControllerName,
+						AdditionalControllers: []egv1a1.GatewayController{
+							{ControllerName: "example.com/internal-gateway", XdsServerPort: ptr.To[int32](70000)},
+						},
+					},
+					Provider: egv1a1.DefaultEnvoyGatewayProvider(),
+				},
+			},
+			expect: false,
+		},
+		{
+			name: "valid xds server isolated gateways",
+			eg: &egv1a1.EnvoyGateway{
+				EnvoyGatewaySpec: egv1a1.EnvoyGatewaySpec{
+					Gateway:  egv1a1.DefaultGateway(),
+					Provider: egv1a1.DefaultEnvoyGatewayProvider(),
+					XdsServer: &egv1a1.EnvoyGatewayXdsServer{
+						IsolatedGateways: []egv1a1.XdsServerIsolatedGateway{
+							{Namespace: "payments", Name: "checkout", Port: 18020},
+							{Namespace: "payments", Name: "refunds", Port: 18021},
+						},
+					},
+				},
+			},
+			expect: true,
+		},
+		{
+			name: "xds server isolated gateway on the port of an additional controller",
+			eg: &egv1a1.EnvoyGateway{
+				EnvoyGatewaySpec: egv1a1.EnvoyGatewaySpec{
+					Gateway: &egv1a1.Gateway{
+						ControllerName: egv1a1.GatewayControllerName,
+						AdditionalControllers: []egv1a1.GatewayController{
+							{ControllerName: "example.com/internal-gateway", XdsServerPort: ptr.To[int32](18010)},
+						},
+					},
+					Provider: egv1a1.DefaultEnvoyGatewayProvider(),
+					XdsServer: &egv1a1.EnvoyGatewayXdsServer{
+						IsolatedGateways: []egv1a1.XdsServerIsolatedGateway{
+							{Namespace: "payments", Name: "checkout", Port: 18010},
+						},
+					},
+				},
+			},
+			expect: false,
+		},
+		{
+			name: "duplicate xds server isolated gateway",
+			eg: &egv1a1.EnvoyGateway{
+				EnvoyGatewaySpec: egv1a1.EnvoyGatewaySpec{
+					Gateway:  egv1a1.DefaultGateway(),
+					Provider: egv1a1.DefaultEnvoyGatewayProvider(),
+					XdsServer: &egv1a1.EnvoyGatewayXdsServer{
+						IsolatedGateways: []egv1a1.XdsServerIsolatedGateway{
+							{Namespace: "payments", Name: "checkout", Port: 18020},
+							{Namespace: "payments", Name: "checkout", Port: 18021},
+						},
+					},
+				},
+			},
+			expect: false,
+		},
+		{
+			name: "valid hostname delegation",
+			eg: &egv1a1.EnvoyGateway{
+				EnvoyGatewaySpec: egv1a1.EnvoyGatewaySpec{
+					Gateway:  egv1a1.DefaultGateway(),
+					Provider: egv1a1.DefaultEnvoyGatewayProvider(),
+					HostnameDelegation: &egv1a1.EnvoyGatewayHostnameDelegation{
+						Delegations: []egv1a1.HostnameDelegation{
+							{
+								Namespaces: []string{"team-a"},
+								Hostnames:  []gwapiv1.Hostname{"*.team-a.example.com", "team-a.example.com"},
+							},
+						},
+					},
+				},
+			},
+			expect: true,
+		},
+		{
+			name: "hostname delegation without namespaces",
+			eg: &egv1a1.EnvoyGateway{
+				EnvoyGatewaySpec: egv1a1.EnvoyGatewaySpec{
+					Gateway:  egv1a1.DefaultGateway(),
+					Provider: egv1a1.DefaultEnvoyGatewayProvider(),
+					HostnameDelegation: &egv1a1.EnvoyGatewayHostnameDelegation{
+						Delegations: []egv1a1.HostnameDelegation{
+							{
+								Hostnames: []gwapiv1.Hostname{"*.team-a.example.com"},
+							},
+						},
+					},
+				},
+			},
+			expect: false,
+		},
+		{
+			name: "hostname delegation with invalid hostname",
+			eg: &egv1a1.EnvoyGateway{
+				EnvoyGatewaySpec: egv1a1.EnvoyGatewaySpec{
+					Gateway:  egv1a1.DefaultGateway(),
+					Provider: egv1a1.DefaultEnvoyGatewayProvider(),
+					HostnameDelegation: &egv1a1.EnvoyGatewayHostnameDelegation{
+						Delegations: []egv1a1.HostnameDelegation{
+							{
+								Namespaces: []string{"team-a"},
+								Hostnames:  []gwapiv1.Hostname{"team_a.example.com"},
+							},
+						},
+					},
+				},
+			},
+			expect: false,
+		},
+		{
+			name: "valid feature gates",
+			eg: &egv1a1.EnvoyGateway{
+				EnvoyGatewaySpec: egv1a1.EnvoyGatewaySpec{
+					Gateway:  egv1a1.DefaultGateway(),
+					Provider: egv1a1.DefaultEnvoyGatewayProvider(),
+					FeatureGates: map[egv1a1.FeatureGate]bool{
+						egv1a1.FeatureGateHTTP3:         false,
+						egv1a1.FeatureGateMergeGateways: true,
+					},
+				},
+			},
+			expect: true,
+		},
+		{
+			name: "valid snapshot cache",
+			eg: &egv1a1.EnvoyGateway{
+				EnvoyGatewaySpec: egv1a1.EnvoyGatewaySpec{
+					Gateway:  egv1a1.DefaultGateway(),
+					Provider: egv1a1.DefaultEnvoyGatewayProvider(),
+					SnapshotCache: &egv1a1.EnvoyGatewaySnapshotCache{
+						MemoryLimit: ptr.To(resource.MustParse("256Mi")),
+						History:     ptr.To[uint32](20),
+					},
+				},
+			},
+			expect: true,
+		},
+		{
+			name: "snapshot cache without memory limit",
+			eg: &egv1a1.EnvoyGateway{
+				EnvoyGatewaySpec: egv1a1.EnvoyGatewaySpec{
+					Gateway:  egv1a1.DefaultGateway(),
+					Provider: egv1a1.DefaultEnvoyGatewayProvider(),
+					SnapshotCache: &egv1a1.EnvoyGatewaySnapshotCache{
+						History: ptr.To[uint32](20),
+					},
+				},
+			},
+			expect: true,
+		},
+		{
+			name: "snapshot cache with zero memory limit",
+			eg: &egv1a1.EnvoyGateway{
+				EnvoyGatewaySpec: egv1a1.EnvoyGatewaySpec{
+					Gateway:  egv1a1.DefaultGateway(),
+					Provider: egv1a1.DefaultEnvoyGatewayProvider(),
+					SnapshotCache: &egv1a1.EnvoyGatewaySnapshotCache{
+						MemoryLimit: ptr.To(resource.MustParse("0")),
+					},
+				},
+			},
+			expect: false,
+		},
+		{
+			name: "snapshot cache with empty history",
+			eg: &egv1a1.EnvoyGateway{
+				EnvoyGatewaySpec: egv1a1.EnvoyGatewaySpec{
+					Gateway:  egv1a1.DefaultGateway(),
+					Provider: egv1a1.DefaultEnvoyGatewayProvider(),
+					SnapshotCache: &egv1a1.EnvoyGatewaySnapshotCache{
+						History: ptr.To[uint32](0),
+					},
+				},
+			},
+			expect: false,
+		},
+		{
+			name: "snapshot cache with persistence",
+			eg: &egv1a1.EnvoyGateway{
+				EnvoyGatewaySpec: egv1a1.EnvoyGatewaySpec{
+					Gateway:  egv1a1.DefaultGateway(),
+					Provider: egv1a1.DefaultEnvoyGatewayProvider(),
+					SnapshotCache: &egv1a1.EnvoyGatewaySnapshotCache{
+						Persistence: &egv1a1.EnvoyGatewaySnapshotPersistence{
+							Path: "/var/lib/envoy-gateway/snapshots",
+						},
+					},
+				},
+			},
+			expect: true,
+		},
+		{
+			name: "snapshot cache with relative persistence path",
+			eg: &egv1a1.EnvoyGateway{
+				EnvoyGatewaySpec: egv1a1.EnvoyGatewaySpec{
+					Gateway:  egv1a1.DefaultGateway(),
+					Provider: egv1a1.DefaultEnvoyGatewayProvider(),
+					SnapshotCache: &egv1a1.EnvoyGatewaySnapshotCache{
+						Persistence: &egv1a1.EnvoyGatewaySnapshotPersistence{
+							Path: "snapshots",
+						},
+					},
+				},
+			},
+			expect: false,
+		},
+		{
+			name: "valid snapshot cache debounce",
+			eg: &egv1a1.EnvoyGateway{
+				EnvoyGatewaySpec: egv1a1.EnvoyGatewaySpec{
+					Gateway:  egv1a1.DefaultGateway(),
+					Provider: egv1a1.DefaultEnvoyGatewayProvider(),
+					SnapshotCache: &egv1a1.EnvoyGatewaySnapshotCache{
+						Debounce: &egv1a1.EnvoyGatewaySnapshotDebounce{
+							Interval: ptr.To(gwapiv1.Duration("200ms")),
+							MaxDelay: ptr.To(gwapiv1.Duration("2s")),
+						},
+					},
+				},
+			},
+			expect: true,
+		},
+		{
+			name: "snapshot cache debounce with invalid interval",
+			eg: &egv1a1.EnvoyGateway{
+				EnvoyGatewaySpec: egv1a1.EnvoyGatewaySpec{
+					Gateway:  egv1a1.DefaultGateway(),
+					Provider: egv1a1.DefaultEnvoyGatewayProvider(),
+					SnapshotCache: &egv1a1.EnvoyGatewaySnapshotCache{
+						Debounce: &egv1a1.EnvoyGatewaySnapshotDebounce{
+							Interval: ptr.To(gwapiv1.Duration("0s")),
+						},
+					},
+				},
+			},
+			expect: false,
+		},
+		{
+			name: "snapshot cache debounce with max delay shorter than the interval",
+			eg: &egv1a1.EnvoyGateway{
+				EnvoyGatewaySpec: egv1a1.EnvoyGatewaySpec{
+					Gateway:  egv1a1.DefaultGateway(),
+					Provider: egv1a1.DefaultEnvoyGatewayProvider(),
+					SnapshotCache: &egv1a1.EnvoyGatewaySnapshotCache{
+						Debounce: &egv1a1.EnvoyGatewaySnapshotDebounce{
+							Interval: ptr.To(gwapiv1.Duration("2s")),
+							MaxDelay: ptr.To(gwapiv1.Duration("1s")),
+						},
+					},
+				},
+			},
+			expect: false,
+		},
+		{
+			name: "valid snapshot cache resource TTL",
+			eg: &egv1a1.EnvoyGateway{
+				EnvoyGatewaySpec: egv1a1.EnvoyGatewaySpec{
+					Gateway:  egv1a1.DefaultGateway(),
+					Provider: egv1a1.DefaultEnvoyGatewayProvider(),
+					SnapshotCache: &egv1a1.EnvoyGatewaySnapshotCache{
+						ResourceTTL: &egv1a1.EnvoyGatewayResourceTTL{
+							Default: ptr.To(gwapiv1.Duration("5m")),
+							Types: []egv1a1.EnvoyGatewayResourceTypeTTL{
+								{Type: egv1a1.ClusterLoadAssignmentEnvoyResourceType, TTL: "1m"},
+							},
+							HeartbeatInterval: ptr.To(gwapiv1.Duration("20s")),
+						},
+					},
+				},
+			},
+			expect: true,
+		},
+		{
+			name: "snapshot cache resource TTL without TTL",
+			eg: &egv1a1.EnvoyGateway{
+				EnvoyGatewaySpec: egv1a1.EnvoyGatewaySpec{
+					Gateway:  egv1a1.DefaultGateway(),
+					Provider: egv1a1.DefaultEnvoyGatewayProvider(),
+					SnapshotCache: &egv1a1.EnvoyGatewaySnapshotCache{
+						ResourceTTL: &egv1a1.EnvoyGatewayResourceTTL{
+							HeartbeatInterval: ptr.To(gwapiv1.Duration("20s")),
+						},
+					},
+				},
+			},
+			expect: false,
+		},
+		{
+			name: "snapshot cache resource TTL with heartbeat interval longer than a TTL",
+			eg: &egv1a1.EnvoyGateway{
+				EnvoyGatewaySpec: egv1a1.EnvoyGatewaySpec{
+					Gateway:  egv1a1.DefaultGateway(),
+					Provider: egv1a1.DefaultEnvoyGatewayProvider(),
+					SnapshotCache: &egv1a1.EnvoyGatewaySnapshotCache{
+						ResourceTTL: &egv1a1.EnvoyGatewayResourceTTL{
+							Types: []egv1a1.EnvoyGatewayResourceTypeTTL{
+								{Type: egv1a1.ClusterLoadAssignmentEnvoyResourceType, TTL: "1m"},
+							},
+							HeartbeatInterval: ptr.To(gwapiv1.Duration("2m")),
+						},
+					},
+				},
+			},
+			expect: false,
+		},
+		{
+			name: "valid snapshot cache metadata node hash",
+			eg: &egv1a1.EnvoyGateway{
+				EnvoyGatewaySpec: egv1a1.EnvoyGatewaySpec{
+					Gateway:  egv1a1.DefaultGateway(),
+					Provider: egv1a1.DefaultEnvoyGatewayProvider(),
+					SnapshotCache: &egv1a1.EnvoyGatewaySnapshotCache{
+						NodeHash: &egv1a1.EnvoyGatewayNodeHash{
+							Type:        egv1a1.NodeHashTypeMetadata,
+							MetadataKey: ptr.To("zone"),
+						},
+					},
+				},
+			},
+			expect: true,
+		},
+		{
+			name: "snapshot cache metadata node hash without metadata key",
+			eg: &egv1a1.EnvoyGateway{
+				EnvoyGatewaySpec: egv1a1.EnvoyGatewaySpec{
+					Gateway:  egv1a1.DefaultGateway(),
+					Provider: egv1a1.DefaultEnvoyGatewayProvider(),
+					SnapshotCache: &egv1a1.EnvoyGatewaySnapshotCache{
+						NodeHash: &egv1a1.EnvoyGatewayNodeHash{Type: egv1a1.NodeHashTypeMetadata},
+					},
+				},
+			},
+			expect: false,
+		},
+		{
+			name: "snapshot cache cluster node hash with metadata key",
+			eg: &egv1a1.EnvoyGateway{
+				EnvoyGatewaySpec: egv1a1.EnvoyGatewaySpec{
+					Gateway:  egv1a1.DefaultGateway(),
+					Provider: egv1a1.DefaultEnvoyGatewayProvider(),
+					SnapshotCache: &egv1a1.EnvoyGatewaySnapshotCache{
+						NodeHash: &egv1a1.EnvoyGatewayNodeHash{
+							Type:        egv1a1.NodeHashTypeCluster,
+							MetadataKey: ptr.To("zone"),
+						},
+					},
+				},
+			},
+			expect: false,
+		},
+		{
+			name: "snapshot cache zero stale node timeout",
+			eg: &egv1a1.EnvoyGateway{
+				EnvoyGatewaySpec: egv1a1.EnvoyGatewaySpec{
+					Gateway:  egv1a1.DefaultGateway(),
+					Provider: egv1a1.DefaultEnvoyGatewayProvider(),
+					SnapshotCache: &egv1a1.EnvoyGatewaySnapshotCache{
+						StaleNodeTimeout: ptr.To(gwapiv1.Duration("0s")),
+					},
+				},
+			},
+			expect: false,
+		},
+		{
+			name: "valid snapshot cache staged rollout",
+			eg: &egv1a1.EnvoyGateway{
+				EnvoyGatewaySpec: egv1a1.EnvoyGatewaySpec{
+					Gateway:  egv1a1.DefaultGateway(),
+					Provider: egv1a1.DefaultEnvoyGatewayProvider(),
+					SnapshotCache: &egv1a1.EnvoyGatewaySnapshotCache{
+						StagedRollout: &egv1a1.EnvoyGatewayStagedRollout{
+							CanaryPercentage: 10,
+							SoakPeriod:       ptr.To(gwapiv1.Duration("30s")),
+						},
+					},
+				},
+			},
+			expect: true,
+		},
+		{
+			name: "snapshot cache staged rollout to all the proxies",
+			eg: &egv1a1.EnvoyGateway{
+				EnvoyGatewaySpec: egv1a1.EnvoyGatewaySpec{
+					Gateway:  egv1a1.DefaultGateway(),
+					Provider: egv1a1.DefaultEnvoyGatewayProvider(),
+					SnapshotCache: &egv1a1.EnvoyGatewaySnapshotCache{
+						StagedRollout: &egv1a1.EnvoyGatewayStagedRollout{
+							CanaryPercentage: 100,
+						},
+					},
+				},
+			},
+			expect: false,
+		},
+		{
+			name: "invalid snapshot cache staged rollout soak period",
+			eg: &egv1a1.EnvoyGateway{
+				EnvoyGatewaySpec: egv1a1.EnvoyGatewaySpec{
+					Gateway:  egv1a1.DefaultGateway(),
+					Provider: egv1a1.DefaultEnvoyGatewayProvider(),
+					SnapshotCache: &egv1a1.EnvoyGatewaySnapshotCache{
+						StagedRollout: &egv1a1.EnvoyGatewayStagedRollout{
+							CanaryPercentage: 10,
+							SoakPeriod:       ptr.To(gwapiv1.Duration("0s")),
+						},
+					},
+				},
+			},
+			expect: false,
+		},
+		{
+			name: "valid snapshot cache push pacing",
+			eg: &egv1a1.EnvoyGateway{
+				EnvoyGatewaySpec: egv1a1.EnvoyGatewaySpec{
+					Gateway:  egv1a1.DefaultGateway(),
+					Provider: egv1a1.DefaultEnvoyGatewayProvider(),
+					SnapshotCache: &egv1a1.EnvoyGatewaySnapshotCache{
+						PushPacing: &egv1a1.EnvoyGatewayPushPacing{
+							MaxNodeUpdatesPerSecond: 50,
+							Jitter:                  ptr.To(gwapiv1.Duration("20ms")),
+						},
+					},
+				},
+			},
+			expect: true,
+		},
+		{
+			name: "invalid snapshot cache push pacing rate",
+			eg: &egv1a1.EnvoyGateway{
+				EnvoyGatewaySpec: egv1a1.EnvoyGatewaySpec{
+					Gateway:  egv1a1.DefaultGateway(),
+					Provider: egv1a1.DefaultEnvoyGatewayProvider(),
+					SnapshotCache: &egv1a1.EnvoyGatewaySnapshotCache{
+						PushPacing: &egv1a1.EnvoyGatewayPushPacing{},
+					},
+				},
+			},
+			expect: false,
+		},
+		{
+			name: "invalid snapshot cache drift detection window",
+			eg: &egv1a1.EnvoyGateway{
+				EnvoyGatewaySpec: egv1a1.EnvoyGatewaySpec{
+					Gateway:  egv1a1.DefaultGateway(),
+					Provider: egv1a1.DefaultEnvoyGatewayProvider(),
+					SnapshotCache: &egv1a1.EnvoyGatewaySnapshotCache{
+						DriftDetection: &egv1a1.EnvoyGatewayDriftDetection{
+							Window: ptr.To(gwapiv1.Duration("5")),
+						},
+					},
+				},
+			},
+			expect: false,
+		},
+		{
+			name: "valid xds server",
+			eg: &egv1a1.EnvoyGateway{
+				EnvoyGatewaySpec: egv1a1.EnvoyGatewaySpec{
+					Gateway:  egv1a1.DefaultGateway(),
+					Provider: egv1a1.DefaultEnvoyGatewayProvider(),
+					XdsServer: &egv1a1.EnvoyGatewayXdsServer{
+						MaxRecvMessageSize:   ptr.To(resource.MustParse("16Mi")),
+						MaxSendMessageSize:   ptr.To(resource.MustParse("64Mi")),
+						MaxConcurrentStreams: ptr.To[uint32](100),
+						Keepalive: &egv1a1.XdsServerKeepalive{
+							Time:    ptr.To(gwapiv1.Duration("30s")),
+							Timeout: ptr.To(gwapiv1.Duration("5s")),
+						},
+					},
+				},
+			},
+			expect: true,
+		},
+		{
+			name: "xds server message size too large",
+			eg: &egv1a1.EnvoyGateway{
+				EnvoyGatewaySpec: egv1a1.EnvoyGatewaySpec{
+					Gateway:  egv1a1.DefaultGateway(),
+					Provider: egv1a1.DefaultEnvoyGatewayProvider(),
+					XdsServer: &egv1a1.EnvoyGatewayXdsServer{
+						MaxSendMessageSize: ptr.To(resource.MustParse("4Gi")),
+					},
+				},
+			},
+			expect: false,
+		},
+		{
+			name: "xds server invalid keepalive time",
+			eg: &egv1a1.EnvoyGateway{
+				EnvoyGatewaySpec: egv1a1.EnvoyGatewaySpec{
+					Gateway:  egv1a1.DefaultGateway(),
+					Provider: egv1a1.DefaultEnvoyGatewayProvider(),
+					XdsServer: &egv1a1.EnvoyGatewayXdsServer{
+						Keepalive: &egv1a1.XdsServerKeepalive{
+							Time: ptr.To(gwapiv1.Duration("0s")),
+						},
+					},
+				},
+			},
+			expect: false,
+		},
+		{
+			name: "xds server valid anomaly detection",
+			eg: &egv1a1.EnvoyGateway{
+				EnvoyGatewaySpec: egv1a1.EnvoyGatewaySpec{
+					Gateway:  egv1a1.DefaultGateway(),
+					Provider: egv1a1.DefaultEnvoyGatewayProvider(),
+					XdsServer: &egv1a1.EnvoyGatewayXdsServer{
+						AnomalyDetection: &egv1a1.XdsAnomalyDetection{
+							Host:           "detector.monitoring",
+							Port:           9000,
+							Interval:       ptr.To(gwapiv1.Duration("5s")),
+							DegradationTTL: ptr.To(gwapiv1.Duration("2m")),
+						},
+					},
+				},
+			},
+			expect: true,
+		},
+		{
+			name: "xds server anomaly detection without host",
+			eg: &egv1a1.EnvoyGateway{
+				EnvoyGatewaySpec: egv1a1.EnvoyGatewaySpec{
+					Gateway:  egv1a1.DefaultGateway(),
+					Provider: egv1a1.DefaultEnvoyGatewayProvider(),
+					XdsServer: &egv1a1.EnvoyGatewayXdsServer{
+						AnomalyDetection: &egv1a1.XdsAnomalyDetection{
+							Port: 9000,
+						},
+					},
+				},
+			},
+			expect: false,
+		},
+		{
+			name: "xds server anomaly detection invalid degradation ttl",
+			eg: &egv1a1.EnvoyGateway{
+				EnvoyGatewaySpec: egv1a1.EnvoyGatewaySpec{
+					Gateway:  egv1a1.DefaultGateway(),
+					Provider: egv1a1.DefaultEnvoyGatewayProvider(),
+					XdsServer: &egv1a1.EnvoyGatewayXdsServer{
+						AnomalyDetection: &egv1a1.XdsAnomalyDetection{
+							Host:           "detector.monitoring",
+							Port:           9000,
+							DegradationTTL: ptr.To(gwapiv1.Duration("0s")),
+						},
+					},
+				},
+			},
+			expect: false,
+		},
+		{
+			name: "xds server invalid send timeout",
+			eg: &egv1a1.EnvoyGateway{
+				EnvoyGatewaySpec: egv1a1.EnvoyGatewaySpec{
+					Gateway:  egv1a1.DefaultGateway(),
+					Provider: egv1a1.DefaultEnvoyGatewayProvider(),
+					XdsServer: &egv1a1.EnvoyGatewayXdsServer{
+						SendTimeout: ptr.To(gwapiv1.Duration("-1s")),
+					},
+				},
+			},
+			expect: false,
+		},
+		{
+			name: "xds server invalid drain timeout",
+			eg: &egv1a1.EnvoyGateway{
+				EnvoyGatewaySpec: egv1a1.EnvoyGatewaySpec{
+					Gateway:  egv1a1.DefaultGateway(),
+					Provider: egv1a1.DefaultEnvoyGatewayProvider(),
+					XdsServer: &egv1a1.EnvoyGatewayXdsServer{
+						DrainTimeout: ptr.To(gwapiv1.Duration("0s")),
+					},
+				},
+			},
+			expect: false,
+		},
+		{
+			name: "valid xds server audit log",
+			eg: &egv1a1.EnvoyGateway{
+				EnvoyGatewaySpec: egv1a1.EnvoyGatewaySpec{
+					Gateway:  egv1a1.DefaultGateway(),
+					Provider: egv1a1.DefaultEnvoyGatewayProvider(),
+					XdsServer: &egv1a1.EnvoyGatewayXdsServer{
+						AuditLog: &egv1a1.XdsAuditLog{
+							Sink: egv1a1.XdsAuditLogSink{
+								Type: egv1a1.XdsAuditLogSinkTypeFile,
+								File: &egv1a1.XdsAuditLogFileSink{Path: "/var/log/eg/xds-audit.log"},
+							},
+							SamplingPercent: ptr.To[uint32](10),
+						},
+					},
+				},
+			},
+			expect: true,
+		},
+		{
+			name: "xds server audit log sink without its settings",
+			eg: &egv1a1.EnvoyGateway{
+				EnvoyGatewaySpec: egv1a1.EnvoyGatewaySpec{
+					Gateway:  egv1a1.DefaultGateway(),
+					Provider: egv1a1.DefaultEnvoyGatewayProvider(),
+					XdsServer: &egv1a1.EnvoyGatewayXdsServer{
+						AuditLog: &egv1a1.XdsAuditLog{
+							Sink: egv1a1.XdsAuditLogSink{Type: egv1a1.XdsAuditLogSinkTypeOpenTelemetry},
+						},
+					},
+				},
+			},
+			expect: false,
+		},
+		{
+			name: "xds server audit log invalid sampling",
+			eg: &egv1a1.EnvoyGateway{
+				EnvoyGatewaySpec: egv1a1.EnvoyGatewaySpec{
+					Gateway:  egv1a1.DefaultGateway(),
+					Provider: egv1a1.DefaultEnvoyGatewayProvider(),
+					XdsServer: &egv1a1.EnvoyGatewayXdsServer{
+						AuditLog: &egv1a1.XdsAuditLog{
+							Sink:            egv1a1.XdsAuditLogSink{Type: egv1a1.XdsAuditLogSinkTypeStdout},
+							SamplingPercent: ptr.To[uint32](150),
+						},
+					},
+				},
+			},
+			expect: false,
+		},
+		{
+			name: "valid xds server node authorization",
+			eg: &egv1a1.EnvoyGateway{
+				EnvoyGatewaySpec: egv1a1.EnvoyGatewaySpec{
+					Gateway:  egv1a1.DefaultGateway(),
+					Provider: egv1a1.DefaultEnvoyGatewayProvider(),
+					XdsServer: &egv1a1.EnvoyGatewayXdsServer{
+						NodeAuthorization: &egv1a1.XdsNodeAuthorization{
+							Type: egv1a1.XdsNodeAuthenticationTypeServiceAccountToken,
+							ServiceAccountToken: &egv1a1.XdsNodeServiceAccountToken{
+								Audiences: []string{"envoy-gateway"},
+							},
+						},
+					},
+				},
+			},
+			expect: true,
+		},
+		{
+			name: "xds server node authorization with settings of another type",
+			eg: &egv1a1.EnvoyGateway{
+				EnvoyGatewaySpec: egv1a1.EnvoyGatewaySpec{
+					Gateway:  egv1a1.DefaultGateway(),
+					Provider: egv1a1.DefaultEnvoyGatewayProvider(),
+					XdsServer: &egv1a1.EnvoyGatewayXdsServer{
+						NodeAuthorization: &egv1a1.XdsNodeAuthorization{
+							Type:                egv1a1.XdsNodeAuthenticationTypeClientCertificate,
+							ServiceAccountToken: &egv1a1.XdsNodeServiceAccountToken{},
+						},
+					},
+				},
+			},
+			expect: false,
+		},
+		{
+			name: "valid bulk import",
+			eg: &egv1a1.EnvoyGateway{
+				EnvoyGatewaySpec: egv1a1.EnvoyGatewaySpec{
+					Gateway:  egv1a1.DefaultGateway(),
+					Provider: egv1a1.DefaultEnvoyGatewayProvider(),
+					BulkImport: &egv1a1.EnvoyGatewayBulkImport{
+						Threshold: ptr.To[uint32](2000),
+						BatchSize: ptr.To[uint32](250),
+					},
+				},
+			},
+			expect: true,
+		},
+		{
+			name: "bulk import empty batch size",
+			eg: &egv1a1.EnvoyGateway{
+				EnvoyGatewaySpec: egv1a1.EnvoyGatewaySpec{
+					Gateway:  egv1a1.DefaultGateway(),
+					Provider: egv1a1.DefaultEnvoyGatewayProvider(),
+					BulkImport: &egv1a1.EnvoyGatewayBulkImport{
+						BatchSize: ptr.To[uint32](0),
+					},
+				},
+			},
+			expect: false,
+		},
+		{
+			name: "valid shadow translation",
+			eg: &egv1a1.EnvoyGateway{
+				EnvoyGatewaySpec: egv1a1.EnvoyGatewaySpec{
+					Gateway:  egv1a1.DefaultGateway(),
+					Provider: egv1a1.DefaultEnvoyGatewayProvider(),
+					ShadowTranslation: &egv1a1.EnvoyGatewayShadowTranslation{
+						Translator: egv1a1.ShadowTranslatorFull,
+					},
+				},
+			},
+			expect: true,
+		},
+		{
+			name: "unknown shadow translator",
+			eg: &egv1a1.EnvoyGateway{
+				EnvoyGatewaySpec: egv1a1.EnvoyGatewaySpec{
+					Gateway:  egv1a1.DefaultGateway(),
+					Provider: egv1a1.DefaultEnvoyGatewayProvider(),
+					ShadowTranslation: &egv1a1.EnvoyGatewayShadowTranslation{
+						Translator: "Incremental",
+					},
+				},
+			},
+			expect: false,
+		},
+		{
+			name: "valid watchable queues",
+			eg: &egv1a1.EnvoyGateway{
+				EnvoyGatewaySpec: egv1a1.EnvoyGatewaySpec{
+					Gateway:  egv1a1.DefaultGateway(),
+					Provider: egv1a1.DefaultEnvoyGatewayProvider(),
+					Watchable: &egv1a1.EnvoyGatewayWatchable{
+						Queues: []egv1a1.WatchableQueue{
+							{Message: "xds-ir", Capacity: 100, OverflowPolicy: ptr.To(egv1a1.WatchableOverflowPolicyCoalesceByKey)},
+							{Message: "xds", Capacity: 10},
+							{Message: "infra-ir", Coalesce: ptr.To(true)},
+						},
+					},
+				},
+			},
+			expect: true,
+		},
+		{
+			name: "watchable queue without capacity",
+			eg: &egv1a1.EnvoyGateway{
+				EnvoyGatewaySpec: egv1a1.EnvoyGatewaySpec{
+					Gateway:  egv1a1.DefaultGateway(),
+					Provider: egv1a1.DefaultEnvoyGatewayProvider(),
+					Watchable: &egv1a1.EnvoyGatewayWatchable{
+						Queues: []egv1a1.WatchableQueue{
+							{Message: "xds-ir", Capacity: 0},
+						},
+					},
+				},
+			},
+			expect: false,
+		},
+		{
+			name: "duplicate watchable queue",
+			eg: &egv1a1.EnvoyGateway{
+				EnvoyGatewaySpec: egv1a1.EnvoyGatewaySpec{
+					Gateway:  egv1a1.DefaultGateway(),
+					Provider: egv1a1.DefaultEnvoyGatewayProvider(),
+					Watchable: &egv1a1.EnvoyGatewayWatchable{
+						Queues: []egv1a1.WatchableQueue{
+							{Message: "xds-ir", Capacity: 10},
+							{Message: "xds-ir", Capacity: 20},
+						},
+					},
+				},
+			},
+			expect: false,
+		},
+		{
+			name: "unknown watchable overflow policy",
+			eg: &egv1a1.EnvoyGateway{
+				EnvoyGatewaySpec: egv1a1.EnvoyGatewaySpec{
+					Gateway:  egv1a1.DefaultGateway(),
+					Provider: egv1a1.DefaultEnvoyGatewayProvider(),
+					Watchable: &egv1a1.EnvoyGatewayWatchable{
+						Queues: []egv1a1.WatchableQueue{
+							{Message: "xds-ir", Capacity: 10, OverflowPolicy: ptr.To(egv1a1.WatchableOverflowPolicy("DropNewest"))},
+						},
+					},
+				},
+			},
+			expect: false,
+		},
+		{
+			name: "valid watchable retries",
+			eg: &egv1a1.EnvoyGateway{
+				EnvoyGatewaySpec: egv1a1.EnvoyGatewaySpec{
+					Gateway:  egv1a1.DefaultGateway(),
+					Provider: egv1a1.DefaultEnvoyGatewayProvider(),
+					Watchable: &egv1a1.EnvoyGatewayWatchable{
+						Retries: []egv1a1.WatchableRetry{
+							{Message: "infra-ir", MaxAttempts: ptr.To[uint32](5), Backoff: ptr.To(gwapiv1.Duration("1s")), MaxBackoff: ptr.To(gwapiv1.Duration("1m"))},
+							{Message: "xds", DeadLetterCapacity: ptr.To[uint32](10)},
+						},
+					},
+				},
+			},
+			expect: true,
+		},
+		{
+			name: "duplicate watchable retry",
+			eg: &egv1a1.EnvoyGateway{
+				EnvoyGatewaySpec: egv1a1.EnvoyGatewaySpec{
+					Gateway:  egv1a1.DefaultGateway(),
+					Provider: egv1a1.DefaultEnvoyGatewayProvider(),
+					Watchable: &egv1a1.EnvoyGatewayWatchable{
+						Retries: []egv1a1.WatchableRetry{
+							{Message: "infra-ir"},
+							{Message: "infra-ir", MaxAttempts: ptr.To[uint32](5)},
+						},
+					},
+				},
+			},
+			expect: false,
+		},
+		{
+			name: "watchable retry backoff greater than maxBackoff",
+			eg: &egv1a1.EnvoyGateway{
+				EnvoyGatewaySpec: egv1a1.EnvoyGatewaySpec{
+					Gateway:  egv1a1.DefaultGateway(),
+					Provider: egv1a1.DefaultEnvoyGatewayProvider(),
+					Watchable: &egv1a1.EnvoyGatewayWatchable{
+						Retries: []egv1a1.WatchableRetry{
+							{Message: "infra-ir", Backoff: ptr.To(gwapiv1.Duration("1m")), MaxBackoff: ptr.To(gwapiv1.Duration("1s"))},
+						},
+					},
+				},
+			},
+			expect: false,
+		},
+		{
+			name: "valid watchable tracing",
+			eg: &egv1a1.EnvoyGateway{
+				EnvoyGatewaySpec: egv1a1.EnvoyGatewaySpec{
+					Gateway:  egv1a1.DefaultGateway(),
+					Provider: egv1a1.DefaultEnvoyGatewayProvider(),
+					Watchable: &egv1a1.EnvoyGatewayWatchable{
+						Tracing: &egv1a1.WatchableTracing{
+							Host:         "otel-collector.monitoring",
+							Port:         ptr.To[int32](4317),
+							SamplingRate: ptr.To[uint32](10),
+						},
+					},
+				},
+			},
+			expect: true,
+		},
+		{
+			name: "watchable tracing without host",
+			eg: &egv1a1.EnvoyGateway{
+				EnvoyGatewaySpec: egv1a1.EnvoyGatewaySpec{
+					Gateway:  egv1a1.DefaultGateway(),
+					Provider: egv1a1.DefaultEnvoyGatewayProvider(),
+					Watchable: &egv1a1.EnvoyGatewayWatchable{
+						Tracing: &egv1a1.WatchableTracing{},
+					},
+				},
+			},
+			expect: false,
+		},
+		{
+			name: "watchable tracing sampling rate greater than 100",
+			eg: &egv1a1.EnvoyGateway{
+				EnvoyGatewaySpec: egv1a1.EnvoyGatewaySpec{
+					Gateway:  egv1a1.DefaultGateway(),
+					Provider: egv1a1.DefaultEnvoyGatewayProvider(),
+					Watchable: &egv1a1.EnvoyGatewayWatchable{
+						Tracing: &egv1a1.WatchableTracing{
+							Host:         "otel-collector.monitoring",
+							SamplingRate: ptr.To[uint32](200),
+						},
+					},
+				},
+			},
+			expect: false,
+		},
+		{
+			name: "valid canary rollout",
+			eg: &egv1a1.EnvoyGateway{
+				EnvoyGatewaySpec: egv1a1.EnvoyGatewaySpec{
+					Gateway:  egv1a1.DefaultGateway(),
+					Provider: egv1a1.DefaultEnvoyGatewayProvider(),
+					CanaryRollout: &egv1a1.EnvoyGatewayCanaryRollout{
+						PrometheusURL: "http://prometheus.monitoring:9090",
+					},
+				},
+			},
+			expect: true,
+		},
+		{
+			name: "canary rollout with invalid prometheus URL",
+			eg: &egv1a1.EnvoyGateway{
+				EnvoyGatewaySpec: egv1a1.EnvoyGatewaySpec{
+					Gateway:  egv1a1.DefaultGateway(),
+					Provider: egv1a1.DefaultEnvoyGatewayProvider(),
+					CanaryRollout: &egv1a1.EnvoyGatewayCanaryRollout{
+						PrometheusURL: "prometheus:9090",
+					},
+				},
+			},
+			expect: false,
+		},
+		{
+			name: "canary rollout with custom provider",
+			eg: &egv1a1.EnvoyGateway{
+				EnvoyGatewaySpec: egv1a1.EnvoyGatewaySpec{
+					Gateway: egv1a1.DefaultGateway(),
+					Provider: &egv1a1.EnvoyGatewayProvider{
+						Type: egv1a1.ProviderTypeCustom,
+						Custom: &egv1a1.EnvoyGatewayCustomProvider{
+							Resource: egv1a1.EnvoyGatewayResourceProvider{
+								Type: egv1a1.ResourceProviderTypeFile,
+								File: &egv1a1.EnvoyGatewayFileResourceProvider{
+									Paths: []string{"foo", "bar"},
+								},
+							},
+							Infrastructure: &egv1a1.EnvoyGatewayInfrastructureProvider{
+								Type: egv1a1.InfrastructureProviderTypeHost,
+								Host: &egv1a1.EnvoyGatewayHostInfrastructureProvider{},
+							},
+						},
+					},
+					CanaryRollout: &egv1a1.EnvoyGatewayCanaryRollout{
+						PrometheusURL: "http://prometheus.monitoring:9090",
+					},
+				},
+			},
+			expect: false,
+		},
+		{
+			name: "unknown feature gate",
+			eg: &egv1a1.EnvoyGateway{
+				EnvoyGatewaySpec: egv1a1.EnvoyGatewaySpec{
+					Gateway:  egv1a1.DefaultGateway(),
+					Provider: egv1a1.DefaultEnvoyGatewayProvider(),
+					FeatureGates: map[egv1a1.FeatureGate]bool{
+						"VHDS": true,
+					},
+				},
+			},
+			expect: false,
+		},
 		{
 			name: "invalid gateway watch mode",
 			eg: &egv1a1.EnvoyGateway{
@@ -835,6 +1916,64 @@ func TestValidateEnvoyGateway(t *testing.T) {
 	}
 }
 
+func TestValidateEnvoyProxyNodeHash(t *testing.T) {
+	testCases := []struct {
+		name     string
+		nodeHash *egv1a1.EnvoyGatewayNodeHash
+		proxy    *egv1a1.EnvoyProxy
+		expect   bool
+	}{
+		{
+			name:   "node group without node hash",
+			proxy:  &egv1a1.EnvoyProxy{Spec: egv1a1.EnvoyProxySpec{NodeGroup: ptr.To("canary")}},
+			expect: true,
+		},
+		{
+			name:     "node group with node ID node hash",
+			nodeHash: &egv1a1.EnvoyGatewayNodeHash{Type: egv1a1.NodeHashTypeNodeID},
+			proxy:    &egv1a1.EnvoyProxy{Spec: egv1a1.EnvoyProxySpec{NodeGroup: ptr.To("canary")}},
+			expect:   true,
+		},
+		{
+			name:     "no node group with cluster node hash",
+			nodeHash: &egv1a1.EnvoyGatewayNodeHash{Type: egv1a1.NodeHashTypeCluster},
+			proxy:    &egv1a1.EnvoyProxy{},
+			expect:   true,
+		},
+		{
+			name:     "node group with cluster node hash",
+			nodeHash: &egv1a1.EnvoyGatewayNodeHash{Type: egv1a1.NodeHashTypeCluster},
+			proxy:    &egv1a1.EnvoyProxy{Spec: egv1a1.EnvoyProxySpec{NodeGroup: ptr.To("canary")}},
+			expect:   false,
+		},
+		{
+			name: "node group with metadata node hash",
+			nodeHash: &egv1a1.EnvoyGatewayNodeHash{
+				Type:        egv1a1.NodeHashTypeMetadata,
+				MetadataKey: ptr.To("zone"),
+			},
+			proxy:  &egv1a1.EnvoyProxy{Spec: egv1a1.EnvoyProxySpec{NodeGroup: ptr.To("canary")}},
+			expect: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			eg := &egv1a1.EnvoyGateway{
+				EnvoyGatewaySpec: egv1a1.EnvoyGatewaySpec{
+					SnapshotCache: &egv1a1.EnvoyGatewaySnapshotCache{NodeHash: tc.nodeHash},
+				},
+			}
+			err := ValidateEnvoyProxyNodeHash(eg, tc.proxy)
+			if !tc.expect {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
 func TestEnvoyGateway(t *testing.T) {
 	envoyGateway := egv1a1.DefaultEnvoyGateway()
 	assert.NotNil(t, envoyGateway.Provider)
@@ -10,6 +10,7 @@ import (
 	"fmt"
 	"net"
 	"net/netip"
+	"slices"
 
 	"github.com/dominikbraun/graph"
 	utilerrors "k8s.io/apimachinery/pkg/util/errors"
@@ -58,9 +59,26 @@ func validateEnvoyProxySpec(spec *egv1a1.EnvoyProxySpec) error {
 		}
 	}
 
+	// validate runtime flags
+	if spec != nil && spec.RuntimeFlags != nil {
+		if err := validateRuntimeFlags(spec.RuntimeFlags); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
 	return utilerrors.NewAggregate(errs)
 }
 
+// TODO: remove this function if CEL validation became stable
+func validateRuntimeFlags(flags *egv1a1.ProxyRuntimeFlags) error {
+	for _, flag := range flags.Enabled {
+		if slices.Contains(flags.Disabled, flag) {
+			return fmt.Errorf("runtime flag %s can't be both enabled and disabled", flag)
+		}
+	}
+	return nil
+}
+
 // TODO: remove this function if CEL validation became stable
 func validateProvider(spec *egv1a1.EnvoyProxySpec) []error {
 	var errs []error
@@ -569,6 +569,38 @@ func TestValidateEnvoyProxy(t *testing.T) {
 			},
 			expected: false,
 		},
+		{
+			name: "valid runtime flags",
+			proxy: &egv1a1.EnvoyProxy{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "test",
+					Name:      "test",
+				},
+				Spec: egv1a1.EnvoyProxySpec{
+					RuntimeFlags: &egv1a1.ProxyRuntimeFlags{
+						Enabled:  []egv1a1.RuntimeFlag{egv1a1.RuntimeFlagHTTP1UseBalsaParser},
+						Disabled: []egv1a1.RuntimeFlag{egv1a1.RuntimeFlagHTTP2UseOghttp2},
+					},
+				},
+			},
+			expected: true,
+		},
+		{
+			name: "invalid runtime flag both enabled and disabled",
+			proxy: &egv1a1.EnvoyProxy{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "test",
+					Name:      "test",
+				},
+				Spec: egv1a1.EnvoyProxySpec{
+					RuntimeFlags: &egv1a1.ProxyRuntimeFlags{
+						Enabled:  []egv1a1.RuntimeFlag{egv1a1.RuntimeFlagHTTP1UseBalsaParser},
+						Disabled: []egv1a1.RuntimeFlag{egv1a1.RuntimeFlagHTTP1UseBalsaParser},
+					},
+				},
+			},
+			expected: false,
+		},
 	}
 
 	for i := range testCases {
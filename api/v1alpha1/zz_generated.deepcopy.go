@@ -76,6 +76,26 @@ func (in *ALSEnvoyProxyHTTPAccessLogConfig) DeepCopy() *ALSEnvoyProxyHTTPAccessL
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AWSSigV4RequestSigning) DeepCopyInto(out *AWSSigV4RequestSigning) {
+	*out = *in
+	if in.UnsignedPayload != nil {
+		in, out := &in.UnsignedPayload, &out.UnsignedPayload
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AWSSigV4RequestSigning.
+func (in *AWSSigV4RequestSigning) DeepCopy() *AWSSigV4RequestSigning {
+	if in == nil {
+		return nil
+	}
+	out := new(AWSSigV4RequestSigning)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ActiveHealthCheck) DeepCopyInto(out *ActiveHealthCheck) {
 	*out = *in
@@ -296,6 +316,16 @@ func (in *BackendConnection) DeepCopyInto(out *BackendConnection) {
 		x := (*in).DeepCopy()
 		*out = &x
 	}
+	if in.Preconnect != nil {
+		in, out := &in.Preconnect, &out.Preconnect
+		*out = new(PreconnectPolicy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.HappyEyeballs != nil {
+		in, out := &in.HappyEyeballs, &out.HappyEyeballs
+		*out = new(HappyEyeballs)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackendConnection.
@@ -401,6 +431,11 @@ func (in *BackendSpec) DeepCopyInto(out *BackendSpec) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.OriginalDestination != nil {
+		in, out := &in.OriginalDestination, &out.OriginalDestination
+		*out = new(OriginalDestination)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.AppProtocols != nil {
 		in, out := &in.AppProtocols, &out.AppProtocols
 		*out = make([]AppProtocolType, len(*in))
@@ -545,6 +580,16 @@ func (in *BackendTrafficPolicySpec) DeepCopyInto(out *BackendTrafficPolicySpec)
 		*out = new(bool)
 		**out = **in
 	}
+	if in.Experiment != nil {
+		in, out := &in.Experiment, &out.Experiment
+		*out = new(Experiment)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.HeaderToMetadata != nil {
+		in, out := &in.HeaderToMetadata, &out.HeaderToMetadata
+		*out = new(HeaderToMetadata)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Compression != nil {
 		in, out := &in.Compression, &out.Compression
 		*out = make([]*Compression, len(*in))
@@ -567,6 +612,31 @@ func (in *BackendTrafficPolicySpec) DeepCopyInto(out *BackendTrafficPolicySpec)
 			}
 		}
 	}
+	if in.GRPCStatusMapping != nil {
+		in, out := &in.GRPCStatusMapping, &out.GRPCStatusMapping
+		*out = new(GRPCStatusMapping)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ResponseBuffer != nil {
+		in, out := &in.ResponseBuffer, &out.ResponseBuffer
+		*out = new(ResponseBuffer)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.RequestSigning != nil {
+		in, out := &in.RequestSigning, &out.RequestSigning
+		*out = new(RequestSigning)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.CredentialInjection != nil {
+		in, out := &in.CredentialInjection, &out.CredentialInjection
+		*out = new(CredentialInjection)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.CanaryRollout != nil {
+		in, out := &in.CanaryRollout, &out.CanaryRollout
+		*out = new(CanaryRollout)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackendTrafficPolicySpec.
@@ -595,6 +665,27 @@ func (in *BasicAuth) DeepCopy() *BasicAuth {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BearerTokenCredential) DeepCopyInto(out *BearerTokenCredential) {
+	*out = *in
+	in.Token.DeepCopyInto(&out.Token)
+	if in.Header != nil {
+		in, out := &in.Header, &out.Header
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BearerTokenCredential.
+func (in *BearerTokenCredential) DeepCopy() *BearerTokenCredential {
+	if in == nil {
+		return nil
+	}
+	out := new(BearerTokenCredential)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CORS) DeepCopyInto(out *CORS) {
 	*out = *in
@@ -640,6 +731,52 @@ func (in *CORS) DeepCopy() *CORS {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CanaryGuardrail) DeepCopyInto(out *CanaryGuardrail) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CanaryGuardrail.
+func (in *CanaryGuardrail) DeepCopy() *CanaryGuardrail {
+	if in == nil {
+		return nil
+	}
+	out := new(CanaryGuardrail)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CanaryRollout) DeepCopyInto(out *CanaryRollout) {
+	*out = *in
+	in.BackendRef.DeepCopyInto(&out.BackendRef)
+	if in.Steps != nil {
+		in, out := &in.Steps, &out.Steps
+		*out = make([]uint32, len(*in))
+		copy(*out, *in)
+	}
+	if in.Interval != nil {
+		in, out := &in.Interval, &out.Interval
+		*out = new(apisv1.Duration)
+		**out = **in
+	}
+	if in.Guardrails != nil {
+		in, out := &in.Guardrails, &out.Guardrails
+		*out = make([]CanaryGuardrail, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CanaryRollout.
+func (in *CanaryRollout) DeepCopy() *CanaryRollout {
+	if in == nil {
+		return nil
+	}
+	out := new(CanaryRollout)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CircuitBreaker) DeepCopyInto(out *CircuitBreaker) {
 	*out = *in
@@ -668,6 +805,11 @@ func (in *CircuitBreaker) DeepCopyInto(out *CircuitBreaker) {
 		*out = new(int64)
 		**out = **in
 	}
+	if in.PerEndpoint != nil {
+		in, out := &in.PerEndpoint, &out.PerEndpoint
+		*out = new(PerEndpointCircuitBreakers)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CircuitBreaker.
@@ -703,6 +845,11 @@ func (in *ClientConnection) DeepCopyInto(out *ClientConnection) {
 		*out = new(ConnectionLimit)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.ConnectionRateLimit != nil {
+		in, out := &in.ConnectionRateLimit, &out.ConnectionRateLimit
+		*out = new(ConnectionRateLimit)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.BufferLimit != nil {
 		in, out := &in.BufferLimit, &out.BufferLimit
 		x := (*in).DeepCopy()
@@ -713,6 +860,11 @@ func (in *ClientConnection) DeepCopyInto(out *ClientConnection) {
 		x := (*in).DeepCopy()
 		*out = &x
 	}
+	if in.SocketOptions != nil {
+		in, out := &in.SocketOptions, &out.SocketOptions
+		*out = new(ClientSocketOptions)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClientConnection.
@@ -750,6 +902,36 @@ func (in *ClientIPDetectionSettings) DeepCopy() *ClientIPDetectionSettings {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClientSocketOptions) DeepCopyInto(out *ClientSocketOptions) {
+	*out = *in
+	if in.NoDelay != nil {
+		in, out := &in.NoDelay, &out.NoDelay
+		*out = new(bool)
+		**out = **in
+	}
+	if in.ReusePort != nil {
+		in, out := &in.ReusePort, &out.ReusePort
+		*out = new(bool)
+		**out = **in
+	}
+	if in.DSCP != nil {
+		in, out := &in.DSCP, &out.DSCP
+		*out = new(uint32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClientSocketOptions.
+func (in *ClientSocketOptions) DeepCopy() *ClientSocketOptions {
+	if in == nil {
+		return nil
+	}
+	out := new(ClientSocketOptions)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ClientTLSSettings) DeepCopyInto(out *ClientTLSSettings) {
 	*out = *in
@@ -759,6 +941,16 @@ func (in *ClientTLSSettings) DeepCopyInto(out *ClientTLSSettings) {
 		(*in).DeepCopyInto(*out)
 	}
 	in.TLSSettings.DeepCopyInto(&out.TLSSettings)
+	if in.SessionResumption != nil {
+		in, out := &in.SessionResumption, &out.SessionResumption
+		*out = new(SessionResumption)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.NoSNIMatch != nil {
+		in, out := &in.NoSNIMatch, &out.NoSNIMatch
+		*out = new(NoSNIMatch)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClientTLSSettings.
@@ -917,7 +1109,7 @@ func (in *ClientTrafficPolicySpec) DeepCopyInto(out *ClientTrafficPolicySpec) {
 	if in.HealthCheck != nil {
 		in, out := &in.HealthCheck, &out.HealthCheck
 		*out = new(HealthCheckSettings)
-		**out = **in
+		(*in).DeepCopyInto(*out)
 	}
 }
 
@@ -1058,6 +1250,26 @@ func (in *ConnectionLimit) DeepCopy() *ConnectionLimit {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ConnectionRateLimit) DeepCopyInto(out *ConnectionRateLimit) {
+	*out = *in
+	if in.Burst != nil {
+		in, out := &in.Burst, &out.Burst
+		*out = new(uint32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConnectionRateLimit.
+func (in *ConnectionRateLimit) DeepCopy() *ConnectionRateLimit {
+	if in == nil {
+		return nil
+	}
+	out := new(ConnectionRateLimit)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ConsistentHash) DeepCopyInto(out *ConsistentHash) {
 	*out = *in
@@ -1115,6 +1327,36 @@ func (in *Cookie) DeepCopy() *Cookie {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CredentialInjection) DeepCopyInto(out *CredentialInjection) {
+	*out = *in
+	if in.BearerToken != nil {
+		in, out := &in.BearerToken, &out.BearerToken
+		*out = new(BearerTokenCredential)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.OAuth2ClientCredentials != nil {
+		in, out := &in.OAuth2ClientCredentials, &out.OAuth2ClientCredentials
+		*out = new(OAuth2ClientCredentials)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Overwrite != nil {
+		in, out := &in.Overwrite, &out.Overwrite
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CredentialInjection.
+func (in *CredentialInjection) DeepCopy() *CredentialInjection {
+	if in == nil {
+		return nil
+	}
+	out := new(CredentialInjection)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CustomHeaderExtensionSettings) DeepCopyInto(out *CustomHeaderExtensionSettings) {
 	*out = *in
@@ -1143,6 +1385,11 @@ func (in *CustomResponse) DeepCopyInto(out *CustomResponse) {
 		*out = new(string)
 		**out = **in
 	}
+	if in.StatusCode != nil {
+		in, out := &in.StatusCode, &out.StatusCode
+		*out = new(int)
+		**out = **in
+	}
 	in.Body.DeepCopyInto(&out.Body)
 }
 
@@ -1251,6 +1498,11 @@ func (in *DNS) DeepCopyInto(out *DNS) {
 		*out = new(bool)
 		**out = **in
 	}
+	if in.LookupFamily != nil {
+		in, out := &in.LookupFamily, &out.LookupFamily
+		*out = new(DNSLookupFamily)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DNS.
@@ -1360,6 +1612,11 @@ func (in *EnvoyExtensionPolicySpec) DeepCopyInto(out *EnvoyExtensionPolicySpec)
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.OpenAPIValidation != nil {
+		in, out := &in.OpenAPIValidation, &out.OpenAPIValidation
+		*out = new(OpenAPIValidation)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EnvoyExtensionPolicySpec.
@@ -1432,6 +1689,46 @@ func (in *EnvoyGatewayAdminAddress) DeepCopy() *EnvoyGatewayAdminAddress {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EnvoyGatewayBulkImport) DeepCopyInto(out *EnvoyGatewayBulkImport) {
+	*out = *in
+	if in.Threshold != nil {
+		in, out := &in.Threshold, &out.Threshold
+		*out = new(uint32)
+		**out = **in
+	}
+	if in.BatchSize != nil {
+		in, out := &in.BatchSize, &out.BatchSize
+		*out = new(uint32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EnvoyGatewayBulkImport.
+func (in *EnvoyGatewayBulkImport) DeepCopy() *EnvoyGatewayBulkImport {
+	if in == nil {
+		return nil
+	}
+	out := new(EnvoyGatewayBulkImport)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EnvoyGatewayCanaryRollout) DeepCopyInto(out *EnvoyGatewayCanaryRollout) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EnvoyGatewayCanaryRollout.
+func (in *EnvoyGatewayCanaryRollout) DeepCopy() *EnvoyGatewayCanaryRollout {
+	if in == nil {
+		return nil
+	}
+	out := new(EnvoyGatewayCanaryRollout)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *EnvoyGatewayCustomProvider) DeepCopyInto(out *EnvoyGatewayCustomProvider) {
 	*out = *in
@@ -1453,6 +1750,26 @@ func (in *EnvoyGatewayCustomProvider) DeepCopy() *EnvoyGatewayCustomProvider {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EnvoyGatewayDriftDetection) DeepCopyInto(out *EnvoyGatewayDriftDetection) {
+	*out = *in
+	if in.Window != nil {
+		in, out := &in.Window, &out.Window
+		*out = new(apisv1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EnvoyGatewayDriftDetection.
+func (in *EnvoyGatewayDriftDetection) DeepCopy() *EnvoyGatewayDriftDetection {
+	if in == nil {
+		return nil
+	}
+	out := new(EnvoyGatewayDriftDetection)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *EnvoyGatewayFileResourceProvider) DeepCopyInto(out *EnvoyGatewayFileResourceProvider) {
 	*out = *in
@@ -1488,6 +1805,28 @@ func (in *EnvoyGatewayHostInfrastructureProvider) DeepCopy() *EnvoyGatewayHostIn
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EnvoyGatewayHostnameDelegation) DeepCopyInto(out *EnvoyGatewayHostnameDelegation) {
+	*out = *in
+	if in.Delegations != nil {
+		in, out := &in.Delegations, &out.Delegations
+		*out = make([]HostnameDelegation, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EnvoyGatewayHostnameDelegation.
+func (in *EnvoyGatewayHostnameDelegation) DeepCopy() *EnvoyGatewayHostnameDelegation {
+	if in == nil {
+		return nil
+	}
+	out := new(EnvoyGatewayHostnameDelegation)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *EnvoyGatewayInfrastructureProvider) DeepCopyInto(out *EnvoyGatewayInfrastructureProvider) {
 	*out = *in
@@ -1622,6 +1961,26 @@ func (in *EnvoyGatewayMetrics) DeepCopy() *EnvoyGatewayMetrics {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EnvoyGatewayNodeHash) DeepCopyInto(out *EnvoyGatewayNodeHash) {
+	*out = *in
+	if in.MetadataKey != nil {
+		in, out := &in.MetadataKey, &out.MetadataKey
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EnvoyGatewayNodeHash.
+func (in *EnvoyGatewayNodeHash) DeepCopy() *EnvoyGatewayNodeHash {
+	if in == nil {
+		return nil
+	}
+	out := new(EnvoyGatewayNodeHash)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *EnvoyGatewayOpenTelemetrySink) DeepCopyInto(out *EnvoyGatewayOpenTelemetrySink) {
 	*out = *in
@@ -1688,34 +2047,279 @@ func (in *EnvoyGatewayProvider) DeepCopy() *EnvoyGatewayProvider {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *EnvoyGatewayResourceProvider) DeepCopyInto(out *EnvoyGatewayResourceProvider) {
+func (in *EnvoyGatewayProxySizing) DeepCopyInto(out *EnvoyGatewayProxySizing) {
 	*out = *in
-	if in.File != nil {
-		in, out := &in.File, &out.File
-		*out = new(EnvoyGatewayFileResourceProvider)
-		(*in).DeepCopyInto(*out)
+	if in.Mode != nil {
+		in, out := &in.Mode, &out.Mode
+		*out = new(ProxySizingMode)
+		**out = **in
+	}
+	if in.Interval != nil {
+		in, out := &in.Interval, &out.Interval
+		*out = new(apisv1.Duration)
+		**out = **in
+	}
+	if in.Window != nil {
+		in, out := &in.Window, &out.Window
+		*out = new(uint32)
+		**out = **in
+	}
+	if in.HeadroomPercent != nil {
+		in, out := &in.HeadroomPercent, &out.HeadroomPercent
+		*out = new(uint32)
+		**out = **in
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EnvoyGatewayResourceProvider.
-func (in *EnvoyGatewayResourceProvider) DeepCopy() *EnvoyGatewayResourceProvider {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EnvoyGatewayProxySizing.
+func (in *EnvoyGatewayProxySizing) DeepCopy() *EnvoyGatewayProxySizing {
 	if in == nil {
 		return nil
 	}
-	out := new(EnvoyGatewayResourceProvider)
+	out := new(EnvoyGatewayProxySizing)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *EnvoyGatewaySpec) DeepCopyInto(out *EnvoyGatewaySpec) {
+func (in *EnvoyGatewayPushPacing) DeepCopyInto(out *EnvoyGatewayPushPacing) {
 	*out = *in
-	if in.Gateway != nil {
-		in, out := &in.Gateway, &out.Gateway
-		*out = new(Gateway)
+	if in.Jitter != nil {
+		in, out := &in.Jitter, &out.Jitter
+		*out = new(apisv1.Duration)
 		**out = **in
 	}
-	if in.Provider != nil {
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EnvoyGatewayPushPacing.
+func (in *EnvoyGatewayPushPacing) DeepCopy() *EnvoyGatewayPushPacing {
+	if in == nil {
+		return nil
+	}
+	out := new(EnvoyGatewayPushPacing)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EnvoyGatewayResourceProvider) DeepCopyInto(out *EnvoyGatewayResourceProvider) {
+	*out = *in
+	if in.File != nil {
+		in, out := &in.File, &out.File
+		*out = new(EnvoyGatewayFileResourceProvider)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EnvoyGatewayResourceProvider.
+func (in *EnvoyGatewayResourceProvider) DeepCopy() *EnvoyGatewayResourceProvider {
+	if in == nil {
+		return nil
+	}
+	out := new(EnvoyGatewayResourceProvider)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EnvoyGatewayResourceTTL) DeepCopyInto(out *EnvoyGatewayResourceTTL) {
+	*out = *in
+	if in.Default != nil {
+		in, out := &in.Default, &out.Default
+		*out = new(apisv1.Duration)
+		**out = **in
+	}
+	if in.Types != nil {
+		in, out := &in.Types, &out.Types
+		*out = make([]EnvoyGatewayResourceTypeTTL, len(*in))
+		copy(*out, *in)
+	}
+	if in.HeartbeatInterval != nil {
+		in, out := &in.HeartbeatInterval, &out.HeartbeatInterval
+		*out = new(apisv1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EnvoyGatewayResourceTTL.
+func (in *EnvoyGatewayResourceTTL) DeepCopy() *EnvoyGatewayResourceTTL {
+	if in == nil {
+		return nil
+	}
+	out := new(EnvoyGatewayResourceTTL)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EnvoyGatewayResourceTypeTTL) DeepCopyInto(out *EnvoyGatewayResourceTypeTTL) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EnvoyGatewayResourceTypeTTL.
+func (in *EnvoyGatewayResourceTypeTTL) DeepCopy() *EnvoyGatewayResourceTypeTTL {
+	if in == nil {
+		return nil
+	}
+	out := new(EnvoyGatewayResourceTypeTTL)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EnvoyGatewaySecretRotation) DeepCopyInto(out *EnvoyGatewaySecretRotation) {
+	*out = *in
+	if in.OverlapWindow != nil {
+		in, out := &in.OverlapWindow, &out.OverlapWindow
+		*out = new(apisv1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EnvoyGatewaySecretRotation.
+func (in *EnvoyGatewaySecretRotation) DeepCopy() *EnvoyGatewaySecretRotation {
+	if in == nil {
+		return nil
+	}
+	out := new(EnvoyGatewaySecretRotation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EnvoyGatewayShadowTranslation) DeepCopyInto(out *EnvoyGatewayShadowTranslation) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EnvoyGatewayShadowTranslation.
+func (in *EnvoyGatewayShadowTranslation) DeepCopy() *EnvoyGatewayShadowTranslation {
+	if in == nil {
+		return nil
+	}
+	out := new(EnvoyGatewayShadowTranslation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EnvoyGatewaySnapshotCache) DeepCopyInto(out *EnvoyGatewaySnapshotCache) {
+	*out = *in
+	if in.MemoryLimit != nil {
+		in, out := &in.MemoryLimit, &out.MemoryLimit
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.History != nil {
+		in, out := &in.History, &out.History
+		*out = new(uint32)
+		**out = **in
+	}
+	if in.Persistence != nil {
+		in, out := &in.Persistence, &out.Persistence
+		*out = new(EnvoyGatewaySnapshotPersistence)
+		**out = **in
+	}
+	if in.Debounce != nil {
+		in, out := &in.Debounce, &out.Debounce
+		*out = new(EnvoyGatewaySnapshotDebounce)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ResourceTTL != nil {
+		in, out := &in.ResourceTTL, &out.ResourceTTL
+		*out = new(EnvoyGatewayResourceTTL)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ValidateConsistency != nil {
+		in, out := &in.ValidateConsistency, &out.ValidateConsistency
+		*out = new(bool)
+		**out = **in
+	}
+	if in.NodeHash != nil {
+		in, out := &in.NodeHash, &out.NodeHash
+		*out = new(EnvoyGatewayNodeHash)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.StaleNodeTimeout != nil {
+		in, out := &in.StaleNodeTimeout, &out.StaleNodeTimeout
+		*out = new(apisv1.Duration)
+		**out = **in
+	}
+	if in.StagedRollout != nil {
+		in, out := &in.StagedRollout, &out.StagedRollout
+		*out = new(EnvoyGatewayStagedRollout)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PushPacing != nil {
+		in, out := &in.PushPacing, &out.PushPacing
+		*out = new(EnvoyGatewayPushPacing)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.DriftDetection != nil {
+		in, out := &in.DriftDetection, &out.DriftDetection
+		*out = new(EnvoyGatewayDriftDetection)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EnvoyGatewaySnapshotCache.
+func (in *EnvoyGatewaySnapshotCache) DeepCopy() *EnvoyGatewaySnapshotCache {
+	if in == nil {
+		return nil
+	}
+	out := new(EnvoyGatewaySnapshotCache)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EnvoyGatewaySnapshotDebounce) DeepCopyInto(out *EnvoyGatewaySnapshotDebounce) {
+	*out = *in
+	if in.Interval != nil {
+		in, out := &in.Interval, &out.Interval
+		*out = new(apisv1.Duration)
+		**out = **in
+	}
+	if in.MaxDelay != nil {
+		in, out := &in.MaxDelay, &out.MaxDelay
+		*out = new(apisv1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EnvoyGatewaySnapshotDebounce.
+func (in *EnvoyGatewaySnapshotDebounce) DeepCopy() *EnvoyGatewaySnapshotDebounce {
+	if in == nil {
+		return nil
+	}
+	out := new(EnvoyGatewaySnapshotDebounce)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EnvoyGatewaySnapshotPersistence) DeepCopyInto(out *EnvoyGatewaySnapshotPersistence) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EnvoyGatewaySnapshotPersistence.
+func (in *EnvoyGatewaySnapshotPersistence) DeepCopy() *EnvoyGatewaySnapshotPersistence {
+	if in == nil {
+		return nil
+	}
+	out := new(EnvoyGatewaySnapshotPersistence)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EnvoyGatewaySpec) DeepCopyInto(out *EnvoyGatewaySpec) {
+	*out = *in
+	if in.Gateway != nil {
+		in, out := &in.Gateway, &out.Gateway
+		*out = new(Gateway)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Provider != nil {
 		in, out := &in.Provider, &out.Provider
 		*out = new(EnvoyGatewayProvider)
 		(*in).DeepCopyInto(*out)
@@ -1750,6 +2354,58 @@ func (in *EnvoyGatewaySpec) DeepCopyInto(out *EnvoyGatewaySpec) {
 		*out = new(ExtensionAPISettings)
 		**out = **in
 	}
+	if in.ProxySizing != nil {
+		in, out := &in.ProxySizing, &out.ProxySizing
+		*out = new(EnvoyGatewayProxySizing)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SecretRotation != nil {
+		in, out := &in.SecretRotation, &out.SecretRotation
+		*out = new(EnvoyGatewaySecretRotation)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SnapshotCache != nil {
+		in, out := &in.SnapshotCache, &out.SnapshotCache
+		*out = new(EnvoyGatewaySnapshotCache)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.XdsServer != nil {
+		in, out := &in.XdsServer, &out.XdsServer
+		*out = new(EnvoyGatewayXdsServer)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.HostnameDelegation != nil {
+		in, out := &in.HostnameDelegation, &out.HostnameDelegation
+		*out = new(EnvoyGatewayHostnameDelegation)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.BulkImport != nil {
+		in, out := &in.BulkImport, &out.BulkImport
+		*out = new(EnvoyGatewayBulkImport)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ShadowTranslation != nil {
+		in, out := &in.ShadowTranslation, &out.ShadowTranslation
+		*out = new(EnvoyGatewayShadowTranslation)
+		**out = **in
+	}
+	if in.Watchable != nil {
+		in, out := &in.Watchable, &out.Watchable
+		*out = new(EnvoyGatewayWatchable)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.CanaryRollout != nil {
+		in, out := &in.CanaryRollout, &out.CanaryRollout
+		*out = new(EnvoyGatewayCanaryRollout)
+		**out = **in
+	}
+	if in.FeatureGates != nil {
+		in, out := &in.FeatureGates, &out.FeatureGates
+		*out = make(map[FeatureGate]bool, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EnvoyGatewaySpec.
@@ -1762,6 +2418,26 @@ func (in *EnvoyGatewaySpec) DeepCopy() *EnvoyGatewaySpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EnvoyGatewayStagedRollout) DeepCopyInto(out *EnvoyGatewayStagedRollout) {
+	*out = *in
+	if in.SoakPeriod != nil {
+		in, out := &in.SoakPeriod, &out.SoakPeriod
+		*out = new(apisv1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EnvoyGatewayStagedRollout.
+func (in *EnvoyGatewayStagedRollout) DeepCopy() *EnvoyGatewayStagedRollout {
+	if in == nil {
+		return nil
+	}
+	out := new(EnvoyGatewayStagedRollout)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *EnvoyGatewayTelemetry) DeepCopyInto(out *EnvoyGatewayTelemetry) {
 	*out = *in
@@ -1782,6 +2458,105 @@ func (in *EnvoyGatewayTelemetry) DeepCopy() *EnvoyGatewayTelemetry {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EnvoyGatewayWatchable) DeepCopyInto(out *EnvoyGatewayWatchable) {
+	*out = *in
+	if in.Queues != nil {
+		in, out := &in.Queues, &out.Queues
+		*out = make([]WatchableQueue, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Retries != nil {
+		in, out := &in.Retries, &out.Retries
+		*out = make([]WatchableRetry, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Tracing != nil {
+		in, out := &in.Tracing, &out.Tracing
+		*out = new(WatchableTracing)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EnvoyGatewayWatchable.
+func (in *EnvoyGatewayWatchable) DeepCopy() *EnvoyGatewayWatchable {
+	if in == nil {
+		return nil
+	}
+	out := new(EnvoyGatewayWatchable)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EnvoyGatewayXdsServer) DeepCopyInto(out *EnvoyGatewayXdsServer) {
+	*out = *in
+	if in.MaxRecvMessageSize != nil {
+		in, out := &in.MaxRecvMessageSize, &out.MaxRecvMessageSize
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.MaxSendMessageSize != nil {
+		in, out := &in.MaxSendMessageSize, &out.MaxSendMessageSize
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.MaxConcurrentStreams != nil {
+		in, out := &in.MaxConcurrentStreams, &out.MaxConcurrentStreams
+		*out = new(uint32)
+		**out = **in
+	}
+	if in.Keepalive != nil {
+		in, out := &in.Keepalive, &out.Keepalive
+		*out = new(XdsServerKeepalive)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SendTimeout != nil {
+		in, out := &in.SendTimeout, &out.SendTimeout
+		*out = new(apisv1.Duration)
+		**out = **in
+	}
+	if in.DrainTimeout != nil {
+		in, out := &in.DrainTimeout, &out.DrainTimeout
+		*out = new(apisv1.Duration)
+		**out = **in
+	}
+	if in.IsolatedGateways != nil {
+		in, out := &in.IsolatedGateways, &out.IsolatedGateways
+		*out = make([]XdsServerIsolatedGateway, len(*in))
+		copy(*out, *in)
+	}
+	if in.AuditLog != nil {
+		in, out := &in.AuditLog, &out.AuditLog
+		*out = new(XdsAuditLog)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.NodeAuthorization != nil {
+		in, out := &in.NodeAuthorization, &out.NodeAuthorization
+		*out = new(XdsNodeAuthorization)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.AnomalyDetection != nil {
+		in, out := &in.AnomalyDetection, &out.AnomalyDetection
+		*out = new(XdsAnomalyDetection)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EnvoyGatewayXdsServer.
+func (in *EnvoyGatewayXdsServer) DeepCopy() *EnvoyGatewayXdsServer {
+	if in == nil {
+		return nil
+	}
+	out := new(EnvoyGatewayXdsServer)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *EnvoyJSONPatchConfig) DeepCopyInto(out *EnvoyJSONPatchConfig) {
 	*out = *in
@@ -2028,6 +2803,103 @@ func (in *EnvoyProxySpec) DeepCopyInto(out *EnvoyProxySpec) {
 		*out = new(int32)
 		**out = **in
 	}
+	if in.RuntimeFlags != nil {
+		in, out := &in.RuntimeFlags, &out.RuntimeFlags
+		*out = new(ProxyRuntimeFlags)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.RuntimeDiscovery != nil {
+		in, out := &in.RuntimeDiscovery, &out.RuntimeDiscovery
+		*out = new(ProxyRuntimeDiscovery)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.IPFamily != nil {
+		in, out := &in.IPFamily, &out.IPFamily
+		*out = new(IPFamily)
+		**out = **in
+	}
+	if in.ListenerUnixSocket != nil {
+		in, out := &in.ListenerUnixSocket, &out.ListenerUnixSocket
+		*out = new(ListenerUnixSocket)
+		**out = **in
+	}
+	if in.ListenerTuning != nil {
+		in, out := &in.ListenerTuning, &out.ListenerTuning
+		*out = make([]ProxyListenerTuning, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Warming != nil {
+		in, out := &in.Warming, &out.Warming
+		*out = new(ProxyWarming)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.DeferDrains != nil {
+		in, out := &in.DeferDrains, &out.DeferDrains
+		*out = new(ProxyDeferDrains)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.LoadReporting != nil {
+		in, out := &in.LoadReporting, &out.LoadReporting
+		*out = new(ProxyLoadReporting)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.XdsCompression != nil {
+		in, out := &in.XdsCompression, &out.XdsCompression
+		*out = new(ProxyXdsCompression)
+		**out = **in
+	}
+	if in.TrafficRecording != nil {
+		in, out := &in.TrafficRecording, &out.TrafficRecording
+		*out = new(ProxyTrafficRecording)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Preview != nil {
+		in, out := &in.Preview, &out.Preview
+		*out = new(ProxyPreview)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.RouteMatcherTree != nil {
+		in, out := &in.RouteMatcherTree, &out.RouteMatcherTree
+		*out = new(ProxyRouteMatcherTree)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.RouteConflictResolution != nil {
+		in, out := &in.RouteConflictResolution, &out.RouteConflictResolution
+		*out = new(RouteConflictResolution)
+		**out = **in
+	}
+	if in.LocalityEndpointDiscovery != nil {
+		in, out := &in.LocalityEndpointDiscovery, &out.LocalityEndpointDiscovery
+		*out = new(ProxyLocalityEndpointDiscovery)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.VirtualHostDiscovery != nil {
+		in, out := &in.VirtualHostDiscovery, &out.VirtualHostDiscovery
+		*out = new(ProxyVirtualHostDiscovery)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ExtensionConfigDiscovery != nil {
+		in, out := &in.ExtensionConfigDiscovery, &out.ExtensionConfigDiscovery
+		*out = new(ProxyExtensionConfigDiscovery)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ConfigCheckpoint != nil {
+		in, out := &in.ConfigCheckpoint, &out.ConfigCheckpoint
+		*out = new(ProxyConfigCheckpoint)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.NodeGroup != nil {
+		in, out := &in.NodeGroup, &out.NodeGroup
+		*out = new(string)
+		**out = **in
+	}
+	if in.Admin != nil {
+		in, out := &in.Admin, &out.Admin
+		*out = new(ProxyAdmin)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.RoutingType != nil {
 		in, out := &in.RoutingType, &out.RoutingType
 		*out = new(RoutingType)
@@ -2087,6 +2959,65 @@ func (in *EnvoyProxyStatus) DeepCopy() *EnvoyProxyStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Experiment) DeepCopyInto(out *Experiment) {
+	*out = *in
+	if in.CookieTTL != nil {
+		in, out := &in.CookieTTL, &out.CookieTTL
+		*out = new(apisv1.Duration)
+		**out = **in
+	}
+	if in.Header != nil {
+		in, out := &in.Header, &out.Header
+		*out = new(string)
+		**out = **in
+	}
+	if in.Salt != nil {
+		in, out := &in.Salt, &out.Salt
+		*out = new(string)
+		**out = **in
+	}
+	if in.Buckets != nil {
+		in, out := &in.Buckets, &out.Buckets
+		*out = make([]ExperimentBucket, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Experiment.
+func (in *Experiment) DeepCopy() *Experiment {
+	if in == nil {
+		return nil
+	}
+	out := new(Experiment)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExperimentBucket) DeepCopyInto(out *ExperimentBucket) {
+	*out = *in
+	if in.BackendRefs != nil {
+		in, out := &in.BackendRefs, &out.BackendRefs
+		*out = make([]BackendRef, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExperimentBucket.
+func (in *ExperimentBucket) DeepCopy() *ExperimentBucket {
+	if in == nil {
+		return nil
+	}
+	out := new(ExperimentBucket)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ExtAuth) DeepCopyInto(out *ExtAuth) {
 	*out = *in
@@ -2461,9 +3392,58 @@ func (in *GRPCExtAuthService) DeepCopy() *GRPCExtAuthService {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GRPCStatusMapping) DeepCopyInto(out *GRPCStatusMapping) {
+	*out = *in
+	if in.ToHTTP != nil {
+		in, out := &in.ToHTTP, &out.ToHTTP
+		*out = make([]GRPCToHTTPStatus, len(*in))
+		copy(*out, *in)
+	}
+	if in.ToGRPC != nil {
+		in, out := &in.ToGRPC, &out.ToGRPC
+		*out = make([]HTTPToGRPCStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GRPCStatusMapping.
+func (in *GRPCStatusMapping) DeepCopy() *GRPCStatusMapping {
+	if in == nil {
+		return nil
+	}
+	out := new(GRPCStatusMapping)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GRPCToHTTPStatus) DeepCopyInto(out *GRPCToHTTPStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GRPCToHTTPStatus.
+func (in *GRPCToHTTPStatus) DeepCopy() *GRPCToHTTPStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(GRPCToHTTPStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Gateway) DeepCopyInto(out *Gateway) {
 	*out = *in
+	if in.AdditionalControllers != nil {
+		in, out := &in.AdditionalControllers, &out.AdditionalControllers
+		*out = make([]GatewayController, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Gateway.
@@ -2476,6 +3456,26 @@ func (in *Gateway) DeepCopy() *Gateway {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GatewayController) DeepCopyInto(out *GatewayController) {
+	*out = *in
+	if in.XdsServerPort != nil {
+		in, out := &in.XdsServerPort, &out.XdsServerPort
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GatewayController.
+func (in *GatewayController) DeepCopy() *GatewayController {
+	if in == nil {
+		return nil
+	}
+	out := new(GatewayController)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *GlobalRateLimit) DeepCopyInto(out *GlobalRateLimit) {
 	*out = *in
@@ -2628,6 +3628,43 @@ func (in *HTTP3Settings) DeepCopy() *HTTP3Settings {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HMACRequestSigning) DeepCopyInto(out *HMACRequestSigning) {
+	*out = *in
+	in.BackendCluster.DeepCopyInto(&out.BackendCluster)
+	in.Key.DeepCopyInto(&out.Key)
+	if in.SignatureHeader != nil {
+		in, out := &in.SignatureHeader, &out.SignatureHeader
+		*out = new(string)
+		**out = **in
+	}
+	if in.TimestampHeader != nil {
+		in, out := &in.TimestampHeader, &out.TimestampHeader
+		*out = new(string)
+		**out = **in
+	}
+	if in.SignedHeaders != nil {
+		in, out := &in.SignedHeaders, &out.SignedHeaders
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.SignBody != nil {
+		in, out := &in.SignBody, &out.SignBody
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HMACRequestSigning.
+func (in *HMACRequestSigning) DeepCopy() *HMACRequestSigning {
+	if in == nil {
+		return nil
+	}
+	out := new(HMACRequestSigning)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *HTTPActiveHealthChecker) DeepCopyInto(out *HTTPActiveHealthChecker) {
 	*out = *in
@@ -2729,6 +3766,31 @@ func (in *HTTPHostnameModifier) DeepCopy() *HTTPHostnameModifier {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HTTPNormalizationFilter) DeepCopyInto(out *HTTPNormalizationFilter) {
+	*out = *in
+	if in.Query != nil {
+		in, out := &in.Query, &out.Query
+		*out = new(HTTPQueryNormalization)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.TrailingSlash != nil {
+		in, out := &in.TrailingSlash, &out.TrailingSlash
+		*out = new(HTTPTrailingSlashNormalization)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HTTPNormalizationFilter.
+func (in *HTTPNormalizationFilter) DeepCopy() *HTTPNormalizationFilter {
+	if in == nil {
+		return nil
+	}
+	out := new(HTTPNormalizationFilter)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *HTTPPathModifier) DeepCopyInto(out *HTTPPathModifier) {
 	*out = *in
@@ -2739,12 +3801,62 @@ func (in *HTTPPathModifier) DeepCopyInto(out *HTTPPathModifier) {
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HTTPPathModifier.
-func (in *HTTPPathModifier) DeepCopy() *HTTPPathModifier {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HTTPPathModifier.
+func (in *HTTPPathModifier) DeepCopy() *HTTPPathModifier {
+	if in == nil {
+		return nil
+	}
+	out := new(HTTPPathModifier)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HTTPQueryNormalization) DeepCopyInto(out *HTTPQueryNormalization) {
+	*out = *in
+	if in.RemoveParameters != nil {
+		in, out := &in.RemoveParameters, &out.RemoveParameters
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Sort != nil {
+		in, out := &in.Sort, &out.Sort
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HTTPQueryNormalization.
+func (in *HTTPQueryNormalization) DeepCopy() *HTTPQueryNormalization {
+	if in == nil {
+		return nil
+	}
+	out := new(HTTPQueryNormalization)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HTTPQueryParamMatchFilter) DeepCopyInto(out *HTTPQueryParamMatchFilter) {
+	*out = *in
+	if in.Present != nil {
+		in, out := &in.Present, &out.Present
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Absent != nil {
+		in, out := &in.Absent, &out.Absent
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HTTPQueryParamMatchFilter.
+func (in *HTTPQueryParamMatchFilter) DeepCopy() *HTTPQueryParamMatchFilter {
 	if in == nil {
 		return nil
 	}
-	out := new(HTTPPathModifier)
+	out := new(HTTPQueryParamMatchFilter)
 	in.DeepCopyInto(out)
 	return out
 }
@@ -2815,6 +3927,16 @@ func (in *HTTPRouteFilterSpec) DeepCopyInto(out *HTTPRouteFilterSpec) {
 		*out = new(HTTPURLRewriteFilter)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Normalization != nil {
+		in, out := &in.Normalization, &out.Normalization
+		*out = new(HTTPNormalizationFilter)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.QueryParamMatch != nil {
+		in, out := &in.QueryParamMatch, &out.QueryParamMatch
+		*out = new(HTTPQueryParamMatchFilter)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HTTPRouteFilterSpec.
@@ -2852,6 +3974,47 @@ func (in *HTTPTimeout) DeepCopy() *HTTPTimeout {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HTTPToGRPCStatus) DeepCopyInto(out *HTTPToGRPCStatus) {
+	*out = *in
+	in.HTTPStatus.DeepCopyInto(&out.HTTPStatus)
+	if in.Message != nil {
+		in, out := &in.Message, &out.Message
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HTTPToGRPCStatus.
+func (in *HTTPToGRPCStatus) DeepCopy() *HTTPToGRPCStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(HTTPToGRPCStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HTTPTrailingSlashNormalization) DeepCopyInto(out *HTTPTrailingSlashNormalization) {
+	*out = *in
+	if in.RedirectStatusCode != nil {
+		in, out := &in.RedirectStatusCode, &out.RedirectStatusCode
+		*out = new(int)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HTTPTrailingSlashNormalization.
+func (in *HTTPTrailingSlashNormalization) DeepCopy() *HTTPTrailingSlashNormalization {
+	if in == nil {
+		return nil
+	}
+	out := new(HTTPTrailingSlashNormalization)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *HTTPURLRewriteFilter) DeepCopyInto(out *HTTPURLRewriteFilter) {
 	*out = *in
@@ -2897,6 +4060,31 @@ func (in *HTTPWasmCodeSource) DeepCopy() *HTTPWasmCodeSource {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HappyEyeballs) DeepCopyInto(out *HappyEyeballs) {
+	*out = *in
+	if in.FirstAddressFamily != nil {
+		in, out := &in.FirstAddressFamily, &out.FirstAddressFamily
+		*out = new(IPFamily)
+		**out = **in
+	}
+	if in.FirstAddressFamilyCount != nil {
+		in, out := &in.FirstAddressFamilyCount, &out.FirstAddressFamilyCount
+		*out = new(uint32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HappyEyeballs.
+func (in *HappyEyeballs) DeepCopy() *HappyEyeballs {
+	if in == nil {
+		return nil
+	}
+	out := new(HappyEyeballs)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Header) DeepCopyInto(out *Header) {
 	*out = *in
@@ -2987,6 +4175,35 @@ func (in *HeaderSettings) DeepCopy() *HeaderSettings {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HeaderToMetadata) DeepCopyInto(out *HeaderToMetadata) {
+	*out = *in
+	if in.RequestHeaders != nil {
+		in, out := &in.RequestHeaders, &out.RequestHeaders
+		*out = make([]RequestHeaderToMetadata, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.ResponseHeaders != nil {
+		in, out := &in.ResponseHeaders, &out.ResponseHeaders
+		*out = make([]MetadataToResponseHeader, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HeaderToMetadata.
+func (in *HeaderToMetadata) DeepCopy() *HeaderToMetadata {
+	if in == nil {
+		return nil
+	}
+	out := new(HeaderToMetadata)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *HealthCheck) DeepCopyInto(out *HealthCheck) {
 	*out = *in
@@ -3012,9 +4229,41 @@ func (in *HealthCheck) DeepCopy() *HealthCheck {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HealthCheckRoute) DeepCopyInto(out *HealthCheckRoute) {
+	*out = *in
+	if in.Kind != nil {
+		in, out := &in.Kind, &out.Kind
+		*out = new(apisv1.Kind)
+		**out = **in
+	}
+	if in.Namespace != nil {
+		in, out := &in.Namespace, &out.Namespace
+		*out = new(apisv1.Namespace)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HealthCheckRoute.
+func (in *HealthCheckRoute) DeepCopy() *HealthCheckRoute {
+	if in == nil {
+		return nil
+	}
+	out := new(HealthCheckRoute)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *HealthCheckSettings) DeepCopyInto(out *HealthCheckSettings) {
 	*out = *in
+	if in.Routes != nil {
+		in, out := &in.Routes, &out.Routes
+		*out = make([]HealthCheckRoute, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HealthCheckSettings.
@@ -3027,6 +4276,31 @@ func (in *HealthCheckSettings) DeepCopy() *HealthCheckSettings {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HostnameDelegation) DeepCopyInto(out *HostnameDelegation) {
+	*out = *in
+	if in.Namespaces != nil {
+		in, out := &in.Namespaces, &out.Namespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Hostnames != nil {
+		in, out := &in.Hostnames, &out.Hostnames
+		*out = make([]apisv1.Hostname, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HostnameDelegation.
+func (in *HostnameDelegation) DeepCopy() *HostnameDelegation {
+	if in == nil {
+		return nil
+	}
+	out := new(HostnameDelegation)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *IPEndpoint) DeepCopyInto(out *IPEndpoint) {
 	*out = *in
@@ -3117,6 +4391,11 @@ func (in *JWT) DeepCopyInto(out *JWT) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.RouteMatch != nil {
+		in, out := &in.RouteMatch, &out.RouteMatch
+		*out = new(JWTRouteMatch)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JWT.
@@ -3269,6 +4548,33 @@ func (in *JWTProvider) DeepCopy() *JWTProvider {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *JWTRouteMatch) DeepCopyInto(out *JWTRouteMatch) {
+	*out = *in
+	if in.Claims != nil {
+		in, out := &in.Claims, &out.Claims
+		*out = make([]JWTClaim, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Scopes != nil {
+		in, out := &in.Scopes, &out.Scopes
+		*out = make([]JWTScope, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JWTRouteMatch.
+func (in *JWTRouteMatch) DeepCopy() *JWTRouteMatch {
+	if in == nil {
+		return nil
+	}
+	out := new(JWTRouteMatch)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *KubernetesContainerSpec) DeepCopyInto(out *KubernetesContainerSpec) {
 	*out = *in
@@ -3610,6 +4916,11 @@ func (in *KubernetesServiceSpec) DeepCopyInto(out *KubernetesServiceSpec) {
 		*out = new(ServiceExternalTrafficPolicy)
 		**out = **in
 	}
+	if in.HealthCheckNodePort != nil {
+		in, out := &in.HealthCheckNodePort, &out.HealthCheckNodePort
+		*out = new(int32)
+		**out = **in
+	}
 	if in.Patch != nil {
 		in, out := &in.Patch, &out.Patch
 		*out = new(KubernetesPatchSpec)
@@ -3692,6 +5003,21 @@ func (in *LeaderElection) DeepCopy() *LeaderElection {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ListenerUnixSocket) DeepCopyInto(out *ListenerUnixSocket) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ListenerUnixSocket.
+func (in *ListenerUnixSocket) DeepCopy() *ListenerUnixSocket {
+	if in == nil {
+		return nil
+	}
+	out := new(ListenerUnixSocket)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *LiteralCustomTag) DeepCopyInto(out *LiteralCustomTag) {
 	*out = *in
@@ -3754,6 +5080,117 @@ func (in *LocalRateLimit) DeepCopy() *LocalRateLimit {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MaintenanceWindow) DeepCopyInto(out *MaintenanceWindow) {
+	*out = *in
+	if in.Days != nil {
+		in, out := &in.Days, &out.Days
+		*out = make([]Weekday, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MaintenanceWindow.
+func (in *MaintenanceWindow) DeepCopy() *MaintenanceWindow {
+	if in == nil {
+		return nil
+	}
+	out := new(MaintenanceWindow)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MetadataToResponseHeader) DeepCopyInto(out *MetadataToResponseHeader) {
+	*out = *in
+	if in.Namespace != nil {
+		in, out := &in.Namespace, &out.Namespace
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MetadataToResponseHeader.
+func (in *MetadataToResponseHeader) DeepCopy() *MetadataToResponseHeader {
+	if in == nil {
+		return nil
+	}
+	out := new(MetadataToResponseHeader)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MetadataValue) DeepCopyInto(out *MetadataValue) {
+	*out = *in
+	if in.Namespace != nil {
+		in, out := &in.Namespace, &out.Namespace
+		*out = new(string)
+		**out = **in
+	}
+	if in.Value != nil {
+		in, out := &in.Value, &out.Value
+		*out = new(string)
+		**out = **in
+	}
+	if in.Type != nil {
+		in, out := &in.Type, &out.Type
+		*out = new(MetadataValueType)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MetadataValue.
+func (in *MetadataValue) DeepCopy() *MetadataValue {
+	if in == nil {
+		return nil
+	}
+	out := new(MetadataValue)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NoSNIMatch) DeepCopyInto(out *NoSNIMatch) {
+	*out = *in
+	if in.BackendRef != nil {
+		in, out := &in.BackendRef, &out.BackendRef
+		*out = new(BackendRef)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NoSNIMatch.
+func (in *NoSNIMatch) DeepCopy() *NoSNIMatch {
+	if in == nil {
+		return nil
+	}
+	out := new(NoSNIMatch)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OAuth2ClientCredentials) DeepCopyInto(out *OAuth2ClientCredentials) {
+	*out = *in
+	in.ClientSecret.DeepCopyInto(&out.ClientSecret)
+	if in.Scopes != nil {
+		in, out := &in.Scopes, &out.Scopes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OAuth2ClientCredentials.
+func (in *OAuth2ClientCredentials) DeepCopy() *OAuth2ClientCredentials {
+	if in == nil {
+		return nil
+	}
+	out := new(OAuth2ClientCredentials)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *OIDC) DeepCopyInto(out *OIDC) {
 	*out = *in
@@ -3841,33 +5278,60 @@ func (in *OIDCCookieNames) DeepCopy() *OIDCCookieNames {
 	if in == nil {
 		return nil
 	}
-	out := new(OIDCCookieNames)
+	out := new(OIDCCookieNames)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OIDCProvider) DeepCopyInto(out *OIDCProvider) {
+	*out = *in
+	in.BackendCluster.DeepCopyInto(&out.BackendCluster)
+	if in.AuthorizationEndpoint != nil {
+		in, out := &in.AuthorizationEndpoint, &out.AuthorizationEndpoint
+		*out = new(string)
+		**out = **in
+	}
+	if in.TokenEndpoint != nil {
+		in, out := &in.TokenEndpoint, &out.TokenEndpoint
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OIDCProvider.
+func (in *OIDCProvider) DeepCopy() *OIDCProvider {
+	if in == nil {
+		return nil
+	}
+	out := new(OIDCProvider)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *OIDCProvider) DeepCopyInto(out *OIDCProvider) {
+func (in *OpenAPIValidation) DeepCopyInto(out *OpenAPIValidation) {
 	*out = *in
 	in.BackendCluster.DeepCopyInto(&out.BackendCluster)
-	if in.AuthorizationEndpoint != nil {
-		in, out := &in.AuthorizationEndpoint, &out.AuthorizationEndpoint
+	out.ConfigMapRef = in.ConfigMapRef
+	if in.Key != nil {
+		in, out := &in.Key, &out.Key
 		*out = new(string)
 		**out = **in
 	}
-	if in.TokenEndpoint != nil {
-		in, out := &in.TokenEndpoint, &out.TokenEndpoint
-		*out = new(string)
+	if in.FailOpen != nil {
+		in, out := &in.FailOpen, &out.FailOpen
+		*out = new(bool)
 		**out = **in
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OIDCProvider.
-func (in *OIDCProvider) DeepCopy() *OIDCProvider {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OpenAPIValidation.
+func (in *OpenAPIValidation) DeepCopy() *OpenAPIValidation {
 	if in == nil {
 		return nil
 	}
-	out := new(OIDCProvider)
+	out := new(OpenAPIValidation)
 	in.DeepCopyInto(out)
 	return out
 }
@@ -3900,6 +5364,26 @@ func (in *OpenTelemetryEnvoyProxyAccessLog) DeepCopy() *OpenTelemetryEnvoyProxyA
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OriginalDestination) DeepCopyInto(out *OriginalDestination) {
+	*out = *in
+	if in.HeaderName != nil {
+		in, out := &in.HeaderName, &out.HeaderName
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OriginalDestination.
+func (in *OriginalDestination) DeepCopy() *OriginalDestination {
+	if in == nil {
+		return nil
+	}
+	out := new(OriginalDestination)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PassiveHealthCheck) DeepCopyInto(out *PassiveHealthCheck) {
 	*out = *in
@@ -3975,6 +5459,26 @@ func (in *PathSettings) DeepCopy() *PathSettings {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PerEndpointCircuitBreakers) DeepCopyInto(out *PerEndpointCircuitBreakers) {
+	*out = *in
+	if in.MaxConnections != nil {
+		in, out := &in.MaxConnections, &out.MaxConnections
+		*out = new(int64)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PerEndpointCircuitBreakers.
+func (in *PerEndpointCircuitBreakers) DeepCopy() *PerEndpointCircuitBreakers {
+	if in == nil {
+		return nil
+	}
+	out := new(PerEndpointCircuitBreakers)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PerRetryPolicy) DeepCopyInto(out *PerRetryPolicy) {
 	*out = *in
@@ -4034,6 +5538,31 @@ func (in *PolicyTargetReferences) DeepCopy() *PolicyTargetReferences {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PreconnectPolicy) DeepCopyInto(out *PreconnectPolicy) {
+	*out = *in
+	if in.PerEndpointPercent != nil {
+		in, out := &in.PerEndpointPercent, &out.PerEndpointPercent
+		*out = new(uint32)
+		**out = **in
+	}
+	if in.PredictivePercent != nil {
+		in, out := &in.PredictivePercent, &out.PredictivePercent
+		*out = new(uint32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PreconnectPolicy.
+func (in *PreconnectPolicy) DeepCopy() *PreconnectPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(PreconnectPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Principal) DeepCopyInto(out *Principal) {
 	*out = *in
@@ -4153,6 +5682,11 @@ func (in *ProxyAccessLogSetting) DeepCopyInto(out *ProxyAccessLogSetting) {
 		*out = new(ProxyAccessLogType)
 		**out = **in
 	}
+	if in.Protocol != nil {
+		in, out := &in.Protocol, &out.Protocol
+		*out = new(ProxyAccessLogProtocol)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProxyAccessLogSetting.
@@ -4195,6 +5729,31 @@ func (in *ProxyAccessLogSink) DeepCopy() *ProxyAccessLogSink {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProxyAdmin) DeepCopyInto(out *ProxyAdmin) {
+	*out = *in
+	if in.Exposure != nil {
+		in, out := &in.Exposure, &out.Exposure
+		*out = new(ProxyAdminExposure)
+		**out = **in
+	}
+	if in.AllowedPaths != nil {
+		in, out := &in.AllowedPaths, &out.AllowedPaths
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProxyAdmin.
+func (in *ProxyAdmin) DeepCopy() *ProxyAdmin {
+	if in == nil {
+		return nil
+	}
+	out := new(ProxyAdmin)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ProxyBootstrap) DeepCopyInto(out *ProxyBootstrap) {
 	*out = *in
@@ -4227,6 +5786,157 @@ func (in *ProxyBootstrap) DeepCopy() *ProxyBootstrap {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProxyConfigCheckpoint) DeepCopyInto(out *ProxyConfigCheckpoint) {
+	*out = *in
+	if in.Interval != nil {
+		in, out := &in.Interval, &out.Interval
+		*out = new(apisv1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProxyConfigCheckpoint.
+func (in *ProxyConfigCheckpoint) DeepCopy() *ProxyConfigCheckpoint {
+	if in == nil {
+		return nil
+	}
+	out := new(ProxyConfigCheckpoint)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProxyDeferDrains) DeepCopyInto(out *ProxyDeferDrains) {
+	*out = *in
+	if in.Scope != nil {
+		in, out := &in.Scope, &out.Scope
+		*out = new(DrainScope)
+		**out = **in
+	}
+	in.MaintenanceWindow.DeepCopyInto(&out.MaintenanceWindow)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProxyDeferDrains.
+func (in *ProxyDeferDrains) DeepCopy() *ProxyDeferDrains {
+	if in == nil {
+		return nil
+	}
+	out := new(ProxyDeferDrains)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProxyExtensionConfigDiscovery) DeepCopyInto(out *ProxyExtensionConfigDiscovery) {
+	*out = *in
+	if in.Filters != nil {
+		in, out := &in.Filters, &out.Filters
+		*out = make([]EnvoyFilter, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProxyExtensionConfigDiscovery.
+func (in *ProxyExtensionConfigDiscovery) DeepCopy() *ProxyExtensionConfigDiscovery {
+	if in == nil {
+		return nil
+	}
+	out := new(ProxyExtensionConfigDiscovery)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProxyListenerTuning) DeepCopyInto(out *ProxyListenerTuning) {
+	*out = *in
+	if in.Ports != nil {
+		in, out := &in.Ports, &out.Ports
+		*out = make([]apisv1.PortNumber, len(*in))
+		copy(*out, *in)
+	}
+	if in.ConnectionBalance != nil {
+		in, out := &in.ConnectionBalance, &out.ConnectionBalance
+		*out = new(ConnectionBalanceType)
+		**out = **in
+	}
+	if in.MaxConnectionsToAcceptPerSocketEvent != nil {
+		in, out := &in.MaxConnectionsToAcceptPerSocketEvent, &out.MaxConnectionsToAcceptPerSocketEvent
+		*out = new(uint32)
+		**out = **in
+	}
+	if in.ListenerFiltersTimeout != nil {
+		in, out := &in.ListenerFiltersTimeout, &out.ListenerFiltersTimeout
+		*out = new(apisv1.Duration)
+		**out = **in
+	}
+	if in.ContinueOnListenerFiltersTimeout != nil {
+		in, out := &in.ContinueOnListenerFiltersTimeout, &out.ContinueOnListenerFiltersTimeout
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProxyListenerTuning.
+func (in *ProxyListenerTuning) DeepCopy() *ProxyListenerTuning {
+	if in == nil {
+		return nil
+	}
+	out := new(ProxyListenerTuning)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProxyLoadReporting) DeepCopyInto(out *ProxyLoadReporting) {
+	*out = *in
+	if in.Mode != nil {
+		in, out := &in.Mode, &out.Mode
+		*out = new(LoadReportingMode)
+		**out = **in
+	}
+	if in.Interval != nil {
+		in, out := &in.Interval, &out.Interval
+		*out = new(apisv1.Duration)
+		**out = **in
+	}
+	if in.Metric != nil {
+		in, out := &in.Metric, &out.Metric
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProxyLoadReporting.
+func (in *ProxyLoadReporting) DeepCopy() *ProxyLoadReporting {
+	if in == nil {
+		return nil
+	}
+	out := new(ProxyLoadReporting)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProxyLocalityEndpointDiscovery) DeepCopyInto(out *ProxyLocalityEndpointDiscovery) {
+	*out = *in
+	if in.MinEndpoints != nil {
+		in, out := &in.MinEndpoints, &out.MinEndpoints
+		*out = new(uint32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProxyLocalityEndpointDiscovery.
+func (in *ProxyLocalityEndpointDiscovery) DeepCopy() *ProxyLocalityEndpointDiscovery {
+	if in == nil {
+		return nil
+	}
+	out := new(ProxyLocalityEndpointDiscovery)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ProxyLogging) DeepCopyInto(out *ProxyLogging) {
 	*out = *in
@@ -4296,80 +6006,180 @@ func (in *ProxyMetrics) DeepCopyInto(out *ProxyMetrics) {
 		*out = new(bool)
 		**out = **in
 	}
-	if in.EnablePerEndpointStats != nil {
-		in, out := &in.EnablePerEndpointStats, &out.EnablePerEndpointStats
-		*out = new(bool)
-		**out = **in
+	if in.EnablePerEndpointStats != nil {
+		in, out := &in.EnablePerEndpointStats, &out.EnablePerEndpointStats
+		*out = new(bool)
+		**out = **in
+	}
+	if in.EnableRequestResponseSizesStats != nil {
+		in, out := &in.EnableRequestResponseSizesStats, &out.EnableRequestResponseSizesStats
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProxyMetrics.
+func (in *ProxyMetrics) DeepCopy() *ProxyMetrics {
+	if in == nil {
+		return nil
+	}
+	out := new(ProxyMetrics)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProxyOpenTelemetrySink) DeepCopyInto(out *ProxyOpenTelemetrySink) {
+	*out = *in
+	in.BackendCluster.DeepCopyInto(&out.BackendCluster)
+	if in.Host != nil {
+		in, out := &in.Host, &out.Host
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProxyOpenTelemetrySink.
+func (in *ProxyOpenTelemetrySink) DeepCopy() *ProxyOpenTelemetrySink {
+	if in == nil {
+		return nil
+	}
+	out := new(ProxyOpenTelemetrySink)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProxyPreview) DeepCopyInto(out *ProxyPreview) {
+	*out = *in
+	if in.TTL != nil {
+		in, out := &in.TTL, &out.TTL
+		*out = new(apisv1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProxyPreview.
+func (in *ProxyPreview) DeepCopy() *ProxyPreview {
+	if in == nil {
+		return nil
+	}
+	out := new(ProxyPreview)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProxyPrometheusProvider) DeepCopyInto(out *ProxyPrometheusProvider) {
+	*out = *in
+	if in.Compression != nil {
+		in, out := &in.Compression, &out.Compression
+		*out = new(Compression)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProxyPrometheusProvider.
+func (in *ProxyPrometheusProvider) DeepCopy() *ProxyPrometheusProvider {
+	if in == nil {
+		return nil
+	}
+	out := new(ProxyPrometheusProvider)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProxyProtocol) DeepCopyInto(out *ProxyProtocol) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProxyProtocol.
+func (in *ProxyProtocol) DeepCopy() *ProxyProtocol {
+	if in == nil {
+		return nil
 	}
-	if in.EnableRequestResponseSizesStats != nil {
-		in, out := &in.EnableRequestResponseSizesStats, &out.EnableRequestResponseSizesStats
-		*out = new(bool)
+	out := new(ProxyProtocol)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProxyRouteMatcherTree) DeepCopyInto(out *ProxyRouteMatcherTree) {
+	*out = *in
+	if in.MinRoutes != nil {
+		in, out := &in.MinRoutes, &out.MinRoutes
+		*out = new(uint32)
 		**out = **in
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProxyMetrics.
-func (in *ProxyMetrics) DeepCopy() *ProxyMetrics {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProxyRouteMatcherTree.
+func (in *ProxyRouteMatcherTree) DeepCopy() *ProxyRouteMatcherTree {
 	if in == nil {
 		return nil
 	}
-	out := new(ProxyMetrics)
+	out := new(ProxyRouteMatcherTree)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ProxyOpenTelemetrySink) DeepCopyInto(out *ProxyOpenTelemetrySink) {
+func (in *ProxyRuntimeDiscovery) DeepCopyInto(out *ProxyRuntimeDiscovery) {
 	*out = *in
-	in.BackendCluster.DeepCopyInto(&out.BackendCluster)
-	if in.Host != nil {
-		in, out := &in.Host, &out.Host
-		*out = new(string)
-		**out = **in
+	if in.Values != nil {
+		in, out := &in.Values, &out.Values
+		*out = make([]ProxyRuntimeValue, len(*in))
+		copy(*out, *in)
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProxyOpenTelemetrySink.
-func (in *ProxyOpenTelemetrySink) DeepCopy() *ProxyOpenTelemetrySink {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProxyRuntimeDiscovery.
+func (in *ProxyRuntimeDiscovery) DeepCopy() *ProxyRuntimeDiscovery {
 	if in == nil {
 		return nil
 	}
-	out := new(ProxyOpenTelemetrySink)
+	out := new(ProxyRuntimeDiscovery)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ProxyPrometheusProvider) DeepCopyInto(out *ProxyPrometheusProvider) {
+func (in *ProxyRuntimeFlags) DeepCopyInto(out *ProxyRuntimeFlags) {
 	*out = *in
-	if in.Compression != nil {
-		in, out := &in.Compression, &out.Compression
-		*out = new(Compression)
-		(*in).DeepCopyInto(*out)
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = make([]RuntimeFlag, len(*in))
+		copy(*out, *in)
+	}
+	if in.Disabled != nil {
+		in, out := &in.Disabled, &out.Disabled
+		*out = make([]RuntimeFlag, len(*in))
+		copy(*out, *in)
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProxyPrometheusProvider.
-func (in *ProxyPrometheusProvider) DeepCopy() *ProxyPrometheusProvider {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProxyRuntimeFlags.
+func (in *ProxyRuntimeFlags) DeepCopy() *ProxyRuntimeFlags {
 	if in == nil {
 		return nil
 	}
-	out := new(ProxyPrometheusProvider)
+	out := new(ProxyRuntimeFlags)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ProxyProtocol) DeepCopyInto(out *ProxyProtocol) {
+func (in *ProxyRuntimeValue) DeepCopyInto(out *ProxyRuntimeValue) {
 	*out = *in
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProxyProtocol.
-func (in *ProxyProtocol) DeepCopy() *ProxyProtocol {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProxyRuntimeValue.
+func (in *ProxyRuntimeValue) DeepCopy() *ProxyRuntimeValue {
 	if in == nil {
 		return nil
 	}
-	out := new(ProxyProtocol)
+	out := new(ProxyRuntimeValue)
 	in.DeepCopyInto(out)
 	return out
 }
@@ -4432,6 +6242,96 @@ func (in *ProxyTracing) DeepCopy() *ProxyTracing {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProxyTrafficRecording) DeepCopyInto(out *ProxyTrafficRecording) {
+	*out = *in
+	if in.SamplingPercentage != nil {
+		in, out := &in.SamplingPercentage, &out.SamplingPercentage
+		*out = new(uint32)
+		**out = **in
+	}
+	if in.MaxRequests != nil {
+		in, out := &in.MaxRequests, &out.MaxRequests
+		*out = new(uint32)
+		**out = **in
+	}
+	if in.Headers != nil {
+		in, out := &in.Headers, &out.Headers
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProxyTrafficRecording.
+func (in *ProxyTrafficRecording) DeepCopy() *ProxyTrafficRecording {
+	if in == nil {
+		return nil
+	}
+	out := new(ProxyTrafficRecording)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProxyVirtualHostDiscovery) DeepCopyInto(out *ProxyVirtualHostDiscovery) {
+	*out = *in
+	if in.MinVirtualHosts != nil {
+		in, out := &in.MinVirtualHosts, &out.MinVirtualHosts
+		*out = new(uint32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProxyVirtualHostDiscovery.
+func (in *ProxyVirtualHostDiscovery) DeepCopy() *ProxyVirtualHostDiscovery {
+	if in == nil {
+		return nil
+	}
+	out := new(ProxyVirtualHostDiscovery)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProxyWarming) DeepCopyInto(out *ProxyWarming) {
+	*out = *in
+	if in.InitialFetchTimeout != nil {
+		in, out := &in.InitialFetchTimeout, &out.InitialFetchTimeout
+		*out = new(apisv1.Duration)
+		**out = **in
+	}
+	if in.WaitForListenerAck != nil {
+		in, out := &in.WaitForListenerAck, &out.WaitForListenerAck
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProxyWarming.
+func (in *ProxyWarming) DeepCopy() *ProxyWarming {
+	if in == nil {
+		return nil
+	}
+	out := new(ProxyWarming)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProxyXdsCompression) DeepCopyInto(out *ProxyXdsCompression) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProxyXdsCompression.
+func (in *ProxyXdsCompression) DeepCopy() *ProxyXdsCompression {
+	if in == nil {
+		return nil
+	}
+	out := new(ProxyXdsCompression)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RateLimit) DeepCopyInto(out *RateLimit) {
 	*out = *in
@@ -4763,6 +6663,87 @@ func (in *RequestHeaderCustomTag) DeepCopy() *RequestHeaderCustomTag {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RequestHeaderToMetadata) DeepCopyInto(out *RequestHeaderToMetadata) {
+	*out = *in
+	if in.OnPresent != nil {
+		in, out := &in.OnPresent, &out.OnPresent
+		*out = new(MetadataValue)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.OnMissing != nil {
+		in, out := &in.OnMissing, &out.OnMissing
+		*out = new(MetadataValue)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Remove != nil {
+		in, out := &in.Remove, &out.Remove
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RequestHeaderToMetadata.
+func (in *RequestHeaderToMetadata) DeepCopy() *RequestHeaderToMetadata {
+	if in == nil {
+		return nil
+	}
+	out := new(RequestHeaderToMetadata)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RequestSigning) DeepCopyInto(out *RequestSigning) {
+	*out = *in
+	if in.AWSSigV4 != nil {
+		in, out := &in.AWSSigV4, &out.AWSSigV4
+		*out = new(AWSSigV4RequestSigning)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.HMAC != nil {
+		in, out := &in.HMAC, &out.HMAC
+		*out = new(HMACRequestSigning)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RequestSigning.
+func (in *RequestSigning) DeepCopy() *RequestSigning {
+	if in == nil {
+		return nil
+	}
+	out := new(RequestSigning)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ResponseBuffer) DeepCopyInto(out *ResponseBuffer) {
+	*out = *in
+	out.Limit = in.Limit.DeepCopy()
+	if in.OnLimitExceeded != nil {
+		in, out := &in.OnLimitExceeded, &out.OnLimitExceeded
+		*out = new(ResponseBufferLimitAction)
+		**out = **in
+	}
+	if in.ChunkedEncoding != nil {
+		in, out := &in.ChunkedEncoding, &out.ChunkedEncoding
+		*out = new(ResponseChunkedEncoding)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResponseBuffer.
+func (in *ResponseBuffer) DeepCopy() *ResponseBuffer {
+	if in == nil {
+		return nil
+	}
+	out := new(ResponseBuffer)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ResponseOverride) DeepCopyInto(out *ResponseOverride) {
 	*out = *in
@@ -4835,6 +6816,96 @@ func (in *RetryOn) DeepCopy() *RetryOn {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RouteConflictResolution) DeepCopyInto(out *RouteConflictResolution) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RouteConflictResolution.
+func (in *RouteConflictResolution) DeepCopy() *RouteConflictResolution {
+	if in == nil {
+		return nil
+	}
+	out := new(RouteConflictResolution)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SecurityHeader) DeepCopyInto(out *SecurityHeader) {
+	*out = *in
+	if in.Value != nil {
+		in, out := &in.Value, &out.Value
+		*out = new(string)
+		**out = **in
+	}
+	if in.Disabled != nil {
+		in, out := &in.Disabled, &out.Disabled
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecurityHeader.
+func (in *SecurityHeader) DeepCopy() *SecurityHeader {
+	if in == nil {
+		return nil
+	}
+	out := new(SecurityHeader)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SecurityHeaders) DeepCopyInto(out *SecurityHeaders) {
+	*out = *in
+	if in.StrictTransportSecurity != nil {
+		in, out := &in.StrictTransportSecurity, &out.StrictTransportSecurity
+		*out = new(SecurityHeader)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ContentSecurityPolicy != nil {
+		in, out := &in.ContentSecurityPolicy, &out.ContentSecurityPolicy
+		*out = new(SecurityHeader)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.XFrameOptions != nil {
+		in, out := &in.XFrameOptions, &out.XFrameOptions
+		*out = new(SecurityHeader)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.XContentTypeOptions != nil {
+		in, out := &in.XContentTypeOptions, &out.XContentTypeOptions
+		*out = new(SecurityHeader)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ReferrerPolicy != nil {
+		in, out := &in.ReferrerPolicy, &out.ReferrerPolicy
+		*out = new(SecurityHeader)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PermissionsPolicy != nil {
+		in, out := &in.PermissionsPolicy, &out.PermissionsPolicy
+		*out = new(SecurityHeader)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Overwrite != nil {
+		in, out := &in.Overwrite, &out.Overwrite
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecurityHeaders.
+func (in *SecurityHeaders) DeepCopy() *SecurityHeaders {
+	if in == nil {
+		return nil
+	}
+	out := new(SecurityHeaders)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SecurityPolicy) DeepCopyInto(out *SecurityPolicy) {
 	*out = *in
@@ -4918,24 +6989,79 @@ func (in *SecurityPolicySpec) DeepCopyInto(out *SecurityPolicySpec) {
 		*out = new(OIDC)
 		(*in).DeepCopyInto(*out)
 	}
-	if in.ExtAuth != nil {
-		in, out := &in.ExtAuth, &out.ExtAuth
-		*out = new(ExtAuth)
+	if in.ExtAuth != nil {
+		in, out := &in.ExtAuth, &out.ExtAuth
+		*out = new(ExtAuth)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Authorization != nil {
+		in, out := &in.Authorization, &out.Authorization
+		*out = new(Authorization)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SecurityHeaders != nil {
+		in, out := &in.SecurityHeaders, &out.SecurityHeaders
+		*out = new(SecurityHeaders)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecurityPolicySpec.
+func (in *SecurityPolicySpec) DeepCopy() *SecurityPolicySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(SecurityPolicySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SessionResumption) DeepCopyInto(out *SessionResumption) {
+	*out = *in
+	if in.Stateless != nil {
+		in, out := &in.Stateless, &out.Stateless
+		*out = new(StatelessSessionResumption)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Stateful != nil {
+		in, out := &in.Stateful, &out.Stateful
+		*out = new(StatefulSessionResumption)
 		(*in).DeepCopyInto(*out)
 	}
-	if in.Authorization != nil {
-		in, out := &in.Authorization, &out.Authorization
-		*out = new(Authorization)
-		(*in).DeepCopyInto(*out)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SessionResumption.
+func (in *SessionResumption) DeepCopy() *SessionResumption {
+	if in == nil {
+		return nil
 	}
+	out := new(SessionResumption)
+	in.DeepCopyInto(out)
+	return out
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecurityPolicySpec.
-func (in *SecurityPolicySpec) DeepCopy() *SecurityPolicySpec {
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SessionTicketKeys) DeepCopyInto(out *SessionTicketKeys) {
+	*out = *in
+	if in.RotationInterval != nil {
+		in, out := &in.RotationInterval, &out.RotationInterval
+		*out = new(apisv1.Duration)
+		**out = **in
+	}
+	if in.RetainedKeys != nil {
+		in, out := &in.RetainedKeys, &out.RetainedKeys
+		*out = new(uint32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SessionTicketKeys.
+func (in *SessionTicketKeys) DeepCopy() *SessionTicketKeys {
 	if in == nil {
 		return nil
 	}
-	out := new(SecurityPolicySpec)
+	out := new(SessionTicketKeys)
 	in.DeepCopyInto(out)
 	return out
 }
@@ -5030,6 +7156,51 @@ func (in *SourceMatch) DeepCopy() *SourceMatch {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *StatefulSessionResumption) DeepCopyInto(out *StatefulSessionResumption) {
+	*out = *in
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StatefulSessionResumption.
+func (in *StatefulSessionResumption) DeepCopy() *StatefulSessionResumption {
+	if in == nil {
+		return nil
+	}
+	out := new(StatefulSessionResumption)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *StatelessSessionResumption) DeepCopyInto(out *StatelessSessionResumption) {
+	*out = *in
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
+	if in.TicketKeys != nil {
+		in, out := &in.TicketKeys, &out.TicketKeys
+		*out = new(SessionTicketKeys)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StatelessSessionResumption.
+func (in *StatelessSessionResumption) DeepCopy() *StatelessSessionResumption {
+	if in == nil {
+		return nil
+	}
+	out := new(StatelessSessionResumption)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *StatusCodeMatch) DeepCopyInto(out *StatusCodeMatch) {
 	*out = *in
@@ -5394,6 +7565,91 @@ func (in *WasmCodeSource) DeepCopy() *WasmCodeSource {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WatchableQueue) DeepCopyInto(out *WatchableQueue) {
+	*out = *in
+	if in.Coalesce != nil {
+		in, out := &in.Coalesce, &out.Coalesce
+		*out = new(bool)
+		**out = **in
+	}
+	if in.OverflowPolicy != nil {
+		in, out := &in.OverflowPolicy, &out.OverflowPolicy
+		*out = new(WatchableOverflowPolicy)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WatchableQueue.
+func (in *WatchableQueue) DeepCopy() *WatchableQueue {
+	if in == nil {
+		return nil
+	}
+	out := new(WatchableQueue)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WatchableRetry) DeepCopyInto(out *WatchableRetry) {
+	*out = *in
+	if in.MaxAttempts != nil {
+		in, out := &in.MaxAttempts, &out.MaxAttempts
+		*out = new(uint32)
+		**out = **in
+	}
+	if in.Backoff != nil {
+		in, out := &in.Backoff, &out.Backoff
+		*out = new(apisv1.Duration)
+		**out = **in
+	}
+	if in.MaxBackoff != nil {
+		in, out := &in.MaxBackoff, &out.MaxBackoff
+		*out = new(apisv1.Duration)
+		**out = **in
+	}
+	if in.DeadLetterCapacity != nil {
+		in, out := &in.DeadLetterCapacity, &out.DeadLetterCapacity
+		*out = new(uint32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WatchableRetry.
+func (in *WatchableRetry) DeepCopy() *WatchableRetry {
+	if in == nil {
+		return nil
+	}
+	out := new(WatchableRetry)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WatchableTracing) DeepCopyInto(out *WatchableTracing) {
+	*out = *in
+	if in.Port != nil {
+		in, out := &in.Port, &out.Port
+		*out = new(int32)
+		**out = **in
+	}
+	if in.SamplingRate != nil {
+		in, out := &in.SamplingRate, &out.SamplingRate
+		*out = new(uint32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WatchableTracing.
+func (in *WatchableTracing) DeepCopy() *WatchableTracing {
+	if in == nil {
+		return nil
+	}
+	out := new(WatchableTracing)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *XDSTranslatorHooks) DeepCopyInto(out *XDSTranslatorHooks) {
 	*out = *in
@@ -5464,6 +7720,202 @@ func (in *XForwardedForSettings) DeepCopy() *XForwardedForSettings {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *XdsAnomalyDetection) DeepCopyInto(out *XdsAnomalyDetection) {
+	*out = *in
+	if in.Interval != nil {
+		in, out := &in.Interval, &out.Interval
+		*out = new(apisv1.Duration)
+		**out = **in
+	}
+	if in.DegradationTTL != nil {
+		in, out := &in.DegradationTTL, &out.DegradationTTL
+		*out = new(apisv1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new XdsAnomalyDetection.
+func (in *XdsAnomalyDetection) DeepCopy() *XdsAnomalyDetection {
+	if in == nil {
+		return nil
+	}
+	out := new(XdsAnomalyDetection)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *XdsAuditLog) DeepCopyInto(out *XdsAuditLog) {
+	*out = *in
+	in.Sink.DeepCopyInto(&out.Sink)
+	if in.SamplingPercent != nil {
+		in, out := &in.SamplingPercent, &out.SamplingPercent
+		*out = new(uint32)
+		**out = **in
+	}
+	if in.Types != nil {
+		in, out := &in.Types, &out.Types
+		*out = make([]EnvoyResourceType, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new XdsAuditLog.
+func (in *XdsAuditLog) DeepCopy() *XdsAuditLog {
+	if in == nil {
+		return nil
+	}
+	out := new(XdsAuditLog)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *XdsAuditLogFileSink) DeepCopyInto(out *XdsAuditLogFileSink) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new XdsAuditLogFileSink.
+func (in *XdsAuditLogFileSink) DeepCopy() *XdsAuditLogFileSink {
+	if in == nil {
+		return nil
+	}
+	out := new(XdsAuditLogFileSink)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *XdsAuditLogOpenTelemetrySink) DeepCopyInto(out *XdsAuditLogOpenTelemetrySink) {
+	*out = *in
+	if in.Port != nil {
+		in, out := &in.Port, &out.Port
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new XdsAuditLogOpenTelemetrySink.
+func (in *XdsAuditLogOpenTelemetrySink) DeepCopy() *XdsAuditLogOpenTelemetrySink {
+	if in == nil {
+		return nil
+	}
+	out := new(XdsAuditLogOpenTelemetrySink)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *XdsAuditLogSink) DeepCopyInto(out *XdsAuditLogSink) {
+	*out = *in
+	if in.File != nil {
+		in, out := &in.File, &out.File
+		*out = new(XdsAuditLogFileSink)
+		**out = **in
+	}
+	if in.OpenTelemetry != nil {
+		in, out := &in.OpenTelemetry, &out.OpenTelemetry
+		*out = new(XdsAuditLogOpenTelemetrySink)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new XdsAuditLogSink.
+func (in *XdsAuditLogSink) DeepCopy() *XdsAuditLogSink {
+	if in == nil {
+		return nil
+	}
+	out := new(XdsAuditLogSink)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *XdsNodeAuthorization) DeepCopyInto(out *XdsNodeAuthorization) {
+	*out = *in
+	if in.ServiceAccountToken != nil {
+		in, out := &in.ServiceAccountToken, &out.ServiceAccountToken
+		*out = new(XdsNodeServiceAccountToken)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new XdsNodeAuthorization.
+func (in *XdsNodeAuthorization) DeepCopy() *XdsNodeAuthorization {
+	if in == nil {
+		return nil
+	}
+	out := new(XdsNodeAuthorization)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *XdsNodeServiceAccountToken) DeepCopyInto(out *XdsNodeServiceAccountToken) {
+	*out = *in
+	if in.MetadataKey != nil {
+		in, out := &in.MetadataKey, &out.MetadataKey
+		*out = new(string)
+		**out = **in
+	}
+	if in.Audiences != nil {
+		in, out := &in.Audiences, &out.Audiences
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new XdsNodeServiceAccountToken.
+func (in *XdsNodeServiceAccountToken) DeepCopy() *XdsNodeServiceAccountToken {
+	if in == nil {
+		return nil
+	}
+	out := new(XdsNodeServiceAccountToken)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *XdsServerIsolatedGateway) DeepCopyInto(out *XdsServerIsolatedGateway) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new XdsServerIsolatedGateway.
+func (in *XdsServerIsolatedGateway) DeepCopy() *XdsServerIsolatedGateway {
+	if in == nil {
+		return nil
+	}
+	out := new(XdsServerIsolatedGateway)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *XdsServerKeepalive) DeepCopyInto(out *XdsServerKeepalive) {
+	*out = *in
+	if in.Time != nil {
+		in, out := &in.Time, &out.Time
+		*out = new(apisv1.Duration)
+		**out = **in
+	}
+	if in.Timeout != nil {
+		in, out := &in.Timeout, &out.Timeout
+		*out = new(apisv1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new XdsServerKeepalive.
+func (in *XdsServerKeepalive) DeepCopy() *XdsServerKeepalive {
+	if in == nil {
+		return nil
+	}
+	out := new(XdsServerKeepalive)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ZipkinTracingProvider) DeepCopyInto(out *ZipkinTracingProvider) {
 	*out = *in
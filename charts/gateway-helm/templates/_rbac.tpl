@@ -8,6 +8,8 @@ All namespaced resources for Envoy Gateway RBAC.
 - {{ include "eg.rbac.namespaced.gateway.envoyproxy" . | nindent 2 | trim }}
 - {{ include "eg.rbac.namespaced.gateway.envoyproxy.status" . | nindent 2 | trim }}
 - {{ include "eg.rbac.namespaced.gateway.networking" . | nindent 2 | trim }}
+- {{ include "eg.rbac.namespaced.gateway.networking.finalizers" . | nindent 2 | trim }}
+- {{ include "eg.rbac.namespaced.gateway.networking.canary" . | nindent 2 | trim }}
 - {{ include "eg.rbac.namespaced.gateway.networking.status" . | nindent 2 | trim }}
 {{- end }}
 
@@ -16,6 +18,7 @@ All cluster scoped resources for Envoy Gateway RBAC.
 */}}
 {{- define "eg.rbac.cluster" -}}
 - {{ include "eg.rbac.cluster.basic" . | nindent 2 | trim }}
+- {{ include "eg.rbac.cluster.events" . | nindent 2 | trim }}
 - {{ include "eg.rbac.cluster.gateway.networking" . | nindent 2 | trim }}
 - {{ include "eg.rbac.cluster.gateway.networking.status" . | nindent 2 | trim }}
 - {{ include "eg.rbac.cluster.multiclusterservices" . | nindent 2 | trim }}
@@ -110,6 +113,25 @@ verbs:
 - watch
 {{- end }}
 
+{{- define "eg.rbac.namespaced.gateway.networking.finalizers" -}}
+apiGroups:
+- gateway.networking.k8s.io
+resources:
+- gateways
+verbs:
+- patch
+- update
+{{- end }}
+
+{{- define "eg.rbac.namespaced.gateway.networking.canary" -}}
+apiGroups:
+- gateway.networking.k8s.io
+resources:
+- httproutes
+verbs:
+- patch
+{{- end }}
+
 {{- define "eg.rbac.namespaced.gateway.networking.status" -}}
 apiGroups:
 - gateway.networking.k8s.io
@@ -141,6 +163,16 @@ verbs:
 - watch
 {{- end }}
 
+{{- define "eg.rbac.cluster.events" -}}
+apiGroups:
+- ""
+resources:
+- events
+verbs:
+- create
+- patch
+{{- end }}
+
 {{- define "eg.rbac.cluster.gateway.networking" -}}
 apiGroups:
 - gateway.networking.k8s.io
@@ -0,0 +1,508 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package admin
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"net/http"
+	"net/url"
+	"slices"
+	"sort"
+	"strings"
+
+	"google.golang.org/protobuf/encoding/protojson"
+
+	"github.com/envoyproxy/gateway/internal/ir"
+	"github.com/envoyproxy/gateway/internal/message"
+	"github.com/envoyproxy/gateway/internal/replay"
+	"github.com/envoyproxy/gateway/internal/xds/cache"
+	xdstypes "github.com/envoyproxy/gateway/internal/xds/types"
+)
+
+// APIPrefix is the path prefix of the versioned admin API.
+const APIPrefix = "/api/v1"
+
+// XdsTranslator is the subset of the xds-translator runner used by the admin API.
+type XdsTranslator interface {
+	// Retranslate translates the xds IR stored for the irKey again.
+	Retranslate(irKey string) error
+	// TranslateIR translates the xds IR into xds resources without publishing them.
+	TranslateIR(xdsIR *ir.Xds) (*xdstypes.ResourceVersionTable, error)
+}
+
+// XdsServer is the subset of the xds-server runner used by the admin API.
+type XdsServer interface {
+	// SnapshotCache returns the snapshot cache backing the xDS server.
+	SnapshotCache() cache.SnapshotCacheWithCallbacks
+	// SnapshotCachePartitions returns the partitions of the snapshot cache of the
+	// isolated Gateways by irKey.
+	SnapshotCachePartitions() map[string]cache.SnapshotCacheWithCallbacks
+	// PausePublishing stops publishing new snapshots for the irKey.
+	PausePublishing(irKey string)
+	// ResumePublishing resumes publishing snapshots for the irKey.
+	ResumePublishing(irKey string) error
+	// PublishingPaused returns true if publishing is paused for the irKey.
+	PublishingPaused(irKey string) bool
+	// RecordedRequests returns the requests recorded for the irKey, or false if its
+	// traffic isn't recorded.
+	RecordedRequests(irKey string) ([]*replay.Request, bool)
+}
+
+// API serves the versioned admin API, which exposes the state of the
+// translation pipeline and allows operators to drive it.
+type API struct {
+	ProviderResources    *message.ProviderResources
+	XdsIR                *message.XdsIR
+	Xds                  *message.Xds
+	GatewayAPITranslator GatewayAPITranslator
+	XdsTranslator        XdsTranslator
+	XdsServer            XdsServer
+	LogLevels            LogLevels
+	InfraIR              *message.InfraIR
+	ProxyAdmin           ProxyAdmin
+	// Replays runs the jobs replaying the recorded traffic.
+	Replays *replay.Manager
+}
+
+// IRKeyStatus is the publishing status of an irKey.
+type IRKeyStatus struct {
+	IRKey           string `json:"irKey"`
+	SnapshotVersion string `json:"snapshotVersion,omitempty"`
+	Paused          bool   `json:"paused"`
+	// Nodes holds the IDs of the Envoy proxies connected for the irKey.
+	Nodes []string `json:"nodes,omitempty"`
+}
+
+// SnapshotMetadata is the metadata of the snapshot served for an irKey.
+type SnapshotMetadata struct {
+	*cache.SnapshotInfo
+	Paused bool `json:"paused"`
+}
+
+// StageHealth is the health of a single stage of the translation pipeline.
+type StageHealth struct {
+	// Name is the name of the runner backing the stage.
+	Name string `json:"name"`
+	// Keys is the number of keys published by the stage.
+	Keys int `json:"keys"`
+	// Missing holds the keys of the previous stage that this stage has not published.
+	Missing []string `json:"missing,omitempty"`
+}
+
+// PipelineHealth is the health of the translation pipeline.
+type PipelineHealth struct {
+	Healthy bool          `json:"healthy"`
+	Stages  []StageHealth `json:"stages"`
+}
+
+// errorResponse is the body returned by the admin API on failure.
+type errorResponse struct {
+	Error string `json:"error"`
+}
+
+// registerHandlers registers the handlers of the admin API, which is only served once
+// enabled by the EnableAPI flag of the admin server. The handlers changing the state of
+// the pipeline only handle the requests of their method that don't come from another origin.
+func (a *API) registerHandlers(mux *http.ServeMux) {
+	mux.HandleFunc("GET "+APIPrefix+"/health", a.handleHealth)
+	mux.HandleFunc("GET "+APIPrefix+"/irkeys", a.handleListIRKeys)
+	mux.HandleFunc("GET "+APIPrefix+"/snapshots/{irKey...}", a.handleGetSnapshot)
+	mux.HandleFunc("GET "+APIPrefix+"/snapshot-history/{irKey...}", a.handleGetSnapshotHistory)
+	mux.HandleFunc("GET "+APIPrefix+"/snapshot-diffs/{irKey...}", a.handleDiffSnapshots)
+	mux.HandleFunc("GET "+APIPrefix+"/config-dumps/{irKey...}", a.handleConfigDump)
+	mux.HandleFunc("GET "+APIPrefix+"/nodes", a.handleListNodes)
+	mux.HandleFunc("POST "+APIPrefix+"/retranslate/{irKey...}", mutating(a.handleRetranslate))
+	mux.HandleFunc("POST "+APIPrefix+"/pause/{irKey...}", mutating(a.handlePause))
+	mux.HandleFunc("POST "+APIPrefix+"/resume/{irKey...}", mutating(a.handleResume))
+	mux.HandleFunc("GET "+APIPrefix+"/dead-letters", a.handleListDeadLetters)
+	mux.HandleFunc("GET "+APIPrefix+"/loglevels", a.handleListLogLevels)
+	mux.HandleFunc("PUT "+APIPrefix+"/loglevels/{component}", mutating(a.handleSetLogLevel))
+	mux.HandleFunc("GET "+APIPrefix+"/proxies/{namespace}/{name}/admin/{path...}", a.handleProxyAdmin)
+	mux.HandleFunc("POST "+APIPrefix+"/simulate", a.handleSimulate)
+	mux.HandleFunc("GET "+APIPrefix+"/recordings/{irKey...}", a.handleGetRecording)
+	mux.HandleFunc("POST "+APIPrefix+"/replays", mutating(a.handleStartReplay))
+	mux.HandleFunc("GET "+APIPrefix+"/replays", a.handleListReplays)
+	mux.HandleFunc("GET "+APIPrefix+"/replays/{id}", a.handleGetReplay)
+	mux.HandleFunc("DELETE "+APIPrefix+"/replays/{id}", mutating(a.handleCancelReplay))
+}
+
+func (a *API) handleListIRKeys(w http.ResponseWriter, _ *http.Request) {
+	keys := make(map[string]struct{})
+	if a.XdsIR != nil {
+		for key := range a.XdsIR.LoadAll() {
+			keys[key] = struct{}{}
+		}
+	}
+	for _, snapshotCache := range a.snapshotCaches() {
+		for _, key := range snapshotCache.IRKeys() {
+			keys[key] = struct{}{}
+		}
+	}
+
+	statuses := make([]IRKeyStatus, 0, len(keys))
+	for key := range keys {
+		status := IRKeyStatus{IRKey: key}
+		if snapshotCache := a.snapshotCacheFor(key); snapshotCache != nil {
+			if info, ok := snapshotCache.GetSnapshotInfo(key); ok {
+				status.SnapshotVersion = info.Version
+				status.Nodes = info.Nodes
+			}
+		}
+		if a.XdsServer != nil {
+			status.Paused = a.XdsServer.PublishingPaused(key)
+		}
+		statuses = append(statuses, status)
+	}
+	sort.Slice(statuses, func(i, j int) bool {
+		return statuses[i].IRKey < statuses[j].IRKey
+	})
+
+	writeJSON(w, http.StatusOK, statuses)
+}
+
+func (a *API) handleGetSnapshot(w http.ResponseWriter, r *http.Request) {
+	irKey := r.PathValue("irKey")
+
+	snapshotCache := a.snapshotCacheFor(irKey)
+	if snapshotCache == nil {
+		writeError(w, http.StatusServiceUnavailable, fmt.Errorf("snapshot cache is not ready"))
+		return
+	}
+
+	info, ok := snapshotCache.GetSnapshotInfo(irKey)
+	if !ok {
+		writeError(w, http.StatusNotFound, fmt.Errorf("snapshot for %s not found", irKey))
+		return
+	}
+
+	writeJSON(w, http.StatusOK, &SnapshotMetadata{
+		SnapshotInfo: info,
+		Paused:       a.XdsServer.PublishingPaused(irKey),
+	})
+}
+
+// handleGetSnapshotHistory returns the versions, generation times and resource counts of
+// the last snapshots of the irKey, newest first.
+func (a *API) handleGetSnapshotHistory(w http.ResponseWriter, r *http.Request) {
+	irKey := r.PathValue("irKey")
+
+	snapshotCache := a.snapshotCacheFor(irKey)
+	if snapshotCache == nil {
+		writeError(w, http.StatusServiceUnavailable, fmt.Errorf("snapshot cache is not ready"))
+		return
+	}
+
+	history := snapshotCache.GetSnapshotHistory(irKey)
+	if len(history) == 0 {
+		writeError(w, http.StatusNotFound, fmt.Errorf("snapshot history for %s not found", irKey))
+		return
+	}
+
+	writeJSON(w, http.StatusOK, history)
+}
+
+// handleDiffSnapshots returns the changes of the resources between the snapshot versions
+// of the irKey in the from and to query parameters, which default to the last snapshot and
+// the one preceding the to version.
+func (a *API) handleDiffSnapshots(w http.ResponseWriter, r *http.Request) {
+	irKey := r.PathValue("irKey")
+
+	snapshotCache := a.snapshotCacheFor(irKey)
+	if snapshotCache == nil {
+		writeError(w, http.StatusServiceUnavailable, fmt.Errorf("snapshot cache is not ready"))
+		return
+	}
+
+	query := r.URL.Query()
+	diff, err := snapshotCache.DiffSnapshots(irKey, query.Get("from"), query.Get("to"))
+	if err != nil {
+		if errors.Is(err, cache.ErrSnapshotNotFound) {
+			writeError(w, http.StatusNotFound, err)
+			return
+		}
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, diff)
+}
+
+// handleConfigDump returns the resources of the last snapshot of the irKey, or of the
+// snapshot served to the node in the node query parameter, in the config dump format of
+// Envoy, with the endpoints if the include_eds query parameter is set as for Envoy.
+func (a *API) handleConfigDump(w http.ResponseWriter, r *http.Request) {
+	irKey := r.PathValue("irKey")
+
+	snapshotCache := a.snapshotCacheFor(irKey)
+	if snapshotCache == nil {
+		writeError(w, http.StatusServiceUnavailable, fmt.Errorf("snapshot cache is not ready"))
+		return
+	}
+
+	query := r.URL.Query()
+	configDump, err := snapshotCache.ConfigDump(irKey, query.Get("node"), query.Has("include_eds"))
+	if err != nil {
+		if errors.Is(err, cache.ErrSnapshotNotFound) || errors.Is(err, cache.ErrNodeNotFound) {
+			writeError(w, http.StatusNotFound, err)
+			return
+		}
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	out, err := protojson.MarshalOptions{Multiline: true, Indent: "  "}.Marshal(configDump)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(out)
+}
+
+// handleListNodes returns the Envoy nodes connected to the xDS server, only the ones of the
+// irKey in the irKey query parameter if set.
+func (a *API) handleListNodes(w http.ResponseWriter, r *http.Request) {
+	snapshotCaches := a.snapshotCaches()
+	if len(snapshotCaches) == 0 {
+		writeError(w, http.StatusServiceUnavailable, fmt.Errorf("snapshot cache is not ready"))
+		return
+	}
+
+	irKey := r.URL.Query().Get("irKey")
+	nodes := make([]cache.ConnectedNode, 0)
+	for _, snapshotCache := range snapshotCaches {
+		for _, node := range snapshotCache.ConnectedNodes() {
+			if irKey == "" || node.Cluster == irKey {
+				nodes = append(nodes, node)
+			}
+		}
+	}
+
+	writeJSON(w, http.StatusOK, nodes)
+}
+
+func (a *API) handleRetranslate(w http.ResponseWriter, r *http.Request) {
+	irKey := r.PathValue("irKey")
+
+	if a.XdsTranslator == nil || a.XdsIR == nil {
+		writeError(w, http.StatusServiceUnavailable, fmt.Errorf("xds translator is not ready"))
+		return
+	}
+	if _, ok := a.XdsIR.Load(irKey); !ok {
+		writeError(w, http.StatusNotFound, fmt.Errorf("xds ir %s not found", irKey))
+		return
+	}
+	if err := a.XdsTranslator.Retranslate(irKey); err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusAccepted)
+}
+
+func (a *API) handlePause(w http.ResponseWriter, r *http.Request) {
+	if a.XdsServer == nil {
+		writeError(w, http.StatusServiceUnavailable, fmt.Errorf("xds server is not ready"))
+		return
+	}
+
+	a.XdsServer.PausePublishing(r.PathValue("irKey"))
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (a *API) handleResume(w http.ResponseWriter, r *http.Request) {
+	if a.XdsServer == nil {
+		writeError(w, http.StatusServiceUnavailable, fmt.Errorf("xds server is not ready"))
+		return
+	}
+
+	if err := a.XdsServer.ResumePublishing(r.PathValue("irKey")); err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// handleListDeadLetters returns the updates of the messages dead-lettered by the
+// subscribers failing to handle them, oldest first.
+func (a *API) handleListDeadLetters(w http.ResponseWriter, _ *http.Request) {
+	letters := message.DeadLetters()
+	if letters == nil {
+		letters = []message.DeadLetter{}
+	}
+	writeJSON(w, http.StatusOK, letters)
+}
+
+func (a *API) handleHealth(w http.ResponseWriter, _ *http.Request) {
+	health := a.pipelineHealth()
+
+	status := http.StatusOK
+	if !health.Healthy {
+		status = http.StatusServiceUnavailable
+	}
+	writeJSON(w, status, health)
+}
+
+// pipelineHealth compares the keys published by each stage of the pipeline
+// with the keys published by the stage before it.
+func (a *API) pipelineHealth() *PipelineHealth {
+	health := &PipelineHealth{Healthy: true}
+
+	if a.ProviderResources != nil {
+		health.Stages = append(health.Stages, StageHealth{
+			Name: "provider",
+			Keys: a.ProviderResources.GatewayAPIResources.Len(),
+		})
+	}
+
+	var irKeys []string
+	if a.XdsIR != nil {
+		for key := range a.XdsIR.LoadAll() {
+			irKeys = append(irKeys, key)
+		}
+		health.Stages = append(health.Stages, StageHealth{
+			Name: "gateway-api",
+			Keys: len(irKeys),
+		})
+	}
+
+	var xdsKeys []string
+	if a.Xds != nil {
+		for key := range a.Xds.LoadAll() {
+			xdsKeys = append(xdsKeys, key)
+		}
+		health.Stages = append(health.Stages, StageHealth{
+			Name:    "xds-translator",
+			Keys:    len(xdsKeys),
+			Missing: missingKeys(irKeys, xdsKeys),
+		})
+	}
+
+	if snapshotCaches := a.snapshotCaches(); len(snapshotCaches) > 0 {
+		var snapshotKeys []string
+		for _, snapshotCache := range snapshotCaches {
+			snapshotKeys = append(snapshotKeys, snapshotCache.IRKeys()...)
+		}
+		var missing []string
+		for _, key := range missingKeys(xdsKeys, snapshotKeys) {
+			// Paused keys are expected to lag behind.
+			if !a.XdsServer.PublishingPaused(key) {
+				missing = append(missing, key)
+			}
+		}
+		health.Stages = append(health.Stages, StageHealth{
+			Name:    "xds-server",
+			Keys:    len(snapshotKeys),
+			Missing: missing,
+		})
+	}
+
+	for _, stage := range health.Stages {
+		if len(stage.Missing) > 0 {
+			health.Healthy = false
+		}
+	}
+
+	return health
+}
+
+func (a *API) snapshotCache() cache.SnapshotCacheWithCallbacks {
+	if a.XdsServer == nil {
+		return nil
+	}
+	return a.XdsServer.SnapshotCache()
+}
+
+// snapshotCacheFor returns the snapshot cache holding the snapshots of the irKey, which is
+// the partition of the irKey if its Gateway is isolated.
+func (a *API) snapshotCacheFor(irKey string) cache.SnapshotCacheWithCallbacks {
+	if a.XdsServer == nil {
+		return nil
+	}
+	if partition, ok := a.XdsServer.SnapshotCachePartitions()[irKey]; ok {
+		return partition
+	}
+	return a.XdsServer.SnapshotCache()
+}
+
+// snapshotCaches returns the snapshot cache backing the xDS server followed by the
+// partitions of the isolated Gateways, sorted by irKey.
+func (a *API) snapshotCaches() []cache.SnapshotCacheWithCallbacks {
+	snapshotCache := a.snapshotCache()
+	if snapshotCache == nil {
+		return nil
+	}
+	caches := []cache.SnapshotCacheWithCallbacks{snapshotCache}
+	partitions := a.XdsServer.SnapshotCachePartitions()
+	for _, irKey := range slices.Sorted(maps.Keys(partitions)) {
+		caches = append(caches, partitions[irKey])
+	}
+	return caches
+}
+
+// missingKeys returns the sorted keys that are in want but not in got.
+func missingKeys(want, got []string) []string {
+	gotSet := make(map[string]struct{}, len(got))
+	for _, key := range got {
+		gotSet[key] = struct{}{}
+	}
+
+	var missing []string
+	for _, key := range want {
+		if _, ok := gotSet[key]; !ok {
+			missing = append(missing, key)
+		}
+	}
+	sort.Strings(missing)
+
+	return missing
+}
+
+// mutating only lets the handler of a request changing the state of the pipeline handle the
+// requests that don't come from a web page of another origin, which the browser of an
+// operator would send to the admin server with its credentials.
+func mutating(handler http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if crossOrigin(r) {
+			writeError(w, http.StatusForbidden, fmt.Errorf("cross-origin %s requests are not allowed", r.Method))
+			return
+		}
+		handler(w, r)
+	}
+}
+
+// crossOrigin returns whether the request was sent by a browser from a web page of another
+// origin than the admin server.
+func crossOrigin(r *http.Request) bool {
+	switch r.Header.Get("Sec-Fetch-Site") {
+	case "", "same-origin", "none":
+	default:
+		return true
+	}
+	origin := r.Header.Get("Origin")
+	if origin == "" {
+		return false
+	}
+	u, err := url.Parse(origin)
+	return err != nil || u.Host != r.Host
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	_ = enc.Encode(v)
+}
+
+func writeError(w http.ResponseWriter, status int, err error) {
+	writeJSON(w, status, &errorResponse{Error: strings.TrimSpace(err.Error())})
+}
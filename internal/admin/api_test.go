@@ -0,0 +1,778 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package admin
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	adminv3 "github.com/envoyproxy/go-control-plane/envoy/admin/v3"
+	clusterv3 "github.com/envoyproxy/go-control-plane/envoy/config/cluster/v3"
+	corev3 "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	listenerv3 "github.com/envoyproxy/go-control-plane/envoy/config/listener/v3"
+	discoveryv3 "github.com/envoyproxy/go-control-plane/envoy/service/discovery/v3"
+	"github.com/envoyproxy/go-control-plane/pkg/cache/types"
+	resourcev3 "github.com/envoyproxy/go-control-plane/pkg/resource/v3"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/types/known/durationpb"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	k8stypes "k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	gwapiv1 "sigs.k8s.io/gateway-api/apis/v1"
+	gwapiv1a2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
+
+	egv1a1 "github.com/envoyproxy/gateway/api/v1alpha1"
+	"github.com/envoyproxy/gateway/internal/gatewayapi"
+	"github.com/envoyproxy/gateway/internal/gatewayapi/resource"
+	"github.com/envoyproxy/gateway/internal/ir"
+	"github.com/envoyproxy/gateway/internal/logging"
+	"github.com/envoyproxy/gateway/internal/message"
+	"github.com/envoyproxy/gateway/internal/replay"
+	"github.com/envoyproxy/gateway/internal/xds/cache"
+	xdstypes "github.com/envoyproxy/gateway/internal/xds/types"
+)
+
+type fakeXdsServer struct {
+	cache cache.SnapshotCacheWithCallbacks
+	// partitions holds the partitions of the snapshot cache by irKey.
+	partitions map[string]cache.SnapshotCacheWithCallbacks
+	paused     map[string]bool
+	// recorded holds the requests recorded by irKey.
+	recorded map[string][]*replay.Request
+}
+
+func (f *fakeXdsServer) SnapshotCache() cache.SnapshotCacheWithCallbacks { return f.cache }
+func (f *fakeXdsServer) SnapshotCachePartitions() map[string]cache.SnapshotCacheWithCallbacks {
+	return f.partitions
+}
+func (f *fakeXdsServer) PausePublishing(irKey string)       { f.paused[irKey] = true }
+func (f *fakeXdsServer) PublishingPaused(irKey string) bool { return f.paused[irKey] }
+func (f *fakeXdsServer) ResumePublishing(irKey string) error {
+	delete(f.paused, irKey)
+	return nil
+}
+
+func (f *fakeXdsServer) RecordedRequests(irKey string) ([]*replay.Request, bool) {
+	requests, ok := f.recorded[irKey]
+	return requests, ok
+}
+
+type fakeXdsTranslator struct {
+	retranslated []string
+}
+
+func (f *fakeXdsTranslator) Retranslate(irKey string) error {
+	f.retranslated = append(f.retranslated, irKey)
+	return nil
+}
+
+// TranslateIR translates each HTTP listener of the xds IR into a listener, and each
+// of its routes into a cluster.
+func (f *fakeXdsTranslator) TranslateIR(xdsIR *ir.Xds) (*xdstypes.ResourceVersionTable, error) {
+	resources := xdstypes.XdsResources{}
+	for _, listener := range xdsIR.HTTP {
+		resources[resourcev3.ListenerType] = append(resources[resourcev3.ListenerType], &listenerv3.Listener{Name: listener.Name})
+		for _, route := range listener.Routes {
+			resources[resourcev3.ClusterType] = append(resources[resourcev3.ClusterType], &clusterv3.Cluster{
+				Name:           route.Name,
+				ConnectTimeout: durationpb.New(time.Duration(len(route.Hostname)) * time.Second),
+			})
+		}
+	}
+	return &xdstypes.ResourceVersionTable{XdsResources: resources}, nil
+}
+
+// fakeGatewayAPITranslator translates the gateways into an irKey with an HTTP listener
+// each, and the routes into a route of each listener by hostname.
+type fakeGatewayAPITranslator struct{}
+
+func (fakeGatewayAPITranslator) TranslateResources(resources *resource.Resources) resource.XdsIRMap {
+	xdsIR := resource.XdsIRMap{}
+	for _, gateway := range resources.Gateways {
+		listener := &ir.HTTPListener{CoreListenerDetails: ir.CoreListenerDetails{Name: gateway.Name + "/http"}}
+		for _, route := range resources.HTTPRoutes {
+			for _, hostname := range route.Spec.Hostnames {
+				listener.Routes = append(listener.Routes, &ir.HTTPRoute{Name: route.Name, Hostname: string(hostname)})
+			}
+		}
+		xdsIR[gateway.Namespace+"/"+gateway.Name] = &ir.Xds{HTTP: []*ir.HTTPListener{listener}}
+	}
+	return xdsIR
+}
+
+func newTestAPI(t *testing.T) (*API, *fakeXdsServer, *fakeXdsTranslator) {
+	t.Helper()
+
+	snapshotCache := cache.NewSnapshotCache(true, logging.DefaultLogger(egv1a1.LogLevelInfo))
+	require.NoError(t, snapshotCache.GenerateNewSnapshot("default/eg", xdstypes.XdsResources{
+		resourcev3.ListenerType: []types.Resource{&listenerv3.Listener{Name: "default/eg/http"}},
+	}))
+
+	xdsIR := new(message.XdsIR)
+	xdsIR.Store("default/eg", &ir.Xds{})
+	xdsIR.Store("default/other", &ir.Xds{})
+	xds := new(message.Xds)
+	xds.Store("default/eg", &xdstypes.ResourceVersionTable{})
+
+	server := &fakeXdsServer{cache: snapshotCache, paused: map[string]bool{}}
+	translator := &fakeXdsTranslator{}
+
+	return &API{
+		XdsIR:         xdsIR,
+		Xds:           xds,
+		XdsTranslator: translator,
+		XdsServer:     server,
+	}, server, translator
+}
+
+func serveAPI(api *API, method, path string) *httptest.ResponseRecorder {
+	mux := http.NewServeMux()
+	api.registerHandlers(mux)
+
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, httptest.NewRequest(method, path, nil))
+	return rec
+}
+
+func TestAPIListIRKeys(t *testing.T) {
+	api, _, _ := newTestAPI(t)
+
+	rec := serveAPI(api, http.MethodGet, "/api/v1/irkeys")
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	var got []IRKeyStatus
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &got))
+	require.Equal(t, []IRKeyStatus{
+		{IRKey: "default/eg", SnapshotVersion: "1"},
+		{IRKey: "default/other"},
+	}, got)
+}
+
+func TestAPIGetSnapshot(t *testing.T) {
+	api, _, _ := newTestAPI(t)
+
+	rec := serveAPI(api, http.MethodGet, "/api/v1/snapshots/default/eg")
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	var got SnapshotMetadata
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &got))
+	require.Equal(t, "default/eg", got.IRKey)
+	require.Equal(t, "1", got.Version)
+	require.Equal(t, map[string]int{resourcev3.ListenerType: 1}, got.Resources)
+
+	rec = serveAPI(api, http.MethodGet, "/api/v1/snapshots/default/missing")
+	require.Equal(t, http.StatusNotFound, rec.Code)
+}
+
+func TestAPIGetSnapshotHistory(t *testing.T) {
+	api, server, _ := newTestAPI(t)
+	require.NoError(t, server.cache.GenerateNewSnapshot("default/eg", nil))
+
+	rec := serveAPI(api, http.MethodGet, "/api/v1/snapshot-history/default/eg")
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	var got []cache.SnapshotRecord
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &got))
+	require.Len(t, got, 2)
+	require.Equal(t, "2", got[0].Version)
+	require.Empty(t, got[0].Resources)
+	require.Equal(t, "1", got[1].Version)
+	require.Equal(t, map[string]int{resourcev3.ListenerType: 1}, got[1].Resources)
+	require.False(t, got[1].Time.IsZero())
+
+	rec = serveAPI(api, http.MethodGet, "/api/v1/snapshot-history/default/missing")
+	require.Equal(t, http.StatusNotFound, rec.Code)
+}
+
+func TestAPIDiffSnapshots(t *testing.T) {
+	api, server, _ := newTestAPI(t)
+	require.NoError(t, server.cache.GenerateNewSnapshot("default/eg", xdstypes.XdsResources{
+		resourcev3.ListenerType: []types.Resource{&listenerv3.Listener{Name: "default/eg/https"}},
+	}))
+
+	rec := serveAPI(api, http.MethodGet, "/api/v1/snapshot-diffs/default/eg")
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	var got cache.SnapshotDiff
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &got))
+	require.Equal(t, cache.SnapshotDiff{
+		IRKey: "default/eg",
+		From:  "1",
+		To:    "2",
+		Resources: map[string]*cache.ResourceDiff{
+			"Listener": {Added: []string{"default/eg/https"}, Removed: []string{"default/eg/http"}},
+		},
+	}, got)
+
+	rec = serveAPI(api, http.MethodGet, "/api/v1/snapshot-diffs/default/eg?to=1")
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &got))
+	require.Empty(t, got.From)
+
+	rec = serveAPI(api, http.MethodGet, "/api/v1/snapshot-diffs/default/eg?from=41&to=42")
+	require.Equal(t, http.StatusNotFound, rec.Code)
+}
+
+func TestAPIConfigDump(t *testing.T) {
+	api, server, _ := newTestAPI(t)
+
+	rec := serveAPI(api, http.MethodGet, "/api/v1/config-dumps/default/eg")
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	got := &adminv3.ConfigDump{}
+	require.NoError(t, protojson.Unmarshal(rec.Body.Bytes(), got))
+	require.Len(t, got.Configs, 5)
+	listeners := &adminv3.ListenersConfigDump{}
+	require.NoError(t, got.Configs[2].UnmarshalTo(listeners))
+	require.Len(t, listeners.DynamicListeners, 1)
+	require.Equal(t, "1", listeners.DynamicListeners[0].ActiveState.VersionInfo)
+
+	rec = serveAPI(api, http.MethodGet, "/api/v1/config-dumps/default/eg?include_eds")
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.NoError(t, protojson.Unmarshal(rec.Body.Bytes(), got))
+	require.Len(t, got.Configs, 6)
+
+	// The snapshot served to a node is dumped once the node is connected.
+	rec = serveAPI(api, http.MethodGet, "/api/v1/config-dumps/default/eg?node=envoy-1")
+	require.Equal(t, http.StatusNotFound, rec.Code)
+	require.NoError(t, server.cache.OnStreamOpen(context.Background(), 1, resourcev3.AnyType))
+	require.NoError(t, server.cache.OnStreamRequest(1, &discoveryv3.DiscoveryRequest{
+		Node:    &corev3.Node{Id: "envoy-1", Cluster: "default/eg"},
+		TypeUrl: resourcev3.ListenerType,
+	}))
+	rec = serveAPI(api, http.MethodGet, "/api/v1/config-dumps/default/eg?node=envoy-1")
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	rec = serveAPI(api, http.MethodGet, "/api/v1/config-dumps/default/missing")
+	require.Equal(t, http.StatusNotFound, rec.Code)
+}
+
+func TestAPIListNodes(t *testing.T) {
+	api, server, _ := newTestAPI(t)
+	for streamID, node := range map[int64]*corev3.Node{
+		1: {Id: "envoy-1", Cluster: "default/eg"},
+		2: {Id: "envoy-2", Cluster: "default/other"},
+	} {
+		require.NoError(t, server.cache.OnStreamOpen(context.Background(), streamID, resourcev3.AnyType))
+		require.NoError(t, server.cache.OnStreamRequest(streamID, &discoveryv3.DiscoveryRequest{
+			Node:    node,
+			TypeUrl: resourcev3.ListenerType,
+		}))
+	}
+
+	rec := serveAPI(api, http.MethodGet, "/api/v1/nodes")
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	var got []cache.ConnectedNode
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &got))
+	require.Len(t, got, 2)
+	require.Equal(t, "envoy-1", got[0].NodeID)
+	require.Equal(t, cache.StreamTypeSotW, got[0].StreamType)
+	require.Equal(t, []string{resourcev3.ListenerType}, got[0].TypeURLs)
+	require.Equal(t, "envoy-2", got[1].NodeID)
+
+	rec = serveAPI(api, http.MethodGet, "/api/v1/nodes?irKey=default/other")
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &got))
+	require.Len(t, got, 1)
+	require.Equal(t, "envoy-2", got[0].NodeID)
+}
+
+func TestAPISnapshotCachePartitions(t *testing.T) {
+	api, server, _ := newTestAPI(t)
+	partition := cache.NewSnapshotCache(true, logging.DefaultLogger(egv1a1.LogLevelInfo), cache.WithPartition("payments/checkout"))
+	require.NoError(t, partition.GenerateNewSnapshot("payments/checkout", xdstypes.XdsResources{
+		resourcev3.ListenerType: []types.Resource{
+			&listenerv3.Listener{Name: "payments/checkout/http"},
+			&listenerv3.Listener{Name: "payments/checkout/https"},
+		},
+	}))
+	require.NoError(t, partition.OnStreamOpen(context.Background(), 1, resourcev3.AnyType))
+	require.NoError(t, partition.OnStreamRequest(1, &discoveryv3.DiscoveryRequest{
+		Node:    &corev3.Node{Id: "envoy-checkout", Cluster: "payments/checkout"},
+		TypeUrl: resourcev3.ListenerType,
+	}))
+	server.partitions = map[string]cache.SnapshotCacheWithCallbacks{"payments/checkout": partition}
+
+	rec := serveAPI(api, http.MethodGet, "/api/v1/irkeys")
+	require.Equal(t, http.StatusOK, rec.Code)
+	var statuses []IRKeyStatus
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &statuses))
+	require.Equal(t, []IRKeyStatus{
+		{IRKey: "default/eg", SnapshotVersion: "1"},
+		{IRKey: "default/other"},
+		{IRKey: "payments/checkout", SnapshotVersion: "1", Nodes: []string{"envoy-checkout"}},
+	}, statuses)
+
+	// The snapshots of an isolated Gateway are served from its partition.
+	rec = serveAPI(api, http.MethodGet, "/api/v1/snapshots/payments/checkout")
+	require.Equal(t, http.StatusOK, rec.Code)
+	var metadata SnapshotMetadata
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &metadata))
+	require.Equal(t, map[string]int{resourcev3.ListenerType: 2}, metadata.Resources)
+
+	rec = serveAPI(api, http.MethodGet, "/api/v1/nodes")
+	require.Equal(t, http.StatusOK, rec.Code)
+	var nodes []cache.ConnectedNode
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &nodes))
+	require.Len(t, nodes, 1)
+	require.Equal(t, "envoy-checkout", nodes[0].NodeID)
+}
+
+func TestAPIPauseResume(t *testing.T) {
+	api, server, _ := newTestAPI(t)
+
+	rec := serveAPI(api, http.MethodPost, "/api/v1/pause/default/eg")
+	require.Equal(t, http.StatusNoContent, rec.Code)
+	require.True(t, server.PublishingPaused("default/eg"))
+
+	rec = serveAPI(api, http.MethodPost, "/api/v1/resume/default/eg")
+	require.Equal(t, http.StatusNoContent, rec.Code)
+	require.False(t, server.PublishingPaused("default/eg"))
+}
+
+func TestAPIRetranslate(t *testing.T) {
+	api, _, translator := newTestAPI(t)
+
+	rec := serveAPI(api, http.MethodPost, "/api/v1/retranslate/default/eg")
+	require.Equal(t, http.StatusAccepted, rec.Code)
+	require.Equal(t, []string{"default/eg"}, translator.retranslated)
+
+	rec = serveAPI(api, http.MethodPost, "/api/v1/retranslate/default/missing")
+	require.Equal(t, http.StatusNotFound, rec.Code)
+
+	// Only POST requests retranslate.
+	rec = serveAPI(api, http.MethodGet, "/api/v1/retranslate/default/eg")
+	require.Equal(t, http.StatusMethodNotAllowed, rec.Code)
+
+	// The requests of the web pages of other origins are refused.
+	mux := http.NewServeMux()
+	api.registerHandlers(mux)
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/retranslate/default/eg", nil)
+	req.Header.Set("Origin", "https://attacker.example.com")
+	rec = httptest.NewRecorder()
+	mux.ServeHTTP(rec, req)
+	require.Equal(t, http.StatusForbidden, rec.Code)
+	req = httptest.NewRequest(http.MethodPost, "/api/v1/retranslate/default/eg", nil)
+	req.Header.Set("Sec-Fetch-Site", "cross-site")
+	rec = httptest.NewRecorder()
+	mux.ServeHTTP(rec, req)
+	require.Equal(t, http.StatusForbidden, rec.Code)
+	require.Equal(t, []string{"default/eg"}, translator.retranslated)
+
+	// The xds IR may not be wired yet.
+	api.XdsIR = nil
+	rec = serveAPI(api, http.MethodPost, "/api/v1/retranslate/default/eg")
+	require.Equal(t, http.StatusServiceUnavailable, rec.Code)
+}
+
+func TestAPIHealth(t *testing.T) {
+	api, server, _ := newTestAPI(t)
+
+	rec := serveAPI(api, http.MethodGet, "/api/v1/health")
+	require.Equal(t, http.StatusServiceUnavailable, rec.Code)
+
+	var got PipelineHealth
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &got))
+	require.False(t, got.Healthy)
+	require.Equal(t, []StageHealth{
+		{Name: "gateway-api", Keys: 2},
+		{Name: "xds-translator", Keys: 1, Missing: []string{"default/other"}},
+		{Name: "xds-server", Keys: 1},
+	}, got.Stages)
+
+	api.Xds.Store("default/other", &xdstypes.ResourceVersionTable{})
+	server.PausePublishing("default/other")
+
+	rec = serveAPI(api, http.MethodGet, "/api/v1/health")
+	require.Equal(t, http.StatusOK, rec.Code)
+}
+
+func TestAPIListGateways(t *testing.T) {
+	api, _, _ := newTestAPI(t)
+
+	res := resource.NewResources()
+	res.GatewayClass = &gwapiv1.GatewayClass{ObjectMeta: metav1.ObjectMeta{Name: "eg"}}
+	res.Gateways = []*gwapiv1.Gateway{{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "eg"},
+		Spec: gwapiv1.GatewaySpec{
+			Listeners: []gwapiv1.Listener{{Name: "http", Protocol: gwapiv1.HTTPProtocolType, Port: 80}},
+		},
+	}}
+	res.HTTPRoutes = []*gwapiv1.HTTPRoute{
+		{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "attached", Labels: map[string]string{"app": "foo"}},
+			Spec: gwapiv1.HTTPRouteSpec{CommonRouteSpec: gwapiv1.CommonRouteSpec{
+				ParentRefs: []gwapiv1.ParentReference{{Name: "eg"}},
+			}},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "detached"},
+			Spec: gwapiv1.HTTPRouteSpec{CommonRouteSpec: gwapiv1.CommonRouteSpec{
+				ParentRefs: []gwapiv1.ParentReference{{Name: "other"}},
+			}},
+		},
+	}
+	res.ClientTrafficPolicies = []*egv1a1.ClientTrafficPolicy{{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "ctp"},
+		Spec: egv1a1.ClientTrafficPolicySpec{PolicyTargetReferences: egv1a1.PolicyTargetReferences{
+			TargetRefs: []gwapiv1a2.LocalPolicyTargetReferenceWithSectionName{{
+				LocalPolicyTargetReference: gwapiv1a2.LocalPolicyTargetReference{Kind: resource.KindGateway, Name: "eg"},
+			}},
+		}},
+	}}
+	res.BackendTrafficPolicies = []*egv1a1.BackendTrafficPolicy{{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "btp"},
+		Spec: egv1a1.BackendTrafficPolicySpec{PolicyTargetReferences: egv1a1.PolicyTargetReferences{
+			TargetSelectors: []egv1a1.TargetSelector{{Kind: resource.KindHTTPRoute, MatchLabels: map[string]string{"app": "foo"}}},
+		}},
+	}}
+
+	api.ProviderResources = new(message.ProviderResources)
+	api.ProviderResources.GatewayAPIResources.Store("eg", &resource.ControllerResources{res})
+	api.ProviderResources.GatewayStatuses.Store(k8stypes.NamespacedName{Namespace: "default", Name: "eg"}, &gwapiv1.GatewayStatus{
+		Conditions: []metav1.Condition{{Type: string(gwapiv1.GatewayConditionProgrammed), Status: metav1.ConditionTrue}},
+		Listeners:  []gwapiv1.ListenerStatus{{Name: "http", AttachedRoutes: 1}},
+	})
+
+	mux := http.NewServeMux()
+	api.registerDashboard(mux)
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/v1/gateways", nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	var got []GatewaySummary
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &got))
+	require.Equal(t, []GatewaySummary{{
+		GatewayClass: "eg",
+		Namespace:    "default",
+		Name:         "eg",
+		Programmed:   "True",
+		Listeners:    []ListenerSummary{{Name: "http", Protocol: "HTTP", Port: 80, AttachedRoutes: 1}},
+		Routes:       []ResourceRef{{Kind: resource.KindHTTPRoute, Namespace: "default", Name: "attached"}},
+		Policies: []ResourceRef{
+			{Kind: egv1a1.KindClientTrafficPolicy, Namespace: "default", Name: "ctp"},
+			{Kind: egv1a1.KindBackendTrafficPolicy, Namespace: "default", Name: "btp"},
+		},
+	}}, got)
+}
+
+func TestAPISimulate(t *testing.T) {
+	api, _, _ := newTestAPI(t)
+	api.GatewayAPITranslator = fakeGatewayAPITranslator{}
+
+	res := resource.NewResources()
+	res.GatewayClass = &gwapiv1.GatewayClass{ObjectMeta: metav1.ObjectMeta{Name: "eg"}}
+	res.Gateways = []*gwapiv1.Gateway{
+		{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "eg"}},
+		{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "other"}},
+	}
+	res.HTTPRoutes = []*gwapiv1.HTTPRoute{
+		{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "foo"},
+			Spec:       gwapiv1.HTTPRouteSpec{Hostnames: []gwapiv1.Hostname{"foo.example.com"}},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "bar"},
+			Spec:       gwapiv1.HTTPRouteSpec{Hostnames: []gwapiv1.Hostname{"bar.example.com"}},
+		},
+	}
+	api.ProviderResources = new(message.ProviderResources)
+	api.ProviderResources.GatewayAPIResources.Store("eg", &resource.ControllerResources{res})
+
+	simulate := func(req *SimulationRequest) *httptest.ResponseRecorder {
+		body, err := json.Marshal(req)
+		require.NoError(t, err)
+
+		mux := http.NewServeMux()
+		api.registerHandlers(mux)
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/api/v1/simulate", bytes.NewReader(body)))
+		return rec
+	}
+
+	// Updating a route modifies its clusters, adding a route adds clusters, and deleting
+	// a gateway removes its listener and clusters.
+	rec := simulate(&SimulationRequest{
+		Apply: []json.RawMessage{
+			json.RawMessage(`{"apiVersion":"gateway.networking.k8s.io/v1","kind":"HTTPRoute",` +
+				`"metadata":{"namespace":"default","name":"foo"},"spec":{"hostnames":["foo.example.io"]}}`),
+			json.RawMessage(`{"apiVersion":"gateway.networking.k8s.io/v1","kind":"HTTPRoute",` +
+				`"metadata":{"namespace":"default","name":"baz"},"spec":{"hostnames":["baz.example.com"]}}`),
+		},
+		Delete: []ResourceRef{{Kind: resource.KindGateway, Namespace: "default", Name: "other"}},
+	})
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+
+	var got SimulationResult
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &got))
+	require.Equal(t, SimulationResult{IRKeys: []IRKeySimulation{
+		{
+			IRKey:    "default/eg",
+			Clusters: &ResourceChanges{Added: []string{"baz"}, Modified: []string{"foo"}},
+		},
+		{
+			IRKey:     "default/other",
+			Listeners: &ResourceChanges{Removed: []string{"other/http"}},
+			Clusters:  &ResourceChanges{Removed: []string{"bar", "foo"}},
+		},
+	}}, got)
+
+	// The current resources are left untouched.
+	require.Len(t, res.Gateways, 2)
+	require.Equal(t, gwapiv1.Hostname("foo.example.com"), res.HTTPRoutes[0].Spec.Hostnames[0])
+
+	rec = simulate(&SimulationRequest{Delete: []ResourceRef{{Kind: resource.KindGateway, Namespace: "default", Name: "missing"}}})
+	require.Equal(t, http.StatusBadRequest, rec.Code)
+}
+
+func TestAPIReplay(t *testing.T) {
+	api, server, _ := newTestAPI(t)
+	api.Replays = replay.NewManager(nil)
+
+	var mu sync.Mutex
+	var replayed []string
+	target := httptest.NewServer(http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		replayed = append(replayed, r.URL.Path)
+	}))
+	defer target.Close()
+
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	server.recorded = map[string][]*replay.Request{"default/eg": {
+		{Time: start, Method: http.MethodGet, Path: "/a"},
+		{Time: start.Add(time.Minute), Method: http.MethodGet, Path: "/b"},
+		{Time: start.Add(2 * time.Minute), Method: http.MethodGet, Path: "/c"},
+	}}
+
+	rec := serveAPI(api, http.MethodGet, "/api/v1/recordings/default/eg")
+	require.Equal(t, http.StatusOK, rec.Code)
+	var summary RecordingSummary
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &summary))
+	require.Equal(t, 3, summary.Requests)
+	require.Equal(t, start, *summary.Oldest)
+
+	rec = serveAPI(api, http.MethodGet, "/api/v1/recordings/default/other")
+	require.Equal(t, http.StatusNotFound, rec.Code)
+
+	startReplay := func(cfg *replay.Config) *httptest.ResponseRecorder {
+		body, err := json.Marshal(cfg)
+		require.NoError(t, err)
+
+		mux := http.NewServeMux()
+		api.registerHandlers(mux)
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/api/v1/replays", bytes.NewReader(body)))
+		return rec
+	}
+
+	// Only the requests recorded within the window are replayed.
+	rec = startReplay(&replay.Config{
+		IRKey:  "default/eg",
+		Target: target.URL,
+		Rate:   100,
+		From:   ptr.To(start.Add(time.Minute)),
+	})
+	require.Equal(t, http.StatusAccepted, rec.Code, rec.Body.String())
+	var status replay.Status
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &status))
+	require.Equal(t, 2, status.Requests)
+
+	job, ok := api.Replays.Get(status.ID)
+	require.True(t, ok)
+	<-job.Done()
+
+	rec = serveAPI(api, http.MethodGet, "/api/v1/replays/"+status.ID)
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &status))
+	require.Equal(t, replay.StateCompleted, status.State)
+	require.Equal(t, map[string]int{"2xx": 2}, status.Responses)
+	mu.Lock()
+	require.ElementsMatch(t, []string{"/b", "/c"}, replayed)
+	mu.Unlock()
+
+	rec = serveAPI(api, http.MethodGet, "/api/v1/replays")
+	require.Equal(t, http.StatusOK, rec.Code)
+	var statuses []replay.Status
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &statuses))
+	require.Len(t, statuses, 1)
+
+	rec = serveAPI(api, http.MethodDelete, "/api/v1/replays/"+status.ID)
+	require.Equal(t, http.StatusAccepted, rec.Code)
+	rec = serveAPI(api, http.MethodDelete, "/api/v1/replays/replay-42")
+	require.Equal(t, http.StatusNotFound, rec.Code)
+
+	rec = startReplay(&replay.Config{IRKey: "default/other", Target: target.URL, Rate: 100})
+	require.Equal(t, http.StatusNotFound, rec.Code)
+	rec = startReplay(&replay.Config{IRKey: "default/eg", Target: target.URL, Rate: 100, From: ptr.To(start.Add(time.Hour))})
+	require.Equal(t, http.StatusBadRequest, rec.Code)
+	rec = startReplay(&replay.Config{IRKey: "default/eg", Target: "not a url", Rate: 100})
+	require.Equal(t, http.StatusBadRequest, rec.Code)
+}
+
+func TestDashboard(t *testing.T) {
+	api, _, _ := newTestAPI(t)
+
+	mux := http.NewServeMux()
+	api.registerDashboard(mux)
+
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/dashboard/", nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.Contains(t, rec.Body.String(), "Envoy Gateway")
+
+	rec = httptest.NewRecorder()
+	mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/v1/changes", nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	var changes []cache.SnapshotChange
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &changes))
+	require.Len(t, changes, 1)
+	require.Equal(t, "default/eg", changes[0].IRKey)
+	require.Equal(t, 1, changes[0].Resources)
+}
+
+func TestAPILogLevels(t *testing.T) {
+	api, _, _ := newTestAPI(t)
+	logger := logging.DefaultLogger(egv1a1.LogLevelInfo)
+	api.LogLevels = logger
+
+	mux := http.NewServeMux()
+	api.registerHandlers(mux)
+	serve := func(method, path, body string) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, httptest.NewRequest(method, path, strings.NewReader(body)))
+		return rec
+	}
+
+	rec := serve(http.MethodPut, "/api/v1/loglevels/xds-server", `{"level":"debug"}`)
+	require.Equal(t, http.StatusNoContent, rec.Code)
+	require.Equal(t, egv1a1.LogLevelDebug, logger.Level(egv1a1.LogComponentXdsServerRunner))
+
+	rec = serve(http.MethodGet, "/api/v1/loglevels", "")
+	require.Equal(t, http.StatusOK, rec.Code)
+	var got map[egv1a1.EnvoyGatewayLogComponent]egv1a1.LogLevel
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &got))
+	require.Equal(t, egv1a1.LogLevelDebug, got[egv1a1.LogComponentXdsServerRunner])
+	require.Equal(t, egv1a1.LogLevelInfo, got[egv1a1.LogComponentProviderRunner])
+
+	rec = serve(http.MethodPut, "/api/v1/loglevels/xds-server", `{"level":"verbose"}`)
+	require.Equal(t, http.StatusBadRequest, rec.Code)
+
+	rec = serve(http.MethodPut, "/api/v1/loglevels/unknown", `{"level":"debug"}`)
+	require.Equal(t, http.StatusNotFound, rec.Code)
+}
+
+func TestAPIDeadLetters(t *testing.T) {
+	api, _, _ := newTestAPI(t)
+	mux := http.NewServeMux()
+	api.registerHandlers(mux)
+
+	message.SetRetries(&egv1a1.EnvoyGatewayWatchable{
+		Retries: []egv1a1.WatchableRetry{{Message: "dead-letters", MaxAttempts: ptr.To[uint32](1)}},
+	})
+	t.Cleanup(func() { message.SetRetries(nil) })
+
+	m := new(message.XdsIR)
+	m.Store("default/eg", &ir.Xds{})
+	message.HandleTracedSubscription(message.Metadata{Runner: "demo", Message: "dead-letters"}, m.Subscribe(context.Background()),
+		func(_ message.Update[string, *ir.Xds], errChan chan error) {
+			errChan <- errors.New("failed to translate")
+			m.Close()
+		})
+
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/v1/dead-letters", nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+	var letters []message.DeadLetter
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &letters))
+	require.Len(t, letters, 1)
+	require.Equal(t, "dead-letters", letters[0].Message)
+	require.Equal(t, "default/eg", letters[0].Key)
+	require.Equal(t, "failed to translate", letters[0].Error)
+}
+
+type fakeProxyAdmin struct {
+	pods      map[k8stypes.NamespacedName]map[string]string
+	requested []string
+}
+
+func (f *fakeProxyAdmin) PodLabels(_ context.Context, pod k8stypes.NamespacedName) (map[string]string, error) {
+	labels, ok := f.pods[pod]
+	if !ok {
+		return nil, kerrors.NewNotFound(schema.GroupResource{Resource: "pods"}, pod.Name)
+	}
+	return labels, nil
+}
+
+func (f *fakeProxyAdmin) Get(_ context.Context, _ k8stypes.NamespacedName, path, rawQuery string) (*ProxyAdminResponse, error) {
+	f.requested = append(f.requested, path+"?"+rawQuery)
+	return &ProxyAdminResponse{StatusCode: http.StatusOK, ContentType: "text/plain", Body: []byte("ok")}, nil
+}
+
+func TestAPIProxyAdmin(t *testing.T) {
+	api, _, _ := newTestAPI(t)
+
+	ownerLabels := map[string]string{
+		gatewayapi.OwningGatewayNamespaceLabel: "default",
+		gatewayapi.OwningGatewayNameLabel:      "eg",
+	}
+	api.InfraIR = new(message.InfraIR)
+	infra := ir.NewInfra()
+	infra.Proxy.GetProxyMetadata().Labels = ownerLabels
+	api.InfraIR.Store("default/eg", infra)
+	unixSocket := ir.NewInfra()
+	unixSocket.Proxy.GetProxyMetadata().Labels = map[string]string{gatewayapi.OwningGatewayClassLabel: "unix"}
+	unixSocket.Proxy.Config = &egv1a1.EnvoyProxy{
+		Spec: egv1a1.EnvoyProxySpec{
+			Admin: &egv1a1.ProxyAdmin{Exposure: ptr.To(egv1a1.ProxyAdminExposureUnixSocket)},
+		},
+	}
+	api.InfraIR.Store("unix", unixSocket)
+
+	rec := serveAPI(api, http.MethodGet, "/api/v1/proxies/envoy-gateway-system/envoy-eg/admin/stats")
+	require.Equal(t, http.StatusServiceUnavailable, rec.Code)
+
+	proxyAdmin := &fakeProxyAdmin{pods: map[k8stypes.NamespacedName]map[string]string{
+		{Namespace: "envoy-gateway-system", Name: "envoy-eg"}:    ownerLabels,
+		{Namespace: "envoy-gateway-system", Name: "envoy-unix"}:  {gatewayapi.OwningGatewayClassLabel: "unix"},
+		{Namespace: "envoy-gateway-system", Name: "not-a-proxy"}: {"app": "other"},
+	}}
+	api.ProxyAdmin = proxyAdmin
+
+	rec = serveAPI(api, http.MethodGet, "/api/v1/proxies/envoy-gateway-system/envoy-eg/admin/stats?filter=server")
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.Equal(t, "ok", rec.Body.String())
+	require.Equal(t, []string{"/stats?filter=server"}, proxyAdmin.requested)
+
+	// Mutating endpoints aren't allowed by default.
+	rec = serveAPI(api, http.MethodGet, "/api/v1/proxies/envoy-gateway-system/envoy-eg/admin/quitquitquit")
+	require.Equal(t, http.StatusForbidden, rec.Code)
+	rec = serveAPI(api, http.MethodPost, "/api/v1/proxies/envoy-gateway-system/envoy-eg/admin/stats")
+	require.Equal(t, http.StatusMethodNotAllowed, rec.Code)
+
+	rec = serveAPI(api, http.MethodGet, "/api/v1/proxies/envoy-gateway-system/envoy-unix/admin/stats")
+	require.Equal(t, http.StatusForbidden, rec.Code)
+
+	rec = serveAPI(api, http.MethodGet, "/api/v1/proxies/envoy-gateway-system/not-a-proxy/admin/stats")
+	require.Equal(t, http.StatusNotFound, rec.Code)
+
+	rec = serveAPI(api, http.MethodGet, "/api/v1/proxies/envoy-gateway-system/missing/admin/stats")
+	require.Equal(t, http.StatusNotFound, rec.Code)
+	require.Len(t, proxyAdmin.requested, 1)
+}
@@ -0,0 +1,240 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package admin
+
+import (
+	"embed"
+	"io/fs"
+	"net/http"
+	"sort"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	gwapiv1 "sigs.k8s.io/gateway-api/apis/v1"
+	gwapiv1a2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
+
+	egv1a1 "github.com/envoyproxy/gateway/api/v1alpha1"
+	"github.com/envoyproxy/gateway/internal/gatewayapi/resource"
+	"github.com/envoyproxy/gateway/internal/xds/cache"
+)
+
+// DashboardPrefix is the path prefix of the read-only dashboard.
+const DashboardPrefix = "/dashboard/"
+
+//go:embed dashboard/*
+var dashboardFiles embed.FS
+
+// GatewaySummary is a read-only view of a Gateway and the resources attached to it.
+type GatewaySummary struct {
+	GatewayClass string            `json:"gatewayClass"`
+	Namespace    string            `json:"namespace"`
+	Name         string            `json:"name"`
+	Programmed   string            `json:"programmed"`
+	Listeners    []ListenerSummary `json:"listeners,omitempty"`
+	Routes       []ResourceRef     `json:"routes,omitempty"`
+	Policies     []ResourceRef     `json:"policies,omitempty"`
+}
+
+// ListenerSummary is a read-only view of a Gateway listener.
+type ListenerSummary struct {
+	Name           string `json:"name"`
+	Protocol       string `json:"protocol"`
+	Port           int32  `json:"port"`
+	Hostname       string `json:"hostname,omitempty"`
+	AttachedRoutes int32  `json:"attachedRoutes"`
+}
+
+// ResourceRef identifies a resource attached to a Gateway.
+type ResourceRef struct {
+	Kind      string `json:"kind"`
+	Namespace string `json:"namespace"`
+	Name      string `json:"name"`
+}
+
+// registerDashboard serves the embedded dashboard and the read-only
+// endpoints backing it.
+func (a *API) registerDashboard(mux *http.ServeMux) {
+	mux.HandleFunc("GET "+APIPrefix+"/gateways", a.handleListGateways)
+	mux.HandleFunc("GET "+APIPrefix+"/changes", a.handleListChanges)
+
+	files, _ := fs.Sub(dashboardFiles, "dashboard")
+	mux.Handle("GET "+DashboardPrefix, http.StripPrefix(DashboardPrefix, http.FileServer(http.FS(files))))
+}
+
+func (a *API) handleListGateways(w http.ResponseWriter, _ *http.Request) {
+	writeJSON(w, http.StatusOK, a.gatewaySummaries())
+}
+
+func (a *API) handleListChanges(w http.ResponseWriter, _ *http.Request) {
+	changes := []cache.SnapshotChange{}
+	for _, snapshotCache := range a.snapshotCaches() {
+		changes = append(changes, snapshotCache.RecentChanges()...)
+	}
+	// The changes of the partitions of the isolated Gateways are interleaved, newest first.
+	sort.SliceStable(changes, func(i, j int) bool {
+		return changes[i].Time.After(changes[j].Time)
+	})
+	writeJSON(w, http.StatusOK, changes)
+}
+
+// gatewaySummaries builds a summary of every Gateway known to the provider.
+func (a *API) gatewaySummaries() []GatewaySummary {
+	summaries := []GatewaySummary{}
+	if a.ProviderResources == nil {
+		return summaries
+	}
+
+	for _, res := range a.ProviderResources.GetResources() {
+		if res == nil || res.GatewayClass == nil {
+			continue
+		}
+		for _, gw := range res.Gateways {
+			summaries = append(summaries, a.gatewaySummary(res, gw))
+		}
+	}
+	sort.Slice(summaries, func(i, j int) bool {
+		if summaries[i].Namespace != summaries[j].Namespace {
+			return summaries[i].Namespace < summaries[j].Namespace
+		}
+		return summaries[i].Name < summaries[j].Name
+	})
+
+	return summaries
+}
+
+// policyTarget is a resource that policies can be attached to.
+type policyTarget struct {
+	ResourceRef
+	labels map[string]string
+}
+
+func (a *API) gatewaySummary(res *resource.Resources, gw *gwapiv1.Gateway) GatewaySummary {
+	summary := GatewaySummary{
+		GatewayClass: res.GatewayClass.Name,
+		Namespace:    gw.Namespace,
+		Name:         gw.Name,
+		Programmed:   string(metav1.ConditionUnknown),
+	}
+
+	attachedRoutes := map[gwapiv1.SectionName]int32{}
+	if status, ok := a.ProviderResources.GatewayStatuses.Load(types.NamespacedName{Namespace: gw.Namespace, Name: gw.Name}); ok && status != nil {
+		for _, cond := range status.Conditions {
+			if cond.Type == string(gwapiv1.GatewayConditionProgrammed) {
+				summary.Programmed = string(cond.Status)
+			}
+		}
+		for _, l := range status.Listeners {
+			attachedRoutes[l.Name] = l.AttachedRoutes
+		}
+	}
+
+	for _, l := range gw.Spec.Listeners {
+		ls := ListenerSummary{
+			Name:           string(l.Name),
+			Protocol:       string(l.Protocol),
+			Port:           int32(l.Port),
+			AttachedRoutes: attachedRoutes[l.Name],
+		}
+		if l.Hostname != nil {
+			ls.Hostname = string(*l.Hostname)
+		}
+		summary.Listeners = append(summary.Listeners, ls)
+	}
+
+	targets := []policyTarget{{
+		ResourceRef: ResourceRef{Kind: resource.KindGateway, Namespace: gw.Namespace, Name: gw.Name},
+		labels:      gw.Labels,
+	}}
+	addRoute := func(kind string, obj metav1.Object, parentRefs []gwapiv1.ParentReference) {
+		if !routeAttachedToGateway(obj.GetNamespace(), parentRefs, gw) {
+			return
+		}
+		ref := ResourceRef{Kind: kind, Namespace: obj.GetNamespace(), Name: obj.GetName()}
+		summary.Routes = append(summary.Routes, ref)
+		targets = append(targets, policyTarget{ResourceRef: ref, labels: obj.GetLabels()})
+	}
+	for _, r := range res.HTTPRoutes {
+		addRoute(resource.KindHTTPRoute, r, r.Spec.ParentRefs)
+	}
+	for _, r := range res.GRPCRoutes {
+		addRoute(resource.KindGRPCRoute, r, r.Spec.ParentRefs)
+	}
+	for _, r := range res.TLSRoutes {
+		addRoute(resource.KindTLSRoute, r, r.Spec.ParentRefs)
+	}
+	for _, r := range res.TCPRoutes {
+		addRoute(resource.KindTCPRoute, r, r.Spec.ParentRefs)
+	}
+	for _, r := range res.UDPRoutes {
+		addRoute(resource.KindUDPRoute, r, r.Spec.ParentRefs)
+	}
+
+	addPolicy := func(kind string, obj metav1.Object, refs egv1a1.PolicyTargetReferences) {
+		if policyAttachedToTargets(obj.GetNamespace(), refs, targets) {
+			summary.Policies = append(summary.Policies, ResourceRef{Kind: kind, Namespace: obj.GetNamespace(), Name: obj.GetName()})
+		}
+	}
+	for _, p := range res.ClientTrafficPolicies {
+		addPolicy(egv1a1.KindClientTrafficPolicy, p, p.Spec.PolicyTargetReferences)
+	}
+	for _, p := range res.BackendTrafficPolicies {
+		addPolicy(egv1a1.KindBackendTrafficPolicy, p, p.Spec.PolicyTargetReferences)
+	}
+	for _, p := range res.SecurityPolicies {
+		addPolicy(egv1a1.KindSecurityPolicy, p, p.Spec.PolicyTargetReferences)
+	}
+	for _, p := range res.EnvoyExtensionPolicies {
+		addPolicy(egv1a1.KindEnvoyExtensionPolicy, p, p.Spec.PolicyTargetReferences)
+	}
+	for _, p := range res.EnvoyPatchPolicies {
+		addPolicy(egv1a1.KindEnvoyPatchPolicy, p, egv1a1.PolicyTargetReferences{TargetRef: &gwapiv1a2.LocalPolicyTargetReferenceWithSectionName{
+			LocalPolicyTargetReference: p.Spec.TargetRef,
+		}})
+	}
+
+	return summary
+}
+
+// routeAttachedToGateway returns true if one of the parentRefs of a route in
+// routeNamespace references the Gateway.
+func routeAttachedToGateway(routeNamespace string, parentRefs []gwapiv1.ParentReference, gw *gwapiv1.Gateway) bool {
+	for _, ref := range parentRefs {
+		if ref.Kind != nil && string(*ref.Kind) != resource.KindGateway {
+			continue
+		}
+		namespace := routeNamespace
+		if ref.Namespace != nil {
+			namespace = string(*ref.Namespace)
+		}
+		if namespace == gw.Namespace && string(ref.Name) == gw.Name {
+			return true
+		}
+	}
+	return false
+}
+
+// policyAttachedToTargets returns true if a policy in policyNamespace targets
+// one of the targets, either by reference or by label selector.
+func policyAttachedToTargets(policyNamespace string, refs egv1a1.PolicyTargetReferences, targets []policyTarget) bool {
+	for _, target := range targets {
+		if target.Namespace != policyNamespace {
+			continue
+		}
+		for _, ref := range refs.GetTargetRefs() {
+			if string(ref.Kind) == target.Kind && string(ref.Name) == target.Name {
+				return true
+			}
+		}
+		for _, selector := range refs.TargetSelectors {
+			if string(selector.Kind) == target.Kind &&
+				labels.SelectorFromSet(selector.MatchLabels).Matches(labels.Set(target.labels)) {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,80 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package admin
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"slices"
+
+	egv1a1 "github.com/envoyproxy/gateway/api/v1alpha1"
+)
+
+// LogLevels is the subset of the Envoy Gateway logger used by the admin API.
+type LogLevels interface {
+	// Level returns the level of the loggers of the component.
+	Level(component egv1a1.EnvoyGatewayLogComponent) egv1a1.LogLevel
+	// SetLevel sets the level of the loggers of the component.
+	SetLevel(component egv1a1.EnvoyGatewayLogComponent, level egv1a1.LogLevel)
+}
+
+// LogLevelRequest is the body of a request changing the level of a component.
+type LogLevelRequest struct {
+	Level egv1a1.LogLevel `json:"level"`
+}
+
+// logComponents are the components whose level can be changed at runtime.
+var logComponents = []egv1a1.EnvoyGatewayLogComponent{
+	egv1a1.LogComponentGatewayDefault,
+	egv1a1.LogComponentProviderRunner,
+	egv1a1.LogComponentGatewayAPIRunner,
+	egv1a1.LogComponentXdsTranslatorRunner,
+	egv1a1.LogComponentXdsServerRunner,
+	egv1a1.LogComponentInfrastructureRunner,
+	egv1a1.LogComponentGlobalRateLimitRunner,
+}
+
+func (a *API) handleListLogLevels(w http.ResponseWriter, _ *http.Request) {
+	if a.LogLevels == nil {
+		writeError(w, http.StatusServiceUnavailable, fmt.Errorf("logger is not ready"))
+		return
+	}
+
+	levels := make(map[egv1a1.EnvoyGatewayLogComponent]egv1a1.LogLevel, len(logComponents))
+	for _, component := range logComponents {
+		levels[component] = a.LogLevels.Level(component)
+	}
+	writeJSON(w, http.StatusOK, levels)
+}
+
+func (a *API) handleSetLogLevel(w http.ResponseWriter, r *http.Request) {
+	if a.LogLevels == nil {
+		writeError(w, http.StatusServiceUnavailable, fmt.Errorf("logger is not ready"))
+		return
+	}
+
+	component := egv1a1.EnvoyGatewayLogComponent(r.PathValue("component"))
+	if !slices.Contains(logComponents, component) {
+		writeError(w, http.StatusNotFound, fmt.Errorf("unknown log component %s", component))
+		return
+	}
+
+	req := &LogLevelRequest{}
+	if err := json.NewDecoder(r.Body).Decode(req); err != nil {
+		writeError(w, http.StatusBadRequest, fmt.Errorf("invalid request body: %w", err))
+		return
+	}
+	switch req.Level {
+	case egv1a1.LogLevelDebug, egv1a1.LogLevelInfo, egv1a1.LogLevelWarn, egv1a1.LogLevelError:
+	default:
+		writeError(w, http.StatusBadRequest, fmt.Errorf("invalid log level %q, valid options: debug/info/warn/error", req.Level))
+		return
+	}
+
+	a.LogLevels.SetLevel(component, req.Level)
+	w.WriteHeader(http.StatusNoContent)
+}
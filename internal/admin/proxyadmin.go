@@ -0,0 +1,115 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package admin
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"slices"
+
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+
+	egv1a1 "github.com/envoyproxy/gateway/api/v1alpha1"
+	"github.com/envoyproxy/gateway/internal/gatewayapi"
+	"github.com/envoyproxy/gateway/internal/ir"
+)
+
+// ProxyAdmin reaches the Envoy admin interface of the managed proxies.
+type ProxyAdmin interface {
+	// PodLabels returns the labels of the Envoy proxy pod.
+	PodLabels(ctx context.Context, pod types.NamespacedName) (map[string]string, error)
+	// Get sends a GET request for the path and raw query to the admin interface
+	// of the Envoy proxy pod.
+	Get(ctx context.Context, pod types.NamespacedName, path, rawQuery string) (*ProxyAdminResponse, error)
+}
+
+// ProxyAdminResponse is the response of the admin interface of an Envoy proxy.
+type ProxyAdminResponse struct {
+	StatusCode  int
+	ContentType string
+	Body        []byte
+}
+
+// handleProxyAdmin proxies a read-only request to the admin interface of an Envoy proxy,
+// if the EnvoyProxy of the proxy allows the path.
+func (a *API) handleProxyAdmin(w http.ResponseWriter, r *http.Request) {
+	if a.ProxyAdmin == nil || a.InfraIR == nil {
+		writeError(w, http.StatusServiceUnavailable, fmt.Errorf("proxy admin is not supported by the provider"))
+		return
+	}
+
+	pod := types.NamespacedName{Namespace: r.PathValue("namespace"), Name: r.PathValue("name")}
+	path := "/" + r.PathValue("path")
+
+	labels, err := a.ProxyAdmin.PodLabels(r.Context(), pod)
+	if err != nil {
+		status := http.StatusInternalServerError
+		if kerrors.IsNotFound(err) {
+			status = http.StatusNotFound
+		}
+		writeError(w, status, err)
+		return
+	}
+
+	infra := a.proxyInfraForPod(labels)
+	if infra == nil {
+		writeError(w, http.StatusNotFound, fmt.Errorf("pod %s is not an Envoy proxy managed by Envoy Gateway", pod))
+		return
+	}
+
+	var admin *egv1a1.ProxyAdmin
+	if infra.Config != nil {
+		admin = infra.Config.Spec.Admin
+	}
+	if admin.GetExposure() == egv1a1.ProxyAdminExposureUnixSocket {
+		writeError(w, http.StatusForbidden, fmt.Errorf("admin interface of %s is exposed on a unix socket", pod))
+		return
+	}
+	if !slices.Contains(admin.GetAllowedPaths(), path) {
+		writeError(w, http.StatusForbidden, fmt.Errorf("admin path %s is not allowed for %s", path, pod))
+		return
+	}
+
+	resp, err := a.ProxyAdmin.Get(r.Context(), pod, path, r.URL.RawQuery)
+	if err != nil {
+		writeError(w, http.StatusBadGateway, err)
+		return
+	}
+
+	if resp.ContentType != "" {
+		w.Header().Set("Content-Type", resp.ContentType)
+	}
+	w.WriteHeader(resp.StatusCode)
+	_, _ = w.Write(resp.Body)
+}
+
+// proxyInfraForPod returns the proxy infra whose owning labels are set on the pod.
+func (a *API) proxyInfraForPod(labels map[string]string) *ir.ProxyInfra {
+	for _, infra := range a.InfraIR.LoadAll() {
+		proxy := infra.GetProxyInfra()
+		if ownsPod(proxy.GetProxyMetadata().Labels, labels) {
+			return proxy
+		}
+	}
+	return nil
+}
+
+// ownsPod returns true if the owning labels of the proxy infra are all set on the pod.
+func ownsPod(infraLabels, podLabels map[string]string) bool {
+	owned := false
+	for k, v := range infraLabels {
+		switch k {
+		case gatewayapi.OwningGatewayNamespaceLabel, gatewayapi.OwningGatewayNameLabel, gatewayapi.OwningGatewayClassLabel:
+			if podLabels[k] != v {
+				return false
+			}
+			owned = true
+		}
+	}
+	return owned
+}
@@ -0,0 +1,83 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package admin
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	clicfg "sigs.k8s.io/controller-runtime/pkg/client/config"
+
+	kube "github.com/envoyproxy/gateway/internal/kubernetes"
+	"github.com/envoyproxy/gateway/internal/xds/bootstrap"
+)
+
+// kubernetesProxyAdmin reaches the Envoy admin interface of the proxy pods
+// by port forwarding to the admin port.
+type kubernetesProxyAdmin struct {
+	client kube.CLIClient
+}
+
+// NewKubernetesProxyAdmin returns a ProxyAdmin reaching the Envoy admin interface
+// of the proxy pods through the Kubernetes API server.
+func NewKubernetesProxyAdmin() (ProxyAdmin, error) {
+	cli, err := kube.NewForRestConfig(clicfg.GetConfigOrDie())
+	if err != nil {
+		return nil, err
+	}
+
+	return &kubernetesProxyAdmin{client: cli}, nil
+}
+
+func (k *kubernetesProxyAdmin) PodLabels(ctx context.Context, pod types.NamespacedName) (map[string]string, error) {
+	p, err := k.client.Kube().CoreV1().Pods(pod.Namespace).Get(ctx, pod.Name, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	return p.Labels, nil
+}
+
+func (k *kubernetesProxyAdmin) Get(ctx context.Context, pod types.NamespacedName, path, rawQuery string) (*ProxyAdminResponse, error) {
+	fw, err := kube.NewLocalPortForwarder(k.client, pod, 0, bootstrap.EnvoyAdminPort)
+	if err != nil {
+		return nil, err
+	}
+	if err := fw.Start(); err != nil {
+		return nil, err
+	}
+	defer fw.Stop()
+
+	url := fmt.Sprintf("http://%s%s", fw.Address(), path)
+	if rawQuery != "" {
+		url += "?" + rawQuery
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ProxyAdminResponse{
+		StatusCode:  resp.StatusCode,
+		ContentType: resp.Header.Get("Content-Type"),
+		Body:        body,
+	}, nil
+}
@@ -0,0 +1,135 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package admin
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/envoyproxy/gateway/internal/replay"
+)
+
+// maxReplayRequestBytes bounds the size of the body of a request starting a replay.
+const maxReplayRequestBytes = 1 << 20
+
+// RecordingSummary summarizes the requests recorded for an irKey.
+type RecordingSummary struct {
+	IRKey    string `json:"irKey"`
+	Requests int    `json:"requests"`
+	// Oldest and Newest are when the oldest and newest recorded requests were received.
+	Oldest *time.Time `json:"oldest,omitempty"`
+	Newest *time.Time `json:"newest,omitempty"`
+}
+
+func (a *API) handleGetRecording(w http.ResponseWriter, r *http.Request) {
+	irKey := r.PathValue("irKey")
+
+	if a.XdsServer == nil {
+		writeError(w, http.StatusServiceUnavailable, fmt.Errorf("xds server is not ready"))
+		return
+	}
+	requests, ok := a.XdsServer.RecordedRequests(irKey)
+	if !ok {
+		writeError(w, http.StatusNotFound, fmt.Errorf("traffic of %s is not recorded", irKey))
+		return
+	}
+
+	summary := &RecordingSummary{IRKey: irKey, Requests: len(requests)}
+	if len(requests) > 0 {
+		summary.Oldest = &requests[0].Time
+		summary.Newest = &requests[len(requests)-1].Time
+	}
+	writeJSON(w, http.StatusOK, summary)
+}
+
+// handleStartReplay starts a job replaying the requests recorded for an irKey within the
+// requested window against the target.
+func (a *API) handleStartReplay(w http.ResponseWriter, r *http.Request) {
+	if a.XdsServer == nil || a.Replays == nil {
+		writeError(w, http.StatusServiceUnavailable, fmt.Errorf("traffic replay is not ready"))
+		return
+	}
+
+	cfg := replay.Config{}
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxReplayRequestBytes)).Decode(&cfg); err != nil {
+		writeError(w, http.StatusBadRequest, fmt.Errorf("invalid request body: %w", err))
+		return
+	}
+	if err := cfg.Validate(); err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	requests, ok := a.XdsServer.RecordedRequests(cfg.IRKey)
+	if !ok {
+		writeError(w, http.StatusNotFound, fmt.Errorf("traffic of %s is not recorded", cfg.IRKey))
+		return
+	}
+	var window []*replay.Request
+	for _, req := range requests {
+		if (cfg.From != nil && req.Time.Before(*cfg.From)) || (cfg.To != nil && req.Time.After(*cfg.To)) {
+			continue
+		}
+		window = append(window, req)
+	}
+	if len(window) == 0 {
+		writeError(w, http.StatusBadRequest, fmt.Errorf("no request of %s was recorded within the window", cfg.IRKey))
+		return
+	}
+
+	job, err := a.Replays.Start(cfg, window)
+	switch {
+	case errors.Is(err, replay.ErrTooManyJobs):
+		writeError(w, http.StatusConflict, err)
+		return
+	case err != nil:
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	writeJSON(w, http.StatusAccepted, job.Status())
+}
+
+func (a *API) handleListReplays(w http.ResponseWriter, _ *http.Request) {
+	if a.Replays == nil {
+		writeJSON(w, http.StatusOK, []replay.Status{})
+		return
+	}
+	writeJSON(w, http.StatusOK, a.Replays.List())
+}
+
+func (a *API) handleGetReplay(w http.ResponseWriter, r *http.Request) {
+	job, ok := a.replayJob(w, r)
+	if !ok {
+		return
+	}
+	writeJSON(w, http.StatusOK, job.Status())
+}
+
+func (a *API) handleCancelReplay(w http.ResponseWriter, r *http.Request) {
+	job, ok := a.replayJob(w, r)
+	if !ok {
+		return
+	}
+	job.Cancel()
+	// The requests in flight are still completing.
+	writeJSON(w, http.StatusAccepted, job.Status())
+}
+
+// replayJob returns the replay job of the request path, writing the error response if
+// not found.
+func (a *API) replayJob(w http.ResponseWriter, r *http.Request) (*replay.Job, bool) {
+	id := r.PathValue("id")
+	if a.Replays != nil {
+		if job, ok := a.Replays.Get(id); ok {
+			return job, true
+		}
+	}
+	writeError(w, http.StatusNotFound, fmt.Errorf("replay %s not found", id))
+	return nil, false
+}
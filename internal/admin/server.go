@@ -15,23 +15,28 @@ import (
 	"github.com/envoyproxy/gateway/internal/envoygateway/config"
 )
 
-func Init(cfg *config.Server) error {
+// Init starts the admin server. The versioned admin API is served from api
+// when it is enabled in the EnvoyGateway configuration.
+func Init(cfg *config.Server, api *API) error {
 	if cfg.EnvoyGateway.GetEnvoyGatewayAdmin().EnableDumpConfig {
 		spewConfig := spew.NewDefaultConfig()
 		spewConfig.DisableMethods = true
 		spewConfig.Dump(cfg)
 	}
 
-	return start(cfg)
+	return start(cfg, api)
 }
 
-func start(cfg *config.Server) error {
+func start(cfg *config.Server, api *API) error {
 	handlers := http.NewServeMux()
 	address := cfg.EnvoyGateway.GetEnvoyGatewayAdminAddress()
 	enablePprof := cfg.EnvoyGateway.GetEnvoyGatewayAdmin().EnablePprof
+	enableAPI := cfg.EnvoyGateway.GetEnvoyGatewayAdmin().EnableAPI && api != nil
+	enableDashboard := cfg.EnvoyGateway.GetEnvoyGatewayAdmin().EnableDashboard && enableAPI
 
 	adminLogger := cfg.Logger.WithName("admin")
-	adminLogger.Info("starting admin server", "address", address, "enablePprof", enablePprof)
+	adminLogger.Info("starting admin server", "address", address, "enablePprof", enablePprof,
+		"enableAPI", enableAPI, "enableDashboard", enableDashboard)
 
 	if enablePprof {
 		// Serve pprof endpoints to aid in live debugging.
@@ -42,6 +47,16 @@ func start(cfg *config.Server) error {
 		handlers.HandleFunc("/debug/pprof/cmdline", pprof.Cmdline)
 	}
 
+	if enableAPI {
+		// Serve the versioned admin API.
+		api.registerHandlers(handlers)
+	}
+
+	if enableDashboard {
+		// Serve the read-only dashboard backed by the admin API.
+		api.registerDashboard(handlers)
+	}
+
 	adminServer := &http.Server{
 		Handler:           handlers,
 		Addr:              address,
@@ -23,6 +23,6 @@ func TestInitAdminServer(t *testing.T) {
 	}
 
 	svrConfig.Logger = logging.NewLogger(egv1a1.DefaultEnvoyGatewayLogging())
-	err := Init(svrConfig)
+	err := Init(svrConfig, nil)
 	require.NoError(t, err)
 }
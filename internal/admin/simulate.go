@@ -0,0 +1,235 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package admin
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+
+	"github.com/envoyproxy/go-control-plane/pkg/cache/types"
+	cachev3 "github.com/envoyproxy/go-control-plane/pkg/cache/v3"
+	resourcev3 "github.com/envoyproxy/go-control-plane/pkg/resource/v3"
+	"google.golang.org/protobuf/proto"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/envoyproxy/gateway/internal/envoygateway"
+	"github.com/envoyproxy/gateway/internal/gatewayapi/resource"
+	xdstypes "github.com/envoyproxy/gateway/internal/xds/types"
+)
+
+// maxSimulationRequestBytes bounds the size of the body of a simulation request.
+const maxSimulationRequestBytes = 10 << 20
+
+// GatewayAPITranslator is the subset of the gateway-api runner used by the admin API.
+type GatewayAPITranslator interface {
+	// TranslateResources translates the resources of a GatewayClass into xds IR without
+	// publishing it.
+	TranslateResources(resources *resource.Resources) resource.XdsIRMap
+}
+
+// SimulationRequest is the body of a request simulating a change of the resources.
+type SimulationRequest struct {
+	// Apply holds the Kubernetes objects to create or update.
+	Apply []json.RawMessage `json:"apply,omitempty"`
+	// Delete holds the references of the objects to delete.
+	Delete []ResourceRef `json:"delete,omitempty"`
+}
+
+// ResourceChanges holds the names of the xDS resources of a type that the simulated
+// change adds, removes or modifies.
+type ResourceChanges struct {
+	Added    []string `json:"added,omitempty"`
+	Removed  []string `json:"removed,omitempty"`
+	Modified []string `json:"modified,omitempty"`
+}
+
+// IRKeySimulation is the effect of the simulated change on the xDS resources of an irKey.
+type IRKeySimulation struct {
+	IRKey     string           `json:"irKey"`
+	Listeners *ResourceChanges `json:"listeners,omitempty"`
+	Routes    *ResourceChanges `json:"routes,omitempty"`
+	Clusters  *ResourceChanges `json:"clusters,omitempty"`
+	// Nodes holds the IDs of the Envoy proxies connected for the irKey, which
+	// would receive the change.
+	Nodes []string `json:"nodes,omitempty"`
+}
+
+// SimulationResult holds the irKeys whose xDS resources the simulated change modifies.
+type SimulationResult struct {
+	IRKeys []IRKeySimulation `json:"irKeys"`
+}
+
+// handleSimulate translates the resources with and without the requested change, and
+// reports the differences of the xDS resources without applying anything.
+func (a *API) handleSimulate(w http.ResponseWriter, r *http.Request) {
+	if a.ProviderResources == nil || a.GatewayAPITranslator == nil || a.XdsTranslator == nil {
+		writeError(w, http.StatusServiceUnavailable, fmt.Errorf("translators are not ready"))
+		return
+	}
+
+	req := &SimulationRequest{}
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxSimulationRequestBytes)).Decode(req); err != nil {
+		writeError(w, http.StatusBadRequest, fmt.Errorf("invalid request body: %w", err))
+		return
+	}
+
+	current := a.ProviderResources.GetResources()
+	proposed := make([]*resource.Resources, 0, len(current))
+	for _, resources := range current {
+		proposed = append(proposed, resources.DeepCopy())
+	}
+	if err := applySimulationRequest(proposed, req); err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	before, err := a.simulateXds(current)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	after, err := a.simulateXds(proposed)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	irKeys := make(map[string]struct{}, len(before)+len(after))
+	for key := range before {
+		irKeys[key] = struct{}{}
+	}
+	for key := range after {
+		irKeys[key] = struct{}{}
+	}
+
+	result := &SimulationResult{IRKeys: []IRKeySimulation{}}
+	for key := range irKeys {
+		simulation := IRKeySimulation{
+			IRKey:     key,
+			Listeners: diffXdsResources(before[key], after[key], resourcev3.ListenerType),
+			Routes:    diffXdsResources(before[key], after[key], resourcev3.RouteType),
+			Clusters:  diffXdsResources(before[key], after[key], resourcev3.ClusterType),
+		}
+		if simulation.Listeners == nil && simulation.Routes == nil && simulation.Clusters == nil {
+			continue
+		}
+		if snapshotCache := a.snapshotCacheFor(key); snapshotCache != nil {
+			if info, ok := snapshotCache.GetSnapshotInfo(key); ok {
+				simulation.Nodes = info.Nodes
+			}
+		}
+		result.IRKeys = append(result.IRKeys, simulation)
+	}
+	sort.Slice(result.IRKeys, func(i, j int) bool {
+		return result.IRKeys[i].IRKey < result.IRKeys[j].IRKey
+	})
+
+	writeJSON(w, http.StatusOK, result)
+}
+
+// simulateXds translates the resources of each GatewayClass into xDS resources by irKey.
+// The resources are left untouched.
+func (a *API) simulateXds(resources []*resource.Resources) (map[string]xdstypes.XdsResources, error) {
+	xds := make(map[string]xdstypes.XdsResources)
+	for _, r := range resources {
+		for key, xdsIR := range a.GatewayAPITranslator.TranslateResources(r.DeepCopy()) {
+			result, err := a.XdsTranslator.TranslateIR(xdsIR)
+			if err != nil {
+				return nil, fmt.Errorf("failed to translate xds ir %s: %w", key, err)
+			}
+			xds[key] = result.XdsResources
+		}
+	}
+	return xds, nil
+}
+
+// applySimulationRequest applies the objects and deletions of the request to the
+// resources of each GatewayClass.
+func applySimulationRequest(resources []*resource.Resources, req *SimulationRequest) error {
+	scheme := envoygateway.GetScheme()
+	for _, raw := range req.Apply {
+		un := &unstructured.Unstructured{}
+		if err := un.UnmarshalJSON(raw); err != nil {
+			return fmt.Errorf("invalid object: %w", err)
+		}
+		kobj, err := scheme.New(un.GroupVersionKind())
+		if err != nil {
+			return err
+		}
+		if err := scheme.Convert(un, kobj, nil); err != nil {
+			return err
+		}
+		obj, ok := kobj.(client.Object)
+		if !ok {
+			return fmt.Errorf("unsupported kind %s", un.GetKind())
+		}
+		// New objects are the most recent ones for the precedence rules.
+		if timestamp := obj.GetCreationTimestamp(); timestamp.IsZero() {
+			obj.SetCreationTimestamp(metav1.Now())
+		}
+
+		applied := false
+		for _, r := range resources {
+			if r.Apply(obj.DeepCopyObject().(client.Object)) {
+				applied = true
+			}
+		}
+		if !applied {
+			return fmt.Errorf("%s %s/%s doesn't apply to the resources of any GatewayClass",
+				un.GetKind(), obj.GetNamespace(), obj.GetName())
+		}
+	}
+
+	for _, ref := range req.Delete {
+		removed := false
+		for _, r := range resources {
+			if r.Remove(ref.Kind, ref.Namespace, ref.Name) {
+				removed = true
+			}
+		}
+		if !removed {
+			return fmt.Errorf("%s %s/%s not found", ref.Kind, ref.Namespace, ref.Name)
+		}
+	}
+
+	return nil
+}
+
+// diffXdsResources returns the changes of the xDS resources of the type, or nil if none.
+func diffXdsResources(before, after xdstypes.XdsResources, typeURL resourcev3.Type) *ResourceChanges {
+	previous := make(map[string]types.Resource, len(before[typeURL]))
+	for _, r := range before[typeURL] {
+		previous[cachev3.GetResourceName(r)] = r
+	}
+
+	changes := &ResourceChanges{}
+	for _, r := range after[typeURL] {
+		name := cachev3.GetResourceName(r)
+		p, ok := previous[name]
+		switch {
+		case !ok:
+			changes.Added = append(changes.Added, name)
+		case !proto.Equal(p, r):
+			changes.Modified = append(changes.Modified, name)
+		}
+		delete(previous, name)
+	}
+	for name := range previous {
+		changes.Removed = append(changes.Removed, name)
+	}
+
+	if len(changes.Added) == 0 && len(changes.Removed) == 0 && len(changes.Modified) == 0 {
+		return nil
+	}
+	sort.Strings(changes.Added)
+	sort.Strings(changes.Removed)
+	sort.Strings(changes.Modified)
+	return changes
+}
@@ -0,0 +1,172 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package egctl
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+
+	adminv3 "github.com/envoyproxy/go-control-plane/envoy/admin/v3"
+	"github.com/spf13/cobra"
+	"google.golang.org/protobuf/encoding/protojson"
+	"k8s.io/apimachinery/pkg/types"
+	cmdutil "k8s.io/kubectl/pkg/cmd/util"
+	"sigs.k8s.io/yaml"
+
+	egv1a1 "github.com/envoyproxy/gateway/api/v1alpha1"
+	kube "github.com/envoyproxy/gateway/internal/kubernetes"
+)
+
+func newConfigDumpCommand() *cobra.Command {
+	var namespace, output, nodeID, resourceType string
+
+	configDumpCommand := &cobra.Command{
+		Use:   "config-dump <irKey>",
+		Short: "Retrieve the configuration Envoy Gateway serves to the Envoy proxies of a Gateway",
+		Long: `Retrieve the resources of the last xDS snapshot Envoy Gateway generated for a Gateway, or of the snapshot
+served to a proxy, in the format of the config dump of the Envoy admin interface, without access to the proxy pods.
+The private keys and the other sensitive values of the secrets are redacted.
+Requires the admin API to be enabled in the Envoy Gateway configuration.`,
+		Example: `  # Retrieve the configuration of the Gateway default/eg served by all the Envoy Gateway pods.
+  egctl x config-dump default/eg
+
+  # Retrieve the routes served to a proxy of the Gateway.
+  egctl x config-dump default/eg --node envoy-default-eg-e41e7b31-58bd6f6f9-kqvtp --type route -o json
+	  `,
+		Args: cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			cmdutil.CheckErr(runConfigDump(cmd.OutOrStdout(), namespace, output, args[0], nodeID, envoyConfigType(resourceType)))
+		},
+	}
+
+	configDumpCommand.Flags().StringVarP(&namespace, "namespace", "n", "envoy-gateway-system", "Namespace where Envoy Gateway is installed.")
+	configDumpCommand.Flags().StringVarP(&output, "output", "o", yamlOutput, "One of 'yaml' or 'json'")
+	configDumpCommand.Flags().StringVar(&nodeID, "node", "", "Retrieve the configuration served to the proxy of the node ID, as listed by egctl x nodes.")
+	configDumpCommand.Flags().StringVar(&resourceType, "type", string(AllEnvoyConfigType),
+		"One of 'all', 'cluster', 'listener', 'route' or 'endpoint'. The endpoints are only retrieved with 'endpoint'.")
+
+	return configDumpCommand
+}
+
+func runConfigDump(w io.Writer, namespace, output, irKey, nodeID string, resourceType envoyConfigType) error {
+	if output != yamlOutput && output != jsonOutput {
+		return fmt.Errorf("invalid output format %q, valid options: yaml/json", output)
+	}
+	switch resourceType {
+	case AllEnvoyConfigType, ClusterEnvoyConfigType, ListenerEnvoyConfigType, RouteEnvoyConfigType, EndpointEnvoyConfigType:
+	default:
+		return fmt.Errorf("invalid resource type %q, valid options: all/cluster/listener/route/endpoint", resourceType)
+	}
+
+	cli, err := getCLIClient()
+	if err != nil {
+		return err
+	}
+	pods, err := fetchRunningEnvoyGatewayPods(cli, namespace)
+	if err != nil {
+		return err
+	}
+
+	out := make(map[string]any, len(pods))
+	for _, pod := range pods {
+		// The snapshot served to a node is only known to the pod it's connected to.
+		if nodeID != "" {
+			connected, err := podNodeConnected(cli, pod, irKey, nodeID)
+			if err != nil {
+				return fmt.Errorf("failed to list the nodes of pod %s: %w", pod, err)
+			}
+			if !connected {
+				continue
+			}
+		}
+		configDump, err := podConfigDump(cli, pod, irKey, nodeID, resourceType)
+		if err != nil {
+			return fmt.Errorf("failed to retrieve the config dump of pod %s: %w", pod, err)
+		}
+		out[pod.String()] = configDump
+	}
+	if nodeID != "" && len(out) == 0 {
+		return fmt.Errorf("node %s of %s is not connected to Envoy Gateway", nodeID, irKey)
+	}
+
+	data, err := json.MarshalIndent(out, "", "  ")
+	if err != nil {
+		return err
+	}
+	if output == yamlOutput {
+		if data, err = yaml.JSONToYAML(data); err != nil {
+			return err
+		}
+	}
+	_, err = fmt.Fprintln(w, string(data))
+	return err
+}
+
+// podNodeConnected returns whether the node of the irKey is connected to the xDS server of
+// the Envoy Gateway pod.
+func podNodeConnected(cli kube.CLIClient, pod types.NamespacedName, irKey, nodeID string) (bool, error) {
+	nodes, err := podConnectedNodes(cli, pod, irKey)
+	if err != nil {
+		return false, err
+	}
+	for _, node := range nodes {
+		if node.NodeID == nodeID {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// podConfigDump returns the config dump of the resources of the type served by the Envoy
+// Gateway pod, decoded to be marshaled along the config dumps of the other pods.
+func podConfigDump(cli kube.CLIClient, pod types.NamespacedName, irKey, nodeID string, resourceType envoyConfigType) (any, error) {
+	fw, err := portForwarder(cli, pod, egv1a1.GatewayAdminPort)
+	if err != nil {
+		return nil, err
+	}
+	if err := fw.Start(); err != nil {
+		return nil, err
+	}
+	defer fw.Stop()
+
+	out, err := envoyGatewayAdminRequest(fw.Address(), http.MethodGet, configDumpPath(irKey, nodeID, resourceType == EndpointEnvoyConfigType), nil)
+	if err != nil {
+		return nil, err
+	}
+	configDump := &adminv3.ConfigDump{}
+	if err := protojson.Unmarshal(out, configDump); err != nil {
+		return nil, err
+	}
+	resources, err := findXDSResourceFromConfigDump(resourceType, configDump)
+	if err != nil {
+		return nil, err
+	}
+
+	var decoded any
+	if err := json.Unmarshal([]byte(protojson.Format(resources)), &decoded); err != nil {
+		return nil, err
+	}
+	return decoded, nil
+}
+
+// configDumpPath returns the admin API path of the config dump of the irKey, of the snapshot
+// served to the node if set.
+func configDumpPath(irKey, nodeID string, includeEndpoints bool) string {
+	query := url.Values{}
+	if nodeID != "" {
+		query.Set("node", nodeID)
+	}
+	if includeEndpoints {
+		query.Set("include_eds", "")
+	}
+	if len(query) == 0 {
+		return "/config-dumps/" + irKey
+	}
+	return "/config-dumps/" + irKey + "?" + query.Encode()
+}
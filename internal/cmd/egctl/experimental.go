@@ -30,6 +30,13 @@ func newExperimentalCommand() *cobra.Command {
 	experimentalCommand.AddCommand(newUnInstallCommand())
 	experimentalCommand.AddCommand(newCollectCommand())
 	experimentalCommand.AddCommand(newValidateCommand())
+	experimentalCommand.AddCommand(newLogLevelCommand())
+	experimentalCommand.AddCommand(newProxyAdminCommand())
+	experimentalCommand.AddCommand(newSimulateCommand())
+	experimentalCommand.AddCommand(newGenerateCommand())
+	experimentalCommand.AddCommand(newReplayCommand())
+	experimentalCommand.AddCommand(newNodesCommand())
+	experimentalCommand.AddCommand(newConfigDumpCommand())
 
 	return experimentalCommand
 }
@@ -0,0 +1,365 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package egctl
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/url"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/ptr"
+	gwapiv1 "sigs.k8s.io/gateway-api/apis/v1"
+	"sigs.k8s.io/yaml"
+
+	"github.com/envoyproxy/gateway/internal/gatewayapi/resource"
+)
+
+const (
+	// openAPITimeoutExtension is the OpenAPI extension setting the request timeout of
+	// the operations of a document, a path or a single operation.
+	openAPITimeoutExtension = "x-envoy-gateway-timeout"
+	// maxRulesPerRoute and maxMatchesPerRule are the limits of the HTTPRoute API.
+	maxRulesPerRoute  = 16
+	maxMatchesPerRule = 64
+)
+
+var (
+	// openAPIMethods are the methods of the operations of an OpenAPI path item.
+	openAPIMethods = []string{"get", "put", "post", "delete", "options", "head", "patch", "trace"}
+	// openAPIPathTemplate matches the templated segments of an OpenAPI path.
+	openAPIPathTemplate = regexp.MustCompile(`\{[^{}/]+\}`)
+	// gatewayAPIDuration is the format of the durations of the Gateway API.
+	gatewayAPIDuration = regexp.MustCompile(`^([0-9]{1,5}(h|m|s|ms)){1,4}$`)
+	// invalidNameChars matches the characters not allowed in a resource name.
+	invalidNameChars = regexp.MustCompile(`[^a-z0-9-]+`)
+)
+
+// openAPIDocument holds the fields of an OpenAPI v3 or Swagger v2 document used to
+// generate routes.
+type openAPIDocument struct {
+	Info struct {
+		Title string `json:"title"`
+	} `json:"info"`
+	// Servers is the OpenAPI v3 servers of the API.
+	Servers []struct {
+		URL       string `json:"url"`
+		Variables map[string]struct {
+			Default string `json:"default"`
+		} `json:"variables,omitempty"`
+	} `json:"servers,omitempty"`
+	// BasePath is the Swagger v2 base path of the API.
+	BasePath string                                `json:"basePath,omitempty"`
+	Paths    map[string]map[string]json.RawMessage `json:"paths"`
+	Timeout  string                                `json:"x-envoy-gateway-timeout,omitempty"`
+}
+
+// openAPIOperation holds the fields of an OpenAPI operation used to generate routes.
+type openAPIOperation struct {
+	Timeout string `json:"x-envoy-gateway-timeout,omitempty"`
+}
+
+// httpRouteGenerator generates the HTTPRoutes of the operations of an OpenAPI document.
+type httpRouteGenerator struct {
+	name, namespace           string
+	gateway, gatewayNamespace string
+	sectionName               string
+	hostnames                 []string
+	backend                   string
+	backendPort               int32
+	basePath                  string
+	basePathSet               bool
+}
+
+func newGenerateCommand() *cobra.Command {
+	generateCommand := &cobra.Command{
+		Use:   "generate",
+		Short: "Generate Gateway API resources from other definitions",
+	}
+
+	generateCommand.AddCommand(newGenerateHTTPRouteCommand())
+
+	return generateCommand
+}
+
+func newGenerateHTTPRouteCommand() *cobra.Command {
+	var (
+		inFile, output string
+		g              httpRouteGenerator
+	)
+
+	generateHTTPRouteCommand := &cobra.Command{
+		Use:   "httproute",
+		Short: "Generate the HTTPRoutes of the operations of an OpenAPI specification",
+		Long: `Generate the HTTPRoutes routing the operations of an OpenAPI v3 or Swagger v2 specification
+to a backend Service, with a path and method match per operation. The request timeout of the
+operations is set from the x-envoy-gateway-timeout extension of the operation, of its path or
+of the specification, in the Gateway API duration format.`,
+		Example: `  # Generate the HTTPRoute of an API served by the petstore Service.
+  egctl x generate httproute -f openapi.yaml --gateway eg --backend petstore --backend-port 8080
+
+  # Generate and apply the HTTPRoute, keeping the routes in sync with the specification.
+  egctl x generate httproute -f openapi.yaml --gateway eg --backend petstore --backend-port 8080 | kubectl apply -f -
+	`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			g.basePathSet = cmd.Flags().Changed("base-path")
+			return runGenerateHTTPRoute(cmd.OutOrStdout(), inFile, output, &g)
+		},
+	}
+
+	generateHTTPRouteCommand.Flags().StringVarP(&inFile, "file", "f", "", "Location of the OpenAPI specification, in YAML or JSON, or - for stdin.")
+	generateHTTPRouteCommand.Flags().StringVar(&g.name, "name", "", "Name of the HTTPRoute, defaults to the title of the specification.")
+	generateHTTPRouteCommand.Flags().StringVarP(&g.namespace, "namespace", "n", "default", "Namespace of the HTTPRoute.")
+	generateHTTPRouteCommand.Flags().StringVar(&g.gateway, "gateway", "", "Name of the parent Gateway.")
+	generateHTTPRouteCommand.Flags().StringVar(&g.gatewayNamespace, "gateway-namespace", "", "Namespace of the parent Gateway, defaults to the namespace of the HTTPRoute.")
+	generateHTTPRouteCommand.Flags().StringVar(&g.sectionName, "section-name", "", "Listener of the parent Gateway.")
+	generateHTTPRouteCommand.Flags().StringSliceVar(&g.hostnames, "hostname", nil, "Hostnames of the HTTPRoute.")
+	generateHTTPRouteCommand.Flags().StringVar(&g.backend, "backend", "", "Name of the backend Service.")
+	generateHTTPRouteCommand.Flags().Int32Var(&g.backendPort, "backend-port", 0, "Port of the backend Service.")
+	generateHTTPRouteCommand.Flags().StringVar(&g.basePath, "base-path", "", "Path prefix of the operations, defaults to the path of the first server of the specification.")
+	generateHTTPRouteCommand.Flags().StringVarP(&output, "output", "o", yamlOutput, "One of 'yaml' or 'json'")
+	for _, flag := range []string{"file", "gateway", "backend", "backend-port"} {
+		if err := generateHTTPRouteCommand.MarkFlagRequired(flag); err != nil {
+			return nil
+		}
+	}
+
+	return generateHTTPRouteCommand
+}
+
+func runGenerateHTTPRoute(w io.Writer, inFile, output string, g *httpRouteGenerator) error {
+	if output != yamlOutput && output != jsonOutput {
+		return fmt.Errorf("invalid output format %q, valid options: yaml/json", output)
+	}
+
+	input, err := getInputBytes(inFile)
+	if err != nil {
+		return fmt.Errorf("unable to read input file: %w", err)
+	}
+	doc := &openAPIDocument{}
+	if err := yaml.Unmarshal(input, doc); err != nil {
+		return fmt.Errorf("invalid OpenAPI specification: %w", err)
+	}
+
+	routes, err := g.generate(doc)
+	if err != nil {
+		return err
+	}
+
+	for i, route := range routes {
+		obj, err := runtime.DefaultUnstructuredConverter.ToUnstructured(route)
+		if err != nil {
+			return err
+		}
+		// Only keep the fields meant to be applied.
+		unstructured.RemoveNestedField(obj, "metadata", "creationTimestamp")
+		unstructured.RemoveNestedField(obj, "status")
+
+		var out []byte
+		if output == jsonOutput {
+			out, err = json.MarshalIndent(obj, "", "  ")
+			out = append(out, '\n')
+		} else {
+			out, err = yaml.Marshal(obj)
+			if i > 0 {
+				out = append([]byte("---\n"), out...)
+			}
+		}
+		if err != nil {
+			return err
+		}
+		if _, err := w.Write(out); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// generate returns the HTTPRoutes of the operations of the document. The operations are
+// split into several HTTPRoutes if they don't fit the limits of a single one.
+func (g *httpRouteGenerator) generate(doc *openAPIDocument) ([]*gwapiv1.HTTPRoute, error) {
+	name := g.name
+	if name == "" {
+		name = strings.Trim(invalidNameChars.ReplaceAllString(strings.ToLower(doc.Info.Title), "-"), "-")
+		if name == "" {
+			return nil, fmt.Errorf("the specification has no title, use --name")
+		}
+	}
+
+	basePath := g.basePath
+	if !g.basePathSet {
+		var err error
+		if basePath, err = openAPIBasePath(doc); err != nil {
+			return nil, err
+		}
+	}
+	basePath = strings.TrimSuffix(basePath, "/")
+
+	// Group the matches of the operations by timeout.
+	matchesByTimeout := make(map[string][]gwapiv1.HTTPRouteMatch)
+	paths := make([]string, 0, len(doc.Paths))
+	for path := range doc.Paths {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	for _, path := range paths {
+		item := doc.Paths[path]
+		pathTimeout := doc.Timeout
+		if raw, ok := item[openAPITimeoutExtension]; ok {
+			if err := json.Unmarshal(raw, &pathTimeout); err != nil {
+				return nil, fmt.Errorf("invalid %s of path %s: %w", openAPITimeoutExtension, path, err)
+			}
+		}
+
+		for _, method := range openAPIMethods {
+			raw, ok := item[method]
+			if !ok {
+				continue
+			}
+			operation := &openAPIOperation{}
+			if err := json.Unmarshal(raw, operation); err != nil {
+				return nil, fmt.Errorf("invalid operation %s %s: %w", strings.ToUpper(method), path, err)
+			}
+			timeout := pathTimeout
+			if operation.Timeout != "" {
+				timeout = operation.Timeout
+			}
+			if timeout != "" && !gatewayAPIDuration.MatchString(timeout) {
+				return nil, fmt.Errorf("invalid %s %q of operation %s %s, expected a duration such as 10s or 1m30s",
+					openAPITimeoutExtension, timeout, strings.ToUpper(method), path)
+			}
+
+			matchesByTimeout[timeout] = append(matchesByTimeout[timeout], gwapiv1.HTTPRouteMatch{
+				Path:   openAPIPathMatch(basePath + path),
+				Method: ptr.To(gwapiv1.HTTPMethod(strings.ToUpper(method))),
+			})
+		}
+	}
+	if len(matchesByTimeout) == 0 {
+		return nil, fmt.Errorf("the specification has no operation")
+	}
+
+	timeouts := make([]string, 0, len(matchesByTimeout))
+	for timeout := range matchesByTimeout {
+		timeouts = append(timeouts, timeout)
+	}
+	sort.Strings(timeouts)
+	var rules []gwapiv1.HTTPRouteRule
+	for _, timeout := range timeouts {
+		matches := matchesByTimeout[timeout]
+		for start := 0; start < len(matches); start += maxMatchesPerRule {
+			rule := gwapiv1.HTTPRouteRule{
+				Matches: matches[start:min(start+maxMatchesPerRule, len(matches))],
+				BackendRefs: []gwapiv1.HTTPBackendRef{{
+					BackendRef: gwapiv1.BackendRef{
+						BackendObjectReference: gwapiv1.BackendObjectReference{
+							Name: gwapiv1.ObjectName(g.backend),
+							Port: ptr.To(gwapiv1.PortNumber(g.backendPort)),
+						},
+					},
+				}},
+			}
+			if timeout != "" {
+				rule.Timeouts = &gwapiv1.HTTPRouteTimeouts{Request: ptr.To(gwapiv1.Duration(timeout))}
+			}
+			rules = append(rules, rule)
+		}
+	}
+
+	var routes []*gwapiv1.HTTPRoute
+	for start := 0; start < len(rules); start += maxRulesPerRoute {
+		route := g.newHTTPRoute(name)
+		route.Spec.Rules = rules[start:min(start+maxRulesPerRoute, len(rules))]
+		routes = append(routes, route)
+	}
+	if len(routes) > 1 {
+		for i, route := range routes {
+			route.Name = name + "-" + strconv.Itoa(i+1)
+		}
+	}
+
+	return routes, nil
+}
+
+// newHTTPRoute returns an HTTPRoute attached to the Gateway, without rules.
+func (g *httpRouteGenerator) newHTTPRoute(name string) *gwapiv1.HTTPRoute {
+	route := &gwapiv1.HTTPRoute{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: gwapiv1.GroupVersion.String(),
+			Kind:       resource.KindHTTPRoute,
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: g.namespace,
+			Name:      name,
+		},
+	}
+	parentRef := gwapiv1.ParentReference{Name: gwapiv1.ObjectName(g.gateway)}
+	if g.gatewayNamespace != "" && g.gatewayNamespace != g.namespace {
+		parentRef.Namespace = ptr.To(gwapiv1.Namespace(g.gatewayNamespace))
+	}
+	if g.sectionName != "" {
+		parentRef.SectionName = ptr.To(gwapiv1.SectionName(g.sectionName))
+	}
+	route.Spec.ParentRefs = []gwapiv1.ParentReference{parentRef}
+	for _, hostname := range g.hostnames {
+		route.Spec.Hostnames = append(route.Spec.Hostnames, gwapiv1.Hostname(hostname))
+	}
+
+	return route
+}
+
+// openAPIBasePath returns the path prefix of the operations of the document, which is the
+// path of its first server with its variables set to their default value, or its base path.
+func openAPIBasePath(doc *openAPIDocument) (string, error) {
+	if len(doc.Servers) == 0 {
+		return doc.BasePath, nil
+	}
+
+	server := doc.Servers[0]
+	serverURL := server.URL
+	for name, variable := range server.Variables {
+		serverURL = strings.ReplaceAll(serverURL, "{"+name+"}", variable.Default)
+	}
+	u, err := url.Parse(serverURL)
+	if err != nil {
+		return "", fmt.Errorf("invalid server URL %s: %w", server.URL, err)
+	}
+	return u.Path, nil
+}
+
+// openAPIPathMatch returns the match of the OpenAPI path, which is an exact match unless the
+// path is templated. The templated segments match any value of the segment.
+func openAPIPathMatch(path string) *gwapiv1.HTTPPathMatch {
+	if !openAPIPathTemplate.MatchString(path) {
+		return &gwapiv1.HTTPPathMatch{
+			Type:  ptr.To(gwapiv1.PathMatchExact),
+			Value: ptr.To(path),
+		}
+	}
+
+	var regex strings.Builder
+	last := 0
+	for _, loc := range openAPIPathTemplate.FindAllStringIndex(path, -1) {
+		regex.WriteString(regexp.QuoteMeta(path[last:loc[0]]))
+		regex.WriteString("[^/]+")
+		last = loc[1]
+	}
+	regex.WriteString(regexp.QuoteMeta(path[last:]))
+
+	return &gwapiv1.HTTPPathMatch{
+		Type:  ptr.To(gwapiv1.PathMatchRegularExpression),
+		Value: ptr.To(regex.String()),
+	}
+}
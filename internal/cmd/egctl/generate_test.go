@@ -0,0 +1,85 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package egctl
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/envoyproxy/gateway/internal/utils/file"
+)
+
+func TestGenerateHTTPRoute(t *testing.T) {
+	testCases := []struct {
+		name   string
+		in     string
+		args   []string
+		expect bool
+	}{
+		{
+			name:   "petstore",
+			in:     "petstore.yaml",
+			args:   []string{"--gateway", "eg", "--gateway-namespace", "envoy-gateway", "--hostname", "petstore.example.com"},
+			expect: true,
+		},
+		{
+			name:   "swagger",
+			in:     "swagger.json",
+			args:   []string{"--gateway", "eg", "--name", "inventory-v2", "--section-name", "http"},
+			expect: true,
+		},
+		{
+			name:   "base-path",
+			in:     "petstore.yaml",
+			args:   []string{"--gateway", "eg", "--base-path", "/", "-o", "json"},
+			expect: true,
+		},
+		{
+			name:   "invalid-output",
+			in:     "petstore.yaml",
+			args:   []string{"--gateway", "eg", "-o", "table"},
+			expect: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			b := bytes.NewBufferString("")
+			root := newGenerateCommand()
+			root.SetOut(b)
+			root.SetErr(b)
+			root.SetArgs(append([]string{
+				"httproute",
+				"--file", filepath.Join("testdata", "generate", "in", tc.in),
+				"--backend", "backend",
+				"--backend-port", "8080",
+			}, tc.args...))
+
+			if !tc.expect {
+				require.Error(t, root.Execute())
+				return
+			}
+			require.NoError(t, root.Execute())
+
+			outFile := filepath.Join("testdata", "generate", "out", tc.name+".out")
+			if *overrideTestData {
+				require.NoError(t, file.Write(b.String(), outFile))
+			}
+			want, err := os.ReadFile(outFile)
+			require.NoError(t, err)
+			require.Equal(t, string(want), b.String())
+		})
+	}
+}
+
+func TestOpenAPIPathMatch(t *testing.T) {
+	require.Equal(t, "/pets", *openAPIPathMatch("/pets").Value)
+	require.Equal(t, `/pets/[^/]+/photo\.png`, *openAPIPathMatch("/pets/{petId}/photo.png").Value)
+}
@@ -0,0 +1,184 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package egctl
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/spf13/cobra"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	cmdutil "k8s.io/kubectl/pkg/cmd/util"
+	"sigs.k8s.io/yaml"
+
+	egv1a1 "github.com/envoyproxy/gateway/api/v1alpha1"
+	"github.com/envoyproxy/gateway/internal/admin"
+	kube "github.com/envoyproxy/gateway/internal/kubernetes"
+	"github.com/envoyproxy/gateway/internal/utils"
+)
+
+const envoyGatewayLabelSelector = "control-plane=envoy-gateway"
+
+func newLogLevelCommand() *cobra.Command {
+	var namespace string
+
+	logLevelCommand := &cobra.Command{
+		Use:   "loglevel [component=level]...",
+		Short: "Retrieve or change the log levels of Envoy Gateway at runtime",
+		Long: `Retrieve or change the log levels of the Envoy Gateway components at runtime.
+Requires the admin API to be enabled in the Envoy Gateway configuration.
+Valid components: default/provider/gateway-api/xds-translator/xds-server/infrastructure/global-ratelimit.`,
+		Example: `  # Retrieve the log levels of all the Envoy Gateway pods.
+  egctl x loglevel
+
+  # Enable debug logging for the xds-server and provider components.
+  egctl x loglevel xds-server=debug provider=debug
+
+  # Reset the default log level of all the components without a level of their own.
+  egctl x loglevel default=info
+	  `,
+		Run: func(cmd *cobra.Command, args []string) {
+			cmdutil.CheckErr(runLogLevel(cmd.OutOrStdout(), namespace, args))
+		},
+	}
+
+	logLevelCommand.Flags().StringVarP(&namespace, "namespace", "n", "envoy-gateway-system", "Namespace where Envoy Gateway is installed.")
+
+	return logLevelCommand
+}
+
+func runLogLevel(w io.Writer, namespace string, args []string) error {
+	levels, err := parseLogLevels(args)
+	if err != nil {
+		return err
+	}
+
+	cli, err := getCLIClient()
+	if err != nil {
+		return err
+	}
+
+	pods, err := fetchRunningEnvoyGatewayPods(cli, namespace)
+	if err != nil {
+		return err
+	}
+
+	out := make(map[string]map[egv1a1.EnvoyGatewayLogComponent]egv1a1.LogLevel, len(pods))
+	for _, pod := range pods {
+		podLevels, err := podLogLevels(cli, pod, levels)
+		if err != nil {
+			return fmt.Errorf("failed to change log levels of pod %s: %w", pod, err)
+		}
+		out[pod.String()] = podLevels
+	}
+
+	data, err := yaml.Marshal(out)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprint(w, string(data))
+	return err
+}
+
+// parseLogLevels parses arguments in the form of component=level.
+func parseLogLevels(args []string) (map[egv1a1.EnvoyGatewayLogComponent]egv1a1.LogLevel, error) {
+	levels := make(map[egv1a1.EnvoyGatewayLogComponent]egv1a1.LogLevel, len(args))
+	for _, arg := range args {
+		component, level, ok := strings.Cut(arg, "=")
+		if !ok || component == "" || level == "" {
+			return nil, fmt.Errorf("invalid argument %q, expected component=level", arg)
+		}
+		levels[egv1a1.EnvoyGatewayLogComponent(component)] = egv1a1.LogLevel(level)
+	}
+	return levels, nil
+}
+
+// podLogLevels sets the levels on the Envoy Gateway pod and returns the levels in effect.
+func podLogLevels(cli kube.CLIClient, pod types.NamespacedName, levels map[egv1a1.EnvoyGatewayLogComponent]egv1a1.LogLevel) (map[egv1a1.EnvoyGatewayLogComponent]egv1a1.LogLevel, error) {
+	fw, err := portForwarder(cli, pod, egv1a1.GatewayAdminPort)
+	if err != nil {
+		return nil, err
+	}
+	if err := fw.Start(); err != nil {
+		return nil, err
+	}
+	defer fw.Stop()
+
+	for component, level := range levels {
+		body, err := json.Marshal(&admin.LogLevelRequest{Level: level})
+		if err != nil {
+			return nil, err
+		}
+		if _, err := envoyGatewayAdminRequest(fw.Address(), http.MethodPut, "/loglevels/"+string(component), body); err != nil {
+			return nil, err
+		}
+	}
+
+	out, err := envoyGatewayAdminRequest(fw.Address(), http.MethodGet, "/loglevels", nil)
+	if err != nil {
+		return nil, err
+	}
+	current := make(map[egv1a1.EnvoyGatewayLogComponent]egv1a1.LogLevel)
+	if err := json.Unmarshal(out, &current); err != nil {
+		return nil, err
+	}
+
+	return current, nil
+}
+
+// fetchRunningEnvoyGatewayPods returns the Envoy Gateway pods in the namespace that are running.
+func fetchRunningEnvoyGatewayPods(cli kube.CLIClient, namespace string) ([]types.NamespacedName, error) {
+	podList, err := cli.PodsForSelector(namespace, envoyGatewayLabelSelector)
+	if err != nil {
+		return nil, fmt.Errorf("list Envoy Gateway pods failed: %w", err)
+	}
+
+	var pods []types.NamespacedName
+	for _, pod := range podList.Items {
+		if pod.Status.Phase == corev1.PodRunning {
+			pods = append(pods, utils.NamespacedName(&pod))
+		}
+	}
+	if len(pods) == 0 {
+		return nil, fmt.Errorf("no running Envoy Gateway pods found in namespace %s", namespace)
+	}
+
+	return pods, nil
+}
+
+// envoyGatewayAdminRequest sends a request to the admin API of Envoy Gateway.
+func envoyGatewayAdminRequest(address, method, path string, body []byte) ([]byte, error) {
+	req, err := http.NewRequest(method, fmt.Sprintf("http://%s%s%s", address, admin.APIPrefix, path), bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	out, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	switch {
+	case resp.StatusCode == http.StatusNotFound && len(out) > 0 && out[0] != '{':
+		return nil, fmt.Errorf("admin API is not enabled")
+	case resp.StatusCode >= http.StatusBadRequest:
+		return nil, fmt.Errorf("%s %s: %s", method, path, strings.TrimSpace(string(out)))
+	}
+
+	return out, nil
+}
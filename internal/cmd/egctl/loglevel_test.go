@@ -0,0 +1,66 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package egctl
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	egv1a1 "github.com/envoyproxy/gateway/api/v1alpha1"
+)
+
+func TestParseLogLevels(t *testing.T) {
+	levels, err := parseLogLevels([]string{"xds-server=debug", "default=warn"})
+	require.NoError(t, err)
+	require.Equal(t, map[egv1a1.EnvoyGatewayLogComponent]egv1a1.LogLevel{
+		egv1a1.LogComponentXdsServerRunner: egv1a1.LogLevelDebug,
+		egv1a1.LogComponentGatewayDefault:  egv1a1.LogLevelWarn,
+	}, levels)
+
+	_, err = parseLogLevels([]string{"xds-server"})
+	require.Error(t, err)
+
+	_, err = parseLogLevels([]string{"=debug"})
+	require.Error(t, err)
+}
+
+func TestEnvoyGatewayAdminRequest(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /api/v1/loglevels", func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`{"default":"info"}`))
+	})
+	mux.HandleFunc("PUT /api/v1/loglevels/{component}", func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(`{"error":"invalid log level"}`))
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+	address := strings.TrimPrefix(server.URL, "http://")
+
+	out, err := envoyGatewayAdminRequest(address, http.MethodGet, "/loglevels", nil)
+	require.NoError(t, err)
+	require.JSONEq(t, `{"default":"info"}`, string(out))
+
+	_, err = envoyGatewayAdminRequest(address, http.MethodPut, "/loglevels/default", []byte(`{"level":"verbose"}`))
+	require.ErrorContains(t, err, "invalid log level")
+
+	_, err = envoyGatewayAdminRequest(address, http.MethodGet, "/irkeys", nil)
+	require.ErrorContains(t, err, "admin API is not enabled")
+}
+
+func TestNodesPath(t *testing.T) {
+	require.Equal(t, "/nodes", nodesPath(""))
+	require.Equal(t, "/nodes?irKey=default%2Feg", nodesPath("default/eg"))
+}
+
+func TestConfigDumpPath(t *testing.T) {
+	require.Equal(t, "/config-dumps/default/eg", configDumpPath("default/eg", "", false))
+	require.Equal(t, "/config-dumps/default/eg?include_eds=&node=envoy-1", configDumpPath("default/eg", "envoy-1", true))
+}
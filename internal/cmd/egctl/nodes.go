@@ -0,0 +1,117 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package egctl
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+
+	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/types"
+	cmdutil "k8s.io/kubectl/pkg/cmd/util"
+	"sigs.k8s.io/yaml"
+
+	egv1a1 "github.com/envoyproxy/gateway/api/v1alpha1"
+	kube "github.com/envoyproxy/gateway/internal/kubernetes"
+	"github.com/envoyproxy/gateway/internal/xds/cache"
+)
+
+func newNodesCommand() *cobra.Command {
+	var namespace, output, irKey string
+
+	nodesCommand := &cobra.Command{
+		Use:   "nodes",
+		Short: "List the Envoy proxies connected to the xDS server of Envoy Gateway",
+		Long: `List the Envoy proxies connected to the xDS server of every Envoy Gateway pod, with their cluster,
+Envoy version, connection time, stream type and the type URLs of the resources they requested.
+Requires the admin API to be enabled in the Envoy Gateway configuration.`,
+		Example: `  # List the proxies connected to all the Envoy Gateway pods.
+  egctl x nodes
+
+  # List the proxies of a Gateway.
+  egctl x nodes --irkey default/eg -o json
+	  `,
+		Run: func(cmd *cobra.Command, args []string) {
+			cmdutil.CheckErr(runNodes(cmd.OutOrStdout(), namespace, output, irKey))
+		},
+	}
+
+	nodesCommand.Flags().StringVarP(&namespace, "namespace", "n", "envoy-gateway-system", "Namespace where Envoy Gateway is installed.")
+	nodesCommand.Flags().StringVarP(&output, "output", "o", yamlOutput, "One of 'yaml' or 'json'")
+	nodesCommand.Flags().StringVar(&irKey, "irkey", "", "Only list the proxies of the irKey, such as default/eg.")
+
+	return nodesCommand
+}
+
+func runNodes(w io.Writer, namespace, output, irKey string) error {
+	if output != yamlOutput && output != jsonOutput {
+		return fmt.Errorf("invalid output format %q, valid options: yaml/json", output)
+	}
+
+	cli, err := getCLIClient()
+	if err != nil {
+		return err
+	}
+	pods, err := fetchRunningEnvoyGatewayPods(cli, namespace)
+	if err != nil {
+		return err
+	}
+
+	out := make(map[string][]cache.ConnectedNode, len(pods))
+	for _, pod := range pods {
+		nodes, err := podConnectedNodes(cli, pod, irKey)
+		if err != nil {
+			return fmt.Errorf("failed to list the nodes of pod %s: %w", pod, err)
+		}
+		out[pod.String()] = nodes
+	}
+
+	if output == jsonOutput {
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(out)
+	}
+	data, err := yaml.Marshal(out)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprint(w, string(data))
+	return err
+}
+
+// podConnectedNodes returns the nodes connected to the xDS server of the Envoy Gateway pod.
+func podConnectedNodes(cli kube.CLIClient, pod types.NamespacedName, irKey string) ([]cache.ConnectedNode, error) {
+	fw, err := portForwarder(cli, pod, egv1a1.GatewayAdminPort)
+	if err != nil {
+		return nil, err
+	}
+	if err := fw.Start(); err != nil {
+		return nil, err
+	}
+	defer fw.Stop()
+
+	out, err := envoyGatewayAdminRequest(fw.Address(), http.MethodGet, nodesPath(irKey), nil)
+	if err != nil {
+		return nil, err
+	}
+	var nodes []cache.ConnectedNode
+	if err := json.Unmarshal(out, &nodes); err != nil {
+		return nil, err
+	}
+
+	return nodes, nil
+}
+
+// nodesPath returns the admin API path listing the nodes, only the ones of the irKey if set.
+func nodesPath(irKey string) string {
+	if irKey == "" {
+		return "/nodes"
+	}
+	return "/nodes?" + url.Values{"irKey": []string{irKey}}.Encode()
+}
@@ -0,0 +1,81 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package egctl
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/types"
+	cmdutil "k8s.io/kubectl/pkg/cmd/util"
+
+	egv1a1 "github.com/envoyproxy/gateway/api/v1alpha1"
+)
+
+func newProxyAdminCommand() *cobra.Command {
+	var namespace, egNamespace string
+
+	proxyAdminCommand := &cobra.Command{
+		Use:   "proxy-admin <pod-name> <path>",
+		Short: "Retrieve an endpoint of the Envoy admin interface through Envoy Gateway",
+		Long: `Retrieve a read-only endpoint of the admin interface of an Envoy proxy, proxied by Envoy Gateway.
+Only requires access to the admin API of Envoy Gateway, not to the Envoy proxy pods.
+The endpoint must be allowed by the admin settings of the EnvoyProxy of the proxy.`,
+		Example: `  # Retrieve the config dump of an Envoy proxy.
+  egctl x proxy-admin envoy-default-eg-e41e7b31-58bd6f6f9-kqvtp /config_dump
+
+  # Retrieve the server stats of an Envoy proxy.
+  egctl x proxy-admin envoy-default-eg-e41e7b31-58bd6f6f9-kqvtp "/stats?filter=^server\."
+	  `,
+		Args: cobra.ExactArgs(2),
+		Run: func(cmd *cobra.Command, args []string) {
+			cmdutil.CheckErr(runProxyAdmin(cmd.OutOrStdout(), egNamespace,
+				types.NamespacedName{Namespace: namespace, Name: args[0]}, args[1]))
+		},
+	}
+
+	proxyAdminCommand.Flags().StringVarP(&namespace, "namespace", "n", "envoy-gateway-system", "Namespace of the Envoy proxy pod.")
+	proxyAdminCommand.Flags().StringVar(&egNamespace, "envoy-gateway-namespace", "envoy-gateway-system", "Namespace where Envoy Gateway is installed.")
+
+	return proxyAdminCommand
+}
+
+func runProxyAdmin(w io.Writer, egNamespace string, pod types.NamespacedName, path string) error {
+	cli, err := getCLIClient()
+	if err != nil {
+		return err
+	}
+
+	pods, err := fetchRunningEnvoyGatewayPods(cli, egNamespace)
+	if err != nil {
+		return err
+	}
+
+	fw, err := portForwarder(cli, pods[0], egv1a1.GatewayAdminPort)
+	if err != nil {
+		return err
+	}
+	if err := fw.Start(); err != nil {
+		return err
+	}
+	defer fw.Stop()
+
+	out, err := envoyGatewayAdminRequest(fw.Address(), http.MethodGet, proxyAdminPath(pod, path), nil)
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(out)
+	return err
+}
+
+// proxyAdminPath returns the path of the admin API of Envoy Gateway proxying
+// the path of the admin interface of the Envoy proxy pod.
+func proxyAdminPath(pod types.NamespacedName, path string) string {
+	return fmt.Sprintf("/proxies/%s/%s/admin/%s", pod.Namespace, pod.Name, strings.TrimPrefix(path, "/"))
+}
@@ -0,0 +1,201 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package egctl
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/spf13/cobra"
+	cmdutil "k8s.io/kubectl/pkg/cmd/util"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/yaml"
+
+	egv1a1 "github.com/envoyproxy/gateway/api/v1alpha1"
+	"github.com/envoyproxy/gateway/internal/replay"
+)
+
+// replayFlags are the flags of the command starting a replay.
+type replayFlags struct {
+	irKey              string
+	target             string
+	rate               float64
+	samplingPercentage uint32
+	since              time.Duration
+	from, to           string
+	preserveHost       bool
+}
+
+func newReplayCommand() *cobra.Command {
+	var namespace, output string
+
+	replayCommand := &cobra.Command{
+		Use:   "replay",
+		Short: "Replay the recorded traffic of the proxies against a target",
+		Long: `Replay a window of the requests recorded by the proxies against a target, such as a shadow
+environment running a new version, at a controlled rate. The traffic of the proxies is only recorded
+once enabled with the trafficRecording field of their EnvoyProxy. Requires the admin API to be enabled
+in the Envoy Gateway configuration.`,
+	}
+
+	replayCommand.PersistentFlags().StringVarP(&namespace, "namespace", "n", "envoy-gateway-system", "Namespace where Envoy Gateway is installed.")
+	replayCommand.PersistentFlags().StringVarP(&output, "output", "o", yamlOutput, "One of 'yaml' or 'json'")
+
+	replayCommand.AddCommand(newReplayStartCommand(&namespace, &output))
+	replayCommand.AddCommand(newReplayStatusCommand(&namespace, &output))
+	replayCommand.AddCommand(newReplayStopCommand(&namespace, &output))
+
+	return replayCommand
+}
+
+func newReplayStartCommand(namespace, output *string) *cobra.Command {
+	flags := &replayFlags{}
+
+	startCommand := &cobra.Command{
+		Use:   "start",
+		Short: "Start replaying the recorded traffic of an irKey against a target",
+		Example: `  # Replay the requests recorded in the last 10 minutes at 50 requests per second.
+  egctl x replay start --irkey envoy-gateway/eg --target http://shadow.example.svc:8080 --rate 50 --since 10m
+
+  # Replay half of the requests recorded in a time window, with their original Host header.
+  egctl x replay start --irkey envoy-gateway/eg --target http://shadow.example.svc:8080 --rate 20 \
+    --from 2024-01-01T10:00:00Z --to 2024-01-01T10:30:00Z --sampling-percentage 50 --preserve-host
+	  `,
+		Run: func(cmd *cobra.Command, args []string) {
+			cmdutil.CheckErr(runReplayStart(cmd.OutOrStdout(), *namespace, *output, flags))
+		},
+	}
+
+	startCommand.Flags().StringVar(&flags.irKey, "irkey", "", "The irKey whose recorded traffic is replayed, such as envoy-gateway/eg.")
+	startCommand.Flags().StringVar(&flags.target, "target", "", "The base URL the requests are replayed against.")
+	startCommand.Flags().Float64Var(&flags.rate, "rate", 10, "The number of requests replayed per second.")
+	startCommand.Flags().Uint32Var(&flags.samplingPercentage, "sampling-percentage", 100, "The percentage of the recorded requests of the window that are replayed.")
+	startCommand.Flags().DurationVar(&flags.since, "since", 0, "Only replay the requests recorded within this duration, such as 10m.")
+	startCommand.Flags().StringVar(&flags.from, "from", "", "Only replay the requests recorded from this RFC3339 time.")
+	startCommand.Flags().StringVar(&flags.to, "to", "", "Only replay the requests recorded until this RFC3339 time.")
+	startCommand.Flags().BoolVar(&flags.preserveHost, "preserve-host", false, "Replay the requests with their recorded Host header rather than the host of the target.")
+	_ = startCommand.MarkFlagRequired("irkey")
+	_ = startCommand.MarkFlagRequired("target")
+
+	return startCommand
+}
+
+func newReplayStatusCommand(namespace, output *string) *cobra.Command {
+	return &cobra.Command{
+		Use:   "status [id]",
+		Short: "Show the progress of the replays, or of a single replay",
+		Args:  cobra.MaximumNArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			path := "/replays"
+			if len(args) == 1 {
+				path += "/" + args[0]
+			}
+			cmdutil.CheckErr(runReplayRequest(cmd.OutOrStdout(), *namespace, *output, http.MethodGet, path, nil))
+		},
+	}
+}
+
+func newReplayStopCommand(namespace, output *string) *cobra.Command {
+	return &cobra.Command{
+		Use:   "stop <id>",
+		Short: "Stop a replay",
+		Args:  cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			cmdutil.CheckErr(runReplayRequest(cmd.OutOrStdout(), *namespace, *output, http.MethodDelete, "/replays/"+args[0], nil))
+		},
+	}
+}
+
+func runReplayStart(w io.Writer, namespace, output string, flags *replayFlags) error {
+	cfg, err := newReplayConfig(flags, time.Now())
+	if err != nil {
+		return err
+	}
+	body, err := json.Marshal(cfg)
+	if err != nil {
+		return err
+	}
+	return runReplayRequest(w, namespace, output, http.MethodPost, "/replays", body)
+}
+
+// newReplayConfig returns the configuration of the replay of the flags, the window
+// being either the duration until now or the from and to times.
+func newReplayConfig(flags *replayFlags, now time.Time) (*replay.Config, error) {
+	cfg := &replay.Config{
+		IRKey:        flags.irKey,
+		Target:       flags.target,
+		Rate:         flags.rate,
+		PreserveHost: flags.preserveHost,
+	}
+	if flags.samplingPercentage != 100 {
+		cfg.SamplingPercentage = ptr.To(flags.samplingPercentage)
+	}
+
+	if flags.since != 0 {
+		if flags.from != "" || flags.to != "" {
+			return nil, fmt.Errorf("--since can't be set along with --from or --to")
+		}
+		cfg.From = ptr.To(now.Add(-flags.since))
+	}
+	if flags.from != "" {
+		from, err := time.Parse(time.RFC3339, flags.from)
+		if err != nil {
+			return nil, fmt.Errorf("invalid --from: %w", err)
+		}
+		cfg.From = &from
+	}
+	if flags.to != "" {
+		to, err := time.Parse(time.RFC3339, flags.to)
+		if err != nil {
+			return nil, fmt.Errorf("invalid --to: %w", err)
+		}
+		cfg.To = &to
+	}
+
+	return cfg, cfg.Validate()
+}
+
+// runReplayRequest sends the request to the admin API of Envoy Gateway, which holds the
+// recorded traffic and runs the replays, and writes the response.
+func runReplayRequest(w io.Writer, namespace, output, method, path string, body []byte) error {
+	if output != yamlOutput && output != jsonOutput {
+		return fmt.Errorf("invalid output format %q, valid options: yaml/json", output)
+	}
+
+	cli, err := getCLIClient()
+	if err != nil {
+		return err
+	}
+	pods, err := fetchRunningEnvoyGatewayPods(cli, namespace)
+	if err != nil {
+		return err
+	}
+
+	fw, err := portForwarder(cli, pods[0], egv1a1.GatewayAdminPort)
+	if err != nil {
+		return err
+	}
+	if err := fw.Start(); err != nil {
+		return err
+	}
+	defer fw.Stop()
+
+	out, err := envoyGatewayAdminRequest(fw.Address(), method, path, body)
+	if err != nil {
+		return err
+	}
+
+	if output == yamlOutput {
+		if out, err = yaml.JSONToYAML(out); err != nil {
+			return err
+		}
+	}
+	_, err = fmt.Fprint(w, string(out))
+	return err
+}
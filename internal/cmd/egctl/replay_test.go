@@ -0,0 +1,60 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package egctl
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewReplayConfig(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	cfg, err := newReplayConfig(&replayFlags{
+		irKey:              "envoy-gateway/eg",
+		target:             "http://shadow.example.svc:8080",
+		rate:               50,
+		samplingPercentage: 100,
+		since:              10 * time.Minute,
+	}, now)
+	require.NoError(t, err)
+	require.Equal(t, now.Add(-10*time.Minute), *cfg.From)
+	require.Nil(t, cfg.To)
+	require.Nil(t, cfg.SamplingPercentage)
+
+	cfg, err = newReplayConfig(&replayFlags{
+		irKey:              "envoy-gateway/eg",
+		target:             "http://shadow.example.svc:8080",
+		rate:               50,
+		samplingPercentage: 25,
+		from:               "2024-01-01T10:00:00Z",
+		to:                 "2024-01-01T10:30:00Z",
+	}, now)
+	require.NoError(t, err)
+	require.Equal(t, time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC), *cfg.From)
+	require.Equal(t, time.Date(2024, 1, 1, 10, 30, 0, 0, time.UTC), *cfg.To)
+	require.Equal(t, uint32(25), *cfg.SamplingPercentage)
+
+	_, err = newReplayConfig(&replayFlags{
+		irKey:              "envoy-gateway/eg",
+		target:             "http://shadow.example.svc:8080",
+		rate:               50,
+		samplingPercentage: 100,
+		since:              time.Minute,
+		from:               "2024-01-01T10:00:00Z",
+	}, now)
+	require.ErrorContains(t, err, "--since can't be set along with --from or --to")
+
+	_, err = newReplayConfig(&replayFlags{
+		irKey:              "envoy-gateway/eg",
+		target:             "shadow",
+		rate:               50,
+		samplingPercentage: 100,
+	}, now)
+	require.ErrorContains(t, err, "target must be an http or https URL")
+}
@@ -0,0 +1,143 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package egctl
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/spf13/cobra"
+	cmdutil "k8s.io/kubectl/pkg/cmd/util"
+	"sigs.k8s.io/yaml"
+
+	egv1a1 "github.com/envoyproxy/gateway/api/v1alpha1"
+	"github.com/envoyproxy/gateway/internal/admin"
+	"github.com/envoyproxy/gateway/internal/gatewayapi/resource"
+)
+
+func newSimulateCommand() *cobra.Command {
+	var (
+		inFile, namespace, output string
+		deletes                   []string
+	)
+
+	simulateCommand := &cobra.Command{
+		Use:   "simulate",
+		Short: "Report the effect of a proposed change of the resources without applying it",
+		Long: `Report the listeners, routes and clusters of the generated xDS configuration that a proposed
+change of the resources would add, remove or modify, and the proxies that would receive the change.
+Nothing is applied. Requires the admin API to be enabled in the Envoy Gateway configuration.`,
+		Example: `  # Simulate creating or updating the resources of a file.
+  egctl x simulate -f httproute.yaml
+
+  # Simulate deleting an HTTPRoute.
+  egctl x simulate --delete HTTPRoute/default/backend
+
+  # Simulate replacing an HTTPRoute by another one, in JSON output.
+  egctl x simulate -f new-route.yaml --delete HTTPRoute/default/old-route -o json
+	  `,
+		Run: func(cmd *cobra.Command, args []string) {
+			cmdutil.CheckErr(runSimulate(cmd.OutOrStdout(), namespace, inFile, deletes, output))
+		},
+	}
+
+	simulateCommand.Flags().StringVarP(&inFile, "file", "f", "", "Location of the resources to create or update, or - for stdin.")
+	simulateCommand.Flags().StringSliceVar(&deletes, "delete", nil, "Resources to delete, in the kind/namespace/name format.")
+	simulateCommand.Flags().StringVarP(&namespace, "namespace", "n", "envoy-gateway-system", "Namespace where Envoy Gateway is installed.")
+	simulateCommand.Flags().StringVarP(&output, "output", "o", yamlOutput, "One of 'yaml' or 'json'")
+
+	return simulateCommand
+}
+
+func runSimulate(w io.Writer, namespace, inFile string, deletes []string, output string) error {
+	if output != yamlOutput && output != jsonOutput {
+		return fmt.Errorf("invalid output format %q, valid options: yaml/json", output)
+	}
+	req, err := newSimulationRequest(inFile, deletes)
+	if err != nil {
+		return err
+	}
+	if len(req.Apply) == 0 && len(req.Delete) == 0 {
+		return fmt.Errorf("no change to simulate, use --file or --delete")
+	}
+	body, err := json.Marshal(req)
+	if err != nil {
+		return err
+	}
+
+	cli, err := getCLIClient()
+	if err != nil {
+		return err
+	}
+	pods, err := fetchRunningEnvoyGatewayPods(cli, namespace)
+	if err != nil {
+		return err
+	}
+
+	fw, err := portForwarder(cli, pods[0], egv1a1.GatewayAdminPort)
+	if err != nil {
+		return err
+	}
+	if err := fw.Start(); err != nil {
+		return err
+	}
+	defer fw.Stop()
+
+	out, err := envoyGatewayAdminRequest(fw.Address(), http.MethodPost, "/simulate", body)
+	if err != nil {
+		return err
+	}
+
+	if output == yamlOutput {
+		if out, err = yaml.JSONToYAML(out); err != nil {
+			return err
+		}
+	}
+	_, err = fmt.Fprint(w, string(out))
+	return err
+}
+
+// newSimulationRequest returns the request simulating the creation or update of the
+// resources of the file, and the deletion of the resources in the kind/namespace/name format.
+func newSimulationRequest(inFile string, deletes []string) (*admin.SimulationRequest, error) {
+	req := &admin.SimulationRequest{}
+
+	if inFile != "" {
+		input, err := getInputBytes(inFile)
+		if err != nil {
+			return nil, err
+		}
+		if err := resource.IterYAMLBytes(input, func(doc []byte) error {
+			if len(bytes.TrimSpace(doc)) == 0 {
+				return nil
+			}
+			obj, err := yaml.YAMLToJSON(doc)
+			if err != nil {
+				return err
+			}
+			if string(obj) != "null" {
+				req.Apply = append(req.Apply, obj)
+			}
+			return nil
+		}); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, d := range deletes {
+		parts := strings.Split(d, "/")
+		if len(parts) != 3 || parts[0] == "" || parts[2] == "" {
+			return nil, fmt.Errorf("invalid resource %q, expected kind/namespace/name", d)
+		}
+		req.Delete = append(req.Delete, admin.ResourceRef{Kind: parts[0], Namespace: parts[1], Name: parts[2]})
+	}
+
+	return req, nil
+}
@@ -0,0 +1,43 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package egctl
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/envoyproxy/gateway/internal/admin"
+)
+
+func TestNewSimulationRequest(t *testing.T) {
+	inFile := filepath.Join(t.TempDir(), "change.yaml")
+	require.NoError(t, os.WriteFile(inFile, []byte(`---
+apiVersion: gateway.networking.k8s.io/v1
+kind: HTTPRoute
+metadata:
+  name: backend
+  namespace: default
+---
+apiVersion: v1
+kind: Service
+metadata:
+  name: backend
+  namespace: default
+`), 0o600))
+
+	req, err := newSimulationRequest(inFile, []string{"HTTPRoute/default/old-backend"})
+	require.NoError(t, err)
+	require.Len(t, req.Apply, 2)
+	require.JSONEq(t, `{"apiVersion":"gateway.networking.k8s.io/v1","kind":"HTTPRoute","metadata":{"name":"backend","namespace":"default"}}`,
+		string(req.Apply[0]))
+	require.Equal(t, []admin.ResourceRef{{Kind: "HTTPRoute", Namespace: "default", Name: "old-backend"}}, req.Delete)
+
+	_, err = newSimulationRequest("", []string{"HTTPRoute/backend"})
+	require.Error(t, err)
+}
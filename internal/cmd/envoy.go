@@ -31,12 +31,13 @@ func getShutdownCommand() *cobra.Command {
 	var drainTimeout time.Duration
 	var minDrainDuration time.Duration
 	var exitAtConnections int
+	var adminUnixSocket string
 
 	cmd := &cobra.Command{
 		Use:   "shutdown",
 		Short: "Gracefully drain open connections prior to pod shutdown.",
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return envoy.Shutdown(drainTimeout, minDrainDuration, exitAtConnections)
+			return envoy.Shutdown(drainTimeout, minDrainDuration, exitAtConnections, adminUnixSocket)
 		},
 	}
 
@@ -49,23 +50,32 @@ func getShutdownCommand() *cobra.Command {
 	cmd.PersistentFlags().IntVar(&exitAtConnections, "exit-at-connections", 0,
 		"Number of connections to wait for when monitoring Envoy listener drain process.")
 
+	cmd.PersistentFlags().StringVar(&adminUnixSocket, "admin-unix-socket", "",
+		"Unix socket of the Envoy admin interface. If empty, the admin interface is reached on localhost.")
+
 	return cmd
 }
 
 // getShutdownManagerCommand returns the shutdown manager cobra command to be executed.
 func getShutdownManagerCommand() *cobra.Command {
-	var readyTimeout time.Duration
+	var (
+		readyTimeout   time.Duration
+		checkpointPath string
+	)
 
 	cmd := &cobra.Command{
 		Use:   "shutdown-manager",
 		Short: "Provides HTTP endpoint used in preStop hook to block until ready for pod shutdown.",
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return envoy.ShutdownManager(readyTimeout)
+			return envoy.ShutdownManager(readyTimeout, checkpointPath)
 		},
 	}
 
 	cmd.PersistentFlags().DurationVar(&readyTimeout, "ready-timeout", 610*time.Second,
 		"Shutdown ready timeout. This should be greater than shutdown's drain-timeout and less than the pod's terminationGracePeriodSeconds.")
 
+	cmd.PersistentFlags().StringVar(&checkpointPath, "checkpoint-path", "",
+		"Path of the configuration checkpoint served to Envoy until it reaches Envoy Gateway. If empty, no checkpoint is served.")
+
 	return cmd
 }
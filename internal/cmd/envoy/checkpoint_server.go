@@ -0,0 +1,118 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package envoy
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"net"
+	"os"
+	"strconv"
+	"time"
+
+	discoveryv3 "github.com/envoyproxy/go-control-plane/envoy/service/discovery/v3"
+	serverv3 "github.com/envoyproxy/go-control-plane/pkg/server/v3"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/keepalive"
+
+	"github.com/envoyproxy/gateway/internal/xds/bootstrap"
+	"github.com/envoyproxy/gateway/internal/xds/cache"
+)
+
+const (
+	// checkpointReloadInterval is how often the checkpoint is checked for changes, as
+	// the ConfigMap holding it is updated in place.
+	checkpointReloadInterval = 10 * time.Second
+	// checkpointStreamLifetime is how long the proxy stays connected to the checkpoint
+	// server, before it retries Envoy Gateway.
+	checkpointStreamLifetime = 30 * time.Second
+)
+
+// checkpointServer serves the configuration checkpoint of the proxy over xDS. The proxy
+// fails over to it when Envoy Gateway is unreachable, and goes back to Envoy Gateway once
+// its stream to the checkpoint server is closed.
+type checkpointServer struct {
+	path  string
+	cache cache.SnapshotCacheWithCallbacks
+	// data is the checkpoint last loaded.
+	data []byte
+}
+
+func newCheckpointServer(path string) *checkpointServer {
+	return &checkpointServer{
+		path:  path,
+		cache: cache.NewSnapshotCache(true, logger.WithName("checkpoint")),
+	}
+}
+
+// load loads the checkpoint if it changed since it was last loaded. A missing checkpoint is
+// not an error, as it's only created once the proxies acknowledged their configuration.
+func (c *checkpointServer) load() error {
+	data, err := os.ReadFile(c.path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if bytes.Equal(data, c.data) {
+		return nil
+	}
+
+	checkpoint, err := cache.ReadCheckpoint(bytes.NewReader(data))
+	if err != nil {
+		return fmt.Errorf("failed to read checkpoint %s: %w", c.path, err)
+	}
+	if err := c.cache.GenerateNewScopedSnapshot(checkpoint.IRKey, checkpoint.Resources, checkpoint.Scopes); err != nil {
+		return err
+	}
+	c.data = data
+	logger.Info("loaded checkpoint", "irKey", checkpoint.IRKey, "version", checkpoint.Version)
+	return nil
+}
+
+// serve serves the checkpoint on the checkpoint server address until the context is done.
+func (c *checkpointServer) serve(ctx context.Context) error {
+	if err := c.load(); err != nil {
+		logger.Error(err, "failed to load checkpoint")
+	}
+
+	var lc net.ListenConfig
+	l, err := lc.Listen(ctx, "tcp", net.JoinHostPort(bootstrap.CheckpointServerAddress, strconv.Itoa(bootstrap.CheckpointServerPort)))
+	if err != nil {
+		return err
+	}
+
+	// Close the connections of the proxy after a while, for it to retry Envoy Gateway
+	// rather than staying on the checkpoint.
+	g := grpc.NewServer(grpc.KeepaliveParams(keepalive.ServerParameters{
+		MaxConnectionAge:      checkpointStreamLifetime,
+		MaxConnectionAgeGrace: time.Second,
+	}))
+	discoveryv3.RegisterAggregatedDiscoveryServiceServer(g, serverv3.NewServer(ctx, c.cache, c.cache))
+
+	go func() {
+		ticker := time.NewTicker(checkpointReloadInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				g.Stop()
+				return
+			case <-ticker.C:
+				if err := c.load(); err != nil {
+					logger.Error(err, "failed to reload checkpoint")
+				}
+			}
+		}
+	}()
+
+	logger.Info("starting checkpoint server")
+	return g.Serve(l)
+}
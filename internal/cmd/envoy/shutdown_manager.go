@@ -10,6 +10,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"net"
 	"net/http"
 	"os"
 	"os/signal"
@@ -36,8 +37,19 @@ const (
 	ShutdownReadyFile = "/tmp/shutdown-ready"
 )
 
-// ShutdownManager serves shutdown manager process for Envoy proxies.
-func ShutdownManager(readyTimeout time.Duration) error {
+// ShutdownManager serves shutdown manager process for Envoy proxies, and the configuration
+// checkpoint of the proxy if the checkpoint path is set.
+func ShutdownManager(readyTimeout time.Duration, checkpointPath string) error {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	if checkpointPath != "" {
+		go func() {
+			if err := newCheckpointServer(checkpointPath).serve(ctx); err != nil && ctx.Err() == nil {
+				logger.Error(err, "serving checkpoint failed")
+			}
+		}()
+	}
+
 	// Setup HTTP handler
 	handler := http.NewServeMux()
 	handler.HandleFunc(ShutdownManagerHealthCheckPath, func(_ http.ResponseWriter, _ *http.Request) {})
@@ -63,6 +75,7 @@ func ShutdownManager(readyTimeout time.Duration) error {
 
 		r := <-s
 		logger.Info(fmt.Sprintf("received %s", unix.SignalName(r.(syscall.Signal))))
+		cancel()
 
 		// Shutdown HTTP server without interrupting active connections
 		if err := srv.Shutdown(context.Background()); err != nil {
@@ -113,12 +126,43 @@ func shutdownReadyHandler(w http.ResponseWriter, readyTimeout time.Duration, rea
 	}
 }
 
+// envoyAdminClient sends requests to the Envoy admin API.
+type envoyAdminClient struct {
+	client  *http.Client
+	baseURL string
+}
+
+// newEnvoyAdminClient returns a client of the Envoy admin API listening on the unix socket,
+// or on the localhost port if the unix socket is empty.
+func newEnvoyAdminClient(unixSocket string) *envoyAdminClient {
+	if unixSocket == "" {
+		return &envoyAdminClient{
+			client:  http.DefaultClient,
+			baseURL: fmt.Sprintf("http://%s:%d", bootstrap.EnvoyAdminAddress, bootstrap.EnvoyAdminPort),
+		}
+	}
+
+	return &envoyAdminClient{
+		client: &http.Client{
+			Transport: &http.Transport{
+				DialContext: func(ctx context.Context, _, _ string) (net.Conn, error) {
+					var d net.Dialer
+					return d.DialContext(ctx, "unix", unixSocket)
+				},
+			},
+		},
+		// The host is ignored when dialing the unix socket.
+		baseURL: "http://envoy-admin",
+	}
+}
+
 // Shutdown is called from a preStop hook on the shutdown-manager container where
 // it will initiate a drain sequence on the Envoy proxy and block until
 // connections are drained or a timeout is exceeded.
-func Shutdown(drainTimeout time.Duration, minDrainDuration time.Duration, exitAtConnections int) error {
+func Shutdown(drainTimeout time.Duration, minDrainDuration time.Duration, exitAtConnections int, adminUnixSocket string) error {
 	startTime := time.Now()
 	allowedToExit := false
+	admin := newEnvoyAdminClient(adminUnixSocket)
 
 	// Reconfigure logger to write to stdout of main process if running in Kubernetes
 	if _, k8s := os.LookupEnv("KUBERNETES_SERVICE_HOST"); k8s && os.Getpid() != 1 {
@@ -129,7 +173,7 @@ func Shutdown(drainTimeout time.Duration, minDrainDuration time.Duration, exitAt
 		minDrainDuration.Seconds(), drainTimeout.Seconds()))
 
 	// Start failing active health checks
-	if err := postEnvoyAdminAPI("healthcheck/fail"); err != nil {
+	if err := admin.post("healthcheck/fail"); err != nil {
 		logger.Error(err, "error failing active health checks")
 	}
 
@@ -138,7 +182,7 @@ func Shutdown(drainTimeout time.Duration, minDrainDuration time.Duration, exitAt
 	for {
 		elapsedTime := time.Since(startTime)
 
-		conn, err := getTotalConnections()
+		conn, err := admin.getTotalConnections()
 		if err != nil {
 			logger.Error(err, "error getting total connections")
 		}
@@ -168,10 +212,9 @@ func Shutdown(drainTimeout time.Duration, minDrainDuration time.Duration, exitAt
 	return nil
 }
 
-// postEnvoyAdminAPI sends a POST request to the Envoy admin API
-func postEnvoyAdminAPI(path string) error {
-	if resp, err := http.Post(fmt.Sprintf("http://%s:%d/%s",
-		bootstrap.EnvoyAdminAddress, bootstrap.EnvoyAdminPort, path), "application/json", nil); err != nil {
+// post sends a POST request to the Envoy admin API
+func (a *envoyAdminClient) post(path string) error {
+	if resp, err := a.client.Post(fmt.Sprintf("%s/%s", a.baseURL, path), "application/json", nil); err != nil {
 		return err
 	} else {
 		defer resp.Body.Close()
@@ -184,10 +227,9 @@ func postEnvoyAdminAPI(path string) error {
 }
 
 // getTotalConnections retrieves the total number of open connections from Envoy's server.total_connections stat
-func getTotalConnections() (*int, error) {
+func (a *envoyAdminClient) getTotalConnections() (*int, error) {
 	// Send request to Envoy admin API to retrieve server.total_connections stat
-	if resp, err := http.Get(fmt.Sprintf("http://%s:%d//stats?filter=^server\\.total_connections$&format=json",
-		bootstrap.EnvoyAdminAddress, bootstrap.EnvoyAdminPort)); err != nil {
+	if resp, err := a.client.Get(fmt.Sprintf("%s//stats?filter=^server\\.total_connections$&format=json", a.baseURL)); err != nil {
 		return nil, err
 	} else {
 		defer resp.Body.Close()
@@ -22,6 +22,7 @@ func GetRootCommand() *cobra.Command {
 	cmd.AddCommand(getEnvoyCommand())
 	cmd.AddCommand(getVersionCommand())
 	cmd.AddCommand(getCertGenCommand())
+	cmd.AddCommand(getValidateCommand())
 
 	return cmd
 }
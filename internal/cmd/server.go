@@ -6,12 +6,15 @@
 package cmd
 
 import (
+	"context"
+
 	"github.com/spf13/cobra"
 	ctrl "sigs.k8s.io/controller-runtime"
 
 	egv1a1 "github.com/envoyproxy/gateway/api/v1alpha1"
 	"github.com/envoyproxy/gateway/internal/admin"
 	"github.com/envoyproxy/gateway/internal/envoygateway/config"
+	"github.com/envoyproxy/gateway/internal/envoygateway/config/loader"
 	extensionregistry "github.com/envoyproxy/gateway/internal/extension/registry"
 	"github.com/envoyproxy/gateway/internal/extension/types"
 	gatewayapirunner "github.com/envoyproxy/gateway/internal/gatewayapi/runner"
@@ -21,6 +24,7 @@ import (
 	"github.com/envoyproxy/gateway/internal/message"
 	"github.com/envoyproxy/gateway/internal/metrics"
 	providerrunner "github.com/envoyproxy/gateway/internal/provider/runner"
+	"github.com/envoyproxy/gateway/internal/replay"
 	xdsserverrunner "github.com/envoyproxy/gateway/internal/xds/server/runner"
 	xdstranslatorrunner "github.com/envoyproxy/gateway/internal/xds/translator/runner"
 )
@@ -51,10 +55,6 @@ func server() error {
 		return err
 	}
 
-	// Init eg admin servers.
-	if err := admin.Init(cfg); err != nil {
-		return err
-	}
 	// Init eg metrics servers.
 	if err := metrics.Init(cfg); err != nil {
 		return err
@@ -124,6 +124,15 @@ func setupRunners(cfg *config.Server) (err error) {
 		}
 	}
 
+	// Bound the watchable queues of the messages, retry their failing updates, and trace
+	// them, before the runners subscribe to them.
+	message.SetQueues(cfg.EnvoyGateway.Watchable)
+	message.SetRetries(cfg.EnvoyGateway.Watchable)
+	shutdownTracing, err := message.SetTracing(cfg.EnvoyGateway.Watchable)
+	if err != nil {
+		return err
+	}
+
 	pResources := new(message.ProviderResources)
 	// Start the Provider Service
 	// It fetches the resources from the configured provider type
@@ -173,8 +182,10 @@ func setupRunners(cfg *config.Server) (err error) {
 	// It subscribes to the infraIR, translates it into Envoy Proxy infrastructure
 	// resources such as K8s deployment and services.
 	infraRunner := infrarunner.New(&infrarunner.Config{
-		Server:  *cfg,
-		InfraIR: infraIR,
+		Server:            *cfg,
+		InfraIR:           infraIR,
+		Xds:               xds,
+		ProviderResources: pResources,
 	})
 	if err = infraRunner.Start(ctx); err != nil {
 		return err
@@ -183,14 +194,59 @@ func setupRunners(cfg *config.Server) (err error) {
 	// Start the xDS Server
 	// It subscribes to the xds Resources and configures the remote Envoy Proxy
 	// via the xDS Protocol.
+	// It also publishes the listeners that the proxies have not acknowledged yet.
 	xdsServerRunner := xdsserverrunner.New(&xdsserverrunner.Config{
-		Server: *cfg,
-		Xds:    xds,
+		Server:            *cfg,
+		Xds:               xds,
+		ProviderResources: pResources,
 	})
 	if err = xdsServerRunner.Start(ctx); err != nil {
 		return err
 	}
 
+	// The admin interface of the proxies can only be reached through the Kubernetes API server.
+	var proxyAdmin admin.ProxyAdmin
+	if cfg.EnvoyGateway.Provider.Type == egv1a1.ProviderTypeKubernetes {
+		proxyAdmin, err = admin.NewKubernetesProxyAdmin()
+		if err != nil {
+			return err
+		}
+	}
+
+	// Init eg admin servers.
+	// The admin API is backed by the runners started above.
+	if err = admin.Init(cfg, &admin.API{
+		ProviderResources:    pResources,
+		XdsIR:                xdsIR,
+		Xds:                  xds,
+		GatewayAPITranslator: gwRunner,
+		XdsTranslator:        xdsTranslatorRunner,
+		XdsServer:            xdsServerRunner,
+		LogLevels:            cfg.Logger,
+		InfraIR:              infraIR,
+		ProxyAdmin:           proxyAdmin,
+		Replays:              replay.NewManager(nil),
+	}); err != nil {
+		return err
+	}
+
+	// Watch the config file and apply the changes that are safe to apply at runtime.
+	if cfgPath != "" {
+		cfgLoader := loader.New(cfgPath, cfg)
+		cfgLoader.Register("logging", func(eg *egv1a1.EnvoyGateway) error {
+			cfg.Logger.SetLogging(eg.Logging)
+			return nil
+		})
+		if mgr, ok := extMgr.(*extensionregistry.Manager); ok {
+			cfgLoader.Register("extensionManager", func(eg *egv1a1.EnvoyGateway) error {
+				return mgr.UpdateExtension(eg.ExtensionManager)
+			})
+		}
+		if err = cfgLoader.Start(ctx); err != nil {
+			return err
+		}
+	}
+
 	// Start the global rateLimit if it has been enabled through the config
 	if cfg.EnvoyGateway.RateLimit != nil {
 		// Start the Global RateLimit xDS Server
@@ -206,12 +262,19 @@ func setupRunners(cfg *config.Server) (err error) {
 
 	// Wait until done
 	<-ctx.Done()
+	// Wait for the xDS streams to be drained before closing their connections.
+	<-xdsServerRunner.Drained()
 	// Close messages
 	pResources.Close()
 	xdsIR.Close()
 	infraIR.Close()
 	xds.Close()
 
+	// Flush the spans of the updates handled before the shutdown.
+	if err := shutdownTracing(context.Background()); err != nil {
+		cfg.Logger.Error(err, "failed to flush the spans of the updates")
+	}
+
 	cfg.Logger.Info("shutting down")
 
 	if extMgr != nil {
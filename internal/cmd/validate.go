@@ -0,0 +1,420 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+
+	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/sets"
+	gwapiv1 "sigs.k8s.io/gateway-api/apis/v1"
+	gwapiv1a2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
+	"sigs.k8s.io/yaml"
+
+	egv1a1 "github.com/envoyproxy/gateway/api/v1alpha1"
+	"github.com/envoyproxy/gateway/internal/envoygateway/config"
+	"github.com/envoyproxy/gateway/internal/gatewayapi"
+	"github.com/envoyproxy/gateway/internal/gatewayapi/resource"
+	"github.com/envoyproxy/gateway/internal/infrastructure/kubernetes/ratelimit"
+	"github.com/envoyproxy/gateway/internal/xds/translator"
+)
+
+// Validation stages, in the order they are run.
+const (
+	ValidationStageConfig      = "config"
+	ValidationStageResources   = "resources"
+	ValidationStageTranslation = "translation"
+	ValidationStageXds         = "xds"
+)
+
+// ValidationError is an error found while validating the Envoy Gateway
+// configuration and the Gateway API resources.
+type ValidationError struct {
+	// Stage is the validation stage the error was found in.
+	Stage string `json:"stage"`
+	// File is the file the invalid resource was loaded from.
+	File string `json:"file,omitempty"`
+	// Resource identifies the invalid resource as Kind/namespace/name.
+	Resource string `json:"resource,omitempty"`
+	// Message describes the error.
+	Message string `json:"message"`
+}
+
+// ValidationResult is the output of the validate command.
+type ValidationResult struct {
+	Valid  bool              `json:"valid"`
+	Errors []ValidationError `json:"errors,omitempty"`
+}
+
+type validateOptions struct {
+	resourcesDir        string
+	output              string
+	addMissingResources bool
+}
+
+// getValidateCommand returns the validate cobra command to be executed.
+func getValidateCommand() *cobra.Command {
+	opts := &validateOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "validate",
+		Short: "Validate the Envoy Gateway configuration and Gateway API resources",
+		Long: `Validate the Envoy Gateway configuration and optionally a directory of Gateway API resources.
+The resources are validated and translated to xDS without connecting to a cluster, and the
+command exits with a non-zero code if any error is found.`,
+		Example: `  # Validate the Envoy Gateway configuration.
+  envoy-gateway validate --config-path envoy-gateway.yaml
+
+  # Validate the Envoy Gateway configuration and the Gateway API resources in a directory.
+  envoy-gateway validate --config-path envoy-gateway.yaml --resources-dir ./gateways -o json
+`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return validate(cmd.OutOrStdout(), cfgPath, opts)
+		},
+		SilenceUsage: true,
+	}
+	cmd.Flags().StringVarP(&cfgPath, "config-path", "c", "",
+		"The path to the configuration file.")
+	cmd.Flags().StringVarP(&opts.resourcesDir, "resources-dir", "d", "",
+		"The directory of Gateway API resources to validate, walked recursively.")
+	cmd.Flags().StringVarP(&opts.output, "output", "o", "yaml",
+		"One of 'yaml' or 'json'.")
+	cmd.Flags().BoolVarP(&opts.addMissingResources, "add-missing-resources", "", false,
+		"Add dummy Services and Namespaces for the ones referenced but not defined in the resources.")
+
+	return cmd
+}
+
+func validate(w io.Writer, cfgPath string, opts *validateOptions) error {
+	result := runValidate(cfgPath, opts)
+
+	var (
+		out []byte
+		err error
+	)
+	switch opts.output {
+	case "json":
+		out, err = json.MarshalIndent(result, "", "  ")
+	default:
+		out, err = yaml.Marshal(result)
+	}
+	if err != nil {
+		return err
+	}
+	if _, err := fmt.Fprintln(w, string(out)); err != nil {
+		return err
+	}
+
+	if !result.Valid {
+		return fmt.Errorf("validation failed with %d errors", len(result.Errors))
+	}
+	return nil
+}
+
+func runValidate(cfgPath string, opts *validateOptions) *ValidationResult {
+	v := &validator{}
+
+	cfg, err := loadConfig(cfgPath)
+	if err != nil {
+		v.add(ValidationError{Stage: ValidationStageConfig, File: cfgPath, Message: err.Error()})
+	}
+
+	if cfg != nil && opts.resourcesDir != "" {
+		v.validateResources(cfg, opts)
+	}
+
+	return &ValidationResult{
+		Valid:  len(v.errors) == 0,
+		Errors: v.errors,
+	}
+}
+
+// loadConfig loads the config the same way as getConfigByPath, without
+// logging to stdout so that the output can be parsed.
+func loadConfig(cfgPath string) (*config.Server, error) {
+	cfg, err := config.New()
+	if err != nil {
+		return nil, err
+	}
+
+	if cfgPath != "" {
+		eg, err := config.Decode(cfgPath)
+		if err != nil {
+			return nil, err
+		}
+		eg.SetEnvoyGatewayDefaults()
+		eg.Logging.SetEnvoyGatewayLoggingDefaults()
+		cfg.EnvoyGateway = eg
+	}
+
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
+
+type validator struct {
+	errors []ValidationError
+}
+
+func (v *validator) add(err ValidationError) {
+	v.errors = append(v.errors, err)
+}
+
+// validateResources validates the resources in each file, and translates
+// all the resources together if they are valid.
+func (v *validator) validateResources(cfg *config.Server, opts *validateOptions) {
+	var all [][]byte
+	providedNamespaces, requiredNamespaces := sets.New[string](), sets.New[string]()
+	err := filepath.WalkDir(opts.resourcesDir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		switch filepath.Ext(path) {
+		case ".yaml", ".yml", ".json":
+		default:
+			return nil
+		}
+
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		return resource.IterYAMLBytes(data, func(doc []byte) error {
+			if len(bytes.TrimSpace(doc)) == 0 {
+				return nil
+			}
+			if _, err := resource.LoadResourcesFromYAMLBytes(doc, false); err != nil {
+				v.add(ValidationError{Stage: ValidationStageResources, File: path, Resource: resourceOf(doc), Message: err.Error()})
+				return nil
+			}
+			obj := objectOf(doc)
+			if obj.Kind == "Namespace" {
+				providedNamespaces.Insert(obj.Name)
+			} else if obj.Namespace != "" {
+				requiredNamespaces.Insert(obj.Namespace)
+			}
+			all = append(all, doc)
+			return nil
+		})
+	})
+	if err != nil {
+		v.add(ValidationError{Stage: ValidationStageResources, File: opts.resourcesDir, Message: err.Error()})
+		return
+	}
+	if len(v.errors) > 0 {
+		return
+	}
+
+	// Namespaces are rarely kept alongside the resources, but are
+	// required to attach routes to listeners.
+	for _, ns := range sets.List(requiredNamespaces.Difference(providedNamespaces)) {
+		all = append(all, []byte(fmt.Sprintf("apiVersion: v1\nkind: Namespace\nmetadata:\n  name: %s\n", ns)))
+	}
+
+	resources, err := resource.LoadResourcesFromYAMLBytes(bytes.Join(all, []byte("\n---\n")), opts.addMissingResources)
+	if err != nil {
+		v.add(ValidationError{Stage: ValidationStageResources, File: opts.resourcesDir, Message: err.Error()})
+		return
+	}
+
+	v.translate(cfg, resources)
+}
+
+// translate runs a dry translation of the resources, mirroring the
+// translation done by the gateway-api and xds-translator runners.
+func (v *validator) translate(cfg *config.Server, resources *resource.Resources) {
+	eg := cfg.EnvoyGateway
+	if resources.GatewayClass == nil {
+		v.add(ValidationError{Stage: ValidationStageTranslation, Message: "no GatewayClass found in the resources"})
+		return
+	}
+	controllerName := string(resources.GatewayClass.Spec.ControllerName)
+	if !slices.Contains(eg.Gateway.ControllerNames(), controllerName) {
+		v.add(ValidationError{
+			Stage:    ValidationStageTranslation,
+			Resource: "GatewayClass/" + resources.GatewayClass.Name,
+			Message:  fmt.Sprintf("controllerName %s does not match any controllerName of Envoy Gateway", controllerName),
+		})
+		return
+	}
+
+	t := &gatewayapi.Translator{
+		GatewayControllerName:   controllerName,
+		GatewayClassName:        gwapiv1.ObjectName(resources.GatewayClass.Name),
+		GlobalRateLimitEnabled:  eg.RateLimit != nil,
+		EnvoyPatchPolicyEnabled: eg.ExtensionAPIs != nil && eg.ExtensionAPIs.EnableEnvoyPatchPolicy,
+		BackendEnabled:          eg.ExtensionAPIs != nil && eg.ExtensionAPIs.EnableBackend,
+		Namespace:               cfg.Namespace,
+		MergeGateways:           gatewayapi.IsMergeGatewaysEnabled(resources),
+		HostnameDelegation:      eg.HostnameDelegation,
+		XdsServerPort:           eg.Gateway.XdsServerPort(controllerName),
+		FeatureGates:            eg.ResolvedFeatureGates(),
+	}
+	if eg.ExtensionManager != nil {
+		for _, gvk := range eg.ExtensionManager.Resources {
+			t.ExtensionGroupKinds = append(t.ExtensionGroupKinds, schema.GroupKind{Group: gvk.Group, Kind: gvk.Kind})
+		}
+	}
+
+	result, err := t.Translate(resources)
+	if err != nil {
+		v.add(ValidationError{Stage: ValidationStageTranslation, Message: err.Error()})
+	}
+	if result == nil {
+		return
+	}
+	v.addStatusErrors(result)
+
+	keys := make([]string, 0, len(result.XdsIR))
+	for key := range result.XdsIR {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		xdsIR := result.XdsIR[key]
+		if err := xdsIR.Validate(); err != nil {
+			v.add(ValidationError{Stage: ValidationStageXds, Resource: key, Message: err.Error()})
+			continue
+		}
+
+		xt := &translator.Translator{
+			FilterOrder: xdsIR.FilterOrder,
+		}
+		if eg.RateLimit != nil {
+			xt.GlobalRateLimit = &translator.GlobalRateLimitSettings{
+				ServiceURL: ratelimit.GetServiceURL(cfg.Namespace, cfg.DNSDomain),
+				FailClosed: eg.RateLimit.FailClosed,
+			}
+		}
+		xdsResult, err := xt.Translate(xdsIR)
+		if err != nil {
+			v.add(ValidationError{Stage: ValidationStageXds, Resource: key, Message: err.Error()})
+		}
+		if xdsResult == nil {
+			continue
+		}
+		for _, status := range xdsResult.EnvoyPatchPolicyStatuses {
+			for _, ancestor := range status.Status.Ancestors {
+				v.addConditionErrors(ValidationStageXds, egv1a1.KindEnvoyPatchPolicy, status.Namespace, status.Name, ancestor.Conditions)
+			}
+		}
+	}
+}
+
+// addStatusErrors adds an error for every condition reporting an invalid
+// resource in the statuses computed by the translation.
+func (v *validator) addStatusErrors(result *gatewayapi.TranslateResult) {
+	for _, gw := range result.Gateways {
+		// The Programmed condition is skipped since no infrastructure is
+		// provisioned for a dry translation.
+		v.addConditionErrors(ValidationStageTranslation, resource.KindGateway, gw.Namespace, gw.Name, gw.Status.Conditions)
+		for _, l := range gw.Status.Listeners {
+			v.addConditionErrors(ValidationStageTranslation, resource.KindGateway, gw.Namespace, gw.Name+"/"+string(l.Name), l.Conditions)
+		}
+	}
+
+	addRouteErrors := func(kind string, obj metav1.Object, parents []gwapiv1.RouteParentStatus) {
+		for _, parent := range parents {
+			v.addConditionErrors(ValidationStageTranslation, kind, obj.GetNamespace(), obj.GetName(), parent.Conditions)
+		}
+	}
+	for _, r := range result.HTTPRoutes {
+		addRouteErrors(resource.KindHTTPRoute, r, r.Status.Parents)
+	}
+	for _, r := range result.GRPCRoutes {
+		addRouteErrors(resource.KindGRPCRoute, r, r.Status.Parents)
+	}
+	for _, r := range result.TLSRoutes {
+		addRouteErrors(resource.KindTLSRoute, r, r.Status.Parents)
+	}
+	for _, r := range result.TCPRoutes {
+		addRouteErrors(resource.KindTCPRoute, r, r.Status.Parents)
+	}
+	for _, r := range result.UDPRoutes {
+		addRouteErrors(resource.KindUDPRoute, r, r.Status.Parents)
+	}
+
+	addPolicyErrors := func(kind string, obj metav1.Object, status gwapiv1a2.PolicyStatus) {
+		for _, ancestor := range status.Ancestors {
+			v.addConditionErrors(ValidationStageTranslation, kind, obj.GetNamespace(), obj.GetName(), ancestor.Conditions)
+		}
+	}
+	for _, p := range result.ClientTrafficPolicies {
+		addPolicyErrors(egv1a1.KindClientTrafficPolicy, p, p.Status)
+	}
+	for _, p := range result.BackendTrafficPolicies {
+		addPolicyErrors(egv1a1.KindBackendTrafficPolicy, p, p.Status)
+	}
+	for _, p := range result.SecurityPolicies {
+		addPolicyErrors(egv1a1.KindSecurityPolicy, p, p.Status)
+	}
+	for _, p := range result.EnvoyExtensionPolicies {
+		addPolicyErrors(egv1a1.KindEnvoyExtensionPolicy, p, p.Status)
+	}
+	for _, p := range result.BackendTLSPolicies {
+		addPolicyErrors(resource.KindBackendTLSPolicy, p, p.Status)
+	}
+}
+
+// addConditionErrors adds an error for every condition reporting an invalid resource.
+func (v *validator) addConditionErrors(stage, kind, namespace, name string, conditions []metav1.Condition) {
+	for _, cond := range conditions {
+		invalid := false
+		switch cond.Type {
+		case string(gwapiv1.RouteConditionAccepted), string(gwapiv1.RouteConditionResolvedRefs):
+			invalid = cond.Status == metav1.ConditionFalse
+		case string(gwapiv1.ListenerConditionConflicted):
+			invalid = cond.Status == metav1.ConditionTrue
+		}
+		if !invalid {
+			continue
+		}
+
+		v.add(ValidationError{
+			Stage:    stage,
+			Resource: kind + "/" + namespace + "/" + name,
+			Message:  fmt.Sprintf("%s=%s (%s): %s", cond.Type, cond.Status, cond.Reason, cond.Message),
+		})
+	}
+}
+
+type object struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata"`
+}
+
+// objectOf returns the type and object metadata of the resource in a YAML document.
+func objectOf(doc []byte) *object {
+	obj := &object{}
+	_ = yaml.Unmarshal(doc, obj)
+	return obj
+}
+
+// resourceOf returns the Kind/namespace/name of the resource in a YAML document,
+// or an empty string if it can't be parsed.
+func resourceOf(doc []byte) string {
+	obj := objectOf(doc)
+	switch {
+	case obj.Kind == "":
+		return ""
+	case obj.Namespace == "":
+		return obj.Kind + "/" + obj.Name
+	default:
+		return obj.Kind + "/" + obj.Namespace + "/" + obj.Name
+	}
+}
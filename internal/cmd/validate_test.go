@@ -0,0 +1,84 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package cmd
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidate(t *testing.T) {
+	validConfigPath := filepath.Join(t.TempDir(), "valid.yaml")
+	require.NoError(t, os.WriteFile(validConfigPath, []byte(validGatewayConfig), 0o600))
+	invalidConfigPath := filepath.Join(t.TempDir(), "invalid.yaml")
+	require.NoError(t, os.WriteFile(invalidConfigPath, []byte(invalidGatewayConfig), 0o600))
+
+	tests := []struct {
+		name         string
+		cfgPath      string
+		resourcesDir string
+		errors       []ValidationError
+	}{
+		{
+			name:    "valid config",
+			cfgPath: validConfigPath,
+		},
+		{
+			name:    "invalid config",
+			cfgPath: invalidConfigPath,
+			errors: []ValidationError{{
+				Stage:   ValidationStageConfig,
+				File:    invalidConfigPath,
+				Message: "gateway controllerName is unspecified",
+			}},
+		},
+		{
+			name:         "valid resources",
+			cfgPath:      validConfigPath,
+			resourcesDir: "testdata/validate/valid",
+		},
+		{
+			name:         "invalid resources",
+			cfgPath:      validConfigPath,
+			resourcesDir: "testdata/validate/schema",
+			errors: []ValidationError{{
+				Stage:    ValidationStageResources,
+				File:     "testdata/validate/schema/gateway.yaml",
+				Resource: "Gateway/default/eg",
+				Message:  `local validation error: Gateway.gateway.networking.k8s.io "eg" is invalid: spec.listeners[0].port: Invalid value: 88888: spec.listeners[0].port in body should be less than or equal to 65535`,
+			}},
+		},
+		{
+			name:         "unresolved backend",
+			cfgPath:      validConfigPath,
+			resourcesDir: "testdata/validate/invalid",
+			errors: []ValidationError{{
+				Stage:    ValidationStageTranslation,
+				Resource: "HTTPRoute/default/backend",
+				Message:  "ResolvedRefs=False (BackendNotFound): Service default/backend not found",
+			}},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			result := runValidate(tc.cfgPath, &validateOptions{resourcesDir: tc.resourcesDir})
+			require.Equal(t, tc.errors, result.Errors)
+			require.Equal(t, len(tc.errors) == 0, result.Valid)
+		})
+	}
+}
+
+func TestValidateOutput(t *testing.T) {
+	var out bytes.Buffer
+	err := validate(&out, "", &validateOptions{resourcesDir: "testdata/validate/invalid", output: "json"})
+	require.EqualError(t, err, "validation failed with 1 errors")
+	require.Contains(t, out.String(), `"valid": false`)
+}
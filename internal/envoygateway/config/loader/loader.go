@@ -0,0 +1,199 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package loader
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"sync"
+
+	"github.com/fsnotify/fsnotify"
+
+	egv1a1 "github.com/envoyproxy/gateway/api/v1alpha1"
+	"github.com/envoyproxy/gateway/api/v1alpha1/validation"
+	"github.com/envoyproxy/gateway/internal/envoygateway/config"
+	"github.com/envoyproxy/gateway/internal/logging"
+)
+
+// ReloadFunc applies a changed EnvoyGateway configuration at runtime.
+// It returns an error if the change can't be applied without a restart.
+type ReloadFunc func(eg *egv1a1.EnvoyGateway) error
+
+// Result is the outcome of a configuration reload.
+type Result struct {
+	// Applied holds the fields that were applied at runtime.
+	Applied []string
+	// RequiresRestart holds the fields that changed but only take effect after a restart.
+	RequiresRestart []string
+}
+
+// Loader watches the EnvoyGateway configuration file and applies the changed
+// fields that have a registered ReloadFunc. Changes to all other fields are
+// reported as requiring a restart.
+type Loader struct {
+	cfgPath   string
+	logger    logging.Logger
+	reloaders map[string]ReloadFunc
+
+	mu sync.Mutex
+	// current is the configuration that is in effect.
+	current *egv1a1.EnvoyGateway
+	// data is the content of the configuration file that was last loaded.
+	data []byte
+}
+
+// New returns a Loader for the configuration file at cfgPath, which
+// was used to create cfg.
+func New(cfgPath string, cfg *config.Server) *Loader {
+	data, _ := os.ReadFile(cfgPath)
+	current := cfg.EnvoyGateway.DeepCopy()
+	setDefaults(current)
+
+	return &Loader{
+		cfgPath:   cfgPath,
+		logger:    cfg.Logger.WithName("config-loader"),
+		reloaders: make(map[string]ReloadFunc),
+		current:   current,
+		data:      data,
+	}
+}
+
+// setDefaults sets the defaults that are set on the configuration while
+// Envoy Gateway is running, so that they aren't reported as changes.
+func setDefaults(eg *egv1a1.EnvoyGateway) {
+	eg.SetEnvoyGatewayDefaults()
+	eg.Logging.SetEnvoyGatewayLoggingDefaults()
+	eg.GetEnvoyGatewayAdmin()
+	eg.GetEnvoyGatewayTelemetry()
+	eg.GetEnvoyGatewayProvider().GetEnvoyGatewayKubeProvider()
+}
+
+// Register registers the ReloadFunc applying changes to the field, which is
+// the JSON name of an EnvoyGatewaySpec field such as "logging".
+func (l *Loader) Register(field string, reload ReloadFunc) {
+	l.reloaders[field] = reload
+}
+
+// Start watches the configuration file until the context is done.
+func (l *Loader) Start(ctx context.Context) error {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return err
+	}
+
+	// Watch the parent directory instead of the file, since the file is
+	// replaced rather than written to when mounted from a ConfigMap.
+	if err := watcher.Add(filepath.Dir(l.cfgPath)); err != nil {
+		watcher.Close()
+		return err
+	}
+
+	go func() {
+		defer watcher.Close()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case _, ok := <-watcher.Events:
+				if !ok {
+					return
+				}
+				if !l.fileChanged() {
+					continue
+				}
+				if _, err := l.Reload(); err != nil {
+					l.logger.Error(err, "failed to reload config", "path", l.cfgPath)
+				}
+			case err, ok := <-watcher.Errors:
+				if !ok {
+					return
+				}
+				l.logger.Error(err, "failed to watch config", "path", l.cfgPath)
+			}
+		}
+	}()
+
+	return nil
+}
+
+// fileChanged returns true if the content of the configuration file differs
+// from the content that was last loaded.
+func (l *Loader) fileChanged() bool {
+	data, err := os.ReadFile(l.cfgPath)
+	if err != nil {
+		return false
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	return !bytes.Equal(data, l.data)
+}
+
+// Reload loads the configuration file and applies the changed fields.
+func (l *Loader) Reload() (*Result, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	data, err := os.ReadFile(l.cfgPath)
+	if err != nil {
+		return nil, err
+	}
+	eg, err := config.Decode(l.cfgPath)
+	if err != nil {
+		return nil, err
+	}
+	setDefaults(eg)
+	if err := validation.ValidateEnvoyGateway(eg); err != nil {
+		return nil, err
+	}
+	l.data = data
+
+	result := &Result{}
+	current := reflect.ValueOf(&l.current.EnvoyGatewaySpec).Elem()
+	desired := reflect.ValueOf(&eg.EnvoyGatewaySpec).Elem()
+	for i := 0; i < current.NumField(); i++ {
+		if reflect.DeepEqual(current.Field(i).Interface(), desired.Field(i).Interface()) {
+			continue
+		}
+
+		field := jsonName(current.Type().Field(i))
+		reload, ok := l.reloaders[field]
+		if !ok {
+			result.RequiresRestart = append(result.RequiresRestart, field)
+			continue
+		}
+		if err := reload(eg); err != nil {
+			l.logger.Error(err, "failed to apply config change", "field", field)
+			result.RequiresRestart = append(result.RequiresRestart, field)
+			continue
+		}
+
+		current.Field(i).Set(desired.Field(i))
+		result.Applied = append(result.Applied, field)
+	}
+
+	if len(result.Applied) > 0 {
+		l.logger.Info("applied config changes", "fields", result.Applied)
+	}
+	if len(result.RequiresRestart) > 0 {
+		l.logger.Info("config changes require a restart to take effect", "fields", result.RequiresRestart)
+	}
+
+	return result, nil
+}
+
+func jsonName(field reflect.StructField) string {
+	name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+	if name == "" {
+		return field.Name
+	}
+	return name
+}
@@ -0,0 +1,115 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package loader
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	egv1a1 "github.com/envoyproxy/gateway/api/v1alpha1"
+	"github.com/envoyproxy/gateway/internal/envoygateway/config"
+)
+
+const baseConfig = `apiVersion: gateway.envoyproxy.io/v1alpha1
+kind: EnvoyGateway
+gateway:
+  controllerName: gateway.envoyproxy.io/gatewayclass-controller
+provider:
+  type: Kubernetes
+`
+
+func newTestLoader(t *testing.T) (*Loader, string) {
+	t.Helper()
+
+	cfgPath := filepath.Join(t.TempDir(), "envoy-gateway.yaml")
+	require.NoError(t, os.WriteFile(cfgPath, []byte(baseConfig), 0o600))
+
+	cfg, err := config.New()
+	require.NoError(t, err)
+	cfg.EnvoyGateway, err = config.Decode(cfgPath)
+	require.NoError(t, err)
+	cfg.EnvoyGateway.SetEnvoyGatewayDefaults()
+
+	return New(cfgPath, cfg), cfgPath
+}
+
+func TestReload(t *testing.T) {
+	loader, cfgPath := newTestLoader(t)
+
+	var logging *egv1a1.EnvoyGatewayLogging
+	loader.Register("logging", func(eg *egv1a1.EnvoyGateway) error {
+		logging = eg.Logging
+		return nil
+	})
+
+	// Unchanged config.
+	result, err := loader.Reload()
+	require.NoError(t, err)
+	require.Empty(t, result.Applied)
+	require.Empty(t, result.RequiresRestart)
+
+	require.NoError(t, os.WriteFile(cfgPath, []byte(baseConfig+`logging:
+  level:
+    default: debug
+admin:
+  enablePprof: true
+`), 0o600))
+
+	result, err = loader.Reload()
+	require.NoError(t, err)
+	require.Equal(t, []string{"logging"}, result.Applied)
+	require.Equal(t, []string{"admin"}, result.RequiresRestart)
+	require.Equal(t, egv1a1.LogLevelDebug, logging.Level[egv1a1.LogComponentGatewayDefault])
+
+	// Applied changes aren't reported again.
+	result, err = loader.Reload()
+	require.NoError(t, err)
+	require.Empty(t, result.Applied)
+	require.Equal(t, []string{"admin"}, result.RequiresRestart)
+}
+
+func TestReloadInvalidConfig(t *testing.T) {
+	loader, cfgPath := newTestLoader(t)
+
+	require.NoError(t, os.WriteFile(cfgPath, []byte(baseConfig+`logging:
+  level:
+    default: verbose
+`), 0o600))
+
+	_, err := loader.Reload()
+	require.Error(t, err)
+}
+
+func TestStart(t *testing.T) {
+	loader, cfgPath := newTestLoader(t)
+
+	reloaded := make(chan *egv1a1.EnvoyGateway, 1)
+	loader.Register("logging", func(eg *egv1a1.EnvoyGateway) error {
+		reloaded <- eg
+		return nil
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	require.NoError(t, loader.Start(ctx))
+
+	require.NoError(t, os.WriteFile(cfgPath, []byte(baseConfig+`logging:
+  level:
+    default: warn
+`), 0o600))
+
+	select {
+	case eg := <-reloaded:
+		require.Equal(t, egv1a1.LogLevelWarn, eg.Logging.Level[egv1a1.LogComponentGatewayDefault])
+	case <-time.After(5 * time.Second):
+		t.Fatal("config was not reloaded")
+	}
+}
@@ -11,6 +11,8 @@ import (
 	"errors"
 	"fmt"
 	"net"
+	"reflect"
+	"sync"
 
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials"
@@ -45,8 +47,12 @@ const grpcServiceConfig = `{
 var _ extTypes.Manager = (*Manager)(nil)
 
 type Manager struct {
-	k8sClient          k8scli.Client
-	namespace          string
+	k8sClient k8scli.Client
+	namespace string
+
+	// mu guards extension and extensionConnCache, which can be
+	// updated at runtime.
+	mu                 sync.Mutex
 	extension          egv1a1.ExtensionManager
 	extensionConnCache *grpc.ClientConn
 }
@@ -109,7 +115,9 @@ func NewInMemoryManager(cfg egv1a1.ExtensionManager, server extension.EnvoyGatew
 // HasExtension checks to see whether a given Group and Kind has an
 // associated extension registered for it.
 func (m *Manager) HasExtension(g gwapiv1.Group, k gwapiv1.Kind) bool {
+	m.mu.Lock()
 	extension := m.extension
+	m.mu.Unlock()
 	// TODO: not currently checking the version since extensionRef only supports group and kind.
 	for _, gvk := range extension.Resources {
 		if g == gwapiv1.Group(gvk.Group) && k == gwapiv1.Kind(gvk.Kind) {
@@ -139,6 +147,9 @@ func getExtensionServerAddress(service *egv1a1.ExtensionService) string {
 // If the extension makes use of the hook then the XDS Hook Client is returned. If it does not support
 // the hook type then nil is returned
 func (m *Manager) GetPreXDSHookClient(xdsHookType egv1a1.XDSTranslatorHook) extTypes.XDSHookClient {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
 	ctx := context.Background()
 	ext := m.extension
 
@@ -188,6 +199,9 @@ func (m *Manager) GetPreXDSHookClient(xdsHookType egv1a1.XDSTranslatorHook) extT
 // If the extension makes use of the hook then the XDS Hook Client is returned. If it does not support
 // the hook type then nil is returned
 func (m *Manager) GetPostXDSHookClient(xdsHookType egv1a1.XDSTranslatorHook) extTypes.XDSHookClient {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
 	ctx := context.Background()
 	ext := m.extension
 
@@ -233,11 +247,40 @@ func (m *Manager) GetPostXDSHookClient(xdsHookType egv1a1.XDSTranslatorHook) ext
 }
 
 func (m *Manager) CleanupHookConns() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
 	if m.extensionConnCache != nil {
 		m.extensionConnCache.Close()
 	}
 }
 
+// UpdateExtension updates the hooks and service of the registered extension at runtime.
+// The resources handled by the extension can't be changed since the provider only
+// watches the resources it was started with.
+func (m *Manager) UpdateExtension(extension *egv1a1.ExtensionManager) error {
+	if extension == nil {
+		extension = &egv1a1.ExtensionManager{}
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if !reflect.DeepEqual(m.extension.Resources, extension.Resources) ||
+		!reflect.DeepEqual(m.extension.PolicyResources, extension.PolicyResources) {
+		return errors.New("extension resources can't be changed at runtime")
+	}
+
+	// Reconnect to the extension service on the next hook call if it changed.
+	if !reflect.DeepEqual(m.extension.Service, extension.Service) && m.extensionConnCache != nil {
+		m.extensionConnCache.Close()
+		m.extensionConnCache = nil
+	}
+	m.extension = *extension
+
+	return nil
+}
+
 func parseCA(caSecret *corev1.Secret) (*x509.CertPool, error) {
 	caCertPEMBytes, ok := caSecret.Data[corev1.TLSCertKey]
 	if !ok {
@@ -71,3 +71,26 @@ func TestGetExtensionServerAddress(t *testing.T) {
 		})
 	}
 }
+
+func TestUpdateExtension(t *testing.T) {
+	m := &Manager{
+		extension: egv1a1.ExtensionManager{
+			Resources: []egv1a1.GroupVersionKind{{Group: "foo.example.io", Version: "v1alpha1", Kind: "Foo"}},
+			Hooks: &egv1a1.ExtensionHooks{
+				XDSTranslator: &egv1a1.XDSTranslatorHooks{Post: []egv1a1.XDSTranslatorHook{egv1a1.XDSRoute}},
+			},
+		},
+	}
+
+	// Hooks can be changed at runtime.
+	updated := m.extension.DeepCopy()
+	updated.Hooks.XDSTranslator.Post = []egv1a1.XDSTranslatorHook{egv1a1.XDSRoute, egv1a1.XDSHTTPListener}
+	require.NoError(t, m.UpdateExtension(updated))
+	require.Equal(t, *updated, m.extension)
+
+	// Resources can't be changed at runtime.
+	updated = m.extension.DeepCopy()
+	updated.Resources = nil
+	require.Error(t, m.UpdateExtension(updated))
+	require.Len(t, m.extension.Resources, 1)
+}
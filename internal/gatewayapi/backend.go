@@ -40,6 +40,9 @@ func (t *Translator) ProcessBackends(backends []*egv1a1.Backend) []*egv1a1.Backe
 }
 
 func validateBackend(backend *egv1a1.Backend) error {
+	if backend.Spec.OriginalDestination != nil && len(backend.Spec.Endpoints) > 0 {
+		return fmt.Errorf("endpoints cannot be specified with originalDestination")
+	}
 	for _, ep := range backend.Spec.Endpoints {
 		if ep.FQDN != nil {
 			hostname := ep.FQDN.Hostname
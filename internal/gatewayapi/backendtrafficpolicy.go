@@ -10,7 +10,9 @@ import (
 	"fmt"
 	"math"
 	"sort"
+	"strconv"
 	"strings"
+	"time"
 
 	perr "github.com/pkg/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -29,11 +31,16 @@ import (
 
 const (
 	MaxConsistentHashTableSize = 5000011 // https://www.envoyproxy.io/docs/envoy/latest/api-v3/config/cluster/v3/cluster.proto#config-cluster-v3-cluster-maglevlbconfig
+
+	// responseBodyKey is the key of the ConfigMap data holding the body of a custom
+	// response.
+	responseBodyKey = "response.body"
 )
 
 func (t *Translator) ProcessBackendTrafficPolicies(backendTrafficPolicies []*egv1a1.BackendTrafficPolicy,
 	gateways []*GatewayContext,
 	routes []RouteContext,
+	resources *resource.Resources,
 	xdsIR resource.XdsIRMap,
 ) []*egv1a1.BackendTrafficPolicy {
 	res := []*egv1a1.BackendTrafficPolicy{}
@@ -128,7 +135,7 @@ func (t *Translator) ProcessBackendTrafficPolicies(backendTrafficPolicies []*egv
 				}
 
 				// Set conditions for translation error if it got any
-				if err := t.translateBackendTrafficPolicyForRoute(policy, route, xdsIR); err != nil {
+				if err := t.translateBackendTrafficPolicyForRoute(policy, route, resources, xdsIR); err != nil {
 					status.SetTranslationErrorForPolicyAncestors(&policy.Status,
 						ancestorRefs,
 						t.GatewayControllerName,
@@ -182,7 +189,7 @@ func (t *Translator) ProcessBackendTrafficPolicies(backendTrafficPolicies []*egv
 				}
 
 				// Set conditions for translation error if it got any
-				if err := t.translateBackendTrafficPolicyForGateway(policy, currTarget, gateway, xdsIR); err != nil {
+				if err := t.translateBackendTrafficPolicyForGateway(policy, currTarget, gateway, resources, xdsIR); err != nil {
 					status.SetTranslationErrorForPolicyAncestors(&policy.Status,
 						ancestorRefs,
 						t.GatewayControllerName,
@@ -282,7 +289,7 @@ func resolveBTPolicyRouteTargetRef(policy *egv1a1.BackendTrafficPolicy, target g
 	return route.RouteContext, nil
 }
 
-func (t *Translator) translateBackendTrafficPolicyForRoute(policy *egv1a1.BackendTrafficPolicy, route RouteContext, xdsIR resource.XdsIRMap) error {
+func (t *Translator) translateBackendTrafficPolicyForRoute(policy *egv1a1.BackendTrafficPolicy, route RouteContext, resources *resource.Resources, xdsIR resource.XdsIRMap) error {
 	var (
 		rl        *ir.RateLimit
 		lb        *ir.LoadBalancer
@@ -296,6 +303,12 @@ func (t *Translator) translateBackendTrafficPolicyForRoute(policy *egv1a1.Backen
 		bc        *ir.BackendConnection
 		ds        *ir.DNS
 		h2        *ir.HTTP2Settings
+		ex        *ir.Experiment
+		hm        *ir.HeaderToMetadata
+		ro        *ir.ResponseOverride
+		rb        *ir.ResponseBuffer
+		rs        *ir.RequestSigning
+		ci        *ir.CredentialInjection
 		err, errs error
 	)
 
@@ -326,6 +339,33 @@ func (t *Translator) translateBackendTrafficPolicyForRoute(policy *egv1a1.Backen
 	if policy.Spec.Retry != nil {
 		rt = t.buildRetry(policy)
 	}
+	hm = buildHeaderToMetadata(policy.Spec.HeaderToMetadata)
+	if ro, err = buildResponseOverride(policy, resources); err != nil {
+		err = perr.WithMessage(err, "ResponseOverride")
+		errs = errors.Join(errs, err)
+	}
+	if rb, err = buildResponseBuffer(policy.Spec.ResponseBuffer); err != nil {
+		err = perr.WithMessage(err, "ResponseBuffer")
+		errs = errors.Join(errs, err)
+	}
+	// The destinations of the signing service and of the buckets of the experiment are
+	// shared by all the parent Gateways of the route, so they are built with the EnvoyProxy
+	// of the first one.
+	var envoyProxy *egv1a1.EnvoyProxy
+	for _, p := range GetParentReferences(route) {
+		if gtwCtx := GetRouteParentContext(route, p).GetGateway(); gtwCtx != nil {
+			envoyProxy = gtwCtx.envoyProxy
+			break
+		}
+	}
+	if rs, err = t.buildRequestSigning(policy, resources, envoyProxy); err != nil {
+		err = perr.WithMessage(err, "RequestSigning")
+		errs = errors.Join(errs, err)
+	}
+	if ci, err = t.buildCredentialInjection(policy, resources); err != nil {
+		err = perr.WithMessage(err, "CredentialInjection")
+		errs = errors.Join(errs, err)
+	}
 	if to, err = buildClusterSettingsTimeout(policy.Spec.ClusterSettings, nil); err != nil {
 		err = perr.WithMessage(err, "Timeout")
 		errs = errors.Join(errs, err)
@@ -343,13 +383,20 @@ func (t *Translator) translateBackendTrafficPolicyForRoute(policy *egv1a1.Backen
 
 	ds = translateDNS(policy.Spec.ClusterSettings)
 
+	if policy.Spec.Experiment != nil {
+		if ex, err = t.buildExperiment(policy, resources, envoyProxy); err != nil {
+			err = perr.WithMessage(err, "Experiment")
+			errs = errors.Join(errs, err)
+		}
+	}
+
 	// Apply IR to all relevant routes
 	prefix := irRoutePrefix(route)
 
 	for _, x := range xdsIR {
 		for _, tcp := range x.TCP {
 			for _, r := range tcp.Routes {
-				if strings.HasPrefix(r.Destination.Name, prefix) {
+				if r.Name == irTCPRouteName(route) {
 					r.LoadBalancer = lb
 					r.ProxyProtocol = pp
 					r.HealthCheck = hc
@@ -366,7 +413,7 @@ func (t *Translator) translateBackendTrafficPolicyForRoute(policy *egv1a1.Backen
 			if udp.Route != nil {
 				r := udp.Route
 
-				if strings.HasPrefix(r.Destination.Name, prefix) {
+				if r.Name == irUDPRouteName(route) {
 					r.LoadBalancer = lb
 					r.DNS = ds
 				}
@@ -391,18 +438,24 @@ func (t *Translator) translateBackendTrafficPolicyForRoute(policy *egv1a1.Backen
 					}
 
 					r.Traffic = &ir.TrafficFeatures{
-						RateLimit:         rl,
-						LoadBalancer:      lb,
-						ProxyProtocol:     pp,
-						HealthCheck:       hc,
-						CircuitBreaker:    cb,
-						FaultInjection:    fi,
-						TCPKeepalive:      ka,
-						Retry:             rt,
-						BackendConnection: bc,
-						HTTP2:             h2,
-						DNS:               ds,
-						Timeout:           to,
+						RateLimit:           rl,
+						LoadBalancer:        lb,
+						ProxyProtocol:       pp,
+						HealthCheck:         hc,
+						CircuitBreaker:      cb,
+						FaultInjection:      fi,
+						TCPKeepalive:        ka,
+						Retry:               rt,
+						BackendConnection:   bc,
+						HTTP2:               h2,
+						DNS:                 ds,
+						Timeout:             to,
+						Experiment:          ex,
+						HeaderToMetadata:    hm,
+						ResponseOverride:    ro,
+						ResponseBuffer:      rb,
+						RequestSigning:      rs,
+						CredentialInjection: ci,
 					}
 
 					// Update the Host field in HealthCheck, now that we have access to the Route Hostname.
@@ -419,7 +472,7 @@ func (t *Translator) translateBackendTrafficPolicyForRoute(policy *egv1a1.Backen
 	return errs
 }
 
-func (t *Translator) translateBackendTrafficPolicyForGateway(policy *egv1a1.BackendTrafficPolicy, target gwapiv1a2.LocalPolicyTargetReferenceWithSectionName, gateway *GatewayContext, xdsIR resource.XdsIRMap) error {
+func (t *Translator) translateBackendTrafficPolicyForGateway(policy *egv1a1.BackendTrafficPolicy, target gwapiv1a2.LocalPolicyTargetReferenceWithSectionName, gateway *GatewayContext, resources *resource.Resources, xdsIR resource.XdsIRMap) error {
 	var (
 		rl        *ir.RateLimit
 		lb        *ir.LoadBalancer
@@ -432,6 +485,12 @@ func (t *Translator) translateBackendTrafficPolicyForGateway(policy *egv1a1.Back
 		rt        *ir.Retry
 		ds        *ir.DNS
 		h2        *ir.HTTP2Settings
+		ex        *ir.Experiment
+		hm        *ir.HeaderToMetadata
+		ro        *ir.ResponseOverride
+		rb        *ir.ResponseBuffer
+		rs        *ir.RequestSigning
+		ci        *ir.CredentialInjection
 		err, errs error
 	)
 
@@ -462,6 +521,23 @@ func (t *Translator) translateBackendTrafficPolicyForGateway(policy *egv1a1.Back
 	if policy.Spec.Retry != nil {
 		rt = t.buildRetry(policy)
 	}
+	hm = buildHeaderToMetadata(policy.Spec.HeaderToMetadata)
+	if ro, err = buildResponseOverride(policy, resources); err != nil {
+		err = perr.WithMessage(err, "ResponseOverride")
+		errs = errors.Join(errs, err)
+	}
+	if rb, err = buildResponseBuffer(policy.Spec.ResponseBuffer); err != nil {
+		err = perr.WithMessage(err, "ResponseBuffer")
+		errs = errors.Join(errs, err)
+	}
+	if rs, err = t.buildRequestSigning(policy, resources, gateway.envoyProxy); err != nil {
+		err = perr.WithMessage(err, "RequestSigning")
+		errs = errors.Join(errs, err)
+	}
+	if ci, err = t.buildCredentialInjection(policy, resources); err != nil {
+		err = perr.WithMessage(err, "CredentialInjection")
+		errs = errors.Join(errs, err)
+	}
 	if ct, err = buildClusterSettingsTimeout(policy.Spec.ClusterSettings, nil); err != nil {
 		err = perr.WithMessage(err, "Timeout")
 		errs = errors.Join(errs, err)
@@ -473,6 +549,13 @@ func (t *Translator) translateBackendTrafficPolicyForGateway(policy *egv1a1.Back
 
 	ds = translateDNS(policy.Spec.ClusterSettings)
 
+	if policy.Spec.Experiment != nil {
+		if ex, err = t.buildExperiment(policy, resources, gateway.envoyProxy); err != nil {
+			err = perr.WithMessage(err, "Experiment")
+			errs = errors.Join(errs, err)
+		}
+	}
+
 	// Apply IR to all the routes within the specific Gateway
 	// If the feature is already set, then skip it, since it must be have
 	// set by a policy attaching to the route
@@ -543,16 +626,22 @@ func (t *Translator) translateBackendTrafficPolicyForGateway(policy *egv1a1.Back
 			}
 
 			r.Traffic = &ir.TrafficFeatures{
-				RateLimit:      rl,
-				LoadBalancer:   lb,
-				ProxyProtocol:  pp,
-				HealthCheck:    hc,
-				CircuitBreaker: cb,
-				FaultInjection: fi,
-				TCPKeepalive:   ka,
-				Retry:          rt,
-				HTTP2:          h2,
-				DNS:            ds,
+				RateLimit:           rl,
+				LoadBalancer:        lb,
+				ProxyProtocol:       pp,
+				HealthCheck:         hc,
+				CircuitBreaker:      cb,
+				FaultInjection:      fi,
+				TCPKeepalive:        ka,
+				Retry:               rt,
+				HTTP2:               h2,
+				DNS:                 ds,
+				Experiment:          ex,
+				HeaderToMetadata:    hm,
+				ResponseOverride:    ro,
+				ResponseBuffer:      rb,
+				RequestSigning:      rs,
+				CredentialInjection: ci,
 			}
 
 			// Update the Host field in HealthCheck, now that we have access to the Route Hostname.
@@ -571,6 +660,77 @@ func (t *Translator) translateBackendTrafficPolicyForGateway(policy *egv1a1.Back
 	return errs
 }
 
+func (t *Translator) buildExperiment(policy *egv1a1.BackendTrafficPolicy, resources *resource.Resources, envoyProxy *egv1a1.EnvoyProxy) (*ir.Experiment, error) {
+	experiment := policy.Spec.Experiment
+	irExperiment := &ir.Experiment{
+		Cookie: experiment.Cookie,
+		Header: experiment.Header,
+		Salt:   experiment.Salt,
+	}
+	if experiment.CookieTTL != nil {
+		d, err := time.ParseDuration(string(*experiment.CookieTTL))
+		if err != nil {
+			return nil, fmt.Errorf("invalid CookieTTL value %s", *experiment.CookieTTL)
+		}
+		irExperiment.CookieTTL = ptr.To(metav1.Duration{Duration: d})
+	}
+
+	var total uint32
+	for _, bucket := range experiment.Buckets {
+		total += bucket.Percentage
+
+		var settings []*ir.DestinationSetting
+		for i := range bucket.BackendRefs {
+			backendRef := &bucket.BackendRefs[i]
+			if err := t.validateExtServiceBackendReference(
+				&backendRef.BackendObjectReference,
+				policy.Namespace,
+				egv1a1.KindBackendTrafficPolicy,
+				resources); err != nil {
+				return nil, fmt.Errorf("bucket %s: %w", bucket.Name, err)
+			}
+
+			ds, err := t.processExtServiceDestination(
+				backendRef,
+				utils.NamespacedName(policy),
+				egv1a1.KindBackendTrafficPolicy,
+				ir.HTTP,
+				resources,
+				envoyProxy,
+			)
+			if err != nil {
+				return nil, fmt.Errorf("bucket %s: %w", bucket.Name, err)
+			}
+			settings = append(settings, ds)
+		}
+
+		irExperiment.Buckets = append(irExperiment.Buckets, &ir.ExperimentBucket{
+			Name:       bucket.Name,
+			Percentage: bucket.Percentage,
+			Destination: &ir.RouteDestination{
+				Name:     irExperimentBucketDestinationName(policy, bucket.Name),
+				Settings: settings,
+			},
+		})
+	}
+	if total != 100 {
+		return nil, fmt.Errorf("the percentages of the buckets add up to %d instead of 100", total)
+	}
+
+	return irExperiment, nil
+}
+
+// irExperimentBucketDestinationName returns the name of the destination of a bucket of
+// the experiment of the policy.
+func irExperimentBucketDestinationName(policy *egv1a1.BackendTrafficPolicy, bucket string) string {
+	return strings.ToLower(fmt.Sprintf(
+		"%s/%s/%s/experiment/%s",
+		egv1a1.KindBackendTrafficPolicy,
+		policy.Namespace,
+		policy.Name,
+		bucket))
+}
+
 func (t *Translator) buildRateLimit(policy *egv1a1.BackendTrafficPolicy) (*ir.RateLimit, error) {
 	switch policy.Spec.RateLimit.Type {
 	case egv1a1.GlobalRateLimitType:
@@ -812,6 +972,347 @@ func (t *Translator) buildFaultInjection(policy *egv1a1.BackendTrafficPolicy) *i
 	return fi
 }
 
+// buildHeaderToMetadata returns the IR of the header to metadata settings, defaulting the
+// metadata namespaces and value types.
+func buildHeaderToMetadata(headerToMetadata *egv1a1.HeaderToMetadata) *ir.HeaderToMetadata {
+	if headerToMetadata == nil {
+		return nil
+	}
+
+	irHeaderToMetadata := &ir.HeaderToMetadata{}
+	for _, header := range headerToMetadata.RequestHeaders {
+		irHeaderToMetadata.RequestHeaders = append(irHeaderToMetadata.RequestHeaders, &ir.RequestHeaderToMetadata{
+			Header:    string(header.Header),
+			OnPresent: buildIRMetadataValue(header.OnPresent),
+			OnMissing: buildIRMetadataValue(header.OnMissing),
+			Remove:    ptr.Deref(header.Remove, false),
+		})
+	}
+	for _, header := range headerToMetadata.ResponseHeaders {
+		irHeaderToMetadata.ResponseHeaders = append(irHeaderToMetadata.ResponseHeaders, &ir.MetadataToResponseHeader{
+			Name:      string(header.Name),
+			Namespace: ptr.Deref(header.Namespace, egv1a1.HeaderToMetadataNamespace),
+			Key:       header.Key,
+		})
+	}
+	return irHeaderToMetadata
+}
+
+// buildResponseBuffer returns the IR of the buffering of the bodies of the responses.
+func buildResponseBuffer(responseBuffer *egv1a1.ResponseBuffer) (*ir.ResponseBuffer, error) {
+	if responseBuffer == nil {
+		return nil, nil
+	}
+
+	limit, ok := responseBuffer.Limit.AsInt64()
+	if !ok || limit <= 0 {
+		return nil, fmt.Errorf("invalid Limit value %s", responseBuffer.Limit.String())
+	}
+
+	return &ir.ResponseBuffer{
+		Limit: uint64(limit),
+		StreamThrough: ptr.Deref(responseBuffer.OnLimitExceeded, egv1a1.ResponseBufferLimitActionReject) ==
+			egv1a1.ResponseBufferLimitActionStreamThrough,
+		InjectContentLength: ptr.Deref(responseBuffer.ChunkedEncoding, egv1a1.ResponseChunkedEncodingPreserve) ==
+			egv1a1.ResponseChunkedEncodingInjectContentLength,
+	}, nil
+}
+
+// buildRequestSigning returns the IR of the signature of the requests of the policy, checking
+// that the Secret of the HMAC signature holds a key.
+func (t *Translator) buildRequestSigning(
+	policy *egv1a1.BackendTrafficPolicy,
+	resources *resource.Resources,
+	envoyProxy *egv1a1.EnvoyProxy,
+) (*ir.RequestSigning, error) {
+	requestSigning := policy.Spec.RequestSigning
+	if requestSigning == nil {
+		return nil, nil
+	}
+
+	switch requestSigning.Type {
+	case egv1a1.RequestSigningTypeAWSSigV4:
+		awsSigV4 := requestSigning.AWSSigV4
+		if awsSigV4 == nil {
+			return nil, errors.New("awsSigV4 must be set for the AWSSigV4 type")
+		}
+		return &ir.RequestSigning{
+			AWSSigV4: &ir.AWSSigV4RequestSigning{
+				Service:         awsSigV4.Service,
+				Region:          awsSigV4.Region,
+				UnsignedPayload: ptr.Deref(awsSigV4.UnsignedPayload, false),
+			},
+		}, nil
+	case egv1a1.RequestSigningTypeHMAC:
+		hmac := requestSigning.HMAC
+		if hmac == nil {
+			return nil, errors.New("hmac must be set for the HMAC type")
+		}
+
+		from := crossNamespaceFrom{
+			group:     egv1a1.GroupName,
+			kind:      resource.KindBackendTrafficPolicy,
+			namespace: policy.Namespace,
+		}
+		keySecret, err := t.validateSecretRef(false, from, hmac.Key, resources)
+		if err != nil {
+			return nil, err
+		}
+		if key, ok := keySecret.Data[egv1a1.RequestSigningHMACSecretKey]; !ok || len(key) == 0 {
+			return nil, fmt.Errorf(
+				"hmac key not found in secret %s/%s",
+				keySecret.Namespace, keySecret.Name)
+		}
+
+		policyNamespacedName := utils.NamespacedName(policy)
+		destination, authority, traffic, err := t.buildExtProcBackend(
+			&hmac.BackendCluster,
+			irIndexedExtServiceDestinationName(policyNamespacedName, egv1a1.KindBackendTrafficPolicy, 0),
+			policyNamespacedName,
+			egv1a1.KindBackendTrafficPolicy,
+			resources,
+			envoyProxy)
+		if err != nil {
+			return nil, err
+		}
+
+		return &ir.RequestSigning{
+			HMAC: &ir.HMACRequestSigning{
+				Name:            irConfigName(policy),
+				Destination:     *destination,
+				Traffic:         traffic,
+				Authority:       authority,
+				KeySecret:       keySecret.Namespace + "/" + keySecret.Name,
+				SignatureHeader: ptr.Deref(hmac.SignatureHeader, "X-Signature"),
+				TimestampHeader: ptr.Deref(hmac.TimestampHeader, "X-Signature-Timestamp"),
+				SignedHeaders:   hmac.SignedHeaders,
+				SignBody:        ptr.Deref(hmac.SignBody, false),
+			},
+		}, nil
+	default:
+		return nil, fmt.Errorf("unsupported request signing type %s", requestSigning.Type)
+	}
+}
+
+// buildCredentialInjection returns the IR of the credentials the policy injects into the
+// requests, reading the bearer token or the OAuth2 client secret from their Secret.
+func (t *Translator) buildCredentialInjection(policy *egv1a1.BackendTrafficPolicy, resources *resource.Resources) (*ir.CredentialInjection, error) {
+	credentialInjection := policy.Spec.CredentialInjection
+	if credentialInjection == nil {
+		return nil, nil
+	}
+
+	from := crossNamespaceFrom{
+		group:     egv1a1.GroupName,
+		kind:      resource.KindBackendTrafficPolicy,
+		namespace: policy.Namespace,
+	}
+	ci := &ir.CredentialInjection{
+		Name:      irConfigName(policy),
+		Overwrite: ptr.Deref(credentialInjection.Overwrite, true),
+	}
+
+	switch credentialInjection.Type {
+	case egv1a1.CredentialInjectionTypeBearerToken:
+		bearerToken := credentialInjection.BearerToken
+		if bearerToken == nil {
+			return nil, errors.New("bearerToken must be set for the BearerToken type")
+		}
+
+		tokenSecret, err := t.validateSecretRef(false, from, bearerToken.Token, resources)
+		if err != nil {
+			return nil, err
+		}
+		token, ok := tokenSecret.Data[egv1a1.CredentialInjectionTokenSecretKey]
+		if !ok || len(token) == 0 {
+			return nil, fmt.Errorf(
+				"token not found in secret %s/%s",
+				tokenSecret.Namespace, tokenSecret.Name)
+		}
+
+		ci.BearerToken = &ir.BearerTokenCredential{
+			Header: ptr.Deref(bearerToken.Header, "Authorization"),
+			Token:  token,
+		}
+	case egv1a1.CredentialInjectionTypeOAuth2ClientCredentials:
+		oauth2 := credentialInjection.OAuth2ClientCredentials
+		if oauth2 == nil {
+			return nil, errors.New("oauth2ClientCredentials must be set for the OAuth2ClientCredentials type")
+		}
+
+		if err := validateTokenEndpoint(oauth2.TokenEndpoint); err != nil {
+			return nil, err
+		}
+		clientSecret, err := t.validateSecretRef(false, from, oauth2.ClientSecret, resources)
+		if err != nil {
+			return nil, err
+		}
+		clientSecretBytes, ok := clientSecret.Data[egv1a1.OIDCClientSecretKey]
+		if !ok || len(clientSecretBytes) == 0 {
+			return nil, fmt.Errorf(
+				"client secret not found in secret %s/%s",
+				clientSecret.Namespace, clientSecret.Name)
+		}
+
+		ci.OAuth2 = &ir.OAuth2ClientCredentials{
+			TokenEndpoint: oauth2.TokenEndpoint,
+			ClientID:      oauth2.ClientID,
+			ClientSecret:  clientSecretBytes,
+			Scopes:        oauth2.Scopes,
+		}
+	default:
+		return nil, fmt.Errorf("unsupported credential injection type %s", credentialInjection.Type)
+	}
+
+	return ci, nil
+}
+
+// buildResponseOverride returns the IR of the custom responses of the policy, the gRPC
+// status mappings coming first.
+func buildResponseOverride(policy *egv1a1.BackendTrafficPolicy, resources *resource.Resources) (*ir.ResponseOverride, error) {
+	var rules []*ir.ResponseOverrideRule
+
+	if mapping := policy.Spec.GRPCStatusMapping; mapping != nil {
+		for i, toHTTP := range mapping.ToHTTP {
+			rules = append(rules, &ir.ResponseOverrideRule{
+				Name: fmt.Sprintf("grpc-to-http-%d", i),
+				Match: ir.CustomResponseMatch{
+					GRPCStatuses: []uint32{toHTTP.GRPCStatus},
+				},
+				Response: ir.CustomResponse{
+					StatusCode: ptr.To(uint32(toHTTP.HTTPStatus)),
+				},
+			})
+		}
+		for i, toGRPC := range mapping.ToGRPC {
+			match, err := buildStatusCodeMatch(toGRPC.HTTPStatus)
+			if err != nil {
+				return nil, err
+			}
+			// The response is a trailers-only gRPC response, whose headers hold the status.
+			headers := []ir.AddHeader{{
+				Name:  "grpc-status",
+				Value: []string{strconv.FormatUint(uint64(toGRPC.GRPCStatus), 10)},
+			}}
+			if toGRPC.Message != nil {
+				headers = append(headers, ir.AddHeader{
+					Name:  "grpc-message",
+					Value: []string{*toGRPC.Message},
+				})
+			}
+			rules = append(rules, &ir.ResponseOverrideRule{
+				Name: fmt.Sprintf("http-to-grpc-%d", i),
+				Match: ir.CustomResponseMatch{
+					StatusCodes: []*ir.StatusCodeMatch{match},
+				},
+				Response: ir.CustomResponse{
+					StatusCode:         ptr.To(uint32(200)),
+					ContentType:        ptr.To("application/grpc"),
+					AddResponseHeaders: headers,
+				},
+			})
+		}
+	}
+
+	for i, override := range policy.Spec.ResponseOverride {
+		if override == nil {
+			continue
+		}
+		rule := &ir.ResponseOverrideRule{
+			Name: fmt.Sprintf("response-override-%d", i),
+			Response: ir.CustomResponse{
+				ContentType: override.Response.ContentType,
+			},
+		}
+		for _, statusCode := range override.Match.StatusCode {
+			match, err := buildStatusCodeMatch(statusCode)
+			if err != nil {
+				return nil, err
+			}
+			rule.Match.StatusCodes = append(rule.Match.StatusCodes, match)
+		}
+		if override.Response.StatusCode != nil {
+			rule.Response.StatusCode = ptr.To(uint32(*override.Response.StatusCode))
+		}
+		body, err := buildCustomResponseBody(override.Response.Body, policy.Namespace, resources)
+		if err != nil {
+			return nil, err
+		}
+		rule.Response.Body = body
+		rules = append(rules, rule)
+	}
+
+	if len(rules) == 0 {
+		return nil, nil
+	}
+	return &ir.ResponseOverride{Rules: rules}, nil
+}
+
+func buildStatusCodeMatch(match egv1a1.StatusCodeMatch) (*ir.StatusCodeMatch, error) {
+	switch ptr.Deref(match.Type, egv1a1.StatusCodeValueTypeValue) {
+	case egv1a1.StatusCodeValueTypeRange:
+		if match.Range == nil {
+			return nil, errors.New("range must be set for the Range status code match type")
+		}
+		if match.Range.Start > match.Range.End {
+			return nil, fmt.Errorf("the start %d of the status code range is greater than its end %d",
+				match.Range.Start, match.Range.End)
+		}
+		return &ir.StatusCodeMatch{
+			Range: &ir.StatusCodeRange{
+				Start: match.Range.Start,
+				End:   match.Range.End,
+			},
+		}, nil
+	default:
+		if match.Value == nil {
+			return nil, errors.New("value must be set for the Value status code match type")
+		}
+		value, err := strconv.Atoi(*match.Value)
+		if err != nil || value < 100 || value > 599 {
+			return nil, fmt.Errorf("invalid status code %q", *match.Value)
+		}
+		return &ir.StatusCodeMatch{Value: ptr.To(value)}, nil
+	}
+}
+
+// buildCustomResponseBody returns the body of a custom response, reading it from the
+// ConfigMap it refers to if any.
+func buildCustomResponseBody(body egv1a1.CustomResponseBody, namespace string, resources *resource.Resources) (*string, error) {
+	switch ptr.Deref(body.Type, egv1a1.ResponseValueTypeInline) {
+	case egv1a1.ResponseValueTypeValueRef:
+		if body.ValueRef == nil {
+			return nil, errors.New("valueRef must be set for the ValueRef response body type")
+		}
+		if body.ValueRef.Kind != resource.KindConfigMap {
+			return nil, fmt.Errorf("unsupported kind %s of the response body, only ConfigMap is supported", body.ValueRef.Kind)
+		}
+		configMap := resources.GetConfigMap(namespace, string(body.ValueRef.Name))
+		if configMap == nil {
+			return nil, fmt.Errorf("configmap %s/%s does not exist", namespace, body.ValueRef.Name)
+		}
+		value, ok := configMap.Data[responseBodyKey]
+		if !ok {
+			return nil, fmt.Errorf("configmap %s/%s does not contain the key %s", namespace, body.ValueRef.Name, responseBodyKey)
+		}
+		return &value, nil
+	default:
+		return body.Inline, nil
+	}
+}
+
+func buildIRMetadataValue(value *egv1a1.MetadataValue) *ir.MetadataValue {
+	if value == nil {
+		return nil
+	}
+	return &ir.MetadataValue{
+		Namespace: ptr.Deref(value.Namespace, egv1a1.HeaderToMetadataNamespace),
+		Key:       value.Key,
+		Value:     value.Value,
+		Type:      ptr.Deref(value.Type, egv1a1.MetadataValueTypeString),
+	}
+}
+
 func (t *Translator) buildRetry(policy *egv1a1.BackendTrafficPolicy) *ir.Retry {
 	var rt *ir.Retry
 	if policy.Spec.Retry != nil {
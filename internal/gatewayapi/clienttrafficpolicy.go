@@ -9,6 +9,7 @@ import (
 	"errors"
 	"fmt"
 	"math"
+	"slices"
 	"sort"
 	"strings"
 	"time"
@@ -31,6 +32,15 @@ import (
 const (
 	// Use an invalid string to represent all sections (listeners) within a Gateway
 	AllSections = "/"
+
+	// defaultSessionTicketKeyRotationInterval is the default interval a new session
+	// ticket key is generated at.
+	defaultSessionTicketKeyRotationInterval = time.Hour
+	// defaultSessionTicketRetainedKeys is the default number of previous session
+	// ticket keys still accepted to resume a session.
+	defaultSessionTicketRetainedKeys = 2
+	// defaultHealthCheckPath is the default path of the health check requests.
+	defaultHealthCheckPath = "/healthz"
 )
 
 func hasSectionName(target *gwapiv1a2.LocalPolicyTargetReferenceWithSectionName) bool {
@@ -144,6 +154,9 @@ func (t *Translator) ProcessClientTrafficPolicies(
 						if err == nil {
 							err = t.translateClientTrafficPolicyForListener(policy, l, xdsIR, infraIR, resources)
 						}
+						if err == nil && !hasNoSNIMatchDefault(policy, l, gwXdsIR) {
+							setNoDefaultFilterChainCondition(policy, ancestorRefs, t.GatewayControllerName, []gwapiv1.PortNumber{l.Port})
+						}
 						break
 					}
 				}
@@ -248,7 +261,10 @@ func (t *Translator) ProcessClientTrafficPolicies(
 				policyMap[key].Insert(AllSections)
 
 				// Translate sections that have not yet been targeted
-				var errs error
+				var (
+					errs          error
+					noDefaultPort []gwapiv1.PortNumber
+				)
 				for _, l := range gateway.listeners {
 					// Skip if section has already been targeted
 					if s != nil && s.Has(string(l.Name)) {
@@ -263,9 +279,15 @@ func (t *Translator) ProcessClientTrafficPolicies(
 						errs = errors.Join(errs, err)
 					} else if err := t.translateClientTrafficPolicyForListener(policy, l, xdsIR, infraIR, resources); err != nil {
 						errs = errors.Join(errs, err)
+					} else if !hasNoSNIMatchDefault(policy, l, gwXdsIR) && !slices.Contains(noDefaultPort, l.Port) {
+						noDefaultPort = append(noDefaultPort, l.Port)
 					}
 				}
 
+				if len(noDefaultPort) > 0 {
+					setNoDefaultFilterChainCondition(policy, ancestorRefs, t.GatewayControllerName, noDefaultPort)
+				}
+
 				// Set conditions for translation error if it got any
 				if errs != nil {
 					status.SetTranslationErrorForPolicyAncestors(&policy.Status,
@@ -285,6 +307,79 @@ func (t *Translator) ProcessClientTrafficPolicies(
 	return res
 }
 
+// hasNoSNIMatchDefault returns false if the policy configures TLS on the listener without
+// configuring how the connections whose SNI matches none of the hostnames of the listeners
+// sharing its port are handled, and none of these listeners handles them, so that they
+// are closed.
+func hasNoSNIMatchDefault(policy *egv1a1.ClientTrafficPolicy, l *ListenerContext, xds *ir.Xds) bool {
+	if policy.Spec.TLS == nil || policy.Spec.TLS.NoSNIMatch != nil {
+		return true
+	}
+
+	irListenerName := irListenerName(l)
+	var listener *ir.CoreListenerDetails
+	for _, http := range xds.HTTP {
+		if http.Name == irListenerName && http.TLS != nil {
+			listener = &http.CoreListenerDetails
+		}
+	}
+	for _, tcp := range xds.TCP {
+		if tcp.Name == irListenerName && tcp.TLS != nil {
+			listener = &tcp.CoreListenerDetails
+		}
+	}
+	// Only the listeners terminating TLS are configured by the policy.
+	if listener == nil {
+		return true
+	}
+
+	handlesNoSNIMatch := func(tls *ir.TLSConfig) bool {
+		return tls != nil && tls.NoSNIMatch != nil && tls.NoSNIMatch.Action != egv1a1.NoSNIMatchActionReject
+	}
+	for _, http := range xds.HTTP {
+		if http.Port != listener.Port || http.Address != listener.Address {
+			continue
+		}
+		if http.TLS == nil || len(http.Hostnames) == 0 || http.Hostnames[0] == "*" || handlesNoSNIMatch(http.TLS) {
+			return true
+		}
+	}
+	for _, tcp := range xds.TCP {
+		if tcp.Port != listener.Port || tcp.Address != listener.Address {
+			continue
+		}
+		if handlesNoSNIMatch(tcp.TLS) {
+			return true
+		}
+		for _, route := range tcp.Routes {
+			if route.TLS == nil || route.TLS.TLSInspectorConfig == nil ||
+				len(route.TLS.TLSInspectorConfig.SNIs) == 0 || route.TLS.TLSInspectorConfig.SNIs[0] == "*" {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// setNoDefaultFilterChainCondition warns that the TLS connections to the ports whose SNI
+// matches none of the hostnames of the listeners are closed.
+func setNoDefaultFilterChainCondition(policy *egv1a1.ClientTrafficPolicy, ancestorRefs []gwapiv1a2.ParentReference,
+	controllerName string, ports []gwapiv1.PortNumber,
+) {
+	slices.Sort(ports)
+	message := fmt.Sprintf("The TLS connections to the ports %v whose SNI matches none of the hostnames of the listeners are closed, "+
+		"set tls.noSNIMatch to handle them", ports)
+	status.SetConditionForPolicyAncestors(&policy.Status,
+		ancestorRefs,
+		controllerName,
+		egv1a1.PolicyConditionNoDefaultFilterChain,
+		metav1.ConditionTrue,
+		egv1a1.PolicyReasonNoDefaultFilterChain,
+		message,
+		policy.Generation,
+	)
+}
+
 func resolveCTPolicyTargetRef(
 	policy *egv1a1.ClientTrafficPolicy,
 	targetRef *gwapiv1a2.LocalPolicyTargetReferenceWithSectionName,
@@ -454,29 +549,34 @@ func (t *Translator) translateClientTrafficPolicyForListener(policy *egv1a1.Clie
 			errs = errors.Join(errs, err)
 		}
 
-		// enable http3 if set and TLS is enabled
+		// enable http3 if set, TLS is enabled and its feature gate is enabled
 		if httpIR.TLS != nil && policy.Spec.HTTP3 != nil {
-			http3 := &ir.HTTP3Settings{
-				QUICPort: int32(l.Port),
-			}
-			httpIR.HTTP3 = http3
-			var proxyListenerIR *ir.ProxyListener
-			for _, proxyListener := range infraIR[irKey].Proxy.Listeners {
-				if proxyListener.Name == irListenerName {
-					proxyListenerIR = proxyListener
-					break
+			if t.featureEnabled(egv1a1.FeatureGateHTTP3) {
+				http3 := &ir.HTTP3Settings{
+					QUICPort: int32(l.Port),
 				}
-			}
-			if proxyListenerIR != nil {
-				proxyListenerIR.HTTP3 = http3
+				httpIR.HTTP3 = http3
+				var proxyListenerIR *ir.ProxyListener
+				for _, proxyListener := range infraIR[irKey].Proxy.Listeners {
+					if proxyListener.Name == irListenerName {
+						proxyListenerIR = proxyListener
+						break
+					}
+				}
+				if proxyListenerIR != nil {
+					proxyListenerIR.HTTP3 = http3
+				}
+			} else {
+				err = fmt.Errorf("HTTP3 is disabled by the %s feature gate", egv1a1.FeatureGateHTTP3)
+				errs = errors.Join(errs, err)
 			}
 		}
 
 		// Translate Health Check Settings
-		translateHealthCheckSettings(policy.Spec.HealthCheck, httpIR)
+		translateHealthCheckSettings(policy, httpIR)
 
 		// Translate TLS parameters
-		tlsConfig, err = t.buildListenerTLSParameters(policy, httpIR.TLS, resources)
+		tlsConfig, err = t.buildListenerTLSParameters(policy, httpIR.TLS, resources, l.gateway.envoyProxy)
 		if err != nil {
 			err = perr.WithMessage(err, "TLS")
 			errs = errors.Join(errs, err)
@@ -496,7 +596,7 @@ func (t *Translator) translateClientTrafficPolicyForListener(policy *egv1a1.Clie
 
 	if tcpIR != nil {
 		// Translate TLS parameters
-		tlsConfig, err = t.buildListenerTLSParameters(policy, tcpIR.TLS, resources)
+		tlsConfig, err = t.buildListenerTLSParameters(policy, tcpIR.TLS, resources, l.gateway.envoyProxy)
 		if err != nil {
 			err = perr.WithMessage(err, "TLS")
 			errs = errors.Join(errs, err)
@@ -749,17 +849,49 @@ func translateHTTP2Settings(http2Settings *egv1a1.HTTP2Settings, httpIR *ir.HTTP
 	return errs
 }
 
-func translateHealthCheckSettings(healthCheckSettings *egv1a1.HealthCheckSettings, httpIR *ir.HTTPListener) {
+func translateHealthCheckSettings(policy *egv1a1.ClientTrafficPolicy, httpIR *ir.HTTPListener) {
+	healthCheckSettings := policy.Spec.HealthCheck
 	// Return early if not set
 	if healthCheckSettings == nil {
 		return
 	}
 
-	httpIR.HealthCheck = (*ir.HealthCheckSettings)(healthCheckSettings)
+	healthCheck := &ir.HealthCheckSettings{
+		Path: healthCheckSettings.Path,
+	}
+	if healthCheck.Path == "" {
+		healthCheck.Path = defaultHealthCheckPath
+	}
+
+	// The routes have been attached to the listener before the policies are processed.
+	for _, route := range healthCheckSettings.Routes {
+		kind := string(ptr.Deref(route.Kind, resource.KindHTTPRoute))
+		namespace := string(ptr.Deref(route.Namespace, gwapiv1.Namespace(policy.Namespace)))
+		for _, irRoute := range httpIR.Routes {
+			if irRoute.Destination == nil || irRoute.Metadata == nil ||
+				irRoute.Metadata.Kind != kind ||
+				irRoute.Metadata.Namespace != namespace ||
+				irRoute.Metadata.Name != string(route.Name) {
+				continue
+			}
+			// The routes of the matches of a rule share its destination.
+			if slices.ContainsFunc(healthCheck.MinHealthyPercentages, func(p *ir.DestinationMinHealthyPercentage) bool {
+				return p.Destination == irRoute.Destination.Name
+			}) {
+				continue
+			}
+			healthCheck.MinHealthyPercentages = append(healthCheck.MinHealthyPercentages, &ir.DestinationMinHealthyPercentage{
+				Destination: irRoute.Destination.Name,
+				Percentage:  route.MinHealthyPercentage,
+			})
+		}
+	}
+
+	httpIR.HealthCheck = healthCheck
 }
 
 func (t *Translator) buildListenerTLSParameters(policy *egv1a1.ClientTrafficPolicy,
-	irTLSConfig *ir.TLSConfig, resources *resource.Resources,
+	irTLSConfig *ir.TLSConfig, resources *resource.Resources, envoyProxy *egv1a1.EnvoyProxy,
 ) (*ir.TLSConfig, error) {
 	// Return if this listener isn't a TLS listener. There has to be
 	// at least one certificate defined, which would cause httpIR/tcpIR to
@@ -803,6 +935,20 @@ func (t *Translator) buildListenerTLSParameters(policy *egv1a1.ClientTrafficPoli
 		irTLSConfig.SignatureAlgorithms = tlsParams.SignatureAlgorithms
 	}
 
+	if tlsParams.NoSNIMatch != nil {
+		noSNIMatch, err := t.buildNoSNIMatch(policy, tlsParams.NoSNIMatch, envoyProxy, resources)
+		if err != nil {
+			return irTLSConfig, err
+		}
+		irTLSConfig.NoSNIMatch = noSNIMatch
+	}
+
+	if tlsParams.SessionResumption != nil {
+		if err := buildSessionResumption(policy, tlsParams.SessionResumption, irTLSConfig); err != nil {
+			return irTLSConfig, err
+		}
+	}
+
 	if tlsParams.ClientValidation != nil {
 		from := crossNamespaceFrom{
 			group:     egv1a1.GroupName,
@@ -867,6 +1013,77 @@ func (t *Translator) buildListenerTLSParameters(policy *egv1a1.ClientTrafficPoli
 	return irTLSConfig, nil
 }
 
+// buildNoSNIMatch translates the handling of the TLS connections whose SNI matches no
+// hostname, resolving the default backend they are proxied to, if any.
+func (t *Translator) buildNoSNIMatch(policy *egv1a1.ClientTrafficPolicy, noSNIMatch *egv1a1.NoSNIMatch,
+	envoyProxy *egv1a1.EnvoyProxy, resources *resource.Resources,
+) (*ir.NoSNIMatch, error) {
+	irNoSNIMatch := &ir.NoSNIMatch{Action: noSNIMatch.Action}
+	if noSNIMatch.Action != egv1a1.NoSNIMatchActionDefaultBackend {
+		return irNoSNIMatch, nil
+	}
+	// This is a sanity check, the API server should have caught it.
+	if noSNIMatch.BackendRef == nil {
+		return nil, errors.New("backendRef must be set when the noSNIMatch action is DefaultBackend")
+	}
+
+	if err := t.validateExtServiceBackendReference(&noSNIMatch.BackendRef.BackendObjectReference,
+		policy.Namespace, resource.KindClientTrafficPolicy, resources); err != nil {
+		return nil, err
+	}
+	pnn := utils.NamespacedName(policy)
+	ds, err := t.processExtServiceDestination(noSNIMatch.BackendRef, pnn, resource.KindClientTrafficPolicy,
+		ir.TCP, resources, envoyProxy)
+	if err != nil {
+		return nil, err
+	}
+	irNoSNIMatch.Destination = &ir.RouteDestination{
+		Name:     irIndexedExtServiceDestinationName(pnn, resource.KindClientTrafficPolicy, 0),
+		Settings: []*ir.DestinationSetting{ds},
+	}
+	return irNoSNIMatch, nil
+}
+
+// buildSessionResumption sets the TLS session resumption settings of the listener.
+func buildSessionResumption(policy *egv1a1.ClientTrafficPolicy, resumption *egv1a1.SessionResumption, irTLSConfig *ir.TLSConfig) error {
+	if stateful := resumption.Stateful; stateful != nil && stateful.Enabled != nil {
+		irTLSConfig.DisableStatefulSessionResumption = !*stateful.Enabled
+	}
+
+	stateless := resumption.Stateless
+	if stateless == nil {
+		return nil
+	}
+	if stateless.Enabled != nil && !*stateless.Enabled {
+		irTLSConfig.DisableStatelessSessionResumption = true
+		return nil
+	}
+	if stateless.TicketKeys == nil {
+		return nil
+	}
+
+	keys := &ir.SessionTicketKeys{
+		Name:             irTLSSessionTicketKeysName(policy.Namespace, policy.Name),
+		RotationInterval: metav1.Duration{Duration: defaultSessionTicketKeyRotationInterval},
+		RetainedKeys:     defaultSessionTicketRetainedKeys,
+	}
+	if stateless.TicketKeys.RotationInterval != nil {
+		d, err := time.ParseDuration(string(*stateless.TicketKeys.RotationInterval))
+		if err != nil {
+			return fmt.Errorf("invalid session ticket key rotation interval: %w", err)
+		}
+		if d < time.Minute {
+			return fmt.Errorf("session ticket key rotation interval %s is shorter than 1m", d)
+		}
+		keys.RotationInterval = metav1.Duration{Duration: d}
+	}
+	if stateless.TicketKeys.RetainedKeys != nil {
+		keys.RetainedKeys = *stateless.TicketKeys.RetainedKeys
+	}
+	irTLSConfig.SessionTicketKeys = keys
+	return nil
+}
+
 func buildConnection(connection *egv1a1.ClientConnection) (*ir.ClientConnection, error) {
 	if connection == nil {
 		return nil, nil
@@ -890,6 +1107,13 @@ func buildConnection(connection *egv1a1.ClientConnection) (*ir.ClientConnection,
 		irConnection.ConnectionLimit = irConnectionLimit
 	}
 
+	if connection.ConnectionRateLimit != nil {
+		irConnection.ConnectionRateLimit = &ir.ConnectionRateLimit{
+			ConnectionsPerSecond: connection.ConnectionRateLimit.ConnectionsPerSecond,
+			Burst:                ptr.Deref(connection.ConnectionRateLimit.Burst, connection.ConnectionRateLimit.ConnectionsPerSecond),
+		}
+	}
+
 	if connection.BufferLimit != nil {
 		bufferLimit, ok := connection.BufferLimit.AsInt64()
 		if !ok {
@@ -902,6 +1126,12 @@ func buildConnection(connection *egv1a1.ClientConnection) (*ir.ClientConnection,
 		irConnection.BufferLimitBytes = ptr.To(uint32(bufferLimit))
 	}
 
+	if connection.SocketOptions != nil {
+		irConnection.NoDelay = connection.SocketOptions.NoDelay
+		irConnection.ReusePort = connection.SocketOptions.ReusePort
+		irConnection.DSCP = connection.SocketOptions.DSCP
+	}
+
 	return irConnection, nil
 }
 
@@ -192,6 +192,20 @@ func buildBackendConnection(policy egv1a1.ClusterSettings) (*ir.BackendConnectio
 
 			bcIR.BufferLimitBytes = ptr.To(uint32(bf))
 		}
+
+		if bc.Preconnect != nil {
+			bcIR.Preconnect = &ir.Preconnect{
+				PerEndpointPercent: bc.Preconnect.PerEndpointPercent,
+				PredictivePercent:  bc.Preconnect.PredictivePercent,
+			}
+		}
+
+		if bc.HappyEyeballs != nil {
+			bcIR.HappyEyeballs = &ir.HappyEyeballs{
+				FirstAddressFamily:      bc.HappyEyeballs.FirstAddressFamily,
+				FirstAddressFamilyCount: bc.HappyEyeballs.FirstAddressFamilyCount,
+			}
+		}
 	}
 
 	return bcIR, nil
@@ -278,6 +292,13 @@ func buildCircuitBreaker(policy egv1a1.ClusterSettings) (*ir.CircuitBreaker, err
 			}
 		}
 
+		if pcb.PerEndpoint != nil && pcb.PerEndpoint.MaxConnections != nil {
+			if ui32, ok := int64ToUint32(*pcb.PerEndpoint.MaxConnections); ok {
+				cb.PerEndpointMaxConnections = &ui32
+			} else {
+				return nil, fmt.Errorf("invalid PerEndpoint.MaxConnections value %d", *pcb.PerEndpoint.MaxConnections)
+			}
+		}
 	}
 
 	return cb, nil
@@ -496,5 +517,6 @@ func translateDNS(policy egv1a1.ClusterSettings) *ir.DNS {
 	return &ir.DNS{
 		RespectDNSTTL:  policy.DNS.RespectDNSTTL,
 		DNSRefreshRate: policy.DNS.DNSRefreshRate,
+		LookupFamily:   policy.DNS.LookupFamily,
 	}
 }
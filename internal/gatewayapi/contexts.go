@@ -44,11 +44,11 @@ func (g *GatewayContext) ResetListeners(resource *resource.Resources) {
 		}
 	}
 
-	g.attachEnvoyProxy(resource)
+	g.attachEnvoyProxy(resource, IsMergeGatewaysEnabled(resource))
 }
 
-func (g *GatewayContext) attachEnvoyProxy(resources *resource.Resources) {
-	if g.Spec.Infrastructure != nil && g.Spec.Infrastructure.ParametersRef != nil && !IsMergeGatewaysEnabled(resources) {
+func (g *GatewayContext) attachEnvoyProxy(resources *resource.Resources, mergeGateways bool) {
+	if g.Spec.Infrastructure != nil && g.Spec.Infrastructure.ParametersRef != nil && !mergeGateways {
 		ref := g.Spec.Infrastructure.ParametersRef
 		if string(ref.Group) == egv1a1.GroupVersion.Group && ref.Kind == egv1a1.KindEnvoyProxy {
 			ep := resources.GetEnvoyProxy(g.Namespace, ref.Name)
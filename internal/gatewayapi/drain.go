@@ -0,0 +1,75 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package gatewayapi
+
+import (
+	"slices"
+	"strconv"
+	"time"
+
+	gwapiv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	"github.com/envoyproxy/gateway/internal/ir"
+)
+
+const (
+	// AnnotationDrain is the annotation of the Gateways whose proxies are drained before
+	// the Gateway is deleted, when set to "true".
+	AnnotationDrain = "gateway.envoyproxy.io/drain"
+	// AnnotationDrainRetryAfter is the annotation of a draining Gateway with the number of
+	// seconds of the Retry-After header of the responses to the new connections.
+	AnnotationDrainRetryAfter = "gateway.envoyproxy.io/drain-retry-after"
+	// AnnotationDrainRedirectHost is the annotation of a draining Gateway with the host the
+	// requests of the new connections are redirected to, instead of being rejected.
+	AnnotationDrainRedirectHost = "gateway.envoyproxy.io/drain-redirect-host"
+	// AnnotationDrainTimeout is the annotation of a draining Gateway with the duration after
+	// which it is considered drained, even with connections left open.
+	AnnotationDrainTimeout = "gateway.envoyproxy.io/drain-timeout"
+
+	// GatewayDrainFinalizer is the finalizer holding the deletion of a draining Gateway, and
+	// the teardown of its infrastructure, until its proxies are drained.
+	GatewayDrainFinalizer = "gateway.envoyproxy.io/drain"
+
+	// defaultDrainRetryAfter is the number of seconds of the Retry-After header by default.
+	defaultDrainRetryAfter = 30
+	// defaultDrainTimeout is how long a Gateway is drained by default.
+	defaultDrainTimeout = 5 * time.Minute
+)
+
+// IsGatewayDraining returns true if the Gateway is annotated with AnnotationDrain, or is
+// being deleted while its drain holds its deletion.
+func IsGatewayDraining(gateway *gwapiv1.Gateway) bool {
+	if gateway.Annotations[AnnotationDrain] == "true" {
+		return true
+	}
+	return gateway.DeletionTimestamp != nil && slices.Contains(gateway.Finalizers, GatewayDrainFinalizer)
+}
+
+// GatewayDrainTimeout returns the drain timeout of the Gateway, or the default one if the
+// annotation is not a positive duration.
+func GatewayDrainTimeout(gateway *gwapiv1.Gateway) time.Duration {
+	if timeout, err := time.ParseDuration(gateway.Annotations[AnnotationDrainTimeout]); err == nil && timeout > 0 {
+		return timeout
+	}
+	return defaultDrainTimeout
+}
+
+// buildIRDrain returns the IR drain settings of the Gateway, or nil if it's not draining.
+// An invalid Retry-After is replaced by the default one.
+func buildIRDrain(gateway *gwapiv1.Gateway) *ir.Drain {
+	if !IsGatewayDraining(gateway) {
+		return nil
+	}
+
+	drain := &ir.Drain{
+		RetryAfter:   defaultDrainRetryAfter,
+		RedirectHost: gateway.Annotations[AnnotationDrainRedirectHost],
+	}
+	if retryAfter, err := strconv.ParseUint(gateway.Annotations[AnnotationDrainRetryAfter], 10, 32); err == nil {
+		drain.RetryAfter = uint32(retryAfter)
+	}
+	return drain
+}
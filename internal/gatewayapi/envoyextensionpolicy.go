@@ -6,6 +6,8 @@
 package gatewayapi
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
 	"errors"
 	"fmt"
 	"sort"
@@ -26,12 +28,18 @@ import (
 	"github.com/envoyproxy/gateway/internal/gatewayapi/resource"
 	"github.com/envoyproxy/gateway/internal/gatewayapi/status"
 	"github.com/envoyproxy/gateway/internal/ir"
+	"github.com/envoyproxy/gateway/internal/openapi"
 	"github.com/envoyproxy/gateway/internal/utils"
 	"github.com/envoyproxy/gateway/internal/wasm"
 )
 
-// oci URL prefix
-const ociURLPrefix = "oci://"
+const (
+	// oci URL prefix
+	ociURLPrefix = "oci://"
+	// defaultOpenAPIDocumentKey is the default key of the ConfigMap data holding
+	// the OpenAPI document.
+	defaultOpenAPIDocumentKey = "openapi.yaml"
+)
 
 func (t *Translator) ProcessEnvoyExtensionPolicies(envoyExtensionPolicies []*egv1a1.EnvoyExtensionPolicy,
 	gateways []*GatewayContext,
@@ -316,6 +324,11 @@ func (t *Translator) translateEnvoyExtensionPolicyForRoute(
 			err = perr.WithMessage(err, "ExtProc")
 			errs = errors.Join(errs, err)
 		}
+		var openAPIValidation *ir.OpenAPIValidation
+		if openAPIValidation, err = t.buildOpenAPIValidation(policy, resources, gtwCtx.envoyProxy); err != nil {
+			err = perr.WithMessage(err, "OpenAPIValidation")
+			errs = errors.Join(errs, err)
+		}
 		irKey := t.getIRKey(gtwCtx.Gateway)
 		for _, listener := range parentRefCtx.listeners {
 			irListener := xdsIR[irKey].GetHTTPListener(irListenerName(listener))
@@ -330,8 +343,9 @@ func (t *Translator) translateEnvoyExtensionPolicyForRoute(
 							continue
 						}
 						r.EnvoyExtensions = &ir.EnvoyExtensionFeatures{
-							ExtProcs: extProcs,
-							Wasms:    wasms,
+							ExtProcs:          extProcs,
+							Wasms:             wasms,
+							OpenAPIValidation: openAPIValidation,
 						}
 					}
 				}
@@ -350,9 +364,10 @@ func (t *Translator) translateEnvoyExtensionPolicyForGateway(
 	resources *resource.Resources,
 ) error {
 	var (
-		extProcs  []ir.ExtProc
-		wasms     []ir.Wasm
-		err, errs error
+		extProcs          []ir.ExtProc
+		wasms             []ir.Wasm
+		openAPIValidation *ir.OpenAPIValidation
+		err, errs         error
 	)
 
 	if extProcs, err = t.buildExtProcs(policy, resources, gateway.envoyProxy); err != nil {
@@ -363,6 +378,10 @@ func (t *Translator) translateEnvoyExtensionPolicyForGateway(
 		err = perr.WithMessage(err, "Wasm")
 		errs = errors.Join(errs, err)
 	}
+	if openAPIValidation, err = t.buildOpenAPIValidation(policy, resources, gateway.envoyProxy); err != nil {
+		err = perr.WithMessage(err, "OpenAPIValidation")
+		errs = errors.Join(errs, err)
+	}
 
 	irKey := t.getIRKey(gateway.Gateway)
 	// Should exist since we've validated this
@@ -393,8 +412,9 @@ func (t *Translator) translateEnvoyExtensionPolicyForGateway(
 			}
 
 			r.EnvoyExtensions = &ir.EnvoyExtensionFeatures{
-				ExtProcs: extProcs,
-				Wasms:    wasms,
+				ExtProcs:          extProcs,
+				Wasms:             wasms,
+				OpenAPIValidation: openAPIValidation,
 			}
 		}
 	}
@@ -428,63 +448,20 @@ func (t *Translator) buildExtProc(
 	resources *resource.Resources,
 	envoyProxy *egv1a1.EnvoyProxy,
 ) (*ir.ExtProc, error) {
-	var (
-		ds        *ir.DestinationSetting
-		authority string
-		err       error
-	)
-
-	var dsl []*ir.DestinationSetting
-	for i := range extProc.BackendRefs {
-		if err = t.validateExtServiceBackendReference(
-			&extProc.BackendRefs[i].BackendObjectReference,
-			policyNamespacedName.Namespace,
-			egv1a1.KindEnvoyExtensionPolicy,
-			resources); err != nil {
-			return nil, err
-		}
-
-		ds, err = t.processExtServiceDestination(
-			&extProc.BackendRefs[i],
-			policyNamespacedName,
-			egv1a1.KindEnvoyExtensionPolicy,
-			ir.GRPC,
-			resources,
-			envoyProxy,
-		)
-		if err != nil {
-			return nil, err
-		}
-
-		dsl = append(dsl, ds)
-	}
-
-	rd := ir.RouteDestination{
-		Name:     irIndexedExtServiceDestinationName(policyNamespacedName, egv1a1.KindEnvoyExtensionPolicy, extProcIdx),
-		Settings: dsl,
-	}
-
-	if extProc.BackendRefs[0].Port != nil {
-		authority = fmt.Sprintf(
-			"%s.%s:%d",
-			extProc.BackendRefs[0].Name,
-			NamespaceDerefOr(extProc.BackendRefs[0].Namespace, policyNamespacedName.Namespace),
-			*extProc.BackendRefs[0].Port)
-	} else {
-		authority = fmt.Sprintf(
-			"%s.%s",
-			extProc.BackendRefs[0].Name,
-			NamespaceDerefOr(extProc.BackendRefs[0].Namespace, policyNamespacedName.Namespace))
-	}
-
-	traffic, err := translateTrafficFeatures(extProc.BackendCluster.BackendSettings)
+	destination, authority, traffic, err := t.buildExtProcBackend(
+		&extProc.BackendCluster,
+		irIndexedExtServiceDestinationName(policyNamespacedName, egv1a1.KindEnvoyExtensionPolicy, extProcIdx),
+		policyNamespacedName,
+		egv1a1.KindEnvoyExtensionPolicy,
+		resources,
+		envoyProxy)
 	if err != nil {
 		return nil, err
 	}
 
 	extProcIR := &ir.ExtProc{
 		Name:        name,
-		Destination: rd,
+		Destination: *destination,
 		Traffic:     traffic,
 		Authority:   authority,
 	}
@@ -702,6 +679,69 @@ func (t *Translator) buildWasm(
 	return wasmIR, nil
 }
 
+func (t *Translator) buildOpenAPIValidation(
+	policy *egv1a1.EnvoyExtensionPolicy,
+	resources *resource.Resources,
+	envoyProxy *egv1a1.EnvoyProxy,
+) (*ir.OpenAPIValidation, error) {
+	if policy == nil || policy.Spec.OpenAPIValidation == nil {
+		return nil, nil
+	}
+
+	config := policy.Spec.OpenAPIValidation
+	configMap := resources.GetConfigMap(policy.Namespace, string(config.ConfigMapRef.Name))
+	if configMap == nil {
+		return nil, fmt.Errorf("configmap %s/%s does not exist", policy.Namespace, config.ConfigMapRef.Name)
+	}
+	key := ptr.Deref(config.Key, defaultOpenAPIDocumentKey)
+	document, ok := configMap.Data[key]
+	if !ok {
+		return nil, fmt.Errorf("configmap %s/%s does not contain the key %s", policy.Namespace, config.ConfigMapRef.Name, key)
+	}
+	// Validate the document, so that an invalid document is reported in the status
+	// of the policy rather than by the external processing service.
+	if _, err := openapi.Parse([]byte(document)); err != nil {
+		return nil, err
+	}
+
+	policyNamespacedName := utils.NamespacedName(policy)
+	destination, authority, traffic, err := t.buildExtProcBackend(
+		&config.BackendCluster,
+		irOpenAPIValidationDestinationName(policyNamespacedName),
+		policyNamespacedName,
+		egv1a1.KindEnvoyExtensionPolicy,
+		resources,
+		envoyProxy)
+	if err != nil {
+		return nil, err
+	}
+
+	// The external processing service is told which document to validate the
+	// requests against, and its digest, so that it can detect a stale copy.
+	digest := sha256.Sum256([]byte(document))
+	return &ir.OpenAPIValidation{
+		Name:           irConfigNameForOpenAPIValidation(policy),
+		Destination:    *destination,
+		Traffic:        traffic,
+		Authority:      authority,
+		Document:       fmt.Sprintf("%s/%s/%s", policy.Namespace, config.ConfigMapRef.Name, key),
+		DocumentSHA256: hex.EncodeToString(digest[:]),
+		FailOpen:       ptr.Deref(config.FailOpen, false),
+	}, nil
+}
+
+func irConfigNameForOpenAPIValidation(policy *egv1a1.EnvoyExtensionPolicy) string {
+	return fmt.Sprintf("%s/openapi", irConfigName(policy))
+}
+
+func irOpenAPIValidationDestinationName(policyNamespacedName types.NamespacedName) string {
+	return strings.ToLower(fmt.Sprintf(
+		"%s/%s/%s/openapi",
+		egv1a1.KindEnvoyExtensionPolicy,
+		policyNamespacedName.Namespace,
+		policyNamespacedName.Name))
+}
+
 func hasDigest(imageURL string) bool {
 	return strings.Contains(imageURL, "@")
 }
@@ -0,0 +1,59 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package gatewayapi
+
+import (
+	"fmt"
+	"strings"
+)
+
+const (
+	// RouteExposureAnnotation is the annotation of a route defining its exposure, either
+	// RouteExposureInternal or RouteExposureExternal. An internal route only attaches to
+	// the internal listeners of its parent Gateways. Routes are external by default.
+	RouteExposureAnnotation = egPrefix + "exposure"
+	// RouteExposureInternal only exposes the route on the internal listeners.
+	RouteExposureInternal = "internal"
+	// RouteExposureExternal exposes the route on any listener.
+	RouteExposureExternal = "external"
+
+	// InternalListenersAnnotation is the annotation of a Gateway listing the names of its
+	// internal listeners, comma-separated, or "*" if all its listeners are internal.
+	InternalListenersAnnotation = egPrefix + "internal-listeners"
+)
+
+// routeExposure returns whether the route is internal, or an error if its exposure
+// annotation is invalid.
+func routeExposure(route RouteContext) (internal bool, err error) {
+	exposure, ok := route.GetAnnotations()[RouteExposureAnnotation]
+	if !ok {
+		return false, nil
+	}
+	switch strings.ToLower(strings.TrimSpace(exposure)) {
+	case RouteExposureInternal:
+		return true, nil
+	case RouteExposureExternal:
+		return false, nil
+	default:
+		return false, fmt.Errorf("invalid %s annotation %q, must be %s or %s",
+			RouteExposureAnnotation, exposure, RouteExposureInternal, RouteExposureExternal)
+	}
+}
+
+// IsInternal returns true if the listener is listed as internal by its Gateway.
+func (l *ListenerContext) IsInternal() bool {
+	internalListeners, ok := l.gateway.Annotations[InternalListenersAnnotation]
+	if !ok {
+		return false
+	}
+	for _, name := range strings.Split(internalListeners, ",") {
+		name = strings.TrimSpace(name)
+		if name == "*" || name == string(l.Name) {
+			return true
+		}
+	}
+	return false
+}
@@ -88,6 +88,73 @@ func (t *Translator) processExtServiceDestination(
 	return ds, nil
 }
 
+// buildExtProcBackend returns the destination, the authority and the traffic features
+// of the gRPC external processing service referenced by the backend cluster.
+func (t *Translator) buildExtProcBackend(
+	backendCluster *egv1a1.BackendCluster,
+	destinationName string,
+	policyNamespacedName types.NamespacedName,
+	policyKind string,
+	resources *resource.Resources,
+	envoyProxy *egv1a1.EnvoyProxy,
+) (*ir.RouteDestination, string, *ir.TrafficFeatures, error) {
+	var (
+		ds        *ir.DestinationSetting
+		authority string
+		err       error
+	)
+
+	var dsl []*ir.DestinationSetting
+	for i := range backendCluster.BackendRefs {
+		if err = t.validateExtServiceBackendReference(
+			&backendCluster.BackendRefs[i].BackendObjectReference,
+			policyNamespacedName.Namespace,
+			policyKind,
+			resources); err != nil {
+			return nil, "", nil, err
+		}
+
+		ds, err = t.processExtServiceDestination(
+			&backendCluster.BackendRefs[i],
+			policyNamespacedName,
+			policyKind,
+			ir.GRPC,
+			resources,
+			envoyProxy,
+		)
+		if err != nil {
+			return nil, "", nil, err
+		}
+
+		dsl = append(dsl, ds)
+	}
+
+	rd := &ir.RouteDestination{
+		Name:     destinationName,
+		Settings: dsl,
+	}
+
+	if backendCluster.BackendRefs[0].Port != nil {
+		authority = fmt.Sprintf(
+			"%s.%s:%d",
+			backendCluster.BackendRefs[0].Name,
+			NamespaceDerefOr(backendCluster.BackendRefs[0].Namespace, policyNamespacedName.Namespace),
+			*backendCluster.BackendRefs[0].Port)
+	} else {
+		authority = fmt.Sprintf(
+			"%s.%s",
+			backendCluster.BackendRefs[0].Name,
+			NamespaceDerefOr(backendCluster.BackendRefs[0].Namespace, policyNamespacedName.Namespace))
+	}
+
+	traffic, err := translateTrafficFeatures(backendCluster.BackendSettings)
+	if err != nil {
+		return nil, "", nil, err
+	}
+
+	return rd, authority, traffic, nil
+}
+
 // TODO: also refer to extension type, as Wasm may also introduce destinations
 func irIndexedExtServiceDestinationName(policyNamespacedName types.NamespacedName, policyKind string, idx int) string {
 	return strings.ToLower(fmt.Sprintf(
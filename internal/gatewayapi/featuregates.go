@@ -0,0 +1,53 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package gatewayapi
+
+import (
+	"strconv"
+
+	egv1a1 "github.com/envoyproxy/gateway/api/v1alpha1"
+	"github.com/envoyproxy/gateway/internal/metrics"
+)
+
+var (
+	featureGateLabel        = metrics.NewLabel("gate")
+	featureGateEnabledLabel = metrics.NewLabel("enabled")
+
+	featureGateEnabled = metrics.NewGauge(
+		"feature_gate_enabled",
+		"Whether the feature gates of the translation are enabled.",
+	)
+
+	featureGateUsageTotal = metrics.NewCounter(
+		"feature_gate_usage_total",
+		"Total number of translations of resources using a gated feature, by whether the gate is enabled.",
+	)
+)
+
+// RecordFeatureGates records whether each feature gate is enabled.
+func RecordFeatureGates(gates map[egv1a1.FeatureGate]bool) {
+	for gate, enabled := range gates {
+		value := 0.0
+		if enabled {
+			value = 1
+		}
+		featureGateEnabled.With(featureGateLabel.Value(string(gate))).Record(value)
+	}
+}
+
+// featureEnabled returns whether the feature gate is enabled, and records the usage
+// of the gated feature.
+func (t *Translator) featureEnabled(gate egv1a1.FeatureGate) bool {
+	enabled, ok := t.FeatureGates[gate]
+	if !ok {
+		enabled = egv1a1.FeatureGateEnabledByDefault(gate)
+	}
+	if !t.Simulation {
+		featureGateUsageTotal.With(featureGateLabel.Value(string(gate)),
+			featureGateEnabledLabel.Value(strconv.FormatBool(enabled))).Increment()
+	}
+	return enabled
+}
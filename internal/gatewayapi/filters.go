@@ -11,6 +11,7 @@ import (
 	"strings"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
 	gwapiv1 "sigs.k8s.io/gateway-api/apis/v1"
 
 	egv1a1 "github.com/envoyproxy/gateway/api/v1alpha1"
@@ -51,6 +52,10 @@ type HTTPFilterIR struct {
 
 	URLRewrite *ir.URLRewrite
 
+	RequestNormalization *ir.RequestNormalization
+
+	QueryParamMatches []*ir.StringMatch
+
 	AddRequestHeaders    []ir.AddHeader
 	RemoveRequestHeaders []string
 
@@ -751,9 +756,13 @@ func (t *Translator) processExtensionRefHTTPFilter(extFilter *gwapiv1.LocalObjec
 
 	if string(extFilter.Kind) == egv1a1.KindHTTPRouteFilter {
 		for _, hrf := range resources.HTTPRouteFilters {
-			if hrf.Namespace == filterNs && hrf.Name == string(extFilter.Name) &&
-				hrf.Spec.URLRewrite.Path.Type == egv1a1.RegexHTTPPathModifier {
+			if hrf.Namespace != filterNs || hrf.Name != string(extFilter.Name) {
+				continue
+			}
 
+			translated := false
+			if hrf.Spec.URLRewrite != nil && hrf.Spec.URLRewrite.Path != nil &&
+				hrf.Spec.URLRewrite.Path.Type == egv1a1.RegexHTTPPathModifier {
 				if hrf.Spec.URLRewrite.Path.ReplaceRegexMatch == nil ||
 					hrf.Spec.URLRewrite.Path.ReplaceRegexMatch.Pattern == "" {
 					errMsg := "ReplaceRegexMatch Pattern must be set when rewrite path type is \"ReplaceRegexMatch\""
@@ -787,37 +796,26 @@ func (t *Translator) processExtensionRefHTTPFilter(extFilter *gwapiv1.LocalObjec
 					Pattern:      hrf.Spec.URLRewrite.Path.ReplaceRegexMatch.Pattern,
 					Substitution: hrf.Spec.URLRewrite.Path.ReplaceRegexMatch.Substitution,
 				}
+				if !setRegexPathRewrite(rmr, filterContext) {
+					return
+				}
+				translated = true
+			}
 
-				if filterContext.HTTPFilterIR.URLRewrite != nil {
-					// If path IR is already set - check for a conflict
-					if filterContext.HTTPFilterIR.URLRewrite.Path != nil {
-						path := filterContext.HTTPFilterIR.URLRewrite.Path
-						if path.RegexMatchReplace != nil || path.PrefixMatchReplace != nil || path.FullReplace != nil {
-							routeStatus := GetRouteStatus(filterContext.Route)
-							status.SetRouteStatusCondition(routeStatus,
-								filterContext.ParentRef.routeParentStatusIdx,
-								filterContext.Route.GetGeneration(),
-								gwapiv1.RouteConditionAccepted,
-								metav1.ConditionFalse,
-								gwapiv1.RouteReasonUnsupportedValue,
-								"Cannot configure multiple urlRewrite filters for a single HTTPRouteRule",
-							)
-							return
-						}
-					} else { // no path
-						filterContext.HTTPFilterIR.URLRewrite.Path = &ir.ExtendedHTTPPathModifier{
-							RegexMatchReplace: rmr,
-						}
-						return
-					}
-				} else { // no url rewrite
-					filterContext.HTTPFilterIR.URLRewrite = &ir.URLRewrite{
-						Path: &ir.ExtendedHTTPPathModifier{
-							RegexMatchReplace: rmr,
-						},
-					}
+			if hrf.Spec.Normalization != nil {
+				if !processNormalizationHTTPRouteFilter(hrf.Spec.Normalization, filterContext) {
 					return
 				}
+				translated = true
+			}
+
+			if hrf.Spec.QueryParamMatch != nil {
+				processQueryParamMatchHTTPRouteFilter(hrf.Spec.QueryParamMatch, filterContext)
+				translated = true
+			}
+
+			if translated {
+				return
 			}
 		}
 		errMsg := fmt.Sprintf("Unable to translate HTTPRouteFilter: %s/%s", filterNs,
@@ -856,6 +854,108 @@ func (t *Translator) processExtensionRefHTTPFilter(extFilter *gwapiv1.LocalObjec
 	t.processUnresolvedHTTPFilter(errMsg, filterContext)
 }
 
+// setRegexPathRewrite sets the regex rewrite of the path of the rule, and sets the status
+// of the route if the path of the rule is already rewritten.
+func setRegexPathRewrite(rmr *ir.RegexMatchReplace, filterContext *HTTPFiltersContext) bool {
+	if filterContext.HTTPFilterIR.URLRewrite == nil {
+		filterContext.HTTPFilterIR.URLRewrite = &ir.URLRewrite{}
+	}
+
+	// If path IR is already set - check for a conflict
+	if path := filterContext.HTTPFilterIR.URLRewrite.Path; path != nil &&
+		(path.RegexMatchReplace != nil || path.PrefixMatchReplace != nil || path.FullReplace != nil) {
+		routeStatus := GetRouteStatus(filterContext.Route)
+		status.SetRouteStatusCondition(routeStatus,
+			filterContext.ParentRef.routeParentStatusIdx,
+			filterContext.Route.GetGeneration(),
+			gwapiv1.RouteConditionAccepted,
+			metav1.ConditionFalse,
+			gwapiv1.RouteReasonUnsupportedValue,
+			"Cannot configure multiple urlRewrite filters for a single HTTPRouteRule",
+		)
+		return false
+	}
+
+	filterContext.HTTPFilterIR.URLRewrite.Path = &ir.ExtendedHTTPPathModifier{
+		RegexMatchReplace: rmr,
+	}
+	return true
+}
+
+// processNormalizationHTTPRouteFilter translates the normalization of the requests of an
+// HTTPRouteFilter. The trailing slash of the paths forwarded to the backends is normalized
+// by a path rewrite, the rest by the Lua filter.
+func processNormalizationHTTPRouteFilter(normalization *egv1a1.HTTPNormalizationFilter, filterContext *HTTPFiltersContext) bool {
+	if filterContext.HTTPFilterIR.RequestNormalization != nil {
+		routeStatus := GetRouteStatus(filterContext.Route)
+		status.SetRouteStatusCondition(routeStatus,
+			filterContext.ParentRef.routeParentStatusIdx,
+			filterContext.Route.GetGeneration(),
+			gwapiv1.RouteConditionAccepted,
+			metav1.ConditionFalse,
+			gwapiv1.RouteReasonUnsupportedValue,
+			"Cannot configure multiple normalization filters for a single HTTPRouteRule",
+		)
+		return false
+	}
+
+	requestNormalization := &ir.RequestNormalization{}
+	if query := normalization.Query; query != nil {
+		requestNormalization.RemoveQueryParameters = query.RemoveParameters
+		requestNormalization.SortQueryParameters = ptr.Deref(query.Sort, false)
+	}
+
+	if trailingSlash := normalization.TrailingSlash; trailingSlash != nil {
+		if trailingSlash.RedirectStatusCode != nil {
+			requestNormalization.TrailingSlashRedirect = &ir.TrailingSlashRedirect{
+				Action:     trailingSlash.Action,
+				StatusCode: int32(*trailingSlash.RedirectStatusCode),
+			}
+		} else if !setRegexPathRewrite(trailingSlashRewrite(trailingSlash.Action), filterContext) {
+			return false
+		}
+	}
+
+	if len(requestNormalization.RemoveQueryParameters) > 0 ||
+		requestNormalization.SortQueryParameters ||
+		requestNormalization.TrailingSlashRedirect != nil {
+		filterContext.HTTPFilterIR.RequestNormalization = requestNormalization
+	}
+	return true
+}
+
+// processQueryParamMatchHTTPRouteFilter adds the presence and absence conditions of the
+// filter to the query parameter matches of the rule.
+func processQueryParamMatchHTTPRouteFilter(match *egv1a1.HTTPQueryParamMatchFilter, filterContext *HTTPFiltersContext) {
+	for _, name := range match.Present {
+		filterContext.QueryParamMatches = append(filterContext.QueryParamMatches, &ir.StringMatch{
+			Name:    name,
+			Present: ptr.To(true),
+		})
+	}
+	for _, name := range match.Absent {
+		filterContext.QueryParamMatches = append(filterContext.QueryParamMatches, &ir.StringMatch{
+			Name:    name,
+			Present: ptr.To(false),
+		})
+	}
+}
+
+// trailingSlashRewrite returns the regex rewrite adding a trailing slash to the paths
+// without one, or removing the trailing slashes of the paths other than /.
+func trailingSlashRewrite(action egv1a1.TrailingSlashAction) *ir.RegexMatchReplace {
+	if action == egv1a1.TrailingSlashActionRemove {
+		return &ir.RegexMatchReplace{
+			Pattern:      `^(.+?)/+$`,
+			Substitution: `\1`,
+		}
+	}
+	return &ir.RegexMatchReplace{
+		Pattern:      `^(.*[^/])$`,
+		Substitution: `\1/`,
+	}
+}
+
 func (t *Translator) processRequestMirrorFilter(
 	filterIdx int,
 	mirrorFilter *gwapiv1.HTTPRequestMirrorFilter,
@@ -9,8 +9,10 @@ import (
 	"errors"
 	"fmt"
 	"net"
+	"path"
 	"slices"
 	"strings"
+	"time"
 
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -267,6 +269,59 @@ func servicePortToContainerPort(servicePort int32, envoyProxy *egv1a1.EnvoyProxy
 	return servicePort
 }
 
+// getEnvoyIPFamily returns the IP family of the listeners of the managed proxies,
+// defaulting to the IP family of the cluster, or nil if neither is set.
+func (t *Translator) getEnvoyIPFamily(envoyProxy *egv1a1.EnvoyProxy) *egv1a1.IPFamily {
+	if envoyProxy == nil || envoyProxy.Spec.IPFamily == nil {
+		return t.ClusterIPFamily
+	}
+	return envoyProxy.Spec.IPFamily
+}
+
+// netListenerAddress returns the address the listeners bind to for the IP family.
+// IPv6 and dual-stack listeners bind to the IPv6 wildcard address.
+func netListenerAddress(ipFamily *egv1a1.IPFamily) string {
+	if ipFamily != nil && (*ipFamily == egv1a1.IPv6 || *ipFamily == egv1a1.DualStack) {
+		return "::"
+	}
+	return "0.0.0.0"
+}
+
+// listenerUnixSocketPath returns the unix domain socket path the listener on the port
+// binds to, or nil if the listeners bind to IP addresses.
+func listenerUnixSocketPath(envoyProxy *egv1a1.EnvoyProxy, port gwapiv1.PortNumber) *string {
+	if envoyProxy == nil || envoyProxy.Spec.ListenerUnixSocket == nil {
+		return nil
+	}
+	return ptr.To(path.Join(envoyProxy.Spec.ListenerUnixSocket.Directory, fmt.Sprintf("%d.sock", port)))
+}
+
+// buildIRListenerTuning returns the IR tuning of the listener on the port, from the first
+// listener tuning of the EnvoyProxy whose ports include it.
+func buildIRListenerTuning(envoyProxy *egv1a1.EnvoyProxy, port gwapiv1.PortNumber) *ir.ListenerTuning {
+	if envoyProxy == nil {
+		return nil
+	}
+	for _, tuning := range envoyProxy.Spec.ListenerTuning {
+		if len(tuning.Ports) > 0 && !slices.Contains(tuning.Ports, port) {
+			continue
+		}
+		irTuning := &ir.ListenerTuning{
+			ExactBalance:                         ptr.Deref(tuning.ConnectionBalance, "") == egv1a1.ConnectionBalanceTypeExact,
+			MaxConnectionsToAcceptPerSocketEvent: tuning.MaxConnectionsToAcceptPerSocketEvent,
+			ContinueOnListenerFiltersTimeout:     ptr.Deref(tuning.ContinueOnListenerFiltersTimeout, false),
+		}
+		if tuning.ListenerFiltersTimeout != nil {
+			// The duration is validated by the CRD, so it can be parsed.
+			if d, err := time.ParseDuration(string(*tuning.ListenerFiltersTimeout)); err == nil {
+				irTuning.ListenerFiltersTimeout = ptr.To(metav1.Duration{Duration: d})
+			}
+		}
+		return irTuning
+	}
+	return nil
+}
+
 // computeHosts returns a list of intersecting listener hostnames and route hostnames
 // that don't intersect with other listener hostnames.
 func computeHosts(routeHostnames []string, listenerContext *ListenerContext) []string {
@@ -447,6 +502,216 @@ func irTLSCACertName(namespace, name string) string {
 	return fmt.Sprintf("%s/%s/%s", namespace, name, caCertKey)
 }
 
+func irTLSSessionTicketKeysName(namespace, name string) string {
+	return fmt.Sprintf("%s/%s/%s", namespace, name, "session-ticket-keys")
+}
+
+// buildIRWarming returns the IR warming settings of the EnvoyProxy.
+func buildIRWarming(warming *egv1a1.ProxyWarming) *ir.Warming {
+	if warming == nil {
+		return nil
+	}
+
+	irWarming := &ir.Warming{
+		WaitForListenerAck: ptr.Deref(warming.WaitForListenerAck, false),
+	}
+	if warming.InitialFetchTimeout != nil {
+		// The duration is validated by the CRD, so it can be parsed.
+		if d, err := time.ParseDuration(string(*warming.InitialFetchTimeout)); err == nil {
+			irWarming.InitialFetchTimeout = ptr.To(metav1.Duration{Duration: d})
+		}
+	}
+
+	return irWarming
+}
+
+// defaultRouteMatcherTreeMinRoutes is the default number of routes of a virtual host from
+// which its routes are matched with a matcher tree.
+const defaultRouteMatcherTreeMinRoutes = 1000
+
+// buildIRRouteMatcherTree returns the IR route matcher tree settings of the EnvoyProxy.
+func buildIRRouteMatcherTree(tree *egv1a1.ProxyRouteMatcherTree) *ir.RouteMatcherTree {
+	if tree == nil {
+		return nil
+	}
+	return &ir.RouteMatcherTree{
+		MinRoutes: ptr.Deref(tree.MinRoutes, defaultRouteMatcherTreeMinRoutes),
+	}
+}
+
+// defaultLocalityEndpointDiscoveryMinEndpoints is the default number of endpoints of a
+// cluster from which its endpoints are delivered with LEDS.
+const defaultLocalityEndpointDiscoveryMinEndpoints = 1000
+
+// buildIRLocalityEndpointDiscovery returns the IR LEDS settings of the EnvoyProxy.
+func buildIRLocalityEndpointDiscovery(leds *egv1a1.ProxyLocalityEndpointDiscovery) *ir.LocalityEndpointDiscovery {
+	if leds == nil {
+		return nil
+	}
+	return &ir.LocalityEndpointDiscovery{
+		MinEndpoints: ptr.Deref(leds.MinEndpoints, defaultLocalityEndpointDiscoveryMinEndpoints),
+	}
+}
+
+// defaultVirtualHostDiscoveryMinVirtualHosts is the default number of virtual hosts of a
+// route configuration from which its virtual hosts are delivered on demand with VHDS.
+const defaultVirtualHostDiscoveryMinVirtualHosts = 1000
+
+// buildIRVirtualHostDiscovery returns the IR VHDS settings of the EnvoyProxy.
+func buildIRVirtualHostDiscovery(vhds *egv1a1.ProxyVirtualHostDiscovery) *ir.VirtualHostDiscovery {
+	if vhds == nil {
+		return nil
+	}
+	return &ir.VirtualHostDiscovery{
+		MinVirtualHosts: ptr.Deref(vhds.MinVirtualHosts, defaultVirtualHostDiscoveryMinVirtualHosts),
+	}
+}
+
+// defaultExtensionConfigDiscoveryFilters are the default HTTP filters whose configurations
+// are delivered with ECDS.
+var defaultExtensionConfigDiscoveryFilters = []egv1a1.EnvoyFilter{
+	egv1a1.EnvoyFilterWasm,
+	egv1a1.EnvoyFilterExtAuthz,
+}
+
+// buildIRExtensionConfigDiscovery returns the IR ECDS settings of the EnvoyProxy.
+func buildIRExtensionConfigDiscovery(ecds *egv1a1.ProxyExtensionConfigDiscovery) *ir.ExtensionConfigDiscovery {
+	if ecds == nil {
+		return nil
+	}
+	filters := ecds.Filters
+	if len(filters) == 0 {
+		filters = defaultExtensionConfigDiscoveryFilters
+	}
+	return &ir.ExtensionConfigDiscovery{
+		Filters: slices.Clone(filters),
+	}
+}
+
+// weekdays maps the days of the week of the API to their time.Weekday.
+var weekdays = map[egv1a1.Weekday]time.Weekday{
+	"Sunday":    time.Sunday,
+	"Monday":    time.Monday,
+	"Tuesday":   time.Tuesday,
+	"Wednesday": time.Wednesday,
+	"Thursday":  time.Thursday,
+	"Friday":    time.Friday,
+	"Saturday":  time.Saturday,
+}
+
+// buildIRDeferDrains returns the IR settings of the deferral of the listener drains of the EnvoyProxy.
+func buildIRDeferDrains(deferDrains *egv1a1.ProxyDeferDrains) *ir.DeferDrains {
+	if deferDrains == nil {
+		return nil
+	}
+
+	window := deferDrains.MaintenanceWindow
+	irDeferDrains := &ir.DeferDrains{
+		FilterChains: ptr.Deref(deferDrains.Scope, egv1a1.DrainScopeListener) == egv1a1.DrainScopeFilterChain,
+	}
+	// The start and duration are validated by the CRD, so they can be parsed.
+	if start, err := time.Parse("15:04", window.Start); err == nil {
+		irDeferDrains.MaintenanceWindow.Start = metav1.Duration{Duration: time.Duration(start.Hour())*time.Hour + time.Duration(start.Minute())*time.Minute}
+	}
+	if d, err := time.ParseDuration(string(window.Duration)); err == nil {
+		irDeferDrains.MaintenanceWindow.Duration = metav1.Duration{Duration: d}
+	}
+	for _, day := range window.Days {
+		if weekday, ok := weekdays[day]; ok {
+			irDeferDrains.MaintenanceWindow.Days = append(irDeferDrains.MaintenanceWindow.Days, weekday)
+		}
+	}
+
+	return irDeferDrains
+}
+
+// defaultConfigCheckpointInterval is the default interval of the checkpoints of the
+// configuration of the proxies.
+const defaultConfigCheckpointInterval = 5 * time.Minute
+
+// buildIRConfigCheckpoint returns the IR settings of the configuration checkpoints of the EnvoyProxy.
+func buildIRConfigCheckpoint(checkpoint *egv1a1.ProxyConfigCheckpoint) *ir.ConfigCheckpoint {
+	if checkpoint == nil {
+		return nil
+	}
+
+	irCheckpoint := &ir.ConfigCheckpoint{
+		Interval: metav1.Duration{Duration: defaultConfigCheckpointInterval},
+	}
+	if checkpoint.Interval != nil {
+		// The duration is validated by the CRD, so it can be parsed.
+		if d, err := time.ParseDuration(string(*checkpoint.Interval)); err == nil && d > 0 {
+			irCheckpoint.Interval.Duration = d
+		}
+	}
+
+	return irCheckpoint
+}
+
+// buildIRRuntimeDiscovery returns the IR runtime values delivered with RTDS of the EnvoyProxy.
+func buildIRRuntimeDiscovery(runtimeDiscovery *egv1a1.ProxyRuntimeDiscovery) *ir.RuntimeDiscovery {
+	if runtimeDiscovery == nil {
+		return nil
+	}
+
+	// The runtime layer is delivered even without values, since the proxies wait for it
+	// to be fetched before they initialize.
+	irRuntimeDiscovery := &ir.RuntimeDiscovery{}
+	for _, value := range runtimeDiscovery.Values {
+		irRuntimeDiscovery.Values = append(irRuntimeDiscovery.Values, ir.RuntimeValue{
+			Key:   value.Key,
+			Value: value.Value,
+		})
+	}
+
+	return irRuntimeDiscovery
+}
+
+// defaultLoadReportingInterval is the default interval of the load reports of the proxies.
+const defaultLoadReportingInterval = 10 * time.Second
+
+// buildIRLoadReporting returns the IR load reporting settings of the EnvoyProxy.
+func buildIRLoadReporting(loadReporting *egv1a1.ProxyLoadReporting) *ir.LoadReporting {
+	if loadReporting == nil {
+		return nil
+	}
+
+	irLoadReporting := &ir.LoadReporting{
+		Interval: metav1.Duration{Duration: defaultLoadReportingInterval},
+		Balance:  ptr.Deref(loadReporting.Mode, egv1a1.LoadReportingModeReport) == egv1a1.LoadReportingModeBalance,
+		Metric:   ptr.Deref(loadReporting.Metric, ""),
+	}
+	if loadReporting.Interval != nil {
+		// The duration is validated by the CRD, so it can be parsed.
+		if d, err := time.ParseDuration(string(*loadReporting.Interval)); err == nil && d > 0 {
+			irLoadReporting.Interval.Duration = d
+		}
+	}
+
+	return irLoadReporting
+}
+
+const (
+	// defaultTrafficRecordingSamplingPercentage is the default percentage of the requests
+	// recorded by the proxies.
+	defaultTrafficRecordingSamplingPercentage = 1
+	// defaultTrafficRecordingMaxRequests is the default number of the recorded requests kept.
+	defaultTrafficRecordingMaxRequests = 10000
+)
+
+// buildIRTrafficRecording returns the IR traffic recording settings of the EnvoyProxy.
+func buildIRTrafficRecording(trafficRecording *egv1a1.ProxyTrafficRecording) *ir.TrafficRecording {
+	if trafficRecording == nil {
+		return nil
+	}
+
+	return &ir.TrafficRecording{
+		SamplingPercentage: ptr.Deref(trafficRecording.SamplingPercentage, defaultTrafficRecordingSamplingPercentage),
+		MaxRequests:        ptr.Deref(trafficRecording.MaxRequests, defaultTrafficRecordingMaxRequests),
+		Headers:            trafficRecording.Headers,
+	}
+}
+
 func IsMergeGatewaysEnabled(resources *resource.Resources) bool {
 	return resources.EnvoyProxyForGatewayClass != nil && resources.EnvoyProxyForGatewayClass.Spec.MergeGateways != nil && *resources.EnvoyProxyForGatewayClass.Spec.MergeGateways
 }
@@ -0,0 +1,75 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package gatewayapi
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/util/sets"
+	gwapiv1 "sigs.k8s.io/gateway-api/apis/v1"
+)
+
+// RouteReasonHostnameNotDelegated is the reason of the Accepted condition of a route
+// using hostnames that aren't delegated to its namespace.
+const RouteReasonHostnameNotDelegated gwapiv1.RouteConditionReason = "HostnameNotDelegated"
+
+// checkHostnameDelegation returns an error if the route uses hostnames that aren't
+// delegated to its namespace on the listeners of the parentRef.
+func (t *Translator) checkHostnameDelegation(route RouteContext, parentRef *RouteParentContext) error {
+	if t.HostnameDelegation == nil {
+		return nil
+	}
+
+	rejected := sets.NewString()
+	for _, listener := range parentRef.listeners {
+		for _, host := range computeHosts(GetHostnames(route), listener) {
+			if !t.hostnameAllowed(route.GetNamespace(), host) {
+				rejected.Insert(host)
+			}
+		}
+	}
+	if rejected.Len() == 0 {
+		return nil
+	}
+	return fmt.Errorf("hostnames %s are not delegated to the namespace %s",
+		strings.Join(rejected.List(), ", "), route.GetNamespace())
+}
+
+// hostnameAllowed returns true if the routes of the namespace may use the hostname: a
+// namespace with delegations may only use the hostnames delegated to it, while the other
+// namespaces may use any hostname not delegated to another namespace.
+func (t *Translator) hostnameAllowed(namespace, hostname string) bool {
+	var delegated, owned bool
+	for _, delegation := range t.HostnameDelegation.Delegations {
+		own := slices.Contains(delegation.Namespaces, namespace)
+		delegated = delegated || own
+		for _, pattern := range delegation.Hostnames {
+			if !hostnameDelegatedBy(hostname, string(pattern)) {
+				continue
+			}
+			if own {
+				return true
+			}
+			owned = true
+		}
+	}
+	return !delegated && !owned
+}
+
+// hostnameDelegatedBy returns true if the hostname is delegated by the pattern: either
+// the same hostname, or a subdomain of a wildcard pattern, including the wildcard
+// hostnames it matches.
+func hostnameDelegatedBy(hostname, pattern string) bool {
+	if pattern == "*" {
+		return true
+	}
+	if !strings.HasPrefix(pattern, "*.") {
+		return hostname == pattern
+	}
+	return strings.HasSuffix(strings.TrimPrefix(hostname, "*"), pattern[1:])
+}
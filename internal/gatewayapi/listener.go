@@ -102,14 +102,21 @@ func (t *Translator) ProcessListeners(gateways []*GatewayContext, xdsIR resource
 			// Add the listener to the Xds IR
 			servicePort := &protocolPort{protocol: listener.Protocol, port: int32(listener.Port)}
 			containerPort := servicePortToContainerPort(int32(listener.Port), gateway.envoyProxy)
+			ipFamily := t.getEnvoyIPFamily(gateway.envoyProxy)
+			address := netListenerAddress(ipFamily)
+			socketPath := listenerUnixSocketPath(gateway.envoyProxy, listener.Port)
+			tuning := buildIRListenerTuning(gateway.envoyProxy, listener.Port)
 			switch listener.Protocol {
 			case gwapiv1.HTTPProtocolType, gwapiv1.HTTPSProtocolType:
 				irListener := &ir.HTTPListener{
 					CoreListenerDetails: ir.CoreListenerDetails{
-						Name:     irListenerName(listener),
-						Address:  "0.0.0.0",
-						Port:     uint32(containerPort),
-						Metadata: buildListenerMetadata(listener, gateway),
+						Name:           irListenerName(listener),
+						Address:        address,
+						Port:           uint32(containerPort),
+						Metadata:       buildListenerMetadata(listener, gateway),
+						IPFamily:       ipFamily,
+						UnixSocketPath: socketPath,
+						Tuning:         tuning,
 					},
 					TLS: irTLSConfigs(listener.tlsSecrets...),
 					Path: ir.PathSettings{
@@ -129,9 +136,12 @@ func (t *Translator) ProcessListeners(gateways []*GatewayContext, xdsIR resource
 			case gwapiv1.TCPProtocolType, gwapiv1.TLSProtocolType:
 				irListener := &ir.TCPListener{
 					CoreListenerDetails: ir.CoreListenerDetails{
-						Name:    irListenerName(listener),
-						Address: "0.0.0.0",
-						Port:    uint32(containerPort),
+						Name:           irListenerName(listener),
+						Address:        address,
+						Port:           uint32(containerPort),
+						IPFamily:       ipFamily,
+						UnixSocketPath: socketPath,
+						Tuning:         tuning,
 					},
 
 					// Gateway is processed firstly, then ClientTrafficPolicy, then xRoute.
@@ -144,9 +154,10 @@ func (t *Translator) ProcessListeners(gateways []*GatewayContext, xdsIR resource
 			case gwapiv1.UDPProtocolType:
 				irListener := &ir.UDPListener{
 					CoreListenerDetails: ir.CoreListenerDetails{
-						Name:    irListenerName(listener),
-						Address: "0.0.0.0",
-						Port:    uint32(containerPort),
+						Name:     irListenerName(listener),
+						Address:  address,
+						Port:     uint32(containerPort),
+						IPFamily: ipFamily,
 					},
 				}
 				xdsIR[irKey].UDP = append(xdsIR[irKey].UDP, irListener)
@@ -163,10 +174,14 @@ func (t *Translator) ProcessListeners(gateways []*GatewayContext, xdsIR resource
 }
 
 func buildListenerMetadata(listener *ListenerContext, gateway *GatewayContext) *ir.ResourceMetadata {
+	gv := resourceGroupVersion(gateway)
 	return &ir.ResourceMetadata{
 		Kind:        gateway.GetObjectKind().GroupVersionKind().Kind,
+		Group:       gv.Group,
+		Version:     gv.Version,
 		Name:        gateway.GetName(),
 		Namespace:   gateway.GetNamespace(),
+		Generation:  gateway.GetGeneration(),
 		Annotations: filterEGPrefix(gateway.GetAnnotations()),
 		SectionName: string(listener.Name),
 	}
@@ -287,6 +302,7 @@ func (t *Translator) processAccessLog(envoyproxy *egv1a1.EnvoyProxy, resources *
 						Format:     format.Text,
 						Path:       sink.File.Path,
 						CELMatches: validExprs,
+						Protocol:   accessLog.Protocol,
 					}
 					irAccessLog.Text = append(irAccessLog.Text, al)
 				case egv1a1.ProxyAccessLogFormatTypeJSON:
@@ -299,6 +315,7 @@ func (t *Translator) processAccessLog(envoyproxy *egv1a1.EnvoyProxy, resources *
 						JSON:       format.JSON,
 						Path:       sink.File.Path,
 						CELMatches: validExprs,
+						Protocol:   accessLog.Protocol,
 					}
 					irAccessLog.JSON = append(irAccessLog.JSON, al)
 				}
@@ -329,6 +346,7 @@ func (t *Translator) processAccessLog(envoyproxy *egv1a1.EnvoyProxy, resources *
 					Traffic:    traffic,
 					Type:       sink.ALS.Type,
 					CELMatches: validExprs,
+					Protocol:   accessLog.Protocol,
 				}
 
 				if al.Type == egv1a1.ALSEnvoyProxyAccessLogTypeHTTP && sink.ALS.HTTP != nil {
@@ -361,6 +379,7 @@ func (t *Translator) processAccessLog(envoyproxy *egv1a1.EnvoyProxy, resources *
 				// TODO: remove support for Host/Port in v1.2
 				al := &ir.OpenTelemetryAccessLog{
 					CELMatches: validExprs,
+					Protocol:   accessLog.Protocol,
 					Resources:  sink.OpenTelemetry.Resources,
 					Destination: ir.RouteDestination{
 						Name:     fmt.Sprintf("accesslog_otel_%d_%d", i, j), // TODO: rename this, so that we can share backend with tracing?
@@ -0,0 +1,43 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package gatewayapi
+
+import (
+	"sort"
+	"strings"
+)
+
+const (
+	// AnnotationNodeGroups is the annotation of the routes only served by the proxies of
+	// some node groups. Its value is the comma-separated list of the node groups.
+	AnnotationNodeGroups = "gateway.envoyproxy.io/node-groups"
+
+	// NodeGroupLabel is the label of the proxy pods holding their node group, which is
+	// propagated to the node metadata of the proxies.
+	NodeGroupLabel = "gateway.envoyproxy.io/node-group"
+)
+
+// routeNodeGroups returns the sorted node groups of the proxies the route is served by,
+// or nil if the route is served by all the proxies.
+func routeNodeGroups(route RouteContext) []string {
+	value, ok := route.GetAnnotations()[AnnotationNodeGroups]
+	if !ok {
+		return nil
+	}
+
+	seen := make(map[string]bool)
+	var groups []string
+	for _, group := range strings.Split(value, ",") {
+		group = strings.TrimSpace(group)
+		if group == "" || seen[group] {
+			continue
+		}
+		seen[group] = true
+		groups = append(groups, group)
+	}
+	sort.Strings(groups)
+	return groups
+}
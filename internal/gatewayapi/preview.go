@@ -0,0 +1,219 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package gatewayapi
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation"
+	gwapiv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	egv1a1 "github.com/envoyproxy/gateway/api/v1alpha1"
+	"github.com/envoyproxy/gateway/internal/gatewayapi/resource"
+	"github.com/envoyproxy/gateway/internal/gatewayapi/status"
+	"github.com/envoyproxy/gateway/internal/ir"
+	"github.com/envoyproxy/gateway/internal/utils"
+)
+
+const (
+	// AnnotationPreview is the annotation of the HTTPRoutes exposed on the preview listener
+	// of their Gateways. Its value is the name of the preview, which prefixes the preview
+	// hostname.
+	AnnotationPreview = "gateway.envoyproxy.io/preview"
+
+	// RouteConditionPreview is the condition of a route annotated with AnnotationPreview,
+	// which is true while the route is exposed on the preview listener.
+	RouteConditionPreview gwapiv1.RouteConditionType = "Preview"
+	// RouteReasonPreviewProvisioned is the reason of the Preview condition of a route
+	// exposed on the preview listener.
+	RouteReasonPreviewProvisioned gwapiv1.RouteConditionReason = "Provisioned"
+	// RouteReasonPreviewExpired is the reason of the Preview condition of a route whose
+	// TTL expired.
+	RouteReasonPreviewExpired gwapiv1.RouteConditionReason = "Expired"
+	// RouteReasonPreviewInvalid is the reason of the Preview condition of a route that
+	// can't be exposed on the preview listener.
+	RouteReasonPreviewInvalid gwapiv1.RouteConditionReason = "Invalid"
+
+	// defaultPreviewTTL is how long the routes are exposed on the preview listener by default.
+	defaultPreviewTTL = 72 * time.Hour
+)
+
+// processPreviewRoutes exposes the HTTPRoutes annotated with AnnotationPreview on the preview
+// listener of their Gateways, with the hostname of their preview, until their TTL expires.
+// The name of a preview is exposed for the first HTTPRoute annotated with it only.
+// It returns when the earliest exposed route expires, or the zero time if none expires.
+func (t *Translator) processPreviewRoutes(httpRoutes []*HTTPRouteContext, resources *resource.Resources,
+	xdsIR resource.XdsIRMap, now time.Time,
+) time.Time {
+	var nextExpiry time.Time
+	// previews holds the HTTPRoute each preview is exposed for, by irKey and name.
+	previews := make(map[string]types.NamespacedName)
+	for _, route := range httpRoutes {
+		name, ok := route.GetAnnotations()[AnnotationPreview]
+		if !ok {
+			continue
+		}
+		for _, parentRef := range GetParentReferences(route) {
+			parentRefCtx := GetRouteParentContext(route, parentRef)
+			if len(parentRefCtx.listeners) == 0 {
+				continue
+			}
+			gateway := parentRefCtx.listeners[0].gateway
+			if gateway.envoyProxy == nil || gateway.envoyProxy.Spec.Preview == nil {
+				continue
+			}
+
+			var (
+				expiry     time.Time
+				err        error
+				previewKey = t.getIRKey(gateway.Gateway) + "/" + name
+			)
+			if owner, ok := previews[previewKey]; ok && owner != utils.NamespacedName(route) {
+				err = fmt.Errorf("preview name %q is already used by HTTPRoute %s", name, owner)
+			} else {
+				expiry, err = t.processPreviewRoute(route, parentRefCtx, gateway, gateway.envoyProxy.Spec.Preview, name, resources, xdsIR, now)
+				if err == nil && (expiry.IsZero() || now.Before(expiry)) {
+					previews[previewKey] = utils.NamespacedName(route)
+				}
+			}
+			var (
+				conditionStatus = metav1.ConditionTrue
+				reason          = RouteReasonPreviewProvisioned
+				message         string
+			)
+			hostname := fmt.Sprintf("%s.%s", name, gateway.envoyProxy.Spec.Preview.HostnameSuffix)
+			switch {
+			case err != nil:
+				conditionStatus, reason, message = metav1.ConditionFalse, RouteReasonPreviewInvalid, err.Error()
+			case !expiry.IsZero() && !now.Before(expiry):
+				conditionStatus, reason = metav1.ConditionFalse, RouteReasonPreviewExpired
+				message = fmt.Sprintf("The preview on hostname %s expired at %s.", hostname, expiry.UTC().Format(time.RFC3339))
+			case expiry.IsZero():
+				message = fmt.Sprintf("The route is exposed on hostname %s.", hostname)
+			default:
+				message = fmt.Sprintf("The route is exposed on hostname %s until %s.", hostname, expiry.UTC().Format(time.RFC3339))
+				if nextExpiry.IsZero() || expiry.Before(nextExpiry) {
+					nextExpiry = expiry
+				}
+			}
+			status.SetRouteStatusCondition(GetRouteStatus(route),
+				parentRefCtx.routeParentStatusIdx,
+				route.GetGeneration(),
+				RouteConditionPreview,
+				conditionStatus,
+				reason,
+				message,
+			)
+		}
+	}
+	return nextExpiry
+}
+
+// processPreviewRoute adds the routes of the HTTPRoute on the listeners of the parentRef to
+// the preview listener of the Gateway, with the preview hostname, unless expired or not
+// allowed by the preview listener. It returns when the preview expires, or the zero time if
+// the creation of the route is unknown.
+func (t *Translator) processPreviewRoute(route *HTTPRouteContext, parentRefCtx *RouteParentContext, gateway *GatewayContext,
+	preview *egv1a1.ProxyPreview, name string, resources *resource.Resources, xdsIR resource.XdsIRMap, now time.Time,
+) (time.Time, error) {
+	if errs := validation.IsDNS1123Label(name); errs != nil {
+		return time.Time{}, fmt.Errorf("invalid preview name %q: %s", name, strings.Join(errs, "; "))
+	}
+
+	ttl := defaultPreviewTTL
+	if preview.TTL != nil {
+		d, err := time.ParseDuration(string(*preview.TTL))
+		if err != nil {
+			return time.Time{}, fmt.Errorf("invalid preview TTL: %w", err)
+		}
+		ttl = d
+	}
+	var expiry time.Time
+	if created := route.GetCreationTimestamp(); !created.IsZero() {
+		expiry = created.Add(ttl)
+		if !now.Before(expiry) {
+			return expiry, nil
+		}
+	}
+
+	var previewListener *ListenerContext
+	for _, listener := range gateway.listeners {
+		if listener.Name == preview.Listener {
+			previewListener = listener
+			break
+		}
+	}
+	if previewListener == nil {
+		return time.Time{}, fmt.Errorf("preview listener %s not found on Gateway %s/%s", preview.Listener, gateway.Namespace, gateway.Name)
+	}
+	if previewListener.Protocol != gwapiv1.HTTPProtocolType && previewListener.Protocol != gwapiv1.HTTPSProtocolType {
+		return time.Time{}, fmt.Errorf("preview listener %s must be an HTTP or HTTPS listener", preview.Listener)
+	}
+	if !previewListener.AllowsKind(gwapiv1.RouteGroupKind{Group: GroupPtr(gwapiv1.GroupName), Kind: resource.KindHTTPRoute}) {
+		return time.Time{}, fmt.Errorf("preview listener %s does not allow HTTPRoutes", preview.Listener)
+	}
+	if !previewListener.AllowsNamespace(resources.GetNamespace(route.GetNamespace())) {
+		return time.Time{}, fmt.Errorf("preview listener %s does not allow the routes of namespace %s", preview.Listener, route.GetNamespace())
+	}
+	irKey := t.getIRKey(gateway.Gateway)
+	irListener := xdsIR[irKey].GetHTTPListener(irListenerName(previewListener))
+	if irListener == nil {
+		return time.Time{}, fmt.Errorf("preview listener %s is not ready", preview.Listener)
+	}
+
+	hostname := fmt.Sprintf("%s.%s", name, preview.HostnameSuffix)
+	underscoredHost := strings.ReplaceAll(hostname, ".", "_")
+	existing := make(map[string]bool, len(irListener.Routes))
+	for _, r := range irListener.Routes {
+		existing[r.Name] = true
+	}
+	for _, source := range previewSourceRoutes(route, parentRefCtx, xdsIR[irKey]) {
+		previewRoute := source.DeepCopy()
+		previewRoute.Hostname = hostname
+		previewRoute.Name = fmt.Sprintf("%s/%s", source.Name[:strings.LastIndex(source.Name, "/")], underscoredHost)
+		if existing[previewRoute.Name] {
+			continue
+		}
+		existing[previewRoute.Name] = true
+		irListener.Routes = append(irListener.Routes, previewRoute)
+	}
+	return expiry, nil
+}
+
+// previewSourceRoutes returns the IR routes of the HTTPRoute for the first of its hostnames
+// on the first listener of the parentRef it is attached to.
+func previewSourceRoutes(route *HTTPRouteContext, parentRefCtx *RouteParentContext, xdsIR *ir.Xds) []*ir.HTTPRoute {
+	prefix := irRoutePrefix(route)
+	for _, listener := range parentRefCtx.listeners {
+		irListener := xdsIR.GetHTTPListener(irListenerName(listener))
+		if irListener == nil {
+			continue
+		}
+		var (
+			routes   []*ir.HTTPRoute
+			hostname string
+		)
+		for _, r := range irListener.Routes {
+			if !strings.HasPrefix(r.Name, prefix) {
+				continue
+			}
+			if hostname == "" {
+				hostname = r.Hostname
+			}
+			if r.Hostname == hostname {
+				routes = append(routes, r)
+			}
+		}
+		if len(routes) > 0 {
+			return routes
+		}
+	}
+	return nil
+}
@@ -0,0 +1,75 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package resource
+
+import (
+	"reflect"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// Apply adds the object to the resources, replacing the object of the same kind,
+// namespace and name if any. It returns false if the resources don't hold objects
+// of that kind, or if the object is a GatewayClass other than the one of the resources.
+func (r *Resources) Apply(obj client.Object) bool {
+	objValue := reflect.ValueOf(obj)
+	var appendTo reflect.Value
+
+	v := reflect.ValueOf(r).Elem()
+	for i := 0; i < v.NumField(); i++ {
+		field := v.Field(i)
+		switch {
+		case field.Type() == objValue.Type():
+			if !field.IsNil() && sameObject(field.Interface().(client.Object), obj) {
+				field.Set(objValue)
+				return true
+			}
+		case field.Kind() == reflect.Slice && field.Type().Elem() == objValue.Type():
+			for j := 0; j < field.Len(); j++ {
+				if sameObject(field.Index(j).Interface().(client.Object), obj) {
+					field.Index(j).Set(objValue)
+					return true
+				}
+			}
+			if !appendTo.IsValid() {
+				appendTo = field
+			}
+		}
+	}
+
+	if !appendTo.IsValid() {
+		return false
+	}
+	appendTo.Set(reflect.Append(appendTo, objValue))
+	return true
+}
+
+// Remove removes the object of the kind, namespace and name from the resources, and
+// returns false if not found. The GatewayClass and the EnvoyProxy attached to it
+// can't be removed.
+func (r *Resources) Remove(kind, namespace, name string) bool {
+	v := reflect.ValueOf(r).Elem()
+	for i := 0; i < v.NumField(); i++ {
+		field := v.Field(i)
+		if field.Kind() != reflect.Slice || field.Type().Elem().Kind() != reflect.Ptr ||
+			field.Type().Elem().Elem().Name() != kind {
+			continue
+		}
+		for j := 0; j < field.Len(); j++ {
+			obj, ok := field.Index(j).Interface().(client.Object)
+			if !ok || obj.GetNamespace() != namespace || obj.GetName() != name {
+				continue
+			}
+			field.Set(reflect.AppendSlice(field.Slice(0, j), field.Slice(j+1, field.Len())))
+			return true
+		}
+	}
+	return false
+}
+
+func sameObject(a, b client.Object) bool {
+	return a.GetNamespace() == b.GetNamespace() && a.GetName() == b.GetName()
+}
@@ -123,3 +123,31 @@ func TestEqualXds(t *testing.T) {
 		})
 	}
 }
+
+func TestResourcesApplyRemove(t *testing.T) {
+	route := func(name, hostname string) *gwapiv1.HTTPRoute {
+		return &gwapiv1.HTTPRoute{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: name},
+			Spec:       gwapiv1.HTTPRouteSpec{Hostnames: []gwapiv1.Hostname{gwapiv1.Hostname(hostname)}},
+		}
+	}
+
+	r := NewResources()
+	r.GatewayClass = &gwapiv1.GatewayClass{ObjectMeta: metav1.ObjectMeta{Name: "eg"}}
+	r.HTTPRoutes = append(r.HTTPRoutes, route("route-1", "foo.example.com"))
+
+	// Objects are added or replaced by namespace and name.
+	require.True(t, r.Apply(route("route-2", "bar.example.com")))
+	require.True(t, r.Apply(route("route-1", "baz.example.com")))
+	require.Equal(t, []*gwapiv1.HTTPRoute{route("route-1", "baz.example.com"), route("route-2", "bar.example.com")}, r.HTTPRoutes)
+
+	// The GatewayClass is only replaced.
+	require.True(t, r.Apply(&gwapiv1.GatewayClass{ObjectMeta: metav1.ObjectMeta{Name: "eg", Labels: map[string]string{"a": "b"}}}))
+	require.Equal(t, "b", r.GatewayClass.Labels["a"])
+	require.False(t, r.Apply(&gwapiv1.GatewayClass{ObjectMeta: metav1.ObjectMeta{Name: "other"}}))
+
+	require.True(t, r.Remove(KindHTTPRoute, "default", "route-1"))
+	require.False(t, r.Remove(KindHTTPRoute, "default", "route-1"))
+	require.False(t, r.Remove(KindGatewayClass, "", "eg"))
+	require.Equal(t, []*gwapiv1.HTTPRoute{route("route-2", "bar.example.com")}, r.HTTPRoutes)
+}
@@ -15,7 +15,10 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	discoveryv1 "k8s.io/api/discovery/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	gwapiv1 "sigs.k8s.io/gateway-api/apis/v1"
 	gwapiv1a2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
 	mcsapiv1a1 "sigs.k8s.io/mcs-api/pkg/apis/v1alpha1"
@@ -143,6 +146,19 @@ func (t *Translator) processHTTPRouteParentRefs(httpRoute *HTTPRouteContext, res
 			continue
 		}
 
+		if err := t.checkHostnameDelegation(httpRoute, parentRef); err != nil {
+			routeStatus := GetRouteStatus(httpRoute)
+			status.SetRouteStatusCondition(routeStatus,
+				parentRef.routeParentStatusIdx,
+				httpRoute.GetGeneration(),
+				gwapiv1.RouteConditionAccepted,
+				metav1.ConditionFalse,
+				RouteReasonHostnameNotDelegated,
+				status.Error2ConditionMsg(err),
+			)
+			continue
+		}
+
 		hasHostnameIntersection := t.processHTTPRouteParentRefListener(httpRoute, routeRoutes, parentRef, xdsIR)
 		if !hasHostnameIntersection {
 			routeStatus := GetRouteStatus(httpRoute)
@@ -195,10 +211,15 @@ func (t *Translator) processHTTPRouteRules(httpRoute *HTTPRouteContext, parentRe
 		}
 
 		dstAddrTypeMap := make(map[ir.DestinationAddressType]int)
+		hasOriginalDestination := false
 
 		for _, backendRef := range rule.BackendRefs {
 			ds := t.processDestination(backendRef, parentRef, httpRoute, resources)
 
+			if ds != nil && ds.OriginalDestination != nil {
+				hasOriginalDestination = true
+			}
+
 			if !t.IsEnvoyServiceRouting(envoyProxy) && ds != nil && len(ds.Endpoints) > 0 && ds.AddressType != nil {
 				dstAddrTypeMap[*ds.AddressType]++
 			}
@@ -234,6 +255,18 @@ func (t *Translator) processHTTPRouteRules(httpRoute *HTTPRouteContext, parentRe
 				"Mixed endpointslice address type between backendRefs is not supported")
 		}
 
+		// The traffic of an original destination backend can't be split with other backendRefs.
+		if hasOriginalDestination && len(rule.BackendRefs) > 1 {
+			routeStatus := GetRouteStatus(httpRoute)
+			status.SetRouteStatusCondition(routeStatus,
+				parentRef.routeParentStatusIdx,
+				httpRoute.GetGeneration(),
+				gwapiv1.RouteConditionResolvedRefs,
+				metav1.ConditionFalse,
+				gwapiv1.RouteReasonResolvedRefs,
+				"Original destination Backends cannot be mixed with other backendRefs")
+		}
+
 		// If the route has no valid backends then just use a direct response and don't fuss with weighted responses
 		for _, ruleRoute := range ruleRoutes {
 			noValidBackends := ruleRoute.Destination == nil || ruleRoute.Destination.ToBackendWeights().Valid == 0
@@ -400,7 +433,13 @@ func (t *Translator) processHTTPRouteRule(httpRoute *HTTPRouteContext, ruleIdx i
 				})
 			}
 		}
+		queryParamNames := sets.New[gwapiv1.HTTPHeaderName]()
 		for _, queryParamMatch := range match.QueryParams {
+			// Only the first match of a query parameter is considered.
+			if queryParamNames.Has(queryParamMatch.Name) {
+				continue
+			}
+			queryParamNames.Insert(queryParamMatch.Name)
 			switch QueryParamMatchTypeDerefOr(queryParamMatch.Type, gwapiv1.QueryParamMatchExact) {
 			case gwapiv1.QueryParamMatchExact:
 				irRoute.QueryParamMatches = append(irRoute.QueryParamMatches, &ir.StringMatch{
@@ -442,6 +481,12 @@ func applyHTTPFiltersContextToIRRoute(httpFiltersContext *HTTPFiltersContext, ir
 	if httpFiltersContext.URLRewrite != nil {
 		irRoute.URLRewrite = httpFiltersContext.URLRewrite
 	}
+	if httpFiltersContext.RequestNormalization != nil {
+		irRoute.RequestNormalization = httpFiltersContext.RequestNormalization
+	}
+	if len(httpFiltersContext.QueryParamMatches) > 0 {
+		irRoute.QueryParamMatches = append(irRoute.QueryParamMatches, httpFiltersContext.QueryParamMatches...)
+	}
 	if len(httpFiltersContext.AddRequestHeaders) > 0 {
 		irRoute.AddRequestHeaders = httpFiltersContext.AddRequestHeaders
 	}
@@ -499,6 +544,19 @@ func (t *Translator) processGRPCRouteParentRefs(grpcRoute *GRPCRouteContext, res
 		if parentRef.HasCondition(grpcRoute, gwapiv1.RouteConditionAccepted, metav1.ConditionFalse) {
 			continue
 		}
+		if err := t.checkHostnameDelegation(grpcRoute, parentRef); err != nil {
+			routeStatus := GetRouteStatus(grpcRoute)
+			status.SetRouteStatusCondition(routeStatus,
+				parentRef.routeParentStatusIdx,
+				grpcRoute.GetGeneration(),
+				gwapiv1.RouteConditionAccepted,
+				metav1.ConditionFalse,
+				RouteReasonHostnameNotDelegated,
+				status.Error2ConditionMsg(err),
+			)
+			continue
+		}
+
 		hasHostnameIntersection := t.processHTTPRouteParentRefListener(grpcRoute, routeRoutes, parentRef, xdsIR)
 		if !hasHostnameIntersection {
 			routeStatus := GetRouteStatus(grpcRoute)
@@ -697,6 +755,7 @@ func (t *Translator) processHTTPRouteParentRefListener(route RouteContext, route
 		}
 		hasHostnameIntersection = true
 		routeMetadata := buildRouteMetadata(route)
+		nodeGroups := routeNodeGroups(route)
 
 		var perHostRoutes []*ir.HTTPRoute
 		for _, host := range hosts {
@@ -721,9 +780,15 @@ func (t *Translator) processHTTPRouteParentRefListener(route RouteContext, route
 				// Remove dots from the hostname before appending it to the IR Route name
 				// since dots are special chars used in stats tag extraction in Envoy
 				underscoredHost := strings.ReplaceAll(host, ".", "_")
+				destination := routeRoute.Destination
+				if t.MergeGateways && destination != nil {
+					destination = destination.DeepCopy()
+					destination.Name = t.irListenerDestinationName(listener, destination.Name)
+				}
 				hostRoute := &ir.HTTPRoute{
 					Name:                  fmt.Sprintf("%s/%s", routeRoute.Name, underscoredHost),
 					Metadata:              routeMetadata,
+					NodeGroups:            nodeGroups,
 					Hostname:              host,
 					PathMatch:             routeRoute.PathMatch,
 					HeaderMatches:         routeRoute.HeaderMatches,
@@ -732,10 +797,11 @@ func (t *Translator) processHTTPRouteParentRefListener(route RouteContext, route
 					RemoveRequestHeaders:  routeRoute.RemoveRequestHeaders,
 					AddResponseHeaders:    routeRoute.AddResponseHeaders,
 					RemoveResponseHeaders: routeRoute.RemoveResponseHeaders,
-					Destination:           routeRoute.Destination,
+					Destination:           destination,
 					Redirect:              routeRoute.Redirect,
 					DirectResponse:        routeRoute.DirectResponse,
 					URLRewrite:            routeRoute.URLRewrite,
+					RequestNormalization:  routeRoute.RequestNormalization,
 					Mirrors:               routeRoute.Mirrors,
 					ExtensionRefs:         routeRoute.ExtensionRefs,
 					IsHTTP2:               routeRoute.IsHTTP2,
@@ -764,15 +830,46 @@ func (t *Translator) processHTTPRouteParentRefListener(route RouteContext, route
 	return hasHostnameIntersection
 }
 
+// irListenerDestinationName returns the name of the destination of a route attached to the listener.
+// Merged Gateways share the same xds IR, so the name is scoped to the Gateway of the listener: a route
+// attached to several merged Gateways gets a dedicated destination per Gateway, which the policies
+// attached to each Gateway configure independently.
+func (t *Translator) irListenerDestinationName(listener *ListenerContext, name string) string {
+	if !t.MergeGateways {
+		return name
+	}
+	return fmt.Sprintf("%s/%s", irStringKey(listener.gateway.Namespace, listener.gateway.Name), name)
+}
+
 func buildRouteMetadata(route RouteContext) *ir.ResourceMetadata {
+	gv := resourceGroupVersion(route)
 	return &ir.ResourceMetadata{
 		Kind:        route.GetObjectKind().GroupVersionKind().Kind,
+		Group:       gv.Group,
+		Version:     gv.Version,
 		Name:        route.GetName(),
 		Namespace:   route.GetNamespace(),
+		Generation:  route.GetGeneration(),
 		Annotations: filterEGPrefix(route.GetAnnotations()),
 	}
 }
 
+// resourceGroupVersion returns the API group and version of the Gateway API resource, which
+// are derived from its kind when the type meta of the resource only holds its kind.
+func resourceGroupVersion(obj client.Object) schema.GroupVersion {
+	gvk := obj.GetObjectKind().GroupVersionKind()
+	switch {
+	case gvk.Group != "" || gvk.Version != "":
+		return gvk.GroupVersion()
+	case gvk.Kind == "":
+		return schema.GroupVersion{}
+	case gvk.Kind == resource.KindTLSRoute || gvk.Kind == resource.KindTCPRoute || gvk.Kind == resource.KindUDPRoute:
+		return schema.GroupVersion(gwapiv1a2.GroupVersion)
+	default:
+		return schema.GroupVersion(gwapiv1.GroupVersion)
+	}
+}
+
 func filterEGPrefix(in map[string]string) map[string]string {
 	out := map[string]string{}
 	for k, v := range in {
@@ -856,6 +953,19 @@ func (t *Translator) processTLSRouteParentRefs(tlsRoute *TLSRouteContext, resour
 			continue
 		}
 
+		if err := t.checkHostnameDelegation(tlsRoute, parentRef); err != nil {
+			routeStatus := GetRouteStatus(tlsRoute)
+			status.SetRouteStatusCondition(routeStatus,
+				parentRef.routeParentStatusIdx,
+				tlsRoute.GetGeneration(),
+				gwapiv1.RouteConditionAccepted,
+				metav1.ConditionFalse,
+				RouteReasonHostnameNotDelegated,
+				status.Error2ConditionMsg(err),
+			)
+			continue
+		}
+
 		var hasHostnameIntersection bool
 		for _, listener := range parentRef.listeners {
 			hosts := computeHosts(GetHostnames(tlsRoute), listener)
@@ -876,7 +986,7 @@ func (t *Translator) processTLSRouteParentRefs(tlsRoute *TLSRouteContext, resour
 						SNIs: hosts,
 					}},
 					Destination: &ir.RouteDestination{
-						Name:     irRouteDestinationName(tlsRoute, -1 /*rule index*/),
+						Name:     t.irListenerDestinationName(listener, irRouteDestinationName(tlsRoute, -1 /*rule index*/)),
 						Settings: destSettings,
 					},
 				}
@@ -1010,7 +1120,7 @@ func (t *Translator) processUDPRouteParentRefs(udpRoute *UDPRouteContext, resour
 				irRoute := &ir.UDPRoute{
 					Name: irUDPRouteName(udpRoute),
 					Destination: &ir.RouteDestination{
-						Name:     irRouteDestinationName(udpRoute, -1 /*rule index*/),
+						Name:     t.irListenerDestinationName(listener, irRouteDestinationName(udpRoute, -1 /*rule index*/)),
 						Settings: destSettings,
 					},
 				}
@@ -1142,7 +1252,7 @@ func (t *Translator) processTCPRouteParentRefs(tcpRoute *TCPRouteContext, resour
 				irRoute := &ir.TCPRoute{
 					Name: irTCPRouteName(tcpRoute),
 					Destination: &ir.RouteDestination{
-						Name:     irRouteDestinationName(tcpRoute, -1 /*rule index*/),
+						Name:     t.irListenerDestinationName(listener, irRouteDestinationName(tcpRoute, -1 /*rule index*/)),
 						Settings: destSettings,
 					},
 				}
@@ -1239,9 +1349,9 @@ func (t *Translator) processDestination(backendRefContext BackendRefContext,
 
 		if !t.IsEnvoyServiceRouting(envoyProxy) {
 			endpointSlices := resources.GetEndpointSlicesForBackend(backendNamespace, string(backendRef.Name), KindDerefOr(backendRef.Kind, resource.KindService))
-			endpoints, addrType = getIREndpointsFromEndpointSlices(endpointSlices, servicePort.Name, servicePort.Protocol)
+			endpoints, addrType = getIREndpointsFromEndpointSlices(endpointSlicesOfIPFamily(endpointSlices, t.getEnvoyIPFamily(envoyProxy)), servicePort.Name, servicePort.Protocol)
 		} else {
-			backendIps := resources.GetServiceImport(backendNamespace, string(backendRef.Name)).Spec.IPs
+			backendIps := ipsOfIPFamily(resources.GetServiceImport(backendNamespace, string(backendRef.Name)).Spec.IPs, t.getEnvoyIPFamily(envoyProxy))
 			for _, ip := range backendIps {
 				ep := ir.NewDestEndpoint(
 					ip,
@@ -1357,13 +1467,19 @@ func (t *Translator) processServiceDestinationSetting(
 	}
 
 	// Route to endpoints by default
+	ipFamily := t.getEnvoyIPFamily(envoyProxy)
 	if !t.IsEnvoyServiceRouting(envoyProxy) {
 		endpointSlices := resources.GetEndpointSlicesForBackend(backendNamespace, string(backendRef.Name), KindDerefOr(backendRef.Kind, resource.KindService))
-		endpoints, addrType = getIREndpointsFromEndpointSlices(endpointSlices, servicePort.Name, servicePort.Protocol)
+		endpoints, addrType = getIREndpointsFromEndpointSlices(endpointSlicesOfIPFamily(endpointSlices, ipFamily), servicePort.Name, servicePort.Protocol)
 	} else {
-		// Fall back to Service ClusterIP routing
+		// Fall back to Service ClusterIP routing, to the ClusterIP of the IP family
+		// of the proxies on dual-stack Services.
+		clusterIP := service.Spec.ClusterIP
+		if clusterIPs := ipsOfIPFamily(service.Spec.ClusterIPs, ipFamily); len(clusterIPs) > 0 {
+			clusterIP = clusterIPs[0]
+		}
 		ep := ir.NewDestEndpoint(
-			service.Spec.ClusterIP,
+			clusterIP,
 			uint32(*backendRef.Port))
 		endpoints = append(endpoints, ep)
 	}
@@ -1449,6 +1565,7 @@ func inspectAppProtocolByRouteKind(kind gwapiv1.Kind) ir.AppProtocol {
 func (t *Translator) processAllowedListenersForParentRefs(routeContext RouteContext, gateways []*GatewayContext, resources *resource.Resources) bool {
 	var relevantRoute bool
 	ns := gwapiv1.Namespace(routeContext.GetNamespace())
+	internalRoute, exposureErr := routeExposure(routeContext)
 	for _, parentRef := range GetParentReferences(routeContext) {
 		isRelevantParentRef, selectedListeners := GetReferencedListeners(ns, parentRef, gateways)
 
@@ -1497,6 +1614,46 @@ func (t *Translator) processAllowedListenersForParentRefs(routeContext RouteCont
 			continue
 		}
 
+		if exposureErr != nil {
+			routeStatus := GetRouteStatus(routeContext)
+			status.SetRouteStatusCondition(routeStatus,
+				parentRefCtx.routeParentStatusIdx,
+				routeContext.GetGeneration(),
+				gwapiv1.RouteConditionAccepted,
+				metav1.ConditionFalse,
+				gwapiv1.RouteReasonUnsupportedValue,
+				status.Error2ConditionMsg(exposureErr),
+			)
+			continue
+		}
+
+		// Internal routes are only attached to the internal listeners.
+		var externalListeners []string
+		if internalRoute {
+			internalListeners := make([]*ListenerContext, 0, len(allowedListeners))
+			for _, listener := range allowedListeners {
+				if listener.IsInternal() {
+					internalListeners = append(internalListeners, listener)
+				} else {
+					externalListeners = append(externalListeners, string(listener.Name))
+				}
+			}
+			allowedListeners = internalListeners
+		}
+		if len(allowedListeners) == 0 {
+			routeStatus := GetRouteStatus(routeContext)
+			status.SetRouteStatusCondition(routeStatus,
+				parentRefCtx.routeParentStatusIdx,
+				routeContext.GetGeneration(),
+				gwapiv1.RouteConditionAccepted,
+				metav1.ConditionFalse,
+				gwapiv1.RouteReasonNotAllowedByListeners,
+				fmt.Sprintf("Internal route is not allowed to attach to the external listeners: %s.",
+					strings.Join(externalListeners, ", ")),
+			)
+			continue
+		}
+
 		// Its safe to increment AttachedRoutes since we've found a valid parentRef
 		// and the listener allows this Route kind
 
@@ -1522,6 +1679,11 @@ func (t *Translator) processAllowedListenersForParentRefs(routeContext RouteCont
 
 		parentRefCtx.SetListeners(allowedListeners...)
 
+		message := "Route is accepted"
+		if len(externalListeners) > 0 {
+			message = fmt.Sprintf("Route is accepted, except on the external listeners: %s",
+				strings.Join(externalListeners, ", "))
+		}
 		routeStatus := GetRouteStatus(routeContext)
 		status.SetRouteStatusCondition(routeStatus,
 			parentRefCtx.routeParentStatusIdx,
@@ -1529,7 +1691,7 @@ func (t *Translator) processAllowedListenersForParentRefs(routeContext RouteCont
 			gwapiv1.RouteConditionAccepted,
 			metav1.ConditionTrue,
 			gwapiv1.RouteReasonAccepted,
-			"Route is accepted",
+			message,
 		)
 	}
 	return relevantRoute
@@ -1566,6 +1728,61 @@ func getIREndpointsFromEndpointSlices(endpointSlices []*discoveryv1.EndpointSlic
 	return dstEndpoints, dstAddrType
 }
 
+// endpointSlicesOfIPFamily returns the EndpointSlices whose addresses the proxies of the IP
+// family reach, the FQDN EndpointSlices included. The EndpointSlices of both families of the
+// dual-stack Services are kept for the dual-stack proxies, or when the IP family is not
+// known, and all the EndpointSlices are kept if none is of the IP family of the proxies.
+func endpointSlicesOfIPFamily(endpointSlices []*discoveryv1.EndpointSlice, ipFamily *egv1a1.IPFamily) []*discoveryv1.EndpointSlice {
+	var addressType discoveryv1.AddressType
+	switch ptr.Deref(ipFamily, egv1a1.DualStack) {
+	case egv1a1.IPv4:
+		addressType = discoveryv1.AddressTypeIPv4
+	case egv1a1.IPv6:
+		addressType = discoveryv1.AddressTypeIPv6
+	default:
+		return endpointSlices
+	}
+
+	var matched []*discoveryv1.EndpointSlice
+	found := false
+	for _, endpointSlice := range endpointSlices {
+		switch endpointSlice.AddressType {
+		case addressType:
+			found = true
+			matched = append(matched, endpointSlice)
+		case discoveryv1.AddressTypeFQDN:
+			matched = append(matched, endpointSlice)
+		}
+	}
+	if !found {
+		return endpointSlices
+	}
+	return matched
+}
+
+// ipsOfIPFamily returns the IPs of the IP family, or all the IPs if the IP family is
+// dual-stack, not known, or none of the IPs is of the IP family.
+func ipsOfIPFamily(ips []string, ipFamily *egv1a1.IPFamily) []string {
+	if ipFamily == nil || *ipFamily == egv1a1.DualStack {
+		return ips
+	}
+
+	var matched []string
+	for _, ip := range ips {
+		addr := net.ParseIP(ip)
+		if addr == nil {
+			continue
+		}
+		if (addr.To4() != nil) == (*ipFamily == egv1a1.IPv4) {
+			matched = append(matched, ip)
+		}
+	}
+	if len(matched) == 0 {
+		return ips
+	}
+	return matched
+}
+
 func getIREndpointsFromEndpointSlice(endpointSlice *discoveryv1.EndpointSlice, portName string, portProtocol corev1.Protocol) []*ir.DestinationEndpoint {
 	var endpoints []*ir.DestinationEndpoint
 	for _, endpoint := range endpointSlice.Endpoints {
@@ -1626,6 +1843,14 @@ func (t *Translator) processBackendDestinationSetting(backendRef gwapiv1.Backend
 	addrTypeMap := make(map[ir.DestinationAddressType]int)
 
 	backend := resources.GetBackend(backendNamespace, string(backendRef.Name))
+	if backend.Spec.OriginalDestination != nil {
+		return &ir.DestinationSetting{
+			OriginalDestination: &ir.OriginalDestination{
+				HeaderName: backend.Spec.OriginalDestination.HeaderName,
+			},
+		}
+	}
+
 	for _, bep := range backend.Spec.Endpoints {
 		var irde *ir.DestinationEndpoint
 		switch {
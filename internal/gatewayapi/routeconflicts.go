@@ -0,0 +1,259 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package gatewayapi
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	gwapiv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	egv1a1 "github.com/envoyproxy/gateway/api/v1alpha1"
+	"github.com/envoyproxy/gateway/internal/gatewayapi/resource"
+	"github.com/envoyproxy/gateway/internal/gatewayapi/status"
+	"github.com/envoyproxy/gateway/internal/ir"
+)
+
+const (
+	// RouteConditionConflicted is the condition of a route with rules dropped because an
+	// older route claims the same hostname and path.
+	RouteConditionConflicted gwapiv1.RouteConditionType = "Conflicted"
+	// RouteReasonOlderRouteWins is the reason of the Conflicted condition.
+	RouteReasonOlderRouteWins gwapiv1.RouteConditionReason = "OlderRouteWins"
+	// RouteReasonConflictsWithOlderRoute is the reason of the Accepted condition of a route
+	// rejected because an older route claims the same hostname and path.
+	RouteReasonConflictsWithOlderRoute gwapiv1.RouteConditionReason = "ConflictsWithOlderRoute"
+)
+
+// routeClaim is a hostname and path claimed by the routes of a listener.
+type routeClaim struct {
+	hostname, path string
+}
+
+// processRouteConflicts resolves the conflicts between the routes of different route objects
+// claiming the same hostname and path on a listener, with the strategy of the EnvoyProxy of
+// their Gateway, and surfaces the routes losing a conflict on their status.
+func (t *Translator) processRouteConflicts(gateways []*GatewayContext, xdsIR resource.XdsIRMap, routes []RouteContext) {
+	strategies := make(map[string]egv1a1.RouteConflictStrategy)
+	for _, gateway := range gateways {
+		if gateway.envoyProxy != nil && gateway.envoyProxy.Spec.RouteConflictResolution != nil {
+			strategies[t.getIRKey(gateway.Gateway)] = gateway.envoyProxy.Spec.RouteConflictResolution.Strategy
+		}
+	}
+	if len(strategies) == 0 {
+		return
+	}
+
+	routesByKey := make(map[routeKey]RouteContext, len(routes))
+	for _, route := range routes {
+		routesByKey[routeKey{kind: string(GetRouteType(route)), namespace: route.GetNamespace(), name: route.GetName()}] = route
+	}
+	older := func(a, b routeKey) bool {
+		return routeOlder(routesByKey[a], routesByKey[b], a, b)
+	}
+
+	conflicts := make(map[listenerRouteKey][]string)
+	rejected := make(map[listenerRouteKey]bool)
+	for irKey, strategy := range strategies {
+		for _, listener := range xdsIR[irKey].HTTP {
+			var lost map[routeKey][]string
+			switch strategy {
+			case egv1a1.RouteConflictStrategyMostSpecificWins:
+				sortRoutesBySpecificity(listener.Routes, older)
+			case egv1a1.RouteConflictStrategyOldestWins:
+				listener.Routes, lost = dropConflictingRouteMatches(listener.Routes, older)
+			case egv1a1.RouteConflictStrategyReject:
+				listener.Routes, lost = dropConflictingRoutes(listener.Routes, older)
+			}
+			for key, messages := range lost {
+				k := listenerRouteKey{listener: listener.Name, route: key}
+				conflicts[k] = append(conflicts[k], messages...)
+				rejected[k] = strategy == egv1a1.RouteConflictStrategyReject
+			}
+		}
+	}
+	if len(conflicts) == 0 {
+		return
+	}
+
+	for _, route := range routes {
+		key := routeKey{kind: string(GetRouteType(route)), namespace: route.GetNamespace(), name: route.GetName()}
+		for _, parentRef := range GetParentReferences(route) {
+			parentRefCtx := GetRouteParentContext(route, parentRef)
+			var messages []string
+			var isRejected bool
+			for _, listener := range parentRefCtx.listeners {
+				k := listenerRouteKey{listener: irListenerName(listener), route: key}
+				messages = append(messages, conflicts[k]...)
+				isRejected = isRejected || rejected[k]
+			}
+			if len(messages) == 0 {
+				continue
+			}
+
+			conditionType, conditionStatus, reason := RouteConditionConflicted, metav1.ConditionTrue, RouteReasonOlderRouteWins
+			if isRejected {
+				conditionType, conditionStatus, reason = gwapiv1.RouteConditionAccepted, metav1.ConditionFalse, RouteReasonConflictsWithOlderRoute
+			}
+			status.SetRouteStatusCondition(GetRouteStatus(route),
+				parentRefCtx.routeParentStatusIdx,
+				route.GetGeneration(),
+				conditionType,
+				conditionStatus,
+				reason,
+				conditionMessage(messages),
+			)
+		}
+	}
+}
+
+// sortRoutesBySpecificity sorts the routes in the order of the precedence of their matches,
+// the routes of the older route objects first among the routes with equally specific matches.
+func sortRoutesBySpecificity(routes []*ir.HTTPRoute, older func(a, b routeKey) bool) {
+	sort.SliceStable(routes, func(i, j int) bool {
+		x := XdsIRRoutes(routes)
+		if x.Less(j, i) {
+			return true
+		}
+		if x.Less(i, j) || routes[i].Metadata == nil || routes[j].Metadata == nil {
+			return false
+		}
+		return older(irRouteKey(routes[i]), irRouteKey(routes[j]))
+	})
+}
+
+// dropConflictingRouteMatches drops the routes claiming a hostname and path claimed by an
+// older route object, and returns the descriptions of the dropped matches by route.
+func dropConflictingRouteMatches(routes []*ir.HTTPRoute, older func(a, b routeKey) bool) ([]*ir.HTTPRoute, map[routeKey][]string) {
+	owners := routeClaimOwners(routes, older)
+	lost := make(map[routeKey][]string)
+	kept := routes[:0]
+	for _, route := range routes {
+		if route.Metadata != nil {
+			key := irRouteKey(route)
+			claim := irRouteClaim(route)
+			if owner := owners[claim]; owner != key {
+				lost[key] = append(lost[key], fmt.Sprintf("%s conflicts with %s %s/%s on hostname %s",
+					irRouteMatchName(route), owner.kind, owner.namespace, owner.name, claim.hostname))
+				continue
+			}
+		}
+		kept = append(kept, route)
+	}
+	return kept, lost
+}
+
+// dropConflictingRoutes drops all the routes of the route objects claiming a hostname and path
+// claimed by an older route object, and returns the descriptions of the conflicts by route.
+// The route objects are accepted from the oldest one, so that a route object only conflicts
+// with the route objects kept.
+func dropConflictingRoutes(routes []*ir.HTTPRoute, older func(a, b routeKey) bool) ([]*ir.HTTPRoute, map[routeKey][]string) {
+	claims := make(map[routeKey][]routeClaim)
+	var keys []routeKey
+	for _, route := range routes {
+		if route.Metadata == nil {
+			continue
+		}
+		key := irRouteKey(route)
+		if _, ok := claims[key]; !ok {
+			keys = append(keys, key)
+		}
+		claims[key] = append(claims[key], irRouteClaim(route))
+	}
+	sort.SliceStable(keys, func(i, j int) bool { return older(keys[i], keys[j]) })
+
+	owners := make(map[routeClaim]routeKey)
+	lost := make(map[routeKey][]string)
+	for _, key := range keys {
+		for _, claim := range claims[key] {
+			if owner, ok := owners[claim]; ok && owner != key {
+				lost[key] = append(lost[key], fmt.Sprintf("path %s conflicts with %s %s/%s on hostname %s",
+					claim.path, owner.kind, owner.namespace, owner.name, claim.hostname))
+			}
+		}
+		if len(lost[key]) > 0 {
+			continue
+		}
+		for _, claim := range claims[key] {
+			owners[claim] = key
+		}
+	}
+
+	kept := routes[:0]
+	for _, route := range routes {
+		if route.Metadata != nil && len(lost[irRouteKey(route)]) > 0 {
+			continue
+		}
+		kept = append(kept, route)
+	}
+	return kept, lost
+}
+
+// routeClaimOwners returns the oldest route object claiming each hostname and path.
+func routeClaimOwners(routes []*ir.HTTPRoute, older func(a, b routeKey) bool) map[routeClaim]routeKey {
+	owners := make(map[routeClaim]routeKey)
+	for _, route := range routes {
+		if route.Metadata == nil {
+			continue
+		}
+		key := irRouteKey(route)
+		claim := irRouteClaim(route)
+		if owner, ok := owners[claim]; !ok || older(key, owner) {
+			owners[claim] = key
+		}
+	}
+	return owners
+}
+
+// routeOlder returns true if the route a was created before the route b, or at the same time
+// with a namespace and name first in alphabetical order, as the Gateway API breaks the ties
+// between the routes.
+func routeOlder(a, b RouteContext, aKey, bKey routeKey) bool {
+	if a != nil && b != nil {
+		aTime, bTime := a.GetCreationTimestamp(), b.GetCreationTimestamp()
+		if !aTime.Equal(&bTime) {
+			return aTime.Before(&bTime)
+		}
+	}
+	if aKey.namespace != bKey.namespace {
+		return aKey.namespace < bKey.namespace
+	}
+	if aKey.name != bKey.name {
+		return aKey.name < bKey.name
+	}
+	return aKey.kind < bKey.kind
+}
+
+// irRouteKey returns the key of the route object of the IR route.
+func irRouteKey(route *ir.HTTPRoute) routeKey {
+	return routeKey{kind: route.Metadata.Kind, namespace: route.Metadata.Namespace, name: route.Metadata.Name}
+}
+
+// irRouteClaim returns the hostname and path claimed by the IR route.
+func irRouteClaim(route *ir.HTTPRoute) routeClaim {
+	path := "prefix /"
+	switch match := route.PathMatch; {
+	case match == nil:
+	case match.Exact != nil:
+		path = "exact " + *match.Exact
+	case match.Prefix != nil:
+		path = "prefix " + *match.Prefix
+	case match.SafeRegex != nil:
+		path = "regex " + *match.SafeRegex
+	}
+	return routeClaim{hostname: route.Hostname, path: path}
+}
+
+// conditionMessage joins the items of a condition message, up to the maximum number of items.
+func conditionMessage(messages []string) string {
+	if len(messages) > maxConditionMessageItems {
+		return fmt.Sprintf("%s; and %d more", strings.Join(messages[:maxConditionMessageItems], "; "),
+			len(messages)-maxConditionMessageItems)
+	}
+	return strings.Join(messages, "; ")
+}
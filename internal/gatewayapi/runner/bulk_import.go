@@ -0,0 +1,173 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package runner
+
+import (
+	"context"
+	"runtime"
+	"slices"
+
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/utils/ptr"
+	gwapiv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	"github.com/envoyproxy/gateway/internal/gatewayapi/resource"
+	"github.com/envoyproxy/gateway/internal/ir"
+	"github.com/envoyproxy/gateway/internal/metrics"
+	"github.com/envoyproxy/gateway/internal/utils"
+)
+
+const (
+	// defaultBulkImportThreshold is the default number of the new HTTPRoutes above
+	// which they are translated in batches.
+	defaultBulkImportThreshold = 1000
+	// defaultBulkImportBatchSize is the default number of the new HTTPRoutes added
+	// to the translation with each batch.
+	defaultBulkImportBatchSize = 500
+)
+
+var bulkImportPendingRoutes = metrics.NewGauge(
+	"bulk_import_pending_routes",
+	"Number of the HTTPRoutes of the bulk import in progress not translated yet.",
+)
+
+// bulkImportSettings returns the threshold and the batch size of the bulk imports,
+// and whether the bulk imports are enabled.
+func (r *Runner) bulkImportSettings() (threshold, batchSize int, enabled bool) {
+	bulkImport := r.EnvoyGateway.BulkImport
+	if bulkImport == nil {
+		return 0, 0, false
+	}
+	threshold = int(ptr.Deref(bulkImport.Threshold, defaultBulkImportThreshold))
+	batchSize = int(ptr.Deref(bulkImport.BatchSize, defaultBulkImportBatchSize))
+	return threshold, batchSize, true
+}
+
+// translateInBatches publishes the IRs of the resources with the HTTPRoutes not translated
+// yet added batch after batch, when there are more new HTTPRoutes than the threshold of the
+// bulk imports. The resources are then translated with all their routes as usual, which
+// publishes the statuses.
+//
+// Only the first batch is translated with the HTTPRoutes already translated: the others are
+// translated with their new HTTPRoutes alone, and their routes are merged into the IRs
+// published by the previous batches, so that each HTTPRoute is translated once across the
+// batches. The runner yields in between the batches, and stops once the context is done.
+//
+// The first translation is never batched, so that the proxies still served the
+// configuration of a previous run of Envoy Gateway don't lose any of its routes.
+func (r *Runner) translateInBatches(ctx context.Context, resources *resource.Resources, errChan chan error) {
+	threshold, batchSize, enabled := r.bulkImportSettings()
+	if !enabled || r.translatedHTTPRoutes == nil {
+		return
+	}
+
+	var translated, pending []*gwapiv1.HTTPRoute
+	for _, route := range resources.HTTPRoutes {
+		if r.translatedHTTPRoutes.Has(utils.NamespacedName(route)) {
+			translated = append(translated, route)
+		} else {
+			pending = append(pending, route)
+		}
+	}
+	if len(pending) <= threshold {
+		return
+	}
+
+	r.Logger.Info("translating the new HTTPRoutes in batches", "routes", len(pending), "batchSize", batchSize)
+	defer bulkImportPendingRoutes.Record(0)
+	var published resource.XdsIRMap
+	// The last batch is translated with all the routes.
+	for start := 0; start+batchSize < len(pending); start += batchSize {
+		select {
+		case <-ctx.Done():
+			return
+		default:
+			runtime.Gosched()
+		}
+
+		routes := pending[start : start+batchSize]
+		if start == 0 {
+			routes = append(slices.Clip(translated), routes...)
+		}
+		batch := httpRoutesBatch(resources, routes)
+		result, err := r.newTranslator(batch).Translate(batch)
+		if err != nil {
+			r.Logger.Error(err, "errors detected during translation")
+		}
+		if published != nil {
+			for key, xds := range result.XdsIR {
+				result.XdsIR[key] = mergeHTTPRoutes(published[key], xds)
+			}
+			// The infra IRs don't change with the routes.
+			result.InfraIR = nil
+		}
+		r.publishIRs(ctx, result, errChan)
+		published = result.XdsIR
+
+		bulkImportPendingRoutes.Record(float64(len(pending) - start - batchSize))
+		r.Logger.Info("translated a batch of the new HTTPRoutes", "translated", start+batchSize, "routes", len(pending))
+	}
+}
+
+// httpRoutesBatch returns a copy of the resources holding the given HTTPRoutes only, so
+// that the translation of the batch doesn't change the resources.
+func httpRoutesBatch(resources *resource.Resources, routes []*gwapiv1.HTTPRoute) *resource.Resources {
+	withoutRoutes := *resources
+	withoutRoutes.HTTPRoutes = nil
+	batch := withoutRoutes.DeepCopy()
+	batch.HTTPRoutes = make([]*gwapiv1.HTTPRoute, 0, len(routes))
+	for _, route := range routes {
+		batch.HTTPRoutes = append(batch.HTTPRoutes, route.DeepCopy())
+	}
+	return batch
+}
+
+// mergeHTTPRoutes returns a copy of the published IR with the routes of the IR of a batch
+// it doesn't hold yet added to its HTTP listeners. The IR of the batch is returned if no IR
+// was published for its key.
+func mergeHTTPRoutes(published, batch *ir.Xds) *ir.Xds {
+	if published == nil {
+		return batch
+	}
+	merged := *published
+	merged.HTTP = make([]*ir.HTTPListener, 0, len(published.HTTP))
+	listeners := make(map[string]*ir.HTTPListener, len(published.HTTP))
+	for _, l := range published.HTTP {
+		listener := *l
+		listener.Routes = slices.Clone(l.Routes)
+		merged.HTTP = append(merged.HTTP, &listener)
+		listeners[listener.Name] = &listener
+	}
+	for _, l := range batch.HTTP {
+		listener, ok := listeners[l.Name]
+		if !ok {
+			merged.HTTP = append(merged.HTTP, l)
+			continue
+		}
+		names := sets.New[string]()
+		for _, route := range listener.Routes {
+			names.Insert(route.Name)
+		}
+		for _, route := range l.Routes {
+			if !names.Has(route.Name) {
+				listener.Routes = append(listener.Routes, route)
+			}
+		}
+	}
+	return &merged
+}
+
+// recordTranslatedHTTPRoutes records the HTTPRoutes of the controllers translated.
+func (r *Runner) recordTranslatedHTTPRoutes(controllers map[string]*resource.ControllerResources) {
+	translated := sets.New[types.NamespacedName]()
+	for _, resources := range controllerResources(controllers) {
+		for _, route := range resources.HTTPRoutes {
+			translated.Insert(utils.NamespacedName(route))
+		}
+	}
+	r.translatedHTTPRoutes = translated
+}
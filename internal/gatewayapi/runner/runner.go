@@ -12,8 +12,12 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"net"
 	"os"
 	"reflect"
+	"sort"
+	"sync"
+	"time"
 
 	"github.com/docker/docker/pkg/fileutils"
 	kerrors "k8s.io/apimachinery/pkg/api/errors"
@@ -22,6 +26,7 @@ import (
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/client-go/kubernetes"
+	"k8s.io/utils/ptr"
 	ctrl "sigs.k8s.io/controller-runtime"
 	gwapiv1 "sigs.k8s.io/gateway-api/apis/v1"
 	gwapiv1a2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
@@ -54,11 +59,25 @@ type Config struct {
 type Runner struct {
 	Config
 	wasmCache wasm.Cache
+
+	// translateMu serializes the translations of the resource updates and
+	// of the preview expiries.
+	translateMu sync.Mutex
+	// previewTimer re-translates the resources when the next preview expires.
+	previewTimer *time.Timer
+	// previewErrs receives the errors of the translations of the preview expiries, which
+	// outlive the updates they are scheduled by. It is never closed, since a preview timer
+	// may still fire while the runner shuts down.
+	previewErrs chan error
+	// translatedHTTPRoutes are the HTTPRoutes of the last translation, to tell the
+	// new HTTPRoutes of a bulk import.
+	translatedHTTPRoutes sets.Set[types.NamespacedName]
 }
 
 func New(cfg *Config) *Runner {
 	return &Runner{
-		Config: *cfg,
+		Config:      *cfg,
+		previewErrs: make(chan error, 10),
 	}
 }
 
@@ -75,8 +94,10 @@ func (r *Runner) Name() string {
 // Start starts the gateway-api translator runner
 func (r *Runner) Start(ctx context.Context) (err error) {
 	r.Logger = r.Logger.WithName(r.Name()).WithValues("runner", r.Name())
+	gatewayapi.RecordFeatureGates(r.EnvoyGateway.ResolvedFeatureGates())
 
 	go r.startWasmCache(ctx)
+	go message.ReportErrors(providerResourcesMetadata, r.previewErrs)
 	go r.subscribeAndTranslate(ctx)
 	r.Logger.Info("started")
 	return
@@ -114,189 +135,302 @@ func (r *Runner) startWasmCache(ctx context.Context) {
 	r.wasmCache.Start(ctx)
 }
 
+// providerResourcesMetadata is the metadata of the subscription to the provider resources.
+var providerResourcesMetadata = message.Metadata{Runner: string(egv1a1.LogComponentGatewayAPIRunner), Message: "provider-resources"}
+
 func (r *Runner) subscribeAndTranslate(ctx context.Context) {
-	message.HandleSubscription(message.Metadata{Runner: string(egv1a1.LogComponentGatewayAPIRunner), Message: "provider-resources"}, r.ProviderResources.GatewayAPIResources.Subscribe(ctx),
+	message.HandleTracedSubscription(providerResourcesMetadata, r.ProviderResources.GatewayAPIResources.Subscribe(ctx),
 		func(update message.Update[string, *resource.ControllerResources], errChan chan error) {
-			r.Logger.Info("received an update")
-			val := update.Value
-			// There is only 1 key which is the controller name
-			// so when a delete is triggered, delete all IR keys
-			if update.Delete || val == nil {
-				r.deleteAllIRKeys()
-				r.deleteAllStatusKeys()
-				return
-			}
+			r.Logger.Info("received an update", "controllerName", update.Key)
+			r.translateAll(update.Context(), errChan)
+		},
+	)
+	r.Logger.Info("shutting down")
+}
 
-			// IR keys for watchable
-			var curIRKeys, newIRKeys []string
+// translateAll translates the resources of all the controllers, and publishes the IRs
+// and the statuses. The translation runs again once the earliest preview expires.
+// The IRs are published in ctx, which traces their updates.
+func (r *Runner) translateAll(ctx context.Context, errChan chan error) {
+	r.translateMu.Lock()
+	defer r.translateMu.Unlock()
+
+	// The keys are the names of the controllers, whose resources are all
+	// translated on every update so that the IR keys and statuses of the
+	// other controllers are kept. When the last controller is deleted,
+	// delete all IR keys.
+	controllers := r.ProviderResources.GatewayAPIResources.LoadAll()
+	if len(controllers) == 0 {
+		r.deleteAllIRKeys()
+		r.deleteAllStatusKeys()
+		return
+	}
 
-			// Get current IR keys
-			for key := range r.InfraIR.LoadAll() {
-				curIRKeys = append(curIRKeys, key)
-			}
+	// IR keys for watchable
+	var curIRKeys, newIRKeys []string
+
+	// Get current IR keys
+	for key := range r.InfraIR.LoadAll() {
+		curIRKeys = append(curIRKeys, key)
+	}
+
+	// Get all status keys from watchable and save them in this StatusesToDelete structure.
+	// Iterating through the controller resources, any valid keys will be removed from statusesToDelete.
+	// Remaining keys will be deleted from watchable before we exit this function.
+	statusesToDelete := r.getAllStatuses()
+
+	// The earliest expiry of the routes exposed on preview listeners.
+	var nextPreviewExpiry time.Time
+
+	for _, resources := range controllerResources(controllers) {
+		// Publish the new HTTPRoutes of a bulk import batch after batch first.
+		r.translateInBatches(ctx, resources, errChan)
+
+		// Translate and publish IRs.
+		t := r.newTranslator(resources)
+		if len(t.ExtensionGroupKinds) > 0 {
+			r.Logger.Info("extension resources", "GVKs count", len(t.ExtensionGroupKinds))
+		}
+		// Translate to IR
+		result, err := t.Translate(resources)
+		if err != nil {
+			// Currently all errors that Translate returns should just be logged
+			r.Logger.Error(err, "errors detected during translation")
+		}
+		if result.NextPreviewExpiry != nil && (nextPreviewExpiry.IsZero() || result.NextPreviewExpiry.Time.Before(nextPreviewExpiry)) {
+			nextPreviewExpiry = result.NextPreviewExpiry.Time
+		}
+
+		newIRKeys = append(newIRKeys, r.publishIRs(ctx, result, errChan)...)
 
-			// Get all status keys from watchable and save them in this StatusesToDelete structure.
-			// Iterating through the controller resources, any valid keys will be removed from statusesToDelete.
-			// Remaining keys will be deleted from watchable before we exit this function.
-			statusesToDelete := r.getAllStatuses()
-
-			for _, resources := range *val {
-				// Translate and publish IRs.
-				t := &gatewayapi.Translator{
-					GatewayControllerName:   r.Server.EnvoyGateway.Gateway.ControllerName,
-					GatewayClassName:        gwapiv1.ObjectName(resources.GatewayClass.Name),
-					GlobalRateLimitEnabled:  r.EnvoyGateway.RateLimit != nil,
-					EnvoyPatchPolicyEnabled: r.EnvoyGateway.ExtensionAPIs != nil && r.EnvoyGateway.ExtensionAPIs.EnableEnvoyPatchPolicy,
-					BackendEnabled:          r.EnvoyGateway.ExtensionAPIs != nil && r.EnvoyGateway.ExtensionAPIs.EnableBackend,
-					Namespace:               r.Namespace,
-					MergeGateways:           gatewayapi.IsMergeGatewaysEnabled(resources),
-					WasmCache:               r.wasmCache,
-				}
-
-				// If an extension is loaded, pass its supported groups/kinds to the translator
-				if r.EnvoyGateway.ExtensionManager != nil {
-					var extGKs []schema.GroupKind
-					for _, gvk := range r.EnvoyGateway.ExtensionManager.Resources {
-						extGKs = append(extGKs, schema.GroupKind{Group: gvk.Group, Kind: gvk.Kind})
-					}
-					t.ExtensionGroupKinds = extGKs
-					r.Logger.Info("extension resources", "GVKs count", len(extGKs))
-				}
-				// Translate to IR
-				result, err := t.Translate(resources)
-				if err != nil {
-					// Currently all errors that Translate returns should just be logged
-					r.Logger.Error(err, "errors detected during translation")
-				}
-
-				// Publish the IRs.
-				// Also validate the ir before sending it.
-				for key, val := range result.InfraIR {
-					r.Logger.WithValues("infra-ir", key).Info(val.JSONString())
-					if err := val.Validate(); err != nil {
-						r.Logger.Error(err, "unable to validate infra ir, skipped sending it")
-						errChan <- err
-					} else {
-						r.InfraIR.Store(key, val)
-						newIRKeys = append(newIRKeys, key)
-					}
-				}
-
-				for key, val := range result.XdsIR {
-					r.Logger.WithValues("xds-ir", key).Info(val.JSONString())
-					if err := val.Validate(); err != nil {
-						r.Logger.Error(err, "unable to validate xds ir, skipped sending it")
-						errChan <- err
-					} else {
-						r.XdsIR.Store(key, val)
-					}
-				}
-
-				// Update Status
-				for _, gateway := range result.Gateways {
-					key := utils.NamespacedName(gateway)
-					r.ProviderResources.GatewayStatuses.Store(key, &gateway.Status)
-					delete(statusesToDelete.GatewayStatusKeys, key)
-				}
-				for _, httpRoute := range result.HTTPRoutes {
-					key := utils.NamespacedName(httpRoute)
-					r.ProviderResources.HTTPRouteStatuses.Store(key, &httpRoute.Status)
-					delete(statusesToDelete.HTTPRouteStatusKeys, key)
-				}
-				for _, grpcRoute := range result.GRPCRoutes {
-					key := utils.NamespacedName(grpcRoute)
-					r.ProviderResources.GRPCRouteStatuses.Store(key, &grpcRoute.Status)
-					delete(statusesToDelete.GRPCRouteStatusKeys, key)
-				}
-				for _, tlsRoute := range result.TLSRoutes {
-					key := utils.NamespacedName(tlsRoute)
-					r.ProviderResources.TLSRouteStatuses.Store(key, &tlsRoute.Status)
-					delete(statusesToDelete.TLSRouteStatusKeys, key)
-				}
-				for _, tcpRoute := range result.TCPRoutes {
-					key := utils.NamespacedName(tcpRoute)
-					r.ProviderResources.TCPRouteStatuses.Store(key, &tcpRoute.Status)
-					delete(statusesToDelete.TCPRouteStatusKeys, key)
-				}
-				for _, udpRoute := range result.UDPRoutes {
-					key := utils.NamespacedName(udpRoute)
-					r.ProviderResources.UDPRouteStatuses.Store(key, &udpRoute.Status)
-					delete(statusesToDelete.UDPRouteStatusKeys, key)
-				}
-
-				// Skip updating status for policies with empty status
-				// They may have been skipped in this translation because
-				// their target is not found (not relevant)
-
-				for _, backendTLSPolicy := range result.BackendTLSPolicies {
-					key := utils.NamespacedName(backendTLSPolicy)
-					if !(reflect.ValueOf(backendTLSPolicy.Status).IsZero()) {
-						r.ProviderResources.BackendTLSPolicyStatuses.Store(key, &backendTLSPolicy.Status)
-					}
-					delete(statusesToDelete.BackendTLSPolicyStatusKeys, key)
-				}
-
-				for _, clientTrafficPolicy := range result.ClientTrafficPolicies {
-					key := utils.NamespacedName(clientTrafficPolicy)
-					if !(reflect.ValueOf(clientTrafficPolicy.Status).IsZero()) {
-						r.ProviderResources.ClientTrafficPolicyStatuses.Store(key, &clientTrafficPolicy.Status)
-					}
-					delete(statusesToDelete.ClientTrafficPolicyStatusKeys, key)
-				}
-				for _, backendTrafficPolicy := range result.BackendTrafficPolicies {
-					key := utils.NamespacedName(backendTrafficPolicy)
-					if !(reflect.ValueOf(backendTrafficPolicy.Status).IsZero()) {
-						r.ProviderResources.BackendTrafficPolicyStatuses.Store(key, &backendTrafficPolicy.Status)
-					}
-					delete(statusesToDelete.BackendTrafficPolicyStatusKeys, key)
-				}
-				for _, securityPolicy := range result.SecurityPolicies {
-					key := utils.NamespacedName(securityPolicy)
-					if !(reflect.ValueOf(securityPolicy.Status).IsZero()) {
-						r.ProviderResources.SecurityPolicyStatuses.Store(key, &securityPolicy.Status)
-					}
-					delete(statusesToDelete.SecurityPolicyStatusKeys, key)
-				}
-				for _, envoyExtensionPolicy := range result.EnvoyExtensionPolicies {
-					key := utils.NamespacedName(envoyExtensionPolicy)
-					if !(reflect.ValueOf(envoyExtensionPolicy.Status).IsZero()) {
-						r.ProviderResources.EnvoyExtensionPolicyStatuses.Store(key, &envoyExtensionPolicy.Status)
-					}
-					delete(statusesToDelete.EnvoyExtensionPolicyStatusKeys, key)
-				}
-				for _, backend := range result.Backends {
-					key := utils.NamespacedName(backend)
-					if !(reflect.ValueOf(backend.Status).IsZero()) {
-						r.ProviderResources.BackendStatuses.Store(key, &backend.Status)
-					}
-					delete(statusesToDelete.BackendStatusKeys, key)
-				}
-				for _, extServerPolicy := range result.ExtensionServerPolicies {
-					key := message.NamespacedNameAndGVK{
-						NamespacedName:   utils.NamespacedName(&extServerPolicy),
-						GroupVersionKind: extServerPolicy.GroupVersionKind(),
-					}
-					if !(reflect.ValueOf(extServerPolicy.Object["status"]).IsZero()) {
-						policyStatus := unstructuredToPolicyStatus(extServerPolicy.Object["status"].(map[string]any))
-						r.ProviderResources.ExtensionPolicyStatuses.Store(key, &policyStatus)
-					}
-					delete(statusesToDelete.ExtensionServerPolicyStatusKeys, key)
-				}
+		// Update Status
+		for _, gateway := range result.Gateways {
+			key := utils.NamespacedName(gateway)
+			r.ProviderResources.GatewayStatuses.Store(key, &gateway.Status)
+			delete(statusesToDelete.GatewayStatusKeys, key)
+		}
+		for _, httpRoute := range result.HTTPRoutes {
+			key := utils.NamespacedName(httpRoute)
+			r.ProviderResources.HTTPRouteStatuses.Store(key, &httpRoute.Status)
+			delete(statusesToDelete.HTTPRouteStatusKeys, key)
+		}
+		for _, grpcRoute := range result.GRPCRoutes {
+			key := utils.NamespacedName(grpcRoute)
+			r.ProviderResources.GRPCRouteStatuses.Store(key, &grpcRoute.Status)
+			delete(statusesToDelete.GRPCRouteStatusKeys, key)
+		}
+		for _, tlsRoute := range result.TLSRoutes {
+			key := utils.NamespacedName(tlsRoute)
+			r.ProviderResources.TLSRouteStatuses.Store(key, &tlsRoute.Status)
+			delete(statusesToDelete.TLSRouteStatusKeys, key)
+		}
+		for _, tcpRoute := range result.TCPRoutes {
+			key := utils.NamespacedName(tcpRoute)
+			r.ProviderResources.TCPRouteStatuses.Store(key, &tcpRoute.Status)
+			delete(statusesToDelete.TCPRouteStatusKeys, key)
+		}
+		for _, udpRoute := range result.UDPRoutes {
+			key := utils.NamespacedName(udpRoute)
+			r.ProviderResources.UDPRouteStatuses.Store(key, &udpRoute.Status)
+			delete(statusesToDelete.UDPRouteStatusKeys, key)
+		}
+
+		// Skip updating status for policies with empty status
+		// They may have been skipped in this translation because
+		// their target is not found (not relevant)
+
+		for _, backendTLSPolicy := range result.BackendTLSPolicies {
+			key := utils.NamespacedName(backendTLSPolicy)
+			if !(reflect.ValueOf(backendTLSPolicy.Status).IsZero()) {
+				r.ProviderResources.BackendTLSPolicyStatuses.Store(key, &backendTLSPolicy.Status)
 			}
+			delete(statusesToDelete.BackendTLSPolicyStatusKeys, key)
+		}
 
-			// Delete IR keys
-			// There is a 1:1 mapping between infra and xds IR keys
-			delKeys := getIRKeysToDelete(curIRKeys, newIRKeys)
-			for _, key := range delKeys {
-				r.InfraIR.Delete(key)
-				r.XdsIR.Delete(key)
+		for _, clientTrafficPolicy := range result.ClientTrafficPolicies {
+			key := utils.NamespacedName(clientTrafficPolicy)
+			if !(reflect.ValueOf(clientTrafficPolicy.Status).IsZero()) {
+				r.ProviderResources.ClientTrafficPolicyStatuses.Store(key, &clientTrafficPolicy.Status)
+			}
+			delete(statusesToDelete.ClientTrafficPolicyStatusKeys, key)
+		}
+		for _, backendTrafficPolicy := range result.BackendTrafficPolicies {
+			key := utils.NamespacedName(backendTrafficPolicy)
+			if !(reflect.ValueOf(backendTrafficPolicy.Status).IsZero()) {
+				r.ProviderResources.BackendTrafficPolicyStatuses.Store(key, &backendTrafficPolicy.Status)
+			}
+			delete(statusesToDelete.BackendTrafficPolicyStatusKeys, key)
+		}
+		for _, securityPolicy := range result.SecurityPolicies {
+			key := utils.NamespacedName(securityPolicy)
+			if !(reflect.ValueOf(securityPolicy.Status).IsZero()) {
+				r.ProviderResources.SecurityPolicyStatuses.Store(key, &securityPolicy.Status)
+			}
+			delete(statusesToDelete.SecurityPolicyStatusKeys, key)
+		}
+		for _, envoyExtensionPolicy := range result.EnvoyExtensionPolicies {
+			key := utils.NamespacedName(envoyExtensionPolicy)
+			if !(reflect.ValueOf(envoyExtensionPolicy.Status).IsZero()) {
+				r.ProviderResources.EnvoyExtensionPolicyStatuses.Store(key, &envoyExtensionPolicy.Status)
+			}
+			delete(statusesToDelete.EnvoyExtensionPolicyStatusKeys, key)
+		}
+		for _, backend := range result.Backends {
+			key := utils.NamespacedName(backend)
+			if !(reflect.ValueOf(backend.Status).IsZero()) {
+				r.ProviderResources.BackendStatuses.Store(key, &backend.Status)
+			}
+			delete(statusesToDelete.BackendStatusKeys, key)
+		}
+		for _, extServerPolicy := range result.ExtensionServerPolicies {
+			key := message.NamespacedNameAndGVK{
+				NamespacedName:   utils.NamespacedName(&extServerPolicy),
+				GroupVersionKind: extServerPolicy.GroupVersionKind(),
+			}
+			if !(reflect.ValueOf(extServerPolicy.Object["status"]).IsZero()) {
+				policyStatus := unstructuredToPolicyStatus(extServerPolicy.Object["status"].(map[string]any))
+				r.ProviderResources.ExtensionPolicyStatuses.Store(key, &policyStatus)
 			}
+			delete(statusesToDelete.ExtensionServerPolicyStatusKeys, key)
+		}
+	}
 
-			// Delete status keys
-			r.deleteStatusKeys(statusesToDelete)
-		},
-	)
-	r.Logger.Info("shutting down")
+	// Delete IR keys
+	// There is a 1:1 mapping between infra and xds IR keys
+	delKeys := getIRKeysToDelete(curIRKeys, newIRKeys)
+	for _, key := range delKeys {
+		r.InfraIR.Delete(key)
+		r.XdsIR.Delete(key)
+	}
+
+	// Delete status keys
+	r.deleteStatusKeys(statusesToDelete)
+
+	r.recordTranslatedHTTPRoutes(controllers)
+
+	r.schedulePreviewExpiry(ctx, nextPreviewExpiry)
+}
+
+// publishIRs validates and publishes the IRs of the translation result, and returns
+// the keys of the published infra IRs.
+func (r *Runner) publishIRs(ctx context.Context, result *gatewayapi.TranslateResult, errChan chan error) []string {
+	var keys []string
+	for key, val := range result.InfraIR {
+		r.Logger.WithValues("infra-ir", key).Info(val.JSONString())
+		if err := val.Validate(); err != nil {
+			r.Logger.Error(err, "unable to validate infra ir, skipped sending it")
+			errChan <- err
+		} else {
+			r.InfraIR.StoreTraced(ctx, key, val)
+			keys = append(keys, key)
+		}
+	}
+
+	for key, val := range result.XdsIR {
+		r.Logger.WithValues("xds-ir", key).Info(val.JSONString())
+		if err := val.Validate(); err != nil {
+			r.Logger.Error(err, "unable to validate xds ir, skipped sending it")
+			errChan <- err
+		} else {
+			r.XdsIR.StoreTraced(ctx, key, val)
+		}
+	}
+	return keys
+}
+
+// schedulePreviewExpiry re-translates the resources when the next preview expires,
+// so that the expired routes are removed from the preview listeners. The re-translation
+// is traced from the span of the translation scheduling it, and its errors are reported
+// to the preview errors of the runner.
+func (r *Runner) schedulePreviewExpiry(ctx context.Context, expiry time.Time) {
+	if r.previewTimer != nil {
+		r.previewTimer.Stop()
+		r.previewTimer = nil
+	}
+	if expiry.IsZero() {
+		return
+	}
+	r.previewTimer = time.AfterFunc(time.Until(expiry), func() {
+		ctx, span := message.StartSpan(ctx, message.Metadata{Runner: providerResourcesMetadata.Runner, Message: "preview-expiry"})
+		defer span.End()
+		r.translateAll(ctx, r.previewErrs)
+	})
+}
+
+// clusterIPFamily returns the IP family of the Kubernetes cluster Envoy Gateway runs in,
+// detected from the address of the Service of the API server, which is of the primary
+// IP family of the cluster, or nil outside of Kubernetes.
+func clusterIPFamily() *egv1a1.IPFamily {
+	ip := net.ParseIP(os.Getenv("KUBERNETES_SERVICE_HOST"))
+	switch {
+	case ip == nil:
+		return nil
+	case ip.To4() != nil:
+		return ptr.To(egv1a1.IPv4)
+	default:
+		return ptr.To(egv1a1.IPv6)
+	}
+}
+
+// controllerResources returns the resources of the GatewayClasses of all the controllers,
+// ordered by controller name.
+func controllerResources(controllers map[string]*resource.ControllerResources) []*resource.Resources {
+	names := make([]string, 0, len(controllers))
+	for name := range controllers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var all []*resource.Resources
+	for _, name := range names {
+		if controllers[name] == nil {
+			continue
+		}
+		all = append(all, *controllers[name]...)
+	}
+	return all
+}
+
+// newTranslator returns the translator of the resources of a GatewayClass.
+func (r *Runner) newTranslator(resources *resource.Resources) *gatewayapi.Translator {
+	controllerName := string(resources.GatewayClass.Spec.ControllerName)
+	t := &gatewayapi.Translator{
+		GatewayControllerName:   controllerName,
+		GatewayClassName:        gwapiv1.ObjectName(resources.GatewayClass.Name),
+		GlobalRateLimitEnabled:  r.EnvoyGateway.RateLimit != nil,
+		EnvoyPatchPolicyEnabled: r.EnvoyGateway.ExtensionAPIs != nil && r.EnvoyGateway.ExtensionAPIs.EnableEnvoyPatchPolicy,
+		BackendEnabled:          r.EnvoyGateway.ExtensionAPIs != nil && r.EnvoyGateway.ExtensionAPIs.EnableBackend,
+		Namespace:               r.Namespace,
+		MergeGateways:           gatewayapi.IsMergeGatewaysEnabled(resources),
+		WasmCache:               r.wasmCache,
+		HostnameDelegation:      r.EnvoyGateway.HostnameDelegation,
+		XdsServerPort:           r.EnvoyGateway.Gateway.XdsServerPort(controllerName),
+		XdsServer:               r.EnvoyGateway.XdsServer,
+		FeatureGates:            r.EnvoyGateway.ResolvedFeatureGates(),
+		ClusterIPFamily:         clusterIPFamily(),
+	}
+
+	// If an extension is loaded, pass its supported groups/kinds to the translator
+	if r.EnvoyGateway.ExtensionManager != nil {
+		for _, gvk := range r.EnvoyGateway.ExtensionManager.Resources {
+			t.ExtensionGroupKinds = append(t.ExtensionGroupKinds, schema.GroupKind{Group: gvk.Group, Kind: gvk.Kind})
+		}
+	}
+
+	return t
+}
+
+// TranslateResources translates the resources of a GatewayClass into xds IR without
+// publishing it nor updating any status, to simulate a change of the resources.
+// The resources are modified by the translation.
+func (r *Runner) TranslateResources(resources *resource.Resources) resource.XdsIRMap {
+	t := r.newTranslator(resources)
+	t.Simulation = true
+	result, err := t.Translate(resources)
+	if err != nil {
+		r.Logger.Error(err, "errors detected during simulated translation")
+	}
+	return result.XdsIR
 }
 
 func unstructuredToPolicyStatus(policyStatus map[string]any) gwapiv1a2.PolicyStatus {
@@ -13,13 +13,20 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/utils/ptr"
 	gwapiv1 "sigs.k8s.io/gateway-api/apis/v1"
 	gwapiv1a2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
 
 	egv1a1 "github.com/envoyproxy/gateway/api/v1alpha1"
 	"github.com/envoyproxy/gateway/internal/envoygateway/config"
 	"github.com/envoyproxy/gateway/internal/extension/registry"
+	"github.com/envoyproxy/gateway/internal/gatewayapi/resource"
 	"github.com/envoyproxy/gateway/internal/ir"
 	"github.com/envoyproxy/gateway/internal/message"
 	pb "github.com/envoyproxy/gateway/proto/extension"
@@ -304,3 +311,179 @@ func TestDeleteAllStatusKeys(t *testing.T) {
 	require.Equal(t, 0, r.ProviderResources.UDPRouteStatuses.Len())
 	require.Equal(t, 0, r.ProviderResources.BackendStatuses.Len())
 }
+
+func TestControllerResources(t *testing.T) {
+	newResources := func(controllerName, className string) *resource.Resources {
+		res := resource.NewResources()
+		res.GatewayClass = &gwapiv1.GatewayClass{
+			ObjectMeta: metav1.ObjectMeta{Name: className},
+			Spec:       gwapiv1.GatewayClassSpec{ControllerName: gwapiv1.GatewayController(controllerName)},
+		}
+		return res
+	}
+	controllers := map[string]*resource.ControllerResources{
+		"example.com/partner-gateway": {newResources("example.com/partner-gateway", "partner")},
+		egv1a1.GatewayControllerName: {
+			newResources(egv1a1.GatewayControllerName, "public"),
+			newResources(egv1a1.GatewayControllerName, "internal"),
+		},
+		"example.com/deleted-gateway": nil,
+	}
+
+	var classes []string
+	for _, res := range controllerResources(controllers) {
+		classes = append(classes, res.GatewayClass.Name)
+	}
+	require.Equal(t, []string{"partner", "public", "internal"}, classes)
+}
+
+func TestTranslateInBatches(t *testing.T) {
+	newRoute := func(name string) *gwapiv1.HTTPRoute {
+		return &gwapiv1.HTTPRoute{
+			TypeMeta:   metav1.TypeMeta{Kind: resource.KindHTTPRoute},
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: name},
+			Spec: gwapiv1.HTTPRouteSpec{
+				CommonRouteSpec: gwapiv1.CommonRouteSpec{
+					ParentRefs: []gwapiv1.ParentReference{{
+						Group:     ptr.To(gwapiv1.Group(gwapiv1.GroupName)),
+						Kind:      ptr.To(gwapiv1.Kind(resource.KindGateway)),
+						Namespace: ptr.To(gwapiv1.Namespace("default")),
+						Name:      "gateway-1",
+					}},
+				},
+				Hostnames: []gwapiv1.Hostname{gwapiv1.Hostname(name + ".example.com")},
+				Rules:     []gwapiv1.HTTPRouteRule{{}},
+			},
+		}
+	}
+	resources := resource.NewResources()
+	resources.GatewayClass = &gwapiv1.GatewayClass{
+		ObjectMeta: metav1.ObjectMeta{Name: "envoy-gateway-class"},
+		Spec:       gwapiv1.GatewayClassSpec{ControllerName: egv1a1.GatewayControllerName},
+	}
+	resources.Namespaces = []*corev1.Namespace{{ObjectMeta: metav1.ObjectMeta{Name: "default"}}}
+	resources.Gateways = []*gwapiv1.Gateway{{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "gateway-1"},
+		Spec: gwapiv1.GatewaySpec{
+			GatewayClassName: "envoy-gateway-class",
+			Listeners: []gwapiv1.Listener{{
+				Name:     "http",
+				Protocol: gwapiv1.HTTPProtocolType,
+				Port:     80,
+				AllowedRoutes: &gwapiv1.AllowedRoutes{
+					Namespaces: &gwapiv1.RouteNamespaces{From: ptr.To(gwapiv1.NamespacesFromAll)},
+				},
+			}},
+		},
+	}}
+	for _, name := range []string{"new-1", "old-1", "new-2", "new-3", "new-4", "new-5"} {
+		resources.HTTPRoutes = append(resources.HTTPRoutes, newRoute(name))
+	}
+
+	newRunner := func() (*Runner, *message.XdsIR) {
+		cfg, err := config.New()
+		require.NoError(t, err)
+		cfg.EnvoyGateway.BulkImport = &egv1a1.EnvoyGatewayBulkImport{
+			Threshold: ptr.To[uint32](2),
+			BatchSize: ptr.To[uint32](2),
+		}
+		xdsIR := new(message.XdsIR)
+		r := New(&Config{
+			Server:            *cfg,
+			ProviderResources: new(message.ProviderResources),
+			XdsIR:             xdsIR,
+			InfraIR:           new(message.InfraIR),
+		})
+		r.translatedHTTPRoutes = sets.New(types.NamespacedName{Namespace: "default", Name: "old-1"})
+		return r, xdsIR
+	}
+	routeNames := func(xds *ir.Xds) []string {
+		var names []string
+		for _, l := range xds.HTTP {
+			for _, route := range l.Routes {
+				names = append(names, route.Hostname)
+			}
+		}
+		return names
+	}
+
+	// The batches publish the HTTPRoutes already translated and the new ones but the last
+	// batch, which is left to the translation of all the routes.
+	r, xdsIR := newRunner()
+	errChan := make(chan error, 10)
+	r.translateInBatches(context.Background(), resources, errChan)
+	xds, ok := xdsIR.Load("default/gateway-1")
+	require.True(t, ok)
+	assert.ElementsMatch(t, []string{
+		"old-1.example.com", "new-1.example.com", "new-2.example.com", "new-3.example.com", "new-4.example.com",
+	}, routeNames(xds))
+	assert.Empty(t, errChan)
+	// The resources are not changed by the translation of the batches.
+	for _, route := range resources.HTTPRoutes {
+		assert.Empty(t, route.Status.Parents)
+	}
+
+	// No batch is published once the context is done.
+	r, xdsIR = newRunner()
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	r.translateInBatches(ctx, resources, errChan)
+	assert.Empty(t, xdsIR.LoadAll())
+}
+
+func TestSchedulePreviewExpiry(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	message.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	t.Cleanup(func() { message.SetTracerProvider(nil) })
+
+	cfg, err := config.New()
+	require.NoError(t, err)
+	xdsIR, infraIR := new(message.XdsIR), new(message.InfraIR)
+	r := New(&Config{
+		Server:            *cfg,
+		ProviderResources: new(message.ProviderResources),
+		XdsIR:             xdsIR,
+		InfraIR:           infraIR,
+	})
+	xdsIR.Store("default/gateway-1", &ir.Xds{})
+	infraIR.Store("default/gateway-1", &ir.Infra{})
+
+	// The resources are translated again once the preview expires, after the update that
+	// scheduled the expiry was handled.
+	ctx, span := message.StartSpan(context.Background(), message.Metadata{Runner: "gateway-api", Message: "provider-resources"})
+	r.translateMu.Lock()
+	r.schedulePreviewExpiry(ctx, time.Now().Add(50*time.Millisecond))
+	r.translateMu.Unlock()
+	span.End()
+	require.Eventually(t, func() bool {
+		return len(xdsIR.LoadAll()) == 0 && len(infraIR.LoadAll()) == 0
+	}, 5*time.Second, 10*time.Millisecond)
+
+	// The translation is traced from the span of the update that scheduled it.
+	require.Eventually(t, func() bool {
+		for _, s := range recorder.Ended() {
+			if s.Name() == "gateway-api preview-expiry" {
+				return s.Parent().SpanID() == span.SpanContext().SpanID()
+			}
+		}
+		return false
+	}, 5*time.Second, 10*time.Millisecond)
+}
+
+func TestClusterIPFamily(t *testing.T) {
+	testCases := []struct {
+		name string
+		host string
+		want *egv1a1.IPFamily
+	}{
+		{name: "not detected", host: "", want: nil},
+		{name: "ipv4", host: "10.96.0.1", want: ptr.To(egv1a1.IPv4)},
+		{name: "ipv6", host: "fd00:10:96::1", want: ptr.To(egv1a1.IPv6)},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Setenv("KUBERNETES_SERVICE_HOST", tc.host)
+			require.Equal(t, tc.want, clusterIPFamily())
+		})
+	}
+}
@@ -327,12 +327,13 @@ func (t *Translator) translateSecurityPolicyForRoute(
 ) error {
 	// Build IR
 	var (
-		cors          *ir.CORS
-		jwt           *ir.JWT
-		oidc          *ir.OIDC
-		basicAuth     *ir.BasicAuth
-		authorization *ir.Authorization
-		err, errs     error
+		cors            *ir.CORS
+		jwt             *ir.JWT
+		oidc            *ir.OIDC
+		basicAuth       *ir.BasicAuth
+		authorization   *ir.Authorization
+		securityHeaders *ir.SecurityHeaders
+		err, errs       error
 	)
 
 	if policy.Spec.CORS != nil {
@@ -367,6 +368,13 @@ func (t *Translator) translateSecurityPolicyForRoute(
 		}
 	}
 
+	if policy.Spec.SecurityHeaders != nil {
+		if securityHeaders, err = buildSecurityHeaders(policy.Spec.SecurityHeaders); err != nil {
+			err = perr.WithMessage(err, "SecurityHeaders")
+			errs = errors.Join(errs, err)
+		}
+	}
+
 	// Apply IR to all relevant routes
 	prefix := irRoutePrefix(route)
 	parentRefs := GetParentReferences(route)
@@ -395,12 +403,13 @@ func (t *Translator) translateSecurityPolicyForRoute(
 				for _, r := range irListener.Routes {
 					if strings.HasPrefix(r.Name, prefix) {
 						r.Security = &ir.SecurityFeatures{
-							CORS:          cors,
-							JWT:           jwt,
-							OIDC:          oidc,
-							BasicAuth:     basicAuth,
-							ExtAuth:       extAuth,
-							Authorization: authorization,
+							CORS:            cors,
+							JWT:             jwt,
+							OIDC:            oidc,
+							BasicAuth:       basicAuth,
+							ExtAuth:         extAuth,
+							Authorization:   authorization,
+							SecurityHeaders: securityHeaders,
 						}
 						if errs != nil {
 							// Return a 500 direct response to avoid unauthorized access
@@ -425,13 +434,14 @@ func (t *Translator) translateSecurityPolicyForGateway(
 ) error {
 	// Build IR
 	var (
-		cors          *ir.CORS
-		jwt           *ir.JWT
-		oidc          *ir.OIDC
-		basicAuth     *ir.BasicAuth
-		extAuth       *ir.ExtAuth
-		authorization *ir.Authorization
-		err, errs     error
+		cors            *ir.CORS
+		jwt             *ir.JWT
+		oidc            *ir.OIDC
+		basicAuth       *ir.BasicAuth
+		extAuth         *ir.ExtAuth
+		authorization   *ir.Authorization
+		securityHeaders *ir.SecurityHeaders
+		err, errs       error
 	)
 
 	if policy.Spec.CORS != nil {
@@ -476,6 +486,13 @@ func (t *Translator) translateSecurityPolicyForGateway(
 			errs = errors.Join(errs, err)
 		}
 	}
+
+	if policy.Spec.SecurityHeaders != nil {
+		if securityHeaders, err = buildSecurityHeaders(policy.Spec.SecurityHeaders); err != nil {
+			err = perr.WithMessage(err, "SecurityHeaders")
+			errs = errors.Join(errs, err)
+		}
+	}
 	// Apply IR to all the routes within the specific Gateway that originated
 	// from the gateway to which this security policy was attached.
 	// If the feature is already set, then skip it, since it must have be
@@ -502,12 +519,13 @@ func (t *Translator) translateSecurityPolicyForGateway(
 				continue
 			}
 			r.Security = &ir.SecurityFeatures{
-				CORS:          cors,
-				JWT:           jwt,
-				OIDC:          oidc,
-				BasicAuth:     basicAuth,
-				ExtAuth:       extAuth,
-				Authorization: authorization,
+				CORS:            cors,
+				JWT:             jwt,
+				OIDC:            oidc,
+				BasicAuth:       basicAuth,
+				ExtAuth:         extAuth,
+				Authorization:   authorization,
+				SecurityHeaders: securityHeaders,
 			}
 			if errs != nil {
 				// Return a 500 direct response to avoid unauthorized access
@@ -520,6 +538,50 @@ func (t *Translator) translateSecurityPolicyForGateway(
 	return errs
 }
 
+// buildSecurityHeaders returns the security headers added to the responses, which are
+// the headers with a recommended value unless disabled, and the headers whose value is set.
+func buildSecurityHeaders(securityHeaders *egv1a1.SecurityHeaders) (*ir.SecurityHeaders, error) {
+	headers := []struct {
+		name         string
+		header       *egv1a1.SecurityHeader
+		defaultValue string
+	}{
+		{"Strict-Transport-Security", securityHeaders.StrictTransportSecurity, "max-age=31536000; includeSubDomains"},
+		{"Content-Security-Policy", securityHeaders.ContentSecurityPolicy, ""},
+		{"X-Frame-Options", securityHeaders.XFrameOptions, "DENY"},
+		{"X-Content-Type-Options", securityHeaders.XContentTypeOptions, "nosniff"},
+		{"Referrer-Policy", securityHeaders.ReferrerPolicy, "strict-origin-when-cross-origin"},
+		{"Permissions-Policy", securityHeaders.PermissionsPolicy, ""},
+	}
+
+	irSecurityHeaders := &ir.SecurityHeaders{
+		Overwrite: ptr.Deref(securityHeaders.Overwrite, false),
+	}
+	for _, h := range headers {
+		value := h.defaultValue
+		if h.header != nil {
+			if ptr.Deref(h.header.Disabled, false) {
+				continue
+			}
+			if h.header.Value != nil {
+				value = *h.header.Value
+			}
+		}
+		if value == "" {
+			continue
+		}
+		// Avoid envoy NACKs due to invalid header values.
+		if strings.ContainsAny(value, "\r\n\x00") {
+			return nil, fmt.Errorf("invalid value of the %s header: %q", h.name, value)
+		}
+		irSecurityHeaders.Headers = append(irSecurityHeaders.Headers, ir.SecurityHeader{
+			Name:  h.name,
+			Value: value,
+		})
+	}
+	return irSecurityHeaders, nil
+}
+
 func (t *Translator) buildCORS(cors *egv1a1.CORS) *ir.CORS {
 	var allowOrigins []*ir.StringMatch
 
@@ -560,6 +622,7 @@ func (t *Translator) buildJWT(jwt *egv1a1.JWT) *ir.JWT {
 	return &ir.JWT{
 		AllowMissing: ptr.Deref(jwt.Optional, false),
 		Providers:    jwt.Providers,
+		RouteMatch:   jwt.RouteMatch,
 	}
 }
 
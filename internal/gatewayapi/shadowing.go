@@ -0,0 +1,194 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package gatewayapi
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	gwapiv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	"github.com/envoyproxy/gateway/internal/gatewayapi/resource"
+	"github.com/envoyproxy/gateway/internal/gatewayapi/status"
+	"github.com/envoyproxy/gateway/internal/ir"
+	"github.com/envoyproxy/gateway/internal/metrics"
+)
+
+const (
+	// RouteConditionShadowed is the condition of a route with matches that can never be
+	// hit, because a broader match of the same hostname takes precedence over them.
+	RouteConditionShadowed gwapiv1.RouteConditionType = "Shadowed"
+	// RouteReasonShadowedByPrecedingMatch is the reason of the Shadowed condition.
+	RouteReasonShadowedByPrecedingMatch gwapiv1.RouteConditionReason = "ShadowedByPrecedingMatch"
+
+	// maxConditionMessageItems bounds the matches listed in a condition message.
+	maxConditionMessageItems = 10
+)
+
+var (
+	routeKindLabel      = metrics.NewLabel("kind")
+	routeNamespaceLabel = metrics.NewLabel("namespace")
+	routeNameLabel      = metrics.NewLabel("name")
+
+	routeShadowedMatches = metrics.NewGauge(
+		"route_shadowed_matches",
+		"Number of route matches that can never be hit because a broader match takes precedence.",
+	)
+)
+
+// routeKey identifies a route by kind, namespace and name.
+type routeKey struct {
+	kind, namespace, name string
+}
+
+// listenerRouteKey identifies a route attached to an IR listener.
+type listenerRouteKey struct {
+	listener string
+	route    routeKey
+}
+
+// processShadowedRoutes detects the route matches of the sorted xds IR that can never be hit,
+// because a preceding match of the same hostname matches all their requests, and surfaces them
+// with the Shadowed condition of the routes and the route_shadowed_matches metric.
+func (t *Translator) processShadowedRoutes(xdsIR resource.XdsIRMap, routes []RouteContext) {
+	shadowed := make(map[listenerRouteKey][]string)
+	for _, irItem := range xdsIR {
+		for _, listener := range irItem.HTTP {
+			for key, matches := range shadowedRouteMatches(listener.Routes) {
+				k := listenerRouteKey{listener: listener.Name, route: key}
+				shadowed[k] = append(shadowed[k], matches...)
+			}
+		}
+	}
+
+	for _, route := range routes {
+		key := routeKey{kind: string(GetRouteType(route)), namespace: route.GetNamespace(), name: route.GetName()}
+		count := 0
+		for _, parentRef := range GetParentReferences(route) {
+			parentRefCtx := GetRouteParentContext(route, parentRef)
+			var matches []string
+			for _, listener := range parentRefCtx.listeners {
+				matches = append(matches, shadowed[listenerRouteKey{listener: irListenerName(listener), route: key}]...)
+			}
+			if len(matches) == 0 {
+				continue
+			}
+			count += len(matches)
+
+			status.SetRouteStatusCondition(GetRouteStatus(route),
+				parentRefCtx.routeParentStatusIdx,
+				route.GetGeneration(),
+				RouteConditionShadowed,
+				metav1.ConditionTrue,
+				RouteReasonShadowedByPrecedingMatch,
+				conditionMessage(matches),
+			)
+		}
+		if t.Simulation {
+			continue
+		}
+		routeShadowedMatches.With(routeKindLabel.Value(key.kind), routeNamespaceLabel.Value(key.namespace),
+			routeNameLabel.Value(key.name)).Record(float64(count))
+	}
+}
+
+// shadowedRouteMatches returns the descriptions of the shadowed matches of the sorted routes
+// of a listener, by route. A route is shadowed by a preceding route of the same hostname
+// matching all its requests.
+func shadowedRouteMatches(routes []*ir.HTTPRoute) map[routeKey][]string {
+	shadowed := make(map[routeKey][]string)
+	byHostname := make(map[string][]*ir.HTTPRoute)
+	for _, route := range routes {
+		if route.Metadata == nil {
+			continue
+		}
+		for _, preceding := range byHostname[route.Hostname] {
+			if !routeMatchCovers(preceding, route) {
+				continue
+			}
+			key := routeKey{kind: route.Metadata.Kind, namespace: route.Metadata.Namespace, name: route.Metadata.Name}
+			shadowed[key] = append(shadowed[key], fmt.Sprintf("%s is shadowed by %s on hostname %s",
+				irRouteMatchName(route), irRouteMatchName(preceding), route.Hostname))
+			break
+		}
+		byHostname[route.Hostname] = append(byHostname[route.Hostname], route)
+	}
+	return shadowed
+}
+
+// irRouteMatchName returns the name of the IR route without its hostname suffix.
+func irRouteMatchName(route *ir.HTTPRoute) string {
+	if i := strings.LastIndex(route.Name, "/"); i > 0 {
+		return route.Name[:i]
+	}
+	return route.Name
+}
+
+// routeMatchCovers returns true if all the requests matched by the route are matched
+// by the broader route.
+func routeMatchCovers(broader, route *ir.HTTPRoute) bool {
+	return pathMatchCovers(broader.PathMatch, route.PathMatch) &&
+		stringMatchesCover(broader.HeaderMatches, route.HeaderMatches) &&
+		stringMatchesCover(broader.QueryParamMatches, route.QueryParamMatches)
+}
+
+// pathMatchCovers returns true if all the paths matched by the path match are matched by
+// the broader one. Regular expressions are only compared as is, except for the ones
+// matching any path.
+func pathMatchCovers(broader, path *ir.StringMatch) bool {
+	switch {
+	case broader == nil:
+		return true
+	case broader.Prefix != nil:
+		if *broader.Prefix == "/" {
+			return true
+		}
+		switch {
+		case path == nil:
+			return false
+		case path.Prefix != nil:
+			return pathPrefixCovers(*broader.Prefix, *path.Prefix)
+		case path.Exact != nil:
+			return pathPrefixCovers(*broader.Prefix, *path.Exact)
+		}
+	case broader.SafeRegex != nil:
+		switch *broader.SafeRegex {
+		case ".*", "/.*", "^.*$", "^/.*$":
+			return true
+		}
+		return path != nil && path.SafeRegex != nil && *path.SafeRegex == *broader.SafeRegex
+	case broader.Exact != nil:
+		return path != nil && path.Exact != nil && *path.Exact == *broader.Exact
+	}
+	return false
+}
+
+// pathPrefixCovers returns true if the path prefix matches the path, as an element-wise
+// prefix of the path.
+func pathPrefixCovers(prefix, path string) bool {
+	prefix = strings.TrimSuffix(prefix, "/")
+	return path == prefix || strings.HasPrefix(path, prefix+"/")
+}
+
+// stringMatchesCover returns true if all the requests satisfying the matches satisfy the
+// broader ones, which is the case if each broader match is also required by the matches.
+func stringMatchesCover(broader, matches []*ir.StringMatch) bool {
+	for _, b := range broader {
+		found := false
+		for _, m := range matches {
+			if reflect.DeepEqual(b, m) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
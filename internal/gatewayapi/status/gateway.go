@@ -7,13 +7,17 @@ package status
 
 import (
 	"fmt"
+	"strings"
 	"time"
 
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/utils/ptr"
 	gwapiv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	xdstypes "github.com/envoyproxy/gateway/internal/xds/types"
 )
 
 func UpdateGatewayListenersNotValidCondition(gw *gwapiv1.Gateway, reason gwapiv1.GatewayConditionReason, status metav1.ConditionStatus, msg string) *gwapiv1.Gateway {
@@ -130,12 +134,289 @@ func computeGatewayAcceptedCondition(gw *gwapiv1.Gateway, accepted bool) metav1.
 }
 
 const (
-	messageAddressNotAssigned  = "No addresses have been assigned to the Gateway"
-	messageFmtTooManyAddresses = "Too many addresses (%d) have been assigned to the Gateway, the maximum number of addresses is 16"
-	messageNoResources         = "Deployment replicas unavailable"
-	messageFmtProgrammed       = "Address assigned to the Gateway, %d/%d envoy Deployment replicas available"
+	messageAddressNotAssigned   = "No addresses have been assigned to the Gateway"
+	messageFmtTooManyAddresses  = "Too many addresses (%d) have been assigned to the Gateway, the maximum number of addresses is 16"
+	messageNoResources          = "Deployment replicas unavailable"
+	messageFmtProgrammed        = "Address assigned to the Gateway, %d/%d envoy Deployment replicas available"
+	messageFmtInfraError        = "Failed to provision the Gateway infrastructure: %s"
+	messageFmtPendingListeners  = "Waiting for the envoy proxies to acknowledge the listeners: %s"
+	messageFmtSecretRotation    = "Waiting for the envoy proxies to acknowledge the rotated secrets: %s"
+	messageFmtDeferredDrains    = "The changes of the listeners draining connections are deferred until the maintenance window opens at %s: %s"
+	messageFmtXdsNacks          = "The envoy proxies rejected the configuration: %s"
+	messageFmtXdsInconsistent   = "The configuration was not pushed to the envoy proxies, which keep the previous one, as it is inconsistent: %s"
+	messageFmtXdsDrifted        = "The envoy proxies did not acknowledge the configuration they are served: %s"
+	messageFmtDraining          = "%d connections left open on the envoy proxies"
+	messageFmtUncountedProxies  = "; the connections of %d envoy proxies could not be counted"
+	messageDrained              = "The connections of the envoy proxies are closed"
+	messageFmtDrainTimedOut     = "The drain timed out with %d connections left open on the envoy proxies"
+	messageClientIPPreserved    = "The envoy proxies see the client IP addresses, as the nodes only forward the external traffic to the envoy proxies they run"
+	messageFmtHealthCheckNode   = "; the load balancer health checks the nodes on node port %d"
+	messageClientIPNotPreserved = "The envoy proxies see the IP addresses of the nodes forwarding the external traffic instead of the client IP addresses"
+)
+
+// maxXdsNacksInMessage is the number of rejections detailed in the message of the
+// XdsRejected condition, and of drifted proxies detailed in the message of the
+// XdsDrifted condition.
+const maxXdsNacksInMessage = 5
+
+const (
+	// GatewayConditionSecretRotationInProgress indicates that the TLS secrets of the
+	// Gateway have been rotated, and not all the envoy proxies acknowledged them yet.
+	GatewayConditionSecretRotationInProgress gwapiv1.GatewayConditionType = "SecretRotationInProgress"
+
+	// GatewayReasonSecretRotationInProgress is used with the SecretRotationInProgress
+	// condition when the rotated secrets are being pushed to the envoy proxies.
+	GatewayReasonSecretRotationInProgress gwapiv1.GatewayConditionReason = "RotationInProgress"
+
+	// GatewayConditionDrainsDeferred indicates that the changes of the listeners of the Gateway
+	// that drain connections are deferred until the maintenance window opens.
+	GatewayConditionDrainsDeferred gwapiv1.GatewayConditionType = "DrainsDeferred"
+
+	// GatewayReasonListenerDrain is used with the DrainsDeferred condition when the change of a
+	// listener drains all its connections, such as changing its address or removing it.
+	GatewayReasonListenerDrain gwapiv1.GatewayConditionReason = "ListenerDrain"
+
+	// GatewayReasonFilterChainDrain is used with the DrainsDeferred condition when the changes
+	// of the listeners only drain the connections of some of their filter chains.
+	GatewayReasonFilterChainDrain gwapiv1.GatewayConditionReason = "FilterChainDrain"
+
+	// GatewayConditionXdsRejected indicates that envoy proxies of the Gateway rejected
+	// its configuration.
+	GatewayConditionXdsRejected gwapiv1.GatewayConditionType = "XdsRejected"
+
+	// GatewayReasonRolledBack is used with the XdsRejected condition when the proxies
+	// rejecting the configuration were rolled back to the configuration they accepted last.
+	GatewayReasonRolledBack gwapiv1.GatewayConditionReason = "RolledBack"
+
+	// GatewayReasonNack is used with the XdsRejected condition when some proxies rejecting
+	// the configuration had not accepted any other one to roll back to.
+	GatewayReasonNack gwapiv1.GatewayConditionReason = "Nack"
+
+	// GatewayConditionDraining indicates that the envoy proxies of the Gateway are drained
+	// before its deletion.
+	GatewayConditionDraining gwapiv1.GatewayConditionType = "Draining"
+
+	// GatewayReasonDraining is used with the Draining condition while connections are left
+	// open on the envoy proxies.
+	GatewayReasonDraining gwapiv1.GatewayConditionReason = "Draining"
+
+	// GatewayReasonDrained is used with the Draining condition once the connections of the
+	// envoy proxies are closed.
+	GatewayReasonDrained gwapiv1.GatewayConditionReason = "Drained"
+
+	// GatewayReasonDrainTimedOut is used with the Draining condition once the drain timeout
+	// elapsed with connections left open.
+	GatewayReasonDrainTimedOut gwapiv1.GatewayConditionReason = "DrainTimedOut"
+
+	// GatewayConditionXdsInconsistent indicates that the configuration of the Gateway was
+	// not pushed to its envoy proxies because its resources reference missing resources.
+	GatewayConditionXdsInconsistent gwapiv1.GatewayConditionType = "XdsInconsistent"
+
+	// GatewayReasonMissingReferences is used with the XdsInconsistent condition when the
+	// routes or the clusters of the configuration reference missing resources.
+	GatewayReasonMissingReferences gwapiv1.GatewayConditionReason = "MissingReferences"
+
+	// GatewayConditionXdsDrifted indicates that envoy proxies of the Gateway stayed behind
+	// the configuration they are served for longer than the drift window.
+	GatewayConditionXdsDrifted gwapiv1.GatewayConditionType = "XdsDrifted"
+
+	// GatewayReasonProxiesBehind is used with the XdsDrifted condition while some proxies
+	// have not acknowledged the resources they are served.
+	GatewayReasonProxiesBehind gwapiv1.GatewayConditionReason = "ProxiesBehind"
+
+	// GatewayConditionClientIPPreserved indicates whether the envoy proxies of the Gateway
+	// see the client IP addresses of the external traffic of their Service.
+	GatewayConditionClientIPPreserved gwapiv1.GatewayConditionType = "ClientIPPreserved"
+
+	// GatewayReasonExternalTrafficPolicyLocal is used with the ClientIPPreserved condition
+	// when the Service of the envoy proxies uses the Local external traffic policy.
+	GatewayReasonExternalTrafficPolicyLocal gwapiv1.GatewayConditionReason = "ExternalTrafficPolicyLocal"
+
+	// GatewayReasonExternalTrafficPolicyCluster is used with the ClientIPPreserved condition
+	// when the Service of the envoy proxies uses the Cluster external traffic policy.
+	GatewayReasonExternalTrafficPolicyCluster gwapiv1.GatewayConditionReason = "ExternalTrafficPolicyCluster"
 )
 
+// UpdateGatewayStatusInfraErrorCondition updates the Programmed condition of the
+// provided Gateway to surface an error that occurred while provisioning its infrastructure.
+func UpdateGatewayStatusInfraErrorCondition(gw *gwapiv1.Gateway, reason gwapiv1.GatewayConditionReason, msg string) {
+	gw.Status.Conditions = MergeConditions(gw.Status.Conditions,
+		newCondition(string(gwapiv1.GatewayConditionProgrammed), metav1.ConditionFalse, string(reason),
+			fmt.Sprintf(messageFmtInfraError, msg), time.Now(), gw.Generation))
+}
+
+// UpdateGatewayStatusPendingListenersCondition holds the Programmed condition of the provided
+// Gateway back until the proxies acknowledged the listeners. The condition is only updated if the
+// Gateway is otherwise programmed, so that it keeps surfacing other reasons.
+func UpdateGatewayStatusPendingListenersCondition(gw *gwapiv1.Gateway, listeners []string) {
+	if len(listeners) == 0 {
+		return
+	}
+	programmed := meta.FindStatusCondition(gw.Status.Conditions, string(gwapiv1.GatewayConditionProgrammed))
+	if programmed == nil || programmed.Status != metav1.ConditionTrue {
+		return
+	}
+
+	gw.Status.Conditions = MergeConditions(gw.Status.Conditions,
+		newCondition(string(gwapiv1.GatewayConditionProgrammed), metav1.ConditionFalse, string(gwapiv1.GatewayReasonPending),
+			fmt.Sprintf(messageFmtPendingListeners, strings.Join(listeners, ", ")), time.Now(), gw.Generation))
+}
+
+// UpdateGatewayStatusSecretRotationCondition sets the SecretRotationInProgress condition of
+// the provided Gateway while the rotated secrets are being pushed to the proxies, and removes
+// it once the rotation completed.
+func UpdateGatewayStatusSecretRotationCondition(gw *gwapiv1.Gateway, secrets []string) {
+	if len(secrets) == 0 {
+		meta.RemoveStatusCondition(&gw.Status.Conditions, string(GatewayConditionSecretRotationInProgress))
+		return
+	}
+
+	gw.Status.Conditions = MergeConditions(gw.Status.Conditions,
+		newCondition(string(GatewayConditionSecretRotationInProgress), metav1.ConditionTrue, string(GatewayReasonSecretRotationInProgress),
+			fmt.Sprintf(messageFmtSecretRotation, strings.Join(secrets, ", ")), time.Now(), gw.Generation))
+}
+
+// UpdateGatewayStatusDeferredDrainsCondition sets the DrainsDeferred condition of the provided
+// Gateway while the changes of its listeners draining connections are deferred, and removes it
+// once they are applied. The listeners whose change drains all their connections are listed
+// apart from the ones only draining the connections of some of their filter chains.
+func UpdateGatewayStatusDeferredDrainsCondition(gw *gwapiv1.Gateway, listeners, filterChainListeners []string, until time.Time) {
+	if len(listeners) == 0 && len(filterChainListeners) == 0 {
+		meta.RemoveStatusCondition(&gw.Status.Conditions, string(GatewayConditionDrainsDeferred))
+		return
+	}
+
+	reason := GatewayReasonFilterChainDrain
+	var changes []string
+	if len(listeners) > 0 {
+		reason = GatewayReasonListenerDrain
+		changes = append(changes, "all the connections of "+strings.Join(listeners, ", "))
+	}
+	if len(filterChainListeners) > 0 {
+		changes = append(changes, "the connections of filter chains of "+strings.Join(filterChainListeners, ", "))
+	}
+	gw.Status.Conditions = MergeConditions(gw.Status.Conditions,
+		newCondition(string(GatewayConditionDrainsDeferred), metav1.ConditionTrue, string(reason),
+			fmt.Sprintf(messageFmtDeferredDrains, until.UTC().Format(time.RFC3339), strings.Join(changes, "; ")), time.Now(), gw.Generation))
+}
+
+// UpdateGatewayStatusDrainingCondition sets the Draining condition of the provided Gateway
+// while its envoy proxies are drained, with the number of connections left open, and removes
+// it once the Gateway is no longer draining.
+func UpdateGatewayStatusDrainingCondition(gw *gwapiv1.Gateway, draining bool, connections uint64, uncountedProxies int, timedOut bool) {
+	if !draining {
+		meta.RemoveStatusCondition(&gw.Status.Conditions, string(GatewayConditionDraining))
+		return
+	}
+
+	reason, msg := GatewayReasonDraining, fmt.Sprintf(messageFmtDraining, connections)
+	switch {
+	case timedOut:
+		reason, msg = GatewayReasonDrainTimedOut, fmt.Sprintf(messageFmtDrainTimedOut, connections)
+	case connections == 0 && uncountedProxies == 0:
+		reason, msg = GatewayReasonDrained, messageDrained
+	}
+	if uncountedProxies > 0 {
+		msg += fmt.Sprintf(messageFmtUncountedProxies, uncountedProxies)
+	}
+	gw.Status.Conditions = MergeConditions(gw.Status.Conditions,
+		newCondition(string(GatewayConditionDraining), metav1.ConditionTrue, string(reason), msg, time.Now(), gw.Generation))
+}
+
+// UpdateGatewayStatusXdsNacksCondition sets the XdsRejected condition of the provided
+// Gateway while some of its proxies reject its configuration, and removes it once they
+// accept a newer one.
+func UpdateGatewayStatusXdsNacksCondition(gw *gwapiv1.Gateway, nacks []xdstypes.Nack) {
+	if len(nacks) == 0 {
+		meta.RemoveStatusCondition(&gw.Status.Conditions, string(GatewayConditionXdsRejected))
+		return
+	}
+
+	reason := GatewayReasonRolledBack
+	var rejections []string
+	for i, nack := range nacks {
+		if nack.RolledBackTo == "" {
+			reason = GatewayReasonNack
+		}
+		if i == maxXdsNacksInMessage {
+			rejections = append(rejections, fmt.Sprintf("and %d more", len(nacks)-i))
+			continue
+		}
+		if i > maxXdsNacksInMessage {
+			continue
+		}
+		rejection := fmt.Sprintf("%s rejected version %s of %s", nack.NodeID, nack.Version, nack.TypeURL)
+		if nack.RolledBackTo != "" {
+			rejection += fmt.Sprintf(" and was rolled back to version %s", nack.RolledBackTo)
+		}
+		rejections = append(rejections, rejection+": "+nack.Message)
+	}
+	gw.Status.Conditions = MergeConditions(gw.Status.Conditions,
+		newCondition(string(GatewayConditionXdsRejected), metav1.ConditionTrue, string(reason),
+			fmt.Sprintf(messageFmtXdsNacks, strings.Join(rejections, "; ")), time.Now(), gw.Generation))
+}
+
+// UpdateGatewayStatusXdsInconsistentCondition sets the XdsInconsistent condition of the
+// provided Gateway while its last configuration is refused as inconsistent, and removes
+// it once a consistent one is pushed to the proxies.
+func UpdateGatewayStatusXdsInconsistentCondition(gw *gwapiv1.Gateway, problems []string) {
+	if len(problems) == 0 {
+		meta.RemoveStatusCondition(&gw.Status.Conditions, string(GatewayConditionXdsInconsistent))
+		return
+	}
+
+	gw.Status.Conditions = MergeConditions(gw.Status.Conditions,
+		newCondition(string(GatewayConditionXdsInconsistent), metav1.ConditionTrue, string(GatewayReasonMissingReferences),
+			fmt.Sprintf(messageFmtXdsInconsistent, strings.Join(problems, "; ")), time.Now(), gw.Generation))
+}
+
+// UpdateGatewayStatusXdsDriftedCondition sets the XdsDrifted condition of the provided
+// Gateway while some of its proxies stay behind the configuration they are served, and
+// removes it once they acknowledge it.
+func UpdateGatewayStatusXdsDriftedCondition(gw *gwapiv1.Gateway, drifts []xdstypes.Drift) {
+	if len(drifts) == 0 {
+		meta.RemoveStatusCondition(&gw.Status.Conditions, string(GatewayConditionXdsDrifted))
+		return
+	}
+
+	var behind []string
+	for i, drift := range drifts {
+		if i == maxXdsNacksInMessage {
+			behind = append(behind, fmt.Sprintf("and %d more", len(drifts)-i))
+			break
+		}
+		behind = append(behind, fmt.Sprintf("%s is behind on %s since %s", drift.NodeID,
+			strings.Join(drift.TypeURLs, ", "), drift.Since.UTC().Format(time.RFC3339)))
+	}
+	gw.Status.Conditions = MergeConditions(gw.Status.Conditions,
+		newCondition(string(GatewayConditionXdsDrifted), metav1.ConditionTrue, string(GatewayReasonProxiesBehind),
+			fmt.Sprintf(messageFmtXdsDrifted, strings.Join(behind, "; ")), time.Now(), gw.Generation))
+}
+
+// UpdateGatewayStatusClientIPPreservedCondition updates the ClientIPPreserved condition of
+// the Gateway from the external traffic policy of the Service of its envoy proxies. The
+// condition is removed when the Service does not receive external traffic.
+func UpdateGatewayStatusClientIPPreservedCondition(gw *gwapiv1.Gateway, svc *corev1.Service) {
+	if svc == nil || (svc.Spec.Type != corev1.ServiceTypeLoadBalancer && svc.Spec.Type != corev1.ServiceTypeNodePort) {
+		meta.RemoveStatusCondition(&gw.Status.Conditions, string(GatewayConditionClientIPPreserved))
+		return
+	}
+
+	if svc.Spec.ExternalTrafficPolicy != corev1.ServiceExternalTrafficPolicyLocal {
+		gw.Status.Conditions = MergeConditions(gw.Status.Conditions,
+			newCondition(string(GatewayConditionClientIPPreserved), metav1.ConditionFalse, string(GatewayReasonExternalTrafficPolicyCluster),
+				messageClientIPNotPreserved, time.Now(), gw.Generation))
+		return
+	}
+	message := messageClientIPPreserved
+	if svc.Spec.HealthCheckNodePort != 0 {
+		message += fmt.Sprintf(messageFmtHealthCheckNode, svc.Spec.HealthCheckNodePort)
+	}
+	gw.Status.Conditions = MergeConditions(gw.Status.Conditions,
+		newCondition(string(GatewayConditionClientIPPreserved), metav1.ConditionTrue, string(GatewayReasonExternalTrafficPolicyLocal),
+			message, time.Now(), gw.Generation))
+}
+
 // updateGatewayProgrammedCondition computes the Gateway Programmed status condition.
 // Programmed condition surfaces true when the Envoy Deployment status is ready.
 func updateGatewayProgrammedCondition(gw *gwapiv1.Gateway, deployment *appsv1.Deployment) {
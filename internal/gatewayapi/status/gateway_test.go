@@ -10,7 +10,9 @@ import (
 	"reflect"
 	"strconv"
 	"testing"
+	"time"
 
+	resourcev3 "github.com/envoyproxy/go-control-plane/pkg/resource/v3"
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
 	"github.com/stretchr/testify/assert"
@@ -19,6 +21,8 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/utils/ptr"
 	gwapiv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	xdstypes "github.com/envoyproxy/gateway/internal/xds/types"
 )
 
 // TestUpdateGatewayStatusProgrammedCondition tests whether UpdateGatewayStatusProgrammedCondition correctly updates the addresses in the Gateway status.
@@ -271,6 +275,264 @@ func TestUpdateGatewayProgrammedCondition(t *testing.T) {
 	}
 }
 
+func TestUpdateGatewayStatusInfraErrorCondition(t *testing.T) {
+	gtw := &gwapiv1.Gateway{}
+	gtw.Status.Addresses = []gwapiv1.GatewayStatusAddress{{Type: ptr.To(gwapiv1.IPAddressType), Value: "1.1.1.1"}}
+	updateGatewayProgrammedCondition(gtw, &appsv1.Deployment{Status: appsv1.DeploymentStatus{AvailableReplicas: 1, Replicas: 1}})
+
+	msg := `services "envoy-default-gateway" is forbidden: exceeded quota: compute-resources`
+	UpdateGatewayStatusInfraErrorCondition(gtw, gwapiv1.GatewayReasonNoResources, msg)
+
+	expected := []metav1.Condition{
+		{
+			Type:    string(gwapiv1.GatewayConditionProgrammed),
+			Status:  metav1.ConditionFalse,
+			Reason:  string(gwapiv1.GatewayReasonNoResources),
+			Message: fmt.Sprintf(messageFmtInfraError, msg),
+		},
+	}
+	if d := cmp.Diff(expected, gtw.Status.Conditions, cmpopts.IgnoreFields(metav1.Condition{}, "LastTransitionTime")); d != "" {
+		t.Errorf("unexpected condition diff: %s", d)
+	}
+}
+
+func TestUpdateGatewayStatusPendingListenersCondition(t *testing.T) {
+	testCases := []struct {
+		name      string
+		deploy    *appsv1.Deployment
+		listeners []string
+		expect    metav1.Condition
+	}{
+		{
+			name:      "acknowledged listeners",
+			deploy:    &appsv1.Deployment{Status: appsv1.DeploymentStatus{AvailableReplicas: 1, Replicas: 1}},
+			listeners: nil,
+			expect: metav1.Condition{
+				Type:    string(gwapiv1.GatewayConditionProgrammed),
+				Status:  metav1.ConditionTrue,
+				Reason:  string(gwapiv1.GatewayConditionProgrammed),
+				Message: fmt.Sprintf(messageFmtProgrammed, 1, 1),
+			},
+		},
+		{
+			name:      "pending listeners",
+			deploy:    &appsv1.Deployment{Status: appsv1.DeploymentStatus{AvailableReplicas: 1, Replicas: 1}},
+			listeners: []string{"default/gateway-1/http", "default/gateway-1/https"},
+			expect: metav1.Condition{
+				Type:    string(gwapiv1.GatewayConditionProgrammed),
+				Status:  metav1.ConditionFalse,
+				Reason:  string(gwapiv1.GatewayReasonPending),
+				Message: fmt.Sprintf(messageFmtPendingListeners, "default/gateway-1/http, default/gateway-1/https"),
+			},
+		},
+		{
+			name:      "pending listeners without available replicas",
+			deploy:    &appsv1.Deployment{Status: appsv1.DeploymentStatus{AvailableReplicas: 0, Replicas: 1}},
+			listeners: []string{"default/gateway-1/http"},
+			expect: metav1.Condition{
+				Type:    string(gwapiv1.GatewayConditionProgrammed),
+				Status:  metav1.ConditionFalse,
+				Reason:  string(gwapiv1.GatewayReasonNoResources),
+				Message: messageNoResources,
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			gtw := &gwapiv1.Gateway{}
+			gtw.Status.Addresses = []gwapiv1.GatewayStatusAddress{{Type: ptr.To(gwapiv1.IPAddressType), Value: "1.1.1.1"}}
+			updateGatewayProgrammedCondition(gtw, tc.deploy)
+
+			UpdateGatewayStatusPendingListenersCondition(gtw, tc.listeners)
+
+			if d := cmp.Diff([]metav1.Condition{tc.expect}, gtw.Status.Conditions, cmpopts.IgnoreFields(metav1.Condition{}, "LastTransitionTime")); d != "" {
+				t.Errorf("unexpected condition diff: %s", d)
+			}
+		})
+	}
+}
+
+func TestUpdateGatewayStatusSecretRotationCondition(t *testing.T) {
+	gtw := &gwapiv1.Gateway{}
+
+	UpdateGatewayStatusSecretRotationCondition(gtw, []string{"default/tls-secret"})
+	expected := []metav1.Condition{{
+		Type:    string(GatewayConditionSecretRotationInProgress),
+		Status:  metav1.ConditionTrue,
+		Reason:  string(GatewayReasonSecretRotationInProgress),
+		Message: fmt.Sprintf(messageFmtSecretRotation, "default/tls-secret"),
+	}}
+	if d := cmp.Diff(expected, gtw.Status.Conditions, cmpopts.IgnoreFields(metav1.Condition{}, "LastTransitionTime")); d != "" {
+		t.Errorf("unexpected condition diff: %s", d)
+	}
+
+	// The condition is removed once the rotation completed.
+	UpdateGatewayStatusSecretRotationCondition(gtw, nil)
+	if len(gtw.Status.Conditions) != 0 {
+		t.Errorf("expected no conditions, got %v", gtw.Status.Conditions)
+	}
+}
+
+func TestUpdateGatewayStatusDeferredDrainsCondition(t *testing.T) {
+	gtw := &gwapiv1.Gateway{}
+	until := time.Date(2024, 1, 1, 2, 0, 0, 0, time.UTC)
+
+	UpdateGatewayStatusDeferredDrainsCondition(gtw, []string{"default/eg/http"}, []string{"default/eg/https"}, until)
+	expected := []metav1.Condition{{
+		Type:   string(GatewayConditionDrainsDeferred),
+		Status: metav1.ConditionTrue,
+		Reason: string(GatewayReasonListenerDrain),
+		Message: fmt.Sprintf(messageFmtDeferredDrains, "2024-01-01T02:00:00Z",
+			"all the connections of default/eg/http; the connections of filter chains of default/eg/https"),
+	}}
+	if d := cmp.Diff(expected, gtw.Status.Conditions, cmpopts.IgnoreFields(metav1.Condition{}, "LastTransitionTime")); d != "" {
+		t.Errorf("unexpected condition diff: %s", d)
+	}
+
+	UpdateGatewayStatusDeferredDrainsCondition(gtw, nil, []string{"default/eg/https"}, until)
+	if reason := gtw.Status.Conditions[0].Reason; reason != string(GatewayReasonFilterChainDrain) {
+		t.Errorf("expected reason %s, got %s", GatewayReasonFilterChainDrain, reason)
+	}
+
+	// The condition is removed once the changes are applied.
+	UpdateGatewayStatusDeferredDrainsCondition(gtw, nil, nil, time.Time{})
+	if len(gtw.Status.Conditions) != 0 {
+		t.Errorf("expected no conditions, got %v", gtw.Status.Conditions)
+	}
+}
+
+func TestUpdateGatewayStatusXdsNacksCondition(t *testing.T) {
+	gtw := &gwapiv1.Gateway{}
+
+	UpdateGatewayStatusXdsNacksCondition(gtw, []xdstypes.Nack{{
+		NodeID:       "envoy-1",
+		TypeURL:      resourcev3.ListenerType,
+		Version:      "5",
+		Message:      "invalid listener",
+		RolledBackTo: "4",
+	}})
+	expected := []metav1.Condition{{
+		Type:   string(GatewayConditionXdsRejected),
+		Status: metav1.ConditionTrue,
+		Reason: string(GatewayReasonRolledBack),
+		Message: fmt.Sprintf(messageFmtXdsNacks,
+			"envoy-1 rejected version 5 of "+resourcev3.ListenerType+" and was rolled back to version 4: invalid listener"),
+	}}
+	if d := cmp.Diff(expected, gtw.Status.Conditions, cmpopts.IgnoreFields(metav1.Condition{}, "LastTransitionTime")); d != "" {
+		t.Errorf("unexpected condition diff: %s", d)
+	}
+
+	// A proxy without configuration to roll back to changes the reason.
+	UpdateGatewayStatusXdsNacksCondition(gtw, []xdstypes.Nack{{
+		NodeID:  "envoy-1",
+		TypeURL: resourcev3.ListenerType,
+		Version: "1",
+		Message: "invalid listener",
+	}})
+	if reason := gtw.Status.Conditions[0].Reason; reason != string(GatewayReasonNack) {
+		t.Errorf("expected reason %s, got %s", GatewayReasonNack, reason)
+	}
+
+	// The condition is removed once the proxies accept a newer configuration.
+	UpdateGatewayStatusXdsNacksCondition(gtw, nil)
+	if len(gtw.Status.Conditions) != 0 {
+		t.Errorf("expected no conditions, got %v", gtw.Status.Conditions)
+	}
+}
+
+func TestUpdateGatewayStatusXdsDriftedCondition(t *testing.T) {
+	gtw := &gwapiv1.Gateway{}
+
+	UpdateGatewayStatusXdsDriftedCondition(gtw, []xdstypes.Drift{{
+		NodeID:   "envoy-1",
+		TypeURLs: []string{resourcev3.ClusterType, resourcev3.ListenerType},
+		Since:    time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC),
+	}})
+	expected := []metav1.Condition{{
+		Type:   string(GatewayConditionXdsDrifted),
+		Status: metav1.ConditionTrue,
+		Reason: string(GatewayReasonProxiesBehind),
+		Message: fmt.Sprintf(messageFmtXdsDrifted,
+			"envoy-1 is behind on "+resourcev3.ClusterType+", "+resourcev3.ListenerType+" since 2024-01-02T03:04:05Z"),
+	}}
+	if d := cmp.Diff(expected, gtw.Status.Conditions, cmpopts.IgnoreFields(metav1.Condition{}, "LastTransitionTime")); d != "" {
+		t.Errorf("unexpected condition diff: %s", d)
+	}
+
+	// The condition is removed once the proxies acknowledge the configuration.
+	UpdateGatewayStatusXdsDriftedCondition(gtw, nil)
+	if len(gtw.Status.Conditions) != 0 {
+		t.Errorf("expected no conditions, got %v", gtw.Status.Conditions)
+	}
+}
+
+func TestUpdateGatewayStatusClientIPPreservedCondition(t *testing.T) {
+	testCases := []struct {
+		name   string
+		svc    *corev1.Service
+		expect []metav1.Condition
+	}{
+		{
+			name: "local traffic policy with health check node port",
+			svc: &corev1.Service{Spec: corev1.ServiceSpec{
+				Type:                  corev1.ServiceTypeLoadBalancer,
+				ExternalTrafficPolicy: corev1.ServiceExternalTrafficPolicyLocal,
+				HealthCheckNodePort:   30000,
+			}},
+			expect: []metav1.Condition{{
+				Type:    string(GatewayConditionClientIPPreserved),
+				Status:  metav1.ConditionTrue,
+				Reason:  string(GatewayReasonExternalTrafficPolicyLocal),
+				Message: messageClientIPPreserved + fmt.Sprintf(messageFmtHealthCheckNode, 30000),
+			}},
+		},
+		{
+			name: "local traffic policy of node port",
+			svc: &corev1.Service{Spec: corev1.ServiceSpec{
+				Type:                  corev1.ServiceTypeNodePort,
+				ExternalTrafficPolicy: corev1.ServiceExternalTrafficPolicyLocal,
+			}},
+			expect: []metav1.Condition{{
+				Type:    string(GatewayConditionClientIPPreserved),
+				Status:  metav1.ConditionTrue,
+				Reason:  string(GatewayReasonExternalTrafficPolicyLocal),
+				Message: messageClientIPPreserved,
+			}},
+		},
+		{
+			name: "cluster traffic policy",
+			svc: &corev1.Service{Spec: corev1.ServiceSpec{
+				Type:                  corev1.ServiceTypeLoadBalancer,
+				ExternalTrafficPolicy: corev1.ServiceExternalTrafficPolicyCluster,
+			}},
+			expect: []metav1.Condition{{
+				Type:    string(GatewayConditionClientIPPreserved),
+				Status:  metav1.ConditionFalse,
+				Reason:  string(GatewayReasonExternalTrafficPolicyCluster),
+				Message: messageClientIPNotPreserved,
+			}},
+		},
+		{
+			name: "cluster IP",
+			svc:  &corev1.Service{Spec: corev1.ServiceSpec{Type: corev1.ServiceTypeClusterIP}},
+		},
+		{
+			name: "no service",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			gtw := &gwapiv1.Gateway{}
+			UpdateGatewayStatusClientIPPreservedCondition(gtw, tc.svc)
+			if d := cmp.Diff(tc.expect, gtw.Status.Conditions, cmpopts.IgnoreFields(metav1.Condition{}, "LastTransitionTime"), cmpopts.EquateEmpty()); d != "" {
+				t.Errorf("unexpected condition diff: %s", d)
+			}
+		})
+	}
+}
+
 func TestComputeGatewayScheduledCondition(t *testing.T) {
 	testCases := []struct {
 		name   string
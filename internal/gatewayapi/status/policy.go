@@ -11,6 +11,8 @@ import (
 	"github.com/google/go-cmp/cmp"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	gwapiv1a2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
+
+	egv1a1 "github.com/envoyproxy/gateway/api/v1alpha1"
 )
 
 type PolicyResolveError struct {
@@ -90,3 +92,21 @@ func SetConditionForPolicyAncestor(policyStatus *gwapiv1a2.PolicyStatus, ancesto
 		Conditions:     []metav1.Condition{cond},
 	})
 }
+
+// SetCanaryRolloutForPolicyAncestors sets the CanaryRollout condition on the ancestors of the
+// policy managed by the controller, which is True while the rollout progresses or once it
+// succeeded, and False once it was rolled back.
+func SetCanaryRolloutForPolicyAncestors(policyStatus *gwapiv1a2.PolicyStatus, controllerName string,
+	reason gwapiv1a2.PolicyConditionReason, message string, generation int64,
+) {
+	status := metav1.ConditionTrue
+	if reason == egv1a1.PolicyReasonCanaryRolledBack {
+		status = metav1.ConditionFalse
+	}
+	cond := newCondition(string(egv1a1.PolicyConditionCanaryRollout), status, string(reason), message, time.Now(), generation)
+	for i, ancestor := range policyStatus.Ancestors {
+		if string(ancestor.ControllerName) == controllerName {
+			policyStatus.Ancestors[i].Conditions = MergeConditions(policyStatus.Ancestors[i].Conditions, cond)
+		}
+	}
+}
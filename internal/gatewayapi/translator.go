@@ -7,8 +7,10 @@ package gatewayapi
 
 import (
 	"sort"
+	"time"
 
 	"golang.org/x/exp/maps"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/utils/ptr"
@@ -91,12 +93,40 @@ type Translator struct {
 
 	// WasmCache is the cache for Wasm modules.
 	WasmCache wasm.Cache
+
+	// HostnameDelegation restricts the hostnames the routes of each
+	// namespace may use, if set.
+	HostnameDelegation *egv1a1.EnvoyGatewayHostnameDelegation
+
+	// Simulation is true when the translation simulates a change of the
+	// resources, and must not record any metric.
+	Simulation bool
+
+	// XdsServerPort is the port the xDS server serves the proxies of the
+	// GatewayClass on, or zero for the default port.
+	XdsServerPort int32
+
+	// XdsServer holds the Gateways whose proxies are served on an xDS server
+	// port of their own, if set.
+	XdsServer *egv1a1.EnvoyGatewayXdsServer
+
+	// FeatureGates enables or disables the gated features of the translation.
+	// The gates not set keep their default.
+	FeatureGates map[egv1a1.FeatureGate]bool
+
+	// ClusterIPFamily is the IP family of the cluster the managed proxies run in,
+	// which their listeners and backend endpoints default to when their EnvoyProxy
+	// does not set one, if detected.
+	ClusterIPFamily *egv1a1.IPFamily
 }
 
 type TranslateResult struct {
 	resource.Resources
 	XdsIR   resource.XdsIRMap   `json:"xdsIR" yaml:"xdsIR"`
 	InfraIR resource.InfraIRMap `json:"infraIR" yaml:"infraIR"`
+	// NextPreviewExpiry is when the earliest HTTPRoute exposed on a preview listener
+	// expires, or nil if none expires.
+	NextPreviewExpiry *metav1.Time `json:"nextPreviewExpiry,omitempty" yaml:"nextPreviewExpiry,omitempty"`
 }
 
 func newTranslateResult(gateways []*GatewayContext,
@@ -150,6 +180,11 @@ func newTranslateResult(gateways []*GatewayContext,
 }
 
 func (t *Translator) Translate(resources *resource.Resources) (*TranslateResult, error) {
+	// The Gateways are translated separately if merging them is disabled by its feature gate.
+	if t.MergeGateways && !t.featureEnabled(egv1a1.FeatureGateMergeGateways) {
+		t.MergeGateways = false
+	}
+
 	// Get Gateways belonging to our GatewayClass.
 	gateways := t.GetRelevantGateways(resources)
 
@@ -211,7 +246,7 @@ func (t *Translator) Translate(resources *resource.Resources) (*TranslateResult,
 
 	// Process BackendTrafficPolicies
 	backendTrafficPolicies := t.ProcessBackendTrafficPolicies(
-		resources.BackendTrafficPolicies, gateways, routes, xdsIR)
+		resources.BackendTrafficPolicies, gateways, routes, resources, xdsIR)
 
 	// Process SecurityPolicies
 	securityPolicies := t.ProcessSecurityPolicies(
@@ -224,22 +259,54 @@ func (t *Translator) Translate(resources *resource.Resources) (*TranslateResult,
 	extServerPolicies, translateErrs := t.ProcessExtensionServerPolicies(
 		resources.ExtensionServerPolicies, gateways, xdsIR)
 
+	// Expose the annotated HTTPRoutes on the preview listeners, once the policies
+	// applied to their routes
+	nextPreviewExpiry := t.processPreviewRoutes(httpRoutes, resources, xdsIR, time.Now())
+
 	// Sort xdsIR based on the Gateway API spec
 	sortXdsIRMap(xdsIR)
 
-	// Set custom filter order if EnvoyProxy is set
+	// Resolve the conflicts between the routes claiming the same hostname and path
+	t.processRouteConflicts(gateways, xdsIR, routes)
+
+	// Detect the route matches shadowed by the preceding ones once sorted
+	t.processShadowedRoutes(xdsIR, routes)
+
+	// Set custom filter order, warming, drain deferral, load reporting, traffic recording, route matcher tree, LEDS, VHDS, ECDS, checkpoint and runtime discovery settings if EnvoyProxy is set
 	// The custom filter order will be applied when generating the HTTP filter chain.
 	for _, gateway := range gateways {
 		if gateway.envoyProxy != nil {
 			irKey := t.getIRKey(gateway.Gateway)
 			xdsIR[irKey].FilterOrder = gateway.envoyProxy.Spec.FilterOrder
+			xdsIR[irKey].Warming = buildIRWarming(gateway.envoyProxy.Spec.Warming)
+			xdsIR[irKey].DeferDrains = buildIRDeferDrains(gateway.envoyProxy.Spec.DeferDrains)
+			xdsIR[irKey].LoadReporting = buildIRLoadReporting(gateway.envoyProxy.Spec.LoadReporting)
+			xdsIR[irKey].TrafficRecording = buildIRTrafficRecording(gateway.envoyProxy.Spec.TrafficRecording)
+			xdsIR[irKey].RouteMatcherTree = buildIRRouteMatcherTree(gateway.envoyProxy.Spec.RouteMatcherTree)
+			xdsIR[irKey].LocalityEndpointDiscovery = buildIRLocalityEndpointDiscovery(gateway.envoyProxy.Spec.LocalityEndpointDiscovery)
+			xdsIR[irKey].VirtualHostDiscovery = buildIRVirtualHostDiscovery(gateway.envoyProxy.Spec.VirtualHostDiscovery)
+			xdsIR[irKey].ExtensionConfigDiscovery = buildIRExtensionConfigDiscovery(gateway.envoyProxy.Spec.ExtensionConfigDiscovery)
+			xdsIR[irKey].ConfigCheckpoint = buildIRConfigCheckpoint(gateway.envoyProxy.Spec.ConfigCheckpoint)
+			xdsIR[irKey].RuntimeDiscovery = buildIRRuntimeDiscovery(gateway.envoyProxy.Spec.RuntimeDiscovery)
+		}
+	}
+
+	// Drain the proxies of the Gateways marked as draining, which are not shared with other
+	// Gateways when merged
+	if !t.MergeGateways {
+		for _, gateway := range gateways {
+			xdsIR[t.getIRKey(gateway.Gateway)].Drain = buildIRDrain(gateway.Gateway)
 		}
 	}
 
-	return newTranslateResult(gateways, httpRoutes, grpcRoutes, tlsRoutes,
+	result := newTranslateResult(gateways, httpRoutes, grpcRoutes, tlsRoutes,
 		tcpRoutes, udpRoutes, clientTrafficPolicies, backendTrafficPolicies,
 		securityPolicies, resources.BackendTLSPolicies, envoyExtensionPolicies,
-		extServerPolicies, backends, xdsIR, infraIR), translateErrs
+		extServerPolicies, backends, xdsIR, infraIR)
+	if !nextPreviewExpiry.IsZero() {
+		result.NextPreviewExpiry = &metav1.Time{Time: nextPreviewExpiry}
+	}
+	return result, translateErrs
 }
 
 // GetRelevantGateways returns GatewayContexts, containing a copy of the original
@@ -257,6 +324,10 @@ func (t *Translator) GetRelevantGateways(resources *resource.Resources) []*Gatew
 				Gateway: gateway.DeepCopy(),
 			}
 			gc.ResetListeners(resources)
+			if !t.MergeGateways && IsMergeGatewaysEnabled(resources) {
+				// The merging of the Gateways is disabled by its feature gate.
+				gc.attachEnvoyProxy(resources, false)
+			}
 
 			relevant = append(relevant, gc)
 		}
@@ -272,8 +343,9 @@ func (t *Translator) InitIRs(gateways []*GatewayContext) (map[string]*ir.Xds, ma
 
 	var irKey string
 	for _, gateway := range gateways {
-		gwXdsIR := &ir.Xds{}
+		gwXdsIR := &ir.Xds{XdsServerPort: t.XdsServerPort}
 		gwInfraIR := ir.NewInfra()
+		gwInfraIR.Proxy.XdsServerPort = t.XdsServerPort
 		labels := infrastructureLabels(gateway.Gateway)
 		annotations := infrastructureAnnotations(gateway.Gateway)
 		gwInfraIR.Proxy.GetProxyMetadata().Annotations = annotations
@@ -288,6 +360,12 @@ func (t *Translator) InitIRs(gateways []*GatewayContext) (map[string]*ir.Xds, ma
 
 			maps.Copy(labels, GatewayOwnerLabels(gateway.Namespace, gateway.Name))
 			gwInfraIR.Proxy.GetProxyMetadata().Labels = labels
+
+			// The proxies of an isolated Gateway are served on its own port.
+			if port := t.XdsServer.IsolatedGatewayPort(gateway.Namespace, gateway.Name); port != 0 {
+				gwXdsIR.XdsServerPort = port
+				gwInfraIR.Proxy.XdsServerPort = port
+			}
 		}
 
 		gwInfraIR.Proxy.Name = irKey
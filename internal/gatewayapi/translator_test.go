@@ -29,6 +29,7 @@ import (
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/utils/ptr"
+	gwapiv1 "sigs.k8s.io/gateway-api/apis/v1"
 	gwapiv1b1 "sigs.k8s.io/gateway-api/apis/v1beta1"
 	"sigs.k8s.io/yaml"
 
@@ -50,6 +51,10 @@ func TestTranslate(t *testing.T) {
 		name                    string
 		EnvoyPatchPolicyEnabled bool
 		BackendEnabled          bool
+		HostnameDelegation      *egv1a1.EnvoyGatewayHostnameDelegation
+		FeatureGates            map[egv1a1.FeatureGate]bool
+		XdsServer               *egv1a1.EnvoyGatewayXdsServer
+		ClusterIPFamily         *egv1a1.IPFamily
 	}{
 		{
 			name:                    "envoypatchpolicy-invalid-feature-disabled",
@@ -59,6 +64,47 @@ func TestTranslate(t *testing.T) {
 			name:                    "backend-invalid-feature-disabled",
 			EnvoyPatchPolicyEnabled: false,
 		},
+		{
+			name:                    "httproute-with-hostname-delegation",
+			EnvoyPatchPolicyEnabled: true,
+			BackendEnabled:          true,
+			HostnameDelegation: &egv1a1.EnvoyGatewayHostnameDelegation{
+				Delegations: []egv1a1.HostnameDelegation{
+					{
+						Namespaces: []string{"team-a"},
+						Hostnames:  []gwapiv1.Hostname{"*.team-a.example.com"},
+					},
+				},
+			},
+		},
+		{
+			name:                    "clienttrafficpolicy-http3-feature-disabled",
+			EnvoyPatchPolicyEnabled: true,
+			BackendEnabled:          true,
+			FeatureGates:            map[egv1a1.FeatureGate]bool{egv1a1.FeatureGateHTTP3: false},
+		},
+		{
+			name:                    "merge-gateways-feature-disabled",
+			EnvoyPatchPolicyEnabled: true,
+			BackendEnabled:          true,
+			FeatureGates:            map[egv1a1.FeatureGate]bool{egv1a1.FeatureGateMergeGateways: false},
+		},
+		{
+			name:                    "gateway-with-isolated-xds-server",
+			EnvoyPatchPolicyEnabled: true,
+			BackendEnabled:          true,
+			XdsServer: &egv1a1.EnvoyGatewayXdsServer{
+				IsolatedGateways: []egv1a1.XdsServerIsolatedGateway{
+					{Namespace: "envoy-gateway", Name: "gateway-2", Port: 18020},
+				},
+			},
+		},
+		{
+			name:                    "cluster-ipv6-only",
+			EnvoyPatchPolicyEnabled: true,
+			BackendEnabled:          true,
+			ClusterIPFamily:         ptr.To(egv1a1.IPv6),
+		},
 	}
 
 	inputFiles, err := filepath.Glob(filepath.Join("testdata", "*.in.yaml"))
@@ -73,11 +119,19 @@ func TestTranslate(t *testing.T) {
 			mustUnmarshal(t, input, resources)
 			envoyPatchPolicyEnabled := true
 			backendEnabled := true
+			var hostnameDelegation *egv1a1.EnvoyGatewayHostnameDelegation
+			var featureGates map[egv1a1.FeatureGate]bool
+			var xdsServer *egv1a1.EnvoyGatewayXdsServer
+			var clusterIPFamily *egv1a1.IPFamily
 
 			for _, config := range testCasesConfig {
 				if config.name == strings.Split(filepath.Base(inputFile), ".")[0] {
 					envoyPatchPolicyEnabled = config.EnvoyPatchPolicyEnabled
 					backendEnabled = config.BackendEnabled
+					hostnameDelegation = config.HostnameDelegation
+					featureGates = config.FeatureGates
+					xdsServer = config.XdsServer
+					clusterIPFamily = config.ClusterIPFamily
 				}
 			}
 
@@ -90,6 +144,10 @@ func TestTranslate(t *testing.T) {
 				Namespace:               "envoy-gateway-system",
 				MergeGateways:           IsMergeGatewaysEnabled(resources),
 				WasmCache:               &mockWasmCache{},
+				HostnameDelegation:      hostnameDelegation,
+				FeatureGates:            featureGates,
+				XdsServer:               xdsServer,
+				ClusterIPFamily:         clusterIPFamily,
 			}
 
 			// Add common test fixtures
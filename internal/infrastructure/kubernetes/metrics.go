@@ -30,6 +30,11 @@ var (
 		[]float64{0.001, 0.01, 0.1, 1, 5, 10},
 	)
 
+	resourceOrphanedTotal = metrics.NewCounter(
+		"resource_orphaned_total",
+		"Total number of orphaned resources found and deleted.",
+	)
+
 	kindLabel      = metrics.NewLabel("kind")
 	nameLabel      = metrics.NewLabel("name")
 	namespaceLabel = metrics.NewLabel("namespace")
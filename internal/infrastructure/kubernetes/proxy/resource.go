@@ -15,6 +15,7 @@ import (
 	egv1a1 "github.com/envoyproxy/gateway/api/v1alpha1"
 	"github.com/envoyproxy/gateway/internal/cmd/envoy"
 	"github.com/envoyproxy/gateway/internal/envoygateway/config"
+	"github.com/envoyproxy/gateway/internal/gatewayapi"
 	"github.com/envoyproxy/gateway/internal/infrastructure/kubernetes/resource"
 	"github.com/envoyproxy/gateway/internal/ir"
 	"github.com/envoyproxy/gateway/internal/utils"
@@ -39,6 +40,19 @@ const (
 	envoyNsEnvVar = "ENVOY_GATEWAY_NAMESPACE"
 	// envoyPodEnvVar is the name of the Envoy pod name environment variable.
 	envoyPodEnvVar = "ENVOY_POD_NAME"
+	// envoyNodeGroupEnvVar is the name of the Envoy node group environment variable,
+	// set from the node group label of the pod.
+	envoyNodeGroupEnvVar = "ENVOY_NODE_GROUP"
+	// adminSocketVolumeName is the name of the volume holding the unix socket of the Envoy admin interface.
+	adminSocketVolumeName = "envoy-admin"
+	// listenerSocketVolumeName is the name of the volume holding the unix sockets of the listeners.
+	listenerSocketVolumeName = "envoy-listener-sockets"
+	// checkpointVolumeName is the name of the volume holding the configuration checkpoint of the proxy.
+	checkpointVolumeName = "envoy-checkpoint"
+	// checkpointDir is the directory the configuration checkpoint is mounted at in the shutdown manager.
+	checkpointDir = "/var/run/envoy-checkpoint"
+	// CheckpointFilename is the key of the configuration checkpoint in its ConfigMap.
+	CheckpointFilename = "checkpoint.pb"
 )
 
 var (
@@ -57,6 +71,12 @@ func ExpectedResourceHashedName(name string) string {
 	return fmt.Sprintf("%s-%s", config.EnvoyPrefix, hashedName)
 }
 
+// ExpectedCheckpointName returns the name of the ConfigMap holding the configuration checkpoint
+// of the proxies.
+func ExpectedCheckpointName(name string) string {
+	return ExpectedResourceHashedName(name) + "-checkpoint"
+}
+
 // EnvoyAppLabel returns the labels used for all Envoy resources.
 func EnvoyAppLabel() map[string]string {
 	return map[string]string{
@@ -143,12 +163,45 @@ func expectedProxyContainers(infra *ir.ProxyInfra,
 		proxyMetrics = infra.Config.Spec.Telemetry.Metrics
 	}
 
+	var (
+		runtimeFlags     *egv1a1.ProxyRuntimeFlags
+		admin            *egv1a1.ProxyAdmin
+		listenerSocket   *egv1a1.ListenerUnixSocket
+		loadReporting    *egv1a1.ProxyLoadReporting
+		xdsCompression   *egv1a1.ProxyXdsCompression
+		nodeGroup        string
+		checkpoint       *egv1a1.ProxyConfigCheckpoint
+		runtimeDiscovery *egv1a1.ProxyRuntimeDiscovery
+	)
+	if infra.Config != nil {
+		runtimeFlags = infra.Config.Spec.RuntimeFlags
+		admin = infra.Config.Spec.Admin
+		listenerSocket = infra.Config.Spec.ListenerUnixSocket
+		loadReporting = infra.Config.Spec.LoadReporting
+		xdsCompression = infra.Config.Spec.XdsCompression
+		if infra.Config.Spec.NodeGroup != nil {
+			// The node group is read from the label of the pod, so that the pods of
+			// an additional pool of proxies only need a different label.
+			nodeGroup = fmt.Sprintf("$(%s)", envoyNodeGroupEnvVar)
+		}
+		checkpoint = infra.Config.Spec.ConfigCheckpoint
+		runtimeDiscovery = infra.Config.Spec.RuntimeDiscovery
+	}
+
 	maxHeapSizeBytes := calculateMaxHeapSizeBytes(containerSpec.Resources)
 
 	// Get the default Bootstrap
 	bootstrapConfigurations, err := bootstrap.GetRenderedBootstrapConfig(&bootstrap.RenderBootstrapConfigOptions{
 		ProxyMetrics:     proxyMetrics,
 		MaxHeapSizeBytes: maxHeapSizeBytes,
+		RuntimeFlags:     runtimeFlags,
+		Admin:            admin,
+		LoadReporting:    loadReporting,
+		XdsServerPort:    infra.XdsServerPort,
+		XdsCompression:   xdsCompression,
+		NodeGroup:        nodeGroup,
+		ConfigCheckpoint: checkpoint != nil,
+		RuntimeDiscovery: runtimeDiscovery != nil,
 	})
 	if err != nil {
 		return nil, err
@@ -201,11 +254,11 @@ func expectedProxyContainers(infra *ir.ProxyInfra,
 			ImagePullPolicy:          corev1.PullIfNotPresent,
 			Command:                  []string{"envoy"},
 			Args:                     args,
-			Env:                      expectedContainerEnv(containerSpec),
+			Env:                      expectedContainerEnv(containerSpec, nodeGroup != ""),
 			Resources:                *containerSpec.Resources,
 			SecurityContext:          expectedEnvoySecurityContext(containerSpec),
 			Ports:                    ports,
-			VolumeMounts:             expectedContainerVolumeMounts(containerSpec),
+			VolumeMounts:             expectedContainerVolumeMounts(containerSpec, admin, listenerSocket),
 			TerminationMessagePolicy: corev1.TerminationMessageReadFile,
 			TerminationMessagePath:   "/dev/termination-log",
 			StartupProbe: &corev1.Probe{
@@ -249,9 +302,10 @@ func expectedProxyContainers(infra *ir.ProxyInfra,
 			Image:                    expectedShutdownManagerImage(shutdownManager),
 			ImagePullPolicy:          corev1.PullIfNotPresent,
 			Command:                  []string{"envoy-gateway"},
-			Args:                     expectedShutdownManagerArgs(shutdownConfig),
-			Env:                      expectedContainerEnv(nil),
+			Args:                     expectedShutdownManagerArgs(shutdownConfig, checkpoint),
+			Env:                      expectedContainerEnv(nil, false),
 			Resources:                *egv1a1.DefaultShutdownManagerContainerResourceRequirements(),
+			VolumeMounts:             append(expectedShutdownManagerVolumeMounts(admin), expectedCheckpointVolumeMounts(checkpoint)...),
 			TerminationMessagePolicy: corev1.TerminationMessageReadFile,
 			TerminationMessagePath:   "/dev/termination-log",
 			StartupProbe: &corev1.Probe{
@@ -296,7 +350,7 @@ func expectedProxyContainers(infra *ir.ProxyInfra,
 			Lifecycle: &corev1.Lifecycle{
 				PreStop: &corev1.LifecycleHandler{
 					Exec: &corev1.ExecAction{
-						Command: expectedShutdownPreStopCommand(shutdownConfig),
+						Command: expectedShutdownPreStopCommand(shutdownConfig, admin),
 					},
 				},
 			},
@@ -314,17 +368,24 @@ func expectedShutdownManagerImage(shutdownManager *egv1a1.ShutdownManager) strin
 	return egv1a1.DefaultShutdownManagerImage
 }
 
-func expectedShutdownManagerArgs(cfg *egv1a1.ShutdownConfig) []string {
+func expectedShutdownManagerArgs(cfg *egv1a1.ShutdownConfig, checkpoint *egv1a1.ProxyConfigCheckpoint) []string {
 	args := []string{"envoy", "shutdown-manager"}
 	if cfg != nil && cfg.DrainTimeout != nil {
 		args = append(args, fmt.Sprintf("--ready-timeout=%.0fs", cfg.DrainTimeout.Seconds()+10))
 	}
+	if checkpoint != nil {
+		args = append(args, fmt.Sprintf("--checkpoint-path=%s/%s", checkpointDir, CheckpointFilename))
+	}
 	return args
 }
 
-func expectedShutdownPreStopCommand(cfg *egv1a1.ShutdownConfig) []string {
+func expectedShutdownPreStopCommand(cfg *egv1a1.ShutdownConfig, admin *egv1a1.ProxyAdmin) []string {
 	command := []string{"envoy-gateway", "envoy", "shutdown"}
 
+	if admin.GetExposure() == egv1a1.ProxyAdminExposureUnixSocket {
+		command = append(command, fmt.Sprintf("--admin-unix-socket=%s", bootstrap.EnvoyAdminUnixSocketPath))
+	}
+
 	if cfg == nil {
 		return command
 	}
@@ -341,7 +402,9 @@ func expectedShutdownPreStopCommand(cfg *egv1a1.ShutdownConfig) []string {
 }
 
 // expectedContainerVolumeMounts returns expected proxy container volume mounts.
-func expectedContainerVolumeMounts(containerSpec *egv1a1.KubernetesContainerSpec) []corev1.VolumeMount {
+func expectedContainerVolumeMounts(containerSpec *egv1a1.KubernetesContainerSpec, admin *egv1a1.ProxyAdmin,
+	listenerSocket *egv1a1.ListenerUnixSocket,
+) []corev1.VolumeMount {
 	volumeMounts := []corev1.VolumeMount{
 		{
 			Name:      "certs",
@@ -353,12 +416,53 @@ func expectedContainerVolumeMounts(containerSpec *egv1a1.KubernetesContainerSpec
 			MountPath: "/sds",
 		},
 	}
+	volumeMounts = append(volumeMounts, expectedShutdownManagerVolumeMounts(admin)...)
+	if listenerSocket != nil {
+		volumeMounts = append(volumeMounts, corev1.VolumeMount{
+			Name:      listenerSocketVolumeName,
+			MountPath: listenerSocket.Directory,
+		})
+	}
 
 	return resource.ExpectedContainerVolumeMounts(containerSpec, volumeMounts)
 }
 
+// expectedShutdownManagerVolumeMounts returns expected shutdown manager container volume mounts.
+// The unix socket of the Envoy admin interface is shared with the shutdown manager, which
+// uses it to drain the listeners.
+func expectedShutdownManagerVolumeMounts(admin *egv1a1.ProxyAdmin) []corev1.VolumeMount {
+	if admin.GetExposure() != egv1a1.ProxyAdminExposureUnixSocket {
+		return nil
+	}
+
+	return []corev1.VolumeMount{
+		{
+			Name:      adminSocketVolumeName,
+			MountPath: bootstrap.EnvoyAdminUnixSocketDir,
+		},
+	}
+}
+
+// expectedCheckpointVolumeMounts returns the volume mounts of the configuration checkpoint,
+// which the shutdown manager serves to the proxy until it reaches Envoy Gateway.
+func expectedCheckpointVolumeMounts(checkpoint *egv1a1.ProxyConfigCheckpoint) []corev1.VolumeMount {
+	if checkpoint == nil {
+		return nil
+	}
+
+	return []corev1.VolumeMount{
+		{
+			Name:      checkpointVolumeName,
+			MountPath: checkpointDir,
+			ReadOnly:  true,
+		},
+	}
+}
+
 // expectedVolumes returns expected proxy deployment volumes.
-func expectedVolumes(name string, pod *egv1a1.KubernetesPodSpec) []corev1.Volume {
+func expectedVolumes(name string, pod *egv1a1.KubernetesPodSpec, admin *egv1a1.ProxyAdmin,
+	listenerSocket *egv1a1.ListenerUnixSocket, checkpoint *egv1a1.ProxyConfigCheckpoint,
+) []corev1.Volume {
 	volumes := []corev1.Volume{
 		{
 			Name: "certs",
@@ -393,11 +497,47 @@ func expectedVolumes(name string, pod *egv1a1.KubernetesPodSpec) []corev1.Volume
 		},
 	}
 
+	if admin.GetExposure() == egv1a1.ProxyAdminExposureUnixSocket {
+		volumes = append(volumes, corev1.Volume{
+			Name: adminSocketVolumeName,
+			VolumeSource: corev1.VolumeSource{
+				EmptyDir: &corev1.EmptyDirVolumeSource{},
+			},
+		})
+	}
+
+	if listenerSocket != nil {
+		volumes = append(volumes, corev1.Volume{
+			Name: listenerSocketVolumeName,
+			VolumeSource: corev1.VolumeSource{
+				EmptyDir: &corev1.EmptyDirVolumeSource{},
+			},
+		})
+	}
+
+	// The checkpoint is optional, as it's only created once the proxies acknowledged
+	// their configuration.
+	if checkpoint != nil {
+		volumes = append(volumes, corev1.Volume{
+			Name: checkpointVolumeName,
+			VolumeSource: corev1.VolumeSource{
+				ConfigMap: &corev1.ConfigMapVolumeSource{
+					LocalObjectReference: corev1.LocalObjectReference{
+						Name: ExpectedCheckpointName(name),
+					},
+					DefaultMode: ptr.To[int32](420),
+					Optional:    ptr.To(true),
+				},
+			},
+		})
+	}
+
 	return resource.ExpectedVolumes(pod, volumes)
 }
 
-// expectedContainerEnv returns expected proxy container envs.
-func expectedContainerEnv(containerSpec *egv1a1.KubernetesContainerSpec) []corev1.EnvVar {
+// expectedContainerEnv returns expected proxy container envs, with the node group of the
+// pod if set.
+func expectedContainerEnv(containerSpec *egv1a1.KubernetesContainerSpec, nodeGroup bool) []corev1.EnvVar {
 	env := []corev1.EnvVar{
 		{
 			Name: envoyNsEnvVar,
@@ -418,6 +558,17 @@ func expectedContainerEnv(containerSpec *egv1a1.KubernetesContainerSpec) []corev
 			},
 		},
 	}
+	if nodeGroup {
+		env = append(env, corev1.EnvVar{
+			Name: envoyNodeGroupEnvVar,
+			ValueFrom: &corev1.EnvVarSource{
+				FieldRef: &corev1.ObjectFieldSelector{
+					APIVersion: "v1",
+					FieldPath:  fmt.Sprintf("metadata.labels['%s']", gatewayapi.NodeGroupLabel),
+				},
+			},
+		})
+	}
 
 	if containerSpec != nil {
 		return resource.ExpectedContainerEnv(containerSpec, env)
@@ -124,6 +124,7 @@ func (r *ResourceRender) Service() (*corev1.Service, error) {
 	serviceSpec := resource.ExpectedServiceSpec(envoyServiceConfig)
 	serviceSpec.Ports = ports
 	serviceSpec.Selector = resource.GetSelector(labels).MatchLabels
+	setServiceIPFamily(&serviceSpec, r.infra.GetProxyConfig().Spec.IPFamily)
 
 	if (*envoyServiceConfig.Type) == egv1a1.ServiceTypeClusterIP {
 		if len(r.infra.Addresses) > 0 {
@@ -166,6 +167,26 @@ func (r *ResourceRender) Service() (*corev1.Service, error) {
 	return svc, nil
 }
 
+// setServiceIPFamily sets the IP families of the service of the managed proxies
+// according to the IP family of the EnvoyProxy.
+func setServiceIPFamily(serviceSpec *corev1.ServiceSpec, ipFamily *egv1a1.IPFamily) {
+	if ipFamily == nil {
+		return
+	}
+
+	switch *ipFamily {
+	case egv1a1.IPv4:
+		serviceSpec.IPFamilies = []corev1.IPFamily{corev1.IPv4Protocol}
+		serviceSpec.IPFamilyPolicy = ptr.To(corev1.IPFamilyPolicySingleStack)
+	case egv1a1.IPv6:
+		serviceSpec.IPFamilies = []corev1.IPFamily{corev1.IPv6Protocol}
+		serviceSpec.IPFamilyPolicy = ptr.To(corev1.IPFamilyPolicySingleStack)
+	case egv1a1.DualStack:
+		serviceSpec.IPFamilies = []corev1.IPFamily{corev1.IPv4Protocol, corev1.IPv6Protocol}
+		serviceSpec.IPFamilyPolicy = ptr.To(corev1.IPFamilyPolicyRequireDualStack)
+	}
+}
+
 // ConfigMap returns the expected ConfigMap based on the provided infra.
 func (r *ResourceRender) ConfigMap() (*corev1.ConfigMap, error) {
 	// Set the labels based on the owning gateway name.
@@ -192,6 +213,32 @@ func (r *ResourceRender) ConfigMap() (*corev1.ConfigMap, error) {
 	}, nil
 }
 
+// CheckpointConfigMap returns the ConfigMap holding the configuration checkpoint of the proxies,
+// which is mounted into the shutdown manager.
+func (r *ResourceRender) CheckpointConfigMap(data []byte) (*corev1.ConfigMap, error) {
+	// Set the labels based on the owning gateway name, for the checkpoint to be swept
+	// with the rest of the infra.
+	labels := envoyLabels(r.infra.GetProxyMetadata().Labels)
+	if OwningGatewayLabelsAbsent(labels) {
+		return nil, fmt.Errorf("missing owning gateway labels")
+	}
+
+	return &corev1.ConfigMap{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "ConfigMap",
+			APIVersion: "v1",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: r.Namespace,
+			Name:      ExpectedCheckpointName(r.infra.Name),
+			Labels:    labels,
+		},
+		BinaryData: map[string][]byte{
+			CheckpointFilename: data,
+		},
+	}, nil
+}
+
 // stableSelector returns a stable selector based on the owning gateway labels.
 // "stable" here means the selector doesn't change when the infra is updated.
 func (r *ResourceRender) stableSelector() *metav1.LabelSelector {
@@ -276,7 +323,7 @@ func (r *ResourceRender) Deployment() (*appsv1.Deployment, error) {
 					SecurityContext:               deploymentConfig.Pod.SecurityContext,
 					Affinity:                      deploymentConfig.Pod.Affinity,
 					Tolerations:                   deploymentConfig.Pod.Tolerations,
-					Volumes:                       expectedVolumes(r.infra.Name, deploymentConfig.Pod),
+					Volumes:                       expectedVolumes(r.infra.Name, deploymentConfig.Pod, proxyConfig.Spec.Admin, proxyConfig.Spec.ListenerUnixSocket, proxyConfig.Spec.ConfigCheckpoint),
 					ImagePullSecrets:              deploymentConfig.Pod.ImagePullSecrets,
 					NodeSelector:                  deploymentConfig.Pod.NodeSelector,
 					TopologySpreadConstraints:     deploymentConfig.Pod.TopologySpreadConstraints,
@@ -502,7 +549,7 @@ func (r *ResourceRender) getPodSpec(
 		SecurityContext:               pod.SecurityContext,
 		Affinity:                      pod.Affinity,
 		Tolerations:                   pod.Tolerations,
-		Volumes:                       expectedVolumes(r.infra.Name, pod),
+		Volumes:                       expectedVolumes(r.infra.Name, pod, proxyConfig.Spec.Admin, proxyConfig.Spec.ListenerUnixSocket, proxyConfig.Spec.ConfigCheckpoint),
 		ImagePullSecrets:              pod.ImagePullSecrets,
 		NodeSelector:                  pod.NodeSelector,
 		TopologySpreadConstraints:     pod.TopologySpreadConstraints,
@@ -541,7 +588,11 @@ func (r *ResourceRender) getPodLabels(pod *egv1a1.KubernetesPodSpec) map[string]
 	labels := r.infra.GetProxyMetadata().Labels
 	maps.Copy(labels, pod.Labels)
 
-	return envoyLabels(labels)
+	podLabels := envoyLabels(labels)
+	if r.infra.Config != nil && r.infra.Config.Spec.NodeGroup != nil {
+		podLabels[gatewayapi.NodeGroupLabel] = *r.infra.Config.Spec.NodeGroup
+	}
+	return podLabels
 }
 
 // OwningGatewayLabelsAbsent Check if labels are missing some OwningGatewayLabels
@@ -99,6 +99,10 @@ func TestDeployment(t *testing.T) {
 		telemetry       *egv1a1.ProxyTelemetry
 		concurrency     *int32
 		extraArgs       []string
+		admin           *egv1a1.ProxyAdmin
+		listenerSocket  *egv1a1.ListenerUnixSocket
+		nodeGroup       *string
+		checkpoint      *egv1a1.ProxyConfigCheckpoint
 	}{
 		{
 			caseName: "default",
@@ -519,6 +523,30 @@ func TestDeployment(t *testing.T) {
 				Name: ptr.To("custom-deployment-name"),
 			},
 		},
+		{
+			caseName: "with-admin-unix-socket",
+			infra:    newTestInfra(),
+			admin: &egv1a1.ProxyAdmin{
+				Exposure: ptr.To(egv1a1.ProxyAdminExposureUnixSocket),
+			},
+		},
+		{
+			caseName: "with-listener-unix-socket",
+			infra:    newTestInfra(),
+			listenerSocket: &egv1a1.ListenerUnixSocket{
+				Directory: "/var/run/envoy-listeners",
+			},
+		},
+		{
+			caseName:  "with-node-group",
+			infra:     newTestInfra(),
+			nodeGroup: ptr.To("partner"),
+		},
+		{
+			caseName:   "with-config-checkpoint",
+			infra:      newTestInfra(),
+			checkpoint: &egv1a1.ProxyConfigCheckpoint{},
+		},
 	}
 	for _, tc := range cases {
 		t.Run(tc.caseName, func(t *testing.T) {
@@ -564,6 +592,17 @@ func TestDeployment(t *testing.T) {
 				tc.infra.Proxy.Config.Spec.ExtraArgs = tc.extraArgs
 			}
 
+			if tc.admin != nil {
+				tc.infra.Proxy.Config.Spec.Admin = tc.admin
+			}
+
+			if tc.listenerSocket != nil {
+				tc.infra.Proxy.Config.Spec.ListenerUnixSocket = tc.listenerSocket
+			}
+
+			tc.infra.Proxy.Config.Spec.NodeGroup = tc.nodeGroup
+			tc.infra.Proxy.Config.Spec.ConfigCheckpoint = tc.checkpoint
+
 			r := NewResourceRender(cfg.Namespace, tc.infra.GetProxyInfra(), cfg.EnvoyGateway)
 			dp, err := r.Deployment()
 			require.NoError(t, err)
@@ -1041,6 +1080,7 @@ func TestService(t *testing.T) {
 		caseName string
 		infra    *ir.Infra
 		service  *egv1a1.KubernetesServiceSpec
+		ipFamily *egv1a1.IPFamily
 	}{
 		{
 			caseName: "default",
@@ -1107,6 +1147,11 @@ func TestService(t *testing.T) {
 				Name: ptr.To("custom-service-name"),
 			},
 		},
+		{
+			caseName: "dualstack",
+			infra:    newTestInfra(),
+			ipFamily: ptr.To(egv1a1.DualStack),
+		},
 	}
 	for _, tc := range cases {
 		t.Run(tc.caseName, func(t *testing.T) {
@@ -1114,6 +1159,9 @@ func TestService(t *testing.T) {
 			if tc.service != nil {
 				provider.EnvoyService = tc.service
 			}
+			if tc.ipFamily != nil {
+				tc.infra.GetProxyInfra().GetProxyConfig().Spec.IPFamily = tc.ipFamily
+			}
 
 			r := NewResourceRender(cfg.Namespace, tc.infra.GetProxyInfra(), cfg.EnvoyGateway)
 			svc, err := r.Service()
@@ -1180,6 +1228,20 @@ func loadConfigmap(tc string) (*corev1.ConfigMap, error) {
 	return cm, nil
 }
 
+func TestCheckpointConfigMap(t *testing.T) {
+	cfg, err := config.New()
+	require.NoError(t, err)
+
+	r := NewResourceRender(cfg.Namespace, newTestInfra().GetProxyInfra(), cfg.EnvoyGateway)
+	cm, err := r.CheckpointConfigMap([]byte("checkpoint"))
+	require.NoError(t, err)
+
+	expected, err := loadConfigmap("checkpoint")
+	require.NoError(t, err)
+
+	assert.Equal(t, expected, cm)
+}
+
 func TestServiceAccount(t *testing.T) {
 	cfg, err := config.New()
 	require.NoError(t, err)
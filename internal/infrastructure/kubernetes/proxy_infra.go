@@ -8,9 +8,14 @@ package kubernetes
 import (
 	"context"
 	"errors"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	"github.com/envoyproxy/gateway/internal/infrastructure/kubernetes/proxy"
 	"github.com/envoyproxy/gateway/internal/ir"
+	"github.com/envoyproxy/gateway/internal/metrics"
 )
 
 // CreateOrUpdateProxyInfra creates the managed kube infra, if it doesn't exist.
@@ -34,5 +39,86 @@ func (i *Infra) DeleteProxyInfra(ctx context.Context, infra *ir.Infra) error {
 	}
 
 	r := proxy.NewResourceRender(i.Namespace, infra.GetProxyInfra(), i.EnvoyGateway)
-	return i.delete(ctx, r)
+	if err := i.delete(ctx, r); err != nil {
+		return err
+	}
+	return i.DeleteProxyCheckpoint(ctx, infra)
+}
+
+// CreateOrUpdateProxyCheckpoint creates or updates the ConfigMap holding the configuration
+// checkpoint of the proxies of the infra.
+func (i *Infra) CreateOrUpdateProxyCheckpoint(ctx context.Context, infra *ir.Infra, data []byte) (err error) {
+	if infra == nil {
+		return errors.New("infra ir is nil")
+	}
+
+	if infra.Proxy == nil {
+		return errors.New("infra proxy ir is nil")
+	}
+
+	var (
+		cm        *corev1.ConfigMap
+		r         = proxy.NewResourceRender(i.Namespace, infra.GetProxyInfra(), i.EnvoyGateway)
+		startTime = time.Now()
+		labels    = []metrics.LabelValue{
+			kindLabel.Value("ConfigMap"),
+			nameLabel.Value(proxy.ExpectedCheckpointName(infra.Proxy.Name)),
+			namespaceLabel.Value(i.Namespace),
+		}
+	)
+
+	if cm, err = r.CheckpointConfigMap(data); err != nil {
+		resourceApplyTotal.WithFailure(metrics.ReasonError, labels...).Increment()
+		return err
+	}
+
+	defer func() {
+		if err == nil {
+			resourceApplyDurationSeconds.With(labels...).Record(time.Since(startTime).Seconds())
+			resourceApplyTotal.WithSuccess(labels...).Increment()
+		} else {
+			resourceApplyTotal.WithFailure(metrics.ReasonError, labels...).Increment()
+		}
+	}()
+
+	return i.Client.ServerSideApply(ctx, cm)
+}
+
+// DeleteProxyCheckpoint removes the ConfigMap holding the configuration checkpoint of the
+// proxies of the infra, if it exists.
+func (i *Infra) DeleteProxyCheckpoint(ctx context.Context, infra *ir.Infra) (err error) {
+	if infra == nil {
+		return errors.New("infra ir is nil")
+	}
+
+	if infra.Proxy == nil {
+		return nil
+	}
+
+	var (
+		name, ns = proxy.ExpectedCheckpointName(infra.Proxy.Name), i.Namespace
+		cm       = &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: ns,
+				Name:      name,
+			},
+		}
+		startTime = time.Now()
+		labels    = []metrics.LabelValue{
+			kindLabel.Value("ConfigMap"),
+			nameLabel.Value(name),
+			namespaceLabel.Value(ns),
+		}
+	)
+
+	defer func() {
+		if err == nil {
+			resourceDeleteDurationSeconds.With(labels...).Record(time.Since(startTime).Seconds())
+			resourceDeleteTotal.WithSuccess(labels...).Increment()
+		} else {
+			resourceDeleteTotal.WithFailure(metrics.ReasonError, labels...).Increment()
+		}
+	}()
+
+	return i.Client.Delete(ctx, cm)
 }
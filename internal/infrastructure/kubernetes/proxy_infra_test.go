@@ -222,3 +222,58 @@ func TestDeleteProxyInfra(t *testing.T) {
 		})
 	}
 }
+
+func TestSweepOrphanedProxyInfra(t *testing.T) {
+	newInfra := func(name string, labels map[string]string) *ir.Infra {
+		infra := ir.NewInfra()
+		infra.GetProxyInfra().Name = name
+		infra.GetProxyInfra().GetProxyMetadata().Labels = proxy.EnvoyAppLabel()
+		for k, v := range labels {
+			infra.GetProxyInfra().GetProxyMetadata().Labels[k] = v
+		}
+		return infra
+	}
+	live := newInfra("default/live", map[string]string{
+		gatewayapi.OwningGatewayNamespaceLabel: "default",
+		gatewayapi.OwningGatewayNameLabel:      "live",
+	})
+	deleted := newInfra("default/deleted", map[string]string{
+		gatewayapi.OwningGatewayNamespaceLabel: "default",
+		gatewayapi.OwningGatewayNameLabel:      "deleted",
+	})
+	merged := newInfra("eg", map[string]string{
+		gatewayapi.OwningGatewayClassLabel: "eg",
+	})
+
+	cli := fakeclient.NewClientBuilder().
+		WithScheme(envoygateway.GetScheme()).
+		WithInterceptorFuncs(interceptorFunc).
+		WithObjects(&gwapiv1.Gateway{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "live"}}).
+		Build()
+	kube := newTestInfraWithClient(t, cli)
+	ctx := context.Background()
+	for _, infra := range []*ir.Infra{live, deleted, merged} {
+		require.NoError(t, kube.CreateOrUpdateProxyInfra(ctx, infra))
+	}
+	// A resource in the namespace without owning labels is left alone.
+	require.NoError(t, cli.Create(ctx, &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Namespace: kube.Namespace, Name: "unrelated"}}))
+
+	// The ServiceAccount, ConfigMap, Deployment and Service of both orphans are deleted.
+	found, err := kube.SweepOrphanedProxyInfra(ctx)
+	require.NoError(t, err)
+	require.Equal(t, 8, found)
+
+	exists := func(infra *ir.Infra) bool {
+		deploy := &appsv1.Deployment{}
+		err := cli.Get(ctx, types.NamespacedName{Namespace: kube.Namespace, Name: proxy.ExpectedResourceHashedName(infra.Proxy.Name)}, deploy)
+		return err == nil
+	}
+	require.True(t, exists(live))
+	require.False(t, exists(deleted))
+	require.False(t, exists(merged))
+	require.NoError(t, cli.Get(ctx, types.NamespacedName{Namespace: kube.Namespace, Name: "unrelated"}, &corev1.ConfigMap{}))
+
+	found, err = kube.SweepOrphanedProxyInfra(ctx)
+	require.NoError(t, err)
+	require.Zero(t, found)
+}
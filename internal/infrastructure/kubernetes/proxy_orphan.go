@@ -0,0 +1,129 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+
+	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	gwapiv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	"github.com/envoyproxy/gateway/internal/gatewayapi"
+	"github.com/envoyproxy/gateway/internal/infrastructure/kubernetes/proxy"
+)
+
+// SweepOrphanedProxyInfra deletes the proxy infra resources of the managed namespace whose
+// owning Gateway or GatewayClass no longer exists, such as the ones left behind when the
+// deletion of the infra failed or Envoy Gateway was down while their owner was deleted.
+// The resources are tracked by their owning labels rather than owner references, since
+// the owning Gateways live in other namespaces. It returns the number of orphaned resources
+// found.
+func (i *Infra) SweepOrphanedProxyInfra(ctx context.Context) (int, error) {
+	lists := []struct {
+		kind string
+		list client.ObjectList
+	}{
+		{"ServiceAccount", &corev1.ServiceAccountList{}},
+		{"ConfigMap", &corev1.ConfigMapList{}},
+		{"Secret", &corev1.SecretList{}},
+		{"Deployment", &appsv1.DeploymentList{}},
+		{"DaemonSet", &appsv1.DaemonSetList{}},
+		{"Service", &corev1.ServiceList{}},
+		{"HPA", &autoscalingv2.HorizontalPodAutoscalerList{}},
+		{"PDB", &policyv1.PodDisruptionBudgetList{}},
+	}
+
+	// Cache the existence of the owners, which are shared by several resources.
+	owners := make(map[string]bool)
+	found := 0
+	for _, l := range lists {
+		kind := l.kind
+		objs, err := i.listProxyInfra(ctx, l.list)
+		if err != nil {
+			return found, fmt.Errorf("failed to list %s in %s: %w", kind, i.Namespace, err)
+		}
+		for _, obj := range objs {
+			exists, err := i.ownerExists(ctx, obj.GetLabels(), owners)
+			if err != nil {
+				return found, err
+			}
+			if exists {
+				continue
+			}
+
+			found++
+			resourceOrphanedTotal.With(kindLabel.Value(kind), nameLabel.Value(obj.GetName()), namespaceLabel.Value(obj.GetNamespace())).Increment()
+			if err := i.Client.Delete(ctx, obj); err != nil {
+				return found, fmt.Errorf("failed to delete orphaned %s %s/%s: %w", kind, obj.GetNamespace(), obj.GetName(), err)
+			}
+		}
+	}
+
+	return found, nil
+}
+
+// listProxyInfra returns the objects of the list in the managed namespace carrying the
+// owning labels of the proxy infra.
+func (i *Infra) listProxyInfra(ctx context.Context, list client.ObjectList) ([]client.Object, error) {
+	if err := i.Client.List(ctx, list, client.InNamespace(i.Namespace)); err != nil {
+		return nil, err
+	}
+
+	items, err := apimeta.ExtractList(list)
+	if err != nil {
+		return nil, err
+	}
+	var objs []client.Object
+	for _, item := range items {
+		if obj, ok := item.(client.Object); ok && !proxy.OwningGatewayLabelsAbsent(obj.GetLabels()) {
+			objs = append(objs, obj)
+		}
+	}
+
+	return objs, nil
+}
+
+// ownerExists returns whether the Gateway, or the GatewayClass of the merged Gateways,
+// owning the resource with the labels exists.
+func (i *Infra) ownerExists(ctx context.Context, labels map[string]string, owners map[string]bool) (bool, error) {
+	var (
+		key   string
+		owner client.Object
+	)
+	if name, ns := labels[gatewayapi.OwningGatewayNameLabel], labels[gatewayapi.OwningGatewayNamespaceLabel]; name != "" && ns != "" {
+		key = "Gateway/" + ns + "/" + name
+		owner = &gwapiv1.Gateway{}
+		owner.SetNamespace(ns)
+		owner.SetName(name)
+	} else {
+		key = "GatewayClass/" + labels[gatewayapi.OwningGatewayClassLabel]
+		owner = &gwapiv1.GatewayClass{}
+		owner.SetName(labels[gatewayapi.OwningGatewayClassLabel])
+	}
+	if exists, ok := owners[key]; ok {
+		return exists, nil
+	}
+
+	err := i.Client.Get(ctx, types.NamespacedName{Namespace: owner.GetNamespace(), Name: owner.GetName()}, owner)
+	switch {
+	case err == nil:
+		owners[key] = true
+	case kerrors.IsNotFound(err):
+		owners[key] = false
+	default:
+		return false, fmt.Errorf("failed to get owner %s: %w", key, err)
+	}
+
+	return owners[key], nil
+}
@@ -44,6 +44,14 @@ func ExpectedServiceSpec(service *egv1a1.KubernetesServiceSpec) corev1.ServiceSp
 		if service.LoadBalancerIP != nil {
 			serviceSpec.LoadBalancerIP = *service.LoadBalancerIP
 		}
+		// The load balancer health checks the nodes on the health check node port, which
+		// only the nodes running a ready pod pass, so that the client IP is preserved.
+		if *service.ExternalTrafficPolicy == egv1a1.ServiceExternalTrafficPolicyLocal && service.HealthCheckNodePort != nil {
+			serviceSpec.HealthCheckNodePort = *service.HealthCheckNodePort
+		}
+	}
+	// The external traffic policy applies to the node ports of the NodePort Services too.
+	if *service.Type == egv1a1.ServiceTypeLoadBalancer || *service.Type == egv1a1.ServiceTypeNodePort {
 		serviceSpec.ExternalTrafficPolicy = corev1.ServiceExternalTrafficPolicy(*service.ExternalTrafficPolicy)
 	}
 
@@ -103,6 +103,30 @@ func TestExpectedServiceSpec(t *testing.T) {
 				ExternalTrafficPolicy: corev1.ServiceExternalTrafficPolicyTypeLocal,
 			},
 		},
+		{
+			name: "LoadBalancerWithHealthCheckNodePort",
+			args: args{service: &egv1a1.KubernetesServiceSpec{
+				Type:                egv1a1.GetKubernetesServiceType(egv1a1.ServiceTypeLoadBalancer),
+				HealthCheckNodePort: ptr.To[int32](32100),
+			}},
+			want: corev1.ServiceSpec{
+				Type:                  corev1.ServiceTypeLoadBalancer,
+				SessionAffinity:       corev1.ServiceAffinityNone,
+				ExternalTrafficPolicy: corev1.ServiceExternalTrafficPolicyTypeLocal,
+				HealthCheckNodePort:   32100,
+			},
+		},
+		{
+			name: "NodePort",
+			args: args{service: &egv1a1.KubernetesServiceSpec{
+				Type: egv1a1.GetKubernetesServiceType(egv1a1.ServiceTypeNodePort),
+			}},
+			want: corev1.ServiceSpec{
+				Type:                  corev1.ServiceTypeNodePort,
+				SessionAffinity:       corev1.ServiceAffinityNone,
+				ExternalTrafficPolicy: corev1.ServiceExternalTrafficPolicyTypeLocal,
+			},
+		},
 		{
 			name: "ClusterIP",
 			args: args{service: &egv1a1.KubernetesServiceSpec{
@@ -27,6 +27,14 @@ type Manager interface {
 	CreateOrUpdateProxyInfra(ctx context.Context, infra *ir.Infra) error
 	// DeleteProxyInfra deletes infra.
 	DeleteProxyInfra(ctx context.Context, infra *ir.Infra) error
+	// CreateOrUpdateProxyCheckpoint creates or updates the configuration checkpoint of the
+	// proxies of the infra.
+	CreateOrUpdateProxyCheckpoint(ctx context.Context, infra *ir.Infra, data []byte) error
+	// DeleteProxyCheckpoint deletes the configuration checkpoint of the proxies of the infra.
+	DeleteProxyCheckpoint(ctx context.Context, infra *ir.Infra) error
+	// SweepOrphanedProxyInfra deletes the infra whose owner no longer exists, returning
+	// the number of orphaned resources found.
+	SweepOrphanedProxyInfra(ctx context.Context) (int, error)
 	// CreateOrUpdateRateLimitInfra creates or updates rate limit infra.
 	CreateOrUpdateRateLimitInfra(ctx context.Context) error
 	// DeleteRateLimitInfra deletes rate limit infra.
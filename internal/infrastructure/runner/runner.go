@@ -7,24 +7,39 @@ package runner
 
 import (
 	"context"
+	"time"
 
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/utils/ptr"
+	gwapiv1 "sigs.k8s.io/gateway-api/apis/v1"
 
 	egv1a1 "github.com/envoyproxy/gateway/api/v1alpha1"
 	"github.com/envoyproxy/gateway/internal/envoygateway/config"
 	"github.com/envoyproxy/gateway/internal/infrastructure"
+	"github.com/envoyproxy/gateway/internal/infrastructure/sizing"
 	"github.com/envoyproxy/gateway/internal/ir"
 	"github.com/envoyproxy/gateway/internal/message"
 )
 
+// orphanSweepInterval is the interval the orphaned proxy infra is swept at.
+const orphanSweepInterval = 10 * time.Minute
+
 type Config struct {
 	config.Server
 	InfraIR *message.InfraIR
+	// Xds is used to compute the size of the xDS configuration of the fleets
+	// for the resource sizing recommendations.
+	Xds *message.Xds
+	// ProviderResources is used to report infrastructure provisioning
+	// errors, which are surfaced on the status of the Gateways, and to
+	// receive the configuration checkpoints of the proxies.
+	ProviderResources *message.ProviderResources
 }
 
 type Runner struct {
 	Config
-	mgr infrastructure.Manager
+	mgr         infrastructure.Manager
+	recommender *sizing.Recommender
 }
 
 func (r *Runner) Name() string {
@@ -51,7 +66,16 @@ func (r *Runner) Start(ctx context.Context) (err error) {
 	}
 
 	initInfra := func() {
+		// Enable the proxy resource sizing recommendations if they have been configured.
+		if r.EnvoyGateway.ProxySizing != nil {
+			if err := r.enableProxySizing(ctx); err != nil {
+				r.Logger.Error(err, "failed to enable proxy sizing recommendations")
+			}
+		}
+
 		go r.subscribeToProxyInfraIR(ctx)
+		go r.subscribeToXdsCheckpoints(ctx)
+		go r.sweepOrphanedInfra(ctx)
 
 		// Enable global ratelimit if it has been configured.
 		if r.EnvoyGateway.RateLimit != nil {
@@ -80,7 +104,7 @@ func (r *Runner) Start(ctx context.Context) (err error) {
 
 func (r *Runner) subscribeToProxyInfraIR(ctx context.Context) {
 	// Subscribe to resources
-	message.HandleSubscription(message.Metadata{Runner: string(egv1a1.LogComponentInfrastructureRunner), Message: "infra-ir"}, r.InfraIR.Subscribe(ctx),
+	message.HandleTracedSubscription(message.Metadata{Runner: string(egv1a1.LogComponentInfrastructureRunner), Message: "infra-ir"}, r.InfraIR.Subscribe(ctx),
 		func(update message.Update[string, *ir.Infra], errChan chan error) {
 			r.Logger.Info("received an update")
 			val := update.Value
@@ -90,6 +114,7 @@ func (r *Runner) subscribeToProxyInfraIR(ctx context.Context) {
 					r.Logger.Error(err, "failed to delete infra")
 					errChan <- err
 				}
+				r.clearInfraError(update.Key)
 			} else {
 				// Manage the proxy infra.
 				if len(val.Proxy.Listeners) == 0 {
@@ -97,16 +122,117 @@ func (r *Runner) subscribeToProxyInfraIR(ctx context.Context) {
 					return
 				}
 
-				if err := r.mgr.CreateOrUpdateProxyInfra(ctx, val); err != nil {
+				if err := r.mgr.CreateOrUpdateProxyInfra(ctx, r.recommender.Apply(update.Key, val)); err != nil {
 					r.Logger.Error(err, "failed to create new infra")
+					r.storeInfraError(update.Key, err)
 					errChan <- err
+					return
 				}
+				r.clearInfraError(update.Key)
 			}
 		},
 	)
 	r.Logger.Info("infra subscriber shutting down")
 }
 
+// subscribeToXdsCheckpoints stores the configuration checkpoints of the proxies published by
+// the xds server runner in the ConfigMaps mounted into the proxies.
+func (r *Runner) subscribeToXdsCheckpoints(ctx context.Context) {
+	if r.ProviderResources == nil {
+		return
+	}
+
+	message.HandleSubscription(message.Metadata{Runner: string(egv1a1.LogComponentInfrastructureRunner), Message: "xds-checkpoint"}, r.ProviderResources.XdsCheckpoints.Subscribe(ctx),
+		func(update message.Update[string, *message.XdsCheckpoint], errChan chan error) {
+			// The checkpoint of a deleted infra is deleted with the rest of the infra.
+			infra, ok := r.InfraIR.Load(update.Key)
+			if !ok || infra.Proxy == nil {
+				return
+			}
+
+			if update.Delete {
+				if err := r.mgr.DeleteProxyCheckpoint(ctx, infra); err != nil {
+					r.Logger.Error(err, "failed to delete checkpoint", "irKey", update.Key)
+					errChan <- err
+				}
+				return
+			}
+
+			if err := r.mgr.CreateOrUpdateProxyCheckpoint(ctx, infra, update.Value.Data); err != nil {
+				r.Logger.Error(err, "failed to store checkpoint", "irKey", update.Key, "version", update.Value.Version)
+				errChan <- err
+			}
+		},
+	)
+	r.Logger.Info("checkpoint subscriber shutting down")
+}
+
+// sweepOrphanedInfra periodically deletes the proxy infra whose owning Gateway or
+// GatewayClass no longer exists, which is missed when the deletion of the infra fails
+// or the owner is deleted while Envoy Gateway is down.
+func (r *Runner) sweepOrphanedInfra(ctx context.Context) {
+	ticker := time.NewTicker(orphanSweepInterval)
+	defer ticker.Stop()
+
+	for {
+		found, err := r.mgr.SweepOrphanedProxyInfra(ctx)
+		if err != nil {
+			r.Logger.Error(err, "failed to sweep orphaned infra")
+		} else if found > 0 {
+			r.Logger.Info("deleted orphaned infra resources", "count", found)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// storeInfraError records the error that occurred while provisioning the
+// infrastructure for the infra IR, so that it's surfaced on the Gateways.
+func (r *Runner) storeInfraError(key string, err error) {
+	if r.ProviderResources == nil {
+		return
+	}
+	r.ProviderResources.InfraErrors.Store(key, &message.InfraError{
+		Reason:  infraErrorReason(err),
+		Message: err.Error(),
+	})
+}
+
+// clearInfraError removes the error recorded for the infra IR, if any.
+func (r *Runner) clearInfraError(key string) {
+	if r.ProviderResources == nil {
+		return
+	}
+	if _, ok := r.ProviderResources.InfraErrors.Load(key); ok {
+		r.ProviderResources.InfraErrors.Delete(key)
+	}
+}
+
+// infraErrorReason returns the Programmed condition reason for the error.
+// Errors caused by invalid resources, such as bad EnvoyProxy values, are
+// reported as Invalid, while all other errors, such as exceeded quotas or
+// missing permissions, are reported as NoResources.
+func infraErrorReason(err error) gwapiv1.GatewayConditionReason {
+	if kerrors.IsInvalid(err) || kerrors.IsBadRequest(err) {
+		return gwapiv1.GatewayReasonInvalid
+	}
+	return gwapiv1.GatewayReasonNoResources
+}
+
+func (r *Runner) enableProxySizing(ctx context.Context) error {
+	source, err := sizing.NewKubernetesUsageSource(r.Namespace)
+	if err != nil {
+		return err
+	}
+	r.recommender = sizing.New(r.EnvoyGateway.ProxySizing, source, r.InfraIR, r.Xds, r.mgr.CreateOrUpdateProxyInfra, r.Logger)
+	go r.recommender.Start(ctx)
+	return nil
+}
+
 func (r *Runner) enableRateLimitInfra(ctx context.Context) {
 	if err := r.mgr.CreateOrUpdateRateLimitInfra(ctx); err != nil {
 		r.Logger.Error(err, "failed to create ratelimit infra")
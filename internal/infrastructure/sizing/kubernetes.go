@@ -0,0 +1,67 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package sizing
+
+import (
+	"context"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	metricsv1beta1 "k8s.io/metrics/pkg/apis/metrics/v1beta1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	clicfg "sigs.k8s.io/controller-runtime/pkg/client/config"
+)
+
+// envoyContainerName is the name of the Envoy container of the proxy pods.
+const envoyContainerName = "envoy"
+
+// kubernetesUsageSource gets the usage of the Envoy Proxy fleets from the
+// Kubernetes metrics API.
+type kubernetesUsageSource struct {
+	client    client.Client
+	namespace string
+}
+
+// NewKubernetesUsageSource returns a UsageSource that gets the usage of the Envoy
+// Proxy fleets in the namespace from the Kubernetes metrics API.
+func NewKubernetesUsageSource(namespace string) (UsageSource, error) {
+	scheme := runtime.NewScheme()
+	if err := metricsv1beta1.AddToScheme(scheme); err != nil {
+		return nil, err
+	}
+	cli, err := client.New(clicfg.GetConfigOrDie(), client.Options{Scheme: scheme})
+	if err != nil {
+		return nil, err
+	}
+
+	return &kubernetesUsageSource{client: cli, namespace: namespace}, nil
+}
+
+func (k *kubernetesUsageSource) Usage(ctx context.Context, labels map[string]string) (*Usage, error) {
+	podMetrics := new(metricsv1beta1.PodMetricsList)
+	if err := k.client.List(ctx, podMetrics, client.InNamespace(k.namespace), client.MatchingLabels(labels)); err != nil {
+		return nil, err
+	}
+
+	return usageFromPodMetrics(podMetrics.Items), nil
+}
+
+// usageFromPodMetrics returns the highest usage of the Envoy containers of the pods.
+func usageFromPodMetrics(pods []metricsv1beta1.PodMetrics) *Usage {
+	var usage *Usage
+	for i := range pods {
+		for _, container := range pods[i].Containers {
+			if container.Name != envoyContainerName {
+				continue
+			}
+			if usage == nil {
+				usage = &Usage{}
+			}
+			usage.CPU = max(usage.CPU, container.Usage.Cpu().MilliValue())
+			usage.Memory = max(usage.Memory, container.Usage.Memory().Value())
+		}
+	}
+	return usage
+}
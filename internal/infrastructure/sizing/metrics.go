@@ -0,0 +1,42 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package sizing
+
+import "github.com/envoyproxy/gateway/internal/metrics"
+
+var (
+	recommendedCPURequest = metrics.NewGauge(
+		"proxy_sizing_recommended_cpu_request_millicores",
+		"Recommended CPU request of the Envoy container of a proxy fleet, in millicores.",
+	)
+
+	recommendedMemoryRequest = metrics.NewGauge(
+		"proxy_sizing_recommended_memory_request_bytes",
+		"Recommended memory request of the Envoy container of a proxy fleet.",
+		metrics.WithUnit(metrics.Bytes),
+	)
+
+	recommendedMemoryLimit = metrics.NewGauge(
+		"proxy_sizing_recommended_memory_limit_bytes",
+		"Recommended memory limit of the Envoy container of a proxy fleet.",
+		metrics.WithUnit(metrics.Bytes),
+	)
+
+	recommendedConcurrency = metrics.NewGauge(
+		"proxy_sizing_recommended_concurrency",
+		"Recommended number of Envoy worker threads of a proxy fleet.",
+	)
+
+	fleetLabel = metrics.NewLabel("fleet")
+)
+
+// recordRecommendation publishes the recommendation of the fleet of the infra IR.
+func recordRecommendation(key string, rec *Recommendation) {
+	recommendedCPURequest.With(fleetLabel.Value(key)).Record(float64(rec.CPURequest))
+	recommendedMemoryRequest.With(fleetLabel.Value(key)).Record(float64(rec.MemoryRequest))
+	recommendedMemoryLimit.With(fleetLabel.Value(key)).Record(float64(rec.MemoryLimit))
+	recommendedConcurrency.With(fleetLabel.Value(key)).Record(float64(rec.Concurrency))
+}
@@ -0,0 +1,254 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package sizing
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"google.golang.org/protobuf/proto"
+
+	egv1a1 "github.com/envoyproxy/gateway/api/v1alpha1"
+	"github.com/envoyproxy/gateway/internal/gatewayapi"
+	"github.com/envoyproxy/gateway/internal/ir"
+	"github.com/envoyproxy/gateway/internal/logging"
+	"github.com/envoyproxy/gateway/internal/message"
+)
+
+const (
+	defaultInterval        = time.Minute
+	defaultWindow          = 60
+	defaultHeadroomPercent = 20
+	// applyTolerancePercent is the change of the recommended requests that's
+	// required to apply a new recommendation in the Auto mode, which avoids
+	// rolling out the Envoy Proxy fleets on small usage changes.
+	applyTolerancePercent = 10
+)
+
+// UsageSource provides the resource usage of the Envoy Proxy fleets.
+type UsageSource interface {
+	// Usage returns the current usage of the fleet whose pods match the labels,
+	// or nil if no usage is available.
+	Usage(ctx context.Context, labels map[string]string) (*Usage, error)
+}
+
+// ApplyFunc updates the infrastructure of a fleet to apply a recommendation.
+type ApplyFunc func(ctx context.Context, infra *ir.Infra) error
+
+// Recommender periodically samples the resource usage of the Envoy Proxy fleets and
+// computes their resource sizing recommendations.
+type Recommender struct {
+	mode            egv1a1.ProxySizingMode
+	interval        time.Duration
+	window          int
+	headroomPercent uint32
+
+	source  UsageSource
+	infraIR *message.InfraIR
+	xds     *message.Xds
+	apply   ApplyFunc
+	logger  logging.Logger
+
+	mu sync.Mutex
+	// samples holds the usage samples in the window, per infra IR key.
+	samples map[string][]Usage
+	// recommendations holds the latest recommendation, per infra IR key.
+	recommendations map[string]*Recommendation
+	// applied holds the recommendation applied in the Auto mode, per infra IR key.
+	applied map[string]*Recommendation
+}
+
+// New returns a Recommender for the fleets of the infra IR. The apply function is
+// called in the Auto mode when the recommendation of a fleet changed.
+func New(sizing *egv1a1.EnvoyGatewayProxySizing, source UsageSource, infraIR *message.InfraIR,
+	xds *message.Xds, apply ApplyFunc, logger logging.Logger,
+) *Recommender {
+	r := &Recommender{
+		mode:            egv1a1.ProxySizingModeRecommend,
+		interval:        defaultInterval,
+		window:          defaultWindow,
+		headroomPercent: defaultHeadroomPercent,
+		source:          source,
+		infraIR:         infraIR,
+		xds:             xds,
+		apply:           apply,
+		logger:          logger.WithName("proxy-sizing"),
+		samples:         make(map[string][]Usage),
+		recommendations: make(map[string]*Recommendation),
+		applied:         make(map[string]*Recommendation),
+	}
+
+	if sizing == nil {
+		return r
+	}
+	if sizing.Mode != nil {
+		r.mode = *sizing.Mode
+	}
+	if sizing.Interval != nil {
+		if d, err := time.ParseDuration(string(*sizing.Interval)); err == nil {
+			r.interval = d
+		}
+	}
+	if sizing.Window != nil && *sizing.Window > 0 {
+		r.window = int(*sizing.Window)
+	}
+	if sizing.HeadroomPercent != nil {
+		r.headroomPercent = *sizing.HeadroomPercent
+	}
+
+	return r
+}
+
+// Start samples the fleets at every interval until the context is done.
+func (r *Recommender) Start(ctx context.Context) {
+	r.logger.Info("started", "mode", r.mode, "interval", r.interval)
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			r.sample(ctx)
+		}
+	}
+}
+
+// sample collects the usage of every fleet and updates its recommendation.
+func (r *Recommender) sample(ctx context.Context) {
+	infras := r.infraIR.LoadAll()
+
+	for key, infra := range infras {
+		if infra == nil || infra.Proxy == nil {
+			continue
+		}
+
+		usage, err := r.source.Usage(ctx, fleetLabels(infra))
+		if err != nil {
+			r.logger.Error(err, "failed to get the resource usage of the fleet", "key", key)
+			continue
+		}
+
+		rec, changed := r.record(key, usage, r.configBytes(key))
+		if rec == nil {
+			continue
+		}
+		recordRecommendation(key, rec)
+
+		if !changed {
+			continue
+		}
+		r.logger.Info("updated recommendation", "key", key, "cpuRequestMillis", rec.CPURequest,
+			"memoryRequestBytes", rec.MemoryRequest, "memoryLimitBytes", rec.MemoryLimit, "concurrency", rec.Concurrency)
+		if r.apply == nil {
+			continue
+		}
+		if err := r.apply(ctx, Apply(infra, rec)); err != nil {
+			r.logger.Error(err, "failed to apply recommendation", "key", key)
+			r.mu.Lock()
+			delete(r.applied, key)
+			r.mu.Unlock()
+		}
+	}
+
+	r.prune(infras)
+}
+
+// record adds the usage sample of the fleet and computes its recommendation. It returns
+// true if the recommendation needs to be applied in the Auto mode.
+func (r *Recommender) record(key string, usage *Usage, configBytes int64) (*Recommendation, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if usage != nil {
+		samples := append(r.samples[key], *usage)
+		if len(samples) > r.window {
+			samples = samples[len(samples)-r.window:]
+		}
+		r.samples[key] = samples
+	}
+	if len(r.samples[key]) == 0 {
+		return nil, false
+	}
+
+	rec := Recommend(r.samples[key], configBytes, r.headroomPercent)
+	r.recommendations[key] = rec
+
+	if r.mode != egv1a1.ProxySizingModeAuto || !rec.differs(r.applied[key], applyTolerancePercent) {
+		return rec, false
+	}
+	r.applied[key] = rec
+	return rec, true
+}
+
+// prune removes the state of the fleets that no longer exist.
+func (r *Recommender) prune(infras map[string]*ir.Infra) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for key := range r.recommendations {
+		if _, ok := infras[key]; !ok {
+			delete(r.samples, key)
+			delete(r.recommendations, key)
+			delete(r.applied, key)
+		}
+	}
+}
+
+// configBytes returns the size of the xDS configuration of the fleet.
+func (r *Recommender) configBytes(key string) int64 {
+	if r.xds == nil {
+		return 0
+	}
+	table, ok := r.xds.Load(key)
+	if !ok || table == nil {
+		return 0
+	}
+
+	var size int64
+	for _, resources := range table.XdsResources {
+		for _, res := range resources {
+			size += int64(proto.Size(res))
+		}
+	}
+	return size
+}
+
+// Recommendation returns the latest recommendation of the fleet of the infra IR, if any.
+func (r *Recommender) Recommendation(key string) *Recommendation {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.recommendations[key]
+}
+
+// Apply returns the infra with the recommendation of its fleet applied in the
+// Auto mode, so that infra updates keep the applied recommendation.
+func (r *Recommender) Apply(key string, infra *ir.Infra) *ir.Infra {
+	if r == nil || r.mode != egv1a1.ProxySizingModeAuto {
+		return infra
+	}
+
+	r.mu.Lock()
+	rec := r.applied[key]
+	r.mu.Unlock()
+
+	return Apply(infra, rec)
+}
+
+// fleetLabels returns the owner labels selecting the pods of the fleet.
+func fleetLabels(infra *ir.Infra) map[string]string {
+	labels := make(map[string]string)
+	for k, v := range infra.Proxy.GetProxyMetadata().Labels {
+		switch k {
+		case gatewayapi.OwningGatewayNamespaceLabel, gatewayapi.OwningGatewayNameLabel, gatewayapi.OwningGatewayClassLabel:
+			labels[k] = v
+		}
+	}
+	return labels
+}
@@ -0,0 +1,151 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package sizing
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/utils/ptr"
+
+	egv1a1 "github.com/envoyproxy/gateway/api/v1alpha1"
+	"github.com/envoyproxy/gateway/internal/ir"
+)
+
+const (
+	// minCPUMillis is the minimum recommended CPU request, in millicores.
+	minCPUMillis = 100
+	// minMemoryBytes is the minimum recommended memory request, in bytes.
+	minMemoryBytes = 64 << 20
+	// baseMemoryBytes is an estimate of the memory used by an Envoy without configuration.
+	baseMemoryBytes = 32 << 20
+	// configMemoryFactor is an estimate of the memory used by Envoy per byte of xDS configuration.
+	configMemoryFactor = 4
+	// memoryLimitFactor is the ratio between the recommended memory limit and request.
+	memoryLimitFactor = 2
+)
+
+// Usage is the resource usage of an Envoy Proxy fleet at a point in time.
+type Usage struct {
+	// CPU is the highest CPU usage of the Envoy containers of the fleet, in millicores.
+	CPU int64
+	// Memory is the highest memory usage of the Envoy containers of the fleet, in bytes.
+	Memory int64
+}
+
+// Recommendation is the resource sizing recommended for an Envoy Proxy fleet.
+type Recommendation struct {
+	// CPURequest is the recommended CPU request of the Envoy container, in millicores.
+	CPURequest int64
+	// MemoryRequest is the recommended memory request of the Envoy container, in bytes.
+	MemoryRequest int64
+	// MemoryLimit is the recommended memory limit of the Envoy container, in bytes.
+	MemoryLimit int64
+	// Concurrency is the recommended number of Envoy worker threads.
+	Concurrency int32
+}
+
+// Recommend computes the recommendation for a fleet based on the usage samples and the
+// size of its xDS configuration, in bytes. The requests are the peak usage of the samples
+// plus the headroom percentage, and the memory request is never lower than the memory
+// estimated to hold the xDS configuration.
+func Recommend(samples []Usage, configBytes int64, headroomPercent uint32) *Recommendation {
+	var peak Usage
+	for _, s := range samples {
+		peak.CPU = max(peak.CPU, s.CPU)
+		peak.Memory = max(peak.Memory, s.Memory)
+	}
+
+	headroom := int64(100 + headroomPercent)
+	cpu := max(peak.CPU*headroom/100, minCPUMillis)
+	memory := max(peak.Memory*headroom/100, baseMemoryBytes+configBytes*configMemoryFactor, minMemoryBytes)
+
+	cpu = roundUp(cpu, 10)
+	memory = roundUp(memory, 1<<20)
+
+	return &Recommendation{
+		CPURequest:    cpu,
+		MemoryRequest: memory,
+		MemoryLimit:   memory * memoryLimitFactor,
+		Concurrency:   int32((cpu + 999) / 1000),
+	}
+}
+
+// differs returns true if the requests of the recommendations differ by more
+// than the tolerance percentage, or if the concurrency differs.
+func (r *Recommendation) differs(o *Recommendation, tolerancePercent int64) bool {
+	if r == nil || o == nil {
+		return r != o
+	}
+	return r.Concurrency != o.Concurrency ||
+		outsideTolerance(r.CPURequest, o.CPURequest, tolerancePercent) ||
+		outsideTolerance(r.MemoryRequest, o.MemoryRequest, tolerancePercent)
+}
+
+func outsideTolerance(a, b, tolerancePercent int64) bool {
+	diff := a - b
+	if diff < 0 {
+		diff = -diff
+	}
+	return diff*100 > b*tolerancePercent
+}
+
+func roundUp(v, unit int64) int64 {
+	return (v + unit - 1) / unit * unit
+}
+
+// Apply returns a copy of the infra with the recommendation applied to the Envoy
+// container of the fleet. The resource requests are overridden, the memory limit is
+// raised to the recommended limit if it's lower than the recommended request, and the
+// concurrency is set if it's not set in the EnvoyProxy.
+func Apply(infra *ir.Infra, rec *Recommendation) *ir.Infra {
+	if infra == nil || infra.Proxy == nil || rec == nil {
+		return infra
+	}
+
+	out := infra.DeepCopy()
+	if out.Proxy.Config == nil {
+		out.Proxy.Config = &egv1a1.EnvoyProxy{}
+	}
+	cfg := out.Proxy.Config
+
+	kube := cfg.GetEnvoyProxyProvider().GetEnvoyProxyKubeProvider()
+	if kube == nil {
+		return infra
+	}
+	if kube.EnvoyDeployment != nil {
+		applyToContainer(kube.EnvoyDeployment.Container, rec)
+	}
+	if kube.EnvoyDaemonSet != nil {
+		applyToContainer(kube.EnvoyDaemonSet.Container, rec)
+	}
+	if cfg.Spec.Concurrency == nil {
+		cfg.Spec.Concurrency = ptr.To(rec.Concurrency)
+	}
+
+	return out
+}
+
+func applyToContainer(container *egv1a1.KubernetesContainerSpec, rec *Recommendation) {
+	if container == nil {
+		return
+	}
+	if container.Resources == nil {
+		container.Resources = &corev1.ResourceRequirements{}
+	}
+	resources := container.Resources
+	if resources.Requests == nil {
+		resources.Requests = corev1.ResourceList{}
+	}
+	resources.Requests[corev1.ResourceCPU] = *resource.NewMilliQuantity(rec.CPURequest, resource.DecimalSI)
+	resources.Requests[corev1.ResourceMemory] = *resource.NewQuantity(rec.MemoryRequest, resource.BinarySI)
+
+	if limit, ok := resources.Limits[corev1.ResourceMemory]; ok && limit.Value() < rec.MemoryRequest {
+		resources.Limits[corev1.ResourceMemory] = *resource.NewQuantity(rec.MemoryLimit, resource.BinarySI)
+	}
+	if limit, ok := resources.Limits[corev1.ResourceCPU]; ok && limit.MilliValue() < rec.CPURequest {
+		resources.Limits[corev1.ResourceCPU] = *resource.NewMilliQuantity(rec.CPURequest, resource.DecimalSI)
+	}
+}
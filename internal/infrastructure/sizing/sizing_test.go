@@ -0,0 +1,215 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package sizing
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metricsv1beta1 "k8s.io/metrics/pkg/apis/metrics/v1beta1"
+	"k8s.io/utils/ptr"
+
+	egv1a1 "github.com/envoyproxy/gateway/api/v1alpha1"
+	"github.com/envoyproxy/gateway/internal/gatewayapi"
+	"github.com/envoyproxy/gateway/internal/ir"
+	"github.com/envoyproxy/gateway/internal/logging"
+	"github.com/envoyproxy/gateway/internal/message"
+)
+
+func TestRecommend(t *testing.T) {
+	testCases := []struct {
+		name        string
+		samples     []Usage
+		configBytes int64
+		headroom    uint32
+		expected    *Recommendation
+	}{
+		{
+			name:     "idle fleet",
+			samples:  []Usage{{CPU: 5, Memory: 20 << 20}},
+			headroom: 20,
+			expected: &Recommendation{
+				CPURequest:    100,
+				MemoryRequest: 64 << 20,
+				MemoryLimit:   128 << 20,
+				Concurrency:   1,
+			},
+		},
+		{
+			name: "peak usage with headroom",
+			samples: []Usage{
+				{CPU: 1500, Memory: 200 << 20},
+				{CPU: 2000, Memory: 100 << 20},
+			},
+			headroom: 20,
+			expected: &Recommendation{
+				CPURequest:    2400,
+				MemoryRequest: 240 << 20,
+				MemoryLimit:   480 << 20,
+				Concurrency:   3,
+			},
+		},
+		{
+			name:        "large config",
+			samples:     []Usage{{CPU: 200, Memory: 100 << 20}},
+			configBytes: 100 << 20,
+			expected: &Recommendation{
+				CPURequest:    200,
+				MemoryRequest: 432 << 20,
+				MemoryLimit:   864 << 20,
+				Concurrency:   1,
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.expected, Recommend(tc.samples, tc.configBytes, tc.headroom))
+		})
+	}
+}
+
+func TestApply(t *testing.T) {
+	rec := &Recommendation{
+		CPURequest:    500,
+		MemoryRequest: 1 << 30,
+		MemoryLimit:   2 << 30,
+		Concurrency:   1,
+	}
+
+	t.Run("default config", func(t *testing.T) {
+		infra := ir.NewInfra()
+		out := Apply(infra, rec)
+
+		require.Nil(t, infra.Proxy.Config)
+		container := out.Proxy.Config.Spec.Provider.Kubernetes.EnvoyDeployment.Container
+		require.Equal(t, "500m", ptr.To(container.Resources.Requests[corev1.ResourceCPU]).String())
+		require.Equal(t, "1Gi", ptr.To(container.Resources.Requests[corev1.ResourceMemory]).String())
+		require.Equal(t, ptr.To[int32](1), out.Proxy.Config.Spec.Concurrency)
+	})
+
+	t.Run("keep concurrency and raise low limit", func(t *testing.T) {
+		infra := ir.NewInfra()
+		infra.Proxy.Config = &egv1a1.EnvoyProxy{
+			Spec: egv1a1.EnvoyProxySpec{
+				Concurrency: ptr.To[int32](4),
+				Provider: &egv1a1.EnvoyProxyProvider{
+					Type: egv1a1.ProviderTypeKubernetes,
+					Kubernetes: &egv1a1.EnvoyProxyKubernetesProvider{
+						EnvoyDaemonSet: &egv1a1.KubernetesDaemonSetSpec{
+							Container: &egv1a1.KubernetesContainerSpec{
+								Resources: &corev1.ResourceRequirements{
+									Limits: corev1.ResourceList{
+										corev1.ResourceMemory: resource.MustParse("512Mi"),
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		}
+		out := Apply(infra, rec)
+
+		container := out.Proxy.Config.Spec.Provider.Kubernetes.EnvoyDaemonSet.Container
+		require.Equal(t, "2Gi", ptr.To(container.Resources.Limits[corev1.ResourceMemory]).String())
+		require.Equal(t, ptr.To[int32](4), out.Proxy.Config.Spec.Concurrency)
+		require.Nil(t, out.Proxy.Config.Spec.Provider.Kubernetes.EnvoyDeployment)
+	})
+}
+
+type fakeUsageSource struct {
+	usage  *Usage
+	labels map[string]string
+}
+
+func (f *fakeUsageSource) Usage(_ context.Context, labels map[string]string) (*Usage, error) {
+	f.labels = labels
+	return f.usage, nil
+}
+
+func TestRecommenderAuto(t *testing.T) {
+	infraIR := new(message.InfraIR)
+	infra := ir.NewInfra()
+	infra.Proxy.GetProxyMetadata().Labels = map[string]string{
+		gatewayapi.OwningGatewayNamespaceLabel: "default",
+		gatewayapi.OwningGatewayNameLabel:      "eg",
+		"custom":                               "label",
+	}
+	infraIR.Store("default/eg", infra)
+
+	var applied []*ir.Infra
+	source := &fakeUsageSource{usage: &Usage{CPU: 1000, Memory: 256 << 20}}
+	r := New(&egv1a1.EnvoyGatewayProxySizing{Mode: ptr.To(egv1a1.ProxySizingModeAuto)}, source, infraIR, nil,
+		func(_ context.Context, infra *ir.Infra) error {
+			applied = append(applied, infra)
+			return nil
+		}, logging.DefaultLogger(egv1a1.LogLevelInfo))
+
+	r.sample(context.Background())
+	require.Equal(t, map[string]string{
+		gatewayapi.OwningGatewayNamespaceLabel: "default",
+		gatewayapi.OwningGatewayNameLabel:      "eg",
+	}, source.labels)
+	require.Len(t, applied, 1)
+	require.Equal(t, int64(1200), r.Recommendation("default/eg").CPURequest)
+
+	// A small usage change doesn't roll out the fleet.
+	source.usage = &Usage{CPU: 1050, Memory: 256 << 20}
+	r.sample(context.Background())
+	require.Len(t, applied, 1)
+	require.Equal(t, int64(1260), r.Recommendation("default/eg").CPURequest)
+
+	// Updates of the infra keep the applied recommendation.
+	out := r.Apply("default/eg", infra)
+	container := out.Proxy.Config.Spec.Provider.Kubernetes.EnvoyDeployment.Container
+	require.Equal(t, "1200m", ptr.To(container.Resources.Requests[corev1.ResourceCPU]).String())
+
+	// The state of deleted fleets is removed.
+	infraIR.Delete("default/eg")
+	r.sample(context.Background())
+	require.Nil(t, r.Recommendation("default/eg"))
+}
+
+func TestUsageFromPodMetrics(t *testing.T) {
+	require.Nil(t, usageFromPodMetrics(nil))
+
+	pods := []metricsv1beta1.PodMetrics{
+		{
+			Containers: []metricsv1beta1.ContainerMetrics{
+				{
+					Name: envoyContainerName,
+					Usage: corev1.ResourceList{
+						corev1.ResourceCPU:    resource.MustParse("250m"),
+						corev1.ResourceMemory: resource.MustParse("100Mi"),
+					},
+				},
+				{
+					Name: "shutdown-manager",
+					Usage: corev1.ResourceList{
+						corev1.ResourceCPU:    resource.MustParse("2"),
+						corev1.ResourceMemory: resource.MustParse("1Gi"),
+					},
+				},
+			},
+		},
+		{
+			Containers: []metricsv1beta1.ContainerMetrics{
+				{
+					Name: envoyContainerName,
+					Usage: corev1.ResourceList{
+						corev1.ResourceCPU:    resource.MustParse("100m"),
+						corev1.ResourceMemory: resource.MustParse("200Mi"),
+					},
+				},
+			},
+		},
+	}
+	require.Equal(t, &Usage{CPU: 250, Memory: 200 << 20}, usageFromPodMetrics(pods))
+}
@@ -54,6 +54,9 @@ type ProxyInfra struct {
 	// Addresses contain the external addresses this gateway has been
 	// requested to be available at.
 	Addresses []string `json:"addresses,omitempty" yaml:"addresses,omitempty"`
+	// XdsServerPort is the port the proxies connect to the xDS server on, or zero for
+	// the default port.
+	XdsServerPort int32 `json:"xdsServerPort,omitempty" yaml:"xdsServerPort,omitempty"`
 }
 
 // InfraMetadata defines metadata for the managed proxy infrastructure.
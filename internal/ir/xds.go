@@ -13,6 +13,7 @@ import (
 	"net/http"
 	"net/netip"
 	"reflect"
+	"time"
 
 	"golang.org/x/exp/slices"
 	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
@@ -46,6 +47,9 @@ var (
 	ErrDestEndpointPortInvalid                 = errors.New("field Port specified is invalid")
 	ErrDestEndpointUDSPortInvalid              = errors.New("field Port must not be specified for Unix Domain Socket address")
 	ErrDestEndpointUDSHostInvalid              = errors.New("field Host must not be specified for Unix Domain Socket address")
+	ErrDestEndpointInternalListenerInvalid     = errors.New("fields Host, Port and Path must not be specified for internal listener address")
+	ErrListenerInternalUnixSocket              = errors.New("field UnixSocketPath must not be specified for internal listener")
+	ErrDestinationOriginalDstEndpoints         = errors.New("field Endpoints must not be specified for original destination")
 	ErrStringMatchConditionInvalid             = errors.New("only one of the Exact, Prefix, SafeRegex or Distinct fields must be set")
 	ErrStringMatchInvertDistinctInvalid        = errors.New("only one of the Invert or Distinct fields can be set")
 	ErrStringMatchNameIsEmpty                  = errors.New("field Name must be specified")
@@ -96,6 +100,165 @@ type Xds struct {
 	EnvoyPatchPolicies []*EnvoyPatchPolicy `json:"envoyPatchPolicies,omitempty" yaml:"envoyPatchPolicies,omitempty"`
 	// FilterOrder holds the custom order of the HTTP filters
 	FilterOrder []egv1a1.FilterPosition `json:"filterOrder,omitempty" yaml:"filterOrder,omitempty"`
+	// Warming holds the settings of the warming of the new listeners and clusters.
+	Warming *Warming `json:"warming,omitempty" yaml:"warming,omitempty"`
+	// LoadReporting holds the settings of the load reports of the proxies.
+	LoadReporting *LoadReporting `json:"loadReporting,omitempty" yaml:"loadReporting,omitempty"`
+	// TrafficRecording holds the settings of the recording of the requests of the proxies.
+	TrafficRecording *TrafficRecording `json:"trafficRecording,omitempty" yaml:"trafficRecording,omitempty"`
+	// DeferDrains holds the settings of the deferral of the changes of the listeners that
+	// drain the connections of the proxies.
+	DeferDrains *DeferDrains `json:"deferDrains,omitempty" yaml:"deferDrains,omitempty"`
+	// XdsServerPort is the port the xDS server serves the proxies on, or zero if they
+	// are served on the default port.
+	XdsServerPort int32 `json:"xdsServerPort,omitempty" yaml:"xdsServerPort,omitempty"`
+	// RouteMatcherTree holds the settings of the matcher trees of the virtual hosts with
+	// many routes.
+	RouteMatcherTree *RouteMatcherTree `json:"routeMatcherTree,omitempty" yaml:"routeMatcherTree,omitempty"`
+	// LocalityEndpointDiscovery holds the settings of the delivery of the endpoints of the
+	// clusters with many endpoints with LEDS.
+	LocalityEndpointDiscovery *LocalityEndpointDiscovery `json:"localityEndpointDiscovery,omitempty" yaml:"localityEndpointDiscovery,omitempty"`
+	// VirtualHostDiscovery holds the settings of the delivery of the virtual hosts of the
+	// route configurations with many virtual hosts on demand with VHDS.
+	VirtualHostDiscovery *VirtualHostDiscovery `json:"virtualHostDiscovery,omitempty" yaml:"virtualHostDiscovery,omitempty"`
+	// ExtensionConfigDiscovery holds the settings of the delivery of the configurations of
+	// the HTTP filters with ECDS.
+	ExtensionConfigDiscovery *ExtensionConfigDiscovery `json:"extensionConfigDiscovery,omitempty" yaml:"extensionConfigDiscovery,omitempty"`
+	// Drain holds the settings of the drain of the proxies of a Gateway before its deletion.
+	Drain *Drain `json:"drain,omitempty" yaml:"drain,omitempty"`
+	// ConfigCheckpoint holds the settings of the checkpoints of the configuration of the
+	// proxies.
+	ConfigCheckpoint *ConfigCheckpoint `json:"configCheckpoint,omitempty" yaml:"configCheckpoint,omitempty"`
+	// RuntimeDiscovery holds the runtime values delivered to the proxies with RTDS.
+	RuntimeDiscovery *RuntimeDiscovery `json:"runtimeDiscovery,omitempty" yaml:"runtimeDiscovery,omitempty"`
+}
+
+// RuntimeDiscovery holds the runtime values of the runtime layer delivered to the proxies
+// with RTDS.
+// +k8s:deepcopy-gen=true
+type RuntimeDiscovery struct {
+	// Values are the runtime values of the layer.
+	Values []RuntimeValue `json:"values,omitempty" yaml:"values,omitempty"`
+}
+
+// RuntimeValue is the value of a runtime key.
+// +k8s:deepcopy-gen=true
+type RuntimeValue struct {
+	// Key is the runtime key.
+	Key string `json:"key" yaml:"key"`
+	// Value is the value of the runtime key.
+	Value string `json:"value" yaml:"value"`
+}
+
+// ConfigCheckpoint holds the settings of the checkpoints of the last configuration
+// acknowledged by the proxies, which the proxies fall back to until they reach the xDS server.
+// +k8s:deepcopy-gen=true
+type ConfigCheckpoint struct {
+	// Interval is how often the configuration is checkpointed.
+	Interval metav1.Duration `json:"interval" yaml:"interval"`
+}
+
+// Drain holds the settings of the drain of the proxies of a Gateway before its deletion,
+// answering the requests of the new connections while the open connections finish.
+// +k8s:deepcopy-gen=true
+type Drain struct {
+	// RetryAfter is the number of seconds of the Retry-After header of the responses
+	// rejecting the requests.
+	RetryAfter uint32 `json:"retryAfter" yaml:"retryAfter"`
+	// RedirectHost is the host the requests are redirected to instead of being rejected,
+	// if set.
+	RedirectHost string `json:"redirectHost,omitempty" yaml:"redirectHost,omitempty"`
+}
+
+// VirtualHostDiscovery holds the settings of the delivery of the virtual hosts of the route
+// configurations with many virtual hosts on demand with VHDS.
+// +k8s:deepcopy-gen=true
+type VirtualHostDiscovery struct {
+	// MinVirtualHosts is the number of virtual hosts of a route configuration from which
+	// its virtual hosts are delivered on demand with VHDS.
+	MinVirtualHosts uint32 `json:"minVirtualHosts" yaml:"minVirtualHosts"`
+}
+
+// ExtensionConfigDiscovery holds the settings of the delivery of the configurations of the
+// HTTP filters with ECDS.
+// +k8s:deepcopy-gen=true
+type ExtensionConfigDiscovery struct {
+	// Filters are the HTTP filters whose configurations are delivered with ECDS.
+	Filters []egv1a1.EnvoyFilter `json:"filters" yaml:"filters"`
+}
+
+// LocalityEndpointDiscovery holds the settings of the delivery of the endpoints of the
+// clusters with many endpoints with LEDS.
+// +k8s:deepcopy-gen=true
+type LocalityEndpointDiscovery struct {
+	// MinEndpoints is the number of endpoints of a cluster from which its endpoints are
+	// delivered with LEDS.
+	MinEndpoints uint32 `json:"minEndpoints" yaml:"minEndpoints"`
+}
+
+// RouteMatcherTree holds the settings of the matcher trees of the virtual hosts with many
+// routes.
+// +k8s:deepcopy-gen=true
+type RouteMatcherTree struct {
+	// MinRoutes is the number of routes of a virtual host from which its routes are
+	// matched with a matcher tree.
+	MinRoutes uint32 `json:"minRoutes" yaml:"minRoutes"`
+}
+
+// TrafficRecording holds the settings of the recording of the requests of the proxies.
+// +k8s:deepcopy-gen=true
+type TrafficRecording struct {
+	// SamplingPercentage is the percentage of the requests that are recorded.
+	SamplingPercentage uint32 `json:"samplingPercentage" yaml:"samplingPercentage"`
+	// MaxRequests is the number of the most recent requests that are kept.
+	MaxRequests uint32 `json:"maxRequests" yaml:"maxRequests"`
+	// Headers are the names of the request headers that are recorded.
+	Headers []string `json:"headers,omitempty" yaml:"headers,omitempty"`
+}
+
+// LoadReporting holds the settings of the load reports of the proxies.
+// +k8s:deepcopy-gen=true
+type LoadReporting struct {
+	// Interval is the interval at which the proxies report the load.
+	Interval metav1.Duration `json:"interval" yaml:"interval"`
+	// Balance adjusts the load balancing weights of the endpoints based on their load.
+	Balance bool `json:"balance,omitempty" yaml:"balance,omitempty"`
+	// Metric is the name of the ORCA metric used as the load of the endpoints,
+	// or empty to use the requests in progress.
+	Metric string `json:"metric,omitempty" yaml:"metric,omitempty"`
+}
+
+// DeferDrains holds the settings of the deferral of the changes of the listeners that
+// drain the connections of the proxies.
+// +k8s:deepcopy-gen=true
+type DeferDrains struct {
+	// FilterChains also defers the changes draining the connections of a filter chain,
+	// on top of the changes draining all the connections of a listener.
+	FilterChains bool `json:"filterChains,omitempty" yaml:"filterChains,omitempty"`
+	// MaintenanceWindow is the window the deferred changes are applied during.
+	MaintenanceWindow MaintenanceWindow `json:"maintenanceWindow" yaml:"maintenanceWindow"`
+}
+
+// MaintenanceWindow is a recurring window of time.
+// +k8s:deepcopy-gen=true
+type MaintenanceWindow struct {
+	// Start is the offset from midnight UTC the window opens at.
+	Start metav1.Duration `json:"start" yaml:"start"`
+	// Duration is how long the window stays open.
+	Duration metav1.Duration `json:"duration" yaml:"duration"`
+	// Days are the days of the week the window opens on, every day if empty.
+	Days []time.Weekday `json:"days,omitempty" yaml:"days,omitempty"`
+}
+
+// Warming holds the settings of the warming of the new listeners and clusters.
+// +k8s:deepcopy-gen=true
+type Warming struct {
+	// InitialFetchTimeout is the maximum time the new listeners wait for their routes, and the
+	// new clusters wait for their endpoints, before they are considered warm.
+	InitialFetchTimeout *metav1.Duration `json:"initialFetchTimeout,omitempty" yaml:"initialFetchTimeout,omitempty"`
+	// WaitForListenerAck gates the Programmed condition of the Gateways on all the proxies
+	// acknowledging the listeners.
+	WaitForListenerAck bool `json:"waitForListenerAck,omitempty" yaml:"waitForListenerAck,omitempty"`
 }
 
 // Equal implements the Comparable interface used by watchable.DeepEqual to skip unnecessary updates.
@@ -200,6 +363,14 @@ func (x *Xds) Printable() *Xds {
 			if route.Security != nil {
 				route.Security = route.Security.Printable()
 			}
+			if route.Traffic != nil && route.Traffic.CredentialInjection != nil {
+				if route.Traffic.CredentialInjection.BearerToken != nil {
+					route.Traffic.CredentialInjection.BearerToken.Token = redacted
+				}
+				if route.Traffic.CredentialInjection.OAuth2 != nil {
+					route.Traffic.CredentialInjection.OAuth2.ClientSecret = redacted
+				}
+			}
 		}
 	}
 	return out
@@ -209,6 +380,7 @@ type Listener interface {
 	GetName() string
 	GetAddress() string
 	GetPort() uint32
+	GetUnixSocketPath() *string
 	GetExtensionRefs() []*UnstructuredRef
 }
 
@@ -224,6 +396,30 @@ type CoreListenerDetails struct {
 	ExtensionRefs []*UnstructuredRef `json:"extensionRefs,omitempty" yaml:"extensionRefs,omitempty"`
 	// Metadata is used to enrich envoy resource metadata with user and provider-specific information
 	Metadata *ResourceMetadata `json:"metadata,omitempty" yaml:"metadata,omitempty"`
+	// IPFamily specifies the IP family the listener binds to.
+	IPFamily *egv1a1.IPFamily `json:"ipFamily,omitempty" yaml:"ipFamily,omitempty"`
+	// UnixSocketPath is the unix domain socket path the listener binds to instead of Address and Port.
+	UnixSocketPath *string `json:"unixSocketPath,omitempty" yaml:"unixSocketPath,omitempty"`
+	// Internal indicates the listener is an Envoy internal listener, which doesn't bind to Address and Port
+	// and is only reachable by the clusters whose endpoints reference it by name. Internal listeners allow
+	// chaining listeners, e.g. terminating TLS on a listener then routing the decrypted traffic on another.
+	Internal bool `json:"internal,omitempty" yaml:"internal,omitempty"`
+	// Tuning holds how the listener accepts the connections and balances them over the worker threads.
+	Tuning *ListenerTuning `json:"tuning,omitempty" yaml:"tuning,omitempty"`
+}
+
+// ListenerTuning holds how a listener accepts the connections and balances them over the
+// worker threads.
+// +k8s:deepcopy-gen=true
+type ListenerTuning struct {
+	// ExactBalance hands each accepted connection to the worker thread with the fewest active connections.
+	ExactBalance bool `json:"exactBalance,omitempty" yaml:"exactBalance,omitempty"`
+	// MaxConnectionsToAcceptPerSocketEvent is the maximum number of connections a worker thread accepts at once.
+	MaxConnectionsToAcceptPerSocketEvent *uint32 `json:"maxConnectionsToAcceptPerSocketEvent,omitempty" yaml:"maxConnectionsToAcceptPerSocketEvent,omitempty"`
+	// ListenerFiltersTimeout is the maximum time the listener filters wait for the first bytes of a connection.
+	ListenerFiltersTimeout *metav1.Duration `json:"listenerFiltersTimeout,omitempty" yaml:"listenerFiltersTimeout,omitempty"`
+	// ContinueOnListenerFiltersTimeout selects the filter chain of the connections whose listener filters time out.
+	ContinueOnListenerFiltersTimeout bool `json:"continueOnListenerFiltersTimeout,omitempty" yaml:"continueOnListenerFiltersTimeout,omitempty"`
 }
 
 func (l CoreListenerDetails) GetName() string {
@@ -238,6 +434,10 @@ func (l CoreListenerDetails) GetPort() uint32 {
 	return l.Port
 }
 
+func (l CoreListenerDetails) GetUnixSocketPath() *string {
+	return l.UnixSocketPath
+}
+
 func (l CoreListenerDetails) GetExtensionRefs() []*UnstructuredRef {
 	return l.ExtensionRefs
 }
@@ -291,11 +491,18 @@ func (h HTTPListener) Validate() error {
 	if h.Name == "" {
 		errs = errors.Join(errs, ErrListenerNameEmpty)
 	}
-	if _, err := netip.ParseAddr(h.Address); err != nil {
-		errs = errors.Join(errs, ErrListenerAddressInvalid)
-	}
-	if h.Port == 0 {
-		errs = errors.Join(errs, ErrListenerPortInvalid)
+	// Internal listeners don't bind to an address and port.
+	if h.Internal {
+		if h.UnixSocketPath != nil {
+			errs = errors.Join(errs, ErrListenerInternalUnixSocket)
+		}
+	} else {
+		if _, err := netip.ParseAddr(h.Address); err != nil {
+			errs = errors.Join(errs, ErrListenerAddressInvalid)
+		}
+		if h.Port == 0 {
+			errs = errors.Join(errs, ErrListenerPortInvalid)
+		}
 	}
 	if len(h.Hostnames) == 0 {
 		errs = errors.Join(errs, ErrHTTPListenerHostnamesEmpty)
@@ -351,6 +558,37 @@ type TLSConfig struct {
 	SignatureAlgorithms []string `json:"signatureAlgorithms,omitempty" yaml:"signatureAlgorithms,omitempty"`
 	// ALPNProtocols exposed by this listener
 	ALPNProtocols []string `json:"alpnProtocols,omitempty" yaml:"alpnProtocols,omitempty"`
+	// DisableStatelessSessionResumption disables the session resumption with session tickets.
+	DisableStatelessSessionResumption bool `json:"disableStatelessSessionResumption,omitempty" yaml:"disableStatelessSessionResumption,omitempty"`
+	// DisableStatefulSessionResumption disables the session resumption with session IDs.
+	DisableStatefulSessionResumption bool `json:"disableStatefulSessionResumption,omitempty" yaml:"disableStatefulSessionResumption,omitempty"`
+	// SessionTicketKeys holds the rotation settings of the session ticket keys managed by
+	// the control plane, if any.
+	SessionTicketKeys *SessionTicketKeys `json:"sessionTicketKeys,omitempty" yaml:"sessionTicketKeys,omitempty"`
+	// NoSNIMatch holds the handling of the connections whose SNI matches none of the
+	// hostnames of the listeners sharing the port.
+	NoSNIMatch *NoSNIMatch `json:"noSNIMatch,omitempty" yaml:"noSNIMatch,omitempty"`
+}
+
+// NoSNIMatch holds the handling of the TLS connections whose SNI matches no hostname.
+// +k8s:deepcopy-gen=true
+type NoSNIMatch struct {
+	// Action taken on the connections.
+	Action egv1a1.NoSNIMatchAction `json:"action" yaml:"action"`
+	// Destination the connections are proxied to when the action is DefaultBackend.
+	Destination *RouteDestination `json:"destination,omitempty" yaml:"destination,omitempty"`
+}
+
+// SessionTicketKeys holds the rotation settings of the session ticket keys generated by
+// the control plane and served to the proxies as a SDS secret.
+// +k8s:deepcopy-gen=true
+type SessionTicketKeys struct {
+	// Name of the SDS secret holding the keys.
+	Name string `json:"name" yaml:"name"`
+	// RotationInterval is the interval a new key is generated at.
+	RotationInterval metav1.Duration `json:"rotationInterval" yaml:"rotationInterval"`
+	// RetainedKeys is the number of previous keys still accepted to decrypt the session tickets.
+	RetainedKeys uint32 `json:"retainedKeys" yaml:"retainedKeys"`
 }
 
 // TLSCertificate holds a single certificate's details
@@ -475,7 +713,23 @@ type HTTP2Settings struct {
 
 // HealthCheckSettings provides HealthCheck configuration on the HTTP/HTTPS listener.
 // +k8s:deepcopy-gen=true
-type HealthCheckSettings egv1a1.HealthCheckSettings
+type HealthCheckSettings struct {
+	// Path is the HTTP path of the health check requests.
+	Path string `json:"path" yaml:"path"`
+	// MinHealthyPercentages are the minimum percentages of healthy endpoints of the
+	// destinations for the listener to be healthy.
+	MinHealthyPercentages []*DestinationMinHealthyPercentage `json:"minHealthyPercentages,omitempty" yaml:"minHealthyPercentages,omitempty"`
+}
+
+// DestinationMinHealthyPercentage holds the minimum percentage of healthy endpoints of a
+// destination.
+// +k8s:deepcopy-gen=true
+type DestinationMinHealthyPercentage struct {
+	// Destination is the name of the route destination.
+	Destination string `json:"destination" yaml:"destination"`
+	// Percentage is the minimum percentage of healthy endpoints.
+	Percentage uint32 `json:"percentage" yaml:"percentage"`
+}
 
 // HeaderSettings provides configuration related to header processing on the listener.
 // +k8s:deepcopy-gen=true
@@ -572,6 +826,8 @@ type HTTPRoute struct {
 	Destination *RouteDestination `json:"destination,omitempty" yaml:"destination,omitempty"`
 	// Rewrite to be changed for this route.
 	URLRewrite *URLRewrite `json:"urlRewrite,omitempty" yaml:"urlRewrite,omitempty"`
+	// RequestNormalization holds the normalization of the requests of the route.
+	RequestNormalization *RequestNormalization `json:"requestNormalization,omitempty" yaml:"requestNormalization,omitempty"`
 	// ExtensionRefs holds unstructured resources that were introduced by an extension and used on the HTTPRoute as extensionRef filters
 	ExtensionRefs []*UnstructuredRef `json:"extensionRefs,omitempty" yaml:"extensionRefs,omitempty"`
 	// Traffic holds the features associated with BackendTrafficPolicy
@@ -584,6 +840,9 @@ type HTTPRoute struct {
 	UseClientProtocol *bool `json:"useClientProtocol,omitempty" yaml:"useClientProtocol,omitempty"`
 	// Metadata is used to enrich envoy route metadata with user and provider-specific information
 	Metadata *ResourceMetadata `json:"metadata,omitempty" yaml:"metadata,omitempty"`
+	// NodeGroups are the node groups of the proxies the route is served by.
+	// If empty, the route is served by all the proxies.
+	NodeGroups []string `json:"nodeGroups,omitempty" yaml:"nodeGroups,omitempty"`
 	// SessionPersistence holds the configuration for session persistence.
 	SessionPersistence *SessionPersistence `json:"sessionPersistence,omitempty" yaml:"sessionPersistence,omitempty"`
 }
@@ -595,6 +854,8 @@ type DNS struct {
 	DNSRefreshRate *metav1.Duration `json:"dnsRefreshRate,omitempty"`
 	// RespectDNSTTL indicates whether the DNS Time-To-Live (TTL) should be respected.
 	RespectDNSTTL *bool `json:"respectDnsTtl,omitempty"`
+	// LookupFamily specifies the IP families of the addresses resolved for the hostnames.
+	LookupFamily *egv1a1.DNSLookupFamily `json:"lookupFamily,omitempty"`
 }
 
 // SessionPersistence defines the desired state of SessionPersistence.
@@ -653,6 +914,250 @@ type TrafficFeatures struct {
 	HTTP2 *HTTP2Settings `json:"http2,omitempty" yaml:"http2,omitempty"`
 	// DNS is used to configure how DNS resolution is handled by the Envoy Proxy cluster
 	DNS *DNS `json:"dns,omitempty" yaml:"dns,omitempty"`
+	// Experiment assigns the requests to the buckets of an A/B experiment.
+	Experiment *Experiment `json:"experiment,omitempty" yaml:"experiment,omitempty"`
+	// HeaderToMetadata sets request headers as dynamic metadata, and dynamic metadata as
+	// response headers.
+	HeaderToMetadata *HeaderToMetadata `json:"headerToMetadata,omitempty" yaml:"headerToMetadata,omitempty"`
+	// ResponseOverride replaces the responses of the backends with custom responses.
+	ResponseOverride *ResponseOverride `json:"responseOverride,omitempty" yaml:"responseOverride,omitempty"`
+	// ResponseBuffer buffers the bodies of the responses up to a limit.
+	ResponseBuffer *ResponseBuffer `json:"responseBuffer,omitempty" yaml:"responseBuffer,omitempty"`
+	// RequestSigning signs the requests forwarded to the backends.
+	RequestSigning *RequestSigning `json:"requestSigning,omitempty" yaml:"requestSigning,omitempty"`
+	// CredentialInjection injects the credentials of the gateway into the requests forwarded
+	// to the backends.
+	CredentialInjection *CredentialInjection `json:"credentialInjection,omitempty" yaml:"credentialInjection,omitempty"`
+}
+
+// CredentialInjection holds the credentials injected into the requests of a route forwarded
+// to the backends. Exactly one of BearerToken and OAuth2 is set.
+// +k8s:deepcopy-gen=true
+type CredentialInjection struct {
+	// Name is the unique name of the credentials.
+	Name string `json:"name" yaml:"name"`
+	// Overwrite replaces the credentials the requests already carry.
+	Overwrite bool `json:"overwrite,omitempty" yaml:"overwrite,omitempty"`
+	// BearerToken injects a static bearer token.
+	BearerToken *BearerTokenCredential `json:"bearerToken,omitempty" yaml:"bearerToken,omitempty"`
+	// OAuth2 injects an access token fetched with the OAuth2 client credentials grant.
+	OAuth2 *OAuth2ClientCredentials `json:"oauth2,omitempty" yaml:"oauth2,omitempty"`
+}
+
+// BearerTokenCredential holds a static bearer token injected into the requests.
+// +k8s:deepcopy-gen=true
+type BearerTokenCredential struct {
+	// Header is the header the token is set in.
+	Header string `json:"header" yaml:"header"`
+	// Token is the bearer token.
+	Token []byte `json:"token,omitempty" yaml:"token,omitempty"`
+}
+
+// OAuth2ClientCredentials holds the OAuth2 client credentials grant the access token injected
+// into the requests is fetched with by the proxies.
+// +k8s:deepcopy-gen=true
+type OAuth2ClientCredentials struct {
+	// TokenEndpoint is the token endpoint of the authorization server.
+	TokenEndpoint string `json:"tokenEndpoint" yaml:"tokenEndpoint"`
+	// ClientID is the client ID the access token is fetched with.
+	ClientID string `json:"clientID" yaml:"clientID"`
+	// ClientSecret is the client secret the access token is fetched with.
+	ClientSecret []byte `json:"clientSecret,omitempty" yaml:"clientSecret,omitempty"`
+	// Scopes are the scopes requested for the access token.
+	Scopes []string `json:"scopes,omitempty" yaml:"scopes,omitempty"`
+}
+
+// RequestSigning holds the signature of the requests of a route forwarded to the backends.
+// Exactly one of AWSSigV4 and HMAC is set.
+// +k8s:deepcopy-gen=true
+type RequestSigning struct {
+	// AWSSigV4 signs the requests with the AWS Signature Version 4.
+	AWSSigV4 *AWSSigV4RequestSigning `json:"awsSigV4,omitempty" yaml:"awsSigV4,omitempty"`
+	// HMAC signs the requests with an HMAC-SHA256 signature.
+	HMAC *HMACRequestSigning `json:"hmac,omitempty" yaml:"hmac,omitempty"`
+}
+
+// AWSSigV4RequestSigning holds the AWS Signature Version 4 of the requests.
+// +k8s:deepcopy-gen=true
+type AWSSigV4RequestSigning struct {
+	// Service is the name of the AWS service the requests are signed for.
+	Service string `json:"service" yaml:"service"`
+	// Region is the AWS region the requests are signed for.
+	Region string `json:"region" yaml:"region"`
+	// UnsignedPayload signs the requests without their body.
+	UnsignedPayload bool `json:"unsignedPayload,omitempty" yaml:"unsignedPayload,omitempty"`
+}
+
+// HMACRequestSigning holds the HMAC-SHA256 signature of the requests, computed by an
+// external processing service.
+// +k8s:deepcopy-gen=true
+type HMACRequestSigning struct {
+	// Name is the unique name of the signer.
+	// The xds translator only generates one filter for each unique name.
+	Name string `json:"name" yaml:"name"`
+	// Destination defines the destination for the gRPC External Processing service.
+	Destination RouteDestination `json:"destination" yaml:"destination"`
+	// Traffic holds the features associated with traffic management
+	Traffic *TrafficFeatures `json:"traffic,omitempty" yaml:"traffic,omitempty"`
+	// Authority is the hostname:port of the gRPC External Processing service.
+	Authority string `json:"authority" yaml:"authority"`
+	// KeySecret identifies the Secret holding the key the requests are signed with, as
+	// <namespace>/<name>.
+	KeySecret string `json:"keySecret" yaml:"keySecret"`
+	// SignatureHeader is the header the signature is set in.
+	SignatureHeader string `json:"signatureHeader" yaml:"signatureHeader"`
+	// TimestampHeader is the header the timestamp of the signature is set in.
+	TimestampHeader string `json:"timestampHeader" yaml:"timestampHeader"`
+	// SignedHeaders are the headers whose values are signed, in order.
+	SignedHeaders []string `json:"signedHeaders,omitempty" yaml:"signedHeaders,omitempty"`
+	// SignBody signs the SHA-256 digest of the bodies of the requests.
+	SignBody bool `json:"signBody,omitempty" yaml:"signBody,omitempty"`
+}
+
+// ResponseBuffer holds the buffering of the bodies of the responses of a route.
+// +k8s:deepcopy-gen=true
+type ResponseBuffer struct {
+	// Limit is the maximum size in bytes of the body of a response buffered in memory.
+	Limit uint64 `json:"limit" yaml:"limit"`
+	// StreamThrough streams the responses exceeding the limit instead of rejecting them,
+	// the responses being only buffered while the clients are slower than the backends.
+	StreamThrough bool `json:"streamThrough,omitempty" yaml:"streamThrough,omitempty"`
+	// InjectContentLength sets the Content-Length header of the fully buffered responses.
+	InjectContentLength bool `json:"injectContentLength,omitempty" yaml:"injectContentLength,omitempty"`
+}
+
+// HeaderToMetadata holds the request headers set as dynamic metadata of the requests, and
+// the dynamic metadata set as headers of the responses.
+// +k8s:deepcopy-gen=true
+type HeaderToMetadata struct {
+	// RequestHeaders are the request headers set as dynamic metadata.
+	RequestHeaders []*RequestHeaderToMetadata `json:"requestHeaders,omitempty" yaml:"requestHeaders,omitempty"`
+	// ResponseHeaders are the response headers set from the dynamic metadata.
+	ResponseHeaders []*MetadataToResponseHeader `json:"responseHeaders,omitempty" yaml:"responseHeaders,omitempty"`
+}
+
+// RequestHeaderToMetadata holds the dynamic metadata set from a request header.
+// +k8s:deepcopy-gen=true
+type RequestHeaderToMetadata struct {
+	// Header is the name of the request header.
+	Header string `json:"header" yaml:"header"`
+	// OnPresent is the metadata set when the header is present.
+	OnPresent *MetadataValue `json:"onPresent,omitempty" yaml:"onPresent,omitempty"`
+	// OnMissing is the metadata set when the header is missing.
+	OnMissing *MetadataValue `json:"onMissing,omitempty" yaml:"onMissing,omitempty"`
+	// Remove removes the header from the request once the metadata is set.
+	Remove bool `json:"remove,omitempty" yaml:"remove,omitempty"`
+}
+
+// MetadataValue holds a dynamic metadata value of the requests.
+// +k8s:deepcopy-gen=true
+type MetadataValue struct {
+	// Namespace is the namespace of the metadata.
+	Namespace string `json:"namespace" yaml:"namespace"`
+	// Key is the key of the metadata.
+	Key string `json:"key" yaml:"key"`
+	// Value is the value of the metadata, the value of the header if unset.
+	Value *string `json:"value,omitempty" yaml:"value,omitempty"`
+	// Type is the type of the value.
+	Type egv1a1.MetadataValueType `json:"type" yaml:"type"`
+}
+
+// MetadataToResponseHeader holds a response header set from the dynamic metadata.
+// +k8s:deepcopy-gen=true
+type MetadataToResponseHeader struct {
+	// Name is the name of the response header.
+	Name string `json:"name" yaml:"name"`
+	// Namespace is the namespace of the metadata.
+	Namespace string `json:"namespace" yaml:"namespace"`
+	// Key is the key of the metadata.
+	Key string `json:"key" yaml:"key"`
+}
+
+// ResponseOverride holds the rules replacing the responses of the backends of a route with
+// custom responses. The first rule matching a response wins.
+// +k8s:deepcopy-gen=true
+type ResponseOverride struct {
+	// Rules are the rules of the response override.
+	Rules []*ResponseOverrideRule `json:"rules" yaml:"rules"`
+}
+
+// ResponseOverrideRule holds the custom response replacing the responses matched.
+// +k8s:deepcopy-gen=true
+type ResponseOverrideRule struct {
+	// Name is the name of the rule.
+	Name string `json:"name" yaml:"name"`
+	// Match matches the responses replaced.
+	Match CustomResponseMatch `json:"match" yaml:"match"`
+	// Response is the custom response.
+	Response CustomResponse `json:"response" yaml:"response"`
+}
+
+// CustomResponseMatch matches the responses whose status, or gRPC status, is any of the
+// ones listed.
+// +k8s:deepcopy-gen=true
+type CustomResponseMatch struct {
+	// StatusCodes are the status codes matched.
+	StatusCodes []*StatusCodeMatch `json:"statusCodes,omitempty" yaml:"statusCodes,omitempty"`
+	// GRPCStatuses are the gRPC status codes matched.
+	GRPCStatuses []uint32 `json:"grpcStatuses,omitempty" yaml:"grpcStatuses,omitempty"`
+}
+
+// StatusCodeMatch matches a status code, or a range of status codes.
+// +k8s:deepcopy-gen=true
+type StatusCodeMatch struct {
+	// Value is the status code matched.
+	Value *int `json:"value,omitempty" yaml:"value,omitempty"`
+	// Range is the range of status codes matched.
+	Range *StatusCodeRange `json:"range,omitempty" yaml:"range,omitempty"`
+}
+
+// StatusCodeRange holds a range of status codes, including its start and end.
+// +k8s:deepcopy-gen=true
+type StatusCodeRange struct {
+	Start int `json:"start" yaml:"start"`
+	End   int `json:"end" yaml:"end"`
+}
+
+// CustomResponse holds a response replacing the response of a backend.
+// +k8s:deepcopy-gen=true
+type CustomResponse struct {
+	// StatusCode is the status code of the response, the status code of the response
+	// replaced if unset.
+	StatusCode *uint32 `json:"statusCode,omitempty" yaml:"statusCode,omitempty"`
+	// ContentType is the content type of the response.
+	ContentType *string `json:"contentType,omitempty" yaml:"contentType,omitempty"`
+	// Body is the body of the response.
+	Body *string `json:"body,omitempty" yaml:"body,omitempty"`
+	// AddResponseHeaders are the headers set on the response.
+	AddResponseHeaders []AddHeader `json:"addResponseHeaders,omitempty" yaml:"addResponseHeaders,omitempty"`
+}
+
+// Experiment holds the information of an A/B experiment assigning the requests of the
+// clients to buckets routed to their own destination.
+// +k8s:deepcopy-gen=true
+type Experiment struct {
+	// Cookie is the name of the cookie holding the bucket of the client.
+	Cookie string `json:"cookie" yaml:"cookie"`
+	// CookieTTL is the lifetime of the cookie, which is a session cookie if unset.
+	CookieTTL *metav1.Duration `json:"cookieTTL,omitempty" yaml:"cookieTTL,omitempty"`
+	// Header is the name of the header holding the numeric identifier the clients without
+	// cookie are assigned a bucket by. They are randomly assigned a bucket if unset.
+	Header *string `json:"header,omitempty" yaml:"header,omitempty"`
+	// Salt shifts the boundaries of the buckets the identifiers are hashed into.
+	Salt *string `json:"salt,omitempty" yaml:"salt,omitempty"`
+	// Buckets are the buckets of the experiment.
+	Buckets []*ExperimentBucket `json:"buckets" yaml:"buckets"`
+}
+
+// ExperimentBucket holds the information of a bucket of an experiment.
+// +k8s:deepcopy-gen=true
+type ExperimentBucket struct {
+	// Name is the name of the bucket, which is the value of the cookie.
+	Name string `json:"name" yaml:"name"`
+	// Percentage is the percentage of the clients assigned to the bucket.
+	Percentage uint32 `json:"percentage" yaml:"percentage"`
+	// Destination is the destination the requests of the bucket are routed to.
+	Destination *RouteDestination `json:"destination" yaml:"destination"`
 }
 
 func (b *TrafficFeatures) Validate() error {
@@ -687,6 +1192,26 @@ type SecurityFeatures struct {
 	ExtAuth *ExtAuth `json:"extAuth,omitempty" yaml:"extAuth,omitempty"`
 	// Authorization defines the schema for the authorization.
 	Authorization *Authorization `json:"authorization,omitempty" yaml:"authorization,omitempty"`
+	// SecurityHeaders defines the security headers added to the responses.
+	SecurityHeaders *SecurityHeaders `json:"securityHeaders,omitempty" yaml:"securityHeaders,omitempty"`
+}
+
+// SecurityHeaders holds the security headers added to the responses of a route.
+// +k8s:deepcopy-gen=true
+type SecurityHeaders struct {
+	// Headers are the security headers added to the responses.
+	Headers []SecurityHeader `json:"headers,omitempty" yaml:"headers,omitempty"`
+	// Overwrite replaces the headers set by the backends instead of keeping them.
+	Overwrite bool `json:"overwrite,omitempty" yaml:"overwrite,omitempty"`
+}
+
+// SecurityHeader holds a security header added to the responses.
+// +k8s:deepcopy-gen=true
+type SecurityHeader struct {
+	// Name is the name of the header.
+	Name string `json:"name" yaml:"name"`
+	// Value is the value of the header.
+	Value string `json:"value" yaml:"value"`
 }
 
 func (s *SecurityFeatures) Printable() *SecurityFeatures {
@@ -720,6 +1245,8 @@ type EnvoyExtensionFeatures struct {
 	ExtProcs []ExtProc `json:"extProcs,omitempty" yaml:"extProcs,omitempty"`
 	// Wasm extensions
 	Wasms []Wasm `json:"wasms,omitempty" yaml:"wasms,omitempty"`
+	// OpenAPIValidation validates the requests against an OpenAPI document
+	OpenAPIValidation *OpenAPIValidation `json:"openAPIValidation,omitempty" yaml:"openAPIValidation,omitempty"`
 }
 
 // UnstructuredRef holds unstructured data for an arbitrary k8s resource introduced by an extension
@@ -772,6 +1299,10 @@ type JWT struct {
 
 	// Providers defines a list of JSON Web Token (JWT) authentication providers.
 	Providers []egv1a1.JWTProvider `json:"providers,omitempty" yaml:"providers,omitempty"`
+
+	// RouteMatch defines the claims and the scopes the validated JWT must carry
+	// for the request to match the route.
+	RouteMatch *egv1a1.JWTRouteMatch `json:"routeMatch,omitempty" yaml:"routeMatch,omitempty"`
 }
 
 // OIDC defines the schema for authenticating HTTP requests using
@@ -1171,7 +1702,7 @@ func (r *RouteDestination) ToBackendWeights() *BackendWeights {
 			continue
 		}
 
-		if len(s.Endpoints) > 0 {
+		if len(s.Endpoints) > 0 || s.OriginalDestination != nil {
 			w.Valid += *s.Weight
 		} else {
 			w.Invalid += *s.Weight
@@ -1200,11 +1731,24 @@ type DestinationSetting struct {
 
 	TLS     *TLSUpstreamConfig  `json:"tls,omitempty" yaml:"tls,omitempty"`
 	Filters *DestinationFilters `json:"filters,omitempty" yaml:"filters,omitempty"`
+	// OriginalDestination forwards the traffic to its original destination instead of to the Endpoints.
+	OriginalDestination *OriginalDestination `json:"originalDestination,omitempty" yaml:"originalDestination,omitempty"`
+}
+
+// OriginalDestination holds the settings of the forwarding of the traffic to its original destination.
+// +k8s:deepcopy-gen=true
+type OriginalDestination struct {
+	// HeaderName is the name of the request header holding the original destination address.
+	// If unset, the destination address of the downstream connection is used.
+	HeaderName *string `json:"headerName,omitempty" yaml:"headerName,omitempty"`
 }
 
 // Validate the fields within the RouteDestination structure
 func (d *DestinationSetting) Validate() error {
 	var errs error
+	if d.OriginalDestination != nil && len(d.Endpoints) > 0 {
+		errs = errors.Join(errs, ErrDestinationOriginalDstEndpoints)
+	}
 	for _, ep := range d.Endpoints {
 		if err := ep.Validate(); err != nil {
 			errs = errors.Join(errs, err)
@@ -1232,21 +1776,29 @@ type DestinationEndpoint struct {
 	Port uint32 `json:"port" yaml:"port"`
 	// Path refers to the Unix Domain Socket
 	Path *string `json:"path,omitempty" yaml:"path,omitempty"`
+	// InternalListener refers to the name of the Envoy internal listener the traffic is forwarded to.
+	InternalListener *string `json:"internalListener,omitempty" yaml:"internalListener,omitempty"`
 }
 
 // Validate the fields within the DestinationEndpoint structure
 func (d DestinationEndpoint) Validate() error {
 	var errs error
 
+	switch {
+	// internal listener
+	case d.InternalListener != nil:
+		if d.Port != 0 || d.Host != "" || d.Path != nil {
+			errs = errors.Join(errs, ErrDestEndpointInternalListenerInvalid)
+		}
 	// unix domain socket
-	if d.Path != nil {
+	case d.Path != nil:
 		if d.Port != 0 {
 			errs = errors.Join(errs, ErrDestEndpointUDSPortInvalid)
 		}
 		if d.Host != "" {
 			errs = errors.Join(errs, ErrDestEndpointUDSHostInvalid)
 		}
-	} else { // IP or FQDN
+	default: // IP or FQDN
 		err := validation.IsDNS1123Subdomain(d.Host)
 		_, pErr := netip.ParseAddr(d.Host)
 
@@ -1305,6 +1857,29 @@ func (r DirectResponse) Validate() error {
 	return errs
 }
 
+// RequestNormalization holds the normalization of the requests of a route performed by the
+// Lua filter. The trailing slash of the paths forwarded to the backends is normalized by a
+// path rewrite instead.
+// +k8s:deepcopy-gen=true
+type RequestNormalization struct {
+	// RemoveQueryParameters are the names of the query parameters removed from the requests.
+	RemoveQueryParameters []string `json:"removeQueryParameters,omitempty" yaml:"removeQueryParameters,omitempty"`
+	// SortQueryParameters sorts the query parameters by name.
+	SortQueryParameters bool `json:"sortQueryParameters,omitempty" yaml:"sortQueryParameters,omitempty"`
+	// TrailingSlashRedirect redirects the requests whose trailing slash is not normalized.
+	TrailingSlashRedirect *TrailingSlashRedirect `json:"trailingSlashRedirect,omitempty" yaml:"trailingSlashRedirect,omitempty"`
+}
+
+// TrailingSlashRedirect holds the redirection of the requests to the path with its
+// trailing slash normalized.
+// +k8s:deepcopy-gen=true
+type TrailingSlashRedirect struct {
+	// Action defines whether the trailing slash is added to or removed from the path.
+	Action egv1a1.TrailingSlashAction `json:"action" yaml:"action"`
+	// StatusCode is the status code of the redirection.
+	StatusCode int32 `json:"statusCode" yaml:"statusCode"`
+}
+
 // URLRewrite holds the details for how to rewrite a request
 // +k8s:deepcopy-gen=true
 type URLRewrite struct {
@@ -1446,6 +2021,9 @@ type StringMatch struct {
 	Distinct bool `json:"distinct" yaml:"distinct"`
 	// Invert inverts the final match decision
 	Invert *bool `json:"invert,omitempty" yaml:"invert,omitempty"`
+	// Present match condition, true if the field must be present with any value,
+	// false if it must be absent.
+	Present *bool `json:"present,omitempty" yaml:"present,omitempty"`
 }
 
 // Validate the fields within the StringMatch structure
@@ -1464,6 +2042,12 @@ func (s StringMatch) Validate() error {
 	if s.SafeRegex != nil {
 		matchCount++
 	}
+	if s.Present != nil {
+		if s.Name == "" {
+			errs = errors.Join(errs, ErrStringMatchNameIsEmpty)
+		}
+		matchCount++
+	}
 	if s.Distinct {
 		if s.Name == "" {
 			errs = errors.Join(errs, ErrStringMatchNameIsEmpty)
@@ -1542,11 +2126,18 @@ func (t TCPListener) Validate() error {
 	if t.Name == "" {
 		errs = errors.Join(errs, ErrListenerNameEmpty)
 	}
-	if _, err := netip.ParseAddr(t.Address); err != nil {
-		errs = errors.Join(errs, ErrListenerAddressInvalid)
-	}
-	if t.Port == 0 {
-		errs = errors.Join(errs, ErrListenerPortInvalid)
+	// Internal listeners don't bind to an address and port.
+	if t.Internal {
+		if t.UnixSocketPath != nil {
+			errs = errors.Join(errs, ErrListenerInternalUnixSocket)
+		}
+	} else {
+		if _, err := netip.ParseAddr(t.Address); err != nil {
+			errs = errors.Join(errs, ErrListenerAddressInvalid)
+		}
+		if t.Port == 0 {
+			errs = errors.Join(errs, ErrListenerPortInvalid)
+		}
 	}
 	for _, route := range t.Routes {
 		if err := route.Validate(); err != nil {
@@ -1751,22 +2342,25 @@ type AccessLog struct {
 // TextAccessLog holds the configuration for text access logging.
 // +k8s:deepcopy-gen=true
 type TextAccessLog struct {
-	CELMatches []string `json:"celMatches,omitempty" yaml:"celMatches,omitempty"`
-	Format     *string  `json:"format,omitempty" yaml:"format,omitempty"`
-	Path       string   `json:"path" yaml:"path"`
+	Protocol   *egv1a1.ProxyAccessLogProtocol `json:"protocol,omitempty" yaml:"protocol,omitempty"`
+	CELMatches []string                       `json:"celMatches,omitempty" yaml:"celMatches,omitempty"`
+	Format     *string                        `json:"format,omitempty" yaml:"format,omitempty"`
+	Path       string                         `json:"path" yaml:"path"`
 }
 
 // JSONAccessLog holds the configuration for JSON access logging.
 // +k8s:deepcopy-gen=true
 type JSONAccessLog struct {
-	CELMatches []string          `json:"celMatches,omitempty" yaml:"celMatches,omitempty"`
-	JSON       map[string]string `json:"json,omitempty" yaml:"json,omitempty"`
-	Path       string            `json:"path" yaml:"path"`
+	Protocol   *egv1a1.ProxyAccessLogProtocol `json:"protocol,omitempty" yaml:"protocol,omitempty"`
+	CELMatches []string                       `json:"celMatches,omitempty" yaml:"celMatches,omitempty"`
+	JSON       map[string]string              `json:"json,omitempty" yaml:"json,omitempty"`
+	Path       string                         `json:"path" yaml:"path"`
 }
 
 // ALSAccessLog holds the configuration for gRPC ALS access logging.
 // +k8s:deepcopy-gen=true
 type ALSAccessLog struct {
+	Protocol    *egv1a1.ProxyAccessLogProtocol    `json:"protocol,omitempty" yaml:"protocol,omitempty"`
 	CELMatches  []string                          `json:"celMatches,omitempty" yaml:"celMatches,omitempty"`
 	LogName     string                            `json:"name" yaml:"name"`
 	Destination RouteDestination                  `json:"destination,omitempty" yaml:"destination,omitempty"`
@@ -1788,13 +2382,14 @@ type ALSAccessLogHTTP struct {
 // OpenTelemetryAccessLog holds the configuration for OpenTelemetry access logging.
 // +k8s:deepcopy-gen=true
 type OpenTelemetryAccessLog struct {
-	CELMatches  []string          `json:"celMatches,omitempty" yaml:"celMatches,omitempty"`
-	Authority   string            `json:"authority,omitempty" yaml:"authority,omitempty"`
-	Text        *string           `json:"text,omitempty" yaml:"text,omitempty"`
-	Attributes  map[string]string `json:"attributes,omitempty" yaml:"attributes,omitempty"`
-	Resources   map[string]string `json:"resources,omitempty" yaml:"resources,omitempty"`
-	Destination RouteDestination  `json:"destination,omitempty" yaml:"destination,omitempty"`
-	Traffic     *TrafficFeatures  `json:"traffic,omitempty" yaml:"traffic,omitempty"`
+	Protocol    *egv1a1.ProxyAccessLogProtocol `json:"protocol,omitempty" yaml:"protocol,omitempty"`
+	CELMatches  []string                       `json:"celMatches,omitempty" yaml:"celMatches,omitempty"`
+	Authority   string                         `json:"authority,omitempty" yaml:"authority,omitempty"`
+	Text        *string                        `json:"text,omitempty" yaml:"text,omitempty"`
+	Attributes  map[string]string              `json:"attributes,omitempty" yaml:"attributes,omitempty"`
+	Resources   map[string]string              `json:"resources,omitempty" yaml:"resources,omitempty"`
+	Destination RouteDestination               `json:"destination,omitempty" yaml:"destination,omitempty"`
+	Traffic     *TrafficFeatures               `json:"traffic,omitempty" yaml:"traffic,omitempty"`
 }
 
 // EnvoyPatchPolicy defines the intermediate representation of the EnvoyPatchPolicy resource.
@@ -2066,6 +2661,9 @@ type CircuitBreaker struct {
 
 	// The maximum number of parallel retries that Envoy will make.
 	MaxParallelRetries *uint32 `json:"maxParallelRetries,omitempty" yaml:"maxParallelRetries,omitempty"`
+
+	// The maximum number of connections that Envoy will establish to each endpoint.
+	PerEndpointMaxConnections *uint32 `json:"perEndpointMaxConnections,omitempty" yaml:"perEndpointMaxConnections,omitempty"`
 }
 
 // HealthCheck defines health check settings
@@ -2395,6 +2993,29 @@ type TLSUpstreamConfig struct {
 type BackendConnection struct {
 	// BufferLimitBytes is the maximum number of bytes that can be buffered for a connection.
 	BufferLimitBytes *uint32 `json:"bufferLimit,omitempty" yaml:"bufferLimit,omitempty"`
+	// Preconnect defines how Envoy preconnects to the endpoints.
+	Preconnect *Preconnect `json:"preconnect,omitempty" yaml:"preconnect,omitempty"`
+	// HappyEyeballs defines the happy eyeballs settings for dual-stack endpoints.
+	HappyEyeballs *HappyEyeballs `json:"happyEyeballs,omitempty" yaml:"happyEyeballs,omitempty"`
+}
+
+// HappyEyeballs defines the order in which the addresses of a dual-stack endpoint are attempted.
+// +k8s:deepcopy-gen=true
+type HappyEyeballs struct {
+	// FirstAddressFamily is the IP family of the first address attempted.
+	FirstAddressFamily *egv1a1.IPFamily `json:"firstAddressFamily,omitempty" yaml:"firstAddressFamily,omitempty"`
+	// FirstAddressFamilyCount is the number of addresses of the first family attempted
+	// before the addresses of the other family.
+	FirstAddressFamilyCount *uint32 `json:"firstAddressFamilyCount,omitempty" yaml:"firstAddressFamilyCount,omitempty"`
+}
+
+// Preconnect defines how Envoy preconnects to the endpoints of a cluster.
+// +k8s:deepcopy-gen=true
+type Preconnect struct {
+	// PerEndpointPercent is the percentage of the needed connections established to each endpoint.
+	PerEndpointPercent *uint32 `json:"perEndpointPercent,omitempty" yaml:"perEndpointPercent,omitempty"`
+	// PredictivePercent is the percentage of the needed connections established across the endpoints.
+	PredictivePercent *uint32 `json:"predictivePercent,omitempty" yaml:"predictivePercent,omitempty"`
 }
 
 // ClientConnection settings for downstream connections
@@ -2402,8 +3023,25 @@ type BackendConnection struct {
 type ClientConnection struct {
 	// ConnectionLimit is the limit of number of connections
 	ConnectionLimit *ConnectionLimit `json:"limit,omitempty" yaml:"limit,omitempty"`
+	// ConnectionRateLimit is the rate limit of the new connections accepted by the listener.
+	ConnectionRateLimit *ConnectionRateLimit `json:"rateLimit,omitempty" yaml:"rateLimit,omitempty"`
 	// BufferLimitBytes is the maximum number of bytes that can be buffered for a connection.
 	BufferLimitBytes *uint32 `json:"bufferLimit,omitempty" yaml:"bufferLimit,omitempty"`
+	// NoDelay sets TCP_NODELAY on the listener socket.
+	NoDelay *bool `json:"noDelay,omitempty" yaml:"noDelay,omitempty"`
+	// ReusePort defines whether SO_REUSEPORT is set on the listener socket.
+	ReusePort *bool `json:"reusePort,omitempty" yaml:"reusePort,omitempty"`
+	// DSCP is the Differentiated Services Code Point of the IP packets sent to the clients.
+	DSCP *uint32 `json:"dscp,omitempty" yaml:"dscp,omitempty"`
+}
+
+// ConnectionRateLimit contains settings for the rate limit of new downstream connections
+// +k8s:deepcopy-gen=true
+type ConnectionRateLimit struct {
+	// ConnectionsPerSecond is the number of new connections accepted per second.
+	ConnectionsPerSecond uint32 `json:"connectionsPerSecond" yaml:"connectionsPerSecond"`
+	// Burst is the number of new connections that can be accepted at once.
+	Burst uint32 `json:"burst" yaml:"burst"`
 }
 
 // ConnectionLimit contains settings for downstream connection limits
@@ -2465,6 +3103,34 @@ type ExtProc struct {
 	ResponseBodyProcessingMode *ExtProcBodyProcessingMode `json:"responseBodyProcessingMode,omitempty" yaml:"responseBodyProcessingMode,omitempty"`
 }
 
+// OpenAPIValidation holds the information associated with the validation of the
+// requests against an OpenAPI document by an external processing service.
+// +k8s:deepcopy-gen=true
+type OpenAPIValidation struct {
+	// Name is a unique name for an OpenAPIValidation configuration.
+	// The xds translator only generates one filter for each unique name.
+	Name string `json:"name" yaml:"name"`
+
+	// Destination defines the destination for the gRPC External Processing service.
+	Destination RouteDestination `json:"destination" yaml:"destination"`
+
+	// Traffic holds the features associated with traffic management
+	Traffic *TrafficFeatures `json:"traffic,omitempty" yaml:"traffic,omitempty"`
+
+	// Authority is the hostname:port of the gRPC External Processing service.
+	Authority string `json:"authority" yaml:"authority"`
+
+	// Document identifies the OpenAPI document as <namespace>/<configmap>/<key>.
+	Document string `json:"document" yaml:"document"`
+
+	// DocumentSHA256 is the hex encoded SHA-256 digest of the OpenAPI document.
+	DocumentSHA256 string `json:"documentSHA256" yaml:"documentSHA256"`
+
+	// FailOpen defines if the requests that cannot be validated due to connectivity
+	// to the external processing service are passed-through.
+	FailOpen bool `json:"failOpen,omitempty" yaml:"failOpen,omitempty"`
+}
+
 // Wasm holds the information associated with the Wasm extensions.
 // +k8s:deepcopy-gen=true
 type Wasm struct {
@@ -2530,10 +3196,16 @@ type DestinationFilters struct {
 type ResourceMetadata struct {
 	// Kind is the kind of the resource
 	Kind string `json:"kind,omitempty" yaml:"kind,omitempty"`
+	// Group is the API group of the resource
+	Group string `json:"group,omitempty" yaml:"group,omitempty"`
+	// Version is the API version of the resource
+	Version string `json:"version,omitempty" yaml:"version,omitempty"`
 	// Name is the name of the resource
 	Name string `json:"name,omitempty" yaml:"name,omitempty"`
 	// Namespace is the namespace of the resource
 	Namespace string `json:"namespace,omitempty" yaml:"namespace,omitempty"`
+	// Generation is the generation of the resource the configuration was generated from
+	Generation int64 `json:"generation,omitempty" yaml:"generation,omitempty"`
 	// Annotations are the annotations of the resource
 	Annotations map[string]string `json:"annotations,omitempty" yaml:"annotations,omitempty"`
 	// SectionName is the name of a section of a resource
@@ -634,6 +634,31 @@ func TestValidateHTTPListener(t *testing.T) {
 			},
 			want: []error{ErrListenerPortInvalid, ErrHTTPListenerHostnamesEmpty},
 		},
+		{
+			name: "internal",
+			input: HTTPListener{
+				CoreListenerDetails: CoreListenerDetails{
+					Name:     "internal",
+					Internal: true,
+				},
+				Hostnames: []string{"example.com"},
+				Routes:    []*HTTPRoute{&happyHTTPRoute},
+			},
+			want: nil,
+		},
+		{
+			name: "internal with unix socket path",
+			input: HTTPListener{
+				CoreListenerDetails: CoreListenerDetails{
+					Name:           "internal",
+					Internal:       true,
+					UnixSocketPath: ptr.To("/var/run/envoy-listeners/80.sock"),
+				},
+				Hostnames: []string{"example.com"},
+				Routes:    []*HTTPRoute{&happyHTTPRoute},
+			},
+			want: []error{ErrListenerInternalUnixSocket},
+		},
 	}
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
@@ -1098,6 +1123,39 @@ func TestValidateRouteDestination(t *testing.T) {
 			},
 			want: nil,
 		},
+		{
+			name: "valid internal listener",
+			input: RouteDestination{
+				Name: "valid internal listener",
+				Settings: []*DestinationSetting{
+					{
+						Endpoints: []*DestinationEndpoint{
+							{
+								InternalListener: ptr.To("internal"),
+							},
+						},
+					},
+				},
+			},
+			want: nil,
+		},
+		{
+			name: "invalid internal listener with port",
+			input: RouteDestination{
+				Name: "invalid internal listener with port",
+				Settings: []*DestinationSetting{
+					{
+						Endpoints: []*DestinationEndpoint{
+							{
+								InternalListener: ptr.To("internal"),
+								Port:             8080,
+							},
+						},
+					},
+				},
+			},
+			want: ErrDestEndpointInternalListenerInvalid,
+		},
 		{
 			name: "invalid address",
 			input: RouteDestination{
@@ -1196,6 +1254,21 @@ func TestValidateStringMatch(t *testing.T) {
 			},
 			want: nil,
 		},
+		{
+			name: "happy absent",
+			input: StringMatch{
+				Name:    "debug",
+				Present: ptr.To(false),
+			},
+			want: nil,
+		},
+		{
+			name: "present without name",
+			input: StringMatch{
+				Present: ptr.To(true),
+			},
+			want: ErrStringMatchNameIsEmpty,
+		},
 		{
 			name:  "no fields set",
 			input: StringMatch{},
@@ -14,11 +14,17 @@ import (
 	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/gateway-api/apis/v1alpha2"
+	timex "time"
 )
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ALSAccessLog) DeepCopyInto(out *ALSAccessLog) {
 	*out = *in
+	if in.Protocol != nil {
+		in, out := &in.Protocol, &out.Protocol
+		*out = new(v1alpha1.ProxyAccessLogProtocol)
+		**out = **in
+	}
 	if in.CELMatches != nil {
 		in, out := &in.CELMatches, &out.CELMatches
 		*out = make([]string, len(*in))
@@ -89,6 +95,21 @@ func (in *ALSAccessLogHTTP) DeepCopy() *ALSAccessLogHTTP {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AWSSigV4RequestSigning) DeepCopyInto(out *AWSSigV4RequestSigning) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AWSSigV4RequestSigning.
+func (in *AWSSigV4RequestSigning) DeepCopy() *AWSSigV4RequestSigning {
+	if in == nil {
+		return nil
+	}
+	out := new(AWSSigV4RequestSigning)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AccessLog) DeepCopyInto(out *AccessLog) {
 	*out = *in
@@ -293,6 +314,16 @@ func (in *BackendConnection) DeepCopyInto(out *BackendConnection) {
 		*out = new(uint32)
 		**out = **in
 	}
+	if in.Preconnect != nil {
+		in, out := &in.Preconnect, &out.Preconnect
+		*out = new(Preconnect)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.HappyEyeballs != nil {
+		in, out := &in.HappyEyeballs, &out.HappyEyeballs
+		*out = new(HappyEyeballs)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackendConnection.
@@ -325,6 +356,26 @@ func (in *BasicAuth) DeepCopy() *BasicAuth {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BearerTokenCredential) DeepCopyInto(out *BearerTokenCredential) {
+	*out = *in
+	if in.Token != nil {
+		in, out := &in.Token, &out.Token
+		*out = make([]byte, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BearerTokenCredential.
+func (in *BearerTokenCredential) DeepCopy() *BearerTokenCredential {
+	if in == nil {
+		return nil
+	}
+	out := new(BearerTokenCredential)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CORS) DeepCopyInto(out *CORS) {
 	*out = *in
@@ -399,6 +450,11 @@ func (in *CircuitBreaker) DeepCopyInto(out *CircuitBreaker) {
 		*out = new(uint32)
 		**out = **in
 	}
+	if in.PerEndpointMaxConnections != nil {
+		in, out := &in.PerEndpointMaxConnections, &out.PerEndpointMaxConnections
+		*out = new(uint32)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CircuitBreaker.
@@ -419,11 +475,31 @@ func (in *ClientConnection) DeepCopyInto(out *ClientConnection) {
 		*out = new(ConnectionLimit)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.ConnectionRateLimit != nil {
+		in, out := &in.ConnectionRateLimit, &out.ConnectionRateLimit
+		*out = new(ConnectionRateLimit)
+		**out = **in
+	}
 	if in.BufferLimitBytes != nil {
 		in, out := &in.BufferLimitBytes, &out.BufferLimitBytes
 		*out = new(uint32)
 		**out = **in
 	}
+	if in.NoDelay != nil {
+		in, out := &in.NoDelay, &out.NoDelay
+		*out = new(bool)
+		**out = **in
+	}
+	if in.ReusePort != nil {
+		in, out := &in.ReusePort, &out.ReusePort
+		*out = new(bool)
+		**out = **in
+	}
+	if in.DSCP != nil {
+		in, out := &in.DSCP, &out.DSCP
+		*out = new(uint32)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClientConnection.
@@ -486,6 +562,22 @@ func (in *ClientTimeout) DeepCopy() *ClientTimeout {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ConfigCheckpoint) DeepCopyInto(out *ConfigCheckpoint) {
+	*out = *in
+	out.Interval = in.Interval
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConfigCheckpoint.
+func (in *ConfigCheckpoint) DeepCopy() *ConfigCheckpoint {
+	if in == nil {
+		return nil
+	}
+	out := new(ConfigCheckpoint)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ConnectionLimit) DeepCopyInto(out *ConnectionLimit) {
 	*out = *in
@@ -511,6 +603,21 @@ func (in *ConnectionLimit) DeepCopy() *ConnectionLimit {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ConnectionRateLimit) DeepCopyInto(out *ConnectionRateLimit) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConnectionRateLimit.
+func (in *ConnectionRateLimit) DeepCopy() *ConnectionRateLimit {
+	if in == nil {
+		return nil
+	}
+	out := new(ConnectionRateLimit)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ConsistentHash) DeepCopyInto(out *ConsistentHash) {
 	*out = *in
@@ -585,6 +692,21 @@ func (in *CoreListenerDetails) DeepCopyInto(out *CoreListenerDetails) {
 		*out = new(ResourceMetadata)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.IPFamily != nil {
+		in, out := &in.IPFamily, &out.IPFamily
+		*out = new(v1alpha1.IPFamily)
+		**out = **in
+	}
+	if in.UnixSocketPath != nil {
+		in, out := &in.UnixSocketPath, &out.UnixSocketPath
+		*out = new(string)
+		**out = **in
+	}
+	if in.Tuning != nil {
+		in, out := &in.Tuning, &out.Tuning
+		*out = new(ListenerTuning)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CoreListenerDetails.
@@ -597,6 +719,99 @@ func (in *CoreListenerDetails) DeepCopy() *CoreListenerDetails {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CredentialInjection) DeepCopyInto(out *CredentialInjection) {
+	*out = *in
+	if in.BearerToken != nil {
+		in, out := &in.BearerToken, &out.BearerToken
+		*out = new(BearerTokenCredential)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.OAuth2 != nil {
+		in, out := &in.OAuth2, &out.OAuth2
+		*out = new(OAuth2ClientCredentials)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CredentialInjection.
+func (in *CredentialInjection) DeepCopy() *CredentialInjection {
+	if in == nil {
+		return nil
+	}
+	out := new(CredentialInjection)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CustomResponse) DeepCopyInto(out *CustomResponse) {
+	*out = *in
+	if in.StatusCode != nil {
+		in, out := &in.StatusCode, &out.StatusCode
+		*out = new(uint32)
+		**out = **in
+	}
+	if in.ContentType != nil {
+		in, out := &in.ContentType, &out.ContentType
+		*out = new(string)
+		**out = **in
+	}
+	if in.Body != nil {
+		in, out := &in.Body, &out.Body
+		*out = new(string)
+		**out = **in
+	}
+	if in.AddResponseHeaders != nil {
+		in, out := &in.AddResponseHeaders, &out.AddResponseHeaders
+		*out = make([]AddHeader, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CustomResponse.
+func (in *CustomResponse) DeepCopy() *CustomResponse {
+	if in == nil {
+		return nil
+	}
+	out := new(CustomResponse)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CustomResponseMatch) DeepCopyInto(out *CustomResponseMatch) {
+	*out = *in
+	if in.StatusCodes != nil {
+		in, out := &in.StatusCodes, &out.StatusCodes
+		*out = make([]*StatusCodeMatch, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = new(StatusCodeMatch)
+				(*in).DeepCopyInto(*out)
+			}
+		}
+	}
+	if in.GRPCStatuses != nil {
+		in, out := &in.GRPCStatuses, &out.GRPCStatuses
+		*out = make([]uint32, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CustomResponseMatch.
+func (in *CustomResponseMatch) DeepCopy() *CustomResponseMatch {
+	if in == nil {
+		return nil
+	}
+	out := new(CustomResponseMatch)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DNS) DeepCopyInto(out *DNS) {
 	*out = *in
@@ -610,6 +825,11 @@ func (in *DNS) DeepCopyInto(out *DNS) {
 		*out = new(bool)
 		**out = **in
 	}
+	if in.LookupFamily != nil {
+		in, out := &in.LookupFamily, &out.LookupFamily
+		*out = new(v1alpha1.DNSLookupFamily)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DNS.
@@ -622,6 +842,22 @@ func (in *DNS) DeepCopy() *DNS {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DeferDrains) DeepCopyInto(out *DeferDrains) {
+	*out = *in
+	in.MaintenanceWindow.DeepCopyInto(&out.MaintenanceWindow)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DeferDrains.
+func (in *DeferDrains) DeepCopy() *DeferDrains {
+	if in == nil {
+		return nil
+	}
+	out := new(DeferDrains)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DestinationEndpoint) DeepCopyInto(out *DestinationEndpoint) {
 	*out = *in
@@ -630,6 +866,11 @@ func (in *DestinationEndpoint) DeepCopyInto(out *DestinationEndpoint) {
 		*out = new(string)
 		**out = **in
 	}
+	if in.InternalListener != nil {
+		in, out := &in.InternalListener, &out.InternalListener
+		*out = new(string)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DestinationEndpoint.
@@ -681,6 +922,21 @@ func (in *DestinationFilters) DeepCopy() *DestinationFilters {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DestinationMinHealthyPercentage) DeepCopyInto(out *DestinationMinHealthyPercentage) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DestinationMinHealthyPercentage.
+func (in *DestinationMinHealthyPercentage) DeepCopy() *DestinationMinHealthyPercentage {
+	if in == nil {
+		return nil
+	}
+	out := new(DestinationMinHealthyPercentage)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DestinationSetting) DeepCopyInto(out *DestinationSetting) {
 	*out = *in
@@ -720,6 +976,11 @@ func (in *DestinationSetting) DeepCopyInto(out *DestinationSetting) {
 		*out = new(DestinationFilters)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.OriginalDestination != nil {
+		in, out := &in.OriginalDestination, &out.OriginalDestination
+		*out = new(OriginalDestination)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DestinationSetting.
@@ -747,6 +1008,21 @@ func (in *DirectResponse) DeepCopy() *DirectResponse {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Drain) DeepCopyInto(out *Drain) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Drain.
+func (in *Drain) DeepCopy() *Drain {
+	if in == nil {
+		return nil
+	}
+	out := new(Drain)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *EnvoyExtensionFeatures) DeepCopyInto(out *EnvoyExtensionFeatures) {
 	*out = *in
@@ -764,6 +1040,11 @@ func (in *EnvoyExtensionFeatures) DeepCopyInto(out *EnvoyExtensionFeatures) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.OpenAPIValidation != nil {
+		in, out := &in.OpenAPIValidation, &out.OpenAPIValidation
+		*out = new(OpenAPIValidation)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EnvoyExtensionFeatures.
@@ -823,6 +1104,67 @@ func (in *EnvoyPatchPolicyStatus) DeepCopy() *EnvoyPatchPolicyStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Experiment) DeepCopyInto(out *Experiment) {
+	*out = *in
+	if in.CookieTTL != nil {
+		in, out := &in.CookieTTL, &out.CookieTTL
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.Header != nil {
+		in, out := &in.Header, &out.Header
+		*out = new(string)
+		**out = **in
+	}
+	if in.Salt != nil {
+		in, out := &in.Salt, &out.Salt
+		*out = new(string)
+		**out = **in
+	}
+	if in.Buckets != nil {
+		in, out := &in.Buckets, &out.Buckets
+		*out = make([]*ExperimentBucket, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = new(ExperimentBucket)
+				(*in).DeepCopyInto(*out)
+			}
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Experiment.
+func (in *Experiment) DeepCopy() *Experiment {
+	if in == nil {
+		return nil
+	}
+	out := new(Experiment)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExperimentBucket) DeepCopyInto(out *ExperimentBucket) {
+	*out = *in
+	if in.Destination != nil {
+		in, out := &in.Destination, &out.Destination
+		*out = new(RouteDestination)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExperimentBucket.
+func (in *ExperimentBucket) DeepCopy() *ExperimentBucket {
+	if in == nil {
+		return nil
+	}
+	out := new(ExperimentBucket)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ExtAuth) DeepCopyInto(out *ExtAuth) {
 	*out = *in
@@ -930,6 +1272,26 @@ func (in *ExtendedHTTPPathModifier) DeepCopy() *ExtendedHTTPPathModifier {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExtensionConfigDiscovery) DeepCopyInto(out *ExtensionConfigDiscovery) {
+	*out = *in
+	if in.Filters != nil {
+		in, out := &in.Filters, &out.Filters
+		*out = make([]v1alpha1.EnvoyFilter, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExtensionConfigDiscovery.
+func (in *ExtensionConfigDiscovery) DeepCopy() *ExtensionConfigDiscovery {
+	if in == nil {
+		return nil
+	}
+	out := new(ExtensionConfigDiscovery)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *FaultInjection) DeepCopyInto(out *FaultInjection) {
 	*out = *in
@@ -1073,21 +1435,47 @@ func (in *GlobalRateLimit) DeepCopy() *GlobalRateLimit {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *HTTP10Settings) DeepCopyInto(out *HTTP10Settings) {
+func (in *HMACRequestSigning) DeepCopyInto(out *HMACRequestSigning) {
 	*out = *in
-	if in.DefaultHost != nil {
-		in, out := &in.DefaultHost, &out.DefaultHost
-		*out = new(string)
-		**out = **in
+	in.Destination.DeepCopyInto(&out.Destination)
+	if in.Traffic != nil {
+		in, out := &in.Traffic, &out.Traffic
+		*out = new(TrafficFeatures)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SignedHeaders != nil {
+		in, out := &in.SignedHeaders, &out.SignedHeaders
+		*out = make([]string, len(*in))
+		copy(*out, *in)
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HTTP10Settings.
-func (in *HTTP10Settings) DeepCopy() *HTTP10Settings {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HMACRequestSigning.
+func (in *HMACRequestSigning) DeepCopy() *HMACRequestSigning {
 	if in == nil {
 		return nil
 	}
-	out := new(HTTP10Settings)
+	out := new(HMACRequestSigning)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HTTP10Settings) DeepCopyInto(out *HTTP10Settings) {
+	*out = *in
+	if in.DefaultHost != nil {
+		in, out := &in.DefaultHost, &out.DefaultHost
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HTTP10Settings.
+func (in *HTTP10Settings) DeepCopy() *HTTP10Settings {
+	if in == nil {
+		return nil
+	}
+	out := new(HTTP10Settings)
 	in.DeepCopyInto(out)
 	return out
 }
@@ -1282,7 +1670,7 @@ func (in *HTTPListener) DeepCopyInto(out *HTTPListener) {
 	if in.HealthCheck != nil {
 		in, out := &in.HealthCheck, &out.HealthCheck
 		*out = new(HealthCheckSettings)
-		**out = **in
+		(*in).DeepCopyInto(*out)
 	}
 	if in.Timeout != nil {
 		in, out := &in.Timeout, &out.Timeout
@@ -1416,6 +1804,11 @@ func (in *HTTPRoute) DeepCopyInto(out *HTTPRoute) {
 		*out = new(URLRewrite)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.RequestNormalization != nil {
+		in, out := &in.RequestNormalization, &out.RequestNormalization
+		*out = new(RequestNormalization)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.ExtensionRefs != nil {
 		in, out := &in.ExtensionRefs, &out.ExtensionRefs
 		*out = make([]*UnstructuredRef, len(*in))
@@ -1452,6 +1845,11 @@ func (in *HTTPRoute) DeepCopyInto(out *HTTPRoute) {
 		*out = new(ResourceMetadata)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.NodeGroups != nil {
+		in, out := &in.NodeGroups, &out.NodeGroups
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	if in.SessionPersistence != nil {
 		in, out := &in.SessionPersistence, &out.SessionPersistence
 		*out = new(SessionPersistence)
@@ -1514,6 +1912,31 @@ func (in *HTTPWasmCode) DeepCopy() *HTTPWasmCode {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HappyEyeballs) DeepCopyInto(out *HappyEyeballs) {
+	*out = *in
+	if in.FirstAddressFamily != nil {
+		in, out := &in.FirstAddressFamily, &out.FirstAddressFamily
+		*out = new(v1alpha1.IPFamily)
+		**out = **in
+	}
+	if in.FirstAddressFamilyCount != nil {
+		in, out := &in.FirstAddressFamilyCount, &out.FirstAddressFamilyCount
+		*out = new(uint32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HappyEyeballs.
+func (in *HappyEyeballs) DeepCopy() *HappyEyeballs {
+	if in == nil {
+		return nil
+	}
+	out := new(HappyEyeballs)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *HeaderBasedSessionPersistence) DeepCopyInto(out *HeaderBasedSessionPersistence) {
 	*out = *in
@@ -1561,6 +1984,43 @@ func (in *HeaderSettings) DeepCopy() *HeaderSettings {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HeaderToMetadata) DeepCopyInto(out *HeaderToMetadata) {
+	*out = *in
+	if in.RequestHeaders != nil {
+		in, out := &in.RequestHeaders, &out.RequestHeaders
+		*out = make([]*RequestHeaderToMetadata, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = new(RequestHeaderToMetadata)
+				(*in).DeepCopyInto(*out)
+			}
+		}
+	}
+	if in.ResponseHeaders != nil {
+		in, out := &in.ResponseHeaders, &out.ResponseHeaders
+		*out = make([]*MetadataToResponseHeader, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = new(MetadataToResponseHeader)
+				**out = **in
+			}
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HeaderToMetadata.
+func (in *HeaderToMetadata) DeepCopy() *HeaderToMetadata {
+	if in == nil {
+		return nil
+	}
+	out := new(HeaderToMetadata)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *HealthCheck) DeepCopyInto(out *HealthCheck) {
 	*out = *in
@@ -1614,6 +2074,17 @@ func (in *HealthCheckPayload) DeepCopy() *HealthCheckPayload {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *HealthCheckSettings) DeepCopyInto(out *HealthCheckSettings) {
 	*out = *in
+	if in.MinHealthyPercentages != nil {
+		in, out := &in.MinHealthyPercentages, &out.MinHealthyPercentages
+		*out = make([]*DestinationMinHealthyPercentage, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = new(DestinationMinHealthyPercentage)
+				**out = **in
+			}
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HealthCheckSettings.
@@ -1678,6 +2149,11 @@ func (in *InfraMetadata) DeepCopy() *InfraMetadata {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *JSONAccessLog) DeepCopyInto(out *JSONAccessLog) {
 	*out = *in
+	if in.Protocol != nil {
+		in, out := &in.Protocol, &out.Protocol
+		*out = new(v1alpha1.ProxyAccessLogProtocol)
+		**out = **in
+	}
 	if in.CELMatches != nil {
 		in, out := &in.CELMatches, &out.CELMatches
 		*out = make([]string, len(*in))
@@ -1763,6 +2239,11 @@ func (in *JWT) DeepCopyInto(out *JWT) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.RouteMatch != nil {
+		in, out := &in.RouteMatch, &out.RouteMatch
+		*out = new(v1alpha1.JWTRouteMatch)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JWT.
@@ -1810,6 +2291,31 @@ func (in *ListenerPort) DeepCopy() *ListenerPort {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ListenerTuning) DeepCopyInto(out *ListenerTuning) {
+	*out = *in
+	if in.MaxConnectionsToAcceptPerSocketEvent != nil {
+		in, out := &in.MaxConnectionsToAcceptPerSocketEvent, &out.MaxConnectionsToAcceptPerSocketEvent
+		*out = new(uint32)
+		**out = **in
+	}
+	if in.ListenerFiltersTimeout != nil {
+		in, out := &in.ListenerFiltersTimeout, &out.ListenerFiltersTimeout
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ListenerTuning.
+func (in *ListenerTuning) DeepCopy() *ListenerTuning {
+	if in == nil {
+		return nil
+	}
+	out := new(ListenerTuning)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *LoadBalancer) DeepCopyInto(out *LoadBalancer) {
 	*out = *in
@@ -1845,6 +2351,22 @@ func (in *LoadBalancer) DeepCopy() *LoadBalancer {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LoadReporting) DeepCopyInto(out *LoadReporting) {
+	*out = *in
+	out.Interval = in.Interval
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LoadReporting.
+func (in *LoadReporting) DeepCopy() *LoadReporting {
+	if in == nil {
+		return nil
+	}
+	out := new(LoadReporting)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *LocalRateLimit) DeepCopyInto(out *LocalRateLimit) {
 	*out = *in
@@ -1872,6 +2394,78 @@ func (in *LocalRateLimit) DeepCopy() *LocalRateLimit {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LocalityEndpointDiscovery) DeepCopyInto(out *LocalityEndpointDiscovery) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LocalityEndpointDiscovery.
+func (in *LocalityEndpointDiscovery) DeepCopy() *LocalityEndpointDiscovery {
+	if in == nil {
+		return nil
+	}
+	out := new(LocalityEndpointDiscovery)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MaintenanceWindow) DeepCopyInto(out *MaintenanceWindow) {
+	*out = *in
+	out.Start = in.Start
+	out.Duration = in.Duration
+	if in.Days != nil {
+		in, out := &in.Days, &out.Days
+		*out = make([]timex.Weekday, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MaintenanceWindow.
+func (in *MaintenanceWindow) DeepCopy() *MaintenanceWindow {
+	if in == nil {
+		return nil
+	}
+	out := new(MaintenanceWindow)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MetadataToResponseHeader) DeepCopyInto(out *MetadataToResponseHeader) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MetadataToResponseHeader.
+func (in *MetadataToResponseHeader) DeepCopy() *MetadataToResponseHeader {
+	if in == nil {
+		return nil
+	}
+	out := new(MetadataToResponseHeader)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MetadataValue) DeepCopyInto(out *MetadataValue) {
+	*out = *in
+	if in.Value != nil {
+		in, out := &in.Value, &out.Value
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MetadataValue.
+func (in *MetadataValue) DeepCopy() *MetadataValue {
+	if in == nil {
+		return nil
+	}
+	out := new(MetadataValue)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Metrics) DeepCopyInto(out *Metrics) {
 	*out = *in
@@ -1887,6 +2481,51 @@ func (in *Metrics) DeepCopy() *Metrics {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NoSNIMatch) DeepCopyInto(out *NoSNIMatch) {
+	*out = *in
+	if in.Destination != nil {
+		in, out := &in.Destination, &out.Destination
+		*out = new(RouteDestination)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NoSNIMatch.
+func (in *NoSNIMatch) DeepCopy() *NoSNIMatch {
+	if in == nil {
+		return nil
+	}
+	out := new(NoSNIMatch)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OAuth2ClientCredentials) DeepCopyInto(out *OAuth2ClientCredentials) {
+	*out = *in
+	if in.ClientSecret != nil {
+		in, out := &in.ClientSecret, &out.ClientSecret
+		*out = make([]byte, len(*in))
+		copy(*out, *in)
+	}
+	if in.Scopes != nil {
+		in, out := &in.Scopes, &out.Scopes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OAuth2ClientCredentials.
+func (in *OAuth2ClientCredentials) DeepCopy() *OAuth2ClientCredentials {
+	if in == nil {
+		return nil
+	}
+	out := new(OAuth2ClientCredentials)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *OIDC) DeepCopyInto(out *OIDC) {
 	*out = *in
@@ -1943,9 +2582,35 @@ func (in *OIDC) DeepCopy() *OIDC {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OpenAPIValidation) DeepCopyInto(out *OpenAPIValidation) {
+	*out = *in
+	in.Destination.DeepCopyInto(&out.Destination)
+	if in.Traffic != nil {
+		in, out := &in.Traffic, &out.Traffic
+		*out = new(TrafficFeatures)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OpenAPIValidation.
+func (in *OpenAPIValidation) DeepCopy() *OpenAPIValidation {
+	if in == nil {
+		return nil
+	}
+	out := new(OpenAPIValidation)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *OpenTelemetryAccessLog) DeepCopyInto(out *OpenTelemetryAccessLog) {
 	*out = *in
+	if in.Protocol != nil {
+		in, out := &in.Protocol, &out.Protocol
+		*out = new(v1alpha1.ProxyAccessLogProtocol)
+		**out = **in
+	}
 	if in.CELMatches != nil {
 		in, out := &in.CELMatches, &out.CELMatches
 		*out = make([]string, len(*in))
@@ -1988,6 +2653,26 @@ func (in *OpenTelemetryAccessLog) DeepCopy() *OpenTelemetryAccessLog {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OriginalDestination) DeepCopyInto(out *OriginalDestination) {
+	*out = *in
+	if in.HeaderName != nil {
+		in, out := &in.HeaderName, &out.HeaderName
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OriginalDestination.
+func (in *OriginalDestination) DeepCopy() *OriginalDestination {
+	if in == nil {
+		return nil
+	}
+	out := new(OriginalDestination)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *OutlierDetection) DeepCopyInto(out *OutlierDetection) {
 	*out = *in
@@ -2078,6 +2763,31 @@ func (in *PerRetryPolicy) DeepCopy() *PerRetryPolicy {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Preconnect) DeepCopyInto(out *Preconnect) {
+	*out = *in
+	if in.PerEndpointPercent != nil {
+		in, out := &in.PerEndpointPercent, &out.PerEndpointPercent
+		*out = new(uint32)
+		**out = **in
+	}
+	if in.PredictivePercent != nil {
+		in, out := &in.PredictivePercent, &out.PredictivePercent
+		*out = new(uint32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Preconnect.
+func (in *Preconnect) DeepCopy() *Preconnect {
+	if in == nil {
+		return nil
+	}
+	out := new(Preconnect)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Principal) DeepCopyInto(out *Principal) {
 	*out = *in
@@ -2338,14 +3048,89 @@ func (in *RegexMatchReplace) DeepCopy() *RegexMatchReplace {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ResourceMetadata) DeepCopyInto(out *ResourceMetadata) {
+func (in *RequestHeaderToMetadata) DeepCopyInto(out *RequestHeaderToMetadata) {
 	*out = *in
-	if in.Annotations != nil {
-		in, out := &in.Annotations, &out.Annotations
-		*out = make(map[string]string, len(*in))
-		for key, val := range *in {
-			(*out)[key] = val
-		}
+	if in.OnPresent != nil {
+		in, out := &in.OnPresent, &out.OnPresent
+		*out = new(MetadataValue)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.OnMissing != nil {
+		in, out := &in.OnMissing, &out.OnMissing
+		*out = new(MetadataValue)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RequestHeaderToMetadata.
+func (in *RequestHeaderToMetadata) DeepCopy() *RequestHeaderToMetadata {
+	if in == nil {
+		return nil
+	}
+	out := new(RequestHeaderToMetadata)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RequestNormalization) DeepCopyInto(out *RequestNormalization) {
+	*out = *in
+	if in.RemoveQueryParameters != nil {
+		in, out := &in.RemoveQueryParameters, &out.RemoveQueryParameters
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.TrailingSlashRedirect != nil {
+		in, out := &in.TrailingSlashRedirect, &out.TrailingSlashRedirect
+		*out = new(TrailingSlashRedirect)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RequestNormalization.
+func (in *RequestNormalization) DeepCopy() *RequestNormalization {
+	if in == nil {
+		return nil
+	}
+	out := new(RequestNormalization)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RequestSigning) DeepCopyInto(out *RequestSigning) {
+	*out = *in
+	if in.AWSSigV4 != nil {
+		in, out := &in.AWSSigV4, &out.AWSSigV4
+		*out = new(AWSSigV4RequestSigning)
+		**out = **in
+	}
+	if in.HMAC != nil {
+		in, out := &in.HMAC, &out.HMAC
+		*out = new(HMACRequestSigning)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RequestSigning.
+func (in *RequestSigning) DeepCopy() *RequestSigning {
+	if in == nil {
+		return nil
+	}
+	out := new(RequestSigning)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ResourceMetadata) DeepCopyInto(out *ResourceMetadata) {
+	*out = *in
+	if in.Annotations != nil {
+		in, out := &in.Annotations, &out.Annotations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
 	}
 }
 
@@ -2359,6 +3144,64 @@ func (in *ResourceMetadata) DeepCopy() *ResourceMetadata {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ResponseBuffer) DeepCopyInto(out *ResponseBuffer) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResponseBuffer.
+func (in *ResponseBuffer) DeepCopy() *ResponseBuffer {
+	if in == nil {
+		return nil
+	}
+	out := new(ResponseBuffer)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ResponseOverride) DeepCopyInto(out *ResponseOverride) {
+	*out = *in
+	if in.Rules != nil {
+		in, out := &in.Rules, &out.Rules
+		*out = make([]*ResponseOverrideRule, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = new(ResponseOverrideRule)
+				(*in).DeepCopyInto(*out)
+			}
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResponseOverride.
+func (in *ResponseOverride) DeepCopy() *ResponseOverride {
+	if in == nil {
+		return nil
+	}
+	out := new(ResponseOverride)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ResponseOverrideRule) DeepCopyInto(out *ResponseOverrideRule) {
+	*out = *in
+	in.Match.DeepCopyInto(&out.Match)
+	in.Response.DeepCopyInto(&out.Response)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResponseOverrideRule.
+func (in *ResponseOverrideRule) DeepCopy() *ResponseOverrideRule {
+	if in == nil {
+		return nil
+	}
+	out := new(ResponseOverrideRule)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Retry) DeepCopyInto(out *Retry) {
 	*out = *in
@@ -2460,6 +3303,56 @@ func (in *RouteDestination) DeepCopy() *RouteDestination {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RouteMatcherTree) DeepCopyInto(out *RouteMatcherTree) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RouteMatcherTree.
+func (in *RouteMatcherTree) DeepCopy() *RouteMatcherTree {
+	if in == nil {
+		return nil
+	}
+	out := new(RouteMatcherTree)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RuntimeDiscovery) DeepCopyInto(out *RuntimeDiscovery) {
+	*out = *in
+	if in.Values != nil {
+		in, out := &in.Values, &out.Values
+		*out = make([]RuntimeValue, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RuntimeDiscovery.
+func (in *RuntimeDiscovery) DeepCopy() *RuntimeDiscovery {
+	if in == nil {
+		return nil
+	}
+	out := new(RuntimeDiscovery)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RuntimeValue) DeepCopyInto(out *RuntimeValue) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RuntimeValue.
+func (in *RuntimeValue) DeepCopy() *RuntimeValue {
+	if in == nil {
+		return nil
+	}
+	out := new(RuntimeValue)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SecurityFeatures) DeepCopyInto(out *SecurityFeatures) {
 	*out = *in
@@ -2493,6 +3386,11 @@ func (in *SecurityFeatures) DeepCopyInto(out *SecurityFeatures) {
 		*out = new(Authorization)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.SecurityHeaders != nil {
+		in, out := &in.SecurityHeaders, &out.SecurityHeaders
+		*out = new(SecurityHeaders)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecurityFeatures.
@@ -2505,6 +3403,41 @@ func (in *SecurityFeatures) DeepCopy() *SecurityFeatures {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SecurityHeader) DeepCopyInto(out *SecurityHeader) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecurityHeader.
+func (in *SecurityHeader) DeepCopy() *SecurityHeader {
+	if in == nil {
+		return nil
+	}
+	out := new(SecurityHeader)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SecurityHeaders) DeepCopyInto(out *SecurityHeaders) {
+	*out = *in
+	if in.Headers != nil {
+		in, out := &in.Headers, &out.Headers
+		*out = make([]SecurityHeader, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecurityHeaders.
+func (in *SecurityHeaders) DeepCopy() *SecurityHeaders {
+	if in == nil {
+		return nil
+	}
+	out := new(SecurityHeaders)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SessionPersistence) DeepCopyInto(out *SessionPersistence) {
 	*out = *in
@@ -2530,6 +3463,22 @@ func (in *SessionPersistence) DeepCopy() *SessionPersistence {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SessionTicketKeys) DeepCopyInto(out *SessionTicketKeys) {
+	*out = *in
+	out.RotationInterval = in.RotationInterval
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SessionTicketKeys.
+func (in *SessionTicketKeys) DeepCopy() *SessionTicketKeys {
+	if in == nil {
+		return nil
+	}
+	out := new(SessionTicketKeys)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SlowStart) DeepCopyInto(out *SlowStart) {
 	*out = *in
@@ -2550,6 +3499,46 @@ func (in *SlowStart) DeepCopy() *SlowStart {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *StatusCodeMatch) DeepCopyInto(out *StatusCodeMatch) {
+	*out = *in
+	if in.Value != nil {
+		in, out := &in.Value, &out.Value
+		*out = new(int)
+		**out = **in
+	}
+	if in.Range != nil {
+		in, out := &in.Range, &out.Range
+		*out = new(StatusCodeRange)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StatusCodeMatch.
+func (in *StatusCodeMatch) DeepCopy() *StatusCodeMatch {
+	if in == nil {
+		return nil
+	}
+	out := new(StatusCodeMatch)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *StatusCodeRange) DeepCopyInto(out *StatusCodeRange) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StatusCodeRange.
+func (in *StatusCodeRange) DeepCopy() *StatusCodeRange {
+	if in == nil {
+		return nil
+	}
+	out := new(StatusCodeRange)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *StringMatch) DeepCopyInto(out *StringMatch) {
 	*out = *in
@@ -2578,6 +3567,11 @@ func (in *StringMatch) DeepCopyInto(out *StringMatch) {
 		*out = new(bool)
 		**out = **in
 	}
+	if in.Present != nil {
+		in, out := &in.Present, &out.Present
+		*out = new(bool)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StringMatch.
@@ -2919,6 +3913,16 @@ func (in *TLSConfig) DeepCopyInto(out *TLSConfig) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.SessionTicketKeys != nil {
+		in, out := &in.SessionTicketKeys, &out.SessionTicketKeys
+		*out = new(SessionTicketKeys)
+		**out = **in
+	}
+	if in.NoSNIMatch != nil {
+		in, out := &in.NoSNIMatch, &out.NoSNIMatch
+		*out = new(NoSNIMatch)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TLSConfig.
@@ -2975,6 +3979,11 @@ func (in *TLSUpstreamConfig) DeepCopy() *TLSUpstreamConfig {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TextAccessLog) DeepCopyInto(out *TextAccessLog) {
 	*out = *in
+	if in.Protocol != nil {
+		in, out := &in.Protocol, &out.Protocol
+		*out = new(v1alpha1.ProxyAccessLogProtocol)
+		**out = **in
+	}
 	if in.CELMatches != nil {
 		in, out := &in.CELMatches, &out.CELMatches
 		*out = make([]string, len(*in))
@@ -3114,6 +4123,36 @@ func (in *TrafficFeatures) DeepCopyInto(out *TrafficFeatures) {
 		*out = new(DNS)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Experiment != nil {
+		in, out := &in.Experiment, &out.Experiment
+		*out = new(Experiment)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.HeaderToMetadata != nil {
+		in, out := &in.HeaderToMetadata, &out.HeaderToMetadata
+		*out = new(HeaderToMetadata)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ResponseOverride != nil {
+		in, out := &in.ResponseOverride, &out.ResponseOverride
+		*out = new(ResponseOverride)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ResponseBuffer != nil {
+		in, out := &in.ResponseBuffer, &out.ResponseBuffer
+		*out = new(ResponseBuffer)
+		**out = **in
+	}
+	if in.RequestSigning != nil {
+		in, out := &in.RequestSigning, &out.RequestSigning
+		*out = new(RequestSigning)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.CredentialInjection != nil {
+		in, out := &in.CredentialInjection, &out.CredentialInjection
+		*out = new(CredentialInjection)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TrafficFeatures.
@@ -3126,6 +4165,41 @@ func (in *TrafficFeatures) DeepCopy() *TrafficFeatures {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TrafficRecording) DeepCopyInto(out *TrafficRecording) {
+	*out = *in
+	if in.Headers != nil {
+		in, out := &in.Headers, &out.Headers
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TrafficRecording.
+func (in *TrafficRecording) DeepCopy() *TrafficRecording {
+	if in == nil {
+		return nil
+	}
+	out := new(TrafficRecording)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TrailingSlashRedirect) DeepCopyInto(out *TrailingSlashRedirect) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TrailingSlashRedirect.
+func (in *TrailingSlashRedirect) DeepCopy() *TrailingSlashRedirect {
+	if in == nil {
+		return nil
+	}
+	out := new(TrailingSlashRedirect)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *UDPListener) DeepCopyInto(out *UDPListener) {
 	*out = *in
@@ -3221,6 +4295,41 @@ func (in *UnstructuredRef) DeepCopy() *UnstructuredRef {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VirtualHostDiscovery) DeepCopyInto(out *VirtualHostDiscovery) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualHostDiscovery.
+func (in *VirtualHostDiscovery) DeepCopy() *VirtualHostDiscovery {
+	if in == nil {
+		return nil
+	}
+	out := new(VirtualHostDiscovery)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Warming) DeepCopyInto(out *Warming) {
+	*out = *in
+	if in.InitialFetchTimeout != nil {
+		in, out := &in.InitialFetchTimeout, &out.InitialFetchTimeout
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Warming.
+func (in *Warming) DeepCopy() *Warming {
+	if in == nil {
+		return nil
+	}
+	out := new(Warming)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Wasm) DeepCopyInto(out *Wasm) {
 	*out = *in
@@ -3340,6 +4449,61 @@ func (in *Xds) DeepCopyInto(out *Xds) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Warming != nil {
+		in, out := &in.Warming, &out.Warming
+		*out = new(Warming)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.LoadReporting != nil {
+		in, out := &in.LoadReporting, &out.LoadReporting
+		*out = new(LoadReporting)
+		**out = **in
+	}
+	if in.TrafficRecording != nil {
+		in, out := &in.TrafficRecording, &out.TrafficRecording
+		*out = new(TrafficRecording)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.DeferDrains != nil {
+		in, out := &in.DeferDrains, &out.DeferDrains
+		*out = new(DeferDrains)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.RouteMatcherTree != nil {
+		in, out := &in.RouteMatcherTree, &out.RouteMatcherTree
+		*out = new(RouteMatcherTree)
+		**out = **in
+	}
+	if in.LocalityEndpointDiscovery != nil {
+		in, out := &in.LocalityEndpointDiscovery, &out.LocalityEndpointDiscovery
+		*out = new(LocalityEndpointDiscovery)
+		**out = **in
+	}
+	if in.VirtualHostDiscovery != nil {
+		in, out := &in.VirtualHostDiscovery, &out.VirtualHostDiscovery
+		*out = new(VirtualHostDiscovery)
+		**out = **in
+	}
+	if in.ExtensionConfigDiscovery != nil {
+		in, out := &in.ExtensionConfigDiscovery, &out.ExtensionConfigDiscovery
+		*out = new(ExtensionConfigDiscovery)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Drain != nil {
+		in, out := &in.Drain, &out.Drain
+		*out = new(Drain)
+		**out = **in
+	}
+	if in.ConfigCheckpoint != nil {
+		in, out := &in.ConfigCheckpoint, &out.ConfigCheckpoint
+		*out = new(ConfigCheckpoint)
+		**out = **in
+	}
+	if in.RuntimeDiscovery != nil {
+		in, out := &in.RuntimeDiscovery, &out.RuntimeDiscovery
+		*out = new(RuntimeDiscovery)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Xds.
@@ -8,6 +8,7 @@ package logging
 import (
 	"io"
 	"os"
+	"sync"
 
 	"github.com/go-logr/logr"
 	"github.com/go-logr/zapr"
@@ -19,16 +20,84 @@ import (
 
 type Logger struct {
 	logr.Logger
-	logging       *egv1a1.EnvoyGatewayLogging
+	levels        *levels
 	sugaredLogger *zap.SugaredLogger
 }
 
+// levels holds the atomic levels shared by a logger and all the loggers
+// derived from it, so that their levels can be changed at runtime.
+type levels struct {
+	mu         sync.Mutex
+	logging    *egv1a1.EnvoyGatewayLogging
+	components map[egv1a1.EnvoyGatewayLogComponent]zap.AtomicLevel
+}
+
+func newLevels(logging *egv1a1.EnvoyGatewayLogging) *levels {
+	return &levels{
+		logging:    logging,
+		components: make(map[egv1a1.EnvoyGatewayLogComponent]zap.AtomicLevel),
+	}
+}
+
+// atomicLevel returns the atomic level of the component, creating it if needed.
+func (l *levels) atomicLevel(component egv1a1.EnvoyGatewayLogComponent) zap.AtomicLevel {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	level, ok := l.components[component]
+	if !ok {
+		level = zap.NewAtomicLevelAt(parseLevel(l.logging, l.logging.Level[component]))
+		l.components[component] = level
+	}
+	return level
+}
+
+// setLogging updates the atomic level of every component from logging.
+func (l *levels) setLogging(logging *egv1a1.EnvoyGatewayLogging) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.setLoggingLocked(logging)
+}
+
+func (l *levels) setLoggingLocked(logging *egv1a1.EnvoyGatewayLogging) {
+	l.logging = logging
+	for component, level := range l.components {
+		level.SetLevel(parseLevel(logging, logging.Level[component]))
+	}
+}
+
+// level returns the level of the component.
+func (l *levels) level(component egv1a1.EnvoyGatewayLogComponent) egv1a1.LogLevel {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	return l.logging.DefaultEnvoyGatewayLoggingLevel(l.logging.Level[component])
+}
+
+// setLevel sets the level of the component, leaving the other components unchanged.
+func (l *levels) setLevel(component egv1a1.EnvoyGatewayLogComponent, level egv1a1.LogLevel) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	logging := &egv1a1.EnvoyGatewayLogging{
+		Level: make(map[egv1a1.EnvoyGatewayLogComponent]egv1a1.LogLevel, len(l.logging.Level)+1),
+	}
+	for c, lvl := range l.logging.Level {
+		logging.Level[c] = lvl
+	}
+	logging.Level[component] = level
+
+	l.setLoggingLocked(logging)
+}
+
 func NewLogger(logging *egv1a1.EnvoyGatewayLogging) Logger {
-	logger := initZapLogger(os.Stdout, logging, logging.Level[egv1a1.LogComponentGatewayDefault])
+	levels := newLevels(logging)
+	logger := initZapLogger(os.Stdout, levels.atomicLevel(egv1a1.LogComponentGatewayDefault))
 
 	return Logger{
 		Logger:        zapr.NewLogger(logger),
-		logging:       logging,
+		levels:        levels,
 		sugaredLogger: logger.Sugar(),
 	}
 }
@@ -39,43 +108,70 @@ func FileLogger(file string, name string, level egv1a1.LogLevel) Logger {
 		panic(err)
 	}
 
-	logging := egv1a1.DefaultEnvoyGatewayLogging()
-	logger := initZapLogger(writer, logging, level)
+	levels := newLevels(defaultLogging(level))
+	logger := initZapLogger(writer, levels.atomicLevel(egv1a1.LogComponentGatewayDefault))
 
 	return Logger{
 		Logger:        zapr.NewLogger(logger).WithName(name),
-		logging:       logging,
+		levels:        levels,
 		sugaredLogger: logger.Sugar(),
 	}
 }
 
 func DefaultLogger(level egv1a1.LogLevel) Logger {
-	logging := egv1a1.DefaultEnvoyGatewayLogging()
-	logger := initZapLogger(os.Stdout, logging, level)
+	levels := newLevels(defaultLogging(level))
+	logger := initZapLogger(os.Stdout, levels.atomicLevel(egv1a1.LogComponentGatewayDefault))
 
 	return Logger{
 		Logger:        zapr.NewLogger(logger),
-		logging:       logging,
+		levels:        levels,
 		sugaredLogger: logger.Sugar(),
 	}
 }
 
+// defaultLogging returns the default logging configuration with the default
+// level of all components set to level.
+func defaultLogging(level egv1a1.LogLevel) *egv1a1.EnvoyGatewayLogging {
+	logging := egv1a1.DefaultEnvoyGatewayLogging()
+	if level != "" {
+		logging.Level[egv1a1.LogComponentGatewayDefault] = level
+	}
+	return logging
+}
+
 // WithName returns a new Logger instance with the specified name element added
 // to the Logger's name.  Successive calls with WithName append additional
 // suffixes to the Logger's name.  It's strongly recommended that name segments
 // contain only letters, digits, and hyphens (see the package documentation for
 // more information).
 func (l Logger) WithName(name string) Logger {
-	logLevel := l.logging.Level[egv1a1.EnvoyGatewayLogComponent(name)]
-	logger := initZapLogger(os.Stdout, l.logging, logLevel)
+	logger := initZapLogger(os.Stdout, l.levels.atomicLevel(egv1a1.EnvoyGatewayLogComponent(name)))
 
 	return Logger{
 		Logger:        zapr.NewLogger(logger).WithName(name),
-		logging:       l.logging,
+		levels:        l.levels,
 		sugaredLogger: logger.Sugar().Named(name),
 	}
 }
 
+// SetLogging updates the levels of the logger and all the loggers derived
+// from it at runtime.
+func (l Logger) SetLogging(logging *egv1a1.EnvoyGatewayLogging) {
+	l.levels.setLogging(logging)
+}
+
+// Level returns the level of the loggers of the component.
+func (l Logger) Level(component egv1a1.EnvoyGatewayLogComponent) egv1a1.LogLevel {
+	return l.levels.level(component)
+}
+
+// SetLevel sets the level of the loggers of the component at runtime.
+// Setting the level of LogComponentGatewayDefault also changes the level
+// of the components that have no level of their own.
+func (l Logger) SetLevel(component egv1a1.EnvoyGatewayLogComponent, level egv1a1.LogLevel) {
+	l.levels.setLevel(component, level)
+}
+
 // WithValues returns a new Logger instance with additional key/value pairs.
 // See Info for documentation on how key/value pairs work.
 func (l Logger) WithValues(keysAndValues ...interface{}) Logger {
@@ -105,9 +201,15 @@ func (l Logger) Sugar() *zap.SugaredLogger {
 	return l.sugaredLogger
 }
 
-func initZapLogger(w io.Writer, logging *egv1a1.EnvoyGatewayLogging, level egv1a1.LogLevel) *zap.Logger {
-	parseLevel, _ := zapcore.ParseLevel(string(logging.DefaultEnvoyGatewayLoggingLevel(level)))
-	core := zapcore.NewCore(zapcore.NewConsoleEncoder(zap.NewDevelopmentEncoderConfig()), zapcore.AddSync(w), zap.NewAtomicLevelAt(parseLevel))
+func initZapLogger(w io.Writer, level zap.AtomicLevel) *zap.Logger {
+	core := zapcore.NewCore(zapcore.NewConsoleEncoder(zap.NewDevelopmentEncoderConfig()), zapcore.AddSync(w), level)
 
 	return zap.New(core, zap.AddCaller())
 }
+
+// parseLevel returns the zap level of a component with the level, falling
+// back to the default level of logging.
+func parseLevel(logging *egv1a1.EnvoyGatewayLogging, level egv1a1.LogLevel) zapcore.Level {
+	parsed, _ := zapcore.ParseLevel(string(logging.DefaultEnvoyGatewayLoggingLevel(level)))
+	return parsed
+}
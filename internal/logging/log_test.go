@@ -39,11 +39,11 @@ func TestLogger(t *testing.T) {
 	logger.WithName(string(egv1a1.LogComponentGlobalRateLimitRunner)).WithValues("runner", egv1a1.LogComponentGlobalRateLimitRunner).Info("msg", "k", "v")
 
 	defaultLogger := DefaultLogger(egv1a1.LogLevelInfo)
-	assert.NotNil(t, defaultLogger.logging)
+	assert.NotNil(t, defaultLogger.levels)
 	assert.NotNil(t, defaultLogger.sugaredLogger)
 
 	fileLogger := FileLogger("/dev/stderr", "fl-test", egv1a1.LogLevelInfo)
-	assert.NotNil(t, fileLogger.logging)
+	assert.NotNil(t, fileLogger.levels)
 	assert.NotNil(t, fileLogger.sugaredLogger)
 }
 
@@ -104,3 +104,54 @@ func TestLoggerSugarName(t *testing.T) {
 	capturedOutput := string(outputBytes)
 	assert.Contains(t, capturedOutput, "debugging message", logName)
 }
+
+func TestLoggerSetLogging(t *testing.T) {
+	originalStdout := os.Stdout
+	r, w, _ := os.Pipe()
+	os.Stdout = w
+
+	defer func() {
+		// Restore the original stdout and close the pipe
+		os.Stdout = originalStdout
+		err := w.Close()
+		require.NoError(t, err)
+	}()
+
+	root := NewLogger(egv1a1.DefaultEnvoyGatewayLogging())
+	logger := root.WithName(string(egv1a1.LogComponentXdsServerRunner))
+	logger.Sugar().Debugf("suppressed message")
+
+	config := egv1a1.DefaultEnvoyGatewayLogging()
+	config.Level[egv1a1.LogComponentXdsServerRunner] = egv1a1.LogLevelDebug
+	root.SetLogging(config)
+	logger.Sugar().Debugf("debug message")
+
+	// Read from the pipe (captured stdout)
+	outputBytes := make([]byte, 200)
+	_, err := r.Read(outputBytes)
+	require.NoError(t, err)
+	capturedOutput := string(outputBytes)
+	assert.NotContains(t, capturedOutput, "suppressed message")
+	assert.Contains(t, capturedOutput, "debug message")
+}
+
+func TestLoggerSetLevel(t *testing.T) {
+	config := egv1a1.DefaultEnvoyGatewayLogging()
+	config.Level[egv1a1.LogComponentProviderRunner] = egv1a1.LogLevelWarn
+	logger := NewLogger(config)
+
+	require.Equal(t, egv1a1.LogLevelInfo, logger.Level(egv1a1.LogComponentXdsServerRunner))
+	require.Equal(t, egv1a1.LogLevelWarn, logger.Level(egv1a1.LogComponentProviderRunner))
+
+	logger.SetLevel(egv1a1.LogComponentXdsServerRunner, egv1a1.LogLevelDebug)
+	require.Equal(t, egv1a1.LogLevelDebug, logger.Level(egv1a1.LogComponentXdsServerRunner))
+
+	// Components without a level of their own follow the default level.
+	logger.SetLevel(egv1a1.LogComponentGatewayDefault, egv1a1.LogLevelError)
+	require.Equal(t, egv1a1.LogLevelError, logger.Level(egv1a1.LogComponentInfrastructureRunner))
+	require.Equal(t, egv1a1.LogLevelWarn, logger.Level(egv1a1.LogComponentProviderRunner))
+	require.Equal(t, egv1a1.LogLevelDebug, logger.Level(egv1a1.LogComponentXdsServerRunner))
+
+	// The original config is left untouched.
+	require.NotContains(t, config.Level, egv1a1.LogComponentXdsServerRunner)
+}
@@ -24,6 +24,36 @@ var (
 		"Total number of subscribed watchable queue.",
 	)
 
+	watchableQueueDroppedTotal = metrics.NewCounter(
+		"watchable_queue_dropped_total",
+		"Total number of updates dropped from the full bounded watchable queues.",
+	)
+
+	watchableQueueCoalescedTotal = metrics.NewCounter(
+		"watchable_queue_coalesced_total",
+		"Total number of updates coalesced with the pending update of their key in the watchable queues.",
+	)
+
+	watchableQueuePrioritizedTotal = metrics.NewCounter(
+		"watchable_queue_prioritized_total",
+		"Total number of updates handled ahead of the pending updates of a lower priority in the watchable queues.",
+	)
+
+	watchableSubscribeRetriesTotal = metrics.NewCounter(
+		"watchable_subscribe_retries_total",
+		"Total number of retries of the updates the subscribers of the watchable queues failed to handle.",
+	)
+
+	watchableDeadLetteredTotal = metrics.NewCounter(
+		"watchable_dead_lettered_total",
+		"Total number of updates dead-lettered once the subscribers of the watchable queues failed to handle them after the last retry.",
+	)
+
+	watchableReplayedTotal = metrics.NewCounter(
+		"watchable_replayed_total",
+		"Total number of updates replaying the state of the watchable queues to their subscribers when they subscribe.",
+	)
+
 	runnerLabel  = metrics.NewLabel("runner")
 	messageLabel = metrics.NewLabel("message")
 )
@@ -0,0 +1,328 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package message
+
+import (
+	"slices"
+	"sync"
+
+	"github.com/telepresenceio/watchable"
+	"k8s.io/utils/ptr"
+
+	egv1a1 "github.com/envoyproxy/gateway/api/v1alpha1"
+)
+
+var (
+	queuesMu sync.RWMutex
+	// queues holds the bounds of the queues of the subscriptions, by message.
+	queues map[string]egv1a1.WatchableQueue
+)
+
+// SetQueues bounds the queues of the subscriptions of the messages handled from then on,
+// by message. The queues of the messages not listed are unbounded.
+func SetQueues(watchable *egv1a1.EnvoyGatewayWatchable) {
+	queuesMu.Lock()
+	defer queuesMu.Unlock()
+
+	queues = make(map[string]egv1a1.WatchableQueue)
+	if watchable == nil {
+		return
+	}
+	for _, q := range watchable.Queues {
+		queues[q.Message] = q
+	}
+}
+
+// queueFor returns the bounds of the queues of the subscriptions of the message, if any.
+func queueFor(message string) (egv1a1.WatchableQueue, bool) {
+	queuesMu.RLock()
+	defer queuesMu.RUnlock()
+
+	q, ok := queues[message]
+	return q, ok
+}
+
+// Priority is the priority of an update of a subscription. The updates of a higher priority
+// are handled ahead of the pending updates of a lower priority.
+type Priority int
+
+const (
+	// PriorityNormal is the priority of the updates by default.
+	PriorityNormal Priority = iota
+	// PriorityHigh is the priority of the updates handled ahead of the others, e.g. the
+	// updates of the secrets and of the endpoints ahead of the recomputations of the routes.
+	PriorityHigh
+
+	numPriorities = iota
+)
+
+// updateQueue is the queue of the updates of a subscription not handled yet, holding a lane
+// of pending updates for each priority.
+type updateQueue[K comparable, V any] struct {
+	mu   sync.Mutex
+	cond *sync.Cond
+
+	// capacity is the maximum number of pending updates across the lanes, or zero if the
+	// queue is unbounded.
+	capacity int
+	policy   egv1a1.WatchableOverflowPolicy
+	// coalesce is whether the pending update of the key of a pushed update is replaced
+	// even if the queue is not full.
+	coalesce bool
+	lanes    [numPriorities][]Update[K, V]
+	closed   bool
+	// woken is whether the retries of the subscription are due.
+	woken bool
+}
+
+func newUpdateQueue[K comparable, V any](q egv1a1.WatchableQueue) *updateQueue[K, V] {
+	policy := egv1a1.WatchableOverflowPolicyBlock
+	if q.OverflowPolicy != nil {
+		policy = *q.OverflowPolicy
+	}
+	uq := &updateQueue[K, V]{
+		capacity: int(q.Capacity),
+		policy:   policy,
+		coalesce: ptr.Deref(q.Coalesce, false),
+	}
+	uq.cond = sync.NewCond(&uq.mu)
+	return uq
+}
+
+// len returns the number of pending updates across the lanes.
+func (q *updateQueue[K, V]) len() int {
+	n := 0
+	for _, lane := range q.lanes {
+		n += len(lane)
+	}
+	return n
+}
+
+// full returns whether the queue is bounded and full.
+func (q *updateQueue[K, V]) full() bool {
+	return q.capacity > 0 && q.len() >= q.capacity
+}
+
+// push adds the update to the lane of its priority, coalescing it with the pending update of
+// its key if the queue coalesces, and applying the overflow policy of the queue once it is
+// full. It returns whether an update pending in the queue was dropped or coalesced to make
+// room for the update.
+func (q *updateQueue[K, V]) push(update Update[K, V], priority Priority) (dropped, coalesced bool) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if q.coalesce && q.coalesceWith(update, priority) {
+		q.cond.Broadcast()
+		return false, true
+	}
+	for q.full() && !q.closed {
+		switch q.policy {
+		case egv1a1.WatchableOverflowPolicyDropOldest:
+			// The queue blocks as with Block when no pending update is superseded.
+			if q.dropSuperseded(update) {
+				dropped = true
+				continue
+			}
+		case egv1a1.WatchableOverflowPolicyCoalesceByKey:
+			if q.coalesceWith(update, priority) {
+				q.cond.Broadcast()
+				return false, true
+			}
+		}
+		q.cond.Wait()
+	}
+	q.enqueue(update, priority)
+	q.cond.Broadcast()
+	return dropped, false
+}
+
+// dropSuperseded drops the oldest update of the lowest priority which is superseded by a
+// newer update of its key, pending or pushed, and is not a deletion, so that the subscriber
+// never misses the latest update or the deletion of a key. It returns whether one was dropped.
+func (q *updateQueue[K, V]) dropSuperseded(update Update[K, V]) bool {
+	for i := range q.lanes {
+		// The pending updates of a key are all in the same lane.
+		lane := q.lanes[i]
+		for j, u := range lane {
+			if u.Delete {
+				continue
+			}
+			if u.Key == update.Key || slices.ContainsFunc(lane[j+1:], func(n Update[K, V]) bool { return n.Key == u.Key }) {
+				q.lanes[i] = slices.Delete(lane, j, j+1)
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// coalesceWith replaces the pending update of the key of the update with it, in place if
+// its priority is not higher, and returns whether an update of the key was pending.
+func (q *updateQueue[K, V]) coalesceWith(update Update[K, V], priority Priority) bool {
+	for i := len(q.lanes) - 1; i >= 0; i-- {
+		lane := q.lanes[i]
+		for j := len(lane) - 1; j >= 0; j-- {
+			if lane[j].Key != update.Key {
+				continue
+			}
+			if int(priority) <= i {
+				lane[j] = update
+			} else {
+				q.lanes[i] = slices.Delete(lane, j, j+1)
+				q.enqueue(update, priority)
+			}
+			return true
+		}
+	}
+	return false
+}
+
+// enqueue appends the update to the lane of its priority, or to the higher lane holding a
+// pending update of its key. The pending updates of its key in the lower lanes are promoted
+// to its lane, so that the updates of a key are always handled in order.
+func (q *updateQueue[K, V]) enqueue(update Update[K, V], priority Priority) {
+	lane := int(priority)
+	for i := lane + 1; i < len(q.lanes); i++ {
+		if slices.ContainsFunc(q.lanes[i], func(u Update[K, V]) bool { return u.Key == update.Key }) {
+			lane = i
+		}
+	}
+	for i := 0; i < lane; i++ {
+		kept := q.lanes[i][:0]
+		for _, u := range q.lanes[i] {
+			if u.Key == update.Key {
+				q.lanes[lane] = append(q.lanes[lane], u)
+			} else {
+				kept = append(kept, u)
+			}
+		}
+		clear(q.lanes[i][len(kept):])
+		q.lanes[i] = kept
+	}
+	q.lanes[lane] = append(q.lanes[lane], update)
+}
+
+// pop removes the oldest update of the highest priority from the queue, waiting for one to
+// be pushed or for the queue to be woken. It returns the number of updates still pending, and
+// whether the update is handled ahead of pending updates of a lower priority, or whether the
+// queue was woken instead. It returns false once the queue is closed and empty.
+func (q *updateQueue[K, V]) pop() (update Update[K, V], depth int, ahead, woken, ok bool) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	for q.len() == 0 && !q.woken {
+		if q.closed {
+			return Update[K, V]{}, 0, false, false, false
+		}
+		q.cond.Wait()
+	}
+	if q.woken {
+		q.woken = false
+		return Update[K, V]{}, q.len(), false, true, true
+	}
+	for i := len(q.lanes) - 1; i >= 0; i-- {
+		if len(q.lanes[i]) == 0 {
+			continue
+		}
+		update = q.lanes[i][0]
+		clear(q.lanes[i][:1])
+		q.lanes[i] = q.lanes[i][1:]
+		for _, lower := range q.lanes[:i] {
+			ahead = ahead || len(lower) > 0
+		}
+		break
+	}
+	q.cond.Broadcast()
+	return update, q.len(), ahead, false, true
+}
+
+// wake wakes the queue, so that the retries of the subscription which are due are made
+// in between the pending updates.
+func (q *updateQueue[K, V]) wake() {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	q.woken = true
+	q.cond.Broadcast()
+}
+
+// close closes the queue once the subscription is closed, leaving the pending updates to be
+// popped.
+func (q *updateQueue[K, V]) close() {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	q.closed = true
+	q.cond.Broadcast()
+}
+
+// handleQueued calls the given function for each update of the subscription, passing the
+// updates through the queue, which the subscription is read into as soon as the updates are
+// stored. While a bounded queue blocks, the subscription is not read, and the watchable map
+// accumulates the updates stored meanwhile in the snapshot it delivers next. The updates are numbered
+// with the generations of their key when read. The priority of the updates is returned by
+// the priority function, if any, which is called in the order of the updates by a single
+// goroutine. The failing updates are retried by the retrier, if any, when it wakes the queue.
+func handleQueued[K comparable, V any](
+	meta Metadata,
+	subscription <-chan watchable.Snapshot[K, V],
+	q egv1a1.WatchableQueue,
+	gens generations[K, V],
+	priority func(update Update[K, V]) Priority,
+	handle func(update Update[K, V]),
+	retries *retrier[K, V],
+) {
+	uq := newUpdateQueue[K, V](q)
+	if retries != nil {
+		done := make(chan struct{})
+		defer close(done)
+		go func() {
+			for {
+				select {
+				case <-retries.ready:
+					uq.wake()
+				case <-done:
+					return
+				}
+			}
+		}()
+	}
+	go func() {
+		defer uq.close()
+		for snapshot := range subscription {
+			for _, u := range snapshot.Updates {
+				update := gens.next(u)
+				p := PriorityNormal
+				if priority != nil {
+					p = priority(update)
+				}
+				dropped, coalesced := uq.push(update, p)
+				if dropped {
+					watchableQueueDroppedTotal.With(meta.LabelValues()...).Increment()
+				}
+				if coalesced {
+					watchableQueueCoalescedTotal.With(meta.LabelValues()...).Increment()
+				}
+			}
+		}
+	}()
+
+	for {
+		update, depth, ahead, woken, ok := uq.pop()
+		if !ok {
+			return
+		}
+		if woken {
+			retries.retryDue()
+			continue
+		}
+		watchableDepth.With(meta.LabelValues()...).Record(float64(depth))
+		if ahead {
+			watchableQueuePrioritizedTotal.With(meta.LabelValues()...).Increment()
+		}
+		handle(update)
+	}
+}
@@ -0,0 +1,364 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package message
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/telepresenceio/watchable"
+	"k8s.io/utils/ptr"
+
+	egv1a1 "github.com/envoyproxy/gateway/api/v1alpha1"
+)
+
+func TestUpdateQueueOverflow(t *testing.T) {
+	testCases := []struct {
+		name          string
+		policy        egv1a1.WatchableOverflowPolicy
+		expected      []Update[string, int]
+		wantDropped   int
+		wantCoalesced int
+	}{
+		{
+			name:   "drop oldest",
+			policy: egv1a1.WatchableOverflowPolicyDropOldest,
+			expected: []Update[string, int]{
+				{Key: "a", Value: 2},
+				{Key: "b", Value: 2},
+			},
+			wantDropped: 2,
+		},
+		{
+			name:   "coalesce by key",
+			policy: egv1a1.WatchableOverflowPolicyCoalesceByKey,
+			expected: []Update[string, int]{
+				{Key: "a", Value: 2},
+				{Key: "b", Value: 2},
+			},
+			wantCoalesced: 2,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			q := newUpdateQueue[string, int](egv1a1.WatchableQueue{
+				Capacity:       2,
+				OverflowPolicy: ptr.To(tc.policy),
+			})
+			dropped, coalesced := 0, 0
+			for _, u := range []Update[string, int]{
+				{Key: "a", Value: 1},
+				{Key: "b", Value: 1},
+				{Key: "a", Value: 2},
+				{Key: "b", Value: 2},
+			} {
+				d, c := q.push(u, PriorityNormal)
+				if d {
+					dropped++
+				}
+				if c {
+					coalesced++
+				}
+			}
+			q.close()
+
+			var got []Update[string, int]
+			for {
+				u, _, _, _, ok := q.pop()
+				if !ok {
+					break
+				}
+				got = append(got, u)
+			}
+			assert.Equal(t, tc.expected, got)
+			assert.Equal(t, tc.wantDropped, dropped)
+			assert.Equal(t, tc.wantCoalesced, coalesced)
+		})
+	}
+}
+
+func TestUpdateQueueDropOldestKeepsLatest(t *testing.T) {
+	q := newUpdateQueue[string, int](egv1a1.WatchableQueue{
+		Capacity:       2,
+		OverflowPolicy: ptr.To(egv1a1.WatchableOverflowPolicyDropOldest),
+	})
+	q.push(Update[string, int]{Key: "a", Delete: true}, PriorityNormal)
+	q.push(Update[string, int]{Key: "b", Value: 1}, PriorityNormal)
+	// The update superseded by the pushed update of its key is dropped.
+	dropped, _ := q.push(Update[string, int]{Key: "b", Value: 2}, PriorityNormal)
+	assert.True(t, dropped)
+
+	// The queue blocks once it holds only deletions and the single update of a key, which
+	// would be lost if dropped.
+	pushed := make(chan struct{})
+	go func() {
+		q.push(Update[string, int]{Key: "c", Value: 1}, PriorityNormal)
+		close(pushed)
+	}()
+	select {
+	case <-pushed:
+		t.Fatal("push did not block on the queue full of the latest updates")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	u, _, _, _, ok := q.pop()
+	require.True(t, ok)
+	assert.Equal(t, Update[string, int]{Key: "a", Delete: true}, u)
+	<-pushed
+	q.close()
+
+	var got []Update[string, int]
+	for {
+		u, _, _, _, ok := q.pop()
+		if !ok {
+			break
+		}
+		got = append(got, u)
+	}
+	assert.Equal(t, []Update[string, int]{
+		{Key: "b", Value: 2},
+		{Key: "c", Value: 1},
+	}, got)
+}
+
+func TestUpdateQueueCoalesce(t *testing.T) {
+	q := newUpdateQueue[string, int](egv1a1.WatchableQueue{Coalesce: ptr.To(true)})
+	coalesced := 0
+	for _, u := range []Update[string, int]{
+		{Key: "a", Value: 1, Generation: 1},
+		{Key: "b", Value: 1, Generation: 1},
+		{Key: "a", Value: 2, Generation: 2},
+		{Key: "a", Value: 3, Generation: 3},
+		{Key: "b", Delete: true, Generation: 2},
+	} {
+		if _, c := q.push(u, PriorityNormal); c {
+			coalesced++
+		}
+	}
+	q.close()
+
+	var got []Update[string, int]
+	for {
+		u, _, _, _, ok := q.pop()
+		if !ok {
+			break
+		}
+		got = append(got, u)
+	}
+	assert.Equal(t, []Update[string, int]{
+		{Key: "a", Value: 3, Generation: 3},
+		{Key: "b", Delete: true, Generation: 2},
+	}, got)
+	assert.Equal(t, 3, coalesced)
+}
+
+func TestUpdateQueueBlock(t *testing.T) {
+	q := newUpdateQueue[string, int](egv1a1.WatchableQueue{Capacity: 1})
+	q.push(Update[string, int]{Key: "a", Value: 1}, PriorityNormal)
+
+	pushed := make(chan struct{})
+	go func() {
+		q.push(Update[string, int]{Key: "a", Value: 2}, PriorityNormal)
+		close(pushed)
+	}()
+
+	select {
+	case <-pushed:
+		t.Fatal("push did not block on the full queue")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	u, _, _, _, ok := q.pop()
+	require.True(t, ok)
+	assert.Equal(t, 1, u.Value)
+	<-pushed
+	u, depth, _, _, ok := q.pop()
+	require.True(t, ok)
+	assert.Equal(t, 2, u.Value)
+	assert.Equal(t, 0, depth)
+}
+
+func TestUpdateQueuePriority(t *testing.T) {
+	testCases := []struct {
+		name      string
+		queue     egv1a1.WatchableQueue
+		pushes    []Update[string, int]
+		high      map[string]bool
+		expected  []Update[string, int]
+		wantAhead int
+	}{
+		{
+			name: "high priority first",
+			pushes: []Update[string, int]{
+				{Key: "route", Value: 1},
+				{Key: "secret", Value: 1},
+				{Key: "route", Value: 2},
+				{Key: "secret", Value: 2},
+			},
+			high: map[string]bool{"secret": true},
+			expected: []Update[string, int]{
+				{Key: "secret", Value: 1},
+				{Key: "secret", Value: 2},
+				{Key: "route", Value: 1},
+				{Key: "route", Value: 2},
+			},
+			wantAhead: 2,
+		},
+		{
+			name: "pending updates of the key promoted",
+			pushes: []Update[string, int]{
+				{Key: "a", Value: 1},
+				{Key: "b", Value: 1},
+				{Key: "a", Value: 2},
+				{Key: "a", Value: 3},
+			},
+			// Only the last update of a is of high priority.
+			high: map[string]bool{"a3": true},
+			expected: []Update[string, int]{
+				{Key: "a", Value: 1},
+				{Key: "a", Value: 2},
+				{Key: "a", Value: 3},
+				{Key: "b", Value: 1},
+			},
+			wantAhead: 3,
+		},
+		{
+			name:  "drop oldest superseded of the lowest priority",
+			queue: egv1a1.WatchableQueue{Capacity: 3, OverflowPolicy: ptr.To(egv1a1.WatchableOverflowPolicyDropOldest)},
+			pushes: []Update[string, int]{
+				{Key: "secret", Value: 1},
+				{Key: "secret", Value: 2},
+				{Key: "route", Value: 1},
+				{Key: "route", Value: 2},
+			},
+			high: map[string]bool{"secret": true},
+			expected: []Update[string, int]{
+				{Key: "secret", Value: 1},
+				{Key: "secret", Value: 2},
+				{Key: "route", Value: 2},
+			},
+			wantAhead: 2,
+		},
+		{
+			name:  "coalesce by key promoted",
+			queue: egv1a1.WatchableQueue{Capacity: 2, OverflowPolicy: ptr.To(egv1a1.WatchableOverflowPolicyCoalesceByKey)},
+			pushes: []Update[string, int]{
+				{Key: "a", Value: 1},
+				{Key: "b", Value: 1},
+				{Key: "a", Value: 2},
+			},
+			high: map[string]bool{"a2": true},
+			expected: []Update[string, int]{
+				{Key: "a", Value: 2},
+				{Key: "b", Value: 1},
+			},
+			wantAhead: 1,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			q := newUpdateQueue[string, int](tc.queue)
+			for _, u := range tc.pushes {
+				priority := PriorityNormal
+				if tc.high[u.Key] || tc.high[fmt.Sprintf("%s%d", u.Key, u.Value)] {
+					priority = PriorityHigh
+				}
+				q.push(u, priority)
+			}
+			q.close()
+
+			var got []Update[string, int]
+			ahead := 0
+			for {
+				u, _, a, _, ok := q.pop()
+				if !ok {
+					break
+				}
+				got = append(got, u)
+				if a {
+					ahead++
+				}
+			}
+			assert.Equal(t, tc.expected, got)
+			assert.Equal(t, tc.wantAhead, ahead)
+		})
+	}
+}
+
+func TestHandleSubscriptionBounded(t *testing.T) {
+	SetQueues(&egv1a1.EnvoyGatewayWatchable{
+		Queues: []egv1a1.WatchableQueue{{
+			Message:        "bounded",
+			Capacity:       1,
+			OverflowPolicy: ptr.To(egv1a1.WatchableOverflowPolicyCoalesceByKey),
+		}},
+	})
+	t.Cleanup(func() { SetQueues(nil) })
+
+	var m watchable.Map[string, int]
+	m.Store("foo", 0)
+
+	var values []int
+	HandleSubscription(Metadata{Runner: "demo", Message: "bounded"}, m.Subscribe(context.Background()),
+		func(update Update[string, int], errChans chan error) {
+			values = append(values, update.Value)
+			switch update.Value {
+			case 0:
+				// Store the updates while the initial value is handled, so that they may
+				// be coalesced in the full queue.
+				for i := 1; i <= 3; i++ {
+					m.Store("foo", i)
+				}
+			case 3:
+				m.Close()
+			}
+		})
+	assert.Equal(t, 0, values[0])
+	assert.Equal(t, 3, values[len(values)-1])
+	assert.IsIncreasing(t, values)
+}
+
+func TestHandleSubscriptionCoalesced(t *testing.T) {
+	SetQueues(&egv1a1.EnvoyGatewayWatchable{
+		Queues: []egv1a1.WatchableQueue{{
+			Message:  "coalesced",
+			Coalesce: ptr.To(true),
+		}},
+	})
+	t.Cleanup(func() { SetQueues(nil) })
+
+	var m watchable.Map[string, int]
+	m.Store("foo", 0)
+
+	var got []Update[string, int]
+	HandleSubscription(Metadata{Runner: "demo", Message: "coalesced"}, m.Subscribe(context.Background()),
+		func(update Update[string, int], errChans chan error) {
+			got = append(got, update)
+			switch {
+			case update.Value == 0:
+				// Store a burst of updates while the initial value is handled, and wait
+				// for them to be coalesced in the queue.
+				for i := 1; i <= 3; i++ {
+					m.Store("foo", i)
+				}
+				m.Store("bar", 1)
+				time.Sleep(100 * time.Millisecond)
+			case update.Key == "bar":
+				m.Close()
+			}
+		})
+	assert.Equal(t, []Update[string, int]{
+		{Key: "foo", Value: 0, Generation: 1, Replayed: true},
+		{Key: "foo", Value: 3, Generation: 4},
+		{Key: "bar", Value: 1, Generation: 1},
+	}, got)
+}
@@ -0,0 +1,363 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package message
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+
+	egv1a1 "github.com/envoyproxy/gateway/api/v1alpha1"
+)
+
+const (
+	defaultRetryMaxAttempts        = 3
+	defaultRetryBackoff            = 100 * time.Millisecond
+	defaultRetryMaxBackoff         = 10 * time.Second
+	defaultRetryDeadLetterCapacity = 100
+)
+
+var (
+	retriesMu sync.RWMutex
+	// retries holds the retry policies of the subscriptions, by message.
+	retries map[string]retryPolicy
+
+	deadLettersMu sync.Mutex
+	// deadLetters holds the updates dead-lettered by the subscriptions, oldest first, by
+	// subscription.
+	deadLetters = make(map[Metadata][]DeadLetter)
+)
+
+// retryPolicy is how the updates of a message failing to be handled are retried.
+type retryPolicy struct {
+	maxAttempts        int
+	backoff            time.Duration
+	maxBackoff         time.Duration
+	deadLetterCapacity int
+}
+
+// SetRetries retries the failing updates of the messages handled from then on, by message.
+// The failing updates of the messages not listed are not retried.
+func SetRetries(watchable *egv1a1.EnvoyGatewayWatchable) {
+	retriesMu.Lock()
+	defer retriesMu.Unlock()
+
+	retries = make(map[string]retryPolicy)
+	if watchable == nil {
+		return
+	}
+	for _, r := range watchable.Retries {
+		policy := retryPolicy{
+			maxAttempts:        defaultRetryMaxAttempts,
+			backoff:            defaultRetryBackoff,
+			maxBackoff:         defaultRetryMaxBackoff,
+			deadLetterCapacity: defaultRetryDeadLetterCapacity,
+		}
+		if r.MaxAttempts != nil {
+			policy.maxAttempts = int(*r.MaxAttempts)
+		}
+		// The durations are validated with the configuration.
+		if r.Backoff != nil {
+			policy.backoff, _ = time.ParseDuration(string(*r.Backoff))
+		}
+		if r.MaxBackoff != nil {
+			policy.maxBackoff, _ = time.ParseDuration(string(*r.MaxBackoff))
+		}
+		if r.DeadLetterCapacity != nil {
+			policy.deadLetterCapacity = int(*r.DeadLetterCapacity)
+		}
+		retries[r.Message] = policy
+	}
+}
+
+// retryFor returns the retry policy of the subscriptions of the message, if any.
+func retryFor(message string) (retryPolicy, bool) {
+	retriesMu.RLock()
+	defer retriesMu.RUnlock()
+
+	r, ok := retries[message]
+	return r, ok
+}
+
+// DeadLetter is an update a subscriber failed to handle after the last attempt.
+type DeadLetter struct {
+	Runner  string `json:"runner"`
+	Message string `json:"message"`
+	// Key is the key of the update, formatted as a string.
+	Key        string    `json:"key"`
+	Delete     bool      `json:"delete,omitempty"`
+	Generation uint64    `json:"generation"`
+	Attempts   int       `json:"attempts"`
+	Error      string    `json:"error"`
+	Time       time.Time `json:"time"`
+}
+
+// DeadLetters returns the updates dead-lettered by the subscriptions, oldest first.
+func DeadLetters() []DeadLetter {
+	deadLettersMu.Lock()
+	defer deadLettersMu.Unlock()
+
+	var letters []DeadLetter
+	for _, l := range deadLetters {
+		letters = append(letters, l...)
+	}
+	slices.SortStableFunc(letters, func(a, b DeadLetter) int {
+		return a.Time.Compare(b.Time)
+	})
+	return letters
+}
+
+// addDeadLetter holds the dead-lettered update in the buffer of its subscription, evicting
+// the oldest ones beyond the capacity.
+func addDeadLetter(meta Metadata, letter DeadLetter, capacity int) {
+	deadLettersMu.Lock()
+	defer deadLettersMu.Unlock()
+
+	letters := append(deadLetters[meta], letter)
+	if len(letters) > capacity {
+		letters = slices.Clone(letters[len(letters)-capacity:])
+	}
+	deadLetters[meta] = letters
+}
+
+// retryAttempt is an attempt to handle an update, once its backoff elapsed.
+type retryAttempt[K comparable, V any] struct {
+	update Update[K, V]
+	// attempt is the number of the attempt, the first attempt being numbered 1.
+	attempt int
+	// backoff is how long the next attempt waits if this one fails.
+	backoff time.Duration
+	timer   *time.Timer
+}
+
+// retrier handles the updates of a subscription, retrying the failing updates without
+// blocking the subscription: the attempts whose backoff elapsed are made by the
+// subscription in between its updates, unless an update of their key was received since,
+// and the update is dead-lettered once the attempts are exhausted. The attempts pending
+// when the subscription ends are abandoned.
+type retrier[K comparable, V any] struct {
+	meta   Metadata
+	policy retryPolicy
+	handle func(update Update[K, V], errChans chan error)
+	errs   *attemptErrors
+	// ready is signaled once an attempt is due.
+	ready chan struct{}
+
+	mu sync.Mutex
+	// pending holds the attempt waiting for its backoff, by key.
+	pending map[K]*retryAttempt[K, V]
+	// due holds the attempts whose backoff elapsed, in the order they elapsed.
+	due     []*retryAttempt[K, V]
+	stopped bool
+}
+
+func newRetrier[K comparable, V any](
+	meta Metadata,
+	policy retryPolicy,
+	handle func(update Update[K, V], errChans chan error),
+	errChans chan error,
+) *retrier[K, V] {
+	return &retrier[K, V]{
+		meta:    meta,
+		policy:  policy,
+		handle:  handle,
+		errs:    newAttemptErrors(errChans),
+		ready:   make(chan struct{}, 1),
+		pending: make(map[K]*retryAttempt[K, V]),
+	}
+}
+
+// handleUpdate makes the first attempt to handle the update received by the subscription,
+// superseding the attempt pending for its key, if any.
+func (r *retrier[K, V]) handleUpdate(update Update[K, V]) {
+	r.mu.Lock()
+	if pending, ok := r.pending[update.Key]; ok {
+		pending.timer.Stop()
+		delete(r.pending, update.Key)
+	}
+	r.mu.Unlock()
+
+	r.attempt(&retryAttempt[K, V]{update: update, attempt: 1, backoff: r.policy.backoff})
+}
+
+// retryDue makes the attempts whose backoff elapsed and which weren't superseded since.
+func (r *retrier[K, V]) retryDue() {
+	r.mu.Lock()
+	due := r.due
+	r.due = nil
+	r.mu.Unlock()
+
+	for _, a := range due {
+		r.mu.Lock()
+		current := r.pending[a.update.Key] == a
+		if current {
+			delete(r.pending, a.update.Key)
+		}
+		r.mu.Unlock()
+		if current {
+			r.attempt(a)
+		}
+	}
+}
+
+// attempt makes the attempt, and schedules the next attempt if it fails, or dead-letters
+// the update once the attempts are exhausted.
+func (r *retrier[K, V]) attempt(a *retryAttempt[K, V]) {
+	err := attemptUpdate(a.update, r.handle, r.errs)
+	if err == nil {
+		return
+	}
+	if a.attempt >= r.policy.maxAttempts {
+		logger.WithValues("runner", r.meta.Runner, "message", r.meta.Message).Error(err,
+			"dead-lettered an update", "key", a.update.Key, "attempts", a.attempt)
+		addDeadLetter(r.meta, DeadLetter{
+			Runner:     r.meta.Runner,
+			Message:    r.meta.Message,
+			Key:        fmt.Sprint(a.update.Key),
+			Delete:     a.update.Delete,
+			Generation: a.update.Generation,
+			Attempts:   a.attempt,
+			Error:      err.Error(),
+			Time:       time.Now(),
+		}, r.policy.deadLetterCapacity)
+		watchableDeadLetteredTotal.With(r.meta.LabelValues()...).Increment()
+		return
+	}
+	watchableSubscribeRetriesTotal.With(r.meta.LabelValues()...).Increment()
+
+	next := &retryAttempt[K, V]{
+		update:  a.update,
+		attempt: a.attempt + 1,
+		backoff: min(2*a.backoff, r.policy.maxBackoff),
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.stopped {
+		return
+	}
+	next.timer = time.AfterFunc(a.backoff, func() { r.elapse(next) })
+	r.pending[a.update.Key] = next
+}
+
+// elapse queues the attempt whose backoff elapsed, unless superseded, and signals the
+// subscription.
+func (r *retrier[K, V]) elapse(a *retryAttempt[K, V]) {
+	r.mu.Lock()
+	if r.pending[a.update.Key] != a {
+		r.mu.Unlock()
+		return
+	}
+	r.due = append(r.due, a)
+	r.mu.Unlock()
+
+	select {
+	case r.ready <- struct{}{}:
+	default:
+	}
+}
+
+// stop abandons the pending attempts once the subscription ended, and stops telling the
+// errors of the attempts apart.
+func (r *retrier[K, V]) stop() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.stopped {
+		return
+	}
+	r.stopped = true
+	close(r.errs.done)
+	for _, a := range r.pending {
+		a.timer.Stop()
+	}
+	clear(r.pending)
+	r.due = nil
+}
+
+// errAttemptStarted and errAttemptDone mark the start and the end of an attempt in the
+// errors reported to attemptErrors.
+var (
+	errAttemptStarted = errors.New("attempt started")
+	errAttemptDone    = errors.New("attempt done")
+)
+
+// attemptErrors tells the errors reported by each attempt apart, forwarding all the errors
+// to the errors of the subscription until the subscription ended. Its channel is not closed,
+// so that sending the errors reported once an attempt returned doesn't panic: they are
+// forwarded, but only attributed to the attempt in progress, if any. The handlers don't report
+// errors once the subscription ended, since nothing receives them anymore.
+type attemptErrors struct {
+	errs chan error
+	// result receives the first error reported by each attempt once it is done.
+	result chan error
+	// done is closed once the subscription ended.
+	done chan struct{}
+}
+
+func newAttemptErrors(errChans chan error) *attemptErrors {
+	a := &attemptErrors{
+		errs:   make(chan error),
+		result: make(chan error),
+		done:   make(chan struct{}),
+	}
+	go func() {
+		var (
+			attempting bool
+			reported   error
+		)
+		for {
+			var err error
+			select {
+			case <-a.done:
+				return
+			case err = <-a.errs:
+			}
+			switch {
+			case errors.Is(err, errAttemptStarted):
+				attempting, reported = true, nil
+			case errors.Is(err, errAttemptDone):
+				attempting = false
+				a.result <- reported
+			default:
+				if attempting && reported == nil {
+					reported = err
+				}
+				errChans <- err
+			}
+		}
+	}()
+	return a
+}
+
+// attemptUpdate calls the given function with the update, and returns the first error it
+// reports, or the value it panics with. The errors the function reports once it returned are
+// forwarded to the errors of the subscription, but not returned.
+func attemptUpdate[K comparable, V any](
+	update Update[K, V],
+	handle func(update Update[K, V], errChans chan error),
+	errs *attemptErrors,
+) error {
+	errs.errs <- errAttemptStarted
+	panicked := func() (err error) {
+		defer func() {
+			if r := recover(); r != nil {
+				err = fmt.Errorf("handler panicked: %v", r)
+			}
+		}()
+		handle(update, errs.errs)
+		return nil
+	}()
+	errs.errs <- errAttemptDone
+	reported := <-errs.result
+
+	if panicked != nil {
+		errs.errs <- panicked
+		return panicked
+	}
+	return reported
+}
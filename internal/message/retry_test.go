@@ -0,0 +1,124 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package message
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/telepresenceio/watchable"
+	"go.uber.org/goleak"
+	"k8s.io/utils/ptr"
+	gwapiv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	egv1a1 "github.com/envoyproxy/gateway/api/v1alpha1"
+)
+
+func TestHandleSubscriptionRetried(t *testing.T) {
+	// The goroutine reporting the errors of the subscription outlives it, since the handlers
+	// may still report errors.
+	defer goleak.VerifyNone(t, goleak.IgnoreCurrent(),
+		goleak.IgnoreAnyFunction("github.com/envoyproxy/gateway/internal/message.ReportErrors"))
+
+	SetRetries(&egv1a1.EnvoyGatewayWatchable{
+		Retries: []egv1a1.WatchableRetry{{
+			Message:            "retried",
+			MaxAttempts:        ptr.To[uint32](3),
+			Backoff:            ptr.To(gwapiv1.Duration("1ms")),
+			DeadLetterCapacity: ptr.To[uint32](1),
+		}},
+	})
+	t.Cleanup(func() { SetRetries(nil) })
+
+	var m watchable.Map[string, int]
+	m.Store("errors-once", 0)
+
+	meta := Metadata{Runner: "demo", Message: "retried"}
+	attempts := make(map[string]int)
+	HandleSubscription(meta, m.Subscribe(context.Background()),
+		func(update Update[string, int], errChans chan error) {
+			attempts[update.Key]++
+			switch update.Key {
+			case "errors-once":
+				if attempts[update.Key] == 1 {
+					errChans <- errors.New("transient")
+					return
+				}
+				m.Store("errors", 0)
+			case "errors":
+				errChans <- errors.New("persistent")
+				if attempts[update.Key] == 3 {
+					m.Store("panics", 0)
+				}
+			case "panics":
+				if attempts[update.Key] == 3 {
+					m.Close()
+				}
+				panic("boom")
+			}
+		})
+	assert.Equal(t, map[string]int{"errors-once": 2, "errors": 3, "panics": 3}, attempts)
+
+	// The oldest update is evicted from the dead-letter buffer of the subscription.
+	letters := DeadLetters()
+	require.Len(t, letters, 1)
+	assert.Equal(t, "panics", letters[0].Key)
+	assert.Equal(t, 3, letters[0].Attempts)
+	assert.Equal(t, "handler panicked: boom", letters[0].Error)
+}
+
+func TestHandleSubscriptionRetriedWithoutBlocking(t *testing.T) {
+	SetRetries(&egv1a1.EnvoyGatewayWatchable{
+		Retries: []egv1a1.WatchableRetry{{
+			Message:     "retried",
+			MaxAttempts: ptr.To[uint32](3),
+			Backoff:     ptr.To(gwapiv1.Duration("1h")),
+		}},
+	})
+	t.Cleanup(func() { SetRetries(nil) })
+
+	var m watchable.Map[string, int]
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	meta := Metadata{Runner: "demo", Message: "retried"}
+	handled := make(chan Update[string, int])
+	late := make(chan chan error, 1)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		HandleSubscription(meta, m.Subscribe(ctx),
+			func(update Update[string, int], errChans chan error) {
+				if update.Key == "fails" && update.Value == 0 {
+					errChans <- errors.New("transient")
+					late <- errChans
+				}
+				handled <- update
+			})
+	}()
+
+	// The updates of the other keys are handled while the failing update waits for its
+	// backoff.
+	m.Store("fails", 0)
+	assert.Equal(t, 0, (<-handled).Value)
+	m.Store("other", 1)
+	assert.Equal(t, "other", (<-handled).Key)
+
+	// The errors reported once the attempt returned are forwarded without panicking.
+	(<-late) <- errors.New("late")
+
+	// A newer update of the key supersedes its pending retry.
+	m.Store("fails", 1)
+	update := <-handled
+	assert.Equal(t, "fails", update.Key)
+	assert.Equal(t, 1, update.Value)
+
+	cancel()
+	<-done
+}
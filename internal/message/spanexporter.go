@@ -0,0 +1,119 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package message
+
+import (
+	"context"
+	"fmt"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	collectortracev1 "go.opentelemetry.io/proto/otlp/collector/trace/v1"
+	commonv1 "go.opentelemetry.io/proto/otlp/common/v1"
+	resourcev1 "go.opentelemetry.io/proto/otlp/resource/v1"
+	tracev1 "go.opentelemetry.io/proto/otlp/trace/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+// spanExporter exports the spans of the updates to an OpenTelemetry collector over OTLP/gRPC.
+type spanExporter struct {
+	client collectortracev1.TraceServiceClient
+	conn   *grpc.ClientConn
+}
+
+var _ sdktrace.SpanExporter = (*spanExporter)(nil)
+
+// newSpanExporter returns the exporter of the spans to the collector at the address.
+func newSpanExporter(address string) (*spanExporter, error) {
+	conn, err := grpc.NewClient(address, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to the watchable tracing collector: %w", err)
+	}
+	return &spanExporter{client: collectortracev1.NewTraceServiceClient(conn), conn: conn}, nil
+}
+
+func (e *spanExporter) ExportSpans(ctx context.Context, spans []sdktrace.ReadOnlySpan) error {
+	if len(spans) == 0 {
+		return nil
+	}
+
+	// The spans are all recorded by the tracer provider of the messages, so they share
+	// its resource, and are grouped by the scope of their tracer.
+	var scopes []*tracev1.ScopeSpans
+	byScope := make(map[string]*tracev1.ScopeSpans)
+	for _, span := range spans {
+		name := span.InstrumentationScope().Name
+		scope, ok := byScope[name]
+		if !ok {
+			scope = &tracev1.ScopeSpans{Scope: &commonv1.InstrumentationScope{Name: name}}
+			byScope[name] = scope
+			scopes = append(scopes, scope)
+		}
+		scope.Spans = append(scope.Spans, otlpSpan(span))
+	}
+
+	_, err := e.client.Export(ctx, &collectortracev1.ExportTraceServiceRequest{
+		ResourceSpans: []*tracev1.ResourceSpans{{
+			Resource:   &resourcev1.Resource{Attributes: otlpAttributes(spans[0].Resource().Attributes())},
+			ScopeSpans: scopes,
+		}},
+	})
+	return err
+}
+
+func (e *spanExporter) Shutdown(context.Context) error {
+	return e.conn.Close()
+}
+
+// otlpSpan returns the OTLP span of the recorded span.
+func otlpSpan(span sdktrace.ReadOnlySpan) *tracev1.Span {
+	sc := span.SpanContext()
+	traceID, spanID := sc.TraceID(), sc.SpanID()
+	s := &tracev1.Span{
+		TraceId:           traceID[:],
+		SpanId:            spanID[:],
+		TraceState:        sc.TraceState().String(),
+		Name:              span.Name(),
+		Kind:              tracev1.Span_SpanKind(span.SpanKind()),
+		StartTimeUnixNano: uint64(span.StartTime().UnixNano()),
+		EndTimeUnixNano:   uint64(span.EndTime().UnixNano()),
+		Attributes:        otlpAttributes(span.Attributes()),
+		Status:            &tracev1.Status{Message: span.Status().Description},
+	}
+	if parent := span.Parent(); parent.IsValid() {
+		parentID := parent.SpanID()
+		s.ParentSpanId = parentID[:]
+	}
+	switch span.Status().Code {
+	case codes.Ok:
+		s.Status.Code = tracev1.Status_STATUS_CODE_OK
+	case codes.Error:
+		s.Status.Code = tracev1.Status_STATUS_CODE_ERROR
+	}
+	return s
+}
+
+// otlpAttributes returns the OTLP attributes of the attributes of a span.
+func otlpAttributes(attrs []attribute.KeyValue) []*commonv1.KeyValue {
+	kvs := make([]*commonv1.KeyValue, 0, len(attrs))
+	for _, attr := range attrs {
+		kv := &commonv1.KeyValue{Key: string(attr.Key)}
+		switch attr.Value.Type() {
+		case attribute.BOOL:
+			kv.Value = &commonv1.AnyValue{Value: &commonv1.AnyValue_BoolValue{BoolValue: attr.Value.AsBool()}}
+		case attribute.INT64:
+			kv.Value = &commonv1.AnyValue{Value: &commonv1.AnyValue_IntValue{IntValue: attr.Value.AsInt64()}}
+		case attribute.FLOAT64:
+			kv.Value = &commonv1.AnyValue{Value: &commonv1.AnyValue_DoubleValue{DoubleValue: attr.Value.AsFloat64()}}
+		default:
+			kv.Value = &commonv1.AnyValue{Value: &commonv1.AnyValue_StringValue{StringValue: attr.Value.Emit()}}
+		}
+		kvs = append(kvs, kv)
+	}
+	return kvs
+}
@@ -0,0 +1,72 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package message
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+	collectortracev1 "go.opentelemetry.io/proto/otlp/collector/trace/v1"
+	tracev1 "go.opentelemetry.io/proto/otlp/trace/v1"
+	"google.golang.org/grpc"
+)
+
+type traceCollector struct {
+	collectortracev1.UnimplementedTraceServiceServer
+	requests chan *collectortracev1.ExportTraceServiceRequest
+}
+
+func (c *traceCollector) Export(_ context.Context, req *collectortracev1.ExportTraceServiceRequest) (*collectortracev1.ExportTraceServiceResponse, error) {
+	c.requests <- req
+	return &collectortracev1.ExportTraceServiceResponse{}, nil
+}
+
+func TestSpanExporter(t *testing.T) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	collector := &traceCollector{requests: make(chan *collectortracev1.ExportTraceServiceRequest, 1)}
+	server := grpc.NewServer()
+	collectortracev1.RegisterTraceServiceServer(server, collector)
+	go func() { _ = server.Serve(l) }()
+	defer server.Stop()
+
+	exporter, err := newSpanExporter(l.Addr().String())
+	require.NoError(t, err)
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+	ctx, parent := tp.Tracer(tracerName).Start(context.Background(), "provider provider-resources")
+	_, child := tp.Tracer(tracerName).Start(ctx, "gateway-api provider-resources",
+		trace.WithSpanKind(trace.SpanKindConsumer),
+		trace.WithAttributes(attribute.String("key", "eg"), attribute.Int64("generation", 2)))
+	child.SetStatus(codes.Error, "failed to translate")
+	child.End()
+
+	req := <-collector.requests
+	require.Len(t, req.ResourceSpans, 1)
+	require.Len(t, req.ResourceSpans[0].ScopeSpans, 1)
+	require.Equal(t, tracerName, req.ResourceSpans[0].ScopeSpans[0].Scope.Name)
+	require.Len(t, req.ResourceSpans[0].ScopeSpans[0].Spans, 1)
+	span := req.ResourceSpans[0].ScopeSpans[0].Spans[0]
+	traceID, parentID, spanID := parent.SpanContext().TraceID(), parent.SpanContext().SpanID(), child.SpanContext().SpanID()
+	require.Equal(t, traceID[:], span.TraceId)
+	require.Equal(t, spanID[:], span.SpanId)
+	require.Equal(t, parentID[:], span.ParentSpanId)
+	require.Equal(t, "gateway-api provider-resources", span.Name)
+	require.Equal(t, tracev1.Span_SPAN_KIND_CONSUMER, span.Kind)
+	require.Equal(t, tracev1.Status_STATUS_CODE_ERROR, span.Status.Code)
+	require.Equal(t, "failed to translate", span.Status.Message)
+	require.Len(t, span.Attributes, 2)
+	require.Equal(t, "eg", span.Attributes[0].Value.GetStringValue())
+	require.Equal(t, int64(2), span.Attributes[1].Value.GetIntValue())
+
+	parent.End()
+	require.NoError(t, tp.Shutdown(context.Background()))
+}
@@ -0,0 +1,268 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package message
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"sync"
+
+	"github.com/telepresenceio/watchable"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/trace/noop"
+
+	egv1a1 "github.com/envoyproxy/gateway/api/v1alpha1"
+)
+
+const (
+	defaultTracingPort         = 4317
+	defaultTracingSamplingRate = 100
+
+	tracerName = "envoy-gateway/watchable"
+)
+
+var (
+	tracerProviderMu sync.RWMutex
+	// tracerProvider records the spans of the updates, once set by SetTracing. It is not
+	// the global tracer provider of the process, so that the other instrumented libraries
+	// don't export their spans to the collector of the tracing.
+	tracerProvider trace.TracerProvider = noop.NewTracerProvider()
+)
+
+// SetTracing exports the spans of the updates of the messages handled from then on to the
+// collector of the tracing, and returns the function flushing and stopping the export.
+// The updates are not traced if the tracing is unset.
+func SetTracing(watchable *egv1a1.EnvoyGatewayWatchable) (func(context.Context) error, error) {
+	if watchable == nil || watchable.Tracing == nil {
+		return func(context.Context) error { return nil }, nil
+	}
+
+	port := int32(defaultTracingPort)
+	if watchable.Tracing.Port != nil {
+		port = *watchable.Tracing.Port
+	}
+	rate := uint32(defaultTracingSamplingRate)
+	if watchable.Tracing.SamplingRate != nil {
+		rate = *watchable.Tracing.SamplingRate
+	}
+	exporter, err := newSpanExporter(net.JoinHostPort(watchable.Tracing.Host, fmt.Sprint(port)))
+	if err != nil {
+		return nil, err
+	}
+
+	tp := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		// The updates follow the sampling decision of the event they originate from.
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(float64(rate)/100))),
+		sdktrace.WithResource(resource.NewSchemaless(semconv.ServiceName("envoy-gateway"))),
+	)
+	SetTracerProvider(tp)
+	return tp.Shutdown, nil
+}
+
+// SetTracerProvider sets the tracer provider the spans of the updates are recorded with,
+// or stops recording them if nil.
+func SetTracerProvider(tp trace.TracerProvider) {
+	tracerProviderMu.Lock()
+	defer tracerProviderMu.Unlock()
+
+	if tp == nil {
+		tp = noop.NewTracerProvider()
+	}
+	tracerProvider = tp
+}
+
+// TracerProvider returns the tracer provider the spans of the updates are recorded with,
+// for the runners to record the spans of the work the updates lead to.
+func TracerProvider() trace.TracerProvider {
+	tracerProviderMu.RLock()
+	defer tracerProviderMu.RUnlock()
+
+	return tracerProvider
+}
+
+// tracer returns the tracer of the spans of the updates.
+func tracer() trace.Tracer {
+	return TracerProvider().Tracer(tracerName)
+}
+
+// StartSpan starts the span of an event of a runner, such as a reconciliation of the
+// provider, which the updates published in the returned context are traced from.
+func StartSpan(ctx context.Context, meta Metadata) (context.Context, trace.Span) {
+	return tracer().Start(ctx, spanName(meta), trace.WithAttributes(meta.attributes()...))
+}
+
+// Traced is a value of a TracedMap, along with the span context it was stored in. The span
+// context isn't part of the value: storing a value equal to the current one is no update of
+// the map, whichever span it is stored in.
+type Traced[V any] struct {
+	Value       V
+	SpanContext trace.SpanContext
+}
+
+func (t Traced[V]) DeepCopy() Traced[V] {
+	return Traced[V]{Value: watchable.DeepCopy(t.Value), SpanContext: t.SpanContext}
+}
+
+func (t Traced[V]) Equal(other Traced[V]) bool {
+	return watchable.DeepEqual(t.Value, other.Value)
+}
+
+// publishedSpanContext returns the span context the value was stored in.
+func (t Traced[V]) publishedSpanContext() trace.SpanContext {
+	return t.SpanContext
+}
+
+// publishedSpan is implemented by the values of the traced maps.
+type publishedSpan interface {
+	publishedSpanContext() trace.SpanContext
+}
+
+// TracedMap is a watchable map whose values carry the span context they were stored in, so
+// that the updates handled by the subscribers of HandleTracedSubscription are traced from
+// the span of their publisher. The deletions are not traced.
+//
+// The zero TracedMap is empty and ready to use.
+type TracedMap[K comparable, V any] struct {
+	m watchable.Map[K, Traced[V]]
+}
+
+// Load returns a deep copy of the value of the key.
+func (t *TracedMap[K, V]) Load(key K) (V, bool) {
+	v, ok := t.m.Load(key)
+	return v.Value, ok
+}
+
+// LoadAll returns a deep copy of the values of the map.
+func (t *TracedMap[K, V]) LoadAll() map[K]V {
+	all := t.m.LoadAll()
+	values := make(map[K]V, len(all))
+	for k, v := range all {
+		values[k] = v.Value
+	}
+	return values
+}
+
+// Len returns the number of keys of the map.
+func (t *TracedMap[K, V]) Len() int {
+	return t.m.Len()
+}
+
+// Store stores the value of the key, whose update isn't traced.
+func (t *TracedMap[K, V]) Store(key K, value V) {
+	t.m.Store(key, Traced[V]{Value: value})
+}
+
+// StoreTraced stores the value of the key, so that the update of the key handled by the
+// subscribers is traced from the span of the context, if any.
+func (t *TracedMap[K, V]) StoreTraced(ctx context.Context, key K, value V) {
+	t.m.Store(key, Traced[V]{Value: value, SpanContext: trace.SpanContextFromContext(ctx)})
+}
+
+// Delete deletes the key from the map.
+func (t *TracedMap[K, V]) Delete(key K) {
+	t.m.Delete(key)
+}
+
+// Close closes the map, ending its subscriptions.
+func (t *TracedMap[K, V]) Close() {
+	t.m.Close()
+}
+
+// Subscribe returns the subscription to the map, handled by HandleTracedSubscription.
+func (t *TracedMap[K, V]) Subscribe(ctx context.Context) <-chan watchable.Snapshot[K, Traced[V]] {
+	return t.m.Subscribe(ctx)
+}
+
+// HandleTracedSubscription is HandleSubscription for the subscription of a TracedMap, the
+// updates of which are traced from the span they were stored in, if any.
+func HandleTracedSubscription[K comparable, V any](
+	meta Metadata,
+	subscription <-chan watchable.Snapshot[K, Traced[V]],
+	handle func(updateFunc Update[K, V], errChans chan error),
+) {
+	HandleTracedSubscriptionWithPriority(meta, subscription, nil, handle)
+}
+
+// HandleTracedSubscriptionWithPriority is HandleSubscriptionWithPriority for the
+// subscription of a TracedMap.
+func HandleTracedSubscriptionWithPriority[K comparable, V any](
+	meta Metadata,
+	subscription <-chan watchable.Snapshot[K, Traced[V]],
+	priority func(update Update[K, V]) Priority,
+	handle func(updateFunc Update[K, V], errChans chan error),
+) {
+	var tracedPriority func(update Update[K, Traced[V]]) Priority
+	if priority != nil {
+		tracedPriority = func(update Update[K, Traced[V]]) Priority {
+			return priority(untraced(update))
+		}
+	}
+	handleSubscription(meta, subscription, tracedPriority, nil, func(update Update[K, Traced[V]], errChans chan error) {
+		handle(untraced(update), errChans)
+	})
+}
+
+// untraced returns the update of the value carried by the update of a TracedMap.
+func untraced[K comparable, V any](update Update[K, Traced[V]]) Update[K, V] {
+	return Update[K, V]{
+		Key:         update.Key,
+		Delete:      update.Delete,
+		Value:       update.Value.Value,
+		Generation:  update.Generation,
+		Replayed:    update.Replayed,
+		SpanContext: update.SpanContext,
+	}
+}
+
+// startUpdateSpan starts the span of the update handled by the subscriber, as a child of
+// the span the value of the update was stored in, if it was traced.
+func startUpdateSpan[K comparable, V any](meta Metadata, update *Update[K, V]) (trace.Span, bool) {
+	// The replayed updates are not changes of the map, and the value of a deletion is the
+	// deleted one.
+	if update.Replayed || update.Delete {
+		return nil, false
+	}
+	published, ok := any(update.Value).(publishedSpan)
+	if !ok {
+		return nil, false
+	}
+	parent := published.publishedSpanContext()
+	if !parent.IsValid() {
+		return nil, false
+	}
+
+	attrs := append(meta.attributes(),
+		attribute.String("key", fmt.Sprint(update.Key)),
+		attribute.Int64("generation", int64(update.Generation)),
+	)
+	_, span := tracer().Start(trace.ContextWithRemoteSpanContext(context.Background(), parent), spanName(meta),
+		trace.WithSpanKind(trace.SpanKindConsumer),
+		trace.WithAttributes(attrs...))
+	update.SpanContext = span.SpanContext()
+	return span, true
+}
+
+// spanName returns the name of the spans of the subscription.
+func spanName(meta Metadata) string {
+	return meta.Runner + " " + meta.Message
+}
+
+// attributes returns the attributes of the spans of the subscription, named after the
+// labels of its metrics.
+func (m Metadata) attributes() []attribute.KeyValue {
+	labels := m.LabelValues()
+	attrs := make([]attribute.KeyValue, 0, len(labels))
+	for _, l := range labels {
+		attrs = append(attrs, l.Attribute())
+	}
+	return attrs
+}
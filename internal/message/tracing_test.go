@@ -0,0 +1,100 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package message_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/envoyproxy/gateway/internal/message"
+)
+
+func TestHandleSubscriptionTraced(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	message.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	t.Cleanup(func() { message.SetTracerProvider(nil) })
+
+	var first, second message.TracedMap[string, string]
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// The first subscriber publishes the updates it handles to the second one. The
+	// subscribers are replayed an initial key once subscribed.
+	first.Store("init", "")
+	second.Store("init", "")
+	replayed := make(chan struct{})
+	go message.HandleTracedSubscription(
+		message.Metadata{Runner: "first", Message: "first-message"},
+		first.Subscribe(ctx),
+		func(update message.Update[string, string], _ chan error) {
+			if update.Replayed {
+				replayed <- struct{}{}
+				return
+			}
+			if update.Delete {
+				second.Delete(update.Key)
+				return
+			}
+			second.StoreTraced(update.Context(), update.Key, update.Value)
+		},
+	)
+	handled := make(chan message.Update[string, string])
+	go message.HandleTracedSubscription(
+		message.Metadata{Runner: "second", Message: "second-message"},
+		second.Subscribe(ctx),
+		func(update message.Update[string, string], _ chan error) { handled <- update },
+	)
+	<-replayed
+	assert.True(t, (<-handled).Replayed)
+
+	// The update published in the span of an event is traced through the subscribers.
+	eventCtx, event := message.StartSpan(context.Background(), message.Metadata{Runner: "provider", Message: "first-message"})
+	first.StoreTraced(eventCtx, "foo", "bar")
+	event.End()
+	update := <-handled
+	assert.Equal(t, "bar", update.Value)
+	require.True(t, update.SpanContext.IsValid())
+	assert.Equal(t, event.SpanContext().TraceID(), update.SpanContext.TraceID())
+
+	// The updates published outside of a span are not traced, even after a traced one.
+	first.StoreTraced(context.Background(), "foo", "baz")
+	update = <-handled
+	assert.Equal(t, "baz", update.Value)
+	assert.False(t, update.SpanContext.IsValid())
+
+	// Storing the current value again in another span is no update, and the deletions are
+	// not traced.
+	_, other := message.StartSpan(context.Background(), message.Metadata{Runner: "provider", Message: "first-message"})
+	first.StoreTraced(trace.ContextWithSpan(context.Background(), other), "foo", "baz")
+	other.End()
+	first.Delete("foo")
+	update = <-handled
+	assert.True(t, update.Delete)
+	assert.False(t, update.SpanContext.IsValid())
+
+	cancel()
+	spans := make(map[string]sdktrace.ReadOnlySpan)
+	for _, span := range recorder.Ended() {
+		if span.SpanContext().TraceID() == event.SpanContext().TraceID() {
+			spans[span.Name()] = span
+		}
+	}
+	require.Len(t, spans, 3)
+	firstSpan, secondSpan := spans["first first-message"], spans["second second-message"]
+	require.NotNil(t, firstSpan)
+	require.NotNil(t, secondSpan)
+	assert.Equal(t, event.SpanContext().SpanID(), firstSpan.Parent().SpanID())
+	assert.Equal(t, firstSpan.SpanContext().SpanID(), secondSpan.Parent().SpanID())
+	assert.Contains(t, secondSpan.Attributes(), attribute.String("runner", "second"))
+	assert.Contains(t, secondSpan.Attributes(), attribute.String("message", "second-message"))
+}
@@ -6,6 +6,9 @@
 package message
 
 import (
+	"slices"
+	"time"
+
 	"github.com/telepresenceio/watchable"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
@@ -22,7 +25,7 @@ import (
 type ProviderResources struct {
 	// GatewayAPIResources is a map from a GatewayClass name to
 	// a group of gateway API and other related resources.
-	GatewayAPIResources watchable.Map[string, *resource.ControllerResources]
+	GatewayAPIResources TracedMap[string, *resource.ControllerResources]
 
 	// GatewayAPIStatuses is a group of gateway api
 	// resource statuses maps.
@@ -33,6 +36,47 @@ type ProviderResources struct {
 
 	// ExtensionStatuses is a group of gw-api extension resource statuses map.
 	ExtensionStatuses
+
+	// InfraErrors is a map from an infra IR key to the error that
+	// occurred while provisioning its infrastructure.
+	InfraErrors watchable.Map[string, *InfraError]
+
+	// PendingListeners is a map from an xds IR key to the listeners that
+	// have not been acknowledged by all its proxies yet.
+	PendingListeners watchable.Map[string, *PendingListeners]
+
+	// SecretRotations is a map from an xds IR key to the secrets whose
+	// rotation is in progress.
+	SecretRotations watchable.Map[string, *SecretRotations]
+
+	// DeferredDrains is a map from an xds IR key to the listeners whose
+	// change drains connections and is deferred to the maintenance window.
+	DeferredDrains watchable.Map[string, *DeferredDrains]
+
+	// XdsNacks is a map from an xds IR key to the xds snapshots rejected
+	// by its proxies.
+	XdsNacks watchable.Map[string, *XdsNacks]
+
+	// XdsInconsistencies is a map from an xds IR key to the inconsistencies
+	// of its last snapshot refused by the snapshot cache.
+	XdsInconsistencies watchable.Map[string, *XdsInconsistencies]
+
+	// XdsDrifts is a map from an xds IR key to its proxies whose acknowledged
+	// configuration stayed behind the configuration they are served.
+	XdsDrifts watchable.Map[string, *XdsDrifts]
+
+	// GatewayDrains is a map from an xds IR key to the drain of the proxies
+	// of its draining Gateway.
+	GatewayDrains watchable.Map[string, *GatewayDrain]
+
+	// XdsCheckpoints is a map from an xds IR key to the checkpoint of the last
+	// configuration acknowledged by its proxies, stored in the infrastructure of
+	// the proxies.
+	XdsCheckpoints watchable.Map[string, *XdsCheckpoint]
+
+	// CanaryRollouts is a map from a BackendTrafficPolicy to the progress of
+	// its canary rollout.
+	CanaryRollouts watchable.Map[types.NamespacedName, *CanaryRollout]
 }
 
 func (p *ProviderResources) GetResources() []*resource.Resources {
@@ -71,6 +115,16 @@ func (p *ProviderResources) Close() {
 	p.GatewayAPIResources.Close()
 	p.GatewayAPIStatuses.Close()
 	p.PolicyStatuses.Close()
+	p.InfraErrors.Close()
+	p.PendingListeners.Close()
+	p.SecretRotations.Close()
+	p.DeferredDrains.Close()
+	p.XdsNacks.Close()
+	p.XdsInconsistencies.Close()
+	p.XdsDrifts.Close()
+	p.GatewayDrains.Close()
+	p.XdsCheckpoints.Close()
+	p.CanaryRollouts.Close()
 }
 
 // GatewayAPIStatuses contains gateway API resources statuses
@@ -122,17 +176,206 @@ func (p *PolicyStatuses) Close() {
 	p.ExtensionPolicyStatuses.Close()
 }
 
+// InfraError is an error that occurred while provisioning the infrastructure
+// of the Gateways of an infra IR.
+type InfraError struct {
+	// Reason is the reason of the Programmed condition of the Gateways.
+	Reason gwapiv1.GatewayConditionReason
+	// Message is the underlying API error.
+	Message string
+}
+
+// DeepCopy returns a copy of the infra error.
+func (e *InfraError) DeepCopy() *InfraError {
+	if e == nil {
+		return nil
+	}
+	out := *e
+	return &out
+}
+
+// PendingListeners holds the listeners of the Gateways of an xds IR that have
+// not been acknowledged by all the proxies yet.
+type PendingListeners struct {
+	// Listeners holds the names of the pending xds listeners.
+	Listeners []string
+}
+
+// DeepCopy returns a copy of the pending listeners.
+func (p *PendingListeners) DeepCopy() *PendingListeners {
+	if p == nil {
+		return nil
+	}
+	return &PendingListeners{Listeners: slices.Clone(p.Listeners)}
+}
+
+// SecretRotations holds the secrets of the Gateways of an xds IR whose rotation
+// has not been acknowledged by all the proxies yet.
+type SecretRotations struct {
+	// Secrets holds the names of the rotated xds secrets.
+	Secrets []string
+}
+
+// DeepCopy returns a copy of the secret rotations.
+func (r *SecretRotations) DeepCopy() *SecretRotations {
+	if r == nil {
+		return nil
+	}
+	return &SecretRotations{Secrets: slices.Clone(r.Secrets)}
+}
+
+// DeferredDrains holds the listeners of the Gateways of an xds IR whose change drains
+// connections, and is deferred until the maintenance window opens.
+type DeferredDrains struct {
+	// Listeners holds the names of the xds listeners whose change drains all their
+	// connections.
+	Listeners []string
+	// FilterChainListeners holds the names of the xds listeners whose change drains the
+	// connections of some of their filter chains.
+	FilterChainListeners []string
+	// Until is when the maintenance window opens.
+	Until time.Time
+}
+
+// DeepCopy returns a copy of the deferred drains.
+func (d *DeferredDrains) DeepCopy() *DeferredDrains {
+	if d == nil {
+		return nil
+	}
+	return &DeferredDrains{
+		Listeners:            slices.Clone(d.Listeners),
+		FilterChainListeners: slices.Clone(d.FilterChainListeners),
+		Until:                d.Until,
+	}
+}
+
+// Has returns true if the change of the listener is deferred.
+func (d *DeferredDrains) Has(listener string) bool {
+	return d != nil && (slices.Contains(d.Listeners, listener) || slices.Contains(d.FilterChainListeners, listener))
+}
+
+// XdsNacks holds the rejections of the xds snapshots of an xds IR by its proxies that
+// are not resolved yet.
+type XdsNacks struct {
+	// Nacks holds the rejection of each proxy.
+	Nacks []xdstypes.Nack
+}
+
+// DeepCopy returns a copy of the rejections.
+func (n *XdsNacks) DeepCopy() *XdsNacks {
+	if n == nil {
+		return nil
+	}
+	return &XdsNacks{Nacks: slices.Clone(n.Nacks)}
+}
+
+// XdsInconsistencies holds the inconsistencies of the last xds snapshot of an xds IR,
+// which was refused instead of being pushed to its proxies.
+type XdsInconsistencies struct {
+	// Problems describes each inconsistency of the snapshot.
+	Problems []string
+}
+
+// DeepCopy returns a copy of the inconsistencies.
+func (i *XdsInconsistencies) DeepCopy() *XdsInconsistencies {
+	if i == nil {
+		return nil
+	}
+	return &XdsInconsistencies{Problems: slices.Clone(i.Problems)}
+}
+
+// XdsDrifts holds the proxies of an xds IR whose acknowledged configuration stayed behind
+// the configuration they are served for longer than the drift window.
+type XdsDrifts struct {
+	// Drifts holds the drift of each proxy.
+	Drifts []xdstypes.Drift
+}
+
+// DeepCopy returns a copy of the drifts.
+func (d *XdsDrifts) DeepCopy() *XdsDrifts {
+	if d == nil {
+		return nil
+	}
+	drifts := make([]xdstypes.Drift, len(d.Drifts))
+	for i, drift := range d.Drifts {
+		drifts[i] = drift
+		drifts[i].TypeURLs = slices.Clone(drift.TypeURLs)
+	}
+	return &XdsDrifts{Drifts: drifts}
+}
+
+// XdsCheckpoint holds the checkpoint of the last configuration acknowledged by the proxies
+// of an xds IR.
+type XdsCheckpoint struct {
+	// Version is the version of the checkpointed snapshot.
+	Version string
+	// Data is the checkpointed snapshot, read with cache.ReadCheckpoint.
+	Data []byte
+}
+
+// DeepCopy returns a copy of the checkpoint.
+func (c *XdsCheckpoint) DeepCopy() *XdsCheckpoint {
+	if c == nil {
+		return nil
+	}
+	return &XdsCheckpoint{Version: c.Version, Data: slices.Clone(c.Data)}
+}
+
+// GatewayDrain holds the progress of the drain of the proxies of a Gateway.
+type GatewayDrain struct {
+	// Connections is the number of connections left open on the proxies.
+	Connections uint64
+	// UncountedProxies is the number of proxies whose connections could not be counted.
+	UncountedProxies int
+	// TimedOut is true once the drain timeout elapsed with connections left open.
+	TimedOut bool
+}
+
+// Drained returns true once all the connections of the proxies are closed.
+func (d *GatewayDrain) Drained() bool {
+	return d.Connections == 0 && d.UncountedProxies == 0
+}
+
+// DeepCopy returns a copy of the drain.
+func (d *GatewayDrain) DeepCopy() *GatewayDrain {
+	if d == nil {
+		return nil
+	}
+	out := *d
+	return &out
+}
+
+// CanaryRollout holds the progress of the canary rollout of a BackendTrafficPolicy.
+type CanaryRollout struct {
+	// Generation is the generation of the policy the rollout is run for.
+	Generation int64
+	// Reason is the reason of the CanaryRollout condition of the policy: Progressing,
+	// Succeeded or RolledBack.
+	Reason gwapiv1a2.PolicyConditionReason
+	// Message describes the progress of the rollout.
+	Message string
+}
+
+// DeepCopy returns a copy of the rollout.
+func (r *CanaryRollout) DeepCopy() *CanaryRollout {
+	if r == nil {
+		return nil
+	}
+	out := *r
+	return &out
+}
+
 // XdsIR message
 type XdsIR struct {
-	watchable.Map[string, *ir.Xds]
+	TracedMap[string, *ir.Xds]
 }
 
 // InfraIR message
 type InfraIR struct {
-	watchable.Map[string, *ir.Infra]
+	TracedMap[string, *ir.Infra]
 }
 
 // Xds message
 type Xds struct {
-	watchable.Map[string, *xdstypes.ResourceVersionTable]
+	TracedMap[string, *xdstypes.ResourceVersionTable]
 }
@@ -0,0 +1,73 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package message
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/types"
+
+	xdstypes "github.com/envoyproxy/gateway/internal/xds/types"
+)
+
+// TestProviderResourcesStore checks that the values of the maps of the provider resources
+// are copied when stored, as the watchable maps require.
+func TestProviderResourcesStore(t *testing.T) {
+	p := new(ProviderResources)
+	defer p.Close()
+
+	infraError := &InfraError{Reason: "InfraError", Message: "failed"}
+	p.InfraErrors.Store("key", infraError)
+	got, _ := p.InfraErrors.Load("key")
+	require.Equal(t, infraError, got)
+
+	pending := &PendingListeners{Listeners: []string{"listener"}}
+	p.PendingListeners.Store("key", pending)
+	gotPending, _ := p.PendingListeners.Load("key")
+	require.Equal(t, pending, gotPending)
+
+	rotations := &SecretRotations{Secrets: []string{"secret"}}
+	p.SecretRotations.Store("key", rotations)
+	gotRotations, _ := p.SecretRotations.Load("key")
+	require.Equal(t, rotations, gotRotations)
+
+	deferred := &DeferredDrains{Listeners: []string{"listener"}, Until: time.Unix(0, 0)}
+	p.DeferredDrains.Store("key", deferred)
+	gotDeferred, _ := p.DeferredDrains.Load("key")
+	require.Equal(t, deferred, gotDeferred)
+
+	nacks := &XdsNacks{Nacks: []xdstypes.Nack{{NodeID: "node", Message: "rejected"}}}
+	p.XdsNacks.Store("key", nacks)
+	gotNacks, _ := p.XdsNacks.Load("key")
+	require.Equal(t, nacks, gotNacks)
+
+	inconsistencies := &XdsInconsistencies{Problems: []string{"problem"}}
+	p.XdsInconsistencies.Store("key", inconsistencies)
+	gotInconsistencies, _ := p.XdsInconsistencies.Load("key")
+	require.Equal(t, inconsistencies, gotInconsistencies)
+
+	drifts := &XdsDrifts{Drifts: []xdstypes.Drift{{NodeID: "node", TypeURLs: []string{"type"}, Since: time.Unix(0, 0)}}}
+	p.XdsDrifts.Store("key", drifts)
+	gotDrifts, _ := p.XdsDrifts.Load("key")
+	require.Equal(t, drifts, gotDrifts)
+
+	drain := &GatewayDrain{Connections: 3, UncountedProxies: 1}
+	p.GatewayDrains.Store("key", drain)
+	gotDrain, _ := p.GatewayDrains.Load("key")
+	require.Equal(t, drain, gotDrain)
+
+	checkpoint := &XdsCheckpoint{Version: "1", Data: []byte("snapshot")}
+	p.XdsCheckpoints.Store("key", checkpoint)
+	gotCheckpoint, _ := p.XdsCheckpoints.Load("key")
+	require.Equal(t, checkpoint, gotCheckpoint)
+
+	canary := &CanaryRollout{Generation: 2, Reason: "Progressing", Message: "step 1/3: 10% of the requests"}
+	p.CanaryRollouts.Store(types.NamespacedName{Namespace: "default", Name: "policy"}, canary)
+	gotCanary, _ := p.CanaryRollouts.Load(types.NamespacedName{Namespace: "default", Name: "policy"})
+	require.Equal(t, canary, gotCanary)
+}
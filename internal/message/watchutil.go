@@ -6,16 +6,91 @@
 package message
 
 import (
+	"context"
+	"maps"
+	"sync"
 	"time"
 
 	"github.com/telepresenceio/watchable"
+	"go.opentelemetry.io/otel/trace"
 
 	egv1a1 "github.com/envoyproxy/gateway/api/v1alpha1"
 	"github.com/envoyproxy/gateway/internal/logging"
 	"github.com/envoyproxy/gateway/internal/metrics"
 )
 
-type Update[K comparable, V any] watchable.Update[K, V]
+// Update is an update of a key of a watchable map, as handled by a subscriber.
+type Update[K comparable, V any] struct {
+	Key K
+	// Delete is whether this is deleting the entry for Key, or setting it to Value.
+	Delete bool
+	Value  V
+	// Generation is the number of updates of the key received by the subscription since
+	// the key was last deleted, this one included. The generations of the updates handled
+	// for a key leap over the updates coalesced into a newer one.
+	Generation uint64
+	// Replayed is whether the update replays the state of the map when the subscription
+	// started, rather than a change of the map since.
+	Replayed bool
+	// SpanContext is the span context of the handling of the update, if the update is
+	// traced. The updates published by the subscriber in Context are traced from it.
+	SpanContext trace.SpanContext
+}
+
+// Context returns the context carrying the span context of the update, which the updates
+// published by the subscriber while handling it are stored in.
+func (u Update[K, V]) Context() context.Context {
+	return trace.ContextWithSpanContext(context.Background(), u.SpanContext)
+}
+
+// generations numbers the updates of each key received by a subscription.
+type generations[K comparable, V any] map[K]uint64
+
+// next returns the update numbered with the next generation of its key, forgetting the key
+// once it is deleted.
+func (g generations[K, V]) next(update watchable.Update[K, V]) Update[K, V] {
+	generation := g[update.Key] + 1
+	if update.Delete {
+		delete(g, update.Key)
+	} else {
+		g[update.Key] = generation
+	}
+	return Update[K, V]{
+		Key:        update.Key,
+		Delete:     update.Delete,
+		Value:      update.Value,
+		Generation: generation,
+	}
+}
+
+// Replay holds the keys received by the subscriptions of a subscriber, so that a subscriber
+// subscribing again, such as a runner restarted in-process, is replayed the deletion of the
+// keys deleted while it wasn't subscribed, along with the state of the map. The generations
+// of the keys carry on across the subscriptions.
+//
+// The zero value is ready to use, and must not be shared by several subscribers.
+type Replay[K comparable, V any] struct {
+	mu   sync.Mutex
+	gens generations[K, V]
+}
+
+// load returns the generations of the keys received by the last subscription.
+func (r *Replay[K, V]) load() generations[K, V] {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	gens := make(generations[K, V], len(r.gens))
+	maps.Copy(gens, r.gens)
+	return gens
+}
+
+// save holds the generations of the keys received by the subscription once it ended.
+func (r *Replay[K, V]) save(gens generations[K, V]) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.gens = gens
+}
 
 var logger = logging.DefaultLogger(egv1a1.LogLevelInfo).WithName("watchable")
 
@@ -38,8 +113,8 @@ func (m Metadata) LabelValues() []metrics.LabelValue {
 
 // HandleSubscription takes a channel returned by
 // watchable.Map.Subscribe() (or .SubscribeSubset()), and calls the
-// given function for each initial value in the map, and for any
-// updates.
+// given function for each initial value in the map, flagged as
+// replayed, and for any updates.
 //
 // This is better than simply iterating over snapshot.Updates because
 // it handles the case where the watchable.Map already contains
@@ -48,34 +123,128 @@ func HandleSubscription[K comparable, V any](
 	meta Metadata,
 	subscription <-chan watchable.Snapshot[K, V],
 	handle func(updateFunc Update[K, V], errChans chan error),
+) {
+	HandleSubscriptionWithPriority(meta, subscription, nil, handle)
+}
+
+// HandleSubscriptionWithPriority is HandleSubscription, handling the updates of a higher
+// priority, as returned by the priority function, ahead of the pending updates of a lower
+// priority. The updates of a key are still handled in order.
+func HandleSubscriptionWithPriority[K comparable, V any](
+	meta Metadata,
+	subscription <-chan watchable.Snapshot[K, V],
+	priority func(update Update[K, V]) Priority,
+	handle func(updateFunc Update[K, V], errChans chan error),
+) {
+	handleSubscription(meta, subscription, priority, nil, handle)
+}
+
+// HandleSubscriptionWithReplay is HandleSubscription, replaying the deletion of the keys
+// received by the previous subscription of the replay but missing from the state of the map,
+// once the state of the map is replayed, before handling the changes of the map.
+func HandleSubscriptionWithReplay[K comparable, V any](
+	meta Metadata,
+	subscription <-chan watchable.Snapshot[K, V],
+	replay *Replay[K, V],
+	handle func(updateFunc Update[K, V], errChans chan error),
+) {
+	handleSubscription(meta, subscription, nil, replay, handle)
+}
+
+// ReportErrors logs and counts the errors sent to the channel as errors of the subscription,
+// until the channel is closed. The subscribers report the errors of the work they do outside
+// of the handling of an update, such as on a timer, to a channel of their own reported with
+// ReportErrors, since the channel of an update is closed once the update is handled.
+func ReportErrors(meta Metadata, errChans <-chan error) {
+	for err := range errChans {
+		logger.WithValues("runner", meta.Runner).Error(err, "observed an error")
+		watchableSubscribeTotal.WithFailure(metrics.ReasonError, meta.LabelValues()...).Increment()
+	}
+}
+
+func handleSubscription[K comparable, V any](
+	meta Metadata,
+	subscription <-chan watchable.Snapshot[K, V],
+	priority func(update Update[K, V]) Priority,
+	replay *Replay[K, V],
+	handle func(updateFunc Update[K, V], errChans chan error),
 ) {
 	// TODO: find a suitable value
 	errChans := make(chan error, 10)
-	go func() {
-		for err := range errChans {
-			logger.WithValues("runner", meta.Runner).Error(err, "observed an error")
-			watchableSubscribeTotal.WithFailure(metrics.ReasonError, meta.LabelValues()...).Increment()
+	go ReportErrors(meta, errChans)
+
+	// The failing updates are retried if the message is retried, once their backoff elapsed,
+	// in between the updates of the subscription.
+	var (
+		retries *retrier[K, V]
+		ready   <-chan struct{}
+	)
+	if policy, ok := retryFor(meta.Message); ok {
+		retries = newRetrier(meta, policy, handle, errChans)
+		ready = retries.ready
+		defer retries.stop()
+	}
+	handleUpdate := func(update Update[K, V]) {
+		startHandleTime := time.Now()
+		if span, ok := startUpdateSpan(meta, &update); ok {
+			defer span.End()
+		}
+		if retries != nil {
+			retries.handleUpdate(update)
+		} else {
+			handle(update, errChans)
 		}
-	}()
+		watchableSubscribeTotal.WithSuccess(meta.LabelValues()...).Increment()
+		watchableSubscribeDurationSeconds.With(meta.LabelValues()...).Record(time.Since(startHandleTime).Seconds())
+	}
 
+	gens := make(generations[K, V])
+	if replay != nil {
+		gens = replay.load()
+		defer func() { replay.save(gens) }()
+	}
 	if snapshot, ok := <-subscription; ok {
+		replayUpdate := func(update watchable.Update[K, V]) {
+			u := gens.next(update)
+			u.Replayed = true
+			handleUpdate(u)
+			watchableReplayedTotal.With(meta.LabelValues()...).Increment()
+		}
 		for k, v := range snapshot.State {
-			startHandleTime := time.Now()
-			handle(Update[K, V]{
+			replayUpdate(watchable.Update[K, V]{
 				Key:   k,
 				Value: v,
-			}, errChans)
-			watchableSubscribeTotal.WithSuccess(meta.LabelValues()...).Increment()
-			watchableSubscribeDurationSeconds.With(meta.LabelValues()...).Record(time.Since(startHandleTime).Seconds())
+			})
 		}
+		// The keys deleted before the subscription started were received by the previous
+		// subscription only.
+		for k := range gens {
+			if _, ok := snapshot.State[k]; !ok {
+				replayUpdate(watchable.Update[K, V]{
+					Key:    k,
+					Delete: true,
+				})
+			}
+		}
+	}
+	// Pass the updates through a queue if the queues of the message are bounded or
+	// coalesced, or if the updates are prioritized.
+	if q, ok := queueFor(meta.Message); ok || priority != nil {
+		handleQueued(meta, subscription, q, gens, priority, handleUpdate, retries)
+		return
 	}
-	for snapshot := range subscription {
-		watchableDepth.With(meta.LabelValues()...).Record(float64(len(subscription)))
-		for _, update := range snapshot.Updates {
-			startHandleTime := time.Now()
-			handle(Update[K, V](update), errChans)
-			watchableSubscribeTotal.WithSuccess(meta.LabelValues()...).Increment()
-			watchableSubscribeDurationSeconds.With(meta.LabelValues()...).Record(time.Since(startHandleTime).Seconds())
+	for {
+		select {
+		case snapshot, ok := <-subscription:
+			if !ok {
+				return
+			}
+			watchableDepth.With(meta.LabelValues()...).Record(float64(len(subscription)))
+			for _, update := range snapshot.Updates {
+				handleUpdate(gens.next(update))
+			}
+		case <-ready:
+			retries.retryDue()
 		}
 	}
 }
@@ -63,6 +63,46 @@ func TestHandleSubscriptionAlreadyInitialized(t *testing.T) {
 	assert.Equal(t, 1, deleteCalls)
 }
 
+func TestHandleSubscriptionWithReplay(t *testing.T) {
+	var m watchable.Map[string, int]
+	m.Store("foo", 1)
+	m.Store("bar", 2)
+
+	var replay message.Replay[string, int]
+	subscribe := func(expected int) map[string]message.Update[string, int] {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		updates := make(map[string]message.Update[string, int])
+		message.HandleSubscriptionWithReplay(
+			message.Metadata{Runner: "demo", Message: "replayed"},
+			m.Subscribe(ctx),
+			&replay,
+			func(update message.Update[string, int], errChans chan error) {
+				updates[update.Key] = update
+				if len(updates) == expected {
+					cancel()
+				}
+			},
+		)
+		return updates
+	}
+
+	assert.Equal(t, map[string]message.Update[string, int]{
+		"foo": {Key: "foo", Value: 1, Generation: 1, Replayed: true},
+		"bar": {Key: "bar", Value: 2, Generation: 1, Replayed: true},
+	}, subscribe(2))
+
+	// The subscriber subscribing again is replayed the deletion of the keys deleted while it
+	// wasn't subscribed.
+	m.Delete("bar")
+	m.Store("baz", 3)
+	assert.Equal(t, map[string]message.Update[string, int]{
+		"foo": {Key: "foo", Value: 1, Generation: 2, Replayed: true},
+		"bar": {Key: "bar", Delete: true, Generation: 2, Replayed: true},
+		"baz": {Key: "baz", Value: 3, Generation: 1, Replayed: true},
+	}, subscribe(3))
+}
+
 func TestXdsIRUpdates(t *testing.T) {
 	tests := []struct {
 		desc    string
@@ -123,7 +163,7 @@ func TestXdsIRUpdates(t *testing.T) {
 			}()
 
 			updates := 0
-			message.HandleSubscription(message.Metadata{Runner: "demo", Message: "demo"}, snapshotC, func(u message.Update[string, *ir.Xds], errChans chan error) {
+			message.HandleTracedSubscription(message.Metadata{Runner: "demo", Message: "demo"}, snapshotC, func(u message.Update[string, *ir.Xds], errChans chan error) {
 				end()
 				if u.Key == "test" {
 					updates += 1
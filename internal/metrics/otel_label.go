@@ -21,8 +21,9 @@ const (
 	StatusSuccess = "success"
 	StatusFailure = "failure"
 
-	ReasonError    = "error"
-	ReasonConflict = "conflict"
+	ReasonError        = "error"
+	ReasonConflict     = "conflict"
+	ReasonInconsistent = "inconsistent"
 )
 
 // A Label provides a named dimension for a Metric.
@@ -54,6 +55,11 @@ func (l LabelValue) Value() string {
 	return l.keyValue.Value.AsString()
 }
 
+// Attribute returns the LabelValue as an attribute, e.g. of a span.
+func (l LabelValue) Attribute() attribute.KeyValue {
+	return l.keyValue
+}
+
 func mergeLabelValues(attrs []attribute.KeyValue, labelValues []LabelValue) ([]attribute.KeyValue, attribute.Set) {
 	mergedAttrs := make([]attribute.KeyValue, 0, len(attrs)+len(labelValues))
 	mergedAttrs = append(mergedAttrs, attrs...)
@@ -0,0 +1,376 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package openapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"k8s.io/kube-openapi/pkg/validation/spec"
+	"sigs.k8s.io/yaml"
+)
+
+// defaultMediaType is the media type of the Swagger v2 request bodies whose
+// operation doesn't declare the consumed media types.
+const defaultMediaType = "application/json"
+
+// maxRecursionDepth is the number of times a recursive schema is expanded.
+const maxRecursionDepth = 3
+
+// methods are the HTTP methods of the operations of a path item.
+var methods = []string{"get", "put", "post", "delete", "options", "head", "patch", "trace"}
+
+// pathParameterRegex matches the parameters of a path template.
+var pathParameterRegex = regexp.MustCompile(`\{([^{}/]+)\}`)
+
+// Validator validates the requests against an OpenAPI document.
+type Validator struct {
+	// basePath is the path the paths of the document are relative to.
+	basePath string
+	// paths holds the paths of the document, the concrete paths first.
+	paths []*pathItem
+}
+
+// pathItem is a path of the document with its operations.
+type pathItem struct {
+	template   string
+	regex      *regexp.Regexp
+	parameters []string
+	// operations holds the operations of the path by upper-cased method.
+	operations map[string]*operation
+}
+
+// operation is an operation of the document.
+type operation struct {
+	parameters []*parameter
+	body       *requestBody
+}
+
+// parameter is a path, query, header or cookie parameter of an operation.
+type parameter struct {
+	name     string
+	in       string
+	required bool
+	// explode is set if the values of an array parameter are passed as separate
+	// parameters rather than a comma-separated list.
+	explode bool
+	schema  *spec.Schema
+}
+
+// requestBody is the request body of an operation.
+type requestBody struct {
+	required bool
+	// content holds the schema of the body by media type, nil if the body isn't
+	// validated.
+	content map[string]*spec.Schema
+}
+
+// Parse parses an OpenAPI v3 or Swagger v2 document, in the JSON or YAML format.
+func Parse(doc []byte) (*Validator, error) {
+	data, err := yaml.YAMLToJSON(doc)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse the OpenAPI document: %w", err)
+	}
+	var root map[string]any
+	if err := json.Unmarshal(data, &root); err != nil {
+		return nil, fmt.Errorf("failed to parse the OpenAPI document: %w", err)
+	}
+	if root == nil {
+		return nil, fmt.Errorf("the OpenAPI document is empty")
+	}
+	_, v3 := root["openapi"]
+	if _, v2 := root["swagger"]; !v2 && !v3 {
+		return nil, fmt.Errorf("the document is neither an OpenAPI v3 nor a Swagger v2 document")
+	}
+
+	expanded, err := expandRefs(root, root, nil)
+	if err != nil {
+		return nil, err
+	}
+	root = expanded.(map[string]any)
+
+	v := &Validator{basePath: basePath(root, v3)}
+	paths, _ := root["paths"].(map[string]any)
+	for template, value := range paths {
+		item, _ := value.(map[string]any)
+		p, err := parsePathItem(template, item, root, v3)
+		if err != nil {
+			return nil, fmt.Errorf("path %s: %w", template, err)
+		}
+		v.paths = append(v.paths, p)
+	}
+	// The concrete paths are matched before the templated ones.
+	sort.Slice(v.paths, func(i, j int) bool {
+		if len(v.paths[i].parameters) != len(v.paths[j].parameters) {
+			return len(v.paths[i].parameters) < len(v.paths[j].parameters)
+		}
+		return v.paths[i].template < v.paths[j].template
+	})
+	return v, nil
+}
+
+// basePath returns the path the paths of the document are relative to, which is the path
+// of the first server of an OpenAPI v3 document, or the base path of a Swagger v2 document.
+func basePath(root map[string]any, v3 bool) string {
+	var path string
+	if v3 {
+		if servers, ok := root["servers"].([]any); ok && len(servers) > 0 {
+			server, _ := servers[0].(map[string]any)
+			serverURL, _ := server["url"].(string)
+			// The server variables can't be resolved, so the path is only used if
+			// it doesn't contain any.
+			if u, err := url.Parse(serverURL); err == nil && !strings.Contains(u.Path, "{") {
+				path = u.Path
+			}
+		}
+	} else {
+		path, _ = root["basePath"].(string)
+	}
+	return strings.TrimSuffix(path, "/")
+}
+
+func parsePathItem(template string, item map[string]any, root map[string]any, v3 bool) (*pathItem, error) {
+	p := &pathItem{
+		template:   template,
+		operations: make(map[string]*operation),
+	}
+	expr := "^"
+	last := 0
+	for _, match := range pathParameterRegex.FindAllStringSubmatchIndex(template, -1) {
+		expr += regexp.QuoteMeta(template[last:match[0]]) + "([^/]+)"
+		p.parameters = append(p.parameters, template[match[2]:match[3]])
+		last = match[1]
+	}
+	expr += regexp.QuoteMeta(template[last:]) + "$"
+	regex, err := regexp.Compile(expr)
+	if err != nil {
+		return nil, err
+	}
+	p.regex = regex
+
+	common, _ := item["parameters"].([]any)
+	for _, method := range methods {
+		value, ok := item[method].(map[string]any)
+		if !ok {
+			continue
+		}
+		op, err := parseOperation(value, common, root, v3)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", method, err)
+		}
+		p.operations[strings.ToUpper(method)] = op
+	}
+	return p, nil
+}
+
+func parseOperation(value map[string]any, common []any, root map[string]any, v3 bool) (*operation, error) {
+	op := &operation{}
+
+	// The parameters of the operation override the parameters of the path item.
+	operationParameters, _ := value["parameters"].([]any)
+	byKey := make(map[string]int)
+	var formData bool
+	for _, raw := range append(append([]any{}, common...), operationParameters...) {
+		param, _ := raw.(map[string]any)
+		name, _ := param["name"].(string)
+		in, _ := param["in"].(string)
+		required, _ := param["required"].(bool)
+
+		switch in {
+		case "body":
+			schema, err := toSchema(param["schema"])
+			if err != nil {
+				return nil, fmt.Errorf("parameter %s: %w", name, err)
+			}
+			op.body = &requestBody{required: required, content: make(map[string]*spec.Schema)}
+			for _, mediaType := range consumes(value, root) {
+				op.body.content[mediaType] = schema
+			}
+			continue
+		case "formData":
+			formData = true
+			continue
+		case "path", "query", "header", "cookie":
+		default:
+			return nil, fmt.Errorf("parameter %s: unsupported location %q", name, in)
+		}
+
+		p := &parameter{name: name, in: in, required: required || in == "path"}
+		var schema any
+		if v3 {
+			schema = param["schema"]
+			style, _ := param["style"].(string)
+			explode, ok := param["explode"].(bool)
+			if !ok {
+				explode = style == "" || style == "form"
+			}
+			p.explode = explode && (in == "query" || in == "cookie")
+		} else {
+			// The schema of a Swagger v2 parameter is inlined in the parameter.
+			inline := make(map[string]any, len(param))
+			for k, v := range param {
+				switch k {
+				case "name", "in", "required", "description", "collectionFormat", "allowEmptyValue":
+				default:
+					inline[k] = v
+				}
+			}
+			schema = inline
+			p.explode = param["collectionFormat"] == "multi"
+		}
+		s, err := toSchema(schema)
+		if err != nil {
+			return nil, fmt.Errorf("parameter %s: %w", name, err)
+		}
+		p.schema = s
+
+		key := in + "/" + strings.ToLower(name)
+		if in != "header" {
+			key = in + "/" + name
+		}
+		if i, ok := byKey[key]; ok {
+			op.parameters[i] = p
+			continue
+		}
+		byKey[key] = len(op.parameters)
+		op.parameters = append(op.parameters, p)
+	}
+
+	if formData && op.body == nil {
+		op.body = &requestBody{content: map[string]*spec.Schema{
+			"application/x-www-form-urlencoded": nil,
+			"multipart/form-data":               nil,
+		}}
+	}
+
+	if body, ok := value["requestBody"].(map[string]any); ok {
+		required, _ := body["required"].(bool)
+		op.body = &requestBody{required: required, content: make(map[string]*spec.Schema)}
+		content, _ := body["content"].(map[string]any)
+		for mediaType, raw := range content {
+			media, _ := raw.(map[string]any)
+			schema, err := toSchema(media["schema"])
+			if err != nil {
+				return nil, fmt.Errorf("request body %s: %w", mediaType, err)
+			}
+			op.body.content[strings.ToLower(mediaType)] = schema
+		}
+	}
+	return op, nil
+}
+
+// consumes returns the media types consumed by a Swagger v2 operation.
+func consumes(value map[string]any, root map[string]any) []string {
+	list, ok := value["consumes"].([]any)
+	if !ok {
+		list, _ = root["consumes"].([]any)
+	}
+	var mediaTypes []string
+	for _, item := range list {
+		if mediaType, ok := item.(string); ok {
+			mediaTypes = append(mediaTypes, strings.ToLower(mediaType))
+		}
+	}
+	if len(mediaTypes) == 0 {
+		mediaTypes = []string{defaultMediaType}
+	}
+	return mediaTypes
+}
+
+// toSchema converts a schema of the document to a JSON schema, or nil if not set.
+func toSchema(value any) (*spec.Schema, error) {
+	if value == nil {
+		return nil, nil
+	}
+	data, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+	schema := &spec.Schema{}
+	if err := json.Unmarshal(data, schema); err != nil {
+		return nil, fmt.Errorf("invalid schema: %w", err)
+	}
+	return schema, nil
+}
+
+// expandRefs returns the value with the local references of the document replaced by
+// the values they refer to. A recursive reference is replaced by an empty schema once
+// expanded maxRecursionDepth times, so that the deeper values are not validated.
+func expandRefs(value any, root map[string]any, visiting []string) (any, error) {
+	switch v := value.(type) {
+	case map[string]any:
+		if ref, ok := v["$ref"].(string); ok {
+			depth := 0
+			for _, r := range visiting {
+				if r == ref {
+					depth++
+				}
+			}
+			if depth >= maxRecursionDepth {
+				return map[string]any{}, nil
+			}
+			target, err := resolveRef(ref, root)
+			if err != nil {
+				return nil, err
+			}
+			return expandRefs(target, root, append(visiting, ref))
+		}
+		expanded := make(map[string]any, len(v))
+		for k, item := range v {
+			e, err := expandRefs(item, root, visiting)
+			if err != nil {
+				return nil, err
+			}
+			expanded[k] = e
+		}
+		return expanded, nil
+	case []any:
+		expanded := make([]any, 0, len(v))
+		for _, item := range v {
+			e, err := expandRefs(item, root, visiting)
+			if err != nil {
+				return nil, err
+			}
+			expanded = append(expanded, e)
+		}
+		return expanded, nil
+	default:
+		return value, nil
+	}
+}
+
+// resolveRef returns the value a local reference of the document refers to.
+func resolveRef(ref string, root map[string]any) (any, error) {
+	if !strings.HasPrefix(ref, "#/") {
+		return nil, fmt.Errorf("reference %s: only local references are supported", ref)
+	}
+	var current any = root
+	for _, token := range strings.Split(ref[2:], "/") {
+		token = strings.ReplaceAll(strings.ReplaceAll(token, "~1", "/"), "~0", "~")
+		switch v := current.(type) {
+		case map[string]any:
+			next, ok := v[token]
+			if !ok {
+				return nil, fmt.Errorf("reference %s: %s not found", ref, token)
+			}
+			current = next
+		case []any:
+			i, err := strconv.Atoi(token)
+			if err != nil || i < 0 || i >= len(v) {
+				return nil, fmt.Errorf("reference %s: %s not found", ref, token)
+			}
+			current = v[i]
+		default:
+			return nil, fmt.Errorf("reference %s: %s not found", ref, token)
+		}
+	}
+	return current, nil
+}
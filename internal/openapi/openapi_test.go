@@ -0,0 +1,201 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package openapi
+
+import (
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParse(t *testing.T) {
+	_, err := Parse([]byte("info: {}"))
+	require.EqualError(t, err, "the document is neither an OpenAPI v3 nor a Swagger v2 document")
+
+	_, err = Parse([]byte("openapi: 3.0.3\npaths:\n  /pets:\n    get:\n      parameters:\n      - $ref: 'other.yaml#/Limit'\n"))
+	require.EqualError(t, err, "reference other.yaml#/Limit: only local references are supported")
+
+	_, err = Parse([]byte("openapi: 3.0.3\npaths:\n  /pets:\n    get:\n      parameters:\n      - $ref: '#/components/parameters/Limit'\n"))
+	require.EqualError(t, err, "reference #/components/parameters/Limit: components not found")
+}
+
+func TestValidate(t *testing.T) {
+	json := map[string][]string{"content-type": {"application/json"}}
+	testCases := []struct {
+		name       string
+		document   string
+		req        Request
+		statusCode int
+	}{
+		{
+			name:     "valid query parameters",
+			document: "petstore.yaml",
+			req:      Request{Method: "GET", Path: "/api/v3/pets?limit=10&tags=cat&tags=dog"},
+		},
+		{
+			name:       "query parameter of the wrong type",
+			document:   "petstore.yaml",
+			req:        Request{Method: "GET", Path: "/api/v3/pets?limit=ten"},
+			statusCode: http.StatusBadRequest,
+		},
+		{
+			name:       "query parameter out of range",
+			document:   "petstore.yaml",
+			req:        Request{Method: "GET", Path: "/api/v3/pets?limit=1000"},
+			statusCode: http.StatusBadRequest,
+		},
+		{
+			name:       "path outside of the base path",
+			document:   "petstore.yaml",
+			req:        Request{Method: "GET", Path: "/pets"},
+			statusCode: http.StatusNotFound,
+		},
+		{
+			name:       "unknown path",
+			document:   "petstore.yaml",
+			req:        Request{Method: "GET", Path: "/api/v3/owners"},
+			statusCode: http.StatusNotFound,
+		},
+		{
+			name:       "unknown method",
+			document:   "petstore.yaml",
+			req:        Request{Method: "DELETE", Path: "/api/v3/pets"},
+			statusCode: http.StatusMethodNotAllowed,
+		},
+		{
+			name:     "concrete path matched before templated path",
+			document: "petstore.yaml",
+			req: Request{Method: "GET", Path: "/api/v3/pets/mine", Headers: map[string][]string{
+				"x-owner": {"6ba7b810-9dad-11d1-80b4-00c04fd430c8"},
+			}},
+		},
+		{
+			name:       "missing required header",
+			document:   "petstore.yaml",
+			req:        Request{Method: "GET", Path: "/api/v3/pets/mine"},
+			statusCode: http.StatusBadRequest,
+		},
+		{
+			name:       "header of the wrong format",
+			document:   "petstore.yaml",
+			req:        Request{Method: "GET", Path: "/api/v3/pets/mine", Headers: map[string][]string{"x-owner": {"me"}}},
+			statusCode: http.StatusBadRequest,
+		},
+		{
+			name:     "valid path parameter",
+			document: "petstore.yaml",
+			req:      Request{Method: "GET", Path: "/api/v3/pets/1"},
+		},
+		{
+			name:       "path parameter out of range",
+			document:   "petstore.yaml",
+			req:        Request{Method: "GET", Path: "/api/v3/pets/0"},
+			statusCode: http.StatusBadRequest,
+		},
+		{
+			name:     "valid body",
+			document: "petstore.yaml",
+			req:      Request{Method: "POST", Path: "/api/v3/pets", Headers: json, Body: []byte(`{"name":"rex","parent":{"name":"max"}}`)},
+		},
+		{
+			name:       "missing required body",
+			document:   "petstore.yaml",
+			req:        Request{Method: "POST", Path: "/api/v3/pets", Headers: json},
+			statusCode: http.StatusBadRequest,
+		},
+		{
+			name:       "body missing a required property",
+			document:   "petstore.yaml",
+			req:        Request{Method: "POST", Path: "/api/v3/pets", Headers: json, Body: []byte(`{"tag":"dog"}`)},
+			statusCode: http.StatusBadRequest,
+		},
+		{
+			name:       "nested body missing a required property",
+			document:   "petstore.yaml",
+			req:        Request{Method: "POST", Path: "/api/v3/pets", Headers: json, Body: []byte(`{"name":"rex","parent":{"name":""}}`)},
+			statusCode: http.StatusBadRequest,
+		},
+		{
+			name:     "recursive schema validated up to the maximum depth",
+			document: "petstore.yaml",
+			req:      Request{Method: "POST", Path: "/api/v3/pets", Headers: json, Body: []byte(`{"name":"a","parent":{"name":"b","parent":{"name":"c","parent":{"tag":1}}}}`)},
+		},
+		{
+			name:       "invalid JSON body",
+			document:   "petstore.yaml",
+			req:        Request{Method: "POST", Path: "/api/v3/pets", Headers: json, Body: []byte(`{"name":`)},
+			statusCode: http.StatusBadRequest,
+		},
+		{
+			name:     "body of a media type range",
+			document: "petstore.yaml",
+			req: Request{Method: "PUT", Path: "/api/v3/pets/1", Body: []byte(`{"name":"rex"}`), Headers: map[string][]string{
+				"content-type": {"application/merge-patch+json; charset=utf-8"},
+			}},
+		},
+		{
+			name:     "body without schema",
+			document: "petstore.yaml",
+			req: Request{Method: "PUT", Path: "/api/v3/pets/1", Body: []byte(`rex`), Headers: map[string][]string{
+				"content-type": {"text/plain"},
+			}},
+		},
+		{
+			name:     "unsupported media type",
+			document: "petstore.yaml",
+			req: Request{Method: "PUT", Path: "/api/v3/pets/1", Body: []byte(`rex`), Headers: map[string][]string{
+				"content-type": {"text/html"},
+			}},
+			statusCode: http.StatusUnsupportedMediaType,
+		},
+		{
+			name:     "swagger valid collection",
+			document: "swagger.json",
+			req:      Request{Method: "GET", Path: "/v2/pets?status=available,sold"},
+		},
+		{
+			name:       "swagger invalid collection item",
+			document:   "swagger.json",
+			req:        Request{Method: "GET", Path: "/v2/pets?status=available,lost"},
+			statusCode: http.StatusBadRequest,
+		},
+		{
+			name:     "swagger valid body",
+			document: "swagger.json",
+			req:      Request{Method: "POST", Path: "/v2/pets", Headers: json, Body: []byte(`{"name":"rex"}`)},
+		},
+		{
+			name:       "swagger invalid body",
+			document:   "swagger.json",
+			req:        Request{Method: "POST", Path: "/v2/pets", Headers: json, Body: []byte(`{"name":1}`)},
+			statusCode: http.StatusBadRequest,
+		},
+	}
+
+	validators := make(map[string]*Validator)
+	for _, document := range []string{"petstore.yaml", "swagger.json"} {
+		doc, err := os.ReadFile(filepath.Join("testdata", document))
+		require.NoError(t, err)
+		validators[document], err = Parse(doc)
+		require.NoError(t, err)
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := validators[tc.document].Validate(&tc.req)
+			if tc.statusCode == 0 {
+				require.NoError(t, err)
+				return
+			}
+			var validationErr *Error
+			require.ErrorAs(t, err, &validationErr)
+			require.Equal(t, tc.statusCode, validationErr.StatusCode, validationErr.Message)
+		})
+	}
+}
@@ -0,0 +1,265 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package openapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"mime"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"k8s.io/kube-openapi/pkg/validation/spec"
+	"k8s.io/kube-openapi/pkg/validation/strfmt"
+	"k8s.io/kube-openapi/pkg/validation/validate"
+)
+
+// Request is a request to validate.
+type Request struct {
+	Method string
+	// Path is the path of the request, including the query.
+	Path string
+	// Headers holds the headers of the request by lower-cased name.
+	Headers map[string][]string
+	Body    []byte
+}
+
+// Error is the error of a request that doesn't conform to the document.
+type Error struct {
+	// StatusCode is the HTTP status code the request is rejected with.
+	StatusCode int
+	Message    string
+}
+
+func (e *Error) Error() string {
+	return e.Message
+}
+
+func invalid(statusCode int, format string, args ...any) *Error {
+	return &Error{StatusCode: statusCode, Message: fmt.Sprintf(format, args...)}
+}
+
+// Validate validates the request against the document, and returns an *Error if
+// the request doesn't conform to it.
+func (v *Validator) Validate(req *Request) error {
+	rawPath, rawQuery, _ := strings.Cut(req.Path, "?")
+	path := strings.TrimPrefix(rawPath, v.basePath)
+	if len(path) == len(rawPath) && v.basePath != "" {
+		return invalid(http.StatusNotFound, "path %s not found", rawPath)
+	}
+	if path == "" {
+		path = "/"
+	}
+
+	item, pathValues := v.matchPath(path)
+	if item == nil {
+		return invalid(http.StatusNotFound, "path %s not found", rawPath)
+	}
+	op, ok := item.operations[strings.ToUpper(req.Method)]
+	if !ok {
+		return invalid(http.StatusMethodNotAllowed, "method %s not allowed for path %s", req.Method, rawPath)
+	}
+
+	query, err := url.ParseQuery(rawQuery)
+	if err != nil {
+		return invalid(http.StatusBadRequest, "invalid query: %v", err)
+	}
+	var cookies []*http.Cookie
+	for _, p := range op.parameters {
+		var values []string
+		switch p.in {
+		case "path":
+			if value, ok := pathValues[p.name]; ok {
+				values = []string{value}
+			}
+		case "query":
+			values = query[p.name]
+		case "header":
+			values = req.Headers[strings.ToLower(p.name)]
+		case "cookie":
+			if cookies == nil {
+				cookies = (&http.Request{Header: http.Header{"Cookie": req.Headers["cookie"]}}).Cookies()
+			}
+			for _, c := range cookies {
+				if c.Name == p.name {
+					values = append(values, c.Value)
+				}
+			}
+		}
+		if err := p.validate(values); err != nil {
+			return err
+		}
+	}
+
+	return op.validateBody(req)
+}
+
+// matchPath returns the path item matching the path, with the values of its parameters.
+func (v *Validator) matchPath(path string) (*pathItem, map[string]string) {
+	for _, item := range v.paths {
+		match := item.regex.FindStringSubmatch(path)
+		if match == nil {
+			continue
+		}
+		values := make(map[string]string, len(item.parameters))
+		for i, name := range item.parameters {
+			value, err := url.PathUnescape(match[i+1])
+			if err != nil {
+				value = match[i+1]
+			}
+			values[name] = value
+		}
+		return item, values
+	}
+	return nil, nil
+}
+
+// validate validates the values of the parameter.
+func (p *parameter) validate(values []string) error {
+	if len(values) == 0 {
+		if p.required {
+			return invalid(http.StatusBadRequest, "%s parameter %s is required", p.in, p.name)
+		}
+		return nil
+	}
+	if p.schema == nil {
+		return nil
+	}
+
+	var value any
+	if p.schema.Type.Contains("array") {
+		if !p.explode {
+			values = strings.Split(values[0], ",")
+		}
+		var items *spec.Schema
+		if p.schema.Items != nil {
+			items = p.schema.Items.Schema
+		}
+		list := make([]any, 0, len(values))
+		for _, v := range values {
+			item, err := convert(v, items)
+			if err != nil {
+				return invalid(http.StatusBadRequest, "%s parameter %s: %v", p.in, p.name, err)
+			}
+			list = append(list, item)
+		}
+		value = list
+	} else {
+		v, err := convert(values[0], p.schema)
+		if err != nil {
+			return invalid(http.StatusBadRequest, "%s parameter %s: %v", p.in, p.name, err)
+		}
+		value = v
+	}
+
+	if err := validateSchema(p.schema, p.name, value); err != nil {
+		return invalid(http.StatusBadRequest, "%s parameter %s: %v", p.in, p.name, err)
+	}
+	return nil
+}
+
+// convert converts the value of a parameter to the type of its schema.
+func convert(value string, schema *spec.Schema) (any, error) {
+	if schema == nil {
+		return value, nil
+	}
+	switch {
+	case schema.Type.Contains("integer"):
+		i, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("%q is not an integer", value)
+		}
+		return i, nil
+	case schema.Type.Contains("number"):
+		f, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return nil, fmt.Errorf("%q is not a number", value)
+		}
+		return f, nil
+	case schema.Type.Contains("boolean"):
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return nil, fmt.Errorf("%q is not a boolean", value)
+		}
+		return b, nil
+	default:
+		return value, nil
+	}
+}
+
+// validateBody validates the body of the request against the schema of its media type.
+func (op *operation) validateBody(req *Request) error {
+	if op.body == nil {
+		return nil
+	}
+	if len(req.Body) == 0 {
+		if op.body.required {
+			return invalid(http.StatusBadRequest, "request body is required")
+		}
+		return nil
+	}
+
+	contentType := ""
+	if values := req.Headers["content-type"]; len(values) > 0 {
+		contentType = values[0]
+	}
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return invalid(http.StatusUnsupportedMediaType, "invalid content type %q", contentType)
+	}
+	schema, ok := op.body.schema(mediaType)
+	if !ok {
+		return invalid(http.StatusUnsupportedMediaType, "unsupported content type %q", mediaType)
+	}
+	// Only the JSON bodies are validated against their schema.
+	if schema == nil || !isJSON(mediaType) {
+		return nil
+	}
+
+	var body any
+	if err := json.Unmarshal(req.Body, &body); err != nil {
+		return invalid(http.StatusBadRequest, "request body is not valid JSON: %v", err)
+	}
+	if err := validateSchema(schema, "body", body); err != nil {
+		return invalid(http.StatusBadRequest, "request body: %v", err)
+	}
+	return nil
+}
+
+// schema returns the schema of the body of the media type, matching the media type
+// ranges of the document if the media type isn't listed.
+func (b *requestBody) schema(mediaType string) (*spec.Schema, bool) {
+	if schema, ok := b.content[mediaType]; ok {
+		return schema, true
+	}
+	if typ, _, ok := strings.Cut(mediaType, "/"); ok {
+		if schema, ok := b.content[typ+"/*"]; ok {
+			return schema, true
+		}
+	}
+	schema, ok := b.content["*/*"]
+	return schema, ok
+}
+
+func isJSON(mediaType string) bool {
+	return mediaType == "application/json" || strings.HasSuffix(mediaType, "+json")
+}
+
+// validateSchema validates the value against the schema.
+func validateSchema(schema *spec.Schema, name string, value any) error {
+	result := validate.NewSchemaValidator(schema, nil, name, strfmt.Default).Validate(value)
+	if !result.HasErrors() {
+		return nil
+	}
+	messages := make([]string, 0, len(result.Errors))
+	for _, err := range result.Errors {
+		// The validator reports all the values as located in the body.
+		messages = append(messages, strings.Replace(err.Error(), " in body ", " ", 1))
+	}
+	return fmt.Errorf("%s", strings.Join(messages, "; "))
+}
@@ -0,0 +1,357 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package kubernetes
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	prom "github.com/prometheus/client_golang/api"
+	prompapiv1 "github.com/prometheus/client_golang/api/prometheus/v1"
+	"github.com/prometheus/common/model"
+	"k8s.io/apimachinery/pkg/api/equality"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	gwapiv1 "sigs.k8s.io/gateway-api/apis/v1"
+	gwapiv1a2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
+
+	egv1a1 "github.com/envoyproxy/gateway/api/v1alpha1"
+	"github.com/envoyproxy/gateway/internal/gatewayapi"
+	"github.com/envoyproxy/gateway/internal/gatewayapi/resource"
+	"github.com/envoyproxy/gateway/internal/logging"
+	"github.com/envoyproxy/gateway/internal/message"
+	"github.com/envoyproxy/gateway/internal/utils"
+)
+
+const (
+	// canaryRolloutInterval is how often the canary rollouts are moved forward.
+	canaryRolloutInterval = 10 * time.Second
+
+	// defaultCanaryStepInterval is how long each step of a canary rollout lasts by default.
+	defaultCanaryStepInterval = 5 * time.Minute
+
+	// canaryRolloutAnnotation holds the state of the canary rollout of an HTTPRoute, so that
+	// the rollout carries on where it was after a restart or a change of leader.
+	canaryRolloutAnnotation = "gateway.envoyproxy.io/canary-rollout"
+)
+
+// guardrailQuerier returns true if the PromQL expression of a guardrail returns any sample.
+type guardrailQuerier func(ctx context.Context, query string) (bool, error)
+
+// canaryRolloutState is the state of the canary rollout of an HTTPRoute.
+type canaryRolloutState struct {
+	// Policy is the BackendTrafficPolicy running the rollout.
+	Policy string `json:"policy"`
+	// Generation is the generation of the policy the rollout is run for.
+	Generation int64 `json:"generation"`
+	// Step is the index of the current step of the rollout.
+	Step        int                             `json:"step"`
+	StepStarted time.Time                       `json:"stepStarted"`
+	Phase       gwapiv1a2.PolicyConditionReason `json:"phase"`
+	// Guardrail is the name of the guardrail whose breach rolled the rollout back.
+	Guardrail string `json:"guardrail,omitempty"`
+}
+
+// canaryRolloutController runs the canary rollouts of the BackendTrafficPolicies: it moves
+// the rollout of each targeted HTTPRoute to the next step once the interval of its step
+// elapsed, and rolls it back once a guardrail of the policy is breached, by adjusting the
+// weights of the backendRefs of the rules of the route referencing the canary backend.
+type canaryRolloutController struct {
+	client         client.Client
+	log            logging.Logger
+	resources      *message.ProviderResources
+	queryGuardrail guardrailQuerier
+	now            func() time.Time
+}
+
+// Start moves the canary rollouts forward until the context is done.
+func (c *canaryRolloutController) Start(ctx context.Context) error {
+	ticker := time.NewTicker(canaryRolloutInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			if err := c.reconcile(ctx); err != nil {
+				c.log.Error(err, "failed to roll out the canary backends")
+			}
+		}
+	}
+}
+
+// NeedLeaderElection makes the controller only run on the leader, so that the weights of
+// the routes are adjusted by a single controller.
+func (c *canaryRolloutController) NeedLeaderElection() bool {
+	return true
+}
+
+// reconcile moves the canary rollout of each BackendTrafficPolicy forward, and publishes its
+// progress.
+func (c *canaryRolloutController) reconcile(ctx context.Context) error {
+	policies := new(egv1a1.BackendTrafficPolicyList)
+	if err := c.client.List(ctx, policies); err != nil {
+		return err
+	}
+	var errs error
+	rolling := sets.New[types.NamespacedName]()
+	for i := range policies.Items {
+		policy := &policies.Items[i]
+		if policy.Spec.CanaryRollout == nil {
+			continue
+		}
+		key := utils.NamespacedName(policy)
+		rolling.Insert(key)
+		rollout, err := c.rollout(ctx, policy)
+		errs = errors.Join(errs, err)
+		c.resources.CanaryRollouts.Store(key, rollout)
+	}
+
+	for key := range c.resources.CanaryRollouts.LoadAll() {
+		if !rolling.Has(key) {
+			c.resources.CanaryRollouts.Delete(key)
+		}
+	}
+	return errs
+}
+
+// rollout moves the canary rollout of the HTTPRoutes targeted by the policy forward, and
+// returns the progress of the rollout of the route the least advanced, or rolled back.
+func (c *canaryRolloutController) rollout(ctx context.Context, policy *egv1a1.BackendTrafficPolicy) (*message.CanaryRollout, error) {
+	canary := policy.Spec.CanaryRollout
+	interval := defaultCanaryStepInterval
+	if canary.Interval != nil {
+		if d, err := time.ParseDuration(string(*canary.Interval)); err == nil {
+			interval = d
+		}
+	}
+
+	// The guardrails are only queried once per reconciliation of the policy, and only
+	// if a rollout is progressing.
+	var (
+		queried   bool
+		breached  string
+		queryErr  error
+		guardrail = func() (string, error) {
+			if !queried {
+				queried = true
+				breached, queryErr = c.breachedGuardrail(ctx, canary.Guardrails)
+			}
+			return breached, queryErr
+		}
+	)
+
+	key := utils.NamespacedName(policy).String()
+	progress := &message.CanaryRollout{Generation: policy.Generation, Reason: egv1a1.PolicyReasonCanaryProgressing}
+	var errs error
+	routes := 0
+	for _, ref := range policy.Spec.GetTargetRefs() {
+		if ref.Kind != resource.KindHTTPRoute {
+			continue
+		}
+		route := new(gwapiv1.HTTPRoute)
+		if err := c.client.Get(ctx, types.NamespacedName{Namespace: policy.Namespace, Name: string(ref.Name)}, route); err != nil {
+			if !kerrors.IsNotFound(err) {
+				errs = errors.Join(errs, err)
+			}
+			continue
+		}
+
+		state := canaryStateOf(route)
+		if state == nil || state.Policy != key || state.Generation != policy.Generation {
+			state = &canaryRolloutState{
+				Policy:      key,
+				Generation:  policy.Generation,
+				StepStarted: c.now(),
+				Phase:       egv1a1.PolicyReasonCanaryProgressing,
+			}
+		}
+
+		var msg string
+		if state.Phase == egv1a1.PolicyReasonCanaryProgressing {
+			name, err := guardrail()
+			switch {
+			case err != nil:
+				// The rollout holds its step until the guardrails can be queried.
+				msg = fmt.Sprintf("failed to query the guardrails: %v", err)
+			case name != "":
+				state.Phase = egv1a1.PolicyReasonCanaryRolledBack
+				state.Guardrail = name
+			case c.now().Sub(state.StepStarted) >= interval:
+				if state.Step+1 < len(canary.Steps) {
+					state.Step++
+					state.StepStarted = c.now()
+				} else {
+					state.Phase = egv1a1.PolicyReasonCanarySucceeded
+				}
+			}
+		}
+
+		if err := c.update(ctx, route, canary, state); err != nil {
+			errs = errors.Join(errs, err)
+			continue
+		}
+
+		if msg == "" {
+			msg = canaryMessage(canary, state)
+		}
+		if routes == 0 || canaryPhaseOrder(state.Phase) > canaryPhaseOrder(progress.Reason) {
+			progress.Reason = state.Phase
+			progress.Message = fmt.Sprintf("httproute %s/%s: %s", route.Namespace, route.Name, msg)
+		}
+		routes++
+	}
+
+	if routes == 0 {
+		progress.Message = "none of the targeted HTTPRoutes exist"
+	}
+	return progress, errs
+}
+
+// update sets the weights of the backendRefs of the route for the state of its rollout, and
+// patches the route if they, or the state, changed.
+func (c *canaryRolloutController) update(ctx context.Context, route *gwapiv1.HTTPRoute, canary *egv1a1.CanaryRollout, state *canaryRolloutState) error {
+	data, err := json.Marshal(state)
+	if err != nil {
+		return err
+	}
+	base := route.DeepCopy()
+	if route.Annotations == nil {
+		route.Annotations = make(map[string]string)
+	}
+	route.Annotations[canaryRolloutAnnotation] = string(data)
+
+	var weight int32
+	if state.Phase != egv1a1.PolicyReasonCanaryRolledBack {
+		weight = int32(canary.Steps[min(state.Step, len(canary.Steps)-1)])
+	}
+	setCanaryWeights(route, canary.BackendRef, weight)
+
+	if equality.Semantic.DeepEqual(base, route) {
+		return nil
+	}
+	if err := c.client.Patch(ctx, route, client.MergeFrom(base)); err != nil {
+		return fmt.Errorf("failed to update the canary rollout of httproute %s/%s: %w", route.Namespace, route.Name, err)
+	}
+	return nil
+}
+
+// breachedGuardrail returns the name of the first breached guardrail, if any.
+func (c *canaryRolloutController) breachedGuardrail(ctx context.Context, guardrails []egv1a1.CanaryGuardrail) (string, error) {
+	for _, guardrail := range guardrails {
+		breached, err := c.queryGuardrail(ctx, guardrail.Query)
+		if err != nil {
+			return "", fmt.Errorf("guardrail %s: %w", guardrail.Name, err)
+		}
+		if breached {
+			return guardrail.Name, nil
+		}
+	}
+	return "", nil
+}
+
+// canaryStateOf returns the state of the canary rollout held by the route, if any.
+func canaryStateOf(route *gwapiv1.HTTPRoute) *canaryRolloutState {
+	data, ok := route.Annotations[canaryRolloutAnnotation]
+	if !ok {
+		return nil
+	}
+	state := new(canaryRolloutState)
+	if err := json.Unmarshal([]byte(data), state); err != nil {
+		return nil
+	}
+	return state
+}
+
+// setCanaryWeights routes the given percentage of the requests of the rules of the route
+// referencing the canary backend to it, and shares the rest equally between the other
+// backends of the rules. The rules without other backends are left unchanged.
+func setCanaryWeights(route *gwapiv1.HTTPRoute, canary gwapiv1.BackendObjectReference, percentage int32) {
+	for i := range route.Spec.Rules {
+		refs := route.Spec.Rules[i].BackendRefs
+		index := -1
+		for j := range refs {
+			if isCanaryBackendRef(canary, refs[j].BackendObjectReference, route.Namespace) {
+				index = j
+				break
+			}
+		}
+		if index < 0 || len(refs) == 1 {
+			continue
+		}
+		others := int32(len(refs) - 1)
+		for j := range refs {
+			weight := 100 - percentage
+			if j == index {
+				weight = percentage * others
+			}
+			refs[j].Weight = &weight
+		}
+	}
+}
+
+// isCanaryBackendRef returns true if the backendRef of a route references the canary backend.
+func isCanaryBackendRef(canary, ref gwapiv1.BackendObjectReference, namespace string) bool {
+	return gatewayapi.GroupDerefOr(ref.Group, "") == gatewayapi.GroupDerefOr(canary.Group, "") &&
+		gatewayapi.KindDerefOr(ref.Kind, resource.KindService) == gatewayapi.KindDerefOr(canary.Kind, resource.KindService) &&
+		ref.Name == canary.Name &&
+		gatewayapi.NamespaceDerefOr(ref.Namespace, namespace) == gatewayapi.NamespaceDerefOr(canary.Namespace, namespace) &&
+		(canary.Port == nil || ref.Port != nil && *ref.Port == *canary.Port)
+}
+
+// canaryMessage describes the progress of the canary rollout in the given state.
+func canaryMessage(canary *egv1a1.CanaryRollout, state *canaryRolloutState) string {
+	switch state.Phase {
+	case egv1a1.PolicyReasonCanaryRolledBack:
+		return fmt.Sprintf("guardrail %s breached, the canary backend was rolled back", state.Guardrail)
+	case egv1a1.PolicyReasonCanarySucceeded:
+		return fmt.Sprintf("%d%% of the requests are routed to the canary backend", canary.Steps[len(canary.Steps)-1])
+	default:
+		return fmt.Sprintf("step %d/%d: %d%% of the requests are routed to the canary backend",
+			state.Step+1, len(canary.Steps), canary.Steps[state.Step])
+	}
+}
+
+// canaryPhaseOrder orders the phases of the rollouts of the routes of a policy, so that the
+// progress of the policy is the one of its route the least advanced, or rolled back.
+func canaryPhaseOrder(phase gwapiv1a2.PolicyConditionReason) int {
+	switch phase {
+	case egv1a1.PolicyReasonCanaryRolledBack:
+		return 2
+	case egv1a1.PolicyReasonCanaryProgressing:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// prometheusGuardrailQuerier queries the guardrails from the Prometheus server at the URL.
+func prometheusGuardrailQuerier(url string) (guardrailQuerier, error) {
+	cli, err := prom.NewClient(prom.Config{Address: url})
+	if err != nil {
+		return nil, err
+	}
+	api := prompapiv1.NewAPI(cli)
+	return func(ctx context.Context, query string) (bool, error) {
+		value, _, err := api.Query(ctx, query, time.Now())
+		if err != nil {
+			return false, err
+		}
+		switch v := value.(type) {
+		case model.Vector:
+			return len(v) > 0, nil
+		case model.Matrix:
+			return len(v) > 0, nil
+		default:
+			return false, fmt.Errorf("unsupported value type %s", value.Type())
+		}
+	}, nil
+}
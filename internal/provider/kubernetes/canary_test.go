@@ -0,0 +1,160 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package kubernetes
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	fakeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+	gwapiv1 "sigs.k8s.io/gateway-api/apis/v1"
+	gwapiv1a2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
+
+	egv1a1 "github.com/envoyproxy/gateway/api/v1alpha1"
+	"github.com/envoyproxy/gateway/internal/envoygateway"
+	"github.com/envoyproxy/gateway/internal/logging"
+	"github.com/envoyproxy/gateway/internal/message"
+)
+
+func TestCanaryRolloutController(t *testing.T) {
+	backendRef := func(name string) gwapiv1.HTTPBackendRef {
+		return gwapiv1.HTTPBackendRef{BackendRef: gwapiv1.BackendRef{BackendObjectReference: gwapiv1.BackendObjectReference{
+			Name: gwapiv1.ObjectName(name),
+			Port: ptr.To(gwapiv1.PortNumber(8080)),
+		}}}
+	}
+	route := &gwapiv1.HTTPRoute{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "app"},
+		Spec: gwapiv1.HTTPRouteSpec{
+			Rules: []gwapiv1.HTTPRouteRule{
+				{BackendRefs: []gwapiv1.HTTPBackendRef{backendRef("stable"), backendRef("canary")}},
+				{BackendRefs: []gwapiv1.HTTPBackendRef{backendRef("stable")}},
+			},
+		},
+	}
+	policy := &egv1a1.BackendTrafficPolicy{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "rollout", Generation: 1},
+		Spec: egv1a1.BackendTrafficPolicySpec{
+			PolicyTargetReferences: egv1a1.PolicyTargetReferences{
+				TargetRef: &gwapiv1a2.LocalPolicyTargetReferenceWithSectionName{
+					LocalPolicyTargetReference: gwapiv1a2.LocalPolicyTargetReference{
+						Group: gwapiv1.GroupName,
+						Kind:  "HTTPRoute",
+						Name:  "app",
+					},
+				},
+			},
+			CanaryRollout: &egv1a1.CanaryRollout{
+				BackendRef: gwapiv1.BackendObjectReference{Name: "canary", Port: ptr.To(gwapiv1.PortNumber(8080))},
+				Steps:      []uint32{10, 50},
+				Interval:   ptr.To(gwapiv1.Duration("1m")),
+				Guardrails: []egv1a1.CanaryGuardrail{{Name: "errors", Query: "canary_errors > 0"}},
+			},
+		},
+	}
+	cli := fakeclient.NewClientBuilder().
+		WithScheme(envoygateway.GetScheme()).
+		WithObjects(route, policy).
+		Build()
+
+	var (
+		breached bool
+		queryErr error
+	)
+	now := time.Now()
+	resources := new(message.ProviderResources)
+	c := &canaryRolloutController{
+		client:    cli,
+		log:       logging.DefaultLogger(egv1a1.LogLevelInfo),
+		resources: resources,
+		queryGuardrail: func(_ context.Context, query string) (bool, error) {
+			require.Equal(t, "canary_errors > 0", query)
+			return breached, queryErr
+		},
+		now: func() time.Time { return now },
+	}
+	ctx := context.Background()
+	key := types.NamespacedName{Namespace: "default", Name: "rollout"}
+	weights := func() [][]int32 {
+		got := new(gwapiv1.HTTPRoute)
+		require.NoError(t, cli.Get(ctx, types.NamespacedName{Namespace: "default", Name: "app"}, got))
+		var weights [][]int32
+		for _, rule := range got.Spec.Rules {
+			var ruleWeights []int32
+			for _, ref := range rule.BackendRefs {
+				ruleWeights = append(ruleWeights, ptr.Deref(ref.Weight, -1))
+			}
+			weights = append(weights, ruleWeights)
+		}
+		return weights
+	}
+	progress := func() *message.CanaryRollout {
+		rollout, ok := resources.CanaryRollouts.Load(key)
+		require.True(t, ok)
+		return rollout
+	}
+
+	// The canary backend receives the requests of the first step, while the rules not
+	// referencing it are left unchanged.
+	require.NoError(t, c.reconcile(ctx))
+	require.Equal(t, [][]int32{{90, 10}, {-1}}, weights())
+	require.Equal(t, &message.CanaryRollout{
+		Generation: 1,
+		Reason:     egv1a1.PolicyReasonCanaryProgressing,
+		Message:    "httproute default/app: step 1/2: 10% of the requests are routed to the canary backend",
+	}, progress())
+
+	// The rollout holds its step while the guardrails can't be queried.
+	queryErr = errors.New("unreachable")
+	now = now.Add(time.Minute)
+	require.NoError(t, c.reconcile(ctx))
+	require.Equal(t, [][]int32{{90, 10}, {-1}}, weights())
+	require.Equal(t, "httproute default/app: failed to query the guardrails: guardrail errors: unreachable", progress().Message)
+
+	// The rollout moves to the next step once the interval of its step elapsed, and succeeds
+	// once the last step lasted the interval.
+	queryErr = nil
+	require.NoError(t, c.reconcile(ctx))
+	require.Equal(t, [][]int32{{50, 50}, {-1}}, weights())
+	require.Equal(t, egv1a1.PolicyReasonCanaryProgressing, progress().Reason)
+	now = now.Add(time.Minute)
+	require.NoError(t, c.reconcile(ctx))
+	require.Equal(t, [][]int32{{50, 50}, {-1}}, weights())
+	require.Equal(t, &message.CanaryRollout{
+		Generation: 1,
+		Reason:     egv1a1.PolicyReasonCanarySucceeded,
+		Message:    "httproute default/app: 50% of the requests are routed to the canary backend",
+	}, progress())
+
+	// The rollout starts over once the policy changes, and is rolled back once a guardrail
+	// is breached.
+	changed := new(egv1a1.BackendTrafficPolicy)
+	require.NoError(t, cli.Get(ctx, key, changed))
+	changed.Generation = 2
+	require.NoError(t, cli.Update(ctx, changed))
+	require.NoError(t, c.reconcile(ctx))
+	require.Equal(t, [][]int32{{90, 10}, {-1}}, weights())
+	breached = true
+	require.NoError(t, c.reconcile(ctx))
+	require.Equal(t, [][]int32{{100, 0}, {-1}}, weights())
+	require.Equal(t, &message.CanaryRollout{
+		Generation: 2,
+		Reason:     egv1a1.PolicyReasonCanaryRolledBack,
+		Message:    "httproute default/app: guardrail errors breached, the canary backend was rolled back",
+	}, progress())
+
+	// The progress of the deleted policies is no longer published.
+	require.NoError(t, cli.Delete(ctx, changed))
+	require.NoError(t, c.reconcile(ctx))
+	_, ok := resources.CanaryRollouts.Load(key)
+	require.False(t, ok)
+}
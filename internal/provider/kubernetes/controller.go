@@ -8,6 +8,8 @@ package kubernetes
 import (
 	"context"
 	"fmt"
+	"sync"
+	"time"
 
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
@@ -20,6 +22,7 @@ import (
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/tools/record"
 	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
@@ -40,6 +43,7 @@ import (
 	"github.com/envoyproxy/gateway/internal/gatewayapi"
 	"github.com/envoyproxy/gateway/internal/gatewayapi/resource"
 	"github.com/envoyproxy/gateway/internal/gatewayapi/status"
+	kube "github.com/envoyproxy/gateway/internal/kubernetes"
 	"github.com/envoyproxy/gateway/internal/logging"
 	"github.com/envoyproxy/gateway/internal/message"
 	"github.com/envoyproxy/gateway/internal/utils"
@@ -55,6 +59,7 @@ type gatewayAPIReconciler struct {
 	client            client.Client
 	log               logging.Logger
 	statusUpdater     Updater
+	eventRecorder     record.EventRecorder
 	classController   gwapiv1.GatewayController
 	store             *kubernetesProviderStore
 	namespace         string
@@ -64,6 +69,8 @@ type gatewayAPIReconciler struct {
 	resources         *message.ProviderResources
 	extGVKs           []schema.GroupVersionKind
 	extServerPolicies []schema.GroupVersionKind
+	// reconcileMu serializes the reconciliations of the controllers.
+	reconcileMu *sync.Mutex
 }
 
 // newGatewayAPIController
@@ -93,36 +100,93 @@ func newGatewayAPIController(mgr manager.Manager, cfg *config.Server, su Updater
 		(cfg.EnvoyGateway.Provider.Kubernetes.Watch.NamespaceSelector.MatchLabels != nil ||
 			len(cfg.EnvoyGateway.Provider.Kubernetes.Watch.NamespaceSelector.MatchExpressions) > 0)
 
-	r := &gatewayAPIReconciler{
-		client:            mgr.GetClient(),
-		log:               cfg.Logger,
-		classController:   gwapiv1.GatewayController(cfg.EnvoyGateway.Gateway.ControllerName),
-		namespace:         cfg.Namespace,
-		statusUpdater:     su,
-		resources:         resources,
-		extGVKs:           extGVKs,
-		store:             newProviderStore(),
-		envoyGateway:      cfg.EnvoyGateway,
-		mergeGateways:     sets.New[string](),
-		extServerPolicies: extServerPoliciesGVKs,
+	if err := addIndexers(ctx, mgr); err != nil {
+		return err
 	}
 
-	if byNamespaceSelector {
-		r.namespaceLabel = cfg.EnvoyGateway.Provider.Kubernetes.Watch.NamespaceSelector
+	// The reconcilers of the controllers share the store and the merged GatewayClasses,
+	// which are read by the status updates, and reconcile one at a time.
+	store := newProviderStore()
+	mergeGateways := sets.New[string]()
+	reconcileMu := &sync.Mutex{}
+	for i, controllerName := range cfg.EnvoyGateway.Gateway.ControllerNames() {
+		r := &gatewayAPIReconciler{
+			client:            mgr.GetClient(),
+			log:               cfg.Logger,
+			classController:   gwapiv1.GatewayController(controllerName),
+			namespace:         cfg.Namespace,
+			statusUpdater:     su,
+			eventRecorder:     mgr.GetEventRecorderFor("envoy-gateway"),
+			resources:         resources,
+			extGVKs:           extGVKs,
+			store:             store,
+			envoyGateway:      cfg.EnvoyGateway,
+			mergeGateways:     mergeGateways,
+			reconcileMu:       reconcileMu,
+			extServerPolicies: extServerPoliciesGVKs,
+		}
+
+		if byNamespaceSelector {
+			r.namespaceLabel = cfg.EnvoyGateway.Provider.Kubernetes.Watch.NamespaceSelector
+		}
+
+		name := "gatewayapi"
+		if i > 0 {
+			name = fmt.Sprintf("gatewayapi-%d", i)
+		}
+		c, err := controller.New(name, mgr, controller.Options{Reconciler: r, SkipNameValidation: skipNameValidation()})
+		if err != nil {
+			return fmt.Errorf("error creating controller: %w", err)
+		}
+		r.log.Info("created gatewayapi controller", "controllerName", controllerName)
+
+		// Subscribe to status updates, which hold the statuses of the resources of
+		// all the controllers.
+		if i == 0 {
+			r.subscribeAndUpdateStatus(ctx, cfg.EnvoyGateway.EnvoyGatewaySpec.ExtensionManager != nil)
+		}
+
+		// Watch resources
+		if err := r.watchResources(ctx, mgr, c); err != nil {
+			return fmt.Errorf("error watching resources: %w", err)
+		}
 	}
 
-	c, err := controller.New("gatewayapi", mgr, controller.Options{Reconciler: r, SkipNameValidation: skipNameValidation()})
+	// Drain the proxies of the draining Gateways before their deletion.
+	cli, err := kube.NewForRestConfig(mgr.GetConfig())
 	if err != nil {
-		return fmt.Errorf("error creating controller: %w", err)
+		return fmt.Errorf("error creating the client counting the connections of the proxies: %w", err)
+	}
+	if err := mgr.Add(&gatewayDrainMonitor{
+		client:           mgr.GetClient(),
+		podReader:        mgr.GetAPIReader(),
+		log:              cfg.Logger,
+		controllers:      sets.New(cfg.EnvoyGateway.Gateway.ControllerNames()...),
+		namespace:        cfg.Namespace,
+		mergeGateways:    mergeGateways,
+		resources:        resources,
+		countConnections: kubernetesConnectionCounter(cli),
+		started:          make(map[string]time.Time),
+		now:              time.Now,
+	}); err != nil {
+		return fmt.Errorf("error adding the gateway drain monitor: %w", err)
 	}
-	r.log.Info("created gatewayapi controller")
-
-	// Subscribe to status updates
-	r.subscribeAndUpdateStatus(ctx, cfg.EnvoyGateway.EnvoyGatewaySpec.ExtensionManager != nil)
 
-	// Watch resources
-	if err := r.watchResources(ctx, mgr, c); err != nil {
-		return fmt.Errorf("error watching resources: %w", err)
+	// Run the canary rollouts of the BackendTrafficPolicies, if enabled.
+	if canary := cfg.EnvoyGateway.CanaryRollout; canary != nil {
+		queryGuardrail, err := prometheusGuardrailQuerier(canary.PrometheusURL)
+		if err != nil {
+			return fmt.Errorf("error creating the client querying the canary guardrails: %w", err)
+		}
+		if err := mgr.Add(&canaryRolloutController{
+			client:         mgr.GetClient(),
+			log:            cfg.Logger,
+			resources:      resources,
+			queryGuardrail: queryGuardrail,
+			now:            time.Now,
+		}); err != nil {
+			return fmt.Errorf("error adding the canary rollout controller: %w", err)
+		}
 	}
 	return nil
 }
@@ -176,7 +240,14 @@ func (r *gatewayAPIReconciler) Reconcile(ctx context.Context, _ reconcile.Reques
 		managedGCs []*gwapiv1.GatewayClass
 		err        error
 	)
-	r.log.Info("reconciling gateways")
+	r.reconcileMu.Lock()
+	defer r.reconcileMu.Unlock()
+
+	r.log.Info("reconciling gateways", "controllerName", r.classController)
+
+	// Trace the updates caused by the reconciliation up to the proxies.
+	ctx, span := message.StartSpan(ctx, message.Metadata{Runner: string(egv1a1.LogComponentProviderRunner), Message: "provider-resources"})
+	defer span.End()
 
 	// Get the GatewayClasses managed by the Envoy Gateway Controller.
 	managedGCs, err = r.managedGatewayClasses(ctx)
@@ -234,8 +305,8 @@ func (r *gatewayAPIReconciler) Reconcile(ctx context.Context, _ reconcile.Reques
 			return reconcile.Result{}, err
 		}
 
-		// Add all BackendTrafficPolicies to the resourceTree
-		if err = r.processBackendTrafficPolicies(ctx, gwcResource); err != nil {
+		// Add all BackendTrafficPolicies and their referenced resources to the resourceTree
+		if err = r.processBackendTrafficPolicies(ctx, gwcResource, resourceMappings); err != nil {
 			return reconcile.Result{}, err
 		}
 
@@ -321,7 +392,7 @@ func (r *gatewayAPIReconciler) Reconcile(ctx context.Context, _ reconcile.Reques
 	// The Store is triggered even when there are no Gateways associated to the
 	// GatewayClass. This would happen in case the last Gateway is removed and the
 	// Store will be required to trigger a cleanup of envoy infra resources.
-	r.resources.GatewayAPIResources.Store(string(r.classController), &gwcResources)
+	r.resources.GatewayAPIResources.StoreTraced(ctx, string(r.classController), &gwcResources)
 
 	r.log.Info("reconciled gateways successfully")
 	return reconcile.Result{}, nil
@@ -937,8 +1008,10 @@ func (r *gatewayAPIReconciler) processClientTrafficPolicies(
 	return nil
 }
 
-// processBackendTrafficPolicies adds BackendTrafficPolicies to the resourceTree
-func (r *gatewayAPIReconciler) processBackendTrafficPolicies(ctx context.Context, resourceTree *resource.Resources) error {
+// processBackendTrafficPolicies adds BackendTrafficPolicies and their referenced resources to the resourceTree
+func (r *gatewayAPIReconciler) processBackendTrafficPolicies(
+	ctx context.Context, resourceTree *resource.Resources, resourceMap *resourceMappings,
+) error {
 	backendTrafficPolicies := egv1a1.BackendTrafficPolicyList{}
 	if err := r.client.List(ctx, &backendTrafficPolicies); err != nil {
 		return fmt.Errorf("error listing BackendTrafficPolicies: %w", err)
@@ -951,9 +1024,115 @@ func (r *gatewayAPIReconciler) processBackendTrafficPolicies(ctx context.Context
 		policy.Status = gwapiv1a2.PolicyStatus{}
 		resourceTree.BackendTrafficPolicies = append(resourceTree.BackendTrafficPolicies, &policy)
 	}
+
+	// Add the referenced Resources in BackendTrafficPolicies to the resourceTree
+	r.processBackendTrafficPolicyObjectRefs(ctx, resourceTree, resourceMap)
 	return nil
 }
 
+// processBackendTrafficPolicyObjectRefs adds the referenced resources in BackendTrafficPolicies
+// to the resourceTree
+// - BackendRefs for the buckets of Experiments and the HMAC RequestSigning
+// - Secrets for the HMAC RequestSigning
+// - Secrets for the CredentialInjection
+func (r *gatewayAPIReconciler) processBackendTrafficPolicyObjectRefs(
+	ctx context.Context, resourceTree *resource.Resources, resourceMap *resourceMappings,
+) {
+	// we don't return errors from this method, because we want to continue reconciling
+	// the rest of the BackendTrafficPolicies despite that one reference is invalid.
+	//
+	// This BackendTrafficPolicy will be marked as invalid in its status when translating
+	// to IR because the referenced service can't be found.
+	for _, policy := range resourceTree.BackendTrafficPolicies {
+		// Add the referenced Secret in the HMAC RequestSigning to the resourceTree
+		if rs := policy.Spec.RequestSigning; rs != nil && rs.HMAC != nil {
+			if err := r.processSecretRef(
+				ctx,
+				resourceMap,
+				resourceTree,
+				resource.KindBackendTrafficPolicy,
+				policy.Namespace,
+				policy.Name,
+				rs.HMAC.Key); err != nil {
+				r.log.Error(err,
+					"failed to process RequestSigning SecretRef for BackendTrafficPolicy",
+					"policy", policy, "secretRef", rs.HMAC.Key)
+			}
+		}
+
+		// Add the referenced Secrets in the CredentialInjection to the resourceTree
+		if ci := policy.Spec.CredentialInjection; ci != nil {
+			var secretRefs []gwapiv1b1.SecretObjectReference
+			if ci.BearerToken != nil {
+				secretRefs = append(secretRefs, ci.BearerToken.Token)
+			}
+			if ci.OAuth2ClientCredentials != nil {
+				secretRefs = append(secretRefs, ci.OAuth2ClientCredentials.ClientSecret)
+			}
+			for _, secretRef := range secretRefs {
+				if err := r.processSecretRef(
+					ctx,
+					resourceMap,
+					resourceTree,
+					resource.KindBackendTrafficPolicy,
+					policy.Namespace,
+					policy.Name,
+					secretRef); err != nil {
+					r.log.Error(err,
+						"failed to process CredentialInjection SecretRef for BackendTrafficPolicy",
+						"policy", policy, "secretRef", secretRef)
+				}
+			}
+		}
+
+		var backendRefs []egv1a1.BackendRef
+		if policy.Spec.Experiment != nil {
+			for _, bucket := range policy.Spec.Experiment.Buckets {
+				backendRefs = append(backendRefs, bucket.BackendRefs...)
+			}
+		}
+		if rs := policy.Spec.RequestSigning; rs != nil && rs.HMAC != nil {
+			backendRefs = append(backendRefs, rs.HMAC.BackendRefs...)
+		}
+		for _, br := range backendRefs {
+			backendRef := br.BackendObjectReference
+
+			backendNamespace := gatewayapi.NamespaceDerefOr(backendRef.Namespace, policy.Namespace)
+			resourceMap.allAssociatedBackendRefs.Insert(gwapiv1.BackendObjectReference{
+				Group:     backendRef.Group,
+				Kind:      backendRef.Kind,
+				Namespace: gatewayapi.NamespacePtr(backendNamespace),
+				Name:      backendRef.Name,
+			})
+
+			if backendNamespace != policy.Namespace {
+				from := ObjectKindNamespacedName{
+					kind:      resource.KindBackendTrafficPolicy,
+					namespace: policy.Namespace,
+					name:      policy.Name,
+				}
+				to := ObjectKindNamespacedName{
+					kind:      gatewayapi.KindDerefOr(backendRef.Kind, resource.KindService),
+					namespace: backendNamespace,
+					name:      string(backendRef.Name),
+				}
+				refGrant, err := r.findReferenceGrant(ctx, from, to)
+				switch {
+				case err != nil:
+					r.log.Error(err, "failed to find ReferenceGrant")
+				case refGrant == nil:
+					r.log.Info("no matching ReferenceGrants found", "from", from.kind,
+						"from namespace", from.namespace, "target", to.kind, "target namespace", to.namespace)
+				default:
+					resourceTree.ReferenceGrants = append(resourceTree.ReferenceGrants, refGrant)
+					r.log.Info("added ReferenceGrant to resource map", "namespace", refGrant.Namespace,
+						"name", refGrant.Name)
+				}
+			}
+		}
+	}
+}
+
 // processSecurityPolicies adds SecurityPolicies and their referenced resources to the resourceTree
 func (r *gatewayAPIReconciler) processSecurityPolicies(
 	ctx context.Context, resourceTree *resource.Resources, resourceMap *resourceMappings,
@@ -1078,14 +1257,12 @@ func (r *gatewayAPIReconciler) watchResources(ctx context.Context, mgr manager.M
 			epPredicates...)); err != nil {
 		return err
 	}
-	if err := addEnvoyProxyIndexers(ctx, mgr); err != nil {
-		return err
-	}
 
 	// Watch Gateway CRUDs and reconcile affected GatewayClass.
 	gPredicates := []predicate.TypedPredicate[*gwapiv1.Gateway]{
 		predicate.Or(predicate.TypedGenerationChangedPredicate[*gwapiv1.Gateway]{},
-			predicate.TypedLabelChangedPredicate[*gwapiv1.Gateway]{}),
+			predicate.TypedLabelChangedPredicate[*gwapiv1.Gateway]{},
+			predicate.TypedAnnotationChangedPredicate[*gwapiv1.Gateway]{}),
 		predicate.NewTypedPredicateFuncs(func(gtw *gwapiv1.Gateway) bool {
 			return r.validateGatewayForReconcile(gtw)
 		}),
@@ -1103,14 +1280,12 @@ func (r *gatewayAPIReconciler) watchResources(ctx context.Context, mgr manager.M
 			gPredicates...)); err != nil {
 		return err
 	}
-	if err := addGatewayIndexers(ctx, mgr); err != nil {
-		return err
-	}
 
 	// Watch HTTPRoute CRUDs and process affected Gateways.
 	httprPredicates := []predicate.TypedPredicate[*gwapiv1.HTTPRoute]{
 		predicate.Or(predicate.TypedGenerationChangedPredicate[*gwapiv1.HTTPRoute]{},
-			predicate.TypedLabelChangedPredicate[*gwapiv1.HTTPRoute]{}),
+			predicate.TypedLabelChangedPredicate[*gwapiv1.HTTPRoute]{},
+			predicate.TypedAnnotationChangedPredicate[*gwapiv1.HTTPRoute]{}),
 	}
 	if r.namespaceLabel != nil {
 		httprPredicates = append(httprPredicates, predicate.NewTypedPredicateFuncs(func(hr *gwapiv1.HTTPRoute) bool {
@@ -1125,14 +1300,12 @@ func (r *gatewayAPIReconciler) watchResources(ctx context.Context, mgr manager.M
 			httprPredicates...)); err != nil {
 		return err
 	}
-	if err := addHTTPRouteIndexers(ctx, mgr); err != nil {
-		return err
-	}
 
 	// Watch GRPCRoute CRUDs and process affected Gateways.
 	grpcrPredicates := []predicate.TypedPredicate[*gwapiv1.GRPCRoute]{
 		predicate.Or(predicate.TypedGenerationChangedPredicate[*gwapiv1.GRPCRoute]{},
-			predicate.TypedLabelChangedPredicate[*gwapiv1.GRPCRoute]{}),
+			predicate.TypedLabelChangedPredicate[*gwapiv1.GRPCRoute]{},
+			predicate.TypedAnnotationChangedPredicate[*gwapiv1.GRPCRoute]{}),
 	}
 	if r.namespaceLabel != nil {
 		grpcrPredicates = append(grpcrPredicates, predicate.NewTypedPredicateFuncs[*gwapiv1.GRPCRoute](func(grpc *gwapiv1.GRPCRoute) bool {
@@ -1147,14 +1320,12 @@ func (r *gatewayAPIReconciler) watchResources(ctx context.Context, mgr manager.M
 			grpcrPredicates...)); err != nil {
 		return err
 	}
-	if err := addGRPCRouteIndexers(ctx, mgr); err != nil {
-		return err
-	}
 
 	// Watch TLSRoute CRUDs and process affected Gateways.
 	tlsrPredicates := []predicate.TypedPredicate[*gwapiv1a2.TLSRoute]{
 		predicate.Or(predicate.TypedGenerationChangedPredicate[*gwapiv1a2.TLSRoute]{},
-			predicate.TypedLabelChangedPredicate[*gwapiv1a2.TLSRoute]{}),
+			predicate.TypedLabelChangedPredicate[*gwapiv1a2.TLSRoute]{},
+			predicate.TypedAnnotationChangedPredicate[*gwapiv1a2.TLSRoute]{}),
 	}
 	if r.namespaceLabel != nil {
 		tlsrPredicates = append(tlsrPredicates, predicate.NewTypedPredicateFuncs[*gwapiv1a2.TLSRoute](func(route *gwapiv1a2.TLSRoute) bool {
@@ -1169,14 +1340,12 @@ func (r *gatewayAPIReconciler) watchResources(ctx context.Context, mgr manager.M
 			tlsrPredicates...)); err != nil {
 		return err
 	}
-	if err := addTLSRouteIndexers(ctx, mgr); err != nil {
-		return err
-	}
 
 	// Watch UDPRoute CRUDs and process affected Gateways.
 	udprPredicates := []predicate.TypedPredicate[*gwapiv1a2.UDPRoute]{
 		predicate.Or(predicate.TypedGenerationChangedPredicate[*gwapiv1a2.UDPRoute]{},
-			predicate.TypedLabelChangedPredicate[*gwapiv1a2.UDPRoute]{}),
+			predicate.TypedLabelChangedPredicate[*gwapiv1a2.UDPRoute]{},
+			predicate.TypedAnnotationChangedPredicate[*gwapiv1a2.UDPRoute]{}),
 	}
 	if r.namespaceLabel != nil {
 		udprPredicates = append(udprPredicates, predicate.NewTypedPredicateFuncs[*gwapiv1a2.UDPRoute](func(route *gwapiv1a2.UDPRoute) bool {
@@ -1191,14 +1360,12 @@ func (r *gatewayAPIReconciler) watchResources(ctx context.Context, mgr manager.M
 			udprPredicates...)); err != nil {
 		return err
 	}
-	if err := addUDPRouteIndexers(ctx, mgr); err != nil {
-		return err
-	}
 
 	// Watch TCPRoute CRUDs and process affected Gateways.
 	tcprPredicates := []predicate.TypedPredicate[*gwapiv1a2.TCPRoute]{
 		predicate.Or(predicate.TypedGenerationChangedPredicate[*gwapiv1a2.TCPRoute]{},
-			predicate.TypedLabelChangedPredicate[*gwapiv1a2.TCPRoute]{}),
+			predicate.TypedLabelChangedPredicate[*gwapiv1a2.TCPRoute]{},
+			predicate.TypedAnnotationChangedPredicate[*gwapiv1a2.TCPRoute]{}),
 	}
 	if r.namespaceLabel != nil {
 		tcprPredicates = append(tcprPredicates, predicate.NewTypedPredicateFuncs[*gwapiv1a2.TCPRoute](func(route *gwapiv1a2.TCPRoute) bool {
@@ -1213,9 +1380,6 @@ func (r *gatewayAPIReconciler) watchResources(ctx context.Context, mgr manager.M
 			tcprPredicates...)); err != nil {
 		return err
 	}
-	if err := addTCPRouteIndexers(ctx, mgr); err != nil {
-		return err
-	}
 
 	// Watch Service CRUDs and process affected *Route objects.
 	servicePredicates := []predicate.TypedPredicate[*corev1.Service]{
@@ -1344,7 +1508,7 @@ func (r *gatewayAPIReconciler) watchResources(ctx context.Context, mgr manager.M
 		return err
 	}
 
-	// Watch ConfigMap CRUDs and process affected ClienTraffiPolicies and BackendTLSPolicies.
+	// Watch ConfigMap CRUDs and process affected ClienTraffiPolicies, BackendTLSPolicies and EnvoyExtensionPolicies.
 	configMapPredicates := []predicate.TypedPredicate[*corev1.ConfigMap]{
 		predicate.NewTypedPredicateFuncs[*corev1.ConfigMap](func(cm *corev1.ConfigMap) bool {
 			return r.validateConfigMapForReconcile(cm)
@@ -1381,9 +1545,6 @@ func (r *gatewayAPIReconciler) watchResources(ctx context.Context, mgr manager.M
 			rgPredicates...)); err != nil {
 		return err
 	}
-	if err := addReferenceGrantIndexers(ctx, mgr); err != nil {
-		return err
-	}
 
 	// Watch Deployment CRUDs and process affected Gateways.
 	dPredicates := []predicate.TypedPredicate[*appsv1.Deployment]{
@@ -1445,10 +1606,6 @@ func (r *gatewayAPIReconciler) watchResources(ctx context.Context, mgr manager.M
 		return err
 	}
 
-	if err := addCtpIndexers(ctx, mgr); err != nil {
-		return err
-	}
-
 	// Watch BackendTrafficPolicy
 	btpPredicates := []predicate.TypedPredicate[*egv1a1.BackendTrafficPolicy]{
 		predicate.TypedGenerationChangedPredicate[*egv1a1.BackendTrafficPolicy]{},
@@ -1486,9 +1643,6 @@ func (r *gatewayAPIReconciler) watchResources(ctx context.Context, mgr manager.M
 			spPredicates...)); err != nil {
 		return err
 	}
-	if err := addSecurityPolicyIndexers(ctx, mgr); err != nil {
-		return err
-	}
 
 	// Watch BackendTLSPolicy
 	btlsPredicates := []predicate.TypedPredicate[*gwapiv1a3.BackendTLSPolicy]{
@@ -1509,10 +1663,6 @@ func (r *gatewayAPIReconciler) watchResources(ctx context.Context, mgr manager.M
 		return err
 	}
 
-	if err := addBtlsIndexers(ctx, mgr); err != nil {
-		return err
-	}
-
 	// Watch EnvoyExtensionPolicy
 	eepPredicates := []predicate.TypedPredicate[*egv1a1.EnvoyExtensionPolicy]{
 		predicate.TypedGenerationChangedPredicate[*egv1a1.EnvoyExtensionPolicy]{},
@@ -1532,9 +1682,6 @@ func (r *gatewayAPIReconciler) watchResources(ctx context.Context, mgr manager.M
 			eepPredicates...)); err != nil {
 		return err
 	}
-	if err := addEnvoyExtensionPolicyIndexers(ctx, mgr); err != nil {
-		return err
-	}
 
 	r.log.Info("Watching gatewayAPI related objects")
 
@@ -1684,6 +1831,9 @@ func (r *gatewayAPIReconciler) processEnvoyProxy(ep *egv1a1.EnvoyProxy, resource
 	if err := validation.ValidateEnvoyProxy(ep); err != nil {
 		return fmt.Errorf("invalid envoyproxy: %w", err)
 	}
+	if err := validation.ValidateEnvoyProxyNodeHash(r.envoyGateway, ep); err != nil {
+		return fmt.Errorf("invalid envoyproxy: %w", err)
+	}
 	if err := bootstrap.Validate(ep.Spec.Bootstrap); err != nil {
 		return fmt.Errorf("invalid envoyproxy: %w", err)
 	}
@@ -1872,7 +2022,7 @@ func (r *gatewayAPIReconciler) processExtensionServerPolicies(
 
 // processEnvoyExtensionPolicyObjectRefs adds the referenced resources in EnvoyExtensionPolicies
 // to the resourceTree
-// - BackendRefs for ExtProcs
+// - BackendRefs for ExtProcs and OpenAPIValidation
 // - SecretRefs for Wasms
 func (r *gatewayAPIReconciler) processEnvoyExtensionPolicyObjectRefs(
 	ctx context.Context, resourceTree *resource.Resources, resourceMap *resourceMappings,
@@ -1886,41 +2036,46 @@ func (r *gatewayAPIReconciler) processEnvoyExtensionPolicyObjectRefs(
 	// to IR because the referenced service can't be found.
 	for _, policy := range resourceTree.EnvoyExtensionPolicies {
 		// Add the referenced BackendRefs and ReferenceGrants in ExtAuth to Maps for later processing
+		var backendRefs []egv1a1.BackendRef
 		for _, ep := range policy.Spec.ExtProc {
-			for _, br := range ep.BackendRefs {
-				backendRef := br.BackendObjectReference
-
-				backendNamespace := gatewayapi.NamespaceDerefOr(backendRef.Namespace, policy.Namespace)
-				resourceMap.allAssociatedBackendRefs.Insert(gwapiv1.BackendObjectReference{
-					Group:     backendRef.Group,
-					Kind:      backendRef.Kind,
-					Namespace: gatewayapi.NamespacePtr(backendNamespace),
-					Name:      backendRef.Name,
-				})
-
-				if backendNamespace != policy.Namespace {
-					from := ObjectKindNamespacedName{
-						kind:      resource.KindHTTPRoute,
-						namespace: policy.Namespace,
-						name:      policy.Name,
-					}
-					to := ObjectKindNamespacedName{
-						kind:      gatewayapi.KindDerefOr(backendRef.Kind, resource.KindService),
-						namespace: backendNamespace,
-						name:      string(backendRef.Name),
-					}
-					refGrant, err := r.findReferenceGrant(ctx, from, to)
-					switch {
-					case err != nil:
-						r.log.Error(err, "failed to find ReferenceGrant")
-					case refGrant == nil:
-						r.log.Info("no matching ReferenceGrants found", "from", from.kind,
-							"from namespace", from.namespace, "target", to.kind, "target namespace", to.namespace)
-					default:
-						resourceTree.ReferenceGrants = append(resourceTree.ReferenceGrants, refGrant)
-						r.log.Info("added ReferenceGrant to resource map", "namespace", refGrant.Namespace,
-							"name", refGrant.Name)
-					}
+			backendRefs = append(backendRefs, ep.BackendRefs...)
+		}
+		if validation := policy.Spec.OpenAPIValidation; validation != nil {
+			backendRefs = append(backendRefs, validation.BackendRefs...)
+		}
+		for _, br := range backendRefs {
+			backendRef := br.BackendObjectReference
+
+			backendNamespace := gatewayapi.NamespaceDerefOr(backendRef.Namespace, policy.Namespace)
+			resourceMap.allAssociatedBackendRefs.Insert(gwapiv1.BackendObjectReference{
+				Group:     backendRef.Group,
+				Kind:      backendRef.Kind,
+				Namespace: gatewayapi.NamespacePtr(backendNamespace),
+				Name:      backendRef.Name,
+			})
+
+			if backendNamespace != policy.Namespace {
+				from := ObjectKindNamespacedName{
+					kind:      resource.KindHTTPRoute,
+					namespace: policy.Namespace,
+					name:      policy.Name,
+				}
+				to := ObjectKindNamespacedName{
+					kind:      gatewayapi.KindDerefOr(backendRef.Kind, resource.KindService),
+					namespace: backendNamespace,
+					name:      string(backendRef.Name),
+				}
+				refGrant, err := r.findReferenceGrant(ctx, from, to)
+				switch {
+				case err != nil:
+					r.log.Error(err, "failed to find ReferenceGrant")
+				case refGrant == nil:
+					r.log.Info("no matching ReferenceGrants found", "from", from.kind,
+						"from namespace", from.namespace, "target", to.kind, "target namespace", to.namespace)
+				default:
+					resourceTree.ReferenceGrants = append(resourceTree.ReferenceGrants, refGrant)
+					r.log.Info("added ReferenceGrant to resource map", "namespace", refGrant.Namespace,
+						"name", refGrant.Name)
 				}
 			}
 		}
@@ -1942,5 +2097,24 @@ func (r *gatewayAPIReconciler) processEnvoyExtensionPolicyObjectRefs(
 				}
 			}
 		}
+
+		// Add the referenced ConfigMap holding the OpenAPI document to the resourceTree
+		if validation := policy.Spec.OpenAPIValidation; validation != nil {
+			if err := r.processConfigMapRef(
+				ctx,
+				resourceMap,
+				resourceTree,
+				resource.KindEnvoyExtensionPolicy,
+				policy.Namespace,
+				policy.Name,
+				gwapiv1b1.SecretObjectReference{
+					Kind: gatewayapi.KindPtr(resource.KindConfigMap),
+					Name: validation.ConfigMapRef.Name,
+				}); err != nil {
+				r.log.Error(err,
+					"failed to process OpenAPIValidation ConfigMapRef for EnvoyExtensionPolicy",
+					"policy", policy, "configMapRef", validation.ConfigMapRef)
+			}
+		}
 	}
 }
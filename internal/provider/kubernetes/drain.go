@@ -0,0 +1,233 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package kubernetes
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	gwapiv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	"github.com/envoyproxy/gateway/internal/gatewayapi"
+	kube "github.com/envoyproxy/gateway/internal/kubernetes"
+	"github.com/envoyproxy/gateway/internal/logging"
+	"github.com/envoyproxy/gateway/internal/message"
+	"github.com/envoyproxy/gateway/internal/utils"
+	"github.com/envoyproxy/gateway/internal/utils/slice"
+	"github.com/envoyproxy/gateway/internal/xds/bootstrap"
+)
+
+// gatewayDrainInterval is how often the connections of the proxies of the draining Gateways
+// are counted.
+const gatewayDrainInterval = 10 * time.Second
+
+// connectionCounter returns the number of connections open on the proxy pod.
+type connectionCounter func(ctx context.Context, pod types.NamespacedName) (uint64, error)
+
+// gatewayDrainMonitor counts the connections left open on the proxies of the draining Gateways,
+// and holds the deletion of the Gateways with a finalizer until their connections are closed,
+// or their drain times out. The Gateways whose proxies are merged are not drained.
+type gatewayDrainMonitor struct {
+	client client.Client
+	// podReader lists the proxy pods, which are not cached.
+	podReader        client.Reader
+	log              logging.Logger
+	controllers      sets.Set[string]
+	namespace        string
+	mergeGateways    sets.Set[string]
+	resources        *message.ProviderResources
+	countConnections connectionCounter
+	// started holds when each draining Gateway was first seen draining.
+	started map[string]time.Time
+	now     func() time.Time
+}
+
+// Start counts the connections of the proxies of the draining Gateways until the context is done.
+func (m *gatewayDrainMonitor) Start(ctx context.Context) error {
+	ticker := time.NewTicker(gatewayDrainInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			if err := m.reconcile(ctx); err != nil {
+				m.log.Error(err, "failed to drain the gateways")
+			}
+		}
+	}
+}
+
+// NeedLeaderElection makes the monitor only run on the leader, which holds the finalizers.
+func (m *gatewayDrainMonitor) NeedLeaderElection() bool {
+	return true
+}
+
+// reconcile publishes the drain of the proxies of each draining Gateway, and removes the drain
+// finalizer of the Gateways no longer draining.
+func (m *gatewayDrainMonitor) reconcile(ctx context.Context) error {
+	classes := new(gwapiv1.GatewayClassList)
+	if err := m.client.List(ctx, classes); err != nil {
+		return err
+	}
+	managed := sets.New[string]()
+	for _, gc := range classes.Items {
+		if m.controllers.Has(string(gc.Spec.ControllerName)) && !m.mergeGateways.Has(gc.Name) {
+			managed.Insert(gc.Name)
+		}
+	}
+
+	gateways := new(gwapiv1.GatewayList)
+	if err := m.client.List(ctx, gateways); err != nil {
+		return err
+	}
+	var errs error
+	draining := sets.New[string]()
+	for i := range gateways.Items {
+		gtw := &gateways.Items[i]
+		if !managed.Has(string(gtw.Spec.GatewayClassName)) {
+			continue
+		}
+		if !gatewayapi.IsGatewayDraining(gtw) {
+			errs = errors.Join(errs, m.removeFinalizer(ctx, gtw))
+			continue
+		}
+		key := utils.NamespacedName(gtw).String()
+		draining.Insert(key)
+		errs = errors.Join(errs, m.drain(ctx, gtw, key))
+	}
+
+	for key := range m.started {
+		if !draining.Has(key) {
+			delete(m.started, key)
+			m.resources.GatewayDrains.Delete(key)
+		}
+	}
+	return errs
+}
+
+// drain counts the connections of the proxies of the draining Gateway, and releases its
+// deletion once they are drained or the drain timed out.
+func (m *gatewayDrainMonitor) drain(ctx context.Context, gtw *gwapiv1.Gateway, key string) error {
+	if gtw.DeletionTimestamp == nil {
+		if err := m.addFinalizer(ctx, gtw); err != nil {
+			return err
+		}
+	}
+	started, ok := m.started[key]
+	if !ok {
+		started = m.now()
+		m.started[key] = started
+	}
+
+	pods := new(corev1.PodList)
+	if err := m.podReader.List(ctx, pods, &client.ListOptions{
+		LabelSelector: labels.SelectorFromSet(gatewayapi.OwnerLabels(gtw, false)),
+		Namespace:     m.namespace,
+	}); err != nil {
+		return err
+	}
+	drain := &message.GatewayDrain{}
+	for _, pod := range pods.Items {
+		if pod.Status.Phase != corev1.PodRunning {
+			continue
+		}
+		connections, err := m.countConnections(ctx, utils.NamespacedName(&pod))
+		if err != nil {
+			m.log.Error(err, "failed to count the connections of the proxy", "namespace", pod.Namespace, "name", pod.Name)
+			drain.UncountedProxies++
+			continue
+		}
+		drain.Connections += connections
+	}
+	drain.TimedOut = !drain.Drained() && m.now().Sub(started) >= gatewayapi.GatewayDrainTimeout(gtw)
+	m.resources.GatewayDrains.Store(key, drain)
+
+	if gtw.DeletionTimestamp != nil && (drain.Drained() || drain.TimedOut) {
+		return m.removeFinalizer(ctx, gtw)
+	}
+	return nil
+}
+
+// addFinalizer adds the drain finalizer to the Gateway, if it doesn't have it.
+func (m *gatewayDrainMonitor) addFinalizer(ctx context.Context, gtw *gwapiv1.Gateway) error {
+	if slices.Contains(gtw.Finalizers, gatewayapi.GatewayDrainFinalizer) {
+		return nil
+	}
+	base := client.MergeFrom(gtw.DeepCopy())
+	gtw.Finalizers = append(gtw.Finalizers, gatewayapi.GatewayDrainFinalizer)
+	if err := m.client.Patch(ctx, gtw, base); err != nil {
+		return fmt.Errorf("failed to add the drain finalizer to gateway %s/%s: %w", gtw.Namespace, gtw.Name, err)
+	}
+	return nil
+}
+
+// removeFinalizer removes the drain finalizer from the Gateway, if it has it.
+func (m *gatewayDrainMonitor) removeFinalizer(ctx context.Context, gtw *gwapiv1.Gateway) error {
+	if !slices.Contains(gtw.Finalizers, gatewayapi.GatewayDrainFinalizer) {
+		return nil
+	}
+	base := client.MergeFrom(gtw.DeepCopy())
+	gtw.Finalizers = slice.RemoveString(gtw.Finalizers, gatewayapi.GatewayDrainFinalizer)
+	if err := m.client.Patch(ctx, gtw, base); err != nil {
+		return fmt.Errorf("failed to remove the drain finalizer from gateway %s/%s: %w", gtw.Namespace, gtw.Name, err)
+	}
+	return nil
+}
+
+// kubernetesConnectionCounter counts the connections of the proxy pods with the
+// server.total_connections stat of their admin interface, reached by port forwarding.
+func kubernetesConnectionCounter(cli kube.CLIClient) connectionCounter {
+	return func(ctx context.Context, pod types.NamespacedName) (uint64, error) {
+		fw, err := kube.NewLocalPortForwarder(cli, pod, 0, bootstrap.EnvoyAdminPort)
+		if err != nil {
+			return 0, err
+		}
+		if err := fw.Start(); err != nil {
+			return 0, err
+		}
+		defer fw.Stop()
+
+		url := fmt.Sprintf("http://%s/stats?filter=^server\\.total_connections$&format=json", fw.Address())
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+		if err != nil {
+			return 0, err
+		}
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			return 0, err
+		}
+		defer resp.Body.Close()
+		if resp.StatusCode != http.StatusOK {
+			return 0, fmt.Errorf("unexpected response status: %s", resp.Status)
+		}
+
+		// {"stats":[{"name":"server.total_connections","value":123}]}
+		var stats struct {
+			Stats []struct {
+				Name  string
+				Value uint64
+			}
+		}
+		if err := json.NewDecoder(resp.Body).Decode(&stats); err != nil {
+			return 0, err
+		}
+		if len(stats.Stats) == 0 {
+			return 0, errors.New("no server.total_connections stat")
+		}
+		return stats.Stats[0].Value, nil
+	}
+}
@@ -0,0 +1,159 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package kubernetes
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	fakeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+	gwapiv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	egv1a1 "github.com/envoyproxy/gateway/api/v1alpha1"
+	"github.com/envoyproxy/gateway/internal/envoygateway"
+	"github.com/envoyproxy/gateway/internal/gatewayapi"
+	"github.com/envoyproxy/gateway/internal/logging"
+	"github.com/envoyproxy/gateway/internal/message"
+)
+
+func TestGatewayDrainMonitor(t *testing.T) {
+	const namespace = "envoy-gateway-system"
+	gc := &gwapiv1.GatewayClass{
+		ObjectMeta: metav1.ObjectMeta{Name: "eg"},
+		Spec:       gwapiv1.GatewayClassSpec{ControllerName: egv1a1.GatewayControllerName},
+	}
+	gateway := func(name string, annotations map[string]string) *gwapiv1.Gateway {
+		return &gwapiv1.Gateway{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: name, Annotations: annotations},
+			Spec:       gwapiv1.GatewaySpec{GatewayClassName: "eg"},
+		}
+	}
+	pod := func(gtw *gwapiv1.Gateway, name string) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name, Labels: gatewayapi.OwnerLabels(gtw, false)},
+			Status:     corev1.PodStatus{Phase: corev1.PodRunning},
+		}
+	}
+
+	draining := gateway("draining", map[string]string{gatewayapi.AnnotationDrain: "true"})
+	timingOut := gateway("timing-out", map[string]string{
+		gatewayapi.AnnotationDrain:        "true",
+		gatewayapi.AnnotationDrainTimeout: "1m",
+	})
+	cli := fakeclient.NewClientBuilder().
+		WithScheme(envoygateway.GetScheme()).
+		WithObjects(gc, draining, timingOut, pod(draining, "draining-1"), pod(draining, "draining-2"), pod(timingOut, "timing-out-1")).
+		Build()
+
+	connections := map[string]uint64{"draining-1": 3, "draining-2": 1}
+	now := time.Now()
+	resources := new(message.ProviderResources)
+	m := &gatewayDrainMonitor{
+		client:        cli,
+		podReader:     cli,
+		log:           logging.DefaultLogger(egv1a1.LogLevelInfo),
+		controllers:   sets.New(egv1a1.GatewayControllerName),
+		namespace:     namespace,
+		mergeGateways: sets.New[string](),
+		resources:     resources,
+		countConnections: func(_ context.Context, pod types.NamespacedName) (uint64, error) {
+			if count, ok := connections[pod.Name]; ok {
+				return count, nil
+			}
+			return 0, errors.New("unreachable")
+		},
+		started: make(map[string]time.Time),
+		now:     func() time.Time { return now },
+	}
+	ctx := context.Background()
+	getGateway := func(name string) (*gwapiv1.Gateway, error) {
+		gtw := new(gwapiv1.Gateway)
+		err := cli.Get(ctx, types.NamespacedName{Namespace: "default", Name: name}, gtw)
+		return gtw, err
+	}
+
+	// The draining Gateways hold their deletion, with their connections counted.
+	require.NoError(t, m.reconcile(ctx))
+	for _, name := range []string{"draining", "timing-out"} {
+		gtw, err := getGateway(name)
+		require.NoError(t, err)
+		require.Contains(t, gtw.Finalizers, gatewayapi.GatewayDrainFinalizer)
+	}
+	drain, ok := resources.GatewayDrains.Load("default/draining")
+	require.True(t, ok)
+	require.Equal(t, &message.GatewayDrain{Connections: 4}, drain)
+	drain, ok = resources.GatewayDrains.Load("default/timing-out")
+	require.True(t, ok)
+	require.Equal(t, &message.GatewayDrain{UncountedProxies: 1}, drain)
+
+	// The deletion waits for the connections to be closed, or for the drain to time out.
+	gtw, err := getGateway("draining")
+	require.NoError(t, err)
+	require.NoError(t, cli.Delete(ctx, gtw))
+	gtw, err = getGateway("timing-out")
+	require.NoError(t, err)
+	require.NoError(t, cli.Delete(ctx, gtw))
+	require.NoError(t, m.reconcile(ctx))
+	_, err = getGateway("draining")
+	require.NoError(t, err)
+	_, err = getGateway("timing-out")
+	require.NoError(t, err)
+
+	connections["draining-1"], connections["draining-2"] = 0, 0
+	now = now.Add(2 * time.Minute)
+	require.NoError(t, m.reconcile(ctx))
+	drain, ok = resources.GatewayDrains.Load("default/timing-out")
+	require.True(t, ok)
+	require.Equal(t, &message.GatewayDrain{UncountedProxies: 1, TimedOut: true}, drain)
+	_, err = getGateway("draining")
+	require.True(t, kerrors.IsNotFound(err))
+	_, err = getGateway("timing-out")
+	require.True(t, kerrors.IsNotFound(err))
+
+	// The drains of the deleted Gateways are forgotten.
+	require.NoError(t, m.reconcile(ctx))
+	require.Equal(t, 0, resources.GatewayDrains.Len())
+}
+
+func TestGatewayDrainMonitorStopDraining(t *testing.T) {
+	gtw := &gwapiv1.Gateway{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:  "default",
+			Name:       "gateway",
+			Finalizers: []string{gatewayapi.GatewayDrainFinalizer},
+		},
+		Spec: gwapiv1.GatewaySpec{GatewayClassName: "eg"},
+	}
+	gc := &gwapiv1.GatewayClass{
+		ObjectMeta: metav1.ObjectMeta{Name: "eg"},
+		Spec:       gwapiv1.GatewayClassSpec{ControllerName: egv1a1.GatewayControllerName},
+	}
+	cli := fakeclient.NewClientBuilder().WithScheme(envoygateway.GetScheme()).WithObjects(gc, gtw).Build()
+	m := &gatewayDrainMonitor{
+		client:        cli,
+		podReader:     cli,
+		log:           logging.DefaultLogger(egv1a1.LogLevelInfo),
+		controllers:   sets.New(egv1a1.GatewayControllerName),
+		mergeGateways: sets.New[string](),
+		resources:     new(message.ProviderResources),
+		started:       make(map[string]time.Time),
+		now:           time.Now,
+	}
+
+	// The finalizer of a Gateway no longer annotated as draining is removed.
+	require.NoError(t, m.reconcile(context.Background()))
+	got := new(gwapiv1.Gateway)
+	require.NoError(t, cli.Get(context.Background(), types.NamespacedName{Namespace: "default", Name: "gateway"}, got))
+	require.Empty(t, got.Finalizers)
+}
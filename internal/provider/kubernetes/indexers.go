@@ -42,12 +42,59 @@ const (
 	secretCtpIndex                   = "secretCtpIndex"
 	configMapBtlsIndex               = "configMapBtlsIndex"
 	backendEnvoyExtensionPolicyIndex = "backendEnvoyExtensionPolicyIndex"
+	backendBtpIndex                  = "backendBtpIndex"
+	secretBtpIndex                   = "secretBtpIndex"
 	backendEnvoyProxyTelemetryIndex  = "backendEnvoyProxyTelemetryIndex"
 	secretEnvoyProxyIndex            = "secretEnvoyProxyIndex"
 	secretEnvoyExtensionPolicyIndex  = "secretEnvoyExtensionPolicyIndex"
+	configMapEepIndex                = "configMapEepIndex"
 	httpRouteFilterHTTPRouteIndex    = "httpRouteFilterHTTPRouteIndex"
 )
 
+// addIndexers adds the field indexers of the resources watched by the controllers.
+func addIndexers(ctx context.Context, mgr manager.Manager) error {
+	if err := addEnvoyProxyIndexers(ctx, mgr); err != nil {
+		return err
+	}
+	if err := addGatewayIndexers(ctx, mgr); err != nil {
+		return err
+	}
+	if err := addHTTPRouteIndexers(ctx, mgr); err != nil {
+		return err
+	}
+	if err := addGRPCRouteIndexers(ctx, mgr); err != nil {
+		return err
+	}
+	if err := addTLSRouteIndexers(ctx, mgr); err != nil {
+		return err
+	}
+	if err := addUDPRouteIndexers(ctx, mgr); err != nil {
+		return err
+	}
+	if err := addTCPRouteIndexers(ctx, mgr); err != nil {
+		return err
+	}
+	if err := addReferenceGrantIndexers(ctx, mgr); err != nil {
+		return err
+	}
+	if err := addCtpIndexers(ctx, mgr); err != nil {
+		return err
+	}
+	if err := addBtpIndexers(ctx, mgr); err != nil {
+		return err
+	}
+	if err := addSecurityPolicyIndexers(ctx, mgr); err != nil {
+		return err
+	}
+	if err := addBtlsIndexers(ctx, mgr); err != nil {
+		return err
+	}
+	if err := addEnvoyExtensionPolicyIndexers(ctx, mgr); err != nil {
+		return err
+	}
+	return nil
+}
+
 func addReferenceGrantIndexers(ctx context.Context, mgr manager.Manager) error {
 	if err := mgr.GetFieldIndexer().IndexField(ctx, &gwapiv1b1.ReferenceGrant{}, targetRefGrantRouteIndex, func(rawObj client.Object) []string {
 		refGrant := rawObj.(*gwapiv1b1.ReferenceGrant)
@@ -670,10 +717,94 @@ func configMapBtlsIndexFunc(rawObj client.Object) []string {
 	return configMapReferences
 }
 
+// addBtpIndexers adds indexing on BackendTrafficPolicy.
+//   - For Service objects that are referenced in BackendTrafficPolicy objects via
+//     `.spec.experiment.buckets[*].backendRefs` and `.spec.requestSigning.hmac.backendRefs`.
+//     This helps in querying for BackendTrafficPolicies that are affected by a
+//     particular Service CRUD.
+//   - For Secret objects that are referenced in BackendTrafficPolicy objects via
+//     `.spec.requestSigning.hmac.key`, `.spec.credentialInjection.bearerToken.token`
+//     and `.spec.credentialInjection.oauth2ClientCredentials.clientSecret`. This
+//     helps in querying for BackendTrafficPolicies that are affected by a particular
+//     Secret CRUD.
+func addBtpIndexers(ctx context.Context, mgr manager.Manager) error {
+	if err := mgr.GetFieldIndexer().IndexField(
+		ctx, &egv1a1.BackendTrafficPolicy{}, backendBtpIndex,
+		backendBtpIndexFunc); err != nil {
+		return err
+	}
+
+	if err := mgr.GetFieldIndexer().IndexField(
+		ctx, &egv1a1.BackendTrafficPolicy{}, secretBtpIndex,
+		secretBtpIndexFunc); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func backendBtpIndexFunc(rawObj client.Object) []string {
+	btp := rawObj.(*egv1a1.BackendTrafficPolicy)
+
+	var backendRefs []egv1a1.BackendRef
+	if btp.Spec.Experiment != nil {
+		for _, bucket := range btp.Spec.Experiment.Buckets {
+			backendRefs = append(backendRefs, bucket.BackendRefs...)
+		}
+	}
+	if rs := btp.Spec.RequestSigning; rs != nil && rs.HMAC != nil {
+		backendRefs = append(backendRefs, rs.HMAC.BackendRefs...)
+	}
+
+	var ret []string
+	for _, br := range backendRefs {
+		backendRef := br.BackendObjectReference
+		ret = append(ret,
+			types.NamespacedName{
+				Namespace: gatewayapi.NamespaceDerefOr(backendRef.Namespace, btp.Namespace),
+				Name:      string(backendRef.Name),
+			}.String())
+	}
+
+	return ret
+}
+
+func secretBtpIndexFunc(rawObj client.Object) []string {
+	btp := rawObj.(*egv1a1.BackendTrafficPolicy)
+
+	var secretRefs []gwapiv1b1.SecretObjectReference
+	if btp.Spec.RequestSigning != nil && btp.Spec.RequestSigning.HMAC != nil {
+		secretRefs = append(secretRefs, btp.Spec.RequestSigning.HMAC.Key)
+	}
+	if ci := btp.Spec.CredentialInjection; ci != nil {
+		if ci.BearerToken != nil {
+			secretRefs = append(secretRefs, ci.BearerToken.Token)
+		}
+		if ci.OAuth2ClientCredentials != nil {
+			secretRefs = append(secretRefs, ci.OAuth2ClientCredentials.ClientSecret)
+		}
+	}
+
+	var ret []string
+	for _, secretRef := range secretRefs {
+		ret = append(ret,
+			types.NamespacedName{
+				Namespace: gatewayapi.NamespaceDerefOr(secretRef.Namespace, btp.Namespace),
+				Name:      string(secretRef.Name),
+			}.String())
+	}
+
+	return ret
+}
+
 // addEnvoyExtensionPolicyIndexers adds indexing on EnvoyExtensionPolicy.
 //   - For Service objects that are referenced in EnvoyExtensionPolicy objects via
-//     `.spec.extProc.[*].service.backendObjectReference`. This helps in querying for
+//     `.spec.extProc.[*].service.backendObjectReference` or
+//     `.spec.openAPIValidation.backendRefs`. This helps in querying for
 //     EnvoyExtensionPolicy that are affected by a particular Service CRUD.
+//   - For ConfigMap objects that are referenced in EnvoyExtensionPolicy objects via
+//     `.spec.openAPIValidation.configMapRef`. This helps in querying for
+//     EnvoyExtensionPolicy that are affected by a particular ConfigMap CRUD.
 func addEnvoyExtensionPolicyIndexers(ctx context.Context, mgr manager.Manager) error {
 	var err error
 
@@ -689,6 +820,12 @@ func addEnvoyExtensionPolicyIndexers(ctx context.Context, mgr manager.Manager) e
 		return err
 	}
 
+	if err = mgr.GetFieldIndexer().IndexField(
+		ctx, &egv1a1.EnvoyExtensionPolicy{}, configMapEepIndex,
+		configMapEepIndexFunc); err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -697,15 +834,20 @@ func backendEnvoyExtensionPolicyIndexFunc(rawObj client.Object) []string {
 
 	var ret []string
 
+	var backendRefs []egv1a1.BackendRef
 	for _, ep := range envoyExtensionPolicy.Spec.ExtProc {
-		for _, br := range ep.BackendRefs {
-			backendRef := br.BackendObjectReference
-			ret = append(ret,
-				types.NamespacedName{
-					Namespace: gatewayapi.NamespaceDerefOr(backendRef.Namespace, envoyExtensionPolicy.Namespace),
-					Name:      string(backendRef.Name),
-				}.String())
-		}
+		backendRefs = append(backendRefs, ep.BackendRefs...)
+	}
+	if validation := envoyExtensionPolicy.Spec.OpenAPIValidation; validation != nil {
+		backendRefs = append(backendRefs, validation.BackendRefs...)
+	}
+	for _, br := range backendRefs {
+		backendRef := br.BackendObjectReference
+		ret = append(ret,
+			types.NamespacedName{
+				Namespace: gatewayapi.NamespaceDerefOr(backendRef.Namespace, envoyExtensionPolicy.Namespace),
+				Name:      string(backendRef.Name),
+			}.String())
 	}
 
 	return ret
@@ -729,3 +871,19 @@ func secretEnvoyExtensionPolicyIndexFunc(rawObj client.Object) []string {
 
 	return ret
 }
+
+func configMapEepIndexFunc(rawObj client.Object) []string {
+	envoyExtensionPolicy := rawObj.(*egv1a1.EnvoyExtensionPolicy)
+
+	var ret []string
+
+	if validation := envoyExtensionPolicy.Spec.OpenAPIValidation; validation != nil {
+		ret = append(ret,
+			types.NamespacedName{
+				Namespace: envoyExtensionPolicy.Namespace,
+				Name:      string(validation.ConfigMapRef.Name),
+			}.String())
+	}
+
+	return ret
+}
@@ -163,6 +163,10 @@ func (r *gatewayAPIReconciler) validateSecretForReconcile(obj client.Object) boo
 		return true
 	}
 
+	if r.isBtpReferencingSecret(&nsName) {
+		return true
+	}
+
 	return false
 }
 
@@ -290,6 +294,10 @@ func (r *gatewayAPIReconciler) validateServiceForReconcile(obj client.Object) bo
 		return true
 	}
 
+	if r.isBackendTrafficPolicyReferencingBackend(&nsName) {
+		return true
+	}
+
 	return r.isEnvoyExtensionPolicyReferencingBackend(&nsName)
 }
 
@@ -316,6 +324,10 @@ func (r *gatewayAPIReconciler) validateBackendForReconcile(obj client.Object) bo
 		return true
 	}
 
+	if r.isBackendTrafficPolicyReferencingBackend(&nsName) {
+		return true
+	}
+
 	return r.isEnvoyExtensionPolicyReferencingBackend(&nsName)
 }
 
@@ -436,6 +448,10 @@ func (r *gatewayAPIReconciler) validateEndpointSliceForReconcile(obj client.Obje
 		return true
 	}
 
+	if r.isBackendTrafficPolicyReferencingBackend(&nsName) {
+		return true
+	}
+
 	return r.isEnvoyExtensionPolicyReferencingBackend(&nsName)
 }
 
@@ -577,7 +593,8 @@ func (r *gatewayAPIReconciler) handleNode(obj client.Object) bool {
 	return true
 }
 
-// validateConfigMapForReconcile checks whether the ConfigMap belongs to a valid ClientTrafficPolicy.
+// validateConfigMapForReconcile checks whether the ConfigMap is referenced by a ClientTrafficPolicy,
+// a BackendTLSPolicy or an EnvoyExtensionPolicy.
 func (r *gatewayAPIReconciler) validateConfigMapForReconcile(obj client.Object) bool {
 	configMap, ok := obj.(*corev1.ConfigMap)
 	if !ok {
@@ -593,8 +610,8 @@ func (r *gatewayAPIReconciler) validateConfigMapForReconcile(obj client.Object)
 		return false
 	}
 
-	if len(ctpList.Items) == 0 {
-		return false
+	if len(ctpList.Items) > 0 {
+		return true
 	}
 
 	btlsList := &gwapiv1a3.BackendTLSPolicyList{}
@@ -605,11 +622,31 @@ func (r *gatewayAPIReconciler) validateConfigMapForReconcile(obj client.Object)
 		return false
 	}
 
-	if len(btlsList.Items) == 0 {
+	if len(btlsList.Items) > 0 {
+		return true
+	}
+
+	eepList := &egv1a1.EnvoyExtensionPolicyList{}
+	if err := r.client.List(context.Background(), eepList, &client.ListOptions{
+		FieldSelector: fields.OneTermEqualSelector(configMapEepIndex, utils.NamespacedName(configMap).String()),
+	}); err != nil {
+		r.log.Error(err, "unable to find associated EnvoyExtensionPolicy")
+		return false
+	}
+
+	return len(eepList.Items) > 0
+}
+
+func (r *gatewayAPIReconciler) isBackendTrafficPolicyReferencingBackend(nsName *types.NamespacedName) bool {
+	btpList := &egv1a1.BackendTrafficPolicyList{}
+	if err := r.client.List(context.Background(), btpList, &client.ListOptions{
+		FieldSelector: fields.OneTermEqualSelector(backendBtpIndex, nsName.String()),
+	}); err != nil {
+		r.log.Error(err, "unable to find associated BackendTrafficPolicies")
 		return false
 	}
 
-	return true
+	return len(btpList.Items) > 0
 }
 
 func (r *gatewayAPIReconciler) isEnvoyExtensionPolicyReferencingBackend(nsName *types.NamespacedName) bool {
@@ -648,6 +685,18 @@ func (r *gatewayAPIReconciler) isExtensionPolicyReferencingSecret(nsName *types.
 	return len(eepList.Items) > 0
 }
 
+func (r *gatewayAPIReconciler) isBtpReferencingSecret(nsName *types.NamespacedName) bool {
+	btpList := &egv1a1.BackendTrafficPolicyList{}
+	if err := r.client.List(context.Background(), btpList, &client.ListOptions{
+		FieldSelector: fields.OneTermEqualSelector(secretBtpIndex, nsName.String()),
+	}); err != nil {
+		r.log.Error(err, "unable to find associated BackendTrafficPolicies")
+		return false
+	}
+
+	return len(btpList.Items) > 0
+}
+
 // isRouteReferencingHTTPRouteFilter returns true if the HTTPRouteFilter is referenced by an HTTPRoute
 func (r *gatewayAPIReconciler) isRouteReferencingHTTPRouteFilter(nsName *types.NamespacedName) bool {
 	ctx := context.Background()
@@ -19,6 +19,7 @@ import (
 	fakeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
 	gwapiv1 "sigs.k8s.io/gateway-api/apis/v1"
 	gwapiv1a2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
+	gwapiv1a3 "sigs.k8s.io/gateway-api/apis/v1alpha3"
 	gwapiv1b1 "sigs.k8s.io/gateway-api/apis/v1beta1"
 
 	egv1a1 "github.com/envoyproxy/gateway/api/v1alpha1"
@@ -349,6 +350,54 @@ func TestValidateSecretForReconcile(t *testing.T) {
 			secret: test.GetSecret(types.NamespacedName{Name: "secret"}),
 			expect: true,
 		},
+		{
+			name: "references BackendTrafficPolicy HMAC RequestSigning",
+			configs: []client.Object{
+				test.GetGatewayClass("test-gc", egv1a1.GatewayControllerName, nil),
+				&egv1a1.BackendTrafficPolicy{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "request-signing",
+					},
+					Spec: egv1a1.BackendTrafficPolicySpec{
+						RequestSigning: &egv1a1.RequestSigning{
+							Type: egv1a1.RequestSigningTypeHMAC,
+							HMAC: &egv1a1.HMACRequestSigning{
+								Key: gwapiv1b1.SecretObjectReference{
+									Name: "secret",
+								},
+							},
+						},
+					},
+				},
+			},
+			secret: test.GetSecret(types.NamespacedName{Name: "secret"}),
+			expect: true,
+		},
+		{
+			name: "references BackendTrafficPolicy OAuth2 CredentialInjection",
+			configs: []client.Object{
+				test.GetGatewayClass("test-gc", egv1a1.GatewayControllerName, nil),
+				&egv1a1.BackendTrafficPolicy{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "credential-injection",
+					},
+					Spec: egv1a1.BackendTrafficPolicySpec{
+						CredentialInjection: &egv1a1.CredentialInjection{
+							Type: egv1a1.CredentialInjectionTypeOAuth2ClientCredentials,
+							OAuth2ClientCredentials: &egv1a1.OAuth2ClientCredentials{
+								TokenEndpoint: "https://oauth.example.com/token",
+								ClientID:      "client1",
+								ClientSecret: gwapiv1b1.SecretObjectReference{
+									Name: "secret",
+								},
+							},
+						},
+					},
+				},
+			},
+			secret: test.GetSecret(types.NamespacedName{Name: "secret"}),
+			expect: true,
+		},
 	}
 
 	// Create the reconciler.
@@ -367,6 +416,7 @@ func TestValidateSecretForReconcile(t *testing.T) {
 			WithIndex(&egv1a1.SecurityPolicy{}, secretSecurityPolicyIndex, secretSecurityPolicyIndexFunc).
 			WithIndex(&egv1a1.EnvoyProxy{}, secretEnvoyProxyIndex, secretEnvoyProxyIndexFunc).
 			WithIndex(&egv1a1.EnvoyExtensionPolicy{}, secretEnvoyExtensionPolicyIndex, secretEnvoyExtensionPolicyIndexFunc).
+			WithIndex(&egv1a1.BackendTrafficPolicy{}, secretBtpIndex, secretBtpIndexFunc).
 			Build()
 		t.Run(tc.name, func(t *testing.T) {
 			res := r.validateSecretForReconcile(tc.secret)
@@ -375,6 +425,74 @@ func TestValidateSecretForReconcile(t *testing.T) {
 	}
 }
 
+// TestValidateConfigMapForReconcile tests the validateConfigMapForReconcile
+// predicate function.
+func TestValidateConfigMapForReconcile(t *testing.T) {
+	configMap := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "default",
+			Name:      "petstore",
+		},
+	}
+
+	testCases := []struct {
+		name    string
+		configs []client.Object
+		expect  bool
+	}{
+		{
+			name: "configmap is not referenced by any EG CRs",
+			configs: []client.Object{
+				test.GetGatewayClass("test-gc", egv1a1.GatewayControllerName, nil),
+			},
+			expect: false,
+		},
+		{
+			name: "references EnvoyExtensionPolicy OpenAPIValidation",
+			configs: []client.Object{
+				test.GetGatewayClass("test-gc", egv1a1.GatewayControllerName, nil),
+				&egv1a1.EnvoyExtensionPolicy{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: "default",
+						Name:      "openapi",
+					},
+					Spec: egv1a1.EnvoyExtensionPolicySpec{
+						OpenAPIValidation: &egv1a1.OpenAPIValidation{
+							ConfigMapRef: gwapiv1.LocalObjectReference{
+								Kind: "ConfigMap",
+								Name: "petstore",
+							},
+						},
+					},
+				},
+			},
+			expect: true,
+		},
+	}
+
+	// Create the reconciler.
+	logger := logging.DefaultLogger(egv1a1.LogLevelInfo)
+
+	r := gatewayAPIReconciler{
+		classController: egv1a1.GatewayControllerName,
+		log:             logger,
+	}
+
+	for _, tc := range testCases {
+		r.client = fakeclient.NewClientBuilder().
+			WithScheme(envoygateway.GetScheme()).
+			WithObjects(tc.configs...).
+			WithIndex(&egv1a1.ClientTrafficPolicy{}, configMapCtpIndex, configMapCtpIndexFunc).
+			WithIndex(&gwapiv1a3.BackendTLSPolicy{}, configMapBtlsIndex, configMapBtlsIndexFunc).
+			WithIndex(&egv1a1.EnvoyExtensionPolicy{}, configMapEepIndex, configMapEepIndexFunc).
+			Build()
+		t.Run(tc.name, func(t *testing.T) {
+			res := r.validateConfigMapForReconcile(configMap)
+			require.Equal(t, tc.expect, res)
+		})
+	}
+}
+
 // TestValidateEndpointSliceForReconcile tests the validateEndpointSliceForReconcile
 // predicate function.
 func TestValidateEndpointSliceForReconcile(t *testing.T) {
@@ -850,6 +968,7 @@ func TestValidateServiceForReconcile(t *testing.T) {
 			WithIndex(&gwapiv1a2.UDPRoute{}, backendUDPRouteIndex, backendUDPRouteIndexFunc).
 			WithIndex(&egv1a1.SecurityPolicy{}, backendSecurityPolicyIndex, backendSecurityPolicyIndexFunc).
 			WithIndex(&egv1a1.EnvoyExtensionPolicy{}, backendEnvoyExtensionPolicyIndex, backendEnvoyExtensionPolicyIndexFunc).
+			WithIndex(&egv1a1.BackendTrafficPolicy{}, backendBtpIndex, backendBtpIndexFunc).
 			WithIndex(&egv1a1.EnvoyProxy{}, backendEnvoyProxyTelemetryIndex, backendEnvoyProxyTelemetryIndexFunc).
 			Build()
 		t.Run(tc.name, func(t *testing.T) {
@@ -8,9 +8,13 @@ package kubernetes
 import (
 	"context"
 	"fmt"
+	"strings"
+	"time"
 
+	corev1 "k8s.io/api/core/v1"
 	kerrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/fields"
 	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	gwapiv1 "sigs.k8s.io/gateway-api/apis/v1"
@@ -21,8 +25,13 @@ import (
 	"github.com/envoyproxy/gateway/internal/gatewayapi/status"
 	"github.com/envoyproxy/gateway/internal/message"
 	"github.com/envoyproxy/gateway/internal/utils"
+	xdstypes "github.com/envoyproxy/gateway/internal/xds/types"
 )
 
+// infraErrorEventReason is the reason of the Events emitted for Gateways whose
+// infrastructure failed to be provisioned.
+const infraErrorEventReason = "InfrastructureProvisioningFailed"
+
 // subscribeAndUpdateStatus subscribes to gateway API object status updates and
 // writes it into the Kubernetes API Server.
 func (r *gatewayAPIReconciler) subscribeAndUpdateStatus(ctx context.Context, extensionManagerEnabled bool) {
@@ -51,6 +60,170 @@ func (r *gatewayAPIReconciler) subscribeAndUpdateStatus(ctx context.Context, ext
 		r.log.Info("gateway status subscriber shutting down")
 	}()
 
+	// Infrastructure provisioning errors updater
+	go func() {
+		message.HandleSubscription(
+			message.Metadata{Runner: string(egv1a1.LogComponentProviderRunner), Message: "infra-errors"},
+			r.resources.InfraErrors.Subscribe(ctx),
+			func(update message.Update[string, *message.InfraError], errChan chan error) {
+				gateways, err := r.gatewaysForInfraIR(ctx, update.Key)
+				if err != nil {
+					r.log.Error(err, "failed to get gateways for infra", "key", update.Key)
+					errChan <- err
+					return
+				}
+				for i := range gateways {
+					gtw := &gateways[i]
+					if !update.Delete && r.eventRecorder != nil {
+						r.eventRecorder.Event(gtw, corev1.EventTypeWarning, infraErrorEventReason, update.Value.Message)
+					}
+					r.updateStatusForGateway(ctx, gtw)
+				}
+			},
+		)
+		r.log.Info("infra errors subscriber shutting down")
+	}()
+
+	// Gateway object status updater for the listeners pending acknowledgement
+	go func() {
+		message.HandleSubscription(
+			message.Metadata{Runner: string(egv1a1.LogComponentProviderRunner), Message: "pending-listeners"},
+			r.resources.PendingListeners.Subscribe(ctx),
+			func(update message.Update[string, *message.PendingListeners], errChan chan error) {
+				gateways, err := r.gatewaysForInfraIR(ctx, update.Key)
+				if err != nil {
+					r.log.Error(err, "failed to get gateways for infra", "key", update.Key)
+					errChan <- err
+					return
+				}
+				for i := range gateways {
+					r.updateStatusForGateway(ctx, &gateways[i])
+				}
+			},
+		)
+		r.log.Info("pending listeners subscriber shutting down")
+	}()
+
+	// Gateway object status updater for the secret rotations in progress
+	go func() {
+		message.HandleSubscription(
+			message.Metadata{Runner: string(egv1a1.LogComponentProviderRunner), Message: "secret-rotations"},
+			r.resources.SecretRotations.Subscribe(ctx),
+			func(update message.Update[string, *message.SecretRotations], errChan chan error) {
+				gateways, err := r.gatewaysForInfraIR(ctx, update.Key)
+				if err != nil {
+					r.log.Error(err, "failed to get gateways for infra", "key", update.Key)
+					errChan <- err
+					return
+				}
+				for i := range gateways {
+					r.updateStatusForGateway(ctx, &gateways[i])
+				}
+			},
+		)
+		r.log.Info("secret rotations subscriber shutting down")
+	}()
+
+	// Gateway object status updater for the listener changes deferred to the maintenance window
+	go func() {
+		message.HandleSubscription(
+			message.Metadata{Runner: string(egv1a1.LogComponentProviderRunner), Message: "deferred-drains"},
+			r.resources.DeferredDrains.Subscribe(ctx),
+			func(update message.Update[string, *message.DeferredDrains], errChan chan error) {
+				gateways, err := r.gatewaysForInfraIR(ctx, update.Key)
+				if err != nil {
+					r.log.Error(err, "failed to get gateways for infra", "key", update.Key)
+					errChan <- err
+					return
+				}
+				for i := range gateways {
+					r.updateStatusForGateway(ctx, &gateways[i])
+				}
+			},
+		)
+		r.log.Info("deferred drains subscriber shutting down")
+	}()
+
+	// Gateway object status updater for the xds snapshots rejected by the proxies
+	go func() {
+		message.HandleSubscription(
+			message.Metadata{Runner: string(egv1a1.LogComponentProviderRunner), Message: "xds-nacks"},
+			r.resources.XdsNacks.Subscribe(ctx),
+			func(update message.Update[string, *message.XdsNacks], errChan chan error) {
+				gateways, err := r.gatewaysForInfraIR(ctx, update.Key)
+				if err != nil {
+					r.log.Error(err, "failed to get gateways for infra", "key", update.Key)
+					errChan <- err
+					return
+				}
+				for i := range gateways {
+					r.updateStatusForGateway(ctx, &gateways[i])
+				}
+			},
+		)
+		r.log.Info("xds nacks subscriber shutting down")
+	}()
+
+	// Gateway object status updater for the xds snapshots refused as inconsistent
+	go func() {
+		message.HandleSubscription(
+			message.Metadata{Runner: string(egv1a1.LogComponentProviderRunner), Message: "xds-inconsistencies"},
+			r.resources.XdsInconsistencies.Subscribe(ctx),
+			func(update message.Update[string, *message.XdsInconsistencies], errChan chan error) {
+				gateways, err := r.gatewaysForInfraIR(ctx, update.Key)
+				if err != nil {
+					r.log.Error(err, "failed to get gateways for infra", "key", update.Key)
+					errChan <- err
+					return
+				}
+				for i := range gateways {
+					r.updateStatusForGateway(ctx, &gateways[i])
+				}
+			},
+		)
+		r.log.Info("xds inconsistencies subscriber shutting down")
+	}()
+
+	// Gateway object status updater for the proxies behind the configuration they are served
+	go func() {
+		message.HandleSubscription(
+			message.Metadata{Runner: string(egv1a1.LogComponentProviderRunner), Message: "xds-drifts"},
+			r.resources.XdsDrifts.Subscribe(ctx),
+			func(update message.Update[string, *message.XdsDrifts], errChan chan error) {
+				gateways, err := r.gatewaysForInfraIR(ctx, update.Key)
+				if err != nil {
+					r.log.Error(err, "failed to get gateways for infra", "key", update.Key)
+					errChan <- err
+					return
+				}
+				for i := range gateways {
+					r.updateStatusForGateway(ctx, &gateways[i])
+				}
+			},
+		)
+		r.log.Info("xds drifts subscriber shutting down")
+	}()
+
+	// Gateway object status updater for the drains of the proxies of the draining Gateways
+	go func() {
+		message.HandleSubscription(
+			message.Metadata{Runner: string(egv1a1.LogComponentProviderRunner), Message: "gateway-drains"},
+			r.resources.GatewayDrains.Subscribe(ctx),
+			func(update message.Update[string, *message.GatewayDrain], errChan chan error) {
+				gateways, err := r.gatewaysForInfraIR(ctx, update.Key)
+				if err != nil {
+					r.log.Error(err, "failed to get gateways for infra", "key", update.Key)
+					errChan <- err
+					return
+				}
+				for i := range gateways {
+					r.updateStatusForGateway(ctx, &gateways[i])
+				}
+			},
+		)
+		r.log.Info("gateway drains subscriber shutting down")
+	}()
+
 	// HTTPRoute object status updater
 	go func() {
 		message.HandleSubscription(
@@ -283,28 +456,28 @@ func (r *gatewayAPIReconciler) subscribeAndUpdateStatus(ctx context.Context, ext
 				if update.Delete {
 					return
 				}
-				key := update.Key
-				val := update.Value
-				r.statusUpdater.Send(Update{
-					NamespacedName: key,
-					Resource:       new(egv1a1.BackendTrafficPolicy),
-					Mutator: MutatorFunc(func(obj client.Object) client.Object {
-						t, ok := obj.(*egv1a1.BackendTrafficPolicy)
-						if !ok {
-							err := fmt.Errorf("unsupported object type %T", obj)
-							errChan <- err
-							panic(err)
-						}
-						tCopy := t.DeepCopy()
-						tCopy.Status = *val
-						return tCopy
-					}),
-				})
+				r.updateStatusForBackendTrafficPolicy(update.Key, update.Value, errChan)
 			},
 		)
 		r.log.Info("backendTrafficPolicy status subscriber shutting down")
 	}()
 
+	// BackendTrafficPolicy object status updater for the progress of the canary rollouts
+	go func() {
+		message.HandleSubscription(
+			message.Metadata{Runner: string(egv1a1.LogComponentProviderRunner), Message: "canary-rollouts"},
+			r.resources.CanaryRollouts.Subscribe(ctx),
+			func(update message.Update[types.NamespacedName, *message.CanaryRollout], errChan chan error) {
+				val, ok := r.resources.BackendTrafficPolicyStatuses.Load(update.Key)
+				if !ok {
+					return
+				}
+				r.updateStatusForBackendTrafficPolicy(update.Key, val, errChan)
+			},
+		)
+		r.log.Info("canary rollouts subscriber shutting down")
+	}()
+
 	// SecurityPolicy object status updater
 	go func() {
 		message.HandleSubscription(
@@ -492,6 +665,36 @@ func (r *gatewayAPIReconciler) updateStatusForGateway(ctx context.Context, gtw *
 	status.UpdateGatewayStatusAcceptedCondition(gtw, true)
 	// update address field and programmed condition
 	status.UpdateGatewayStatusProgrammedCondition(gtw, svc, deploy, r.store.listNodeAddresses()...)
+	// surface whether the proxies see the client IP addresses
+	status.UpdateGatewayStatusClientIPPreservedCondition(gtw, svc)
+	// surface infrastructure provisioning errors on the programmed condition
+	if infraErr := r.infraErrorForGateway(gtw); infraErr != nil {
+		status.UpdateGatewayStatusInfraErrorCondition(gtw, infraErr.Reason, infraErr.Message)
+	}
+	// hold the programmed condition until the proxies acknowledged the listeners
+	if pending := r.pendingListenersForGateway(gtw); pending != nil {
+		status.UpdateGatewayStatusPendingListenersCondition(gtw, pending.Listeners)
+	}
+	// surface the secret rotations in progress
+	status.UpdateGatewayStatusSecretRotationCondition(gtw, r.secretRotationsForGateway(gtw))
+	// surface the listener changes draining connections deferred to the maintenance window
+	if deferred := r.deferredDrainsForGateway(gtw); deferred != nil {
+		status.UpdateGatewayStatusDeferredDrainsCondition(gtw, deferred.Listeners, deferred.FilterChainListeners, deferred.Until)
+	} else {
+		status.UpdateGatewayStatusDeferredDrainsCondition(gtw, nil, nil, time.Time{})
+	}
+	// surface the configuration rejected by the proxies
+	status.UpdateGatewayStatusXdsNacksCondition(gtw, r.xdsNacksForGateway(gtw))
+	// surface the configuration refused as inconsistent
+	status.UpdateGatewayStatusXdsInconsistentCondition(gtw, r.xdsInconsistenciesForGateway(gtw))
+	// surface the proxies behind the configuration they are served
+	status.UpdateGatewayStatusXdsDriftedCondition(gtw, r.xdsDriftsForGateway(gtw))
+	// surface the drain of the proxies of a draining Gateway
+	if drain := r.gatewayDrainForGateway(gtw); drain != nil {
+		status.UpdateGatewayStatusDrainingCondition(gtw, true, drain.Connections, drain.UncountedProxies, drain.TimedOut)
+	} else {
+		status.UpdateGatewayStatusDrainingCondition(gtw, false, 0, 0, false)
+	}
 
 	key := utils.NamespacedName(gtw)
 
@@ -513,6 +716,167 @@ func (r *gatewayAPIReconciler) updateStatusForGateway(ctx context.Context, gtw *
 	})
 }
 
+// infraIRKey returns the key of the infra IR the Gateway belongs to.
+func (r *gatewayAPIReconciler) infraIRKey(gtw *gwapiv1.Gateway) string {
+	if r.mergeGateways.Has(string(gtw.Spec.GatewayClassName)) {
+		return string(gtw.Spec.GatewayClassName)
+	}
+	return utils.NamespacedName(gtw).String()
+}
+
+// infraErrorForGateway returns the error that occurred while provisioning
+// the infrastructure of the Gateway, if any.
+func (r *gatewayAPIReconciler) infraErrorForGateway(gtw *gwapiv1.Gateway) *message.InfraError {
+	if r.resources == nil {
+		return nil
+	}
+	infraErr, ok := r.resources.InfraErrors.Load(r.infraIRKey(gtw))
+	if !ok {
+		return nil
+	}
+	return infraErr
+}
+
+// pendingListenersForGateway returns the listeners of the Gateway that the proxies
+// have not acknowledged yet, or nil if the Gateway doesn't wait for the acknowledgement.
+func (r *gatewayAPIReconciler) pendingListenersForGateway(gtw *gwapiv1.Gateway) *message.PendingListeners {
+	if r.resources == nil {
+		return nil
+	}
+	pending, ok := r.resources.PendingListeners.Load(r.infraIRKey(gtw))
+	if !ok {
+		return nil
+	}
+	return pending
+}
+
+// secretRotationsForGateway returns the secrets of the Gateway whose rotation
+// has not been acknowledged by all the proxies yet.
+func (r *gatewayAPIReconciler) secretRotationsForGateway(gtw *gwapiv1.Gateway) []string {
+	if r.resources == nil {
+		return nil
+	}
+	rotations, ok := r.resources.SecretRotations.Load(r.infraIRKey(gtw))
+	if !ok {
+		return nil
+	}
+	return rotations.Secrets
+}
+
+// deferredDrainsForGateway returns the listener changes of the Gateway draining connections
+// that are deferred to the maintenance window, or nil if none.
+func (r *gatewayAPIReconciler) deferredDrainsForGateway(gtw *gwapiv1.Gateway) *message.DeferredDrains {
+	if r.resources == nil {
+		return nil
+	}
+	deferred, ok := r.resources.DeferredDrains.Load(r.infraIRKey(gtw))
+	if !ok {
+		return nil
+	}
+	return deferred
+}
+
+// xdsNacksForGateway returns the rejections of the configuration of the Gateway by the
+// proxies that are not resolved yet.
+func (r *gatewayAPIReconciler) xdsNacksForGateway(gtw *gwapiv1.Gateway) []xdstypes.Nack {
+	if r.resources == nil {
+		return nil
+	}
+	nacks, ok := r.resources.XdsNacks.Load(r.infraIRKey(gtw))
+	if !ok {
+		return nil
+	}
+	return nacks.Nacks
+}
+
+// xdsInconsistenciesForGateway returns the inconsistencies of the last configuration of
+// the Gateway refused by the snapshot cache.
+func (r *gatewayAPIReconciler) xdsInconsistenciesForGateway(gtw *gwapiv1.Gateway) []string {
+	if r.resources == nil {
+		return nil
+	}
+	inconsistencies, ok := r.resources.XdsInconsistencies.Load(r.infraIRKey(gtw))
+	if !ok {
+		return nil
+	}
+	return inconsistencies.Problems
+}
+
+// xdsDriftsForGateway returns the proxies of the Gateway behind the configuration they
+// are served for longer than the drift window.
+func (r *gatewayAPIReconciler) xdsDriftsForGateway(gtw *gwapiv1.Gateway) []xdstypes.Drift {
+	if r.resources == nil {
+		return nil
+	}
+	drifts, ok := r.resources.XdsDrifts.Load(r.infraIRKey(gtw))
+	if !ok {
+		return nil
+	}
+	return drifts.Drifts
+}
+
+// gatewayDrainForGateway returns the progress of the drain of the proxies of the Gateway,
+// or nil if it's not draining.
+func (r *gatewayAPIReconciler) gatewayDrainForGateway(gtw *gwapiv1.Gateway) *message.GatewayDrain {
+	if r.resources == nil {
+		return nil
+	}
+	drain, ok := r.resources.GatewayDrains.Load(r.infraIRKey(gtw))
+	if !ok {
+		return nil
+	}
+	return drain
+}
+
+// updateStatusForBackendTrafficPolicy updates the status of the BackendTrafficPolicy with the
+// status of its translation, along with the progress of its canary rollout, if any.
+func (r *gatewayAPIReconciler) updateStatusForBackendTrafficPolicy(key types.NamespacedName, val *gwapiv1a2.PolicyStatus, errChan chan error) {
+	policyStatus := val.DeepCopy()
+	if rollout, ok := r.resources.CanaryRollouts.Load(key); ok {
+		status.SetCanaryRolloutForPolicyAncestors(policyStatus, string(r.classController),
+			rollout.Reason, rollout.Message, rollout.Generation)
+	}
+	r.statusUpdater.Send(Update{
+		NamespacedName: key,
+		Resource:       new(egv1a1.BackendTrafficPolicy),
+		Mutator: MutatorFunc(func(obj client.Object) client.Object {
+			t, ok := obj.(*egv1a1.BackendTrafficPolicy)
+			if !ok {
+				err := fmt.Errorf("unsupported object type %T", obj)
+				errChan <- err
+				panic(err)
+			}
+			tCopy := t.DeepCopy()
+			tCopy.Status = *policyStatus
+			return tCopy
+		}),
+	})
+}
+
+// gatewaysForInfraIR returns the Gateways of the infra IR with the key, which is
+// either the namespaced name of a Gateway, or the name of a GatewayClass when
+// its Gateways are merged.
+func (r *gatewayAPIReconciler) gatewaysForInfraIR(ctx context.Context, key string) ([]gwapiv1.Gateway, error) {
+	if namespace, name, ok := strings.Cut(key, "/"); ok {
+		gtw := new(gwapiv1.Gateway)
+		if err := r.client.Get(ctx, types.NamespacedName{Namespace: namespace, Name: name}, gtw); err != nil {
+			if kerrors.IsNotFound(err) {
+				return nil, nil
+			}
+			return nil, err
+		}
+		return []gwapiv1.Gateway{*gtw}, nil
+	}
+
+	gateways := new(gwapiv1.GatewayList)
+	if err := r.client.List(ctx, gateways, &client.ListOptions{
+		FieldSelector: fields.OneTermEqualSelector(classGatewayIndex, key),
+	}); err != nil {
+		return nil, err
+	}
+	return gateways.Items, nil
+}
+
 func (r *gatewayAPIReconciler) updateStatusForGatewayClass(
 	ctx context.Context,
 	gc *gwapiv1.GatewayClass,
@@ -0,0 +1,287 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package replay
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// HeaderName is the header set on the replayed requests to the ID of their job, so
+	// that the target can tell them apart from the regular traffic.
+	HeaderName = "x-envoy-gateway-replay"
+	// defaultConcurrency is the number of replayed requests in flight at most.
+	defaultConcurrency = 16
+	// defaultSamplingPercentage is the percentage of the recorded requests replayed
+	// if not set.
+	defaultSamplingPercentage = 100
+	// maxRate bounds the number of requests replayed per second.
+	maxRate = 10000
+)
+
+// State is the state of a replay job.
+type State string
+
+const (
+	StateRunning   State = "Running"
+	StateCompleted State = "Completed"
+	StateCancelled State = "Cancelled"
+)
+
+// Config is the configuration of a replay job.
+type Config struct {
+	// IRKey is the irKey whose recorded requests are replayed.
+	IRKey string `json:"irKey"`
+	// Target is the base URL the requests are replayed against.
+	Target string `json:"target"`
+	// Rate is the number of requests replayed per second.
+	Rate float64 `json:"rate"`
+	// SamplingPercentage is the percentage of the recorded requests of the window that
+	// are replayed. Defaults to 100.
+	SamplingPercentage *uint32 `json:"samplingPercentage,omitempty"`
+	// From and To bound the window of the recorded requests that are replayed.
+	From *time.Time `json:"from,omitempty"`
+	To   *time.Time `json:"to,omitempty"`
+	// PreserveHost replays the requests with their recorded authority rather than
+	// the host of the target.
+	PreserveHost bool `json:"preserveHost,omitempty"`
+}
+
+// Validate validates the configuration.
+func (c *Config) Validate() error {
+	var errs error
+	if c.IRKey == "" {
+		errs = errors.Join(errs, fmt.Errorf("irKey is required"))
+	}
+	if u, err := url.Parse(c.Target); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		errs = errors.Join(errs, fmt.Errorf("target must be an http or https URL"))
+	}
+	if c.Rate <= 0 || c.Rate > maxRate {
+		errs = errors.Join(errs, fmt.Errorf("rate must be greater than 0 and at most %d", maxRate))
+	}
+	if c.SamplingPercentage != nil && (*c.SamplingPercentage == 0 || *c.SamplingPercentage > 100) {
+		errs = errors.Join(errs, fmt.Errorf("samplingPercentage must be between 1 and 100"))
+	}
+	if c.From != nil && c.To != nil && c.To.Before(*c.From) {
+		errs = errors.Join(errs, fmt.Errorf("to must not be before from"))
+	}
+	return errs
+}
+
+// Status is the progress of a replay job.
+type Status struct {
+	ID     string `json:"id"`
+	Config Config `json:"config"`
+	State  State  `json:"state"`
+	// Requests is the number of requests the job replays.
+	Requests int `json:"requests"`
+	// Sent is the number of requests replayed so far.
+	Sent int `json:"sent"`
+	// Responses holds the number of responses by status class, such as 2xx.
+	Responses map[string]int `json:"responses,omitempty"`
+	// Errors is the number of requests that failed without response.
+	Errors    int        `json:"errors"`
+	LastError string     `json:"lastError,omitempty"`
+	StartTime time.Time  `json:"startTime"`
+	EndTime   *time.Time `json:"endTime,omitempty"`
+}
+
+// Job replays recorded requests against a target at a controlled rate.
+type Job struct {
+	client   *http.Client
+	target   *url.URL
+	requests []*Request
+	cancel   context.CancelFunc
+	done     chan struct{}
+
+	mu     sync.Mutex
+	status Status
+}
+
+// newJob returns a job replaying the sample of the requests configured.
+func newJob(id string, cfg Config, requests []*Request, client *http.Client) (*Job, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	target, err := url.Parse(cfg.Target)
+	if err != nil {
+		return nil, err
+	}
+
+	percentage := uint32(defaultSamplingPercentage)
+	if cfg.SamplingPercentage != nil {
+		percentage = *cfg.SamplingPercentage
+	}
+	requests = sample(requests, percentage)
+
+	return &Job{
+		client:   client,
+		target:   target,
+		requests: requests,
+		done:     make(chan struct{}),
+		status: Status{
+			ID:        id,
+			Config:    cfg,
+			State:     StateRunning,
+			Requests:  len(requests),
+			Responses: make(map[string]int),
+			StartTime: time.Now(),
+		},
+	}, nil
+}
+
+// sample returns the percentage of the requests, evenly spread over the requests so that
+// the replayed traffic keeps the shape of the recorded one.
+func sample(requests []*Request, percentage uint32) []*Request {
+	if percentage >= 100 {
+		return requests
+	}
+	var sampled []*Request
+	for i, req := range requests {
+		if (i+1)*int(percentage)/100 > i*int(percentage)/100 {
+			sampled = append(sampled, req)
+		}
+	}
+	return sampled
+}
+
+// start replays the requests in the background until done or cancelled.
+func (j *Job) start() {
+	ctx, cancel := context.WithCancel(context.Background())
+	j.cancel = cancel
+	go j.run(ctx)
+}
+
+func (j *Job) run(ctx context.Context) {
+	defer close(j.done)
+
+	ticker := time.NewTicker(time.Duration(float64(time.Second) / j.status.Config.Rate))
+	defer ticker.Stop()
+
+	var wg sync.WaitGroup
+	inFlight := make(chan struct{}, defaultConcurrency)
+	cancelled := false
+	for _, req := range j.requests {
+		select {
+		case <-ctx.Done():
+			cancelled = true
+		case <-ticker.C:
+		}
+		if cancelled {
+			break
+		}
+		// The rate drops if the target doesn't keep up with it.
+		select {
+		case <-ctx.Done():
+			cancelled = true
+		case inFlight <- struct{}{}:
+		}
+		if cancelled {
+			break
+		}
+
+		wg.Add(1)
+		go func(req *Request) {
+			defer func() {
+				<-inFlight
+				wg.Done()
+			}()
+			j.record(j.send(ctx, req))
+		}(req)
+	}
+	wg.Wait()
+
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	now := time.Now()
+	j.status.EndTime = &now
+	j.status.State = StateCompleted
+	if cancelled {
+		j.status.State = StateCancelled
+	}
+}
+
+// send replays the request against the target, and returns its status code.
+func (j *Job) send(ctx context.Context, req *Request) (int, error) {
+	u := *j.target
+	path, query, _ := strings.Cut(req.Path, "?")
+	u.Path = strings.TrimSuffix(u.Path, "/") + path
+	u.RawQuery = query
+
+	method := req.Method
+	if method == "" {
+		method = http.MethodGet
+	}
+	httpReq, err := http.NewRequestWithContext(ctx, method, u.String(), nil)
+	if err != nil {
+		return 0, err
+	}
+	for name, value := range req.Headers {
+		httpReq.Header.Set(name, value)
+	}
+	if j.status.Config.PreserveHost && req.Authority != "" {
+		httpReq.Host = req.Authority
+	}
+	httpReq.Header.Set(HeaderName, j.status.ID)
+
+	resp, err := j.client.Do(httpReq)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, resp.Body)
+	return resp.StatusCode, nil
+}
+
+// record records the outcome of a replayed request.
+func (j *Job) record(code int, err error) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	j.status.Sent++
+	if err != nil {
+		// The requests in flight when the job is cancelled are not errors.
+		if !errors.Is(err, context.Canceled) {
+			j.status.Errors++
+			j.status.LastError = err.Error()
+		}
+		return
+	}
+	j.status.Responses[fmt.Sprintf("%dxx", code/100)]++
+}
+
+// Cancel stops replaying the requests.
+func (j *Job) Cancel() {
+	if j.cancel != nil {
+		j.cancel()
+	}
+}
+
+// Done returns a channel closed once the job stopped replaying the requests.
+func (j *Job) Done() <-chan struct{} {
+	return j.done
+}
+
+// Status returns the progress of the job.
+func (j *Job) Status() Status {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	status := j.status
+	status.Responses = make(map[string]int, len(j.status.Responses))
+	for class, count := range j.status.Responses {
+		status.Responses[class] = count
+	}
+	return status
+}
@@ -0,0 +1,131 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package replay
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+)
+
+const (
+	// defaultRequestTimeout bounds the time a replayed request takes.
+	defaultRequestTimeout = 10 * time.Second
+	// maxRunningJobs is the number of jobs replaying requests at the same time at most.
+	maxRunningJobs = 4
+	// maxFinishedJobs is the number of finished jobs whose status is kept.
+	maxFinishedJobs = 32
+)
+
+// ErrTooManyJobs is returned when a job is started while maxRunningJobs are running.
+var ErrTooManyJobs = errors.New("too many replay jobs running")
+
+// Manager runs the replay jobs and keeps track of their status.
+type Manager struct {
+	client *http.Client
+
+	mu     sync.Mutex
+	nextID int
+	jobs   map[string]*Job
+}
+
+// NewManager returns a manager replaying the requests with the client, or with a
+// default client if nil.
+func NewManager(client *http.Client) *Manager {
+	if client == nil {
+		client = &http.Client{
+			Timeout: defaultRequestTimeout,
+			// The redirects are replayed as the responses of the recorded requests.
+			CheckRedirect: func(*http.Request, []*http.Request) error {
+				return http.ErrUseLastResponse
+			},
+		}
+	}
+	return &Manager{
+		client: client,
+		jobs:   make(map[string]*Job),
+	}
+}
+
+// Start starts a job replaying the requests as configured.
+func (m *Manager) Start(cfg Config, requests []*Request) (*Job, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	running := 0
+	for _, job := range m.jobs {
+		if job.Status().State == StateRunning {
+			running++
+		}
+	}
+	if running >= maxRunningJobs {
+		return nil, ErrTooManyJobs
+	}
+
+	m.nextID++
+	job, err := newJob(fmt.Sprintf("replay-%d", m.nextID), cfg, requests, m.client)
+	if err != nil {
+		return nil, err
+	}
+	m.jobs[job.status.ID] = job
+	m.prune()
+	job.start()
+	return job, nil
+}
+
+// Get returns the job of the ID.
+func (m *Manager) Get(id string) (*Job, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	job, ok := m.jobs[id]
+	return job, ok
+}
+
+// List returns the status of the jobs, in the order they were started.
+func (m *Manager) List() []Status {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	statuses := make([]Status, 0, len(m.jobs))
+	for _, job := range m.jobs {
+		statuses = append(statuses, job.Status())
+	}
+	sort.Slice(statuses, func(i, j int) bool {
+		return jobNumber(statuses[i].ID) < jobNumber(statuses[j].ID)
+	})
+	return statuses
+}
+
+// prune forgets the oldest finished jobs beyond maxFinishedJobs. The caller must hold
+// the lock.
+func (m *Manager) prune() {
+	var finished []string
+	for id, job := range m.jobs {
+		if job.Status().State != StateRunning {
+			finished = append(finished, id)
+		}
+	}
+	if len(finished) <= maxFinishedJobs {
+		return
+	}
+	sort.Slice(finished, func(i, j int) bool {
+		return jobNumber(finished[i]) < jobNumber(finished[j])
+	})
+	for _, id := range finished[:len(finished)-maxFinishedJobs] {
+		delete(m.jobs, id)
+	}
+}
+
+// jobNumber returns the sequence number of the job ID.
+func jobNumber(id string) int {
+	n, _ := strconv.Atoi(id[len("replay-"):])
+	return n
+}
@@ -0,0 +1,123 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package replay
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"k8s.io/utils/ptr"
+)
+
+func TestConfigValidate(t *testing.T) {
+	valid := Config{IRKey: "envoy-gateway/eg", Target: "http://shadow.example.com", Rate: 10}
+	require.NoError(t, valid.Validate())
+
+	invalid := valid
+	invalid.Target = "shadow.example.com"
+	require.ErrorContains(t, invalid.Validate(), "target must be an http or https URL")
+
+	invalid = valid
+	invalid.Rate = 0
+	require.ErrorContains(t, invalid.Validate(), "rate must be greater than 0")
+
+	invalid = valid
+	invalid.SamplingPercentage = ptr.To[uint32](0)
+	require.ErrorContains(t, invalid.Validate(), "samplingPercentage must be between 1 and 100")
+
+	invalid = valid
+	invalid.From = ptr.To(time.Now())
+	invalid.To = ptr.To(invalid.From.Add(-time.Minute))
+	require.ErrorContains(t, invalid.Validate(), "to must not be before from")
+}
+
+func TestManager(t *testing.T) {
+	var mu sync.Mutex
+	var received []*http.Request
+	target := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		received = append(received, r)
+		mu.Unlock()
+		if r.URL.Path == "/shadow/missing" {
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer target.Close()
+
+	requests := []*Request{
+		{Method: http.MethodGet, Authority: "www.example.com", Path: "/users?id=1", Headers: map[string]string{"user-agent": "test"}},
+		{Method: http.MethodDelete, Authority: "www.example.com", Path: "/users/2"},
+		{Method: http.MethodGet, Authority: "www.example.com", Path: "/missing"},
+	}
+
+	m := NewManager(nil)
+	job, err := m.Start(Config{
+		IRKey:        "envoy-gateway/eg",
+		Target:       target.URL + "/shadow/",
+		Rate:         100,
+		PreserveHost: true,
+	}, requests)
+	require.NoError(t, err)
+
+	select {
+	case <-job.Done():
+	case <-time.After(10 * time.Second):
+		t.Fatal("the replay job didn't complete")
+	}
+
+	status := job.Status()
+	require.Equal(t, "replay-1", status.ID)
+	require.Equal(t, StateCompleted, status.State)
+	require.Equal(t, 3, status.Requests)
+	require.Equal(t, 3, status.Sent)
+	require.Equal(t, map[string]int{"2xx": 2, "4xx": 1}, status.Responses)
+	require.Zero(t, status.Errors)
+	require.NotNil(t, status.EndTime)
+
+	mu.Lock()
+	defer mu.Unlock()
+	require.Len(t, received, 3)
+	for _, r := range received {
+		require.Equal(t, "www.example.com", r.Host)
+		require.Equal(t, "replay-1", r.Header.Get(HeaderName))
+	}
+	require.Equal(t, "/shadow/users", received[0].URL.Path)
+	require.Equal(t, "id=1", received[0].URL.RawQuery)
+	require.Equal(t, "test", received[0].Header.Get("User-Agent"))
+
+	require.Len(t, m.List(), 1)
+}
+
+func TestManagerCancel(t *testing.T) {
+	target := httptest.NewServer(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {}))
+	defer target.Close()
+
+	requests := make([]*Request, 100)
+	for i := range requests {
+		requests[i] = &Request{Path: "/"}
+	}
+
+	m := NewManager(nil)
+	job, err := m.Start(Config{IRKey: "envoy-gateway/eg", Target: target.URL, Rate: 1}, requests)
+	require.NoError(t, err)
+
+	got, ok := m.Get(job.Status().ID)
+	require.True(t, ok)
+	got.Cancel()
+
+	select {
+	case <-job.Done():
+	case <-time.After(10 * time.Second):
+		t.Fatal("the replay job wasn't cancelled")
+	}
+	status := job.Status()
+	require.Equal(t, StateCancelled, status.State)
+	require.Less(t, status.Sent, 100)
+}
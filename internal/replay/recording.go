@@ -0,0 +1,110 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package replay
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+// Request is a request recorded from the access logs of the proxies. The bodies of the
+// requests are not recorded.
+type Request struct {
+	// Time is when the proxy started receiving the request.
+	Time      time.Time `json:"time"`
+	Method    string    `json:"method"`
+	Scheme    string    `json:"scheme,omitempty"`
+	Authority string    `json:"authority,omitempty"`
+	// Path is the path of the request, including the query.
+	Path string `json:"path"`
+	// Headers holds the recorded headers of the request by lower-cased name.
+	Headers map[string]string `json:"headers,omitempty"`
+}
+
+// Recording holds the latest requests recorded, up to its capacity, the oldest
+// requests being dropped first.
+type Recording struct {
+	mu       sync.RWMutex
+	requests []*Request
+	// next is the index the next request is stored at once the recording is full.
+	next     int
+	capacity int
+}
+
+// NewRecording returns an empty recording of the capacity.
+func NewRecording(capacity int) *Recording {
+	return &Recording{capacity: capacity}
+}
+
+// Add records the request, dropping the oldest request if the recording is full.
+func (r *Recording) Add(req *Request) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.capacity <= 0 {
+		return
+	}
+	if len(r.requests) < r.capacity {
+		r.requests = append(r.requests, req)
+		return
+	}
+	r.requests[r.next] = req
+	r.next = (r.next + 1) % r.capacity
+}
+
+// Resize changes the capacity of the recording, keeping the latest requests.
+func (r *Recording) Resize(capacity int) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if capacity == r.capacity {
+		return
+	}
+	requests := r.ordered()
+	if len(requests) > capacity {
+		requests = requests[len(requests)-capacity:]
+	}
+	r.requests = requests
+	r.next = 0
+	r.capacity = capacity
+}
+
+// Len returns the number of requests recorded.
+func (r *Recording) Len() int {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return len(r.requests)
+}
+
+// Requests returns the requests recorded between from and to, in the order they were
+// received. A zero from or to leaves the window unbounded on that side.
+func (r *Recording) Requests(from, to time.Time) []*Request {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var requests []*Request
+	for _, req := range r.ordered() {
+		if (!from.IsZero() && req.Time.Before(from)) || (!to.IsZero() && req.Time.After(to)) {
+			continue
+		}
+		requests = append(requests, req)
+	}
+	// The access logs of the proxies are not received in order.
+	sort.SliceStable(requests, func(i, j int) bool {
+		return requests[i].Time.Before(requests[j].Time)
+	})
+	return requests
+}
+
+// ordered returns the requests in the order they were recorded. The caller must hold
+// the lock.
+func (r *Recording) ordered() []*Request {
+	requests := make([]*Request, 0, len(r.requests))
+	requests = append(requests, r.requests[r.next:]...)
+	return append(requests, r.requests[:r.next]...)
+}
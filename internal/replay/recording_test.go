@@ -0,0 +1,59 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package replay
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func paths(requests []*Request) []string {
+	var p []string
+	for _, req := range requests {
+		p = append(p, req.Path)
+	}
+	return p
+}
+
+func TestRecording(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	recording := NewRecording(3)
+	for i, path := range []string{"/a", "/b", "/c", "/d"} {
+		recording.Add(&Request{Time: start.Add(time.Duration(i) * time.Second), Path: path})
+	}
+
+	// The oldest request is dropped once the recording is full.
+	require.Equal(t, 3, recording.Len())
+	require.Equal(t, []string{"/b", "/c", "/d"}, paths(recording.Requests(time.Time{}, time.Time{})))
+	require.Equal(t, []string{"/c"}, paths(recording.Requests(start.Add(2*time.Second), start.Add(2*time.Second))))
+	require.Equal(t, []string{"/c", "/d"}, paths(recording.Requests(start.Add(2*time.Second), time.Time{})))
+
+	// Shrinking the recording keeps the latest requests.
+	recording.Resize(2)
+	require.Equal(t, []string{"/c", "/d"}, paths(recording.Requests(time.Time{}, time.Time{})))
+
+	recording.Resize(4)
+	recording.Add(&Request{Time: start.Add(10 * time.Second), Path: "/e"})
+	require.Equal(t, []string{"/c", "/d", "/e"}, paths(recording.Requests(time.Time{}, time.Time{})))
+
+	// The requests are returned in the order they were received.
+	recording.Add(&Request{Time: start.Add(5 * time.Second), Path: "/late"})
+	require.Equal(t, []string{"/c", "/d", "/late", "/e"}, paths(recording.Requests(time.Time{}, time.Time{})))
+}
+
+func TestSample(t *testing.T) {
+	var requests []*Request
+	for i := 0; i < 10; i++ {
+		requests = append(requests, &Request{Path: string(rune('a' + i))})
+	}
+
+	require.Len(t, sample(requests, 100), 10)
+	require.Equal(t, []string{"b", "d", "f", "h", "j"}, paths(sample(requests, 50)))
+	require.Equal(t, []string{"d", "g", "j"}, paths(sample(requests, 30)))
+	require.Empty(t, sample(requests, 5))
+}
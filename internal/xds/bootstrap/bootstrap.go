@@ -31,9 +31,25 @@ const (
 	EnvoyAdminPort = 19000
 	// envoyAdminAccessLogPath is the path used to expose admin access log.
 	envoyAdminAccessLogPath = "/dev/null"
+	// EnvoyAdminUnixSocketDir is the directory of the unix socket of the envoy admin interface.
+	EnvoyAdminUnixSocketDir = "/var/run/envoy-admin"
+	// EnvoyAdminUnixSocketPath is the unix socket used to expose admin interface.
+	EnvoyAdminUnixSocketPath = EnvoyAdminUnixSocketDir + "/admin.sock"
 
 	// DefaultXdsServerPort is the default listening port of the xds-server.
 	DefaultXdsServerPort = 18000
+	// XdsClusterName is the name of the static cluster of the xds-server.
+	XdsClusterName = "xds_cluster"
+	// CheckpointServerAddress is the listening address of the checkpoint xDS server of
+	// the shutdown manager, which serves the configuration checkpoint of the proxy.
+	CheckpointServerAddress = "127.0.0.1"
+	// CheckpointServerPort is the listening port of the checkpoint xDS server.
+	CheckpointServerPort = 19003
+	// RuntimeLayerName is the name of the runtime layer delivered to the proxy with RTDS.
+	RuntimeLayerName = "rtds"
+	// grpcCompressGzip is the gzip value of the grpc.default_compression_algorithm
+	// channel argument of the Google gRPC client.
+	grpcCompressGzip = 2
 
 	wasmServerHost = envoyGatewayXdsServerHost
 	// DefaultWasmServerPort is the default listening port of the wasm HTTP server.
@@ -83,6 +99,29 @@ type bootstrapParameters struct {
 	StatsMatcher *StatsMatcherParameters
 	// OverloadManager defines the configuration of the Envoy overload manager.
 	OverloadManager overloadManagerParameters
+	// RuntimeFlags defines the runtime flags set in the static layer of the layered runtime.
+	RuntimeFlags []runtimeFlag
+	// EnableLoadReporting defines whether Envoy reports the load of the upstream endpoints
+	// to the XDS Server with the Load Reporting Service.
+	EnableLoadReporting bool
+	// XdsCompression is the gRPC compression algorithm of the xDS stream, which is
+	// fetched with the Google gRPC client if set, or zero to fetch it uncompressed.
+	XdsCompression int
+	// NodeGroup is the node group of the proxy set in its node metadata, if set.
+	NodeGroup string
+	// CheckpointServer defines the configuration of the checkpoint xDS server, which the
+	// proxy fails over to when the XDS Server is unreachable, if set.
+	CheckpointServer *serverParameters
+	// EnableRuntimeDiscovery defines whether the layered runtime has a layer fetched
+	// from the XDS Server with the Runtime Discovery Service.
+	EnableRuntimeDiscovery bool
+}
+
+type runtimeFlag struct {
+	// Name is the name of the runtime flag.
+	Name string
+	// Enabled defines whether the runtime flag is enabled.
+	Enabled bool
 }
 
 type serverParameters struct {
@@ -106,6 +145,9 @@ type adminServerParameters struct {
 	Port int32
 	// AccessLogPath is the path of the Envoy admin access log.
 	AccessLogPath string
+	// UnixSocketPath is the unix socket of the Envoy admin interface.
+	// If set, the admin interface listens on the unix socket instead of the address and port.
+	UnixSocketPath string
 }
 
 type readyServerParameters struct {
@@ -131,6 +173,21 @@ type overloadManagerParameters struct {
 type RenderBootstrapConfigOptions struct {
 	ProxyMetrics     *egv1a1.ProxyMetrics
 	MaxHeapSizeBytes uint64
+	RuntimeFlags     *egv1a1.ProxyRuntimeFlags
+	Admin            *egv1a1.ProxyAdmin
+	LoadReporting    *egv1a1.ProxyLoadReporting
+	// XdsServerPort is the port of the xDS server, or zero for the default port.
+	XdsServerPort int32
+	// XdsCompression enables the compression of the xDS responses, if set.
+	XdsCompression *egv1a1.ProxyXdsCompression
+	// NodeGroup is the node group of the proxy, which may reference an environment
+	// variable of the proxy container, if set.
+	NodeGroup string
+	// ConfigCheckpoint enables the failover of the xDS stream to the checkpoint xDS server,
+	// serving the configuration checkpoint of the proxy until the xDS server is reached.
+	ConfigCheckpoint bool
+	// RuntimeDiscovery enables the runtime layer of the proxy fetched with RTDS.
+	RuntimeDiscovery bool
 }
 
 // render the stringified bootstrap config in yaml format.
@@ -252,6 +309,47 @@ func GetRenderedBootstrapConfig(opts *RenderBootstrapConfigOptions) (string, err
 		cfg.parameters.OverloadManager.MaxHeapSizeBytes = opts.MaxHeapSizeBytes
 	}
 
+	if opts != nil && opts.RuntimeFlags != nil {
+		for _, flag := range opts.RuntimeFlags.Enabled {
+			cfg.parameters.RuntimeFlags = append(cfg.parameters.RuntimeFlags, runtimeFlag{Name: string(flag), Enabled: true})
+		}
+		for _, flag := range opts.RuntimeFlags.Disabled {
+			cfg.parameters.RuntimeFlags = append(cfg.parameters.RuntimeFlags, runtimeFlag{Name: string(flag), Enabled: false})
+		}
+	}
+
+	if opts != nil && opts.Admin.GetExposure() == egv1a1.ProxyAdminExposureUnixSocket {
+		cfg.parameters.AdminServer.UnixSocketPath = EnvoyAdminUnixSocketPath
+	}
+
+	if opts != nil && opts.LoadReporting != nil {
+		cfg.parameters.EnableLoadReporting = true
+	}
+
+	if opts != nil && opts.XdsServerPort != 0 {
+		cfg.parameters.XdsServer.Port = opts.XdsServerPort
+	}
+
+	if opts != nil && opts.XdsCompression != nil {
+		// Gzip is the only supported algorithm.
+		cfg.parameters.XdsCompression = grpcCompressGzip
+	}
+
+	if opts != nil {
+		cfg.parameters.NodeGroup = opts.NodeGroup
+	}
+
+	if opts != nil {
+		cfg.parameters.EnableRuntimeDiscovery = opts.RuntimeDiscovery
+	}
+
+	if opts != nil && opts.ConfigCheckpoint {
+		cfg.parameters.CheckpointServer = &serverParameters{
+			Address: CheckpointServerAddress,
+			Port:    CheckpointServerPort,
+		}
+	}
+
 	if err := cfg.render(); err != nil {
 		return "", err
 	}
@@ -5,9 +5,14 @@ admin:
       "@type": type.googleapis.com/envoy.extensions.access_loggers.file.v3.FileAccessLog
       path: {{ .AdminServer.AccessLogPath }}
   address:
+    {{- if .AdminServer.UnixSocketPath }}
+    pipe:
+      path: {{ .AdminServer.UnixSocketPath }}
+    {{- else }}
     socket_address:
       address: {{ .AdminServer.Address }}
       port_value: {{ .AdminServer.Port }}
+    {{- end }}
 {{- if .StatsMatcher  }}
 stats_config:
   stats_matcher:
@@ -35,13 +40,71 @@ layered_runtime:
       envoy.restart_features.use_eds_cache_for_ads: true
       re2.max_program_size.error_level: 4294967295
       re2.max_program_size.warn_level: 1000
+{{- if .CheckpointServer }}
+      envoy.restart_features.xds_failover_support: true
+{{- end }}
+{{- range $flag := .RuntimeFlags }}
+      {{ $flag.Name }}: {{ $flag.Enabled }}
+{{- end }}
+{{- if .EnableRuntimeDiscovery }}
+  - name: rtds
+    rtds_layer:
+      name: rtds
+      rtds_config:
+        ads: {}
+        resource_api_version: V3
+{{- end }}
+bootstrap_extensions:
+- name: envoy.bootstrap.internal_listener
+  typed_config:
+    "@type": type.googleapis.com/envoy.extensions.bootstrap.internal_listener.v3.InternalListener
+{{- if .EnableLoadReporting }}
+cluster_manager:
+  load_stats_config:
+    api_type: GRPC
+    transport_api_version: V3
+    grpc_services:
+    - envoy_grpc:
+        cluster_name: xds_cluster
+{{- end }}
+{{- with .NodeGroup }}
+node:
+  metadata:
+    nodeGroup: "{{ . }}"
+{{- end }}
 dynamic_resources:
   ads_config:
     api_type: DELTA_GRPC
     transport_api_version: V3
     grpc_services:
+{{- if .XdsCompression }}
+    - google_grpc:
+        target_uri: {{ .XdsServer.Address }}:{{ .XdsServer.Port }}
+        stat_prefix: xds_cluster
+        channel_credentials:
+          ssl_credentials:
+            root_certs:
+              filename: /certs/ca.crt
+            private_key:
+              filename: /certs/tls.key
+            cert_chain:
+              filename: /certs/tls.crt
+        channel_args:
+          args:
+            grpc.default_compression_algorithm:
+              int_value: {{ .XdsCompression }}
+            grpc.keepalive_time_ms:
+              int_value: 30000
+            grpc.keepalive_timeout_ms:
+              int_value: 5000
+{{- else }}
     - envoy_grpc:
         cluster_name: xds_cluster
+{{- end }}
+{{- if .CheckpointServer }}
+    - envoy_grpc:
+        cluster_name: checkpoint_cluster
+{{- end }}
     set_node_on_first_message_only: true
   lds_config:
     ads: {}
@@ -134,9 +197,14 @@ static_resources:
       - lb_endpoints:
         - endpoint:
             address:
+              {{- if .AdminServer.UnixSocketPath }}
+              pipe:
+                path: {{ .AdminServer.UnixSocketPath }}
+              {{- else }}
               socket_address:
                 address: {{ .AdminServer.Address }}
                 port_value: {{ .AdminServer.Port }}
+              {{- end }}
   {{- end }}
   {{- range $idx, $sink := .OtelMetricSinks }}
   - name: otel_metric_sink_{{ $idx }}
@@ -199,6 +267,26 @@ static_resources:
               path_config_source:
                 path: "/sds/xds-trusted-ca.json"
               resource_api_version: V3
+  {{- with .CheckpointServer }}
+  - name: checkpoint_cluster
+    connect_timeout: 1s
+    type: STATIC
+    lb_policy: ROUND_ROBIN
+    load_assignment:
+      cluster_name: checkpoint_cluster
+      endpoints:
+      - lb_endpoints:
+        - endpoint:
+            address:
+              socket_address:
+                address: {{ .Address }}
+                port_value: {{ .Port }}
+    typed_extension_protocol_options:
+      envoy.extensions.upstreams.http.v3.HttpProtocolOptions:
+        "@type": "type.googleapis.com/envoy.extensions.upstreams.http.v3.HttpProtocolOptions"
+        explicit_http_config:
+          http2_protocol_options: {}
+  {{- end }}
   - name: wasm_cluster
     type: STRICT_DNS
     connect_timeout: 10s
@@ -138,6 +138,61 @@ func TestGetRenderedBootstrapConfig(t *testing.T) {
 				MaxHeapSizeBytes: 1073741824,
 			},
 		},
+		{
+			name: "runtime-flags",
+			opts: &RenderBootstrapConfigOptions{
+				RuntimeFlags: &egv1a1.ProxyRuntimeFlags{
+					Enabled:  []egv1a1.RuntimeFlag{egv1a1.RuntimeFlagHTTP1UseBalsaParser},
+					Disabled: []egv1a1.RuntimeFlag{egv1a1.RuntimeFlagNoExtensionLookupByName},
+				},
+			},
+		},
+		{
+			name: "admin-unix-socket",
+			opts: &RenderBootstrapConfigOptions{
+				Admin: &egv1a1.ProxyAdmin{
+					Exposure: ptr.To(egv1a1.ProxyAdminExposureUnixSocket),
+				},
+			},
+		},
+		{
+			name: "load-reporting",
+			opts: &RenderBootstrapConfigOptions{
+				LoadReporting: &egv1a1.ProxyLoadReporting{},
+			},
+		},
+		{
+			name: "xds-server-port",
+			opts: &RenderBootstrapConfigOptions{
+				XdsServerPort: 18010,
+			},
+		},
+		{
+			name: "xds-compression",
+			opts: &RenderBootstrapConfigOptions{
+				XdsCompression: &egv1a1.ProxyXdsCompression{
+					Type: egv1a1.XdsCompressionTypeGzip,
+				},
+			},
+		},
+		{
+			name: "node-group",
+			opts: &RenderBootstrapConfigOptions{
+				NodeGroup: "$(ENVOY_NODE_GROUP)",
+			},
+		},
+		{
+			name: "config-checkpoint",
+			opts: &RenderBootstrapConfigOptions{
+				ConfigCheckpoint: true,
+			},
+		},
+		{
+			name: "runtime-discovery",
+			opts: &RenderBootstrapConfigOptions{
+				RuntimeDiscovery: true,
+			},
+		},
 	}
 
 	for _, tc := range cases {
@@ -0,0 +1,93 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package cache
+
+import (
+	"encoding/json"
+	"time"
+)
+
+const (
+	// ExchangeDirectionRequest is the direction of the requests received from the nodes.
+	ExchangeDirectionRequest = "request"
+	// ExchangeDirectionResponse is the direction of the responses sent to the nodes.
+	ExchangeDirectionResponse = "response"
+)
+
+// ExchangeRecord records an xDS request received from a node or a response sent to it.
+type ExchangeRecord struct {
+	// Time is when the request was received or the response sent.
+	Time time.Time `json:"time"`
+	// Direction is either ExchangeDirectionRequest or ExchangeDirectionResponse.
+	Direction string `json:"direction"`
+	// Partition is the partition of the snapshot cache of the stream.
+	Partition string `json:"partition"`
+	// StreamID is the ID of the stream of the exchange.
+	StreamID int64 `json:"streamID"`
+	// Delta is true if the stream uses the incremental xDS protocol.
+	Delta bool `json:"delta,omitempty"`
+	// NodeID is the ID of the node of the stream.
+	NodeID string `json:"nodeID"`
+	// TypeURL is the type URL of the resources of the exchange.
+	TypeURL string `json:"typeURL"`
+	// Version is the version of the response, or the last version accepted by the
+	// node for a State of the World request.
+	Version string `json:"version,omitempty"`
+	// Nonce is the nonce of the response, or the nonce of the response answered by
+	// the request.
+	Nonce string `json:"nonce,omitempty"`
+	// ResourceNames are the names of the resources requested, or sent by an
+	// incremental response.
+	ResourceNames []string `json:"resourceNames,omitempty"`
+	// RemovedResourceNames are the names of the resources unsubscribed from by an
+	// incremental request, or removed by an incremental response.
+	RemovedResourceNames []string `json:"removedResourceNames,omitempty"`
+	// Resources is the number of the resources of a response.
+	Resources int `json:"resources,omitempty"`
+	// Result is the result of a request: "ack" or "nack" if it answers a response,
+	// "request" otherwise.
+	Result string `json:"result,omitempty"`
+	// ErrorMessage is the error of the node rejecting a response.
+	ErrorMessage string `json:"errorMessage,omitempty"`
+	// Latency is how long the node took to answer the last response sent on the
+	// stream, or zero if the request doesn't answer it.
+	Latency time.Duration `json:"-"`
+}
+
+// MarshalJSON encodes the record with its latency in seconds.
+func (r *ExchangeRecord) MarshalJSON() ([]byte, error) {
+	type record ExchangeRecord
+	return json.Marshal(struct {
+		*record
+		LatencySeconds float64 `json:"latencySeconds,omitempty"`
+	}{
+		record:         (*record)(r),
+		LatencySeconds: r.Latency.Seconds(),
+	})
+}
+
+// ExchangeAuditor is notified of the exchanges of the snapshot cache with the nodes.
+// It's called with the lock of the cache held, so it must not block.
+type ExchangeAuditor interface {
+	Audit(record *ExchangeRecord)
+}
+
+// WithExchangeAuditor sets the auditor notified of the exchanges with the nodes.
+func WithExchangeAuditor(auditor ExchangeAuditor) Option {
+	return func(o *options) {
+		o.auditor = auditor
+	}
+}
+
+// audit notifies the auditor of the exchange, if set.
+func (s *snapshotCache) audit(record *ExchangeRecord) {
+	if s.auditor == nil {
+		return
+	}
+	record.Time = time.Now()
+	record.Partition = s.partition
+	s.auditor.Audit(record)
+}
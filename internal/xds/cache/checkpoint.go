@@ -0,0 +1,118 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package cache
+
+import (
+	"bufio"
+	"bytes"
+	"io"
+	"maps"
+	"slices"
+
+	cachev3 "github.com/envoyproxy/go-control-plane/pkg/cache/v3"
+	resourcev3 "github.com/envoyproxy/go-control-plane/pkg/resource/v3"
+
+	"github.com/envoyproxy/gateway/internal/xds/types"
+)
+
+// Checkpoint returns the newest snapshot of the irKey acknowledged by one of its nodes, with
+// the LEDS and VHDS resources served at the time, encoded like the persisted snapshots, and
+// its version. The secrets are left out, as the checkpoints are stored in ConfigMaps. The
+// version is empty if no node acknowledged a snapshot still retained in the history.
+func (s *snapshotCache) Checkpoint(irKey string) (string, []byte, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var version string
+	for _, node := range s.getNodes(irKey) {
+		good := s.goodSnapshots[node.Id]
+		if len(good) == 0 {
+			continue
+		}
+		if v := newestVersion(good[len(good)-1]); version == "" || newerVersion(v, version) {
+			version = v
+		}
+	}
+	history := s.snapshotHistory[irKey]
+	i := snapshotIndex(history, version)
+	if version == "" || i < 0 {
+		return "", nil, nil
+	}
+
+	resources := snapshotResources(history[i].Snapshot)
+	delete(resources, resourcev3.SecretType)
+	endpoints := s.localityEndpoints[irKey]
+	for _, name := range slices.Sorted(maps.Keys(endpoints)) {
+		resources[types.LbEndpointType] = append(resources[types.LbEndpointType], endpoints[name].resource)
+	}
+	routeConfigs := s.virtualHosts[irKey]
+	for _, name := range slices.Sorted(maps.Keys(routeConfigs)) {
+		for _, vHost := range routeConfigs[name] {
+			resources[types.VirtualHostType] = append(resources[types.VirtualHostType], vHost.resource)
+		}
+	}
+	var scopes []types.NodeScope
+	for _, scoped := range s.scopedSnapshots[irKey] {
+		scopes = append(scopes, scoped.scope)
+	}
+
+	buf := new(bytes.Buffer)
+	header := persistedSnapshotHeader{Format: currentSnapshotFormat, IRKey: irKey, Version: version, Scopes: scopes}
+	if err := marshalSnapshot(buf, header, resources); err != nil {
+		return "", nil, err
+	}
+	return version, buf.Bytes(), nil
+}
+
+// CheckpointSnapshot is the snapshot of an irKey read from a checkpoint.
+type CheckpointSnapshot struct {
+	// IRKey is the irKey of the snapshot.
+	IRKey string
+	// Version is the version of the snapshot.
+	Version string
+	// Resources are the resources of the snapshot.
+	Resources types.XdsResources
+	// Scopes are the node scopes of the snapshot.
+	Scopes []types.NodeScope
+}
+
+// ReadCheckpoint reads the snapshot of a checkpoint, migrating it from the format of an
+// older version of Envoy Gateway if needed.
+func ReadCheckpoint(r io.Reader) (*CheckpointSnapshot, error) {
+	br := bufio.NewReader(r)
+	header, err := readSnapshotHeader(br)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkSnapshotFormat(&header); err != nil {
+		return nil, err
+	}
+	resources, err := readSnapshotResources(br)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := migrateSnapshot(&header, resources); err != nil {
+		return nil, err
+	}
+	return &CheckpointSnapshot{
+		IRKey:     header.IRKey,
+		Version:   header.Version,
+		Resources: resources,
+		Scopes:    header.Scopes,
+	}, nil
+}
+
+// newestVersion returns the newest version of the resources of the snapshot, as the
+// endpoints of a snapshot can be updated on their own.
+func newestVersion(snapshot cachev3.ResourceSnapshot) string {
+	var version string
+	for _, typeURL := range resourceTypes {
+		if v := snapshot.GetVersion(typeURL); version == "" || newerVersion(v, version) {
+			version = v
+		}
+	}
+	return version
+}
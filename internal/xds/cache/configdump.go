@@ -0,0 +1,185 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package cache
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+
+	adminv3 "github.com/envoyproxy/go-control-plane/envoy/admin/v3"
+	corev3 "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	tlsv3 "github.com/envoyproxy/go-control-plane/envoy/extensions/transport_sockets/tls/v3"
+	cachev3 "github.com/envoyproxy/go-control-plane/pkg/cache/v3"
+	resourcev3 "github.com/envoyproxy/go-control-plane/pkg/resource/v3"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
+// ErrNodeNotFound is returned when dumping the configuration of a node not connected.
+var ErrNodeNotFound = errors.New("node not found")
+
+// redacted replaces the sensitive values of the dumped secrets, as in the config dumps of Envoy.
+const redacted = "[redacted]"
+
+// ConfigDump returns the resources of the snapshot served to the node of the irKey, or of the
+// last snapshot generated for the irKey if nodeID is empty, in the format of the config dump
+// of the admin interface of Envoy, with the endpoints if includeEndpoints is set. The served
+// snapshot of a node differs from the last snapshot while publishing is paused, once it's
+// rolled back after a rejection, or if the node is served a subset of the resources.
+//
+// The private keys and the other sensitive values of the secrets are redacted.
+func (s *snapshotCache) ConfigDump(irKey, nodeID string, includeEndpoints bool) (*adminv3.ConfigDump, error) {
+	s.mu.RLock()
+	var snapshot cachev3.ResourceSnapshot
+	if nodeID == "" {
+		if last := s.lastSnapshot[irKey]; last != nil {
+			snapshot = last
+		}
+	} else {
+		var node *corev3.Node
+		for _, n := range s.getNodes(irKey) {
+			if n.Id == nodeID {
+				node = n
+				break
+			}
+		}
+		if node == nil {
+			s.mu.RUnlock()
+			return nil, fmt.Errorf("%w: %s is not connected for %s", ErrNodeNotFound, nodeID, irKey)
+		}
+		if served, err := s.GetSnapshot(s.snapshotKey(node)); err == nil {
+			snapshot = served
+		}
+	}
+	s.mu.RUnlock()
+
+	if snapshot == nil {
+		return nil, fmt.Errorf("%w: no snapshot of %s", ErrSnapshotNotFound, irKey)
+	}
+	return buildConfigDump(snapshot, includeEndpoints)
+}
+
+// buildConfigDump returns the config dump of the resources of the snapshot, in the order
+// Envoy dumps them.
+func buildConfigDump(snapshot cachev3.ResourceSnapshot, includeEndpoints bool) (*adminv3.ConfigDump, error) {
+	var (
+		clusters  = &adminv3.ClustersConfigDump{VersionInfo: snapshot.GetVersion(resourcev3.ClusterType)}
+		ecds      = &adminv3.EcdsConfigDump{}
+		listeners = &adminv3.ListenersConfigDump{VersionInfo: snapshot.GetVersion(resourcev3.ListenerType)}
+		routes    = &adminv3.RoutesConfigDump{}
+		secrets   = &adminv3.SecretsConfigDump{}
+		endpoints = &adminv3.EndpointsConfigDump{}
+	)
+	err := forEachResource(snapshot, resourcev3.ClusterType, func(version string, r *anypb.Any) {
+		clusters.DynamicActiveClusters = append(clusters.DynamicActiveClusters, &adminv3.ClustersConfigDump_DynamicCluster{
+			VersionInfo: version,
+			Cluster:     r,
+		})
+	})
+	if err == nil {
+		err = forEachResource(snapshot, resourcev3.ExtensionConfigType, func(version string, r *anypb.Any) {
+			ecds.EcdsFilters = append(ecds.EcdsFilters, &adminv3.EcdsConfigDump_EcdsFilterConfig{
+				VersionInfo: version,
+				EcdsFilter:  r,
+			})
+		})
+	}
+	if err == nil {
+		err = forEachResource(snapshot, resourcev3.ListenerType, func(version string, r *anypb.Any) {
+			listeners.DynamicListeners = append(listeners.DynamicListeners, &adminv3.ListenersConfigDump_DynamicListener{
+				ActiveState: &adminv3.ListenersConfigDump_DynamicListenerState{
+					VersionInfo: version,
+					Listener:    r,
+				},
+			})
+		})
+	}
+	if err == nil {
+		err = forEachResource(snapshot, resourcev3.RouteType, func(version string, r *anypb.Any) {
+			routes.DynamicRouteConfigs = append(routes.DynamicRouteConfigs, &adminv3.RoutesConfigDump_DynamicRouteConfig{
+				VersionInfo: version,
+				RouteConfig: r,
+			})
+		})
+	}
+	if err == nil {
+		err = forEachResource(snapshot, resourcev3.SecretType, func(version string, r *anypb.Any) {
+			secrets.DynamicActiveSecrets = append(secrets.DynamicActiveSecrets, &adminv3.SecretsConfigDump_DynamicSecret{
+				VersionInfo: version,
+				Secret:      r,
+			})
+		})
+	}
+	if err == nil && includeEndpoints {
+		err = forEachResource(snapshot, resourcev3.EndpointType, func(version string, r *anypb.Any) {
+			endpoints.DynamicEndpointConfigs = append(endpoints.DynamicEndpointConfigs, &adminv3.EndpointsConfigDump_DynamicEndpointConfig{
+				VersionInfo:    version,
+				EndpointConfig: r,
+			})
+		})
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	configDump := &adminv3.ConfigDump{}
+	configs := []proto.Message{clusters, ecds, listeners, routes, secrets}
+	if includeEndpoints {
+		configs = append(configs, endpoints)
+	}
+	for _, config := range configs {
+		c, err := anypb.New(config)
+		if err != nil {
+			return nil, err
+		}
+		configDump.Configs = append(configDump.Configs, c)
+	}
+	return configDump, nil
+}
+
+// forEachResource calls the function with the version of the resources of the type and
+// each resource of the type by name, with the secrets redacted.
+func forEachResource(snapshot cachev3.ResourceSnapshot, typeURL resourcev3.Type, fn func(version string, r *anypb.Any)) error {
+	version := snapshot.GetVersion(typeURL)
+	resources := snapshot.GetResources(typeURL)
+	for _, name := range slices.Sorted(maps.Keys(resources)) {
+		resource := resources[name]
+		if secret, ok := resource.(*tlsv3.Secret); ok {
+			resource = redactSecret(secret)
+		}
+		r, err := anypb.New(resource)
+		if err != nil {
+			return fmt.Errorf("failed to dump %s %s: %w", typeURL, cachev3.GetResourceName(resource), err)
+		}
+		fn(version, r)
+	}
+	return nil
+}
+
+// redactSecret returns a copy of the secret with its private keys, passwords, session ticket
+// keys and generic secrets redacted.
+func redactSecret(secret *tlsv3.Secret) *tlsv3.Secret {
+	secret = proto.Clone(secret).(*tlsv3.Secret)
+	redactedSource := &corev3.DataSource{Specifier: &corev3.DataSource_InlineString{InlineString: redacted}}
+	switch s := secret.Type.(type) {
+	case *tlsv3.Secret_TlsCertificate:
+		if s.TlsCertificate.PrivateKey != nil {
+			s.TlsCertificate.PrivateKey = redactedSource
+		}
+		if s.TlsCertificate.Password != nil {
+			s.TlsCertificate.Password = redactedSource
+		}
+	case *tlsv3.Secret_SessionTicketKeys:
+		for i := range s.SessionTicketKeys.Keys {
+			s.SessionTicketKeys.Keys[i] = redactedSource
+		}
+	case *tlsv3.Secret_GenericSecret:
+		s.GenericSecret.Secret = redactedSource
+	}
+	return secret
+}
@@ -0,0 +1,217 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package cache
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	xdsmatcherv3 "github.com/cncf/xds/go/xds/type/matcher/v3"
+	clusterv3 "github.com/envoyproxy/go-control-plane/envoy/config/cluster/v3"
+	corev3 "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	listenerv3 "github.com/envoyproxy/go-control-plane/envoy/config/listener/v3"
+	routev3 "github.com/envoyproxy/go-control-plane/envoy/config/route/v3"
+	tlsv3 "github.com/envoyproxy/go-control-plane/envoy/extensions/transport_sockets/tls/v3"
+	cachetypes "github.com/envoyproxy/go-control-plane/pkg/cache/types"
+	cachev3 "github.com/envoyproxy/go-control-plane/pkg/cache/v3"
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+// InconsistentSnapshotError is returned when a snapshot is refused because its
+// resources reference resources missing from it.
+type InconsistentSnapshotError struct {
+	// IRKey is the irKey of the snapshot.
+	IRKey string
+	// Problems describes each inconsistency of the snapshot.
+	Problems []string
+}
+
+func (e *InconsistentSnapshotError) Error() string {
+	return fmt.Sprintf("inconsistent snapshot for %s: %s", e.IRKey, strings.Join(e.Problems, "; "))
+}
+
+// WithConsistencyCheck enables the validation of the snapshots before they are set,
+// refusing the snapshots whose resources reference resources missing from them.
+func WithConsistencyCheck() Option {
+	return func(o *options) {
+		o.checkConsistency = true
+	}
+}
+
+// checkSnapshotConsistency returns the inconsistencies of the snapshot: the ones
+// reported by the snapshot itself, the clusters referenced by the routes and the
+// secrets referenced by the clusters missing from it.
+func checkSnapshotConsistency(snapshot *cachev3.Snapshot) []string {
+	var problems []string
+	if err := snapshot.Consistent(); err != nil {
+		problems = append(problems, err.Error())
+	}
+
+	clusters := snapshot.Resources[cachetypes.Cluster].Items
+	secrets := snapshot.Resources[cachetypes.Secret].Items
+
+	missingClusters := sets.New[string]()
+	for _, item := range snapshot.Resources[cachetypes.Route].Items {
+		routeConfig, ok := item.Resource.(*routev3.RouteConfiguration)
+		if !ok {
+			continue
+		}
+		for _, name := range routeClusterNames(routeConfig) {
+			if _, ok := clusters[name]; !ok {
+				missingClusters.Insert(name)
+			}
+		}
+	}
+	for _, name := range sets.List(missingClusters) {
+		problems = append(problems, fmt.Sprintf("cluster %q referenced by a route is missing", name))
+	}
+
+	missingSecrets := sets.New[string]()
+	for _, item := range clusters {
+		cluster, ok := item.Resource.(*clusterv3.Cluster)
+		if !ok {
+			continue
+		}
+		for _, name := range clusterSecretNames(cluster) {
+			if _, ok := secrets[name]; !ok {
+				missingSecrets.Insert(name)
+			}
+		}
+	}
+	for _, name := range sets.List(missingSecrets) {
+		problems = append(problems, fmt.Sprintf("secret %q referenced by a cluster is missing", name))
+	}
+
+	sort.Strings(problems)
+	return problems
+}
+
+// routeClusterNames returns the names of the clusters the routes of the route
+// configuration forward and mirror the requests to. The routes setting the response
+// of a missing cluster, such as the routes to invalid backends, are skipped.
+func routeClusterNames(routeConfig *routev3.RouteConfiguration) []string {
+	var names []string
+	for _, vh := range routeConfig.GetVirtualHosts() {
+		for _, route := range virtualHostRoutes(vh) {
+			action := route.GetRoute()
+			if action == nil || action.GetClusterNotFoundResponseCode() != routev3.RouteAction_SERVICE_UNAVAILABLE {
+				continue
+			}
+			if name := action.GetCluster(); name != "" {
+				names = append(names, name)
+			}
+			for _, weighted := range action.GetWeightedClusters().GetClusters() {
+				names = append(names, weighted.GetName())
+			}
+			for _, mirror := range action.GetRequestMirrorPolicies() {
+				if name := mirror.GetCluster(); name != "" {
+					names = append(names, name)
+				}
+			}
+		}
+	}
+	return names
+}
+
+// virtualHostRoutes returns the routes of the virtual host, listed or in the leaves of
+// its matcher tree.
+func virtualHostRoutes(vh *routev3.VirtualHost) []*routev3.Route {
+	if vh.GetMatcher() == nil {
+		return vh.GetRoutes()
+	}
+	var routes []*routev3.Route
+	var walk func(onMatch *xdsmatcherv3.Matcher_OnMatch)
+	walkMatcher := func(matcher *xdsmatcherv3.Matcher) {
+		tree := matcher.GetMatcherTree()
+		for _, onMatch := range tree.GetExactMatchMap().GetMap() {
+			walk(onMatch)
+		}
+		for _, onMatch := range tree.GetPrefixMatchMap().GetMap() {
+			walk(onMatch)
+		}
+		for _, fieldMatcher := range matcher.GetMatcherList().GetMatchers() {
+			walk(fieldMatcher.GetOnMatch())
+		}
+		walk(matcher.GetOnNoMatch())
+	}
+	walk = func(onMatch *xdsmatcherv3.Matcher_OnMatch) {
+		if onMatch == nil {
+			return
+		}
+		if matcher := onMatch.GetMatcher(); matcher != nil {
+			walkMatcher(matcher)
+			return
+		}
+		action := onMatch.GetAction().GetTypedConfig()
+		routeList := &routev3.RouteList{}
+		route := &routev3.Route{}
+		switch {
+		case action.MessageIs(routeList) && action.UnmarshalTo(routeList) == nil:
+			routes = append(routes, routeList.GetRoutes()...)
+		case action.MessageIs(route) && action.UnmarshalTo(route) == nil:
+			routes = append(routes, route)
+		}
+	}
+	walkMatcher(vh.GetMatcher())
+	return routes
+}
+
+// clusterSecretNames returns the names of the secrets the TLS transport sockets of
+// the cluster fetch over ADS.
+func clusterSecretNames(cluster *clusterv3.Cluster) []string {
+	sockets := []*corev3.TransportSocket{cluster.GetTransportSocket()}
+	for _, match := range cluster.GetTransportSocketMatches() {
+		sockets = append(sockets, match.GetTransportSocket())
+	}
+
+	var names []string
+	for _, socket := range sockets {
+		tlsCtx := &tlsv3.UpstreamTlsContext{}
+		typedConfig := socket.GetTypedConfig()
+		if !typedConfig.MessageIs(tlsCtx) || typedConfig.UnmarshalTo(tlsCtx) != nil {
+			continue
+		}
+		names = append(names, commonTLSSecretNames(tlsCtx.GetCommonTlsContext())...)
+	}
+	return names
+}
+
+// listenerSecretNames returns the names of the secrets the TLS transport sockets of the
+// filter chains of the listener fetch over ADS.
+func listenerSecretNames(listener *listenerv3.Listener) []string {
+	chains := append([]*listenerv3.FilterChain{listener.GetDefaultFilterChain()}, listener.GetFilterChains()...)
+
+	var names []string
+	for _, chain := range chains {
+		tlsCtx := &tlsv3.DownstreamTlsContext{}
+		typedConfig := chain.GetTransportSocket().GetTypedConfig()
+		if !typedConfig.MessageIs(tlsCtx) || typedConfig.UnmarshalTo(tlsCtx) != nil {
+			continue
+		}
+		names = append(names, commonTLSSecretNames(tlsCtx.GetCommonTlsContext())...)
+		if sds := tlsCtx.GetSessionTicketKeysSdsSecretConfig(); sds.GetName() != "" && sds.GetSdsConfig().GetAds() != nil {
+			names = append(names, sds.GetName())
+		}
+	}
+	return names
+}
+
+// commonTLSSecretNames returns the names of the secrets of the TLS context fetched over ADS.
+func commonTLSSecretNames(common *tlsv3.CommonTlsContext) []string {
+	sdsConfigs := append([]*tlsv3.SdsSecretConfig{}, common.GetTlsCertificateSdsSecretConfigs()...)
+	sdsConfigs = append(sdsConfigs,
+		common.GetValidationContextSdsSecretConfig(),
+		common.GetCombinedValidationContext().GetValidationContextSdsSecretConfig())
+
+	var names []string
+	for _, sds := range sdsConfigs {
+		if sds.GetName() != "" && sds.GetSdsConfig().GetAds() != nil {
+			names = append(names, sds.GetName())
+		}
+	}
+	return names
+}
@@ -0,0 +1,297 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package cache
+
+import (
+	"slices"
+	"sync"
+
+	cachetypes "github.com/envoyproxy/go-control-plane/pkg/cache/types"
+	cachev3 "github.com/envoyproxy/go-control-plane/pkg/cache/v3"
+
+	"github.com/envoyproxy/gateway/internal/xds/types"
+)
+
+// poolKey identifies a resource of the pool by its type URL and the hash of its content.
+type poolKey struct {
+	typeURL string
+	hash    string
+}
+
+// pooledResource is a resource of the pool, with the number of retained snapshots it's
+// referenced by and its serialized size.
+type pooledResource struct {
+	resource cachetypes.Resource
+	refs     int
+	size     int64
+}
+
+// resourcePool stores the resources of the snapshots retained by the cache once by content,
+// so that the identical resources of the snapshots of all the irKeys, and of the successive
+// snapshots of an irKey, are shared by reference instead of being stored once per snapshot.
+// The resources are referenced by the snapshots of the histories of the irKeys, and dropped
+// from the pool once no snapshot references them. The hash of a resource is its version in
+// the delta xDS responses, so the pool also sets the versions of the snapshots it holds
+// all the resources of. The pool has a lock of its own, so that the resources of the
+// snapshots of an irKey are interned without holding the lock of the cache.
+type resourcePool struct {
+	mu      sync.Mutex
+	entries map[poolKey]*pooledResource
+	// keys holds the key of each pooled resource.
+	keys map[cachetypes.Resource]poolKey
+	// unreferenced holds the keys of the entries no snapshot references, dropped by prune.
+	unreferenced map[poolKey]bool
+	// size is the serialized size of the pooled resources.
+	size int64
+	// refs is the number of references of the snapshots to the pooled resources.
+	refs int
+}
+
+func newResourcePool() *resourcePool {
+	return &resourcePool{
+		entries:      make(map[poolKey]*pooledResource),
+		keys:         make(map[cachetypes.Resource]poolKey),
+		unreferenced: make(map[poolKey]bool),
+	}
+}
+
+// hashedResource is the pool key and the serialized size of a resource.
+type hashedResource struct {
+	key  poolKey
+	size int64
+}
+
+// hashResource marshals and hashes the resource.
+func hashResource(typeURL string, r cachetypes.Resource) (hashedResource, error) {
+	marshaled, err := cachev3.MarshalResource(r)
+	if err != nil {
+		return hashedResource{}, err
+	}
+	return hashedResource{
+		key:  poolKey{typeURL: typeURL, hash: cachev3.HashResource(marshaled)},
+		size: int64(len(marshaled)),
+	}, nil
+}
+
+// hashResources marshals and hashes the resources of the types held by the snapshots. It
+// doesn't involve the pool, so the resources of a new snapshot are hashed without holding
+// the lock of the pool, and interned once it's held.
+func hashResources(resources types.XdsResources) (map[cachetypes.Resource]hashedResource, error) {
+	hashed := make(map[cachetypes.Resource]hashedResource)
+	for typeURL, rs := range resources {
+		if !slices.Contains(resourceTypes, typeURL) {
+			continue
+		}
+		for _, r := range rs {
+			h, err := hashResource(typeURL, r)
+			if err != nil {
+				return nil, err
+			}
+			hashed[r] = h
+		}
+	}
+	return hashed, nil
+}
+
+// intern returns the resources with the resources already pooled replaced by the pooled
+// resources of identical content, and adds the others to the pool. The resources are pinned
+// to the pool, so that they are not pruned until the returned function unpins them once
+// the snapshot holding them is recorded. The resources not hashed beforehand are hashed.
+// The LEDS and VHDS resources, which are not held by the snapshots, are left as is.
+func (p *resourcePool) intern(resources types.XdsResources, hashed map[cachetypes.Resource]hashedResource) (types.XdsResources, func(), error) {
+	if resources == nil {
+		return nil, func() {}, nil
+	}
+	for typeURL, rs := range resources {
+		if !slices.Contains(resourceTypes, typeURL) {
+			continue
+		}
+		for _, r := range rs {
+			if _, ok := hashed[r]; ok {
+				continue
+			}
+			h, err := hashResource(typeURL, r)
+			if err != nil {
+				return nil, nil, err
+			}
+			if hashed == nil {
+				hashed = make(map[cachetypes.Resource]hashedResource)
+			}
+			hashed[r] = h
+		}
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	interned := make(types.XdsResources, len(resources))
+	var pinned []poolKey
+	for typeURL, rs := range resources {
+		if rs == nil || !slices.Contains(resourceTypes, typeURL) {
+			interned[typeURL] = rs
+			continue
+		}
+		interned[typeURL] = make([]cachetypes.Resource, 0, len(rs))
+		for _, r := range rs {
+			pooled := p.internHashed(r, hashed[r])
+			key := p.keys[pooled]
+			p.entries[key].refs++
+			delete(p.unreferenced, key)
+			pinned = append(pinned, key)
+			interned[typeURL] = append(interned[typeURL], pooled)
+		}
+	}
+	return interned, func() { p.unpin(pinned) }, nil
+}
+
+// unpin drops the pins of the interned resources.
+func (p *resourcePool) unpin(keys []poolKey) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	for _, key := range keys {
+		entry := p.entries[key]
+		entry.refs--
+		if entry.refs == 0 {
+			p.unreferenced[key] = true
+		}
+	}
+}
+
+// internResource returns the pooled resource of identical content, adding the resource to
+// the pool if there is none.
+func (p *resourcePool) internResource(typeURL string, r cachetypes.Resource) (cachetypes.Resource, error) {
+	p.mu.Lock()
+	_, ok := p.keys[r]
+	p.mu.Unlock()
+	if ok {
+		return r, nil
+	}
+	h, err := hashResource(typeURL, r)
+	if err != nil {
+		return nil, err
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.internHashed(r, h), nil
+}
+
+// internHashed is internResource with the resource hashed beforehand.
+func (p *resourcePool) internHashed(r cachetypes.Resource, h hashedResource) cachetypes.Resource {
+	if _, ok := p.keys[r]; ok {
+		return r
+	}
+	if entry, ok := p.entries[h.key]; ok {
+		return entry.resource
+	}
+	p.entries[h.key] = &pooledResource{resource: r, size: h.size}
+	p.keys[r] = h.key
+	p.unreferenced[h.key] = true
+	p.size += h.size
+	return r
+}
+
+// ref references the pooled resources of the snapshot.
+func (p *resourcePool) ref(snapshot *cachev3.Snapshot) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.forEach(snapshot, func(key poolKey, entry *pooledResource) {
+		entry.refs++
+		p.refs++
+		delete(p.unreferenced, key)
+	})
+}
+
+// release drops the references of the snapshot to the pooled resources.
+func (p *resourcePool) release(snapshot *cachev3.Snapshot) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.forEach(snapshot, func(key poolKey, entry *pooledResource) {
+		entry.refs--
+		p.refs--
+		if entry.refs == 0 {
+			p.unreferenced[key] = true
+		}
+	})
+}
+
+// forEach calls the function with the entry of each pooled resource of the snapshot.
+func (p *resourcePool) forEach(snapshot *cachev3.Snapshot, f func(poolKey, *pooledResource)) {
+	if snapshot == nil {
+		return
+	}
+	for _, resources := range snapshot.Resources {
+		for _, item := range resources.Items {
+			key, ok := p.keys[item.Resource]
+			if !ok {
+				continue
+			}
+			f(key, p.entries[key])
+		}
+	}
+}
+
+// prune drops the resources no snapshot references from the pool, and returns the size
+// and the dedup ratio of the pool.
+func (p *resourcePool) prune() (int64, float64) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	for key := range p.unreferenced {
+		entry := p.entries[key]
+		delete(p.entries, key)
+		delete(p.keys, entry.resource)
+		p.size -= entry.size
+	}
+	clear(p.unreferenced)
+	return p.size, p.dedupRatio()
+}
+
+// setVersions sets the versions of the resources of the snapshot to their hash, if all
+// its resources are pooled, so that they are not hashed again to serve the delta xDS
+// requests.
+func (p *resourcePool) setVersions(snapshot *cachev3.Snapshot) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	versions := make(map[string]map[string]string, len(snapshot.Resources))
+	for i, resources := range snapshot.Resources {
+		typeURL, err := cachev3.GetResponseTypeURL(cachetypes.ResponseType(i))
+		if err != nil {
+			return
+		}
+		versions[typeURL] = make(map[string]string, len(resources.Items))
+		for name, item := range resources.Items {
+			key, ok := p.keys[item.Resource]
+			if !ok {
+				return
+			}
+			versions[typeURL][name] = key.hash
+		}
+	}
+	snapshot.VersionMap = versions
+}
+
+// dedupRatio returns the ratio of the references of the snapshots to the pooled resources
+// to the number of pooled resources, which is the number of copies of each resource the
+// pool saves on average. The lock of the pool must be held.
+func (p *resourcePool) dedupRatio() float64 {
+	if len(p.entries) == 0 {
+		return 0
+	}
+	return float64(p.refs) / float64(len(p.entries))
+}
+
+// prunePool drops the resources no retained snapshot references from the pool, and records
+// its size and dedup ratio.
+func (s *snapshotCache) prunePool() {
+	size, ratio := s.pool.prune()
+	snapshotPoolBytes.With(s.partitionLabel()).Record(float64(size))
+	snapshotPoolDedupRatio.With(s.partitionLabel()).Record(ratio)
+}
@@ -0,0 +1,153 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package cache
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/envoyproxy/go-control-plane/pkg/cache/types"
+	cachev3 "github.com/envoyproxy/go-control-plane/pkg/cache/v3"
+	resourcev3 "github.com/envoyproxy/go-control-plane/pkg/resource/v3"
+	"google.golang.org/protobuf/proto"
+)
+
+// maxDiffNames is the number of resource names listed per change of a resource type
+// in the snapshot diff logged on debug level, the others being only counted.
+const maxDiffNames = 20
+
+// ErrSnapshotNotFound is returned when diffing a snapshot version that is not retained.
+var ErrSnapshotNotFound = errors.New("snapshot not found")
+
+// ResourceDiff lists the resources of a type added, removed and changed between two snapshots.
+type ResourceDiff struct {
+	Added   []string `json:"added,omitempty"`
+	Removed []string `json:"removed,omitempty"`
+	Changed []string `json:"changed,omitempty"`
+	// More is the number of names not listed.
+	More int `json:"more,omitempty"`
+}
+
+// SnapshotDiff describes the changes of the resources between two snapshots of an irKey.
+type SnapshotDiff struct {
+	// IRKey is the key of the xDS IR the snapshots were generated from.
+	IRKey string `json:"irKey"`
+	// From is the version of the snapshot the changes are relative to.
+	From string `json:"from"`
+	// To is the version of the changed snapshot.
+	To string `json:"to"`
+	// Resources holds the changes of the resources keyed by the short name of their
+	// type, such as Cluster, and omits the types without changes.
+	Resources map[string]*ResourceDiff `json:"resources"`
+}
+
+// DiffSnapshots returns the changes of the resources between the snapshot versions of the
+// irKey. The last snapshot is diffed if versionB is empty, against the snapshot preceding
+// versionB if versionA is empty. Only the snapshots of the history of the irKey can be
+// diffed. The names of the secrets are redacted.
+func (s *snapshotCache) DiffSnapshots(irKey, versionA, versionB string) (*SnapshotDiff, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	history := s.snapshotHistory[irKey]
+	if len(history) == 0 {
+		return nil, fmt.Errorf("%w: no snapshot of %s", ErrSnapshotNotFound, irKey)
+	}
+
+	b := len(history) - 1
+	if versionB != "" {
+		if b = snapshotIndex(history, versionB); b < 0 {
+			return nil, fmt.Errorf("%w: version %s of %s", ErrSnapshotNotFound, versionB, irKey)
+		}
+	}
+	a := b - 1
+	if versionA != "" {
+		if a = snapshotIndex(history, versionA); a < 0 {
+			return nil, fmt.Errorf("%w: version %s of %s", ErrSnapshotNotFound, versionA, irKey)
+		}
+	}
+
+	diff := &SnapshotDiff{
+		IRKey: irKey,
+		To:    history[b].Version,
+	}
+	var before *cachev3.Snapshot
+	if a >= 0 {
+		diff.From = history[a].Version
+		before = history[a].Snapshot
+	}
+	diff.Resources = diffSnapshots(before, history[b].Snapshot, 0)
+	return diff, nil
+}
+
+// diffSnapshots returns the resources added, removed and changed by a snapshot against
+// the previous snapshot, keyed by the short name of their type. The names of the secrets
+// are redacted, and at most maxNames names are listed per change if maxNames is positive.
+func diffSnapshots(previous, snapshot *cachev3.Snapshot, maxNames int) map[string]*ResourceDiff {
+	diffs := make(map[string]*ResourceDiff)
+	for _, typeURL := range resourceTypes {
+		var before, after map[string]types.Resource
+		if previous != nil {
+			before = previous.GetResources(typeURL)
+		}
+		if snapshot != nil {
+			after = snapshot.GetResources(typeURL)
+		}
+
+		diff := &ResourceDiff{}
+		for name, r := range after {
+			old, ok := before[name]
+			switch {
+			case !ok:
+				diff.Added = append(diff.Added, name)
+			case !proto.Equal(old, r):
+				diff.Changed = append(diff.Changed, name)
+			}
+		}
+		for name := range before {
+			if _, ok := after[name]; !ok {
+				diff.Removed = append(diff.Removed, name)
+			}
+		}
+		if len(diff.Added)+len(diff.Removed)+len(diff.Changed) == 0 {
+			continue
+		}
+
+		redact := typeURL == resourcev3.SecretType
+		diff.Added = diff.summarize(diff.Added, redact, maxNames)
+		diff.Removed = diff.summarize(diff.Removed, redact, maxNames)
+		diff.Changed = diff.summarize(diff.Changed, redact, maxNames)
+		diffs[typeURL[strings.LastIndex(typeURL, ".")+1:]] = diff
+	}
+	return diffs
+}
+
+// summarize sorts and redacts the names, and truncates them to maxNames if positive,
+// counting the names dropped.
+func (d *ResourceDiff) summarize(names []string, redact bool, maxNames int) []string {
+	if redact {
+		for i, name := range names {
+			names[i] = redactName(name)
+		}
+	}
+	sort.Strings(names)
+	if maxNames > 0 && len(names) > maxNames {
+		d.More += len(names) - maxNames
+		names = names[:maxNames]
+	}
+	return names
+}
+
+// redactName replaces a resource name by a digest, which tells whether the
+// resources changed across snapshots without revealing their name.
+func redactName(name string) string {
+	sum := sha256.Sum256([]byte(name))
+	return "redacted-" + hex.EncodeToString(sum[:4])
+}
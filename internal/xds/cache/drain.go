@@ -0,0 +1,52 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package cache
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// drainPollInterval is the interval the responses in flight are checked at while draining.
+const drainPollInterval = 50 * time.Millisecond
+
+// ErrDraining is returned for the streams opened while the cache is draining, which are
+// refused so that the proxies connect to another replica of the xDS server.
+var ErrDraining = errors.New("xds server is draining")
+
+// Drain refuses the streams opened from now on, and waits until the responses sent on the
+// open streams have been acknowledged or rejected by the proxies, or their streams closed,
+// or until the context is done.
+func (s *snapshotCache) Drain(ctx context.Context) error {
+	s.mu.Lock()
+	s.draining = true
+	s.mu.Unlock()
+
+	ticker := time.NewTicker(drainPollInterval)
+	defer ticker.Stop()
+	for {
+		inFlight := s.inFlightResponses()
+		if inFlight == 0 {
+			return nil
+		}
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("%d responses still in flight in partition %s: %w", inFlight, s.partition, ctx.Err())
+		case <-ticker.C:
+		}
+	}
+}
+
+// inFlightResponses returns the number of responses sent on the open streams that have not
+// been answered yet.
+func (s *snapshotCache) inFlightResponses() int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.outstandingResponses
+}
@@ -0,0 +1,171 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package cache
+
+import (
+	"context"
+	"maps"
+	"reflect"
+	"sort"
+	"time"
+
+	corev3 "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	cachev3 "github.com/envoyproxy/go-control-plane/pkg/cache/v3"
+
+	"github.com/envoyproxy/gateway/internal/xds/types"
+)
+
+// maxDriftCheckInterval is the maximum interval the drift of the nodes is checked at.
+const maxDriftCheckInterval = 30 * time.Second
+
+// DriftHandler is called with the nodes of the irKey whose acknowledged resources stayed
+// behind the resources they are served for longer than the drift window, whenever they
+// change.
+type DriftHandler func(irKey string, drifts []types.Drift)
+
+// WithDriftDetection reports the nodes whose acknowledged resources stay behind the resources
+// they are served for longer than the window, checked until the context is done, so that the
+// nodes silently stuck on an older configuration are noticed.
+func WithDriftDetection(ctx context.Context, window time.Duration) Option {
+	return func(o *options) {
+		o.driftCtx = ctx
+		o.driftWindow = window
+	}
+}
+
+// SetDriftHandler sets the handler notified of the drifted nodes.
+func (s *snapshotCache) SetDriftHandler(handler DriftHandler) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.onDrift = handler
+}
+
+// runDriftDetection checks the drift of the nodes periodically until the context is done.
+func (s *snapshotCache) runDriftDetection(ctx context.Context) {
+	ticker := time.NewTicker(min(s.driftWindow/2, maxDriftCheckInterval))
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			s.checkDrift(now)
+		}
+	}
+}
+
+// resourceHashes returns the hash of each resource of the type of the snapshot, by name.
+func resourceHashes(snapshot cachev3.ResourceSnapshot, typeURL string) map[string]string {
+	// The versions of the resources of the pooled snapshots are their hash.
+	if versions := snapshot.GetVersionMap(typeURL); len(versions) > 0 {
+		return maps.Clone(versions)
+	}
+	hashes := make(map[string]string)
+	for name, r := range snapshot.GetResources(typeURL) {
+		if marshaled, err := cachev3.MarshalResource(r); err == nil {
+			hashes[name] = cachev3.HashResource(marshaled)
+		}
+	}
+	return hashes
+}
+
+// recordAckedHashes records the hashes of the resources of the type of the snapshot
+// acknowledged by the node.
+func (s *snapshotCache) recordAckedHashes(node *corev3.Node, typeURL string, snapshot cachev3.ResourceSnapshot) {
+	if s.ackedHashes[node.Id] == nil {
+		s.ackedHashes[node.Id] = make(map[string]map[string]string)
+	}
+	s.ackedHashes[node.Id][typeURL] = resourceHashes(snapshot, typeURL)
+}
+
+// driftedTypeURLs returns the sorted types the node of the stream requested whose resources
+// it acknowledged last differ from the resources of the snapshot it is served.
+func (s *snapshotCache) driftedTypeURLs(streamID int64, node *corev3.Node) []string {
+	snapshot, err := s.GetSnapshot(s.snapshotKey(node))
+	if err != nil {
+		return nil
+	}
+	var typeURLs []string
+	for typeURL := range s.streamTypeURLs[streamID] {
+		if !maps.Equal(s.ackedHashes[node.Id][typeURL], resourceHashes(snapshot, typeURL)) {
+			typeURLs = append(typeURLs, typeURL)
+		}
+	}
+	sort.Strings(typeURLs)
+	return typeURLs
+}
+
+// checkDrift records when each connected node was first seen behind the resources it is
+// served, and notifies the handler of the nodes of each irKey behind for the drift window
+// once they change.
+func (s *snapshotCache) checkDrift(now time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	behind := make(map[string]bool)
+	drifts := make(map[string][]types.Drift)
+	for streamID, node := range s.streamIDNodeInfo {
+		if node == nil || behind[node.Id] {
+			continue
+		}
+		typeURLs := s.driftedTypeURLs(streamID, node)
+		if len(typeURLs) == 0 {
+			continue
+		}
+		behind[node.Id] = true
+		since, ok := s.driftSince[node.Id]
+		if !ok {
+			since = now
+			s.driftSince[node.Id] = since
+		}
+		if now.Sub(since) >= s.driftWindow {
+			drifts[node.Cluster] = append(drifts[node.Cluster], types.Drift{
+				NodeID:   node.Id,
+				TypeURLs: typeURLs,
+				Since:    since,
+			})
+		}
+	}
+	for nodeID := range s.driftSince {
+		if !behind[nodeID] {
+			delete(s.driftSince, nodeID)
+		}
+	}
+
+	drifted := 0
+	for _, nodeDrifts := range drifts {
+		drifted += len(nodeDrifts)
+		sort.Slice(nodeDrifts, func(i, j int) bool {
+			return nodeDrifts[i].NodeID < nodeDrifts[j].NodeID
+		})
+	}
+	driftedNodes.With(s.partitionLabel()).Record(float64(drifted))
+
+	for irKey, nodeDrifts := range drifts {
+		if !reflect.DeepEqual(s.drifts[irKey], nodeDrifts) {
+			s.notifyDrifts(irKey, nodeDrifts)
+		}
+	}
+	for irKey := range s.drifts {
+		if _, ok := drifts[irKey]; !ok {
+			s.notifyDrifts(irKey, nil)
+		}
+	}
+	s.drifts = drifts
+}
+
+// notifyDrifts notifies the handler of the drifted nodes of the irKey.
+func (s *snapshotCache) notifyDrifts(irKey string, drifts []types.Drift) {
+	for _, drift := range drifts {
+		s.log.Infow("node drifted from the resources it is served", "irKey", irKey,
+			"nodeID", drift.NodeID, "typeURLs", drift.TypeURLs, "since", drift.Since)
+	}
+	if s.onDrift != nil {
+		s.onDrift(irKey, drifts)
+	}
+}
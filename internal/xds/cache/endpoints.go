@@ -0,0 +1,50 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package cache
+
+import (
+	"maps"
+	"slices"
+
+	endpointv3 "github.com/envoyproxy/go-control-plane/envoy/config/endpoint/v3"
+	cachetypes "github.com/envoyproxy/go-control-plane/pkg/cache/types"
+	cachev3 "github.com/envoyproxy/go-control-plane/pkg/cache/v3"
+	resourcev3 "github.com/envoyproxy/go-control-plane/pkg/resource/v3"
+
+	"github.com/envoyproxy/gateway/internal/xds/types"
+)
+
+// UpdateEndpoints replaces the endpoints of the cluster in the last snapshot generated for
+// the irKey, or removes them if endpoints is nil. Only the version of the endpoints is
+// bumped, so that the nodes are only sent the endpoints again instead of all the resources.
+// It returns ErrSnapshotNotFound if no snapshot of the irKey is cached, in which case a
+// new snapshot must be generated. The endpoints of several clusters are updated at once
+// with UpdateResources.
+func (s *snapshotCache) UpdateEndpoints(irKey, clusterName string, endpoints *endpointv3.ClusterLoadAssignment) error {
+	var r cachetypes.Resource
+	if endpoints != nil {
+		r = endpoints
+	}
+	return s.UpdateResources(irKey, map[resourcev3.Type]map[string]cachetypes.Resource{
+		resourcev3.EndpointType: {clusterName: r},
+	})
+}
+
+// snapshotResources returns the resources of the snapshot by type URL.
+func snapshotResources(snapshot *cachev3.Snapshot) types.XdsResources {
+	resources := make(types.XdsResources)
+	for _, typeURL := range resourceTypes {
+		items := snapshot.GetResources(typeURL)
+		if len(items) == 0 {
+			continue
+		}
+		names := slices.Sorted(maps.Keys(items))
+		for _, name := range names {
+			resources[typeURL] = append(resources[typeURL], items[name])
+		}
+	}
+	return resources
+}
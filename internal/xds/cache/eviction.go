@@ -0,0 +1,123 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package cache
+
+import (
+	"sort"
+
+	"google.golang.org/protobuf/proto"
+
+	"github.com/envoyproxy/gateway/internal/xds/types"
+)
+
+// EvictionHandler is called with the irKey whose snapshot was evicted, and called again
+// with restore set when a node requests the evicted snapshot, which must then be generated
+// again. It is called asynchronously, so that it may generate snapshots.
+type EvictionHandler func(irKey string, restore bool)
+
+// SetMemoryLimit sets the size of the resources of the snapshots above which the least
+// recently used snapshots of the irKeys without connected nodes are evicted, and the
+// handler notified of the evictions.
+func (s *snapshotCache) SetMemoryLimit(bytes int64, handler EvictionHandler) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.memoryLimit = bytes
+	s.onEviction = handler
+}
+
+// trackSnapshot records the size of the resources of the snapshot generated for the irKey,
+// then evicts the snapshots exceeding the memory limit.
+func (s *snapshotCache) trackSnapshot(irKey string, resources types.XdsResources) {
+	delete(s.evicted, irKey)
+	if resources == nil {
+		delete(s.snapshotSizes, irKey)
+		delete(s.snapshotUses, irKey)
+	} else {
+		var size int64
+		for _, rs := range resources {
+			for _, r := range rs {
+				size += int64(proto.Size(r))
+			}
+		}
+		s.snapshotSizes[irKey] = size
+		s.touchSnapshot(irKey)
+	}
+	s.evictSnapshots()
+}
+
+// touchSnapshot marks the snapshot of the irKey as the most recently used.
+func (s *snapshotCache) touchSnapshot(irKey string) {
+	if s.memoryLimit <= 0 {
+		return
+	}
+	s.useCount++
+	s.snapshotUses[irKey] = s.useCount
+}
+
+// evictSnapshots evicts the least recently used snapshots of the irKeys without connected
+// nodes until the size of the cached snapshots is within the memory limit.
+func (s *snapshotCache) evictSnapshots() {
+	var total int64
+	for _, size := range s.snapshotSizes {
+		total += size
+	}
+	defer func() { snapshotCacheBytes.With(s.partitionLabel()).Record(float64(total)) }()
+	if total <= s.memoryLimit {
+		return
+	}
+
+	connected := make(map[string]bool)
+	for _, node := range s.streamIDNodeInfo {
+		if node != nil {
+			connected[node.Cluster] = true
+		}
+	}
+	var candidates []string
+	for irKey := range s.snapshotSizes {
+		if !connected[irKey] {
+			candidates = append(candidates, irKey)
+		}
+	}
+	sort.Slice(candidates, func(i, j int) bool {
+		return s.snapshotUses[candidates[i]] < s.snapshotUses[candidates[j]]
+	})
+
+	for _, irKey := range candidates {
+		if total <= s.memoryLimit {
+			break
+		}
+		total -= s.snapshotSizes[irKey]
+		for _, record := range s.snapshotHistory[irKey] {
+			s.pool.release(record.Snapshot)
+		}
+		delete(s.lastSnapshot, irKey)
+		delete(s.snapshotHistory, irKey)
+		delete(s.scopedSnapshots, irKey)
+		delete(s.groupSnapshots, irKey)
+		delete(s.snapshotSizes, irKey)
+		delete(s.snapshotUses, irKey)
+		s.evicted[irKey] = true
+		snapshotEvictionsTotal.With(s.partitionLabel()).Increment()
+		s.log.Debugf("Evicted the snapshot of %s", irKey)
+		if s.onEviction != nil {
+			go s.onEviction(irKey, false)
+		}
+	}
+	s.prunePool()
+}
+
+// restoreSnapshot notifies the handler that a node requested the evicted snapshot of the irKey.
+func (s *snapshotCache) restoreSnapshot(irKey string) {
+	if !s.evicted[irKey] {
+		return
+	}
+	delete(s.evicted, irKey)
+	snapshotRestoresTotal.With(s.partitionLabel()).Increment()
+	if s.onEviction != nil {
+		go s.onEviction(irKey, true)
+	}
+}
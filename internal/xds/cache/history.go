@@ -0,0 +1,100 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package cache
+
+import (
+	"time"
+
+	cachev3 "github.com/envoyproxy/go-control-plane/pkg/cache/v3"
+)
+
+// defaultHistorySize is the number of snapshots of each irKey retained by default.
+const defaultHistorySize = 10
+
+// SnapshotRecord is a snapshot of the history of an irKey.
+type SnapshotRecord struct {
+	// Version is the version of the snapshot.
+	Version string `json:"version"`
+	// Time is when the snapshot was generated.
+	Time time.Time `json:"time"`
+	// Resources holds the number of resources in the snapshot by type URL.
+	Resources map[string]int `json:"resources"`
+	// Snapshot is the snapshot itself, which nodes can be rolled back to.
+	Snapshot *cachev3.Snapshot `json:"-"`
+}
+
+// SetHistorySize sets the number of the last snapshots of each irKey retained, at least one.
+func (s *snapshotCache) SetHistorySize(size int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.historySize = max(size, 1)
+	for irKey, history := range s.snapshotHistory {
+		if len(history) > s.historySize {
+			for _, record := range history[:len(history)-s.historySize] {
+				s.pool.release(record.Snapshot)
+			}
+			s.snapshotHistory[irKey] = history[len(history)-s.historySize:]
+		}
+	}
+	s.prunePool()
+}
+
+// GetSnapshotHistory returns the snapshots of the history of the irKey, newest first.
+func (s *snapshotCache) GetSnapshotHistory(irKey string) []SnapshotRecord {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	history := s.snapshotHistory[irKey]
+	records := make([]SnapshotRecord, 0, len(history))
+	for i := len(history) - 1; i >= 0; i-- {
+		records = append(records, history[i])
+	}
+	return records
+}
+
+// recordSnapshot appends the snapshot to the history of the irKey, dropping the oldest
+// snapshot once the history is full. The snapshots of the history reference the pooled
+// resources they hold.
+func (s *snapshotCache) recordSnapshot(irKey, version string, snapshot *cachev3.Snapshot) {
+	s.pool.ref(snapshot)
+	history := append(s.snapshotHistory[irKey], SnapshotRecord{
+		Version:   version,
+		Time:      time.Now(),
+		Resources: resourceCounts(snapshot),
+		Snapshot:  snapshot,
+	})
+	if len(history) > s.historySize {
+		for _, record := range history[:len(history)-s.historySize] {
+			s.pool.release(record.Snapshot)
+		}
+		// Copy the records so that the dropped snapshots are not retained by the
+		// backing array.
+		history = append([]SnapshotRecord(nil), history[len(history)-s.historySize:]...)
+	}
+	s.snapshotHistory[irKey] = history
+}
+
+// snapshotIndex returns the index of the snapshot version in the history, or -1.
+func snapshotIndex(history []SnapshotRecord, version string) int {
+	for i, record := range history {
+		if record.Version == version {
+			return i
+		}
+	}
+	return -1
+}
+
+// resourceCounts returns the number of resources of the snapshot by type URL.
+func resourceCounts(snapshot *cachev3.Snapshot) map[string]int {
+	counts := make(map[string]int)
+	for _, typeURL := range resourceTypes {
+		if n := len(snapshot.GetResources(typeURL)); n > 0 {
+			counts[typeURL] = n
+		}
+	}
+	return counts
+}
@@ -0,0 +1,185 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package cache
+
+import (
+	"maps"
+	"slices"
+	"strings"
+
+	discoveryv3 "github.com/envoyproxy/go-control-plane/envoy/service/discovery/v3"
+	cachetypes "github.com/envoyproxy/go-control-plane/pkg/cache/types"
+	cachev3 "github.com/envoyproxy/go-control-plane/pkg/cache/v3"
+	resourcev3 "github.com/envoyproxy/go-control-plane/pkg/resource/v3"
+	streamv3 "github.com/envoyproxy/go-control-plane/pkg/server/stream/v3"
+
+	"github.com/envoyproxy/gateway/internal/xds/types"
+)
+
+// localityEndpoint is a LEDS resource, with the hash of its endpoint as version.
+type localityEndpoint struct {
+	resource *discoveryv3.Resource
+	version  string
+}
+
+// deltaWatch is an open LEDS or VHDS watch of a delta stream, waiting for the resources it
+// subscribes to to change.
+type deltaWatch struct {
+	request  *cachev3.DeltaRequest
+	state    streamv3.StreamState
+	response chan cachev3.DeltaResponse
+}
+
+// newSnapshot returns the snapshot of the resources, without the LEDS and VHDS resources the
+// snapshots cannot hold, which are served by the cache itself.
+func newSnapshot(version string, resources types.XdsResources) (*cachev3.Snapshot, error) {
+	_, hasLocalityEndpoints := resources[types.LbEndpointType]
+	_, hasVirtualHosts := resources[types.VirtualHostType]
+	if hasLocalityEndpoints || hasVirtualHosts {
+		resources = maps.Clone(resources)
+		delete(resources, types.LbEndpointType)
+		delete(resources, types.VirtualHostType)
+	}
+	return cachev3.NewSnapshot(version, resources)
+}
+
+// setLocalityEndpoints replaces the LEDS resources of the irKey, and responds to the open
+// LEDS watches of its nodes with the changes of the resources they subscribe to.
+func (s *snapshotCache) setLocalityEndpoints(irKey string, resources []cachetypes.Resource) {
+	if len(resources) == 0 && s.localityEndpoints[irKey] == nil && len(s.localityEndpointWatches[irKey]) == 0 {
+		return
+	}
+
+	if len(resources) == 0 {
+		delete(s.localityEndpoints, irKey)
+	} else {
+		endpoints := make(map[string]localityEndpoint, len(resources))
+		for _, r := range resources {
+			resource, ok := r.(*discoveryv3.Resource)
+			if !ok {
+				continue
+			}
+			marshaled, err := cachev3.MarshalResource(resource.GetResource())
+			if err != nil {
+				s.log.Errorw("failed to marshal a LEDS resource", "irKey", irKey, "name", resource.GetName(), "error", err)
+				continue
+			}
+			endpoints[resource.GetName()] = localityEndpoint{resource: resource, version: cachev3.HashResource(marshaled)}
+		}
+		s.localityEndpoints[irKey] = endpoints
+	}
+
+	for id, watch := range s.localityEndpointWatches[irKey] {
+		if response := s.localityEndpointsResponse(irKey, watch, false); response != nil {
+			watch.response <- response
+			delete(s.localityEndpointWatches[irKey], id)
+		}
+	}
+}
+
+// CreateDeltaWatch serves the LEDS and VHDS resources of the irKey of the node, and the other
+// resources from the snapshots. LEDS and VHDS are only served on delta streams, since the
+// localities subscribe to glob collections of resources, and the route configurations to
+// virtual hosts on demand.
+func (s *snapshotCache) CreateDeltaWatch(request *cachev3.DeltaRequest, state streamv3.StreamState, value chan cachev3.DeltaResponse) func() {
+	switch request.GetTypeUrl() {
+	case types.LbEndpointType:
+		return s.createLocalityEndpointsWatch(request, state, value)
+	case types.VirtualHostType:
+		return s.createVirtualHostsWatch(request, state, value)
+	default:
+		return s.SnapshotCache.CreateDeltaWatch(request, state, value)
+	}
+}
+
+// createLocalityEndpointsWatch responds to the LEDS request with the changes of the resources
+// it subscribes to, or opens a watch waiting for them to change.
+func (s *snapshotCache) createLocalityEndpointsWatch(request *cachev3.DeltaRequest, state streamv3.StreamState, value chan cachev3.DeltaResponse) func() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	irKey := request.GetNode().GetCluster()
+	watch := &deltaWatch{request: request, state: state, response: value}
+	if response := s.localityEndpointsResponse(irKey, watch, state.IsFirst()); response != nil {
+		value <- response
+		return func() {}
+	}
+
+	s.localityEndpointWatchCount++
+	id := s.localityEndpointWatchCount
+	if s.localityEndpointWatches[irKey] == nil {
+		s.localityEndpointWatches[irKey] = make(map[int64]*deltaWatch)
+	}
+	s.localityEndpointWatches[irKey][id] = watch
+	return func() {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		delete(s.localityEndpointWatches[irKey], id)
+	}
+}
+
+// localityEndpointsResponse returns the response sending the LEDS resources the watch
+// subscribes to that changed since they were last sent on its stream, and removing the
+// ones that are gone. It returns nil if nothing changed, unless the response is forced.
+func (s *snapshotCache) localityEndpointsResponse(irKey string, watch *deltaWatch, force bool) cachev3.DeltaResponse {
+	sent := watch.state.GetResourceVersions()
+	subscribed := watch.state.GetSubscribedResourceNames()
+	nextVersions := make(map[string]string)
+	var resources []*discoveryv3.Resource
+	endpoints := s.localityEndpoints[irKey]
+	for _, name := range slices.Sorted(maps.Keys(endpoints)) {
+		if !watch.state.IsWildcard() && !subscribesToResource(subscribed, name) {
+			continue
+		}
+		endpoint := endpoints[name]
+		nextVersions[name] = endpoint.version
+		if version, ok := sent[name]; !ok || version != endpoint.version {
+			resources = append(resources, &discoveryv3.Resource{
+				Name:     name,
+				Version:  endpoint.version,
+				Resource: endpoint.resource.GetResource(),
+			})
+		}
+	}
+	var removed []string
+	for _, name := range slices.Sorted(maps.Keys(sent)) {
+		if _, ok := nextVersions[name]; !ok {
+			removed = append(removed, name)
+		}
+	}
+	if len(resources) == 0 && len(removed) == 0 && !force {
+		return nil
+	}
+
+	var version string
+	if snapshot := s.lastSnapshot[irKey]; snapshot != nil {
+		version = snapshot.GetVersion(resourcev3.EndpointType)
+	}
+	return &cachev3.DeltaPassthroughResponse{
+		DeltaRequest:   watch.request,
+		NextVersionMap: nextVersions,
+		DeltaDiscoveryResponse: &discoveryv3.DeltaDiscoveryResponse{
+			SystemVersionInfo: version,
+			TypeUrl:           types.LbEndpointType,
+			Resources:         resources,
+			RemovedResources:  removed,
+		},
+	}
+}
+
+// subscribesToResource returns whether the resource is subscribed to by name, or by the
+// glob collection it belongs to, its name up to its last segment followed by *.
+func subscribesToResource(subscribed map[string]struct{}, name string) bool {
+	if _, ok := subscribed[name]; ok {
+		return true
+	}
+	i := strings.LastIndex(name, "/")
+	if i < 0 {
+		return false
+	}
+	_, ok := subscribed[name[:i+1]+"*"]
+	return ok
+}
@@ -24,7 +24,116 @@ var (
 		[]float64{0.1, 10, 50, 100, 1000, 10000},
 	)
 
+	snapshotCacheBytes = metrics.NewGauge(
+		"xds_snapshot_cache_bytes",
+		"Size of the resources of the xds snapshots cached for the irKeys, once a memory limit is set.",
+	)
+
+	snapshotPoolBytes = metrics.NewGauge(
+		"xds_snapshot_pool_bytes",
+		"Size of the distinct resources of the xds snapshots retained by the cache, each stored once.",
+	)
+
+	snapshotPoolDedupRatio = metrics.NewGauge(
+		"xds_snapshot_pool_dedup_ratio",
+		"Average number of retained xds snapshots sharing each distinct resource stored by the cache.",
+	)
+
+	snapshotEvictionsTotal = metrics.NewCounter(
+		"xds_snapshot_evictions_total",
+		"Total number of xds snapshots evicted from the cache to stay within its memory limit.",
+	)
+
+	snapshotRestoresTotal = metrics.NewCounter(
+		"xds_snapshot_restores_total",
+		"Total number of evicted xds snapshots requested by a node and generated again.",
+	)
+
+	driftedNodes = metrics.NewGauge(
+		"xds_drifted_nodes",
+		"Number of the nodes whose acknowledged resources stayed behind the resources they are served for the drift window.",
+	)
+
+	pushQueueDepth = metrics.NewGauge(
+		"xds_snapshot_push_queue_depth",
+		"Number of the snapshot keys of the nodes queued to be pushed a snapshot, once the pushes are paced.",
+	)
+
+	staleNodeEvictionsTotal = metrics.NewCounter(
+		"xds_stale_node_evictions_total",
+		"Total number of xds snapshots of the nodes without a stream for the idle timeout cleared from the cache.",
+	)
+
+	endpointUpdatesTotal = metrics.NewCounter(
+		"xds_snapshot_endpoint_updates_total",
+		"Total number of xds snapshots generated by updating only the endpoints of a cluster.",
+	)
+
+	resourceUpdatesTotal = metrics.NewCounter(
+		"xds_snapshot_resource_updates_total",
+		"Total number of xds snapshots generated by updating only some routes, clusters and endpoints.",
+	)
+
+	snapshotPersistenceLoadsTotal = metrics.NewCounter(
+		"xds_snapshot_persistence_loads_total",
+		"Total number of xds snapshots restored from the persistence directory.",
+	)
+
+	snapshotPersistenceMigrationsTotal = metrics.NewCounter(
+		"xds_snapshot_persistence_migrations_total",
+		"Total number of xds snapshots migrated from an older persistence format.",
+	)
+
+	snapshotPersistenceErrorsTotal = metrics.NewCounter(
+		"xds_snapshot_persistence_errors_total",
+		"Total number of xds snapshots that failed to be written to or restored from the persistence directory.",
+	)
+
+	rolloutsTotal = metrics.NewCounter(
+		"xds_snapshot_rollouts_total",
+		"Total number of staged rollouts of xds snapshots completed or halted by a rejection of a canary.",
+	)
+
+	xdsNackTotal = metrics.NewCounter(
+		"xds_nack_total",
+		"Total number of xds responses rejected by the nodes.",
+	)
+
+	xdsRequestsTotal = metrics.NewCounter(
+		"xds_requests_total",
+		"Total number of xds discovery requests received from the nodes, by whether they acknowledge or reject a response.",
+	)
+
+	xdsResponsesTotal = metrics.NewCounter(
+		"xds_responses_total",
+		"Total number of xds discovery responses sent to the nodes.",
+	)
+
+	xdsResponseAnswerDurationSeconds = metrics.NewHistogram(
+		"xds_response_answer_duration_seconds",
+		"How long the nodes take to acknowledge or reject the xds responses.",
+		[]float64{0.005, 0.01, 0.05, 0.1, 0.5, 1, 5, 10, 30},
+	)
+
+	xdsOutstandingResponses = metrics.NewGauge(
+		"xds_outstanding_responses",
+		"Number of the xds discovery responses sent to the nodes that are awaiting an acknowledgement or a rejection.",
+	)
+
 	nodeIDLabel        = metrics.NewLabel("nodeID")
 	streamIDLabel      = metrics.NewLabel("streamID")
 	isDeltaStreamLabel = metrics.NewLabel("isDeltaStream")
+	typeURLLabel       = metrics.NewLabel("typeURL")
+	partitionLabel     = metrics.NewLabel("partition")
+	resultLabel        = metrics.NewLabel("result")
+)
+
+const (
+	// requestResultRequest is the result of the requests that don't answer a response,
+	// such as the first request of a type on a stream.
+	requestResultRequest = "request"
+	// requestResultAck is the result of the requests acknowledging a response.
+	requestResultAck = "ack"
+	// requestResultNack is the result of the requests rejecting a response.
+	requestResultNack = "nack"
 )
@@ -0,0 +1,68 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package cache
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/envoyproxy/gateway/internal/xds/types"
+)
+
+const (
+	// legacySnapshotFormat is the format of the snapshots persisted without a format
+	// version in their header.
+	legacySnapshotFormat = 1
+	// currentSnapshotFormat is the format the snapshots are persisted in. It is bumped
+	// whenever the layout of the persisted snapshots changes, along with a migration
+	// from the previous format.
+	currentSnapshotFormat = 2
+)
+
+// ErrNewerSnapshotFormat is returned when a snapshot was persisted in a format newer than
+// the supported one, by a newer version of Envoy Gateway, which the snapshot can't be
+// safely read by after a downgrade.
+var ErrNewerSnapshotFormat = errors.New("snapshot persisted in a newer format")
+
+// snapshotMigration migrates a snapshot persisted in a format to the next format.
+type snapshotMigration func(header *persistedSnapshotHeader, resources types.XdsResources) error
+
+// snapshotMigrations holds the migration of the snapshots persisted in each format to the
+// next format.
+var snapshotMigrations = map[int]snapshotMigration{
+	// The format 2 only adds the format version to the header.
+	legacySnapshotFormat: func(*persistedSnapshotHeader, types.XdsResources) error { return nil },
+}
+
+// checkSnapshotFormat defaults the format of the header of a legacy snapshot, and returns
+// an error if the snapshot was persisted in a newer format.
+func checkSnapshotFormat(header *persistedSnapshotHeader) error {
+	if header.Format == 0 {
+		header.Format = legacySnapshotFormat
+	}
+	if header.Format > currentSnapshotFormat {
+		return fmt.Errorf("%w: the format is %d, but up to %d is supported", ErrNewerSnapshotFormat, header.Format, currentSnapshotFormat)
+	}
+	return nil
+}
+
+// migrateSnapshot migrates the snapshot persisted in an older format to the current format,
+// returning whether it was migrated.
+func migrateSnapshot(header *persistedSnapshotHeader, resources types.XdsResources) (bool, error) {
+	migrated := false
+	for header.Format < currentSnapshotFormat {
+		migrate, ok := snapshotMigrations[header.Format]
+		if !ok {
+			return migrated, fmt.Errorf("no migration from the snapshot format %d", header.Format)
+		}
+		if err := migrate(header, resources); err != nil {
+			return migrated, fmt.Errorf("failed to migrate from the snapshot format %d: %w", header.Format, err)
+		}
+		header.Format++
+		migrated = true
+	}
+	return migrated, nil
+}
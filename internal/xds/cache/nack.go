@@ -0,0 +1,219 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package cache
+
+import (
+	"context"
+	"sort"
+	"strconv"
+	"time"
+
+	corev3 "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	cachev3 "github.com/envoyproxy/go-control-plane/pkg/cache/v3"
+
+	"github.com/envoyproxy/gateway/internal/metrics"
+	"github.com/envoyproxy/gateway/internal/xds/types"
+)
+
+// maxGoodSnapshots is the number of snapshots acknowledged by a node that are retained to
+// roll the node back to, since a snapshot may be acknowledged for some of its resource types
+// before being rejected for another one.
+const maxGoodSnapshots = 2
+
+// NackHandler is called with the rejections of the snapshots of the irKey by its nodes that
+// are not resolved yet, whenever they change. A rejection is resolved once the node
+// acknowledges a newer snapshot or disconnects.
+type NackHandler func(irKey string, nacks []types.Nack)
+
+// sentResponse is the last response of a type sent on a stream.
+type sentResponse struct {
+	nonce   string
+	version string
+	// sentAt is when the response was sent.
+	sentAt time.Time
+	// answered is true once the node acknowledged or rejected the response.
+	answered bool
+}
+
+// SetNackHandler sets the handler notified of the rejections of the snapshots.
+func (s *snapshotCache) SetNackHandler(handler NackHandler) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.onNack = handler
+}
+
+// recordResponse records the snapshot version of the response of the type sent on the
+// stream, which the nonce of a rejection refers to.
+func (s *snapshotCache) recordResponse(streamID int64, typeURL, nonce, version string) {
+	s.trackResponse(streamID, typeURL, sentResponse{nonce: nonce, version: version, sentAt: time.Now()})
+	xdsResponsesTotal.With(nodeIDLabel.Value(s.streamNodeID(streamID)), typeURLLabel.Value(typeURL), s.partitionLabel()).Increment()
+}
+
+// answerResponse records the request of the type received on the stream, which acknowledges
+// or rejects the response with the nonce, if any, and how long the node took to answer the
+// last response sent on the stream. It returns the result of the request, and the latency
+// of the answer or zero if the request doesn't answer the last response.
+func (s *snapshotCache) answerResponse(streamID int64, typeURL, nonce string, nacked bool) (string, time.Duration) {
+	result := requestResultRequest
+	if nonce != "" {
+		result = requestResultAck
+		if nacked {
+			result = requestResultNack
+		}
+	}
+	labels := []metrics.LabelValue{
+		nodeIDLabel.Value(s.streamNodeID(streamID)),
+		typeURLLabel.Value(typeURL),
+		resultLabel.Value(result),
+		s.partitionLabel(),
+	}
+	xdsRequestsTotal.With(labels...).Increment()
+
+	sent, ok := s.sentResponses[streamID][typeURL]
+	if !ok || nonce == "" || sent.nonce != nonce || sent.answered {
+		return result, 0
+	}
+	sent.answered = true
+	s.sentResponses[streamID][typeURL] = sent
+	s.outstandingResponses--
+	s.recordOutstandingResponses()
+	latency := time.Since(sent.sentAt)
+	xdsResponseAnswerDurationSeconds.With(labels...).Record(latency.Seconds())
+	return result, latency
+}
+
+// streamNodeID returns the ID of the node of the stream, or empty if the stream has not
+// received a request yet.
+func (s *snapshotCache) streamNodeID(streamID int64) string {
+	if node := s.streamIDNodeInfo[streamID]; node != nil {
+		return node.Id
+	}
+	return ""
+}
+
+// respondedVersion returns the snapshot version of the response of the type with the
+// nonce, or empty if it is not the last response sent on the stream.
+func (s *snapshotCache) respondedVersion(streamID int64, typeURL, nonce string) string {
+	if sent, ok := s.sentResponses[streamID][typeURL]; ok && sent.nonce == nonce {
+		return sent.version
+	}
+	return ""
+}
+
+// recordAck records the snapshot served to the node as known good once the node
+// acknowledged it, with the hashes of its resources once the drift is detected, and
+// resolves the rejection of an older snapshot by the node.
+func (s *snapshotCache) recordAck(node *corev3.Node, irKey, typeURL, version string) {
+	nodeID := node.Id
+	snapshot, err := s.GetSnapshot(s.snapshotKey(node))
+	if err != nil || snapshot.GetVersion(typeURL) != version {
+		return
+	}
+	if s.driftWindow > 0 {
+		s.recordAckedHashes(node, typeURL, snapshot)
+	}
+
+	nack, nacked := s.nacks[nodeID]
+	if nacked && nack.Version == version {
+		return
+	}
+	good := s.goodSnapshots[nodeID]
+	if len(good) == 0 || good[len(good)-1].GetVersion(typeURL) != version {
+		good = append(good, snapshot)
+		if len(good) > maxGoodSnapshots {
+			good = good[len(good)-maxGoodSnapshots:]
+		}
+		s.goodSnapshots[nodeID] = good
+	}
+
+	if nacked && newerVersion(version, nack.Version) {
+		delete(s.nacks, nodeID)
+		s.notifyNacks(irKey)
+	}
+	s.traceAck(nodeID, irKey, typeURL, version)
+	s.recordRolloutAck(node, irKey, typeURL, version)
+}
+
+// handleNack rolls the node back to the last snapshot it acknowledged when it rejects the
+// snapshot it is served, and records the rejection.
+func (s *snapshotCache) handleNack(streamID int64, node *corev3.Node, irKey, typeURL, nonce, message string) {
+	nodeID := node.Id
+	xdsNackTotal.With(nodeIDLabel.Value(nodeID), typeURLLabel.Value(typeURL), s.partitionLabel()).Increment()
+
+	version := s.respondedVersion(streamID, typeURL, nonce)
+	if version == "" {
+		// The rejected response is not the last one, whose version is unknown.
+		return
+	}
+
+	// A snapshot acknowledged for other types is not good either.
+	var good []cachev3.ResourceSnapshot
+	for _, snapshot := range s.goodSnapshots[nodeID] {
+		if snapshot.GetVersion(typeURL) != version {
+			good = append(good, snapshot)
+		}
+	}
+	s.goodSnapshots[nodeID] = good
+
+	nack := types.Nack{
+		NodeID:  nodeID,
+		TypeURL: typeURL,
+		Version: version,
+		Message: message,
+	}
+	if previous, ok := s.nacks[nodeID]; ok && previous.Version == version {
+		nack.RolledBackTo = previous.RolledBackTo
+	}
+
+	// The nodes sharing the snapshot key of the node are rolled back along with it, and
+	// the other canaries of the rollout of the snapshot, if any.
+	key := s.snapshotKey(node)
+	served, err := s.GetSnapshot(key)
+	if err == nil && served.GetVersion(typeURL) == version {
+		s.haltRollout(node, irKey, version)
+	}
+	if err == nil && served.GetVersion(typeURL) == version && len(good) > 0 {
+		rollback := good[len(good)-1]
+		if err := s.SetSnapshot(context.TODO(), key, rollback); err != nil {
+			s.log.Errorf("failed to roll node %s back to snapshot version %s: %v", nodeID, rollback.GetVersion(typeURL), err)
+		} else {
+			nack.RolledBackTo = rollback.GetVersion(typeURL)
+			s.log.Infof("rolled node %s back to snapshot version %s after it rejected version %s: %s",
+				nodeID, nack.RolledBackTo, version, message)
+		}
+	}
+
+	s.nacks[nodeID] = nack
+	s.notifyNacks(irKey)
+}
+
+// notifyNacks notifies the handler of the unresolved rejections of the nodes of the irKey.
+func (s *snapshotCache) notifyNacks(irKey string) {
+	if s.onNack == nil {
+		return
+	}
+
+	var nacks []types.Nack
+	seen := make(map[string]bool)
+	for _, nodeID := range s.getNodeIDs(irKey) {
+		if nack, ok := s.nacks[nodeID]; ok && !seen[nodeID] {
+			seen[nodeID] = true
+			nacks = append(nacks, nack)
+		}
+	}
+	sort.Slice(nacks, func(i, j int) bool {
+		return nacks[i].NodeID < nacks[j].NodeID
+	})
+	s.onNack(irKey, nacks)
+}
+
+// newerVersion returns whether the snapshot version a is newer than b.
+func newerVersion(a, b string) bool {
+	va, errA := strconv.ParseInt(a, 10, 64)
+	vb, errB := strconv.ParseInt(b, 10, 64)
+	return errA == nil && errB == nil && va > vb
+}
@@ -0,0 +1,75 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package cache
+
+import (
+	corev3 "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	cachev3 "github.com/envoyproxy/go-control-plane/pkg/cache/v3"
+)
+
+// ClusterHash keys the snapshots of the nodes by their cluster, which is the irKey of
+// their Gateway, so that all the nodes of a Gateway share a single snapshot entry.
+type ClusterHash struct{}
+
+// ID returns the cluster of the node.
+func (ClusterHash) ID(node *corev3.Node) string {
+	if node == nil {
+		return ""
+	}
+	return node.Cluster
+}
+
+var _ cachev3.NodeHash = ClusterHash{}
+
+// MetadataHash keys the snapshots of the nodes by the string value of a key of their
+// metadata, so that the nodes of a cluster with the same value share a snapshot entry.
+// The nodes without the key get a snapshot entry of their own.
+type MetadataHash struct {
+	// Key is the key of the metadata of the nodes.
+	Key string
+}
+
+// ID returns the cluster of the node and the value of the key of its metadata, or the ID
+// of the node if the value is empty. The cluster is part of the key so that the nodes of
+// different Gateways never share a snapshot entry.
+func (h MetadataHash) ID(node *corev3.Node) string {
+	if node == nil {
+		return ""
+	}
+	value := node.GetMetadata().GetFields()[h.Key].GetStringValue()
+	if value == "" {
+		return node.Id
+	}
+	return node.Cluster + "#" + h.Key + "=" + value
+}
+
+var _ cachev3.NodeHash = MetadataHash{}
+
+// WithNodeHash sets the hash keying the snapshots of the nodes, which nodes with the same
+// key share. The nodes sharing a key must be served the same snapshot, so the snapshots
+// with node scopes are rejected unless keyed by node ID. The snapshots are keyed by the ID
+// of the nodes by default.
+func WithNodeHash(hash cachev3.NodeHash) Option {
+	return func(o *options) {
+		o.nodeHash = hash
+	}
+}
+
+// snapshotKey returns the key of the snapshot served to the node.
+func (s *snapshotCache) snapshotKey(node *corev3.Node) string {
+	return s.nodeHash.ID(node)
+}
+
+// snapshotKeyConnected returns whether a stream of a node whose snapshot has the key is
+// open.
+func (s *snapshotCache) snapshotKeyConnected(key string) bool {
+	for _, node := range s.streamIDNodeInfo {
+		if node != nil && s.snapshotKey(node) == key {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,123 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package cache
+
+import (
+	"fmt"
+	"maps"
+	"sort"
+	"time"
+
+	corev3 "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+)
+
+// StreamType is the type of the xDS stream of a node.
+type StreamType string
+
+const (
+	// StreamTypeSotW is the type of the state-of-the-world xDS streams.
+	StreamTypeSotW StreamType = "SotW"
+	// StreamTypeDelta is the type of the incremental xDS streams.
+	StreamTypeDelta StreamType = "Delta"
+)
+
+// ConnectedNode describes an Envoy node connected to the xDS server over a stream.
+type ConnectedNode struct {
+	// StreamID is the ID of the stream of the node.
+	StreamID int64 `json:"streamID"`
+	// NodeID is the ID of the node.
+	NodeID string `json:"nodeID"`
+	// Cluster is the cluster of the node, which is the irKey it is served the snapshot of.
+	Cluster string `json:"cluster"`
+	// EnvoyVersion is the version of Envoy running the node, if reported.
+	EnvoyVersion string `json:"envoyVersion,omitempty"`
+	// ConnectedSince is when the stream was opened.
+	ConnectedSince time.Time `json:"connectedSince"`
+	// StreamType is the type of the stream.
+	StreamType StreamType `json:"streamType"`
+	// TypeURLs holds the sorted type URLs of the resources the node requested on the stream.
+	TypeURLs []string `json:"typeURLs,omitempty"`
+	// AckedResources holds the hashes of the resources the node acknowledged last, by type
+	// and name, once the drift of the nodes is detected.
+	AckedResources map[string]map[string]string `json:"ackedResources,omitempty"`
+	// BehindSince is when the node was first seen behind the resources it is served, if it
+	// still is.
+	BehindSince *time.Time `json:"behindSince,omitempty"`
+}
+
+// ConnectedNodes returns the nodes connected over the streams that received their first
+// request, sorted by cluster, node ID and stream ID.
+func (s *snapshotCache) ConnectedNodes() []ConnectedNode {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	nodes := make([]ConnectedNode, 0, len(s.streamIDNodeInfo))
+	for streamID, node := range s.streamIDNodeInfo {
+		if node == nil {
+			continue
+		}
+
+		connected := ConnectedNode{
+			StreamID:     streamID,
+			NodeID:       node.Id,
+			Cluster:      node.Cluster,
+			EnvoyVersion: envoyVersion(node),
+		}
+		if since, ok := s.deltaStreamDuration[streamID]; ok {
+			connected.StreamType = StreamTypeDelta
+			connected.ConnectedSince = since
+		} else {
+			connected.StreamType = StreamTypeSotW
+			connected.ConnectedSince = s.streamDuration[streamID]
+		}
+		for typeURL := range s.streamTypeURLs[streamID] {
+			connected.TypeURLs = append(connected.TypeURLs, typeURL)
+		}
+		sort.Strings(connected.TypeURLs)
+		if acked := s.ackedHashes[node.Id]; len(acked) > 0 {
+			connected.AckedResources = make(map[string]map[string]string, len(acked))
+			for typeURL, hashes := range acked {
+				connected.AckedResources[typeURL] = maps.Clone(hashes)
+			}
+		}
+		if since, ok := s.driftSince[node.Id]; ok {
+			connected.BehindSince = &since
+		}
+
+		nodes = append(nodes, connected)
+	}
+	sort.Slice(nodes, func(i, j int) bool {
+		if nodes[i].Cluster != nodes[j].Cluster {
+			return nodes[i].Cluster < nodes[j].Cluster
+		}
+		if nodes[i].NodeID != nodes[j].NodeID {
+			return nodes[i].NodeID < nodes[j].NodeID
+		}
+		return nodes[i].StreamID < nodes[j].StreamID
+	})
+
+	return nodes
+}
+
+// recordTypeURL records the type URL of the resources requested on the stream.
+func (s *snapshotCache) recordTypeURL(streamID int64, typeURL string) {
+	if typeURL == "" {
+		return
+	}
+	if s.streamTypeURLs[streamID] == nil {
+		s.streamTypeURLs[streamID] = make(map[string]bool)
+	}
+	s.streamTypeURLs[streamID][typeURL] = true
+}
+
+// envoyVersion returns the version of Envoy reported by the node, or empty if it did not
+// report it.
+func envoyVersion(node *corev3.Node) string {
+	if bv := node.GetUserAgentBuildVersion(); bv != nil && bv.Version != nil {
+		return fmt.Sprintf("v%d.%d.%d", bv.Version.MajorNumber, bv.Version.MinorNumber, bv.Version.Patch)
+	}
+	return ""
+}
@@ -0,0 +1,183 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package cache
+
+import (
+	"fmt"
+	"slices"
+	"time"
+
+	corev3 "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	routev3 "github.com/envoyproxy/go-control-plane/envoy/config/route/v3"
+	cachetypes "github.com/envoyproxy/go-control-plane/pkg/cache/types"
+	cachev3 "github.com/envoyproxy/go-control-plane/pkg/cache/v3"
+	resourcev3 "github.com/envoyproxy/go-control-plane/pkg/resource/v3"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/structpb"
+
+	"github.com/envoyproxy/gateway/internal/xds/types"
+)
+
+// scopedSnapshot is the snapshot of the subset of the resources of an irKey selected by
+// a node scope.
+type scopedSnapshot struct {
+	scope    types.NodeScope
+	snapshot *cachev3.Snapshot
+}
+
+// newScopedSnapshots returns the snapshots of the node scopes, with the version of the
+// snapshot of all the resources.
+func newScopedSnapshots(version string, resources types.XdsResources, scopes []types.NodeScope) ([]scopedSnapshot, error) {
+	scoped := make([]scopedSnapshot, 0, len(scopes))
+	for _, scope := range scopes {
+		scopeResources := make(types.XdsResources, len(resources))
+		for typeURL, rs := range resources {
+			names, ok := scope.ResourceNames[typeURL]
+			if !ok {
+				scopeResources[typeURL] = rs
+				continue
+			}
+			for _, r := range rs {
+				if slices.Contains(names, cachev3.GetResourceName(r)) {
+					scopeResources[typeURL] = append(scopeResources[typeURL], r)
+				}
+			}
+		}
+		snapshot, err := newSnapshot(version, scopeResources)
+		if err != nil {
+			return nil, fmt.Errorf("failed to generate the snapshot of node scope %s: %w", scope.Name, err)
+		}
+		scoped = append(scoped, scopedSnapshot{scope: scope, snapshot: snapshot})
+	}
+	return scoped, nil
+}
+
+// nodeMetadata returns the string values of the metadata of the node.
+func nodeMetadata(node *corev3.Node) map[string]string {
+	metadata := make(map[string]string)
+	for key, value := range node.GetMetadata().GetFields() {
+		if v, ok := value.GetKind().(*structpb.Value_StringValue); ok {
+			metadata[key] = v.StringValue
+		}
+	}
+	return metadata
+}
+
+// snapshotForNode returns the snapshot of the irKey served to the node: the snapshot of
+// the first node scope matching the node, or of all the resources, without the routes only
+// served by the proxies of other node groups. The snapshots of each node scope and group
+// are cached until a new snapshot is generated for the irKey.
+func (s *snapshotCache) snapshotForNode(irKey string, node *corev3.Node) (*cachev3.Snapshot, error) {
+	if s.groupSnapshots[irKey] == nil {
+		s.groupSnapshots[irKey] = make(map[string]*cachev3.Snapshot)
+	}
+	return nodeSnapshot(s.lastSnapshot[irKey], s.scopedSnapshots[irKey], node, s.resourceTTLs, s.groupSnapshots[irKey])
+}
+
+// nodeSnapshot returns the snapshot served to the node out of the snapshot of an irKey and
+// of its node scopes, caching the snapshot of each node scope and group in groupSnapshots.
+func nodeSnapshot(
+	snapshot *cachev3.Snapshot,
+	scopedSnapshots []scopedSnapshot,
+	node *corev3.Node,
+	ttls map[resourcev3.Type]time.Duration,
+	groupSnapshots map[string]*cachev3.Snapshot,
+) (*cachev3.Snapshot, error) {
+	metadata := nodeMetadata(node)
+	scope := ""
+	for _, scoped := range scopedSnapshots {
+		if scoped.scope.Matches(metadata) {
+			snapshot, scope = scoped.snapshot, scoped.scope.Name
+			break
+		}
+	}
+
+	group := metadata[types.NodeGroupMetadataKey]
+	key := scope + "/" + group
+	if groupSnapshot, ok := groupSnapshots[key]; ok {
+		return groupSnapshot, nil
+	}
+
+	groupSnapshot := snapshot
+	if routes, filtered := filterNodeGroupRoutes(snapshot.GetResources(resourcev3.RouteType), group); filtered {
+		resources := make(types.XdsResources)
+		for _, typeURL := range resourceTypes {
+			if typeURL == resourcev3.RouteType {
+				resources[typeURL] = routes
+				continue
+			}
+			for _, r := range snapshot.GetResources(typeURL) {
+				resources[typeURL] = append(resources[typeURL], r)
+			}
+		}
+		// The snapshot keeps the version of the irKey, so that the acknowledgements of
+		// the proxies of all the node groups are tracked alike.
+		var err error
+		if groupSnapshot, err = cachev3.NewSnapshot(snapshot.GetVersion(resourcev3.RouteType), resources); err != nil {
+			return nil, err
+		}
+	}
+
+	groupSnapshot = withResourceTTLs(groupSnapshot, ttls)
+	groupSnapshots[key] = groupSnapshot
+	return groupSnapshot, nil
+}
+
+// filterNodeGroupRoutes returns the route configurations without the routes only served by
+// the proxies of other node groups than the group, and whether any route was removed.
+func filterNodeGroupRoutes(routeConfigs map[string]cachetypes.Resource, group string) ([]cachetypes.Resource, bool) {
+	filtered := false
+	result := make([]cachetypes.Resource, 0, len(routeConfigs))
+	for _, r := range routeConfigs {
+		routeConfig, ok := r.(*routev3.RouteConfiguration)
+		if !ok || !hasNodeGroupRoutes(routeConfig) {
+			result = append(result, r)
+			continue
+		}
+
+		routeConfig = proto.Clone(routeConfig).(*routev3.RouteConfiguration)
+		for _, vhost := range routeConfig.VirtualHosts {
+			routes := vhost.Routes[:0]
+			for _, route := range vhost.Routes {
+				if groups := routeNodeGroups(route); groups == nil || slices.Contains(groups, group) {
+					routes = append(routes, route)
+				} else {
+					filtered = true
+				}
+			}
+			vhost.Routes = routes
+		}
+		result = append(result, routeConfig)
+	}
+	return result, filtered
+}
+
+// hasNodeGroupRoutes returns whether the route configuration has routes only served by
+// the proxies of some node groups.
+func hasNodeGroupRoutes(routeConfig *routev3.RouteConfiguration) bool {
+	for _, vhost := range routeConfig.VirtualHosts {
+		for _, route := range vhost.Routes {
+			if routeNodeGroups(route) != nil {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// routeNodeGroups returns the node groups of the proxies the route is served by, listed in
+// its metadata, or nil if the route is served by all the proxies.
+func routeNodeGroups(route *routev3.Route) []string {
+	value := route.GetMetadata().GetFilterMetadata()[types.XdsMetadataNamespace].GetFields()[types.RouteNodeGroupsMetadataKey]
+	if value == nil {
+		return nil
+	}
+	groups := []string{}
+	for _, group := range value.GetListValue().GetValues() {
+		groups = append(groups, group.GetStringValue())
+	}
+	return groups
+}
@@ -0,0 +1,125 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package cache
+
+import (
+	"context"
+	"time"
+)
+
+const (
+	// DefaultResponseExpiry is how long the responses sent to the nodes are awaited to be
+	// acknowledged or rejected by default.
+	DefaultResponseExpiry = 5 * time.Minute
+
+	// maxResponseExpiryCheckInterval is the maximum interval the expired responses are
+	// checked at.
+	maxResponseExpiryCheckInterval = time.Minute
+
+	// maxSentResponsesPerStream is the number of the responses tracked on a stream, the
+	// oldest of which is dropped to track a response of another type, so that a node
+	// requesting many types doesn't grow the tracking of its stream without bound.
+	maxSentResponsesPerStream = 64
+)
+
+// WithResponseExpiry stops tracking the responses the nodes don't answer within the timeout,
+// checked until the context is done, so that they are not outstanding until their stream is
+// closed. A node rejecting an expired response is not rolled back.
+func WithResponseExpiry(ctx context.Context, timeout time.Duration) Option {
+	return func(o *options) {
+		o.responseExpiryCtx = ctx
+		o.responseExpiry = timeout
+	}
+}
+
+// runResponseExpiry drops the expired responses periodically until the context is done.
+func (s *snapshotCache) runResponseExpiry(ctx context.Context) {
+	ticker := time.NewTicker(min(s.responseExpiry/2, maxResponseExpiryCheckInterval))
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			s.expireResponses(now)
+		}
+	}
+}
+
+// expireResponses drops the responses of the streams not answered within the timeout.
+func (s *snapshotCache) expireResponses(now time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for streamID := range s.sentResponses {
+		s.sweepResponses(streamID, now)
+	}
+}
+
+// trackResponse tracks the response of the type sent on the stream, replacing the previous
+// response of the type.
+func (s *snapshotCache) trackResponse(streamID int64, typeURL string, sent sentResponse) {
+	s.sweepResponses(streamID, sent.sentAt)
+	responses := s.sentResponses[streamID]
+	if responses == nil {
+		responses = make(map[string]sentResponse)
+		s.sentResponses[streamID] = responses
+	}
+	if _, ok := responses[typeURL]; !ok && len(responses) >= maxSentResponsesPerStream {
+		oldest := ""
+		for t, r := range responses {
+			if oldest == "" || r.sentAt.Before(responses[oldest].sentAt) {
+				oldest = t
+			}
+		}
+		s.dropResponse(streamID, oldest)
+	}
+	s.dropResponse(streamID, typeURL)
+	responses[typeURL] = sent
+	s.outstandingResponses++
+	s.recordOutstandingResponses()
+}
+
+// sweepResponses drops the responses of the stream that were answered, which the request
+// answering them no longer refers to, and the responses not answered within the timeout.
+func (s *snapshotCache) sweepResponses(streamID int64, now time.Time) {
+	for typeURL, sent := range s.sentResponses[streamID] {
+		if sent.answered || (s.responseExpiry > 0 && now.Sub(sent.sentAt) >= s.responseExpiry) {
+			s.dropResponse(streamID, typeURL)
+		}
+	}
+	if len(s.sentResponses[streamID]) == 0 {
+		delete(s.sentResponses, streamID)
+	}
+	s.recordOutstandingResponses()
+}
+
+// dropResponse stops tracking the response of the type sent on the stream, if any.
+func (s *snapshotCache) dropResponse(streamID int64, typeURL string) {
+	sent, ok := s.sentResponses[streamID][typeURL]
+	if !ok {
+		return
+	}
+	if !sent.answered {
+		s.outstandingResponses--
+	}
+	delete(s.sentResponses[streamID], typeURL)
+}
+
+// forgetResponses stops tracking the responses sent on the closed stream.
+func (s *snapshotCache) forgetResponses(streamID int64) {
+	for typeURL := range s.sentResponses[streamID] {
+		s.dropResponse(streamID, typeURL)
+	}
+	delete(s.sentResponses, streamID)
+	s.recordOutstandingResponses()
+}
+
+// recordOutstandingResponses records the number of the responses awaiting an answer.
+func (s *snapshotCache) recordOutstandingResponses() {
+	xdsOutstandingResponses.With(s.partitionLabel()).Record(float64(s.outstandingResponses))
+}
@@ -0,0 +1,109 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package cache
+
+import (
+	"context"
+	"math/rand/v2"
+	"time"
+
+	corev3 "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+
+	"github.com/envoyproxy/gateway/internal/metrics"
+)
+
+// WithPushPacing pushes the snapshots of the irKeys to at most maxPerSecond snapshot keys of
+// their nodes per second, with a random delay of up to the jitter added between two pushes,
+// until the context is done. The snapshot keys are queued, and pushed the last snapshot of
+// their irKey once dequeued, so that a key queued again before it is pushed is pushed once.
+func WithPushPacing(ctx context.Context, maxPerSecond uint32, jitter time.Duration) Option {
+	return func(o *options) {
+		o.pushCtx = ctx
+		o.pushMaxPerSecond = maxPerSecond
+		o.pushJitter = jitter
+	}
+}
+
+// runPushes pushes the queued snapshot keys at the paced rate until the context is done.
+func (s *snapshotCache) runPushes(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-s.pushReady:
+		}
+
+		for ctx.Err() == nil && s.pushNext() {
+			delay := s.pushInterval
+			if s.pushJitter > 0 {
+				delay += rand.N(s.pushJitter)
+			}
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(delay):
+			}
+		}
+	}
+}
+
+// queuePush queues the snapshot key of a node of the irKey to be pushed the last snapshot
+// of the irKey, unless it is already queued.
+func (s *snapshotCache) queuePush(irKey, key string) {
+	if _, ok := s.pushes[key]; !ok {
+		s.pushQueue = append(s.pushQueue, key)
+	}
+	s.pushes[key] = irKey
+	pushQueueDepth.With(s.partitionLabel()).Record(float64(len(s.pushQueue)))
+
+	select {
+	case s.pushReady <- struct{}{}:
+	default:
+	}
+}
+
+// pushNext pushes the last snapshot of its irKey to the next queued snapshot key. The keys
+// without a connected node anymore, and the keys the snapshot is not rolled out to yet, are
+// skipped. It returns false once there is no key left to push.
+func (s *snapshotCache) pushNext() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for len(s.pushQueue) > 0 {
+		key := s.pushQueue[0]
+		s.pushQueue = s.pushQueue[1:]
+		irKey := s.pushes[key]
+		delete(s.pushes, key)
+		pushQueueDepth.With(s.partitionLabel()).Record(float64(len(s.pushQueue)))
+
+		node := s.connectedNode(irKey, key)
+		if node == nil || s.lastSnapshot[irKey] == nil || s.rolloutPending(irKey, key) {
+			continue
+		}
+		nodeSnapshot, err := s.snapshotForNode(irKey, node)
+		if err == nil {
+			err = s.SetSnapshot(context.TODO(), key, nodeSnapshot)
+		}
+		if err != nil {
+			xdsSnapshotUpdateTotal.WithFailure(metrics.ReasonError, nodeIDLabel.Value(node.Id), s.partitionLabel()).Increment()
+			s.log.Errorf("failed to push the snapshot of %s to %s: %v", irKey, key, err)
+			return true
+		}
+		xdsSnapshotUpdateTotal.WithSuccess(nodeIDLabel.Value(node.Id), s.partitionLabel()).Increment()
+		return true
+	}
+	return false
+}
+
+// connectedNode returns a connected node of the irKey whose snapshot has the key, or nil.
+func (s *snapshotCache) connectedNode(irKey, key string) *corev3.Node {
+	for _, node := range s.getNodes(irKey) {
+		if s.snapshotKey(node) == key {
+			return node
+		}
+	}
+	return nil
+}
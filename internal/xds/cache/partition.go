@@ -0,0 +1,25 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package cache
+
+import "github.com/envoyproxy/gateway/internal/metrics"
+
+// DefaultPartition is the name of the partition of the snapshots of the irKeys that are
+// not isolated in a snapshot cache of their own.
+const DefaultPartition = "default"
+
+// WithPartition sets the name of the partition of the snapshots held by the cache, which
+// labels its metrics, so that the caches of isolated irKeys are monitored apart.
+func WithPartition(name string) Option {
+	return func(o *options) {
+		o.partition = name
+	}
+}
+
+// partitionLabel returns the label of the metrics of the partition of the cache.
+func (s *snapshotCache) partitionLabel() metrics.LabelValue {
+	return partitionLabel.Value(s.partition)
+}
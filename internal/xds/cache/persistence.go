@@ -0,0 +1,473 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package cache
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+
+	listenerv3 "github.com/envoyproxy/go-control-plane/envoy/config/listener/v3"
+	discoveryv3 "github.com/envoyproxy/go-control-plane/envoy/service/discovery/v3"
+	cachetypes "github.com/envoyproxy/go-control-plane/pkg/cache/types"
+	cachev3 "github.com/envoyproxy/go-control-plane/pkg/cache/v3"
+	resourcev3 "github.com/envoyproxy/go-control-plane/pkg/resource/v3"
+	"google.golang.org/protobuf/encoding/protodelim"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/anypb"
+	"google.golang.org/protobuf/types/known/structpb"
+
+	"github.com/envoyproxy/gateway/internal/xds/types"
+)
+
+// persistedSnapshotExt is the extension of the files the snapshots are persisted to.
+const persistedSnapshotExt = ".pb"
+
+// persistedResourceTypes lists the types of the persisted resources: the types a snapshot
+// can hold but the secrets, which are not written to the disk, and the LEDS resources the
+// cache serves itself.
+var persistedResourceTypes = append(slices.DeleteFunc(slices.Clone(resourceTypes), func(typeURL resourcev3.Type) bool {
+	return typeURL == resourcev3.SecretType
+}), types.LbEndpointType)
+
+// persistedSnapshotHeader is the header of a persisted snapshot, followed by a discovery
+// response holding the resources of each type.
+type persistedSnapshotHeader struct {
+	// Format is the format version of the snapshot, unset for the legacy snapshots.
+	Format  int               `json:"format,omitempty"`
+	IRKey   string            `json:"irKey"`
+	Version string            `json:"version"`
+	Scopes  []types.NodeScope `json:"scopes,omitempty"`
+}
+
+// SetPersistenceDir sets the directory the last snapshot of each irKey is written to, and
+// restores the snapshots written to it before, so that the nodes reconnecting after a
+// restart are served their last snapshot before the first translation completes. The
+// snapshots that cannot be read are skipped, the ones persisted in an older format are
+// migrated, and the restore is refused if a snapshot was persisted in a newer format.
+// The secrets are not persisted: the listeners referencing secrets are not restored, nor
+// are the snapshots referencing other missing resources.
+// The snapshots are read without holding the lock of the cache, which is only held to
+// restore them.
+func (s *snapshotCache) SetPersistenceDir(dir string) error {
+	if err := os.MkdirAll(dir, 0o750); err != nil {
+		return fmt.Errorf("failed to create the snapshot persistence directory: %w", err)
+	}
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return fmt.Errorf("failed to read the snapshot persistence directory: %w", err)
+	}
+
+	var restored []*restoredSnapshot
+	defer func() {
+		for _, r := range restored {
+			r.unpin()
+		}
+		s.prunePool()
+	}()
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), persistedSnapshotExt) {
+			continue
+		}
+		r, err := s.loadSnapshot(filepath.Join(dir, entry.Name()))
+		if err != nil {
+			if errors.Is(err, ErrNewerSnapshotFormat) {
+				return fmt.Errorf("refusing to restore the snapshot %s persisted by a newer version of Envoy Gateway: %w", entry.Name(), err)
+			}
+			snapshotPersistenceErrorsTotal.With(s.partitionLabel()).Increment()
+			s.log.Errorw("failed to restore the persisted snapshot", "file", entry.Name(), "error", err)
+			continue
+		}
+		if r != nil {
+			restored = append(restored, r)
+		}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.persistenceDir = dir
+	for _, r := range restored {
+		s.installSnapshot(r)
+	}
+	return nil
+}
+
+// restoredSnapshot is a snapshot of an irKey read from the persistence directory, whose
+// resources are pinned to the pool until it is restored.
+type restoredSnapshot struct {
+	header    persistedSnapshotHeader
+	version   int64
+	snapshot  *cachev3.Snapshot
+	scoped    []scopedSnapshot
+	resources types.XdsResources
+	unpin     func()
+}
+
+// loadSnapshot reads the last snapshot of an irKey from the file, unless a snapshot has
+// already been generated for it, in which case it returns nil.
+func (s *snapshotCache) loadSnapshot(path string) (*restoredSnapshot, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	r := bufio.NewReader(f)
+	header, err := readSnapshotHeader(r)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkSnapshotFormat(&header); err != nil {
+		return nil, err
+	}
+	s.mu.RLock()
+	generated := s.lastSnapshot[header.IRKey] != nil
+	s.mu.RUnlock()
+	if generated {
+		return nil, nil
+	}
+	version, err := strconv.ParseInt(header.Version, 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid version %q: %w", header.Version, err)
+	}
+
+	resources, err := readSnapshotResources(r)
+	if err != nil {
+		return nil, err
+	}
+
+	migrated, err := migrateSnapshot(&header, resources)
+	if err != nil {
+		return nil, err
+	}
+	// The secrets persisted by the older versions of Envoy Gateway are removed from the disk.
+	_, hasSecrets := resources[resourcev3.SecretType]
+	delete(resources, resourcev3.SecretType)
+	if migrated || hasSecrets {
+		// Persist the migrated snapshot, so that it's read in the current format from now on.
+		if err := writeSnapshot(path, header, resources); err != nil {
+			return nil, fmt.Errorf("failed to persist the migrated snapshot: %w", err)
+		}
+	}
+	if migrated {
+		snapshotPersistenceMigrationsTotal.With(s.partitionLabel()).Increment()
+		s.log.Infow("migrated the persisted snapshot", "irKey", header.IRKey, "format", header.Format)
+	}
+	// The listeners terminating TLS would warm until the first translation served their
+	// secrets, which are not persisted, so they're only served once translated.
+	if skipped := withoutSecretListeners(resources); len(skipped) > 0 {
+		s.log.Infow("skipped restoring the listeners referencing secrets", "irKey", header.IRKey, "listeners", skipped)
+	}
+
+	resources, unpin, err := s.pool.intern(resources, nil)
+	if err != nil {
+		return nil, err
+	}
+	snapshot, err := newSnapshot(header.Version, resources)
+	if err != nil {
+		unpin()
+		return nil, err
+	}
+	// Keep the nodes waiting for the first translation rather than serving them resources
+	// referencing resources missing from the snapshot, such as the secrets of the clusters.
+	if problems := checkSnapshotConsistency(snapshot); len(problems) > 0 {
+		unpin()
+		return nil, &InconsistentSnapshotError{IRKey: header.IRKey, Problems: problems}
+	}
+	scoped, err := newScopedSnapshots(header.Version, resources, header.Scopes)
+	if err != nil {
+		unpin()
+		return nil, err
+	}
+	s.pool.setVersions(snapshot)
+	for _, scopedSnapshot := range scoped {
+		s.pool.setVersions(scopedSnapshot.snapshot)
+	}
+
+	return &restoredSnapshot{
+		header:    header,
+		version:   version,
+		snapshot:  snapshot,
+		scoped:    scoped,
+		resources: resources,
+		unpin:     unpin,
+	}, nil
+}
+
+// installSnapshot restores the snapshot read from the persistence directory, unless a
+// snapshot has been generated for its irKey meanwhile.
+func (s *snapshotCache) installSnapshot(r *restoredSnapshot) {
+	irKey := r.header.IRKey
+	if s.lastSnapshot[irKey] != nil {
+		return
+	}
+
+	s.lastSnapshot[irKey] = r.snapshot
+	s.recordSnapshot(irKey, r.header.Version, r.snapshot)
+	if len(r.scoped) > 0 {
+		s.scopedSnapshots[irKey] = r.scoped
+	}
+	s.setLocalityEndpoints(irKey, r.resources[types.LbEndpointType])
+	s.setVirtualHosts(irKey, r.resources[types.VirtualHostType])
+	// Keep the versions generated from now on newer than the restored ones.
+	s.keepSnapshotVersionAbove(r.version)
+	if s.memoryLimit > 0 {
+		s.trackSnapshot(irKey, r.resources)
+	}
+	snapshotPersistenceLoadsTotal.With(s.partitionLabel()).Increment()
+	s.log.Infow("restored the persisted snapshot", "irKey", irKey, "version", r.header.Version)
+}
+
+// withoutSecretListeners removes the listeners referencing secrets from the resources,
+// along with the route configurations only these listeners referenced, and returns the
+// names of the removed listeners.
+func withoutSecretListeners(resources types.XdsResources) []string {
+	var skipped []string
+	kept := make(map[string]cachetypes.ResourceWithTTL)
+	removed := make(map[string]cachetypes.ResourceWithTTL)
+	listeners := make([]cachetypes.Resource, 0, len(resources[resourcev3.ListenerType]))
+	for _, r := range resources[resourcev3.ListenerType] {
+		name := cachev3.GetResourceName(r)
+		if listener, ok := r.(*listenerv3.Listener); ok && len(listenerSecretNames(listener)) > 0 {
+			skipped = append(skipped, name)
+			removed[name] = cachetypes.ResourceWithTTL{Resource: r}
+			continue
+		}
+		kept[name] = cachetypes.ResourceWithTTL{Resource: r}
+		listeners = append(listeners, r)
+	}
+	if len(skipped) == 0 {
+		return nil
+	}
+
+	resources[resourcev3.ListenerType] = listeners
+	keptRoutes := cachev3.GetResourceReferences(kept)[resourcev3.RouteType]
+	removedRoutes := cachev3.GetResourceReferences(removed)[resourcev3.RouteType]
+	resources[resourcev3.RouteType] = slices.DeleteFunc(resources[resourcev3.RouteType], func(r cachetypes.Resource) bool {
+		name := cachev3.GetResourceName(r)
+		return removedRoutes[name] && !keptRoutes[name]
+	})
+	return skipped
+}
+
+// readSnapshotHeader reads the header of a persisted snapshot.
+func readSnapshotHeader(r *bufio.Reader) (persistedSnapshotHeader, error) {
+	var header persistedSnapshotHeader
+	headerStruct := &structpb.Struct{}
+	if err := protodelim.UnmarshalFrom(r, headerStruct); err != nil {
+		return header, fmt.Errorf("failed to read the header: %w", err)
+	}
+	headerJSON, err := protojson.Marshal(headerStruct)
+	if err != nil {
+		return header, err
+	}
+	if err := json.Unmarshal(headerJSON, &header); err != nil {
+		return header, fmt.Errorf("failed to decode the header: %w", err)
+	}
+	return header, nil
+}
+
+// readSnapshotResources reads the resources of a persisted snapshot following its header.
+func readSnapshotResources(r *bufio.Reader) (types.XdsResources, error) {
+	resources := make(types.XdsResources)
+	for {
+		response := &discoveryv3.DiscoveryResponse{}
+		if err := protodelim.UnmarshalFrom(r, response); err != nil {
+			if errors.Is(err, io.EOF) {
+				return resources, nil
+			}
+			return nil, fmt.Errorf("failed to read the resources: %w", err)
+		}
+		for _, resource := range response.Resources {
+			msg, err := resource.UnmarshalNew()
+			if err != nil {
+				return nil, fmt.Errorf("failed to decode a resource of type %s: %w", response.TypeUrl, err)
+			}
+			resources[response.TypeUrl] = append(resources[response.TypeUrl], msg)
+		}
+	}
+}
+
+// persistedSnapshot is a snapshot of an irKey to write to the persistence directory, or
+// whose file to remove if it has no resources.
+type persistedSnapshot struct {
+	dir       string
+	header    persistedSnapshotHeader
+	resources types.XdsResources
+}
+
+// snapshotPersister writes the snapshots to the persistence directory in the background,
+// so that the snapshots are generated and served without waiting for the disk. Only the
+// last snapshot of each irKey queued is written.
+type snapshotPersister struct {
+	mu   sync.Mutex
+	cond *sync.Cond
+	// pending holds the last snapshot of each queued irKey not written yet, and queue
+	// holds the queued irKeys in order.
+	pending map[string]persistedSnapshot
+	queue   []string
+	// writing is true while a goroutine writes the queued snapshots.
+	writing bool
+}
+
+func newSnapshotPersister() *snapshotPersister {
+	p := &snapshotPersister{pending: make(map[string]persistedSnapshot)}
+	p.cond = sync.NewCond(&p.mu)
+	return p
+}
+
+// persistSnapshot queues the last snapshot generated for the irKey to be written to the
+// persistence directory, if set, or its file to be removed if the snapshot has no
+// resources, since the irKey was deleted.
+func (s *snapshotCache) persistSnapshot(dir, irKey, version string, resources types.XdsResources, scopes []types.NodeScope) {
+	if dir == "" {
+		return
+	}
+
+	p := s.persister
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if _, ok := p.pending[irKey]; !ok {
+		p.queue = append(p.queue, irKey)
+	}
+	p.pending[irKey] = persistedSnapshot{
+		dir:       dir,
+		header:    persistedSnapshotHeader{Format: currentSnapshotFormat, IRKey: irKey, Version: version, Scopes: scopes},
+		resources: resources,
+	}
+	if !p.writing {
+		p.writing = true
+		go s.writeSnapshots()
+	}
+}
+
+// writeSnapshots writes the queued snapshots until none is left.
+func (s *snapshotCache) writeSnapshots() {
+	p := s.persister
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	for len(p.queue) > 0 {
+		irKey := p.queue[0]
+		p.queue = p.queue[1:]
+		snapshot := p.pending[irKey]
+		delete(p.pending, irKey)
+
+		p.mu.Unlock()
+		s.writePersistedSnapshot(snapshot)
+		p.mu.Lock()
+	}
+	p.writing = false
+	p.cond.Broadcast()
+}
+
+// waitPersisted waits until the queued snapshots are written.
+func (s *snapshotCache) waitPersisted() {
+	p := s.persister
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	for p.writing {
+		p.cond.Wait()
+	}
+}
+
+// writePersistedSnapshot writes the snapshot, or removes the file of its irKey if it has
+// no resources. The file is replaced atomically, so that a crash while writing it leaves
+// the previous snapshot.
+func (s *snapshotCache) writePersistedSnapshot(snapshot persistedSnapshot) {
+	irKey := snapshot.header.IRKey
+	path := filepath.Join(snapshot.dir, url.PathEscape(irKey)+persistedSnapshotExt)
+	if snapshot.resources == nil {
+		if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+			snapshotPersistenceErrorsTotal.With(s.partitionLabel()).Increment()
+			s.log.Errorw("failed to remove the persisted snapshot", "irKey", irKey, "error", err)
+		}
+		return
+	}
+
+	if err := writeSnapshot(path, snapshot.header, snapshot.resources); err != nil {
+		snapshotPersistenceErrorsTotal.With(s.partitionLabel()).Increment()
+		s.log.Errorw("failed to persist the snapshot", "irKey", irKey, "version", snapshot.header.Version, "error", err)
+	}
+}
+
+// writeSnapshot writes the header and the resources of a snapshot to a temporary file,
+// then renames it to the path.
+func writeSnapshot(path string, header persistedSnapshotHeader, resources types.XdsResources) error {
+	f, err := os.CreateTemp(filepath.Dir(path), ".snapshot-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(f.Name())
+
+	w := bufio.NewWriter(f)
+	if err := marshalSnapshot(w, header, resources); err != nil {
+		f.Close()
+		return err
+	}
+	if err := w.Flush(); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Sync(); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	return os.Rename(f.Name(), path)
+}
+
+// marshalSnapshot writes the header of a snapshot, followed by a discovery response holding
+// its resources of each type, including the LEDS and VHDS resources the cache serves itself,
+// but the secrets.
+func marshalSnapshot(w io.Writer, header persistedSnapshotHeader, resources types.XdsResources) error {
+	headerJSON, err := json.Marshal(header)
+	if err != nil {
+		return err
+	}
+	headerStruct := &structpb.Struct{}
+	if err := protojson.Unmarshal(headerJSON, headerStruct); err != nil {
+		return err
+	}
+	messages := []proto.Message{headerStruct}
+	for _, typeURL := range persistedResourceTypes {
+		rs, ok := resources[typeURL]
+		if !ok {
+			continue
+		}
+		response := &discoveryv3.DiscoveryResponse{VersionInfo: header.Version, TypeUrl: typeURL}
+		for _, r := range rs {
+			resource, err := anypb.New(r)
+			if err != nil {
+				return err
+			}
+			response.Resources = append(response.Resources, resource)
+		}
+		messages = append(messages, response)
+	}
+
+	for _, msg := range messages {
+		if _, err := protodelim.MarshalTo(w, msg); err != nil {
+			return err
+		}
+	}
+	return nil
+}
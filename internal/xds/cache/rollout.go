@@ -0,0 +1,239 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package cache
+
+import (
+	"context"
+	"hash/fnv"
+	"slices"
+	"time"
+
+	corev3 "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	cachev3 "github.com/envoyproxy/go-control-plane/pkg/cache/v3"
+)
+
+// maxRolloutCheckInterval is the maximum interval the rollouts are checked at.
+const maxRolloutCheckInterval = 5 * time.Second
+
+const (
+	// rolloutResultCompleted is the result of the rollouts pushed to all the nodes.
+	rolloutResultCompleted = "completed"
+	// rolloutResultHalted is the result of the rollouts halted by a rejection of the snapshot.
+	rolloutResultHalted = "halted"
+)
+
+// rollout is the staged rollout of the last snapshot of an irKey to its nodes, by snapshot
+// key: the canary keys are set the snapshot first, and the pending keys keep their previous
+// snapshot until the canaries acknowledged the snapshot for the soak period.
+type rollout struct {
+	// version is the version of the snapshot rolled out.
+	version string
+	// canaries holds the snapshot keys set the snapshot first.
+	canaries map[string]bool
+	// previous holds the snapshot of each canary key before the rollout, to roll it
+	// back to if a canary rejects the snapshot.
+	previous map[string]cachev3.ResourceSnapshot
+	// pending holds the snapshot keys not set the snapshot yet.
+	pending map[string]bool
+	// acked holds the snapshot version of each type acknowledged by each canary node.
+	acked map[string]map[string]string
+	// healthySince is when all the canaries were first seen acknowledging the snapshot
+	// since they last weren't, or zero.
+	healthySince time.Time
+	// halted is true once a node served the snapshot rejected it.
+	halted bool
+}
+
+// WithStagedRollout rolls the new snapshots of each irKey out to the canaryPercentage of
+// the snapshot keys of its nodes first, and to the other keys once the canaries kept
+// acknowledging the snapshot for the soak period, checked until the context is done. A
+// rollout halts when a canary rejects the snapshot, and the canaries are rolled back.
+func WithStagedRollout(ctx context.Context, canaryPercentage uint32, soakPeriod time.Duration) Option {
+	return func(o *options) {
+		o.rolloutCtx = ctx
+		o.rolloutCanaryPercentage = canaryPercentage
+		o.rolloutSoakPeriod = soakPeriod
+	}
+}
+
+// runRollouts advances the rollouts periodically until the context is done.
+func (s *snapshotCache) runRollouts(ctx context.Context) {
+	ticker := time.NewTicker(min(s.rolloutSoakPeriod/2, maxRolloutCheckInterval))
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			s.advanceRollouts(now)
+		}
+	}
+}
+
+// startRollout starts the rollout of the new snapshot of the irKey to the snapshot keys of
+// its nodes served a snapshot, replacing the rollout in progress. The snapshot is set to all
+// the keys if there are not enough keys to leave any of them pending.
+func (s *snapshotCache) startRollout(irKey, version string) {
+	if s.rolloutCanaryPercentage == 0 {
+		return
+	}
+	delete(s.rollouts, irKey)
+
+	var keys []string
+	for _, node := range s.getNodes(irKey) {
+		key := s.snapshotKey(node)
+		if slices.Contains(keys, key) {
+			continue
+		}
+		if _, err := s.GetSnapshot(key); err == nil {
+			keys = append(keys, key)
+		}
+	}
+	canaryCount := (len(keys)*int(s.rolloutCanaryPercentage) + 99) / 100
+	if canaryCount >= len(keys) {
+		return
+	}
+
+	// The keys are ordered by a hash salted with the version, so that the canaries differ
+	// from one rollout to the next.
+	slices.SortFunc(keys, func(a, b string) int {
+		ha, hb := rolloutHash(version, a), rolloutHash(version, b)
+		switch {
+		case ha < hb:
+			return -1
+		case ha > hb:
+			return 1
+		}
+		return 0
+	})
+	r := &rollout{
+		version:  version,
+		canaries: make(map[string]bool, canaryCount),
+		previous: make(map[string]cachev3.ResourceSnapshot, canaryCount),
+		pending:  make(map[string]bool, len(keys)-canaryCount),
+		acked:    make(map[string]map[string]string),
+	}
+	for i, key := range keys {
+		if i < canaryCount {
+			r.canaries[key] = true
+			r.previous[key], _ = s.GetSnapshot(key)
+		} else {
+			r.pending[key] = true
+		}
+	}
+	s.rollouts[irKey] = r
+	s.log.Infow("rolling out snapshot to the canaries", "irKey", irKey, "version", version,
+		"canaries", canaryCount, "pending", len(r.pending))
+}
+
+// rolloutHash returns the hash ordering the snapshot key in the rollout of the version.
+func rolloutHash(version, key string) uint64 {
+	h := fnv.New64a()
+	_, _ = h.Write([]byte(version + "/" + key))
+	return h.Sum64()
+}
+
+// rolloutPending returns whether the snapshot key of a node of the irKey must not be set the
+// last snapshot of the irKey yet.
+func (s *snapshotCache) rolloutPending(irKey, key string) bool {
+	r := s.rollouts[irKey]
+	return r != nil && (r.halted || r.pending[key])
+}
+
+// recordRolloutAck records the snapshot version of the type acknowledged by a canary node.
+func (s *snapshotCache) recordRolloutAck(node *corev3.Node, irKey, typeURL, version string) {
+	r := s.rollouts[irKey]
+	if r == nil || !r.canaries[s.snapshotKey(node)] {
+		return
+	}
+	if r.acked[node.Id] == nil {
+		r.acked[node.Id] = make(map[string]string)
+	}
+	r.acked[node.Id][typeURL] = version
+}
+
+// haltRollout halts the rollout of the irKey once a node served the snapshot, a canary or
+// a node connected since the rollout started, rejected it, and rolls the canaries back to
+// their snapshot before the rollout. The rollout stays halted, and the snapshot is not
+// pushed to the other nodes, until a new snapshot is generated.
+func (s *snapshotCache) haltRollout(node *corev3.Node, irKey, version string) {
+	r := s.rollouts[irKey]
+	if r == nil || r.halted || r.pending[s.snapshotKey(node)] {
+		return
+	}
+	r.halted = true
+	for key, previous := range r.previous {
+		if previous == nil {
+			continue
+		}
+		if err := s.SetSnapshot(context.TODO(), key, previous); err != nil {
+			s.log.Errorf("failed to roll canary %s of %s back: %v", key, irKey, err)
+		}
+	}
+	rolloutsTotal.With(resultLabel.Value(rolloutResultHalted), s.partitionLabel()).Increment()
+	s.log.Infow("halted the rollout of the rejected snapshot", "irKey", irKey,
+		"version", r.version, "nodeID", node.Id, "rejectedVersion", version)
+}
+
+// advanceRollouts sets the snapshot of the rollouts whose canaries acknowledged it for the
+// soak period to the pending snapshot keys.
+func (s *snapshotCache) advanceRollouts(now time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for irKey, r := range s.rollouts {
+		if r.halted {
+			continue
+		}
+		if !s.canariesHealthy(irKey, r) {
+			r.healthySince = time.Time{}
+			continue
+		}
+		if r.healthySince.IsZero() {
+			r.healthySince = now
+		}
+		if now.Sub(r.healthySince) < s.rolloutSoakPeriod {
+			continue
+		}
+
+		delete(s.rollouts, irKey)
+		if err := s.setNodeSnapshots(irKey); err != nil {
+			s.log.Errorf("failed to roll the snapshot of %s out to the pending nodes: %v", irKey, err)
+			continue
+		}
+		rolloutsTotal.With(resultLabel.Value(rolloutResultCompleted), s.partitionLabel()).Increment()
+		s.log.Infow("rolled the snapshot out to the pending nodes", "irKey", irKey,
+			"version", r.version, "pending", len(r.pending))
+	}
+}
+
+// canariesHealthy returns whether a canary node of the rollout is connected, and all the
+// connected canary nodes acknowledged the snapshot they are served for all the types they
+// requested.
+func (s *snapshotCache) canariesHealthy(irKey string, r *rollout) bool {
+	connected := false
+	for streamID, node := range s.streamIDNodeInfo {
+		if node == nil || node.Cluster != irKey {
+			continue
+		}
+		key := s.snapshotKey(node)
+		if !r.canaries[key] {
+			continue
+		}
+		snapshot, err := s.GetSnapshot(key)
+		if err != nil {
+			return false
+		}
+		for typeURL := range s.streamTypeURLs[streamID] {
+			if r.acked[node.Id][typeURL] != snapshot.GetVersion(typeURL) {
+				return false
+			}
+		}
+		connected = true
+	}
+	return connected
+}
@@ -0,0 +1,24 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package cache
+
+import "sync"
+
+// lockShard locks the shard of the irKey, which serializes the generation and the updates
+// of its snapshots, and returns the function unlocking it. The shard of an irKey is never dropped, so that the
+// generations of the snapshots of an irKey deleted and added again are still serialized.
+func (s *snapshotCache) lockShard(irKey string) func() {
+	s.shardsMu.Lock()
+	shard, ok := s.shards[irKey]
+	if !ok {
+		shard = &sync.Mutex{}
+		s.shards[irKey] = shard
+	}
+	s.shardsMu.Unlock()
+
+	shard.Lock()
+	return shard.Unlock
+}
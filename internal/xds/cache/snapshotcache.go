@@ -17,22 +17,43 @@ import (
 	"context"
 	"fmt"
 	"math"
+	"sort"
 	"strconv"
 	"sync"
+	"sync/atomic"
 	"time"
 
+	adminv3 "github.com/envoyproxy/go-control-plane/envoy/admin/v3"
 	corev3 "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	endpointv3 "github.com/envoyproxy/go-control-plane/envoy/config/endpoint/v3"
 	discoveryv3 "github.com/envoyproxy/go-control-plane/envoy/service/discovery/v3"
+	cachetypes "github.com/envoyproxy/go-control-plane/pkg/cache/types"
 	cachev3 "github.com/envoyproxy/go-control-plane/pkg/cache/v3"
+	resourcev3 "github.com/envoyproxy/go-control-plane/pkg/resource/v3"
 	serverv3 "github.com/envoyproxy/go-control-plane/pkg/server/v3"
+	"go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/trace/noop"
 	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
 
 	"github.com/envoyproxy/gateway/internal/logging"
 	"github.com/envoyproxy/gateway/internal/metrics"
 	"github.com/envoyproxy/gateway/internal/xds/types"
 )
 
-var Hash = cachev3.IDHash{}
+// resourceTypes lists the xDS resource types that a snapshot can hold.
+var resourceTypes = []resourcev3.Type{
+	resourcev3.ClusterType,
+	resourcev3.EndpointType,
+	resourcev3.ListenerType,
+	resourcev3.RouteType,
+	resourcev3.ScopedRouteType,
+	resourcev3.VirtualHostType,
+	resourcev3.SecretType,
+	resourcev3.RuntimeType,
+	resourcev3.ExtensionConfigType,
+	resourcev3.RateLimitConfigType,
+}
 
 // SnapshotCacheWithCallbacks uses the go-control-plane SimpleCache to store snapshots of
 // Envoy resources, sliced by Node ID so that we can do incremental xDS properly.
@@ -47,8 +68,111 @@ type SnapshotCacheWithCallbacks interface {
 	cachev3.SnapshotCache
 	serverv3.Callbacks
 	GenerateNewSnapshot(string, types.XdsResources) error
+	// GenerateNewScopedSnapshot generates the snapshot of the resources of the irKey,
+	// and the snapshots of the subsets of the resources served to the nodes of the
+	// node scopes instead.
+	GenerateNewScopedSnapshot(irKey string, resources types.XdsResources, scopes []types.NodeScope) error
+	// IRKeys returns the irKeys for which a snapshot has been generated.
+	IRKeys() []string
+	// GetSnapshotInfo returns metadata about the last snapshot generated
+	// for the irKey, and false if no snapshot exists.
+	GetSnapshotInfo(irKey string) (*SnapshotInfo, bool)
+	// RecentChanges returns the most recent snapshot changes, newest first.
+	RecentChanges() []SnapshotChange
+	// GetSnapshotHistory returns the last snapshots generated for the irKey,
+	// newest first.
+	GetSnapshotHistory(irKey string) []SnapshotRecord
+	// SetHistorySize sets the number of the last snapshots of each irKey
+	// retained in its history.
+	SetHistorySize(int)
+	// DiffSnapshots returns the changes of the resources between two snapshot
+	// versions of the history of the irKey.
+	DiffSnapshots(irKey, versionA, versionB string) (*SnapshotDiff, error)
+	// SetListenerAckHandler sets the handler notified of the listeners pending
+	// acknowledgement by the nodes.
+	SetListenerAckHandler(ListenerAckHandler)
+	// SetSecretAckHandler sets the handler notified of the version of the
+	// secrets acknowledged by the nodes.
+	SetSecretAckHandler(SecretAckHandler)
+	// SetNackHandler sets the handler notified of the snapshots rejected by
+	// the nodes, which are rolled back to the last snapshot they acknowledged.
+	SetNackHandler(NackHandler)
+	// SetMemoryLimit sets the size of the resources of the snapshots above
+	// which the least recently used snapshots are evicted, and the handler
+	// notified of the evictions.
+	SetMemoryLimit(int64, EvictionHandler)
+	// UpdateEndpoints replaces the endpoints of the cluster in the last snapshot
+	// generated for the irKey, bumping only the version of the endpoints.
+	UpdateEndpoints(irKey, clusterName string, endpoints *endpointv3.ClusterLoadAssignment) error
+	// UpdateResources replaces the routes, clusters and endpoints of the last snapshot
+	// generated for the irKey by name, bumping only the versions of their types.
+	UpdateResources(irKey string, updates map[resourcev3.Type]map[string]cachetypes.Resource) error
+	// SetPersistenceDir sets the directory the last snapshot of each irKey is
+	// persisted to, and restores the snapshots persisted to it before.
+	SetPersistenceDir(string) error
+	// ConnectedNodes returns the Envoy nodes connected to the xDS server, with
+	// their stream, the type URLs of the resources they requested, and the hashes
+	// of the resources they acknowledged once the drift is detected.
+	ConnectedNodes() []ConnectedNode
+	// SetDriftHandler sets the handler notified of the nodes staying behind the
+	// resources they are served for longer than the drift window.
+	SetDriftHandler(DriftHandler)
+	// Checkpoint returns the newest snapshot of the irKey acknowledged by one of
+	// its nodes without its secrets, encoded to be read by ReadCheckpoint, and
+	// its version, which is empty if there is none.
+	Checkpoint(irKey string) (string, []byte, error)
+	// ConfigDump returns the resources of the snapshot served to the node of the irKey,
+	// or of the last snapshot of the irKey if nodeID is empty, as an Envoy config dump.
+	ConfigDump(irKey, nodeID string, includeEndpoints bool) (*adminv3.ConfigDump, error)
+	// Drain refuses the new streams and waits until the responses in flight on
+	// the open streams are answered by the nodes, or the context is done.
+	Drain(ctx context.Context) error
+	// SetSnapshotTrace traces the first acknowledgement by each node of the next
+	// snapshot generated for the irKey from the span context of the update it is
+	// generated from.
+	SetSnapshotTrace(irKey string, spanContext trace.SpanContext)
+}
+
+// ListenerAckHandler is called with the listeners of the last snapshot generated for
+// the irKey that have not been acknowledged by all the nodes served the snapshot yet,
+// whenever they may have changed.
+type ListenerAckHandler func(irKey string, pendingListeners []string)
+
+// SecretAckHandler is called with the oldest snapshot version of the secrets acknowledged
+// by all the nodes served the snapshot of the irKey, whenever it may have changed. The
+// version is empty if a node has not acknowledged any secrets yet, and is the version of
+// the last snapshot if no node is connected, since a connecting node is served that one.
+type SecretAckHandler func(irKey string, version string)
+
+// SnapshotInfo summarizes the last snapshot generated for an irKey.
+type SnapshotInfo struct {
+	// IRKey is the key of the xDS IR the snapshot was generated from.
+	IRKey string `json:"irKey"`
+	// Version is the version of the snapshot.
+	Version string `json:"version"`
+	// Resources holds the number of resources in the snapshot by type URL.
+	Resources map[string]int `json:"resources"`
+	// Nodes holds the IDs of the Envoy nodes currently served the snapshot.
+	Nodes []string `json:"nodes"`
+}
+
+// SnapshotChange records the generation of a snapshot for an irKey.
+type SnapshotChange struct {
+	// IRKey is the key of the xDS IR the snapshot was generated from.
+	IRKey string `json:"irKey"`
+	// Version is the version of the generated snapshot.
+	Version string `json:"version"`
+	// Time is when the snapshot was generated.
+	Time time.Time `json:"time"`
+	// Resources is the total number of resources in the snapshot.
+	Resources int `json:"resources"`
+	// Deleted is true if the snapshot was generated because the irKey was deleted.
+	Deleted bool `json:"deleted,omitempty"`
 }
 
+// maxRecentChanges is the number of snapshot changes retained by the cache.
+const maxRecentChanges = 100
+
 type snapshotMap map[string]*cachev3.Snapshot
 
 type nodeInfoMap map[int64]*corev3.Node
@@ -60,87 +184,622 @@ type snapshotCache struct {
 	streamIDNodeInfo    nodeInfoMap
 	streamDuration      streamDurationMap
 	deltaStreamDuration streamDurationMap
-	snapshotVersion     int64
-	lastSnapshot        snapshotMap
-	log                 *zap.SugaredLogger
-	mu                  sync.Mutex
+	// streamTypeURLs holds the type URLs of the resources requested on each stream.
+	streamTypeURLs map[int64]map[string]bool
+	// snapshotVersion is the version of the last snapshot generated for any irKey.
+	snapshotVersion atomic.Int64
+	lastSnapshot    snapshotMap
+	recentChanges   []SnapshotChange
+	// snapshotHistory holds the last historySize snapshots of each irKey, oldest first.
+	snapshotHistory map[string][]SnapshotRecord
+	historySize     int
+	log             *zap.SugaredLogger
+	// mu guards the state of the cache. The callbacks of the streams and the storing of
+	// the snapshots hold it exclusively, and the readers of the state share it. The lock
+	// of the shard of an irKey is taken before it.
+	mu sync.RWMutex
+	// shards holds the lock serializing the generation of the snapshots of each irKey,
+	// guarded by shardsMu.
+	shards   map[string]*sync.Mutex
+	shardsMu sync.Mutex
+
+	// ackedListeners holds the names of the listeners acknowledged by each node.
+	ackedListeners map[string]map[string]bool
+	onListenerAck  ListenerAckHandler
+	// ackedSecrets holds the snapshot version of the secrets acknowledged by each node.
+	ackedSecrets map[string]int64
+	onSecretAck  SecretAckHandler
+
+	// sentResponses holds the last response of each type sent on each stream, until it is
+	// answered and no longer referred to, or expired.
+	sentResponses map[int64]map[string]sentResponse
+	// outstandingResponses is the number of the sent responses awaiting an answer.
+	outstandingResponses int
+	responseExpiry       time.Duration
+	// goodSnapshots holds the last snapshots acknowledged by each node, oldest first.
+	goodSnapshots map[string][]cachev3.ResourceSnapshot
+	// nacks holds the unresolved rejection of a snapshot by each node.
+	nacks  map[string]types.Nack
+	onNack NackHandler
+
+	// memoryLimit is the size of the resources of the snapshots above which
+	// the snapshots are evicted, or zero to retain them all.
+	memoryLimit int64
+	onEviction  EvictionHandler
+	// snapshotSizes holds the size of the resources of each cached snapshot.
+	snapshotSizes map[string]int64
+	// snapshotUses holds when each cached snapshot was last used, as a count
+	// of the uses of all the snapshots.
+	snapshotUses map[string]int64
+	useCount     int64
+	// evicted holds the irKeys whose snapshot was evicted.
+	evicted map[string]bool
+	// pool holds the resources of the retained snapshots once by content.
+	pool *resourcePool
+
+	// persistenceDir is the directory the last snapshot of each irKey is written
+	// to, or empty to not persist the snapshots.
+	persistenceDir string
+	// persister writes the snapshots to the persistence directory.
+	persister *snapshotPersister
+
+	// scopedSnapshots holds the snapshots of the node scopes of each irKey.
+	scopedSnapshots map[string][]scopedSnapshot
+	// groupSnapshots holds the snapshots of each irKey served to the proxies of each node
+	// scope and group, which omit the routes of the other node groups.
+	groupSnapshots map[string]map[string]*cachev3.Snapshot
+
+	// snapshotTraces holds the trace of the last traced snapshot of each irKey.
+	snapshotTraces map[string]*snapshotTrace
+	// tracer records the spans of the acknowledgements of the traced snapshots.
+	tracer trace.Tracer
+
+	// resourceTTLs holds the TTL of the resources of each type served to the nodes.
+	resourceTTLs map[resourcev3.Type]time.Duration
+
+	// partition is the name of the partition of the snapshots held by the cache,
+	// which labels its metrics.
+	partition string
+	// auditor is notified of the exchanges with the nodes, if set.
+	auditor ExchangeAuditor
+	// checkConsistency is true to refuse the snapshots whose resources reference
+	// resources missing from them.
+	checkConsistency bool
+	// nodeHash keys the snapshots served to the nodes.
+	nodeHash cachev3.NodeHash
+	// staleNodeTimeout is the time without an open stream after which the snapshot
+	// of a node is cleared, if positive.
+	staleNodeTimeout time.Duration
+	// nodesLastSeen holds the last time a node of each snapshot key was seen, once
+	// the stale nodes are evicted.
+	nodesLastSeen map[string]time.Time
+
+	// localityEndpoints holds the LEDS resources of each irKey, by name.
+	localityEndpoints map[string]map[string]localityEndpoint
+	// localityEndpointWatches holds the open LEDS watches of the nodes of each irKey, by ID.
+	localityEndpointWatches    map[string]map[int64]*deltaWatch
+	localityEndpointWatchCount int64
+
+	// virtualHosts holds the VHDS resources of each irKey, by route configuration, in the
+	// order of the route configuration.
+	virtualHosts map[string]map[string][]virtualHost
+	// virtualHostWatches holds the open VHDS watches of the nodes of each irKey, by ID.
+	virtualHostWatches    map[string]map[int64]*deltaWatch
+	virtualHostWatchCount int64
+
+	// draining is true once the cache is drained, refusing the new streams.
+	draining bool
+
+	// rolloutCanaryPercentage is the percentage of the snapshot keys of the nodes of an
+	// irKey its new snapshots are rolled out to first, or zero to set them to all the keys.
+	rolloutCanaryPercentage uint32
+	// rolloutSoakPeriod is how long the canaries must acknowledge a new snapshot before it
+	// is rolled out to the other keys.
+	rolloutSoakPeriod time.Duration
+	// rollouts holds the rollout in progress of the last snapshot of each irKey.
+	rollouts map[string]*rollout
+
+	// pushInterval is the minimum interval between two pushes of the snapshots to the
+	// snapshot keys of the nodes, or zero to push them right away.
+	pushInterval time.Duration
+	// pushJitter is the maximum random delay added between two pushes.
+	pushJitter time.Duration
+	// pushQueue holds the snapshot keys queued to be pushed, in order, and pushes holds
+	// the irKey of each queued key.
+	pushQueue []string
+	pushes    map[string]string
+	// pushReady is signaled once a snapshot key is queued.
+	pushReady chan struct{}
+
+	// driftWindow is how long a node may stay behind the resources it is served before
+	// it is reported as drifted, or zero to not detect the drift.
+	driftWindow time.Duration
+	// ackedHashes holds the hashes of the resources acknowledged last by each node, by
+	// type and name, once the drift is detected.
+	ackedHashes map[string]map[string]map[string]string
+	// driftSince holds when each node behind the resources it is served was first seen
+	// behind them.
+	driftSince map[string]time.Time
+	// drifts holds the drifted nodes of each irKey last notified.
+	drifts  map[string][]types.Drift
+	onDrift DriftHandler
 }
 
 // GenerateNewSnapshot takes a table of resources (the output from the IR->xDS
 // translator) and updates the snapshot version.
 func (s *snapshotCache) GenerateNewSnapshot(irKey string, resources types.XdsResources) error {
-	s.mu.Lock()
-	defer s.mu.Unlock()
+	return s.GenerateNewScopedSnapshot(irKey, resources, nil)
+}
+
+// GenerateNewScopedSnapshot is GenerateNewSnapshot, with the node scopes selecting the
+// subsets of the resources served to the nodes whose metadata matches.
+func (s *snapshotCache) GenerateNewScopedSnapshot(irKey string, resources types.XdsResources, scopes []types.NodeScope) error {
+	// The snapshot is generated, persisted and set to the nodes holding the lock of the shard
+	// of the irKey, and the lock of the cache only to store it, so that the streams and the
+	// snapshots of the other irKeys are not blocked meanwhile.
+	defer s.lockShard(irKey)()
+
+	// Reject the resources instead of letting the snapshot silently serve only one of
+	// the resources sharing a name.
+	if err := types.CheckResourceNames(resources); err != nil {
+		xdsSnapshotCreateTotal.WithFailure(metrics.ReasonConflict, s.partitionLabel()).Increment()
+		return fmt.Errorf("failed to generate snapshot for %s: %w", irKey, err)
+	}
+	// The nodes sharing a snapshot key would all be served the subset of the scope of one
+	// of them.
+	if _, byID := s.nodeHash.(cachev3.IDHash); len(scopes) > 0 && !byID {
+		xdsSnapshotCreateTotal.WithFailure(metrics.ReasonConflict, s.partitionLabel()).Increment()
+		return fmt.Errorf("failed to generate snapshot for %s: node scopes require the snapshots to be keyed by node ID", irKey)
+	}
+	hashed, err := hashResources(resources)
+	if err != nil {
+		xdsSnapshotCreateTotal.WithFailure(metrics.ReasonError, s.partitionLabel()).Increment()
+		return fmt.Errorf("failed to generate snapshot for %s: %w", irKey, err)
+	}
+	// Keep serving the previous snapshot rather than pushing routes to clusters or
+	// clusters to secrets the proxies would never receive.
+	if s.checkConsistency {
+		unversioned, err := newSnapshot("", resources)
+		if err != nil {
+			xdsSnapshotCreateTotal.WithFailure(metrics.ReasonError, s.partitionLabel()).Increment()
+			return err
+		}
+		if problems := checkSnapshotConsistency(unversioned); len(problems) > 0 {
+			xdsSnapshotCreateTotal.WithFailure(metrics.ReasonInconsistent, s.partitionLabel()).Increment()
+			return &InconsistentSnapshotError{IRKey: irKey, Problems: problems}
+		}
+	}
+
+	// Share the resources identical to the resources of the retained snapshots.
+	resources, unpin, err := s.pool.intern(resources, hashed)
+	if err != nil {
+		xdsSnapshotCreateTotal.WithFailure(metrics.ReasonError, s.partitionLabel()).Increment()
+		return fmt.Errorf("failed to generate snapshot for %s: %w", irKey, err)
+	}
+	defer s.prunePool()
+	defer unpin()
 
 	version := s.newSnapshotVersion()
 
 	// Create a snapshot with all xDS resources.
-	snapshot, err := cachev3.NewSnapshot(
+	snapshot, err := newSnapshot(
 		version,
 		resources,
 	)
 	if err != nil {
-		xdsSnapshotCreateTotal.WithFailure(metrics.ReasonError).Increment()
+		xdsSnapshotCreateTotal.WithFailure(metrics.ReasonError, s.partitionLabel()).Increment()
 		return err
 	}
-	xdsSnapshotCreateTotal.WithSuccess().Increment()
+	scoped, err := newScopedSnapshots(version, resources, scopes)
+	if err != nil {
+		xdsSnapshotCreateTotal.WithFailure(metrics.ReasonError, s.partitionLabel()).Increment()
+		return err
+	}
+	xdsSnapshotCreateTotal.WithSuccess(s.partitionLabel()).Increment()
+	s.pool.setVersions(snapshot)
+	for _, scopedSnapshot := range scoped {
+		s.pool.setVersions(scopedSnapshot.snapshot)
+	}
 
-	s.lastSnapshot[irKey] = snapshot
+	// Log what the snapshot changes for debugging, without dumping the resources. The last
+	// snapshot of the irKey is only replaced holding the lock of its shard.
+	if s.log.Desugar().Core().Enabled(zapcore.DebugLevel) {
+		s.mu.RLock()
+		previous := s.lastSnapshot[irKey]
+		s.mu.RUnlock()
+		s.log.Debugw("generated snapshot", "irKey", irKey, "version", version,
+			"diff", diffSnapshots(previous, snapshot, maxDiffNames))
+	}
+
+	nodes, persistenceDir := s.storeSnapshot(irKey, version, snapshot, scoped, resources)
+	s.persistSnapshot(persistenceDir, irKey, version, resources, scopes)
 
-	for _, node := range s.getNodeIDs(irKey) {
-		s.log.Debugf("Generating a snapshot with Node %s", node)
+	return s.setStoredNodeSnapshots(irKey, snapshot, scoped, nodes)
+}
 
-		if err = s.SetSnapshot(context.TODO(), node, snapshot); err != nil {
-			xdsSnapshotUpdateTotal.WithFailure(metrics.ReasonError, nodeIDLabel.Value(node)).Increment()
+// setStoredNodeSnapshots sets the nodes their snapshot out of the stored snapshot of the
+// irKey and of its node scopes, without holding the lock of the cache, and caches the
+// snapshots of the node scopes and groups once set unless a newer snapshot was stored.
+func (s *snapshotCache) setStoredNodeSnapshots(irKey string, snapshot *cachev3.Snapshot, scoped []scopedSnapshot, nodes []*corev3.Node) error {
+	groupSnapshots := make(map[string]*cachev3.Snapshot)
+	for _, node := range nodes {
+		nodeSnapshot, err := nodeSnapshot(snapshot, scoped, node, s.resourceTTLs, groupSnapshots)
+		if err := s.setNodeSnapshot(node, nodeSnapshot, err); err != nil {
 			return err
-		} else {
-			xdsSnapshotUpdateTotal.WithSuccess(nodeIDLabel.Value(node)).Increment()
 		}
 	}
-
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.lastSnapshot[irKey] == snapshot {
+		if s.groupSnapshots[irKey] == nil {
+			s.groupSnapshots[irKey] = make(map[string]*cachev3.Snapshot)
+		}
+		for key, groupSnapshot := range groupSnapshots {
+			if _, ok := s.groupSnapshots[irKey][key]; !ok {
+				s.groupSnapshots[irKey][key] = groupSnapshot
+			}
+		}
+	}
 	return nil
 }
 
+// storeSnapshot replaces the last snapshot of the irKey and the state derived from it, and
+// returns the nodes to set the snapshot of, and the persistence directory of the snapshots.
+func (s *snapshotCache) storeSnapshot(
+	irKey, version string,
+	snapshot *cachev3.Snapshot,
+	scoped []scopedSnapshot,
+	resources types.XdsResources,
+) ([]*corev3.Node, string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.lastSnapshot[irKey] = snapshot
+	s.recordSnapshot(irKey, version, snapshot)
+	if len(scoped) > 0 {
+		s.scopedSnapshots[irKey] = scoped
+	} else {
+		delete(s.scopedSnapshots, irKey)
+	}
+	delete(s.groupSnapshots, irKey)
+	s.setLocalityEndpoints(irKey, resources[types.LbEndpointType])
+	s.setVirtualHosts(irKey, resources[types.VirtualHostType])
+	s.recordChange(irKey, version, resources)
+	if s.memoryLimit > 0 {
+		defer s.trackSnapshot(irKey, resources)
+	}
+	s.notifyListenerAcks(irKey)
+	s.notifySecretAcks(irKey)
+	s.startRollout(irKey, version)
+
+	return s.nodesToUpdate(irKey), s.persistenceDir
+}
+
+// IRKeys returns the sorted irKeys for which a snapshot has been generated.
+func (s *snapshotCache) IRKeys() []string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	keys := make([]string, 0, len(s.lastSnapshot))
+	for key, snapshot := range s.lastSnapshot {
+		if snapshot != nil {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+
+	return keys
+}
+
+// GetSnapshotInfo returns metadata about the last snapshot generated for the irKey.
+func (s *snapshotCache) GetSnapshotInfo(irKey string) (*SnapshotInfo, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	snapshot := s.lastSnapshot[irKey]
+	if snapshot == nil {
+		return nil, false
+	}
+
+	info := &SnapshotInfo{
+		IRKey:     irKey,
+		Resources: resourceCounts(snapshot),
+		Nodes:     s.getNodeIDs(irKey),
+	}
+	for _, typeURL := range resourceTypes {
+		if version := snapshot.GetVersion(typeURL); version != "" {
+			info.Version = version
+		}
+	}
+	sort.Strings(info.Nodes)
+
+	return info, true
+}
+
+// recordChange appends a snapshot change, dropping the oldest change
+// once maxRecentChanges is reached.
+func (s *snapshotCache) recordChange(irKey, version string, resources types.XdsResources) {
+	change := SnapshotChange{
+		IRKey:   irKey,
+		Version: version,
+		Time:    time.Now(),
+		Deleted: resources == nil,
+	}
+	for _, rs := range resources {
+		change.Resources += len(rs)
+	}
+
+	if len(s.recentChanges) == maxRecentChanges {
+		s.recentChanges = s.recentChanges[1:]
+	}
+	s.recentChanges = append(s.recentChanges, change)
+	s.bindSnapshotTrace(irKey, version, change.Deleted, change.Time)
+}
+
+// RecentChanges returns the most recent snapshot changes, newest first.
+func (s *snapshotCache) RecentChanges() []SnapshotChange {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	changes := make([]SnapshotChange, 0, len(s.recentChanges))
+	for i := len(s.recentChanges) - 1; i >= 0; i-- {
+		changes = append(changes, s.recentChanges[i])
+	}
+
+	return changes
+}
+
+// SetListenerAckHandler sets the handler notified of the listeners pending acknowledgement.
+func (s *snapshotCache) SetListenerAckHandler(handler ListenerAckHandler) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.onListenerAck = handler
+}
+
+// recordListenerAck records the listeners of the snapshot version acknowledged by the node.
+// The acknowledgement is ignored if the node has already been served a newer snapshot, since
+// the listeners of the acknowledged version are no longer known.
+func (s *snapshotCache) recordListenerAck(node *corev3.Node, irKey, version string) {
+	snapshot, err := s.GetSnapshot(s.snapshotKey(node))
+	if err != nil || snapshot.GetVersion(resourcev3.ListenerType) != version {
+		return
+	}
+
+	acked := make(map[string]bool)
+	for name := range snapshot.GetResources(resourcev3.ListenerType) {
+		acked[name] = true
+	}
+	s.ackedListeners[node.Id] = acked
+	s.notifyListenerAcks(irKey)
+}
+
+// notifyListenerAcks notifies the handler of the listeners of the last snapshot generated
+// for the irKey that have not been acknowledged by all the nodes they are served to. All
+// the listeners are pending until at least one node they are served to is connected.
+func (s *snapshotCache) notifyListenerAcks(irKey string) {
+	if s.onListenerAck == nil {
+		return
+	}
+
+	var pending []string
+	if snapshot := s.lastSnapshot[irKey]; snapshot != nil {
+		nodes := s.getNodes(irKey)
+		for name := range snapshot.GetResources(resourcev3.ListenerType) {
+			served, acked := false, true
+			for _, node := range nodes {
+				if !s.servesListener(irKey, node, name) {
+					continue
+				}
+				served = true
+				if !s.ackedListeners[node.Id][name] {
+					acked = false
+					break
+				}
+			}
+			if !served || !acked {
+				pending = append(pending, name)
+			}
+		}
+		sort.Strings(pending)
+	}
+
+	s.onListenerAck(irKey, pending)
+}
+
+// servesListener returns whether the listener of the last snapshot generated for the irKey
+// is served to the node, which is not the case of the listeners outside its node scope.
+func (s *snapshotCache) servesListener(irKey string, node *corev3.Node, name string) bool {
+	snapshot, err := s.snapshotForNode(irKey, node)
+	if err != nil {
+		return true
+	}
+	_, ok := snapshot.GetResources(resourcev3.ListenerType)[name]
+	return ok
+}
+
+// SetSecretAckHandler sets the handler notified of the version of the acknowledged secrets.
+func (s *snapshotCache) SetSecretAckHandler(handler SecretAckHandler) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.onSecretAck = handler
+}
+
+// recordSecretAck records the snapshot version of the secrets acknowledged by the node.
+func (s *snapshotCache) recordSecretAck(nodeID, irKey, version string) {
+	v, err := strconv.ParseInt(version, 10, 64)
+	if err != nil {
+		return
+	}
+
+	s.ackedSecrets[nodeID] = v
+	s.notifySecretAcks(irKey)
+}
+
+// notifySecretAcks notifies the handler of the oldest snapshot version of the secrets
+// acknowledged by all the nodes of the irKey.
+func (s *snapshotCache) notifySecretAcks(irKey string) {
+	if s.onSecretAck == nil {
+		return
+	}
+
+	snapshot := s.lastSnapshot[irKey]
+	if snapshot == nil {
+		return
+	}
+
+	nodeIDs := s.getNodeIDs(irKey)
+	if len(nodeIDs) == 0 {
+		s.onSecretAck(irKey, snapshot.GetVersion(resourcev3.SecretType))
+		return
+	}
+
+	var oldest int64 = math.MaxInt64
+	for _, nodeID := range nodeIDs {
+		acked, ok := s.ackedSecrets[nodeID]
+		if !ok {
+			s.onSecretAck(irKey, "")
+			return
+		}
+		oldest = min(oldest, acked)
+	}
+	s.onSecretAck(irKey, strconv.FormatInt(oldest, 10))
+}
+
 // newSnapshotVersion increments the current snapshotVersion
 // and returns as a string.
 func (s *snapshotCache) newSnapshotVersion() string {
-	// Reset the snapshotVersion if it ever hits max size.
-	if s.snapshotVersion == math.MaxInt64 {
-		s.snapshotVersion = 0
+	for {
+		current := s.snapshotVersion.Load()
+		// Reset the snapshotVersion if it ever hits max size.
+		next := current + 1
+		if current == math.MaxInt64 {
+			next = 1
+		}
+		if s.snapshotVersion.CompareAndSwap(current, next) {
+			return strconv.FormatInt(next, 10)
+		}
 	}
+}
 
-	// Increment the snapshot version & return as string.
-	s.snapshotVersion++
-	return strconv.FormatInt(s.snapshotVersion, 10)
+// keepSnapshotVersionAbove keeps the versions generated from now on newer than the version.
+func (s *snapshotCache) keepSnapshotVersionAbove(version int64) {
+	for {
+		current := s.snapshotVersion.Load()
+		if current >= version || s.snapshotVersion.CompareAndSwap(current, version) {
+			return
+		}
+	}
 }
 
 // NewSnapshotCache gives you a fresh SnapshotCache.
 // It needs a logger that supports the go-control-plane
 // required interface (Debugf, Infof, Warnf, and Errorf).
-func NewSnapshotCache(ads bool, logger logging.Logger) SnapshotCacheWithCallbacks {
+func NewSnapshotCache(ads bool, logger logging.Logger, opts ...Option) SnapshotCacheWithCallbacks {
+	o := options{partition: DefaultPartition, nodeHash: cachev3.IDHash{}, tracerProvider: noop.NewTracerProvider()}
+	for _, opt := range opts {
+		opt(&o)
+	}
+
 	// Set up the nasty wrapper hack.
 	wrappedLogger := logger.Sugar()
-	return &snapshotCache{
-		SnapshotCache:       cachev3.NewSnapshotCache(ads, &Hash, wrappedLogger),
-		log:                 wrappedLogger,
-		lastSnapshot:        make(snapshotMap),
-		streamIDNodeInfo:    make(nodeInfoMap),
-		streamDuration:      make(streamDurationMap),
-		deltaStreamDuration: make(streamDurationMap),
+	var cache cachev3.SnapshotCache
+	if len(o.resourceTTLs) > 0 {
+		cache = cachev3.NewSnapshotCacheWithHeartbeating(o.heartbeatCtx, ads, o.nodeHash, wrappedLogger, o.heartbeatInterval)
+	} else {
+		cache = cachev3.NewSnapshotCache(ads, o.nodeHash, wrappedLogger)
 	}
+	c := &snapshotCache{
+		SnapshotCache:           cache,
+		resourceTTLs:            o.resourceTTLs,
+		partition:               o.partition,
+		auditor:                 o.auditor,
+		checkConsistency:        o.checkConsistency,
+		nodeHash:                o.nodeHash,
+		staleNodeTimeout:        o.staleNodeTimeout,
+		responseExpiry:          o.responseExpiry,
+		rolloutCanaryPercentage: o.rolloutCanaryPercentage,
+		rolloutSoakPeriod:       o.rolloutSoakPeriod,
+		rollouts:                make(map[string]*rollout),
+		pushJitter:              o.pushJitter,
+		pushes:                  make(map[string]string),
+		pushReady:               make(chan struct{}, 1),
+		driftWindow:             o.driftWindow,
+		ackedHashes:             make(map[string]map[string]map[string]string),
+		driftSince:              make(map[string]time.Time),
+		drifts:                  make(map[string][]types.Drift),
+		nodesLastSeen:           make(map[string]time.Time),
+		log:                     wrappedLogger,
+		lastSnapshot:            make(snapshotMap),
+		snapshotHistory:         make(map[string][]SnapshotRecord),
+		historySize:             defaultHistorySize,
+		ackedListeners:          make(map[string]map[string]bool),
+		ackedSecrets:            make(map[string]int64),
+		sentResponses:           make(map[int64]map[string]sentResponse),
+		goodSnapshots:           make(map[string][]cachev3.ResourceSnapshot),
+		nacks:                   make(map[string]types.Nack),
+		snapshotSizes:           make(map[string]int64),
+		snapshotUses:            make(map[string]int64),
+		evicted:                 make(map[string]bool),
+		pool:                    newResourcePool(),
+		shards:                  make(map[string]*sync.Mutex),
+		persister:               newSnapshotPersister(),
+		scopedSnapshots:         make(map[string][]scopedSnapshot),
+		groupSnapshots:          make(map[string]map[string]*cachev3.Snapshot),
+		snapshotTraces:          make(map[string]*snapshotTrace),
+		tracer:                  o.tracerProvider.Tracer(tracerName),
+		streamIDNodeInfo:        make(nodeInfoMap),
+		streamDuration:          make(streamDurationMap),
+		deltaStreamDuration:     make(streamDurationMap),
+		streamTypeURLs:          make(map[int64]map[string]bool),
+
+		localityEndpoints:       make(map[string]map[string]localityEndpoint),
+		localityEndpointWatches: make(map[string]map[int64]*deltaWatch),
+		virtualHosts:            make(map[string]map[string][]virtualHost),
+		virtualHostWatches:      make(map[string]map[int64]*deltaWatch),
+	}
+	if o.staleNodeTimeout > 0 {
+		go c.runStaleNodeEviction(o.staleNodeCtx)
+	}
+	if o.responseExpiry > 0 {
+		go c.runResponseExpiry(o.responseExpiryCtx)
+	}
+	if o.rolloutCanaryPercentage > 0 {
+		go c.runRollouts(o.rolloutCtx)
+	}
+	if o.pushMaxPerSecond > 0 {
+		c.pushInterval = time.Second / time.Duration(o.pushMaxPerSecond)
+		go c.runPushes(o.pushCtx)
+	}
+	if o.driftWindow > 0 {
+		go c.runDriftDetection(o.driftCtx)
+	}
+	return c
 }
 
 // getNodeIDs retrieves the node ids from the node info map whose
 // cluster field matches the ir key
 func (s *snapshotCache) getNodeIDs(irKey string) []string {
 	var nodeIDs []string
+	for _, node := range s.getNodes(irKey) {
+		nodeIDs = append(nodeIDs, node.Id)
+	}
+
+	return nodeIDs
+}
+
+// getNodes retrieves the nodes from the node info map whose cluster field
+// matches the ir key
+func (s *snapshotCache) getNodes(irKey string) []*corev3.Node {
+	var nodes []*corev3.Node
 	for _, node := range s.streamIDNodeInfo {
 		if node != nil && node.Cluster == irKey {
-			nodeIDs = append(nodeIDs, node.Id)
+			nodes = append(nodes, node)
 		}
 	}
 
-	return nodeIDs
+	return nodes
 }
 
 // OnStreamOpen and the other OnStream* functions implement the callbacks for the
@@ -149,6 +808,10 @@ func (s *snapshotCache) OnStreamOpen(_ context.Context, streamID int64, _ string
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
+	if s.draining {
+		return ErrDraining
+	}
+
 	s.streamIDNodeInfo[streamID] = nil
 	s.streamDuration[streamID] = time.Now()
 
@@ -166,10 +829,11 @@ func (s *snapshotCache) OnStreamClosed(streamID int64, node *corev3.Node) {
 			streamIDLabel.Value(strconv.FormatInt(streamID, 10)),
 			nodeIDLabel.Value(node.Id),
 			isDeltaStreamLabel.Value("false"),
+			s.partitionLabel(),
 		).Record(streamDuration.Seconds())
 	}
 
-	delete(s.streamIDNodeInfo, streamID)
+	s.forgetNode(streamID)
 	delete(s.streamDuration, streamID)
 }
 
@@ -189,8 +853,23 @@ func (s *snapshotCache) OnStreamRequest(streamID int64, req *discoveryv3.Discove
 		s.log.Debugf("First discovery request on stream %d, got nodeID %s", streamID, req.Node.Id)
 		s.streamIDNodeInfo[streamID] = req.Node
 	}
-	nodeID := s.streamIDNodeInfo[streamID].Id
-	cluster := s.streamIDNodeInfo[streamID].Cluster
+	s.recordTypeURL(streamID, req.GetTypeUrl())
+	result, latency := s.answerResponse(streamID, req.GetTypeUrl(), req.ResponseNonce, req.ErrorDetail != nil)
+	node := s.streamIDNodeInfo[streamID]
+	nodeID := node.Id
+	cluster := node.Cluster
+	s.audit(&ExchangeRecord{
+		Direction:     ExchangeDirectionRequest,
+		StreamID:      streamID,
+		NodeID:        nodeID,
+		TypeURL:       req.GetTypeUrl(),
+		Version:       req.VersionInfo,
+		Nonce:         req.ResponseNonce,
+		ResourceNames: req.ResourceNames,
+		Result:        result,
+		ErrorMessage:  req.GetErrorDetail().GetMessage(),
+		Latency:       latency,
+	})
 
 	var nodeVersion string
 
@@ -199,31 +878,46 @@ func (s *snapshotCache) OnStreamRequest(streamID int64, req *discoveryv3.Discove
 
 	// If no snapshot has been generated yet, we can't do anything, so don't mess with this request.
 	// go-control-plane will respond with an empty response, then send an update when a snapshot is generated.
+	// An evicted snapshot is generated again.
 	if s.lastSnapshot[cluster] == nil {
+		s.restoreSnapshot(cluster)
 		return nil
 	}
+	s.touchSnapshot(cluster)
 
-	_, err := s.GetSnapshot(nodeID)
+	key := s.snapshotKey(node)
+	s.recordNodeSeen(key, time.Now())
+	_, err := s.GetSnapshot(key)
 	if err != nil {
-		err = s.SetSnapshot(context.TODO(), nodeID, s.lastSnapshot[cluster])
+		nodeSnapshot, err := s.snapshotForNode(cluster, node)
 		if err != nil {
 			return err
 		}
+		if err = s.SetSnapshot(context.TODO(), key, nodeSnapshot); err != nil {
+			return err
+		}
 	}
 
 	if req.Node != nil {
-		if bv := req.Node.GetUserAgentBuildVersion(); bv != nil && bv.Version != nil {
-			nodeVersion = fmt.Sprintf("v%d.%d.%d", bv.Version.MajorNumber, bv.Version.MinorNumber, bv.Version.Patch)
-		}
+		nodeVersion = envoyVersion(req.Node)
 	}
 
 	s.log.Debugf("Got a new request, version_info %s, response_nonce %s, nodeID %s, node_version %s", req.VersionInfo, req.ResponseNonce, nodeID, nodeVersion)
 
 	if status := req.ErrorDetail; status != nil {
-		// if Envoy rejected the last update log the details here.
-		// TODO(youngnick): Handle NACK properly
+		// Envoy rejected the response with the nonce of the request.
 		errorCode = status.Code
 		errorMessage = status.Message
+		s.handleNack(streamID, node, cluster, req.GetTypeUrl(), req.ResponseNonce, status.Message)
+	} else if req.VersionInfo != "" {
+		// The version info of a request is the last version accepted by Envoy.
+		s.recordAck(node, cluster, req.GetTypeUrl(), req.VersionInfo)
+		switch req.GetTypeUrl() {
+		case resourcev3.ListenerType:
+			s.recordListenerAck(node, cluster, req.VersionInfo)
+		case resourcev3.SecretType:
+			s.recordSecretAck(nodeID, cluster, req.VersionInfo)
+		}
 	}
 
 	s.log.Debugf("handling v3 xDS resource request, version_info %s, response_nonce %s, nodeID %s, node_version %s, resource_names %v, type_url %s, errorCode %d, errorMessage %s",
@@ -234,14 +928,26 @@ func (s *snapshotCache) OnStreamRequest(streamID int64, req *discoveryv3.Discove
 	return nil
 }
 
-func (s *snapshotCache) OnStreamResponse(_ context.Context, streamID int64, _ *discoveryv3.DiscoveryRequest, _ *discoveryv3.DiscoveryResponse) {
-	// No mutex lock required here because no writing to the cache.
+func (s *snapshotCache) OnStreamResponse(_ context.Context, streamID int64, _ *discoveryv3.DiscoveryRequest, resp *discoveryv3.DiscoveryResponse) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
 	node := s.streamIDNodeInfo[streamID]
 	if node == nil {
 		s.log.Errorf("Tried to send a response to a node we haven't seen yet on stream %d", streamID)
 	} else {
 		s.log.Debugf("Sending Response on stream %d to node %s", streamID, node.Id)
 	}
+	s.recordResponse(streamID, resp.GetTypeUrl(), resp.GetNonce(), resp.GetVersionInfo())
+	s.audit(&ExchangeRecord{
+		Direction: ExchangeDirectionResponse,
+		StreamID:  streamID,
+		NodeID:    s.streamNodeID(streamID),
+		TypeURL:   resp.GetTypeUrl(),
+		Version:   resp.GetVersionInfo(),
+		Nonce:     resp.GetNonce(),
+		Resources: len(resp.GetResources()),
+	})
 }
 
 // OnDeltaStreamOpen and the other OnDeltaStream*/OnStreamDelta* functions implement
@@ -251,6 +957,10 @@ func (s *snapshotCache) OnDeltaStreamOpen(_ context.Context, streamID int64, _ s
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
+	if s.draining {
+		return ErrDraining
+	}
+
 	// Ensure that we're adding the streamID to the Node ID list.
 	s.streamIDNodeInfo[streamID] = nil
 	s.deltaStreamDuration[streamID] = time.Now()
@@ -269,13 +979,46 @@ func (s *snapshotCache) OnDeltaStreamClosed(streamID int64, node *corev3.Node) {
 			streamIDLabel.Value(strconv.FormatInt(streamID, 10)),
 			nodeIDLabel.Value(node.Id),
 			isDeltaStreamLabel.Value("true"),
+			s.partitionLabel(),
 		).Record(deltaStreamDuration.Seconds())
 	}
 
-	delete(s.streamIDNodeInfo, streamID)
+	s.forgetNode(streamID)
 	delete(s.deltaStreamDuration, streamID)
 }
 
+// forgetNode removes the node of the closed stream, whose resources are
+// no longer required to be acknowledged.
+func (s *snapshotCache) forgetNode(streamID int64) {
+	node := s.streamIDNodeInfo[streamID]
+	delete(s.streamIDNodeInfo, streamID)
+	s.forgetResponses(streamID)
+	delete(s.streamTypeURLs, streamID)
+	if node == nil {
+		return
+	}
+
+	delete(s.ackedListeners, node.Id)
+	delete(s.ackedSecrets, node.Id)
+	delete(s.goodSnapshots, node.Id)
+	delete(s.nacks, node.Id)
+	delete(s.ackedHashes, node.Id)
+	delete(s.driftSince, node.Id)
+	// Once evicting snapshots, don't retain the snapshot of the node, which is
+	// set again from the snapshot of its irKey if it reconnects, unless it is
+	// shared with a node still connected.
+	key := s.snapshotKey(node)
+	if s.memoryLimit > 0 && !s.snapshotKeyConnected(key) {
+		s.ClearSnapshot(key)
+		delete(s.nodesLastSeen, key)
+	} else {
+		s.recordNodeSeen(key, time.Now())
+	}
+	s.notifyListenerAcks(node.Cluster)
+	s.notifySecretAcks(node.Cluster)
+	s.notifyNacks(node.Cluster)
+}
+
 func (s *snapshotCache) OnStreamDeltaRequest(streamID int64, req *discoveryv3.DeltaDiscoveryRequest) error {
 	s.mu.Lock()
 	// We could do this a little earlier than with a defer, since the last half of this func is logging
@@ -297,37 +1040,70 @@ func (s *snapshotCache) OnStreamDeltaRequest(streamID int64, req *discoveryv3.De
 		s.log.Debugf("First incremental discovery request on stream %d, got nodeID %s", streamID, req.Node.Id)
 		s.streamIDNodeInfo[streamID] = req.Node
 	}
-	nodeID := s.streamIDNodeInfo[streamID].Id
-	cluster := s.streamIDNodeInfo[streamID].Cluster
+	s.recordTypeURL(streamID, req.GetTypeUrl())
+	result, latency := s.answerResponse(streamID, req.GetTypeUrl(), req.ResponseNonce, req.ErrorDetail != nil)
+	node = s.streamIDNodeInfo[streamID]
+	nodeID := node.Id
+	cluster := node.Cluster
+	s.audit(&ExchangeRecord{
+		Direction:            ExchangeDirectionRequest,
+		StreamID:             streamID,
+		Delta:                true,
+		NodeID:               nodeID,
+		TypeURL:              req.GetTypeUrl(),
+		Nonce:                req.ResponseNonce,
+		ResourceNames:        req.ResourceNamesSubscribe,
+		RemovedResourceNames: req.ResourceNamesUnsubscribe,
+		Result:               result,
+		ErrorMessage:         req.GetErrorDetail().GetMessage(),
+		Latency:              latency,
+	})
 
 	// If no snapshot has been written into the snapshotCache yet, we can't do anything, so don't mess with
 	// this request. go-control-plane will respond with an empty response, then send an update when a
-	// snapshot is generated.
+	// snapshot is generated. An evicted snapshot is generated again.
 	if s.lastSnapshot[cluster] == nil {
+		s.restoreSnapshot(cluster)
 		return nil
 	}
+	s.touchSnapshot(cluster)
 
-	_, err := s.GetSnapshot(nodeID)
+	key := s.snapshotKey(node)
+	s.recordNodeSeen(key, time.Now())
+	_, err := s.GetSnapshot(key)
 	if err != nil {
-		err = s.SetSnapshot(context.TODO(), nodeID, s.lastSnapshot[cluster])
+		nodeSnapshot, err := s.snapshotForNode(cluster, node)
 		if err != nil {
 			return err
 		}
+		if err = s.SetSnapshot(context.TODO(), key, nodeSnapshot); err != nil {
+			return err
+		}
 	}
 
 	if req.Node != nil {
-		if bv := req.Node.GetUserAgentBuildVersion(); bv != nil && bv.Version != nil {
-			nodeVersion = fmt.Sprintf("v%d.%d.%d", bv.Version.MajorNumber, bv.Version.MinorNumber, bv.Version.Patch)
-		}
+		nodeVersion = envoyVersion(req.Node)
 	}
 
 	s.log.Debugf("Got a new request, response_nonce %s, nodeID %s, node_version %s",
 		req.ResponseNonce, nodeID, nodeVersion)
 	if status := req.ErrorDetail; status != nil {
-		// if Envoy rejected the last update log the details here.
-		// TODO(youngnick): Handle NACK properly
+		// Envoy rejected the response with the nonce of the request.
 		errorCode = status.Code
 		errorMessage = status.Message
+		s.handleNack(streamID, node, cluster, req.GetTypeUrl(), req.ResponseNonce, status.Message)
+	} else if req.ResponseNonce != "" {
+		// Incremental requests don't carry versions, a request acknowledging a response
+		// acknowledges the resources of the snapshot served to the node.
+		if snapshot, err := s.GetSnapshot(key); err == nil {
+			s.recordAck(node, cluster, req.GetTypeUrl(), snapshot.GetVersion(req.GetTypeUrl()))
+			switch req.GetTypeUrl() {
+			case resourcev3.ListenerType:
+				s.recordListenerAck(node, cluster, snapshot.GetVersion(resourcev3.ListenerType))
+			case resourcev3.SecretType:
+				s.recordSecretAck(nodeID, cluster, snapshot.GetVersion(resourcev3.SecretType))
+			}
+		}
 	}
 	s.log.Debugf("handling v3 xDS resource request, response_nonce %s, nodeID %s, node_version %s, resource_names_subscribe %v, resource_names_unsubscribe %v, type_url %s, errorCode %d, errorMessage %s",
 		req.ResponseNonce,
@@ -339,14 +1115,35 @@ func (s *snapshotCache) OnStreamDeltaRequest(streamID int64, req *discoveryv3.De
 	return nil
 }
 
-func (s *snapshotCache) OnStreamDeltaResponse(streamID int64, _ *discoveryv3.DeltaDiscoveryRequest, _ *discoveryv3.DeltaDiscoveryResponse) {
-	// No mutex lock required here because no writing to the cache.
+func (s *snapshotCache) OnStreamDeltaResponse(streamID int64, _ *discoveryv3.DeltaDiscoveryRequest, resp *discoveryv3.DeltaDiscoveryResponse) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
 	node := s.streamIDNodeInfo[streamID]
 	if node == nil {
 		s.log.Errorf("Tried to send a response to a node we haven't seen yet on stream %d", streamID)
 	} else {
 		s.log.Debugf("Sending Incremental Response on stream %d to node %s", streamID, node.Id)
 	}
+	s.recordResponse(streamID, resp.GetTypeUrl(), resp.GetNonce(), resp.GetSystemVersionInfo())
+	if s.auditor != nil {
+		names := make([]string, 0, len(resp.GetResources()))
+		for _, resource := range resp.GetResources() {
+			names = append(names, resource.GetName())
+		}
+		s.audit(&ExchangeRecord{
+			Direction:            ExchangeDirectionResponse,
+			StreamID:             streamID,
+			Delta:                true,
+			NodeID:               s.streamNodeID(streamID),
+			TypeURL:              resp.GetTypeUrl(),
+			Version:              resp.GetSystemVersionInfo(),
+			Nonce:                resp.GetNonce(),
+			ResourceNames:        names,
+			RemovedResourceNames: resp.GetRemovedResources(),
+			Resources:            len(names),
+		})
+	}
 }
 
 func (s *snapshotCache) OnFetchRequest(_ context.Context, _ *discoveryv3.DiscoveryRequest) error {
@@ -0,0 +1,1799 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package cache
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+
+	adminv3 "github.com/envoyproxy/go-control-plane/envoy/admin/v3"
+	clusterv3 "github.com/envoyproxy/go-control-plane/envoy/config/cluster/v3"
+	corev3 "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	endpointv3 "github.com/envoyproxy/go-control-plane/envoy/config/endpoint/v3"
+	listenerv3 "github.com/envoyproxy/go-control-plane/envoy/config/listener/v3"
+	routev3 "github.com/envoyproxy/go-control-plane/envoy/config/route/v3"
+	hcmv3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/network/http_connection_manager/v3"
+	tlsv3 "github.com/envoyproxy/go-control-plane/envoy/extensions/transport_sockets/tls/v3"
+	discoveryv3 "github.com/envoyproxy/go-control-plane/envoy/service/discovery/v3"
+	typev3 "github.com/envoyproxy/go-control-plane/envoy/type/v3"
+	"github.com/envoyproxy/go-control-plane/pkg/cache/types"
+	cachev3 "github.com/envoyproxy/go-control-plane/pkg/cache/v3"
+	resourcev3 "github.com/envoyproxy/go-control-plane/pkg/resource/v3"
+	streamv3 "github.com/envoyproxy/go-control-plane/pkg/server/stream/v3"
+	"github.com/stretchr/testify/require"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protodelim"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/anypb"
+	"google.golang.org/protobuf/types/known/structpb"
+	"k8s.io/utils/ptr"
+
+	egv1a1 "github.com/envoyproxy/gateway/api/v1alpha1"
+	"github.com/envoyproxy/gateway/internal/logging"
+	xdstypes "github.com/envoyproxy/gateway/internal/xds/types"
+)
+
+func listeners(names ...string) xdstypes.XdsResources {
+	resources := make([]types.Resource, 0, len(names))
+	for _, name := range names {
+		resources = append(resources, &listenerv3.Listener{Name: name})
+	}
+	return xdstypes.XdsResources{resourcev3.ListenerType: resources}
+}
+
+func secrets(names ...string) xdstypes.XdsResources {
+	resources := make([]types.Resource, 0, len(names))
+	for _, name := range names {
+		resources = append(resources, &tlsv3.Secret{Name: name})
+	}
+	return xdstypes.XdsResources{resourcev3.SecretType: resources}
+}
+
+func TestListenerAcks(t *testing.T) {
+	const irKey = "default/gateway-1"
+
+	c := NewSnapshotCache(true, logging.DefaultLogger(egv1a1.LogLevelInfo))
+	pending := map[string][]string{}
+	c.SetListenerAckHandler(func(irKey string, pendingListeners []string) {
+		pending[irKey] = pendingListeners
+	})
+	node := &corev3.Node{Id: "envoy-1", Cluster: irKey}
+	request := func(version string, errorDetail *status.Status) {
+		require.NoError(t, c.OnStreamRequest(1, &discoveryv3.DiscoveryRequest{
+			Node:        node,
+			TypeUrl:     resourcev3.ListenerType,
+			VersionInfo: version,
+			ErrorDetail: errorDetail.Proto(),
+		}))
+	}
+
+	// The listeners are pending until a proxy is connected.
+	require.NoError(t, c.GenerateNewSnapshot(irKey, listeners("http")))
+	require.Equal(t, []string{"http"}, pending[irKey])
+
+	require.NoError(t, c.OnStreamOpen(context.Background(), 1, resourcev3.ListenerType))
+	request("", nil)
+	require.Equal(t, []string{"http"}, pending[irKey])
+
+	request("1", nil)
+	require.Empty(t, pending[irKey])
+
+	// Only the new listener is pending.
+	require.NoError(t, c.GenerateNewSnapshot(irKey, listeners("http", "https")))
+	require.Equal(t, []string{"https"}, pending[irKey])
+
+	// A rejected update doesn't acknowledge the new listener.
+	request("1", status.New(codes.InvalidArgument, "invalid listener"))
+	require.Equal(t, []string{"https"}, pending[irKey])
+
+	request("2", nil)
+	require.Empty(t, pending[irKey])
+
+	// The listeners are pending again once the proxy disconnects.
+	c.OnStreamClosed(1, node)
+	require.Equal(t, []string{"http", "https"}, pending[irKey])
+
+	require.NoError(t, c.GenerateNewSnapshot(irKey, nil))
+	require.Empty(t, pending[irKey])
+}
+
+func TestSecretAcks(t *testing.T) {
+	const irKey = "default/gateway-1"
+
+	c := NewSnapshotCache(true, logging.DefaultLogger(egv1a1.LogLevelInfo))
+	acked := map[string]string{}
+	c.SetSecretAckHandler(func(irKey string, version string) {
+		acked[irKey] = version
+	})
+	request := func(streamID int64, node *corev3.Node, version string) {
+		require.NoError(t, c.OnStreamRequest(streamID, &discoveryv3.DiscoveryRequest{
+			Node:        node,
+			TypeUrl:     resourcev3.SecretType,
+			VersionInfo: version,
+		}))
+	}
+
+	node1 := &corev3.Node{Id: "envoy-1", Cluster: irKey}
+	node2 := &corev3.Node{Id: "envoy-2", Cluster: irKey}
+	require.NoError(t, c.OnStreamOpen(context.Background(), 1, resourcev3.SecretType))
+	require.NoError(t, c.OnStreamOpen(context.Background(), 2, resourcev3.SecretType))
+	request(1, node1, "")
+	request(2, node2, "")
+
+	require.NoError(t, c.GenerateNewSnapshot(irKey, secrets("tls-secret")))
+	require.Empty(t, acked[irKey])
+
+	// The oldest version acknowledged by the proxies is notified.
+	request(1, node1, "1")
+	require.Empty(t, acked[irKey])
+	request(2, node2, "1")
+	require.Equal(t, "1", acked[irKey])
+
+	require.NoError(t, c.GenerateNewSnapshot(irKey, secrets("tls-secret")))
+	request(1, node1, "2")
+	require.Equal(t, "1", acked[irKey])
+
+	// The version is no longer held back once the lagging proxy disconnects.
+	c.OnStreamClosed(2, node2)
+	require.Equal(t, "2", acked[irKey])
+
+	// Without proxies, the last snapshot is acknowledged.
+	c.OnStreamClosed(1, node1)
+	require.NoError(t, c.GenerateNewSnapshot(irKey, secrets("tls-secret")))
+	require.Equal(t, "3", acked[irKey])
+}
+
+func TestDiffSnapshot(t *testing.T) {
+	previous, err := cachev3.NewSnapshot("1", xdstypes.XdsResources{
+		resourcev3.ListenerType: {
+			&listenerv3.Listener{Name: "http"},
+			&listenerv3.Listener{Name: "https", StatPrefix: "https"},
+			&listenerv3.Listener{Name: "tcp"},
+		},
+		resourcev3.SecretType: {&tlsv3.Secret{Name: "tls-secret"}},
+	})
+	require.NoError(t, err)
+
+	snapshot, err := cachev3.NewSnapshot("2", xdstypes.XdsResources{
+		resourcev3.ListenerType: {
+			&listenerv3.Listener{Name: "http"},
+			&listenerv3.Listener{Name: "https", StatPrefix: "https-v2"},
+			&listenerv3.Listener{Name: "udp"},
+		},
+		resourcev3.SecretType: {&tlsv3.Secret{Name: "tls-secret"}, &tlsv3.Secret{Name: "tls-secret-2"}},
+	})
+	require.NoError(t, err)
+	require.Equal(t, map[string]*ResourceDiff{
+		"Listener": {Added: []string{"udp"}, Removed: []string{"tcp"}, Changed: []string{"https"}},
+		"Secret":   {Added: []string{redactName("tls-secret-2")}},
+	}, diffSnapshots(previous, snapshot, maxDiffNames))
+
+	// All the resources are added to an irKey without snapshot, and removed
+	// when the irKey is deleted.
+	diffs := diffSnapshots(nil, snapshot, maxDiffNames)
+	require.Equal(t, &ResourceDiff{Added: []string{"http", "https", "udp"}}, diffs["Listener"])
+	require.ElementsMatch(t, []string{redactName("tls-secret"), redactName("tls-secret-2")}, diffs["Secret"].Added)
+	require.Equal(t, map[string]*ResourceDiff{
+		"Listener": {Removed: []string{"http", "https", "tcp"}},
+		"Secret":   {Removed: []string{redactName("tls-secret")}},
+	}, diffSnapshots(previous, nil, maxDiffNames))
+
+	// The names beyond maxDiffNames are only counted, unless the names are not limited.
+	names := make([]string, maxDiffNames+5)
+	for i := range names {
+		names[i] = fmt.Sprintf("listener-%02d", i)
+	}
+	many, err := cachev3.NewSnapshot("3", listeners(names...))
+	require.NoError(t, err)
+	diff := diffSnapshots(nil, many, maxDiffNames)["Listener"]
+	require.Len(t, diff.Added, maxDiffNames)
+	require.Equal(t, 5, diff.More)
+	diff = diffSnapshots(nil, many, 0)["Listener"]
+	require.Len(t, diff.Added, maxDiffNames+5)
+	require.Zero(t, diff.More)
+}
+
+func TestDiffSnapshots(t *testing.T) {
+	c := NewSnapshotCache(true, logging.DefaultLogger(egv1a1.LogLevelInfo))
+	irKey := "envoy-gateway/eg"
+
+	_, err := c.DiffSnapshots(irKey, "", "")
+	require.ErrorIs(t, err, ErrSnapshotNotFound)
+
+	require.NoError(t, c.GenerateNewSnapshot(irKey, listeners("http")))
+	require.NoError(t, c.GenerateNewSnapshot(irKey, listeners("http", "https")))
+	require.NoError(t, c.GenerateNewSnapshot(irKey, listeners("https")))
+
+	// The last snapshot is diffed against the previous one by default.
+	diff, err := c.DiffSnapshots(irKey, "", "")
+	require.NoError(t, err)
+	require.Equal(t, &SnapshotDiff{
+		IRKey:     irKey,
+		From:      "2",
+		To:        "3",
+		Resources: map[string]*ResourceDiff{"Listener": {Removed: []string{"http"}}},
+	}, diff)
+
+	diff, err = c.DiffSnapshots(irKey, "1", "3")
+	require.NoError(t, err)
+	require.Equal(t, map[string]*ResourceDiff{"Listener": {Added: []string{"https"}, Removed: []string{"http"}}}, diff.Resources)
+
+	// The first snapshot is diffed against no snapshot.
+	diff, err = c.DiffSnapshots(irKey, "", "1")
+	require.NoError(t, err)
+	require.Empty(t, diff.From)
+	require.Equal(t, map[string]*ResourceDiff{"Listener": {Added: []string{"http"}}}, diff.Resources)
+
+	// Only the recent snapshots are retained.
+	for i := 0; i < defaultHistorySize; i++ {
+		require.NoError(t, c.GenerateNewSnapshot(irKey, listeners("https")))
+	}
+	_, err = c.DiffSnapshots(irKey, "1", "")
+	require.ErrorIs(t, err, ErrSnapshotNotFound)
+	diff, err = c.DiffSnapshots(irKey, "", "")
+	require.NoError(t, err)
+	require.Empty(t, diff.Resources)
+
+	// The resources of a deleted irKey are removed.
+	require.NoError(t, c.GenerateNewSnapshot(irKey, nil))
+	diff, err = c.DiffSnapshots(irKey, "", "")
+	require.NoError(t, err)
+	require.Equal(t, "14", diff.To)
+	require.Equal(t, map[string]*ResourceDiff{"Listener": {Removed: []string{"https"}}}, diff.Resources)
+}
+
+func TestSnapshotHistory(t *testing.T) {
+	c := NewSnapshotCache(true, logging.DefaultLogger(egv1a1.LogLevelInfo))
+	irKey := "envoy-gateway/eg"
+	c.SetHistorySize(2)
+
+	require.Empty(t, c.GetSnapshotHistory(irKey))
+
+	require.NoError(t, c.GenerateNewSnapshot(irKey, listeners("http")))
+	require.NoError(t, c.GenerateNewSnapshot(irKey, listeners("http", "https")))
+	require.NoError(t, c.GenerateNewSnapshot(irKey, listeners("https")))
+
+	// The oldest snapshot is dropped, and the history is listed newest first.
+	history := c.GetSnapshotHistory(irKey)
+	require.Len(t, history, 2)
+	require.Equal(t, "3", history[0].Version)
+	require.Equal(t, map[string]int{resourcev3.ListenerType: 1}, history[0].Resources)
+	require.Equal(t, "2", history[1].Version)
+	require.Equal(t, map[string]int{resourcev3.ListenerType: 2}, history[1].Resources)
+	require.False(t, history[0].Time.Before(history[1].Time))
+	require.Len(t, history[1].Snapshot.GetResources(resourcev3.ListenerType), 2)
+
+	// Shrinking the history drops the oldest snapshots.
+	c.SetHistorySize(1)
+	history = c.GetSnapshotHistory(irKey)
+	require.Len(t, history, 1)
+	require.Equal(t, "3", history[0].Version)
+}
+
+func TestSnapshotEviction(t *testing.T) {
+	type eviction struct {
+		irKey   string
+		restore bool
+	}
+	evictions := make(chan eviction, 10)
+
+	c := NewSnapshotCache(true, logging.DefaultLogger(egv1a1.LogLevelInfo))
+	size := int64(proto.Size(&listenerv3.Listener{Name: "http"}))
+	c.SetMemoryLimit(2*size, func(irKey string, restore bool) {
+		evictions <- eviction{irKey: irKey, restore: restore}
+	})
+
+	// The node of gateway-1 is connected, so its snapshot is never evicted.
+	node := &corev3.Node{Id: "envoy-1", Cluster: "default/gateway-1"}
+	require.NoError(t, c.OnStreamOpen(context.Background(), 1, resourcev3.ListenerType))
+	require.NoError(t, c.OnStreamRequest(1, &discoveryv3.DiscoveryRequest{Node: node, TypeUrl: resourcev3.ListenerType}))
+
+	require.NoError(t, c.GenerateNewSnapshot("default/gateway-1", listeners("http")))
+	require.NoError(t, c.GenerateNewSnapshot("default/gateway-2", listeners("http")))
+	require.NoError(t, c.GenerateNewSnapshot("default/gateway-3", listeners("http")))
+	require.Equal(t, eviction{irKey: "default/gateway-2"}, <-evictions)
+	require.Equal(t, []string{"default/gateway-1", "default/gateway-3"}, c.IRKeys())
+	require.Empty(t, c.GetSnapshotHistory("default/gateway-2"))
+
+	// A node requesting the evicted snapshot has it generated again.
+	node2 := &corev3.Node{Id: "envoy-2", Cluster: "default/gateway-2"}
+	require.NoError(t, c.OnStreamOpen(context.Background(), 2, resourcev3.ListenerType))
+	require.NoError(t, c.OnStreamRequest(2, &discoveryv3.DiscoveryRequest{Node: node2, TypeUrl: resourcev3.ListenerType}))
+	require.Equal(t, eviction{irKey: "default/gateway-2", restore: true}, <-evictions)
+
+	// Restoring the snapshot evicts the least recently used snapshot of the irKeys
+	// without connected nodes.
+	require.NoError(t, c.GenerateNewSnapshot("default/gateway-2", listeners("http")))
+	require.Equal(t, eviction{irKey: "default/gateway-3"}, <-evictions)
+	require.Equal(t, []string{"default/gateway-1", "default/gateway-2"}, c.IRKeys())
+
+	// A deleted irKey is not restored.
+	require.NoError(t, c.GenerateNewSnapshot("default/gateway-3", nil))
+	require.NoError(t, c.OnStreamOpen(context.Background(), 3, resourcev3.ListenerType))
+	require.NoError(t, c.OnStreamRequest(3, &discoveryv3.DiscoveryRequest{
+		Node:    &corev3.Node{Id: "envoy-3", Cluster: "default/gateway-3"},
+		TypeUrl: resourcev3.ListenerType,
+	}))
+	require.Empty(t, evictions)
+}
+
+func TestSnapshotDedup(t *testing.T) {
+	c := NewSnapshotCache(true, logging.DefaultLogger(egv1a1.LogLevelInfo)).(*snapshotCache)
+	c.SetHistorySize(2)
+	listener := func(irKey, name string) types.Resource {
+		return c.lastSnapshot[irKey].GetResources(resourcev3.ListenerType)[name]
+	}
+
+	// The identical resources of the snapshots of several irKeys are shared.
+	require.NoError(t, c.GenerateNewSnapshot("default/gateway-1", listeners("http", "https")))
+	require.NoError(t, c.GenerateNewSnapshot("default/gateway-2", listeners("http")))
+	require.Same(t, listener("default/gateway-1", "http"), listener("default/gateway-2", "http"))
+	require.Len(t, c.pool.entries, 2)
+	require.InDelta(t, 1.5, c.pool.dedupRatio(), 0.001)
+
+	// The versions of the resources are their hash.
+	marshaled, err := cachev3.MarshalResource(&listenerv3.Listener{Name: "http"})
+	require.NoError(t, err)
+	require.Equal(t, cachev3.HashResource(marshaled), c.lastSnapshot["default/gateway-2"].VersionMap[resourcev3.ListenerType]["http"])
+
+	// The resources are shared with the previous snapshots of the history, and dropped once
+	// no retained snapshot holds them.
+	require.NoError(t, c.GenerateNewSnapshot("default/gateway-1", listeners("http", "admin")))
+	require.Len(t, c.pool.entries, 3)
+	require.NoError(t, c.GenerateNewSnapshot("default/gateway-1", listeners("http", "admin")))
+	require.Len(t, c.pool.entries, 2)
+	require.Same(t, listener("default/gateway-1", "http"), listener("default/gateway-2", "http"))
+	require.InDelta(t, 2.5, c.pool.dedupRatio(), 0.001)
+
+	// Shrinking the history releases the resources of the dropped snapshots.
+	c.SetHistorySize(1)
+	require.Len(t, c.pool.entries, 2)
+	require.InDelta(t, 1.5, c.pool.dedupRatio(), 0.001)
+}
+
+func TestSnapshotPersistence(t *testing.T) {
+	dir := t.TempDir()
+
+	c := NewSnapshotCache(true, logging.DefaultLogger(egv1a1.LogLevelInfo))
+	require.NoError(t, c.SetPersistenceDir(dir))
+	require.NoError(t, c.GenerateNewSnapshot("default/gateway-1", listeners("http")))
+	resources := listeners("http", "https")
+	resources[resourcev3.ClusterType] = []types.Resource{&clusterv3.Cluster{Name: "backend"}}
+	// The secrets are not written to the disk.
+	resources[resourcev3.SecretType] = secrets("tls")[resourcev3.SecretType]
+	scopes := []xdstypes.NodeScope{{
+		Name:          "edge",
+		NodeMetadata:  map[string]string{"zone": "edge"},
+		ResourceNames: map[resourcev3.Type][]string{resourcev3.ListenerType: {"https"}},
+	}}
+	require.NoError(t, c.GenerateNewScopedSnapshot("default/gateway-1", resources, scopes))
+	// The file of a deleted irKey is removed.
+	require.NoError(t, c.GenerateNewSnapshot("default/gateway-2", listeners("http")))
+	require.NoError(t, c.GenerateNewSnapshot("default/gateway-2", nil))
+
+	// The snapshots are written in the background.
+	c.(*snapshotCache).waitPersisted()
+	persisted, err := os.ReadFile(filepath.Join(dir, "default%2Fgateway-1.pb"))
+	require.NoError(t, err)
+	require.NotContains(t, string(persisted), resourcev3.SecretType)
+
+	// A corrupted file is skipped.
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "corrupted.pb"), []byte("corrupted"), 0o600))
+
+	// The last snapshot of each irKey is restored with its version and node scopes.
+	restored := NewSnapshotCache(true, logging.DefaultLogger(egv1a1.LogLevelInfo))
+	require.NoError(t, restored.SetPersistenceDir(dir))
+	require.Equal(t, []string{"default/gateway-1"}, restored.IRKeys())
+	info, ok := restored.GetSnapshotInfo("default/gateway-1")
+	require.True(t, ok)
+	require.Equal(t, "2", info.Version)
+	require.Equal(t, map[string]int{resourcev3.ListenerType: 2, resourcev3.ClusterType: 1}, info.Resources)
+
+	// A reconnecting node is served the restored snapshot of its scope.
+	node := &corev3.Node{Id: "envoy-edge", Cluster: "default/gateway-1", Metadata: &structpb.Struct{
+		Fields: map[string]*structpb.Value{"zone": structpb.NewStringValue("edge")},
+	}}
+	require.NoError(t, restored.OnStreamOpen(context.Background(), 1, resourcev3.ListenerType))
+	require.NoError(t, restored.OnStreamRequest(1, &discoveryv3.DiscoveryRequest{Node: node, TypeUrl: resourcev3.ListenerType}))
+	snapshot, err := restored.GetSnapshot("envoy-edge")
+	require.NoError(t, err)
+	require.Equal(t, "2", snapshot.GetVersion(resourcev3.ListenerType))
+	require.Empty(t, snapshot.GetResources(resourcev3.SecretType))
+	require.Len(t, snapshot.GetResources(resourcev3.ListenerType), 1)
+	require.Contains(t, snapshot.GetResources(resourcev3.ListenerType), "https")
+
+	// The snapshots generated after the restore are newer than the restored ones.
+	require.NoError(t, restored.GenerateNewSnapshot("default/gateway-1", listeners("http")))
+	info, ok = restored.GetSnapshotInfo("default/gateway-1")
+	require.True(t, ok)
+	require.Equal(t, "3", info.Version)
+	restored.(*snapshotCache).waitPersisted()
+}
+
+func TestSnapshotPersistenceFormats(t *testing.T) {
+	readHeader := func(path string) persistedSnapshotHeader {
+		f, err := os.Open(path)
+		require.NoError(t, err)
+		defer f.Close()
+		header, err := readSnapshotHeader(bufio.NewReader(f))
+		require.NoError(t, err)
+		return header
+	}
+
+	// A legacy snapshot is restored, and persisted again in the current format.
+	dir := t.TempDir()
+	legacy := filepath.Join(dir, "default%2Fgateway-1.pb")
+	require.NoError(t, writeSnapshot(legacy, persistedSnapshotHeader{IRKey: "default/gateway-1", Version: "5"}, listeners("http")))
+	require.Zero(t, readHeader(legacy).Format)
+	c := NewSnapshotCache(true, logging.DefaultLogger(egv1a1.LogLevelInfo))
+	require.NoError(t, c.SetPersistenceDir(dir))
+	require.Equal(t, []string{"default/gateway-1"}, c.IRKeys())
+	require.Equal(t, currentSnapshotFormat, readHeader(legacy).Format)
+
+	// The secrets persisted by the older versions are removed from the disk once restored.
+	dir = t.TempDir()
+	withSecrets := filepath.Join(dir, "default%2Fgateway-1.pb")
+	var buf bytes.Buffer
+	require.NoError(t, marshalSnapshot(&buf, persistedSnapshotHeader{
+		Format: currentSnapshotFormat, IRKey: "default/gateway-1", Version: "5",
+	}, listeners("http")))
+	secret, err := anypb.New(&tlsv3.Secret{Name: "tls"})
+	require.NoError(t, err)
+	_, err = protodelim.MarshalTo(&buf, &discoveryv3.DiscoveryResponse{TypeUrl: resourcev3.SecretType, Resources: []*anypb.Any{secret}})
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(withSecrets, buf.Bytes(), 0o600))
+	c = NewSnapshotCache(true, logging.DefaultLogger(egv1a1.LogLevelInfo))
+	require.NoError(t, c.SetPersistenceDir(dir))
+	info, ok := c.GetSnapshotInfo("default/gateway-1")
+	require.True(t, ok)
+	require.NotContains(t, info.Resources, resourcev3.SecretType)
+	persisted, err := os.ReadFile(withSecrets)
+	require.NoError(t, err)
+	require.NotContains(t, string(persisted), resourcev3.SecretType)
+
+	// The restore is refused after a downgrade.
+	dir = t.TempDir()
+	require.NoError(t, writeSnapshot(filepath.Join(dir, "default%2Fgateway-1.pb"), persistedSnapshotHeader{
+		Format: currentSnapshotFormat + 1, IRKey: "default/gateway-1", Version: "5",
+	}, listeners("http")))
+	c = NewSnapshotCache(true, logging.DefaultLogger(egv1a1.LogLevelInfo))
+	require.ErrorIs(t, c.SetPersistenceDir(dir), ErrNewerSnapshotFormat)
+}
+
+func TestSnapshotPersistenceSecrets(t *testing.T) {
+	const irKey = "default/gateway-1"
+
+	ads := &corev3.ConfigSource{ConfigSourceSpecifier: &corev3.ConfigSource_Ads{Ads: &corev3.AggregatedConfigSource{}}}
+	listener := func(name string, tls bool) *listenerv3.Listener {
+		hcm, err := anypb.New(&hcmv3.HttpConnectionManager{
+			RouteSpecifier: &hcmv3.HttpConnectionManager_Rds{Rds: &hcmv3.Rds{RouteConfigName: name}},
+		})
+		require.NoError(t, err)
+		chain := &listenerv3.FilterChain{
+			Filters: []*listenerv3.Filter{{Name: "hcm", ConfigType: &listenerv3.Filter_TypedConfig{TypedConfig: hcm}}},
+		}
+		if tls {
+			tlsCtx, err := anypb.New(&tlsv3.DownstreamTlsContext{CommonTlsContext: &tlsv3.CommonTlsContext{
+				TlsCertificateSdsSecretConfigs: []*tlsv3.SdsSecretConfig{{Name: "tls", SdsConfig: ads}},
+			}})
+			require.NoError(t, err)
+			chain.TransportSocket = &corev3.TransportSocket{Name: "tls", ConfigType: &corev3.TransportSocket_TypedConfig{TypedConfig: tlsCtx}}
+		}
+		return &listenerv3.Listener{Name: name, FilterChains: []*listenerv3.FilterChain{chain}}
+	}
+	persist := func(resources xdstypes.XdsResources) string {
+		dir := t.TempDir()
+		require.NoError(t, writeSnapshot(filepath.Join(dir, "default%2Fgateway-1.pb"), persistedSnapshotHeader{
+			Format: currentSnapshotFormat, IRKey: irKey, Version: "5",
+		}, resources))
+		return dir
+	}
+
+	// The listeners terminating TLS, whose secrets are not persisted, are not restored, nor
+	// are the route configurations only they referenced.
+	dir := persist(xdstypes.XdsResources{
+		resourcev3.ListenerType: []types.Resource{listener("http", false), listener("https", true)},
+		resourcev3.RouteType:    []types.Resource{&routev3.RouteConfiguration{Name: "http"}, &routev3.RouteConfiguration{Name: "https"}},
+	})
+	c := NewSnapshotCache(true, logging.DefaultLogger(egv1a1.LogLevelInfo))
+	require.NoError(t, c.SetPersistenceDir(dir))
+	info, ok := c.GetSnapshotInfo(irKey)
+	require.True(t, ok)
+	require.Equal(t, map[string]int{resourcev3.ListenerType: 1, resourcev3.RouteType: 1}, info.Resources)
+	node := &corev3.Node{Id: "envoy", Cluster: irKey}
+	require.NoError(t, c.OnStreamOpen(context.Background(), 1, resourcev3.ListenerType))
+	require.NoError(t, c.OnStreamRequest(1, &discoveryv3.DiscoveryRequest{Node: node, TypeUrl: resourcev3.ListenerType}))
+	snapshot, err := c.GetSnapshot("envoy")
+	require.NoError(t, err)
+	require.Contains(t, snapshot.GetResources(resourcev3.ListenerType), "http")
+	require.Contains(t, snapshot.GetResources(resourcev3.RouteType), "http")
+
+	// The snapshots referencing other missing resources, such as the secrets of their
+	// clusters, are not restored.
+	tlsCtx, err := anypb.New(&tlsv3.UpstreamTlsContext{CommonTlsContext: &tlsv3.CommonTlsContext{
+		ValidationContextType: &tlsv3.CommonTlsContext_ValidationContextSdsSecretConfig{
+			ValidationContextSdsSecretConfig: &tlsv3.SdsSecretConfig{Name: "ca", SdsConfig: ads},
+		},
+	}})
+	require.NoError(t, err)
+	dir = persist(xdstypes.XdsResources{
+		resourcev3.ClusterType: []types.Resource{&clusterv3.Cluster{
+			Name:            "backend",
+			TransportSocket: &corev3.TransportSocket{Name: "tls", ConfigType: &corev3.TransportSocket_TypedConfig{TypedConfig: tlsCtx}},
+		}},
+	})
+	c = NewSnapshotCache(true, logging.DefaultLogger(egv1a1.LogLevelInfo))
+	require.NoError(t, c.SetPersistenceDir(dir))
+	require.Empty(t, c.IRKeys())
+}
+
+func TestUpdateEndpoints(t *testing.T) {
+	const irKey = "default/gateway-1"
+	cla := func(cluster string, hosts ...string) *endpointv3.ClusterLoadAssignment {
+		endpoints := &endpointv3.ClusterLoadAssignment{ClusterName: cluster}
+		for _, host := range hosts {
+			endpoints.Endpoints = append(endpoints.Endpoints, &endpointv3.LocalityLbEndpoints{
+				LbEndpoints: []*endpointv3.LbEndpoint{{
+					HostIdentifier: &endpointv3.LbEndpoint_Endpoint{Endpoint: &endpointv3.Endpoint{
+						Address: &corev3.Address{Address: &corev3.Address_SocketAddress{SocketAddress: &corev3.SocketAddress{Address: host}}},
+					}},
+				}},
+			})
+		}
+		return endpoints
+	}
+
+	c := NewSnapshotCache(true, logging.DefaultLogger(egv1a1.LogLevelInfo))
+	require.ErrorIs(t, c.UpdateEndpoints(irKey, "backend", cla("backend", "10.0.0.1")), ErrSnapshotNotFound)
+
+	node := &corev3.Node{Id: "envoy", Cluster: irKey}
+	require.NoError(t, c.OnStreamOpen(context.Background(), 1, resourcev3.EndpointType))
+	require.NoError(t, c.OnStreamRequest(1, &discoveryv3.DiscoveryRequest{Node: node, TypeUrl: resourcev3.EndpointType}))
+
+	resources := listeners("http")
+	resources[resourcev3.ClusterType] = []types.Resource{&clusterv3.Cluster{Name: "backend"}, &clusterv3.Cluster{Name: "other"}}
+	resources[resourcev3.EndpointType] = []types.Resource{cla("backend", "10.0.0.1"), cla("other", "10.0.1.1")}
+	require.NoError(t, c.GenerateNewSnapshot(irKey, resources))
+
+	// Only the endpoints of the cluster, and their version, are updated.
+	require.Error(t, c.UpdateEndpoints(irKey, "backend", cla("other", "10.0.0.2")))
+	require.NoError(t, c.UpdateEndpoints(irKey, "backend", cla("backend", "10.0.0.2")))
+	snapshot, err := c.GetSnapshot("envoy")
+	require.NoError(t, err)
+	require.Equal(t, "2", snapshot.GetVersion(resourcev3.EndpointType))
+	require.Equal(t, "1", snapshot.GetVersion(resourcev3.ListenerType))
+	require.Equal(t, "1", snapshot.GetVersion(resourcev3.ClusterType))
+	endpoints := snapshot.GetResources(resourcev3.EndpointType)
+	require.Len(t, endpoints, 2)
+	require.True(t, proto.Equal(cla("backend", "10.0.0.2"), endpoints["backend"]))
+	require.True(t, proto.Equal(cla("other", "10.0.1.1"), endpoints["other"]))
+
+	// The update is recorded in the history, and diffed with the previous snapshot.
+	diff, err := c.DiffSnapshots(irKey, "", "")
+	require.NoError(t, err)
+	require.Equal(t, []string{"backend"}, diff.Resources["ClusterLoadAssignment"].Changed)
+	require.Len(t, diff.Resources, 1)
+
+	// Removing the endpoints of a cluster.
+	require.NoError(t, c.UpdateEndpoints(irKey, "other", nil))
+	snapshot, err = c.GetSnapshot("envoy")
+	require.NoError(t, err)
+	require.Equal(t, "3", snapshot.GetVersion(resourcev3.EndpointType))
+	require.NotContains(t, snapshot.GetResources(resourcev3.EndpointType), "other")
+}
+
+func TestUpdateResources(t *testing.T) {
+	const irKey = "default/gateway-1"
+	route := func(name, cluster string) *routev3.RouteConfiguration {
+		return &routev3.RouteConfiguration{Name: name, VirtualHosts: []*routev3.VirtualHost{{
+			Name:    name,
+			Domains: []string{"*"},
+			Routes: []*routev3.Route{{
+				Match:  &routev3.RouteMatch{PathSpecifier: &routev3.RouteMatch_Prefix{Prefix: "/"}},
+				Action: &routev3.Route_Route{Route: &routev3.RouteAction{ClusterSpecifier: &routev3.RouteAction_Cluster{Cluster: cluster}}},
+			}},
+		}}}
+	}
+
+	c := NewSnapshotCache(true, logging.DefaultLogger(egv1a1.LogLevelInfo), WithConsistencyCheck())
+	require.ErrorIs(t, c.UpdateResources(irKey, nil), ErrSnapshotNotFound)
+
+	node := &corev3.Node{Id: "envoy", Cluster: irKey}
+	require.NoError(t, c.OnStreamOpen(context.Background(), 1, resourcev3.RouteType))
+	require.NoError(t, c.OnStreamRequest(1, &discoveryv3.DiscoveryRequest{Node: node, TypeUrl: resourcev3.RouteType}))
+
+	hcm, err := anypb.New(&hcmv3.HttpConnectionManager{
+		RouteSpecifier: &hcmv3.HttpConnectionManager_Rds{Rds: &hcmv3.Rds{RouteConfigName: "http"}},
+	})
+	require.NoError(t, err)
+	resources := xdstypes.XdsResources{
+		resourcev3.ListenerType: []types.Resource{&listenerv3.Listener{Name: "http", FilterChains: []*listenerv3.FilterChain{{
+			Filters: []*listenerv3.Filter{{Name: "hcm", ConfigType: &listenerv3.Filter_TypedConfig{TypedConfig: hcm}}},
+		}}}},
+	}
+	resources[resourcev3.RouteType] = []types.Resource{route("http", "backend")}
+	resources[resourcev3.ClusterType] = []types.Resource{&clusterv3.Cluster{Name: "backend"}}
+	require.NoError(t, c.GenerateNewSnapshot(irKey, resources))
+
+	// Only the updated resources, and the versions of their types, are updated.
+	require.NoError(t, c.UpdateResources(irKey, map[resourcev3.Type]map[string]types.Resource{
+		resourcev3.RouteType:   {"http": route("http", "other")},
+		resourcev3.ClusterType: {"backend": nil, "other": &clusterv3.Cluster{Name: "other"}},
+	}))
+	snapshot, err := c.GetSnapshot("envoy")
+	require.NoError(t, err)
+	require.Equal(t, "1", snapshot.GetVersion(resourcev3.ListenerType))
+	require.Equal(t, "2", snapshot.GetVersion(resourcev3.RouteType))
+	require.Equal(t, "2", snapshot.GetVersion(resourcev3.ClusterType))
+	require.True(t, proto.Equal(route("http", "other"), snapshot.GetResources(resourcev3.RouteType)["http"]))
+	require.Equal(t, []string{"other"}, slices.Collect(maps.Keys(snapshot.GetResources(resourcev3.ClusterType))))
+
+	// The update is recorded in the history, and diffed with the previous snapshot.
+	diff, err := c.DiffSnapshots(irKey, "", "")
+	require.NoError(t, err)
+	require.Equal(t, []string{"http"}, diff.Resources["RouteConfiguration"].Changed)
+	require.Equal(t, []string{"other"}, diff.Resources["Cluster"].Added)
+	require.Equal(t, []string{"backend"}, diff.Resources["Cluster"].Removed)
+
+	// The inconsistent updates, the updates of the listeners, and the misnamed resources
+	// are refused.
+	var inconsistent *InconsistentSnapshotError
+	require.ErrorAs(t, c.UpdateResources(irKey, map[resourcev3.Type]map[string]types.Resource{
+		resourcev3.ClusterType: {"other": nil},
+	}), &inconsistent)
+	require.Error(t, c.UpdateResources(irKey, map[resourcev3.Type]map[string]types.Resource{
+		resourcev3.ListenerType: {"http": nil},
+	}))
+	require.Error(t, c.UpdateResources(irKey, map[resourcev3.Type]map[string]types.Resource{
+		resourcev3.ClusterType: {"backend": &clusterv3.Cluster{Name: "other"}},
+	}))
+	snapshot, err = c.GetSnapshot("envoy")
+	require.NoError(t, err)
+	require.Equal(t, "2", snapshot.GetVersion(resourcev3.ClusterType))
+
+	// The snapshots with node scopes only have their endpoints updated in place.
+	require.NoError(t, c.GenerateNewScopedSnapshot(irKey, resources, []xdstypes.NodeScope{{Name: "edge"}}))
+	require.ErrorIs(t, c.UpdateResources(irKey, map[resourcev3.Type]map[string]types.Resource{
+		resourcev3.ClusterType: {"backend": &clusterv3.Cluster{Name: "backend"}},
+	}), ErrScopedSnapshot)
+	require.NoError(t, c.UpdateResources(irKey, nil))
+}
+
+func TestResourceTTLs(t *testing.T) {
+	const irKey = "default/gateway-1"
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	c := NewSnapshotCache(true, logging.DefaultLogger(egv1a1.LogLevelInfo),
+		WithResourceTTLs(ctx, map[resourcev3.Type]time.Duration{resourcev3.EndpointType: 30 * time.Second}, 10*time.Second))
+
+	node := &corev3.Node{Id: "envoy", Cluster: irKey}
+	require.NoError(t, c.OnStreamOpen(context.Background(), 1, resourcev3.EndpointType))
+	require.NoError(t, c.OnStreamRequest(1, &discoveryv3.DiscoveryRequest{Node: node, TypeUrl: resourcev3.EndpointType}))
+
+	resources := listeners("http")
+	resources[resourcev3.EndpointType] = []types.Resource{&endpointv3.ClusterLoadAssignment{ClusterName: "backend"}}
+	require.NoError(t, c.GenerateNewSnapshot(irKey, resources))
+
+	// Only the resources of the types with a TTL are served with it.
+	snapshot, err := c.GetSnapshot("envoy")
+	require.NoError(t, err)
+	endpoints := snapshot.(*cachev3.Snapshot).GetResourcesAndTTL(resourcev3.EndpointType)
+	require.Equal(t, ptr.To(30*time.Second), endpoints["backend"].TTL)
+	listenerResources := snapshot.(*cachev3.Snapshot).GetResourcesAndTTL(resourcev3.ListenerType)
+	require.Nil(t, listenerResources["http"].TTL)
+	require.Equal(t, "1", snapshot.GetVersion(resourcev3.EndpointType))
+}
+
+func TestNodeGroups(t *testing.T) {
+	const irKey = "default/gateway-1"
+
+	groupRoute := func(name string, groups ...string) *routev3.Route {
+		route := &routev3.Route{Name: name}
+		if len(groups) > 0 {
+			values := make([]any, 0, len(groups))
+			for _, group := range groups {
+				values = append(values, group)
+			}
+			list, err := structpb.NewList(values)
+			require.NoError(t, err)
+			route.Metadata = &corev3.Metadata{FilterMetadata: map[string]*structpb.Struct{
+				xdstypes.XdsMetadataNamespace: {Fields: map[string]*structpb.Value{
+					xdstypes.RouteNodeGroupsMetadataKey: structpb.NewListValue(list),
+				}},
+			}}
+		}
+		return route
+	}
+	node := func(id, group string) *corev3.Node {
+		n := &corev3.Node{Id: id, Cluster: irKey}
+		if group != "" {
+			n.Metadata = &structpb.Struct{Fields: map[string]*structpb.Value{
+				xdstypes.NodeGroupMetadataKey: structpb.NewStringValue(group),
+			}}
+		}
+		return n
+	}
+	servedRoutes := func(c SnapshotCacheWithCallbacks, nodeID string) []string {
+		snapshot, err := c.GetSnapshot(nodeID)
+		require.NoError(t, err)
+		var names []string
+		for _, r := range snapshot.GetResources(resourcev3.RouteType) {
+			for _, vhost := range r.(*routev3.RouteConfiguration).VirtualHosts {
+				for _, route := range vhost.Routes {
+					names = append(names, route.Name)
+				}
+			}
+		}
+		return names
+	}
+
+	c := NewSnapshotCache(true, logging.DefaultLogger(egv1a1.LogLevelInfo))
+	nodes := []*corev3.Node{node("envoy-public", "public"), node("envoy-partner", "partner"), node("envoy", "")}
+	for i, n := range nodes {
+		streamID := int64(i + 1)
+		require.NoError(t, c.OnStreamOpen(context.Background(), streamID, resourcev3.RouteType))
+		require.NoError(t, c.OnStreamRequest(streamID, &discoveryv3.DiscoveryRequest{Node: n, TypeUrl: resourcev3.RouteType}))
+	}
+
+	require.NoError(t, c.GenerateNewSnapshot(irKey, xdstypes.XdsResources{
+		resourcev3.RouteType: []types.Resource{&routev3.RouteConfiguration{
+			Name: "http",
+			VirtualHosts: []*routev3.VirtualHost{{
+				Name: "http/*",
+				Routes: []*routev3.Route{
+					groupRoute("all"),
+					groupRoute("partner", "partner"),
+					groupRoute("public", "public", "internal"),
+				},
+			}},
+		}},
+	}))
+	require.Equal(t, []string{"all", "public"}, servedRoutes(c, "envoy-public"))
+	require.Equal(t, []string{"all", "partner"}, servedRoutes(c, "envoy-partner"))
+	require.Equal(t, []string{"all"}, servedRoutes(c, "envoy"))
+
+	// The snapshots of the node groups keep the version of the irKey.
+	snapshot, err := c.GetSnapshot("envoy-partner")
+	require.NoError(t, err)
+	require.Equal(t, "1", snapshot.GetVersion(resourcev3.RouteType))
+
+	// Without routes of node groups, all the nodes are served the same snapshot.
+	require.NoError(t, c.GenerateNewSnapshot(irKey, listeners("http")))
+	public, err := c.GetSnapshot("envoy-public")
+	require.NoError(t, err)
+	partner, err := c.GetSnapshot("envoy-partner")
+	require.NoError(t, err)
+	require.Same(t, public, partner)
+}
+
+func TestNodeScopes(t *testing.T) {
+	const irKey = "default/gateway-1"
+
+	node := func(id, shard string) *corev3.Node {
+		n := &corev3.Node{Id: id, Cluster: irKey}
+		if shard != "" {
+			n.Metadata = &structpb.Struct{Fields: map[string]*structpb.Value{
+				"shard": structpb.NewStringValue(shard),
+			}}
+		}
+		return n
+	}
+	servedListeners := func(c SnapshotCacheWithCallbacks, nodeID string) []string {
+		snapshot, err := c.GetSnapshot(nodeID)
+		require.NoError(t, err)
+		var names []string
+		for name := range snapshot.GetResources(resourcev3.ListenerType) {
+			names = append(names, name)
+		}
+		slices.Sort(names)
+		return names
+	}
+
+	c := NewSnapshotCache(true, logging.DefaultLogger(egv1a1.LogLevelInfo))
+	pending := map[string][]string{}
+	c.SetListenerAckHandler(func(irKey string, pendingListeners []string) {
+		pending[irKey] = pendingListeners
+	})
+	nodes := []*corev3.Node{node("envoy-a", "a"), node("envoy-b", "b"), node("envoy", "")}
+	for i, n := range nodes {
+		streamID := int64(i + 1)
+		require.NoError(t, c.OnStreamOpen(context.Background(), streamID, resourcev3.ListenerType))
+		require.NoError(t, c.OnStreamRequest(streamID, &discoveryv3.DiscoveryRequest{Node: n, TypeUrl: resourcev3.ListenerType}))
+	}
+
+	resources := listeners("http", "https", "tcp")
+	resources[resourcev3.ClusterType] = []types.Resource{&clusterv3.Cluster{Name: "backend"}}
+	scopes := []xdstypes.NodeScope{
+		{
+			Name:          "a",
+			NodeMetadata:  map[string]string{"shard": "a"},
+			ResourceNames: map[resourcev3.Type][]string{resourcev3.ListenerType: {"http"}},
+		},
+		{
+			Name:          "b",
+			NodeMetadata:  map[string]string{"shard": "b"},
+			ResourceNames: map[resourcev3.Type][]string{resourcev3.ListenerType: {"https"}},
+		},
+	}
+	require.NoError(t, c.GenerateNewScopedSnapshot(irKey, resources, scopes))
+	require.Equal(t, []string{"http"}, servedListeners(c, "envoy-a"))
+	require.Equal(t, []string{"https"}, servedListeners(c, "envoy-b"))
+	require.Equal(t, []string{"http", "https", "tcp"}, servedListeners(c, "envoy"))
+
+	// The types not scoped are served entirely, with the version of the irKey.
+	snapshot, err := c.GetSnapshot("envoy-a")
+	require.NoError(t, err)
+	require.Contains(t, snapshot.GetResources(resourcev3.ClusterType), "backend")
+	require.Equal(t, "1", snapshot.GetVersion(resourcev3.ListenerType))
+
+	// A listener is acknowledged once all the nodes it is served to acknowledge it.
+	ack := func(streamID int64, n *corev3.Node) {
+		require.NoError(t, c.OnStreamRequest(streamID, &discoveryv3.DiscoveryRequest{
+			Node: n, TypeUrl: resourcev3.ListenerType, VersionInfo: "1",
+		}))
+	}
+	ack(1, nodes[0])
+	require.Equal(t, []string{"http", "https", "tcp"}, pending[irKey])
+	ack(3, nodes[2])
+	require.Equal(t, []string{"https"}, pending[irKey])
+	ack(2, nodes[1])
+	require.Empty(t, pending[irKey])
+
+	// Without scopes, all the nodes are served all the resources.
+	require.NoError(t, c.GenerateNewSnapshot(irKey, resources))
+	require.Equal(t, []string{"http", "https", "tcp"}, servedListeners(c, "envoy-a"))
+}
+
+func TestNacks(t *testing.T) {
+	const irKey = "default/gateway-1"
+
+	c := NewSnapshotCache(true, logging.DefaultLogger(egv1a1.LogLevelInfo))
+	var nacks []xdstypes.Nack
+	c.SetNackHandler(func(_ string, irNacks []xdstypes.Nack) {
+		nacks = irNacks
+	})
+	node := &corev3.Node{Id: "envoy-1", Cluster: irKey}
+	respond := func(nonce, version string) {
+		c.OnStreamResponse(context.Background(), 1, nil, &discoveryv3.DiscoveryResponse{
+			TypeUrl:     resourcev3.ListenerType,
+			Nonce:       nonce,
+			VersionInfo: version,
+		})
+	}
+	request := func(version, nonce string, errorDetail *status.Status) {
+		require.NoError(t, c.OnStreamRequest(1, &discoveryv3.DiscoveryRequest{
+			Node:          node,
+			TypeUrl:       resourcev3.ListenerType,
+			VersionInfo:   version,
+			ResponseNonce: nonce,
+			ErrorDetail:   errorDetail.Proto(),
+		}))
+	}
+	servedVersion := func() string {
+		snapshot, err := c.GetSnapshot(node.Id)
+		require.NoError(t, err)
+		return snapshot.GetVersion(resourcev3.ListenerType)
+	}
+
+	require.NoError(t, c.OnStreamOpen(context.Background(), 1, resourcev3.ListenerType))
+	require.NoError(t, c.GenerateNewSnapshot(irKey, listeners("http")))
+	request("", "", nil)
+
+	// Without an acknowledged snapshot, the node can't be rolled back.
+	respond("a", "1")
+	request("", "a", status.New(codes.InvalidArgument, "invalid listener"))
+	require.Equal(t, []xdstypes.Nack{{
+		NodeID:  "envoy-1",
+		TypeURL: resourcev3.ListenerType,
+		Version: "1",
+		Message: "invalid listener",
+	}}, nacks)
+	require.Equal(t, "1", servedVersion())
+
+	require.NoError(t, c.GenerateNewSnapshot(irKey, listeners("http", "https")))
+	respond("b", "2")
+	request("2", "b", nil)
+	require.Empty(t, nacks)
+
+	// The node rejecting a snapshot is rolled back to the one it acknowledged.
+	require.NoError(t, c.GenerateNewSnapshot(irKey, listeners("http", "https", "tcp")))
+	respond("c", "3")
+	request("2", "c", status.New(codes.InvalidArgument, "invalid listener tcp"))
+	require.Equal(t, []xdstypes.Nack{{
+		NodeID:       "envoy-1",
+		TypeURL:      resourcev3.ListenerType,
+		Version:      "3",
+		Message:      "invalid listener tcp",
+		RolledBackTo: "2",
+	}}, nacks)
+	require.Equal(t, "2", servedVersion())
+
+	// Acknowledging the rolled back snapshot doesn't resolve the rejection.
+	respond("d", "2")
+	request("2", "d", nil)
+	require.Len(t, nacks, 1)
+
+	// A rejection of an older response is only counted.
+	request("2", "c", status.New(codes.InvalidArgument, "invalid listener tcp"))
+	require.Equal(t, "2", servedVersion())
+
+	// Acknowledging a newer snapshot resolves the rejection.
+	require.NoError(t, c.GenerateNewSnapshot(irKey, listeners("http", "https")))
+	respond("e", "4")
+	request("4", "e", nil)
+	require.Empty(t, nacks)
+}
+
+func TestSnapshotTraces(t *testing.T) {
+	const irKey = "default/gateway-1"
+
+	recorder := tracetest.NewSpanRecorder()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	_, update := tp.Tracer("test").Start(context.Background(), "update")
+	update.End()
+
+	c := NewSnapshotCache(true, logging.DefaultLogger(egv1a1.LogLevelInfo), WithTracerProvider(tp))
+	node1 := &corev3.Node{Id: "envoy-1", Cluster: irKey}
+	node2 := &corev3.Node{Id: "envoy-2", Cluster: irKey}
+	request := func(streamID int64, node *corev3.Node, version string) {
+		require.NoError(t, c.OnStreamRequest(streamID, &discoveryv3.DiscoveryRequest{
+			Node:        node,
+			TypeUrl:     resourcev3.ListenerType,
+			VersionInfo: version,
+		}))
+	}
+	acks := func() []string {
+		var nodes []string
+		for _, span := range recorder.Ended() {
+			if span.Name() != "xds ack" {
+				continue
+			}
+			require.Equal(t, update.SpanContext().SpanID(), span.Parent().SpanID())
+			for _, attr := range span.Attributes() {
+				if attr.Key == "nodeID" {
+					nodes = append(nodes, attr.Value.AsString())
+				}
+			}
+		}
+		return nodes
+	}
+	require.NoError(t, c.OnStreamOpen(context.Background(), 1, resourcev3.ListenerType))
+	require.NoError(t, c.OnStreamOpen(context.Background(), 2, resourcev3.ListenerType))
+	require.NoError(t, c.OnStreamOpen(context.Background(), 3, resourcev3.ListenerType))
+	request(1, node1, "")
+	request(2, node2, "")
+
+	// The snapshots generated before the update are not traced.
+	require.NoError(t, c.GenerateNewSnapshot(irKey, listeners("http")))
+	c.SetSnapshotTrace(irKey, update.SpanContext())
+	request(1, node1, "1")
+	require.Empty(t, acks())
+
+	// The first acknowledgement by each node of the snapshot generated from the update,
+	// or of a newer one, is traced.
+	require.NoError(t, c.GenerateNewSnapshot(irKey, listeners("http", "https")))
+	request(1, node1, "2")
+	request(1, node1, "2")
+	require.Equal(t, []string{"envoy-1"}, acks())
+	require.NoError(t, c.GenerateNewSnapshot(irKey, listeners("http", "https", "tcp")))
+	request(2, node2, "3")
+	require.Equal(t, []string{"envoy-1", "envoy-2"}, acks())
+
+	// The trace of a deleted irKey is forgotten.
+	require.NoError(t, c.GenerateNewSnapshot(irKey, nil))
+	require.NoError(t, c.GenerateNewSnapshot(irKey, listeners("http")))
+	request(3, &corev3.Node{Id: "envoy-3", Cluster: irKey}, "5")
+	require.Equal(t, []string{"envoy-1", "envoy-2"}, acks())
+}
+
+func TestConnectedNodes(t *testing.T) {
+	const irKey = "default/gateway-1"
+
+	c := NewSnapshotCache(true, logging.DefaultLogger(egv1a1.LogLevelInfo))
+	require.NoError(t, c.GenerateNewSnapshot(irKey, listeners("http")))
+
+	node1 := &corev3.Node{
+		Id:      "envoy-1",
+		Cluster: irKey,
+		UserAgentVersionType: &corev3.Node_UserAgentBuildVersion{
+			UserAgentBuildVersion: &corev3.BuildVersion{
+				Version: &typev3.SemanticVersion{MajorNumber: 1, MinorNumber: 32, Patch: 1},
+			},
+		},
+	}
+	node2 := &corev3.Node{Id: "envoy-2", Cluster: irKey}
+
+	// The streams without a request yet are omitted.
+	require.NoError(t, c.OnStreamOpen(context.Background(), 1, resourcev3.AnyType))
+	require.NoError(t, c.OnDeltaStreamOpen(context.Background(), 2, resourcev3.AnyType))
+	require.Empty(t, c.ConnectedNodes())
+
+	for _, typeURL := range []string{resourcev3.ListenerType, resourcev3.ClusterType, resourcev3.ListenerType} {
+		require.NoError(t, c.OnStreamRequest(1, &discoveryv3.DiscoveryRequest{Node: node1, TypeUrl: typeURL}))
+	}
+	require.NoError(t, c.OnStreamDeltaRequest(2, &discoveryv3.DeltaDiscoveryRequest{Node: node2, TypeUrl: resourcev3.RouteType}))
+
+	nodes := c.ConnectedNodes()
+	require.Len(t, nodes, 2)
+	require.False(t, nodes[0].ConnectedSince.IsZero())
+	require.Equal(t, ConnectedNode{
+		StreamID:       1,
+		NodeID:         "envoy-1",
+		Cluster:        irKey,
+		EnvoyVersion:   "v1.32.1",
+		ConnectedSince: nodes[0].ConnectedSince,
+		StreamType:     StreamTypeSotW,
+		TypeURLs:       []string{resourcev3.ClusterType, resourcev3.ListenerType},
+	}, nodes[0])
+	require.Equal(t, ConnectedNode{
+		StreamID:       2,
+		NodeID:         "envoy-2",
+		Cluster:        irKey,
+		ConnectedSince: nodes[1].ConnectedSince,
+		StreamType:     StreamTypeDelta,
+		TypeURLs:       []string{resourcev3.RouteType},
+	}, nodes[1])
+
+	// The node is no longer listed once its stream is closed.
+	c.OnStreamClosed(1, node1)
+	nodes = c.ConnectedNodes()
+	require.Len(t, nodes, 1)
+	require.Equal(t, "envoy-2", nodes[0].NodeID)
+}
+
+func TestStaleNodeEviction(t *testing.T) {
+	const irKey = "default/gateway-1"
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	c := NewSnapshotCache(true, logging.DefaultLogger(egv1a1.LogLevelInfo), WithStaleNodeEviction(ctx, time.Hour)).(*snapshotCache)
+	require.NoError(t, c.GenerateNewSnapshot(irKey, listeners("http")))
+	for i, id := range []string{"envoy-1", "envoy-2"} {
+		streamID := int64(i + 1)
+		require.NoError(t, c.OnStreamOpen(context.Background(), streamID, resourcev3.ListenerType))
+		require.NoError(t, c.OnStreamRequest(streamID, &discoveryv3.DiscoveryRequest{
+			Node:    &corev3.Node{Id: id, Cluster: irKey},
+			TypeUrl: resourcev3.ListenerType,
+		}))
+	}
+	c.OnStreamClosed(1, &corev3.Node{Id: "envoy-1", Cluster: irKey})
+
+	// The snapshot of the disconnected node is kept for the idle timeout.
+	c.evictStaleNodes(time.Now().Add(time.Minute))
+	_, err := c.GetSnapshot("envoy-1")
+	require.NoError(t, err)
+
+	// The snapshot of the connected node is never cleared.
+	c.evictStaleNodes(time.Now().Add(2 * time.Hour))
+	_, err = c.GetSnapshot("envoy-1")
+	require.Error(t, err)
+	_, err = c.GetSnapshot("envoy-2")
+	require.NoError(t, err)
+}
+
+func TestStagedRollout(t *testing.T) {
+	const irKey = "default/gateway-1"
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	c := NewSnapshotCache(true, logging.DefaultLogger(egv1a1.LogLevelInfo), WithStagedRollout(ctx, 25, time.Minute)).(*snapshotCache)
+	nodeIDs := []string{"envoy-1", "envoy-2", "envoy-3", "envoy-4"}
+	request := func(nodeID, version, nonce string, errorDetail *status.Status) {
+		streamID := int64(slices.Index(nodeIDs, nodeID) + 1)
+		require.NoError(t, c.OnStreamRequest(streamID, &discoveryv3.DiscoveryRequest{
+			Node:          &corev3.Node{Id: nodeID, Cluster: irKey},
+			TypeUrl:       resourcev3.ListenerType,
+			VersionInfo:   version,
+			ResponseNonce: nonce,
+			ErrorDetail:   errorDetail.Proto(),
+		}))
+	}
+	servedVersions := func() map[string][]string {
+		versions := make(map[string][]string)
+		for _, nodeID := range nodeIDs {
+			snapshot, err := c.GetSnapshot(nodeID)
+			require.NoError(t, err)
+			version := snapshot.GetVersion(resourcev3.ListenerType)
+			versions[version] = append(versions[version], nodeID)
+		}
+		return versions
+	}
+
+	require.NoError(t, c.GenerateNewSnapshot(irKey, listeners("http")))
+	for i, nodeID := range nodeIDs {
+		require.NoError(t, c.OnStreamOpen(context.Background(), int64(i+1), resourcev3.ListenerType))
+		request(nodeID, "", "", nil)
+		request(nodeID, "1", "", nil)
+	}
+
+	// The new snapshot is only set to a canary.
+	require.NoError(t, c.GenerateNewSnapshot(irKey, listeners("http", "https")))
+	versions := servedVersions()
+	require.Len(t, versions["1"], 3)
+	require.Len(t, versions["2"], 1)
+	canary := versions["2"][0]
+
+	// The soak period starts once the canary acknowledged the snapshot.
+	now := time.Now()
+	c.advanceRollouts(now.Add(2 * time.Minute))
+	require.Len(t, servedVersions()["1"], 3)
+	request(canary, "2", "", nil)
+	c.advanceRollouts(now)
+	c.advanceRollouts(now.Add(30 * time.Second))
+	require.Len(t, servedVersions()["1"], 3)
+	c.advanceRollouts(now.Add(time.Minute))
+	require.Equal(t, map[string][]string{"2": nodeIDs}, servedVersions())
+	for _, nodeID := range nodeIDs {
+		request(nodeID, "2", "", nil)
+	}
+
+	// The rollout halts once a canary rejects the snapshot, which is rolled back.
+	require.NoError(t, c.GenerateNewSnapshot(irKey, listeners("http", "https", "tcp")))
+	versions = servedVersions()
+	require.Len(t, versions["3"], 1)
+	canary = versions["3"][0]
+	streamID := int64(slices.Index(nodeIDs, canary) + 1)
+	c.OnStreamResponse(context.Background(), streamID, nil, &discoveryv3.DiscoveryResponse{
+		TypeUrl:     resourcev3.ListenerType,
+		Nonce:       "a",
+		VersionInfo: "3",
+	})
+	request(canary, "2", "a", status.New(codes.InvalidArgument, "invalid listener tcp"))
+	require.Equal(t, map[string][]string{"2": nodeIDs}, servedVersions())
+	c.advanceRollouts(now.Add(time.Hour))
+	require.Equal(t, map[string][]string{"2": nodeIDs}, servedVersions())
+
+	// The endpoints updated in place are not pushed while the rollout is halted.
+	require.NoError(t, c.UpdateEndpoints(irKey, "backend", &endpointv3.ClusterLoadAssignment{ClusterName: "backend"}))
+	require.Equal(t, map[string][]string{"2": nodeIDs}, servedVersions())
+
+	// A new snapshot starts a new rollout.
+	require.NoError(t, c.GenerateNewSnapshot(irKey, listeners("http", "https")))
+	require.Len(t, servedVersions()["5"], 1)
+}
+
+func TestPushPacing(t *testing.T) {
+	const irKey = "default/gateway-1"
+
+	// The pushes are made by hand, as the pacing stops with the context.
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	c := NewSnapshotCache(true, logging.DefaultLogger(egv1a1.LogLevelInfo), WithPushPacing(ctx, 10, 0)).(*snapshotCache)
+	nodeIDs := []string{"envoy-1", "envoy-2", "envoy-3"}
+	servedVersions := func() map[string]int {
+		versions := make(map[string]int)
+		for _, nodeID := range nodeIDs {
+			snapshot, err := c.GetSnapshot(nodeID)
+			require.NoError(t, err)
+			versions[snapshot.GetVersion(resourcev3.ListenerType)]++
+		}
+		return versions
+	}
+
+	// The connecting nodes are served their snapshot right away.
+	require.NoError(t, c.GenerateNewSnapshot(irKey, listeners("http")))
+	for i, nodeID := range nodeIDs {
+		require.NoError(t, c.OnStreamOpen(context.Background(), int64(i+1), resourcev3.ListenerType))
+		require.NoError(t, c.OnStreamRequest(int64(i+1), &discoveryv3.DiscoveryRequest{
+			Node:    &corev3.Node{Id: nodeID, Cluster: irKey},
+			TypeUrl: resourcev3.ListenerType,
+		}))
+	}
+	require.Equal(t, map[string]int{"1": 3}, servedVersions())
+
+	// The new snapshots are queued, and a node queued again is pushed once.
+	require.NoError(t, c.GenerateNewSnapshot(irKey, listeners("http", "https")))
+	require.NoError(t, c.GenerateNewSnapshot(irKey, listeners("http", "https", "tcp")))
+	require.Equal(t, map[string]int{"1": 3}, servedVersions())
+	require.Len(t, c.pushQueue, 3)
+
+	require.True(t, c.pushNext())
+	require.Equal(t, map[string]int{"1": 2, "3": 1}, servedVersions())
+
+	// The nodes disconnected before their push are skipped.
+	for i, nodeID := range nodeIDs {
+		snapshot, err := c.GetSnapshot(nodeID)
+		require.NoError(t, err)
+		if snapshot.GetVersion(resourcev3.ListenerType) == "1" {
+			c.OnStreamClosed(int64(i+1), &corev3.Node{Id: nodeID, Cluster: irKey})
+			break
+		}
+	}
+	require.True(t, c.pushNext())
+	require.False(t, c.pushNext())
+	require.Empty(t, c.pushQueue)
+	require.Equal(t, map[string]int{"1": 1, "3": 2}, servedVersions())
+}
+
+func TestDriftDetection(t *testing.T) {
+	const irKey = "default/gateway-1"
+
+	// The drift is checked by hand, as the detection stops with the context.
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	c := NewSnapshotCache(true, logging.DefaultLogger(egv1a1.LogLevelInfo), WithDriftDetection(ctx, time.Minute)).(*snapshotCache)
+	var notified [][]xdstypes.Drift
+	c.SetDriftHandler(func(key string, drifts []xdstypes.Drift) {
+		require.Equal(t, irKey, key)
+		notified = append(notified, drifts)
+	})
+	nodeIDs := []string{"envoy-1", "envoy-2"}
+	request := func(nodeID, version string) {
+		streamID := int64(slices.Index(nodeIDs, nodeID) + 1)
+		require.NoError(t, c.OnStreamRequest(streamID, &discoveryv3.DiscoveryRequest{
+			Node:        &corev3.Node{Id: nodeID, Cluster: irKey},
+			TypeUrl:     resourcev3.ListenerType,
+			VersionInfo: version,
+		}))
+	}
+
+	require.NoError(t, c.GenerateNewSnapshot(irKey, listeners("http")))
+	for i, nodeID := range nodeIDs {
+		require.NoError(t, c.OnStreamOpen(context.Background(), int64(i+1), resourcev3.ListenerType))
+		request(nodeID, "")
+		request(nodeID, "1")
+	}
+	now := time.Now()
+	c.checkDrift(now)
+	require.Empty(t, notified)
+
+	// A node is only reported once behind for the drift window.
+	require.NoError(t, c.GenerateNewSnapshot(irKey, listeners("http", "https")))
+	request("envoy-1", "2")
+	c.checkDrift(now)
+	c.checkDrift(now.Add(30 * time.Second))
+	require.Empty(t, notified)
+	c.checkDrift(now.Add(time.Minute))
+	require.Equal(t, [][]xdstypes.Drift{{{NodeID: "envoy-2", TypeURLs: []string{resourcev3.ListenerType}, Since: now}}}, notified)
+
+	// The hashes of the acknowledged resources are listed with the connected nodes.
+	nodes := c.ConnectedNodes()
+	require.Len(t, nodes, 2)
+	require.Len(t, nodes[0].AckedResources[resourcev3.ListenerType], 2)
+	require.Nil(t, nodes[0].BehindSince)
+	require.Len(t, nodes[1].AckedResources[resourcev3.ListenerType], 1)
+	require.Equal(t, now, *nodes[1].BehindSince)
+
+	// The drift is resolved once the node acknowledges the resources it is served.
+	request("envoy-2", "2")
+	c.checkDrift(now.Add(2 * time.Minute))
+	require.Len(t, notified, 2)
+	require.Empty(t, notified[1])
+}
+
+func TestDrain(t *testing.T) {
+	const irKey = "default/gateway-1"
+
+	c := NewSnapshotCache(true, logging.DefaultLogger(egv1a1.LogLevelInfo))
+	require.NoError(t, c.GenerateNewSnapshot(irKey, listeners("http")))
+
+	node := &corev3.Node{Id: "envoy-1", Cluster: irKey}
+	require.NoError(t, c.OnStreamOpen(context.Background(), 1, resourcev3.AnyType))
+	require.NoError(t, c.OnStreamRequest(1, &discoveryv3.DiscoveryRequest{Node: node, TypeUrl: resourcev3.ListenerType}))
+	c.OnStreamResponse(context.Background(), 1, nil, &discoveryv3.DiscoveryResponse{TypeUrl: resourcev3.ListenerType, Nonce: "1", VersionInfo: "1"})
+
+	// The drain times out while the response is in flight.
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	require.ErrorIs(t, c.Drain(ctx), context.DeadlineExceeded)
+
+	// The new streams are refused while draining.
+	require.ErrorIs(t, c.OnStreamOpen(context.Background(), 2, resourcev3.AnyType), ErrDraining)
+	require.ErrorIs(t, c.OnDeltaStreamOpen(context.Background(), 3, resourcev3.AnyType), ErrDraining)
+
+	// The drain completes once the node acknowledged the response.
+	require.NoError(t, c.OnStreamRequest(1, &discoveryv3.DiscoveryRequest{Node: node, TypeUrl: resourcev3.ListenerType, ResponseNonce: "1", VersionInfo: "1"}))
+	require.NoError(t, c.Drain(context.Background()))
+}
+
+func TestResponseAnswers(t *testing.T) {
+	const irKey = "default/gateway-1"
+
+	c := NewSnapshotCache(true, logging.DefaultLogger(egv1a1.LogLevelInfo)).(*snapshotCache)
+	require.NoError(t, c.GenerateNewSnapshot(irKey, listeners("http")))
+
+	node := &corev3.Node{Id: "envoy-1", Cluster: irKey}
+	require.NoError(t, c.OnStreamOpen(context.Background(), 1, resourcev3.AnyType))
+	require.NoError(t, c.OnStreamRequest(1, &discoveryv3.DiscoveryRequest{Node: node, TypeUrl: resourcev3.ListenerType}))
+	require.Equal(t, "envoy-1", c.streamNodeID(1))
+	c.OnStreamResponse(context.Background(), 1, nil, &discoveryv3.DiscoveryResponse{TypeUrl: resourcev3.ListenerType, Nonce: "2", VersionInfo: "1"})
+	require.False(t, c.sentResponses[1][resourcev3.ListenerType].sentAt.IsZero())
+
+	// A request answering an older response doesn't answer the last one.
+	require.NoError(t, c.OnStreamRequest(1, &discoveryv3.DiscoveryRequest{Node: node, TypeUrl: resourcev3.ListenerType, ResponseNonce: "1"}))
+	require.False(t, c.sentResponses[1][resourcev3.ListenerType].answered)
+
+	require.NoError(t, c.OnStreamRequest(1, &discoveryv3.DiscoveryRequest{Node: node, TypeUrl: resourcev3.ListenerType, ResponseNonce: "2", VersionInfo: "1"}))
+	require.True(t, c.sentResponses[1][resourcev3.ListenerType].answered)
+}
+
+func TestResponseExpiry(t *testing.T) {
+	const irKey = "default/gateway-1"
+
+	c := NewSnapshotCache(true, logging.DefaultLogger(egv1a1.LogLevelInfo)).(*snapshotCache)
+	c.responseExpiry = time.Minute
+	require.NoError(t, c.GenerateNewSnapshot(irKey, listeners("http")))
+
+	node := &corev3.Node{Id: "envoy-1", Cluster: irKey}
+	require.NoError(t, c.OnStreamOpen(context.Background(), 1, resourcev3.AnyType))
+	require.NoError(t, c.OnStreamRequest(1, &discoveryv3.DiscoveryRequest{Node: node, TypeUrl: resourcev3.ListenerType}))
+	c.OnStreamResponse(context.Background(), 1, nil, &discoveryv3.DiscoveryResponse{TypeUrl: resourcev3.ListenerType, Nonce: "1", VersionInfo: "1"})
+	c.OnStreamResponse(context.Background(), 1, nil, &discoveryv3.DiscoveryResponse{TypeUrl: resourcev3.ClusterType, Nonce: "2", VersionInfo: "1"})
+	require.Equal(t, 2, c.inFlightResponses())
+
+	// The answered responses are dropped once another response is sent.
+	require.NoError(t, c.OnStreamRequest(1, &discoveryv3.DiscoveryRequest{Node: node, TypeUrl: resourcev3.ClusterType, ResponseNonce: "2", VersionInfo: "1"}))
+	require.Equal(t, 1, c.inFlightResponses())
+	c.OnStreamResponse(context.Background(), 1, nil, &discoveryv3.DiscoveryResponse{TypeUrl: resourcev3.RouteType, Nonce: "3", VersionInfo: "1"})
+	require.Len(t, c.sentResponses[1], 2)
+	require.Equal(t, 2, c.inFlightResponses())
+
+	// The responses not answered within the timeout are no longer outstanding.
+	c.expireResponses(time.Now().Add(time.Minute))
+	require.Empty(t, c.sentResponses)
+	require.Equal(t, 0, c.inFlightResponses())
+
+	// The responses of a stream are bounded, dropping the oldest one.
+	for i := range maxSentResponsesPerStream + 1 {
+		c.OnStreamResponse(context.Background(), 1, nil, &discoveryv3.DiscoveryResponse{TypeUrl: fmt.Sprintf("type-%d", i), Nonce: fmt.Sprint(i)})
+	}
+	require.Len(t, c.sentResponses[1], maxSentResponsesPerStream)
+	require.NotContains(t, c.sentResponses[1], "type-0")
+	require.Equal(t, maxSentResponsesPerStream, c.inFlightResponses())
+
+	// The responses of a closed stream are no longer outstanding.
+	c.OnStreamClosed(1, node)
+	require.Empty(t, c.sentResponses)
+	require.Equal(t, 0, c.inFlightResponses())
+}
+
+func TestConsistencyCheck(t *testing.T) {
+	const irKey = "default/gateway-1"
+
+	hcm, err := anypb.New(&hcmv3.HttpConnectionManager{
+		RouteSpecifier: &hcmv3.HttpConnectionManager_Rds{Rds: &hcmv3.Rds{RouteConfigName: "http"}},
+	})
+	require.NoError(t, err)
+	listener := &listenerv3.Listener{Name: "http", FilterChains: []*listenerv3.FilterChain{{
+		Filters: []*listenerv3.Filter{{Name: "hcm", ConfigType: &listenerv3.Filter_TypedConfig{TypedConfig: hcm}}},
+	}}}
+	routeTo := func(cluster string, clusterNotFound routev3.RouteAction_ClusterNotFoundResponseCode) *routev3.Route {
+		return &routev3.Route{Action: &routev3.Route_Route{Route: &routev3.RouteAction{
+			ClusterSpecifier:            &routev3.RouteAction_Cluster{Cluster: cluster},
+			ClusterNotFoundResponseCode: clusterNotFound,
+		}}}
+	}
+	tlsCtx, err := anypb.New(&tlsv3.UpstreamTlsContext{CommonTlsContext: &tlsv3.CommonTlsContext{
+		ValidationContextType: &tlsv3.CommonTlsContext_ValidationContextSdsSecretConfig{
+			ValidationContextSdsSecretConfig: &tlsv3.SdsSecretConfig{
+				Name:      "ca",
+				SdsConfig: &corev3.ConfigSource{ConfigSourceSpecifier: &corev3.ConfigSource_Ads{Ads: &corev3.AggregatedConfigSource{}}},
+			},
+		},
+	}})
+	require.NoError(t, err)
+	resources := func(routes ...*routev3.Route) xdstypes.XdsResources {
+		return xdstypes.XdsResources{
+			resourcev3.ListenerType: []types.Resource{listener},
+			resourcev3.RouteType: []types.Resource{&routev3.RouteConfiguration{
+				Name:         "http",
+				VirtualHosts: []*routev3.VirtualHost{{Name: "www", Domains: []string{"*"}, Routes: routes}},
+			}},
+			resourcev3.ClusterType: []types.Resource{&clusterv3.Cluster{
+				Name:            "backend",
+				TransportSocket: &corev3.TransportSocket{Name: "tls", ConfigType: &corev3.TransportSocket_TypedConfig{TypedConfig: tlsCtx}},
+			}},
+		}
+	}
+
+	c := NewSnapshotCache(true, logging.DefaultLogger(egv1a1.LogLevelInfo), WithConsistencyCheck())
+	consistent := resources(routeTo("backend", routev3.RouteAction_SERVICE_UNAVAILABLE))
+	consistent[resourcev3.SecretType] = secrets("ca")[resourcev3.SecretType]
+	require.NoError(t, c.GenerateNewSnapshot(irKey, consistent))
+
+	// The routes to invalid backends, which set the response of a missing cluster, are
+	// not inconsistent.
+	withInvalidBackend := resources(routeTo("invalid-backend-cluster", routev3.RouteAction_INTERNAL_SERVER_ERROR))
+	withInvalidBackend[resourcev3.SecretType] = secrets("ca")[resourcev3.SecretType]
+	require.NoError(t, c.GenerateNewSnapshot(irKey, withInvalidBackend))
+
+	// The snapshot referencing a missing cluster and a missing secret is refused, and the
+	// previous one is kept.
+	err = c.GenerateNewSnapshot(irKey, resources(routeTo("other", routev3.RouteAction_SERVICE_UNAVAILABLE)))
+	var inconsistent *InconsistentSnapshotError
+	require.ErrorAs(t, err, &inconsistent)
+	require.Equal(t, irKey, inconsistent.IRKey)
+	require.Equal(t, []string{
+		`cluster "other" referenced by a route is missing`,
+		`secret "ca" referenced by a cluster is missing`,
+	}, inconsistent.Problems)
+	info, ok := c.GetSnapshotInfo(irKey)
+	require.True(t, ok)
+	require.Equal(t, "2", info.Version)
+
+	// The routes not referenced by a listener are inconsistent as well.
+	unreferenced := resources()
+	unreferenced[resourcev3.ListenerType] = listeners("http")[resourcev3.ListenerType]
+	unreferenced[resourcev3.SecretType] = secrets("ca")[resourcev3.SecretType]
+	require.ErrorAs(t, c.GenerateNewSnapshot(irKey, unreferenced), &inconsistent)
+	require.Len(t, inconsistent.Problems, 1)
+
+	// The snapshots are not validated unless enabled.
+	c = NewSnapshotCache(true, logging.DefaultLogger(egv1a1.LogLevelInfo))
+	require.NoError(t, c.GenerateNewSnapshot(irKey, resources(routeTo("other", routev3.RouteAction_SERVICE_UNAVAILABLE))))
+}
+
+func TestNodeHash(t *testing.T) {
+	const irKey = "default/gateway-1"
+
+	zone := func(id, zone string) *corev3.Node {
+		node := &corev3.Node{Id: id, Cluster: irKey}
+		if zone != "" {
+			node.Metadata = &structpb.Struct{Fields: map[string]*structpb.Value{"zone": structpb.NewStringValue(zone)}}
+		}
+		return node
+	}
+	require.Equal(t, irKey, ClusterHash{}.ID(zone("envoy-1", "")))
+	require.Equal(t, "default/gateway-1#zone=a", MetadataHash{Key: "zone"}.ID(zone("envoy-1", "a")))
+	require.Equal(t, "envoy-2", MetadataHash{Key: "zone"}.ID(zone("envoy-2", "")))
+
+	// The nodes of the cluster share a single snapshot entry.
+	c := NewSnapshotCache(true, logging.DefaultLogger(egv1a1.LogLevelInfo), WithNodeHash(ClusterHash{}))
+	require.NoError(t, c.GenerateNewSnapshot(irKey, listeners("http")))
+	for i, id := range []string{"envoy-1", "envoy-2"} {
+		streamID := int64(i + 1)
+		require.NoError(t, c.OnStreamOpen(context.Background(), streamID, resourcev3.ListenerType))
+		require.NoError(t, c.OnStreamRequest(streamID, &discoveryv3.DiscoveryRequest{Node: zone(id, ""), TypeUrl: resourcev3.ListenerType}))
+	}
+	snapshot, err := c.GetSnapshot(irKey)
+	require.NoError(t, err)
+	require.Equal(t, "1", snapshot.GetVersion(resourcev3.ListenerType))
+	_, err = c.GetSnapshot("envoy-1")
+	require.Error(t, err)
+
+	require.NoError(t, c.GenerateNewSnapshot(irKey, listeners("http", "https")))
+	snapshot, err = c.GetSnapshot(irKey)
+	require.NoError(t, err)
+	require.Equal(t, "2", snapshot.GetVersion(resourcev3.ListenerType))
+
+	// The nodes sharing the snapshot entry cannot be served the subsets of different scopes.
+	require.Error(t, c.GenerateNewScopedSnapshot(irKey, listeners("http", "https"), []xdstypes.NodeScope{{
+		Name:          "edge",
+		NodeMetadata:  map[string]string{"zone": "edge"},
+		ResourceNames: map[resourcev3.Type][]string{resourcev3.ListenerType: {"https"}},
+	}}))
+	snapshot, err = c.GetSnapshot(irKey)
+	require.NoError(t, err)
+	require.Equal(t, "2", snapshot.GetVersion(resourcev3.ListenerType))
+
+	// The shared snapshot entry is kept until its last node disconnects.
+	c.SetMemoryLimit(1, nil)
+	c.OnStreamClosed(1, zone("envoy-1", ""))
+	_, err = c.GetSnapshot(irKey)
+	require.NoError(t, err)
+	c.OnStreamClosed(2, zone("envoy-2", ""))
+	_, err = c.GetSnapshot(irKey)
+	require.Error(t, err)
+}
+
+func TestLocalityEndpointDiscovery(t *testing.T) {
+	const irKey = "envoy-gateway/gateway-1"
+	const collection = "xdstp://envoy-gateway/envoy.config.endpoint.v3.LbEndpoint/cluster-1/0/"
+	lbEndpoints := func(addresses ...string) xdstypes.XdsResources {
+		resources := listeners("http")
+		for _, address := range addresses {
+			endpoint, err := anypb.New(&endpointv3.LbEndpoint{
+				HostIdentifier: &endpointv3.LbEndpoint_Endpoint{Endpoint: &endpointv3.Endpoint{
+					Address: &corev3.Address{Address: &corev3.Address_SocketAddress{SocketAddress: &corev3.SocketAddress{
+						Address:       address,
+						PortSpecifier: &corev3.SocketAddress_PortValue{PortValue: 8080},
+					}}},
+				}},
+			})
+			require.NoError(t, err)
+			resources[xdstypes.LbEndpointType] = append(resources[xdstypes.LbEndpointType],
+				&discoveryv3.Resource{Name: collection + address + ":8080", Resource: endpoint})
+		}
+		return resources
+	}
+	responseNames := func(t *testing.T, response cachev3.DeltaResponse) ([]string, []string) {
+		resp, err := response.GetDeltaDiscoveryResponse()
+		require.NoError(t, err)
+		var names []string
+		for _, r := range resp.Resources {
+			names = append(names, r.Name)
+		}
+		return names, resp.RemovedResources
+	}
+
+	c := NewSnapshotCache(true, logging.DefaultLogger(egv1a1.LogLevelInfo)).(*snapshotCache)
+	require.NoError(t, c.GenerateNewSnapshot(irKey, lbEndpoints("10.0.0.1", "10.0.0.2")))
+
+	// The LEDS resources are not held by the snapshots.
+	require.Empty(t, c.lastSnapshot[irKey].GetResources(xdstypes.LbEndpointType))
+
+	// The locality is sent the endpoints of its collection on subscription.
+	request := &discoveryv3.DeltaDiscoveryRequest{Node: &corev3.Node{Id: "envoy-1", Cluster: irKey}, TypeUrl: xdstypes.LbEndpointType}
+	state := streamv3.NewStreamState(false, nil)
+	state.GetSubscribedResourceNames()[collection+"*"] = struct{}{}
+	responses := make(chan cachev3.DeltaResponse, 1)
+	c.CreateDeltaWatch(request, state, responses)
+	response := <-responses
+	names, removed := responseNames(t, response)
+	require.Equal(t, []string{collection + "10.0.0.1:8080", collection + "10.0.0.2:8080"}, names)
+	require.Empty(t, removed)
+	state.SetResourceVersions(response.GetNextVersionMap())
+
+	// Only the changes of the endpoints are sent.
+	c.CreateDeltaWatch(request, state, responses)
+	require.Empty(t, responses)
+	require.NoError(t, c.GenerateNewSnapshot(irKey, lbEndpoints("10.0.0.1", "10.0.0.3")))
+	names, removed = responseNames(t, <-responses)
+	require.Equal(t, []string{collection + "10.0.0.3:8080"}, names)
+	require.Equal(t, []string{collection + "10.0.0.2:8080"}, removed)
+
+	// The endpoints of other collections are not sent.
+	require.False(t, subscribesToResource(state.GetSubscribedResourceNames(),
+		"xdstp://envoy-gateway/envoy.config.endpoint.v3.LbEndpoint/cluster-1/1/10.0.0.1:8080"))
+}
+
+func TestVirtualHostDiscovery(t *testing.T) {
+	const irKey = "envoy-gateway/gateway-1"
+	const routeConfig = "envoy-gateway/gateway-1/http"
+	virtualHosts := func(vHosts ...*routev3.VirtualHost) xdstypes.XdsResources {
+		resources := listeners("http")
+		for _, vHost := range vHosts {
+			vHostAny, err := anypb.New(vHost)
+			require.NoError(t, err)
+			resources[xdstypes.VirtualHostType] = append(resources[xdstypes.VirtualHostType],
+				&discoveryv3.Resource{Name: vHost.Name, Resource: vHostAny})
+		}
+		return resources
+	}
+	vHost := func(name string, domains ...string) *routev3.VirtualHost {
+		return &routev3.VirtualHost{Name: routeConfig + "/" + name, Domains: domains}
+	}
+	type answer struct {
+		name    string
+		aliases []string
+		found   bool
+	}
+	responseAnswers := func(t *testing.T, response cachev3.DeltaResponse) ([]answer, []string) {
+		resp, err := response.GetDeltaDiscoveryResponse()
+		require.NoError(t, err)
+		var answers []answer
+		for _, r := range resp.Resources {
+			answers = append(answers, answer{name: r.Name, aliases: r.Aliases, found: r.Resource != nil})
+		}
+		return answers, resp.RemovedResources
+	}
+
+	c := NewSnapshotCache(true, logging.DefaultLogger(egv1a1.LogLevelInfo)).(*snapshotCache)
+	require.NoError(t, c.GenerateNewSnapshot(irKey, virtualHosts(
+		vHost("foo", "foo.example.com"),
+		vHost("wildcard", "*.example.com"),
+		vHost("any", "*"),
+	)))
+
+	// The VHDS resources are not held by the snapshots.
+	require.Empty(t, c.lastSnapshot[irKey].GetResources(xdstypes.VirtualHostType))
+
+	// The subscription to the route configuration is answered with no virtual host.
+	request := &discoveryv3.DeltaDiscoveryRequest{Node: &corev3.Node{Id: "envoy-1", Cluster: irKey}, TypeUrl: xdstypes.VirtualHostType}
+	state := streamv3.NewStreamState(false, nil)
+	state.GetSubscribedResourceNames()[routeConfig] = struct{}{}
+	responses := make(chan cachev3.DeltaResponse, 1)
+	c.CreateDeltaWatch(request, state, responses)
+	response := <-responses
+	answers, removed := responseAnswers(t, response)
+	require.Empty(t, answers)
+	require.Empty(t, removed)
+	state.SetResourceVersions(response.GetNextVersionMap())
+
+	// The hostnames are answered with the virtual hosts matching them as Envoy does.
+	for _, hostname := range []string{"foo.example.com:8080", "bar.example.com", "example.org"} {
+		state.GetSubscribedResourceNames()[routeConfig+"/"+hostname] = struct{}{}
+	}
+	c.CreateDeltaWatch(request, state, responses)
+	response = <-responses
+	answers, removed = responseAnswers(t, response)
+	require.Equal(t, []answer{
+		{name: routeConfig + "/wildcard", aliases: []string{routeConfig + "/bar.example.com"}, found: true},
+		{name: routeConfig + "/any", aliases: []string{routeConfig + "/example.org"}, found: true},
+		{name: routeConfig + "/foo", aliases: []string{routeConfig + "/foo.example.com:8080"}, found: true},
+	}, answers)
+	require.Empty(t, removed)
+	state.SetResourceVersions(response.GetNextVersionMap())
+
+	// Only the changes of the virtual hosts are sent, and the hostnames no virtual host
+	// matches anymore are answered with an empty resource.
+	c.CreateDeltaWatch(request, state, responses)
+	require.Empty(t, responses)
+	require.NoError(t, c.GenerateNewSnapshot(irKey, virtualHosts(
+		vHost("foo", "foo.example.com"),
+		vHost("wildcard", "*.example.com", "bar.example.com"),
+	)))
+	answers, removed = responseAnswers(t, <-responses)
+	require.Equal(t, []answer{
+		{name: routeConfig + "/wildcard", aliases: []string{routeConfig + "/bar.example.com"}, found: true},
+		{name: routeConfig + "/example.org", aliases: []string{routeConfig + "/example.org"}},
+	}, answers)
+	require.Equal(t, []string{routeConfig + "/any"}, removed)
+}
+
+func TestCheckpoint(t *testing.T) {
+	const irKey = "default/gateway-1"
+	withSecrets := func(resources xdstypes.XdsResources) xdstypes.XdsResources {
+		resources[resourcev3.SecretType] = secrets("tls-secret")[resourcev3.SecretType]
+		return resources
+	}
+
+	c := NewSnapshotCache(true, logging.DefaultLogger(egv1a1.LogLevelInfo))
+	require.NoError(t, c.GenerateNewSnapshot(irKey, withSecrets(listeners("http"))))
+
+	// There is no checkpoint until a node acknowledged a snapshot.
+	version, _, err := c.Checkpoint(irKey)
+	require.NoError(t, err)
+	require.Empty(t, version)
+
+	node := &corev3.Node{Id: "envoy-1", Cluster: irKey}
+	require.NoError(t, c.OnStreamOpen(context.Background(), 1, resourcev3.AnyType))
+	require.NoError(t, c.OnStreamRequest(1, &discoveryv3.DiscoveryRequest{Node: node, TypeUrl: resourcev3.ListenerType}))
+	c.OnStreamResponse(context.Background(), 1, nil, &discoveryv3.DiscoveryResponse{TypeUrl: resourcev3.ListenerType, Nonce: "1", VersionInfo: "1"})
+	require.NoError(t, c.OnStreamRequest(1, &discoveryv3.DiscoveryRequest{Node: node, TypeUrl: resourcev3.ListenerType, ResponseNonce: "1", VersionInfo: "1"}))
+
+	// The snapshot not acknowledged yet is not checkpointed.
+	require.NoError(t, c.GenerateNewSnapshot(irKey, withSecrets(listeners("http", "https"))))
+	version, data, err := c.Checkpoint(irKey)
+	require.NoError(t, err)
+	require.Equal(t, "1", version)
+
+	// The checkpoint holds the acknowledged snapshot, without its secrets.
+	checkpoint, err := ReadCheckpoint(bytes.NewReader(data))
+	require.NoError(t, err)
+	require.Equal(t, irKey, checkpoint.IRKey)
+	require.Equal(t, "1", checkpoint.Version)
+	require.Empty(t, checkpoint.Scopes)
+	require.Len(t, checkpoint.Resources[resourcev3.ListenerType], 1)
+	require.Equal(t, "http", checkpoint.Resources[resourcev3.ListenerType][0].(*listenerv3.Listener).Name)
+	require.Empty(t, checkpoint.Resources[resourcev3.SecretType])
+}
+
+func TestConfigDump(t *testing.T) {
+	const irKey = "default/gateway-1"
+	resources := listeners("http")
+	resources[resourcev3.SecretType] = []types.Resource{&tlsv3.Secret{
+		Name: "tls-secret",
+		Type: &tlsv3.Secret_TlsCertificate{TlsCertificate: &tlsv3.TlsCertificate{
+			CertificateChain: &corev3.DataSource{Specifier: &corev3.DataSource_InlineString{InlineString: "cert"}},
+			PrivateKey:       &corev3.DataSource{Specifier: &corev3.DataSource_InlineString{InlineString: "key"}},
+		}},
+	}}
+	resources[resourcev3.EndpointType] = []types.Resource{&endpointv3.ClusterLoadAssignment{ClusterName: "backend"}}
+
+	c := NewSnapshotCache(true, logging.DefaultLogger(egv1a1.LogLevelInfo))
+	_, err := c.ConfigDump(irKey, "", false)
+	require.ErrorIs(t, err, ErrSnapshotNotFound)
+	require.NoError(t, c.GenerateNewSnapshot(irKey, resources))
+
+	configDump, err := c.ConfigDump(irKey, "", false)
+	require.NoError(t, err)
+	require.Len(t, configDump.Configs, 5)
+
+	listenersDump := &adminv3.ListenersConfigDump{}
+	require.NoError(t, configDump.Configs[2].UnmarshalTo(listenersDump))
+	require.Len(t, listenersDump.DynamicListeners, 1)
+	listener := &listenerv3.Listener{}
+	require.NoError(t, listenersDump.DynamicListeners[0].ActiveState.Listener.UnmarshalTo(listener))
+	require.Equal(t, "http", listener.Name)
+	require.Equal(t, "1", listenersDump.DynamicListeners[0].ActiveState.VersionInfo)
+
+	// The private key is redacted, but not the certificate.
+	secretsDump := &adminv3.SecretsConfigDump{}
+	require.NoError(t, configDump.Configs[4].UnmarshalTo(secretsDump))
+	require.Len(t, secretsDump.DynamicActiveSecrets, 1)
+	secret := &tlsv3.Secret{}
+	require.NoError(t, secretsDump.DynamicActiveSecrets[0].Secret.UnmarshalTo(secret))
+	require.Equal(t, "cert", secret.GetTlsCertificate().GetCertificateChain().GetInlineString())
+	require.Equal(t, "[redacted]", secret.GetTlsCertificate().GetPrivateKey().GetInlineString())
+	require.Equal(t, "key", resources[resourcev3.SecretType][0].(*tlsv3.Secret).GetTlsCertificate().GetPrivateKey().GetInlineString())
+
+	// The endpoints are only dumped if included.
+	configDump, err = c.ConfigDump(irKey, "", true)
+	require.NoError(t, err)
+	require.Len(t, configDump.Configs, 6)
+	endpointsDump := &adminv3.EndpointsConfigDump{}
+	require.NoError(t, configDump.Configs[5].UnmarshalTo(endpointsDump))
+	require.Len(t, endpointsDump.DynamicEndpointConfigs, 1)
+
+	// The snapshot served to a node is dumped once it is connected.
+	_, err = c.ConfigDump(irKey, "envoy-1", false)
+	require.ErrorIs(t, err, ErrNodeNotFound)
+	node := &corev3.Node{Id: "envoy-1", Cluster: irKey}
+	require.NoError(t, c.OnStreamOpen(context.Background(), 1, resourcev3.AnyType))
+	require.NoError(t, c.OnStreamRequest(1, &discoveryv3.DiscoveryRequest{Node: node, TypeUrl: resourcev3.ListenerType}))
+	configDump, err = c.ConfigDump(irKey, "envoy-1", false)
+	require.NoError(t, err)
+	require.NoError(t, configDump.Configs[2].UnmarshalTo(listenersDump))
+	require.Len(t, listenersDump.DynamicListeners, 1)
+}
+
+func TestConcurrentSnapshots(t *testing.T) {
+	const (
+		irKeys      = 8
+		generations = 20
+	)
+
+	c := NewSnapshotCache(true, logging.DefaultLogger(egv1a1.LogLevelInfo))
+	errs := make(chan error, 2*irKeys)
+	for i := range irKeys {
+		irKey := fmt.Sprintf("default/gateway-%d", i)
+		node := &corev3.Node{Id: fmt.Sprintf("envoy-%d", i), Cluster: irKey}
+		streamID := int64(i + 1)
+		require.NoError(t, c.OnStreamOpen(context.Background(), streamID, resourcev3.ListenerType))
+
+		// The snapshots of the irKeys are generated while the nodes of the other irKeys
+		// request theirs.
+		go func() {
+			for g := range generations {
+				if err := c.GenerateNewSnapshot(irKey, listeners(fmt.Sprintf("http-%d", g))); err != nil {
+					errs <- err
+					return
+				}
+			}
+			errs <- nil
+		}()
+		go func() {
+			for range generations {
+				if err := c.OnStreamRequest(streamID, &discoveryv3.DiscoveryRequest{
+					Node:    node,
+					TypeUrl: resourcev3.ListenerType,
+				}); err != nil {
+					errs <- err
+					return
+				}
+				_ = c.ConnectedNodes()
+			}
+			errs <- nil
+		}()
+	}
+	for range 2 * irKeys {
+		require.NoError(t, <-errs)
+	}
+
+	require.Len(t, c.IRKeys(), irKeys)
+	versions := make(map[string]bool)
+	for i := range irKeys {
+		irKey := fmt.Sprintf("default/gateway-%d", i)
+		history := c.GetSnapshotHistory(irKey)
+		require.NotEmpty(t, history)
+		require.False(t, versions[history[0].Version], "version %s generated twice", history[0].Version)
+		versions[history[0].Version] = true
+
+		// Each node is served the last snapshot of its irKey.
+		snapshot, err := c.GetSnapshot(fmt.Sprintf("envoy-%d", i))
+		require.NoError(t, err)
+		require.Equal(t, history[0].Version, snapshot.GetVersion(resourcev3.ListenerType))
+		require.Contains(t, snapshot.GetResources(resourcev3.ListenerType), fmt.Sprintf("http-%d", generations-1))
+
+		// The resources shared with the snapshots generated concurrently for the other
+		// irKeys stayed pooled until the snapshot was recorded, so its versions are set.
+		last := c.(*snapshotCache).lastSnapshot[irKey]
+		require.Contains(t, last.VersionMap[resourcev3.ListenerType], fmt.Sprintf("http-%d", generations-1))
+	}
+}
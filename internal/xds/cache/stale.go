@@ -0,0 +1,71 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package cache
+
+import (
+	"context"
+	"time"
+)
+
+// maxStaleNodeCheckInterval is the maximum interval the stale nodes are checked at.
+const maxStaleNodeCheckInterval = time.Minute
+
+// WithStaleNodeEviction clears the snapshots of the nodes without an open stream for the
+// idle timeout, checked until the context is done, so that the snapshots of the nodes that
+// disconnected and never reconnect are not retained forever.
+func WithStaleNodeEviction(ctx context.Context, idleTimeout time.Duration) Option {
+	return func(o *options) {
+		o.staleNodeCtx = ctx
+		o.staleNodeTimeout = idleTimeout
+	}
+}
+
+// runStaleNodeEviction clears the snapshots of the stale nodes periodically until the
+// context is done.
+func (s *snapshotCache) runStaleNodeEviction(ctx context.Context) {
+	ticker := time.NewTicker(min(s.staleNodeTimeout/2, maxStaleNodeCheckInterval))
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			s.evictStaleNodes(now)
+		}
+	}
+}
+
+// recordNodeSeen records that a node whose snapshot has the key was seen at the time.
+func (s *snapshotCache) recordNodeSeen(key string, seen time.Time) {
+	if s.staleNodeTimeout <= 0 {
+		return
+	}
+	s.nodesLastSeen[key] = seen
+}
+
+// evictStaleNodes clears the snapshots of the nodes not seen for the idle timeout and
+// without an open stream.
+func (s *snapshotCache) evictStaleNodes(now time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	connected := make(map[string]bool)
+	for _, node := range s.streamIDNodeInfo {
+		if node != nil {
+			connected[s.snapshotKey(node)] = true
+		}
+	}
+	for key, seen := range s.nodesLastSeen {
+		if connected[key] || now.Sub(seen) < s.staleNodeTimeout {
+			continue
+		}
+		s.log.Infow("clearing the snapshot of a stale node", "key", key, "lastSeen", seen)
+		s.ClearSnapshot(key)
+		delete(s.nodesLastSeen, key)
+		staleNodeEvictionsTotal.With(s.partitionLabel()).Increment()
+	}
+}
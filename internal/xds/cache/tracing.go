@@ -0,0 +1,93 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package cache
+
+import (
+	"context"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const tracerName = "envoy-gateway/xds-cache"
+
+// WithTracerProvider sets the tracer provider the spans of the acknowledgements of the
+// traced snapshots are recorded with. They are not recorded by default.
+func WithTracerProvider(tp trace.TracerProvider) Option {
+	return func(o *options) {
+		o.tracerProvider = tp
+	}
+}
+
+// snapshotTrace is the trace of the update a snapshot of an irKey was generated from.
+type snapshotTrace struct {
+	// spanContext is the span context of the update.
+	spanContext trace.SpanContext
+	// version is the version of the snapshot, or empty until the snapshot is generated.
+	version string
+	// generated is when the snapshot was generated.
+	generated time.Time
+	// acked holds the nodes that acknowledged the snapshot, or a newer one.
+	acked map[string]bool
+}
+
+// SetSnapshotTrace traces the first acknowledgement by each node of the next snapshot
+// generated for the irKey from the span context of the update it is generated from. The
+// acknowledgements of the snapshots of the irKey are no longer traced if the span context
+// is invalid.
+func (s *snapshotCache) SetSnapshotTrace(irKey string, spanContext trace.SpanContext) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !spanContext.IsValid() {
+		delete(s.snapshotTraces, irKey)
+		return
+	}
+	s.snapshotTraces[irKey] = &snapshotTrace{spanContext: spanContext}
+}
+
+// bindSnapshotTrace binds the trace pending for the irKey, if any, to the version of the
+// snapshot generated for the irKey. The trace of a deleted irKey is forgotten.
+func (s *snapshotCache) bindSnapshotTrace(irKey, version string, deleted bool, now time.Time) {
+	t, ok := s.snapshotTraces[irKey]
+	if !ok {
+		return
+	}
+	if deleted {
+		delete(s.snapshotTraces, irKey)
+		return
+	}
+	if t.version == "" {
+		t.version = version
+		t.generated = now
+		t.acked = make(map[string]bool)
+	}
+}
+
+// traceAck records the span of the first acknowledgement by the node of the traced snapshot
+// of the irKey, or of a newer one, which started when the snapshot was generated.
+func (s *snapshotCache) traceAck(nodeID, irKey, typeURL, version string) {
+	t, ok := s.snapshotTraces[irKey]
+	if !ok || t.version == "" || t.acked[nodeID] {
+		return
+	}
+	if version != t.version && !newerVersion(version, t.version) {
+		return
+	}
+	t.acked[nodeID] = true
+
+	_, span := s.tracer.Start(trace.ContextWithRemoteSpanContext(context.Background(), t.spanContext), "xds ack",
+		trace.WithTimestamp(t.generated),
+		trace.WithAttributes(
+			attribute.String("irKey", irKey),
+			nodeIDLabel.Value(nodeID).Attribute(),
+			typeURLLabel.Value(typeURL).Attribute(),
+			attribute.String("version", version),
+			s.partitionLabel().Attribute(),
+		))
+	span.End()
+}
@@ -0,0 +1,82 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package cache
+
+import (
+	"context"
+	"time"
+
+	cachetypes "github.com/envoyproxy/go-control-plane/pkg/cache/types"
+	cachev3 "github.com/envoyproxy/go-control-plane/pkg/cache/v3"
+	resourcev3 "github.com/envoyproxy/go-control-plane/pkg/resource/v3"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Option configures a snapshot cache created by NewSnapshotCache.
+type Option func(*options)
+
+type options struct {
+	resourceTTLs      map[resourcev3.Type]time.Duration
+	heartbeatCtx      context.Context
+	heartbeatInterval time.Duration
+	partition         string
+	auditor           ExchangeAuditor
+	checkConsistency  bool
+	nodeHash          cachev3.NodeHash
+	staleNodeCtx      context.Context
+	staleNodeTimeout  time.Duration
+
+	responseExpiryCtx context.Context
+	responseExpiry    time.Duration
+
+	rolloutCtx              context.Context
+	rolloutCanaryPercentage uint32
+	rolloutSoakPeriod       time.Duration
+
+	pushCtx          context.Context
+	pushMaxPerSecond uint32
+	pushJitter       time.Duration
+
+	driftCtx    context.Context
+	driftWindow time.Duration
+
+	tracerProvider trace.TracerProvider
+}
+
+// WithResourceTTLs sets the TTL of the resources of each type served to the nodes, which
+// drop the resources once expired unless they are sent them again. The resources with a
+// TTL are sent again, as heartbeats, every heartbeatInterval until the context is done.
+func WithResourceTTLs(ctx context.Context, ttls map[resourcev3.Type]time.Duration, heartbeatInterval time.Duration) Option {
+	return func(o *options) {
+		o.resourceTTLs = ttls
+		o.heartbeatCtx = ctx
+		o.heartbeatInterval = heartbeatInterval
+	}
+}
+
+// withResourceTTLs returns a copy of the snapshot whose resources have the TTL of their
+// type, or the snapshot itself if no TTL is set. The resources of the types without TTL
+// are shared with the snapshot.
+func withResourceTTLs(snapshot *cachev3.Snapshot, ttls map[resourcev3.Type]time.Duration) *cachev3.Snapshot {
+	if len(ttls) == 0 {
+		return snapshot
+	}
+
+	updated := &cachev3.Snapshot{Resources: snapshot.Resources}
+	for typeURL, ttl := range ttls {
+		index := cachev3.GetResponseType(typeURL)
+		if index == cachetypes.UnknownType {
+			continue
+		}
+		resources := snapshot.Resources[index]
+		items := make(map[string]cachetypes.ResourceWithTTL, len(resources.Items))
+		for name, item := range resources.Items {
+			items[name] = cachetypes.ResourceWithTTL{Resource: item.Resource, TTL: &ttl}
+		}
+		updated.Resources[index] = cachev3.Resources{Version: resources.Version, Items: items}
+	}
+	return updated
+}
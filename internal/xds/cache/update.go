@@ -0,0 +1,241 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package cache
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+
+	corev3 "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	cachetypes "github.com/envoyproxy/go-control-plane/pkg/cache/types"
+	cachev3 "github.com/envoyproxy/go-control-plane/pkg/cache/v3"
+	resourcev3 "github.com/envoyproxy/go-control-plane/pkg/resource/v3"
+	"go.uber.org/zap/zapcore"
+
+	"github.com/envoyproxy/gateway/internal/metrics"
+	"github.com/envoyproxy/gateway/internal/xds/types"
+)
+
+// ErrScopedSnapshot is returned when updating other resources than the endpoints of a
+// snapshot with node scopes, whose subsets served to the nodes of the scopes can't be updated
+// in place.
+var ErrScopedSnapshot = errors.New("snapshot has node scopes")
+
+// UpdatableResourceTypes lists the resource types UpdateResources updates in place. The
+// other types are delivered alongside resources of their own, such as the VHDS and LEDS
+// resources, or change what the nodes request, such as the listeners.
+var UpdatableResourceTypes = []resourcev3.Type{
+	resourcev3.ClusterType,
+	resourcev3.EndpointType,
+	resourcev3.RouteType,
+}
+
+// UpdateResources replaces the resources of the last snapshot generated for the irKey by
+// type and name, or removes them if nil, in a single snapshot. Only the versions of the
+// updated types are bumped, so that the nodes are only sent the resources of these types
+// again instead of all the resources. It returns ErrSnapshotNotFound if no snapshot of the
+// irKey is cached, and ErrScopedSnapshot if the snapshot has node scopes and other resources
+// than the endpoints are updated, in which cases a new snapshot must be generated.
+func (s *snapshotCache) UpdateResources(irKey string, updates map[resourcev3.Type]map[string]cachetypes.Resource) error {
+	// The snapshot is updated holding the lock of the shard of the irKey, and the lock of
+	// the cache only to read and store it, as GenerateNewScopedSnapshot does.
+	defer s.lockShard(irKey)()
+	s.mu.RLock()
+	previous := s.lastSnapshot[irKey]
+	scopedSnapshots := s.scopedSnapshots[irKey]
+	s.mu.RUnlock()
+
+	if previous == nil {
+		return fmt.Errorf("%w: no snapshot of %s", ErrSnapshotNotFound, irKey)
+	}
+	onlyEndpoints := true
+	for typeURL := range updates {
+		onlyEndpoints = onlyEndpoints && typeURL == resourcev3.EndpointType
+	}
+	if len(scopedSnapshots) > 0 && !onlyEndpoints {
+		return fmt.Errorf("%w: %s", ErrScopedSnapshot, irKey)
+	}
+
+	defer s.prunePool()
+	prepared := make(map[resourcev3.Type]map[string]cachetypes.Resource, len(updates))
+	for typeURL, named := range updates {
+		if !slices.Contains(UpdatableResourceTypes, typeURL) {
+			return fmt.Errorf("the resources of type %s can't be updated in place", typeURL)
+		}
+		resources := make(map[string]cachetypes.Resource, len(named))
+		for name, r := range named {
+			if r != nil {
+				if resourceName := cachev3.GetResourceName(r); resourceName != name {
+					return fmt.Errorf("the resource %s of type %s is named %s", name, typeURL, resourceName)
+				}
+				pooled, err := s.pool.internResource(typeURL, r)
+				if err != nil {
+					return err
+				}
+				r = pooled
+			}
+			resources[name] = r
+		}
+		prepared[typeURL] = resources
+	}
+
+	version := s.newSnapshotVersion()
+	snapshot := previous
+	scoped := slices.Clone(scopedSnapshots)
+	for _, typeURL := range slices.Sorted(maps.Keys(prepared)) {
+		resources := prepared[typeURL]
+		snapshot = withResources(snapshot, version, typeURL, resources)
+		// The endpoints of the scopes are the endpoints of the clusters they list, if any.
+		for i, scopedSnapshot := range scoped {
+			inScope := resources
+			if names, ok := scopedSnapshot.scope.ResourceNames[typeURL]; ok {
+				inScope = make(map[string]cachetypes.Resource)
+				for name, r := range resources {
+					if slices.Contains(names, name) {
+						inScope[name] = r
+					}
+				}
+			}
+			if len(inScope) > 0 {
+				scoped[i].snapshot = withResources(scopedSnapshot.snapshot, version, typeURL, inScope)
+			}
+		}
+	}
+	// Keep serving the previous snapshot rather than pushing routes to clusters the
+	// proxies would never receive.
+	if s.checkConsistency {
+		if problems := checkSnapshotConsistency(snapshot); len(problems) > 0 {
+			xdsSnapshotCreateTotal.WithFailure(metrics.ReasonInconsistent, s.partitionLabel()).Increment()
+			return &InconsistentSnapshotError{IRKey: irKey, Problems: problems}
+		}
+	}
+	s.pool.setVersions(snapshot)
+	for _, scopedSnapshot := range scoped {
+		s.pool.setVersions(scopedSnapshot.snapshot)
+	}
+	xdsSnapshotCreateTotal.WithSuccess(s.partitionLabel()).Increment()
+	if onlyEndpoints {
+		endpointUpdatesTotal.With(s.partitionLabel()).Increment()
+	} else {
+		resourceUpdatesTotal.With(s.partitionLabel()).Increment()
+	}
+
+	if s.log.Desugar().Core().Enabled(zapcore.DebugLevel) {
+		s.log.Debugw("updated the resources of the snapshot", "irKey", irKey, "version", version,
+			"diff", diffSnapshots(previous, snapshot, maxDiffNames))
+	}
+
+	resources := snapshotResources(snapshot)
+	nodes, persistenceDir := s.storeUpdatedSnapshot(irKey, version, snapshot, scoped, resources)
+	var scopes []types.NodeScope
+	for _, scopedSnapshot := range scoped {
+		scopes = append(scopes, scopedSnapshot.scope)
+	}
+	s.persistSnapshot(persistenceDir, irKey, version, resources, scopes)
+
+	return s.setStoredNodeSnapshots(irKey, snapshot, scoped, nodes)
+}
+
+// storeUpdatedSnapshot replaces the last snapshot of the irKey and of its node scopes with
+// their updated copies, and returns the nodes to set the snapshot of, and the persistence
+// directory of the snapshots.
+func (s *snapshotCache) storeUpdatedSnapshot(
+	irKey, version string,
+	snapshot *cachev3.Snapshot,
+	scoped []scopedSnapshot,
+	resources types.XdsResources,
+) ([]*corev3.Node, string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.lastSnapshot[irKey] = snapshot
+	s.recordSnapshot(irKey, version, snapshot)
+	if len(scoped) > 0 {
+		s.scopedSnapshots[irKey] = scoped
+	}
+	delete(s.groupSnapshots, irKey)
+	s.recordChange(irKey, version, resources)
+	if s.memoryLimit > 0 {
+		defer s.trackSnapshot(irKey, resources)
+	}
+
+	return s.nodesToUpdate(irKey), s.persistenceDir
+}
+
+// withResources returns a copy of the snapshot with the resources of the type replaced by
+// name, or removed if nil, and the version of the type set to the version. The resources
+// of the other types are shared with the snapshot, keeping their version.
+func withResources(snapshot *cachev3.Snapshot, version string, typeURL resourcev3.Type, resources map[string]cachetypes.Resource) *cachev3.Snapshot {
+	updated := &cachev3.Snapshot{Resources: snapshot.Resources}
+	index := cachev3.GetResponseType(typeURL)
+	items := maps.Clone(snapshot.Resources[index].Items)
+	if items == nil {
+		items = make(map[string]cachetypes.ResourceWithTTL)
+	}
+	for name, r := range resources {
+		if r != nil {
+			items[name] = cachetypes.ResourceWithTTL{Resource: r}
+		} else {
+			delete(items, name)
+		}
+	}
+	updated.Resources[index] = cachev3.Resources{Version: version, Items: items}
+	return updated
+}
+
+// setNodeSnapshots sets the snapshots of the nodes served the irKey once it was updated,
+// except the nodes the last snapshot is not rolled out to yet. The nodes sharing a snapshot
+// key are set their snapshot once, and are queued to be pushed at the paced rate if the
+// pushes are paced.
+func (s *snapshotCache) setNodeSnapshots(irKey string) error {
+	for _, node := range s.nodesToUpdate(irKey) {
+		nodeSnapshot, err := s.snapshotForNode(irKey, node)
+		if err := s.setNodeSnapshot(node, nodeSnapshot, err); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// nodesToUpdate returns a node of each snapshot key served the irKey to set its last
+// snapshot, except the keys the snapshot is not rolled out to yet, and queues the pushes
+// to the keys instead if they are paced.
+func (s *snapshotCache) nodesToUpdate(irKey string) []*corev3.Node {
+	var nodes []*corev3.Node
+	keys := make(map[string]bool)
+	for _, node := range s.getNodes(irKey) {
+		key := s.snapshotKey(node)
+		if keys[key] || s.rolloutPending(irKey, key) {
+			continue
+		}
+		keys[key] = true
+		if s.pushInterval > 0 {
+			s.queuePush(irKey, key)
+			continue
+		}
+		nodes = append(nodes, node)
+	}
+	return nodes
+}
+
+// setNodeSnapshot sets the snapshot of the snapshot key of the node, unless it failed to
+// be generated.
+func (s *snapshotCache) setNodeSnapshot(node *corev3.Node, nodeSnapshot *cachev3.Snapshot, err error) error {
+	s.log.Debugf("Generating a snapshot with Node %s", node.Id)
+
+	if err == nil {
+		err = s.SetSnapshot(context.TODO(), s.snapshotKey(node), nodeSnapshot)
+	}
+	if err != nil {
+		xdsSnapshotUpdateTotal.WithFailure(metrics.ReasonError, nodeIDLabel.Value(node.Id), s.partitionLabel()).Increment()
+		return err
+	}
+	xdsSnapshotUpdateTotal.WithSuccess(nodeIDLabel.Value(node.Id), s.partitionLabel()).Increment()
+	return nil
+}
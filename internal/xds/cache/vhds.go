@@ -0,0 +1,232 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package cache
+
+import (
+	"maps"
+	"slices"
+	"strings"
+
+	routev3 "github.com/envoyproxy/go-control-plane/envoy/config/route/v3"
+	discoveryv3 "github.com/envoyproxy/go-control-plane/envoy/service/discovery/v3"
+	cachetypes "github.com/envoyproxy/go-control-plane/pkg/cache/types"
+	cachev3 "github.com/envoyproxy/go-control-plane/pkg/cache/v3"
+	resourcev3 "github.com/envoyproxy/go-control-plane/pkg/resource/v3"
+	streamv3 "github.com/envoyproxy/go-control-plane/pkg/server/stream/v3"
+
+	"github.com/envoyproxy/gateway/internal/xds/types"
+)
+
+// missingVirtualHostVersion is the version of the on-demand subscriptions to a hostname no
+// virtual host matches, which are answered with an empty resource.
+const missingVirtualHostVersion = "missing"
+
+// virtualHost is a VHDS resource, with the domains of its virtual host and the hash of the
+// virtual host as version.
+type virtualHost struct {
+	resource *discoveryv3.Resource
+	domains  []string
+	version  string
+}
+
+// setVirtualHosts replaces the VHDS resources of the irKey, and responds to the open VHDS
+// watches of its nodes with the changes of the virtual hosts they requested.
+func (s *snapshotCache) setVirtualHosts(irKey string, resources []cachetypes.Resource) {
+	if len(resources) == 0 && s.virtualHosts[irKey] == nil && len(s.virtualHostWatches[irKey]) == 0 {
+		return
+	}
+
+	if len(resources) == 0 {
+		delete(s.virtualHosts, irKey)
+	} else {
+		routeConfigs := make(map[string][]virtualHost)
+		for _, r := range resources {
+			resource, ok := r.(*discoveryv3.Resource)
+			if !ok {
+				continue
+			}
+			vHost := &routev3.VirtualHost{}
+			if err := resource.GetResource().UnmarshalTo(vHost); err != nil {
+				s.log.Errorw("failed to unmarshal a VHDS resource", "irKey", irKey, "name", resource.GetName(), "error", err)
+				continue
+			}
+			marshaled, err := cachev3.MarshalResource(resource.GetResource())
+			if err != nil {
+				s.log.Errorw("failed to marshal a VHDS resource", "irKey", irKey, "name", resource.GetName(), "error", err)
+				continue
+			}
+			// The route configuration is the namespace of the virtual host, up to the last
+			// slash of its name.
+			i := strings.LastIndex(resource.GetName(), "/")
+			if i < 0 {
+				continue
+			}
+			routeConfig := resource.GetName()[:i]
+			routeConfigs[routeConfig] = append(routeConfigs[routeConfig], virtualHost{
+				resource: resource,
+				domains:  vHost.Domains,
+				version:  cachev3.HashResource(marshaled),
+			})
+		}
+		s.virtualHosts[irKey] = routeConfigs
+	}
+
+	for id, watch := range s.virtualHostWatches[irKey] {
+		if response := s.virtualHostsResponse(irKey, watch, false); response != nil {
+			watch.response <- response
+			delete(s.virtualHostWatches[irKey], id)
+		}
+	}
+}
+
+// createVirtualHostsWatch responds to the VHDS request with the changes of the virtual hosts
+// it requested, or opens a watch waiting for them to change.
+func (s *snapshotCache) createVirtualHostsWatch(request *cachev3.DeltaRequest, state streamv3.StreamState, value chan cachev3.DeltaResponse) func() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	irKey := request.GetNode().GetCluster()
+	watch := &deltaWatch{request: request, state: state, response: value}
+	// The first response is always sent, for the proxies to complete the initialization of
+	// the route configurations.
+	if response := s.virtualHostsResponse(irKey, watch, state.IsFirst()); response != nil {
+		value <- response
+		return func() {}
+	}
+
+	s.virtualHostWatchCount++
+	id := s.virtualHostWatchCount
+	if s.virtualHostWatches[irKey] == nil {
+		s.virtualHostWatches[irKey] = make(map[int64]*deltaWatch)
+	}
+	s.virtualHostWatches[irKey][id] = watch
+	return func() {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		delete(s.virtualHostWatches[irKey], id)
+	}
+}
+
+// virtualHostsResponse returns the response sending the virtual hosts matching the hostnames
+// the watch requested on demand that changed since they were last sent on its stream, and
+// removing the ones no longer requested or gone. The proxies subscribe to their route
+// configurations, and request the virtual host of a hostname with <route config>/<hostname>,
+// answered with the virtual host aliased by the request, or an empty resource when no virtual
+// host matches the hostname. It returns nil if nothing changed, unless the response is forced.
+func (s *snapshotCache) virtualHostsResponse(irKey string, watch *deltaWatch, force bool) cachev3.DeltaResponse {
+	sent := watch.state.GetResourceVersions()
+	routeConfigs := s.virtualHosts[irKey]
+	nextVersions := make(map[string]string)
+	var resources []*discoveryv3.Resource
+	answers := make(map[string]*discoveryv3.Resource)
+	for _, alias := range slices.Sorted(maps.Keys(watch.state.GetSubscribedResourceNames())) {
+		// Skip the subscriptions to the route configurations.
+		if _, ok := routeConfigs[alias]; ok {
+			continue
+		}
+		i := strings.LastIndex(alias, "/")
+		if i < 0 {
+			continue
+		}
+
+		vHost := matchVirtualHost(routeConfigs[alias[:i]], alias[i+1:])
+		if vHost == nil {
+			nextVersions[alias] = missingVirtualHostVersion
+			if sent[alias] != missingVirtualHostVersion {
+				resources = append(resources, &discoveryv3.Resource{Name: alias, Aliases: []string{alias}})
+			}
+			continue
+		}
+
+		name := vHost.resource.GetName()
+		nextVersions[alias] = vHost.version
+		nextVersions[name] = vHost.version
+		if version, ok := sent[alias]; ok && version == vHost.version {
+			continue
+		}
+		answer, ok := answers[name]
+		if !ok {
+			answer = &discoveryv3.Resource{Name: name, Version: vHost.version, Resource: vHost.resource.GetResource()}
+			answers[name] = answer
+			resources = append(resources, answer)
+		}
+		answer.Aliases = append(answer.Aliases, alias)
+	}
+	var removed []string
+	for _, name := range slices.Sorted(maps.Keys(sent)) {
+		if _, ok := nextVersions[name]; !ok {
+			removed = append(removed, name)
+		}
+	}
+	if len(resources) == 0 && len(removed) == 0 && !force {
+		return nil
+	}
+
+	var version string
+	if snapshot := s.lastSnapshot[irKey]; snapshot != nil {
+		version = snapshot.GetVersion(resourcev3.RouteType)
+	}
+	return &cachev3.DeltaPassthroughResponse{
+		DeltaRequest:   watch.request,
+		NextVersionMap: nextVersions,
+		DeltaDiscoveryResponse: &discoveryv3.DeltaDiscoveryResponse{
+			SystemVersionInfo: version,
+			TypeUrl:           types.VirtualHostType,
+			Resources:         resources,
+			RemovedResources:  removed,
+		},
+	}
+}
+
+// matchVirtualHost returns the virtual host matching the hostname as Envoy does: the exact
+// domain first, then the longest suffix wildcard, then the longest prefix wildcard, then the
+// * domain. The port of the hostname is ignored, as the route configurations of Envoy Gateway
+// ignore it.
+func matchVirtualHost(vHosts []virtualHost, hostname string) *virtualHost {
+	hostname = strings.ToLower(stripPort(hostname))
+
+	// The precedence of the wildcard domains, the exact domains matching first.
+	const (
+		matchAny = iota
+		matchPrefix
+		matchSuffix
+	)
+	var match *virtualHost
+	matchKind, matchLen := -1, -1
+	for i := range vHosts {
+		for _, domain := range vHosts[i].domains {
+			domain = strings.ToLower(domain)
+			kind, length := -1, len(domain)
+			switch {
+			case domain == hostname:
+				return &vHosts[i]
+			case domain == "*":
+				kind = matchAny
+			case strings.HasPrefix(domain, "*") && len(hostname) > len(domain)-1 && strings.HasSuffix(hostname, domain[1:]):
+				kind = matchSuffix
+			case strings.HasSuffix(domain, "*") && len(hostname) > len(domain)-1 && strings.HasPrefix(hostname, domain[:len(domain)-1]):
+				kind = matchPrefix
+			}
+			if kind >= 0 && (kind > matchKind || (kind == matchKind && length > matchLen)) {
+				match, matchKind, matchLen = &vHosts[i], kind, length
+			}
+		}
+	}
+	return match
+}
+
+// stripPort returns the hostname without its port, if any.
+func stripPort(hostname string) string {
+	i := strings.LastIndex(hostname, ":")
+	if i < 0 || strings.Contains(hostname[i:], "]") {
+		return hostname
+	}
+	// An IPv6 address without brackets has no port.
+	if strings.Count(hostname, ":") > 1 && !strings.HasPrefix(hostname, "[") {
+		return hostname
+	}
+	return hostname[:i]
+}
@@ -0,0 +1,369 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package runner
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"net"
+	"slices"
+	"strconv"
+	"sync"
+	"time"
+
+	corev3 "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	endpointv3 "github.com/envoyproxy/go-control-plane/envoy/config/endpoint/v3"
+	loadstatsv3 "github.com/envoyproxy/go-control-plane/envoy/service/load_stats/v3"
+	"github.com/envoyproxy/go-control-plane/pkg/cache/types"
+	resourcev3 "github.com/envoyproxy/go-control-plane/pkg/resource/v3"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/durationpb"
+
+	egv1a1 "github.com/envoyproxy/gateway/api/v1alpha1"
+	"github.com/envoyproxy/gateway/internal/logging"
+	xdstypes "github.com/envoyproxy/gateway/internal/xds/types"
+)
+
+const (
+	// defaultAnomalyDetectionInterval is the default interval the requests of the
+	// clusters are streamed to the detector at.
+	defaultAnomalyDetectionInterval = 10 * time.Second
+	// defaultAnomalyDetectionDegradationTTL is the default duration a cluster stays
+	// degraded once the detector no longer degrades it.
+	defaultAnomalyDetectionDegradationTTL = time.Minute
+	// anomalyDetectionNodeID identifies the xds server to the detector.
+	anomalyDetectionNodeID = "envoy-gateway"
+)
+
+// requestCounts holds the requests of a cluster reported since the last report sent to
+// the detector.
+type requestCounts struct {
+	successful, errors, issued uint64
+}
+
+// anomalyDetector streams the requests of the clusters reported by the proxies of each
+// irKey to an external detector implementing the Load Reporting Service (LRS), and shifts
+// the traffic of the clusters the detector degrades to their fallback endpoints.
+type anomalyDetector struct {
+	client   loadstatsv3.LoadReportingServiceClient
+	conn     *grpc.ClientConn
+	interval time.Duration
+	ttl      time.Duration
+	logger   logging.Logger
+	// republish publishes the latest update of the irKey again once its degraded
+	// clusters changed.
+	republish func(irKey string)
+
+	mu sync.Mutex
+	// requests holds the requests of the clusters of each irKey reported since the
+	// last report sent to the detector, by cluster name.
+	requests map[string]map[string]*requestCounts
+	// degraded holds when the degradation of the clusters of each irKey expires, by
+	// cluster name.
+	degraded map[string]map[string]time.Time
+	// streams holds the stream to the detector of each irKey.
+	streams map[string]*detectorStream
+}
+
+// detectorStream is the stream the requests of the clusters of an irKey are reported on.
+type detectorStream struct {
+	stream loadstatsv3.LoadReportingService_StreamLoadStatsClient
+	cancel context.CancelFunc
+	// sent is when the last report was sent.
+	sent time.Time
+}
+
+// newAnomalyDetector returns the anomaly detector of the configuration. The detector is
+// connected to once the first requests are reported.
+func newAnomalyDetector(cfg *egv1a1.XdsAnomalyDetection, republish func(irKey string), logger logging.Logger) (*anomalyDetector, error) {
+	conn, err := grpc.NewClient(net.JoinHostPort(cfg.Host, strconv.Itoa(int(cfg.Port))),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to the anomaly detector: %w", err)
+	}
+	d := &anomalyDetector{
+		client:    loadstatsv3.NewLoadReportingServiceClient(conn),
+		conn:      conn,
+		interval:  defaultAnomalyDetectionInterval,
+		ttl:       defaultAnomalyDetectionDegradationTTL,
+		logger:    logger.WithValues("anomalyDetector", conn.Target()),
+		republish: republish,
+		requests:  make(map[string]map[string]*requestCounts),
+		degraded:  make(map[string]map[string]time.Time),
+		streams:   make(map[string]*detectorStream),
+	}
+	// The durations are validated with the EnvoyGateway config.
+	if cfg.Interval != nil {
+		d.interval, _ = time.ParseDuration(string(*cfg.Interval))
+	}
+	if cfg.DegradationTTL != nil {
+		d.ttl, _ = time.ParseDuration(string(*cfg.DegradationTTL))
+	}
+	return d, nil
+}
+
+// run reports the requests of the clusters to the detector and expires the degradations
+// at the interval, until the context is done.
+func (d *anomalyDetector) run(ctx context.Context) {
+	ticker := time.NewTicker(d.interval)
+	defer ticker.Stop()
+	defer d.close()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			d.report(ctx, now)
+			d.expire(now)
+		}
+	}
+}
+
+// close closes the streams and the connection to the detector.
+func (d *anomalyDetector) close() {
+	d.mu.Lock()
+	for irKey, s := range d.streams {
+		s.cancel()
+		delete(d.streams, irKey)
+	}
+	d.mu.Unlock()
+	_ = d.conn.Close()
+}
+
+// observe records the requests of the clusters reported by a proxy of the irKey.
+func (d *anomalyDetector) observe(irKey string, stats []*endpointv3.ClusterStats) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if d.requests[irKey] == nil {
+		d.requests[irKey] = make(map[string]*requestCounts)
+	}
+	for _, cluster := range stats {
+		counts, ok := d.requests[irKey][cluster.ClusterName]
+		if !ok {
+			counts = &requestCounts{}
+			d.requests[irKey][cluster.ClusterName] = counts
+		}
+		for _, locality := range cluster.UpstreamLocalityStats {
+			counts.successful += locality.TotalSuccessfulRequests
+			counts.errors += locality.TotalErrorRequests
+			counts.issued += locality.TotalIssuedRequests
+		}
+	}
+}
+
+// report sends the requests of the clusters of each irKey reported since the last report
+// to the detector, opening the streams of the irKeys not streamed yet or whose stream
+// failed. The requests of a report that failed to be sent are dropped.
+func (d *anomalyDetector) report(ctx context.Context, now time.Time) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	for irKey, clusters := range d.requests {
+		if len(clusters) == 0 {
+			continue
+		}
+		s, ok := d.streams[irKey]
+		if !ok {
+			var err error
+			if s, err = d.openStream(ctx, irKey, now); err != nil {
+				d.logger.Error(err, "failed to open the stream to the anomaly detector", "irKey", irKey)
+				anomalyDetectionReportsTotal.WithFailure("stream", irKeyLabel.Value(irKey)).Increment()
+				continue
+			}
+		}
+
+		req := &loadstatsv3.LoadStatsRequest{
+			Node: &corev3.Node{Id: anomalyDetectionNodeID, Cluster: irKey},
+		}
+		interval := durationpb.New(now.Sub(s.sent))
+		for _, name := range slices.Sorted(maps.Keys(clusters)) {
+			counts := clusters[name]
+			req.ClusterStats = append(req.ClusterStats, &endpointv3.ClusterStats{
+				ClusterName: name,
+				UpstreamLocalityStats: []*endpointv3.UpstreamLocalityStats{{
+					TotalSuccessfulRequests: counts.successful,
+					TotalErrorRequests:      counts.errors,
+					TotalIssuedRequests:     counts.issued,
+				}},
+				LoadReportInterval: interval,
+			})
+		}
+		delete(d.requests, irKey)
+		s.sent = now
+
+		if err := s.stream.Send(req); err != nil {
+			d.logger.Error(err, "failed to report the requests to the anomaly detector", "irKey", irKey)
+			anomalyDetectionReportsTotal.WithFailure("send", irKeyLabel.Value(irKey)).Increment()
+			s.cancel()
+			delete(d.streams, irKey)
+			continue
+		}
+		anomalyDetectionReportsTotal.WithSuccess(irKeyLabel.Value(irKey)).Increment()
+	}
+}
+
+// openStream opens the stream of the irKey, and receives the degraded clusters of the
+// irKey from the detector until the stream fails. The caller must hold the lock.
+func (d *anomalyDetector) openStream(ctx context.Context, irKey string, now time.Time) (*detectorStream, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	stream, err := d.client.StreamLoadStats(ctx)
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+	s := &detectorStream{stream: stream, cancel: cancel, sent: now.Add(-d.interval)}
+	d.streams[irKey] = s
+
+	go func() {
+		for {
+			resp, err := stream.Recv()
+			if err != nil {
+				d.mu.Lock()
+				if d.streams[irKey] == s {
+					s.cancel()
+					delete(d.streams, irKey)
+				}
+				d.mu.Unlock()
+				if ctx.Err() == nil {
+					d.logger.Error(err, "the stream to the anomaly detector failed", "irKey", irKey)
+				}
+				return
+			}
+			if d.degrade(irKey, resp.Clusters, time.Now()) {
+				d.republish(irKey)
+			}
+		}
+	}()
+	return s, nil
+}
+
+// degrade degrades the clusters of the irKey until the TTL elapses, and returns true if
+// a cluster wasn't degraded yet.
+func (d *anomalyDetector) degrade(irKey string, clusters []string, now time.Time) bool {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	changed := false
+	for _, cluster := range clusters {
+		if d.degraded[irKey] == nil {
+			d.degraded[irKey] = make(map[string]time.Time)
+		}
+		if _, ok := d.degraded[irKey][cluster]; !ok {
+			changed = true
+			d.logger.Info("degrading the cluster", "irKey", irKey, "cluster", cluster)
+			anomalyDetectionDegradationsTotal.With(irKeyLabel.Value(irKey), clusterLabel.Value(cluster)).Increment()
+		}
+		d.degraded[irKey][cluster] = now.Add(d.ttl)
+	}
+	return changed
+}
+
+// expire ends the degradations whose TTL elapsed, and publishes the latest update of
+// their irKeys again.
+func (d *anomalyDetector) expire(now time.Time) {
+	var expired []string
+	d.mu.Lock()
+	for irKey, clusters := range d.degraded {
+		changed := false
+		for cluster, until := range clusters {
+			if now.Before(until) {
+				continue
+			}
+			changed = true
+			delete(clusters, cluster)
+			d.logger.Info("the degradation of the cluster expired", "irKey", irKey, "cluster", cluster)
+		}
+		if len(clusters) == 0 {
+			delete(d.degraded, irKey)
+		}
+		if changed {
+			expired = append(expired, irKey)
+		}
+	}
+	d.mu.Unlock()
+
+	for _, irKey := range expired {
+		d.republish(irKey)
+	}
+}
+
+// forget closes the stream of the irKey and forgets its requests and degraded clusters.
+func (d *anomalyDetector) forget(irKey string) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if s, ok := d.streams[irKey]; ok {
+		s.cancel()
+		delete(d.streams, irKey)
+	}
+	delete(d.requests, irKey)
+	delete(d.degraded, irKey)
+}
+
+// apply returns the resources of the irKey with the traffic of its degraded clusters
+// shifted to their fallback endpoints. The clusters without fallback endpoints are left
+// unchanged.
+func (d *anomalyDetector) apply(irKey string, resources xdstypes.XdsResources, now time.Time) xdstypes.XdsResources {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	degraded := d.degraded[irKey]
+	if len(degraded) == 0 {
+		return resources
+	}
+
+	// Don't modify the resources of the update, which are published again once
+	// the degradations expire.
+	served := make(xdstypes.XdsResources, len(resources))
+	for typeURL, rs := range resources {
+		served[typeURL] = rs
+	}
+	endpoints := make([]types.Resource, 0, len(resources[resourcev3.EndpointType]))
+	for _, r := range resources[resourcev3.EndpointType] {
+		cla := r.(*endpointv3.ClusterLoadAssignment)
+		if until, ok := degraded[cla.ClusterName]; ok && now.Before(until) {
+			if fallback, ok := fallbackClusterLoadAssignment(cla); ok {
+				cla = fallback
+			} else {
+				d.logger.Info("the degraded cluster has no fallback endpoints", "irKey", irKey, "cluster", cla.ClusterName)
+			}
+		}
+		endpoints = append(endpoints, cla)
+	}
+	served[resourcev3.EndpointType] = endpoints
+
+	return served
+}
+
+// fallbackClusterLoadAssignment returns a copy of the cluster load assignment with the
+// priority of the fallback localities raised by one, and the primary localities moved to
+// the lowest priority, so that the traffic is shifted to the fallback endpoints while the
+// primary endpoints still serve the traffic the fallback endpoints can't. It returns false
+// if the cluster has no fallback localities.
+func fallbackClusterLoadAssignment(cla *endpointv3.ClusterLoadAssignment) (*endpointv3.ClusterLoadAssignment, bool) {
+	var lowest uint32
+	for _, locality := range cla.Endpoints {
+		lowest = max(lowest, locality.Priority)
+	}
+	if lowest == 0 {
+		return nil, false
+	}
+
+	fallback := proto.Clone(cla).(*endpointv3.ClusterLoadAssignment)
+	for _, locality := range fallback.Endpoints {
+		if locality.Priority == 0 {
+			locality.Priority = lowest
+		} else {
+			locality.Priority--
+		}
+	}
+	return fallback, true
+}
@@ -0,0 +1,141 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package runner
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	endpointv3 "github.com/envoyproxy/go-control-plane/envoy/config/endpoint/v3"
+	loadstatsv3 "github.com/envoyproxy/go-control-plane/envoy/service/load_stats/v3"
+	resourcev3 "github.com/envoyproxy/go-control-plane/pkg/resource/v3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+
+	egv1a1 "github.com/envoyproxy/gateway/api/v1alpha1"
+	"github.com/envoyproxy/gateway/internal/logging"
+	xdstypes "github.com/envoyproxy/gateway/internal/xds/types"
+)
+
+// fakeDetector degrades the clusters whose error requests exceed their successful ones.
+type fakeDetector struct {
+	loadstatsv3.UnimplementedLoadReportingServiceServer
+	reports chan *loadstatsv3.LoadStatsRequest
+}
+
+func (f *fakeDetector) StreamLoadStats(stream loadstatsv3.LoadReportingService_StreamLoadStatsServer) error {
+	for {
+		req, err := stream.Recv()
+		if err != nil {
+			return nil
+		}
+		f.reports <- req
+		resp := &loadstatsv3.LoadStatsResponse{}
+		for _, cluster := range req.ClusterStats {
+			stats := cluster.UpstreamLocalityStats[0]
+			if stats.TotalErrorRequests > stats.TotalSuccessfulRequests {
+				resp.Clusters = append(resp.Clusters, cluster.ClusterName)
+			}
+		}
+		if err := stream.Send(resp); err != nil {
+			return err
+		}
+	}
+}
+
+func requestStats(cluster string, successful, errors uint64) []*endpointv3.ClusterStats {
+	return []*endpointv3.ClusterStats{{
+		ClusterName: cluster,
+		UpstreamLocalityStats: []*endpointv3.UpstreamLocalityStats{{
+			TotalSuccessfulRequests: successful,
+			TotalErrorRequests:      errors,
+			TotalIssuedRequests:     successful + errors,
+		}},
+	}}
+}
+
+func servedPriorities(resources xdstypes.XdsResources) map[string]uint32 {
+	priorities := make(map[string]uint32)
+	for _, r := range resources[resourcev3.EndpointType] {
+		for _, locality := range r.(*endpointv3.ClusterLoadAssignment).Endpoints {
+			for _, lbEndpoint := range locality.LbEndpoints {
+				priorities[lbEndpoint.GetEndpoint().GetAddress().GetSocketAddress().GetAddress()] = locality.Priority
+			}
+		}
+	}
+	return priorities
+}
+
+func TestAnomalyDetector(t *testing.T) {
+	const (
+		irKey   = "default/gateway-1"
+		cluster = "httproute/default/backend/rule/0"
+	)
+
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	detector := &fakeDetector{reports: make(chan *loadstatsv3.LoadStatsRequest, 1)}
+	g := grpc.NewServer()
+	loadstatsv3.RegisterLoadReportingServiceServer(g, detector)
+	go func() { _ = g.Serve(lis) }()
+	t.Cleanup(g.Stop)
+
+	republished := make(chan string, 1)
+	d, err := newAnomalyDetector(&egv1a1.XdsAnomalyDetection{
+		Host: "127.0.0.1",
+		Port: int32(lis.Addr().(*net.TCPAddr).Port),
+	}, func(irKey string) { republished <- irKey }, logging.DefaultLogger(egv1a1.LogLevelInfo))
+	require.NoError(t, err)
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	resources := endpointResources(cluster, "10.0.0.1")
+	cla := resources[resourcev3.EndpointType][0].(*endpointv3.ClusterLoadAssignment)
+	cla.Endpoints = append(cla.Endpoints, &endpointv3.LocalityLbEndpoints{
+		Priority:    1,
+		LbEndpoints: endpointResources(cluster, "10.0.1.1")[resourcev3.EndpointType][0].(*endpointv3.ClusterLoadAssignment).Endpoints[0].LbEndpoints,
+	})
+
+	// The requests reported by the proxies are aggregated until they are streamed.
+	now := time.Now()
+	d.observe(irKey, requestStats(cluster, 10, 0))
+	d.observe(irKey, requestStats(cluster, 5, 1))
+	d.report(ctx, now)
+	report := <-detector.reports
+	assert.Equal(t, irKey, report.Node.Cluster)
+	require.Len(t, report.ClusterStats, 1)
+	assert.Equal(t, uint64(15), report.ClusterStats[0].UpstreamLocalityStats[0].TotalSuccessfulRequests)
+	assert.Equal(t, uint64(1), report.ClusterStats[0].UpstreamLocalityStats[0].TotalErrorRequests)
+	assert.Equal(t, defaultAnomalyDetectionInterval, report.ClusterStats[0].LoadReportInterval.AsDuration())
+	assert.Equal(t, map[string]uint32{"10.0.0.1": 0, "10.0.1.1": 1}, servedPriorities(d.apply(irKey, resources, now)))
+
+	// The traffic of the degraded cluster is shifted to its fallback endpoints.
+	d.observe(irKey, requestStats(cluster, 1, 20))
+	d.report(ctx, now.Add(time.Second))
+	<-detector.reports
+	require.Equal(t, irKey, <-republished)
+	assert.Equal(t, map[string]uint32{"10.0.0.1": 1, "10.0.1.1": 0}, servedPriorities(d.apply(irKey, resources, now)))
+	// The resources of the update are not modified.
+	assert.Equal(t, map[string]uint32{"10.0.0.1": 0, "10.0.1.1": 1}, servedPriorities(resources))
+
+	// The degradation expires once the detector no longer degrades the cluster.
+	d.expire(now.Add(time.Second))
+	assert.Empty(t, republished)
+	d.expire(time.Now().Add(defaultAnomalyDetectionDegradationTTL))
+	require.Equal(t, irKey, <-republished)
+	assert.Equal(t, map[string]uint32{"10.0.0.1": 0, "10.0.1.1": 1}, servedPriorities(d.apply(irKey, resources, now)))
+
+	// The clusters without fallback endpoints are left unchanged.
+	primary := endpointResources(cluster, "10.0.0.1")
+	d.degrade(irKey, []string{cluster}, now)
+	assert.Equal(t, map[string]uint32{"10.0.0.1": 0}, servedPriorities(d.apply(irKey, primary, now)))
+
+	d.forget(irKey)
+	assert.Equal(t, map[string]uint32{"10.0.0.1": 0, "10.0.1.1": 1}, servedPriorities(d.apply(irKey, resources, now)))
+}
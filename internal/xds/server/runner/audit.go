@@ -0,0 +1,240 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package runner
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/rand"
+	"net"
+	"os"
+	"strconv"
+	"time"
+
+	collectorlogsv1 "go.opentelemetry.io/proto/otlp/collector/logs/v1"
+	commonv1 "go.opentelemetry.io/proto/otlp/common/v1"
+	logsv1 "go.opentelemetry.io/proto/otlp/logs/v1"
+	resourcev1 "go.opentelemetry.io/proto/otlp/resource/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/utils/ptr"
+
+	egv1a1 "github.com/envoyproxy/gateway/api/v1alpha1"
+	"github.com/envoyproxy/gateway/internal/logging"
+	"github.com/envoyproxy/gateway/internal/xds/cache"
+)
+
+const (
+	// auditLogBufferSize is the number of the records waiting to be written above
+	// which the new records are dropped, so that a slow sink never blocks the cache.
+	auditLogBufferSize = 4096
+	// auditLogBatchSize is the maximum number of the records written at once.
+	auditLogBatchSize = 256
+	// auditLogFlushInterval is the interval the records waiting are written at.
+	auditLogFlushInterval = time.Second
+	// auditLogExportTimeout is how long an export to an OpenTelemetry collector may take.
+	auditLogExportTimeout = 10 * time.Second
+	// defaultAuditLogOpenTelemetryPort is the default OTLP/gRPC port of the collectors.
+	defaultAuditLogOpenTelemetryPort = 4317
+)
+
+// auditSink writes the records of the xDS audit log.
+type auditSink interface {
+	write(records []*cache.ExchangeRecord) error
+	close() error
+}
+
+// exchangeAuditor logs the sampled exchanges of the snapshot caches with the proxies
+// of the types enabled, writing them to its sink in the background.
+type exchangeAuditor struct {
+	types           sets.Set[string]
+	samplingPercent uint32
+	records         chan *cache.ExchangeRecord
+	sink            auditSink
+	logger          logging.Logger
+}
+
+// newExchangeAuditor returns the auditor of the audit log configuration, which writes
+// the records until the context is done.
+func newExchangeAuditor(ctx context.Context, cfg *egv1a1.XdsAuditLog, logger logging.Logger) (*exchangeAuditor, error) {
+	sink, err := newAuditSink(&cfg.Sink)
+	if err != nil {
+		return nil, err
+	}
+	a := &exchangeAuditor{
+		types:           sets.New[string](),
+		samplingPercent: ptr.Deref(cfg.SamplingPercent, 100),
+		records:         make(chan *cache.ExchangeRecord, auditLogBufferSize),
+		sink:            sink,
+		logger:          logger,
+	}
+	for _, t := range cfg.Types {
+		a.types.Insert(string(t))
+	}
+	go a.run(ctx)
+	return a, nil
+}
+
+// Audit queues the record to be written if its type is enabled and it is sampled.
+func (a *exchangeAuditor) Audit(record *cache.ExchangeRecord) {
+	if a.types.Len() > 0 && !a.types.Has(record.TypeURL) {
+		return
+	}
+	if a.samplingPercent < 100 && uint32(rand.Intn(100)) >= a.samplingPercent { // nolint:gosec
+		return
+	}
+	select {
+	case a.records <- record:
+	default:
+		auditLogDroppedTotal.Increment()
+	}
+}
+
+// run writes the queued records by batches until the context is done.
+func (a *exchangeAuditor) run(ctx context.Context) {
+	ticker := time.NewTicker(auditLogFlushInterval)
+	defer ticker.Stop()
+
+	batch := make([]*cache.ExchangeRecord, 0, auditLogBatchSize)
+	flush := func() {
+		if len(batch) == 0 {
+			return
+		}
+		if err := a.sink.write(batch); err != nil {
+			a.logger.Error(err, "failed to write the xds audit log", "records", len(batch))
+			auditLogDroppedTotal.Add(float64(len(batch)))
+		} else {
+			auditLogRecordsTotal.Add(float64(len(batch)))
+		}
+		batch = batch[:0]
+	}
+	for {
+		select {
+		case <-ctx.Done():
+			// Write the records still queued.
+			for len(a.records) > 0 {
+				batch = append(batch, <-a.records)
+				if len(batch) == auditLogBatchSize {
+					flush()
+				}
+			}
+			flush()
+			if err := a.sink.close(); err != nil {
+				a.logger.Error(err, "failed to close the xds audit log sink")
+			}
+			return
+		case record := <-a.records:
+			batch = append(batch, record)
+			if len(batch) == auditLogBatchSize {
+				flush()
+			}
+		case <-ticker.C:
+			flush()
+		}
+	}
+}
+
+// newAuditSink returns the sink of the audit log records.
+func newAuditSink(cfg *egv1a1.XdsAuditLogSink) (auditSink, error) {
+	switch cfg.Type {
+	case egv1a1.XdsAuditLogSinkTypeStdout:
+		return &jsonAuditSink{w: bufio.NewWriter(os.Stdout)}, nil
+	case egv1a1.XdsAuditLogSinkTypeFile:
+		f, err := os.OpenFile(cfg.File.Path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+		if err != nil {
+			return nil, fmt.Errorf("failed to open the xds audit log file: %w", err)
+		}
+		return &jsonAuditSink{w: bufio.NewWriter(f), closer: f}, nil
+	case egv1a1.XdsAuditLogSinkTypeOpenTelemetry:
+		port := ptr.Deref(cfg.OpenTelemetry.Port, defaultAuditLogOpenTelemetryPort)
+		conn, err := grpc.NewClient(net.JoinHostPort(cfg.OpenTelemetry.Host, strconv.Itoa(int(port))),
+			grpc.WithTransportCredentials(insecure.NewCredentials()))
+		if err != nil {
+			return nil, fmt.Errorf("failed to connect to the xds audit log collector: %w", err)
+		}
+		return &otlpAuditSink{client: collectorlogsv1.NewLogsServiceClient(conn), conn: conn}, nil
+	default:
+		return nil, fmt.Errorf("unsupported xds audit log sink type %q", cfg.Type)
+	}
+}
+
+// jsonAuditSink writes the records as JSON lines.
+type jsonAuditSink struct {
+	w      *bufio.Writer
+	closer io.Closer
+}
+
+func (s *jsonAuditSink) write(records []*cache.ExchangeRecord) error {
+	enc := json.NewEncoder(s.w)
+	for _, record := range records {
+		if err := enc.Encode(record); err != nil {
+			return err
+		}
+	}
+	return s.w.Flush()
+}
+
+func (s *jsonAuditSink) close() error {
+	if s.closer == nil {
+		return nil
+	}
+	return s.closer.Close()
+}
+
+// otlpAuditSink exports the records to an OpenTelemetry collector, with the JSON
+// record as body.
+type otlpAuditSink struct {
+	client collectorlogsv1.LogsServiceClient
+	conn   *grpc.ClientConn
+}
+
+func (s *otlpAuditSink) write(records []*cache.ExchangeRecord) error {
+	logRecords := make([]*logsv1.LogRecord, 0, len(records))
+	for _, record := range records {
+		body, err := json.Marshal(record)
+		if err != nil {
+			return err
+		}
+		logRecords = append(logRecords, &logsv1.LogRecord{
+			TimeUnixNano:   uint64(record.Time.UnixNano()),
+			SeverityNumber: logsv1.SeverityNumber_SEVERITY_NUMBER_INFO,
+			SeverityText:   "INFO",
+			Body:           stringValue(string(body)),
+			Attributes: []*commonv1.KeyValue{
+				{Key: "xds.direction", Value: stringValue(record.Direction)},
+				{Key: "xds.node_id", Value: stringValue(record.NodeID)},
+				{Key: "xds.type_url", Value: stringValue(record.TypeURL)},
+			},
+		})
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), auditLogExportTimeout)
+	defer cancel()
+	_, err := s.client.Export(ctx, &collectorlogsv1.ExportLogsServiceRequest{
+		ResourceLogs: []*logsv1.ResourceLogs{{
+			Resource: &resourcev1.Resource{
+				Attributes: []*commonv1.KeyValue{{Key: "service.name", Value: stringValue("envoy-gateway")}},
+			},
+			ScopeLogs: []*logsv1.ScopeLogs{{
+				Scope:      &commonv1.InstrumentationScope{Name: "xds-audit-log"},
+				LogRecords: logRecords,
+			}},
+		}},
+	})
+	return err
+}
+
+func (s *otlpAuditSink) close() error {
+	return s.conn.Close()
+}
+
+func stringValue(s string) *commonv1.AnyValue {
+	return &commonv1.AnyValue{Value: &commonv1.AnyValue_StringValue{StringValue: s}}
+}
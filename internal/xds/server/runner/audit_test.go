@@ -0,0 +1,84 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package runner
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	corev3 "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	discoveryv3 "github.com/envoyproxy/go-control-plane/envoy/service/discovery/v3"
+	resourcev3 "github.com/envoyproxy/go-control-plane/pkg/resource/v3"
+	"github.com/stretchr/testify/require"
+
+	egv1a1 "github.com/envoyproxy/gateway/api/v1alpha1"
+	"github.com/envoyproxy/gateway/internal/envoygateway/config"
+	"github.com/envoyproxy/gateway/internal/xds/cache"
+)
+
+func TestExchangeAuditor(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "xds-audit.log")
+	cfg, err := config.New()
+	require.NoError(t, err)
+	cfg.EnvoyGateway.XdsServer = &egv1a1.EnvoyGatewayXdsServer{
+		AuditLog: &egv1a1.XdsAuditLog{
+			Sink: egv1a1.XdsAuditLogSink{
+				Type: egv1a1.XdsAuditLogSinkTypeFile,
+				File: &egv1a1.XdsAuditLogFileSink{Path: path},
+			},
+			Types: []egv1a1.EnvoyResourceType{egv1a1.ListenerEnvoyResourceType},
+		},
+	}
+	r := New(&Config{Server: *cfg})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	r.auditor, err = newExchangeAuditor(ctx, cfg.EnvoyGateway.XdsServer.AuditLog, r.Logger)
+	require.NoError(t, err)
+	c, err := r.newSnapshotCache(ctx, cache.DefaultPartition)
+	require.NoError(t, err)
+
+	node := &corev3.Node{Id: "envoy-1", Cluster: "default/eg"}
+	require.NoError(t, c.OnStreamOpen(ctx, 1, resourcev3.AnyType))
+	require.NoError(t, c.OnStreamRequest(1, &discoveryv3.DiscoveryRequest{Node: node, TypeUrl: resourcev3.ListenerType}))
+	c.OnStreamResponse(ctx, 1, nil, &discoveryv3.DiscoveryResponse{TypeUrl: resourcev3.ListenerType, Nonce: "1", VersionInfo: "1"})
+	// The exchanges of the types not enabled are not logged.
+	require.NoError(t, c.OnStreamRequest(1, &discoveryv3.DiscoveryRequest{Node: node, TypeUrl: resourcev3.ClusterType}))
+	require.NoError(t, c.OnStreamRequest(1, &discoveryv3.DiscoveryRequest{Node: node, TypeUrl: resourcev3.ListenerType, ResponseNonce: "1", VersionInfo: "1"}))
+
+	// The records waiting are written once the context is done.
+	cancel()
+	var records []map[string]any
+	require.Eventually(t, func() bool {
+		f, err := os.Open(path)
+		require.NoError(t, err)
+		defer f.Close()
+		records = nil
+		scanner := bufio.NewScanner(f)
+		for scanner.Scan() {
+			var record map[string]any
+			require.NoError(t, json.Unmarshal(scanner.Bytes(), &record))
+			records = append(records, record)
+		}
+		return len(records) == 3
+	}, time.Second, 10*time.Millisecond)
+
+	for _, record := range records {
+		require.Equal(t, "envoy-1", record["nodeID"])
+		require.Equal(t, resourcev3.ListenerType, record["typeURL"])
+		require.Equal(t, cache.DefaultPartition, record["partition"])
+	}
+	require.Equal(t, "request", records[0]["direction"])
+	require.Equal(t, "request", records[0]["result"])
+	require.Equal(t, "response", records[1]["direction"])
+	require.Equal(t, "1", records[1]["nonce"])
+	require.Equal(t, "ack", records[2]["result"])
+	require.Contains(t, records[2], "latencySeconds")
+}
@@ -0,0 +1,251 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package runner
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	corev3 "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	accesslogv3 "github.com/envoyproxy/go-control-plane/envoy/service/accesslog/v3"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	clicfg "sigs.k8s.io/controller-runtime/pkg/client/config"
+
+	egv1a1 "github.com/envoyproxy/gateway/api/v1alpha1"
+	"github.com/envoyproxy/gateway/internal/envoygateway"
+	"github.com/envoyproxy/gateway/internal/infrastructure/kubernetes/proxy"
+)
+
+const (
+	// defaultServiceAccountTokenMetadataKey is the default key of the node metadata field
+	// holding the service account token of a proxy.
+	defaultServiceAccountTokenMetadataKey = "serviceAccountToken"
+	// serviceAccountUsernamePrefix prefixes the usernames of the service accounts,
+	// system:serviceaccount:<namespace>:<name>.
+	serviceAccountUsernamePrefix = "system:serviceaccount:"
+	// nodeAuthorizationTimeout is how long the authorization of a node may take.
+	nodeAuthorizationTimeout = 10 * time.Second
+)
+
+// nodeIdentity is the service account a proxy is authenticated as.
+type nodeIdentity struct {
+	namespace      string
+	serviceAccount string
+}
+
+// tokenReviewer returns the username of the service account token, or an error if
+// the token is not authenticated for the audiences.
+type tokenReviewer func(ctx context.Context, token string, audiences []string) (string, error)
+
+// nodeAuthorizer authorizes the proxies for the Gateways of the namespace of their service
+// account, and the proxies managed by Envoy Gateway, whose service account is named after
+// the irKey of their Gateway, for that Gateway.
+type nodeAuthorizer struct {
+	authentication egv1a1.XdsNodeAuthenticationType
+	metadataKey    string
+	audiences      []string
+	reviewToken    tokenReviewer
+	// namespace is the namespace Envoy Gateway deploys the proxies it manages in.
+	namespace string
+}
+
+// newNodeAuthorizer returns the authorizer of the node authorization configuration.
+func newNodeAuthorizer(cfg *egv1a1.XdsNodeAuthorization, namespace string) (*nodeAuthorizer, error) {
+	a := &nodeAuthorizer{
+		authentication: cfg.Type,
+		metadataKey:    defaultServiceAccountTokenMetadataKey,
+		namespace:      namespace,
+	}
+	if cfg.Type != egv1a1.XdsNodeAuthenticationTypeServiceAccountToken {
+		return a, nil
+	}
+
+	if token := cfg.ServiceAccountToken; token != nil {
+		a.metadataKey = ptr.Deref(token.MetadataKey, defaultServiceAccountTokenMetadataKey)
+		a.audiences = token.Audiences
+	}
+	restConfig, err := clicfg.GetConfig()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get the kubernetes config to review the tokens of the proxies: %w", err)
+	}
+	cli, err := client.New(restConfig, client.Options{Scheme: envoygateway.GetScheme()})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create the kubernetes client to review the tokens of the proxies: %w", err)
+	}
+	a.reviewToken = kubernetesTokenReviewer(cli)
+	return a, nil
+}
+
+// Authorize authenticates the node, and authorizes it for the Gateway of its cluster.
+func (a *nodeAuthorizer) Authorize(ctx context.Context, p *peer.Peer, node *corev3.Node) error {
+	identity, err := a.authenticate(ctx, p, node)
+	if err != nil {
+		return err
+	}
+
+	irKey := node.GetCluster()
+	if namespace, _, ok := strings.Cut(irKey, "/"); ok && identity.namespace == namespace {
+		return nil
+	}
+	if identity.namespace == a.namespace && identity.serviceAccount == proxy.ExpectedResourceHashedName(irKey) {
+		return nil
+	}
+	return fmt.Errorf("service account %s/%s is not authorized", identity.namespace, identity.serviceAccount)
+}
+
+// authenticate returns the service account the node is authenticated as.
+func (a *nodeAuthorizer) authenticate(ctx context.Context, p *peer.Peer, node *corev3.Node) (*nodeIdentity, error) {
+	switch a.authentication {
+	case egv1a1.XdsNodeAuthenticationTypeClientCertificate:
+		return clientCertificateIdentity(p)
+	case egv1a1.XdsNodeAuthenticationTypeServiceAccountToken:
+		token := node.GetMetadata().GetFields()[a.metadataKey].GetStringValue()
+		if token == "" {
+			return nil, fmt.Errorf("no service account token in the %s node metadata field", a.metadataKey)
+		}
+		username, err := a.reviewToken(ctx, token, a.audiences)
+		if err != nil {
+			return nil, err
+		}
+		return serviceAccountIdentity(username)
+	default:
+		return nil, fmt.Errorf("unsupported node authentication type %q", a.authentication)
+	}
+}
+
+// clientCertificateIdentity returns the service account of the SPIFFE ID of the client
+// certificate of the peer, spiffe://<trust domain>/ns/<namespace>/sa/<service account>.
+func clientCertificateIdentity(p *peer.Peer) (*nodeIdentity, error) {
+	tlsInfo, ok := p.AuthInfo.(credentials.TLSInfo)
+	if !ok || len(tlsInfo.State.PeerCertificates) == 0 {
+		return nil, errors.New("no client certificate")
+	}
+	for _, uri := range tlsInfo.State.PeerCertificates[0].URIs {
+		if uri.Scheme != "spiffe" {
+			continue
+		}
+		segments := strings.Split(strings.TrimPrefix(uri.Path, "/"), "/")
+		if len(segments) == 4 && segments[0] == "ns" && segments[2] == "sa" && segments[1] != "" && segments[3] != "" {
+			return &nodeIdentity{namespace: segments[1], serviceAccount: segments[3]}, nil
+		}
+	}
+	return nil, errors.New("no SPIFFE ID of a service account in the client certificate")
+}
+
+// serviceAccountIdentity returns the service account of the username.
+func serviceAccountIdentity(username string) (*nodeIdentity, error) {
+	namespace, name, ok := strings.Cut(strings.TrimPrefix(username, serviceAccountUsernamePrefix), ":")
+	if !strings.HasPrefix(username, serviceAccountUsernamePrefix) || !ok || namespace == "" || name == "" {
+		return nil, fmt.Errorf("%s is not a service account", username)
+	}
+	return &nodeIdentity{namespace: namespace, serviceAccount: name}, nil
+}
+
+// kubernetesTokenReviewer reviews the tokens with the TokenReview API.
+func kubernetesTokenReviewer(cli client.Client) tokenReviewer {
+	return func(ctx context.Context, token string, audiences []string) (string, error) {
+		review := &authenticationv1.TokenReview{
+			Spec: authenticationv1.TokenReviewSpec{Token: token, Audiences: audiences},
+		}
+		if err := cli.Create(ctx, review); err != nil {
+			return "", fmt.Errorf("failed to review the service account token: %w", err)
+		}
+		if !review.Status.Authenticated {
+			return "", fmt.Errorf("the service account token is not authenticated: %s", review.Status.Error)
+		}
+		return review.Status.User.Username, nil
+	}
+}
+
+// streamAuthorizer authorizes the streams of every service of the xds server. The streams
+// identify a node, which is authorized for its irKey on the first message of the stream.
+type streamAuthorizer struct {
+	// authorizer authorizes the nodes for their irKey, or nil to trust the irKey they claim.
+	authorizer *nodeAuthorizer
+}
+
+func newStreamAuthorizer(authorizer *nodeAuthorizer) *streamAuthorizer {
+	return &streamAuthorizer{authorizer: authorizer}
+}
+
+func (a *streamAuthorizer) intercept(srv any, ss grpc.ServerStream, _ *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	return handler(srv, &authorizedStream{ServerStream: ss, authorizer: a})
+}
+
+// authorize authorizes the node of the first message of a stream for its irKey.
+func (a *streamAuthorizer) authorize(ctx context.Context, node *corev3.Node) error {
+	if node.GetCluster() == "" {
+		xdsStreamUnauthorizedTotal.Increment()
+		return status.Error(codes.PermissionDenied, "the first message must identify the node and its cluster")
+	}
+	if a.authorizer == nil {
+		return nil
+	}
+
+	p, _ := peer.FromContext(ctx)
+	if p == nil {
+		p = &peer.Peer{}
+	}
+	ctx, cancel := context.WithTimeout(ctx, nodeAuthorizationTimeout)
+	defer cancel()
+	if err := a.authorizer.Authorize(ctx, p, node); err != nil {
+		xdsStreamUnauthorizedTotal.Increment()
+		return status.Errorf(codes.PermissionDenied, "node %q is not authorized for %q: %v", node.GetId(), node.GetCluster(), err)
+	}
+	return nil
+}
+
+// authorizedStream authorizes the node of the first message of the stream, and refuses the
+// messages identifying another irKey.
+type authorizedStream struct {
+	grpc.ServerStream
+	authorizer *streamAuthorizer
+
+	// irKey is the irKey the stream is authorized for, once its first message is received.
+	irKey string
+}
+
+func (s *authorizedStream) RecvMsg(m any) error {
+	if err := s.ServerStream.RecvMsg(m); err != nil {
+		return err
+	}
+
+	node := messageNode(m)
+	if s.irKey != "" {
+		if node != nil && node.GetCluster() != s.irKey {
+			xdsStreamUnauthorizedTotal.Increment()
+			return status.Errorf(codes.PermissionDenied, "the stream is authorized for %q only", s.irKey)
+		}
+		return nil
+	}
+	if err := s.authorizer.authorize(s.Context(), node); err != nil {
+		return err
+	}
+	s.irKey = node.GetCluster()
+	return nil
+}
+
+// messageNode returns the node the message identifies, if any.
+func messageNode(m any) *corev3.Node {
+	switch msg := m.(type) {
+	case *accesslogv3.StreamAccessLogsMessage:
+		return msg.GetIdentifier().GetNode()
+	case interface{ GetNode() *corev3.Node }:
+		return msg.GetNode()
+	default:
+		return nil
+	}
+}
@@ -0,0 +1,197 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package runner
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"net"
+	"net/url"
+	"testing"
+	"time"
+
+	corev3 "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	accesslogv3 "github.com/envoyproxy/go-control-plane/envoy/service/accesslog/v3"
+	loadstatsv3 "github.com/envoyproxy/go-control-plane/envoy/service/load_stats/v3"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/structpb"
+
+	egv1a1 "github.com/envoyproxy/gateway/api/v1alpha1"
+	"github.com/envoyproxy/gateway/internal/infrastructure/kubernetes/proxy"
+	xdstypes "github.com/envoyproxy/gateway/internal/xds/types"
+)
+
+func TestNodeAuthorizerClientCertificate(t *testing.T) {
+	authorizer, err := newNodeAuthorizer(&egv1a1.XdsNodeAuthorization{Type: egv1a1.XdsNodeAuthenticationTypeClientCertificate}, "envoy-gateway-system")
+	require.NoError(t, err)
+	peerWithSAN := func(spiffeID string) *peer.Peer {
+		cert := &x509.Certificate{}
+		if spiffeID != "" {
+			uri, err := url.Parse(spiffeID)
+			require.NoError(t, err)
+			cert.URIs = []*url.URL{uri}
+		}
+		return &peer.Peer{AuthInfo: credentials.TLSInfo{State: tls.ConnectionState{PeerCertificates: []*x509.Certificate{cert}}}}
+	}
+
+	tests := []struct {
+		name       string
+		spiffeID   string
+		cluster    string
+		authorized bool
+	}{
+		{
+			name:       "service account of the namespace of the Gateway",
+			spiffeID:   "spiffe://cluster.local/ns/tenant-a/sa/envoy",
+			cluster:    "tenant-a/gateway-1",
+			authorized: true,
+		},
+		{
+			name:     "service account of another namespace",
+			spiffeID: "spiffe://cluster.local/ns/tenant-b/sa/envoy",
+			cluster:  "tenant-a/gateway-1",
+		},
+		{
+			name:       "managed proxy of the Gateway",
+			spiffeID:   "spiffe://cluster.local/ns/envoy-gateway-system/sa/" + proxy.ExpectedResourceHashedName("tenant-a/gateway-1"),
+			cluster:    "tenant-a/gateway-1",
+			authorized: true,
+		},
+		{
+			name:     "managed proxy of another Gateway",
+			spiffeID: "spiffe://cluster.local/ns/envoy-gateway-system/sa/" + proxy.ExpectedResourceHashedName("tenant-b/gateway-1"),
+			cluster:  "tenant-a/gateway-1",
+		},
+		{
+			name:       "managed proxy of merged Gateways",
+			spiffeID:   "spiffe://cluster.local/ns/envoy-gateway-system/sa/" + proxy.ExpectedResourceHashedName("envoy-gateway-class"),
+			cluster:    "envoy-gateway-class",
+			authorized: true,
+		},
+		{
+			name:    "no SPIFFE ID",
+			cluster: "tenant-a/gateway-1",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			err := authorizer.Authorize(context.Background(), peerWithSAN(tc.spiffeID), &corev3.Node{Id: "envoy", Cluster: tc.cluster})
+			if tc.authorized {
+				require.NoError(t, err)
+			} else {
+				require.Error(t, err)
+			}
+		})
+	}
+
+	// A peer without a client certificate is not authorized.
+	require.Error(t, authorizer.Authorize(context.Background(), &peer.Peer{}, &corev3.Node{Id: "envoy", Cluster: "tenant-a/gateway-1"}))
+}
+
+func TestNodeAuthorizerServiceAccountToken(t *testing.T) {
+	authorizer := &nodeAuthorizer{
+		authentication: egv1a1.XdsNodeAuthenticationTypeServiceAccountToken,
+		metadataKey:    defaultServiceAccountTokenMetadataKey,
+		audiences:      []string{"envoy-gateway"},
+		namespace:      "envoy-gateway-system",
+		reviewToken: func(_ context.Context, token string, audiences []string) (string, error) {
+			if token != "valid" || len(audiences) != 1 || audiences[0] != "envoy-gateway" {
+				return "", errors.New("invalid token")
+			}
+			return "system:serviceaccount:tenant-a:envoy", nil
+		},
+	}
+	node := func(token string) *corev3.Node {
+		metadata, err := structpb.NewStruct(map[string]any{defaultServiceAccountTokenMetadataKey: token})
+		require.NoError(t, err)
+		return &corev3.Node{Id: "envoy", Cluster: "tenant-a/gateway-1", Metadata: metadata}
+	}
+
+	require.NoError(t, authorizer.Authorize(context.Background(), &peer.Peer{}, node("valid")))
+	require.Error(t, authorizer.Authorize(context.Background(), &peer.Peer{}, node("forged")))
+	require.Error(t, authorizer.Authorize(context.Background(), &peer.Peer{}, &corev3.Node{Id: "envoy", Cluster: "tenant-a/gateway-1"}))
+
+	// The node claiming the cluster of another tenant is not authorized.
+	claimingOther := node("valid")
+	claimingOther.Cluster = "tenant-b/gateway-1"
+	require.Error(t, authorizer.Authorize(context.Background(), &peer.Peer{}, claimingOther))
+
+	_, err := serviceAccountIdentity("system:node:worker-1")
+	require.Error(t, err)
+}
+
+func TestStreamAuthorizer(t *testing.T) {
+	const irKey = "tenant-a/gateway-1"
+
+	authorizer := newStreamAuthorizer(&nodeAuthorizer{
+		authentication: egv1a1.XdsNodeAuthenticationTypeServiceAccountToken,
+		metadataKey:    defaultServiceAccountTokenMetadataKey,
+		namespace:      "envoy-gateway-system",
+		reviewToken: func(_ context.Context, token string, _ []string) (string, error) {
+			if token != "valid" {
+				return "", errors.New("invalid token")
+			}
+			return "system:serviceaccount:tenant-a:envoy", nil
+		},
+	})
+
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	g := grpc.NewServer(grpc.ChainStreamInterceptor(authorizer.intercept))
+	loadstatsv3.RegisterLoadReportingServiceServer(g, newLoadReports(func(string) {}, func(string, string) bool { return true }))
+	accesslogv3.RegisterAccessLogServiceServer(g, newTrafficRecordings())
+	go func() { _ = g.Serve(lis) }()
+	t.Cleanup(g.Stop)
+	conn, err := grpc.NewClient(lis.Addr().String(), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = conn.Close() })
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	node := func(cluster, token string) *corev3.Node {
+		metadata, err := structpb.NewStruct(map[string]any{defaultServiceAccountTokenMetadataKey: token})
+		require.NoError(t, err)
+		return &corev3.Node{Id: "envoy", Cluster: cluster, Metadata: metadata}
+	}
+	// The access logs of a node claiming a Gateway it isn't authorized for are refused.
+	logs, err := accesslogv3.NewAccessLogServiceClient(conn).StreamAccessLogs(ctx)
+	require.NoError(t, err)
+	require.NoError(t, logs.Send(&accesslogv3.StreamAccessLogsMessage{
+		Identifier: &accesslogv3.StreamAccessLogsMessage_Identifier{
+			Node:    node("tenant-b/gateway-1", "valid"),
+			LogName: xdstypes.TrafficRecordingLogName,
+		},
+	}))
+	_, err = logs.CloseAndRecv()
+	require.Equal(t, codes.PermissionDenied, status.Code(err))
+
+	// So are the load reports of a node whose token isn't authenticated.
+	reports, err := loadstatsv3.NewLoadReportingServiceClient(conn).StreamLoadStats(ctx)
+	require.NoError(t, err)
+	require.NoError(t, reports.Send(&loadstatsv3.LoadStatsRequest{Node: node(irKey, "forged")}))
+	_, err = reports.Recv()
+	require.Equal(t, codes.PermissionDenied, status.Code(err))
+
+	// The load reports of an authorized node are served.
+	reports, err = loadstatsv3.NewLoadReportingServiceClient(conn).StreamLoadStats(ctx)
+	require.NoError(t, err)
+	require.NoError(t, reports.Send(&loadstatsv3.LoadStatsRequest{Node: node(irKey, "valid")}))
+	_, err = reports.Recv()
+	require.NoError(t, err)
+
+	// The stream can't report the load of another irKey.
+	require.NoError(t, reports.Send(&loadstatsv3.LoadStatsRequest{Node: node("tenant-a/gateway-2", "valid")}))
+	_, err = reports.Recv()
+	require.Equal(t, codes.PermissionDenied, status.Code(err))
+}
@@ -0,0 +1,139 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package runner
+
+import (
+	"context"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/envoyproxy/gateway/internal/ir"
+	"github.com/envoyproxy/gateway/internal/message"
+)
+
+const (
+	// checkpointTick is how often the irKeys due for a checkpoint are checkpointed.
+	checkpointTick = 10 * time.Second
+	// maxCheckpointSize is the size above which a checkpoint is not published, as it
+	// would not fit in a ConfigMap, limited to 1MiB with its metadata.
+	maxCheckpointSize = 1000 * 1024
+)
+
+// configCheckpoints schedules the checkpoints of the last configuration acknowledged by
+// the proxies of the irKeys with checkpoints enabled, at the interval of each irKey.
+type configCheckpoints struct {
+	mu sync.Mutex
+	// intervals holds the checkpoint interval of each irKey with checkpoints enabled.
+	intervals map[string]time.Duration
+	// due holds when each irKey is checkpointed next.
+	due map[string]time.Time
+}
+
+func newConfigCheckpoints() *configCheckpoints {
+	return &configCheckpoints{
+		intervals: make(map[string]time.Duration),
+		due:       make(map[string]time.Time),
+	}
+}
+
+// setSettings sets the checkpoint settings of the irKey, or disables its checkpoints if nil.
+// The irKeys with checkpoints newly enabled are checkpointed at the next tick.
+func (c *configCheckpoints) setSettings(irKey string, settings *ir.ConfigCheckpoint, now time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if settings == nil {
+		delete(c.intervals, irKey)
+		delete(c.due, irKey)
+		return
+	}
+	interval := settings.Interval.Duration
+	if previous, ok := c.intervals[irKey]; !ok {
+		c.due[irKey] = now
+	} else if interval < previous {
+		c.due[irKey] = minTime(c.due[irKey], now.Add(interval))
+	}
+	c.intervals[irKey] = interval
+}
+
+// dueKeys returns the irKeys due for a checkpoint at the time, and schedules their next one.
+func (c *configCheckpoints) dueKeys(now time.Time) []string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var keys []string
+	for irKey, due := range c.due {
+		if due.After(now) {
+			continue
+		}
+		keys = append(keys, irKey)
+		c.due[irKey] = now.Add(c.intervals[irKey])
+	}
+	slices.Sort(keys)
+	return keys
+}
+
+func minTime(a, b time.Time) time.Time {
+	if a.Before(b) {
+		return a
+	}
+	return b
+}
+
+// publishCheckpoints checkpoints the configuration of the irKeys due for a checkpoint at
+// each tick, until the context is done.
+func (r *Runner) publishCheckpoints(ctx context.Context) {
+	ticker := time.NewTicker(checkpointTick)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			for _, irKey := range r.checkpoints.dueKeys(now) {
+				r.publishCheckpoint(irKey)
+			}
+		}
+	}
+}
+
+// publishCheckpoint publishes the checkpoint of the last snapshot of the irKey acknowledged
+// by its proxies, if it changed since the last checkpoint.
+func (r *Runner) publishCheckpoint(irKey string) {
+	if r.ProviderResources == nil {
+		return
+	}
+
+	version, data, err := r.cacheFor(irKey).Checkpoint(irKey)
+	if err != nil {
+		r.Logger.Error(err, "failed to checkpoint the configuration", "irKey", irKey)
+		return
+	}
+	if version == "" {
+		return
+	}
+	if len(data) > maxCheckpointSize {
+		r.Logger.Info("skipping the checkpoint of the configuration, too large for a ConfigMap", "irKey", irKey,
+			"version", version, "size", len(data))
+		return
+	}
+	if previous, ok := r.ProviderResources.XdsCheckpoints.Load(irKey); ok && previous.Version == version {
+		return
+	}
+	r.ProviderResources.XdsCheckpoints.Store(irKey, &message.XdsCheckpoint{Version: version, Data: data})
+}
+
+// removeCheckpoint removes the checkpoint of the irKey, once its checkpoints are disabled
+// or the irKey is deleted.
+func (r *Runner) removeCheckpoint(irKey string) {
+	if r.ProviderResources == nil {
+		return
+	}
+	if _, ok := r.ProviderResources.XdsCheckpoints.Load(irKey); ok {
+		r.ProviderResources.XdsCheckpoints.Delete(irKey)
+	}
+}
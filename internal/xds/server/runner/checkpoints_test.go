@@ -0,0 +1,83 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package runner
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	corev3 "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	listenerv3 "github.com/envoyproxy/go-control-plane/envoy/config/listener/v3"
+	discoveryv3 "github.com/envoyproxy/go-control-plane/envoy/service/discovery/v3"
+	"github.com/envoyproxy/go-control-plane/pkg/cache/types"
+	resourcev3 "github.com/envoyproxy/go-control-plane/pkg/resource/v3"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	egv1a1 "github.com/envoyproxy/gateway/api/v1alpha1"
+	"github.com/envoyproxy/gateway/internal/envoygateway/config"
+	"github.com/envoyproxy/gateway/internal/ir"
+	"github.com/envoyproxy/gateway/internal/logging"
+	"github.com/envoyproxy/gateway/internal/message"
+	"github.com/envoyproxy/gateway/internal/xds/cache"
+	xdstypes "github.com/envoyproxy/gateway/internal/xds/types"
+)
+
+func TestConfigCheckpointsSchedule(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	checkpoints := newConfigCheckpoints()
+	checkpoints.setSettings("a", &ir.ConfigCheckpoint{Interval: metav1.Duration{Duration: time.Minute}}, now)
+	checkpoints.setSettings("b", &ir.ConfigCheckpoint{Interval: metav1.Duration{Duration: 5 * time.Minute}}, now)
+
+	// The irKeys are checkpointed once enabled, then at their interval.
+	require.Equal(t, []string{"a", "b"}, checkpoints.dueKeys(now))
+	require.Empty(t, checkpoints.dueKeys(now.Add(30*time.Second)))
+	require.Equal(t, []string{"a"}, checkpoints.dueKeys(now.Add(time.Minute)))
+
+	// A shorter interval takes effect right away.
+	checkpoints.setSettings("b", &ir.ConfigCheckpoint{Interval: metav1.Duration{Duration: 2 * time.Minute}}, now.Add(time.Minute))
+	require.Equal(t, []string{"a", "b"}, checkpoints.dueKeys(now.Add(3*time.Minute)))
+
+	// The irKeys with checkpoints disabled are no longer checkpointed.
+	checkpoints.setSettings("a", nil, now)
+	require.Equal(t, []string{"b"}, checkpoints.dueKeys(now.Add(time.Hour)))
+}
+
+func TestPublishCheckpoint(t *testing.T) {
+	const irKey = "default/gateway-1"
+
+	resources := &message.ProviderResources{}
+	r := New(&Config{
+		Server:            config.Server{Logger: logging.DefaultLogger(egv1a1.LogLevelInfo)},
+		ProviderResources: resources,
+	})
+	r.cache = cache.NewSnapshotCache(true, r.Logger)
+	require.NoError(t, r.cache.GenerateNewSnapshot(irKey, xdstypes.XdsResources{
+		resourcev3.ListenerType: []types.Resource{&listenerv3.Listener{Name: "http"}},
+	}))
+
+	// There is no checkpoint until a proxy acknowledged the snapshot.
+	r.publishCheckpoint(irKey)
+	_, ok := resources.XdsCheckpoints.Load(irKey)
+	require.False(t, ok)
+
+	node := &corev3.Node{Id: "envoy", Cluster: irKey}
+	require.NoError(t, r.cache.OnStreamOpen(context.Background(), 1, resourcev3.ListenerType))
+	require.NoError(t, r.cache.OnStreamRequest(1, &discoveryv3.DiscoveryRequest{Node: node, TypeUrl: resourcev3.ListenerType}))
+	r.cache.OnStreamResponse(context.Background(), 1, nil, &discoveryv3.DiscoveryResponse{TypeUrl: resourcev3.ListenerType, Nonce: "1", VersionInfo: "1"})
+	require.NoError(t, r.cache.OnStreamRequest(1, &discoveryv3.DiscoveryRequest{Node: node, TypeUrl: resourcev3.ListenerType, ResponseNonce: "1", VersionInfo: "1"}))
+
+	r.publishCheckpoint(irKey)
+	checkpoint, ok := resources.XdsCheckpoints.Load(irKey)
+	require.True(t, ok)
+	require.Equal(t, "1", checkpoint.Version)
+	require.NotEmpty(t, checkpoint.Data)
+
+	r.removeCheckpoint(irKey)
+	_, ok = resources.XdsCheckpoints.Load(irKey)
+	require.False(t, ok)
+}
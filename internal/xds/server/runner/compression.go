@@ -0,0 +1,62 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package runner
+
+import (
+	"context"
+	"slices"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/encoding/gzip"
+	"google.golang.org/grpc/stats"
+)
+
+// isDiscoveryMethod returns whether the gRPC method serves xDS resources.
+func isDiscoveryMethod(fullMethod string) bool {
+	return strings.Contains(fullMethod, "DiscoveryService/")
+}
+
+// compressDiscoveryResponses compresses the xDS responses with gzip for the proxies
+// accepting it, which are the proxies configured with xDS compression.
+func compressDiscoveryResponses(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	if isDiscoveryMethod(info.FullMethod) {
+		if accepted, err := grpc.ClientSupportedCompressors(ss.Context()); err == nil && slices.Contains(accepted, gzip.Name) {
+			_ = grpc.SetSendCompressor(ss.Context(), gzip.Name)
+		}
+	}
+	return handler(srv, ss)
+}
+
+// discoveryMethodKey is the context key of whether an RPC serves xDS resources.
+type discoveryMethodKey struct{}
+
+// responseSizeHandler records the size of the xDS responses before and after compression.
+type responseSizeHandler struct{}
+
+var _ stats.Handler = responseSizeHandler{}
+
+func (responseSizeHandler) TagRPC(ctx context.Context, info *stats.RPCTagInfo) context.Context {
+	return context.WithValue(ctx, discoveryMethodKey{}, isDiscoveryMethod(info.FullMethodName))
+}
+
+func (responseSizeHandler) HandleRPC(ctx context.Context, s stats.RPCStats) {
+	out, ok := s.(*stats.OutPayload)
+	if !ok || out.IsClient() {
+		return
+	}
+	if discovery, _ := ctx.Value(discoveryMethodKey{}).(bool); !discovery {
+		return
+	}
+	xdsResponseBytesTotal.Add(float64(out.Length))
+	xdsResponseCompressedBytesTotal.Add(float64(out.CompressedLength))
+}
+
+func (responseSizeHandler) TagConn(ctx context.Context, _ *stats.ConnTagInfo) context.Context {
+	return ctx
+}
+
+func (responseSizeHandler) HandleConn(context.Context, stats.ConnStats) {}
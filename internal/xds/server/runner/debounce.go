@@ -0,0 +1,113 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package runner
+
+import (
+	"fmt"
+	"time"
+
+	egv1a1 "github.com/envoyproxy/gateway/api/v1alpha1"
+	"github.com/envoyproxy/gateway/internal/message"
+	xdstypes "github.com/envoyproxy/gateway/internal/xds/types"
+)
+
+const (
+	// defaultDebounceInterval is the default time without update of an irKey after
+	// which its snapshot is generated.
+	defaultDebounceInterval = 100 * time.Millisecond
+	// defaultDebounceMaxDelay is the default maximum time an update is delayed for.
+	defaultDebounceMaxDelay = time.Second
+)
+
+// snapshotDebouncer coalesces the successive updates of each irKey, so that a single
+// snapshot is generated from the last of them. It is guarded by the publishMu of the
+// runner.
+type snapshotDebouncer struct {
+	interval time.Duration
+	maxDelay time.Duration
+	// publish is called once the update held for the irKey is due.
+	publish func(irKey string)
+	pending map[string]*debouncedUpdate
+}
+
+// debouncedUpdate is the last update of an irKey held by the debouncer.
+type debouncedUpdate struct {
+	update message.Update[string, *xdstypes.ResourceVersionTable]
+	// first is the time the first update coalesced was received at.
+	first time.Time
+	timer *time.Timer
+}
+
+func newSnapshotDebouncer(cfg *egv1a1.EnvoyGatewaySnapshotDebounce, publish func(irKey string)) *snapshotDebouncer {
+	d := &snapshotDebouncer{
+		interval: defaultDebounceInterval,
+		maxDelay: defaultDebounceMaxDelay,
+		publish:  publish,
+		pending:  make(map[string]*debouncedUpdate),
+	}
+	// The durations have been validated with the EnvoyGateway configuration.
+	if cfg.Interval != nil {
+		if interval, err := time.ParseDuration(string(*cfg.Interval)); err == nil {
+			d.interval = interval
+		}
+	}
+	if cfg.MaxDelay != nil {
+		if maxDelay, err := time.ParseDuration(string(*cfg.MaxDelay)); err == nil {
+			d.maxDelay = maxDelay
+		}
+	}
+	d.maxDelay = max(d.maxDelay, d.interval)
+	return d
+}
+
+// hold holds the update until no update of its irKey is received for the interval, or
+// until the max delay elapsed since the first update held, replacing the update held
+// before, if any.
+func (d *snapshotDebouncer) hold(update message.Update[string, *xdstypes.ResourceVersionTable], now time.Time) {
+	irKey := update.Key
+	pending, ok := d.pending[irKey]
+	if !ok {
+		pending = &debouncedUpdate{first: now}
+		d.pending[irKey] = pending
+	} else {
+		pending.timer.Stop()
+	}
+	pending.update = update
+
+	delay := max(min(d.interval, d.maxDelay-now.Sub(pending.first)), 0)
+	pending.timer = time.AfterFunc(delay, func() { d.publish(irKey) })
+}
+
+// take removes and returns the update held for the irKey, if any.
+func (d *snapshotDebouncer) take(irKey string) (message.Update[string, *xdstypes.ResourceVersionTable], bool) {
+	pending, ok := d.pending[irKey]
+	if !ok {
+		return message.Update[string, *xdstypes.ResourceVersionTable]{}, false
+	}
+	pending.timer.Stop()
+	delete(d.pending, irKey)
+	return pending.update, true
+}
+
+// publishDebounced publishes the update of the irKey held by the debouncer, unless it
+// was published or dropped since. If publishing is paused, the update is published
+// once resumed. The errors are reported as errors of the subscription to the xds message.
+func (r *Runner) publishDebounced(irKey string) {
+	r.publishMu.Lock()
+	defer r.publishMu.Unlock()
+
+	update, ok := r.debouncer.take(irKey)
+	if !ok {
+		return
+	}
+	if _, ok := r.paused[irKey]; ok {
+		r.paused[irKey] = &update
+		return
+	}
+	if err := r.publish(update); err != nil {
+		r.debounceErrs <- fmt.Errorf("failed to generate a snapshot of %s: %w", irKey, err)
+	}
+}
@@ -0,0 +1,119 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package runner
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	listenerv3 "github.com/envoyproxy/go-control-plane/envoy/config/listener/v3"
+	resourcev3 "github.com/envoyproxy/go-control-plane/pkg/resource/v3"
+	"github.com/stretchr/testify/require"
+	"k8s.io/utils/ptr"
+	gwapiv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	egv1a1 "github.com/envoyproxy/gateway/api/v1alpha1"
+	"github.com/envoyproxy/gateway/internal/envoygateway/config"
+	"github.com/envoyproxy/gateway/internal/message"
+	"github.com/envoyproxy/gateway/internal/xds/cache"
+	xdstypes "github.com/envoyproxy/gateway/internal/xds/types"
+)
+
+func TestSnapshotDebouncer(t *testing.T) {
+	var mu sync.Mutex
+	published := make(chan message.Update[string, *xdstypes.ResourceVersionTable], 10)
+	var d *snapshotDebouncer
+	d = newSnapshotDebouncer(&egv1a1.EnvoyGatewaySnapshotDebounce{
+		Interval: ptr.To(gwapiv1.Duration("50ms")),
+		MaxDelay: ptr.To(gwapiv1.Duration("200ms")),
+	}, func(irKey string) {
+		mu.Lock()
+		defer mu.Unlock()
+		if update, ok := d.take(irKey); ok {
+			published <- update
+		}
+	})
+
+	// The updates are told apart by their xDS server port.
+	update := func(port int32) message.Update[string, *xdstypes.ResourceVersionTable] {
+		return message.Update[string, *xdstypes.ResourceVersionTable]{
+			Key:   "default/gateway-1",
+			Value: &xdstypes.ResourceVersionTable{XdsServerPort: port},
+		}
+	}
+	hold := func(u message.Update[string, *xdstypes.ResourceVersionTable], now time.Time) {
+		mu.Lock()
+		defer mu.Unlock()
+		d.hold(u, now)
+	}
+
+	// The successive updates are coalesced into the last one.
+	now := time.Now()
+	hold(update(1), now)
+	hold(update(2), now)
+	hold(update(3), now)
+	select {
+	case u := <-published:
+		require.Equal(t, int32(3), u.Value.XdsServerPort)
+	case <-time.After(time.Second):
+		t.Fatal("the update was not published")
+	}
+	require.Never(t, func() bool { return len(published) > 0 }, 100*time.Millisecond, 10*time.Millisecond)
+
+	// The update is published once the max delay elapsed since the first update held,
+	// even though the irKey is still updated.
+	first := time.Now()
+	hold(update(1), first)
+	hold(update(2), first.Add(190*time.Millisecond))
+	select {
+	case u := <-published:
+		require.Equal(t, int32(2), u.Value.XdsServerPort)
+	case <-time.After(time.Second):
+		t.Fatal("the update was not published")
+	}
+
+	// A dropped update is not published.
+	hold(update(1), time.Now())
+	mu.Lock()
+	_, ok := d.take("default/gateway-1")
+	mu.Unlock()
+	require.True(t, ok)
+	require.Never(t, func() bool { return len(published) > 0 }, 100*time.Millisecond, 10*time.Millisecond)
+}
+
+func TestPublishDebouncedErrors(t *testing.T) {
+	cfg, err := config.New()
+	require.NoError(t, err)
+	r := New(&Config{Server: *cfg})
+	r.cache, err = r.newSnapshotCache(context.Background(), cache.DefaultPartition)
+	require.NoError(t, err)
+	r.debouncer = newSnapshotDebouncer(&egv1a1.EnvoyGatewaySnapshotDebounce{
+		Interval: ptr.To(gwapiv1.Duration("1h")),
+		MaxDelay: ptr.To(gwapiv1.Duration("1h")),
+	}, r.publishDebounced)
+
+	// The snapshot of the resources sharing a name fails to be generated.
+	resources := gatewayResources("http")
+	resources[resourcev3.ListenerType] = append(resources[resourcev3.ListenerType], &listenerv3.Listener{Name: "http"})
+	r.publishMu.Lock()
+	r.debouncer.hold(message.Update[string, *xdstypes.ResourceVersionTable]{
+		Key:   "default/eg",
+		Value: &xdstypes.ResourceVersionTable{XdsResources: resources},
+	}, time.Now())
+	r.publishMu.Unlock()
+
+	// The error is reported although the update it was held from has been handled.
+	r.publishDebounced("default/eg")
+	select {
+	case err := <-r.debounceErrs:
+		require.Error(t, err)
+	default:
+		t.Fatal("the error was not reported")
+	}
+	require.Empty(t, r.cache.IRKeys())
+}
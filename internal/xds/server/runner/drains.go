@@ -0,0 +1,308 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package runner
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	clusterv3 "github.com/envoyproxy/go-control-plane/envoy/config/cluster/v3"
+	listenerv3 "github.com/envoyproxy/go-control-plane/envoy/config/listener/v3"
+	"github.com/envoyproxy/go-control-plane/pkg/cache/types"
+	resourcev3 "github.com/envoyproxy/go-control-plane/pkg/resource/v3"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/envoyproxy/gateway/internal/ir"
+	"github.com/envoyproxy/gateway/internal/message"
+	xdstypes "github.com/envoyproxy/gateway/internal/xds/types"
+)
+
+// listenerChange is the effect of a change of a listener on the connections of the proxies.
+type listenerChange int
+
+const (
+	// listenerUnchanged is a listener left as is.
+	listenerUnchanged listenerChange = iota
+	// listenerInPlace is a change applied without draining any connection, such as adding
+	// a listener or a filter chain.
+	listenerInPlace
+	// listenerFilterChainDrain is a change only draining the connections of the modified
+	// or removed filter chains of the listener.
+	listenerFilterChainDrain
+	// listenerDrain is a change draining all the connections of the listener, since Envoy
+	// replaces the whole listener, or removes it.
+	listenerDrain
+)
+
+func (c listenerChange) String() string {
+	switch c {
+	case listenerInPlace:
+		return "in_place"
+	case listenerFilterChainDrain:
+		return "filter_chain_drain"
+	case listenerDrain:
+		return "listener_drain"
+	default:
+		return "unchanged"
+	}
+}
+
+// classifyListenerChange returns the effect of the change of the previous listener into the
+// next one, either being nil if the listener is added or removed. Envoy updates a listener in
+// place if only its filter chains changed, draining the connections of the filter chains that
+// are not served as is anymore, and replaces the listener otherwise.
+func classifyListenerChange(previous, next *listenerv3.Listener) listenerChange {
+	switch {
+	case previous == nil && next == nil:
+		return listenerUnchanged
+	case previous == nil:
+		return listenerInPlace
+	case next == nil:
+		return listenerDrain
+	case proto.Equal(previous, next):
+		return listenerUnchanged
+	}
+
+	if !proto.Equal(withoutFilterChains(previous), withoutFilterChains(next)) {
+		return listenerDrain
+	}
+	nextFilterChains := listenerFilterChains(next)
+	for _, filterChain := range listenerFilterChains(previous) {
+		served := false
+		for _, nextFilterChain := range nextFilterChains {
+			if proto.Equal(filterChain, nextFilterChain) {
+				served = true
+				break
+			}
+		}
+		if !served {
+			return listenerFilterChainDrain
+		}
+	}
+	return listenerInPlace
+}
+
+// withoutFilterChains returns a copy of the listener without its filter chains.
+func withoutFilterChains(listener *listenerv3.Listener) *listenerv3.Listener {
+	l := proto.Clone(listener).(*listenerv3.Listener)
+	l.FilterChains = nil
+	l.DefaultFilterChain = nil
+	return l
+}
+
+// listenerFilterChains returns the filter chains of the listener, including the default one.
+func listenerFilterChains(listener *listenerv3.Listener) []*listenerv3.FilterChain {
+	filterChains := listener.FilterChains
+	if listener.DefaultFilterChain != nil {
+		filterChains = append(append([]*listenerv3.FilterChain{}, filterChains...), listener.DefaultFilterChain)
+	}
+	return filterChains
+}
+
+// servedResources holds the listeners and clusters served to the proxies of an irKey,
+// by name.
+type servedResources struct {
+	listeners map[string]*listenerv3.Listener
+	clusters  map[string]types.Resource
+}
+
+func newServedResources(resources xdstypes.XdsResources) *servedResources {
+	served := &servedResources{
+		listeners: make(map[string]*listenerv3.Listener, len(resources[resourcev3.ListenerType])),
+		clusters:  make(map[string]types.Resource, len(resources[resourcev3.ClusterType])),
+	}
+	for _, r := range resources[resourcev3.ListenerType] {
+		listener := r.(*listenerv3.Listener)
+		served.listeners[listener.Name] = listener
+	}
+	for _, r := range resources[resourcev3.ClusterType] {
+		served.clusters[r.(*clusterv3.Cluster).Name] = r
+	}
+	return served
+}
+
+// drainDeferrer classifies the changes of the listeners served to the proxies by their
+// effect on the connections, and defers the changes draining connections to the
+// maintenance window of the irKey, if any. The listeners whose change is deferred keep
+// being served as is until the window opens.
+type drainDeferrer struct {
+	mu sync.Mutex
+	// served holds the listeners and clusters served to the proxies of each irKey.
+	served map[string]*servedResources
+}
+
+func newDrainDeferrer() *drainDeferrer {
+	return &drainDeferrer{
+		served: make(map[string]*servedResources),
+	}
+}
+
+// apply returns the resources of the irKey to serve, with the listeners whose change drains
+// connections replaced by the ones served so far outside of the maintenance window, along
+// with the deferred drains and the time until the window opens, or nil and zero if none.
+func (d *drainDeferrer) apply(irKey string, resources xdstypes.XdsResources, settings *ir.DeferDrains, now time.Time) (xdstypes.XdsResources, *message.DeferredDrains, time.Duration) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	next := newServedResources(resources)
+	previous, ok := d.served[irKey]
+	if !ok {
+		d.served[irKey] = next
+		return resources, nil, 0
+	}
+
+	changes := make(map[string]listenerChange)
+	for name, listener := range previous.listeners {
+		changes[name] = classifyListenerChange(listener, next.listeners[name])
+	}
+	for name, listener := range next.listeners {
+		if _, ok := previous.listeners[name]; !ok {
+			changes[name] = classifyListenerChange(nil, listener)
+		}
+	}
+
+	var (
+		deferred *message.DeferredDrains
+		opensIn  time.Duration
+	)
+	if settings != nil {
+		var open bool
+		open, opensIn = maintenanceWindowState(&settings.MaintenanceWindow, now)
+		if !open {
+			deferred = deferredDrains(changes, settings.FilterChains)
+		}
+	}
+
+	for name, change := range changes {
+		if change == listenerUnchanged || deferred.Has(name) {
+			continue
+		}
+		listenerUpdatesTotal.With(irKeyLabel.Value(irKey), changeLabel.Value(change.String())).Increment()
+	}
+	if deferred == nil {
+		deferredListenerDrains.With(irKeyLabel.Value(irKey)).Record(0)
+		d.served[irKey] = next
+		return resources, nil, 0
+	}
+	deferredListenerDrains.With(irKeyLabel.Value(irKey)).Record(float64(len(deferred.Listeners) + len(deferred.FilterChainListeners)))
+	deferred.Until = now.Add(opensIn)
+
+	// Don't modify the resources of the update, which are published again once the
+	// maintenance window opens.
+	served := make(xdstypes.XdsResources, len(resources))
+	for typeURL, rs := range resources {
+		served[typeURL] = rs
+	}
+	listeners := make([]types.Resource, 0, len(resources[resourcev3.ListenerType]))
+	for _, r := range resources[resourcev3.ListenerType] {
+		name := r.(*listenerv3.Listener).Name
+		if !deferred.Has(name) {
+			listeners = append(listeners, r)
+		} else if listener, ok := previous.listeners[name]; ok {
+			listeners = append(listeners, listener)
+		}
+	}
+	// The removed listeners keep being served too.
+	for _, name := range deferred.Listeners {
+		if _, ok := next.listeners[name]; !ok {
+			listeners = append(listeners, previous.listeners[name])
+		}
+	}
+	served[resourcev3.ListenerType] = listeners
+	// The listeners served as is may route to the removed clusters.
+	clusters := append([]types.Resource{}, resources[resourcev3.ClusterType]...)
+	for name, cluster := range previous.clusters {
+		if _, ok := next.clusters[name]; !ok {
+			clusters = append(clusters, cluster)
+		}
+	}
+	served[resourcev3.ClusterType] = clusters
+
+	d.served[irKey] = newServedResources(served)
+	return served, deferred, opensIn
+}
+
+// remove forgets the served resources of the deleted irKey.
+func (d *drainDeferrer) remove(irKey string) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	delete(d.served, irKey)
+	deferredListenerDrains.With(irKeyLabel.Value(irKey)).Record(0)
+}
+
+// deferredDrains returns the listeners whose change drains connections, or nil if none.
+// The changes only draining the connections of filter chains are deferred if filterChains
+// is set.
+func deferredDrains(changes map[string]listenerChange, filterChains bool) *message.DeferredDrains {
+	deferred := &message.DeferredDrains{}
+	for name, change := range changes {
+		switch {
+		case change == listenerDrain:
+			deferred.Listeners = append(deferred.Listeners, name)
+		case change == listenerFilterChainDrain && filterChains:
+			deferred.FilterChainListeners = append(deferred.FilterChainListeners, name)
+		}
+	}
+	if len(deferred.Listeners) == 0 && len(deferred.FilterChainListeners) == 0 {
+		return nil
+	}
+	sort.Strings(deferred.Listeners)
+	sort.Strings(deferred.FilterChainListeners)
+	return deferred
+}
+
+// maintenanceWindowState returns whether the maintenance window is open at now, or
+// otherwise the time until it opens next.
+func maintenanceWindowState(window *ir.MaintenanceWindow, now time.Time) (bool, time.Duration) {
+	now = now.UTC()
+	midnight := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+
+	opensIn := time.Duration(-1)
+	// A window opened on one of the previous days may still be open.
+	for day := -7; day <= 7; day++ {
+		opens := midnight.AddDate(0, 0, day).Add(window.Start.Duration)
+		if !maintenanceWindowDay(window.Days, opens.Weekday()) {
+			continue
+		}
+		if !now.Before(opens) && now.Before(opens.Add(window.Duration.Duration)) {
+			return true, 0
+		}
+		if d := opens.Sub(now); d > 0 && (opensIn < 0 || d < opensIn) {
+			opensIn = d
+		}
+	}
+	return false, opensIn
+}
+
+// maintenanceWindowDay returns true if the window opens on the day of the week.
+func maintenanceWindowDay(days []time.Weekday, day time.Weekday) bool {
+	if len(days) == 0 {
+		return true
+	}
+	for _, d := range days {
+		if d == day {
+			return true
+		}
+	}
+	return false
+}
+
+// publishDeferredDrains publishes the listeners of the irKey whose change is deferred.
+func (r *Runner) publishDeferredDrains(irKey string, deferred *message.DeferredDrains) {
+	if r.ProviderResources == nil {
+		return
+	}
+
+	if deferred == nil {
+		if _, ok := r.ProviderResources.DeferredDrains.Load(irKey); ok {
+			r.ProviderResources.DeferredDrains.Delete(irKey)
+		}
+		return
+	}
+	r.ProviderResources.DeferredDrains.Store(irKey, deferred)
+}
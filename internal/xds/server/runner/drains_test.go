@@ -0,0 +1,198 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package runner
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	clusterv3 "github.com/envoyproxy/go-control-plane/envoy/config/cluster/v3"
+	corev3 "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	listenerv3 "github.com/envoyproxy/go-control-plane/envoy/config/listener/v3"
+	"github.com/envoyproxy/go-control-plane/pkg/cache/types"
+	resourcev3 "github.com/envoyproxy/go-control-plane/pkg/resource/v3"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/envoyproxy/gateway/internal/ir"
+	"github.com/envoyproxy/gateway/internal/message"
+	xdstypes "github.com/envoyproxy/gateway/internal/xds/types"
+)
+
+func drainListener(name string, port uint32, filterChains ...string) *listenerv3.Listener {
+	listener := &listenerv3.Listener{Name: name, Address: socketAddress("0.0.0.0", port)}
+	for _, fc := range filterChains {
+		listener.FilterChains = append(listener.FilterChains, &listenerv3.FilterChain{Name: fc})
+	}
+	return listener
+}
+
+func drainResources(clusters []string, listeners ...*listenerv3.Listener) xdstypes.XdsResources {
+	resources := xdstypes.XdsResources{}
+	for _, l := range listeners {
+		resources[resourcev3.ListenerType] = append(resources[resourcev3.ListenerType], l)
+	}
+	for _, c := range clusters {
+		resources[resourcev3.ClusterType] = append(resources[resourcev3.ClusterType], &clusterv3.Cluster{Name: c})
+	}
+	return resources
+}
+
+// servedListeners returns the port and filter chains of the listeners, by name.
+func servedListeners(resources xdstypes.XdsResources) map[string]string {
+	listeners := make(map[string]string)
+	for name, l := range newServedResources(resources).listeners {
+		summary := fmt.Sprint(l.GetAddress().GetSocketAddress().GetPortValue())
+		for _, fc := range l.FilterChains {
+			summary += "/" + fc.Name
+		}
+		listeners[name] = summary
+	}
+	return listeners
+}
+
+func TestClassifyListenerChange(t *testing.T) {
+	listener := drainListener("http", 10080, "a", "b")
+
+	testCases := []struct {
+		name     string
+		previous *listenerv3.Listener
+		next     *listenerv3.Listener
+		expected listenerChange
+	}{
+		{name: "unchanged", previous: listener, next: drainListener("http", 10080, "a", "b"), expected: listenerUnchanged},
+		{name: "added", next: listener, expected: listenerInPlace},
+		{name: "removed", previous: listener, expected: listenerDrain},
+		{name: "address changed", previous: listener, next: drainListener("http", 10081, "a", "b"), expected: listenerDrain},
+		{name: "filter chain added", previous: listener, next: drainListener("http", 10080, "a", "b", "c"), expected: listenerInPlace},
+		{name: "filter chains reordered", previous: listener, next: drainListener("http", 10080, "b", "a"), expected: listenerInPlace},
+		{name: "filter chain removed", previous: listener, next: drainListener("http", 10080, "a"), expected: listenerFilterChainDrain},
+		{
+			name:     "socket options changed",
+			previous: listener,
+			next: func() *listenerv3.Listener {
+				l := drainListener("http", 10080, "a", "b")
+				l.SocketOptions = []*corev3.SocketOption{{Level: 1, Name: 2}}
+				return l
+			}(),
+			expected: listenerDrain,
+		},
+		{
+			name:     "default filter chain changed",
+			previous: listener,
+			next: func() *listenerv3.Listener {
+				l := drainListener("http", 10080, "a", "b")
+				l.DefaultFilterChain = &listenerv3.FilterChain{Name: "default"}
+				return l
+			}(),
+			expected: listenerInPlace,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.expected, classifyListenerChange(tc.previous, tc.next))
+		})
+	}
+}
+
+func TestMaintenanceWindowState(t *testing.T) {
+	// Every Saturday and Sunday from 22:00 to 02:00 UTC.
+	window := &ir.MaintenanceWindow{
+		Start:    metav1.Duration{Duration: 22 * time.Hour},
+		Duration: metav1.Duration{Duration: 4 * time.Hour},
+		Days:     []time.Weekday{time.Saturday, time.Sunday},
+	}
+
+	// 2024-01-06 is a Saturday.
+	open, opensIn := maintenanceWindowState(window, time.Date(2024, 1, 6, 21, 0, 0, 0, time.UTC))
+	require.False(t, open)
+	require.Equal(t, time.Hour, opensIn)
+
+	open, _ = maintenanceWindowState(window, time.Date(2024, 1, 6, 23, 0, 0, 0, time.UTC))
+	require.True(t, open)
+
+	// The window opened on Sunday is still open on Monday morning.
+	open, _ = maintenanceWindowState(window, time.Date(2024, 1, 8, 1, 0, 0, 0, time.UTC))
+	require.True(t, open)
+
+	open, opensIn = maintenanceWindowState(window, time.Date(2024, 1, 8, 2, 0, 0, 0, time.UTC))
+	require.False(t, open)
+	require.Equal(t, 5*24*time.Hour+20*time.Hour, opensIn)
+
+	// The window opens every day if no day is set, in UTC.
+	window.Days = nil
+	open, opensIn = maintenanceWindowState(window, time.Date(2024, 1, 8, 20, 0, 0, 0, time.FixedZone("CET", 3600)))
+	require.False(t, open)
+	require.Equal(t, 3*time.Hour, opensIn)
+}
+
+func TestDrainDeferrer(t *testing.T) {
+	settings := &ir.DeferDrains{
+		MaintenanceWindow: ir.MaintenanceWindow{
+			Start:    metav1.Duration{Duration: 2 * time.Hour},
+			Duration: metav1.Duration{Duration: time.Hour},
+		},
+	}
+	outside := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	inside := time.Date(2024, 1, 2, 2, 30, 0, 0, time.UTC)
+
+	d := newDrainDeferrer()
+	initial := drainResources([]string{"backend", "old-backend"},
+		drainListener("http", 10080, "a"), drainListener("https", 10443, "a", "b"), drainListener("tcp", 10090, "a"))
+
+	// The initial resources are served as is.
+	served, deferred, _ := d.apply("default/eg", initial, settings, outside)
+	require.Equal(t, servedListeners(initial), servedListeners(served))
+	require.Nil(t, deferred)
+
+	// Outside of the maintenance window, the changes draining listeners are deferred, while the
+	// changes only draining filter chains and the changes applied in place are served.
+	update := drainResources([]string{"backend", "new-backend"},
+		drainListener("http", 10081, "a"), drainListener("https", 10443, "a"), drainListener("udp", 10053, "a"))
+	served, deferred, opensIn := d.apply("default/eg", update, settings, outside)
+	require.Equal(t, &message.DeferredDrains{
+		Listeners: []string{"http", "tcp"},
+		Until:     outside.Add(14 * time.Hour),
+	}, deferred)
+	require.Equal(t, 14*time.Hour, opensIn)
+	require.Equal(t, map[string]string{
+		"http":  "10080/a",
+		"https": "10443/a",
+		"tcp":   "10090/a",
+		"udp":   "10053/a",
+	}, servedListeners(served))
+	require.ElementsMatch(t, []string{"backend", "new-backend", "old-backend"}, clusterNames(served[resourcev3.ClusterType]))
+	// The resources of the update are left untouched.
+	require.Len(t, update[resourcev3.ListenerType], 3)
+
+	// The changes draining filter chains are deferred too in the FilterChain scope.
+	settings.FilterChains = true
+	update2 := drainResources([]string{"backend", "new-backend"},
+		drainListener("http", 10081, "a"), drainListener("udp", 10053, "b"))
+	_, deferred, _ = d.apply("default/eg", update2, settings, outside)
+	require.Equal(t, []string{"http", "https", "tcp"}, deferred.Listeners)
+	require.Equal(t, []string{"udp"}, deferred.FilterChainListeners)
+
+	// All the changes are served once the maintenance window opens.
+	served, deferred, opensIn = d.apply("default/eg", update2, settings, inside)
+	require.Nil(t, deferred)
+	require.Zero(t, opensIn)
+	require.Equal(t, map[string]string{"http": "10081/a", "udp": "10053/b"}, servedListeners(served))
+
+	// All the changes are served without maintenance window.
+	served, deferred, _ = d.apply("default/eg", initial, nil, outside)
+	require.Nil(t, deferred)
+	require.Equal(t, servedListeners(initial), servedListeners(served))
+}
+
+func clusterNames(resources []types.Resource) []string {
+	var names []string
+	for _, r := range resources {
+		names = append(names, r.(*clusterv3.Cluster).Name)
+	}
+	return names
+}
@@ -0,0 +1,170 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package runner
+
+import (
+	"errors"
+	"maps"
+	"reflect"
+	"slices"
+
+	endpointv3 "github.com/envoyproxy/go-control-plane/envoy/config/endpoint/v3"
+	cachetypes "github.com/envoyproxy/go-control-plane/pkg/cache/types"
+	cachev3 "github.com/envoyproxy/go-control-plane/pkg/cache/v3"
+	resourcev3 "github.com/envoyproxy/go-control-plane/pkg/resource/v3"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/envoyproxy/gateway/internal/xds/cache"
+	xdstypes "github.com/envoyproxy/gateway/internal/xds/types"
+)
+
+// publishedResources holds the resources last published for an irKey, and its node scopes.
+type publishedResources struct {
+	resources xdstypes.XdsResources
+	scopes    []xdstypes.NodeScope
+}
+
+// generateSnapshot publishes the resources of the irKey. If only the endpoints of some
+// clusters changed since the resources last published, only these endpoints are updated,
+// so that the proxies are not sent all the resources again when only the pod IPs of the
+// backends changed. Likewise, if only some routes, clusters and endpoints changed, and the
+// resources are not scoped to node groups, only these resources are updated. Otherwise, a
+// new snapshot is generated.
+func (r *Runner) generateSnapshot(irKey string, resources xdstypes.XdsResources, scopes []xdstypes.NodeScope) error {
+	if r.published == nil {
+		r.published = make(map[string]publishedResources)
+	}
+
+	if previous, ok := r.published[irKey]; ok {
+		if endpoints, ok := changedEndpoints(previous, resources, scopes); ok && len(endpoints) > 0 {
+			// The endpoints of all the clusters are updated in a single snapshot, so that the
+			// proxies are pushed the endpoints once.
+			err := r.cacheFor(irKey).UpdateResources(irKey, map[resourcev3.Type]map[string]cachetypes.Resource{
+				resourcev3.EndpointType: endpoints,
+			})
+			if err == nil {
+				r.published[irKey] = publishedResources{resources: resources, scopes: scopes}
+				return nil
+			}
+			// The snapshot was evicted: generate a new one.
+			r.Logger.V(1).Info("failed to update the endpoints, generating a new snapshot", "irKey", irKey, "error", err.Error())
+		} else if updates, ok := changedResources(previous, resources, scopes); ok && len(updates) > 0 {
+			err := r.cacheFor(irKey).UpdateResources(irKey, updates)
+			if err == nil {
+				r.publishInconsistencies(irKey, nil)
+				r.published[irKey] = publishedResources{resources: resources, scopes: scopes}
+				return nil
+			}
+			// The snapshot was evicted, scoped or inconsistent: generate a new one, which
+			// reports the inconsistencies.
+			r.Logger.V(1).Info("failed to update the resources, generating a new snapshot", "irKey", irKey, "error", err.Error())
+		}
+	}
+
+	if err := r.cacheFor(irKey).GenerateNewScopedSnapshot(irKey, resources, scopes); err != nil {
+		delete(r.published, irKey)
+		var inconsistent *cache.InconsistentSnapshotError
+		if errors.As(err, &inconsistent) {
+			r.publishInconsistencies(irKey, inconsistent.Problems)
+		}
+		return err
+	}
+	r.publishInconsistencies(irKey, nil)
+	r.published[irKey] = publishedResources{resources: resources, scopes: scopes}
+	return nil
+}
+
+// changedEndpoints returns the endpoints of the clusters that changed between the
+// published and the current resources, nil for the removed ones. It returns false if
+// any other resource or the node scopes changed.
+func changedEndpoints(published publishedResources, resources xdstypes.XdsResources, scopes []xdstypes.NodeScope) (map[string]cachetypes.Resource, bool) {
+	if !reflect.DeepEqual(published.scopes, scopes) {
+		return nil, false
+	}
+	for typeURL, rs := range resources {
+		if typeURL != resourcev3.EndpointType && !sameResources(published.resources[typeURL], rs) {
+			return nil, false
+		}
+	}
+	for typeURL, rs := range published.resources {
+		if _, ok := resources[typeURL]; !ok && len(rs) > 0 {
+			return nil, false
+		}
+	}
+
+	changed := changedByName(published.resources[resourcev3.EndpointType], resources[resourcev3.EndpointType])
+	for _, r := range changed {
+		if _, ok := r.(*endpointv3.ClusterLoadAssignment); r != nil && !ok {
+			return nil, false
+		}
+	}
+	return changed, true
+}
+
+// changedResources returns the resources that changed between the published and the
+// current resources by type and name, nil for the removed ones. It returns false if the
+// resources are scoped to node groups, or if resources of a type that can't be updated in
+// place changed.
+func changedResources(published publishedResources, resources xdstypes.XdsResources, scopes []xdstypes.NodeScope) (map[resourcev3.Type]map[string]cachetypes.Resource, bool) {
+	if len(published.scopes) > 0 || len(scopes) > 0 {
+		return nil, false
+	}
+	changed := make(map[resourcev3.Type]map[string]cachetypes.Resource)
+	for _, typeURL := range typeURLs(published.resources, resources) {
+		if sameResources(published.resources[typeURL], resources[typeURL]) {
+			continue
+		}
+		if !slices.Contains(cache.UpdatableResourceTypes, typeURL) {
+			return nil, false
+		}
+		changed[typeURL] = changedByName(published.resources[typeURL], resources[typeURL])
+	}
+	return changed, true
+}
+
+// typeURLs returns the sorted type URLs of the resources of either table.
+func typeURLs(a, b xdstypes.XdsResources) []resourcev3.Type {
+	urls := slices.Collect(maps.Keys(a))
+	for typeURL := range b {
+		if _, ok := a[typeURL]; !ok {
+			urls = append(urls, typeURL)
+		}
+	}
+	slices.Sort(urls)
+	return urls
+}
+
+// changedByName returns the resources that changed between the previous and the current
+// resources by name, nil for the removed ones.
+func changedByName(previous, current []cachetypes.Resource) map[string]cachetypes.Resource {
+	indexed := cachev3.IndexRawResourcesByName(previous)
+	changed := make(map[string]cachetypes.Resource)
+	for _, r := range current {
+		name := cachev3.GetResourceName(r)
+		if p, ok := indexed[name]; !ok || !proto.Equal(p, r) {
+			changed[name] = r
+		}
+		delete(indexed, name)
+	}
+	for name := range indexed {
+		changed[name] = nil
+	}
+	return changed
+}
+
+// sameResources returns whether the resources are the same, regardless of their order.
+func sameResources(a, b []cachetypes.Resource) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	indexed := cachev3.IndexRawResourcesByName(a)
+	for _, r := range b {
+		if p, ok := indexed[cachev3.GetResourceName(r)]; !ok || !proto.Equal(p, r) {
+			return false
+		}
+	}
+	return true
+}
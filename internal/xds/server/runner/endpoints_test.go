@@ -0,0 +1,196 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package runner
+
+import (
+	"context"
+	"testing"
+
+	clusterv3 "github.com/envoyproxy/go-control-plane/envoy/config/cluster/v3"
+	corev3 "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	endpointv3 "github.com/envoyproxy/go-control-plane/envoy/config/endpoint/v3"
+	listenerv3 "github.com/envoyproxy/go-control-plane/envoy/config/listener/v3"
+	discoveryv3 "github.com/envoyproxy/go-control-plane/envoy/service/discovery/v3"
+	"github.com/envoyproxy/go-control-plane/pkg/cache/types"
+	resourcev3 "github.com/envoyproxy/go-control-plane/pkg/resource/v3"
+	"github.com/stretchr/testify/require"
+
+	egv1a1 "github.com/envoyproxy/gateway/api/v1alpha1"
+	"github.com/envoyproxy/gateway/internal/envoygateway/config"
+	"github.com/envoyproxy/gateway/internal/logging"
+	"github.com/envoyproxy/gateway/internal/xds/cache"
+	xdstypes "github.com/envoyproxy/gateway/internal/xds/types"
+)
+
+func loadAssignment(cluster string, hosts ...string) *endpointv3.ClusterLoadAssignment {
+	cla := &endpointv3.ClusterLoadAssignment{ClusterName: cluster}
+	for _, host := range hosts {
+		cla.Endpoints = append(cla.Endpoints, &endpointv3.LocalityLbEndpoints{
+			LbEndpoints: []*endpointv3.LbEndpoint{{
+				HostIdentifier: &endpointv3.LbEndpoint_Endpoint{Endpoint: &endpointv3.Endpoint{Address: socketAddress(host, 8080)}},
+			}},
+		})
+	}
+	return cla
+}
+
+func gatewayResources(listener string, endpoints ...*endpointv3.ClusterLoadAssignment) xdstypes.XdsResources {
+	resources := xdstypes.XdsResources{
+		resourcev3.ListenerType: []types.Resource{&listenerv3.Listener{Name: listener}},
+	}
+	for _, cla := range endpoints {
+		resources[resourcev3.ClusterType] = append(resources[resourcev3.ClusterType], &clusterv3.Cluster{Name: cla.ClusterName})
+		resources[resourcev3.EndpointType] = append(resources[resourcev3.EndpointType], cla)
+	}
+	return resources
+}
+
+func TestChangedEndpoints(t *testing.T) {
+	published := publishedResources{
+		resources: gatewayResources("http", loadAssignment("backend", "10.0.0.1"), loadAssignment("other", "10.0.1.1")),
+	}
+
+	testCases := []struct {
+		name      string
+		resources xdstypes.XdsResources
+		scopes    []xdstypes.NodeScope
+		expected  map[string]*endpointv3.ClusterLoadAssignment
+		ok        bool
+	}{
+		{
+			name:      "unchanged",
+			resources: gatewayResources("http", loadAssignment("other", "10.0.1.1"), loadAssignment("backend", "10.0.0.1")),
+			expected:  map[string]*endpointv3.ClusterLoadAssignment{},
+			ok:        true,
+		},
+		{
+			name:      "endpoints changed",
+			resources: gatewayResources("http", loadAssignment("backend", "10.0.0.2"), loadAssignment("other", "10.0.1.1")),
+			expected:  map[string]*endpointv3.ClusterLoadAssignment{"backend": loadAssignment("backend", "10.0.0.2")},
+			ok:        true,
+		},
+		{
+			name:      "listener changed",
+			resources: gatewayResources("https", loadAssignment("backend", "10.0.0.2"), loadAssignment("other", "10.0.1.1")),
+		},
+		{
+			name:      "cluster removed",
+			resources: gatewayResources("http", loadAssignment("backend", "10.0.0.1")),
+		},
+		{
+			name:      "node scopes changed",
+			resources: gatewayResources("http", loadAssignment("backend", "10.0.0.2"), loadAssignment("other", "10.0.1.1")),
+			scopes:    []xdstypes.NodeScope{{Name: "edge"}},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			endpoints, ok := changedEndpoints(published, tc.resources, tc.scopes)
+			require.Equal(t, tc.ok, ok)
+			if !tc.ok {
+				return
+			}
+			require.Len(t, endpoints, len(tc.expected))
+			for name, cla := range tc.expected {
+				require.Equal(t, cla.String(), endpoints[name].(*endpointv3.ClusterLoadAssignment).String())
+			}
+		})
+	}
+
+	// The endpoints of a cluster removed without removing the cluster are removed.
+	resources := gatewayResources("http", loadAssignment("backend", "10.0.0.1"), loadAssignment("other", "10.0.1.1"))
+	resources[resourcev3.EndpointType] = resources[resourcev3.EndpointType][:1]
+	endpoints, ok := changedEndpoints(published, resources, nil)
+	require.True(t, ok)
+	require.Equal(t, map[string]types.Resource{"other": nil}, endpoints)
+}
+
+func TestGenerateSnapshotUpdatesEndpoints(t *testing.T) {
+	const irKey = "default/gateway-1"
+
+	r := New(&Config{Server: config.Server{Logger: logging.DefaultLogger(egv1a1.LogLevelInfo)}})
+	r.cache = cache.NewSnapshotCache(true, r.Logger)
+	node := &corev3.Node{Id: "envoy", Cluster: irKey}
+	require.NoError(t, r.cache.OnStreamOpen(context.Background(), 1, resourcev3.EndpointType))
+	require.NoError(t, r.cache.OnStreamRequest(1, &discoveryv3.DiscoveryRequest{Node: node, TypeUrl: resourcev3.EndpointType}))
+
+	versions := func() (string, string) {
+		snapshot, err := r.cache.GetSnapshot("envoy")
+		require.NoError(t, err)
+		return snapshot.GetVersion(resourcev3.ListenerType), snapshot.GetVersion(resourcev3.EndpointType)
+	}
+
+	require.NoError(t, r.generateSnapshot(irKey, gatewayResources("http", loadAssignment("backend", "10.0.0.1"), loadAssignment("other", "10.0.1.1")), nil))
+	listenerVersion, endpointVersion := versions()
+	require.Equal(t, "1", listenerVersion)
+	require.Equal(t, "1", endpointVersion)
+
+	// Only the version of the endpoints is bumped when only the endpoints changed, once for
+	// all the changed clusters.
+	require.NoError(t, r.generateSnapshot(irKey, gatewayResources("http", loadAssignment("backend", "10.0.0.2"), loadAssignment("other", "10.0.1.2")), nil))
+	listenerVersion, endpointVersion = versions()
+	require.Equal(t, "1", listenerVersion)
+	require.Equal(t, "2", endpointVersion)
+
+	// A new snapshot is generated when another resource changed.
+	require.NoError(t, r.generateSnapshot(irKey, gatewayResources("https", loadAssignment("backend", "10.0.0.2"), loadAssignment("other", "10.0.1.2")), nil))
+	listenerVersion, endpointVersion = versions()
+	require.Equal(t, "3", listenerVersion)
+	require.Equal(t, "3", endpointVersion)
+}
+
+func TestChangedResources(t *testing.T) {
+	published := publishedResources{
+		resources: gatewayResources("http", loadAssignment("backend", "10.0.0.1")),
+	}
+
+	// The added, changed and removed clusters and endpoints are updated.
+	updates, ok := changedResources(published, gatewayResources("http", loadAssignment("other", "10.0.1.1")), nil)
+	require.True(t, ok)
+	require.Equal(t, map[string]types.Resource{"backend": nil, "other": &clusterv3.Cluster{Name: "other"}}, updates[resourcev3.ClusterType])
+	require.Len(t, updates[resourcev3.EndpointType], 2)
+	require.Nil(t, updates[resourcev3.EndpointType]["backend"])
+	require.Len(t, updates, 2)
+
+	// The listeners are not updated in place.
+	_, ok = changedResources(published, gatewayResources("https", loadAssignment("backend", "10.0.0.1")), nil)
+	require.False(t, ok)
+
+	// Nor are the resources scoped to node groups.
+	_, ok = changedResources(published, gatewayResources("http", loadAssignment("other", "10.0.1.1")), []xdstypes.NodeScope{{Name: "edge"}})
+	require.False(t, ok)
+}
+
+func TestGenerateSnapshotUpdatesResources(t *testing.T) {
+	const irKey = "default/gateway-1"
+
+	r := New(&Config{Server: config.Server{Logger: logging.DefaultLogger(egv1a1.LogLevelInfo)}})
+	r.cache = cache.NewSnapshotCache(true, r.Logger)
+	node := &corev3.Node{Id: "envoy", Cluster: irKey}
+	require.NoError(t, r.cache.OnStreamOpen(context.Background(), 1, resourcev3.ClusterType))
+	require.NoError(t, r.cache.OnStreamRequest(1, &discoveryv3.DiscoveryRequest{Node: node, TypeUrl: resourcev3.ClusterType}))
+
+	versions := func() (string, string) {
+		snapshot, err := r.cache.GetSnapshot("envoy")
+		require.NoError(t, err)
+		return snapshot.GetVersion(resourcev3.ListenerType), snapshot.GetVersion(resourcev3.ClusterType)
+	}
+
+	require.NoError(t, r.generateSnapshot(irKey, gatewayResources("http", loadAssignment("backend", "10.0.0.1")), nil))
+	listenerVersion, clusterVersion := versions()
+	require.Equal(t, "1", listenerVersion)
+	require.Equal(t, "1", clusterVersion)
+
+	// Only the version of the clusters and endpoints is bumped when only they changed.
+	require.NoError(t, r.generateSnapshot(irKey, gatewayResources("http", loadAssignment("other", "10.0.1.1")), nil))
+	listenerVersion, clusterVersion = versions()
+	require.Equal(t, "1", listenerVersion)
+	require.Equal(t, "2", clusterVersion)
+	snapshot, err := r.cache.GetSnapshot("envoy")
+	require.NoError(t, err)
+	require.Contains(t, snapshot.GetResources(resourcev3.ClusterType), "other")
+	require.NotContains(t, snapshot.GetResources(resourcev3.ClusterType), "backend")
+}
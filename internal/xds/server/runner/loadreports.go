@@ -0,0 +1,408 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package runner
+
+import (
+	"errors"
+	"io"
+	"math"
+	"net"
+	"strconv"
+	"sync"
+	"time"
+
+	endpointv3 "github.com/envoyproxy/go-control-plane/envoy/config/endpoint/v3"
+	loadstatsv3 "github.com/envoyproxy/go-control-plane/envoy/service/load_stats/v3"
+	"github.com/envoyproxy/go-control-plane/pkg/cache/types"
+	cachev3 "github.com/envoyproxy/go-control-plane/pkg/cache/v3"
+	resourcev3 "github.com/envoyproxy/go-control-plane/pkg/resource/v3"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+
+	"github.com/envoyproxy/gateway/internal/ir"
+	xdstypes "github.com/envoyproxy/gateway/internal/xds/types"
+)
+
+const (
+	// defaultLoadReportingInterval is the interval of the load reports of the
+	// proxies whose settings are not known yet.
+	defaultLoadReportingInterval = 10 * time.Second
+	// weightScale scales the weight factors of the endpoints to integer weights.
+	weightScale = 100
+	// minWeightFactor and maxWeightFactor bound the weight factors of the endpoints,
+	// so that an endpoint is neither starved nor flooded based on a single report.
+	minWeightFactor = 0.1
+	maxWeightFactor = 10
+	// weightChangeTolerance is the relative change of a weight factor below which
+	// the weights are not published again.
+	weightChangeTolerance = 0.1
+)
+
+// clusterLoads holds the load of the endpoints of the clusters, by cluster name
+// and endpoint address.
+type clusterLoads map[string]map[string]float64
+
+// loadReports serves the Load Reporting Service (LRS) the proxies report the load of the
+// upstream endpoints with. In the Balance mode, the load balancing weights of the endpoints
+// of a cluster are adjusted inversely to their load relative to the other endpoints.
+type loadReports struct {
+	// republish publishes the latest update of the irKey again once the weights of its
+	// endpoints changed.
+	republish func(irKey string)
+	// connected returns whether the node is connected to the xDS server as a proxy of the
+	// irKey, which the load reports are only recorded from.
+	connected func(irKey, nodeID string) bool
+
+	mu sync.Mutex
+	// settings holds the load reporting settings of each irKey.
+	settings map[string]*ir.LoadReporting
+	// loads holds the load reported by each node of an irKey, by node ID.
+	loads map[string]map[string]clusterLoads
+	// weights holds the weight factors applied to the endpoints of each irKey.
+	weights map[string]clusterLoads
+	// clusters holds the names of the clusters served to the proxies of each irKey, which
+	// the load is only recorded for.
+	clusters map[string]map[string]bool
+	// detector is the anomaly detector the requests of the clusters are streamed to,
+	// if enabled.
+	detector *anomalyDetector
+}
+
+func newLoadReports(republish func(irKey string), connected func(irKey, nodeID string) bool) *loadReports {
+	return &loadReports{
+		republish: republish,
+		connected: connected,
+		settings:  make(map[string]*ir.LoadReporting),
+		loads:     make(map[string]map[string]clusterLoads),
+		weights:   make(map[string]clusterLoads),
+		clusters:  make(map[string]map[string]bool),
+	}
+}
+
+var _ loadstatsv3.LoadReportingServiceServer = &loadReports{}
+
+// StreamLoadStats receives the load reports of a proxy. The proxy is asked to report the
+// load of all its clusters at the interval of its irKey, with the endpoint granularity.
+// The reports are ignored until the node is connected to the xDS server as a proxy of the
+// irKey it claims, so that a client can't skew the weights of the endpoints of a Gateway.
+func (l *loadReports) StreamLoadStats(stream loadstatsv3.LoadReportingService_StreamLoadStatsServer) error {
+	var (
+		nodeID, irKey string
+		interval      time.Duration
+		connected     bool
+	)
+	defer func() {
+		if nodeID != "" {
+			l.forgetNode(irKey, nodeID)
+		}
+	}()
+
+	for {
+		req, err := stream.Recv()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+			return err
+		}
+
+		if nodeID == "" {
+			nodeID, irKey = req.GetNode().GetId(), req.GetNode().GetCluster()
+			if nodeID == "" {
+				return status.Error(codes.InvalidArgument, "the first load report must identify the node")
+			}
+		}
+		if !connected {
+			connected = l.connected(irKey, nodeID)
+		}
+		if connected && len(req.ClusterStats) > 0 {
+			l.record(irKey, nodeID, req.ClusterStats)
+		}
+
+		// The interval is sent again if the settings of the irKey changed since.
+		if current := l.interval(irKey); current != interval {
+			interval = current
+			if err := stream.Send(&loadstatsv3.LoadStatsResponse{
+				SendAllClusters:           true,
+				LoadReportingInterval:     durationpb.New(interval),
+				ReportEndpointGranularity: true,
+			}); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// setSettings sets the load reporting settings of the irKey, or forgets the irKey if nil.
+func (l *loadReports) setSettings(irKey string, settings *ir.LoadReporting) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if settings == nil {
+		delete(l.settings, irKey)
+		delete(l.loads, irKey)
+		delete(l.weights, irKey)
+		delete(l.clusters, irKey)
+		return
+	}
+	l.settings[irKey] = settings
+	if !settings.Balance {
+		delete(l.weights, irKey)
+	}
+}
+
+// interval returns the interval of the load reports of the irKey.
+func (l *loadReports) interval(irKey string) time.Duration {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if settings, ok := l.settings[irKey]; ok {
+		return settings.Interval.Duration
+	}
+	return defaultLoadReportingInterval
+}
+
+// record records the load reported by the node, and publishes the latest update of the
+// irKey again if the weights of its endpoints changed. The requests of the clusters are
+// streamed to the anomaly detector, if enabled. The stats of the clusters not served to the
+// proxies of the irKey are ignored.
+func (l *loadReports) record(irKey, nodeID string, stats []*endpointv3.ClusterStats) {
+	l.mu.Lock()
+	settings, ok := l.settings[irKey]
+	if !ok {
+		l.mu.Unlock()
+		return
+	}
+
+	served := make([]*endpointv3.ClusterStats, 0, len(stats))
+	for _, cluster := range stats {
+		if l.clusters[irKey][cluster.ClusterName] {
+			served = append(served, cluster)
+		}
+	}
+	stats = served
+	loads := make(clusterLoads)
+	for _, cluster := range stats {
+		for _, locality := range cluster.UpstreamLocalityStats {
+			for _, endpoint := range locality.UpstreamEndpointStats {
+				load, ok := endpointLoad(endpoint, settings.Metric)
+				if !ok {
+					continue
+				}
+				if loads[cluster.ClusterName] == nil {
+					loads[cluster.ClusterName] = make(map[string]float64)
+				}
+				loads[cluster.ClusterName][endpointAddress(endpoint)] = load
+			}
+		}
+	}
+	loadReportsTotal.With(irKeyLabel.Value(irKey)).Increment()
+	if l.loads[irKey] == nil {
+		l.loads[irKey] = make(map[string]clusterLoads)
+	}
+	l.loads[irKey][nodeID] = loads
+
+	changed := l.updateWeights(irKey)
+	l.mu.Unlock()
+
+	if l.detector != nil && len(stats) > 0 {
+		l.detector.observe(irKey, stats)
+	}
+	if changed {
+		l.republish(irKey)
+	}
+}
+
+// forgetNode forgets the load reported by the disconnected node.
+func (l *loadReports) forgetNode(irKey, nodeID string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	delete(l.loads[irKey], nodeID)
+}
+
+// updateWeights updates the weight factors of the endpoints of the irKey from the load
+// reported by its nodes, and returns true if they changed enough to be published again.
+// The caller must hold the lock.
+func (l *loadReports) updateWeights(irKey string) bool {
+	// Average the load reported by the nodes for each endpoint.
+	sums := make(clusterLoads)
+	counts := make(map[string]map[string]int)
+	for _, loads := range l.loads[irKey] {
+		for cluster, endpoints := range loads {
+			if sums[cluster] == nil {
+				sums[cluster] = make(map[string]float64)
+				counts[cluster] = make(map[string]int)
+			}
+			for address, load := range endpoints {
+				sums[cluster][address] += load
+				counts[cluster][address]++
+			}
+		}
+	}
+
+	weights := make(clusterLoads)
+	for cluster, endpoints := range sums {
+		var mean float64
+		for address := range endpoints {
+			endpoints[address] /= float64(counts[cluster][address])
+			mean += endpoints[address]
+		}
+		mean /= float64(len(endpoints))
+		clusterReportedLoad.With(irKeyLabel.Value(irKey), clusterLabel.Value(cluster)).Record(mean)
+
+		// Weights are only relevant between several endpoints under load.
+		if len(endpoints) < 2 || mean <= 0 {
+			continue
+		}
+		// Smooth the factors so that an idle endpoint isn't weighted infinitely.
+		epsilon := mean / 10
+		weights[cluster] = make(map[string]float64, len(endpoints))
+		for address, load := range endpoints {
+			factor := math.Min(math.Max((mean+epsilon)/(load+epsilon), minWeightFactor), maxWeightFactor)
+			weights[cluster][address] = factor
+		}
+	}
+
+	if !l.settings[irKey].Balance {
+		return false
+	}
+	if !weightsChanged(l.weights[irKey], weights) {
+		return false
+	}
+	l.weights[irKey] = weights
+	return true
+}
+
+// apply returns the resources of the irKey with the load balancing weights of the
+// endpoints scaled by their weight factors, and records the clusters served to the proxies
+// of the irKey.
+func (l *loadReports) apply(irKey string, resources xdstypes.XdsResources) xdstypes.XdsResources {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	clusters := make(map[string]bool)
+	for _, r := range resources[resourcev3.ClusterType] {
+		clusters[cachev3.GetResourceName(r)] = true
+	}
+	for _, r := range resources[resourcev3.EndpointType] {
+		clusters[cachev3.GetResourceName(r)] = true
+	}
+	l.clusters[irKey] = clusters
+
+	weights := l.weights[irKey]
+	if len(weights) == 0 {
+		return resources
+	}
+
+	// Don't modify the resources of the update, which are published again once
+	// the weights change.
+	served := make(xdstypes.XdsResources, len(resources))
+	for typeURL, rs := range resources {
+		served[typeURL] = rs
+	}
+	endpoints := make([]types.Resource, 0, len(resources[resourcev3.EndpointType]))
+	for _, r := range resources[resourcev3.EndpointType] {
+		cla := r.(*endpointv3.ClusterLoadAssignment)
+		if factors, ok := weights[cla.ClusterName]; ok {
+			cla = weightedClusterLoadAssignment(cla, factors)
+			clusterWeightFactorSpread.With(irKeyLabel.Value(irKey), clusterLabel.Value(cla.ClusterName)).
+				Record(weightFactorSpread(factors))
+		}
+		endpoints = append(endpoints, cla)
+	}
+	served[resourcev3.EndpointType] = endpoints
+
+	return served
+}
+
+// weightedClusterLoadAssignment returns a copy of the cluster load assignment with the
+// weights of the endpoints scaled by their factors. The endpoints without factor, such as
+// the endpoints added since the last load report, keep their relative weight.
+func weightedClusterLoadAssignment(cla *endpointv3.ClusterLoadAssignment, factors map[string]float64) *endpointv3.ClusterLoadAssignment {
+	weighted := proto.Clone(cla).(*endpointv3.ClusterLoadAssignment)
+	for _, locality := range weighted.Endpoints {
+		for _, lbEndpoint := range locality.LbEndpoints {
+			sa := lbEndpoint.GetEndpoint().GetAddress().GetSocketAddress()
+			if sa == nil {
+				continue
+			}
+			factor, ok := factors[net.JoinHostPort(sa.Address, strconv.FormatUint(uint64(sa.GetPortValue()), 10))]
+			if !ok {
+				factor = 1
+			}
+			weight := uint32(1)
+			if lbEndpoint.LoadBalancingWeight != nil {
+				weight = lbEndpoint.LoadBalancingWeight.Value
+			}
+			lbEndpoint.LoadBalancingWeight = wrapperspb.UInt32(uint32(math.Max(1, math.Round(float64(weight)*weightScale*factor))))
+		}
+	}
+	return weighted
+}
+
+// weightFactorSpread returns the ratio of the largest to the smallest weight factor.
+func weightFactorSpread(factors map[string]float64) float64 {
+	lowest, highest := math.Inf(1), 0.0
+	for _, factor := range factors {
+		lowest, highest = math.Min(lowest, factor), math.Max(highest, factor)
+	}
+	return highest / lowest
+}
+
+// endpointLoad returns the load reported for the endpoint, which is the average value of
+// the metric if set, or the number of requests in progress.
+func endpointLoad(endpoint *endpointv3.UpstreamEndpointStats, metric string) (float64, bool) {
+	if metric == "" {
+		return float64(endpoint.TotalRequestsInProgress), true
+	}
+	for _, stats := range endpoint.LoadMetricStats {
+		if stats.MetricName == metric && stats.NumRequestsFinishedWithMetric > 0 {
+			return stats.TotalMetricValue / float64(stats.NumRequestsFinishedWithMetric), true
+		}
+	}
+	return 0, false
+}
+
+// endpointAddress returns the address of the endpoint in the host:port format.
+func endpointAddress(endpoint *endpointv3.UpstreamEndpointStats) string {
+	sa := endpoint.GetAddress().GetSocketAddress()
+	return net.JoinHostPort(sa.GetAddress(), strconv.FormatUint(uint64(sa.GetPortValue()), 10))
+}
+
+// weightsChanged returns true if a weight factor changed by more than the tolerance.
+func weightsChanged(old, new clusterLoads) bool {
+	if len(old) != len(new) {
+		return true
+	}
+	for cluster, factors := range new {
+		oldFactors, ok := old[cluster]
+		if !ok || len(oldFactors) != len(factors) {
+			return true
+		}
+		for address, factor := range factors {
+			oldFactor, ok := oldFactors[address]
+			if !ok || math.Abs(factor-oldFactor) > weightChangeTolerance*oldFactor {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// connectedNode returns whether the node is connected to the xDS server as a proxy of the
+// irKey.
+func (r *Runner) connectedNode(irKey, nodeID string) bool {
+	for _, node := range r.cacheFor(irKey).ConnectedNodes() {
+		if node.NodeID == nodeID && node.Cluster == irKey {
+			return true
+		}
+	}
+	return false
+}
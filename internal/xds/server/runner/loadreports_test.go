@@ -0,0 +1,166 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package runner
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	corev3 "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	endpointv3 "github.com/envoyproxy/go-control-plane/envoy/config/endpoint/v3"
+	loadstatsv3 "github.com/envoyproxy/go-control-plane/envoy/service/load_stats/v3"
+	"github.com/envoyproxy/go-control-plane/pkg/cache/types"
+	resourcev3 "github.com/envoyproxy/go-control-plane/pkg/resource/v3"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/envoyproxy/gateway/internal/ir"
+	xdstypes "github.com/envoyproxy/gateway/internal/xds/types"
+)
+
+func socketAddress(host string, port uint32) *corev3.Address {
+	return &corev3.Address{
+		Address: &corev3.Address_SocketAddress{
+			SocketAddress: &corev3.SocketAddress{
+				Address:       host,
+				PortSpecifier: &corev3.SocketAddress_PortValue{PortValue: port},
+			},
+		},
+	}
+}
+
+func clusterStats(cluster string, inProgress map[string]uint64) []*endpointv3.ClusterStats {
+	locality := &endpointv3.UpstreamLocalityStats{}
+	for host, requests := range inProgress {
+		locality.UpstreamEndpointStats = append(locality.UpstreamEndpointStats, &endpointv3.UpstreamEndpointStats{
+			Address:                 socketAddress(host, 8080),
+			TotalRequestsInProgress: requests,
+		})
+	}
+	return []*endpointv3.ClusterStats{{ClusterName: cluster, UpstreamLocalityStats: []*endpointv3.UpstreamLocalityStats{locality}}}
+}
+
+func endpointResources(cluster string, hosts ...string) xdstypes.XdsResources {
+	cla := &endpointv3.ClusterLoadAssignment{
+		ClusterName: cluster,
+		Endpoints:   []*endpointv3.LocalityLbEndpoints{{}},
+	}
+	for _, host := range hosts {
+		cla.Endpoints[0].LbEndpoints = append(cla.Endpoints[0].LbEndpoints, &endpointv3.LbEndpoint{
+			HostIdentifier: &endpointv3.LbEndpoint_Endpoint{
+				Endpoint: &endpointv3.Endpoint{Address: socketAddress(host, 8080)},
+			},
+		})
+	}
+	return xdstypes.XdsResources{resourcev3.EndpointType: []types.Resource{cla}}
+}
+
+func servedWeights(resources xdstypes.XdsResources) map[string]uint32 {
+	weights := make(map[string]uint32)
+	for _, r := range resources[resourcev3.EndpointType] {
+		for _, locality := range r.(*endpointv3.ClusterLoadAssignment).Endpoints {
+			for _, lbEndpoint := range locality.LbEndpoints {
+				weights[lbEndpoint.GetEndpoint().GetAddress().GetSocketAddress().GetAddress()] = lbEndpoint.GetLoadBalancingWeight().GetValue()
+			}
+		}
+	}
+	return weights
+}
+
+func TestLoadReports(t *testing.T) {
+	const (
+		irKey   = "default/gateway-1"
+		cluster = "httproute/default/backend/rule/0"
+	)
+
+	republished := 0
+	l := newLoadReports(func(string) { republished++ }, func(string, string) bool { return true })
+	resources := endpointResources(cluster, "10.0.0.1", "10.0.0.2", "10.0.0.3")
+
+	// Reports of unknown irKeys are ignored.
+	l.record(irKey, "envoy-1", clusterStats(cluster, map[string]uint64{"10.0.0.1": 30, "10.0.0.2": 10}))
+	require.Zero(t, republished)
+	require.Equal(t, defaultLoadReportingInterval, l.interval(irKey))
+
+	// The load is only reported in the Report mode, for the clusters served to the proxies.
+	l.setSettings(irKey, &ir.LoadReporting{Interval: metav1.Duration{Duration: time.Second}})
+	require.Equal(t, time.Second, l.interval(irKey))
+	require.Equal(t, resources, l.apply(irKey, resources))
+	l.record(irKey, "envoy-1", clusterStats("unknown", map[string]uint64{"10.0.0.1": 30}))
+	require.Empty(t, l.loads[irKey]["envoy-1"])
+	l.record(irKey, "envoy-1", clusterStats(cluster, map[string]uint64{"10.0.0.1": 30, "10.0.0.2": 10}))
+	require.Zero(t, republished)
+	require.Equal(t, resources, l.apply(irKey, resources))
+
+	// The weights are adjusted inversely to the load averaged across the proxies in the
+	// Balance mode, and the endpoints without report keep their relative weight.
+	l.setSettings(irKey, &ir.LoadReporting{Interval: metav1.Duration{Duration: time.Second}, Balance: true})
+	l.record(irKey, "envoy-1", clusterStats(cluster, map[string]uint64{"10.0.0.1": 30, "10.0.0.2": 10}))
+	require.Equal(t, 1, republished)
+	l.record(irKey, "envoy-2", clusterStats(cluster, map[string]uint64{"10.0.0.1": 30, "10.0.0.2": 10}))
+	require.Equal(t, 1, republished)
+	require.Equal(t, map[string]uint32{"10.0.0.1": 69, "10.0.0.2": 183, "10.0.0.3": 100}, servedWeights(l.apply(irKey, resources)))
+	require.Equal(t, map[string]uint32{"10.0.0.1": 0, "10.0.0.2": 0, "10.0.0.3": 0}, servedWeights(resources))
+
+	// The weights are reset once the endpoints are idle.
+	l.record(irKey, "envoy-1", clusterStats(cluster, map[string]uint64{"10.0.0.1": 0, "10.0.0.2": 0}))
+	l.forgetNode(irKey, "envoy-2")
+	l.record(irKey, "envoy-1", clusterStats(cluster, map[string]uint64{"10.0.0.1": 0, "10.0.0.2": 0}))
+	require.Equal(t, 2, republished)
+	require.Equal(t, resources, l.apply(irKey, resources))
+}
+
+func TestStreamLoadStatsConnectedNodes(t *testing.T) {
+	const (
+		irKey   = "default/gateway-1"
+		cluster = "httproute/default/backend/rule/0"
+	)
+
+	l := newLoadReports(func(string) {}, func(key, nodeID string) bool { return key == irKey && nodeID == "envoy-1" })
+	l.setSettings(irKey, &ir.LoadReporting{Interval: metav1.Duration{Duration: time.Second}})
+	l.apply(irKey, endpointResources(cluster, "10.0.0.1"))
+
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	g := grpc.NewServer()
+	loadstatsv3.RegisterLoadReportingServiceServer(g, l)
+	go func() { _ = g.Serve(lis) }()
+	t.Cleanup(g.Stop)
+	conn, err := grpc.NewClient(lis.Addr().String(), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = conn.Close() })
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	report := func(nodeID, cluster string) {
+		reports, err := loadstatsv3.NewLoadReportingServiceClient(conn).StreamLoadStats(ctx)
+		require.NoError(t, err)
+		require.NoError(t, reports.Send(&loadstatsv3.LoadStatsRequest{
+			Node:         &corev3.Node{Id: nodeID, Cluster: cluster},
+			ClusterStats: clusterStats("httproute/default/backend/rule/0", map[string]uint64{"10.0.0.1": 30}),
+		}))
+		_, err = reports.Recv()
+		require.NoError(t, err)
+	}
+	loads := func(nodeID string) clusterLoads {
+		l.mu.Lock()
+		defer l.mu.Unlock()
+		return l.loads[irKey][nodeID]
+	}
+
+	// The reports of the nodes not connected as proxies of the irKey they claim are ignored.
+	report("envoy-2", irKey)
+	require.Nil(t, loads("envoy-2"))
+	report("envoy-1", "default/gateway-2")
+	require.Nil(t, loads("envoy-1"))
+
+	report("envoy-1", irKey)
+	require.Equal(t, clusterLoads{cluster: {"10.0.0.1:8080": 30}}, loads("envoy-1"))
+}
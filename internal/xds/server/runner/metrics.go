@@ -0,0 +1,116 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package runner
+
+import "github.com/envoyproxy/gateway/internal/metrics"
+
+var (
+	secretRotationsInProgress = metrics.NewGauge(
+		"xds_secret_rotations_in_progress",
+		"Current number of secret rotations not acknowledged by all the proxies yet.",
+	)
+
+	secretRotationStartedTotal = metrics.NewCounter(
+		"xds_secret_rotation_started_total",
+		"Total number of secret rotations started.",
+	)
+
+	secretRotationCompletedTotal = metrics.NewCounter(
+		"xds_secret_rotation_completed_total",
+		"Total number of secret rotations acknowledged by all the proxies.",
+	)
+
+	secretRotationDurationSeconds = metrics.NewHistogram(
+		"xds_secret_rotation_duration_seconds",
+		"How long a secret rotation takes to be acknowledged by all the proxies.",
+		[]float64{1, 10, 60, 300, 600, 1800, 3600},
+	)
+
+	loadReportsTotal = metrics.NewCounter(
+		"xds_load_reports_total",
+		"Total number of load reports received from the proxies.",
+	)
+
+	clusterReportedLoad = metrics.NewGauge(
+		"xds_cluster_reported_load",
+		"Load of the upstream endpoints of the clusters reported by the proxies, averaged across the endpoints and the proxies.",
+	)
+
+	clusterWeightFactorSpread = metrics.NewGauge(
+		"xds_cluster_weight_factor_spread",
+		"Ratio of the largest to the smallest factor applied to the load balancing weights of the endpoints of the clusters based on their reported load.",
+	)
+
+	xdsStreamUnauthorizedTotal = metrics.NewCounter(
+		"xds_stream_unauthorized_total",
+		"Total number of streams of the xds server refused because their node is not authorized for its cluster.",
+	)
+
+	auditLogRecordsTotal = metrics.NewCounter(
+		"xds_audit_log_records_total",
+		"Total number of xds exchanges written to the audit log.",
+	)
+
+	auditLogDroppedTotal = metrics.NewCounter(
+		"xds_audit_log_dropped_total",
+		"Total number of xds exchanges sampled for the audit log that couldn't be written.",
+	)
+
+	trafficRecordedTotal = metrics.NewCounter(
+		"xds_traffic_recorded_total",
+		"Total number of requests of the proxies recorded to be replayed.",
+	)
+
+	trafficRecordingDroppedTotal = metrics.NewCounter(
+		"xds_traffic_recording_dropped_total",
+		"Total number of requests logged by the proxies whose traffic isn't recorded.",
+	)
+
+	listenerUpdatesTotal = metrics.NewCounter(
+		"xds_listener_updates_total",
+		"Total number of listener changes served to the proxies, by their effect on the connections.",
+	)
+
+	deferredListenerDrains = metrics.NewGauge(
+		"xds_deferred_listener_drains",
+		"Current number of listeners whose change drains connections and is deferred to the maintenance window.",
+	)
+
+	xdsResponseBytesTotal = metrics.NewCounter(
+		"xds_response_bytes_total",
+		"Total size of the xds responses served to the proxies, before compression.",
+	)
+
+	xdsResponseCompressedBytesTotal = metrics.NewCounter(
+		"xds_response_compressed_bytes_total",
+		"Total size of the xds responses served to the proxies, after compression.",
+	)
+
+	xdsStreamSendQueueDepth = metrics.NewGauge(
+		"xds_stream_send_queue_depth",
+		"Current number of xds responses waiting to be sent to a proxy.",
+	)
+
+	xdsStreamResetsTotal = metrics.NewCounter(
+		"xds_stream_resets_total",
+		"Total number of xds streams reset because a response was not sent to the proxy within the send timeout.",
+	)
+
+	anomalyDetectionReportsTotal = metrics.NewCounter(
+		"xds_anomaly_detection_reports_total",
+		"Total number of reports of the requests of the clusters sent to the anomaly detector.",
+	)
+
+	anomalyDetectionDegradationsTotal = metrics.NewCounter(
+		"xds_anomaly_detection_degradations_total",
+		"Total number of clusters degraded by the anomaly detector.",
+	)
+
+	irKeyLabel   = metrics.NewLabel("irKey")
+	nodeIDLabel  = metrics.NewLabel("nodeID")
+	clusterLabel = metrics.NewLabel("cluster")
+	changeLabel  = metrics.NewLabel("change")
+)
@@ -0,0 +1,178 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package runner
+
+import (
+	"sync"
+	"time"
+
+	corev3 "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// streamPacer sends the xDS responses of each stream from a goroutine of its own, so that
+// the responses are queued instead of blocking the snapshot cache while a slow proxy
+// receives them, and resets the streams whose responses wait longer than the send timeout.
+type streamPacer struct {
+	// sendTimeout is how long a response may wait to be sent, zero for no limit.
+	sendTimeout time.Duration
+}
+
+func (p *streamPacer) intercept(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	if !isDiscoveryMethod(info.FullMethod) {
+		return handler(srv, ss)
+	}
+
+	ps := newPacedStream(ss, p.sendTimeout)
+	go ps.send()
+	defer ps.close()
+
+	done := make(chan error, 1)
+	go func() { done <- handler(srv, ps) }()
+	select {
+	case err := <-done:
+		return err
+	case <-ps.reset:
+		// Returning ends the stream, which unblocks the handler and the pending send.
+		xdsStreamResetsTotal.With(nodeIDLabel.Value(ps.nodeID())).Increment()
+		return status.Errorf(codes.DeadlineExceeded, "xds response not sent to node %s within %s", ps.nodeID(), p.sendTimeout)
+	}
+}
+
+// queuedResponse is a response waiting to be sent.
+type queuedResponse struct {
+	msg    any
+	queued time.Time
+}
+
+// pacedStream queues the messages sent on the stream, which are sent by send.
+type pacedStream struct {
+	grpc.ServerStream
+	sendTimeout time.Duration
+
+	mu      sync.Mutex
+	node    string
+	queue   []queuedResponse
+	err     error
+	closed  bool
+	pending chan struct{}
+
+	reset     chan struct{}
+	resetOnce sync.Once
+}
+
+func newPacedStream(ss grpc.ServerStream, sendTimeout time.Duration) *pacedStream {
+	return &pacedStream{
+		ServerStream: ss,
+		sendTimeout:  sendTimeout,
+		pending:      make(chan struct{}, 1),
+		reset:        make(chan struct{}),
+	}
+}
+
+// RecvMsg records the ID of the node of the stream, which is only set in its first request.
+func (s *pacedStream) RecvMsg(m any) error {
+	if err := s.ServerStream.RecvMsg(m); err != nil {
+		return err
+	}
+	if req, ok := m.(interface{ GetNode() *corev3.Node }); ok && req.GetNode() != nil {
+		s.mu.Lock()
+		if s.node == "" {
+			s.node = req.GetNode().GetId()
+		}
+		s.mu.Unlock()
+	}
+	return nil
+}
+
+// SendMsg queues the message, failing with the error of a previous message.
+func (s *pacedStream) SendMsg(m any) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.err != nil {
+		return s.err
+	}
+	s.queue = append(s.queue, queuedResponse{msg: m, queued: time.Now()})
+	s.recordDepth()
+	select {
+	case s.pending <- struct{}{}:
+	default:
+	}
+	return nil
+}
+
+// send sends the queued messages in order until the stream is closed or fails.
+func (s *pacedStream) send() {
+	for range s.pending {
+		for {
+			s.mu.Lock()
+			if s.closed || s.err != nil || len(s.queue) == 0 {
+				s.mu.Unlock()
+				break
+			}
+			next := s.queue[0]
+			s.mu.Unlock()
+
+			var timer *time.Timer
+			if s.sendTimeout > 0 {
+				timer = time.AfterFunc(time.Until(next.queued.Add(s.sendTimeout)), s.resetStream)
+			}
+			err := s.ServerStream.SendMsg(next.msg)
+			if timer != nil {
+				timer.Stop()
+			}
+
+			s.mu.Lock()
+			// The queue is dropped if the stream was closed while sending.
+			if len(s.queue) > 0 {
+				s.queue = s.queue[1:]
+			}
+			if err != nil {
+				s.err = err
+			}
+			s.recordDepth()
+			s.mu.Unlock()
+		}
+	}
+}
+
+// resetStream resets the stream once its pending response timed out.
+func (s *pacedStream) resetStream() {
+	s.resetOnce.Do(func() { close(s.reset) })
+}
+
+// close drops the queued messages and stops send. The messages sent by the handler
+// once closed, when it outlives a reset stream, fail.
+func (s *pacedStream) close() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.closed {
+		return
+	}
+	s.closed = true
+	if s.err == nil {
+		s.err = status.Error(codes.Canceled, "xds stream closed")
+	}
+	s.queue = nil
+	s.recordDepth()
+	close(s.pending)
+}
+
+// nodeID returns the ID of the node of the stream.
+func (s *pacedStream) nodeID() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.node
+}
+
+// recordDepth records the number of queued messages, the lock being held.
+func (s *pacedStream) recordDepth() {
+	if s.node != "" {
+		xdsStreamSendQueueDepth.With(nodeIDLabel.Value(s.node)).Record(float64(len(s.queue)))
+	}
+}
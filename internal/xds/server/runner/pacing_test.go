@@ -0,0 +1,89 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package runner
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	corev3 "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	discoveryv3 "github.com/envoyproxy/go-control-plane/envoy/service/discovery/v3"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// slowStream is a server stream whose sends block until unblocked.
+type slowStream struct {
+	grpc.ServerStream
+	ctx     context.Context
+	unblock chan struct{}
+	sent    chan any
+}
+
+func newSlowStream(ctx context.Context) *slowStream {
+	return &slowStream{ctx: ctx, unblock: make(chan struct{}), sent: make(chan any, 10)}
+}
+
+func (s *slowStream) Context() context.Context { return s.ctx }
+
+func (s *slowStream) RecvMsg(m any) error {
+	m.(*discoveryv3.DiscoveryRequest).Node = &corev3.Node{Id: "slow-node"}
+	return nil
+}
+
+func (s *slowStream) SendMsg(m any) error {
+	select {
+	case <-s.unblock:
+	case <-s.ctx.Done():
+		return s.ctx.Err()
+	}
+	s.sent <- m
+	return nil
+}
+
+func TestStreamPacer(t *testing.T) {
+	info := &grpc.StreamServerInfo{FullMethod: "/envoy.service.discovery.v3.AggregatedDiscoveryService/StreamAggregatedResources"}
+	responses := []*discoveryv3.DiscoveryResponse{{Nonce: "1"}, {Nonce: "2"}}
+
+	t.Run("responses are queued", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		ss := newSlowStream(ctx)
+		p := &streamPacer{}
+
+		err := p.intercept(nil, ss, info, func(_ any, stream grpc.ServerStream) error {
+			require.NoError(t, stream.RecvMsg(&discoveryv3.DiscoveryRequest{}))
+			for _, resp := range responses {
+				// The sends return while the proxy is slow.
+				require.NoError(t, stream.SendMsg(resp))
+			}
+			close(ss.unblock)
+			for _, resp := range responses {
+				require.Equal(t, resp, <-ss.sent)
+			}
+			return nil
+		})
+		require.NoError(t, err)
+	})
+
+	t.Run("stream is reset after the send timeout", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		ss := newSlowStream(ctx)
+		p := &streamPacer{sendTimeout: 50 * time.Millisecond}
+
+		err := p.intercept(nil, ss, info, func(_ any, stream grpc.ServerStream) error {
+			require.NoError(t, stream.RecvMsg(&discoveryv3.DiscoveryRequest{}))
+			require.NoError(t, stream.SendMsg(responses[0]))
+			<-ctx.Done()
+			return nil
+		})
+		require.Equal(t, codes.DeadlineExceeded, status.Code(err))
+		cancel()
+	})
+}
@@ -0,0 +1,193 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package runner
+
+import (
+	"context"
+	"path/filepath"
+	"time"
+
+	cachev3 "github.com/envoyproxy/go-control-plane/pkg/cache/v3"
+	"k8s.io/utils/ptr"
+
+	egv1a1 "github.com/envoyproxy/gateway/api/v1alpha1"
+	"github.com/envoyproxy/gateway/internal/message"
+	"github.com/envoyproxy/gateway/internal/xds/cache"
+)
+
+// isolatedPersistenceDir is the subdirectory of the snapshot persistence directory the
+// snapshots of the isolated Gateways are persisted to, in a directory of each Gateway.
+const isolatedPersistenceDir = "isolated"
+
+// defaultRolloutSoakPeriod is how long the canaries must acknowledge a new snapshot before
+// it is rolled out to the other proxies, unless configured.
+const defaultRolloutSoakPeriod = time.Minute
+
+// defaultDriftWindow is how long a proxy may stay behind the configuration it is served
+// before it is reported as drifted, unless configured.
+const defaultDriftWindow = 5 * time.Minute
+
+// cachePartition is the partition of the snapshot cache of an isolated Gateway, served
+// on a port of its own, so that its snapshots are generated and served under a lock of
+// their own.
+type cachePartition struct {
+	port  int32
+	cache cache.SnapshotCacheWithCallbacks
+}
+
+// newCachePartitions creates the partitions of the snapshot cache of the isolated Gateways.
+func (r *Runner) newCachePartitions(ctx context.Context) error {
+	if r.EnvoyGateway == nil || r.EnvoyGateway.XdsServer == nil {
+		return nil
+	}
+	for _, g := range r.EnvoyGateway.XdsServer.IsolatedGateways {
+		irKey := string(g.Namespace) + "/" + string(g.Name)
+		partitionCache, err := r.newSnapshotCache(ctx, irKey)
+		if err != nil {
+			return err
+		}
+		if r.partitions == nil {
+			r.partitions = make(map[string]*cachePartition)
+		}
+		r.partitions[irKey] = &cachePartition{port: g.Port, cache: partitionCache}
+	}
+	return nil
+}
+
+// newSnapshotCache returns a snapshot cache of the partition configured as set in the
+// EnvoyGateway config. The snapshots of the partition of an isolated Gateway, named
+// after its irKey, are persisted to a directory of their own.
+func (r *Runner) newSnapshotCache(ctx context.Context, partition string) (cache.SnapshotCacheWithCallbacks, error) {
+	cacheOpts := []cache.Option{
+		cache.WithPartition(partition),
+		cache.WithTracerProvider(message.TracerProvider()),
+		cache.WithResponseExpiry(ctx, cache.DefaultResponseExpiry),
+	}
+	if r.EnvoyGateway != nil && r.EnvoyGateway.SnapshotCache != nil && r.EnvoyGateway.SnapshotCache.ResourceTTL != nil {
+		cacheOpts = append(cacheOpts, resourceTTLOption(ctx, r.EnvoyGateway.SnapshotCache.ResourceTTL))
+	}
+	if r.auditor != nil {
+		cacheOpts = append(cacheOpts, cache.WithExchangeAuditor(r.auditor))
+	}
+	if r.EnvoyGateway != nil && r.EnvoyGateway.SnapshotCache != nil && ptr.Deref(r.EnvoyGateway.SnapshotCache.ValidateConsistency, false) {
+		cacheOpts = append(cacheOpts, cache.WithConsistencyCheck())
+	}
+	if r.EnvoyGateway != nil && r.EnvoyGateway.SnapshotCache != nil && r.EnvoyGateway.SnapshotCache.NodeHash != nil {
+		cacheOpts = append(cacheOpts, cache.WithNodeHash(nodeHash(r.EnvoyGateway.SnapshotCache.NodeHash)))
+	}
+	if r.EnvoyGateway != nil && r.EnvoyGateway.SnapshotCache != nil && r.EnvoyGateway.SnapshotCache.StaleNodeTimeout != nil {
+		// The timeout has been validated with the EnvoyGateway configuration.
+		if timeout, err := time.ParseDuration(string(*r.EnvoyGateway.SnapshotCache.StaleNodeTimeout)); err == nil {
+			cacheOpts = append(cacheOpts, cache.WithStaleNodeEviction(ctx, timeout))
+		}
+	}
+	if r.EnvoyGateway != nil && r.EnvoyGateway.SnapshotCache != nil && r.EnvoyGateway.SnapshotCache.StagedRollout != nil {
+		cacheOpts = append(cacheOpts, stagedRolloutOption(ctx, r.EnvoyGateway.SnapshotCache.StagedRollout))
+	}
+	if r.EnvoyGateway != nil && r.EnvoyGateway.SnapshotCache != nil && r.EnvoyGateway.SnapshotCache.PushPacing != nil {
+		cacheOpts = append(cacheOpts, pushPacingOption(ctx, r.EnvoyGateway.SnapshotCache.PushPacing))
+	}
+	if r.EnvoyGateway != nil && r.EnvoyGateway.SnapshotCache != nil && r.EnvoyGateway.SnapshotCache.DriftDetection != nil {
+		cacheOpts = append(cacheOpts, driftDetectionOption(ctx, r.EnvoyGateway.SnapshotCache.DriftDetection))
+	}
+	c := cache.NewSnapshotCache(true, r.Logger, cacheOpts...)
+	c.SetListenerAckHandler(r.publishPendingListeners)
+	c.SetNackHandler(r.publishNacks)
+	c.SetDriftHandler(r.publishDrifts)
+	if r.rotator != nil {
+		c.SetSecretAckHandler(r.rotator.acknowledged)
+	}
+	if r.EnvoyGateway == nil || r.EnvoyGateway.SnapshotCache == nil {
+		return c, nil
+	}
+
+	if limit := r.EnvoyGateway.SnapshotCache.MemoryLimit; limit != nil {
+		c.SetMemoryLimit(limit.Value(), r.handleSnapshotEviction)
+	}
+	if history := r.EnvoyGateway.SnapshotCache.History; history != nil {
+		c.SetHistorySize(int(*history))
+	}
+	// Restore the persisted snapshots once the cache is configured, so that
+	// they are tracked against its memory limit.
+	if persistence := r.EnvoyGateway.SnapshotCache.Persistence; persistence != nil {
+		dir := persistence.Path
+		if partition != cache.DefaultPartition {
+			dir = filepath.Join(dir, isolatedPersistenceDir, filepath.FromSlash(partition))
+		}
+		if err := c.SetPersistenceDir(dir); err != nil {
+			return nil, err
+		}
+	}
+	return c, nil
+}
+
+// stagedRolloutOption returns the option rolling the snapshots out in stages as configured.
+func stagedRolloutOption(ctx context.Context, cfg *egv1a1.EnvoyGatewayStagedRollout) cache.Option {
+	soakPeriod := defaultRolloutSoakPeriod
+	// The soak period has been validated with the EnvoyGateway configuration.
+	if cfg.SoakPeriod != nil {
+		if d, err := time.ParseDuration(string(*cfg.SoakPeriod)); err == nil {
+			soakPeriod = d
+		}
+	}
+	return cache.WithStagedRollout(ctx, cfg.CanaryPercentage, soakPeriod)
+}
+
+// driftDetectionOption returns the option detecting the drift of the proxies as configured.
+func driftDetectionOption(ctx context.Context, cfg *egv1a1.EnvoyGatewayDriftDetection) cache.Option {
+	window := defaultDriftWindow
+	// The window has been validated with the EnvoyGateway configuration.
+	if cfg.Window != nil {
+		if d, err := time.ParseDuration(string(*cfg.Window)); err == nil {
+			window = d
+		}
+	}
+	return cache.WithDriftDetection(ctx, window)
+}
+
+// pushPacingOption returns the option pacing the pushes of the snapshots as configured.
+func pushPacingOption(ctx context.Context, cfg *egv1a1.EnvoyGatewayPushPacing) cache.Option {
+	var jitter time.Duration
+	// The jitter has been validated with the EnvoyGateway configuration.
+	if cfg.Jitter != nil {
+		if d, err := time.ParseDuration(string(*cfg.Jitter)); err == nil {
+			jitter = d
+		}
+	}
+	return cache.WithPushPacing(ctx, cfg.MaxNodeUpdatesPerSecond, jitter)
+}
+
+// nodeHash returns the hash keying the snapshots of the nodes in the snapshot cache,
+// which is the ID of the nodes for the NodeID type.
+func nodeHash(cfg *egv1a1.EnvoyGatewayNodeHash) cachev3.NodeHash {
+	switch cfg.Type {
+	case egv1a1.NodeHashTypeCluster:
+		return cache.ClusterHash{}
+	case egv1a1.NodeHashTypeMetadata:
+		return cache.MetadataHash{Key: ptr.Deref(cfg.MetadataKey, "")}
+	default:
+		return cachev3.IDHash{}
+	}
+}
+
+// cacheFor returns the snapshot cache the snapshots of the irKey are generated in, which
+// is the partition of the irKey if its Gateway is isolated.
+func (r *Runner) cacheFor(irKey string) cache.SnapshotCacheWithCallbacks {
+	if partition, ok := r.partitions[irKey]; ok {
+		return partition.cache
+	}
+	return r.cache
+}
+
+// SnapshotCachePartitions returns the partitions of the snapshot cache of the isolated
+// Gateways by irKey.
+func (r *Runner) SnapshotCachePartitions() map[string]cache.SnapshotCacheWithCallbacks {
+	partitions := make(map[string]cache.SnapshotCacheWithCallbacks, len(r.partitions))
+	for irKey, partition := range r.partitions {
+		partitions[irKey] = partition.cache
+	}
+	return partitions
+}
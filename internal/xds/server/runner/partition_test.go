@@ -0,0 +1,91 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package runner
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	corev3 "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	discoveryv3 "github.com/envoyproxy/go-control-plane/envoy/service/discovery/v3"
+	resourcev3 "github.com/envoyproxy/go-control-plane/pkg/resource/v3"
+	"github.com/stretchr/testify/require"
+
+	egv1a1 "github.com/envoyproxy/gateway/api/v1alpha1"
+	"github.com/envoyproxy/gateway/internal/envoygateway/config"
+	"github.com/envoyproxy/gateway/internal/message"
+	"github.com/envoyproxy/gateway/internal/xds/cache"
+	xdstypes "github.com/envoyproxy/gateway/internal/xds/types"
+)
+
+func TestCachePartitions(t *testing.T) {
+	cfg, err := config.New()
+	require.NoError(t, err)
+	persistenceDir := t.TempDir()
+	cfg.EnvoyGateway.SnapshotCache = &egv1a1.EnvoyGatewaySnapshotCache{
+		Persistence: &egv1a1.EnvoyGatewaySnapshotPersistence{Path: persistenceDir},
+	}
+	cfg.EnvoyGateway.XdsServer = &egv1a1.EnvoyGatewayXdsServer{
+		IsolatedGateways: []egv1a1.XdsServerIsolatedGateway{
+			{Namespace: "payments", Name: "checkout", Port: 18020},
+		},
+	}
+	r := New(&Config{Server: *cfg})
+	r.cache, err = r.newSnapshotCache(context.Background(), cache.DefaultPartition)
+	require.NoError(t, err)
+	require.NoError(t, r.newCachePartitions(context.Background()))
+	require.Contains(t, r.SnapshotCachePartitions(), "payments/checkout")
+
+	publish := func(irKey string, port int32) {
+		require.NoError(t, r.publish(message.Update[string, *xdstypes.ResourceVersionTable]{
+			Key:   irKey,
+			Value: &xdstypes.ResourceVersionTable{XdsResources: gatewayResources("http"), XdsServerPort: port},
+		}))
+	}
+	publish("default/eg", 0)
+	publish("payments/checkout", 18020)
+
+	// The snapshots of the isolated Gateway are only generated in its partition.
+	require.Equal(t, []string{"default/eg"}, r.cache.IRKeys())
+	require.Equal(t, []string{"payments/checkout"}, r.cacheFor("payments/checkout").IRKeys())
+	// The snapshots are persisted in the background.
+	for _, path := range []string{
+		filepath.Join(persistenceDir, "default%2Feg.pb"),
+		filepath.Join(persistenceDir, "isolated", "payments", "checkout", "payments%2Fcheckout.pb"),
+	} {
+		require.Eventually(t, func() bool {
+			_, err := os.Stat(path)
+			return err == nil
+		}, 5*time.Second, 10*time.Millisecond)
+	}
+}
+
+func TestCacheNodeHash(t *testing.T) {
+	cfg, err := config.New()
+	require.NoError(t, err)
+	cfg.EnvoyGateway.SnapshotCache = &egv1a1.EnvoyGatewaySnapshotCache{
+		NodeHash: &egv1a1.EnvoyGatewayNodeHash{Type: egv1a1.NodeHashTypeCluster},
+	}
+	r := New(&Config{Server: *cfg})
+	r.cache, err = r.newSnapshotCache(context.Background(), cache.DefaultPartition)
+	require.NoError(t, err)
+
+	// The proxies of the Gateway share the snapshot keyed by its irKey.
+	require.NoError(t, r.publish(message.Update[string, *xdstypes.ResourceVersionTable]{
+		Key:   "default/eg",
+		Value: &xdstypes.ResourceVersionTable{XdsResources: gatewayResources("http")},
+	}))
+	require.NoError(t, r.cache.OnStreamOpen(context.Background(), 1, resourcev3.ListenerType))
+	require.NoError(t, r.cache.OnStreamRequest(1, &discoveryv3.DiscoveryRequest{
+		Node:    &corev3.Node{Id: "envoy-1", Cluster: "default/eg"},
+		TypeUrl: resourcev3.ListenerType,
+	}))
+	_, err = r.cache.GetSnapshot("default/eg")
+	require.NoError(t, err)
+}
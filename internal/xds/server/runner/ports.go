@@ -0,0 +1,115 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package runner
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	corev3 "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	discoveryv3 "github.com/envoyproxy/go-control-plane/envoy/service/discovery/v3"
+	serverv3 "github.com/envoyproxy/go-control-plane/pkg/server/v3"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/envoyproxy/gateway/internal/xds/cache"
+)
+
+// xdsServerPorts holds the port of the xDS server the proxies of each irKey are served
+// on, so that the proxies of the GatewayClasses of different controllers are isolated
+// from each other.
+type xdsServerPorts struct {
+	mu sync.RWMutex
+	// ports holds the port of each irKey, zero for the default port.
+	ports map[string]int32
+}
+
+func newXdsServerPorts() *xdsServerPorts {
+	return &xdsServerPorts{ports: make(map[string]int32)}
+}
+
+// set sets the port the proxies of the irKey are served on.
+func (p *xdsServerPorts) set(irKey string, port int32) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.ports[irKey] = port
+}
+
+// remove forgets the port of the deleted irKey.
+func (p *xdsServerPorts) remove(irKey string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	delete(p.ports, irKey)
+}
+
+// allowed returns whether the proxies of the irKey are served on the port. The proxies
+// of an irKey whose port isn't known yet are only served on the default port.
+func (p *xdsServerPorts) allowed(irKey string, port int32) bool {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	assigned, ok := p.ports[irKey]
+	if !ok {
+		return port == 0
+	}
+	return assigned == port
+}
+
+// portCallbacks rejects the streams of the proxies that aren't served on the port of
+// the xDS server, zero for the default port.
+type portCallbacks struct {
+	serverv3.Callbacks
+	port  int32
+	ports *xdsServerPorts
+}
+
+func (c *portCallbacks) OnStreamRequest(streamID int64, req *discoveryv3.DiscoveryRequest) error {
+	if err := c.check(req.GetNode()); err != nil {
+		return err
+	}
+	return c.Callbacks.OnStreamRequest(streamID, req)
+}
+
+func (c *portCallbacks) OnStreamDeltaRequest(streamID int64, req *discoveryv3.DeltaDiscoveryRequest) error {
+	if err := c.check(req.GetNode()); err != nil {
+		return err
+	}
+	return c.Callbacks.OnStreamDeltaRequest(streamID, req)
+}
+
+// check returns an error if the proxy isn't served on the port. Only the first request
+// of a stream is required to hold the node, which identifies the irKey of the proxy.
+func (c *portCallbacks) check(node *corev3.Node) error {
+	if node == nil || c.ports.allowed(node.GetCluster(), c.port) {
+		return nil
+	}
+	return status.Errorf(codes.PermissionDenied, "the proxies of %s are not served on this port", node.GetCluster())
+}
+
+// additionalXdsServerPorts returns the ports the xDS server serves the proxies of the
+// GatewayClasses of the additional controllers on.
+func (r *Runner) additionalXdsServerPorts() []int32 {
+	if r.EnvoyGateway == nil || r.EnvoyGateway.Gateway == nil {
+		return nil
+	}
+	seen := make(map[int32]bool)
+	var ports []int32
+	for _, c := range r.EnvoyGateway.Gateway.AdditionalControllers {
+		if c.XdsServerPort == nil || seen[*c.XdsServerPort] {
+			continue
+		}
+		seen[*c.XdsServerPort] = true
+		ports = append(ports, *c.XdsServerPort)
+	}
+	sort.Slice(ports, func(i, j int) bool { return ports[i] < ports[j] })
+	return ports
+}
+
+// newServer returns the xDS server of the port, zero for the default port, serving the
+// snapshots of the cache.
+func (r *Runner) newServer(ctx context.Context, port int32, snapshotCache cache.SnapshotCacheWithCallbacks) serverv3.Server {
+	return serverv3.NewServer(ctx, snapshotCache, &portCallbacks{Callbacks: snapshotCache, port: port, ports: r.ports})
+}
@@ -0,0 +1,59 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package runner
+
+import (
+	"testing"
+
+	corev3 "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	discoveryv3 "github.com/envoyproxy/go-control-plane/envoy/service/discovery/v3"
+	serverv3 "github.com/envoyproxy/go-control-plane/pkg/server/v3"
+	"github.com/stretchr/testify/require"
+	"k8s.io/utils/ptr"
+
+	egv1a1 "github.com/envoyproxy/gateway/api/v1alpha1"
+	"github.com/envoyproxy/gateway/internal/envoygateway/config"
+)
+
+func TestPortCallbacks(t *testing.T) {
+	ports := newXdsServerPorts()
+	ports.set("default/public", 0)
+	ports.set("partner/gateway", 18010)
+
+	request := func(cluster string) *discoveryv3.DiscoveryRequest {
+		return &discoveryv3.DiscoveryRequest{Node: &corev3.Node{Cluster: cluster}}
+	}
+	defaultPort := &portCallbacks{Callbacks: serverv3.CallbackFuncs{}, port: 0, ports: ports}
+	partnerPort := &portCallbacks{Callbacks: serverv3.CallbackFuncs{}, port: 18010, ports: ports}
+
+	require.NoError(t, defaultPort.OnStreamRequest(1, request("default/public")))
+	require.Error(t, defaultPort.OnStreamRequest(2, request("partner/gateway")))
+	require.NoError(t, partnerPort.OnStreamRequest(3, request("partner/gateway")))
+	require.Error(t, partnerPort.OnStreamRequest(4, request("default/public")))
+
+	// The proxies of an unknown irKey are only served on the default port.
+	require.NoError(t, defaultPort.OnStreamRequest(5, request("default/new")))
+	require.Error(t, partnerPort.OnStreamDeltaRequest(6, &discoveryv3.DeltaDiscoveryRequest{Node: &corev3.Node{Cluster: "default/new"}}))
+
+	// The requests following the first one of a stream don't hold the node.
+	require.NoError(t, partnerPort.OnStreamRequest(3, &discoveryv3.DiscoveryRequest{}))
+
+	ports.remove("partner/gateway")
+	require.Error(t, partnerPort.OnStreamRequest(7, request("partner/gateway")))
+}
+
+func TestAdditionalXdsServerPorts(t *testing.T) {
+	cfg, err := config.New()
+	require.NoError(t, err)
+	cfg.EnvoyGateway.Gateway.AdditionalControllers = []egv1a1.GatewayController{
+		{ControllerName: "example.com/partner-gateway", XdsServerPort: ptr.To[int32](18020)},
+		{ControllerName: "example.com/internal-gateway", XdsServerPort: ptr.To[int32](18010)},
+		{ControllerName: "example.com/shared-gateway", XdsServerPort: ptr.To[int32](18010)},
+		{ControllerName: "example.com/default-port-gateway"},
+	}
+	r := New(&Config{Server: *cfg})
+	require.Equal(t, []int32{18010, 18020}, r.additionalXdsServerPorts())
+}
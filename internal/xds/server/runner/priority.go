@@ -0,0 +1,51 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package runner
+
+import (
+	resourcev3 "github.com/envoyproxy/go-control-plane/pkg/resource/v3"
+
+	"github.com/envoyproxy/gateway/internal/message"
+	xdstypes "github.com/envoyproxy/gateway/internal/xds/types"
+)
+
+// highPriorityTypes are the types of the resources whose changes alone are handled ahead of
+// the other updates, so that the renewed certificates and the endpoints of the scaled
+// backends are served without waiting for the recomputation of the routes of other irKeys.
+var highPriorityTypes = map[resourcev3.Type]bool{
+	resourcev3.SecretType:   true,
+	resourcev3.EndpointType: true,
+	xdstypes.LbEndpointType: true,
+}
+
+// xdsUpdatePriority returns the priority function of the updates of the xds resources, which
+// returns the high priority for the updates only changing the secrets and the endpoints of
+// their irKey since its previous update.
+func xdsUpdatePriority() func(message.Update[string, *xdstypes.ResourceVersionTable]) message.Priority {
+	// previous holds the resources of the previous update of each irKey. It is only
+	// accessed by the goroutine reading the subscription.
+	previous := make(map[string]xdstypes.XdsResources)
+
+	return func(update message.Update[string, *xdstypes.ResourceVersionTable]) message.Priority {
+		prev, ok := previous[update.Key]
+		if update.Delete || update.Value == nil {
+			delete(previous, update.Key)
+			return message.PriorityNormal
+		}
+		resources := update.Value.XdsResources
+		previous[update.Key] = resources
+		if !ok {
+			return message.PriorityNormal
+		}
+
+		for _, typeURL := range typeURLs(prev, resources) {
+			if !highPriorityTypes[typeURL] && !sameResources(prev[typeURL], resources[typeURL]) {
+				return message.PriorityNormal
+			}
+		}
+		return message.PriorityHigh
+	}
+}
@@ -0,0 +1,77 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package runner
+
+import (
+	"testing"
+
+	tlsv3 "github.com/envoyproxy/go-control-plane/envoy/extensions/transport_sockets/tls/v3"
+	"github.com/envoyproxy/go-control-plane/pkg/cache/types"
+	resourcev3 "github.com/envoyproxy/go-control-plane/pkg/resource/v3"
+	"github.com/stretchr/testify/require"
+
+	"github.com/envoyproxy/gateway/internal/message"
+	xdstypes "github.com/envoyproxy/gateway/internal/xds/types"
+)
+
+func TestXdsUpdatePriority(t *testing.T) {
+	withSecret := func(resources xdstypes.XdsResources, name string) xdstypes.XdsResources {
+		resources[resourcev3.SecretType] = []types.Resource{&tlsv3.Secret{Name: name}}
+		return resources
+	}
+	update := func(key string, resources xdstypes.XdsResources) message.Update[string, *xdstypes.ResourceVersionTable] {
+		return message.Update[string, *xdstypes.ResourceVersionTable]{
+			Key:   key,
+			Value: &xdstypes.ResourceVersionTable{XdsResources: resources},
+		}
+	}
+
+	priority := xdsUpdatePriority()
+	steps := []struct {
+		name     string
+		update   message.Update[string, *xdstypes.ResourceVersionTable]
+		expected message.Priority
+	}{
+		{
+			name:     "first update",
+			update:   update("gateway-1", gatewayResources("http", loadAssignment("backend", "10.0.0.1"))),
+			expected: message.PriorityNormal,
+		},
+		{
+			name:     "endpoints changed",
+			update:   update("gateway-1", gatewayResources("http", loadAssignment("backend", "10.0.0.2"))),
+			expected: message.PriorityHigh,
+		},
+		{
+			name:     "secret added",
+			update:   update("gateway-1", withSecret(gatewayResources("http", loadAssignment("backend", "10.0.0.2")), "cert")),
+			expected: message.PriorityHigh,
+		},
+		{
+			name:     "listener changed",
+			update:   update("gateway-1", withSecret(gatewayResources("https", loadAssignment("backend", "10.0.0.2")), "cert")),
+			expected: message.PriorityNormal,
+		},
+		{
+			name:     "first update of another irKey",
+			update:   update("gateway-2", gatewayResources("http", loadAssignment("backend", "10.0.0.1"))),
+			expected: message.PriorityNormal,
+		},
+		{
+			name:     "deleted",
+			update:   message.Update[string, *xdstypes.ResourceVersionTable]{Key: "gateway-1", Delete: true},
+			expected: message.PriorityNormal,
+		},
+		{
+			name:     "created again",
+			update:   update("gateway-1", withSecret(gatewayResources("https", loadAssignment("backend", "10.0.0.2")), "cert")),
+			expected: message.PriorityNormal,
+		},
+	}
+	for _, step := range steps {
+		require.Equal(t, step.expected, priority(step.update), step.name)
+	}
+}
@@ -0,0 +1,293 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package runner
+
+import (
+	"bytes"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	corev3 "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	tlsv3 "github.com/envoyproxy/go-control-plane/envoy/extensions/transport_sockets/tls/v3"
+	"github.com/envoyproxy/go-control-plane/pkg/cache/types"
+	resourcev3 "github.com/envoyproxy/go-control-plane/pkg/resource/v3"
+	"google.golang.org/protobuf/proto"
+
+	egv1a1 "github.com/envoyproxy/gateway/api/v1alpha1"
+	"github.com/envoyproxy/gateway/internal/message"
+	xdstypes "github.com/envoyproxy/gateway/internal/xds/types"
+)
+
+// defaultSecretOverlapWindow is the default time the previous CA certificates
+// remain trusted after a CA certificate bundle is rotated.
+const defaultSecretOverlapWindow = 5 * time.Minute
+
+// secretRotation is the rotation of a secret served to the proxies of an irKey.
+type secretRotation struct {
+	// previous is the secret served before the rotation.
+	previous *tlsv3.Secret
+	// started is when the rotation started.
+	started time.Time
+	// overlapEnds is when the previous CA certificates stop being trusted.
+	overlapEnds time.Time
+	// version is the snapshot version serving the rotated secret alone, or zero
+	// until such a snapshot has been published.
+	version int64
+}
+
+// secretRotator coordinates the rotation of the secrets served to the proxies.
+// The CA certificates of a rotated validation context are served along with the
+// previous ones until the overlap window ends, and a rotation completes once the
+// proxies acknowledged a snapshot serving the rotated secret alone.
+type secretRotator struct {
+	overlapWindow time.Duration
+	// notify is called with the secrets of the irKey whose rotation is in
+	// progress whenever they may have changed.
+	notify func(irKey string, secrets []string)
+
+	mu sync.Mutex
+	// secrets holds the latest secrets of each irKey by name.
+	secrets map[string]map[string]*tlsv3.Secret
+	// rotations holds the rotations in progress of each irKey by secret name.
+	rotations map[string]map[string]*secretRotation
+	// acked holds the snapshot version of the secrets acknowledged by the
+	// proxies of each irKey.
+	acked map[string]int64
+}
+
+func newSecretRotator(cfg *egv1a1.EnvoyGatewaySecretRotation, notify func(irKey string, secrets []string)) *secretRotator {
+	s := &secretRotator{
+		overlapWindow: defaultSecretOverlapWindow,
+		notify:        notify,
+		secrets:       make(map[string]map[string]*tlsv3.Secret),
+		rotations:     make(map[string]map[string]*secretRotation),
+		acked:         make(map[string]int64),
+	}
+	if cfg != nil && cfg.OverlapWindow != nil {
+		if d, err := time.ParseDuration(string(*cfg.OverlapWindow)); err == nil {
+			s.overlapWindow = d
+		}
+	}
+	return s
+}
+
+// apply records the secrets of the resources of the irKey, starting the rotation of
+// the updated ones. It returns the resources to publish, with the CA certificates of
+// the rotations in their overlap window merged with the previous ones, and the time
+// until the earliest overlap window ends, or zero if none.
+func (s *secretRotator) apply(irKey string, resources xdstypes.XdsResources, now time.Time) (xdstypes.XdsResources, time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	latest := make(map[string]*tlsv3.Secret)
+	for _, r := range resources[resourcev3.SecretType] {
+		secret := r.(*tlsv3.Secret)
+		latest[secret.Name] = secret
+	}
+
+	rotations := s.rotations[irKey]
+	if rotations == nil {
+		rotations = make(map[string]*secretRotation)
+		s.rotations[irKey] = rotations
+	}
+	for name, secret := range latest {
+		previous, ok := s.secrets[irKey][name]
+		if !ok || proto.Equal(previous, secret) {
+			continue
+		}
+		// Keep trusting the CA certificates of a rotation still in its overlap window.
+		if rotation, ok := rotations[name]; ok && now.Before(rotation.overlapEnds) {
+			if merged := mergeTrustedCA(previous, rotation.previous); merged != nil {
+				previous = merged
+			}
+		}
+		rotation := &secretRotation{previous: previous, started: now, overlapEnds: now}
+		if mergeTrustedCA(secret, previous) != nil {
+			rotation.overlapEnds = now.Add(s.overlapWindow)
+		}
+		rotations[name] = rotation
+		secretRotationStartedTotal.With(irKeyLabel.Value(irKey)).Increment()
+	}
+	for name := range rotations {
+		if _, ok := latest[name]; !ok {
+			delete(rotations, name)
+		}
+	}
+	s.secrets[irKey] = latest
+	s.notifyRotations(irKey)
+
+	var overlapEnds time.Duration
+	overlapped := make(map[string]*tlsv3.Secret)
+	for name, rotation := range rotations {
+		if !now.Before(rotation.overlapEnds) {
+			continue
+		}
+		overlapped[name] = mergeTrustedCA(latest[name], rotation.previous)
+		if d := rotation.overlapEnds.Sub(now); overlapEnds == 0 || d < overlapEnds {
+			overlapEnds = d
+		}
+	}
+	if len(overlapped) == 0 {
+		return resources, 0
+	}
+
+	// Don't modify the resources of the update, which are published again once
+	// the overlap window ends.
+	served := make(xdstypes.XdsResources, len(resources))
+	for typeURL, rs := range resources {
+		served[typeURL] = rs
+	}
+	secrets := make([]types.Resource, 0, len(resources[resourcev3.SecretType]))
+	for _, r := range resources[resourcev3.SecretType] {
+		if merged, ok := overlapped[r.(*tlsv3.Secret).Name]; ok {
+			r = merged
+		}
+		secrets = append(secrets, r)
+	}
+	served[resourcev3.SecretType] = secrets
+
+	return served, overlapEnds
+}
+
+// published records the snapshot version published for the irKey. The rotations whose
+// overlap window ended are served the rotated secret alone from this version on.
+func (s *secretRotator) published(irKey, version string, now time.Time) {
+	v, err := strconv.ParseInt(version, 10, 64)
+	if err != nil {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, rotation := range s.rotations[irKey] {
+		if rotation.version == 0 && !now.Before(rotation.overlapEnds) {
+			rotation.version = v
+		}
+	}
+	s.complete(irKey, now)
+}
+
+// acknowledged records the snapshot version of the secrets acknowledged by all the
+// proxies of the irKey, completing the rotations served in that version.
+func (s *secretRotator) acknowledged(irKey, version string) {
+	v, err := strconv.ParseInt(version, 10, 64)
+	if err != nil {
+		v = 0
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.acked[irKey] = v
+	s.complete(irKey, time.Now())
+}
+
+// remove stops tracking the rotations of the deleted irKey.
+func (s *secretRotator) remove(irKey string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.secrets, irKey)
+	delete(s.acked, irKey)
+	delete(s.rotations, irKey)
+	s.notifyRotations(irKey)
+}
+
+// complete completes the rotations of the irKey served in a snapshot version
+// acknowledged by all its proxies. The caller must hold the lock.
+func (s *secretRotator) complete(irKey string, now time.Time) {
+	rotations := s.rotations[irKey]
+	completed := false
+	for name, rotation := range rotations {
+		if rotation.version == 0 || rotation.version > s.acked[irKey] {
+			continue
+		}
+		delete(rotations, name)
+		completed = true
+		secretRotationCompletedTotal.With(irKeyLabel.Value(irKey)).Increment()
+		secretRotationDurationSeconds.With(irKeyLabel.Value(irKey)).Record(now.Sub(rotation.started).Seconds())
+	}
+	if completed {
+		s.notifyRotations(irKey)
+	}
+}
+
+// notifyRotations notifies the secrets of the irKey whose rotation is in progress.
+// The caller must hold the lock.
+func (s *secretRotator) notifyRotations(irKey string) {
+	secrets := make([]string, 0, len(s.rotations[irKey]))
+	for name := range s.rotations[irKey] {
+		secrets = append(secrets, name)
+	}
+	sort.Strings(secrets)
+
+	secretRotationsInProgress.With(irKeyLabel.Value(irKey)).Record(float64(len(secrets)))
+	if s.notify != nil {
+		s.notify(irKey, secrets)
+	}
+}
+
+// mergeTrustedCA returns a copy of the validation context secret trusting the CA
+// certificates of both the secret and the previous one, or nil if either secret
+// doesn't hold inline CA certificates.
+func mergeTrustedCA(secret, previous *tlsv3.Secret) *tlsv3.Secret {
+	current := secret.GetValidationContext().GetTrustedCa().GetInlineBytes()
+	old := previous.GetValidationContext().GetTrustedCa().GetInlineBytes()
+	if len(current) == 0 || len(old) == 0 {
+		return nil
+	}
+
+	bundle := make([]byte, 0, len(current)+len(old)+1)
+	bundle = append(bundle, bytes.TrimRight(current, "\n")...)
+	bundle = append(append(bundle, '\n'), old...)
+
+	merged := proto.Clone(secret).(*tlsv3.Secret)
+	merged.GetValidationContext().TrustedCa = &corev3.DataSource{
+		Specifier: &corev3.DataSource_InlineBytes{InlineBytes: bundle},
+	}
+	return merged
+}
+
+// scheduleRepublish publishes the latest update of the irKey again after the duration.
+// The caller must hold the publish lock.
+func (r *Runner) scheduleRepublish(irKey string, after time.Duration) {
+	if r.republishTimers == nil {
+		r.republishTimers = make(map[string]*time.Timer)
+	}
+	if timer, ok := r.republishTimers[irKey]; ok {
+		timer.Stop()
+	}
+	r.republishTimers[irKey] = time.AfterFunc(after, func() {
+		r.republish(irKey)
+	})
+}
+
+// stopRepublish stops publishing the latest update of the deleted irKey again.
+// The caller must hold the publish lock.
+func (r *Runner) stopRepublish(irKey string) {
+	if timer, ok := r.republishTimers[irKey]; ok {
+		timer.Stop()
+		delete(r.republishTimers, irKey)
+	}
+}
+
+// publishSecretRotations publishes the secrets of the irKey whose rotation is in progress.
+func (r *Runner) publishSecretRotations(irKey string, secrets []string) {
+	if r.ProviderResources == nil {
+		return
+	}
+
+	if len(secrets) == 0 {
+		if _, ok := r.ProviderResources.SecretRotations.Load(irKey); ok {
+			r.ProviderResources.SecretRotations.Delete(irKey)
+		}
+		return
+	}
+	r.ProviderResources.SecretRotations.Store(irKey, &message.SecretRotations{Secrets: secrets})
+}
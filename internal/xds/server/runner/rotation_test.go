@@ -0,0 +1,120 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package runner
+
+import (
+	"testing"
+	"time"
+
+	corev3 "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	tlsv3 "github.com/envoyproxy/go-control-plane/envoy/extensions/transport_sockets/tls/v3"
+	"github.com/envoyproxy/go-control-plane/pkg/cache/types"
+	resourcev3 "github.com/envoyproxy/go-control-plane/pkg/resource/v3"
+	"github.com/stretchr/testify/require"
+	"k8s.io/utils/ptr"
+	gwapiv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	egv1a1 "github.com/envoyproxy/gateway/api/v1alpha1"
+	xdstypes "github.com/envoyproxy/gateway/internal/xds/types"
+)
+
+func caSecret(name, ca string) *tlsv3.Secret {
+	return &tlsv3.Secret{
+		Name: name,
+		Type: &tlsv3.Secret_ValidationContext{
+			ValidationContext: &tlsv3.CertificateValidationContext{
+				TrustedCa: &corev3.DataSource{
+					Specifier: &corev3.DataSource_InlineBytes{InlineBytes: []byte(ca)},
+				},
+			},
+		},
+	}
+}
+
+func certSecret(name, cert string) *tlsv3.Secret {
+	return &tlsv3.Secret{
+		Name: name,
+		Type: &tlsv3.Secret_TlsCertificate{
+			TlsCertificate: &tlsv3.TlsCertificate{
+				CertificateChain: &corev3.DataSource{
+					Specifier: &corev3.DataSource_InlineBytes{InlineBytes: []byte(cert)},
+				},
+			},
+		},
+	}
+}
+
+func secretResources(secrets ...*tlsv3.Secret) xdstypes.XdsResources {
+	resources := make([]types.Resource, 0, len(secrets))
+	for _, secret := range secrets {
+		resources = append(resources, secret)
+	}
+	return xdstypes.XdsResources{resourcev3.SecretType: resources}
+}
+
+func servedCA(t *testing.T, resources xdstypes.XdsResources, name string) string {
+	t.Helper()
+	for _, r := range resources[resourcev3.SecretType] {
+		if secret := r.(*tlsv3.Secret); secret.Name == name {
+			return string(secret.GetValidationContext().GetTrustedCa().GetInlineBytes())
+		}
+	}
+	t.Fatalf("secret %s not found", name)
+	return ""
+}
+
+func TestSecretRotator(t *testing.T) {
+	const irKey = "default/gateway-1"
+
+	inProgress := map[string][]string{}
+	s := newSecretRotator(&egv1a1.EnvoyGatewaySecretRotation{
+		OverlapWindow: ptr.To(gwapiv1.Duration("1m")),
+	}, func(irKey string, secrets []string) {
+		inProgress[irKey] = secrets
+	})
+	now := time.Now()
+
+	// New secrets aren't rotated.
+	resources, overlapEnds := s.apply(irKey, secretResources(caSecret("ca", "old-ca\n"), certSecret("cert", "old-cert")), now)
+	require.Zero(t, overlapEnds)
+	require.Equal(t, "old-ca\n", servedCA(t, resources, "ca"))
+	s.published(irKey, "1", now)
+	require.Empty(t, inProgress[irKey])
+
+	// The rotated CA is served along with the previous one during the overlap window,
+	// while the rotated certificate is served right away.
+	update := secretResources(caSecret("ca", "new-ca\n"), certSecret("cert", "new-cert"))
+	resources, overlapEnds = s.apply(irKey, update, now)
+	require.Equal(t, time.Minute, overlapEnds)
+	require.Equal(t, "new-ca\nold-ca\n", servedCA(t, resources, "ca"))
+	require.Equal(t, "new-ca\n", servedCA(t, update, "ca"))
+	s.published(irKey, "2", now)
+	require.Equal(t, []string{"ca", "cert"}, inProgress[irKey])
+
+	// The certificate rotation completes once its version is acknowledged.
+	s.acknowledged(irKey, "2")
+	require.Equal(t, []string{"ca"}, inProgress[irKey])
+
+	// The rotated CA is served alone once the overlap window ended, and the rotation
+	// completes once that version is acknowledged.
+	now = now.Add(time.Minute)
+	resources, overlapEnds = s.apply(irKey, update, now)
+	require.Zero(t, overlapEnds)
+	require.Equal(t, "new-ca\n", servedCA(t, resources, "ca"))
+	s.published(irKey, "3", now)
+	require.Equal(t, []string{"ca"}, inProgress[irKey])
+
+	s.acknowledged(irKey, "")
+	require.Equal(t, []string{"ca"}, inProgress[irKey])
+	s.acknowledged(irKey, "3")
+	require.Empty(t, inProgress[irKey])
+
+	// Rotations in progress are dropped with the irKey.
+	s.apply(irKey, secretResources(caSecret("ca", "newer-ca\n")), now)
+	require.Equal(t, []string{"ca"}, inProgress[irKey])
+	s.remove(irKey)
+	require.Empty(t, inProgress[irKey])
+}
@@ -14,16 +14,20 @@ import (
 	"net"
 	"os"
 	"strconv"
+	"sync"
 	"time"
 
+	accesslogv3 "github.com/envoyproxy/go-control-plane/envoy/service/accesslog/v3"
 	clusterv3 "github.com/envoyproxy/go-control-plane/envoy/service/cluster/v3"
 	discoveryv3 "github.com/envoyproxy/go-control-plane/envoy/service/discovery/v3"
 	endpointv3 "github.com/envoyproxy/go-control-plane/envoy/service/endpoint/v3"
 	listenerv3 "github.com/envoyproxy/go-control-plane/envoy/service/listener/v3"
+	loadstatsv3 "github.com/envoyproxy/go-control-plane/envoy/service/load_stats/v3"
 	routev3 "github.com/envoyproxy/go-control-plane/envoy/service/route/v3"
 	runtimev3 "github.com/envoyproxy/go-control-plane/envoy/service/runtime/v3"
 	secretv3 "github.com/envoyproxy/go-control-plane/envoy/service/secret/v3"
 	serverv3 "github.com/envoyproxy/go-control-plane/pkg/server/v3"
+	"go.opentelemetry.io/otel/trace"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/keepalive"
@@ -52,17 +56,81 @@ const (
 
 type Config struct {
 	config.Server
-	Xds   *message.Xds
-	grpc  *grpc.Server
-	cache cache.SnapshotCacheWithCallbacks
+	Xds               *message.Xds
+	ProviderResources *message.ProviderResources
+	grpc              *grpc.Server
+	cache             cache.SnapshotCacheWithCallbacks
+	// partitions holds the partitions of the snapshot cache of the isolated
+	// Gateways by irKey.
+	partitions map[string]*cachePartition
 }
 
 type Runner struct {
 	Config
+
+	// publishMu serializes snapshot publishing with pausing and resuming.
+	publishMu sync.Mutex
+	// paused holds the irKeys whose snapshot publishing is paused, mapped to
+	// the latest update received while paused, if any.
+	paused map[string]*message.Update[string, *xdstypes.ResourceVersionTable]
+
+	// ackMu guards waitForListenerAck.
+	ackMu sync.Mutex
+	// waitForListenerAck holds the irKeys whose Gateways are only programmed
+	// once the proxies acknowledged their listeners.
+	waitForListenerAck map[string]bool
+
+	// rotator coordinates the rotation of the secrets, if enabled.
+	rotator *secretRotator
+	// loadReports serves the load reports of the proxies.
+	loadReports *loadReports
+	// trafficRecordings records the requests of the proxies to replay them.
+	trafficRecordings *trafficRecordings
+	// drains defers the changes of the listeners draining connections to the
+	// maintenance window. Guarded by publishMu.
+	drains *drainDeferrer
+	// ports holds the port of the xDS server the proxies of each irKey are
+	// served on.
+	ports *xdsServerPorts
+	// latest holds the latest update published for each irKey, to publish
+	// it again once the served resources derived from it change, e.g. when
+	// an overlap window ends. Guarded by publishMu.
+	latest map[string]message.Update[string, *xdstypes.ResourceVersionTable]
+	// published holds the resources last published for each irKey, to only
+	// update the endpoints when no other resource changed. Guarded by publishMu.
+	published map[string]publishedResources
+	// republishTimers holds the timers publishing the latest update of
+	// each irKey again once an overlap window ends or the session ticket
+	// keys are rotated. Guarded by publishMu.
+	republishTimers map[string]*time.Timer
+	// ticketKeySeed is the seed the session ticket keys are derived from.
+	// Guarded by publishMu.
+	ticketKeySeed []byte
+	// debouncer coalesces the successive updates of each irKey, if enabled.
+	// Guarded by publishMu.
+	debouncer *snapshotDebouncer
+	// debounceErrs receives the errors of the publishing of the updates held by the
+	// debouncer, which outlive the updates they were received in. It is never closed,
+	// since a debounce timer may still fire while the runner shuts down.
+	debounceErrs chan error
+	// drained is closed once the xDS streams have been drained on shutdown.
+	drained chan struct{}
+	// auditor logs the exchanges of the snapshot caches with the proxies, if enabled.
+	auditor *exchangeAuditor
+	// authorizer authorizes the streams of the proxies for their Gateway.
+	authorizer *streamAuthorizer
+	// checkpoints schedules the checkpoints of the configuration of the proxies.
+	checkpoints *configCheckpoints
+	// anomalyDetector degrades the clusters an external detector detects an anomaly on,
+	// if enabled.
+	anomalyDetector *anomalyDetector
 }
 
 func New(cfg *Config) *Runner {
-	return &Runner{Config: *cfg}
+	return &Runner{
+		Config:       *cfg,
+		debounceErrs: make(chan error, 10),
+	}
 }
 
 func (r *Runner) Name() string {
@@ -77,25 +145,140 @@ func (r *Runner) Start(ctx context.Context) (err error) {
 	// Create SnapshotCache before start subscribeAndTranslate,
 	// prevent panics in case cache is nil.
 	cfg := r.tlsConfig(xdsTLSCertFilename, xdsTLSKeyFilename, xdsTLSCaFilename)
-	r.grpc = grpc.NewServer(grpc.Creds(credentials.NewTLS(cfg)), grpc.KeepaliveEnforcementPolicy(keepalive.EnforcementPolicy{
-		MinTime:             15 * time.Second,
-		PermitWithoutStream: true,
-	}))
 
-	r.cache = cache.NewSnapshotCache(true, r.Logger)
-	registerServer(serverv3.NewServer(ctx, r.cache, r.cache), r.grpc)
+	if r.EnvoyGateway != nil && r.EnvoyGateway.SecretRotation != nil {
+		r.rotator = newSecretRotator(r.EnvoyGateway.SecretRotation, r.publishSecretRotations)
+	}
+	if r.EnvoyGateway != nil && r.EnvoyGateway.SnapshotCache != nil && r.EnvoyGateway.SnapshotCache.Debounce != nil {
+		r.debouncer = newSnapshotDebouncer(r.EnvoyGateway.SnapshotCache.Debounce, r.publishDebounced)
+		go message.ReportErrors(xdsMetadata, r.debounceErrs)
+	}
+	if r.EnvoyGateway != nil && r.EnvoyGateway.XdsServer != nil && r.EnvoyGateway.XdsServer.AuditLog != nil {
+		if r.auditor, err = newExchangeAuditor(ctx, r.EnvoyGateway.XdsServer.AuditLog, r.Logger); err != nil {
+			return err
+		}
+	}
+	var authorizer *nodeAuthorizer
+	if r.EnvoyGateway != nil && r.EnvoyGateway.XdsServer != nil && r.EnvoyGateway.XdsServer.NodeAuthorization != nil {
+		if authorizer, err = newNodeAuthorizer(r.EnvoyGateway.XdsServer.NodeAuthorization, r.Namespace); err != nil {
+			return err
+		}
+	}
+	r.authorizer = newStreamAuthorizer(authorizer)
+	if r.cache, err = r.newSnapshotCache(ctx, cache.DefaultPartition); err != nil {
+		return err
+	}
+	if err = r.newCachePartitions(ctx); err != nil {
+		return err
+	}
+	r.ports = newXdsServerPorts()
+	r.loadReports = newLoadReports(r.republish, r.connectedNode)
+	if r.EnvoyGateway != nil && r.EnvoyGateway.XdsServer != nil && r.EnvoyGateway.XdsServer.AnomalyDetection != nil {
+		if r.anomalyDetector, err = newAnomalyDetector(r.EnvoyGateway.XdsServer.AnomalyDetection, r.republish, r.Logger); err != nil {
+			return err
+		}
+		r.loadReports.detector = r.anomalyDetector
+		go r.anomalyDetector.run(ctx)
+	}
+	r.trafficRecordings = newTrafficRecordings()
+	r.drains = newDrainDeferrer()
+	r.checkpoints = newConfigCheckpoints()
+	go r.publishCheckpoints(ctx)
+	r.ticketKeySeed = r.sessionTicketKeySeed(xdsTLSKeyFilename)
+	r.drained = make(chan struct{})
+	go r.drainOnShutdown(ctx)
+	r.grpc = r.newGRPCServer(ctx, cfg, 0, r.cache)
 
 	// Start and listen xDS gRPC Server.
 	go r.serveXdsServer(ctx)
 
+	// Serve the proxies of the GatewayClasses of the additional controllers on
+	// their own ports.
+	for _, port := range r.additionalXdsServerPorts() {
+		go r.serveGRPCServer(ctx, r.newGRPCServer(ctx, cfg, port, r.cache), int(port))
+	}
+
+	// Serve the proxies of the isolated Gateways on their own ports, from their
+	// own partition of the snapshot cache.
+	for _, partition := range r.partitions {
+		go r.serveGRPCServer(ctx, r.newGRPCServer(ctx, cfg, partition.port, partition.cache), int(partition.port))
+	}
+
 	// Start message Subscription.
 	go r.subscribeAndTranslate(ctx)
 	r.Logger.Info("started")
 	return
 }
 
+// newGRPCServer returns the gRPC server of the port, zero for the default port, serving
+// the xDS resources of the snapshot cache and the services the proxies call the xds
+// server with.
+func (r *Runner) newGRPCServer(ctx context.Context, cfg *tls.Config, port int32, snapshotCache cache.SnapshotCacheWithCallbacks) *grpc.Server {
+	opts := append([]grpc.ServerOption{
+		grpc.Creds(credentials.NewTLS(cfg)),
+		grpc.KeepaliveEnforcementPolicy(keepalive.EnforcementPolicy{
+			MinTime:             15 * time.Second,
+			PermitWithoutStream: true,
+		}),
+		grpc.ChainStreamInterceptor(r.authorizer.intercept, compressDiscoveryResponses, r.streamPacer().intercept),
+		grpc.StatsHandler(responseSizeHandler{}),
+	}, r.xdsServerOptions()...)
+	g := grpc.NewServer(opts...)
+	registerServer(r.newServer(ctx, port, snapshotCache), g)
+	loadstatsv3.RegisterLoadReportingServiceServer(g, r.loadReports)
+	accesslogv3.RegisterAccessLogServiceServer(g, r.trafficRecordings)
+	return g
+}
+
+// streamPacer returns the pacer of the xDS streams, with the send timeout set in the
+// EnvoyGateway config.
+func (r *Runner) streamPacer() *streamPacer {
+	p := &streamPacer{}
+	if r.EnvoyGateway != nil && r.EnvoyGateway.XdsServer != nil && r.EnvoyGateway.XdsServer.SendTimeout != nil {
+		// The timeout was validated with the EnvoyGateway config.
+		p.sendTimeout, _ = time.ParseDuration(string(*r.EnvoyGateway.XdsServer.SendTimeout))
+	}
+	return p
+}
+
+// xdsServerOptions returns the gRPC options of the xds server set in the EnvoyGateway
+// config, the options not set keeping the gRPC defaults.
+func (r *Runner) xdsServerOptions() []grpc.ServerOption {
+	if r.EnvoyGateway == nil || r.EnvoyGateway.XdsServer == nil {
+		return nil
+	}
+	xdsServer := r.EnvoyGateway.XdsServer
+
+	var opts []grpc.ServerOption
+	if xdsServer.MaxRecvMessageSize != nil {
+		opts = append(opts, grpc.MaxRecvMsgSize(int(xdsServer.MaxRecvMessageSize.Value())))
+	}
+	if xdsServer.MaxSendMessageSize != nil {
+		opts = append(opts, grpc.MaxSendMsgSize(int(xdsServer.MaxSendMessageSize.Value())))
+	}
+	if xdsServer.MaxConcurrentStreams != nil {
+		opts = append(opts, grpc.MaxConcurrentStreams(*xdsServer.MaxConcurrentStreams))
+	}
+	if ka := xdsServer.Keepalive; ka != nil && (ka.Time != nil || ka.Timeout != nil) {
+		// The durations were validated with the EnvoyGateway config.
+		params := keepalive.ServerParameters{}
+		if ka.Time != nil {
+			params.Time, _ = time.ParseDuration(string(*ka.Time))
+		}
+		if ka.Timeout != nil {
+			params.Timeout, _ = time.ParseDuration(string(*ka.Timeout))
+		}
+		opts = append(opts, grpc.KeepaliveParams(params))
+	}
+	return opts
+}
+
 func (r *Runner) serveXdsServer(ctx context.Context) {
-	addr := net.JoinHostPort(XdsServerAddress, strconv.Itoa(bootstrap.DefaultXdsServerPort))
+	r.serveGRPCServer(ctx, r.grpc, bootstrap.DefaultXdsServerPort)
+}
+
+func (r *Runner) serveGRPCServer(ctx context.Context, g *grpc.Server, port int) {
+	addr := net.JoinHostPort(XdsServerAddress, strconv.Itoa(port))
 	l, err := net.Listen("tcp", addr)
 	if err != nil {
 		r.Logger.Error(err, "failed to listen on address", "address", addr)
@@ -104,15 +287,23 @@ func (r *Runner) serveXdsServer(ctx context.Context) {
 
 	go func() {
 		<-ctx.Done()
-		r.Logger.Info("grpc server shutting down")
-		// We don't use GracefulStop here because envoy
+		r.Logger.Info("grpc server shutting down", "address", addr)
+		// While the xDS streams are drained, the server stops accepting
+		// new connections and streams.
+		if r.drained != nil {
+			if r.drainTimeout() > 0 {
+				go g.GracefulStop()
+			}
+			<-r.drained
+		}
+		// We don't wait for GracefulStop to return because envoy
 		// has long-lived hanging xDS requests. There's no
 		// mechanism to make those pending requests fail,
 		// so we forcibly terminate the TCP sessions.
-		r.grpc.Stop()
+		g.Stop()
 	}()
 
-	if err = r.grpc.Serve(l); err != nil {
+	if err = g.Serve(l); err != nil {
 		r.Logger.Error(err, "failed to start grpc based xds server")
 	}
 }
@@ -130,27 +321,37 @@ func registerServer(srv serverv3.Server, g *grpc.Server) {
 	runtimev3.RegisterRuntimeDiscoveryServiceServer(g, srv)
 }
 
+// xdsMetadata is the metadata of the subscription of the runner to the xds message.
+var xdsMetadata = message.Metadata{Runner: string(egv1a1.LogComponentXdsServerRunner), Message: "xds"}
+
 func (r *Runner) subscribeAndTranslate(ctx context.Context) {
 	// Subscribe to resources
-	message.HandleSubscription(message.Metadata{Runner: string(egv1a1.LogComponentXdsServerRunner), Message: "xds"}, r.Xds.Subscribe(ctx),
+	message.HandleTracedSubscriptionWithPriority(xdsMetadata, r.Xds.Subscribe(ctx),
+		xdsUpdatePriority(),
 		func(update message.Update[string, *xdstypes.ResourceVersionTable], errChan chan error) {
-			key := update.Key
-			val := update.Value
-
 			r.Logger.Info("received an update")
-			var err error
-			if update.Delete {
-				err = r.cache.GenerateNewSnapshot(key, nil)
-			} else if val != nil && val.XdsResources != nil {
-				if r.cache == nil {
-					r.Logger.Error(err, "failed to init snapshot cache")
-					errChan <- err
-				} else {
-					// Update snapshot cache
-					err = r.cache.GenerateNewSnapshot(key, val.XdsResources)
-				}
+
+			r.publishMu.Lock()
+			defer r.publishMu.Unlock()
+
+			// Drop the update held for a deleted irKey, which would publish its
+			// snapshot again.
+			if r.debouncer != nil && update.Delete {
+				r.debouncer.take(update.Key)
 			}
-			if err != nil {
+
+			if _, ok := r.paused[update.Key]; ok {
+				r.Logger.Info("publishing is paused, deferring the update", "irKey", update.Key)
+				r.paused[update.Key] = &update
+				return
+			}
+
+			if r.debouncer != nil && !update.Delete {
+				r.debouncer.hold(update, time.Now())
+				return
+			}
+
+			if err := r.publish(update); err != nil {
 				r.Logger.Error(err, "failed to generate a snapshot")
 				errChan <- err
 			}
@@ -160,6 +361,314 @@ func (r *Runner) subscribeAndTranslate(ctx context.Context) {
 	r.Logger.Info("subscriber shutting down")
 }
 
+// publish generates a new snapshot from the update.
+func (r *Runner) publish(update message.Update[string, *xdstypes.ResourceVersionTable]) error {
+	key := update.Key
+	val := update.Value
+
+	if r.cache == nil {
+		return fmt.Errorf("failed to init snapshot cache")
+	}
+	// Trace the acknowledgements of the snapshot generated from a traced update.
+	if update.SpanContext.IsValid() {
+		r.cacheFor(key).SetSnapshotTrace(key, update.SpanContext)
+	}
+	r.setWaitForListenerAck(key, !update.Delete && val != nil && val.WaitForListenerAck)
+	if update.Delete {
+		delete(r.latest, key)
+		if r.loadReports != nil {
+			r.loadReports.setSettings(key, nil)
+		}
+		if r.anomalyDetector != nil {
+			r.anomalyDetector.forget(key)
+		}
+		if r.rotator != nil {
+			r.rotator.remove(key)
+		}
+		if r.trafficRecordings != nil {
+			r.trafficRecordings.setSettings(key, nil)
+		}
+		if r.drains != nil {
+			r.drains.remove(key)
+			r.publishDeferredDrains(key, nil)
+		}
+		if r.ports != nil {
+			r.ports.remove(key)
+		}
+		if r.checkpoints != nil {
+			r.checkpoints.setSettings(key, nil, time.Now())
+			r.removeCheckpoint(key)
+		}
+		r.stopRepublish(key)
+		delete(r.published, key)
+		r.publishInconsistencies(key, nil)
+		return r.cacheFor(key).GenerateNewSnapshot(key, nil)
+	}
+	if val != nil && val.XdsResources != nil {
+		if r.latest == nil {
+			r.latest = make(map[string]message.Update[string, *xdstypes.ResourceVersionTable])
+		}
+		// The update published again is no longer traced.
+		latest := update
+		latest.SpanContext = trace.SpanContext{}
+		r.latest[key] = latest
+
+		if r.ports != nil {
+			r.ports.set(key, val.XdsServerPort)
+		}
+
+		if r.trafficRecordings != nil {
+			r.trafficRecordings.setSettings(key, val.TrafficRecording)
+		}
+
+		if r.checkpoints != nil {
+			r.checkpoints.setSettings(key, val.ConfigCheckpoint, time.Now())
+			if val.ConfigCheckpoint == nil {
+				r.removeCheckpoint(key)
+			}
+		}
+
+		resources := val.XdsResources
+		if r.loadReports != nil {
+			r.loadReports.setSettings(key, val.LoadReporting)
+			resources = r.loadReports.apply(key, resources)
+		}
+		if r.anomalyDetector != nil {
+			resources = r.anomalyDetector.apply(key, resources, time.Now())
+		}
+
+		var republishAfter time.Duration
+		if r.drains != nil {
+			var deferred *message.DeferredDrains
+			resources, deferred, republishAfter = r.drains.apply(key, resources, val.DeferDrains, time.Now())
+			if deferred != nil {
+				r.Logger.Info("deferring the listener changes draining connections to the maintenance window", "irKey", key,
+					"listeners", deferred.Listeners, "filterChainListeners", deferred.FilterChainListeners, "until", deferred.Until)
+			}
+			r.publishDeferredDrains(key, deferred)
+		}
+		if r.rotator != nil {
+			var overlapEnds time.Duration
+			resources, overlapEnds = r.rotator.apply(key, resources, time.Now())
+			if republishAfter == 0 || (overlapEnds > 0 && overlapEnds < republishAfter) {
+				republishAfter = overlapEnds
+			}
+		}
+		if len(val.SessionTicketKeys) > 0 {
+			if r.ticketKeySeed == nil {
+				r.ticketKeySeed = randomSessionTicketKeySeed()
+			}
+			var nextRotation time.Duration
+			resources, nextRotation = applySessionTicketKeys(r.ticketKeySeed, key, resources, val.SessionTicketKeys, time.Now())
+			if republishAfter == 0 || nextRotation < republishAfter {
+				republishAfter = nextRotation
+			}
+		}
+
+		// Update snapshot cache
+		if err := r.generateSnapshot(key, resources, val.NodeScopes); err != nil {
+			return err
+		}
+		if r.rotator != nil {
+			if info, ok := r.cacheFor(key).GetSnapshotInfo(key); ok {
+				r.rotator.published(key, info.Version, time.Now())
+			}
+		}
+		if republishAfter > 0 {
+			r.scheduleRepublish(key, republishAfter)
+		}
+	}
+
+	return nil
+}
+
+// republish publishes the latest update of the irKey again. If publishing is paused,
+// the update is published once resumed.
+func (r *Runner) republish(irKey string) {
+	r.publishMu.Lock()
+	defer r.publishMu.Unlock()
+
+	update, ok := r.latest[irKey]
+	if !ok {
+		return
+	}
+	if held, ok := r.paused[irKey]; ok {
+		if held == nil {
+			r.paused[irKey] = &update
+		}
+		return
+	}
+	if err := r.publish(update); err != nil {
+		r.Logger.Error(err, "failed to publish the snapshot again", "irKey", irKey)
+	}
+}
+
+// handleSnapshotEviction releases the latest update of the irKey whose snapshot was
+// evicted, and publishes it again once a proxy requests the evicted snapshot.
+func (r *Runner) handleSnapshotEviction(irKey string, restore bool) {
+	r.publishMu.Lock()
+	defer r.publishMu.Unlock()
+
+	// The snapshot was published again since it was evicted.
+	if _, ok := r.cacheFor(irKey).GetSnapshotInfo(irKey); ok {
+		return
+	}
+
+	if !restore {
+		delete(r.published, irKey)
+		// The update is loaded again from the translated resources when restored,
+		// unless it's older than an update held back while publishing is paused.
+		if r.paused[irKey] == nil {
+			delete(r.latest, irKey)
+		}
+		return
+	}
+
+	update, ok := r.latest[irKey]
+	if !ok {
+		val, ok := r.Xds.Load(irKey)
+		if !ok {
+			return
+		}
+		update = message.Update[string, *xdstypes.ResourceVersionTable]{Key: irKey, Value: val}
+	}
+	r.Logger.Info("restoring the evicted snapshot", "irKey", irKey)
+	if err := r.publish(update); err != nil {
+		r.Logger.Error(err, "failed to restore the evicted snapshot", "irKey", irKey)
+	}
+}
+
+// setWaitForListenerAck records whether the Gateways of the irKey wait for the
+// proxies to acknowledge their listeners.
+func (r *Runner) setWaitForListenerAck(irKey string, wait bool) {
+	r.ackMu.Lock()
+	defer r.ackMu.Unlock()
+
+	if r.waitForListenerAck == nil {
+		r.waitForListenerAck = make(map[string]bool)
+	}
+	if wait {
+		r.waitForListenerAck[irKey] = true
+	} else {
+		delete(r.waitForListenerAck, irKey)
+	}
+}
+
+// publishPendingListeners publishes the listeners of the irKey pending acknowledgement by
+// the proxies, if its Gateways wait for them to be acknowledged.
+func (r *Runner) publishPendingListeners(irKey string, pendingListeners []string) {
+	if r.ProviderResources == nil {
+		return
+	}
+
+	r.ackMu.Lock()
+	wait := r.waitForListenerAck[irKey]
+	r.ackMu.Unlock()
+
+	if !wait {
+		if _, ok := r.ProviderResources.PendingListeners.Load(irKey); ok {
+			r.ProviderResources.PendingListeners.Delete(irKey)
+		}
+		return
+	}
+	r.ProviderResources.PendingListeners.Store(irKey, &message.PendingListeners{Listeners: pendingListeners})
+}
+
+// publishNacks publishes the snapshots of the irKey rejected by the proxies.
+func (r *Runner) publishNacks(irKey string, nacks []xdstypes.Nack) {
+	if r.ProviderResources == nil {
+		return
+	}
+
+	if len(nacks) == 0 {
+		if _, ok := r.ProviderResources.XdsNacks.Load(irKey); ok {
+			r.ProviderResources.XdsNacks.Delete(irKey)
+		}
+		return
+	}
+	r.ProviderResources.XdsNacks.Store(irKey, &message.XdsNacks{Nacks: nacks})
+}
+
+// publishDrifts publishes the proxies of the irKey whose acknowledged configuration stayed
+// behind the configuration they are served.
+func (r *Runner) publishDrifts(irKey string, drifts []xdstypes.Drift) {
+	if r.ProviderResources == nil {
+		return
+	}
+
+	if len(drifts) == 0 {
+		if _, ok := r.ProviderResources.XdsDrifts.Load(irKey); ok {
+			r.ProviderResources.XdsDrifts.Delete(irKey)
+		}
+		return
+	}
+	r.ProviderResources.XdsDrifts.Store(irKey, &message.XdsDrifts{Drifts: drifts})
+}
+
+// publishInconsistencies publishes the inconsistencies of the last snapshot of the
+// irKey refused by the snapshot cache.
+func (r *Runner) publishInconsistencies(irKey string, problems []string) {
+	if r.ProviderResources == nil {
+		return
+	}
+
+	if len(problems) == 0 {
+		if _, ok := r.ProviderResources.XdsInconsistencies.Load(irKey); ok {
+			r.ProviderResources.XdsInconsistencies.Delete(irKey)
+		}
+		return
+	}
+	r.ProviderResources.XdsInconsistencies.Store(irKey, &message.XdsInconsistencies{Problems: problems})
+}
+
+// SnapshotCache returns the snapshot cache backing the xDS server.
+func (r *Runner) SnapshotCache() cache.SnapshotCacheWithCallbacks {
+	return r.cache
+}
+
+// PausePublishing stops publishing new snapshots for the irKey. Updates
+// received while paused are held back until publishing is resumed.
+func (r *Runner) PausePublishing(irKey string) {
+	r.publishMu.Lock()
+	defer r.publishMu.Unlock()
+
+	if r.paused == nil {
+		r.paused = make(map[string]*message.Update[string, *xdstypes.ResourceVersionTable])
+	}
+	if _, ok := r.paused[irKey]; !ok {
+		r.paused[irKey] = nil
+		r.Logger.Info("paused publishing", "irKey", irKey)
+	}
+}
+
+// ResumePublishing resumes publishing snapshots for the irKey, publishing the
+// latest update held back while paused.
+func (r *Runner) ResumePublishing(irKey string) error {
+	r.publishMu.Lock()
+	defer r.publishMu.Unlock()
+
+	update, ok := r.paused[irKey]
+	if !ok {
+		return nil
+	}
+	delete(r.paused, irKey)
+	r.Logger.Info("resumed publishing", "irKey", irKey)
+
+	if update == nil {
+		return nil
+	}
+	return r.publish(*update)
+}
+
+// PublishingPaused returns true if publishing is paused for the irKey.
+func (r *Runner) PublishingPaused(irKey string) bool {
+	r.publishMu.Lock()
+	defer r.publishMu.Unlock()
+
+	_, ok := r.paused[irKey]
+	return ok
+}
+
 func (r *Runner) tlsConfig(cert, key, ca string) *tls.Config {
 	loadConfig := func() (*tls.Config, error) {
 		cert, err := tls.LoadX509KeyPair(cert, key)
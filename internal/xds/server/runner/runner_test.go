@@ -23,6 +23,9 @@ import (
 	"github.com/tsaarni/certyaml"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/utils/ptr"
+	gwapiv1 "sigs.k8s.io/gateway-api/apis/v1"
 
 	egv1a1 "github.com/envoyproxy/gateway/api/v1alpha1"
 	"github.com/envoyproxy/gateway/internal/envoygateway/config"
@@ -209,3 +212,34 @@ func TestServeXdsServerListenFailed(t *testing.T) {
 	// Don't crash in this function
 	r.serveXdsServer(context.Background())
 }
+
+func TestIsDiscoveryMethod(t *testing.T) {
+	tests := map[string]bool{
+		"/envoy.service.discovery.v3.AggregatedDiscoveryService/StreamAggregatedResources": true,
+		"/envoy.service.discovery.v3.AggregatedDiscoveryService/DeltaAggregatedResources":  true,
+		"/envoy.service.load_stats.v3.LoadReportingService/StreamLoadStats":                false,
+		"/grpc.health.v1.Health/Check": false,
+	}
+	for method, want := range tests {
+		t.Run(method, func(t *testing.T) {
+			require.Equal(t, want, isDiscoveryMethod(method))
+		})
+	}
+}
+
+func TestXdsServerOptions(t *testing.T) {
+	r := New(&Config{Server: config.Server{Logger: logging.DefaultLogger(egv1a1.LogLevelInfo)}})
+	require.Empty(t, r.xdsServerOptions())
+
+	r.EnvoyGateway = &egv1a1.EnvoyGateway{EnvoyGatewaySpec: egv1a1.EnvoyGatewaySpec{
+		XdsServer: &egv1a1.EnvoyGatewayXdsServer{
+			MaxRecvMessageSize:   ptr.To(resource.MustParse("16Mi")),
+			MaxSendMessageSize:   ptr.To(resource.MustParse("64Mi")),
+			MaxConcurrentStreams: ptr.To[uint32](100),
+			Keepalive: &egv1a1.XdsServerKeepalive{
+				Time: ptr.To(gwapiv1.Duration("30s")),
+			},
+		},
+	}}
+	require.Len(t, r.xdsServerOptions(), 4)
+}
@@ -0,0 +1,109 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package runner
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"os"
+	"strconv"
+	"time"
+
+	corev3 "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	tlsv3 "github.com/envoyproxy/go-control-plane/envoy/extensions/transport_sockets/tls/v3"
+	"github.com/envoyproxy/go-control-plane/pkg/cache/types"
+	resourcev3 "github.com/envoyproxy/go-control-plane/pkg/resource/v3"
+
+	"github.com/envoyproxy/gateway/internal/ir"
+	xdstypes "github.com/envoyproxy/gateway/internal/xds/types"
+)
+
+// sessionTicketKeyLength is the length of the session ticket keys expected by Envoy.
+const sessionTicketKeyLength = 80
+
+// sessionTicketKeySeed returns the seed the session ticket keys are derived from. It is
+// read from the xDS server TLS key, which all the replicas of Envoy Gateway share, so that
+// they serve the same keys to the proxies of an irKey. A random seed is used if the key
+// can't be read.
+func (r *Runner) sessionTicketKeySeed(key string) []byte {
+	seed, err := os.ReadFile(key)
+	if err == nil && len(seed) > 0 {
+		return seed
+	}
+	r.Logger.Error(err, "failed to read the session ticket key seed, using a random one")
+	return randomSessionTicketKeySeed()
+}
+
+func randomSessionTicketKeySeed() []byte {
+	seed := make([]byte, sha256.Size)
+	_, _ = rand.Read(seed)
+	return seed
+}
+
+// applySessionTicketKeys returns the resources of the irKey with the secrets holding the
+// session ticket keys at the time, and the time until the earliest key rotation.
+func applySessionTicketKeys(seed []byte, irKey string, resources xdstypes.XdsResources,
+	keys []*ir.SessionTicketKeys, now time.Time,
+) (xdstypes.XdsResources, time.Duration) {
+	// Don't modify the resources of the update, which are published again once
+	// the keys are rotated.
+	served := make(xdstypes.XdsResources, len(resources))
+	for typeURL, rs := range resources {
+		served[typeURL] = rs
+	}
+	secrets := make([]types.Resource, 0, len(resources[resourcev3.SecretType])+len(keys))
+	secrets = append(secrets, resources[resourcev3.SecretType]...)
+
+	var nextRotation time.Duration
+	for _, k := range keys {
+		secret, rotation := sessionTicketKeysSecret(seed, irKey, k, now)
+		secrets = append(secrets, secret)
+		if nextRotation == 0 || rotation < nextRotation {
+			nextRotation = rotation
+		}
+	}
+	served[resourcev3.SecretType] = secrets
+
+	return served, nextRotation
+}
+
+// sessionTicketKeysSecret returns the secret holding the session ticket keys at the time,
+// and the time until the keys are rotated. The time is divided into epochs of the rotation
+// interval: the key of the current epoch encrypts the session tickets, while the keys of
+// the retained previous epochs only decrypt them.
+func sessionTicketKeysSecret(seed []byte, irKey string, keys *ir.SessionTicketKeys, now time.Time) (*tlsv3.Secret, time.Duration) {
+	interval := keys.RotationInterval.Duration
+	epoch := now.UnixNano() / int64(interval)
+
+	ticketKeys := &tlsv3.TlsSessionTicketKeys{}
+	for i := int64(0); i <= int64(keys.RetainedKeys); i++ {
+		ticketKeys.Keys = append(ticketKeys.Keys, &corev3.DataSource{
+			Specifier: &corev3.DataSource_InlineBytes{
+				InlineBytes: sessionTicketKey(seed, irKey, keys.Name, epoch-i),
+			},
+		})
+	}
+
+	nextRotation := time.Unix(0, (epoch+1)*int64(interval)).Sub(now)
+	return &tlsv3.Secret{
+		Name: keys.Name,
+		Type: &tlsv3.Secret_SessionTicketKeys{SessionTicketKeys: ticketKeys},
+	}, nextRotation
+}
+
+// sessionTicketKey derives the session ticket key of the epoch from the seed.
+func sessionTicketKey(seed []byte, irKey, name string, epoch int64) []byte {
+	info := []byte(irKey + "/" + name + "/" + strconv.FormatInt(epoch, 10))
+	key := make([]byte, 0, sessionTicketKeyLength+sha256.Size)
+	for block := byte(1); len(key) < sessionTicketKeyLength; block++ {
+		mac := hmac.New(sha256.New, seed)
+		mac.Write([]byte{block})
+		mac.Write(info)
+		key = mac.Sum(key)
+	}
+	return key[:sessionTicketKeyLength]
+}
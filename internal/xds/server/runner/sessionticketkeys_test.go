@@ -0,0 +1,73 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package runner
+
+import (
+	"testing"
+	"time"
+
+	tlsv3 "github.com/envoyproxy/go-control-plane/envoy/extensions/transport_sockets/tls/v3"
+	resourcev3 "github.com/envoyproxy/go-control-plane/pkg/resource/v3"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/envoyproxy/gateway/internal/ir"
+)
+
+func servedTicketKeys(t *testing.T, secret *tlsv3.Secret) [][]byte {
+	t.Helper()
+	var keys [][]byte
+	for _, key := range secret.GetSessionTicketKeys().GetKeys() {
+		require.Len(t, key.GetInlineBytes(), sessionTicketKeyLength)
+		keys = append(keys, key.GetInlineBytes())
+	}
+	return keys
+}
+
+func TestSessionTicketKeys(t *testing.T) {
+	const irKey = "default/gateway-1"
+
+	seed := []byte("seed")
+	keys := &ir.SessionTicketKeys{
+		Name:             "default/policy/session-ticket-keys",
+		RotationInterval: metav1.Duration{Duration: time.Hour},
+		RetainedKeys:     2,
+	}
+	now := time.Date(2024, 1, 1, 10, 15, 0, 0, time.UTC)
+
+	// The current key comes first, followed by the retained previous keys, until
+	// the end of the rotation interval.
+	secret, nextRotation := sessionTicketKeysSecret(seed, irKey, keys, now)
+	require.Equal(t, keys.Name, secret.Name)
+	require.Equal(t, 45*time.Minute, nextRotation)
+	served := servedTicketKeys(t, secret)
+	require.Len(t, served, 3)
+
+	// The keys are derived from the seed, so that they are the same at any time of
+	// the rotation interval, and on any replica.
+	secret, nextRotation = sessionTicketKeysSecret(seed, irKey, keys, now.Add(30*time.Minute))
+	require.Equal(t, 15*time.Minute, nextRotation)
+	require.Equal(t, served, servedTicketKeys(t, secret))
+
+	// A new key is generated once rotated, and the oldest one is dropped.
+	secret, _ = sessionTicketKeysSecret(seed, irKey, keys, now.Add(time.Hour))
+	rotated := servedTicketKeys(t, secret)
+	require.NotEqual(t, served[0], rotated[0])
+	require.Equal(t, served[:2], rotated[1:])
+
+	// The keys differ across the seeds, irKeys and secrets.
+	secret, _ = sessionTicketKeysSecret([]byte("other-seed"), irKey, keys, now)
+	require.NotEqual(t, served[0], servedTicketKeys(t, secret)[0])
+	secret, _ = sessionTicketKeysSecret(seed, "default/gateway-2", keys, now)
+	require.NotEqual(t, served[0], servedTicketKeys(t, secret)[0])
+
+	// The secrets are added to the served resources without modifying the update.
+	update := secretResources(certSecret("cert", "cert"))
+	resources, nextRotation := applySessionTicketKeys(seed, irKey, update, []*ir.SessionTicketKeys{keys}, now)
+	require.Equal(t, 45*time.Minute, nextRotation)
+	require.Len(t, resources[resourcev3.SecretType], 2)
+	require.Len(t, update[resourcev3.SecretType], 1)
+}
@@ -0,0 +1,91 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package runner
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/envoyproxy/gateway/internal/xds/cache"
+)
+
+// drainTimeout returns how long the xDS streams are drained for on shutdown, or zero to
+// close the connections right away.
+func (r *Runner) drainTimeout() time.Duration {
+	if r.EnvoyGateway == nil || r.EnvoyGateway.XdsServer == nil || r.EnvoyGateway.XdsServer.DrainTimeout == nil {
+		return 0
+	}
+	// The timeout was validated with the EnvoyGateway config.
+	d, _ := time.ParseDuration(string(*r.EnvoyGateway.XdsServer.DrainTimeout))
+	return d
+}
+
+// Drain publishes the updates held by the debouncer as the final snapshots, then drains
+// the streams of all the partitions of the snapshot cache, which refuse the new streams and
+// wait for the responses in flight to be answered, until the context is done.
+func (r *Runner) Drain(ctx context.Context) error {
+	r.flushDebounced()
+
+	caches := []cache.SnapshotCacheWithCallbacks{r.cache}
+	for _, partition := range r.partitions {
+		caches = append(caches, partition.cache)
+	}
+	errs := make([]error, len(caches))
+	var wg sync.WaitGroup
+	for i, c := range caches {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			errs[i] = c.Drain(ctx)
+		}()
+	}
+	wg.Wait()
+
+	return errors.Join(errs...)
+}
+
+// flushDebounced publishes the updates held by the debouncer right away.
+func (r *Runner) flushDebounced() {
+	r.publishMu.Lock()
+	var irKeys []string
+	if r.debouncer != nil {
+		for irKey := range r.debouncer.pending {
+			irKeys = append(irKeys, irKey)
+		}
+	}
+	r.publishMu.Unlock()
+
+	for _, irKey := range irKeys {
+		r.publishDebounced(irKey)
+	}
+}
+
+// drainOnShutdown drains the xDS streams for the drain timeout once the context is done,
+// then closes the drained channel so that the connections are closed.
+func (r *Runner) drainOnShutdown(ctx context.Context) {
+	<-ctx.Done()
+	defer close(r.drained)
+
+	timeout := r.drainTimeout()
+	if timeout == 0 {
+		return
+	}
+	r.Logger.Info("draining xds streams", "timeout", timeout)
+	drainCtx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	if err := r.Drain(drainCtx); err != nil {
+		r.Logger.Error(err, "failed to drain xds streams")
+		return
+	}
+	r.Logger.Info("drained xds streams")
+}
+
+// Drained returns a channel closed once the xDS streams have been drained on shutdown.
+func (r *Runner) Drained() <-chan struct{} {
+	return r.drained
+}
@@ -0,0 +1,50 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package runner
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"k8s.io/utils/ptr"
+	gwapiv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	egv1a1 "github.com/envoyproxy/gateway/api/v1alpha1"
+	"github.com/envoyproxy/gateway/internal/envoygateway/config"
+	"github.com/envoyproxy/gateway/internal/message"
+	"github.com/envoyproxy/gateway/internal/xds/cache"
+	xdstypes "github.com/envoyproxy/gateway/internal/xds/types"
+)
+
+func TestDrain(t *testing.T) {
+	cfg, err := config.New()
+	require.NoError(t, err)
+	cfg.EnvoyGateway.XdsServer = &egv1a1.EnvoyGatewayXdsServer{DrainTimeout: ptr.To(gwapiv1.Duration("1s"))}
+	r := New(&Config{Server: *cfg})
+	require.Equal(t, time.Second, r.drainTimeout())
+
+	r.cache, err = r.newSnapshotCache(context.Background(), cache.DefaultPartition)
+	require.NoError(t, err)
+	r.debouncer = newSnapshotDebouncer(&egv1a1.EnvoyGatewaySnapshotDebounce{
+		Interval: ptr.To(gwapiv1.Duration("1h")),
+		MaxDelay: ptr.To(gwapiv1.Duration("1h")),
+	}, r.publishDebounced)
+	r.publishMu.Lock()
+	r.debouncer.hold(message.Update[string, *xdstypes.ResourceVersionTable]{
+		Key:   "default/eg",
+		Value: &xdstypes.ResourceVersionTable{XdsResources: gatewayResources("http")},
+	}, time.Now())
+	r.publishMu.Unlock()
+	require.Empty(t, r.cache.IRKeys())
+
+	// The held update is published as the final snapshot, and the drain completes right
+	// away since no response is in flight.
+	require.NoError(t, r.Drain(context.Background()))
+	require.Equal(t, []string{"default/eg"}, r.cache.IRKeys())
+	require.ErrorIs(t, r.cache.OnStreamOpen(context.Background(), 1, ""), cache.ErrDraining)
+}
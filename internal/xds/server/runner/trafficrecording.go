@@ -0,0 +1,149 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package runner
+
+import (
+	"errors"
+	"io"
+	"strings"
+	"sync"
+	"time"
+
+	corev3 "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	accesslogdatav3 "github.com/envoyproxy/go-control-plane/envoy/data/accesslog/v3"
+	accesslogv3 "github.com/envoyproxy/go-control-plane/envoy/service/accesslog/v3"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/envoyproxy/gateway/internal/ir"
+	"github.com/envoyproxy/gateway/internal/replay"
+	xdstypes "github.com/envoyproxy/gateway/internal/xds/types"
+)
+
+// trafficRecordings serves the Access Log Service (ALS) the proxies send a sample of their
+// requests to, and records the latest requests of each irKey for them to be replayed.
+type trafficRecordings struct {
+	mu sync.RWMutex
+	// recordings holds the recording of each irKey whose traffic is recorded.
+	recordings map[string]*replay.Recording
+}
+
+func newTrafficRecordings() *trafficRecordings {
+	return &trafficRecordings{
+		recordings: make(map[string]*replay.Recording),
+	}
+}
+
+var _ accesslogv3.AccessLogServiceServer = &trafficRecordings{}
+
+// setSettings sets the traffic recording settings of the irKey, or forgets the irKey and
+// its recorded requests if nil.
+func (t *trafficRecordings) setSettings(irKey string, settings *ir.TrafficRecording) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if settings == nil {
+		delete(t.recordings, irKey)
+		return
+	}
+	if recording, ok := t.recordings[irKey]; ok {
+		recording.Resize(int(settings.MaxRequests))
+		return
+	}
+	t.recordings[irKey] = replay.NewRecording(int(settings.MaxRequests))
+}
+
+// recording returns the recording of the irKey, or nil if its traffic isn't recorded.
+func (t *trafficRecordings) recording(irKey string) *replay.Recording {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	return t.recordings[irKey]
+}
+
+// StreamAccessLogs records the requests logged by a proxy. The first message of the
+// stream identifies the proxy, and thereby its irKey.
+func (t *trafficRecordings) StreamAccessLogs(stream accesslogv3.AccessLogService_StreamAccessLogsServer) error {
+	var irKey string
+	for {
+		msg, err := stream.Recv()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				return stream.SendAndClose(&accesslogv3.StreamAccessLogsResponse{})
+			}
+			return err
+		}
+
+		if irKey == "" {
+			if msg.GetIdentifier().GetLogName() != xdstypes.TrafficRecordingLogName {
+				return status.Errorf(codes.InvalidArgument, "only the %s access log is accepted", xdstypes.TrafficRecordingLogName)
+			}
+			irKey = msg.GetIdentifier().GetNode().GetCluster()
+			if irKey == "" {
+				return status.Error(codes.InvalidArgument, "the first message must identify the node")
+			}
+		}
+
+		// The recording is looked up for each message, since the settings of the irKey
+		// may have changed since the stream started.
+		recording := t.recording(irKey)
+		if recording == nil {
+			trafficRecordingDroppedTotal.With(irKeyLabel.Value(irKey)).Add(float64(len(msg.GetHttpLogs().GetLogEntry())))
+			continue
+		}
+		for _, entry := range msg.GetHttpLogs().GetLogEntry() {
+			recording.Add(requestFromAccessLog(entry))
+		}
+		trafficRecordedTotal.With(irKeyLabel.Value(irKey)).Add(float64(len(msg.GetHttpLogs().GetLogEntry())))
+	}
+}
+
+// requestFromAccessLog returns the request recorded from the access log entry. The user
+// agent and referer are logged apart from the other request headers.
+func requestFromAccessLog(entry *accesslogdatav3.HTTPAccessLogEntry) *replay.Request {
+	props := entry.GetRequest()
+	req := &replay.Request{
+		Time:      entry.GetCommonProperties().GetStartTime().AsTime(),
+		Scheme:    props.GetScheme(),
+		Authority: props.GetAuthority(),
+		Path:      props.GetPath(),
+	}
+	// The original path is only logged if the path was rewritten.
+	if props.GetOriginalPath() != "" {
+		req.Path = props.GetOriginalPath()
+	}
+	if method := props.GetRequestMethod(); method != corev3.RequestMethod_METHOD_UNSPECIFIED {
+		req.Method = method.String()
+	}
+
+	headers := make(map[string]string, len(props.GetRequestHeaders())+2)
+	for name, value := range props.GetRequestHeaders() {
+		headers[strings.ToLower(name)] = value
+	}
+	if props.GetUserAgent() != "" {
+		headers["user-agent"] = props.GetUserAgent()
+	}
+	if props.GetReferer() != "" {
+		headers["referer"] = props.GetReferer()
+	}
+	if len(headers) > 0 {
+		req.Headers = headers
+	}
+	return req
+}
+
+// RecordedRequests returns the requests recorded for the irKey, in the order they were
+// received, or false if its traffic isn't recorded.
+func (r *Runner) RecordedRequests(irKey string) ([]*replay.Request, bool) {
+	if r.trafficRecordings == nil {
+		return nil, false
+	}
+	recording := r.trafficRecordings.recording(irKey)
+	if recording == nil {
+		return nil, false
+	}
+	return recording.Requests(time.Time{}, time.Time{}), true
+}
@@ -0,0 +1,117 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package runner
+
+import (
+	"io"
+	"testing"
+	"time"
+
+	corev3 "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	accesslogdatav3 "github.com/envoyproxy/go-control-plane/envoy/data/accesslog/v3"
+	accesslogv3 "github.com/envoyproxy/go-control-plane/envoy/service/accesslog/v3"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/envoyproxy/gateway/internal/ir"
+	"github.com/envoyproxy/gateway/internal/replay"
+	xdstypes "github.com/envoyproxy/gateway/internal/xds/types"
+)
+
+// fakeAccessLogStream is an access log stream sending the messages.
+type fakeAccessLogStream struct {
+	grpc.ServerStream
+	messages []*accesslogv3.StreamAccessLogsMessage
+	closed   bool
+}
+
+func (s *fakeAccessLogStream) Recv() (*accesslogv3.StreamAccessLogsMessage, error) {
+	if len(s.messages) == 0 {
+		return nil, io.EOF
+	}
+	msg := s.messages[0]
+	s.messages = s.messages[1:]
+	return msg, nil
+}
+
+func (s *fakeAccessLogStream) SendAndClose(*accesslogv3.StreamAccessLogsResponse) error {
+	s.closed = true
+	return nil
+}
+
+func httpLogs(entries ...*accesslogdatav3.HTTPAccessLogEntry) *accesslogv3.StreamAccessLogsMessage {
+	return &accesslogv3.StreamAccessLogsMessage{
+		LogEntries: &accesslogv3.StreamAccessLogsMessage_HttpLogs{
+			HttpLogs: &accesslogv3.StreamAccessLogsMessage_HTTPAccessLogEntries{LogEntry: entries},
+		},
+	}
+}
+
+func TestTrafficRecordings(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	entry := &accesslogdatav3.HTTPAccessLogEntry{
+		CommonProperties: &accesslogdatav3.AccessLogCommon{StartTime: timestamppb.New(start)},
+		Request: &accesslogdatav3.HTTPRequestProperties{
+			RequestMethod:  corev3.RequestMethod_POST,
+			Scheme:         "https",
+			Authority:      "www.example.com",
+			Path:           "/v2/users?id=1",
+			OriginalPath:   "/users?id=1",
+			UserAgent:      "curl/8.0",
+			RequestHeaders: map[string]string{"X-Request-Id": "abc"},
+		},
+	}
+	first := httpLogs(entry)
+	first.Identifier = &accesslogv3.StreamAccessLogsMessage_Identifier{
+		Node:    &corev3.Node{Id: "envoy-1", Cluster: "envoy-gateway/eg"},
+		LogName: xdstypes.TrafficRecordingLogName,
+	}
+
+	recordings := newTrafficRecordings()
+
+	// The requests of the irKeys whose traffic isn't recorded are dropped.
+	stream := &fakeAccessLogStream{messages: []*accesslogv3.StreamAccessLogsMessage{first}}
+	require.NoError(t, recordings.StreamAccessLogs(stream))
+	require.True(t, stream.closed)
+	require.Nil(t, recordings.recording("envoy-gateway/eg"))
+
+	recordings.setSettings("envoy-gateway/eg", &ir.TrafficRecording{SamplingPercentage: 10, MaxRequests: 2})
+	stream = &fakeAccessLogStream{messages: []*accesslogv3.StreamAccessLogsMessage{first, httpLogs(entry, entry)}}
+	require.NoError(t, recordings.StreamAccessLogs(stream))
+
+	recording := recordings.recording("envoy-gateway/eg")
+	require.Equal(t, 2, recording.Len())
+	require.Equal(t, &replay.Request{
+		Time:      start,
+		Method:    "POST",
+		Scheme:    "https",
+		Authority: "www.example.com",
+		Path:      "/users?id=1",
+		Headers:   map[string]string{"user-agent": "curl/8.0", "x-request-id": "abc"},
+	}, recording.Requests(time.Time{}, time.Time{})[0])
+
+	recordings.setSettings("envoy-gateway/eg", nil)
+	require.Nil(t, recordings.recording("envoy-gateway/eg"))
+}
+
+func TestTrafficRecordingsInvalidStream(t *testing.T) {
+	recordings := newTrafficRecordings()
+
+	msg := httpLogs()
+	msg.Identifier = &accesslogv3.StreamAccessLogsMessage_Identifier{
+		Node:    &corev3.Node{Id: "envoy-1", Cluster: "envoy-gateway/eg"},
+		LogName: "other",
+	}
+	err := recordings.StreamAccessLogs(&fakeAccessLogStream{messages: []*accesslogv3.StreamAccessLogsMessage{msg}})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	msg.Identifier = &accesslogv3.StreamAccessLogsMessage_Identifier{LogName: xdstypes.TrafficRecordingLogName}
+	err = recordings.StreamAccessLogs(&fakeAccessLogStream{messages: []*accesslogv3.StreamAccessLogsMessage{msg}})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+}
@@ -0,0 +1,59 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package runner
+
+import (
+	"context"
+	"time"
+
+	resourcev3 "github.com/envoyproxy/go-control-plane/pkg/resource/v3"
+
+	egv1a1 "github.com/envoyproxy/gateway/api/v1alpha1"
+	"github.com/envoyproxy/gateway/internal/xds/cache"
+)
+
+// resourceTTLTypes lists the types of the resources the default TTL applies to.
+var resourceTTLTypes = []resourcev3.Type{
+	resourcev3.ListenerType,
+	resourcev3.RouteType,
+	resourcev3.ClusterType,
+	resourcev3.EndpointType,
+	resourcev3.SecretType,
+}
+
+// resourceTTLOption returns the snapshot cache option setting the TTL of the resources of
+// each type, heartbeating them until the context is done.
+func resourceTTLOption(ctx context.Context, cfg *egv1a1.EnvoyGatewayResourceTTL) cache.Option {
+	ttls := make(map[resourcev3.Type]time.Duration)
+	// The durations have been validated with the EnvoyGateway configuration.
+	if cfg.Default != nil {
+		if ttl, err := time.ParseDuration(string(*cfg.Default)); err == nil {
+			for _, typeURL := range resourceTTLTypes {
+				ttls[typeURL] = ttl
+			}
+		}
+	}
+	for _, t := range cfg.Types {
+		if ttl, err := time.ParseDuration(string(t.TTL)); err == nil {
+			ttls[string(t.Type)] = ttl
+		}
+	}
+
+	var heartbeatInterval time.Duration
+	if cfg.HeartbeatInterval != nil {
+		heartbeatInterval, _ = time.ParseDuration(string(*cfg.HeartbeatInterval))
+	}
+	if heartbeatInterval <= 0 {
+		// Send the resources at least twice before the shortest TTL expires.
+		for _, ttl := range ttls {
+			if heartbeatInterval == 0 || ttl/3 < heartbeatInterval {
+				heartbeatInterval = ttl / 3
+			}
+		}
+	}
+
+	return cache.WithResourceTTLs(ctx, ttls, heartbeatInterval)
+}
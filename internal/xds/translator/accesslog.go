@@ -50,6 +50,20 @@ const (
 		"\"downstream_remote_address\":\"%DOWNSTREAM_REMOTE_ADDRESS%\"," +
 		"\"requested_server_name\":\"%REQUESTED_SERVER_NAME%\",\"route_name\":\"%ROUTE_NAME%\"}\n"
 
+	// EnvoyTCPLogFormat is the default log format for the TCP connections, which have no
+	// request and response to log.
+	EnvoyTCPLogFormat = "{\"start_time\":\"%START_TIME%\",\"connection_id\":\"%CONNECTION_ID%\"," +
+		"\"response_flags\":\"%RESPONSE_FLAGS%\"," +
+		"\"connection_termination_details\":\"%CONNECTION_TERMINATION_DETAILS%\"," +
+		"\"upstream_transport_failure_reason\":\"%UPSTREAM_TRANSPORT_FAILURE_REASON%\"," +
+		"\"bytes_received\":\"%BYTES_RECEIVED%\",\"bytes_sent\":\"%BYTES_SENT%\"," +
+		"\"duration\":\"%DURATION%\"," +
+		"\"upstream_host\":\"%UPSTREAM_HOST%\",\"upstream_cluster\":\"%UPSTREAM_CLUSTER%\"," +
+		"\"upstream_local_address\":\"%UPSTREAM_LOCAL_ADDRESS%\"," +
+		"\"downstream_local_address\":\"%DOWNSTREAM_LOCAL_ADDRESS%\"," +
+		"\"downstream_remote_address\":\"%DOWNSTREAM_REMOTE_ADDRESS%\"," +
+		"\"requested_server_name\":\"%REQUESTED_SERVER_NAME%\"}\n"
+
 	otelLogName   = "otel_envoy_accesslog"
 	otelAccessLog = "envoy.access_loggers.open_telemetry"
 
@@ -90,7 +104,9 @@ var (
 	}
 )
 
-func buildXdsAccessLog(al *ir.AccessLog, forListener bool) []*accesslog.AccessLog {
+// buildXdsAccessLog builds the access logs of the traffic of the protocol, skipping the
+// ones restricted to the traffic of another protocol.
+func buildXdsAccessLog(al *ir.AccessLog, protocol egv1a1.ProxyAccessLogProtocol, forListener bool) []*accesslog.AccessLog {
 	if al == nil {
 		return nil
 	}
@@ -99,10 +115,13 @@ func buildXdsAccessLog(al *ir.AccessLog, forListener bool) []*accesslog.AccessLo
 	accessLogs := make([]*accesslog.AccessLog, 0, totalLen)
 	// handle text file access logs
 	for _, text := range al.Text {
+		if !accessLogForProtocol(text.Protocol, protocol) {
+			continue
+		}
 		filelog := &fileaccesslog.FileAccessLog{
 			Path: text.Path,
 		}
-		format := EnvoyTextLogFormat
+		format := defaultAccessLogFormat(protocol)
 		if text.Format != nil {
 			format = *text.Format
 		}
@@ -136,6 +155,9 @@ func buildXdsAccessLog(al *ir.AccessLog, forListener bool) []*accesslog.AccessLo
 	}
 	// handle json file access logs
 	for _, json := range al.JSON {
+		if !accessLogForProtocol(json.Protocol, protocol) {
+			continue
+		}
 		jsonFormat := &structpb.Struct{
 			Fields: make(map[string]*structpb.Value, len(json.JSON)),
 		}
@@ -179,6 +201,9 @@ func buildXdsAccessLog(al *ir.AccessLog, forListener bool) []*accesslog.AccessLo
 	}
 	// handle ALS access logs
 	for _, als := range al.ALS {
+		if !accessLogForProtocol(als.Protocol, protocol) {
+			continue
+		}
 		cc := &grpcaccesslog.CommonGrpcAccessLogConfig{
 			LogName: als.LogName,
 			GrpcService: &cfgcore.GrpcService{
@@ -228,6 +253,9 @@ func buildXdsAccessLog(al *ir.AccessLog, forListener bool) []*accesslog.AccessLo
 	}
 	// handle open telemetry access logs
 	for _, otel := range al.OpenTelemetry {
+		if !accessLogForProtocol(otel.Protocol, protocol) {
+			continue
+		}
 		al := &otelaccesslog.OpenTelemetryAccessLogConfig{
 			CommonConfig: &grpcaccesslog.CommonGrpcAccessLogConfig{
 				LogName: otelLogName,
@@ -244,7 +272,7 @@ func buildXdsAccessLog(al *ir.AccessLog, forListener bool) []*accesslog.AccessLo
 			ResourceAttributes: convertToKeyValueList(otel.Resources, false),
 		}
 
-		format := EnvoyTextLogFormat
+		format := defaultAccessLogFormat(protocol)
 		if otel.Text != nil {
 			format = *otel.Text
 		}
@@ -277,6 +305,21 @@ func buildXdsAccessLog(al *ir.AccessLog, forListener bool) []*accesslog.AccessLo
 	return accessLogs
 }
 
+// accessLogForProtocol returns true if an access log restricted to the traffic of the
+// given protocol, if any, applies to the traffic of the protocol.
+func accessLogForProtocol(restriction *egv1a1.ProxyAccessLogProtocol, protocol egv1a1.ProxyAccessLogProtocol) bool {
+	return restriction == nil || *restriction == protocol
+}
+
+// defaultAccessLogFormat returns the format of the access logs of the traffic of the
+// protocol when none is configured.
+func defaultAccessLogFormat(protocol egv1a1.ProxyAccessLogProtocol) string {
+	if protocol == egv1a1.ProxyAccessLogProtocolTCP {
+		return EnvoyTCPLogFormat
+	}
+	return EnvoyTextLogFormat
+}
+
 func celAccessLogFilter(expr string) *accesslog.AccessLogFilter {
 	fl := &cel.ExpressionFilter{
 		Expression: expr,
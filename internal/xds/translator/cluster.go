@@ -28,6 +28,7 @@ import (
 	"google.golang.org/protobuf/types/known/wrapperspb"
 	"k8s.io/utils/ptr"
 
+	egv1a1 "github.com/envoyproxy/gateway/api/v1alpha1"
 	"github.com/envoyproxy/gateway/internal/ir"
 )
 
@@ -55,6 +56,8 @@ type xdsClusterArgs struct {
 	backendConnection *ir.BackendConnection
 	dns               *ir.DNS
 	useClientProtocol bool
+	// metadata is the metadata of the resource the cluster was generated from.
+	metadata *ir.ResourceMetadata
 }
 
 type EndpointType int
@@ -62,6 +65,7 @@ type EndpointType int
 const (
 	EndpointTypeDNS EndpointType = iota
 	EndpointTypeStatic
+	EndpointTypeOriginalDst
 )
 
 func buildEndpointType(settings []*ir.DestinationSetting) EndpointType {
@@ -71,6 +75,10 @@ func buildEndpointType(settings []*ir.DestinationSetting) EndpointType {
 		return EndpointTypeStatic
 	}
 
+	if settings[0].OriginalDestination != nil {
+		return EndpointTypeOriginalDst
+	}
+
 	addrType := settings[0].AddressType
 
 	if addrType != nil && *addrType == ir.FQDN {
@@ -91,6 +99,7 @@ func buildXdsCluster(args *xdsClusterArgs) *clusterv3.Cluster {
 		},
 		OutlierDetection:              &clusterv3.OutlierDetection{},
 		PerConnectionBufferLimitBytes: buildBackandConnectionBufferLimitBytes(args.backendConnection),
+		Metadata:                      buildXdsClusterMetadata(args.metadata),
 	}
 
 	cluster.ConnectTimeout = buildConnectTimeout(args.timeout)
@@ -140,7 +149,8 @@ func buildXdsCluster(args *xdsClusterArgs) *clusterv3.Cluster {
 		}
 	}
 
-	if args.endpointType == EndpointTypeStatic {
+	switch args.endpointType {
+	case EndpointTypeStatic:
 		cluster.ClusterDiscoveryType = &clusterv3.Cluster_Type{Type: clusterv3.Cluster_EDS}
 		cluster.EdsClusterConfig = &clusterv3.Cluster_EdsClusterConfig{
 			ServiceName: args.name,
@@ -151,7 +161,9 @@ func buildXdsCluster(args *xdsClusterArgs) *clusterv3.Cluster {
 				},
 			},
 		}
-	} else {
+	case EndpointTypeOriginalDst:
+		cluster.ClusterDiscoveryType = &clusterv3.Cluster_Type{Type: clusterv3.Cluster_ORIGINAL_DST}
+	default:
 		cluster.ClusterDiscoveryType = &clusterv3.Cluster_Type{Type: clusterv3.Cluster_STRICT_DNS}
 		cluster.DnsRefreshRate = durationpb.New(30 * time.Second)
 		cluster.RespectDnsTtl = true
@@ -164,6 +176,9 @@ func buildXdsCluster(args *xdsClusterArgs) *clusterv3.Cluster {
 			if args.dns.RespectDNSTTL != nil {
 				cluster.RespectDnsTtl = ptr.Deref(args.dns.RespectDNSTTL, true)
 			}
+			if args.dns.LookupFamily != nil {
+				cluster.DnsLookupFamily = buildXdsDNSLookupFamily(*args.dns.LookupFamily)
+			}
 		}
 	}
 
@@ -215,6 +230,14 @@ func buildXdsCluster(args *xdsClusterArgs) *clusterv3.Cluster {
 		}
 	}
 
+	// Original destination clusters pick the upstream host themselves.
+	if args.endpointType == EndpointTypeOriginalDst {
+		cluster.LbPolicy = clusterv3.Cluster_CLUSTER_PROVIDED
+		if lbConfig := buildXdsOriginalDstLbConfig(args.settings[0].OriginalDestination); lbConfig != nil {
+			cluster.LbConfig = lbConfig
+		}
+	}
+
 	if args.healthCheck != nil && args.healthCheck.Active != nil {
 		cluster.HealthChecks = buildXdsHealthCheck(args.healthCheck.Active)
 	}
@@ -224,13 +247,43 @@ func buildXdsCluster(args *xdsClusterArgs) *clusterv3.Cluster {
 	}
 
 	cluster.CircuitBreakers = buildXdsClusterCircuitBreaker(args.circuitBreaker)
+	cluster.PreconnectPolicy = buildXdsClusterPreconnectPolicy(args.backendConnection)
 
-	if args.tcpkeepalive != nil {
-		cluster.UpstreamConnectionOptions = buildXdsClusterUpstreamOptions(args.tcpkeepalive)
-	}
+	cluster.UpstreamConnectionOptions = buildXdsClusterUpstreamOptions(args.tcpkeepalive, args.backendConnection)
 	return cluster
 }
 
+// buildXdsOriginalDstLbConfig returns the load balancer config of the original destination cluster,
+// which reads the original destination address from the request header if set.
+func buildXdsOriginalDstLbConfig(od *ir.OriginalDestination) *clusterv3.Cluster_OriginalDstLbConfig_ {
+	if od == nil || od.HeaderName == nil {
+		return nil
+	}
+
+	return &clusterv3.Cluster_OriginalDstLbConfig_{
+		OriginalDstLbConfig: &clusterv3.Cluster_OriginalDstLbConfig{
+			UseHttpHeader:  true,
+			HttpHeaderName: *od.HeaderName,
+		},
+	}
+}
+
+// buildXdsDNSLookupFamily converts the DNS lookup family to the xds cluster DNS lookup family.
+func buildXdsDNSLookupFamily(family egv1a1.DNSLookupFamily) clusterv3.Cluster_DnsLookupFamily {
+	switch family {
+	case egv1a1.IPv6DNSLookupFamily:
+		return clusterv3.Cluster_V6_ONLY
+	case egv1a1.IPv4PreferredDNSLookupFamily:
+		return clusterv3.Cluster_V4_PREFERRED
+	case egv1a1.IPv6PreferredDNSLookupFamily:
+		return clusterv3.Cluster_AUTO
+	case egv1a1.IPv4AndIPv6DNSLookupFamily:
+		return clusterv3.Cluster_ALL
+	default:
+		return clusterv3.Cluster_V4_ONLY
+	}
+}
+
 func buildXdsHealthCheck(healthcheck *ir.ActiveHealthCheck) []*corev3.HealthCheck {
 	hc := &corev3.HealthCheck{
 		Timeout:  durationpb.New(healthcheck.Timeout.Duration),
@@ -396,9 +449,33 @@ func buildXdsClusterCircuitBreaker(circuitBreaker *ir.CircuitBreaker) *clusterv3
 		Thresholds: []*clusterv3.CircuitBreakers_Thresholds{cbt},
 	}
 
+	if circuitBreaker != nil && circuitBreaker.PerEndpointMaxConnections != nil {
+		ecb.PerHostThresholds = []*clusterv3.CircuitBreakers_Thresholds{
+			{
+				Priority:       corev3.RoutingPriority_DEFAULT,
+				MaxConnections: wrapperspb.UInt32(*circuitBreaker.PerEndpointMaxConnections),
+			},
+		}
+	}
+
 	return ecb
 }
 
+func buildXdsClusterPreconnectPolicy(bc *ir.BackendConnection) *clusterv3.Cluster_PreconnectPolicy {
+	if bc == nil || bc.Preconnect == nil {
+		return nil
+	}
+
+	policy := &clusterv3.Cluster_PreconnectPolicy{}
+	if bc.Preconnect.PerEndpointPercent != nil {
+		policy.PerUpstreamPreconnectRatio = wrapperspb.Double(float64(*bc.Preconnect.PerEndpointPercent) / 100)
+	}
+	if bc.Preconnect.PredictivePercent != nil {
+		policy.PredictivePreconnectRatio = wrapperspb.Double(float64(*bc.Preconnect.PredictivePercent) / 100)
+	}
+	return policy
+}
+
 func buildXdsClusterLoadAssignment(clusterName string, destSettings []*ir.DestinationSetting) *endpointv3.ClusterLoadAssignment {
 	localities := make([]*endpointv3.LocalityLbEndpoints, 0, len(destSettings))
 	for i, ds := range destSettings {
@@ -622,15 +699,36 @@ func buildConnectTimeout(to *ir.Timeout) *durationpb.Duration {
 	return durationpb.New(tcpClusterPerConnectTimeout)
 }
 
-func buildXdsClusterUpstreamOptions(tcpkeepalive *ir.TCPKeepalive) *clusterv3.UpstreamConnectionOptions {
-	if tcpkeepalive == nil {
+func buildXdsClusterUpstreamOptions(tcpkeepalive *ir.TCPKeepalive, bc *ir.BackendConnection) *clusterv3.UpstreamConnectionOptions {
+	var happyEyeballs *ir.HappyEyeballs
+	if bc != nil {
+		happyEyeballs = bc.HappyEyeballs
+	}
+	if tcpkeepalive == nil && happyEyeballs == nil {
 		return nil
 	}
 
-	ka := &clusterv3.UpstreamConnectionOptions{
-		TcpKeepalive: &corev3.TcpKeepalive{},
+	ka := &clusterv3.UpstreamConnectionOptions{}
+
+	if happyEyeballs != nil {
+		ka.HappyEyeballsConfig = &clusterv3.UpstreamConnectionOptions_HappyEyeballsConfig{}
+		if happyEyeballs.FirstAddressFamily != nil {
+			ka.HappyEyeballsConfig.FirstAddressFamilyVersion = clusterv3.UpstreamConnectionOptions_V4
+			if *happyEyeballs.FirstAddressFamily == egv1a1.IPv6 {
+				ka.HappyEyeballsConfig.FirstAddressFamilyVersion = clusterv3.UpstreamConnectionOptions_V6
+			}
+		}
+		if happyEyeballs.FirstAddressFamilyCount != nil {
+			ka.HappyEyeballsConfig.FirstAddressFamilyCount = wrapperspb.UInt32(*happyEyeballs.FirstAddressFamilyCount)
+		}
+	}
+
+	if tcpkeepalive == nil {
+		return ka
 	}
 
+	ka.TcpKeepalive = &corev3.TcpKeepalive{}
+
 	if tcpkeepalive.Probes != nil {
 		ka.TcpKeepalive.KeepaliveProbes = wrapperspb.UInt32(*tcpkeepalive.Probes)
 	}
@@ -647,6 +745,9 @@ func buildXdsClusterUpstreamOptions(tcpkeepalive *ir.TCPKeepalive) *clusterv3.Up
 }
 
 func buildAddress(irEp *ir.DestinationEndpoint) *corev3.Address {
+	if irEp.InternalListener != nil {
+		return buildXdsInternalAddress(*irEp.InternalListener)
+	}
 	if irEp.Path != nil {
 		return &corev3.Address{
 			Address: &corev3.Address_Pipe{
@@ -737,6 +838,7 @@ func (httpRoute *HTTPRouteTranslator) asClusterArgs(extra *ExtraArgs) *xdsCluste
 		http1Settings:     extra.http1Settings,
 		http2Settings:     extra.http2Settings,
 		useClientProtocol: ptr.Deref(httpRoute.UseClientProtocol, false),
+		metadata:          httpRoute.Metadata,
 	}
 
 	// Populate traffic features.
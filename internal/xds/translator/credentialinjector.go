@@ -0,0 +1,266 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package translator
+
+import (
+	"errors"
+	"fmt"
+
+	corev3 "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	routev3 "github.com/envoyproxy/go-control-plane/envoy/config/route/v3"
+	credentialinjectorv3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/credential_injector/v3"
+	hcmv3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/network/http_connection_manager/v3"
+	genericv3 "github.com/envoyproxy/go-control-plane/envoy/extensions/http/injected_credentials/generic/v3"
+	oauth2credentialv3 "github.com/envoyproxy/go-control-plane/envoy/extensions/http/injected_credentials/oauth2/v3"
+	tlsv3 "github.com/envoyproxy/go-control-plane/envoy/extensions/transport_sockets/tls/v3"
+	"google.golang.org/protobuf/types/known/anypb"
+	"google.golang.org/protobuf/types/known/durationpb"
+
+	egv1a1 "github.com/envoyproxy/gateway/api/v1alpha1"
+	"github.com/envoyproxy/gateway/internal/ir"
+	"github.com/envoyproxy/gateway/internal/xds/types"
+)
+
+const (
+	genericCredentialExtension = "envoy.http.injected_credentials.generic"
+	oauth2CredentialExtension  = "envoy.http.injected_credentials.oauth2"
+)
+
+func init() {
+	registerHTTPFilter(&credentialInjector{})
+}
+
+type credentialInjector struct{}
+
+var _ httpFilter = &credentialInjector{}
+
+// patchHCM builds and appends the credential_injector Filters to the HTTP Connection Manager
+// if applicable, and they do not already exist.
+// Note: the credential_injector filter has no route level config, so this method creates a
+// filter for each set of injected credentials. The filter is disabled by default. It is
+// enabled on the route level.
+func (*credentialInjector) patchHCM(mgr *hcmv3.HttpConnectionManager, irListener *ir.HTTPListener) error {
+	var errs error
+
+	if mgr == nil {
+		return errors.New("hcm is nil")
+	}
+
+	if irListener == nil {
+		return errors.New("ir listener is nil")
+	}
+
+	for _, route := range irListener.Routes {
+		if !routeContainsCredentialInjection(route) {
+			continue
+		}
+
+		if hcmContainsFilter(mgr, credentialInjectorFilterName(route.Traffic.CredentialInjection)) {
+			continue
+		}
+
+		filter, err := buildHCMCredentialInjectorFilter(route.Traffic.CredentialInjection)
+		if err != nil {
+			errs = errors.Join(errs, err)
+			continue
+		}
+
+		mgr.HttpFilters = append(mgr.HttpFilters, filter)
+	}
+
+	return errs
+}
+
+// buildHCMCredentialInjectorFilter returns a credential_injector HTTP filter injecting the
+// provided credentials.
+func buildHCMCredentialInjectorFilter(ci *ir.CredentialInjection) (*hcmv3.HttpFilter, error) {
+	credentialProto, err := credentialInjectorConfig(ci)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := credentialProto.ValidateAll(); err != nil {
+		return nil, err
+	}
+
+	credentialAny, err := anypb.New(credentialProto)
+	if err != nil {
+		return nil, err
+	}
+
+	return &hcmv3.HttpFilter{
+		Name:     credentialInjectorFilterName(ci),
+		Disabled: true,
+		ConfigType: &hcmv3.HttpFilter_TypedConfig{
+			TypedConfig: credentialAny,
+		},
+	}, nil
+}
+
+func credentialInjectorFilterName(ci *ir.CredentialInjection) string {
+	return perRouteFilterName(egv1a1.EnvoyFilterCredentialInjector, ci.Name)
+}
+
+// credentialInjectorConfig returns the credential_injector config of the credentials. The
+// bearer token is read from an SDS secret by the generic credential, and the OAuth2 access
+// token is fetched, and cached until it expires, by the oauth2 credential.
+func credentialInjectorConfig(ci *ir.CredentialInjection) (*credentialinjectorv3.CredentialInjector, error) {
+	var credential *corev3.TypedExtensionConfig
+
+	switch {
+	case ci.BearerToken != nil:
+		genericAny, err := anypb.New(&genericv3.Generic{
+			Credential: &tlsv3.SdsSecretConfig{
+				Name:      credentialInjectorTokenSecretName(ci),
+				SdsConfig: makeConfigSource(),
+			},
+			Header: ci.BearerToken.Header,
+		})
+		if err != nil {
+			return nil, err
+		}
+		credential = &corev3.TypedExtensionConfig{
+			Name:        genericCredentialExtension,
+			TypedConfig: genericAny,
+		}
+	case ci.OAuth2 != nil:
+		cluster, err := url2Cluster(ci.OAuth2.TokenEndpoint)
+		if err != nil {
+			return nil, err
+		}
+		if cluster.endpointType == EndpointTypeStatic {
+			return nil, fmt.Errorf(
+				"static IP cluster is not allowed: %s",
+				ci.OAuth2.TokenEndpoint)
+		}
+
+		oauth2Any, err := anypb.New(&oauth2credentialv3.OAuth2{
+			TokenEndpoint: &corev3.HttpUri{
+				Uri: ci.OAuth2.TokenEndpoint,
+				HttpUpstreamType: &corev3.HttpUri_Cluster{
+					Cluster: cluster.name,
+				},
+				Timeout: &durationpb.Duration{
+					Seconds: defaultExtServiceRequestTimeout,
+				},
+			},
+			Scopes: ci.OAuth2.Scopes,
+			FlowType: &oauth2credentialv3.OAuth2_ClientCredentials_{
+				ClientCredentials: &oauth2credentialv3.OAuth2_ClientCredentials{
+					ClientId: ci.OAuth2.ClientID,
+					ClientSecret: &tlsv3.SdsSecretConfig{
+						Name:      credentialInjectorClientSecretName(ci),
+						SdsConfig: makeConfigSource(),
+					},
+					AuthType: oauth2credentialv3.OAuth2_BASIC_AUTH,
+				},
+			},
+		})
+		if err != nil {
+			return nil, err
+		}
+		credential = &corev3.TypedExtensionConfig{
+			Name:        oauth2CredentialExtension,
+			TypedConfig: oauth2Any,
+		}
+	default:
+		return nil, errors.New("credential injection has no credential")
+	}
+
+	return &credentialinjectorv3.CredentialInjector{
+		Overwrite:  ci.Overwrite,
+		Credential: credential,
+	}, nil
+}
+
+// routeContainsCredentialInjection returns true if the credential injection exists for the
+// provided route.
+func routeContainsCredentialInjection(irRoute *ir.HTTPRoute) bool {
+	if irRoute == nil {
+		return false
+	}
+
+	return irRoute.Traffic != nil && irRoute.Traffic.CredentialInjection != nil
+}
+
+// patchResources creates the token endpoint clusters and the secrets of the injected
+// credentials, if needed.
+func (*credentialInjector) patchResources(tCtx *types.ResourceVersionTable,
+	routes []*ir.HTTPRoute,
+) error {
+	if tCtx == nil || tCtx.XdsResources == nil {
+		return errors.New("xds resource table is nil")
+	}
+
+	var errs error
+	for _, route := range routes {
+		if !routeContainsCredentialInjection(route) {
+			continue
+		}
+
+		ci := route.Traffic.CredentialInjection
+		var secret *tlsv3.Secret
+		switch {
+		case ci.BearerToken != nil:
+			secret = buildGenericSecret(credentialInjectorTokenSecretName(ci),
+				append([]byte("Bearer "), ci.BearerToken.Token...))
+		case ci.OAuth2 != nil:
+			if err := createTokenEndpointCluster(tCtx, ci.OAuth2.TokenEndpoint); err != nil {
+				errs = errors.Join(errs, err)
+				continue
+			}
+			secret = buildGenericSecret(credentialInjectorClientSecretName(ci), ci.OAuth2.ClientSecret)
+		default:
+			continue
+		}
+
+		// The routes sharing the credentials share their secret.
+		if err := addXdsSecret(tCtx, secret); err != nil && !errors.Is(err, ErrXdsSecretExists) {
+			errs = errors.Join(errs, err)
+		}
+	}
+
+	return errs
+}
+
+func buildGenericSecret(name string, value []byte) *tlsv3.Secret {
+	return &tlsv3.Secret{
+		Name: name,
+		Type: &tlsv3.Secret_GenericSecret{
+			GenericSecret: &tlsv3.GenericSecret{
+				Secret: &corev3.DataSource{
+					Specifier: &corev3.DataSource_InlineBytes{
+						InlineBytes: value,
+					},
+				},
+			},
+		},
+	}
+}
+
+func credentialInjectorTokenSecretName(ci *ir.CredentialInjection) string {
+	return fmt.Sprintf("credential_injector/token/%s", ci.Name)
+}
+
+func credentialInjectorClientSecretName(ci *ir.CredentialInjection) string {
+	return fmt.Sprintf("credential_injector/client_secret/%s", ci.Name)
+}
+
+// patchRoute patches the provided route with the credential injection config if applicable.
+// Note: this method enables the corresponding credential_injector filter for the provided route.
+func (*credentialInjector) patchRoute(route *routev3.Route, irRoute *ir.HTTPRoute) error {
+	if route == nil {
+		return errors.New("xds route is nil")
+	}
+	if irRoute == nil {
+		return errors.New("ir route is nil")
+	}
+	if !routeContainsCredentialInjection(irRoute) {
+		return nil
+	}
+
+	return enableFilterOnRoute(route, credentialInjectorFilterName(irRoute.Traffic.CredentialInjection))
+}
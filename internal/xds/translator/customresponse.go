@@ -0,0 +1,306 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package translator
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+
+	cncfv3 "github.com/cncf/xds/go/xds/core/v3"
+	matcherv3 "github.com/cncf/xds/go/xds/type/matcher/v3"
+	corev3 "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	routev3 "github.com/envoyproxy/go-control-plane/envoy/config/route/v3"
+	customresponsev3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/custom_response/v3"
+	hcmv3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/network/http_connection_manager/v3"
+	localresponsev3 "github.com/envoyproxy/go-control-plane/envoy/extensions/http/custom_response/local_response_policy/v3"
+	envoymatcherv3 "github.com/envoyproxy/go-control-plane/envoy/type/matcher/v3"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/anypb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+
+	egv1a1 "github.com/envoyproxy/gateway/api/v1alpha1"
+	"github.com/envoyproxy/gateway/internal/ir"
+	"github.com/envoyproxy/gateway/internal/xds/types"
+)
+
+// grpcStatusHeader is the header holding the gRPC status of trailers-only responses.
+const grpcStatusHeader = "grpc-status"
+
+func init() {
+	registerHTTPFilter(&customResponse{})
+}
+
+type customResponse struct{}
+
+var _ httpFilter = &customResponse{}
+
+// patchHCM builds and appends the custom response filter to the HTTP Connection Manager
+// if applicable, and it does not already exist.
+// Note: the filter has no matcher, the matcher of each route is set on the route.
+func (*customResponse) patchHCM(mgr *hcmv3.HttpConnectionManager, irListener *ir.HTTPListener) error {
+	if mgr == nil {
+		return errors.New("hcm is nil")
+	}
+
+	if irListener == nil {
+		return errors.New("ir listener is nil")
+	}
+
+	if !listenerContainsResponseOverride(irListener) {
+		return nil
+	}
+
+	// Return early if the custom response filter already exists.
+	for _, existingFilter := range mgr.HttpFilters {
+		if existingFilter.Name == egv1a1.EnvoyFilterCustomResponse.String() {
+			return nil
+		}
+	}
+
+	filterAny, err := anypb.New(&customresponsev3.CustomResponse{})
+	if err != nil {
+		return err
+	}
+
+	mgr.HttpFilters = append(mgr.HttpFilters, &hcmv3.HttpFilter{
+		Name: egv1a1.EnvoyFilterCustomResponse.String(),
+		ConfigType: &hcmv3.HttpFilter_TypedConfig{
+			TypedConfig: filterAny,
+		},
+	})
+
+	return nil
+}
+
+// listenerContainsResponseOverride returns true if a route of the listener overrides the
+// responses of its backends.
+func listenerContainsResponseOverride(irListener *ir.HTTPListener) bool {
+	for _, route := range irListener.Routes {
+		if routeContainsResponseOverride(route) {
+			return true
+		}
+	}
+	return false
+}
+
+// routeContainsResponseOverride returns true if the route overrides the responses of its
+// backends.
+func routeContainsResponseOverride(irRoute *ir.HTTPRoute) bool {
+	return irRoute != nil &&
+		irRoute.Traffic != nil &&
+		irRoute.Traffic.ResponseOverride != nil &&
+		len(irRoute.Traffic.ResponseOverride.Rules) > 0
+}
+
+func (*customResponse) patchResources(*types.ResourceVersionTable, []*ir.HTTPRoute) error {
+	return nil
+}
+
+// patchRoute sets the matcher of the custom response filter of the route.
+func (*customResponse) patchRoute(route *routev3.Route, irRoute *ir.HTTPRoute) error {
+	if route == nil {
+		return errors.New("xds route is nil")
+	}
+	if irRoute == nil {
+		return errors.New("ir route is nil")
+	}
+	if !routeContainsResponseOverride(irRoute) {
+		return nil
+	}
+
+	filterName := egv1a1.EnvoyFilterCustomResponse.String()
+	filterCfg := route.GetTypedPerFilterConfig()
+	if _, ok := filterCfg[filterName]; ok {
+		// This should not happen since this is the only place where the custom response
+		// filter is added in a route.
+		return fmt.Errorf("route already contains custom response config: %+v", route)
+	}
+
+	routeCfgProto, err := buildCustomResponse(irRoute.Traffic.ResponseOverride)
+	if err != nil {
+		return err
+	}
+
+	routeCfgAny, err := anypb.New(routeCfgProto)
+	if err != nil {
+		return err
+	}
+
+	if filterCfg == nil {
+		route.TypedPerFilterConfig = make(map[string]*anypb.Any)
+	}
+
+	route.TypedPerFilterConfig[filterName] = routeCfgAny
+
+	return nil
+}
+
+// buildCustomResponse returns the custom response filter config matching the responses
+// against the rules in order, the first matching rule replacing the response.
+func buildCustomResponse(responseOverride *ir.ResponseOverride) (*customresponsev3.CustomResponse, error) {
+	var matchers []*matcherv3.Matcher_MatcherList_FieldMatcher
+
+	for _, rule := range responseOverride.Rules {
+		predicate, err := buildCustomResponsePredicate(rule.Match)
+		if err != nil {
+			return nil, err
+		}
+
+		policyAny, err := anypb.New(buildLocalResponsePolicy(rule.Response))
+		if err != nil {
+			return nil, err
+		}
+
+		matchers = append(matchers, &matcherv3.Matcher_MatcherList_FieldMatcher{
+			Predicate: predicate,
+			OnMatch: &matcherv3.Matcher_OnMatch{
+				OnMatch: &matcherv3.Matcher_OnMatch_Action{
+					Action: &cncfv3.TypedExtensionConfig{
+						Name:        rule.Name,
+						TypedConfig: policyAny,
+					},
+				},
+			},
+		})
+	}
+
+	customResponse := &customresponsev3.CustomResponse{
+		CustomResponseMatcher: &matcherv3.Matcher{
+			MatcherType: &matcherv3.Matcher_MatcherList_{
+				MatcherList: &matcherv3.Matcher_MatcherList{
+					Matchers: matchers,
+				},
+			},
+		},
+	}
+	if err := customResponse.ValidateAll(); err != nil {
+		return nil, err
+	}
+
+	return customResponse, nil
+}
+
+// buildCustomResponsePredicate returns the predicate matching the responses whose status,
+// or gRPC status, is any of the ones of the match.
+// The ranges of status codes covering whole classes, e.g. 500-599, are matched by class.
+func buildCustomResponsePredicate(match ir.CustomResponseMatch) (*matcherv3.Matcher_MatcherList_Predicate, error) {
+	var predicates []*matcherv3.Matcher_MatcherList_Predicate
+
+	addPredicate := func(name string, input proto.Message, value string) error {
+		predicate, err := buildExactValuePredicate(name, input, value)
+		if err != nil {
+			return err
+		}
+		predicates = append(predicates, predicate)
+		return nil
+	}
+
+	for _, statusCode := range match.StatusCodes {
+		switch {
+		case statusCode.Value != nil:
+			if err := addPredicate("status_code", &envoymatcherv3.HttpResponseStatusCodeMatchInput{},
+				strconv.Itoa(*statusCode.Value)); err != nil {
+				return nil, err
+			}
+		case statusCode.Range != nil:
+			for code := statusCode.Range.Start; code <= statusCode.Range.End; {
+				if code%100 == 0 && code+99 <= statusCode.Range.End {
+					if err := addPredicate("status_code_class", &envoymatcherv3.HttpResponseStatusCodeClassMatchInput{},
+						fmt.Sprintf("%dxx", code/100)); err != nil {
+						return nil, err
+					}
+					code += 100
+					continue
+				}
+				if err := addPredicate("status_code", &envoymatcherv3.HttpResponseStatusCodeMatchInput{},
+					strconv.Itoa(code)); err != nil {
+					return nil, err
+				}
+				code++
+			}
+		}
+	}
+
+	for _, grpcStatus := range match.GRPCStatuses {
+		if err := addPredicate("grpc_status", &envoymatcherv3.HttpResponseHeaderMatchInput{HeaderName: grpcStatusHeader},
+			strconv.FormatUint(uint64(grpcStatus), 10)); err != nil {
+			return nil, err
+		}
+	}
+
+	switch len(predicates) {
+	case 0:
+		return nil, errors.New("custom response match is empty")
+	case 1:
+		return predicates[0], nil
+	default:
+		return &matcherv3.Matcher_MatcherList_Predicate{
+			MatchType: &matcherv3.Matcher_MatcherList_Predicate_OrMatcher{
+				OrMatcher: &matcherv3.Matcher_MatcherList_Predicate_PredicateList{
+					Predicate: predicates,
+				},
+			},
+		}, nil
+	}
+}
+
+// buildExactValuePredicate returns the predicate matching the value of the input exactly.
+func buildExactValuePredicate(name string, input proto.Message, value string) (*matcherv3.Matcher_MatcherList_Predicate, error) {
+	inputAny, err := anypb.New(input)
+	if err != nil {
+		return nil, err
+	}
+
+	return &matcherv3.Matcher_MatcherList_Predicate{
+		MatchType: &matcherv3.Matcher_MatcherList_Predicate_SinglePredicate_{
+			SinglePredicate: &matcherv3.Matcher_MatcherList_Predicate_SinglePredicate{
+				Input: &cncfv3.TypedExtensionConfig{
+					Name:        name,
+					TypedConfig: inputAny,
+				},
+				Matcher: &matcherv3.Matcher_MatcherList_Predicate_SinglePredicate_ValueMatch{
+					ValueMatch: &matcherv3.StringMatcher{
+						MatchPattern: &matcherv3.StringMatcher_Exact{
+							Exact: value,
+						},
+					},
+				},
+			},
+		},
+	}, nil
+}
+
+// buildLocalResponsePolicy returns the policy replacing the responses with the custom
+// response.
+func buildLocalResponsePolicy(response ir.CustomResponse) *localresponsev3.LocalResponsePolicy {
+	policy := &localresponsev3.LocalResponsePolicy{}
+
+	if response.StatusCode != nil {
+		policy.StatusCode = wrapperspb.UInt32(*response.StatusCode)
+	}
+	if response.Body != nil {
+		policy.Body = &corev3.DataSource{
+			Specifier: &corev3.DataSource_InlineString{
+				InlineString: *response.Body,
+			},
+		}
+	}
+	if response.ContentType != nil {
+		policy.ResponseHeadersToAdd = append(policy.ResponseHeadersToAdd, &corev3.HeaderValueOption{
+			Header: &corev3.HeaderValue{
+				Key:   "content-type",
+				Value: *response.ContentType,
+			},
+			AppendAction: corev3.HeaderValueOption_OVERWRITE_IF_EXISTS_OR_ADD,
+		})
+	}
+	if len(response.AddResponseHeaders) > 0 {
+		policy.ResponseHeadersToAdd = append(policy.ResponseHeadersToAdd, buildXdsAddedHeaders(response.AddResponseHeaders)...)
+	}
+
+	return policy
+}
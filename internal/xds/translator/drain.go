@@ -0,0 +1,114 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package translator
+
+import (
+	"strconv"
+
+	corev3 "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	listenerv3 "github.com/envoyproxy/go-control-plane/envoy/config/listener/v3"
+	routev3 "github.com/envoyproxy/go-control-plane/envoy/config/route/v3"
+	hcmv3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/network/http_connection_manager/v3"
+	resourcev3 "github.com/envoyproxy/go-control-plane/pkg/resource/v3"
+	"github.com/envoyproxy/go-control-plane/pkg/wellknown"
+
+	"github.com/envoyproxy/gateway/internal/ir"
+	"github.com/envoyproxy/gateway/internal/utils/protocov"
+	"github.com/envoyproxy/gateway/internal/xds/types"
+)
+
+// drainRouteConfigName is the name of the route configuration answering the requests of
+// the new connections of a draining Gateway.
+const drainRouteConfigName = "drain"
+
+// processDrain replaces the routes of the HTTP connection managers of the listeners with
+// a route configuration rejecting, or redirecting, all the requests. The filter chains of
+// the listeners change, so that the new connections are served by the new filter chains
+// while the open connections finish on the drained ones, whose route configurations are
+// still served.
+func processDrain(tCtx *types.ResourceVersionTable, drain *ir.Drain) error {
+	if drain == nil {
+		return nil
+	}
+
+	for _, r := range tCtx.XdsResources[resourcev3.ListenerType] {
+		listener := r.(*listenerv3.Listener)
+		filterChains := listener.FilterChains
+		if listener.DefaultFilterChain != nil {
+			filterChains = append(filterChains, listener.DefaultFilterChain)
+		}
+		for _, filterChain := range filterChains {
+			if err := setDrainRouteConfig(filterChain, drain); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// setDrainRouteConfig replaces the RDS of the HCM of the filter chain, if any, with the
+// inline drain route configuration.
+func setDrainRouteConfig(filterChain *listenerv3.FilterChain, drain *ir.Drain) error {
+	for _, filter := range filterChain.Filters {
+		if filter.Name != wellknown.HTTPConnectionManager {
+			continue
+		}
+
+		hcm := &hcmv3.HttpConnectionManager{}
+		if err := filter.GetTypedConfig().UnmarshalTo(hcm); err != nil {
+			return err
+		}
+		hcm.RouteSpecifier = &hcmv3.HttpConnectionManager_RouteConfig{
+			RouteConfig: buildXdsDrainRouteConfig(drain),
+		}
+
+		hcmAny, err := protocov.ToAnyWithError(hcm)
+		if err != nil {
+			return err
+		}
+		filter.ConfigType = &listenerv3.Filter_TypedConfig{TypedConfig: hcmAny}
+	}
+	return nil
+}
+
+// buildXdsDrainRouteConfig returns the route configuration redirecting all the requests to
+// the redirect host if set, or rejecting them with a 503 and a Retry-After header.
+func buildXdsDrainRouteConfig(drain *ir.Drain) *routev3.RouteConfiguration {
+	route := &routev3.Route{
+		Name: drainRouteConfigName,
+		Match: &routev3.RouteMatch{
+			PathSpecifier: &routev3.RouteMatch_Prefix{Prefix: "/"},
+		},
+	}
+	if drain.RedirectHost != "" {
+		route.Action = &routev3.Route_Redirect{
+			Redirect: &routev3.RedirectAction{
+				HostRedirect: drain.RedirectHost,
+				ResponseCode: routev3.RedirectAction_FOUND,
+			},
+		}
+	} else {
+		route.Action = &routev3.Route_DirectResponse{
+			DirectResponse: &routev3.DirectResponseAction{Status: 503},
+		}
+		route.ResponseHeadersToAdd = []*corev3.HeaderValueOption{{
+			Header: &corev3.HeaderValue{
+				Key:   "Retry-After",
+				Value: strconv.FormatUint(uint64(drain.RetryAfter), 10),
+			},
+			AppendAction: corev3.HeaderValueOption_OVERWRITE_IF_EXISTS_OR_ADD,
+		}}
+	}
+
+	return &routev3.RouteConfiguration{
+		Name: drainRouteConfigName,
+		VirtualHosts: []*routev3.VirtualHost{{
+			Name:    drainRouteConfigName,
+			Domains: []string{"*"},
+			Routes:  []*routev3.Route{route},
+		}},
+	}
+}
@@ -0,0 +1,152 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package translator
+
+import (
+	"strings"
+
+	corev3 "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	listenerv3 "github.com/envoyproxy/go-control-plane/envoy/config/listener/v3"
+	hcmv3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/network/http_connection_manager/v3"
+	resourcev3 "github.com/envoyproxy/go-control-plane/pkg/resource/v3"
+	"github.com/envoyproxy/go-control-plane/pkg/wellknown"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/anypb"
+
+	egv1a1 "github.com/envoyproxy/gateway/api/v1alpha1"
+	"github.com/envoyproxy/gateway/internal/ir"
+	"github.com/envoyproxy/gateway/internal/utils/protocov"
+	"github.com/envoyproxy/gateway/internal/xds/types"
+)
+
+// processExtensionConfigDiscovery moves the configurations of the selected HTTP filters of
+// the HTTP connection managers of the listeners to ECDS resources, named after their filter,
+// so that a change of the configuration of a filter updates the filter without draining the
+// listeners serving it.
+//
+// The filters of the same name with different configurations in different HTTP connection
+// managers keep their configurations inline, as they can't share an ECDS resource.
+func processExtensionConfigDiscovery(tCtx *types.ResourceVersionTable, ecds *ir.ExtensionConfigDiscovery) error {
+	if ecds == nil {
+		return nil
+	}
+
+	var (
+		names     []string
+		configs   = make(map[string]*anypb.Any)
+		conflicts = make(map[string]bool)
+	)
+	err := forEachHCM(tCtx, func(hcm *hcmv3.HttpConnectionManager) bool {
+		for _, filter := range hcm.HttpFilters {
+			if !isDiscoveredFilter(filter, ecds.Filters) {
+				continue
+			}
+			config, ok := configs[filter.Name]
+			if !ok {
+				names = append(names, filter.Name)
+				configs[filter.Name] = filter.GetTypedConfig()
+				continue
+			}
+			if !proto.Equal(config, filter.GetTypedConfig()) {
+				conflicts[filter.Name] = true
+			}
+		}
+		return false
+	})
+	if err != nil {
+		return err
+	}
+	if len(names) == len(conflicts) {
+		return nil
+	}
+
+	err = forEachHCM(tCtx, func(hcm *hcmv3.HttpConnectionManager) bool {
+		changed := false
+		for _, filter := range hcm.HttpFilters {
+			if !isDiscoveredFilter(filter, ecds.Filters) || conflicts[filter.Name] {
+				continue
+			}
+			filter.ConfigType = &hcmv3.HttpFilter_ConfigDiscovery{
+				ConfigDiscovery: &corev3.ExtensionConfigSource{
+					ConfigSource: &corev3.ConfigSource{
+						ResourceApiVersion:    resourcev3.DefaultAPIVersion,
+						ConfigSourceSpecifier: &corev3.ConfigSource_Ads{Ads: &corev3.AggregatedConfigSource{}},
+					},
+					TypeUrls: []string{filter.GetTypedConfig().GetTypeUrl()},
+				},
+			}
+			changed = true
+		}
+		return changed
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, name := range names {
+		if conflicts[name] {
+			continue
+		}
+		if err := tCtx.AddXdsResource(resourcev3.ExtensionConfigType, &corev3.TypedExtensionConfig{
+			Name:        name,
+			TypedConfig: configs[name],
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// isDiscoveredFilter returns whether the configuration of the HTTP filter is delivered
+// with ECDS, which is the case of the filters of the listed types with an inline
+// configuration.
+func isDiscoveredFilter(filter *hcmv3.HttpFilter, filterTypes []egv1a1.EnvoyFilter) bool {
+	if filter.GetTypedConfig() == nil {
+		return false
+	}
+	for _, filterType := range filterTypes {
+		// The filters configured for some routes are named after their type and
+		// their configuration.
+		if filter.Name == string(filterType) || strings.HasPrefix(filter.Name, string(filterType)+"/") {
+			return true
+		}
+	}
+	return false
+}
+
+// forEachHCM calls the function with the HCMs of the filter chains of the listeners, and
+// updates the HCMs the function changed, which it returns true for.
+func forEachHCM(tCtx *types.ResourceVersionTable, fn func(hcm *hcmv3.HttpConnectionManager) bool) error {
+	for _, r := range tCtx.XdsResources[resourcev3.ListenerType] {
+		listener := r.(*listenerv3.Listener)
+		filterChains := listener.FilterChains
+		if listener.DefaultFilterChain != nil {
+			filterChains = append(filterChains, listener.DefaultFilterChain)
+		}
+		for _, filterChain := range filterChains {
+			for _, filter := range filterChain.Filters {
+				if filter.Name != wellknown.HTTPConnectionManager {
+					continue
+				}
+
+				hcm := &hcmv3.HttpConnectionManager{}
+				if err := filter.GetTypedConfig().UnmarshalTo(hcm); err != nil {
+					return err
+				}
+				if !fn(hcm) {
+					continue
+				}
+
+				hcmAny, err := protocov.ToAnyWithError(hcm)
+				if err != nil {
+					return err
+				}
+				filter.ConfigType = &listenerv3.Filter_TypedConfig{TypedConfig: hcmAny}
+			}
+		}
+	}
+	return nil
+}
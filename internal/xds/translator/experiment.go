@@ -0,0 +1,166 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package translator
+
+import (
+	"fmt"
+	"hash/fnv"
+	"regexp"
+	"sort"
+
+	corev3 "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	routev3 "github.com/envoyproxy/go-control-plane/envoy/config/route/v3"
+	matcherv3 "github.com/envoyproxy/go-control-plane/envoy/type/matcher/v3"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+
+	"github.com/envoyproxy/gateway/internal/ir"
+	"github.com/envoyproxy/gateway/internal/xds/types"
+)
+
+// experimentTotalWeight is the total weight of the buckets of an experiment, whose
+// weights are their percentages.
+const experimentTotalWeight = 100
+
+// buildXdsExperimentRoutes assigns the requests of the xDS route to the buckets of the
+// experiment of the route, if any, and returns the routes of the requests of the clients
+// already assigned to a bucket, which must precede the xDS route.
+//
+// The requests without experiment cookie are assigned a bucket by the weighted clusters
+// of the xDS route, which set the cookie on the response. The header of the experiment, if
+// set, provides the value the weighted cluster is selected with, so that the identifiers of
+// the header are hashed into the same bucket on every proxy.
+func buildXdsExperimentRoutes(httpRoute *ir.HTTPRoute, xdsRoute *routev3.Route) []*routev3.Route {
+	if httpRoute.Traffic == nil || httpRoute.Traffic.Experiment == nil || xdsRoute.GetRoute() == nil {
+		return nil
+	}
+	experiment := httpRoute.Traffic.Experiment
+
+	routes := make([]*routev3.Route, 0, len(experiment.Buckets))
+	for _, bucket := range experiment.Buckets {
+		route := proto.Clone(xdsRoute).(*routev3.Route)
+		route.Name = fmt.Sprintf("%s/experiment/%s", xdsRoute.Name, bucket.Name)
+		route.Match.Headers = append(route.Match.Headers, buildExperimentCookieMatch(experiment.Cookie, bucket.Name))
+		route.GetRoute().ClusterSpecifier = &routev3.RouteAction_Cluster{
+			Cluster: bucket.Destination.Name,
+		}
+		routes = append(routes, route)
+	}
+
+	weightedClusters := &routev3.WeightedCluster{
+		Clusters: buildExperimentClusterWeights(experiment),
+	}
+	if experiment.Header != nil {
+		weightedClusters.RandomValueSpecifier = &routev3.WeightedCluster_HeaderName{
+			HeaderName: *experiment.Header,
+		}
+	}
+	xdsRoute.GetRoute().ClusterSpecifier = &routev3.RouteAction_WeightedClusters{
+		WeightedClusters: weightedClusters,
+	}
+
+	return routes
+}
+
+// buildExperimentCookieMatch returns the header matcher of the requests holding the
+// experiment cookie of the bucket.
+func buildExperimentCookieMatch(cookie, bucket string) *routev3.HeaderMatcher {
+	// The values of the cookie headers are joined with a comma when matched.
+	regex := fmt.Sprintf(`(.*[;,]\s*)?%s=%s(\s*[;,].*)?`, regexp.QuoteMeta(cookie), regexp.QuoteMeta(bucket))
+	return &routev3.HeaderMatcher{
+		Name: "cookie",
+		HeaderMatchSpecifier: &routev3.HeaderMatcher_StringMatch{
+			StringMatch: &matcherv3.StringMatcher{
+				MatchPattern: &matcherv3.StringMatcher_SafeRegex{
+					SafeRegex: &matcherv3.RegexMatcher{
+						Regex: regex,
+					},
+				},
+			},
+		},
+	}
+}
+
+// experimentSegment is the range of the values assigned to a bucket.
+type experimentSegment struct {
+	bucket *ir.ExperimentBucket
+	start  uint32
+	weight uint32
+}
+
+// buildExperimentClusterWeights returns the weighted clusters of the buckets of the
+// experiment, each setting the experiment cookie of its bucket on the response.
+//
+// A weighted cluster is selected by the value it is assigned modulo the total weight, so
+// the ranges of the buckets are shifted by the hash of the salt, the range wrapping around
+// being split in two weighted clusters.
+func buildExperimentClusterWeights(experiment *ir.Experiment) []*routev3.WeightedCluster_ClusterWeight {
+	var offset uint32
+	if experiment.Salt != nil {
+		h := fnv.New32a()
+		_, _ = h.Write([]byte(*experiment.Salt))
+		offset = h.Sum32() % experimentTotalWeight
+	}
+
+	var segments []experimentSegment
+	start := offset
+	for _, bucket := range experiment.Buckets {
+		if bucket.Percentage == 0 {
+			continue
+		}
+		if end := start + bucket.Percentage; end > experimentTotalWeight {
+			segments = append(segments,
+				experimentSegment{bucket: bucket, start: start, weight: experimentTotalWeight - start},
+				experimentSegment{bucket: bucket, start: 0, weight: end - experimentTotalWeight})
+		} else {
+			segments = append(segments, experimentSegment{bucket: bucket, start: start, weight: bucket.Percentage})
+		}
+		start = (start + bucket.Percentage) % experimentTotalWeight
+	}
+	sort.SliceStable(segments, func(i, j int) bool {
+		return segments[i].start < segments[j].start
+	})
+
+	clusters := make([]*routev3.WeightedCluster_ClusterWeight, 0, len(segments))
+	for _, segment := range segments {
+		clusters = append(clusters, &routev3.WeightedCluster_ClusterWeight{
+			Name:   segment.bucket.Destination.Name,
+			Weight: wrapperspb.UInt32(segment.weight),
+			ResponseHeadersToAdd: []*corev3.HeaderValueOption{
+				{
+					Header: &corev3.HeaderValue{
+						Key:   "set-cookie",
+						Value: buildExperimentCookie(experiment, segment.bucket.Name),
+					},
+					AppendAction: corev3.HeaderValueOption_APPEND_IF_EXISTS_OR_ADD,
+				},
+			},
+		})
+	}
+	return clusters
+}
+
+// buildExperimentCookie returns the experiment cookie assigning the client to the bucket.
+func buildExperimentCookie(experiment *ir.Experiment, bucket string) string {
+	cookie := fmt.Sprintf("%s=%s; Path=/", experiment.Cookie, bucket)
+	if experiment.CookieTTL != nil {
+		cookie += fmt.Sprintf("; Max-Age=%d", int64(experiment.CookieTTL.Seconds()))
+	}
+	return cookie
+}
+
+// addXdsExperimentClusters adds the clusters of the buckets of the experiment of the route.
+func addXdsExperimentClusters(tCtx *types.ResourceVersionTable, httpRoute *ir.HTTPRoute) error {
+	if httpRoute.Traffic == nil || httpRoute.Traffic.Experiment == nil {
+		return nil
+	}
+	for _, bucket := range httpRoute.Traffic.Experiment.Buckets {
+		if err := createExtServiceXDSCluster(bucket.Destination, httpRoute.Traffic, tCtx); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,176 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package translator
+
+import (
+	"errors"
+	"fmt"
+
+	corev3 "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	routev3 "github.com/envoyproxy/go-control-plane/envoy/config/route/v3"
+	headertometadatav3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/header_to_metadata/v3"
+	hcmv3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/network/http_connection_manager/v3"
+	"google.golang.org/protobuf/types/known/anypb"
+
+	egv1a1 "github.com/envoyproxy/gateway/api/v1alpha1"
+	"github.com/envoyproxy/gateway/internal/ir"
+	"github.com/envoyproxy/gateway/internal/xds/types"
+)
+
+func init() {
+	registerHTTPFilter(&headerToMetadata{})
+}
+
+type headerToMetadata struct{}
+
+var _ httpFilter = &headerToMetadata{}
+
+// patchHCM builds and appends the header to metadata filter to the HTTP Connection
+// Manager if applicable, and it does not already exist.
+// Note: the filter has no rules, the rules of each route are set on the route.
+func (*headerToMetadata) patchHCM(mgr *hcmv3.HttpConnectionManager, irListener *ir.HTTPListener) error {
+	if mgr == nil {
+		return errors.New("hcm is nil")
+	}
+
+	if irListener == nil {
+		return errors.New("ir listener is nil")
+	}
+
+	if !listenerContainsHeaderToMetadata(irListener) {
+		return nil
+	}
+
+	// Return early if the header to metadata filter already exists.
+	for _, existingFilter := range mgr.HttpFilters {
+		if existingFilter.Name == egv1a1.EnvoyFilterHeaderToMetadata.String() {
+			return nil
+		}
+	}
+
+	filterAny, err := anypb.New(&headertometadatav3.Config{})
+	if err != nil {
+		return err
+	}
+
+	mgr.HttpFilters = append(mgr.HttpFilters, &hcmv3.HttpFilter{
+		Name: egv1a1.EnvoyFilterHeaderToMetadata.String(),
+		ConfigType: &hcmv3.HttpFilter_TypedConfig{
+			TypedConfig: filterAny,
+		},
+	})
+
+	return nil
+}
+
+// listenerContainsHeaderToMetadata returns true if a route of the listener sets request
+// headers as dynamic metadata.
+func listenerContainsHeaderToMetadata(irListener *ir.HTTPListener) bool {
+	for _, route := range irListener.Routes {
+		if routeContainsHeaderToMetadata(route) {
+			return true
+		}
+	}
+	return false
+}
+
+// routeContainsHeaderToMetadata returns true if the route sets request headers as dynamic
+// metadata.
+func routeContainsHeaderToMetadata(irRoute *ir.HTTPRoute) bool {
+	return irRoute != nil &&
+		irRoute.Traffic != nil &&
+		irRoute.Traffic.HeaderToMetadata != nil &&
+		len(irRoute.Traffic.HeaderToMetadata.RequestHeaders) > 0
+}
+
+func (*headerToMetadata) patchResources(*types.ResourceVersionTable, []*ir.HTTPRoute) error {
+	return nil
+}
+
+// patchRoute sets the rules of the header to metadata filter of the route, and the
+// response headers of the dynamic metadata.
+func (*headerToMetadata) patchRoute(route *routev3.Route, irRoute *ir.HTTPRoute) error {
+	if route == nil {
+		return errors.New("xds route is nil")
+	}
+	if irRoute == nil {
+		return errors.New("ir route is nil")
+	}
+	if irRoute.Traffic == nil || irRoute.Traffic.HeaderToMetadata == nil {
+		return nil
+	}
+	headerToMetadata := irRoute.Traffic.HeaderToMetadata
+
+	// The metadata is formatted by the header formatter, which omits the headers whose
+	// metadata is not set.
+	for _, header := range headerToMetadata.ResponseHeaders {
+		route.ResponseHeadersToAdd = append(route.ResponseHeadersToAdd, &corev3.HeaderValueOption{
+			Header: &corev3.HeaderValue{
+				Key:   header.Name,
+				Value: fmt.Sprintf("%%DYNAMIC_METADATA(%s:%s)%%", header.Namespace, header.Key),
+			},
+			AppendAction: corev3.HeaderValueOption_OVERWRITE_IF_EXISTS_OR_ADD,
+		})
+	}
+
+	if !routeContainsHeaderToMetadata(irRoute) {
+		return nil
+	}
+
+	filterName := egv1a1.EnvoyFilterHeaderToMetadata.String()
+	filterCfg := route.GetTypedPerFilterConfig()
+	if _, ok := filterCfg[filterName]; ok {
+		// This should not happen since this is the only place where the header to
+		// metadata filter is added in a route.
+		return fmt.Errorf("route already contains header to metadata config: %+v", route)
+	}
+
+	routeCfgProto := &headertometadatav3.Config{}
+	for _, header := range headerToMetadata.RequestHeaders {
+		routeCfgProto.RequestRules = append(routeCfgProto.RequestRules, &headertometadatav3.Config_Rule{
+			Header:          header.Header,
+			OnHeaderPresent: buildHeaderToMetadataKeyValuePair(header.OnPresent),
+			OnHeaderMissing: buildHeaderToMetadataKeyValuePair(header.OnMissing),
+			Remove:          header.Remove,
+		})
+	}
+	if err := routeCfgProto.ValidateAll(); err != nil {
+		return err
+	}
+
+	routeCfgAny, err := anypb.New(routeCfgProto)
+	if err != nil {
+		return err
+	}
+
+	if filterCfg == nil {
+		route.TypedPerFilterConfig = make(map[string]*anypb.Any)
+	}
+
+	route.TypedPerFilterConfig[filterName] = routeCfgAny
+
+	return nil
+}
+
+// buildHeaderToMetadataKeyValuePair returns the metadata set by a rule of the header to
+// metadata filter.
+func buildHeaderToMetadataKeyValuePair(value *ir.MetadataValue) *headertometadatav3.Config_KeyValuePair {
+	if value == nil {
+		return nil
+	}
+
+	kv := &headertometadatav3.Config_KeyValuePair{
+		MetadataNamespace: value.Namespace,
+		Key:               value.Key,
+	}
+	if value.Value != nil {
+		kv.Value = *value.Value
+	}
+	if value.Type == egv1a1.MetadataValueTypeNumber {
+		kv.Type = headertometadatav3.Config_NUMBER
+	}
+	return kv
+}
@@ -12,6 +12,7 @@ import (
 	healthcheckv3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/health_check/v3"
 	hcmv3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/network/http_connection_manager/v3"
 	matcherv3 "github.com/envoyproxy/go-control-plane/envoy/type/matcher/v3"
+	typev3 "github.com/envoyproxy/go-control-plane/envoy/type/v3"
 	"github.com/envoyproxy/go-control-plane/pkg/wellknown"
 	"google.golang.org/protobuf/types/known/anypb"
 	"google.golang.org/protobuf/types/known/wrapperspb"
@@ -82,6 +83,15 @@ func buildHealthCheckFilter(healthCheck *ir.HealthCheckSettings) (*hcmv3.HttpFil
 		}},
 	}
 
+	if len(healthCheck.MinHealthyPercentages) > 0 {
+		healthCheckProto.ClusterMinHealthyPercentages = make(map[string]*typev3.Percent, len(healthCheck.MinHealthyPercentages))
+		for _, p := range healthCheck.MinHealthyPercentages {
+			healthCheckProto.ClusterMinHealthyPercentages[p.Destination] = &typev3.Percent{
+				Value: float64(p.Percentage),
+			}
+		}
+	}
+
 	if err = healthCheckProto.ValidateAll(); err != nil {
 		return nil, err
 	}
@@ -102,22 +102,34 @@ func newOrderedHTTPFilter(filter *hcmv3.HttpFilter) *OrderedHTTPFilter {
 	switch {
 	case isFilterType(filter, egv1a1.EnvoyFilterHealthCheck):
 		order = 0
-	case isFilterType(filter, egv1a1.EnvoyFilterFault):
+	case isFilterType(filter, egv1a1.EnvoyFilterHeaderToMetadata):
+		// The metadata set from the request headers is available to all the filters.
 		order = 1
-	case isFilterType(filter, egv1a1.EnvoyFilterCORS):
+	case isFilterType(filter, egv1a1.EnvoyFilterLua):
+		// The requests are normalized before they are processed by the other filters.
 		order = 2
-	case isFilterType(filter, egv1a1.EnvoyFilterExtAuthz):
+	case isFilterType(filter, egv1a1.EnvoyFilterFault):
 		order = 3
-	case isFilterType(filter, egv1a1.EnvoyFilterBasicAuth):
+	case isFilterType(filter, egv1a1.EnvoyFilterCORS):
 		order = 4
-	case isFilterType(filter, egv1a1.EnvoyFilterOAuth2):
+	case isFilterType(filter, egv1a1.EnvoyFilterExtAuthz):
 		order = 5
-	case isFilterType(filter, egv1a1.EnvoyFilterJWTAuthn):
+	case isFilterType(filter, egv1a1.EnvoyFilterBasicAuth):
 		order = 6
-	case isFilterType(filter, egv1a1.EnvoyFilterSessionPersistence):
+	case isFilterType(filter, egv1a1.EnvoyFilterOAuth2):
 		order = 7
+	case isFilterType(filter, egv1a1.EnvoyFilterJWTAuthn):
+		order = 8
+	case isFilterType(filter, egv1a1.EnvoyFilterSessionPersistence):
+		order = 9
+	case strings.HasPrefix(filter.Name, openAPIValidationFilterPrefix+"/"):
+		// Invalid requests are rejected before reaching the other extensions.
+		order = 10
+	case strings.HasPrefix(filter.Name, requestSigningFilterPrefix+"/"):
+		// The requests are signed once processed by the other filters.
+		order = 206
 	case isFilterType(filter, egv1a1.EnvoyFilterExtProc):
-		order = 8 + mustGetFilterIndex(filter.Name)
+		order = 11 + mustGetFilterIndex(filter.Name)
 	case isFilterType(filter, egv1a1.EnvoyFilterWasm):
 		order = 100 + mustGetFilterIndex(filter.Name)
 	case isFilterType(filter, egv1a1.EnvoyFilterRBAC):
@@ -126,8 +138,22 @@ func newOrderedHTTPFilter(filter *hcmv3.HttpFilter) *OrderedHTTPFilter {
 		order = 202
 	case isFilterType(filter, egv1a1.EnvoyFilterRateLimit):
 		order = 203
-	case isFilterType(filter, wellknown.Router):
+	case isFilterType(filter, egv1a1.EnvoyFilterCredentialInjector):
+		// The credentials are injected once the credentials of the clients are checked,
+		// and before the requests are signed.
 		order = 204
+	case isFilterType(filter, egv1a1.EnvoyFilterAWSRequestSigning):
+		// The requests are signed once processed by the other filters.
+		order = 205
+	case isFilterType(filter, egv1a1.EnvoyFilterCustomResponse):
+		// Only the responses of the backends and of the router are overridden.
+		order = 207
+	case isFilterType(filter, egv1a1.EnvoyFilterFileSystemBuffer):
+		// The responses of the backends are buffered before they are processed by the
+		// other filters.
+		order = 208
+	case isFilterType(filter, wellknown.Router):
+		order = 209
 	}
 
 	return &OrderedHTTPFilter{
@@ -8,13 +8,16 @@ package translator
 import (
 	"errors"
 	"fmt"
+	"strings"
 
 	corev3 "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
 	routev3 "github.com/envoyproxy/go-control-plane/envoy/config/route/v3"
 	jwtauthnv3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/jwt_authn/v3"
 	hcmv3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/network/http_connection_manager/v3"
 	tlsv3 "github.com/envoyproxy/go-control-plane/envoy/extensions/transport_sockets/tls/v3"
+	matcherv3 "github.com/envoyproxy/go-control-plane/envoy/type/matcher/v3"
 	"github.com/envoyproxy/go-control-plane/pkg/wellknown"
+	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/types/known/anypb"
 	"google.golang.org/protobuf/types/known/durationpb"
 	"google.golang.org/protobuf/types/known/emptypb"
@@ -152,6 +155,12 @@ func buildJWTAuthn(irListener *ir.HTTPListener) (*jwtauthnv3.JwtAuthentication,
 				jwtProvider.ClearRouteCache = *irProvider.RecomputeRoute
 			}
 
+			// The route is recomputed once the JWT is validated, for the claims of the
+			// JWT to be matched.
+			if routeContainsJWTRouteMatch(route) {
+				jwtProvider.ClearRouteCache = true
+			}
+
 			if irProvider.ExtractFrom != nil {
 				jwtProvider.FromHeaders = buildJwtFromHeaders(irProvider.ExtractFrom.Headers)
 				jwtProvider.FromCookies = irProvider.ExtractFrom.Cookies
@@ -315,6 +324,139 @@ func routeContainsJWTAuthn(irRoute *ir.HTTPRoute) bool {
 	return false
 }
 
+// routeContainsJWTRouteMatch returns true if the provided route matches the claims
+// of the validated JWT.
+func routeContainsJWTRouteMatch(irRoute *ir.HTTPRoute) bool {
+	return routeContainsJWTAuthn(irRoute) && irRoute.Security.JWT.RouteMatch != nil
+}
+
+// buildJWTRouteMatchers returns the matchers of the claims and the scopes of the JWT
+// validated by the JWT authn filter, if the provided route matches them.
+// Multiple claims and scopes are ANDed together, and multiple values for a claim are
+// ORed together.
+func buildJWTRouteMatchers(irRoute *ir.HTTPRoute) []*matcherv3.MetadataMatcher {
+	if !routeContainsJWTRouteMatch(irRoute) {
+		return nil
+	}
+
+	jwt := irRoute.Security.JWT
+	// The name of the jwt provider is used as the `payload_in_metadata` in the JWT Authn filter.
+	provider := jwt.Providers[0].Name
+
+	var matchers []*matcherv3.MetadataMatcher
+	for _, scope := range jwt.RouteMatch.Scopes {
+		// The scope has already been normalized to a string array in the JWT Authn filter.
+		matchers = append(matchers, &matcherv3.MetadataMatcher{
+			Filter: egv1a1.EnvoyFilterJWTAuthn.String(),
+			Path:   buildJWTClaimPath(provider, "scope"),
+			Value:  buildJWTClaimValueMatcher(string(scope), true),
+		})
+	}
+
+	for _, claim := range jwt.RouteMatch.Claims {
+		isArray := claim.ValueType != nil && *claim.ValueType == egv1a1.JWTClaimValueTypeStringArray
+
+		var value *matcherv3.ValueMatcher
+		if len(claim.Values) == 1 {
+			value = buildJWTClaimValueMatcher(claim.Values[0], isArray)
+		} else {
+			values := make([]*matcherv3.ValueMatcher, 0, len(claim.Values))
+			for _, v := range claim.Values {
+				values = append(values, buildJWTClaimValueMatcher(v, isArray))
+			}
+			value = &matcherv3.ValueMatcher{
+				MatchPattern: &matcherv3.ValueMatcher_OrMatch{
+					OrMatch: &matcherv3.OrMatcher{
+						ValueMatchers: values,
+					},
+				},
+			}
+		}
+
+		matchers = append(matchers, &matcherv3.MetadataMatcher{
+			Filter: egv1a1.EnvoyFilterJWTAuthn.String(),
+			// A nested claim is represented as a dot-separated string, e.g., "user.email".
+			Path:  buildJWTClaimPath(provider, strings.Split(claim.Name, ".")...),
+			Value: value,
+		})
+	}
+
+	return matchers
+}
+
+func buildJWTClaimPath(provider string, keys ...string) []*matcherv3.MetadataMatcher_PathSegment {
+	path := make([]*matcherv3.MetadataMatcher_PathSegment, 0, len(keys)+1)
+	for _, key := range append([]string{provider}, keys...) {
+		path = append(path, &matcherv3.MetadataMatcher_PathSegment{
+			Segment: &matcherv3.MetadataMatcher_PathSegment_Key{
+				Key: key,
+			},
+		})
+	}
+	return path
+}
+
+func buildJWTClaimValueMatcher(value string, isArray bool) *matcherv3.ValueMatcher {
+	exact := &matcherv3.ValueMatcher{
+		MatchPattern: &matcherv3.ValueMatcher_StringMatch{
+			StringMatch: &matcherv3.StringMatcher{
+				MatchPattern: &matcherv3.StringMatcher_Exact{
+					Exact: value,
+				},
+			},
+		},
+	}
+	if !isArray {
+		return exact
+	}
+
+	return &matcherv3.ValueMatcher{
+		MatchPattern: &matcherv3.ValueMatcher_ListMatch{
+			ListMatch: &matcherv3.ListMatcher{
+				MatchPattern: &matcherv3.ListMatcher_OneOf{
+					OneOf: exact,
+				},
+			},
+		},
+	}
+}
+
+// buildXdsJWTRouteMatchFallbackRoute returns the route matching the requests of the
+// xDS route before their JWT is validated, if the xDS route matches the claims of the
+// JWT. The requests matching the fallback route have their JWT validated by the JWT
+// authn filter with the requirement of the xDS route, which then clears the route
+// cache, so that the requests are matched against the claims of their JWT.
+//
+// The fallback route doesn't match once the JWT is validated, and responds with a 404
+// status to the requests whose JWT isn't validated, such as the requests without a JWT.
+func buildXdsJWTRouteMatchFallbackRoute(httpRoute *ir.HTTPRoute, xdsRoute *routev3.Route) *routev3.Route {
+	if !routeContainsJWTRouteMatch(httpRoute) {
+		return nil
+	}
+
+	route := proto.Clone(xdsRoute).(*routev3.Route)
+	route.Name = fmt.Sprintf("%s/jwt_route_match", xdsRoute.Name)
+	route.Match.DynamicMetadata = []*matcherv3.MetadataMatcher{
+		{
+			Filter: egv1a1.EnvoyFilterJWTAuthn.String(),
+			Path:   buildJWTClaimPath(httpRoute.Security.JWT.Providers[0].Name),
+			Value: &matcherv3.ValueMatcher{
+				MatchPattern: &matcherv3.ValueMatcher_PresentMatch{
+					PresentMatch: true,
+				},
+			},
+			Invert: true,
+		},
+	}
+	route.Action = &routev3.Route_DirectResponse{
+		DirectResponse: &routev3.DirectResponseAction{
+			Status: 404,
+		},
+	}
+
+	return route
+}
+
 // buildJwtFromHeaders returns a list of JwtHeader transformed from JWTFromHeader struct
 func buildJwtFromHeaders(headers []egv1a1.JWTHeaderExtractor) []*jwtauthnv3.JwtHeader {
 	jwtHeaders := make([]*jwtauthnv3.JwtHeader, 0, len(headers))
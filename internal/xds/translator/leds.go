@@ -0,0 +1,102 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package translator
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+
+	corev3 "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	endpointv3 "github.com/envoyproxy/go-control-plane/envoy/config/endpoint/v3"
+	discoveryv3 "github.com/envoyproxy/go-control-plane/envoy/service/discovery/v3"
+	resourcev3 "github.com/envoyproxy/go-control-plane/pkg/resource/v3"
+
+	"github.com/envoyproxy/gateway/internal/ir"
+	"github.com/envoyproxy/gateway/internal/utils/protocov"
+	"github.com/envoyproxy/gateway/internal/xds/types"
+)
+
+// ledsCollectionPrefix prefixes the names of the LEDS collections of the localities.
+const ledsCollectionPrefix = "xdstp://envoy-gateway/envoy.config.endpoint.v3.LbEndpoint/"
+
+// processLocalityEndpointDiscovery moves the endpoints of the cluster load assignments with
+// at least the minimum number of endpoints to LEDS resources, one per endpoint, collected by
+// the locality of the endpoint. The localities subscribe to their collection on the ADS
+// stream instead of listing their endpoints, so that a change of some endpoints only sends
+// these endpoints to the proxies.
+func processLocalityEndpointDiscovery(tCtx *types.ResourceVersionTable, leds *ir.LocalityEndpointDiscovery) error {
+	if leds == nil {
+		return nil
+	}
+
+	for _, r := range tCtx.XdsResources[resourcev3.EndpointType] {
+		cla := r.(*endpointv3.ClusterLoadAssignment)
+		if countLbEndpoints(cla) < int(leds.MinEndpoints) {
+			continue
+		}
+
+		for i, locality := range cla.Endpoints {
+			collection := localityEndpointsCollection(cla.ClusterName, i)
+			names := make(map[string]bool, len(locality.LbEndpoints))
+			for _, lbEndpoint := range locality.LbEndpoints {
+				name := collection + lbEndpointKey(lbEndpoint)
+				// Distinguish the endpoints sharing an address.
+				for n := 1; names[name]; n++ {
+					name = collection + lbEndpointKey(lbEndpoint) + "~" + strconv.Itoa(n)
+				}
+				names[name] = true
+
+				endpointAny, err := protocov.ToAnyWithError(lbEndpoint)
+				if err != nil {
+					return err
+				}
+				if err := tCtx.AddXdsResource(types.LbEndpointType, &discoveryv3.Resource{
+					Name:     name,
+					Resource: endpointAny,
+				}); err != nil {
+					return err
+				}
+			}
+
+			locality.LbEndpoints = nil
+			locality.LbConfig = &endpointv3.LocalityLbEndpoints_LedsClusterLocalityConfig{
+				LedsClusterLocalityConfig: &endpointv3.LedsClusterLocalityConfig{
+					LedsConfig: &corev3.ConfigSource{
+						ResourceApiVersion:    resourcev3.DefaultAPIVersion,
+						ConfigSourceSpecifier: &corev3.ConfigSource_Ads{Ads: &corev3.AggregatedConfigSource{}},
+					},
+					LedsCollectionName: collection + "*",
+				},
+			}
+		}
+	}
+	return nil
+}
+
+// countLbEndpoints returns the number of endpoints of the cluster load assignment.
+func countLbEndpoints(cla *endpointv3.ClusterLoadAssignment) int {
+	count := 0
+	for _, locality := range cla.Endpoints {
+		count += len(locality.LbEndpoints)
+	}
+	return count
+}
+
+// localityEndpointsCollection returns the name of the LEDS collection of the locality of the
+// cluster, up to the glob or the key of an endpoint.
+func localityEndpointsCollection(clusterName string, locality int) string {
+	return fmt.Sprintf("%s%s/%d/", ledsCollectionPrefix, url.PathEscape(clusterName), locality)
+}
+
+// lbEndpointKey returns the key of the endpoint in its collection, its address.
+func lbEndpointKey(lbEndpoint *endpointv3.LbEndpoint) string {
+	address := lbEndpoint.GetEndpoint().GetAddress()
+	if socketAddress := address.GetSocketAddress(); socketAddress != nil {
+		return url.PathEscape(fmt.Sprintf("%s:%d", socketAddress.GetAddress(), socketAddress.GetPortValue()))
+	}
+	return url.PathEscape(address.GetPipe().GetPath())
+}
@@ -7,14 +7,17 @@ package translator
 
 import (
 	"errors"
+	"net"
 	"strconv"
 	"strings"
+	"time"
 
 	xdscore "github.com/cncf/xds/go/xds/core/v3"
 	matcher "github.com/cncf/xds/go/xds/type/matcher/v3"
 	mutation_rulesv3 "github.com/envoyproxy/go-control-plane/envoy/config/common/mutation_rules/v3"
 	corev3 "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
 	listenerv3 "github.com/envoyproxy/go-control-plane/envoy/config/listener/v3"
+	listener_local_ratelimitv3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/listener/local_ratelimit/v3"
 	tls_inspectorv3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/listener/tls_inspector/v3"
 	connection_limitv3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/network/connection_limit/v3"
 	hcmv3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/network/http_connection_manager/v3"
@@ -51,6 +54,7 @@ const (
 	http2InitialConnectionWindowSize = 1048576 // 1 MiB
 	// https://www.envoyproxy.io/docs/envoy/latest/api-v3/extensions/filters/network/connection_limit/v3/connection_limit.proto
 	networkConnectionLimit = "envoy.filters.network.connection_limit"
+	listenerLocalRateLimit = "envoy.filters.listener.local_ratelimit"
 )
 
 func http1ProtocolOptions(opts *ir.HTTP1Settings) *corev3.Http1ProtocolOptions {
@@ -148,30 +152,159 @@ func originalIPDetectionExtensions(clientIPDetection *ir.ClientIPDetectionSettin
 }
 
 // buildXdsTCPListener creates a xds Listener resource
-// TODO: Improve function parameters
-func buildXdsTCPListener(name, address string, port uint32, keepalive *ir.TCPKeepalive, connection *ir.ClientConnection, accesslog *ir.AccessLog) *listenerv3.Listener {
-	socketOptions := buildTCPSocketOptions(keepalive)
-	al := buildXdsAccessLog(accesslog, true)
+func buildXdsTCPListener(listenerDetails *ir.CoreListenerDetails, keepalive *ir.TCPKeepalive, connection *ir.ClientConnection,
+	accesslog *ir.AccessLog, protocol egv1a1.ProxyAccessLogProtocol,
+) *listenerv3.Listener {
+	al := buildXdsAccessLog(accesslog, protocol, true)
 	bufferLimitBytes := buildPerConnectionBufferLimitBytes(connection)
-	return &listenerv3.Listener{
-		Name:                          name,
+	xdsListener := &listenerv3.Listener{
+		Name:                          listenerDetails.Name,
 		AccessLog:                     al,
-		SocketOptions:                 socketOptions,
 		PerConnectionBufferLimitBytes: bufferLimitBytes,
-		Address: &corev3.Address{
-			Address: &corev3.Address_SocketAddress{
-				SocketAddress: &corev3.SocketAddress{
-					Protocol: corev3.SocketAddress_TCP,
-					Address:  address,
-					PortSpecifier: &corev3.SocketAddress_PortValue{
-						PortValue: port,
-					},
+	}
+	if connection != nil && connection.ConnectionRateLimit != nil {
+		xdsListener.ListenerFilters = append(xdsListener.ListenerFilters,
+			buildConnectionRateLimitFilter(listenerDetails, connection.ConnectionRateLimit))
+	}
+
+	// TCP socket options and port reuse don't apply to internal listeners and unix domain sockets.
+	if listenerDetails.Internal {
+		xdsListener.Address = buildXdsInternalAddress(listenerDetails.Name)
+		xdsListener.ListenerSpecifier = &listenerv3.Listener_InternalListener{
+			InternalListener: &listenerv3.Listener_InternalListenerConfig{},
+		}
+		return xdsListener
+	}
+	applyListenerTuning(xdsListener, listenerDetails.Tuning)
+	if listenerDetails.UnixSocketPath != nil {
+		xdsListener.Address = &corev3.Address{
+			Address: &corev3.Address_Pipe{
+				Pipe: &corev3.Pipe{
+					Path: *listenerDetails.UnixSocketPath,
+				},
+			},
+		}
+		return xdsListener
+	}
+
+	socketOptions := buildTCPSocketOptions(keepalive)
+	socketOptions = append(socketOptions, buildConnectionSocketOptions(listenerDetails.Address, connection)...)
+	xdsListener.SocketOptions = socketOptions
+	if connection != nil && connection.ReusePort != nil {
+		xdsListener.EnableReusePort = wrapperspb.Bool(*connection.ReusePort)
+	}
+	xdsListener.Address = &corev3.Address{
+		Address: &corev3.Address_SocketAddress{
+			SocketAddress: &corev3.SocketAddress{
+				Protocol: corev3.SocketAddress_TCP,
+				Address:  listenerDetails.Address,
+				PortSpecifier: &corev3.SocketAddress_PortValue{
+					PortValue: listenerDetails.Port,
+				},
+				Ipv4Compat: isDualStack(listenerDetails.IPFamily),
+			},
+		},
+	}
+	return xdsListener
+}
+
+// applyListenerTuning sets how the listener accepts the connections and balances them over
+// the worker threads.
+func applyListenerTuning(xdsListener *listenerv3.Listener, tuning *ir.ListenerTuning) {
+	if tuning == nil {
+		return
+	}
+	if tuning.ExactBalance {
+		xdsListener.ConnectionBalanceConfig = &listenerv3.Listener_ConnectionBalanceConfig{
+			BalanceType: &listenerv3.Listener_ConnectionBalanceConfig_ExactBalance_{
+				ExactBalance: &listenerv3.Listener_ConnectionBalanceConfig_ExactBalance{},
+			},
+		}
+	}
+	if tuning.MaxConnectionsToAcceptPerSocketEvent != nil {
+		xdsListener.MaxConnectionsToAcceptPerSocketEvent = wrapperspb.UInt32(*tuning.MaxConnectionsToAcceptPerSocketEvent)
+	}
+	if tuning.ListenerFiltersTimeout != nil {
+		xdsListener.ListenerFiltersTimeout = durationpb.New(tuning.ListenerFiltersTimeout.Duration)
+	}
+	xdsListener.ContinueOnListenerFiltersTimeout = tuning.ContinueOnListenerFiltersTimeout
+}
+
+// buildXdsInternalAddress returns the address of the Envoy internal listener with the name.
+func buildXdsInternalAddress(name string) *corev3.Address {
+	return &corev3.Address{
+		Address: &corev3.Address_EnvoyInternalAddress{
+			EnvoyInternalAddress: &corev3.EnvoyInternalAddress{
+				AddressNameSpecifier: &corev3.EnvoyInternalAddress_ServerListenerName{
+					ServerListenerName: name,
 				},
 			},
 		},
 	}
 }
 
+// listenerStatSuffix returns the suffix of the stat prefixes of the listener, which is the
+// name of internal listeners and the port of the other listeners.
+func listenerStatSuffix(listenerDetails *ir.CoreListenerDetails) string {
+	if listenerDetails.Internal {
+		return listenerDetails.Name
+	}
+	return strconv.Itoa(int(listenerDetails.Port))
+}
+
+// isDualStack returns true if the listener binds to the IPv6 wildcard address
+// and also accepts IPv4 connections.
+func isDualStack(ipFamily *egv1a1.IPFamily) bool {
+	return ipFamily != nil && *ipFamily == egv1a1.DualStack
+}
+
+// buildConnectionSocketOptions converts the listener socket options of the client connection
+// settings to xds socketOptions.
+func buildConnectionSocketOptions(address string, connection *ir.ClientConnection) []*corev3.SocketOption {
+	if connection == nil {
+		return nil
+	}
+
+	var socketOptions []*corev3.SocketOption
+	if connection.NoDelay != nil {
+		var value int64
+		if *connection.NoDelay {
+			value = 1
+		}
+		socketOptions = append(socketOptions, &corev3.SocketOption{
+			Description: "socket option for tcp no delay",
+			Level:       0x6, // Darwin lacks syscall.SOL_TCP
+			Name:        0x1, // syscall.TCP_NODELAY
+			Value:       &corev3.SocketOption_IntValue{IntValue: value},
+			State:       corev3.SocketOption_STATE_PREBIND,
+		})
+	}
+
+	if connection.DSCP != nil {
+		// The DSCP is the upper six bits of the traffic class.
+		tos := int64(*connection.DSCP) << 2
+		if ip := net.ParseIP(address); ip != nil && ip.To4() == nil {
+			socketOptions = append(socketOptions, &corev3.SocketOption{
+				Description: "socket option for ipv6 traffic class",
+				Level:       0x29, // syscall.IPPROTO_IPV6
+				Name:        0x43, // syscall.IPV6_TCLASS has a different value for Darwin, resulting in `go test` failing
+				Value:       &corev3.SocketOption_IntValue{IntValue: tos},
+				State:       corev3.SocketOption_STATE_PREBIND,
+			})
+		} else {
+			socketOptions = append(socketOptions, &corev3.SocketOption{
+				Description: "socket option for ip type of service",
+				Level:       0x0, // syscall.IPPROTO_IP
+				Name:        0x1, // syscall.IP_TOS has a different value for Darwin, resulting in `go test` failing
+				Value:       &corev3.SocketOption_IntValue{IntValue: tos},
+				State:       corev3.SocketOption_STATE_PREBIND,
+			})
+		}
+	}
+
+	return socketOptions
+}
+
 func buildPerConnectionBufferLimitBytes(connection *ir.ClientConnection) *wrapperspb.UInt32Value {
 	if connection != nil && connection.BufferLimitBytes != nil {
 		return wrapperspb.UInt32(*connection.BufferLimitBytes)
@@ -180,10 +313,10 @@ func buildPerConnectionBufferLimitBytes(connection *ir.ClientConnection) *wrappe
 }
 
 // buildXdsQuicListener creates a xds Listener resource for quic
-func buildXdsQuicListener(name, address string, port uint32, accesslog *ir.AccessLog) *listenerv3.Listener {
+func buildXdsQuicListener(name, address string, port uint32, ipFamily *egv1a1.IPFamily, accesslog *ir.AccessLog) *listenerv3.Listener {
 	xdsListener := &listenerv3.Listener{
 		Name:      name + "-quic",
-		AccessLog: buildXdsAccessLog(accesslog, true),
+		AccessLog: buildXdsAccessLog(accesslog, egv1a1.ProxyAccessLogProtocolHTTP, true),
 		Address: &corev3.Address{
 			Address: &corev3.Address_SocketAddress{
 				SocketAddress: &corev3.SocketAddress{
@@ -192,6 +325,7 @@ func buildXdsQuicListener(name, address string, port uint32, accesslog *ir.Acces
 					PortSpecifier: &corev3.SocketAddress_PortValue{
 						PortValue: port,
 					},
+					Ipv4Compat: isDualStack(ipFamily),
 				},
 			},
 		},
@@ -220,7 +354,7 @@ func buildXdsQuicListener(name, address string, port uint32, accesslog *ir.Acces
 func (t *Translator) addHCMToXDSListener(xdsListener *listenerv3.Listener, irListener *ir.HTTPListener,
 	accesslog *ir.AccessLog, tracing *ir.Tracing, http3Listener bool, connection *ir.ClientConnection,
 ) error {
-	al := buildXdsAccessLog(accesslog, false)
+	al := buildXdsAccessLog(accesslog, egv1a1.ProxyAccessLogProtocolHTTP, false)
 
 	hcmTracing, err := buildHCMTracing(tracing)
 	if err != nil {
@@ -235,8 +369,7 @@ func (t *Translator) addHCMToXDSListener(xdsListener *listenerv3.Listener, irLis
 		statPrefix = "http"
 	}
 
-	// Append port to the statPrefix.
-	statPrefix = strings.Join([]string{statPrefix, strconv.Itoa(int(irListener.Port))}, "-")
+	statPrefix = strings.Join([]string{statPrefix, listenerStatSuffix(&irListener.CoreListenerDetails)}, "-")
 
 	// Client IP detection
 	useRemoteAddress := true
@@ -353,6 +486,14 @@ func (t *Translator) addHCMToXDSListener(xdsListener *listenerv3.Listener, irLis
 		}
 
 		xdsListener.FilterChains = append(xdsListener.FilterChains, filterChain)
+		// The connections to the QUIC listener are never proxied without terminating TLS, so
+		// only the TCP listener gets a default filter chain.
+		if !http3Listener {
+			if err := addXdsNoSNIMatchFilterChain(xdsListener, filterChain, irListener.TLS.NoSNIMatch,
+				listenerStatSuffix(&irListener.CoreListenerDetails), accesslog); err != nil {
+				return err
+			}
+		}
 	} else {
 		// Add the HTTP filter chain as the default filter chain
 		// Make sure one does not exist
@@ -471,7 +612,7 @@ func findXdsHTTPRouteConfigName(xdsListener *listenerv3.Listener) string {
 	return ""
 }
 
-func addXdsTCPFilterChain(xdsListener *listenerv3.Listener, irRoute *ir.TCPRoute,
+func addXdsTCPFilterChain(xdsListener *listenerv3.Listener, statSuffix string, irRoute *ir.TCPRoute,
 	clusterName string, accesslog *ir.AccessLog, timeout *ir.ClientTimeout,
 	connection *ir.ClientConnection,
 ) error {
@@ -490,11 +631,10 @@ func addXdsTCPFilterChain(xdsListener *listenerv3.Listener, irRoute *ir.TCPRoute
 		statPrefix = "tls-terminate"
 	}
 
-	// Append port to the statPrefix.
-	statPrefix = strings.Join([]string{statPrefix, strconv.Itoa(int(xdsListener.Address.GetSocketAddress().GetPortValue()))}, "-")
+	statPrefix = strings.Join([]string{statPrefix, statSuffix}, "-")
 
 	mgr := &tcpv3.TcpProxy{
-		AccessLog:  buildXdsAccessLog(accesslog, false),
+		AccessLog:  buildXdsAccessLog(accesslog, egv1a1.ProxyAccessLogProtocolTCP, false),
 		StatPrefix: statPrefix,
 		ClusterSpecifier: &tcpv3.TcpProxy_Cluster{
 			Cluster: clusterName,
@@ -553,6 +693,52 @@ func addXdsTCPFilterChain(xdsListener *listenerv3.Listener, irRoute *ir.TCPRoute
 
 	xdsListener.FilterChains = append(xdsListener.FilterChains, filterChain)
 
+	if isTLSTerminate {
+		return addXdsNoSNIMatchFilterChain(xdsListener, filterChain, irRoute.TLS.Terminate.NoSNIMatch, statSuffix, accesslog)
+	}
+	return nil
+}
+
+// addXdsNoSNIMatchFilterChain sets the default filter chain of the listener, which handles
+// the connections whose SNI matches the server names of none of its filter chains, from the
+// NoSNIMatch settings of the TLS listener the filter chain was built for.
+// The first listener sharing the port that configures it wins, and the filter chain of a
+// listener without hostname already handles all the connections.
+func addXdsNoSNIMatchFilterChain(xdsListener *listenerv3.Listener, filterChain *listenerv3.FilterChain,
+	noSNIMatch *ir.NoSNIMatch, statSuffix string, accesslog *ir.AccessLog,
+) error {
+	if noSNIMatch == nil || filterChain.FilterChainMatch == nil || xdsListener.DefaultFilterChain != nil {
+		return nil
+	}
+
+	var defaultFilterChain *listenerv3.FilterChain
+	switch noSNIMatch.Action {
+	case egv1a1.NoSNIMatchActionFallbackCertificate:
+		defaultFilterChain = proto.Clone(filterChain).(*listenerv3.FilterChain)
+		defaultFilterChain.FilterChainMatch = nil
+	case egv1a1.NoSNIMatchActionDefaultBackend:
+		if noSNIMatch.Destination == nil {
+			return errors.New("the default backend of the connections matching no SNI is not set")
+		}
+		mgr := &tcpv3.TcpProxy{
+			AccessLog:  buildXdsAccessLog(accesslog, egv1a1.ProxyAccessLogProtocolTCP, false),
+			StatPrefix: "no-sni-match-" + statSuffix,
+			ClusterSpecifier: &tcpv3.TcpProxy_Cluster{
+				Cluster: noSNIMatch.Destination.Name,
+			},
+		}
+		mgrf, err := toNetworkFilter(wellknown.TCPProxy, mgr)
+		if err != nil {
+			return err
+		}
+		defaultFilterChain = &listenerv3.FilterChain{Filters: []*listenerv3.Filter{mgrf}}
+	default:
+		// The connections are closed when no filter chain matches them.
+		return nil
+	}
+
+	defaultFilterChain.Name = filterChain.Name + "-no-sni-match"
+	xdsListener.DefaultFilterChain = defaultFilterChain
 	return nil
 }
 
@@ -568,6 +754,25 @@ func buildConnectionLimitFilter(statPrefix string, connection *ir.ClientConnecti
 	return cl
 }
 
+// buildConnectionRateLimitFilter returns the listener filter limiting the rate of the new
+// connections accepted by the listener. Unlike a network filter, which is instantiated for
+// each filter chain, the listener filter shares its token bucket across the whole listener.
+func buildConnectionRateLimitFilter(listenerDetails *ir.CoreListenerDetails, rateLimit *ir.ConnectionRateLimit) *listenerv3.ListenerFilter {
+	return &listenerv3.ListenerFilter{
+		Name: listenerLocalRateLimit,
+		ConfigType: &listenerv3.ListenerFilter_TypedConfig{
+			TypedConfig: protocov.ToAny(&listener_local_ratelimitv3.LocalRateLimit{
+				StatPrefix: "connection-rate-limit-" + listenerStatSuffix(listenerDetails),
+				TokenBucket: &typev3.TokenBucket{
+					MaxTokens:     rateLimit.Burst,
+					TokensPerFill: wrapperspb.UInt32(rateLimit.ConnectionsPerSecond),
+					FillInterval:  durationpb.New(time.Second),
+				},
+			}),
+		},
+	}
+}
+
 // addXdsTLSInspectorFilter adds a Tls Inspector filter if it does not yet exist.
 func addXdsTLSInspectorFilter(xdsListener *listenerv3.Listener) error {
 	// Return early if it exists
@@ -664,6 +869,21 @@ func buildXdsDownstreamTLSSocket(tlsConfig *ir.TLSConfig) (*corev3.TransportSock
 		}
 	}
 
+	tlsCtx.DisableStatefulSessionResumption = tlsConfig.DisableStatefulSessionResumption
+	switch {
+	case tlsConfig.DisableStatelessSessionResumption:
+		tlsCtx.SessionTicketKeysType = &tlsv3.DownstreamTlsContext_DisableStatelessSessionResumption{
+			DisableStatelessSessionResumption: true,
+		}
+	case tlsConfig.SessionTicketKeys != nil:
+		tlsCtx.SessionTicketKeysType = &tlsv3.DownstreamTlsContext_SessionTicketKeysSdsSecretConfig{
+			SessionTicketKeysSdsSecretConfig: &tlsv3.SdsSecretConfig{
+				Name:      tlsConfig.SessionTicketKeys.Name,
+				SdsConfig: makeConfigSource(),
+			},
+		}
+	}
+
 	tlsCtxAny, err := anypb.New(tlsCtx)
 	if err != nil {
 		return nil, err
@@ -773,7 +993,7 @@ func buildXdsUDPListener(clusterName string, udpListener *ir.UDPListener, access
 
 	udpProxy := &udpv3.UdpProxyConfig{
 		StatPrefix: statPrefix,
-		AccessLog:  buildXdsAccessLog(accesslog, false),
+		AccessLog:  buildXdsAccessLog(accesslog, egv1a1.ProxyAccessLogProtocolUDP, false),
 		RouteSpecifier: &udpv3.UdpProxyConfig_Matcher{
 			Matcher: &matcher.Matcher{
 				OnNoMatch: &matcher.Matcher_OnMatch{
@@ -794,7 +1014,7 @@ func buildXdsUDPListener(clusterName string, udpListener *ir.UDPListener, access
 
 	xdsListener := &listenerv3.Listener{
 		Name:      udpListener.Name,
-		AccessLog: buildXdsAccessLog(accesslog, true),
+		AccessLog: buildXdsAccessLog(accesslog, egv1a1.ProxyAccessLogProtocolUDP, true),
 		Address: &corev3.Address{
 			Address: &corev3.Address_SocketAddress{
 				SocketAddress: &corev3.SocketAddress{
@@ -803,6 +1023,7 @@ func buildXdsUDPListener(clusterName string, udpListener *ir.UDPListener, access
 					PortSpecifier: &corev3.SocketAddress_PortValue{
 						PortValue: udpListener.Port,
 					},
+					Ipv4Compat: isDualStack(udpListener.IPFamily),
 				},
 			},
 		},
@@ -0,0 +1,33 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package translator
+
+import (
+	clusterv3 "github.com/envoyproxy/go-control-plane/envoy/config/cluster/v3"
+	resourcev3 "github.com/envoyproxy/go-control-plane/pkg/resource/v3"
+
+	"github.com/envoyproxy/gateway/internal/ir"
+	"github.com/envoyproxy/gateway/internal/xds/types"
+)
+
+// processLoadReporting passes the load reporting settings on to the xds server, which
+// serves the load reports of the proxies. If the load is a custom metric, the clusters
+// propagate the metric reported by the endpoints with ORCA to the load reports.
+func processLoadReporting(tCtx *types.ResourceVersionTable, loadReporting *ir.LoadReporting) {
+	if loadReporting == nil {
+		return
+	}
+
+	tCtx.LoadReporting = loadReporting
+
+	if loadReporting.Metric == "" {
+		return
+	}
+	for _, r := range tCtx.XdsResources[resourcev3.ClusterType] {
+		cluster := r.(*clusterv3.Cluster)
+		cluster.LrsReportEndpointMetrics = []string{loadReporting.Metric}
+	}
+}
@@ -10,11 +10,15 @@ import (
 	"google.golang.org/protobuf/types/known/structpb"
 
 	"github.com/envoyproxy/gateway/internal/ir"
+	"github.com/envoyproxy/gateway/internal/xds/types"
 )
 
 const (
-	envoyGatewayXdsMetadataNamespace      = "envoy-gateway"
+	envoyGatewayXdsMetadataNamespace      = types.XdsMetadataNamespace
 	envoyGatewayXdsMetadataKeyKind        = "kind"
+	envoyGatewayXdsMetadataKeyGroup       = "group"
+	envoyGatewayXdsMetadataKeyVersion     = "version"
+	envoyGatewayXdsMetadataKeyGeneration  = "generation"
 	envoyGatewayXdsMetadataKeyName        = "name"
 	envoyGatewayXdsMetadataKeyNamespace   = "namespace"
 	envoyGatewayXdsMetadataKeyAnnotations = "annotations"
@@ -45,6 +49,44 @@ func buildXdsMetadata(metadata *ir.ResourceMetadata) *corev3.Metadata {
 	}
 }
 
+// buildXdsClusterMetadata returns the metadata of a cluster generated from the resource:
+// only its kind, name and namespace, so that the clusters are not updated, and their
+// connections drained, whenever the resource is.
+func buildXdsClusterMetadata(metadata *ir.ResourceMetadata) *corev3.Metadata {
+	if metadata == nil {
+		return nil
+	}
+
+	return buildXdsMetadata(&ir.ResourceMetadata{
+		Kind:      metadata.Kind,
+		Name:      metadata.Name,
+		Namespace: metadata.Namespace,
+	})
+}
+
+// addNodeGroupsMetadata lists the node groups of the proxies the route is served by in
+// its metadata, which the snapshot cache filters the routes served to each proxy with.
+func addNodeGroupsMetadata(metadata *corev3.Metadata, nodeGroups []string) *corev3.Metadata {
+	if metadata == nil {
+		metadata = &corev3.Metadata{}
+	}
+	if metadata.FilterMetadata == nil {
+		metadata.FilterMetadata = map[string]*structpb.Struct{}
+	}
+	egMetadata := metadata.FilterMetadata[envoyGatewayXdsMetadataNamespace]
+	if egMetadata == nil {
+		egMetadata = &structpb.Struct{Fields: map[string]*structpb.Value{}}
+		metadata.FilterMetadata[envoyGatewayXdsMetadataNamespace] = egMetadata
+	}
+
+	groups := &structpb.ListValue{}
+	for _, group := range nodeGroups {
+		groups.Values = append(groups.Values, structpb.NewStringValue(group))
+	}
+	egMetadata.Fields[types.RouteNodeGroupsMetadataKey] = structpb.NewListValue(groups)
+	return metadata
+}
+
 func buildResourceMetadata(metadata *ir.ResourceMetadata) *structpb.Value {
 	routeResourceFields := map[string]*structpb.Value{
 		envoyGatewayXdsMetadataKeyKind: {
@@ -64,6 +106,18 @@ func buildResourceMetadata(metadata *ir.ResourceMetadata) *structpb.Value {
 		},
 	}
 
+	if metadata.Group != "" {
+		routeResourceFields[envoyGatewayXdsMetadataKeyGroup] = structpb.NewStringValue(metadata.Group)
+	}
+
+	if metadata.Version != "" {
+		routeResourceFields[envoyGatewayXdsMetadataKeyVersion] = structpb.NewStringValue(metadata.Version)
+	}
+
+	if metadata.Generation != 0 {
+		routeResourceFields[envoyGatewayXdsMetadataKeyGeneration] = structpb.NewNumberValue(float64(metadata.Generation))
+	}
+
 	if len(metadata.Annotations) > 0 {
 		routeResourceFields[envoyGatewayXdsMetadataKeyAnnotations] = &structpb.Value{
 			Kind: &structpb.Value_StructValue{
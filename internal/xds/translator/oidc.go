@@ -250,59 +250,63 @@ func createOAuth2TokenEndpointClusters(tCtx *types.ResourceVersionTable,
 			continue
 		}
 
-		var (
-			cluster *urlCluster
-			ds      *ir.DestinationSetting
-			tSocket *corev3.TransportSocket
-			err     error
-		)
-
-		cluster, err = url2Cluster(route.Security.OIDC.Provider.TokenEndpoint)
-		if err != nil {
+		if err := createTokenEndpointCluster(tCtx, route.Security.OIDC.Provider.TokenEndpoint); err != nil {
 			errs = errors.Join(errs, err)
-			continue
 		}
+	}
 
-		// EG does not support static IP clusters for token endpoint clusters.
-		// This validation could be removed since it's already validated in the
-		// Gateway API translator.
-		if cluster.endpointType == EndpointTypeStatic {
-			errs = errors.Join(errs, fmt.Errorf(
-				"static IP cluster is not allowed: %s",
-				route.Security.OIDC.Provider.TokenEndpoint))
-			continue
-		}
+	return errs
+}
 
-		ds = &ir.DestinationSetting{
-			Weight: ptr.To[uint32](1),
-			Endpoints: []*ir.DestinationEndpoint{
-				ir.NewDestEndpoint(
-					cluster.hostname,
-					cluster.port),
-			},
-		}
+// createTokenEndpointCluster creates the cluster of the token endpoint of an OAuth2
+// authorization server, if it does not already exist.
+func createTokenEndpointCluster(tCtx *types.ResourceVersionTable, tokenEndpoint string) error {
+	var (
+		cluster *urlCluster
+		ds      *ir.DestinationSetting
+		tSocket *corev3.TransportSocket
+		err     error
+	)
+
+	cluster, err = url2Cluster(tokenEndpoint)
+	if err != nil {
+		return err
+	}
 
-		clusterArgs := &xdsClusterArgs{
-			name:         cluster.name,
-			settings:     []*ir.DestinationSetting{ds},
-			tSocket:      tSocket,
-			endpointType: cluster.endpointType,
-		}
-		if cluster.tls {
-			tSocket, err = buildXdsUpstreamTLSSocket(cluster.hostname)
-			if err != nil {
-				errs = errors.Join(errs, err)
-				continue
-			}
-			clusterArgs.tSocket = tSocket
-		}
+	// EG does not support static IP clusters for token endpoint clusters.
+	// This validation could be removed since it's already validated in the
+	// Gateway API translator.
+	if cluster.endpointType == EndpointTypeStatic {
+		return fmt.Errorf("static IP cluster is not allowed: %s", tokenEndpoint)
+	}
 
-		if err = addXdsCluster(tCtx, clusterArgs); err != nil && !errors.Is(err, ErrXdsClusterExists) {
-			errs = errors.Join(errs, err)
+	ds = &ir.DestinationSetting{
+		Weight: ptr.To[uint32](1),
+		Endpoints: []*ir.DestinationEndpoint{
+			ir.NewDestEndpoint(
+				cluster.hostname,
+				cluster.port),
+		},
+	}
+
+	clusterArgs := &xdsClusterArgs{
+		name:         cluster.name,
+		settings:     []*ir.DestinationSetting{ds},
+		tSocket:      tSocket,
+		endpointType: cluster.endpointType,
+	}
+	if cluster.tls {
+		tSocket, err = buildXdsUpstreamTLSSocket(cluster.hostname)
+		if err != nil {
+			return err
 		}
+		clusterArgs.tSocket = tSocket
 	}
 
-	return errs
+	if err = addXdsCluster(tCtx, clusterArgs); err != nil && !errors.Is(err, ErrXdsClusterExists) {
+		return err
+	}
+	return nil
 }
 
 // createOAuth2Secrets creates OAuth2 client and HMAC secrets from the provided
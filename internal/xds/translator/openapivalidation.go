@@ -0,0 +1,193 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package translator
+
+import (
+	"errors"
+
+	corev3 "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	routev3 "github.com/envoyproxy/go-control-plane/envoy/config/route/v3"
+	extprocv3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/ext_proc/v3"
+	hcmv3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/network/http_connection_manager/v3"
+	"google.golang.org/protobuf/types/known/anypb"
+	"google.golang.org/protobuf/types/known/durationpb"
+
+	egv1a1 "github.com/envoyproxy/gateway/api/v1alpha1"
+	"github.com/envoyproxy/gateway/internal/ir"
+	"github.com/envoyproxy/gateway/internal/xds/types"
+)
+
+// openAPIValidationFilterPrefix is the prefix of the name of the ext_proc filters
+// validating the requests against an OpenAPI document.
+const openAPIValidationFilterPrefix = string(egv1a1.EnvoyFilterExtProc) + "/openapi"
+
+const (
+	// openAPIDocumentMetadataKey is the gRPC metadata key identifying the OpenAPI document
+	// as <namespace>/<configmap>/<key> to the external processing service.
+	openAPIDocumentMetadataKey = "x-envoy-gateway-openapi-document"
+	// openAPIDocumentSHA256MetadataKey is the gRPC metadata key holding the hex encoded
+	// SHA-256 digest of the OpenAPI document.
+	openAPIDocumentSHA256MetadataKey = "x-envoy-gateway-openapi-document-sha256"
+)
+
+func init() {
+	registerHTTPFilter(&openAPIValidation{})
+}
+
+type openAPIValidation struct{}
+
+var _ httpFilter = &openAPIValidation{}
+
+// patchHCM builds and appends the OpenAPI validation Filters to the HTTP Connection
+// Manager if applicable, and it does not already exist.
+// Note: this method creates an ext_proc filter calling the external processing service
+// for each OpenAPI document. The filter is disabled by default. It is enabled on the route level.
+func (*openAPIValidation) patchHCM(mgr *hcmv3.HttpConnectionManager, irListener *ir.HTTPListener) error {
+	var errs error
+
+	if mgr == nil {
+		return errors.New("hcm is nil")
+	}
+
+	if irListener == nil {
+		return errors.New("ir listener is nil")
+	}
+
+	for _, route := range irListener.Routes {
+		if !routeContainsOpenAPIValidation(route) {
+			continue
+		}
+
+		validation := route.EnvoyExtensions.OpenAPIValidation
+		if hcmContainsFilter(mgr, openAPIValidationFilterName(validation)) {
+			continue
+		}
+
+		filter, err := buildHCMOpenAPIValidationFilter(validation)
+		if err != nil {
+			errs = errors.Join(errs, err)
+			continue
+		}
+
+		mgr.HttpFilters = append(mgr.HttpFilters, filter)
+	}
+
+	return errs
+}
+
+// buildHCMOpenAPIValidationFilter returns an ext_proc HTTP filter validating the
+// requests against the OpenAPI document.
+func buildHCMOpenAPIValidationFilter(validation *ir.OpenAPIValidation) (*hcmv3.HttpFilter, error) {
+	extProcProto := openAPIValidationConfig(validation)
+	if err := extProcProto.ValidateAll(); err != nil {
+		return nil, err
+	}
+
+	extProcAny, err := anypb.New(extProcProto)
+	if err != nil {
+		return nil, err
+	}
+
+	return &hcmv3.HttpFilter{
+		Name:     openAPIValidationFilterName(validation),
+		Disabled: true,
+		ConfigType: &hcmv3.HttpFilter_TypedConfig{
+			TypedConfig: extProcAny,
+		},
+	}, nil
+}
+
+func openAPIValidationFilterName(validation *ir.OpenAPIValidation) string {
+	return openAPIValidationFilterPrefix + "/" + validation.Name
+}
+
+// openAPIValidationConfig returns the ext_proc config sending the request headers and
+// the buffered request body to the external processing service, which validates them
+// against the document identified by the gRPC metadata.
+func openAPIValidationConfig(validation *ir.OpenAPIValidation) *extprocv3.ExternalProcessor {
+	return &extprocv3.ExternalProcessor{
+		GrpcService: &corev3.GrpcService{
+			TargetSpecifier: &corev3.GrpcService_EnvoyGrpc_{
+				EnvoyGrpc: &corev3.GrpcService_EnvoyGrpc{
+					ClusterName: validation.Destination.Name,
+					Authority:   validation.Authority,
+				},
+			},
+			Timeout: &durationpb.Duration{
+				Seconds: defaultExtServiceRequestTimeout,
+			},
+			InitialMetadata: []*corev3.HeaderValue{
+				{
+					Key:   openAPIDocumentMetadataKey,
+					Value: validation.Document,
+				},
+				{
+					Key:   openAPIDocumentSHA256MetadataKey,
+					Value: validation.DocumentSHA256,
+				},
+			},
+		},
+		FailureModeAllow: validation.FailOpen,
+		ProcessingMode: &extprocv3.ProcessingMode{
+			RequestHeaderMode:   extprocv3.ProcessingMode_SEND,
+			ResponseHeaderMode:  extprocv3.ProcessingMode_SKIP,
+			RequestBodyMode:     extprocv3.ProcessingMode_BUFFERED,
+			ResponseBodyMode:    extprocv3.ProcessingMode_NONE,
+			RequestTrailerMode:  extprocv3.ProcessingMode_SKIP,
+			ResponseTrailerMode: extprocv3.ProcessingMode_SKIP,
+		},
+	}
+}
+
+// routeContainsOpenAPIValidation returns true if OpenAPIValidation exists for the provided route.
+func routeContainsOpenAPIValidation(irRoute *ir.HTTPRoute) bool {
+	if irRoute == nil {
+		return false
+	}
+
+	return irRoute.EnvoyExtensions != nil && irRoute.EnvoyExtensions.OpenAPIValidation != nil
+}
+
+// patchResources patches the cluster resources for the external processing services.
+func (*openAPIValidation) patchResources(tCtx *types.ResourceVersionTable,
+	routes []*ir.HTTPRoute,
+) error {
+	if tCtx == nil || tCtx.XdsResources == nil {
+		return errors.New("xds resource table is nil")
+	}
+
+	var errs error
+	for _, route := range routes {
+		if !routeContainsOpenAPIValidation(route) {
+			continue
+		}
+
+		validation := route.EnvoyExtensions.OpenAPIValidation
+		if err := createExtServiceXDSCluster(
+			&validation.Destination, validation.Traffic, tCtx); err != nil && !errors.Is(
+			err, ErrXdsClusterExists) {
+			errs = errors.Join(errs, err)
+		}
+	}
+
+	return errs
+}
+
+// patchRoute patches the provided route with the OpenAPI validation config if applicable.
+// Note: this method enables the corresponding ext_proc filter for the provided route.
+func (*openAPIValidation) patchRoute(route *routev3.Route, irRoute *ir.HTTPRoute) error {
+	if route == nil {
+		return errors.New("xds route is nil")
+	}
+	if irRoute == nil {
+		return errors.New("ir route is nil")
+	}
+	if !routeContainsOpenAPIValidation(irRoute) {
+		return nil
+	}
+
+	return enableFilterOnRoute(route, openAPIValidationFilterName(irRoute.EnvoyExtensions.OpenAPIValidation))
+}
@@ -0,0 +1,245 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package translator
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	corev3 "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	routev3 "github.com/envoyproxy/go-control-plane/envoy/config/route/v3"
+	luav3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/lua/v3"
+	hcmv3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/network/http_connection_manager/v3"
+	"google.golang.org/protobuf/types/known/anypb"
+
+	egv1a1 "github.com/envoyproxy/gateway/api/v1alpha1"
+	"github.com/envoyproxy/gateway/internal/ir"
+	"github.com/envoyproxy/gateway/internal/xds/types"
+)
+
+func init() {
+	registerHTTPFilter(&requestNormalization{})
+}
+
+type requestNormalization struct{}
+
+var _ httpFilter = &requestNormalization{}
+
+// requestNormalizationScript normalizes the query parameters and redirects the requests
+// whose trailing slash is not normalized, as configured by the variables prepended to it.
+const requestNormalizationScript = `
+local function normalize_query(query)
+  local parameters = {}
+  for parameter in string.gmatch(query, "[^&]+") do
+    local name = string.match(parameter, "^[^=]*")
+    if not remove_parameters[name] then
+      table.insert(parameters, { name = name, value = parameter, index = #parameters + 1 })
+    end
+  end
+  if sort_parameters then
+    table.sort(parameters, function(a, b)
+      if a.name ~= b.name then
+        return a.name < b.name
+      end
+      return a.index < b.index
+    end)
+  end
+  local normalized = {}
+  for i, parameter in ipairs(parameters) do
+    normalized[i] = parameter.value
+  end
+  return table.concat(normalized, "&")
+end
+
+local function normalize_trailing_slash(path)
+  if trailing_slash == "Add" and string.sub(path, -1) ~= "/" then
+    return path .. "/"
+  end
+  if trailing_slash == "Remove" and #path > 1 and string.sub(path, -1) == "/" then
+    local trimmed = string.gsub(path, "/+$", "")
+    if trimmed == "" then
+      return "/"
+    end
+    return trimmed
+  end
+  return path
+end
+
+function envoy_on_request(request_handle)
+  local headers = request_handle:headers()
+  local original = headers:get(":path")
+  if original == nil then
+    return
+  end
+
+  local path = original
+  local query = nil
+  local separator = string.find(original, "?", 1, true)
+  if separator ~= nil then
+    path = string.sub(original, 1, separator - 1)
+    query = normalize_query(string.sub(original, separator + 1))
+  end
+
+  local normalized_path = normalize_trailing_slash(path)
+  local normalized = normalized_path
+  if query ~= nil and query ~= "" then
+    normalized = normalized .. "?" .. query
+  end
+
+  if normalized_path ~= path then
+    request_handle:respond({ [":status"] = redirect_status_code, ["location"] = normalized }, "")
+    return
+  end
+  if normalized ~= original then
+    headers:replace(":path", normalized)
+  end
+end
+`
+
+// patchHCM builds and appends the Lua filter normalizing the requests to the HTTP
+// Connection Manager if applicable, and it does not already exist.
+// Note: the filter has no default script, the script of each route is set on the route.
+func (*requestNormalization) patchHCM(mgr *hcmv3.HttpConnectionManager, irListener *ir.HTTPListener) error {
+	if mgr == nil {
+		return errors.New("hcm is nil")
+	}
+
+	if irListener == nil {
+		return errors.New("ir listener is nil")
+	}
+
+	if !listenerContainsRequestNormalization(irListener) {
+		return nil
+	}
+
+	// Return early if the Lua filter already exists.
+	for _, existingFilter := range mgr.HttpFilters {
+		if existingFilter.Name == egv1a1.EnvoyFilterLua.String() {
+			return nil
+		}
+	}
+
+	filterAny, err := anypb.New(&luav3.Lua{})
+	if err != nil {
+		return err
+	}
+
+	mgr.HttpFilters = append(mgr.HttpFilters, &hcmv3.HttpFilter{
+		Name: egv1a1.EnvoyFilterLua.String(),
+		ConfigType: &hcmv3.HttpFilter_TypedConfig{
+			TypedConfig: filterAny,
+		},
+	})
+
+	return nil
+}
+
+// listenerContainsRequestNormalization returns true if a route of the listener normalizes
+// its requests.
+func listenerContainsRequestNormalization(irListener *ir.HTTPListener) bool {
+	for _, route := range irListener.Routes {
+		if route.RequestNormalization != nil {
+			return true
+		}
+	}
+	return false
+}
+
+func (*requestNormalization) patchResources(*types.ResourceVersionTable, []*ir.HTTPRoute) error {
+	return nil
+}
+
+// patchRoute sets the script of the Lua filter normalizing the requests of the route.
+func (*requestNormalization) patchRoute(route *routev3.Route, irRoute *ir.HTTPRoute) error {
+	if route == nil {
+		return errors.New("xds route is nil")
+	}
+	if irRoute == nil {
+		return errors.New("ir route is nil")
+	}
+	if irRoute.RequestNormalization == nil {
+		return nil
+	}
+
+	filterName := egv1a1.EnvoyFilterLua.String()
+	filterCfg := route.GetTypedPerFilterConfig()
+	if _, ok := filterCfg[filterName]; ok {
+		// This should not happen since this is the only place where the Lua filter is
+		// added in a route.
+		return fmt.Errorf("route already contains lua config: %+v", route)
+	}
+
+	routeCfgAny, err := anypb.New(&luav3.LuaPerRoute{
+		Override: &luav3.LuaPerRoute_SourceCode{
+			SourceCode: &corev3.DataSource{
+				Specifier: &corev3.DataSource_InlineString{
+					InlineString: buildRequestNormalizationScript(irRoute.RequestNormalization),
+				},
+			},
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	if filterCfg == nil {
+		route.TypedPerFilterConfig = make(map[string]*anypb.Any)
+	}
+
+	route.TypedPerFilterConfig[filterName] = routeCfgAny
+
+	return nil
+}
+
+// buildRequestNormalizationScript returns the script normalizing the requests of a route.
+func buildRequestNormalizationScript(normalization *ir.RequestNormalization) string {
+	var sb strings.Builder
+
+	sb.WriteString("local remove_parameters = {")
+	for i, name := range normalization.RemoveQueryParameters {
+		if i > 0 {
+			sb.WriteString(",")
+		}
+		sb.WriteString(" [" + luaQuote(name) + "] = true")
+	}
+	sb.WriteString(" }\n")
+
+	sb.WriteString("local sort_parameters = " + strconv.FormatBool(normalization.SortQueryParameters) + "\n")
+
+	if redirect := normalization.TrailingSlashRedirect; redirect != nil {
+		sb.WriteString("local trailing_slash = " + luaQuote(string(redirect.Action)) + "\n")
+		sb.WriteString("local redirect_status_code = " + luaQuote(strconv.Itoa(int(redirect.StatusCode))) + "\n")
+	} else {
+		sb.WriteString("local trailing_slash = nil\n")
+		sb.WriteString("local redirect_status_code = nil\n")
+	}
+
+	sb.WriteString(requestNormalizationScript)
+	return sb.String()
+}
+
+// luaQuote returns the Lua string literal of s, escaping the bytes other than the
+// printable ASCII characters as decimal escapes, which all Lua versions support.
+func luaQuote(s string) string {
+	var sb strings.Builder
+	sb.WriteByte('"')
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case c == '"' || c == '\\':
+			sb.WriteByte('\\')
+			sb.WriteByte(c)
+		case c >= 0x20 && c < 0x7f:
+			sb.WriteByte(c)
+		default:
+			fmt.Fprintf(&sb, "\\%03d", c)
+		}
+	}
+	sb.WriteByte('"')
+	return sb.String()
+}
@@ -0,0 +1,317 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package translator
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	corev3 "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	routev3 "github.com/envoyproxy/go-control-plane/envoy/config/route/v3"
+	awsrequestsigningv3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/aws_request_signing/v3"
+	extprocv3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/ext_proc/v3"
+	hcmv3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/network/http_connection_manager/v3"
+	"google.golang.org/protobuf/types/known/anypb"
+	"google.golang.org/protobuf/types/known/durationpb"
+
+	egv1a1 "github.com/envoyproxy/gateway/api/v1alpha1"
+	"github.com/envoyproxy/gateway/internal/ir"
+	"github.com/envoyproxy/gateway/internal/xds/types"
+)
+
+// requestSigningFilterPrefix is the prefix of the name of the ext_proc filters signing
+// the requests with an HMAC signature.
+const requestSigningFilterPrefix = string(egv1a1.EnvoyFilterExtProc) + "/request-signing"
+
+const (
+	// requestSigningKeyMetadataKey is the gRPC metadata key identifying the Secret of the
+	// HMAC key as <namespace>/<name> to the external processing service.
+	requestSigningKeyMetadataKey = "x-envoy-gateway-request-signing-key"
+	// requestSigningSignatureHeaderMetadataKey is the gRPC metadata key holding the name of
+	// the header the signature is set in.
+	requestSigningSignatureHeaderMetadataKey = "x-envoy-gateway-request-signing-signature-header"
+	// requestSigningTimestampHeaderMetadataKey is the gRPC metadata key holding the name of
+	// the header the timestamp of the signature is set in.
+	requestSigningTimestampHeaderMetadataKey = "x-envoy-gateway-request-signing-timestamp-header"
+	// requestSigningSignedHeadersMetadataKey is the gRPC metadata key holding the comma
+	// separated names of the signed headers, if any.
+	requestSigningSignedHeadersMetadataKey = "x-envoy-gateway-request-signing-signed-headers"
+	// requestSigningSignBodyMetadataKey is the gRPC metadata key holding whether the body of
+	// the requests is signed.
+	requestSigningSignBodyMetadataKey = "x-envoy-gateway-request-signing-sign-body"
+)
+
+func init() {
+	registerHTTPFilter(&requestSigning{})
+}
+
+type requestSigning struct{}
+
+var _ httpFilter = &requestSigning{}
+
+// patchHCM builds and appends the request signing Filters to the HTTP Connection Manager
+// if applicable, and they do not already exist.
+// Note: the AWS SigV4 signature uses the native aws_request_signing filter, whose HCM
+// level config is overridden at the route level. The HMAC signature uses an ext_proc
+// filter calling the external processing service for each signer. The filters are
+// disabled by default and are enabled on the route level.
+func (*requestSigning) patchHCM(mgr *hcmv3.HttpConnectionManager, irListener *ir.HTTPListener) error {
+	var errs error
+
+	if mgr == nil {
+		return errors.New("hcm is nil")
+	}
+
+	if irListener == nil {
+		return errors.New("ir listener is nil")
+	}
+
+	for _, route := range irListener.Routes {
+		if routeContainsAWSSigV4(route) && !hcmContainsFilter(mgr, egv1a1.EnvoyFilterAWSRequestSigning.String()) {
+			// We use the first route that contains the AWS SigV4 config to build the filter.
+			// The HCM-level filter config doesn't matter since it is overridden at the route level.
+			filter, err := buildHCMAWSRequestSigningFilter(route.Traffic.RequestSigning.AWSSigV4)
+			if err != nil {
+				errs = errors.Join(errs, err)
+				continue
+			}
+			mgr.HttpFilters = append(mgr.HttpFilters, filter)
+		}
+
+		if !routeContainsHMACRequestSigning(route) {
+			continue
+		}
+
+		hmac := route.Traffic.RequestSigning.HMAC
+		if hcmContainsFilter(mgr, hmacRequestSigningFilterName(hmac)) {
+			continue
+		}
+
+		filter, err := buildHCMHMACRequestSigningFilter(hmac)
+		if err != nil {
+			errs = errors.Join(errs, err)
+			continue
+		}
+
+		mgr.HttpFilters = append(mgr.HttpFilters, filter)
+	}
+
+	return errs
+}
+
+// buildHCMAWSRequestSigningFilter returns an aws_request_signing HTTP filter signing the
+// requests with the AWS Signature Version 4.
+func buildHCMAWSRequestSigningFilter(awsSigV4 *ir.AWSSigV4RequestSigning) (*hcmv3.HttpFilter, error) {
+	awsProto := awsRequestSigningConfig(awsSigV4)
+	if err := awsProto.ValidateAll(); err != nil {
+		return nil, err
+	}
+
+	awsAny, err := anypb.New(awsProto)
+	if err != nil {
+		return nil, err
+	}
+
+	return &hcmv3.HttpFilter{
+		Name:     egv1a1.EnvoyFilterAWSRequestSigning.String(),
+		Disabled: true,
+		ConfigType: &hcmv3.HttpFilter_TypedConfig{
+			TypedConfig: awsAny,
+		},
+	}, nil
+}
+
+// awsRequestSigningConfig returns the aws_request_signing config of the signature. The
+// credentials are looked up by the proxies from their environment.
+func awsRequestSigningConfig(awsSigV4 *ir.AWSSigV4RequestSigning) *awsrequestsigningv3.AwsRequestSigning {
+	return &awsrequestsigningv3.AwsRequestSigning{
+		ServiceName:        awsSigV4.Service,
+		Region:             awsSigV4.Region,
+		UseUnsignedPayload: awsSigV4.UnsignedPayload,
+	}
+}
+
+// buildHCMHMACRequestSigningFilter returns an ext_proc HTTP filter signing the requests
+// with an HMAC signature.
+func buildHCMHMACRequestSigningFilter(hmac *ir.HMACRequestSigning) (*hcmv3.HttpFilter, error) {
+	extProcProto := hmacRequestSigningConfig(hmac)
+	if err := extProcProto.ValidateAll(); err != nil {
+		return nil, err
+	}
+
+	extProcAny, err := anypb.New(extProcProto)
+	if err != nil {
+		return nil, err
+	}
+
+	return &hcmv3.HttpFilter{
+		Name:     hmacRequestSigningFilterName(hmac),
+		Disabled: true,
+		ConfigType: &hcmv3.HttpFilter_TypedConfig{
+			TypedConfig: extProcAny,
+		},
+	}, nil
+}
+
+func hmacRequestSigningFilterName(hmac *ir.HMACRequestSigning) string {
+	return requestSigningFilterPrefix + "/" + hmac.Name
+}
+
+// hmacRequestSigningConfig returns the ext_proc config sending the request headers, and
+// the buffered request body if it is signed, to the external processing service, which
+// signs them as described by the gRPC metadata. The requests fail if they can't be signed.
+func hmacRequestSigningConfig(hmac *ir.HMACRequestSigning) *extprocv3.ExternalProcessor {
+	requestBodyMode := extprocv3.ProcessingMode_NONE
+	if hmac.SignBody {
+		requestBodyMode = extprocv3.ProcessingMode_BUFFERED
+	}
+
+	metadata := []*corev3.HeaderValue{
+		{
+			Key:   requestSigningKeyMetadataKey,
+			Value: hmac.KeySecret,
+		},
+		{
+			Key:   requestSigningSignatureHeaderMetadataKey,
+			Value: hmac.SignatureHeader,
+		},
+		{
+			Key:   requestSigningTimestampHeaderMetadataKey,
+			Value: hmac.TimestampHeader,
+		},
+		{
+			Key:   requestSigningSignBodyMetadataKey,
+			Value: strconv.FormatBool(hmac.SignBody),
+		},
+	}
+	if len(hmac.SignedHeaders) > 0 {
+		metadata = append(metadata, &corev3.HeaderValue{
+			Key:   requestSigningSignedHeadersMetadataKey,
+			Value: strings.Join(hmac.SignedHeaders, ","),
+		})
+	}
+
+	return &extprocv3.ExternalProcessor{
+		GrpcService: &corev3.GrpcService{
+			TargetSpecifier: &corev3.GrpcService_EnvoyGrpc_{
+				EnvoyGrpc: &corev3.GrpcService_EnvoyGrpc{
+					ClusterName: hmac.Destination.Name,
+					Authority:   hmac.Authority,
+				},
+			},
+			Timeout: &durationpb.Duration{
+				Seconds: defaultExtServiceRequestTimeout,
+			},
+			InitialMetadata: metadata,
+		},
+		ProcessingMode: &extprocv3.ProcessingMode{
+			RequestHeaderMode:   extprocv3.ProcessingMode_SEND,
+			ResponseHeaderMode:  extprocv3.ProcessingMode_SKIP,
+			RequestBodyMode:     requestBodyMode,
+			ResponseBodyMode:    extprocv3.ProcessingMode_NONE,
+			RequestTrailerMode:  extprocv3.ProcessingMode_SKIP,
+			ResponseTrailerMode: extprocv3.ProcessingMode_SKIP,
+		},
+	}
+}
+
+// routeContainsAWSSigV4 returns true if the AWS SigV4 signature exists for the provided route.
+func routeContainsAWSSigV4(irRoute *ir.HTTPRoute) bool {
+	if irRoute == nil {
+		return false
+	}
+
+	return irRoute.Traffic != nil && irRoute.Traffic.RequestSigning != nil &&
+		irRoute.Traffic.RequestSigning.AWSSigV4 != nil
+}
+
+// routeContainsHMACRequestSigning returns true if the HMAC signature exists for the provided route.
+func routeContainsHMACRequestSigning(irRoute *ir.HTTPRoute) bool {
+	if irRoute == nil {
+		return false
+	}
+
+	return irRoute.Traffic != nil && irRoute.Traffic.RequestSigning != nil &&
+		irRoute.Traffic.RequestSigning.HMAC != nil
+}
+
+// patchResources patches the cluster resources for the external processing services
+// signing the requests.
+func (*requestSigning) patchResources(tCtx *types.ResourceVersionTable,
+	routes []*ir.HTTPRoute,
+) error {
+	if tCtx == nil || tCtx.XdsResources == nil {
+		return errors.New("xds resource table is nil")
+	}
+
+	var errs error
+	for _, route := range routes {
+		if !routeContainsHMACRequestSigning(route) {
+			continue
+		}
+
+		hmac := route.Traffic.RequestSigning.HMAC
+		if err := createExtServiceXDSCluster(
+			&hmac.Destination, hmac.Traffic, tCtx); err != nil && !errors.Is(
+			err, ErrXdsClusterExists) {
+			errs = errors.Join(errs, err)
+		}
+	}
+
+	return errs
+}
+
+// patchRoute patches the provided route with the request signing config if applicable.
+// Note: this method overwrites the HCM level aws_request_signing filter config with the
+// per route filter config, or enables the corresponding ext_proc filter for the route.
+func (*requestSigning) patchRoute(route *routev3.Route, irRoute *ir.HTTPRoute) error {
+	if route == nil {
+		return errors.New("xds route is nil")
+	}
+	if irRoute == nil {
+		return errors.New("ir route is nil")
+	}
+
+	if routeContainsHMACRequestSigning(irRoute) {
+		return enableFilterOnRoute(route, hmacRequestSigningFilterName(irRoute.Traffic.RequestSigning.HMAC))
+	}
+	if !routeContainsAWSSigV4(irRoute) {
+		return nil
+	}
+
+	filterName := egv1a1.EnvoyFilterAWSRequestSigning.String()
+	perFilterCfg := route.GetTypedPerFilterConfig()
+	if _, ok := perFilterCfg[filterName]; ok {
+		// This should not happen since this is the only place where the filter
+		// config is added in a route.
+		return fmt.Errorf("route already contains filter config: %s, %+v",
+			filterName, route)
+	}
+
+	awsSigV4 := irRoute.Traffic.RequestSigning.AWSSigV4
+	awsProto := &awsrequestsigningv3.AwsRequestSigningPerRoute{
+		AwsRequestSigning: awsRequestSigningConfig(awsSigV4),
+		// The signatures are counted by AWS service.
+		StatPrefix: awsSigV4.Service,
+	}
+	if err := awsProto.ValidateAll(); err != nil {
+		return err
+	}
+
+	awsAny, err := anypb.New(awsProto)
+	if err != nil {
+		return err
+	}
+
+	if perFilterCfg == nil {
+		route.TypedPerFilterConfig = make(map[string]*anypb.Any)
+	}
+	route.TypedPerFilterConfig[filterName] = awsAny
+
+	return nil
+}
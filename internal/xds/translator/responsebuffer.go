@@ -0,0 +1,183 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package translator
+
+import (
+	"errors"
+	"fmt"
+
+	routev3 "github.com/envoyproxy/go-control-plane/envoy/config/route/v3"
+	asyncfilesv3 "github.com/envoyproxy/go-control-plane/envoy/extensions/common/async_files/v3"
+	filesystembufferv3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/file_system_buffer/v3"
+	hcmv3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/network/http_connection_manager/v3"
+	"google.golang.org/protobuf/types/known/anypb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+
+	egv1a1 "github.com/envoyproxy/gateway/api/v1alpha1"
+	"github.com/envoyproxy/gateway/internal/ir"
+	"github.com/envoyproxy/gateway/internal/xds/types"
+)
+
+// responseBufferFileManagerID is the ID of the file manager of the file system buffer filter.
+// The manager is required by the filter, even though the responses are only buffered in
+// memory.
+const responseBufferFileManagerID = "envoy-gateway-response-buffer"
+
+func init() {
+	registerHTTPFilter(&responseBuffer{})
+}
+
+type responseBuffer struct{}
+
+var _ httpFilter = &responseBuffer{}
+
+// patchHCM builds and appends the file system buffer filter to the HTTP Connection Manager
+// if applicable, and it does not already exist.
+// Note: the filter bypasses the requests and the responses, the buffering of the responses
+// of each route is set on the route.
+func (*responseBuffer) patchHCM(mgr *hcmv3.HttpConnectionManager, irListener *ir.HTTPListener) error {
+	if mgr == nil {
+		return errors.New("hcm is nil")
+	}
+
+	if irListener == nil {
+		return errors.New("ir listener is nil")
+	}
+
+	if !listenerContainsResponseBuffer(irListener) {
+		return nil
+	}
+
+	// Return early if the file system buffer filter already exists.
+	for _, existingFilter := range mgr.HttpFilters {
+		if existingFilter.Name == egv1a1.EnvoyFilterFileSystemBuffer.String() {
+			return nil
+		}
+	}
+
+	filterAny, err := anypb.New(&filesystembufferv3.FileSystemBufferFilterConfig{
+		ManagerConfig: &asyncfilesv3.AsyncFileManagerConfig{
+			Id: responseBufferFileManagerID,
+			ManagerType: &asyncfilesv3.AsyncFileManagerConfig_ThreadPool_{
+				ThreadPool: &asyncfilesv3.AsyncFileManagerConfig_ThreadPool{
+					ThreadCount: 1,
+				},
+			},
+		},
+		Request:  bypassBufferStreamConfig(),
+		Response: bypassBufferStreamConfig(),
+	})
+	if err != nil {
+		return err
+	}
+
+	mgr.HttpFilters = append(mgr.HttpFilters, &hcmv3.HttpFilter{
+		Name: egv1a1.EnvoyFilterFileSystemBuffer.String(),
+		ConfigType: &hcmv3.HttpFilter_TypedConfig{
+			TypedConfig: filterAny,
+		},
+	})
+
+	return nil
+}
+
+// bypassBufferStreamConfig returns the configuration of a direction of the file system
+// buffer filter which is not buffered.
+func bypassBufferStreamConfig() *filesystembufferv3.StreamConfig {
+	return &filesystembufferv3.StreamConfig{
+		Behavior: &filesystembufferv3.BufferBehavior{
+			Behavior: &filesystembufferv3.BufferBehavior_Bypass_{
+				Bypass: &filesystembufferv3.BufferBehavior_Bypass{},
+			},
+		},
+	}
+}
+
+// listenerContainsResponseBuffer returns true if a route of the listener buffers the bodies
+// of its responses.
+func listenerContainsResponseBuffer(irListener *ir.HTTPListener) bool {
+	for _, route := range irListener.Routes {
+		if routeContainsResponseBuffer(route) {
+			return true
+		}
+	}
+	return false
+}
+
+// routeContainsResponseBuffer returns true if the route buffers the bodies of its responses.
+func routeContainsResponseBuffer(irRoute *ir.HTTPRoute) bool {
+	return irRoute != nil &&
+		irRoute.Traffic != nil &&
+		irRoute.Traffic.ResponseBuffer != nil
+}
+
+func (*responseBuffer) patchResources(*types.ResourceVersionTable, []*ir.HTTPRoute) error {
+	return nil
+}
+
+// patchRoute sets the buffering of the responses of the route on the file system buffer
+// filter. The responses are only buffered in memory, so that the ones exceeding the limit
+// are either rejected or streamed, instead of being written to the disk.
+func (*responseBuffer) patchRoute(route *routev3.Route, irRoute *ir.HTTPRoute) error {
+	if route == nil {
+		return errors.New("xds route is nil")
+	}
+	if irRoute == nil {
+		return errors.New("ir route is nil")
+	}
+	if !routeContainsResponseBuffer(irRoute) {
+		return nil
+	}
+	responseBuffer := irRoute.Traffic.ResponseBuffer
+
+	filterName := egv1a1.EnvoyFilterFileSystemBuffer.String()
+	filterCfg := route.GetTypedPerFilterConfig()
+	if _, ok := filterCfg[filterName]; ok {
+		// This should not happen since this is the only place where the file system
+		// buffer filter is added in a route.
+		return fmt.Errorf("route already contains file system buffer config: %+v", route)
+	}
+
+	behavior := &filesystembufferv3.BufferBehavior{}
+	switch {
+	case responseBuffer.StreamThrough:
+		behavior.Behavior = &filesystembufferv3.BufferBehavior_StreamWhenPossible_{
+			StreamWhenPossible: &filesystembufferv3.BufferBehavior_StreamWhenPossible{},
+		}
+	case responseBuffer.InjectContentLength:
+		behavior.Behavior = &filesystembufferv3.BufferBehavior_FullyBufferAndAlwaysInjectContentLength_{
+			FullyBufferAndAlwaysInjectContentLength: &filesystembufferv3.BufferBehavior_FullyBufferAndAlwaysInjectContentLength{},
+		}
+	default:
+		behavior.Behavior = &filesystembufferv3.BufferBehavior_FullyBuffer_{
+			FullyBuffer: &filesystembufferv3.BufferBehavior_FullyBuffer{},
+		}
+	}
+
+	routeCfgProto := &filesystembufferv3.FileSystemBufferFilterConfig{
+		Response: &filesystembufferv3.StreamConfig{
+			Behavior:                behavior,
+			MemoryBufferBytesLimit:  wrapperspb.UInt64(responseBuffer.Limit),
+			StorageBufferBytesLimit: wrapperspb.UInt64(0),
+		},
+	}
+	if err := routeCfgProto.ValidateAll(); err != nil {
+		return err
+	}
+
+	routeCfgAny, err := anypb.New(routeCfgProto)
+	if err != nil {
+		return err
+	}
+
+	if filterCfg == nil {
+		route.TypedPerFilterConfig = make(map[string]*anypb.Any)
+	}
+
+	route.TypedPerFilterConfig[filterName] = routeCfgAny
+
+	return nil
+}
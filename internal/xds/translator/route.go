@@ -7,6 +7,7 @@ package translator
 
 import (
 	"errors"
+	"regexp"
 	"strings"
 	"time"
 
@@ -33,6 +34,11 @@ func buildXdsRoute(httpRoute *ir.HTTPRoute) (*routev3.Route, error) {
 		Match:    buildXdsRouteMatch(httpRoute.PathMatch, httpRoute.HeaderMatches, httpRoute.QueryParamMatches),
 		Metadata: buildXdsMetadata(httpRoute.Metadata),
 	}
+	// The route is matched against the claims of the JWT once it is validated.
+	router.Match.DynamicMetadata = buildJWTRouteMatchers(httpRoute)
+	if len(httpRoute.NodeGroups) > 0 {
+		router.Metadata = addNodeGroupsMetadata(router.Metadata, httpRoute.NodeGroups)
+	}
 
 	if len(httpRoute.AddRequestHeaders) > 0 {
 		router.RequestHeadersToAdd = buildXdsAddedHeaders(httpRoute.AddRequestHeaders)
@@ -41,8 +47,13 @@ func buildXdsRoute(httpRoute *ir.HTTPRoute) (*routev3.Route, error) {
 		router.RequestHeadersToRemove = httpRoute.RemoveRequestHeaders
 	}
 
+	// The security headers are added first, so that the headers of the route filters
+	// take precedence.
+	if httpRoute.Security != nil && httpRoute.Security.SecurityHeaders != nil {
+		router.ResponseHeadersToAdd = buildXdsSecurityHeaders(httpRoute.Security.SecurityHeaders)
+	}
 	if len(httpRoute.AddResponseHeaders) > 0 {
-		router.ResponseHeadersToAdd = buildXdsAddedHeaders(httpRoute.AddResponseHeaders)
+		router.ResponseHeadersToAdd = append(router.ResponseHeadersToAdd, buildXdsAddedHeaders(httpRoute.AddResponseHeaders)...)
 	}
 	if len(httpRoute.RemoveResponseHeaders) > 0 {
 		router.ResponseHeadersToRemove = httpRoute.RemoveResponseHeaders
@@ -174,6 +185,10 @@ func buildXdsRouteMatch(pathMatch *ir.StringMatch, headerMatches []*ir.StringMat
 
 	// Query param matches
 	for _, queryParamMatch := range queryParamMatches {
+		if queryParamMatch.Present != nil {
+			outMatch = withQueryParamPresenceMatch(outMatch, queryParamMatch.Name, *queryParamMatch.Present)
+			continue
+		}
 		stringMatcher := buildXdsStringMatcher(queryParamMatch)
 
 		queryParamMatcher := &routev3.QueryParameterMatcher{
@@ -188,6 +203,36 @@ func buildXdsRouteMatch(pathMatch *ir.StringMatch, headerMatches []*ir.StringMat
 	return outMatch
 }
 
+// withQueryParamPresenceMatch adds the presence or absence condition of the query
+// parameter to the route match. The query parameter matchers of Envoy can't match the
+// absence of a parameter, so the path, which includes the query, must not match a
+// regex of the parameter instead.
+func withQueryParamPresenceMatch(outMatch *routev3.RouteMatch, name string, present bool) *routev3.RouteMatch {
+	if present {
+		outMatch.QueryParameters = append(outMatch.QueryParameters, &routev3.QueryParameterMatcher{
+			Name: name,
+			QueryParameterMatchSpecifier: &routev3.QueryParameterMatcher_PresentMatch{
+				PresentMatch: true,
+			},
+		})
+		return outMatch
+	}
+	outMatch.Headers = append(outMatch.Headers, &routev3.HeaderMatcher{
+		Name: ":path",
+		HeaderMatchSpecifier: &routev3.HeaderMatcher_StringMatch{
+			StringMatch: &matcherv3.StringMatcher{
+				MatchPattern: &matcherv3.StringMatcher_SafeRegex{
+					SafeRegex: &matcherv3.RegexMatcher{
+						Regex: `^[^?]*\?(.*&)?` + regexp.QuoteMeta(name) + `([=&].*)?$`,
+					},
+				},
+			},
+		},
+		InvertMatch: true,
+	})
+	return outMatch
+}
+
 func buildXdsStringMatcher(irMatch *ir.StringMatch) *matcherv3.StringMatcher {
 	stringMatcher := new(matcherv3.StringMatcher)
 
@@ -445,6 +490,27 @@ func buildXdsRequestMirrorPolicies(mirrorDestinations []*ir.RouteDestination) []
 	return mirrorPolicies
 }
 
+// buildXdsSecurityHeaders returns the security headers added to the responses, which
+// only replace the headers set by the backends if configured to overwrite them.
+func buildXdsSecurityHeaders(securityHeaders *ir.SecurityHeaders) []*corev3.HeaderValueOption {
+	appendAction := corev3.HeaderValueOption_ADD_IF_ABSENT
+	if securityHeaders.Overwrite {
+		appendAction = corev3.HeaderValueOption_OVERWRITE_IF_EXISTS_OR_ADD
+	}
+
+	headerValueOptions := make([]*corev3.HeaderValueOption, 0, len(securityHeaders.Headers))
+	for _, header := range securityHeaders.Headers {
+		headerValueOptions = append(headerValueOptions, &corev3.HeaderValueOption{
+			Header: &corev3.HeaderValue{
+				Key:   header.Name,
+				Value: header.Value,
+			},
+			AppendAction: appendAction,
+		})
+	}
+	return headerValueOptions
+}
+
 func buildXdsAddedHeaders(headersToAdd []ir.AddHeader) []*corev3.HeaderValueOption {
 	headerValueOptions := []*corev3.HeaderValueOption{}
 
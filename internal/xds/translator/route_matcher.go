@@ -0,0 +1,280 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package translator
+
+import (
+	"sort"
+	"strings"
+
+	cncfv3 "github.com/cncf/xds/go/xds/core/v3"
+	matcherv3 "github.com/cncf/xds/go/xds/type/matcher/v3"
+	routev3 "github.com/envoyproxy/go-control-plane/envoy/config/route/v3"
+	envoymatcherv3 "github.com/envoyproxy/go-control-plane/envoy/type/matcher/v3"
+	resourcev3 "github.com/envoyproxy/go-control-plane/pkg/resource/v3"
+	"google.golang.org/protobuf/types/known/anypb"
+
+	"github.com/envoyproxy/gateway/internal/ir"
+	"github.com/envoyproxy/gateway/internal/utils/protocov"
+	"github.com/envoyproxy/gateway/internal/xds/types"
+)
+
+const (
+	// pathHeader and methodHeader are the pseudo-headers the matcher trees are indexed by.
+	pathHeader   = ":path"
+	methodHeader = ":method"
+)
+
+// processRouteMatcherTrees replaces the routes of the virtual hosts with at least the
+// minimum number of routes with a matcher tree selecting the routes a request may match
+// by the first segment of its path, then by its method. Each leaf of the tree lists the
+// routes a request reaching it may match, in their order, so that the route matched is
+// the same as when the routes are matched one after the other.
+//
+// The virtual hosts with routes only served by some node groups are left as they are, as
+// their routes are filtered for each group when the snapshots are generated.
+func processRouteMatcherTrees(tCtx *types.ResourceVersionTable, tree *ir.RouteMatcherTree) error {
+	if tree == nil {
+		return nil
+	}
+
+	for _, r := range tCtx.XdsResources[resourcev3.RouteType] {
+		routeConfig := r.(*routev3.RouteConfiguration)
+		for _, vHost := range routeConfig.VirtualHosts {
+			if len(vHost.Routes) < int(tree.MinRoutes) || hasNodeGroupRoute(vHost.Routes) {
+				continue
+			}
+			matcher, err := buildXdsRouteMatcherTree(vHost.Routes)
+			if err != nil {
+				return err
+			}
+			vHost.Matcher = matcher
+			vHost.Routes = nil
+		}
+	}
+	return nil
+}
+
+// hasNodeGroupRoute returns whether any of the routes is only served by some node groups.
+func hasNodeGroupRoute(routes []*routev3.Route) bool {
+	for _, route := range routes {
+		if _, ok := route.GetMetadata().GetFilterMetadata()[types.XdsMetadataNamespace].GetFields()[types.RouteNodeGroupsMetadataKey]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+// indexedRoute is a route with its position in its virtual host, and the keys of the
+// matcher tree it's indexed by.
+type indexedRoute struct {
+	route *routev3.Route
+	index int
+	// segment is the first segment of the paths the route matches, with its leading
+	// slash, or empty if the route may match any path.
+	segment string
+	// method is the method the route matches, or empty if it matches any method.
+	method string
+}
+
+// buildXdsRouteMatcherTree returns the matcher tree of the routes. The requests are
+// dispatched to the routes matching the first segment of their path, with a prefix map on
+// the path, and to the routes matching their method, with an exact map on the method. The
+// routes matching any path or any method are added to all the leaves they may be reached by.
+func buildXdsRouteMatcherTree(routes []*routev3.Route) (*matcherv3.Matcher, error) {
+	segments := map[string][]indexedRoute{}
+	var anyPath []indexedRoute
+	for i, route := range routes {
+		indexed := indexedRoute{
+			route:   route,
+			index:   i,
+			segment: routePathSegment(route.GetMatch()),
+			method:  routeMethod(route.GetMatch()),
+		}
+		if indexed.segment == "" {
+			anyPath = append(anyPath, indexed)
+		} else {
+			segments[indexed.segment] = append(segments[indexed.segment], indexed)
+		}
+	}
+
+	segmentMap := make(map[string]*matcherv3.Matcher_OnMatch, len(segments))
+	for segment, segmentRoutes := range segments {
+		onMatch, err := buildMethodOnMatch(mergeIndexedRoutes(segmentRoutes, anyPath))
+		if err != nil {
+			return nil, err
+		}
+		segmentMap[segment] = onMatch
+	}
+	onNoMatch, err := buildMethodOnMatch(anyPath)
+	if err != nil {
+		return nil, err
+	}
+
+	input, err := headerMatchInput(pathHeader)
+	if err != nil {
+		return nil, err
+	}
+	return &matcherv3.Matcher{
+		MatcherType: &matcherv3.Matcher_MatcherTree_{
+			MatcherTree: &matcherv3.Matcher_MatcherTree{
+				Input: input,
+				TreeType: &matcherv3.Matcher_MatcherTree_PrefixMatchMap{
+					PrefixMatchMap: &matcherv3.Matcher_MatcherTree_MatchMap{Map: segmentMap},
+				},
+			},
+		},
+		OnNoMatch: onNoMatch,
+	}, nil
+}
+
+// buildMethodOnMatch returns the action running the first of the routes matching the
+// request, preceded by an exact map on the method if some of the routes match a method.
+// It returns nil if there are no routes.
+func buildMethodOnMatch(routes []indexedRoute) (*matcherv3.Matcher_OnMatch, error) {
+	if len(routes) == 0 {
+		return nil, nil
+	}
+
+	var anyMethod []indexedRoute
+	methods := map[string][]indexedRoute{}
+	for _, route := range routes {
+		if route.method == "" {
+			anyMethod = append(anyMethod, route)
+		} else {
+			methods[route.method] = append(methods[route.method], route)
+		}
+	}
+	if len(methods) == 0 {
+		return routeListOnMatch(routes)
+	}
+
+	methodMap := make(map[string]*matcherv3.Matcher_OnMatch, len(methods))
+	for method, methodRoutes := range methods {
+		onMatch, err := routeListOnMatch(mergeIndexedRoutes(methodRoutes, anyMethod))
+		if err != nil {
+			return nil, err
+		}
+		methodMap[method] = onMatch
+	}
+	var onNoMatch *matcherv3.Matcher_OnMatch
+	if len(anyMethod) > 0 {
+		var err error
+		if onNoMatch, err = routeListOnMatch(anyMethod); err != nil {
+			return nil, err
+		}
+	}
+
+	input, err := headerMatchInput(methodHeader)
+	if err != nil {
+		return nil, err
+	}
+	return &matcherv3.Matcher_OnMatch{
+		OnMatch: &matcherv3.Matcher_OnMatch_Matcher{
+			Matcher: &matcherv3.Matcher{
+				MatcherType: &matcherv3.Matcher_MatcherTree_{
+					MatcherTree: &matcherv3.Matcher_MatcherTree{
+						Input: input,
+						TreeType: &matcherv3.Matcher_MatcherTree_ExactMatchMap{
+							ExactMatchMap: &matcherv3.Matcher_MatcherTree_MatchMap{Map: methodMap},
+						},
+					},
+				},
+				OnNoMatch: onNoMatch,
+			},
+		},
+	}, nil
+}
+
+// routeListOnMatch returns the action running the first of the routes matching the request.
+func routeListOnMatch(routes []indexedRoute) (*matcherv3.Matcher_OnMatch, error) {
+	routeList := &routev3.RouteList{Routes: make([]*routev3.Route, 0, len(routes))}
+	for _, route := range routes {
+		routeList.Routes = append(routeList.Routes, route.route)
+	}
+	routeListAny, err := protocov.ToAnyWithError(routeList)
+	if err != nil {
+		return nil, err
+	}
+	return &matcherv3.Matcher_OnMatch{
+		OnMatch: &matcherv3.Matcher_OnMatch_Action{
+			Action: &cncfv3.TypedExtensionConfig{
+				Name:        "routes",
+				TypedConfig: routeListAny,
+			},
+		},
+	}, nil
+}
+
+// headerMatchInput returns the input of a matcher tree reading the request header.
+func headerMatchInput(header string) (*cncfv3.TypedExtensionConfig, error) {
+	input, err := anypb.New(&envoymatcherv3.HttpRequestHeaderMatchInput{HeaderName: header})
+	if err != nil {
+		return nil, err
+	}
+	return &cncfv3.TypedExtensionConfig{
+		Name:        "request-headers",
+		TypedConfig: input,
+	}, nil
+}
+
+// mergeIndexedRoutes merges the routes of both lists in the order of their virtual host.
+func mergeIndexedRoutes(a, b []indexedRoute) []indexedRoute {
+	merged := make([]indexedRoute, 0, len(a)+len(b))
+	merged = append(merged, a...)
+	merged = append(merged, b...)
+	sort.Slice(merged, func(i, j int) bool { return merged[i].index < merged[j].index })
+	return merged
+}
+
+// routePathSegment returns the first segment of the paths the route match matches, with
+// its leading slash, or empty if it may match paths with different first segments. A
+// request path starting with the segment followed by a slash, a query or nothing is never
+// dispatched to a longer segment by the prefix map, so that the routes of the segment
+// are always reached by the requests they match.
+func routePathSegment(match *routev3.RouteMatch) string {
+	if match == nil || (match.GetCaseSensitive() != nil && !match.GetCaseSensitive().GetValue()) {
+		return ""
+	}
+
+	var path string
+	switch {
+	case match.GetPath() != "":
+		path = match.GetPath()
+	case match.GetPathSeparatedPrefix() != "":
+		path = match.GetPathSeparatedPrefix()
+	case match.GetPrefix() != "":
+		// A prefix not ending the first segment may match longer segments.
+		path = match.GetPrefix()
+		if strings.Count(path, "/") < 2 {
+			return ""
+		}
+	default:
+		return ""
+	}
+
+	if !strings.HasPrefix(path, "/") {
+		return ""
+	}
+	segment, _, _ := strings.Cut(path[1:], "/")
+	if segment == "" || strings.ContainsAny(segment, "?#") {
+		return ""
+	}
+	return "/" + segment
+}
+
+// routeMethod returns the method the route match requires, or empty if it matches any method.
+func routeMethod(match *routev3.RouteMatch) string {
+	for _, header := range match.GetHeaders() {
+		if header.GetName() != methodHeader || header.GetInvertMatch() {
+			continue
+		}
+		stringMatch := header.GetStringMatch()
+		if exact := stringMatch.GetExact(); exact != "" && !stringMatch.GetIgnoreCase() {
+			return exact
+		}
+	}
+	return ""
+}
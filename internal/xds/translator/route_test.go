@@ -10,6 +10,7 @@ import (
 	"testing"
 
 	routev3 "github.com/envoyproxy/go-control-plane/envoy/config/route/v3"
+	"google.golang.org/protobuf/types/known/wrapperspb"
 	"k8s.io/utils/ptr"
 
 	"github.com/envoyproxy/gateway/internal/ir"
@@ -102,3 +103,57 @@ func Test_buildHashPolicy(t *testing.T) {
 		})
 	}
 }
+
+func Test_routePathSegment(t *testing.T) {
+	tests := []struct {
+		name  string
+		match *routev3.RouteMatch
+		want  string
+	}{
+		{
+			name:  "exact path",
+			match: &routev3.RouteMatch{PathSpecifier: &routev3.RouteMatch_Path{Path: "/users/me"}},
+			want:  "/users",
+		},
+		{
+			name:  "path separated prefix",
+			match: &routev3.RouteMatch{PathSpecifier: &routev3.RouteMatch_PathSeparatedPrefix{PathSeparatedPrefix: "/users"}},
+			want:  "/users",
+		},
+		{
+			name:  "prefix ending the first segment",
+			match: &routev3.RouteMatch{PathSpecifier: &routev3.RouteMatch_Prefix{Prefix: "/users/"}},
+			want:  "/users",
+		},
+		{
+			name:  "prefix within the first segment",
+			match: &routev3.RouteMatch{PathSpecifier: &routev3.RouteMatch_Prefix{Prefix: "/users"}},
+			want:  "",
+		},
+		{
+			name:  "root prefix",
+			match: &routev3.RouteMatch{PathSpecifier: &routev3.RouteMatch_Prefix{Prefix: "/"}},
+			want:  "",
+		},
+		{
+			name:  "root path",
+			match: &routev3.RouteMatch{PathSpecifier: &routev3.RouteMatch_Path{Path: "/"}},
+			want:  "",
+		},
+		{
+			name: "case insensitive path",
+			match: &routev3.RouteMatch{
+				PathSpecifier: &routev3.RouteMatch_Path{Path: "/users/me"},
+				CaseSensitive: wrapperspb.Bool(false),
+			},
+			want: "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := routePathSegment(tt.match); got != tt.want {
+				t.Errorf("routePathSegment() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
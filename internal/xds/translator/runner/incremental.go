@@ -0,0 +1,276 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package runner
+
+import (
+	"maps"
+	"reflect"
+	"slices"
+
+	"github.com/envoyproxy/go-control-plane/pkg/cache/types"
+	cachev3 "github.com/envoyproxy/go-control-plane/pkg/cache/v3"
+	resourcev3 "github.com/envoyproxy/go-control-plane/pkg/resource/v3"
+
+	"github.com/envoyproxy/gateway/internal/ir"
+	xdstypes "github.com/envoyproxy/gateway/internal/xds/types"
+)
+
+// translation is the last xds IR of a key translated successfully, and its result.
+type translation struct {
+	xdsIR  *ir.Xds
+	result *xdstypes.ResourceVersionTable
+	// ports are the last translations of the HTTP listeners of each port alone, which
+	// hold the resources the changed routes of the port replace.
+	ports map[uint32]*translation
+}
+
+// translateChangedRoutes translates the routes of the HTTP listeners of the xds IR which
+// changed since the last translation of the key, and merges the resources translated from
+// them into the resources of the last translation, so that a change of a route only
+// regenerates the listeners, route configurations and clusters of its port. It returns
+// false if the xds IR must be translated in full, which is the case of any other change,
+// or of a change of resources not tracked back to the changed routes.
+func (r *Runner) translateChangedRoutes(key string, val *ir.Xds) (*translation, bool) {
+	previous := r.translations[key]
+	if previous == nil || !r.canTranslateChangedRoutes(previous, val) {
+		return nil, false
+	}
+
+	ports := changedRoutePorts(previous.xdsIR, val)
+	if len(ports) == 0 {
+		return nil, false
+	}
+	for _, l := range val.TCP {
+		if ports[l.Port] {
+			return nil, false
+		}
+	}
+	for _, l := range val.UDP {
+		if ports[l.Port] {
+			return nil, false
+		}
+	}
+
+	current := &translation{xdsIR: val, result: previous.result, ports: previous.portTranslations(val)}
+	for _, port := range slices.Sorted(maps.Keys(ports)) {
+		subset := map[uint32]bool{port: true}
+		// The resources translated from the previous routes of the port are the ones
+		// to remove if they are not translated from the current routes anymore.
+		oldIR, newIR := subsetIR(previous.xdsIR, subset, true), subsetIR(val, subset, true)
+		oldResult, ok := r.portResult(previous, port, oldIR)
+		if !ok {
+			return nil, false
+		}
+		newResult, err := r.newTranslator(newIR).Translate(newIR)
+		if err != nil || !isMergeable(newResult) {
+			return nil, false
+		}
+
+		// The destinations of the other listeners are collected lazily, as they are only
+		// needed once a cluster is removed.
+		var kept, removed map[string]bool
+		removable := func(typeURL resourcev3.Type, name string) bool {
+			switch typeURL {
+			case resourcev3.ListenerType, resourcev3.RouteType, xdstypes.VirtualHostType:
+				return true
+			case resourcev3.ClusterType, resourcev3.EndpointType:
+				if removed == nil {
+					removed, kept = destinationNames(oldIR), destinationNames(subsetIR(val, subset, false))
+				}
+				return removed[name] && !kept[name]
+			}
+			return false
+		}
+
+		if current.result, ok = mergeResources(current.result, oldResult, newResult, removable); !ok {
+			return nil, false
+		}
+		current.ports[port] = &translation{xdsIR: newIR, result: newResult}
+	}
+	r.Logger.V(1).Info("translated the changed routes", "irKey", key, "ports", len(ports))
+	return current, true
+}
+
+// portTranslations returns the translations of the ports of the HTTP listeners of the
+// xds IR, without the ports it doesn't listen on anymore.
+func (t *translation) portTranslations(xdsIR *ir.Xds) map[uint32]*translation {
+	ports := make(map[uint32]*translation, len(t.ports))
+	for _, l := range xdsIR.HTTP {
+		if portTranslation, ok := t.ports[l.Port]; ok {
+			ports[l.Port] = portTranslation
+		}
+	}
+	return ports
+}
+
+// portResult returns the resources translated from the HTTP listeners of the port of
+// the previous translation, whose xds IR is oldIR. They are only translated if the
+// translation of the port alone is not stored, or was stored for another xds IR.
+func (r *Runner) portResult(previous *translation, port uint32, oldIR *ir.Xds) (*xdstypes.ResourceVersionTable, bool) {
+	if stored, ok := previous.ports[port]; ok && reflect.DeepEqual(stored.xdsIR, oldIR) {
+		return stored.result, true
+	}
+	result, err := r.newTranslator(oldIR).Translate(oldIR)
+	if err != nil || !isMergeable(result) {
+		return nil, false
+	}
+	return result, true
+}
+
+// canTranslateChangedRoutes returns whether the xds IR differs from the last translated
+// xds IR in the routes of its HTTP listeners only, and whether the translation of these
+// routes is independent of the translation of the other listeners.
+func (r *Runner) canTranslateChangedRoutes(previous *translation, val *ir.Xds) bool {
+	// The extensions and the patches may change any resource, and the filters delivered
+	// with ECDS are resolved across all the listeners.
+	if r.ExtensionManager != nil || len(val.EnvoyPatchPolicies) > 0 || val.ExtensionConfigDiscovery != nil {
+		return false
+	}
+	if !isMergeable(previous.result) {
+		return false
+	}
+	return reflect.DeepEqual(withoutRoutes(previous.xdsIR), withoutRoutes(val))
+}
+
+// isMergeable returns whether the resources of the translation can be merged by type and
+// name, which isn't the case of the resources scoped to node groups.
+func isMergeable(result *xdstypes.ResourceVersionTable) bool {
+	return result != nil && len(result.NodeScopes) == 0 && len(result.EnvoyPatchPolicyStatuses) == 0
+}
+
+// withoutRoutes returns a shallow copy of the xds IR without the routes of its HTTP listeners.
+func withoutRoutes(xdsIR *ir.Xds) *ir.Xds {
+	out := *xdsIR
+	out.HTTP = make([]*ir.HTTPListener, len(xdsIR.HTTP))
+	for i, l := range xdsIR.HTTP {
+		listener := *l
+		listener.Routes = nil
+		out.HTTP[i] = &listener
+	}
+	return &out
+}
+
+// changedRoutePorts returns the ports of the HTTP listeners whose routes changed. The
+// listeners are translated by port, since the listeners of a port share an xds listener.
+func changedRoutePorts(previous, current *ir.Xds) map[uint32]bool {
+	ports := make(map[uint32]bool)
+	for i, l := range current.HTTP {
+		if !reflect.DeepEqual(previous.HTTP[i].Routes, l.Routes) {
+			ports[l.Port] = true
+		}
+	}
+	return ports
+}
+
+// subsetIR returns a shallow copy of the xds IR with the HTTP listeners of the ports only,
+// or with all the other listeners if in is false.
+func subsetIR(xdsIR *ir.Xds, ports map[uint32]bool, in bool) *ir.Xds {
+	out := *xdsIR
+	out.HTTP = nil
+	for _, l := range xdsIR.HTTP {
+		if ports[l.Port] == in {
+			out.HTTP = append(out.HTTP, l)
+		}
+	}
+	if in {
+		out.TCP, out.UDP = nil, nil
+	}
+	return &out
+}
+
+// destinationNames returns the names of the route destinations of the xds IR, which name
+// the clusters translated from them.
+func destinationNames(xdsIR *ir.Xds) map[string]bool {
+	names := make(map[string]bool)
+	collectDestinationNames(reflect.ValueOf(xdsIR), names)
+	return names
+}
+
+var routeDestinationType = reflect.TypeOf(ir.RouteDestination{})
+
+func collectDestinationNames(v reflect.Value, names map[string]bool) {
+	switch v.Kind() {
+	case reflect.Pointer, reflect.Interface:
+		if !v.IsNil() {
+			collectDestinationNames(v.Elem(), names)
+		}
+	case reflect.Struct:
+		if v.Type() == routeDestinationType {
+			names[v.FieldByName("Name").String()] = true
+		}
+		for i := 0; i < v.NumField(); i++ {
+			collectDestinationNames(v.Field(i), names)
+		}
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			collectDestinationNames(v.Index(i), names)
+		}
+	case reflect.Map:
+		iter := v.MapRange()
+		for iter.Next() {
+			collectDestinationNames(iter.Value(), names)
+		}
+	}
+}
+
+// mergeResources returns a copy of the previous translation with the resources translated
+// from the old subset of the xds IR replaced by the ones translated from the new subset, by
+// type and name. The resources of the old subset missing from the new subset are removed,
+// unless they're not removable, in which case it returns false. The previous translation
+// is left unchanged, as it may still be served.
+func mergeResources(previous, oldSubset, newSubset *xdstypes.ResourceVersionTable,
+	removable func(typeURL resourcev3.Type, name string) bool,
+) (*xdstypes.ResourceVersionTable, bool) {
+	merged := *previous
+	merged.XdsResources = make(xdstypes.XdsResources, len(previous.XdsResources))
+
+	typeURLs := make(map[resourcev3.Type]bool)
+	for typeURL := range previous.XdsResources {
+		typeURLs[typeURL] = true
+	}
+	for typeURL := range newSubset.XdsResources {
+		typeURLs[typeURL] = true
+	}
+
+	for typeURL := range typeURLs {
+		updated := make(map[string]types.Resource, len(newSubset.XdsResources[typeURL]))
+		for _, r := range newSubset.XdsResources[typeURL] {
+			updated[cachev3.GetResourceName(r)] = r
+		}
+		removed := make(map[string]bool)
+		for _, r := range oldSubset.XdsResources[typeURL] {
+			name := cachev3.GetResourceName(r)
+			if _, ok := updated[name]; ok {
+				continue
+			}
+			if !removable(typeURL, name) {
+				return nil, false
+			}
+			removed[name] = true
+		}
+
+		resources := make([]types.Resource, 0, len(previous.XdsResources[typeURL])+len(updated))
+		for _, r := range previous.XdsResources[typeURL] {
+			name := cachev3.GetResourceName(r)
+			if u, ok := updated[name]; ok {
+				resources = append(resources, u)
+				delete(updated, name)
+			} else if !removed[name] {
+				resources = append(resources, r)
+			}
+		}
+		// The added resources keep the order they were translated in.
+		for _, r := range newSubset.XdsResources[typeURL] {
+			if _, ok := updated[cachev3.GetResourceName(r)]; ok {
+				resources = append(resources, r)
+			}
+		}
+		if len(resources) > 0 {
+			merged.XdsResources[typeURL] = resources
+		}
+	}
+	return &merged, true
+}
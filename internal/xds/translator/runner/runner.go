@@ -7,7 +7,9 @@ package runner
 
 import (
 	"context"
+	"fmt"
 	"reflect"
+	"sync"
 
 	ktypes "k8s.io/apimachinery/pkg/types"
 
@@ -18,6 +20,7 @@ import (
 	"github.com/envoyproxy/gateway/internal/ir"
 	"github.com/envoyproxy/gateway/internal/message"
 	"github.com/envoyproxy/gateway/internal/xds/translator"
+	xdstypes "github.com/envoyproxy/gateway/internal/xds/types"
 )
 
 type Config struct {
@@ -30,10 +33,17 @@ type Config struct {
 
 type Runner struct {
 	Config
+
+	// translateMu serializes translations triggered by subscription
+	// updates and by Retranslate.
+	translateMu sync.Mutex
+	// translations holds the last successful translation of each key, which the
+	// changed routes of the next xds IR of the key are translated against.
+	translations map[string]*translation
 }
 
 func New(cfg *Config) *Runner {
-	return &Runner{Config: *cfg}
+	return &Runner{Config: *cfg, translations: make(map[string]*translation)}
 }
 
 func (r *Runner) Name() string {
@@ -50,83 +60,147 @@ func (r *Runner) Start(ctx context.Context) (err error) {
 
 func (r *Runner) subscribeAndTranslate(ctx context.Context) {
 	// Subscribe to resources
-	message.HandleSubscription(message.Metadata{Runner: string(egv1a1.LogComponentXdsTranslatorRunner), Message: "xds-ir"}, r.XdsIR.Subscribe(ctx),
+	message.HandleTracedSubscription(message.Metadata{Runner: string(egv1a1.LogComponentXdsTranslatorRunner), Message: "xds-ir"}, r.XdsIR.Subscribe(ctx),
 		func(update message.Update[string, *ir.Xds], errChan chan error) {
 			r.Logger.Info("received an update")
 			key := update.Key
 			val := update.Value
 
 			if update.Delete {
+				r.forget(key)
 				r.Xds.Delete(key)
-			} else {
-				// Translate to xds resources
-				t := &translator.Translator{
-					FilterOrder: val.FilterOrder,
-				}
-
-				// Set the extension manager if an extension is loaded
-				if r.ExtensionManager != nil {
-					t.ExtensionManager = &r.ExtensionManager
-				}
-
-				// Set the rate limit service URL if global rate limiting is enabled.
-				if r.EnvoyGateway.RateLimit != nil {
-					t.GlobalRateLimit = &translator.GlobalRateLimitSettings{
-						ServiceURL: ratelimit.GetServiceURL(r.Namespace, r.DNSDomain),
-						FailClosed: r.EnvoyGateway.RateLimit.FailClosed,
-					}
-					if r.EnvoyGateway.RateLimit.Timeout != nil {
-						t.GlobalRateLimit.Timeout = r.EnvoyGateway.RateLimit.Timeout.Duration
-					}
-				}
-
-				result, err := t.Translate(val)
-				if err != nil {
-					r.Logger.Error(err, "failed to translate xds ir")
-					errChan <- err
-				}
-
-				// xDS translation is done in a best-effort manner, so the result
-				// may contain partial resources even if there are errors.
-				if result == nil {
-					r.Logger.Info("no xds resources to publish")
-					return
-				}
-
-				// Get all status keys from watchable and save them in the map statusesToDelete.
-				// Iterating through result.EnvoyPatchPolicyStatuses, any valid keys will be removed from statusesToDelete.
-				// Remaining keys will be deleted from watchable before we exit this function.
-				statusesToDelete := make(map[ktypes.NamespacedName]bool)
-				for key := range r.ProviderResources.EnvoyPatchPolicyStatuses.LoadAll() {
-					statusesToDelete[key] = true
-				}
-
-				// Publish EnvoyPatchPolicyStatus
-				for _, e := range result.EnvoyPatchPolicyStatuses {
-					key := ktypes.NamespacedName{
-						Name:      e.Name,
-						Namespace: e.Namespace,
-					}
-					// Skip updating status for policies with empty status
-					// They may have been skipped in this translation because
-					// their target is not found (not relevant)
-					if !(reflect.ValueOf(e.Status).IsZero()) {
-						r.ProviderResources.EnvoyPatchPolicyStatuses.Store(key, e.Status)
-					}
-					delete(statusesToDelete, key)
-				}
-				// Discard the EnvoyPatchPolicyStatuses to reduce memory footprint
-				result.EnvoyPatchPolicyStatuses = nil
-
-				// Publish
-				r.Xds.Store(key, result)
-
-				// Delete all the deletable status keys
-				for key := range statusesToDelete {
-					r.ProviderResources.EnvoyPatchPolicyStatuses.Delete(key)
-				}
+			} else if err := r.translate(update.Context(), key, val); err != nil {
+				errChan <- err
 			}
 		},
 	)
 	r.Logger.Info("subscriber shutting down")
 }
+
+// Retranslate translates the xds IR currently stored for the key again and
+// publishes the result, even if the xds IR has not changed.
+func (r *Runner) Retranslate(key string) error {
+	val, ok := r.XdsIR.Load(key)
+	if !ok || val == nil {
+		return fmt.Errorf("xds ir %s not found", key)
+	}
+
+	r.Logger.Info("retranslating", "irKey", key)
+	return r.translate(context.Background(), key, val)
+}
+
+// translate translates the xds IR into xds resources and publishes them in ctx, which
+// traces their update.
+func (r *Runner) translate(ctx context.Context, key string, val *ir.Xds) error {
+	r.translateMu.Lock()
+	defer r.translateMu.Unlock()
+
+	// Translate to xds resources, only translating the changed routes again if the
+	// other parts of the xds IR did not change.
+	current, ok := r.translateChangedRoutes(key, val)
+	var result *xdstypes.ResourceVersionTable
+	var err error
+	if ok {
+		result = current.result
+	} else {
+		result, err = r.newTranslator(val).Translate(val)
+		if err != nil {
+			r.Logger.Error(err, "failed to translate xds ir")
+		}
+		// The translations of the ports alone are kept, as they're only used for the
+		// xds IR they were translated from.
+		current = &translation{xdsIR: val, result: result}
+		if previous := r.translations[key]; previous != nil {
+			current.ports = previous.portTranslations(val)
+		}
+	}
+	// The partial results of failed translations are translated in full again.
+	if err == nil && result != nil {
+		r.translations[key] = current
+	} else {
+		delete(r.translations, key)
+	}
+	// The shadow translator translates the xds IR once the active output is published.
+	defer r.shadowTranslate(key, val, result, err)
+
+	// xDS translation is done in a best-effort manner, so the result
+	// may contain partial resources even if there are errors.
+	if result == nil {
+		r.Logger.Info("no xds resources to publish")
+		return err
+	}
+
+	// Get all status keys from watchable and save them in the map statusesToDelete.
+	// Iterating through result.EnvoyPatchPolicyStatuses, any valid keys will be removed from statusesToDelete.
+	// Remaining keys will be deleted from watchable before we exit this function.
+	statusesToDelete := make(map[ktypes.NamespacedName]bool)
+	for key := range r.ProviderResources.EnvoyPatchPolicyStatuses.LoadAll() {
+		statusesToDelete[key] = true
+	}
+
+	// Publish EnvoyPatchPolicyStatus
+	for _, e := range result.EnvoyPatchPolicyStatuses {
+		key := ktypes.NamespacedName{
+			Name:      e.Name,
+			Namespace: e.Namespace,
+		}
+		// Skip updating status for policies with empty status
+		// They may have been skipped in this translation because
+		// their target is not found (not relevant)
+		if !(reflect.ValueOf(e.Status).IsZero()) {
+			r.ProviderResources.EnvoyPatchPolicyStatuses.Store(key, e.Status)
+		}
+		delete(statusesToDelete, key)
+	}
+	// Discard the EnvoyPatchPolicyStatuses to reduce memory footprint
+	result.EnvoyPatchPolicyStatuses = nil
+
+	// Publish
+	r.Xds.StoreTraced(ctx, key, result)
+
+	// Delete all the deletable status keys
+	for key := range statusesToDelete {
+		r.ProviderResources.EnvoyPatchPolicyStatuses.Delete(key)
+	}
+
+	return err
+}
+
+// forget drops the last translation of the key once its xds IR is deleted.
+func (r *Runner) forget(key string) {
+	r.translateMu.Lock()
+	defer r.translateMu.Unlock()
+
+	delete(r.translations, key)
+}
+
+// TranslateIR translates the xds IR into xds resources without publishing them
+// nor updating any status, to simulate a change of the resources.
+func (r *Runner) TranslateIR(val *ir.Xds) (*xdstypes.ResourceVersionTable, error) {
+	return r.newTranslator(val).Translate(val)
+}
+
+// newTranslator returns the translator of the xds IR.
+func (r *Runner) newTranslator(val *ir.Xds) *translator.Translator {
+	t := &translator.Translator{
+		FilterOrder: val.FilterOrder,
+	}
+
+	// Set the extension manager if an extension is loaded
+	if r.ExtensionManager != nil {
+		t.ExtensionManager = &r.ExtensionManager
+	}
+
+	// Set the rate limit service URL if global rate limiting is enabled.
+	if r.EnvoyGateway.RateLimit != nil {
+		t.GlobalRateLimit = &translator.GlobalRateLimitSettings{
+			ServiceURL: ratelimit.GetServiceURL(r.Namespace, r.DNSDomain),
+			FailClosed: r.EnvoyGateway.RateLimit.FailClosed,
+		}
+		if r.EnvoyGateway.RateLimit.Timeout != nil {
+			t.GlobalRateLimit.Timeout = r.EnvoyGateway.RateLimit.Timeout.Duration
+		}
+	}
+
+	return t
+}
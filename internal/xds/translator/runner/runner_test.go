@@ -8,19 +8,28 @@ package runner
 import (
 	"context"
 	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 	"time"
 
 	listenerv3 "github.com/envoyproxy/go-control-plane/envoy/config/listener/v3"
+	cachev3 "github.com/envoyproxy/go-control-plane/pkg/cache/v3"
 	resourcev3 "github.com/envoyproxy/go-control-plane/pkg/resource/v3"
+	"github.com/google/go-cmp/cmp"
 	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/testing/protocmp"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/yaml"
 
 	egv1a1 "github.com/envoyproxy/gateway/api/v1alpha1"
 	"github.com/envoyproxy/gateway/internal/envoygateway/config"
 	"github.com/envoyproxy/gateway/internal/extension/types"
 	"github.com/envoyproxy/gateway/internal/ir"
 	"github.com/envoyproxy/gateway/internal/message"
+	xdstypes "github.com/envoyproxy/gateway/internal/xds/types"
 )
 
 func TestRunner(t *testing.T) {
@@ -170,6 +179,145 @@ func TestRunner_withExtensionManager(t *testing.T) {
 	}, time.Second*5, time.Millisecond*50)
 }
 
+func TestTranslateChangedRoutes(t *testing.T) {
+	cfg, err := config.New()
+	require.NoError(t, err)
+	r := New(&Config{Server: *cfg})
+
+	mutations := map[string]func(routes []*ir.HTTPRoute) []*ir.HTTPRoute{
+		"remove-route": func(routes []*ir.HTTPRoute) []*ir.HTTPRoute {
+			return routes[:len(routes)-1]
+		},
+		"rename-route": func(routes []*ir.HTTPRoute) []*ir.HTTPRoute {
+			renamed := *routes[0]
+			renamed.Name += "-renamed"
+			return append([]*ir.HTTPRoute{&renamed}, routes[1:]...)
+		},
+	}
+
+	inputs, err := filepath.Glob("../testdata/in/xds-ir/*.yaml")
+	require.NoError(t, err)
+	translated := 0
+	for _, input := range inputs {
+		content, err := os.ReadFile(input)
+		require.NoError(t, err)
+		previous := &ir.Xds{}
+		require.NoError(t, yaml.Unmarshal(content, previous))
+		if len(previous.HTTP) == 0 || len(previous.HTTP[0].Routes) < 2 {
+			continue
+		}
+		previousResult, err := r.TranslateIR(previous)
+		if err != nil {
+			continue
+		}
+
+		for name, mutate := range mutations {
+			t.Run(strings.TrimSuffix(filepath.Base(input), ".yaml")+"/"+name, func(t *testing.T) {
+				current := previous.DeepCopy()
+				current.HTTP[0].Routes = mutate(current.HTTP[0].Routes)
+				want, err := r.TranslateIR(current)
+				if err != nil {
+					return
+				}
+
+				r.translations["test"] = &translation{xdsIR: previous, result: previousResult}
+				got, ok := r.translateChangedRoutes("test", current)
+				if !ok {
+					return
+				}
+				translated++
+				requireSameResources(t, want, got.result)
+
+				// The next change of the routes of the port is merged into the stored
+				// translation of the port.
+				r.translations["test"] = got
+				require.NotNil(t, got.ports[current.HTTP[0].Port])
+				again, ok := r.translateChangedRoutes("test", previous)
+				require.True(t, ok)
+				requireSameResources(t, previousResult, again.result)
+			})
+		}
+	}
+	require.Positive(t, translated)
+}
+
+func TestShadowTranslate(t *testing.T) {
+	cfg, err := config.New()
+	require.NoError(t, err)
+	cfg.EnvoyGateway.ShadowTranslation = &egv1a1.EnvoyGatewayShadowTranslation{
+		Translator: egv1a1.ShadowTranslatorFull,
+	}
+	r := New(&Config{Server: *cfg})
+
+	route := func(name, path string) *ir.HTTPRoute {
+		return &ir.HTTPRoute{
+			Name:      name,
+			PathMatch: &ir.StringMatch{Exact: &path},
+			Destination: &ir.RouteDestination{
+				Name: name + "-dest",
+				Settings: []*ir.DestinationSetting{{
+					Endpoints: []*ir.DestinationEndpoint{{Host: "10.11.12.13", Port: 8080}},
+				}},
+			},
+		}
+	}
+	xdsIR := &ir.Xds{
+		HTTP: []*ir.HTTPListener{{
+			CoreListenerDetails: ir.CoreListenerDetails{Name: "test", Address: "0.0.0.0", Port: 80},
+			Hostnames:           []string{"example.com"},
+			Routes:              []*ir.HTTPRoute{route("first", "/first"), route("second", "/second")},
+		}},
+	}
+	active, err := r.TranslateIR(xdsIR)
+	require.NoError(t, err)
+	require.Equal(t, shadowResultMatch, r.shadowTranslate("test", xdsIR, active, nil))
+
+	// The translation of the changed routes only matches the full translation.
+	r.translations["test"] = &translation{xdsIR: xdsIR, result: active}
+	changed := xdsIR.DeepCopy()
+	changed.HTTP[0].Routes[1] = route("third", "/third")
+	changedRoutes, ok := r.translateChangedRoutes("test", changed)
+	require.True(t, ok)
+	incremental := changedRoutes.result
+	require.Equal(t, shadowResultMatch, r.shadowTranslate("test", changed, incremental, nil))
+
+	// A stale resource left by the active translator is reported.
+	require.Equal(t, shadowResultMismatch, r.shadowTranslate("test", changed, active, nil))
+	require.Equal(t, map[resourcev3.Type][]string{
+		resourcev3.ClusterType:  {"second-dest", "third-dest"},
+		resourcev3.EndpointType: {"second-dest", "third-dest"},
+		resourcev3.RouteType:    {"test"},
+	}, diffResources(active, incremental))
+
+	require.Equal(t, shadowResultError, r.shadowTranslate("test", changed, incremental, fmt.Errorf("failed")))
+}
+
+// requireSameResources requires the translations to hold the same resources, in any order.
+func requireSameResources(t *testing.T, want, got *xdstypes.ResourceVersionTable) {
+	t.Helper()
+	typeURLs := make(map[resourcev3.Type]bool)
+	for typeURL := range want.XdsResources {
+		typeURLs[typeURL] = true
+	}
+	for typeURL := range got.XdsResources {
+		typeURLs[typeURL] = true
+	}
+	for typeURL := range typeURLs {
+		wantResources := make(map[string]proto.Message)
+		for _, r := range want.XdsResources[typeURL] {
+			wantResources[cachev3.GetResourceName(r)] = r
+		}
+		require.Len(t, got.XdsResources[typeURL], len(wantResources), typeURL)
+		for _, r := range got.XdsResources[typeURL] {
+			name := cachev3.GetResourceName(r)
+			require.Contains(t, wantResources, name, typeURL)
+			// The typed configs are compared once unpacked, since their maps are serialized
+			// in any order.
+			require.Empty(t, cmp.Diff(wantResources[name], r, protocmp.Transform()), "%s %s", typeURL, name)
+		}
+	}
+}
+
 type extManagerMock struct {
 	types.Manager
 }
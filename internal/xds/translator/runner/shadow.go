@@ -0,0 +1,140 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package runner
+
+import (
+	"sort"
+
+	cachev3 "github.com/envoyproxy/go-control-plane/pkg/cache/v3"
+	resourcev3 "github.com/envoyproxy/go-control-plane/pkg/resource/v3"
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/testing/protocmp"
+
+	egv1a1 "github.com/envoyproxy/gateway/api/v1alpha1"
+	"github.com/envoyproxy/gateway/internal/ir"
+	"github.com/envoyproxy/gateway/internal/metrics"
+	xdstypes "github.com/envoyproxy/gateway/internal/xds/types"
+)
+
+const (
+	// shadowResultMatch is the result of the shadow translations translating the same
+	// resources as the active translator.
+	shadowResultMatch = "match"
+	// shadowResultMismatch is the result of the shadow translations translating
+	// resources differently from the active translator.
+	shadowResultMismatch = "mismatch"
+	// shadowResultError is the result of the shadow translations failing while the
+	// active translation did not, or the other way around.
+	shadowResultError = "error"
+)
+
+var (
+	shadowTranslationTotal = metrics.NewCounter(
+		"xds_shadow_translation_total",
+		"Total number of xds IRs translated by the shadow translator, by result of the comparison with the active translator.",
+	)
+
+	shadowTranslationDiffResourcesTotal = metrics.NewCounter(
+		"xds_shadow_translation_diff_resources_total",
+		"Total number of xds resources translated differently by the shadow translator and the active translator.",
+	)
+
+	resultLabel  = metrics.NewLabel("result")
+	typeURLLabel = metrics.NewLabel("typeURL")
+)
+
+// shadowTranslators translate the xds IRs as the shadow translators, by name.
+var shadowTranslators = map[egv1a1.ShadowTranslator]func(r *Runner, val *ir.Xds) (*xdstypes.ResourceVersionTable, error){
+	egv1a1.ShadowTranslatorFull: func(r *Runner, val *ir.Xds) (*xdstypes.ResourceVersionTable, error) {
+		return r.newTranslator(val).Translate(val)
+	},
+}
+
+// shadowTranslate translates the xds IR with the shadow translator, if any, and compares
+// its output with the output of the active translator, which is published. The output of
+// the shadow translator is never published. It returns the result of the comparison, or
+// an empty string without a shadow translator.
+func (r *Runner) shadowTranslate(key string, val *ir.Xds, active *xdstypes.ResourceVersionTable, activeErr error) string {
+	shadow := r.EnvoyGateway.ShadowTranslation
+	if shadow == nil {
+		return ""
+	}
+	translate, ok := shadowTranslators[shadow.Translator]
+	if !ok {
+		return ""
+	}
+
+	result, err := translate(r, val)
+	if (err != nil) != (activeErr != nil) {
+		shadowTranslationTotal.With(resultLabel.Value(shadowResultError)).Increment()
+		r.Logger.Info("shadow translation failed differently", "irKey", key,
+			"translator", shadow.Translator, "error", err, "activeError", activeErr)
+		return shadowResultError
+	}
+
+	diff := diffResources(active, result)
+	if len(diff) == 0 {
+		shadowTranslationTotal.With(resultLabel.Value(shadowResultMatch)).Increment()
+		return shadowResultMatch
+	}
+	for typeURL, names := range diff {
+		shadowTranslationDiffResourcesTotal.With(typeURLLabel.Value(typeURL)).Add(float64(len(names)))
+	}
+	shadowTranslationTotal.With(resultLabel.Value(shadowResultMismatch)).Increment()
+	r.Logger.Info("shadow translation differs", "irKey", key, "translator", shadow.Translator,
+		"resources", diff)
+	return shadowResultMismatch
+}
+
+// diffResources returns the names of the resources of each type which are added, removed
+// or changed from a translation to the other, sorted. The resources are compared by type
+// and name, in any order, with their typed configs compared once unpacked, since their
+// maps are serialized in any order.
+func diffResources(a, b *xdstypes.ResourceVersionTable) map[resourcev3.Type][]string {
+	byName := func(t *xdstypes.ResourceVersionTable, typeURL resourcev3.Type) map[string]proto.Message {
+		resources := make(map[string]proto.Message)
+		if t == nil {
+			return resources
+		}
+		for _, r := range t.XdsResources[typeURL] {
+			resources[cachev3.GetResourceName(r)] = r
+		}
+		return resources
+	}
+
+	typeURLs := make(map[resourcev3.Type]bool)
+	for _, t := range []*xdstypes.ResourceVersionTable{a, b} {
+		if t == nil {
+			continue
+		}
+		for typeURL := range t.XdsResources {
+			typeURLs[typeURL] = true
+		}
+	}
+
+	diff := make(map[resourcev3.Type][]string)
+	for typeURL := range typeURLs {
+		resourcesA, resourcesB := byName(a, typeURL), byName(b, typeURL)
+		var names []string
+		for name, resourceA := range resourcesA {
+			resourceB, ok := resourcesB[name]
+			if !ok || !cmp.Equal(resourceA, resourceB, protocmp.Transform()) {
+				names = append(names, name)
+			}
+		}
+		for name := range resourcesB {
+			if _, ok := resourcesA[name]; !ok {
+				names = append(names, name)
+			}
+		}
+		if len(names) > 0 {
+			sort.Strings(names)
+			diff[typeURL] = names
+		}
+	}
+	return diff
+}
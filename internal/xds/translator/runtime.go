@@ -0,0 +1,53 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package translator
+
+import (
+	"strconv"
+
+	runtimev3 "github.com/envoyproxy/go-control-plane/envoy/service/runtime/v3"
+	resourcev3 "github.com/envoyproxy/go-control-plane/pkg/resource/v3"
+	"google.golang.org/protobuf/types/known/structpb"
+
+	"github.com/envoyproxy/gateway/internal/ir"
+	"github.com/envoyproxy/gateway/internal/xds/bootstrap"
+	"github.com/envoyproxy/gateway/internal/xds/types"
+)
+
+// processRuntimeDiscovery adds the runtime layer delivered to the proxies with RTDS.
+// The layer is added even without values, since the proxies wait for it to be fetched
+// before they initialize.
+func processRuntimeDiscovery(tCtx *types.ResourceVersionTable, runtimeDiscovery *ir.RuntimeDiscovery) error {
+	if runtimeDiscovery == nil {
+		return nil
+	}
+
+	layer := &structpb.Struct{Fields: make(map[string]*structpb.Value, len(runtimeDiscovery.Values))}
+	for _, value := range runtimeDiscovery.Values {
+		layer.Fields[value.Key] = buildRuntimeValue(value.Value)
+	}
+
+	return tCtx.AddXdsResource(resourcev3.RuntimeType, &runtimev3.Runtime{
+		Name:  bootstrap.RuntimeLayerName,
+		Layer: layer,
+	})
+}
+
+// buildRuntimeValue returns the value of a runtime key, which is a boolean or a number
+// if it parses as one, so that the feature flags and the numeric settings of the
+// proxies are set, or a string otherwise.
+func buildRuntimeValue(value string) *structpb.Value {
+	switch value {
+	case "true":
+		return structpb.NewBoolValue(true)
+	case "false":
+		return structpb.NewBoolValue(false)
+	}
+	if n, err := strconv.ParseFloat(value, 64); err == nil {
+		return structpb.NewNumberValue(n)
+	}
+	return structpb.NewStringValue(value)
+}
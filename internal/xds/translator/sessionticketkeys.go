@@ -0,0 +1,36 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package translator
+
+import (
+	"github.com/envoyproxy/gateway/internal/ir"
+	"github.com/envoyproxy/gateway/internal/xds/types"
+)
+
+// processSessionTicketKeys passes the session ticket keys referenced by the TLS listeners
+// on to the xds server, which generates, rotates and serves them as SDS secrets.
+func processSessionTicketKeys(tCtx *types.ResourceVersionTable, xdsIR *ir.Xds) {
+	seen := make(map[string]bool)
+	add := func(tlsConfig *ir.TLSConfig) {
+		if tlsConfig == nil || tlsConfig.SessionTicketKeys == nil || seen[tlsConfig.SessionTicketKeys.Name] {
+			return
+		}
+		seen[tlsConfig.SessionTicketKeys.Name] = true
+		tCtx.SessionTicketKeys = append(tCtx.SessionTicketKeys, tlsConfig.SessionTicketKeys)
+	}
+
+	for _, httpListener := range xdsIR.HTTP {
+		add(httpListener.TLS)
+	}
+	for _, tcpListener := range xdsIR.TCP {
+		add(tcpListener.TLS)
+		for _, route := range tcpListener.Routes {
+			if route.TLS != nil {
+				add(route.TLS.Terminate)
+			}
+		}
+	}
+}
@@ -0,0 +1,124 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package translator
+
+import (
+	accesslogv3 "github.com/envoyproxy/go-control-plane/envoy/config/accesslog/v3"
+	corev3 "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	listenerv3 "github.com/envoyproxy/go-control-plane/envoy/config/listener/v3"
+	grpcaccesslog "github.com/envoyproxy/go-control-plane/envoy/extensions/access_loggers/grpc/v3"
+	typev3 "github.com/envoyproxy/go-control-plane/envoy/type/v3"
+	resourcev3 "github.com/envoyproxy/go-control-plane/pkg/resource/v3"
+	"github.com/envoyproxy/go-control-plane/pkg/wellknown"
+	"google.golang.org/protobuf/types/known/anypb"
+
+	"github.com/envoyproxy/gateway/internal/ir"
+	"github.com/envoyproxy/gateway/internal/utils/protocov"
+	"github.com/envoyproxy/gateway/internal/xds/bootstrap"
+	"github.com/envoyproxy/gateway/internal/xds/types"
+)
+
+// trafficRecordingRuntimeKey is the runtime key the sampling percentage of the recorded
+// requests can be overridden with.
+const trafficRecordingRuntimeKey = "envoy_gateway.traffic_recording.sampling"
+
+// processTrafficRecording passes the traffic recording settings on to the xds server, which
+// records the requests of the proxies, and adds the access log sending a sample of the
+// requests to the xds server to all the HTTP connection managers.
+func processTrafficRecording(tCtx *types.ResourceVersionTable, trafficRecording *ir.TrafficRecording) error {
+	if trafficRecording == nil {
+		return nil
+	}
+
+	tCtx.TrafficRecording = trafficRecording
+
+	al, err := buildTrafficRecordingAccessLog(trafficRecording)
+	if err != nil {
+		return err
+	}
+	for _, r := range tCtx.XdsResources[resourcev3.ListenerType] {
+		listener := r.(*listenerv3.Listener)
+		filterChains := listener.FilterChains
+		if listener.DefaultFilterChain != nil {
+			filterChains = append(filterChains, listener.DefaultFilterChain)
+		}
+		for _, filterChain := range filterChains {
+			if err := addAccessLogToHCM(filterChain, al); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// buildTrafficRecordingAccessLog returns the access log sending a sample of the requests
+// to the xds server, along with the recorded headers.
+func buildTrafficRecordingAccessLog(trafficRecording *ir.TrafficRecording) (*accesslogv3.AccessLog, error) {
+	alCfg := &grpcaccesslog.HttpGrpcAccessLogConfig{
+		CommonConfig: &grpcaccesslog.CommonGrpcAccessLogConfig{
+			LogName: types.TrafficRecordingLogName,
+			GrpcService: &corev3.GrpcService{
+				TargetSpecifier: &corev3.GrpcService_EnvoyGrpc_{
+					EnvoyGrpc: &corev3.GrpcService_EnvoyGrpc{
+						ClusterName: bootstrap.XdsClusterName,
+					},
+				},
+			},
+			TransportApiVersion: corev3.ApiVersion_V3,
+		},
+		AdditionalRequestHeadersToLog: trafficRecording.Headers,
+	}
+	alAny, err := anypb.New(alCfg)
+	if err != nil {
+		return nil, err
+	}
+
+	return &accesslogv3.AccessLog{
+		Name: wellknown.HTTPGRPCAccessLog,
+		ConfigType: &accesslogv3.AccessLog_TypedConfig{
+			TypedConfig: alAny,
+		},
+		Filter: &accesslogv3.AccessLogFilter{
+			FilterSpecifier: &accesslogv3.AccessLogFilter_RuntimeFilter{
+				RuntimeFilter: &accesslogv3.RuntimeFilter{
+					RuntimeKey: trafficRecordingRuntimeKey,
+					PercentSampled: &typev3.FractionalPercent{
+						Numerator:   trafficRecording.SamplingPercentage,
+						Denominator: typev3.FractionalPercent_HUNDRED,
+					},
+					UseIndependentRandomness: true,
+				},
+			},
+		},
+	}, nil
+}
+
+// addAccessLogToHCM adds the access log to the HTTP connection manager of the filter
+// chain, if any.
+func addAccessLogToHCM(filterChain *listenerv3.FilterChain, al *accesslogv3.AccessLog) error {
+	for i, filter := range filterChain.Filters {
+		if filter.Name != wellknown.HTTPConnectionManager {
+			continue
+		}
+		hcm, err := findHCMinFilterChain(filterChain)
+		if err != nil {
+			return err
+		}
+		hcm.AccessLog = append(hcm.AccessLog, al)
+
+		hcmAny, err := protocov.ToAnyWithError(hcm)
+		if err != nil {
+			return err
+		}
+		filterChain.Filters[i] = &listenerv3.Filter{
+			Name: wellknown.HTTPConnectionManager,
+			ConfigType: &listenerv3.Filter_TypedConfig{
+				TypedConfig: hcmAny,
+			},
+		}
+	}
+	return nil
+}
@@ -105,10 +105,62 @@ func (t *Translator) Translate(xdsIR *ir.Xds) (*types.ResourceVersionTable, erro
 		errs = errors.Join(errs, err)
 	}
 
+	if err := processWarming(tCtx, xdsIR.Warming); err != nil {
+		errs = errors.Join(errs, err)
+	}
+
+	// The drain deferral is applied by the xds server, which knows the served listeners.
+	tCtx.DeferDrains = xdsIR.DeferDrains
+	tCtx.XdsServerPort = xdsIR.XdsServerPort
+	// The configuration is checkpointed by the xds server, which knows the acknowledged snapshots.
+	tCtx.ConfigCheckpoint = xdsIR.ConfigCheckpoint
+
+	processLoadReporting(tCtx, xdsIR.LoadReporting)
+
+	if err := processTrafficRecording(tCtx, xdsIR.TrafficRecording); err != nil {
+		errs = errors.Join(errs, err)
+	}
+
+	processSessionTicketKeys(tCtx, xdsIR)
+
+	if err := processRuntimeDiscovery(tCtx, xdsIR.RuntimeDiscovery); err != nil {
+		errs = errors.Join(errs, err)
+	}
+
 	if err := processJSONPatches(tCtx, xdsIR.EnvoyPatchPolicies); err != nil {
 		errs = errors.Join(errs, err)
 	}
 
+	// The matcher trees are built once the routes are patched, so that the patches
+	// keep targeting the routes by their index in their virtual host.
+	if err := processRouteMatcherTrees(tCtx, xdsIR.RouteMatcherTree); err != nil {
+		errs = errors.Join(errs, err)
+	}
+
+	// The endpoints are moved to LEDS once patched, so that the patches keep targeting
+	// the endpoints of the cluster load assignments.
+	if err := processLocalityEndpointDiscovery(tCtx, xdsIR.LocalityEndpointDiscovery); err != nil {
+		errs = errors.Join(errs, err)
+	}
+
+	// The virtual hosts are moved to VHDS once patched and matched with their matcher
+	// trees, so that the patches keep targeting the virtual hosts of the route configurations.
+	if err := processVirtualHostDiscovery(tCtx, xdsIR.VirtualHostDiscovery); err != nil {
+		errs = errors.Join(errs, err)
+	}
+
+	// The filter configurations are moved to ECDS once patched, so that the patches keep
+	// targeting the filters of the HTTP connection managers.
+	if err := processExtensionConfigDiscovery(tCtx, xdsIR.ExtensionConfigDiscovery); err != nil {
+		errs = errors.Join(errs, err)
+	}
+
+	// The listeners are drained once patched, so that the patches keep targeting the
+	// route configurations of their HTTP connection managers.
+	if err := processDrain(tCtx, xdsIR.Drain); err != nil {
+		errs = errors.Join(errs, err)
+	}
+
 	if err := processClusterForAccessLog(tCtx, xdsIR.AccessLog, xdsIR.Metrics); err != nil {
 		errs = errors.Join(errs, err)
 	}
@@ -129,6 +181,34 @@ func (t *Translator) Translate(xdsIR *ir.Xds) (*types.ResourceVersionTable, erro
 func findIRListenersByXDSListener(xdsIR *ir.Xds, listener *listenerv3.Listener) []ir.Listener {
 	ret := []ir.Listener{}
 
+	if internal := listener.Address.GetEnvoyInternalAddress(); internal != nil {
+		for _, l := range xdsIR.HTTP {
+			if l.Internal && l.Name == internal.GetServerListenerName() {
+				ret = append(ret, l)
+			}
+		}
+		for _, l := range xdsIR.TCP {
+			if l.Internal && l.Name == internal.GetServerListenerName() {
+				ret = append(ret, l)
+			}
+		}
+		return ret
+	}
+
+	if pipe := listener.Address.GetPipe(); pipe != nil {
+		for _, l := range xdsIR.HTTP {
+			if l.GetUnixSocketPath() != nil && *l.GetUnixSocketPath() == pipe.Path {
+				ret = append(ret, l)
+			}
+		}
+		for _, l := range xdsIR.TCP {
+			if l.GetUnixSocketPath() != nil && *l.GetUnixSocketPath() == pipe.Path {
+				ret = append(ret, l)
+			}
+		}
+		return ret
+	}
+
 	addr := listener.Address.GetSocketAddress()
 	if addr == nil {
 		return ret
@@ -208,7 +288,7 @@ func (t *Translator) processHTTPListenerXdsTranslation(
 		)
 
 		// Search for an existing TCP listener on the same address + port combination.
-		tcpXDSListener = findXdsListenerByHostPort(tCtx, httpListener.Address, httpListener.Port, corev3.SocketAddress_TCP)
+		tcpXDSListener = findXdsTCPListener(tCtx, &httpListener.CoreListenerDetails)
 		xdsListenerOnSameAddressPortExists = tcpXDSListener != nil
 		tlsEnabled = httpListener.TLS != nil
 
@@ -216,8 +296,9 @@ func (t *Translator) processHTTPListenerXdsTranslation(
 		// If no existing listener exists, create a new one.
 		case !xdsListenerOnSameAddressPortExists:
 			// Create a new UDP(QUIC) listener for HTTP3 traffic if HTTP3 is enabled
-			if http3Enabled {
-				quicXDSListener = buildXdsQuicListener(httpListener.Name, httpListener.Address, httpListener.Port, accessLog)
+			// HTTP3 is not supported on internal listeners and unix domain sockets.
+			if http3Enabled && !httpListener.Internal && httpListener.UnixSocketPath == nil {
+				quicXDSListener = buildXdsQuicListener(httpListener.Name, httpListener.Address, httpListener.Port, httpListener.IPFamily, accessLog)
 				if err = tCtx.AddXdsResource(resourcev3.ListenerType, quicXDSListener); err != nil {
 					errs = errors.Join(errs, err)
 					continue
@@ -225,7 +306,7 @@ func (t *Translator) processHTTPListenerXdsTranslation(
 			}
 
 			// Create a new TCP listener for HTTP1/HTTP2 traffic.
-			tcpXDSListener = buildXdsTCPListener(httpListener.Name, httpListener.Address, httpListener.Port, httpListener.TCPKeepalive, httpListener.Connection, accessLog)
+			tcpXDSListener = buildXdsTCPListener(&httpListener.CoreListenerDetails, httpListener.TCPKeepalive, httpListener.Connection, accessLog, egv1a1.ProxyAccessLogProtocolHTTP)
 			if err = tCtx.AddXdsResource(resourcev3.ListenerType, tcpXDSListener); err != nil {
 				errs = errors.Join(errs, err)
 				continue
@@ -320,6 +401,9 @@ func (t *Translator) processHTTPListenerXdsTranslation(
 				}
 			}
 
+			if err = processNoSNIMatchCluster(tCtx, httpListener.TLS.NoSNIMatch); err != nil {
+				errs = errors.Join(errs, err)
+			}
 		}
 
 		// add http route client certs
@@ -448,7 +532,14 @@ func (t *Translator) addRouteToRouteConfig(
 			}
 			xdsRoute.ResponseHeadersToAdd = append(xdsRoute.ResponseHeadersToAdd, http3AltSvcHeader)
 		}
+
+		// The requests of the clients already assigned to a bucket of an experiment
+		// are routed to that bucket before the others are assigned one.
+		vHost.Routes = append(vHost.Routes, buildXdsExperimentRoutes(httpRoute, xdsRoute)...)
 		vHost.Routes = append(vHost.Routes, xdsRoute)
+		if fallbackRoute := buildXdsJWTRouteMatchFallbackRoute(httpRoute, xdsRoute); fallbackRoute != nil {
+			vHost.Routes = append(vHost.Routes, fallbackRoute)
+		}
 
 		if httpRoute.Destination != nil {
 			ea := &ExtraArgs{
@@ -469,6 +560,10 @@ func (t *Translator) addRouteToRouteConfig(
 			}
 		}
 
+		if err = addXdsExperimentClusters(tCtx, httpRoute); err != nil {
+			errs = errors.Join(errs, err)
+		}
+
 		if httpRoute.Mirrors != nil {
 			for _, mirrorDest := range httpRoute.Mirrors {
 				if err = addXdsCluster(tCtx, &xdsClusterArgs{
@@ -563,9 +658,9 @@ func (t *Translator) processTCPListenerXdsTranslation(
 	var errs error
 	for _, tcpListener := range tcpListeners {
 		// Search for an existing listener, if it does not exist, create one.
-		xdsListener := findXdsListenerByHostPort(tCtx, tcpListener.Address, tcpListener.Port, corev3.SocketAddress_TCP)
+		xdsListener := findXdsTCPListener(tCtx, &tcpListener.CoreListenerDetails)
 		if xdsListener == nil {
-			xdsListener = buildXdsTCPListener(tcpListener.Name, tcpListener.Address, tcpListener.Port, tcpListener.TCPKeepalive, tcpListener.Connection, accesslog)
+			xdsListener = buildXdsTCPListener(&tcpListener.CoreListenerDetails, tcpListener.TCPKeepalive, tcpListener.Connection, accesslog, egv1a1.ProxyAccessLogProtocolTCP)
 			if err := tCtx.AddXdsResource(resourcev3.ListenerType, xdsListener); err != nil {
 				// skip this listener if failed to add xds listener to the
 				errs = errors.Join(errs, err)
@@ -605,8 +700,11 @@ func (t *Translator) processTCPListenerXdsTranslation(
 						errs = errors.Join(errs, err)
 					}
 				}
+				if err := processNoSNIMatchCluster(tCtx, route.TLS.Terminate.NoSNIMatch); err != nil {
+					errs = errors.Join(errs, err)
+				}
 			}
-			if err := addXdsTCPFilterChain(xdsListener, route, route.Destination.Name, accesslog, tcpListener.Timeout, tcpListener.Connection); err != nil {
+			if err := addXdsTCPFilterChain(xdsListener, listenerStatSuffix(&tcpListener.CoreListenerDetails), route, route.Destination.Name, accesslog, tcpListener.Timeout, tcpListener.Connection); err != nil {
 				errs = errors.Join(errs, err)
 			}
 		}
@@ -651,6 +749,36 @@ func processUDPListenerXdsTranslation(
 	return errs
 }
 
+// findXdsTCPListener finds a xds TCP listener with the same name for internal listeners, bound to the
+// same unix domain socket path, or to the same address and port as the IR listener, and returns nil
+// if there is no match.
+func findXdsTCPListener(tCtx *types.ResourceVersionTable, listenerDetails *ir.CoreListenerDetails) *listenerv3.Listener {
+	// Internal listeners are addressed by name.
+	if listenerDetails.Internal {
+		return findXdsListener(tCtx, listenerDetails.Name)
+	}
+	if listenerDetails.UnixSocketPath != nil {
+		return findXdsListenerByPath(tCtx, *listenerDetails.UnixSocketPath)
+	}
+	return findXdsListenerByHostPort(tCtx, listenerDetails.Address, listenerDetails.Port, corev3.SocketAddress_TCP)
+}
+
+// findXdsListenerByPath finds a xds listener bound to the unix domain socket path, and returns nil if there is no match.
+func findXdsListenerByPath(tCtx *types.ResourceVersionTable, path string) *listenerv3.Listener {
+	if tCtx == nil || tCtx.XdsResources == nil || tCtx.XdsResources[resourcev3.ListenerType] == nil {
+		return nil
+	}
+
+	for _, r := range tCtx.XdsResources[resourcev3.ListenerType] {
+		listener := r.(*listenerv3.Listener)
+		if pipe := listener.GetAddress().GetPipe(); pipe != nil && pipe.Path == path {
+			return listener
+		}
+	}
+
+	return nil
+}
+
 // findXdsListenerByHostPort finds a xds listener with the same address, port and protocol, and returns nil if there is no match.
 func findXdsListenerByHostPort(tCtx *types.ResourceVersionTable, address string, port uint32,
 	protocol corev3.SocketAddress_Protocol,
@@ -736,6 +864,15 @@ func findXdsEndpoint(tCtx *types.ResourceVersionTable, name string) *endpointv3.
 }
 
 // processXdsCluster processes xds cluster with args per route.
+// processNoSNIMatchCluster adds the cluster of the default backend the connections whose
+// SNI matches no hostname are proxied to, if any.
+func processNoSNIMatchCluster(tCtx *types.ResourceVersionTable, noSNIMatch *ir.NoSNIMatch) error {
+	if noSNIMatch == nil || noSNIMatch.Destination == nil {
+		return nil
+	}
+	return createExtServiceXDSCluster(noSNIMatch.Destination, nil, tCtx)
+}
+
 func processXdsCluster(tCtx *types.ResourceVersionTable, route clusterArgs, extras *ExtraArgs) error {
 	if err := addXdsCluster(tCtx, route.asClusterArgs(extras)); err != nil && !errors.Is(err, ErrXdsClusterExists) {
 		return err
@@ -790,12 +927,15 @@ func addXdsCluster(tCtx *types.ResourceVersionTable, args *xdsClusterArgs) error
 			}
 		}
 	}
+	switch args.endpointType {
 	// Use EDS for static endpoints
-	if args.endpointType == EndpointTypeStatic {
+	case EndpointTypeStatic:
 		if err := tCtx.AddXdsResource(resourcev3.EndpointType, xdsEndpoints); err != nil {
 			return err
 		}
-	} else {
+	// Original destination clusters don't have endpoints
+	case EndpointTypeOriginalDst:
+	default:
 		xdsCluster.LoadAssignment = xdsEndpoints
 	}
 	if err := tCtx.AddXdsResource(resourcev3.ClusterType, xdsCluster); err != nil {
@@ -170,6 +170,38 @@ func TestTranslateXds(t *testing.T) {
 				require.Equal(t, requireTestDataOutFile(t, "xds-ir", inputFileName+".secrets.yaml"), requireResourcesToYAMLString(t, secrets))
 			}
 
+			lbEndpoints, ok := tCtx.XdsResources[xtypes.LbEndpointType]
+			if ok && len(lbEndpoints) > 0 {
+				if *overrideTestData {
+					require.NoError(t, file.Write(requireResourcesToYAMLString(t, lbEndpoints), filepath.Join("testdata", "out", "xds-ir", inputFileName+".lbendpoints.yaml")))
+				}
+				require.Equal(t, requireTestDataOutFile(t, "xds-ir", inputFileName+".lbendpoints.yaml"), requireResourcesToYAMLString(t, lbEndpoints))
+			}
+
+			virtualHosts, ok := tCtx.XdsResources[xtypes.VirtualHostType]
+			if ok && len(virtualHosts) > 0 {
+				if *overrideTestData {
+					require.NoError(t, file.Write(requireResourcesToYAMLString(t, virtualHosts), filepath.Join("testdata", "out", "xds-ir", inputFileName+".virtualhosts.yaml")))
+				}
+				require.Equal(t, requireTestDataOutFile(t, "xds-ir", inputFileName+".virtualhosts.yaml"), requireResourcesToYAMLString(t, virtualHosts))
+			}
+
+			extensionConfigs, ok := tCtx.XdsResources[resourcev3.ExtensionConfigType]
+			if ok && len(extensionConfigs) > 0 {
+				if *overrideTestData {
+					require.NoError(t, file.Write(requireResourcesToYAMLString(t, extensionConfigs), filepath.Join("testdata", "out", "xds-ir", inputFileName+".extensionconfigs.yaml")))
+				}
+				require.Equal(t, requireTestDataOutFile(t, "xds-ir", inputFileName+".extensionconfigs.yaml"), requireResourcesToYAMLString(t, extensionConfigs))
+			}
+
+			runtimes, ok := tCtx.XdsResources[resourcev3.RuntimeType]
+			if ok && len(runtimes) > 0 {
+				if *overrideTestData {
+					require.NoError(t, file.Write(requireResourcesToYAMLString(t, runtimes), filepath.Join("testdata", "out", "xds-ir", inputFileName+".runtimes.yaml")))
+				}
+				require.Equal(t, requireTestDataOutFile(t, "xds-ir", inputFileName+".runtimes.yaml"), requireResourcesToYAMLString(t, runtimes))
+			}
+
 			if cfg.requireEnvoyPatchPolicies {
 				got := tCtx.EnvoyPatchPolicyStatuses
 				for _, e := range got {
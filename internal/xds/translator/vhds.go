@@ -0,0 +1,137 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package translator
+
+import (
+	"net/url"
+
+	corev3 "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
+	listenerv3 "github.com/envoyproxy/go-control-plane/envoy/config/listener/v3"
+	routev3 "github.com/envoyproxy/go-control-plane/envoy/config/route/v3"
+	ondemandv3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/http/on_demand/v3"
+	hcmv3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/network/http_connection_manager/v3"
+	discoveryv3 "github.com/envoyproxy/go-control-plane/envoy/service/discovery/v3"
+	resourcev3 "github.com/envoyproxy/go-control-plane/pkg/resource/v3"
+	"github.com/envoyproxy/go-control-plane/pkg/wellknown"
+
+	"github.com/envoyproxy/gateway/internal/ir"
+	"github.com/envoyproxy/gateway/internal/utils/protocov"
+	"github.com/envoyproxy/gateway/internal/xds/types"
+)
+
+// onDemandFilterName is the name of the HTTP filter requesting the virtual hosts on demand.
+const onDemandFilterName = "envoy.filters.http.on_demand"
+
+// processVirtualHostDiscovery moves the virtual hosts of the route configurations with at
+// least the minimum number of virtual hosts to VHDS resources, one per virtual host, named
+// after their route configuration, so that the proxies request the virtual host of the
+// hostname of a request on demand. The on-demand filter is added to the HTTP connection
+// managers of these route configurations to request the virtual hosts.
+//
+// The route configurations with routes only served by some node groups are left as they
+// are, as their routes are filtered for each group when the snapshots are generated.
+func processVirtualHostDiscovery(tCtx *types.ResourceVersionTable, vhds *ir.VirtualHostDiscovery) error {
+	if vhds == nil {
+		return nil
+	}
+
+	onDemand := make(map[string]bool)
+	for _, r := range tCtx.XdsResources[resourcev3.RouteType] {
+		routeConfig := r.(*routev3.RouteConfiguration)
+		if len(routeConfig.VirtualHosts) < int(vhds.MinVirtualHosts) || hasNodeGroupVirtualHost(routeConfig.VirtualHosts) {
+			continue
+		}
+
+		for _, vHost := range routeConfig.VirtualHosts {
+			// The route configuration is the namespace of the virtual host, up to the
+			// last slash of its name.
+			vHost.Name = routeConfig.Name + "/" + url.PathEscape(vHost.Name)
+			vHostAny, err := protocov.ToAnyWithError(vHost)
+			if err != nil {
+				return err
+			}
+			if err := tCtx.AddXdsResource(types.VirtualHostType, &discoveryv3.Resource{
+				Name:     vHost.Name,
+				Resource: vHostAny,
+			}); err != nil {
+				return err
+			}
+		}
+
+		routeConfig.VirtualHosts = nil
+		routeConfig.Vhds = &routev3.Vhds{
+			ConfigSource: &corev3.ConfigSource{
+				ResourceApiVersion:    resourcev3.DefaultAPIVersion,
+				ConfigSourceSpecifier: &corev3.ConfigSource_Ads{Ads: &corev3.AggregatedConfigSource{}},
+			},
+		}
+		onDemand[routeConfig.Name] = true
+	}
+	if len(onDemand) == 0 {
+		return nil
+	}
+
+	for _, r := range tCtx.XdsResources[resourcev3.ListenerType] {
+		listener := r.(*listenerv3.Listener)
+		filterChains := listener.FilterChains
+		if listener.DefaultFilterChain != nil {
+			filterChains = append(filterChains, listener.DefaultFilterChain)
+		}
+		for _, filterChain := range filterChains {
+			if err := addOnDemandFilter(filterChain, onDemand); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// hasNodeGroupVirtualHost returns whether any of the virtual hosts has routes only served
+// by some node groups.
+func hasNodeGroupVirtualHost(vHosts []*routev3.VirtualHost) bool {
+	for _, vHost := range vHosts {
+		if hasNodeGroupRoute(vHost.Routes) {
+			return true
+		}
+	}
+	return false
+}
+
+// addOnDemandFilter adds the on-demand filter before the router of the HCM of the filter
+// chain, if any, when its route configuration is delivered with VHDS.
+func addOnDemandFilter(filterChain *listenerv3.FilterChain, onDemand map[string]bool) error {
+	for _, filter := range filterChain.Filters {
+		if filter.Name != wellknown.HTTPConnectionManager {
+			continue
+		}
+
+		hcm := &hcmv3.HttpConnectionManager{}
+		if err := filter.GetTypedConfig().UnmarshalTo(hcm); err != nil {
+			return err
+		}
+		if !onDemand[hcm.GetRds().GetRouteConfigName()] || len(hcm.HttpFilters) == 0 {
+			continue
+		}
+
+		onDemandAny, err := protocov.ToAnyWithError(&ondemandv3.OnDemand{})
+		if err != nil {
+			return err
+		}
+		// The router is the last filter.
+		last := len(hcm.HttpFilters) - 1
+		hcm.HttpFilters = append(hcm.HttpFilters[:last:last], &hcmv3.HttpFilter{
+			Name:       onDemandFilterName,
+			ConfigType: &hcmv3.HttpFilter_TypedConfig{TypedConfig: onDemandAny},
+		}, hcm.HttpFilters[last])
+
+		hcmAny, err := protocov.ToAnyWithError(hcm)
+		if err != nil {
+			return err
+		}
+		filter.ConfigType = &listenerv3.Filter_TypedConfig{TypedConfig: hcmAny}
+	}
+	return nil
+}
@@ -0,0 +1,86 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package translator
+
+import (
+	"time"
+
+	clusterv3 "github.com/envoyproxy/go-control-plane/envoy/config/cluster/v3"
+	listenerv3 "github.com/envoyproxy/go-control-plane/envoy/config/listener/v3"
+	hcmv3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/network/http_connection_manager/v3"
+	resourcev3 "github.com/envoyproxy/go-control-plane/pkg/resource/v3"
+	"github.com/envoyproxy/go-control-plane/pkg/wellknown"
+	"google.golang.org/protobuf/types/known/durationpb"
+
+	"github.com/envoyproxy/gateway/internal/ir"
+	"github.com/envoyproxy/gateway/internal/utils/protocov"
+	"github.com/envoyproxy/gateway/internal/xds/types"
+)
+
+// processWarming applies the warming settings to the translated listeners and clusters.
+// The initial fetch timeout bounds the time the new listeners wait for their routes, and
+// the new EDS clusters wait for their endpoints, before they are warm.
+func processWarming(tCtx *types.ResourceVersionTable, warming *ir.Warming) error {
+	if warming == nil {
+		return nil
+	}
+
+	tCtx.WaitForListenerAck = warming.WaitForListenerAck
+
+	if warming.InitialFetchTimeout == nil {
+		return nil
+	}
+	timeout := warming.InitialFetchTimeout.Duration
+
+	for _, r := range tCtx.XdsResources[resourcev3.ClusterType] {
+		cluster := r.(*clusterv3.Cluster)
+		if edsConfig := cluster.GetEdsClusterConfig().GetEdsConfig(); edsConfig != nil {
+			edsConfig.InitialFetchTimeout = durationpb.New(timeout)
+		}
+	}
+
+	for _, r := range tCtx.XdsResources[resourcev3.ListenerType] {
+		listener := r.(*listenerv3.Listener)
+		filterChains := listener.FilterChains
+		if listener.DefaultFilterChain != nil {
+			filterChains = append(filterChains, listener.DefaultFilterChain)
+		}
+		for _, filterChain := range filterChains {
+			if err := setRDSInitialFetchTimeout(filterChain, timeout); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// setRDSInitialFetchTimeout sets the initial fetch timeout of the RDS config source
+// of the HCM of the filter chain, if any.
+func setRDSInitialFetchTimeout(filterChain *listenerv3.FilterChain, timeout time.Duration) error {
+	for _, filter := range filterChain.Filters {
+		if filter.Name != wellknown.HTTPConnectionManager {
+			continue
+		}
+
+		hcm := &hcmv3.HttpConnectionManager{}
+		if err := filter.GetTypedConfig().UnmarshalTo(hcm); err != nil {
+			return err
+		}
+		if hcm.GetRds().GetConfigSource() == nil {
+			continue
+		}
+		hcm.GetRds().GetConfigSource().InitialFetchTimeout = durationpb.New(timeout)
+
+		hcmAny, err := protocov.ToAnyWithError(hcm)
+		if err != nil {
+			return err
+		}
+		filter.ConfigType = &listenerv3.Filter_TypedConfig{TypedConfig: hcmAny}
+	}
+
+	return nil
+}
@@ -0,0 +1,19 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package types
+
+import "time"
+
+// Drift describes a node whose acknowledged resources stayed behind the resources it is
+// served for longer than the drift window.
+type Drift struct {
+	// NodeID is the ID of the drifted node.
+	NodeID string
+	// TypeURLs holds the sorted types of the resources the node is behind on.
+	TypeURLs []string
+	// Since is when the node was first seen behind.
+	Since time.Time
+}
@@ -0,0 +1,21 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package types
+
+// Nack describes the rejection of a snapshot version by a node.
+type Nack struct {
+	// NodeID is the ID of the node rejecting the snapshot.
+	NodeID string
+	// TypeURL is the type of the rejected resources.
+	TypeURL string
+	// Version is the rejected snapshot version.
+	Version string
+	// Message is the error reported by the node.
+	Message string
+	// RolledBackTo is the version of the snapshot the node was rolled back to, empty if
+	// the node had not acknowledged any other snapshot.
+	RolledBackTo string
+}
@@ -0,0 +1,103 @@
+// Copyright Envoy Gateway Authors
+// SPDX-License-Identifier: Apache-2.0
+// The full text of the Apache license is available in the LICENSE file at
+// the root of the repo.
+
+package types
+
+import (
+	"maps"
+	"slices"
+
+	listenerv3 "github.com/envoyproxy/go-control-plane/envoy/config/listener/v3"
+	hcmv3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/network/http_connection_manager/v3"
+	resourcev3 "github.com/envoyproxy/go-control-plane/pkg/resource/v3"
+	"github.com/envoyproxy/go-control-plane/pkg/wellknown"
+)
+
+// NodeScope selects the subset of the xds resources of an irKey served to the nodes whose
+// metadata matches, instead of all the resources, such as a shard of the listeners.
+type NodeScope struct {
+	// Name of the scope.
+	Name string
+	// NodeMetadata holds the string values of the node metadata of the nodes of the scope.
+	// A node is in the scope if its metadata has all the values.
+	NodeMetadata map[string]string
+	// ResourceNames holds the names of the resources of each type served to the nodes of
+	// the scope. All the resources of the types not listed are served.
+	ResourceNames map[resourcev3.Type][]string
+}
+
+// DeepCopy generates a deep copy of the NodeScope.
+func (s NodeScope) DeepCopy() NodeScope {
+	out := NodeScope{Name: s.Name, NodeMetadata: maps.Clone(s.NodeMetadata)}
+	if s.ResourceNames != nil {
+		out.ResourceNames = make(map[resourcev3.Type][]string, len(s.ResourceNames))
+		for typeURL, names := range s.ResourceNames {
+			out.ResourceNames[typeURL] = slices.Clone(names)
+		}
+	}
+	return out
+}
+
+// Matches returns whether a node with the string values of the metadata is in the scope.
+func (s NodeScope) Matches(metadata map[string]string) bool {
+	for key, value := range s.NodeMetadata {
+		if v, ok := metadata[key]; !ok || v != value {
+			return false
+		}
+	}
+	return true
+}
+
+// AddListenerScope scopes the nodes whose metadata matches to the listeners, and to the
+// route configurations the listeners fetch with RDS, so that the listeners of an irKey
+// can be sharded across its nodes. The other resources are served to all the nodes.
+func (t *ResourceVersionTable) AddListenerScope(name string, nodeMetadata map[string]string, listeners ...string) {
+	var routeConfigs []string
+	for _, r := range t.XdsResources[resourcev3.ListenerType] {
+		listener, ok := r.(*listenerv3.Listener)
+		if !ok || !slices.Contains(listeners, listener.Name) {
+			continue
+		}
+		for _, routeConfig := range rdsRouteConfigNames(listener) {
+			if !slices.Contains(routeConfigs, routeConfig) {
+				routeConfigs = append(routeConfigs, routeConfig)
+			}
+		}
+	}
+
+	t.NodeScopes = append(t.NodeScopes, NodeScope{
+		Name:         name,
+		NodeMetadata: nodeMetadata,
+		ResourceNames: map[resourcev3.Type][]string{
+			resourcev3.ListenerType: slices.Clone(listeners),
+			resourcev3.RouteType:    routeConfigs,
+		},
+	})
+}
+
+// rdsRouteConfigNames returns the names of the route configurations the HTTP connection
+// managers of the listener fetch with RDS.
+func rdsRouteConfigNames(listener *listenerv3.Listener) []string {
+	var names []string
+	filterChains := listener.FilterChains
+	if listener.DefaultFilterChain != nil {
+		filterChains = append(slices.Clone(filterChains), listener.DefaultFilterChain)
+	}
+	for _, filterChain := range filterChains {
+		for _, filter := range filterChain.Filters {
+			if filter.Name != wellknown.HTTPConnectionManager {
+				continue
+			}
+			hcm := &hcmv3.HttpConnectionManager{}
+			if err := filter.GetTypedConfig().UnmarshalTo(hcm); err != nil {
+				continue
+			}
+			if rds := hcm.GetRds(); rds != nil {
+				names = append(names, rds.RouteConfigName)
+			}
+		}
+	}
+	return names
+}
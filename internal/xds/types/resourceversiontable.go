@@ -6,14 +6,20 @@
 package types
 
 import (
+	"errors"
 	"fmt"
+	"sort"
+	"strings"
 
 	clusterv3 "github.com/envoyproxy/go-control-plane/envoy/config/cluster/v3"
+	corev3 "github.com/envoyproxy/go-control-plane/envoy/config/core/v3"
 	endpointv3 "github.com/envoyproxy/go-control-plane/envoy/config/endpoint/v3"
 	listenerv3 "github.com/envoyproxy/go-control-plane/envoy/config/listener/v3"
 	routev3 "github.com/envoyproxy/go-control-plane/envoy/config/route/v3"
 	tlsv3 "github.com/envoyproxy/go-control-plane/envoy/extensions/transport_sockets/tls/v3"
+	runtimev3 "github.com/envoyproxy/go-control-plane/envoy/service/runtime/v3"
 	"github.com/envoyproxy/go-control-plane/pkg/cache/types"
+	cachev3 "github.com/envoyproxy/go-control-plane/pkg/cache/v3"
 	resourcev3 "github.com/envoyproxy/go-control-plane/pkg/resource/v3"
 	"google.golang.org/protobuf/proto"
 
@@ -23,12 +29,62 @@ import (
 // XdsResources represents all the xds resources
 type XdsResources = map[resourcev3.Type][]types.Resource
 
+// TrafficRecordingLogName is the name of the access log the proxies send the recorded
+// requests to the xds server with.
+const TrafficRecordingLogName = "envoy-gateway-traffic-recording"
+
+// LbEndpointType is the type URL of the LEDS resources, which hold an endpoint of a locality
+// of a cluster each, wrapped in a discovery resource holding their name.
+const LbEndpointType = resourcev3.APITypePrefix + "envoy.config.endpoint.v3.LbEndpoint"
+
+// VirtualHostType is the type URL of the VHDS resources, which hold a virtual host of a route
+// configuration each, wrapped in a discovery resource holding their name.
+const VirtualHostType = resourcev3.VirtualHostType
+
+// XdsMetadataNamespace is the namespace of the filter metadata Envoy Gateway sets on the
+// xds resources.
+const XdsMetadataNamespace = "envoy-gateway"
+
+const (
+	// NodeGroupMetadataKey is the key of the node metadata holding the node group of a proxy.
+	NodeGroupMetadataKey = "nodeGroup"
+	// RouteNodeGroupsMetadataKey is the key of the route metadata listing the node groups
+	// of the proxies the route is served by, in the XdsMetadataNamespace.
+	RouteNodeGroupsMetadataKey = "nodeGroups"
+)
+
+// ErrResourceNameCollision is returned when several xds resources of the same type share a name.
+var ErrResourceNameCollision = errors.New("xds resource name collision")
+
 type EnvoyPatchPolicyStatuses []*ir.EnvoyPatchPolicyStatus
 
 // ResourceVersionTable holds all the translated xds resources
 type ResourceVersionTable struct {
 	XdsResources
 	EnvoyPatchPolicyStatuses
+	// WaitForListenerAck gates the Programmed condition of the Gateways on all the
+	// proxies acknowledging the listeners.
+	WaitForListenerAck bool
+	// DeferDrains holds the settings of the deferral of the changes of the listeners
+	// that drain the connections of the proxies, if enabled.
+	DeferDrains *ir.DeferDrains
+	// XdsServerPort is the port the proxies are served on, or zero for the default port.
+	XdsServerPort int32
+	// LoadReporting holds the settings of the load reports of the proxies, if enabled.
+	LoadReporting *ir.LoadReporting
+	// TrafficRecording holds the settings of the recording of the requests of the
+	// proxies, if enabled.
+	TrafficRecording *ir.TrafficRecording
+	// SessionTicketKeys holds the session ticket keys the xds server generates and
+	// serves to the proxies as SDS secrets.
+	SessionTicketKeys []*ir.SessionTicketKeys
+	// ConfigCheckpoint holds the settings of the checkpoints of the last configuration
+	// acknowledged by the proxies, if enabled.
+	ConfigCheckpoint *ir.ConfigCheckpoint
+	// NodeScopes holds the subsets of the resources served to the nodes whose metadata
+	// matches, in order of precedence. The nodes matching no scope are served all the
+	// resources.
+	NodeScopes []NodeScope
 }
 
 // DeepCopyInto copies the contents into the output object
@@ -37,6 +93,30 @@ type ResourceVersionTable struct {
 // to deep copy the proto.Message
 func (t *ResourceVersionTable) DeepCopyInto(out *ResourceVersionTable) {
 	*out = *t
+	if t.DeferDrains != nil {
+		out.DeferDrains = t.DeferDrains.DeepCopy()
+	}
+	if t.LoadReporting != nil {
+		out.LoadReporting = t.LoadReporting.DeepCopy()
+	}
+	if t.TrafficRecording != nil {
+		out.TrafficRecording = t.TrafficRecording.DeepCopy()
+	}
+	if t.ConfigCheckpoint != nil {
+		out.ConfigCheckpoint = t.ConfigCheckpoint.DeepCopy()
+	}
+	if t.SessionTicketKeys != nil {
+		out.SessionTicketKeys = make([]*ir.SessionTicketKeys, len(t.SessionTicketKeys))
+		for i := range t.SessionTicketKeys {
+			out.SessionTicketKeys[i] = t.SessionTicketKeys[i].DeepCopy()
+		}
+	}
+	if t.NodeScopes != nil {
+		out.NodeScopes = make([]NodeScope, len(t.NodeScopes))
+		for i := range t.NodeScopes {
+			out.NodeScopes[i] = t.NodeScopes[i].DeepCopy()
+		}
+	}
 	if t.XdsResources != nil {
 		in, out := &t.XdsResources, &out.XdsResources
 		*out = make(map[string][]types.Resource, len(*in))
@@ -130,6 +210,24 @@ func (t *ResourceVersionTable) AddXdsResource(rType resourcev3.Type, xdsResource
 		} else {
 			return fmt.Errorf("failed to cast xds resource %+v to Cluster type", xdsResource)
 		}
+	case resourcev3.ExtensionConfigType:
+		// Handle specific operations
+		if resourceOfType, ok := xdsResource.(*corev3.TypedExtensionConfig); ok {
+			if err := resourceOfType.ValidateAll(); err != nil {
+				return fmt.Errorf("validation failed for xds resource %+v, err: %w", xdsResource, err)
+			}
+		} else {
+			return fmt.Errorf("failed to cast xds resource %+v to TypedExtensionConfig type", xdsResource)
+		}
+	case resourcev3.RuntimeType:
+		// Handle specific operations
+		if resourceOfType, ok := xdsResource.(*runtimev3.Runtime); ok {
+			if err := resourceOfType.ValidateAll(); err != nil {
+				return fmt.Errorf("validation failed for xds resource %+v, err: %w", xdsResource, err)
+			}
+		} else {
+			return fmt.Errorf("failed to cast xds resource %+v to Runtime type", xdsResource)
+		}
 	case resourcev3.RateLimitConfigType:
 		// Handle specific operations
 		// cfg resource from runner.go is the RateLimitConfig type from "github.com/envoyproxy/go-control-plane/ratelimit/config/ratelimit/v3", which does have validate function.
@@ -188,3 +286,33 @@ func (t *ResourceVersionTable) SetResources(rType resourcev3.Type, xdsResources
 
 	t.XdsResources[rType] = xdsResources
 }
+
+// CheckResourceNames returns an error wrapping ErrResourceNameCollision if several resources
+// of the same type share a name. Envoy identifies resources by name, so only one of them
+// would be served, whichever Gateway it was generated for.
+func CheckResourceNames(resources XdsResources) error {
+	rTypes := make([]resourcev3.Type, 0, len(resources))
+	for rType := range resources {
+		rTypes = append(rTypes, rType)
+	}
+	sort.Strings(rTypes)
+
+	var errs error
+	for _, rType := range rTypes {
+		seen := make(map[string]int, len(resources[rType]))
+		var collisions []string
+		for _, r := range resources[rType] {
+			name := cachev3.GetResourceName(r)
+			seen[name]++
+			if seen[name] == 2 {
+				collisions = append(collisions, name)
+			}
+		}
+		if len(collisions) > 0 {
+			errs = errors.Join(errs, fmt.Errorf("%w: %s %s", ErrResourceNameCollision,
+				rType[strings.LastIndex(rType, ".")+1:], strings.Join(collisions, ", ")))
+		}
+	}
+
+	return errs
+}
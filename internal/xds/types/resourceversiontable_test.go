@@ -13,12 +13,15 @@ import (
 	endpointv3 "github.com/envoyproxy/go-control-plane/envoy/config/endpoint/v3"
 	listenerv3 "github.com/envoyproxy/go-control-plane/envoy/config/listener/v3"
 	routev3 "github.com/envoyproxy/go-control-plane/envoy/config/route/v3"
+	hcmv3 "github.com/envoyproxy/go-control-plane/envoy/extensions/filters/network/http_connection_manager/v3"
 	tlsv3 "github.com/envoyproxy/go-control-plane/envoy/extensions/transport_sockets/tls/v3"
 	"github.com/envoyproxy/go-control-plane/pkg/cache/types"
 	resourcev3 "github.com/envoyproxy/go-control-plane/pkg/resource/v3"
+	"github.com/envoyproxy/go-control-plane/pkg/wellknown"
 	"github.com/google/go-cmp/cmp"
 	"github.com/stretchr/testify/require"
 	"google.golang.org/protobuf/testing/protocmp"
+	"google.golang.org/protobuf/types/known/anypb"
 )
 
 var (
@@ -891,3 +894,107 @@ func TestInvalidAddXdsResource(t *testing.T) {
 		})
 	}
 }
+
+func TestCheckResourceNames(t *testing.T) {
+	testCases := []struct {
+		name      string
+		resources XdsResources
+		wantErr   string
+	}{
+		{
+			name:      "nil",
+			resources: nil,
+		},
+		{
+			name: "unique names",
+			resources: XdsResources{
+				resourcev3.ClusterType: []types.Resource{
+					&clusterv3.Cluster{Name: "httproute/default/httproute-1/rule/0"},
+					&clusterv3.Cluster{Name: "httproute/envoy-gateway/httproute-1/rule/0"},
+				},
+				resourcev3.ListenerType: []types.Resource{testListener},
+				resourcev3.SecretType:   []types.Resource{testSecret},
+			},
+		},
+		{
+			name: "same name with different types",
+			resources: XdsResources{
+				resourcev3.ClusterType: []types.Resource{
+					&clusterv3.Cluster{Name: "test"},
+				},
+				resourcev3.ListenerType: []types.Resource{
+					&listenerv3.Listener{Name: "test"},
+				},
+			},
+		},
+		{
+			name: "colliding names",
+			resources: XdsResources{
+				resourcev3.ClusterType: []types.Resource{
+					&clusterv3.Cluster{Name: "httproute/default/httproute-1/rule/0"},
+					&clusterv3.Cluster{Name: "httproute/default/httproute-1/rule/0"},
+					&clusterv3.Cluster{Name: "httproute/default/httproute-1/rule/0"},
+				},
+				resourcev3.EndpointType: []types.Resource{
+					&endpointv3.ClusterLoadAssignment{ClusterName: "httproute/default/httproute-1/rule/0"},
+				},
+				resourcev3.ListenerType: []types.Resource{testListener, testListener},
+			},
+			wantErr: "xds resource name collision: Cluster httproute/default/httproute-1/rule/0\n" +
+				"xds resource name collision: Listener test-listener",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := CheckResourceNames(tc.resources)
+			if tc.wantErr == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.ErrorIs(t, err, ErrResourceNameCollision)
+			require.EqualError(t, err, tc.wantErr)
+		})
+	}
+}
+
+func TestAddListenerScope(t *testing.T) {
+	rdsListener := func(name, routeConfig string) *listenerv3.Listener {
+		hcm, err := anypb.New(&hcmv3.HttpConnectionManager{
+			RouteSpecifier: &hcmv3.HttpConnectionManager_Rds{Rds: &hcmv3.Rds{RouteConfigName: routeConfig}},
+		})
+		require.NoError(t, err)
+		return &listenerv3.Listener{
+			Name: name,
+			FilterChains: []*listenerv3.FilterChain{{
+				Filters: []*listenerv3.Filter{{
+					Name:       wellknown.HTTPConnectionManager,
+					ConfigType: &listenerv3.Filter_TypedConfig{TypedConfig: hcm},
+				}},
+			}},
+		}
+	}
+
+	table := &ResourceVersionTable{XdsResources: XdsResources{
+		resourcev3.ListenerType: []types.Resource{
+			rdsListener("http", "http-routes"),
+			rdsListener("https", "https-routes"),
+			&listenerv3.Listener{Name: "tcp"},
+		},
+	}}
+	table.AddListenerScope("shard-a", map[string]string{"shard": "a"}, "http", "tcp")
+
+	require.Equal(t, []NodeScope{{
+		Name:         "shard-a",
+		NodeMetadata: map[string]string{"shard": "a"},
+		ResourceNames: map[resourcev3.Type][]string{
+			resourcev3.ListenerType: {"http", "tcp"},
+			resourcev3.RouteType:    {"http-routes"},
+		},
+	}}, table.NodeScopes)
+
+	scope := table.NodeScopes[0]
+	require.True(t, scope.Matches(map[string]string{"shard": "a", "nodeGroup": "public"}))
+	require.False(t, scope.Matches(map[string]string{"shard": "b"}))
+	require.False(t, scope.Matches(nil))
+}
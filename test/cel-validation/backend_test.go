@@ -15,6 +15,7 @@ import (
 	"time"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
 
 	egv1a1 "github.com/envoyproxy/gateway/api/v1alpha1"
 )
@@ -230,6 +231,43 @@ func TestBackend(t *testing.T) {
 				"spec.endpoints[3].ip.address: Invalid value: \"a.b.c.e\": spec.endpoints[3].ip.address in body should match '^((25[0-5]|2[0-4][0-9]|[01]?[0-9][0-9]?)\\.){3}(25[0-5]|2[0-4][0-9]|[01]?[0-9][0-9]?)$'",
 			},
 		},
+		{
+			desc: "Valid original destination",
+			mutate: func(backend *egv1a1.Backend) {
+				backend.Spec = egv1a1.BackendSpec{
+					OriginalDestination: &egv1a1.OriginalDestination{
+						HeaderName: ptr.To("x-original-destination"),
+					},
+				}
+			},
+			wantErrors: []string{},
+		},
+		{
+			desc: "Both endpoints and original destination",
+			mutate: func(backend *egv1a1.Backend) {
+				backend.Spec = egv1a1.BackendSpec{
+					Endpoints: []egv1a1.BackendEndpoint{
+						{
+							IP: &egv1a1.IPEndpoint{
+								Address: "1.1.1.1",
+								Port:    443,
+							},
+						},
+					},
+					OriginalDestination: &egv1a1.OriginalDestination{},
+				}
+			},
+			wantErrors: []string{"spec: Invalid value: \"object\": exactly one of endpoints or originalDestination must be specified"},
+		},
+		{
+			desc: "Neither endpoints nor original destination",
+			mutate: func(backend *egv1a1.Backend) {
+				backend.Spec = egv1a1.BackendSpec{
+					AppProtocols: []egv1a1.AppProtocolType{egv1a1.AppProtocolTypeH2C},
+				}
+			},
+			wantErrors: []string{"spec: Invalid value: \"object\": exactly one of endpoints or originalDestination must be specified"},
+		},
 	}
 
 	for _, tc := range cases {
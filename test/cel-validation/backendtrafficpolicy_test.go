@@ -19,6 +19,7 @@ import (
 	"k8s.io/utils/ptr"
 	gwapiv1 "sigs.k8s.io/gateway-api/apis/v1"
 	gwapiv1a2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
+	gwapiv1b1 "sigs.k8s.io/gateway-api/apis/v1beta1"
 
 	egv1a1 "github.com/envoyproxy/gateway/api/v1alpha1"
 )
@@ -1210,6 +1211,703 @@ func TestBackendTrafficPolicyTarget(t *testing.T) {
 				"spec.connection.bufferLimit: Invalid value: \"1m\": spec.connection.bufferLimit in body should match '^[1-9]+[0-9]*([EPTGMK]i|[EPTGMk])?$', <nil>: Invalid value: \"\"",
 			},
 		},
+		{
+			desc: "invalid happyEyeballs first address family",
+			mutate: func(btp *egv1a1.BackendTrafficPolicy) {
+				btp.Spec = egv1a1.BackendTrafficPolicySpec{
+					PolicyTargetReferences: egv1a1.PolicyTargetReferences{
+						TargetRef: &gwapiv1a2.LocalPolicyTargetReferenceWithSectionName{
+							LocalPolicyTargetReference: gwapiv1a2.LocalPolicyTargetReference{
+								Group: gwapiv1a2.Group("gateway.networking.k8s.io"),
+								Kind:  gwapiv1a2.Kind("Gateway"),
+								Name:  gwapiv1a2.ObjectName("eg"),
+							},
+						},
+					},
+					ClusterSettings: egv1a1.ClusterSettings{
+						Connection: &egv1a1.BackendConnection{
+							HappyEyeballs: &egv1a1.HappyEyeballs{
+								FirstAddressFamily: ptr.To(egv1a1.DualStack),
+							},
+						},
+					},
+				}
+			},
+			wantErrors: []string{
+				"spec.connection.happyEyeballs: Invalid value: \"object\": firstAddressFamily must be either IPv4 or IPv6",
+			},
+		},
+		{
+			desc: "experiment with duplicate bucket names",
+			mutate: func(btp *egv1a1.BackendTrafficPolicy) {
+				btp.Spec = egv1a1.BackendTrafficPolicySpec{
+					PolicyTargetReferences: egv1a1.PolicyTargetReferences{
+						TargetRef: &gwapiv1a2.LocalPolicyTargetReferenceWithSectionName{
+							LocalPolicyTargetReference: gwapiv1a2.LocalPolicyTargetReference{
+								Group: gwapiv1a2.Group("gateway.networking.k8s.io"),
+								Kind:  gwapiv1a2.Kind("HTTPRoute"),
+								Name:  gwapiv1a2.ObjectName("httpbin-route"),
+							},
+						},
+					},
+					Experiment: &egv1a1.Experiment{
+						Cookie: "experiment",
+						Buckets: []egv1a1.ExperimentBucket{
+							experimentBucket("a", 50),
+							experimentBucket("a", 50),
+						},
+					},
+				}
+			},
+			wantErrors: []string{
+				"spec.experiment: Invalid value: \"object\": the names of the buckets must be unique",
+			},
+		},
+		{
+			desc: "experiment with salt but no header",
+			mutate: func(btp *egv1a1.BackendTrafficPolicy) {
+				btp.Spec = egv1a1.BackendTrafficPolicySpec{
+					PolicyTargetReferences: egv1a1.PolicyTargetReferences{
+						TargetRef: &gwapiv1a2.LocalPolicyTargetReferenceWithSectionName{
+							LocalPolicyTargetReference: gwapiv1a2.LocalPolicyTargetReference{
+								Group: gwapiv1a2.Group("gateway.networking.k8s.io"),
+								Kind:  gwapiv1a2.Kind("HTTPRoute"),
+								Name:  gwapiv1a2.ObjectName("httpbin-route"),
+							},
+						},
+					},
+					Experiment: &egv1a1.Experiment{
+						Cookie: "experiment",
+						Salt:   ptr.To("salt"),
+						Buckets: []egv1a1.ExperimentBucket{
+							experimentBucket("a", 50),
+							experimentBucket("b", 50),
+						},
+					},
+				}
+			},
+			wantErrors: []string{
+				"spec.experiment: Invalid value: \"object\": salt can only be set along with header",
+			},
+		},
+		{
+			desc: "valid experiment",
+			mutate: func(btp *egv1a1.BackendTrafficPolicy) {
+				btp.Spec = egv1a1.BackendTrafficPolicySpec{
+					PolicyTargetReferences: egv1a1.PolicyTargetReferences{
+						TargetRef: &gwapiv1a2.LocalPolicyTargetReferenceWithSectionName{
+							LocalPolicyTargetReference: gwapiv1a2.LocalPolicyTargetReference{
+								Group: gwapiv1a2.Group("gateway.networking.k8s.io"),
+								Kind:  gwapiv1a2.Kind("HTTPRoute"),
+								Name:  gwapiv1a2.ObjectName("httpbin-route"),
+							},
+						},
+					},
+					Experiment: &egv1a1.Experiment{
+						Cookie: "experiment",
+						Header: ptr.To("x-user-id"),
+						Salt:   ptr.To("salt"),
+						Buckets: []egv1a1.ExperimentBucket{
+							experimentBucket("a", 50),
+							experimentBucket("b", 50),
+						},
+					},
+				}
+			},
+			wantErrors: []string{},
+		},
+		{
+			desc: "headerToMetadata without headers",
+			mutate: func(btp *egv1a1.BackendTrafficPolicy) {
+				btp.Spec = egv1a1.BackendTrafficPolicySpec{
+					PolicyTargetReferences: egv1a1.PolicyTargetReferences{
+						TargetRef: &gwapiv1a2.LocalPolicyTargetReferenceWithSectionName{
+							LocalPolicyTargetReference: gwapiv1a2.LocalPolicyTargetReference{
+								Group: gwapiv1a2.Group("gateway.networking.k8s.io"),
+								Kind:  gwapiv1a2.Kind("HTTPRoute"),
+								Name:  gwapiv1a2.ObjectName("httpbin-route"),
+							},
+						},
+					},
+					HeaderToMetadata: &egv1a1.HeaderToMetadata{},
+				}
+			},
+			wantErrors: []string{
+				"spec.headerToMetadata: Invalid value: \"object\": at least one of requestHeaders or responseHeaders must be specified",
+			},
+		},
+		{
+			desc: "headerToMetadata request header without metadata",
+			mutate: func(btp *egv1a1.BackendTrafficPolicy) {
+				btp.Spec = egv1a1.BackendTrafficPolicySpec{
+					PolicyTargetReferences: egv1a1.PolicyTargetReferences{
+						TargetRef: &gwapiv1a2.LocalPolicyTargetReferenceWithSectionName{
+							LocalPolicyTargetReference: gwapiv1a2.LocalPolicyTargetReference{
+								Group: gwapiv1a2.Group("gateway.networking.k8s.io"),
+								Kind:  gwapiv1a2.Kind("HTTPRoute"),
+								Name:  gwapiv1a2.ObjectName("httpbin-route"),
+							},
+						},
+					},
+					HeaderToMetadata: &egv1a1.HeaderToMetadata{
+						RequestHeaders: []egv1a1.RequestHeaderToMetadata{
+							{Header: "x-tenant"},
+						},
+					},
+				}
+			},
+			wantErrors: []string{
+				"spec.headerToMetadata.requestHeaders[0]: Invalid value: \"object\": at least one of onPresent or onMissing must be specified",
+			},
+		},
+		{
+			desc: "headerToMetadata request header missing without value",
+			mutate: func(btp *egv1a1.BackendTrafficPolicy) {
+				btp.Spec = egv1a1.BackendTrafficPolicySpec{
+					PolicyTargetReferences: egv1a1.PolicyTargetReferences{
+						TargetRef: &gwapiv1a2.LocalPolicyTargetReferenceWithSectionName{
+							LocalPolicyTargetReference: gwapiv1a2.LocalPolicyTargetReference{
+								Group: gwapiv1a2.Group("gateway.networking.k8s.io"),
+								Kind:  gwapiv1a2.Kind("HTTPRoute"),
+								Name:  gwapiv1a2.ObjectName("httpbin-route"),
+							},
+						},
+					},
+					HeaderToMetadata: &egv1a1.HeaderToMetadata{
+						RequestHeaders: []egv1a1.RequestHeaderToMetadata{
+							{
+								Header:    "x-tenant",
+								OnMissing: &egv1a1.MetadataValue{Key: "tenant"},
+							},
+						},
+					},
+				}
+			},
+			wantErrors: []string{
+				"spec.headerToMetadata.requestHeaders[0]: Invalid value: \"object\": onMissing.value must be specified",
+			},
+		},
+		{
+			desc: "valid headerToMetadata",
+			mutate: func(btp *egv1a1.BackendTrafficPolicy) {
+				btp.Spec = egv1a1.BackendTrafficPolicySpec{
+					PolicyTargetReferences: egv1a1.PolicyTargetReferences{
+						TargetRef: &gwapiv1a2.LocalPolicyTargetReferenceWithSectionName{
+							LocalPolicyTargetReference: gwapiv1a2.LocalPolicyTargetReference{
+								Group: gwapiv1a2.Group("gateway.networking.k8s.io"),
+								Kind:  gwapiv1a2.Kind("HTTPRoute"),
+								Name:  gwapiv1a2.ObjectName("httpbin-route"),
+							},
+						},
+					},
+					HeaderToMetadata: &egv1a1.HeaderToMetadata{
+						RequestHeaders: []egv1a1.RequestHeaderToMetadata{
+							{
+								Header:    "x-tenant",
+								OnPresent: &egv1a1.MetadataValue{Key: "tenant"},
+								OnMissing: &egv1a1.MetadataValue{Key: "tenant", Value: ptr.To("anonymous")},
+								Remove:    ptr.To(true),
+							},
+						},
+						ResponseHeaders: []egv1a1.MetadataToResponseHeader{
+							{Name: "x-debug-tenant", Key: "tenant"},
+						},
+					},
+				}
+			},
+			wantErrors: []string{},
+		},
+		{
+			desc: "grpcStatusMapping without mappings",
+			mutate: func(btp *egv1a1.BackendTrafficPolicy) {
+				btp.Spec = egv1a1.BackendTrafficPolicySpec{
+					PolicyTargetReferences: egv1a1.PolicyTargetReferences{
+						TargetRef: &gwapiv1a2.LocalPolicyTargetReferenceWithSectionName{
+							LocalPolicyTargetReference: gwapiv1a2.LocalPolicyTargetReference{
+								Group: gwapiv1a2.Group("gateway.networking.k8s.io"),
+								Kind:  gwapiv1a2.Kind("HTTPRoute"),
+								Name:  gwapiv1a2.ObjectName("httpbin-route"),
+							},
+						},
+					},
+					GRPCStatusMapping: &egv1a1.GRPCStatusMapping{},
+				}
+			},
+			wantErrors: []string{
+				"spec.grpcStatusMapping: Invalid value: \"object\": at least one of toHTTP or toGRPC must be specified",
+			},
+		},
+		{
+			desc: "grpcStatusMapping with invalid gRPC status",
+			mutate: func(btp *egv1a1.BackendTrafficPolicy) {
+				btp.Spec = egv1a1.BackendTrafficPolicySpec{
+					PolicyTargetReferences: egv1a1.PolicyTargetReferences{
+						TargetRef: &gwapiv1a2.LocalPolicyTargetReferenceWithSectionName{
+							LocalPolicyTargetReference: gwapiv1a2.LocalPolicyTargetReference{
+								Group: gwapiv1a2.Group("gateway.networking.k8s.io"),
+								Kind:  gwapiv1a2.Kind("HTTPRoute"),
+								Name:  gwapiv1a2.ObjectName("httpbin-route"),
+							},
+						},
+					},
+					GRPCStatusMapping: &egv1a1.GRPCStatusMapping{
+						ToHTTP: []egv1a1.GRPCToHTTPStatus{
+							{GRPCStatus: 17, HTTPStatus: 503},
+						},
+					},
+				}
+			},
+			wantErrors: []string{
+				"spec.grpcStatusMapping.toHTTP[0].grpcStatus: Invalid value: 17: spec.grpcStatusMapping.toHTTP[0].grpcStatus in body should be less than or equal to 16",
+			},
+		},
+		{
+			desc: "valid grpcStatusMapping",
+			mutate: func(btp *egv1a1.BackendTrafficPolicy) {
+				btp.Spec = egv1a1.BackendTrafficPolicySpec{
+					PolicyTargetReferences: egv1a1.PolicyTargetReferences{
+						TargetRef: &gwapiv1a2.LocalPolicyTargetReferenceWithSectionName{
+							LocalPolicyTargetReference: gwapiv1a2.LocalPolicyTargetReference{
+								Group: gwapiv1a2.Group("gateway.networking.k8s.io"),
+								Kind:  gwapiv1a2.Kind("HTTPRoute"),
+								Name:  gwapiv1a2.ObjectName("httpbin-route"),
+							},
+						},
+					},
+					GRPCStatusMapping: &egv1a1.GRPCStatusMapping{
+						ToHTTP: []egv1a1.GRPCToHTTPStatus{
+							{GRPCStatus: 14, HTTPStatus: 503},
+						},
+						ToGRPC: []egv1a1.HTTPToGRPCStatus{
+							{
+								HTTPStatus: egv1a1.StatusCodeMatch{
+									Type:  ptr.To(egv1a1.StatusCodeValueTypeValue),
+									Value: ptr.To("404"),
+								},
+								GRPCStatus: 5,
+							},
+						},
+					},
+				}
+			},
+			wantErrors: []string{},
+		},
+		{
+			desc: "responseBuffer with invalid limit",
+			mutate: func(btp *egv1a1.BackendTrafficPolicy) {
+				btp.Spec = egv1a1.BackendTrafficPolicySpec{
+					PolicyTargetReferences: egv1a1.PolicyTargetReferences{
+						TargetRef: &gwapiv1a2.LocalPolicyTargetReferenceWithSectionName{
+							LocalPolicyTargetReference: gwapiv1a2.LocalPolicyTargetReference{
+								Group: gwapiv1a2.Group("gateway.networking.k8s.io"),
+								Kind:  gwapiv1a2.Kind("HTTPRoute"),
+								Name:  gwapiv1a2.ObjectName("httpbin-route"),
+							},
+						},
+					},
+					ResponseBuffer: &egv1a1.ResponseBuffer{
+						Limit: resource.MustParse("15m"),
+					},
+				}
+			},
+			wantErrors: []string{
+				"spec.responseBuffer.limit: Invalid value: \"15m\": spec.responseBuffer.limit in body should match '^[1-9]+[0-9]*([EPTGMK]i|[EPTGMk])?$'",
+			},
+		},
+		{
+			desc: "responseBuffer injecting content length when streaming through",
+			mutate: func(btp *egv1a1.BackendTrafficPolicy) {
+				btp.Spec = egv1a1.BackendTrafficPolicySpec{
+					PolicyTargetReferences: egv1a1.PolicyTargetReferences{
+						TargetRef: &gwapiv1a2.LocalPolicyTargetReferenceWithSectionName{
+							LocalPolicyTargetReference: gwapiv1a2.LocalPolicyTargetReference{
+								Group: gwapiv1a2.Group("gateway.networking.k8s.io"),
+								Kind:  gwapiv1a2.Kind("HTTPRoute"),
+								Name:  gwapiv1a2.ObjectName("httpbin-route"),
+							},
+						},
+					},
+					ResponseBuffer: &egv1a1.ResponseBuffer{
+						Limit:           resource.MustParse("1Mi"),
+						OnLimitExceeded: ptr.To(egv1a1.ResponseBufferLimitActionStreamThrough),
+						ChunkedEncoding: ptr.To(egv1a1.ResponseChunkedEncodingInjectContentLength),
+					},
+				}
+			},
+			wantErrors: []string{
+				"spec.responseBuffer: Invalid value: \"object\": chunkedEncoding InjectContentLength requires onLimitExceeded Reject",
+			},
+		},
+		{
+			desc: "valid responseBuffer",
+			mutate: func(btp *egv1a1.BackendTrafficPolicy) {
+				btp.Spec = egv1a1.BackendTrafficPolicySpec{
+					PolicyTargetReferences: egv1a1.PolicyTargetReferences{
+						TargetRef: &gwapiv1a2.LocalPolicyTargetReferenceWithSectionName{
+							LocalPolicyTargetReference: gwapiv1a2.LocalPolicyTargetReference{
+								Group: gwapiv1a2.Group("gateway.networking.k8s.io"),
+								Kind:  gwapiv1a2.Kind("HTTPRoute"),
+								Name:  gwapiv1a2.ObjectName("httpbin-route"),
+							},
+						},
+					},
+					ResponseBuffer: &egv1a1.ResponseBuffer{
+						Limit:           resource.MustParse("1Mi"),
+						OnLimitExceeded: ptr.To(egv1a1.ResponseBufferLimitActionReject),
+						ChunkedEncoding: ptr.To(egv1a1.ResponseChunkedEncodingInjectContentLength),
+					},
+				}
+			},
+			wantErrors: []string{},
+		},
+		{
+			desc: "requestSigning of the AWSSigV4 type without awsSigV4",
+			mutate: func(btp *egv1a1.BackendTrafficPolicy) {
+				btp.Spec = egv1a1.BackendTrafficPolicySpec{
+					PolicyTargetReferences: egv1a1.PolicyTargetReferences{
+						TargetRef: &gwapiv1a2.LocalPolicyTargetReferenceWithSectionName{
+							LocalPolicyTargetReference: gwapiv1a2.LocalPolicyTargetReference{
+								Group: gwapiv1a2.Group("gateway.networking.k8s.io"),
+								Kind:  gwapiv1a2.Kind("HTTPRoute"),
+								Name:  gwapiv1a2.ObjectName("httpbin-route"),
+							},
+						},
+					},
+					RequestSigning: &egv1a1.RequestSigning{
+						Type: egv1a1.RequestSigningTypeAWSSigV4,
+						HMAC: &egv1a1.HMACRequestSigning{
+							BackendCluster: egv1a1.BackendCluster{
+								BackendRefs: []egv1a1.BackendRef{
+									{
+										BackendObjectReference: gwapiv1.BackendObjectReference{
+											Name: "request-signer",
+											Port: ptr.To(gwapiv1.PortNumber(9002)),
+										},
+									},
+								},
+							},
+							Key: gwapiv1b1.SecretObjectReference{Name: "hmac-secret"},
+						},
+					},
+				}
+			},
+			wantErrors: []string{
+				"spec.requestSigning: Invalid value: \"object\": awsSigV4 must be set, and hmac unset, for the AWSSigV4 type",
+			},
+		},
+		{
+			desc: "requestSigning of the HMAC type with awsSigV4",
+			mutate: func(btp *egv1a1.BackendTrafficPolicy) {
+				btp.Spec = egv1a1.BackendTrafficPolicySpec{
+					PolicyTargetReferences: egv1a1.PolicyTargetReferences{
+						TargetRef: &gwapiv1a2.LocalPolicyTargetReferenceWithSectionName{
+							LocalPolicyTargetReference: gwapiv1a2.LocalPolicyTargetReference{
+								Group: gwapiv1a2.Group("gateway.networking.k8s.io"),
+								Kind:  gwapiv1a2.Kind("HTTPRoute"),
+								Name:  gwapiv1a2.ObjectName("httpbin-route"),
+							},
+						},
+					},
+					RequestSigning: &egv1a1.RequestSigning{
+						Type: egv1a1.RequestSigningTypeHMAC,
+						AWSSigV4: &egv1a1.AWSSigV4RequestSigning{
+							Service: "s3",
+							Region:  "us-east-1",
+						},
+						HMAC: &egv1a1.HMACRequestSigning{
+							BackendCluster: egv1a1.BackendCluster{
+								BackendRefs: []egv1a1.BackendRef{
+									{
+										BackendObjectReference: gwapiv1.BackendObjectReference{
+											Name: "request-signer",
+											Port: ptr.To(gwapiv1.PortNumber(9002)),
+										},
+									},
+								},
+							},
+							Key: gwapiv1b1.SecretObjectReference{Name: "hmac-secret"},
+						},
+					},
+				}
+			},
+			wantErrors: []string{
+				"spec.requestSigning: Invalid value: \"object\": hmac must be set, and awsSigV4 unset, for the HMAC type",
+			},
+		},
+		{
+			desc: "valid requestSigning",
+			mutate: func(btp *egv1a1.BackendTrafficPolicy) {
+				btp.Spec = egv1a1.BackendTrafficPolicySpec{
+					PolicyTargetReferences: egv1a1.PolicyTargetReferences{
+						TargetRef: &gwapiv1a2.LocalPolicyTargetReferenceWithSectionName{
+							LocalPolicyTargetReference: gwapiv1a2.LocalPolicyTargetReference{
+								Group: gwapiv1a2.Group("gateway.networking.k8s.io"),
+								Kind:  gwapiv1a2.Kind("HTTPRoute"),
+								Name:  gwapiv1a2.ObjectName("httpbin-route"),
+							},
+						},
+					},
+					RequestSigning: &egv1a1.RequestSigning{
+						Type: egv1a1.RequestSigningTypeAWSSigV4,
+						AWSSigV4: &egv1a1.AWSSigV4RequestSigning{
+							Service: "s3",
+							Region:  "us-east-1",
+						},
+					},
+				}
+			},
+			wantErrors: []string{},
+		},
+		{
+			desc: "valid HMAC requestSigning",
+			mutate: func(btp *egv1a1.BackendTrafficPolicy) {
+				btp.Spec = egv1a1.BackendTrafficPolicySpec{
+					PolicyTargetReferences: egv1a1.PolicyTargetReferences{
+						TargetRef: &gwapiv1a2.LocalPolicyTargetReferenceWithSectionName{
+							LocalPolicyTargetReference: gwapiv1a2.LocalPolicyTargetReference{
+								Group: gwapiv1a2.Group("gateway.networking.k8s.io"),
+								Kind:  gwapiv1a2.Kind("HTTPRoute"),
+								Name:  gwapiv1a2.ObjectName("httpbin-route"),
+							},
+						},
+					},
+					RequestSigning: &egv1a1.RequestSigning{
+						Type: egv1a1.RequestSigningTypeHMAC,
+						HMAC: &egv1a1.HMACRequestSigning{
+							BackendCluster: egv1a1.BackendCluster{
+								BackendRefs: []egv1a1.BackendRef{
+									{
+										BackendObjectReference: gwapiv1.BackendObjectReference{
+											Name: "request-signer",
+											Port: ptr.To(gwapiv1.PortNumber(9002)),
+										},
+									},
+								},
+							},
+							Key: gwapiv1b1.SecretObjectReference{Name: "hmac-secret"},
+						},
+					},
+				}
+			},
+			wantErrors: []string{},
+		},
+		{
+			desc: "HMAC requestSigning without backendRefs",
+			mutate: func(btp *egv1a1.BackendTrafficPolicy) {
+				btp.Spec = egv1a1.BackendTrafficPolicySpec{
+					PolicyTargetReferences: egv1a1.PolicyTargetReferences{
+						TargetRef: &gwapiv1a2.LocalPolicyTargetReferenceWithSectionName{
+							LocalPolicyTargetReference: gwapiv1a2.LocalPolicyTargetReference{
+								Group: gwapiv1a2.Group("gateway.networking.k8s.io"),
+								Kind:  gwapiv1a2.Kind("HTTPRoute"),
+								Name:  gwapiv1a2.ObjectName("httpbin-route"),
+							},
+						},
+					},
+					RequestSigning: &egv1a1.RequestSigning{
+						Type: egv1a1.RequestSigningTypeHMAC,
+						HMAC: &egv1a1.HMACRequestSigning{
+							Key: gwapiv1b1.SecretObjectReference{Name: "hmac-secret"},
+						},
+					},
+				}
+			},
+			wantErrors: []string{
+				"spec.requestSigning.hmac: Invalid value: \"object\": BackendRefs is required.",
+			},
+		},
+		{
+			desc: "credentialInjection of the BearerToken type without bearerToken",
+			mutate: func(btp *egv1a1.BackendTrafficPolicy) {
+				btp.Spec = egv1a1.BackendTrafficPolicySpec{
+					PolicyTargetReferences: egv1a1.PolicyTargetReferences{
+						TargetRef: &gwapiv1a2.LocalPolicyTargetReferenceWithSectionName{
+							LocalPolicyTargetReference: gwapiv1a2.LocalPolicyTargetReference{
+								Group: gwapiv1a2.Group("gateway.networking.k8s.io"),
+								Kind:  gwapiv1a2.Kind("HTTPRoute"),
+								Name:  gwapiv1a2.ObjectName("httpbin-route"),
+							},
+						},
+					},
+					CredentialInjection: &egv1a1.CredentialInjection{
+						Type: egv1a1.CredentialInjectionTypeBearerToken,
+						OAuth2ClientCredentials: &egv1a1.OAuth2ClientCredentials{
+							TokenEndpoint: "https://oauth.example.com/token",
+							ClientID:      "client1",
+							ClientSecret:  gwapiv1b1.SecretObjectReference{Name: "client-secret"},
+						},
+					},
+				}
+			},
+			wantErrors: []string{
+				"spec.credentialInjection: Invalid value: \"object\": bearerToken must be set, and oauth2ClientCredentials unset, for the BearerToken type",
+			},
+		},
+		{
+			desc: "credentialInjection of the OAuth2ClientCredentials type with bearerToken",
+			mutate: func(btp *egv1a1.BackendTrafficPolicy) {
+				btp.Spec = egv1a1.BackendTrafficPolicySpec{
+					PolicyTargetReferences: egv1a1.PolicyTargetReferences{
+						TargetRef: &gwapiv1a2.LocalPolicyTargetReferenceWithSectionName{
+							LocalPolicyTargetReference: gwapiv1a2.LocalPolicyTargetReference{
+								Group: gwapiv1a2.Group("gateway.networking.k8s.io"),
+								Kind:  gwapiv1a2.Kind("HTTPRoute"),
+								Name:  gwapiv1a2.ObjectName("httpbin-route"),
+							},
+						},
+					},
+					CredentialInjection: &egv1a1.CredentialInjection{
+						Type: egv1a1.CredentialInjectionTypeOAuth2ClientCredentials,
+						BearerToken: &egv1a1.BearerTokenCredential{
+							Token: gwapiv1b1.SecretObjectReference{Name: "token-secret"},
+						},
+						OAuth2ClientCredentials: &egv1a1.OAuth2ClientCredentials{
+							TokenEndpoint: "https://oauth.example.com/token",
+							ClientID:      "client1",
+							ClientSecret:  gwapiv1b1.SecretObjectReference{Name: "client-secret"},
+						},
+					},
+				}
+			},
+			wantErrors: []string{
+				"spec.credentialInjection: Invalid value: \"object\": oauth2ClientCredentials must be set, and bearerToken unset, for the OAuth2ClientCredentials type",
+			},
+		},
+		{
+			desc: "valid credentialInjection",
+			mutate: func(btp *egv1a1.BackendTrafficPolicy) {
+				btp.Spec = egv1a1.BackendTrafficPolicySpec{
+					PolicyTargetReferences: egv1a1.PolicyTargetReferences{
+						TargetRef: &gwapiv1a2.LocalPolicyTargetReferenceWithSectionName{
+							LocalPolicyTargetReference: gwapiv1a2.LocalPolicyTargetReference{
+								Group: gwapiv1a2.Group("gateway.networking.k8s.io"),
+								Kind:  gwapiv1a2.Kind("HTTPRoute"),
+								Name:  gwapiv1a2.ObjectName("httpbin-route"),
+							},
+						},
+					},
+					CredentialInjection: &egv1a1.CredentialInjection{
+						Type: egv1a1.CredentialInjectionTypeBearerToken,
+						BearerToken: &egv1a1.BearerTokenCredential{
+							Token: gwapiv1b1.SecretObjectReference{Name: "token-secret"},
+						},
+					},
+				}
+			},
+			wantErrors: []string{},
+		},
+		{
+			desc: "valid canaryRollout",
+			mutate: func(btp *egv1a1.BackendTrafficPolicy) {
+				btp.Spec = egv1a1.BackendTrafficPolicySpec{
+					PolicyTargetReferences: egv1a1.PolicyTargetReferences{
+						TargetRef: &gwapiv1a2.LocalPolicyTargetReferenceWithSectionName{
+							LocalPolicyTargetReference: gwapiv1a2.LocalPolicyTargetReference{
+								Group: gwapiv1a2.Group("gateway.networking.k8s.io"),
+								Kind:  gwapiv1a2.Kind("HTTPRoute"),
+								Name:  gwapiv1a2.ObjectName("httpbin-route"),
+							},
+						},
+					},
+					CanaryRollout: &egv1a1.CanaryRollout{
+						BackendRef: gwapiv1.BackendObjectReference{
+							Name: "backend-canary",
+							Port: ptr.To(gwapiv1.PortNumber(8080)),
+						},
+						Steps: []uint32{10, 50, 100},
+						Guardrails: []egv1a1.CanaryGuardrail{
+							{Name: "errors", Query: "canary_error_rate > 0.05"},
+						},
+					},
+				}
+			},
+			wantErrors: []string{},
+		},
+		{
+			desc: "canaryRollout with unordered steps",
+			mutate: func(btp *egv1a1.BackendTrafficPolicy) {
+				btp.Spec = egv1a1.BackendTrafficPolicySpec{
+					PolicyTargetReferences: egv1a1.PolicyTargetReferences{
+						TargetRef: &gwapiv1a2.LocalPolicyTargetReferenceWithSectionName{
+							LocalPolicyTargetReference: gwapiv1a2.LocalPolicyTargetReference{
+								Group: gwapiv1a2.Group("gateway.networking.k8s.io"),
+								Kind:  gwapiv1a2.Kind("HTTPRoute"),
+								Name:  gwapiv1a2.ObjectName("httpbin-route"),
+							},
+						},
+					},
+					CanaryRollout: &egv1a1.CanaryRollout{
+						BackendRef: gwapiv1.BackendObjectReference{
+							Name: "backend-canary",
+							Port: ptr.To(gwapiv1.PortNumber(8080)),
+						},
+						Steps: []uint32{50, 10},
+						Guardrails: []egv1a1.CanaryGuardrail{
+							{Name: "errors", Query: "canary_error_rate > 0.05"},
+						},
+					},
+				}
+			},
+			wantErrors: []string{
+				"spec.canaryRollout: Invalid value: \"object\": the steps must be in increasing order",
+			},
+		},
+		{
+			desc: "canaryRollout with duplicate guardrails",
+			mutate: func(btp *egv1a1.BackendTrafficPolicy) {
+				btp.Spec = egv1a1.BackendTrafficPolicySpec{
+					PolicyTargetReferences: egv1a1.PolicyTargetReferences{
+						TargetRef: &gwapiv1a2.LocalPolicyTargetReferenceWithSectionName{
+							LocalPolicyTargetReference: gwapiv1a2.LocalPolicyTargetReference{
+								Group: gwapiv1a2.Group("gateway.networking.k8s.io"),
+								Kind:  gwapiv1a2.Kind("HTTPRoute"),
+								Name:  gwapiv1a2.ObjectName("httpbin-route"),
+							},
+						},
+					},
+					CanaryRollout: &egv1a1.CanaryRollout{
+						BackendRef: gwapiv1.BackendObjectReference{
+							Name: "backend-canary",
+							Port: ptr.To(gwapiv1.PortNumber(8080)),
+						},
+						Steps: []uint32{10, 50},
+						Guardrails: []egv1a1.CanaryGuardrail{
+							{Name: "errors", Query: "canary_error_rate > 0.05"},
+							{Name: "errors", Query: "canary_latency_p99 > 0.5"},
+						},
+					},
+				}
+			},
+			wantErrors: []string{
+				"spec.canaryRollout: Invalid value: \"object\": the names of the guardrails must be unique",
+			},
+		},
+		{
+			desc: "canaryRollout targeting a Gateway",
+			mutate: func(btp *egv1a1.BackendTrafficPolicy) {
+				btp.Spec = egv1a1.BackendTrafficPolicySpec{
+					PolicyTargetReferences: egv1a1.PolicyTargetReferences{
+						TargetRef: &gwapiv1a2.LocalPolicyTargetReferenceWithSectionName{
+							LocalPolicyTargetReference: gwapiv1a2.LocalPolicyTargetReference{
+								Group: gwapiv1a2.Group("gateway.networking.k8s.io"),
+								Kind:  gwapiv1a2.Kind("Gateway"),
+								Name:  gwapiv1a2.ObjectName("httpbin-route"),
+							},
+						},
+					},
+					CanaryRollout: &egv1a1.CanaryRollout{
+						BackendRef: gwapiv1.BackendObjectReference{
+							Name: "backend-canary",
+							Port: ptr.To(gwapiv1.PortNumber(8080)),
+						},
+						Steps: []uint32{10, 50},
+					},
+				}
+			},
+			wantErrors: []string{
+				"spec: Invalid value: \"object\": canaryRollout can only target HTTPRoutes with targetRef or targetRefs",
+			},
+		},
 		{
 			desc: "both targetref and targetrefs specified",
 			mutate: func(btp *egv1a1.BackendTrafficPolicy) {
@@ -1290,3 +1988,18 @@ func TestBackendTrafficPolicyTarget(t *testing.T) {
 		})
 	}
 }
+
+func experimentBucket(name string, percentage uint32) egv1a1.ExperimentBucket {
+	return egv1a1.ExperimentBucket{
+		Name:       name,
+		Percentage: percentage,
+		BackendRefs: []egv1a1.BackendRef{
+			{
+				BackendObjectReference: gwapiv1.BackendObjectReference{
+					Name: "backend",
+					Port: ptr.To(gwapiv1.PortNumber(8080)),
+				},
+			},
+		},
+	}
+}
@@ -194,6 +194,144 @@ func TestClientTrafficPolicyTarget(t *testing.T) {
 				"spec.tls: Invalid value: \"object\": minVersion must be smaller or equal to maxVersion",
 			},
 		},
+		{
+			desc: "session ticket keys with stateless session resumption enabled",
+			mutate: func(ctp *egv1a1.ClientTrafficPolicy) {
+				ctp.Spec = egv1a1.ClientTrafficPolicySpec{
+					PolicyTargetReferences: egv1a1.PolicyTargetReferences{
+						TargetRef: &gwapiv1a2.LocalPolicyTargetReferenceWithSectionName{
+							LocalPolicyTargetReference: gwapiv1a2.LocalPolicyTargetReference{
+								Group: gwapiv1a2.Group("gateway.networking.k8s.io"),
+								Kind:  gwapiv1a2.Kind("Gateway"),
+								Name:  gwapiv1a2.ObjectName("eg"),
+							},
+						},
+					},
+					TLS: &egv1a1.ClientTLSSettings{
+						SessionResumption: &egv1a1.SessionResumption{
+							Stateless: &egv1a1.StatelessSessionResumption{
+								Enabled: ptr.To(true),
+								TicketKeys: &egv1a1.SessionTicketKeys{
+									RotationInterval: ptr.To(gwapiv1.Duration("30m")),
+								},
+							},
+						},
+					},
+				}
+			},
+			wantErrors: []string{},
+		},
+		{
+			desc: "session ticket keys with stateless session resumption disabled",
+			mutate: func(ctp *egv1a1.ClientTrafficPolicy) {
+				ctp.Spec = egv1a1.ClientTrafficPolicySpec{
+					PolicyTargetReferences: egv1a1.PolicyTargetReferences{
+						TargetRef: &gwapiv1a2.LocalPolicyTargetReferenceWithSectionName{
+							LocalPolicyTargetReference: gwapiv1a2.LocalPolicyTargetReference{
+								Group: gwapiv1a2.Group("gateway.networking.k8s.io"),
+								Kind:  gwapiv1a2.Kind("Gateway"),
+								Name:  gwapiv1a2.ObjectName("eg"),
+							},
+						},
+					},
+					TLS: &egv1a1.ClientTLSSettings{
+						SessionResumption: &egv1a1.SessionResumption{
+							Stateless: &egv1a1.StatelessSessionResumption{
+								Enabled: ptr.To(false),
+								TicketKeys: &egv1a1.SessionTicketKeys{
+									RotationInterval: ptr.To(gwapiv1.Duration("30m")),
+								},
+							},
+						},
+					},
+				}
+			},
+			wantErrors: []string{
+				"spec.tls.sessionResumption.stateless: Invalid value: \"object\": ticketKeys can only be set if the stateless session resumption is enabled",
+			},
+		},
+		{
+			desc: "noSNIMatch default backend",
+			mutate: func(ctp *egv1a1.ClientTrafficPolicy) {
+				ctp.Spec = egv1a1.ClientTrafficPolicySpec{
+					PolicyTargetReferences: egv1a1.PolicyTargetReferences{
+						TargetRef: &gwapiv1a2.LocalPolicyTargetReferenceWithSectionName{
+							LocalPolicyTargetReference: gwapiv1a2.LocalPolicyTargetReference{
+								Group: gwapiv1a2.Group("gateway.networking.k8s.io"),
+								Kind:  gwapiv1a2.Kind("Gateway"),
+								Name:  gwapiv1a2.ObjectName("eg"),
+							},
+						},
+					},
+					TLS: &egv1a1.ClientTLSSettings{
+						NoSNIMatch: &egv1a1.NoSNIMatch{
+							Action: egv1a1.NoSNIMatchActionDefaultBackend,
+							BackendRef: &egv1a1.BackendRef{
+								BackendObjectReference: gwapiv1.BackendObjectReference{
+									Name: "default-backend",
+									Port: ptr.To(gwapiv1.PortNumber(8443)),
+								},
+							},
+						},
+					},
+				}
+			},
+			wantErrors: []string{},
+		},
+		{
+			desc: "noSNIMatch default backend without backendRef",
+			mutate: func(ctp *egv1a1.ClientTrafficPolicy) {
+				ctp.Spec = egv1a1.ClientTrafficPolicySpec{
+					PolicyTargetReferences: egv1a1.PolicyTargetReferences{
+						TargetRef: &gwapiv1a2.LocalPolicyTargetReferenceWithSectionName{
+							LocalPolicyTargetReference: gwapiv1a2.LocalPolicyTargetReference{
+								Group: gwapiv1a2.Group("gateway.networking.k8s.io"),
+								Kind:  gwapiv1a2.Kind("Gateway"),
+								Name:  gwapiv1a2.ObjectName("eg"),
+							},
+						},
+					},
+					TLS: &egv1a1.ClientTLSSettings{
+						NoSNIMatch: &egv1a1.NoSNIMatch{
+							Action: egv1a1.NoSNIMatchActionDefaultBackend,
+						},
+					},
+				}
+			},
+			wantErrors: []string{
+				"spec.tls.noSNIMatch: Invalid value: \"object\": backendRef must be set if, and only if, the action is DefaultBackend",
+			},
+		},
+		{
+			desc: "noSNIMatch fallback certificate with backendRef",
+			mutate: func(ctp *egv1a1.ClientTrafficPolicy) {
+				ctp.Spec = egv1a1.ClientTrafficPolicySpec{
+					PolicyTargetReferences: egv1a1.PolicyTargetReferences{
+						TargetRef: &gwapiv1a2.LocalPolicyTargetReferenceWithSectionName{
+							LocalPolicyTargetReference: gwapiv1a2.LocalPolicyTargetReference{
+								Group: gwapiv1a2.Group("gateway.networking.k8s.io"),
+								Kind:  gwapiv1a2.Kind("Gateway"),
+								Name:  gwapiv1a2.ObjectName("eg"),
+							},
+						},
+					},
+					TLS: &egv1a1.ClientTLSSettings{
+						NoSNIMatch: &egv1a1.NoSNIMatch{
+							Action: egv1a1.NoSNIMatchActionFallbackCertificate,
+							BackendRef: &egv1a1.BackendRef{
+								BackendObjectReference: gwapiv1.BackendObjectReference{
+									Name: "default-backend",
+									Port: ptr.To(gwapiv1.PortNumber(8443)),
+								},
+							},
+						},
+					},
+				}
+			},
+			wantErrors: []string{
+				"spec.tls.noSNIMatch: Invalid value: \"object\": backendRef must be set if, and only if, the action is DefaultBackend",
+			},
+		},
 		{
 			desc: "clientIPDetection xForwardedFor and customHeader set",
 			mutate: func(ctp *egv1a1.ClientTrafficPolicy) {
@@ -314,6 +452,78 @@ func TestClientTrafficPolicyTarget(t *testing.T) {
 				"spec.connection.bufferLimit: Invalid value: \"15m\": spec.connection.bufferLimit in body should match '^[1-9]+[0-9]*([EPTGMK]i|[EPTGMk])?$', <nil>: Invalid value: \"\"",
 			},
 		},
+		{
+			desc: "invalid socket options dscp",
+			mutate: func(ctp *egv1a1.ClientTrafficPolicy) {
+				ctp.Spec = egv1a1.ClientTrafficPolicySpec{
+					PolicyTargetReferences: egv1a1.PolicyTargetReferences{
+						TargetRef: &gwapiv1a2.LocalPolicyTargetReferenceWithSectionName{
+							LocalPolicyTargetReference: gwapiv1a2.LocalPolicyTargetReference{
+								Group: gwapiv1a2.Group("gateway.networking.k8s.io"),
+								Kind:  gwapiv1a2.Kind("Gateway"),
+								Name:  gwapiv1a2.ObjectName("eg"),
+							},
+						},
+					},
+					Connection: &egv1a1.ClientConnection{
+						SocketOptions: &egv1a1.ClientSocketOptions{
+							DSCP: ptr.To[uint32](64),
+						},
+					},
+				}
+			},
+			wantErrors: []string{
+				"spec.connection.socketOptions.dscp: Invalid value: 64: spec.connection.socketOptions.dscp in body should be less than or equal to 63",
+			},
+		},
+		{
+			desc: "valid connection rate limit",
+			mutate: func(ctp *egv1a1.ClientTrafficPolicy) {
+				ctp.Spec = egv1a1.ClientTrafficPolicySpec{
+					PolicyTargetReferences: egv1a1.PolicyTargetReferences{
+						TargetRef: &gwapiv1a2.LocalPolicyTargetReferenceWithSectionName{
+							LocalPolicyTargetReference: gwapiv1a2.LocalPolicyTargetReference{
+								Group: gwapiv1a2.Group("gateway.networking.k8s.io"),
+								Kind:  gwapiv1a2.Kind("Gateway"),
+								Name:  gwapiv1a2.ObjectName("eg"),
+							},
+						},
+					},
+					Connection: &egv1a1.ClientConnection{
+						ConnectionRateLimit: &egv1a1.ConnectionRateLimit{
+							ConnectionsPerSecond: 10,
+							Burst:                ptr.To[uint32](50),
+						},
+					},
+				}
+			},
+			wantErrors: []string{},
+		},
+		{
+			desc: "connection rate limit burst lower than the rate",
+			mutate: func(ctp *egv1a1.ClientTrafficPolicy) {
+				ctp.Spec = egv1a1.ClientTrafficPolicySpec{
+					PolicyTargetReferences: egv1a1.PolicyTargetReferences{
+						TargetRef: &gwapiv1a2.LocalPolicyTargetReferenceWithSectionName{
+							LocalPolicyTargetReference: gwapiv1a2.LocalPolicyTargetReference{
+								Group: gwapiv1a2.Group("gateway.networking.k8s.io"),
+								Kind:  gwapiv1a2.Kind("Gateway"),
+								Name:  gwapiv1a2.ObjectName("eg"),
+							},
+						},
+					},
+					Connection: &egv1a1.ClientConnection{
+						ConnectionRateLimit: &egv1a1.ConnectionRateLimit{
+							ConnectionsPerSecond: 10,
+							Burst:                ptr.To[uint32](5),
+						},
+					},
+				}
+			},
+			wantErrors: []string{
+				"spec.connection.connectionRateLimit: Invalid value: \"object\": burst must be greater than or equal to connectionsPerSecond",
+			},
+		},
 		{
 			desc: "invalid InitialStreamWindowSize format",
 			mutate: func(ctp *egv1a1.ClientTrafficPolicy) {
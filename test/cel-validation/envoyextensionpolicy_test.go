@@ -414,6 +414,98 @@ func TestEnvoyExtensionPolicyTarget(t *testing.T) {
 				"spec.extProc[0].processingMode.request.body: Unsupported value: \"not-a-body-mode\": supported values: \"Streamed\", \"Buffered\", \"BufferedPartial\"",
 			},
 		},
+		{
+			desc: "OpenAPIValidation with ConfigMap",
+			mutate: func(sp *egv1a1.EnvoyExtensionPolicy) {
+				sp.Spec = egv1a1.EnvoyExtensionPolicySpec{
+					OpenAPIValidation: &egv1a1.OpenAPIValidation{
+						BackendCluster: egv1a1.BackendCluster{
+							BackendRefs: []egv1a1.BackendRef{
+								{
+									BackendObjectReference: gwapiv1.BackendObjectReference{
+										Name: "openapi-validator",
+										Port: ptr.To(gwapiv1.PortNumber(9002)),
+									},
+								},
+							},
+						},
+						ConfigMapRef: gwapiv1.LocalObjectReference{
+							Group: "",
+							Kind:  "ConfigMap",
+							Name:  "petstore",
+						},
+					},
+					PolicyTargetReferences: egv1a1.PolicyTargetReferences{
+						TargetRef: &gwapiv1a2.LocalPolicyTargetReferenceWithSectionName{
+							LocalPolicyTargetReference: gwapiv1a2.LocalPolicyTargetReference{
+								Group: "gateway.networking.k8s.io",
+								Kind:  "HTTPRoute",
+								Name:  "petstore",
+							},
+						},
+					},
+				}
+			},
+			wantErrors: []string{},
+		},
+		{
+			desc: "OpenAPIValidation with invalid ConfigMapRef kind",
+			mutate: func(sp *egv1a1.EnvoyExtensionPolicy) {
+				sp.Spec = egv1a1.EnvoyExtensionPolicySpec{
+					OpenAPIValidation: &egv1a1.OpenAPIValidation{
+						BackendCluster: egv1a1.BackendCluster{
+							BackendRefs: []egv1a1.BackendRef{
+								{
+									BackendObjectReference: gwapiv1.BackendObjectReference{
+										Name: "openapi-validator",
+										Port: ptr.To(gwapiv1.PortNumber(9002)),
+									},
+								},
+							},
+						},
+						ConfigMapRef: gwapiv1.LocalObjectReference{
+							Group: "",
+							Kind:  "Secret",
+							Name:  "petstore",
+						},
+					},
+					PolicyTargetReferences: egv1a1.PolicyTargetReferences{
+						TargetRef: &gwapiv1a2.LocalPolicyTargetReferenceWithSectionName{
+							LocalPolicyTargetReference: gwapiv1a2.LocalPolicyTargetReference{
+								Group: "gateway.networking.k8s.io",
+								Kind:  "HTTPRoute",
+								Name:  "petstore",
+							},
+						},
+					},
+				}
+			},
+			wantErrors: []string{"spec.openAPIValidation.configMapRef: Invalid value: \"object\": only support ConfigMap kind."},
+		},
+		{
+			desc: "OpenAPIValidation without BackendRefs",
+			mutate: func(sp *egv1a1.EnvoyExtensionPolicy) {
+				sp.Spec = egv1a1.EnvoyExtensionPolicySpec{
+					OpenAPIValidation: &egv1a1.OpenAPIValidation{
+						ConfigMapRef: gwapiv1.LocalObjectReference{
+							Group: "",
+							Kind:  "ConfigMap",
+							Name:  "petstore",
+						},
+					},
+					PolicyTargetReferences: egv1a1.PolicyTargetReferences{
+						TargetRef: &gwapiv1a2.LocalPolicyTargetReferenceWithSectionName{
+							LocalPolicyTargetReference: gwapiv1a2.LocalPolicyTargetReference{
+								Group: "gateway.networking.k8s.io",
+								Kind:  "HTTPRoute",
+								Name:  "petstore",
+							},
+						},
+					},
+				}
+			},
+			wantErrors: []string{"spec.openAPIValidation: Invalid value: \"object\": BackendRefs is required."},
+		},
 		{
 			desc: "target selectors without targetRefs or targetRef",
 			mutate: func(sp *egv1a1.EnvoyExtensionPolicy) {
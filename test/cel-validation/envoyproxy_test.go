@@ -119,6 +119,77 @@ func TestEnvoyProxyProvider(t *testing.T) {
 			},
 			wantErrors: []string{"allocateLoadBalancerNodePorts can only be set for LoadBalancer type"},
 		},
+		{
+			desc: "healthCheckNodePort-pass-case1",
+			mutate: func(envoy *egv1a1.EnvoyProxy) {
+				envoy.Spec = egv1a1.EnvoyProxySpec{
+					Provider: &egv1a1.EnvoyProxyProvider{
+						Type: egv1a1.ProviderTypeKubernetes,
+						Kubernetes: &egv1a1.EnvoyProxyKubernetesProvider{
+							EnvoyService: &egv1a1.KubernetesServiceSpec{
+								Type:                  ptr.To(egv1a1.ServiceTypeLoadBalancer),
+								ExternalTrafficPolicy: ptr.To(egv1a1.ServiceExternalTrafficPolicyLocal),
+								HealthCheckNodePort:   ptr.To[int32](30000),
+							},
+						},
+					},
+				}
+			},
+			wantErrors: []string{},
+		},
+		{
+			desc: "healthCheckNodePort-pass-case2",
+			mutate: func(envoy *egv1a1.EnvoyProxy) {
+				envoy.Spec = egv1a1.EnvoyProxySpec{
+					Provider: &egv1a1.EnvoyProxyProvider{
+						Type: egv1a1.ProviderTypeKubernetes,
+						Kubernetes: &egv1a1.EnvoyProxyKubernetesProvider{
+							EnvoyService: &egv1a1.KubernetesServiceSpec{
+								Type:                ptr.To(egv1a1.ServiceTypeLoadBalancer),
+								HealthCheckNodePort: ptr.To[int32](30000),
+							},
+						},
+					},
+				}
+			},
+			wantErrors: []string{},
+		},
+		{
+			desc: "healthCheckNodePort-fail-case1",
+			mutate: func(envoy *egv1a1.EnvoyProxy) {
+				envoy.Spec = egv1a1.EnvoyProxySpec{
+					Provider: &egv1a1.EnvoyProxyProvider{
+						Type: egv1a1.ProviderTypeKubernetes,
+						Kubernetes: &egv1a1.EnvoyProxyKubernetesProvider{
+							EnvoyService: &egv1a1.KubernetesServiceSpec{
+								Type:                  ptr.To(egv1a1.ServiceTypeLoadBalancer),
+								ExternalTrafficPolicy: ptr.To(egv1a1.ServiceExternalTrafficPolicyCluster),
+								HealthCheckNodePort:   ptr.To[int32](30000),
+							},
+						},
+					},
+				}
+			},
+			wantErrors: []string{"healthCheckNodePort can only be set for LoadBalancer type with Local externalTrafficPolicy"},
+		},
+		{
+			desc: "healthCheckNodePort-fail-case2",
+			mutate: func(envoy *egv1a1.EnvoyProxy) {
+				envoy.Spec = egv1a1.EnvoyProxySpec{
+					Provider: &egv1a1.EnvoyProxyProvider{
+						Type: egv1a1.ProviderTypeKubernetes,
+						Kubernetes: &egv1a1.EnvoyProxyKubernetesProvider{
+							EnvoyService: &egv1a1.KubernetesServiceSpec{
+								Type:                  ptr.To(egv1a1.ServiceTypeNodePort),
+								ExternalTrafficPolicy: ptr.To(egv1a1.ServiceExternalTrafficPolicyLocal),
+								HealthCheckNodePort:   ptr.To[int32](30000),
+							},
+						},
+					},
+				}
+			},
+			wantErrors: []string{"healthCheckNodePort can only be set for LoadBalancer type with Local externalTrafficPolicy"},
+		},
 		{
 			desc: "loadBalancerSourceRanges-pass-case1",
 			mutate: func(envoy *egv1a1.EnvoyProxy) {
@@ -1404,6 +1475,113 @@ func TestEnvoyProxyProvider(t *testing.T) {
 				"provided bootstrap patch doesn't match the configured patch type",
 			},
 		},
+		{
+			desc: "valid runtime flags",
+			mutate: func(envoy *egv1a1.EnvoyProxy) {
+				envoy.Spec = egv1a1.EnvoyProxySpec{
+					RuntimeFlags: &egv1a1.ProxyRuntimeFlags{
+						Enabled:  []egv1a1.RuntimeFlag{egv1a1.RuntimeFlagHTTP1UseBalsaParser},
+						Disabled: []egv1a1.RuntimeFlag{egv1a1.RuntimeFlagHTTP2UseOghttp2},
+					},
+				}
+			},
+		},
+		{
+			desc: "runtime flag both enabled and disabled",
+			mutate: func(envoy *egv1a1.EnvoyProxy) {
+				envoy.Spec = egv1a1.EnvoyProxySpec{
+					RuntimeFlags: &egv1a1.ProxyRuntimeFlags{
+						Enabled:  []egv1a1.RuntimeFlag{egv1a1.RuntimeFlagHTTP1UseBalsaParser},
+						Disabled: []egv1a1.RuntimeFlag{egv1a1.RuntimeFlagHTTP1UseBalsaParser},
+					},
+				}
+			},
+			wantErrors: []string{
+				"a runtime flag can't be both enabled and disabled",
+			},
+		},
+		{
+			desc: "valid admin",
+			mutate: func(envoy *egv1a1.EnvoyProxy) {
+				envoy.Spec = egv1a1.EnvoyProxySpec{
+					Admin: &egv1a1.ProxyAdmin{
+						Exposure:     ptr.To(egv1a1.ProxyAdminExposureLocalhost),
+						AllowedPaths: []string{"/config_dump", "/stats/prometheus"},
+					},
+				}
+			},
+		},
+		{
+			desc: "invalid admin allowed path",
+			mutate: func(envoy *egv1a1.EnvoyProxy) {
+				envoy.Spec = egv1a1.EnvoyProxySpec{
+					Admin: &egv1a1.ProxyAdmin{
+						AllowedPaths: []string{"config_dump"},
+					},
+				}
+			},
+			wantErrors: []string{
+				"spec.admin.allowedPaths[0]: Invalid value: \"config_dump\"",
+			},
+		},
+		{
+			desc: "valid listener unix socket",
+			mutate: func(envoy *egv1a1.EnvoyProxy) {
+				envoy.Spec = egv1a1.EnvoyProxySpec{
+					ListenerUnixSocket: &egv1a1.ListenerUnixSocket{
+						Directory: "/var/run/envoy-listeners",
+					},
+				}
+			},
+			wantErrors: []string{},
+		},
+		{
+			desc: "invalid listener unix socket directory",
+			mutate: func(envoy *egv1a1.EnvoyProxy) {
+				envoy.Spec = egv1a1.EnvoyProxySpec{
+					ListenerUnixSocket: &egv1a1.ListenerUnixSocket{
+						Directory: "var/run/envoy-listeners/",
+					},
+				}
+			},
+			wantErrors: []string{
+				"spec.listenerUnixSocket.directory: Invalid value: \"var/run/envoy-listeners/\"",
+			},
+		},
+		{
+			desc: "valid defer drains",
+			mutate: func(envoy *egv1a1.EnvoyProxy) {
+				envoy.Spec = egv1a1.EnvoyProxySpec{
+					DeferDrains: &egv1a1.ProxyDeferDrains{
+						Scope: ptr.To(egv1a1.DrainScopeFilterChain),
+						MaintenanceWindow: egv1a1.MaintenanceWindow{
+							Start:    "22:30",
+							Duration: "2h",
+							Days:     []egv1a1.Weekday{"Saturday", "Sunday"},
+						},
+					},
+				}
+			},
+			wantErrors: []string{},
+		},
+		{
+			desc: "invalid defer drains maintenance window",
+			mutate: func(envoy *egv1a1.EnvoyProxy) {
+				envoy.Spec = egv1a1.EnvoyProxySpec{
+					DeferDrains: &egv1a1.ProxyDeferDrains{
+						MaintenanceWindow: egv1a1.MaintenanceWindow{
+							Start:    "24:00",
+							Duration: "2h",
+							Days:     []egv1a1.Weekday{"Someday"},
+						},
+					},
+				}
+			},
+			wantErrors: []string{
+				"spec.deferDrains.maintenanceWindow.start: Invalid value: \"24:00\"",
+				"spec.deferDrains.maintenanceWindow.days[0]: Unsupported value: \"Someday\"",
+			},
+		},
 	}
 
 	for _, tc := range cases {
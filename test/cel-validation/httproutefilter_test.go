@@ -139,6 +139,94 @@ func TestHTTPRouteFilter(t *testing.T) {
 			},
 			wantErrors: []string{"spec.urlRewrite.hostname: Invalid value: \"object\": setFromHeader must be nil if the type is not SetFromHeader"},
 		},
+		{
+			desc: "valid normalization",
+			mutate: func(httproutefilter *egv1a1.HTTPRouteFilter) {
+				httproutefilter.Spec = egv1a1.HTTPRouteFilterSpec{
+					Normalization: &egv1a1.HTTPNormalizationFilter{
+						Query: &egv1a1.HTTPQueryNormalization{
+							RemoveParameters: []string{"utm_source", "utm_medium"},
+							Sort:             ptr.To(true),
+						},
+						TrailingSlash: &egv1a1.HTTPTrailingSlashNormalization{
+							Action:             egv1a1.TrailingSlashActionAdd,
+							RedirectStatusCode: ptr.To(301),
+						},
+					},
+				}
+			},
+			wantErrors: []string{},
+		},
+		{
+			desc: "valid query param match",
+			mutate: func(httproutefilter *egv1a1.HTTPRouteFilter) {
+				httproutefilter.Spec = egv1a1.HTTPRouteFilterSpec{
+					QueryParamMatch: &egv1a1.HTTPQueryParamMatchFilter{
+						Present: []string{"session"},
+						Absent:  []string{"debug"},
+					},
+				}
+			},
+			wantErrors: []string{},
+		},
+		{
+			desc: "empty query param match",
+			mutate: func(httproutefilter *egv1a1.HTTPRouteFilter) {
+				httproutefilter.Spec = egv1a1.HTTPRouteFilterSpec{
+					QueryParamMatch: &egv1a1.HTTPQueryParamMatchFilter{},
+				}
+			},
+			wantErrors: []string{"spec.queryParamMatch: Invalid value: \"object\": at least one of present or absent must be specified"},
+		},
+		{
+			desc: "empty normalization",
+			mutate: func(httproutefilter *egv1a1.HTTPRouteFilter) {
+				httproutefilter.Spec = egv1a1.HTTPRouteFilterSpec{
+					Normalization: &egv1a1.HTTPNormalizationFilter{},
+				}
+			},
+			wantErrors: []string{"spec.normalization: Invalid value: \"object\": at least one of query or trailingSlash must be specified"},
+		},
+		{
+			desc: "query normalization without sorting or removed parameters",
+			mutate: func(httproutefilter *egv1a1.HTTPRouteFilter) {
+				httproutefilter.Spec = egv1a1.HTTPRouteFilterSpec{
+					Normalization: &egv1a1.HTTPNormalizationFilter{
+						Query: &egv1a1.HTTPQueryNormalization{
+							Sort: ptr.To(false),
+						},
+					},
+				}
+			},
+			wantErrors: []string{"spec.normalization.query: Invalid value: \"object\": at least one of removeParameters or sort must be specified"},
+		},
+		{
+			desc: "invalid removed query parameter",
+			mutate: func(httproutefilter *egv1a1.HTTPRouteFilter) {
+				httproutefilter.Spec = egv1a1.HTTPRouteFilterSpec{
+					Normalization: &egv1a1.HTTPNormalizationFilter{
+						Query: &egv1a1.HTTPQueryNormalization{
+							RemoveParameters: []string{"utm_source=foo"},
+						},
+					},
+				}
+			},
+			wantErrors: []string{"spec.normalization.query.removeParameters[0]: Invalid value: \"utm_source=foo\""},
+		},
+		{
+			desc: "invalid trailing slash redirect status code",
+			mutate: func(httproutefilter *egv1a1.HTTPRouteFilter) {
+				httproutefilter.Spec = egv1a1.HTTPRouteFilterSpec{
+					Normalization: &egv1a1.HTTPNormalizationFilter{
+						TrailingSlash: &egv1a1.HTTPTrailingSlashNormalization{
+							Action:             egv1a1.TrailingSlashActionRemove,
+							RedirectStatusCode: ptr.To(200),
+						},
+					},
+				}
+			},
+			wantErrors: []string{"spec.normalization.trailingSlash.redirectStatusCode: Unsupported value: 200"},
+		},
 	}
 
 	for _, tc := range cases {
@@ -940,6 +940,110 @@ func TestSecurityPolicyTarget(t *testing.T) {
 			},
 			wantErrors: []string{},
 		},
+		{
+			desc: "jwt with route match",
+			mutate: func(sp *egv1a1.SecurityPolicy) {
+				sp.Spec = egv1a1.SecurityPolicySpec{
+					JWT: &egv1a1.JWT{
+						Providers: []egv1a1.JWTProvider{
+							{
+								Name: "example",
+								RemoteJWKS: egv1a1.RemoteJWKS{
+									URI: "https://example.com/jwt/jwks.json",
+								},
+							},
+						},
+						RouteMatch: &egv1a1.JWTRouteMatch{
+							Claims: []egv1a1.JWTClaim{
+								{
+									Name:   "tenant",
+									Values: []string{"tenant-a"},
+								},
+							},
+						},
+					},
+					PolicyTargetReferences: egv1a1.PolicyTargetReferences{
+						TargetRef: &gwapiv1a2.LocalPolicyTargetReferenceWithSectionName{
+							LocalPolicyTargetReference: gwapiv1a2.LocalPolicyTargetReference{
+								Group: "gateway.networking.k8s.io",
+								Kind:  "HTTPRoute",
+								Name:  "httpbin-route",
+							},
+						},
+					},
+				}
+			},
+			wantErrors: []string{},
+		},
+		{
+			desc: "jwt with route match and multiple providers",
+			mutate: func(sp *egv1a1.SecurityPolicy) {
+				sp.Spec = egv1a1.SecurityPolicySpec{
+					JWT: &egv1a1.JWT{
+						Providers: []egv1a1.JWTProvider{
+							{
+								Name: "example",
+								RemoteJWKS: egv1a1.RemoteJWKS{
+									URI: "https://example.com/jwt/jwks.json",
+								},
+							},
+							{
+								Name: "other",
+								RemoteJWKS: egv1a1.RemoteJWKS{
+									URI: "https://other.com/jwt/jwks.json",
+								},
+							},
+						},
+						RouteMatch: &egv1a1.JWTRouteMatch{
+							Claims: []egv1a1.JWTClaim{
+								{
+									Name:   "tenant",
+									Values: []string{"tenant-a"},
+								},
+							},
+						},
+					},
+					PolicyTargetReferences: egv1a1.PolicyTargetReferences{
+						TargetRef: &gwapiv1a2.LocalPolicyTargetReferenceWithSectionName{
+							LocalPolicyTargetReference: gwapiv1a2.LocalPolicyTargetReference{
+								Group: "gateway.networking.k8s.io",
+								Kind:  "HTTPRoute",
+								Name:  "httpbin-route",
+							},
+						},
+					},
+				}
+			},
+			wantErrors: []string{"spec.jwt: Invalid value: \"object\": routeMatch can only be specified with a single provider"},
+		},
+		{
+			desc: "jwt with empty route match",
+			mutate: func(sp *egv1a1.SecurityPolicy) {
+				sp.Spec = egv1a1.SecurityPolicySpec{
+					JWT: &egv1a1.JWT{
+						Providers: []egv1a1.JWTProvider{
+							{
+								Name: "example",
+								RemoteJWKS: egv1a1.RemoteJWKS{
+									URI: "https://example.com/jwt/jwks.json",
+								},
+							},
+						},
+						RouteMatch: &egv1a1.JWTRouteMatch{},
+					},
+					PolicyTargetReferences: egv1a1.PolicyTargetReferences{
+						TargetRef: &gwapiv1a2.LocalPolicyTargetReferenceWithSectionName{
+							LocalPolicyTargetReference: gwapiv1a2.LocalPolicyTargetReference{
+								Group: "gateway.networking.k8s.io",
+								Kind:  "HTTPRoute",
+								Name:  "httpbin-route",
+							},
+						},
+					},
+				}
+			},
+			wantErrors: []string{"spec.jwt.routeMatch: Invalid value: \"object\": at least one of claims or scopes must be specified"},
+		},
 		{
 			desc: "target selectors without targetRefs or targetRef",
 			mutate: func(sp *egv1a1.SecurityPolicy) {
@@ -1121,6 +1225,55 @@ func TestSecurityPolicyTarget(t *testing.T) {
 			},
 			wantErrors: []string{"at least one of claims or scopes must be specified"},
 		},
+		{
+			desc: "valid security headers",
+			mutate: func(sp *egv1a1.SecurityPolicy) {
+				sp.Spec = egv1a1.SecurityPolicySpec{
+					PolicyTargetReferences: egv1a1.PolicyTargetReferences{
+						TargetRef: &gwapiv1a2.LocalPolicyTargetReferenceWithSectionName{
+							LocalPolicyTargetReference: gwapiv1a2.LocalPolicyTargetReference{
+								Group: gwapiv1a2.Group("gateway.networking.k8s.io"),
+								Kind:  gwapiv1a2.Kind("Gateway"),
+								Name:  gwapiv1a2.ObjectName("eg"),
+							},
+						},
+					},
+					SecurityHeaders: &egv1a1.SecurityHeaders{
+						ContentSecurityPolicy: &egv1a1.SecurityHeader{
+							Value: ptr.To("default-src 'self'"),
+						},
+						XFrameOptions: &egv1a1.SecurityHeader{
+							Disabled: ptr.To(true),
+						},
+						Overwrite: ptr.To(true),
+					},
+				}
+			},
+			wantErrors: []string{},
+		},
+		{
+			desc: "security header with value and disabled",
+			mutate: func(sp *egv1a1.SecurityPolicy) {
+				sp.Spec = egv1a1.SecurityPolicySpec{
+					PolicyTargetReferences: egv1a1.PolicyTargetReferences{
+						TargetRef: &gwapiv1a2.LocalPolicyTargetReferenceWithSectionName{
+							LocalPolicyTargetReference: gwapiv1a2.LocalPolicyTargetReference{
+								Group: gwapiv1a2.Group("gateway.networking.k8s.io"),
+								Kind:  gwapiv1a2.Kind("Gateway"),
+								Name:  gwapiv1a2.ObjectName("eg"),
+							},
+						},
+					},
+					SecurityHeaders: &egv1a1.SecurityHeaders{
+						ReferrerPolicy: &egv1a1.SecurityHeader{
+							Value:    ptr.To("no-referrer"),
+							Disabled: ptr.To(true),
+						},
+					},
+				}
+			},
+			wantErrors: []string{"value cannot be set when the header is disabled"},
+		},
 	}
 
 	for _, tc := range cases {